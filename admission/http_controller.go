@@ -0,0 +1,102 @@
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
+)
+
+// Request is POSTed to the admission controller for each build.
+type Request struct {
+	Build atc.Build `json:"build"`
+	Plan  atc.Plan  `json:"plan"`
+}
+
+// Response is expected from the admission controller. If the build is
+// allowed and a Plan is given, it is run instead of the requested plan.
+type Response struct {
+	Allowed bool      `json:"allowed"`
+	Reason  string    `json:"reason,omitempty"`
+	Plan    *atc.Plan `json:"plan,omitempty"`
+}
+
+// UnexpectedResponseError is returned when the admission controller responds
+// with anything other than 200 OK.
+type UnexpectedResponseError struct {
+	StatusCode int
+}
+
+func (err UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("admission controller responded with unexpected status: %d", err.StatusCode)
+}
+
+type httpController struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPController constructs an engine.AdmissionController which POSTs a
+// JSON Request for each build to the given URL, e.g. that of a policy agent,
+// and expects a JSON Response.
+func NewHTTPController(controllerURL string, client *http.Client) engine.AdmissionController {
+	return &httpController{
+		url:    controllerURL,
+		client: client,
+	}
+}
+
+func (controller *httpController) Admit(logger lager.Logger, build db.Build, plan atc.Plan) (atc.Plan, error) {
+	logger = logger.Session("admit")
+
+	payload, err := json.Marshal(Request{
+		Build: atc.Build{
+			ID:           build.ID(),
+			Name:         build.Name(),
+			TeamName:     build.TeamName(),
+			PipelineName: build.PipelineName(),
+			JobName:      build.JobName(),
+		},
+		Plan: plan,
+	})
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	resp, err := controller.client.Post(controller.url, "application/json", bytes.NewBuffer(payload))
+	if err != nil {
+		logger.Error("failed-to-reach-admission-controller", err)
+		return atc.Plan{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		logger.Info("unexpected-response", lager.Data{"status": resp.StatusCode})
+		return atc.Plan{}, UnexpectedResponseError{StatusCode: resp.StatusCode}
+	}
+
+	var response Response
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		logger.Error("failed-to-decode-response", err)
+		return atc.Plan{}, err
+	}
+
+	if !response.Allowed {
+		logger.Info("rejected", lager.Data{"reason": response.Reason})
+		return atc.Plan{}, engine.AdmissionRejectedError{Reason: response.Reason}
+	}
+
+	if response.Plan != nil {
+		logger.Info("plan-modified")
+		return *response.Plan, nil
+	}
+
+	return plan, nil
+}
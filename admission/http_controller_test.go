@@ -0,0 +1,161 @@
+package admission_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/admission"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/engine"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("HTTPController", func() {
+	var (
+		server     *ghttp.Server
+		controller engine.AdmissionController
+
+		fakeBuild *dbfakes.FakeBuild
+		plan      atc.Plan
+
+		admittedPlan atc.Plan
+		admitErr     error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		controller = admission.NewHTTPController(server.URL()+"/admit", http.DefaultClient)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("1")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.JobNameReturns("some-job")
+
+		plan = atc.Plan{
+			ID: "some-plan-id",
+			Task: &atc.TaskPlan{
+				Name:       "some-task",
+				Privileged: true,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		admittedPlan, admitErr = controller.Admit(lagertest.NewTestLogger("test"), fakeBuild, plan)
+	})
+
+	Context("when the build is allowed", func() {
+		BeforeEach(func() {
+			expectedBody, err := json.Marshal(admission.Request{
+				Build: atc.Build{
+					ID:           42,
+					Name:         "1",
+					TeamName:     "some-team",
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+				},
+				Plan: plan,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/admit"),
+					ghttp.VerifyContentType("application/json"),
+					ghttp.VerifyJSON(string(expectedBody)),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, admission.Response{
+						Allowed: true,
+					}),
+				),
+			)
+		})
+
+		It("posts the build and its plan to the controller", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns the plan unmodified", func() {
+			Expect(admitErr).ToNot(HaveOccurred())
+			Expect(admittedPlan).To(Equal(plan))
+		})
+	})
+
+	Context("when the build is allowed with a modified plan", func() {
+		var modifiedPlan atc.Plan
+
+		BeforeEach(func() {
+			modifiedPlan = atc.Plan{
+				ID: "some-plan-id",
+				Task: &atc.TaskPlan{
+					Name:       "some-task",
+					Privileged: false,
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.RespondWithJSONEncoded(http.StatusOK, admission.Response{
+					Allowed: true,
+					Plan:    &modifiedPlan,
+				}),
+			)
+		})
+
+		It("returns the modified plan", func() {
+			Expect(admitErr).ToNot(HaveOccurred())
+			Expect(admittedPlan).To(Equal(modifiedPlan))
+		})
+	})
+
+	Context("when the build is rejected", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWithJSONEncoded(http.StatusOK, admission.Response{
+					Allowed: false,
+					Reason:  "privileged tasks are forbidden",
+				}),
+			)
+		})
+
+		It("returns the reason", func() {
+			Expect(admitErr).To(Equal(engine.AdmissionRejectedError{
+				Reason: "privileged tasks are forbidden",
+			}))
+		})
+	})
+
+	Context("when the controller responds with an unexpected status", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, ""),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(admitErr).To(Equal(admission.UnexpectedResponseError{
+				StatusCode: http.StatusServiceUnavailable,
+			}))
+		})
+	})
+
+	Context("when the controller responds with invalid JSON", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, "{"),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(admitErr).To(HaveOccurred())
+		})
+	})
+})
@@ -13,6 +13,7 @@ type Access interface {
 	IsSystem() bool
 	TeamNames() []string
 	CSRFToken() string
+	Requester() string
 }
 
 type access struct {
@@ -73,3 +74,18 @@ func (a *access) CSRFToken() string {
 	}
 	return ""
 }
+
+// Requester identifies who made the request, for recording in the database.
+// Tokens are issued per team rather than per user, so this is the team the
+// request authenticated as, or "system" for the ATC's own token.
+func (a *access) Requester() string {
+	if a.IsSystem() {
+		return "system"
+	}
+
+	for _, teamName := range a.TeamNames() {
+		return teamName
+	}
+
+	return ""
+}
@@ -302,4 +302,39 @@ var _ = Describe("Accessor", func() {
 			})
 		})
 	})
+
+	Describe("Requester", func() {
+		JustBeforeEach(func() {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			tokenString, err := token.SignedString(key)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", fmt.Sprintf("BEARER %s", tokenString))
+			access = accessorFactory.Create(req)
+		})
+
+		Context("when request has team name claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"teamName": "fake-team-name"}
+			})
+			It("returns the team name", func() {
+				Expect(access.Requester()).To(Equal("fake-team-name"))
+			})
+		})
+		Context("when request has system claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"teamName": "fake-team-name", "system": true}
+			})
+			It("returns system", func() {
+				Expect(access.Requester()).To(Equal("system"))
+			})
+		})
+		Context("when request does not have team name claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{}
+			})
+			It("returns empty", func() {
+				Expect(access.Requester()).To(BeEmpty())
+			})
+		})
+	})
 })
@@ -64,6 +64,15 @@ type FakeAccess struct {
 	cSRFTokenReturnsOnCall map[int]struct {
 		result1 string
 	}
+	RequesterStub        func() string
+	requesterMutex       sync.RWMutex
+	requesterArgsForCall []struct{}
+	requesterReturns     struct {
+		result1 string
+	}
+	requesterReturnsOnCall map[int]struct {
+		result1 string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -316,6 +325,46 @@ func (fake *FakeAccess) CSRFTokenReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeAccess) Requester() string {
+	fake.requesterMutex.Lock()
+	ret, specificReturn := fake.requesterReturnsOnCall[len(fake.requesterArgsForCall)]
+	fake.requesterArgsForCall = append(fake.requesterArgsForCall, struct{}{})
+	fake.recordInvocation("Requester", []interface{}{})
+	fake.requesterMutex.Unlock()
+	if fake.RequesterStub != nil {
+		return fake.RequesterStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.requesterReturns.result1
+}
+
+func (fake *FakeAccess) RequesterCallCount() int {
+	fake.requesterMutex.RLock()
+	defer fake.requesterMutex.RUnlock()
+	return len(fake.requesterArgsForCall)
+}
+
+func (fake *FakeAccess) RequesterReturns(result1 string) {
+	fake.RequesterStub = nil
+	fake.requesterReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeAccess) RequesterReturnsOnCall(i int, result1 string) {
+	fake.RequesterStub = nil
+	if fake.requesterReturnsOnCall == nil {
+		fake.requesterReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.requesterReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeAccess) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -331,6 +380,8 @@ func (fake *FakeAccess) Invocations() map[string][][]interface{} {
 	defer fake.teamNamesMutex.RUnlock()
 	fake.cSRFTokenMutex.RLock()
 	defer fake.cSRFTokenMutex.RUnlock()
+	fake.requesterMutex.RLock()
+	defer fake.requesterMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
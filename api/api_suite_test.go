@@ -17,14 +17,15 @@ import (
 	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/auth"
 	"github.com/concourse/atc/creds/credsfakes"
-	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 
 	"github.com/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/atc/api/containerserver/containerserverfakes"
 	"github.com/concourse/atc/api/jobserver/jobserverfakes"
 	"github.com/concourse/atc/api/resourceserver/resourceserverfakes"
+	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/imageresolver/imageresolverfakes"
 	"github.com/concourse/atc/worker/workerfakes"
 	"github.com/concourse/atc/wrappa"
 )
@@ -49,9 +50,12 @@ var (
 	dbWorkerLifecycle       *dbfakes.FakeWorkerLifecycle
 	build                   *dbfakes.FakeBuild
 	dbBuildFactory          *dbfakes.FakeBuildFactory
+	dbAuditLog              *dbfakes.FakeAuditLog
+	dbCacheUsageLedger      *dbfakes.FakeCacheUsageLedger
 	dbTeam                  *dbfakes.FakeTeam
 	fakeSchedulerFactory    *jobserverfakes.FakeSchedulerFactory
 	fakeScannerFactory      *resourceserverfakes.FakeScannerFactory
+	fakeImageResolver       *imageresolverfakes.FakeResolver
 	fakeVariablesFactory    *credsfakes.FakeVariablesFactory
 	interceptTimeoutFactory *containerserverfakes.FakeInterceptTimeoutFactory
 	interceptTimeout        *containerserverfakes.FakeInterceptTimeout
@@ -69,14 +73,14 @@ var (
 )
 
 type fakeEventHandlerFactory struct {
-	build db.Build
+	build engine.Build
 
 	lock sync.Mutex
 }
 
 func (f *fakeEventHandlerFactory) Construct(
 	logger lager.Logger,
-	build db.Build,
+	build engine.Build,
 ) http.Handler {
 	f.lock.Lock()
 	f.build = build
@@ -93,6 +97,8 @@ var _ = BeforeEach(func() {
 	dbPipelineFactory = new(dbfakes.FakePipelineFactory)
 	dbJobFactory = new(dbfakes.FakeJobFactory)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
+	dbAuditLog = new(dbfakes.FakeAuditLog)
+	dbCacheUsageLedger = new(dbfakes.FakeCacheUsageLedger)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
 	interceptTimeout = new(containerserverfakes.FakeInterceptTimeout)
@@ -120,6 +126,7 @@ var _ = BeforeEach(func() {
 
 	fakeSchedulerFactory = new(jobserverfakes.FakeSchedulerFactory)
 	fakeScannerFactory = new(resourceserverfakes.FakeScannerFactory)
+	fakeImageResolver = new(imageresolverfakes.FakeResolver)
 
 	fakeVolumeFactory = new(dbfakes.FakeVolumeFactory)
 	fakeContainerRepository = new(dbfakes.FakeContainerRepository)
@@ -172,6 +179,8 @@ var _ = BeforeEach(func() {
 		fakeVolumeFactory,
 		fakeContainerRepository,
 		dbBuildFactory,
+		dbAuditLog,
+		dbCacheUsageLedger,
 
 		peerURL,
 		constructedEventHandler.Construct,
@@ -195,6 +204,7 @@ var _ = BeforeEach(func() {
 		"4.5.6",
 		fakeVariablesFactory,
 		interceptTimeoutFactory,
+		fakeImageResolver,
 	)
 
 	Expect(err).NotTo(HaveOccurred())
@@ -16,6 +16,7 @@ import (
 	"github.com/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -69,11 +70,12 @@ var _ = Describe("Builds API", func() {
 		Context("when authorized", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.RequesterReturns("some-team")
 			})
 
 			Context("when creating a one-off build succeeds", func() {
 				BeforeEach(func() {
-					dbTeam.CreateOneOffBuildStub = func() (db.Build, error) {
+					dbTeam.CreateOneOffBuildStub = func(db.BuildTriggerCause) (db.Build, error) {
 						Expect(dbTeamFactory.FindTeamCallCount()).To(Equal(1))
 						teamName := dbTeamFactory.FindTeamArgsForCall(0)
 						build.IDReturns(42)
@@ -136,6 +138,10 @@ var _ = Describe("Builds API", func() {
 
 					It("creates a one-off build and runs it asynchronously", func() {
 						Expect(dbTeam.CreateOneOffBuildCallCount()).To(Equal(1))
+						Expect(dbTeam.CreateOneOffBuildArgsForCall(0)).To(Equal(db.BuildTriggerCause{
+							Type:      db.BuildTriggerManual,
+							Requester: "some-team",
+						}))
 
 						Expect(fakeEngine.CreateBuildCallCount()).To(Equal(1))
 						_, oneOffBuild, builtPlan := fakeEngine.CreateBuildArgsForCall(0)
@@ -156,6 +162,22 @@ var _ = Describe("Builds API", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				Context("and the build is rejected by the admission controller", func() {
+					BeforeEach(func() {
+						fakeEngine.CreateBuildReturns(nil, engine.AdmissionRejectedError{
+							Reason: "privileged tasks are forbidden",
+						})
+					})
+
+					It("returns 403 Forbidden with the reason", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(ContainSubstring("privileged tasks are forbidden"))
+					})
+				})
 			})
 
 			Context("when creating a one-off build fails", func() {
@@ -201,6 +223,11 @@ var _ = Describe("Builds API", func() {
 			build1.StartTimeReturns(time.Unix(1, 0))
 			build1.EndTimeReturns(time.Unix(100, 0))
 			build1.ReapTimeReturns(time.Unix(300, 0))
+			build1.TriggerCauseReturns(db.BuildTriggerCause{
+				Type:     db.BuildTriggerInput,
+				Resource: "some-resource",
+				Version:  db.ResourceVersion{"ref": "abc"},
+			})
 
 			build2 := new(dbfakes.FakeBuild)
 			build2.IDReturns(3)
@@ -212,6 +239,10 @@ var _ = Describe("Builds API", func() {
 			build2.StartTimeReturns(time.Unix(101, 0))
 			build2.EndTimeReturns(time.Unix(200, 0))
 			build2.ReapTimeReturns(time.Unix(400, 0))
+			build2.TriggerCauseReturns(db.BuildTriggerCause{
+				Type:      db.BuildTriggerManual,
+				Requester: "some-team",
+			})
 
 			returnedBuilds = []db.Build{build1, build2}
 			fakeaccess.TeamNamesReturns([]string{"some-team"})
@@ -292,7 +323,12 @@ var _ = Describe("Builds API", func() {
 							"api_url": "/api/v1/builds/4",
 							"start_time": 1,
 							"end_time": 100,
-							"reap_time": 300
+							"reap_time": 300,
+							"trigger_cause": {
+								"type": "input",
+								"resource": "some-resource",
+								"version": {"ref": "abc"}
+							}
 						},
 						{
 							"id": 3,
@@ -304,7 +340,11 @@ var _ = Describe("Builds API", func() {
 							"api_url": "/api/v1/builds/3",
 							"start_time": 101,
 							"end_time": 200,
-							"reap_time": 400
+							"reap_time": 400,
+							"trigger_cause": {
+								"type": "manual",
+								"requester": "some-team"
+							}
 						}
 					]`))
 				})
@@ -400,7 +440,12 @@ var _ = Describe("Builds API", func() {
 							"api_url": "/api/v1/builds/4",
 							"start_time": 1,
 							"end_time": 100,
-							"reap_time": 300
+							"reap_time": 300,
+							"trigger_cause": {
+								"type": "input",
+								"resource": "some-resource",
+								"version": {"ref": "abc"}
+							}
 						},
 						{
 							"id": 3,
@@ -412,7 +457,11 @@ var _ = Describe("Builds API", func() {
 							"api_url": "/api/v1/builds/3",
 							"start_time": 101,
 							"end_time": 200,
-							"reap_time": 400
+							"reap_time": 400,
+							"trigger_cause": {
+								"type": "manual",
+								"requester": "some-team"
+							}
 						}
 					]`))
 				})
@@ -854,11 +903,16 @@ var _ = Describe("Builds API", func() {
 		})
 
 		Context("when the build can be found", func() {
+			var engineBuild *enginefakes.FakeBuild
+
 			BeforeEach(func() {
 				build.JobNameReturns("some-job")
 				build.TeamNameReturns("some-team")
 				build.PipelineReturns(fakePipeline, true, nil)
 				dbBuildFactory.BuildReturns(build, true, nil)
+
+				engineBuild = new(enginefakes.FakeBuild)
+				fakeEngine.LookupBuildReturns(engineBuild, nil)
 			})
 
 			Context("when authenticated, but not authorized", func() {
@@ -888,10 +942,24 @@ var _ = Describe("Builds API", func() {
 
 					Expect(string(body)).To(Equal("fake event handler factory was here"))
 
-					Expect(constructedEventHandler.build).To(Equal(build))
+					Expect(constructedEventHandler.build).To(Equal(engineBuild))
 					Expect(dbBuildFactory.BuildCallCount()).To(Equal(1))
 					buildID := dbBuildFactory.BuildArgsForCall(0)
 					Expect(buildID).To(Equal(128))
+
+					Expect(fakeEngine.LookupBuildCallCount()).To(Equal(1))
+					_, lookedUpBuild := fakeEngine.LookupBuildArgsForCall(0)
+					Expect(lookedUpBuild).To(Equal(build))
+				})
+
+				Context("when the engine fails to look up the build", func() {
+					BeforeEach(func() {
+						fakeEngine.LookupBuildReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
 				})
 			})
 
@@ -956,7 +1024,7 @@ var _ = Describe("Builds API", func() {
 
 								Expect(string(body)).To(Equal("fake event handler factory was here"))
 
-								Expect(constructedEventHandler.build).To(Equal(build))
+								Expect(constructedEventHandler.build).To(Equal(engineBuild))
 								Expect(dbBuildFactory.BuildCallCount()).To(Equal(1))
 								buildID := dbBuildFactory.BuildArgsForCall(0)
 								Expect(buildID).To(Equal(128))
@@ -1026,7 +1094,7 @@ var _ = Describe("Builds API", func() {
 		JustBeforeEach(func() {
 			var err error
 
-			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/abort", nil)
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/abort?reason=some-reason", nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(req)
@@ -1036,6 +1104,7 @@ var _ = Describe("Builds API", func() {
 		Context("when authenticated", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.RequesterReturns("some-team")
 			})
 
 			Context("when the build can be found", func() {
@@ -1061,6 +1130,14 @@ var _ = Describe("Builds API", func() {
 							Expect(engineBuild.AbortCallCount()).To(Equal(1))
 						})
 
+						It("records the requester and the reason", func() {
+							_, cause := engineBuild.AbortArgsForCall(0)
+							Expect(cause).To(Equal(db.BuildAbortCause{
+								Requester: "some-team",
+								Reason:    "some-reason",
+							}))
+						})
+
 						Context("when aborting succeeds", func() {
 							BeforeEach(func() {
 								engineBuild.AbortReturns(nil)
@@ -1475,244 +1552,392 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
-	Describe("PUT /api/v1/builds/:build_id/plan/:plan_id/input", func() {
-		var (
-			otherTracker *ghttp.Server
-
-			response *http.Response
-		)
-
-		BeforeEach(func() {
-			otherTracker = ghttp.NewServer()
-
-			otherTracker.AppendHandlers(
-				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("PUT", "/api/v1/builds/128/plan/some-plan/input"),
-					ghttp.VerifyBody([]byte("some-payload")),
-					ghttp.RespondWith(http.StatusTeapot, "im a teapot"),
-				),
-			)
-		})
+	Describe("GET /api/v1/builds/:build_id/environment", func() {
+		var response *http.Response
 
 		JustBeforeEach(func() {
 			var err error
-
-			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/plan/some-plan/input", bytes.NewBufferString("some-payload"))
-			Expect(err).NotTo(HaveOccurred())
-
-			response, err = client.Do(req)
+			response, err = http.Get(server.URL + "/api/v1/builds/42/environment")
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		AfterEach(func() {
-			otherTracker.Close()
-		})
-
-		Context("when authenticated", func() {
+		Context("when the build is found", func() {
 			BeforeEach(func() {
-				fakeaccess.IsAuthenticatedReturns(true)
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				build.EnvironmentReturns(atc.BuildEnvironment{
+					ATCVersion:        "1.2.3",
+					CredentialManager: "vault",
+					Workers: []atc.BuildEnvironmentWorker{
+						{
+							Name:          "some-worker",
+							Version:       "2.1",
+							Platform:      "linux",
+							ResourceTypes: map[string]string{"git": "some-git-version"},
+						},
+					},
+					ResourceTypes: []atc.BuildEnvironmentResourceType{},
+					FeatureFlags:  map[string]bool{"artifact-cache": true},
+				}, true, nil)
 			})
 
-			Context("when the build can be found", func() {
+			Context("when not authenticated and the pipeline is private", func() {
 				BeforeEach(func() {
-					build.TeamNameReturns("some-team")
-					dbBuildFactory.BuildReturns(build, true, nil)
+					fakeaccess.IsAuthenticatedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+					fakePipeline.PublicReturns(false)
 				})
 
-				Context("when accessing same teams build", func() {
-					BeforeEach(func() {
-						fakeaccess.IsAuthorizedReturns(true)
-					})
-
-					Context("when the build is tracked by the current ATC", func() {
-						BeforeEach(func() {
-							build.TrackerReturns("http://127.0.0.1:1234")
-						})
-
-						Context("when the engine returns a build", func() {
-							var engineBuild *enginefakes.FakeBuild
-							var streamedBody string
-
-							BeforeEach(func() {
-								engineBuild = new(enginefakes.FakeBuild)
-								fakeEngine.LookupBuildReturns(engineBuild, nil)
-
-								engineBuild.ReceiveInputStub = func(logger lager.Logger, id atc.PlanID, stream io.ReadCloser) {
-									p, err := ioutil.ReadAll(stream)
-									Expect(err).ToNot(HaveOccurred())
-
-									streamedBody = string(p)
-								}
-							})
-
-							It("sends the request body to the plan", func() {
-								Expect(engineBuild.ReceiveInputCallCount()).To(Equal(1))
-
-								_, id, _ := engineBuild.ReceiveInputArgsForCall(0)
-								Expect(id).To(Equal(atc.PlanID("some-plan")))
-								Expect(streamedBody).To(Equal("some-payload"))
-							})
-
-							It("returns No Content", func() {
-								Expect(response.StatusCode).To(Equal(http.StatusNoContent))
-							})
-
-							Context("when the build is initially not tracked", func() {
-								BeforeEach(func() {
-									build.ReloadReturns(true, nil)
-
-									build.TrackerReturnsOnCall(0, "")
-									build.TrackerReturnsOnCall(2, "http://127.0.0.1:1234")
-								})
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
 
-								It("waits until it is", func() {
-									Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthenticatedReturns(true)
+					fakeaccess.IsAuthorizedReturns(true)
+				})
 
-									Expect(engineBuild.ReceiveInputCallCount()).To(Equal(1))
+				It("returns OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
 
-									_, id, _ := engineBuild.ReceiveInputArgsForCall(0)
-									Expect(id).To(Equal(atc.PlanID("some-plan")))
-									Expect(streamedBody).To(Equal("some-payload"))
-								})
-							})
-						})
+				It("returns the build environment", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
 
-						Context("when the engine returns no build", func() {
-							BeforeEach(func() {
-								fakeEngine.LookupBuildReturns(nil, errors.New("oh no!"))
-							})
+					Expect(body).To(MatchJSON(`{
+						"atc_version": "1.2.3",
+						"credential_manager": "vault",
+						"workers": [
+							{
+								"name": "some-worker",
+								"version": "2.1",
+								"platform": "linux",
+								"resource_types": {"git": "some-git-version"}
+							}
+						],
+						"resource_types": [],
+						"feature_flags": {"artifact-cache": true}
+					}`))
+				})
 
-							It("returns Internal Server Error", func() {
-								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
-							})
-						})
+				Context("when no environment was saved", func() {
+					BeforeEach(func() {
+						build.EnvironmentReturns(atc.BuildEnvironment{}, false, nil)
 					})
 
-					Context("when the build is tracked by another ATC", func() {
-						BeforeEach(func() {
-							build.TrackerReturns(otherTracker.URL())
-						})
-
-						It("does not bother looking up the build in the engine", func() {
-							Expect(fakeEngine.LookupBuildCallCount()).To(Equal(0))
-						})
-
-						It("forwards the request to the other ATC", func() {
-							Expect(otherTracker.ReceivedRequests()).To(HaveLen(1))
-							Expect(response.StatusCode).To(Equal(http.StatusTeapot))
-							Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("im a teapot")))
-						})
-
-						Context("when the build is initially not tracked", func() {
-							BeforeEach(func() {
-								build.ReloadReturns(true, nil)
-
-								build.TrackerReturnsOnCall(0, "")
-								build.TrackerReturnsOnCall(2, otherTracker.URL())
-							})
-
-							It("waits until it is", func() {
-								Expect(fakeEngine.LookupBuildCallCount()).To(Equal(0))
-
-								Expect(otherTracker.ReceivedRequests()).To(HaveLen(1))
-								Expect(response.StatusCode).To(Equal(http.StatusTeapot))
-								Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("im a teapot")))
-							})
-						})
+					It("returns Not Found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 					})
 				})
 
-				Context("when accessing other teams build", func() {
+				Context("when looking up the environment fails", func() {
 					BeforeEach(func() {
-						fakeaccess.IsAuthorizedReturns(false)
+						build.EnvironmentReturns(atc.BuildEnvironment{}, false, errors.New("nope"))
 					})
 
-					It("returns 403", func() {
-						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
 			})
-
-			Context("when the build can not be found", func() {
-				BeforeEach(func() {
-					dbBuildFactory.BuildReturns(nil, false, nil)
-				})
-
-				It("returns Not Found", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
-				})
-			})
-
-			Context("when calling the database fails", func() {
-				BeforeEach(func() {
-					dbBuildFactory.BuildReturns(nil, false, errors.New("nope"))
-				})
-
-				It("returns Internal Server Error", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
-				})
-			})
 		})
 
-		Context("when not authenticated", func() {
+		Context("when build is not found", func() {
 			BeforeEach(func() {
-				fakeaccess.IsAuthenticatedReturns(false)
-			})
-
-			It("returns 401", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				dbBuildFactory.BuildReturns(nil, false, nil)
 			})
 
-			It("does not abort the build", func() {
-				Expect(otherTracker.ReceivedRequests()).To(BeEmpty())
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 			})
 		})
 	})
 
-	Describe("GET /api/v1/builds/:build_id/plan/:plan_id/output", func() {
-		var (
-			otherTracker *ghttp.Server
-
-			response *http.Response
-		)
-
-		BeforeEach(func() {
-			otherTracker = ghttp.NewServer()
-
-			otherTracker.AppendHandlers(
-				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/api/v1/builds/128/plan/some-plan/output"),
-					ghttp.RespondWith(http.StatusTeapot, "im a teapot"),
-				),
-			)
-		})
+	Describe("GET /api/v1/builds/:build_id/usage", func() {
+		var response *http.Response
 
 		JustBeforeEach(func() {
 			var err error
-
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/builds/128/plan/some-plan/output", nil)
+			response, err = http.Get(server.URL + "/api/v1/builds/42/usage")
 			Expect(err).NotTo(HaveOccurred())
-
-			response, err = client.Do(req)
-			Expect(err).NotTo(HaveOccurred())
-		})
-
-		AfterEach(func() {
-			otherTracker.Close()
 		})
 
-		Context("when authenticated", func() {
+		Context("when the build is found", func() {
 			BeforeEach(func() {
-				fakeaccess.IsAuthenticatedReturns(true)
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				build.ContainerUsagesReturns([]atc.BuildContainerUsage{
+					{
+						Handle:   "some-handle",
+						PlanID:   "some-plan-id",
+						StepName: "some-step",
+						Type:     "task",
+						Samples: []atc.ContainerUsageSample{
+							{Time: 1, CPUUsage: 100, MemoryUsage: 200, DiskUsage: 300},
+							{Time: 2, CPUUsage: 400, MemoryUsage: 500, DiskUsage: 600},
+						},
+					},
+				}, nil)
 			})
 
-			Context("when the build can be found", func() {
+			Context("when not authenticated and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthenticatedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthenticatedReturns(true)
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				It("returns OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the usage of each container", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"handle": "some-handle",
+							"plan_id": "some-plan-id",
+							"step_name": "some-step",
+							"type": "task",
+							"samples": [
+								{"time": 1, "cpu_usage": 100, "memory_usage": 200, "disk_usage": 300},
+								{"time": 2, "cpu_usage": 400, "memory_usage": 500, "disk_usage": 600}
+							]
+						}
+					]`))
+				})
+
+				Context("when looking up the usages fails", func() {
+					BeforeEach(func() {
+						build.ContainerUsagesReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/plan/graph", func() {
+		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/plan/graph" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			var engineBuild *enginefakes.FakeBuild
+
+			BeforeEach(func() {
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				build.EngineReturns("exec.v2")
+				dbBuildFactory.BuildReturns(build, true, nil)
+
+				engineBuild = new(enginefakes.FakeBuild)
+				fakeEngine.LookupBuildReturns(engineBuild, nil)
+
+				engineBuild.PlanGraphReturns(atc.PlanGraph{
+					Nodes: []atc.PlanGraphNode{
+						{ID: "1", Type: "do"},
+						{ID: "2", Type: "get", Name: "some-input"},
+						{ID: "3", Type: "task", Name: "some-task"},
+					},
+					Edges: []atc.PlanGraphEdge{
+						{From: "1", To: "2", Type: atc.PlanGraphEdgeStep},
+						{From: "1", To: "3", Type: atc.PlanGraphEdgeStep},
+						{From: "2", To: "3", Type: atc.PlanGraphEdgeNext},
+					},
+				}, nil)
+			})
+
+			Context("when not authenticated and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthenticatedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthenticatedReturns(true)
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				It("looks up the build with the engine", func() {
+					Expect(fakeEngine.LookupBuildCallCount()).To(Equal(1))
+					_, lookedUp := fakeEngine.LookupBuildArgsForCall(0)
+					Expect(lookedUp).To(Equal(build))
+				})
+
+				It("returns the graph as JSON", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"nodes": [
+							{"id": "1", "type": "do"},
+							{"id": "2", "type": "get", "name": "some-input"},
+							{"id": "3", "type": "task", "name": "some-task"}
+						],
+						"edges": [
+							{"from": "1", "to": "2", "type": "step"},
+							{"from": "1", "to": "3", "type": "step"},
+							{"from": "2", "to": "3", "type": "next"}
+						]
+					}`))
+				})
+
+				Context("when asked for DOT", func() {
+					BeforeEach(func() {
+						query = "?format=dot"
+					})
+
+					It("renders the graph in DOT", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("text/vnd.graphviz"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(string(body)).To(HavePrefix("digraph plan {"))
+						Expect(string(body)).To(ContainSubstring(`"2" -> "3" [label="next" style=solid];`))
+					})
+				})
+
+				Context("when asked for an unknown format", func() {
+					BeforeEach(func() {
+						query = "?format=svg"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the build has not started", func() {
+					BeforeEach(func() {
+						build.EngineReturns("")
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when the engine fails to describe the plan", func() {
+					BeforeEach(func() {
+						engineBuild.PlanGraphReturns(atc.PlanGraph{}, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/plan/:plan_id/input", func() {
+		var (
+			otherTracker *ghttp.Server
+
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			otherTracker = ghttp.NewServer()
+
+			otherTracker.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/128/plan/some-plan/input"),
+					ghttp.VerifyBody([]byte("some-payload")),
+					ghttp.RespondWith(http.StatusTeapot, "im a teapot"),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/plan/some-plan/input", bytes.NewBufferString("some-payload"))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			otherTracker.Close()
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can be found", func() {
 				BeforeEach(func() {
 					build.TeamNameReturns("some-team")
 					dbBuildFactory.BuildReturns(build, true, nil)
 				})
 
-				Context("when accessing same team's build", func() {
+				Context("when accessing same teams build", func() {
 					BeforeEach(func() {
 						fakeaccess.IsAuthorizedReturns(true)
 					})
@@ -1724,26 +1949,30 @@ var _ = Describe("Builds API", func() {
 
 						Context("when the engine returns a build", func() {
 							var engineBuild *enginefakes.FakeBuild
+							var streamedBody string
 
 							BeforeEach(func() {
 								engineBuild = new(enginefakes.FakeBuild)
 								fakeEngine.LookupBuildReturns(engineBuild, nil)
 
-								engineBuild.SendOutputStub = func(logger lager.Logger, id atc.PlanID, dest io.Writer) {
-									fmt.Fprintln(dest, "hello from build")
+								engineBuild.ReceiveInputStub = func(logger lager.Logger, id atc.PlanID, stream io.ReadCloser) {
+									p, err := ioutil.ReadAll(stream)
+									Expect(err).ToNot(HaveOccurred())
+
+									streamedBody = string(p)
 								}
 							})
 
-							It("sends the plan's output to the response body", func() {
-								Expect(engineBuild.SendOutputCallCount()).To(Equal(1))
+							It("sends the request body to the plan", func() {
+								Expect(engineBuild.ReceiveInputCallCount()).To(Equal(1))
 
-								_, id, _ := engineBuild.SendOutputArgsForCall(0)
+								_, id, _ := engineBuild.ReceiveInputArgsForCall(0)
 								Expect(id).To(Equal(atc.PlanID("some-plan")))
-								Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("hello from build\n")))
+								Expect(streamedBody).To(Equal("some-payload"))
 							})
 
-							It("returns OK", func() {
-								Expect(response.StatusCode).To(Equal(http.StatusOK))
+							It("returns No Content", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusNoContent))
 							})
 
 							Context("when the build is initially not tracked", func() {
@@ -1755,13 +1984,13 @@ var _ = Describe("Builds API", func() {
 								})
 
 								It("waits until it is", func() {
-									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(response.StatusCode).To(Equal(http.StatusNoContent))
 
-									Expect(engineBuild.SendOutputCallCount()).To(Equal(1))
+									Expect(engineBuild.ReceiveInputCallCount()).To(Equal(1))
 
-									_, id, _ := engineBuild.SendOutputArgsForCall(0)
+									_, id, _ := engineBuild.ReceiveInputArgsForCall(0)
 									Expect(id).To(Equal(atc.PlanID("some-plan")))
-									Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("hello from build\n")))
+									Expect(streamedBody).To(Equal("some-payload"))
 								})
 							})
 						})
@@ -1811,7 +2040,7 @@ var _ = Describe("Builds API", func() {
 					})
 				})
 
-				Context("when accessing other team's build", func() {
+				Context("when accessing other teams build", func() {
 					BeforeEach(func() {
 						fakeaccess.IsAuthorizedReturns(false)
 					})
@@ -1857,4 +2086,631 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/plan/:plan_id/output", func() {
+		var (
+			otherTracker *ghttp.Server
+
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			otherTracker = ghttp.NewServer()
+
+			otherTracker.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/128/plan/some-plan/output"),
+					ghttp.RespondWith(http.StatusTeapot, "im a teapot"),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/builds/128/plan/some-plan/output", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			otherTracker.Close()
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can be found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when accessing same team's build", func() {
+					BeforeEach(func() {
+						fakeaccess.IsAuthorizedReturns(true)
+					})
+
+					Context("when the build is tracked by the current ATC", func() {
+						BeforeEach(func() {
+							build.TrackerReturns("http://127.0.0.1:1234")
+						})
+
+						Context("when the engine returns a build", func() {
+							var engineBuild *enginefakes.FakeBuild
+
+							BeforeEach(func() {
+								engineBuild = new(enginefakes.FakeBuild)
+								fakeEngine.LookupBuildReturns(engineBuild, nil)
+
+								engineBuild.SendOutputStub = func(logger lager.Logger, id atc.PlanID, dest io.Writer) error {
+									fmt.Fprintln(dest, "hello from build")
+									return nil
+								}
+							})
+
+							It("sends the plan's output to the response body", func() {
+								Expect(engineBuild.SendOutputCallCount()).To(Equal(1))
+
+								_, id, _ := engineBuild.SendOutputArgsForCall(0)
+								Expect(id).To(Equal(atc.PlanID("some-plan")))
+								Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("hello from build\n")))
+							})
+
+							It("returns OK", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+							})
+
+							Context("when the build fails to send the whole output", func() {
+								BeforeEach(func() {
+									engineBuild.SendOutputStub = func(logger lager.Logger, id atc.PlanID, dest io.Writer) error {
+										fmt.Fprintln(dest, "hello from")
+										return errors.New("nope")
+									}
+								})
+
+								It("breaks off the response instead of ending it", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+									_, err := ioutil.ReadAll(response.Body)
+									Expect(err).To(HaveOccurred())
+								})
+							})
+
+							Context("when the build is initially not tracked", func() {
+								BeforeEach(func() {
+									build.ReloadReturns(true, nil)
+
+									build.TrackerReturnsOnCall(0, "")
+									build.TrackerReturnsOnCall(2, "http://127.0.0.1:1234")
+								})
+
+								It("waits until it is", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+									Expect(engineBuild.SendOutputCallCount()).To(Equal(1))
+
+									_, id, _ := engineBuild.SendOutputArgsForCall(0)
+									Expect(id).To(Equal(atc.PlanID("some-plan")))
+									Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("hello from build\n")))
+								})
+							})
+						})
+
+						Context("when the engine returns no build", func() {
+							BeforeEach(func() {
+								fakeEngine.LookupBuildReturns(nil, errors.New("oh no!"))
+							})
+
+							It("returns Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+
+					Context("when the build is tracked by another ATC", func() {
+						BeforeEach(func() {
+							build.TrackerReturns(otherTracker.URL())
+						})
+
+						It("does not bother looking up the build in the engine", func() {
+							Expect(fakeEngine.LookupBuildCallCount()).To(Equal(0))
+						})
+
+						It("forwards the request to the other ATC", func() {
+							Expect(otherTracker.ReceivedRequests()).To(HaveLen(1))
+							Expect(response.StatusCode).To(Equal(http.StatusTeapot))
+							Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("im a teapot")))
+						})
+
+						Context("when the build is initially not tracked", func() {
+							BeforeEach(func() {
+								build.ReloadReturns(true, nil)
+
+								build.TrackerReturnsOnCall(0, "")
+								build.TrackerReturnsOnCall(2, otherTracker.URL())
+							})
+
+							It("waits until it is", func() {
+								Expect(fakeEngine.LookupBuildCallCount()).To(Equal(0))
+
+								Expect(otherTracker.ReceivedRequests()).To(HaveLen(1))
+								Expect(response.StatusCode).To(Equal(http.StatusTeapot))
+								Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("im a teapot")))
+							})
+						})
+					})
+				})
+
+				Context("when accessing other team's build", func() {
+					BeforeEach(func() {
+						fakeaccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when calling the database fails", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not abort the build", func() {
+				Expect(otherTracker.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("POST /api/v1/builds/:build_id/plan/:plan_id/replay", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds/128/plan/some-plan/replay", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can be found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when accessing same teams build", func() {
+					BeforeEach(func() {
+						fakeaccess.IsAuthorizedReturns(true)
+					})
+
+					Context("when the engine returns a build", func() {
+						var engineBuild *enginefakes.FakeBuild
+
+						BeforeEach(func() {
+							engineBuild = new(enginefakes.FakeBuild)
+							fakeEngine.LookupBuildReturns(engineBuild, nil)
+						})
+
+						Context("when the step is replayed", func() {
+							BeforeEach(func() {
+								engineBuild.ReplayReturns(nil)
+							})
+
+							It("replays the plan", func() {
+								Expect(engineBuild.ReplayCallCount()).To(Equal(1))
+
+								_, id := engineBuild.ReplayArgsForCall(0)
+								Expect(id).To(Equal(atc.PlanID("some-plan")))
+							})
+
+							It("returns Accepted", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusAccepted))
+							})
+						})
+
+						Context("when the step cannot be found", func() {
+							BeforeEach(func() {
+								engineBuild.ReplayReturns(engine.ErrStepNotFound)
+							})
+
+							It("returns Not Found", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+							})
+						})
+
+						Context("when the step is not a task", func() {
+							BeforeEach(func() {
+								engineBuild.ReplayReturns(engine.ErrStepNotReplayable)
+							})
+
+							It("returns Bad Request", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+						})
+
+						Context("when replaying fails", func() {
+							BeforeEach(func() {
+								engineBuild.ReplayReturns(errors.New("nope"))
+							})
+
+							It("returns Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+
+					Context("when the engine returns no build", func() {
+						BeforeEach(func() {
+							fakeEngine.LookupBuildReturns(nil, errors.New("oh no!"))
+						})
+
+						It("returns Internal Server Error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when accessing other teams build", func() {
+					BeforeEach(func() {
+						fakeaccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/plan/:plan_id/replay", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/builds/128/plan/some-plan/replay")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can be found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when accessing same teams build", func() {
+					BeforeEach(func() {
+						fakeaccess.IsAuthorizedReturns(true)
+					})
+
+					Context("when the replay container has been created", func() {
+						BeforeEach(func() {
+							fakeContainer := new(dbfakes.FakeCreatedContainer)
+							fakeContainer.HandleReturns("some-handle")
+							build.ReplayContainerReturns(fakeContainer, true, nil)
+						})
+
+						It("looks it up for the plan", func() {
+							Expect(build.ReplayContainerCallCount()).To(Equal(1))
+							Expect(build.ReplayContainerArgsForCall(0)).To(Equal(atc.PlanID("some-plan")))
+						})
+
+						It("returns the container", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(body).To(MatchJSON(`{"container_id": "some-handle"}`))
+						})
+					})
+
+					Context("when the replay container has not been created yet", func() {
+						BeforeEach(func() {
+							build.ReplayContainerReturns(nil, false, nil)
+						})
+
+						It("returns Not Found", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+
+					Context("when looking up the container fails", func() {
+						BeforeEach(func() {
+							build.ReplayContainerReturns(nil, false, errors.New("nope"))
+						})
+
+						It("returns Internal Server Error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when accessing other teams build", func() {
+					BeforeEach(func() {
+						fakeaccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+	Describe("POST /api/v1/builds/:build_id/plan/:plan_id/rerun", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds/128/plan/some-plan/rerun", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.RequesterReturns("some-team")
+			})
+
+			Context("when the build can be found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					build.StatusReturns(db.BuildStatusFailed)
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when accessing same teams build", func() {
+					BeforeEach(func() {
+						fakeaccess.IsAuthorizedReturns(true)
+					})
+
+					Context("when the engine returns a build", func() {
+						var engineBuild *enginefakes.FakeBuild
+						var rerunPlan atc.Plan
+
+						BeforeEach(func() {
+							engineBuild = new(enginefakes.FakeBuild)
+							fakeEngine.LookupBuildReturns(engineBuild, nil)
+
+							rerunPlan = atc.Plan{ID: "some-do", Do: &atc.DoPlan{}}
+						})
+
+						Context("when the rerun is planned", func() {
+							var rerunBuild *dbfakes.FakeBuild
+							var engineRerun *enginefakes.FakeBuild
+
+							BeforeEach(func() {
+								engineBuild.PlanRerunReturns(engine.Rerun{
+									Plan:   rerunPlan,
+									Reused: []atc.PlanID{"some-do", "some-get"},
+								}, nil)
+
+								rerunBuild = new(dbfakes.FakeBuild)
+								rerunBuild.IDReturns(129)
+								rerunBuild.NameReturns("2")
+								rerunBuild.JobNameReturns("some-job")
+								rerunBuild.PipelineNameReturns("some-pipeline")
+								rerunBuild.TeamNameReturns("some-team")
+								rerunBuild.StatusReturns(db.BuildStatusStarted)
+								rerunBuild.RerunOfReturns(128)
+								rerunBuild.RerunFromReturns("some-plan")
+								build.RerunReturns(rerunBuild, nil)
+
+								engineRerun = new(enginefakes.FakeBuild)
+								fakeEngine.CreateBuildReturns(engineRerun, nil)
+							})
+
+							It("plans the rerun from the step", func() {
+								Expect(engineBuild.PlanRerunCallCount()).To(Equal(1))
+
+								_, id := engineBuild.PlanRerunArgsForCall(0)
+								Expect(id).To(Equal(atc.PlanID("some-plan")))
+							})
+
+							It("creates a build reusing the preceding steps", func() {
+								Expect(build.RerunCallCount()).To(Equal(1))
+
+								from, reused, cause := build.RerunArgsForCall(0)
+								Expect(from).To(Equal(atc.PlanID("some-plan")))
+								Expect(reused).To(Equal([]atc.PlanID{"some-do", "some-get"}))
+								Expect(cause).To(Equal(db.BuildTriggerCause{
+									Type:      db.BuildTriggerRerun,
+									Requester: "some-team",
+								}))
+							})
+
+							It("starts the build with the original plan", func() {
+								Expect(fakeEngine.CreateBuildCallCount()).To(Equal(1))
+
+								_, createdBuild, plan := fakeEngine.CreateBuildArgsForCall(0)
+								Expect(createdBuild).To(Equal(rerunBuild))
+								Expect(plan).To(Equal(rerunPlan))
+
+								Eventually(engineRerun.ResumeCallCount).Should(Equal(1))
+							})
+
+							Context("when the build belongs to a job", func() {
+								BeforeEach(func() {
+									rerunBuild.JobIDReturns(1)
+									rerunBuild.StatusReturns(db.BuildStatusPending)
+								})
+
+								It("leaves the build pending for the scheduler to start", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusCreated))
+									Expect(fakeEngine.CreateBuildCallCount()).To(BeZero())
+								})
+							})
+
+							It("returns Created with the build", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(body).To(MatchJSON(`{
+									"id": 129,
+									"name": "2",
+									"job_name": "some-job",
+									"pipeline_name": "some-pipeline",
+									"team_name": "some-team",
+									"status": "started",
+									"api_url": "/api/v1/builds/129",
+									"rerun_of": 128,
+									"rerun_from": "some-plan"
+								}`))
+							})
+
+							Context("when creating the build fails", func() {
+								BeforeEach(func() {
+									build.RerunReturns(nil, errors.New("nope"))
+								})
+
+								It("returns Internal Server Error without starting anything", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+									Expect(fakeEngine.CreateBuildCallCount()).To(BeZero())
+								})
+							})
+						})
+
+						Context("when the step cannot be found", func() {
+							BeforeEach(func() {
+								engineBuild.PlanRerunReturns(engine.Rerun{}, engine.ErrStepNotFound)
+							})
+
+							It("returns Not Found", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+								Expect(build.RerunCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when planning the rerun fails", func() {
+							BeforeEach(func() {
+								engineBuild.PlanRerunReturns(engine.Rerun{}, errors.New("nope"))
+							})
+
+							It("returns Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+
+					Context("when the build is still running", func() {
+						BeforeEach(func() {
+							build.IsRunningReturns(true)
+						})
+
+						It("returns Conflict", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+							Expect(fakeEngine.LookupBuildCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the build succeeded", func() {
+						BeforeEach(func() {
+							build.StatusReturns(db.BuildStatusSucceeded)
+						})
+
+						It("returns Conflict", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+					})
+				})
+
+				Context("when accessing other teams build", func() {
+					BeforeEach(func() {
+						fakeaccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
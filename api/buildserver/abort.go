@@ -3,6 +3,7 @@ package buildserver
 import (
 	"net/http"
 
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/db"
 
 	"code.cloudfoundry.org/lager"
@@ -21,7 +22,10 @@ func (s *Server) AbortBuild(build db.Build) http.Handler {
 			return
 		}
 
-		err = engineBuild.Abort(aLog)
+		err = engineBuild.Abort(aLog, db.BuildAbortCause{
+			Requester: accessor.GetAccessor(r).Requester(),
+			Reason:    r.FormValue("reason"),
+		})
 		if err != nil {
 			aLog.Error("failed-to-abort-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
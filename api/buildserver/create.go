@@ -2,12 +2,15 @@ package buildserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
 )
 
 func (s *Server) CreateBuild(team db.Team) http.Handler {
@@ -22,7 +25,10 @@ func (s *Server) CreateBuild(team db.Team) http.Handler {
 			return
 		}
 
-		build, err := team.CreateOneOffBuild()
+		build, err := team.CreateOneOffBuild(db.BuildTriggerCause{
+			Type:      db.BuildTriggerManual,
+			Requester: accessor.GetAccessor(r).Requester(),
+		})
 		if err != nil {
 			hLog.Error("failed-to-create-one-off-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -30,6 +36,13 @@ func (s *Server) CreateBuild(team db.Team) http.Handler {
 		}
 
 		engineBuild, err := s.engine.CreateBuild(hLog, build, plan)
+		if rejected, ok := err.(engine.AdmissionRejectedError); ok {
+			hLog.Info("build-rejected", lager.Data{"reason": rejected.Reason})
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintln(w, rejected.Error())
+			return
+		}
+
 		if err != nil {
 			hLog.Error("failed-to-start-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,37 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+// GetBuildEnvironment returns the snapshot of the cluster configuration taken
+// as the build finished, for audits.
+func (s *Server) GetBuildEnvironment(build db.Build) http.Handler {
+	logger := s.logger.Session("build-environment", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		environment, found, err := build.Environment()
+		if err != nil {
+			logger.Error("cannot-find-build-environment", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(environment)
+		if err != nil {
+			logger.Error("failed-to-encode-build-environment", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
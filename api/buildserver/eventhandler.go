@@ -7,19 +7,37 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
 	"github.com/vito/go-sse/sse"
 )
 
 const ProtocolVersionHeader = "X-ATC-Stream-Version"
 const CurrentProtocolVersion = "2.0"
 
-func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
+// eventCheckpointInterval limits how often a subscriber's checkpoint is saved
+// while events are streamed to it.
+const eventCheckpointInterval = time.Second
+
+// NewEventHandler streams the build's events. Clients resume from the event
+// after the one given by the Last-Event-ID header, or, if they identify
+// themselves with the subscriber query parameter, from the event after the
+// last one delivered to them. The last event delivered to a subscriber is
+// checkpointed as they are streamed, and once more when the stream ends.
+//
+// Subscribers are named within the identity the request authenticated as, so
+// that nobody can read or move another's checkpoint. Anonymous clients of
+// public pipelines cannot checkpoint and resume with Last-Event-ID instead.
+func NewEventHandler(logger lager.Logger, build engine.Build) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		clientNotifier := w.(http.CloseNotifier)
 
+		subscriber := eventSubscriber(r)
+
 		var eventID uint = 0
 		if r.Header.Get("Last-Event-ID") != "" {
 			startString := r.Header.Get("Last-Event-ID")
@@ -31,6 +49,17 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 			}
 
 			eventID++
+		} else if subscriber != "" {
+			lastEventID, found, err := build.EventCheckpoint(logger, subscriber)
+			if err != nil {
+				logger.Error("failed-to-get-event-checkpoint", err, lager.Data{"subscriber": subscriber})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if found {
+				eventID = lastEventID + 1
+			}
 		}
 
 		w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
@@ -55,15 +84,27 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 			writer.writeFlusher = gz
 		}
 
-		events, err := build.Events(eventID)
+		events, err := build.ReplayEvents(logger, eventID)
 		if err != nil {
-			logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID(), "start": eventID})
+			logger.Error("failed-to-get-build-events", err, lager.Data{"start": eventID})
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
 		defer db.Close(events)
 
+		if subscriber != "" {
+			checkpoint := &eventCheckpoint{
+				logger:     logger,
+				build:      build,
+				subscriber: subscriber,
+			}
+
+			defer checkpoint.save()
+
+			writer.checkpoint = checkpoint
+		}
+
 		for {
 			logger = logger.WithData(lager.Data{"id": eventID})
 
@@ -104,6 +145,8 @@ type eventWriter struct {
 	responseWriter  io.Writer
 	writeFlusher    flusher
 	responseFlusher http.Flusher
+
+	checkpoint *eventCheckpoint
 }
 
 func (writer eventWriter) WriteEvent(id uint, envelope interface{}) error {
@@ -121,7 +164,16 @@ func (writer eventWriter) WriteEvent(id uint, envelope interface{}) error {
 		return err
 	}
 
-	return writer.flush()
+	err = writer.flush()
+	if err != nil {
+		return err
+	}
+
+	if writer.checkpoint != nil {
+		writer.checkpoint.delivered(id)
+	}
+
+	return nil
 }
 
 func (writer eventWriter) WriteEnd(id uint) error {
@@ -145,3 +197,55 @@ func (writer eventWriter) flush() error {
 
 	return nil
 }
+
+// eventSubscriber returns the name of the subscriber the request checkpoints
+// as, qualified by who made the request, or "" if it does not checkpoint.
+func eventSubscriber(r *http.Request) string {
+	name := r.URL.Query().Get("subscriber")
+	if name == "" {
+		return ""
+	}
+
+	acc := accessor.GetAccessor(r)
+	if !acc.IsAuthenticated() || acc.Requester() == "" {
+		return ""
+	}
+
+	return acc.Requester() + "/" + name
+}
+
+// eventCheckpoint tracks the last event delivered to a subscriber, saving it
+// at most once per eventCheckpointInterval.
+type eventCheckpoint struct {
+	logger     lager.Logger
+	build      engine.Build
+	subscriber string
+
+	lastEventID uint
+	pending     bool
+	savedAt     time.Time
+}
+
+func (checkpoint *eventCheckpoint) delivered(eventID uint) {
+	checkpoint.lastEventID = eventID
+	checkpoint.pending = true
+
+	if time.Since(checkpoint.savedAt) >= eventCheckpointInterval {
+		checkpoint.save()
+	}
+}
+
+func (checkpoint *eventCheckpoint) save() {
+	if !checkpoint.pending {
+		return
+	}
+
+	err := checkpoint.build.CheckpointEvent(checkpoint.logger, checkpoint.subscriber, checkpoint.lastEventID)
+	if err != nil {
+		checkpoint.logger.Error("failed-to-save-event-checkpoint", err, lager.Data{"subscriber": checkpoint.subscriber})
+		return
+	}
+
+	checkpoint.pending = false
+	checkpoint.savedAt = time.Now()
+}
@@ -8,10 +8,14 @@ import (
 	"net/http/httptest"
 	"time"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/api/accessor/accessorfakes"
 	. "github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/engine/enginefakes"
 	"github.com/concourse/atc/event"
 	"github.com/vito/go-sse/sse"
 
@@ -30,15 +34,27 @@ func fakeEvent(payload string) event.Envelope {
 
 var _ = Describe("Handler", func() {
 	var (
-		build *dbfakes.FakeBuild
+		build *enginefakes.FakeBuild
+
+		fakeAccess *accessorfakes.FakeAccess
 
 		server *httptest.Server
 	)
 
 	BeforeEach(func() {
-		build = new(dbfakes.FakeBuild)
+		build = new(enginefakes.FakeBuild)
+
+		fakeAccess = new(accessorfakes.FakeAccess)
+		fakeAccess.IsAuthenticatedReturns(true)
+		fakeAccess.RequesterReturns("some-team")
+
+		fakeAccessFactory := new(accessorfakes.FakeAccessFactory)
+		fakeAccessFactory.CreateReturns(fakeAccess)
 
-		server = httptest.NewServer(NewEventHandler(lagertest.NewTestLogger("test"), build))
+		server = httptest.NewServer(accessor.NewHandler(
+			NewEventHandler(lagertest.NewTestLogger("test"), build),
+			fakeAccessFactory,
+		))
 	})
 
 	Describe("GET", func() {
@@ -67,7 +83,7 @@ var _ = Describe("Handler", func() {
 
 				fakeEventSource = new(dbfakes.FakeEventSource)
 
-				build.EventsStub = func(from uint) (db.EventSource, error) {
+				build.ReplayEventsStub = func(_ lager.Logger, from uint) (db.EventSource, error) {
 					fakeEventSource.NextStub = func() (event.Envelope, error) {
 						defer GinkgoRecover()
 
@@ -102,8 +118,8 @@ var _ = Describe("Handler", func() {
 
 			It("gets the events from the right build, starting at 0", func() {
 				_ = response.Body.Close()
-				Eventually(build.EventsCallCount).Should(Equal(1))
-				actualFrom := build.EventsArgsForCall(0)
+				Eventually(build.ReplayEventsCallCount).Should(Equal(1))
+				_, actualFrom := build.ReplayEventsArgsForCall(0)
 				Expect(actualFrom).To(BeZero())
 			})
 
@@ -161,11 +177,103 @@ var _ = Describe("Handler", func() {
 
 				It("starts subscribing from after the id", func() {
 					_ = response.Body.Close()
-					Eventually(build.EventsCallCount).Should(Equal(1))
-					actualFrom := build.EventsArgsForCall(0)
+					Eventually(build.ReplayEventsCallCount).Should(Equal(1))
+					_, actualFrom := build.ReplayEventsArgsForCall(0)
 					Expect(actualFrom).To(Equal(uint(2)))
 				})
 			})
+
+			Context("when a subscriber is given", func() {
+				BeforeEach(func() {
+					request.URL.RawQuery = "subscriber=some-subscriber"
+				})
+
+				Context("when the subscriber has no checkpoint", func() {
+					It("starts subscribing from 0", func() {
+						_ = response.Body.Close()
+						Eventually(build.ReplayEventsCallCount).Should(Equal(1))
+						_, actualFrom := build.ReplayEventsArgsForCall(0)
+						Expect(actualFrom).To(BeZero())
+
+						_, subscriber := build.EventCheckpointArgsForCall(0)
+						Expect(subscriber).To(Equal("some-team/some-subscriber"))
+					})
+
+					It("checkpoints the last event delivered once the stream ends", func() {
+						reader := sse.NewReadCloser(response.Body)
+						for i := 0; i < 4; i++ {
+							_, err := reader.Next()
+							Expect(err).NotTo(HaveOccurred())
+						}
+
+						_ = response.Body.Close()
+
+						Eventually(func() uint {
+							count := build.CheckpointEventCallCount()
+							if count == 0 {
+								return 0
+							}
+
+							_, _, eventID := build.CheckpointEventArgsForCall(count - 1)
+							return eventID
+						}).Should(Equal(uint(2)))
+
+						_, subscriber, _ := build.CheckpointEventArgsForCall(0)
+						Expect(subscriber).To(Equal("some-team/some-subscriber"))
+					})
+				})
+
+				Context("when the request is not authenticated", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthenticatedReturns(false)
+						fakeAccess.RequesterReturns("")
+					})
+
+					It("neither reads nor saves a checkpoint", func() {
+						reader := sse.NewReadCloser(response.Body)
+						for i := 0; i < 4; i++ {
+							_, err := reader.Next()
+							Expect(err).NotTo(HaveOccurred())
+						}
+
+						_ = response.Body.Close()
+
+						Eventually(build.ReplayEventsCallCount).Should(Equal(1))
+						_, actualFrom := build.ReplayEventsArgsForCall(0)
+						Expect(actualFrom).To(BeZero())
+
+						Consistently(build.CheckpointEventCallCount).Should(BeZero())
+						Expect(build.EventCheckpointCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the subscriber has a checkpoint", func() {
+					BeforeEach(func() {
+						build.EventCheckpointReturns(0, true, nil)
+					})
+
+					It("starts subscribing from after the checkpoint", func() {
+						_ = response.Body.Close()
+						Eventually(build.ReplayEventsCallCount).Should(Equal(1))
+						_, actualFrom := build.ReplayEventsArgsForCall(0)
+						Expect(actualFrom).To(Equal(uint(1)))
+					})
+
+					Context("when the Last-Event-ID header is given", func() {
+						BeforeEach(func() {
+							request.Header.Set("Last-Event-ID", "1")
+						})
+
+						It("takes precedence over the checkpoint", func() {
+							_ = response.Body.Close()
+							Eventually(build.ReplayEventsCallCount).Should(Equal(1))
+							_, actualFrom := build.ReplayEventsArgsForCall(0)
+							Expect(actualFrom).To(Equal(uint(2)))
+							Expect(build.EventCheckpointCallCount()).To(BeZero())
+						})
+					})
+				})
+			})
 		})
 
 		Context("when the eventsource returns an error", func() {
@@ -192,7 +300,7 @@ var _ = Describe("Handler", func() {
 					}
 				}
 
-				build.EventsReturns(fakeEventSource, nil)
+				build.ReplayEventsReturns(fakeEventSource, nil)
 			})
 
 			AfterEach(func() {
@@ -229,7 +337,7 @@ var _ = Describe("Handler", func() {
 			BeforeEach(func() {
 				fakeEventSource = new(dbfakes.FakeEventSource)
 				fakeEventSource.NextReturns(fakeEvent(`{"event":1}`), nil)
-				build.EventsReturns(fakeEventSource, nil)
+				build.ReplayEventsReturns(fakeEventSource, nil)
 			})
 
 			JustBeforeEach(func() {
@@ -255,9 +363,31 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when getting the subscriber's checkpoint fails", func() {
+			BeforeEach(func() {
+				request.URL.RawQuery = "subscriber=some-subscriber"
+				build.EventCheckpointReturns(0, false, errors.New("nope"))
+			})
+
+			JustBeforeEach(func() {
+				var err error
+
+				client := &http.Client{
+					Transport: &http.Transport{},
+				}
+				response, err = client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				Expect(build.ReplayEventsCallCount()).To(BeZero())
+			})
+		})
+
 		Context("when subscribing to it fails", func() {
 			BeforeEach(func() {
-				build.EventsReturns(nil, errors.New("nope"))
+				build.ReplayEventsReturns(nil, errors.New("nope"))
 			})
 
 			JustBeforeEach(func() {
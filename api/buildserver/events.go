@@ -3,17 +3,27 @@ package buildserver
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db"
 )
 
 func (s *Server) BuildEvents(build db.Build) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("build-events", lager.Data{"build": build.ID()})
+
+		engineBuild, err := s.engine.LookupBuild(logger, build)
+		if err != nil {
+			logger.Error("failed-to-lookup-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		streamDone := make(chan struct{})
 
 		go func() {
 			defer close(streamDone)
 
-			s.eventHandlerFactory(s.logger, build).ServeHTTP(w, r)
+			s.eventHandlerFactory(logger, engineBuild).ServeHTTP(w, r)
 		}()
 
 		select {
@@ -0,0 +1,68 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
+)
+
+// GetBuildPlanGraph renders the build's plan as a graph of its steps, as JSON
+// or, given format=dot, in the DOT language of Graphviz.
+func (s *Server) GetBuildPlanGraph(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-build-plan-graph", lager.Data{
+			"build": build.ID(),
+		})
+
+		format := r.FormValue("format")
+		if format != "" && format != "json" && format != "dot" {
+			logger.Info("unknown-format", lager.Data{"format": format})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if build.Engine() == "" {
+			logger.Info("build-not-started")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		engineBuild, err := s.engine.LookupBuild(logger, build)
+		if err != nil {
+			logger.Error("failed-to-lookup-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		graph, err := engineBuild.PlanGraph(logger)
+		if err != nil {
+			logger.Error("failed-to-get-plan-graph", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if format == "dot" {
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			w.WriteHeader(http.StatusOK)
+
+			_, err = io.WriteString(w, engine.PlanGraphDOT(graph))
+			if err != nil {
+				logger.Error("failed-to-write-plan-graph", err)
+			}
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(graph)
+		if err != nil {
+			logger.Error("failed-to-encode-plan-graph", err)
+		}
+	})
+}
@@ -58,7 +58,14 @@ func (s *Server) ReadOutputFromBuildPlan(build db.Build) http.Handler {
 
 			w.WriteHeader(http.StatusOK)
 
-			engineBuild.SendOutput(logger, planID, w)
+			err = engineBuild.SendOutput(logger, planID, w)
+			if err != nil {
+				logger.Error("failed-to-send-output", err)
+
+				// the output is incomplete, so break the response off rather
+				// than ending it as if it were whole
+				panic(http.ErrAbortHandler)
+			}
 		} else {
 			logger.Debug("forwarding", lager.Data{"to": build.Tracker()})
 
@@ -0,0 +1,92 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// ReplayBuildPlan starts replaying the step in a container of its own. The
+// container can be found with GetBuildPlanReplay once it has been created.
+func (s *Server) ReplayBuildPlan(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("replay", lager.Data{
+			"build": build.ID(),
+		})
+
+		planID := atc.PlanID(r.FormValue(":plan_id"))
+		if len(planID) == 0 {
+			logger.Info("no-plan-id-specified")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		engineBuild, err := s.engine.LookupBuild(logger, build)
+		if err != nil {
+			logger.Error("failed-to-lookup-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = engineBuild.Replay(logger, planID)
+		switch err {
+		case nil:
+		case engine.ErrStepNotFound:
+			logger.Info("step-not-found", lager.Data{"plan": planID})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case engine.ErrStepNotReplayable:
+			logger.Info("step-not-replayable", lager.Data{"plan": planID})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		default:
+			logger.Error("failed-to-replay", err, lager.Data{"plan": planID})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func (s *Server) GetBuildPlanReplay(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-replay", lager.Data{
+			"build": build.ID(),
+		})
+
+		planID := atc.PlanID(r.FormValue(":plan_id"))
+		if len(planID) == 0 {
+			logger.Info("no-plan-id-specified")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		container, found, err := build.ReplayContainer(planID)
+		if err != nil {
+			logger.Error("failed-to-find-replay-container", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(atc.BuildPlanReplay{
+			ContainerID: container.Handle(),
+		})
+		if err != nil {
+			logger.Error("failed-to-encode-replay", err)
+		}
+	})
+}
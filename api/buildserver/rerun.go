@@ -0,0 +1,100 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// RerunBuildPlan creates a build which runs a finished build's plan again from
+// the given step. The results of the steps preceding it are reused where they
+// are still around.
+//
+// A rerun of a job's build is left pending for the scheduler to start, so
+// that it waits for the job and pipeline to be unpaused and for its max in
+// flight and serial groups to allow it, as any other build of the job would.
+// A rerun of a one-off build is started straight away.
+func (s *Server) RerunBuildPlan(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("rerun", lager.Data{
+			"build": build.ID(),
+		})
+
+		planID := atc.PlanID(r.FormValue(":plan_id"))
+		if len(planID) == 0 {
+			logger.Info("no-plan-id-specified")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if build.IsRunning() || build.Status() == db.BuildStatusSucceeded {
+			logger.Info("build-not-rerunnable", lager.Data{"status": build.Status()})
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		engineBuild, err := s.engine.LookupBuild(logger, build)
+		if err != nil {
+			logger.Error("failed-to-lookup-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		rerun, err := engineBuild.PlanRerun(logger, planID)
+		switch err {
+		case nil:
+		case engine.ErrStepNotFound:
+			logger.Info("step-not-found", lager.Data{"plan": planID})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		default:
+			logger.Error("failed-to-plan-rerun", err, lager.Data{"plan": planID})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		rerunBuild, err := build.Rerun(planID, rerun.Reused, db.BuildTriggerCause{
+			Type:      db.BuildTriggerRerun,
+			Requester: accessor.GetAccessor(r).Requester(),
+		})
+		if err != nil {
+			logger.Error("failed-to-create-rerun-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if rerunBuild.JobID() == 0 {
+			engineRerun, err := s.engine.CreateBuild(logger, rerunBuild, rerun.Plan)
+			if rejected, ok := err.(engine.AdmissionRejectedError); ok {
+				logger.Info("build-rejected", lager.Data{"reason": rejected.Reason})
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprintln(w, rejected.Error())
+				return
+			}
+
+			if err != nil {
+				logger.Error("failed-to-start-build", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			go engineRerun.Resume(logger)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(present.Build(rerunBuild))
+		if err != nil {
+			logger.Error("failed-to-encode-build", err)
+		}
+	})
+}
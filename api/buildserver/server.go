@@ -10,7 +10,7 @@ import (
 	"github.com/concourse/atc/worker"
 )
 
-type EventHandlerFactory func(lager.Logger, db.Build) http.Handler
+type EventHandlerFactory func(lager.Logger, engine.Build) http.Handler
 
 type Server struct {
 	logger lager.Logger
@@ -0,0 +1,32 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+// GetBuildUsage returns the CPU, memory, and disk usage recorded for each of
+// the build's containers, as reported by their workers.
+func (s *Server) GetBuildUsage(build db.Build) http.Handler {
+	logger := s.logger.Session("build-usage", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		usages, err := build.ContainerUsages()
+		if err != nil {
+			logger.Error("failed-to-get-container-usages", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(usages)
+		if err != nil {
+			logger.Error("failed-to-encode-container-usages", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
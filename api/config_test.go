@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/api/configserver"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/onsi/gomega/gbytes"
@@ -423,7 +426,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 						})
 					})
 
@@ -447,7 +450,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 						})
 					})
 				})
@@ -468,18 +471,76 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+							_, name, savedConfig, id, pipelineState := dbTeam.SavePipelineAsArgsForCall(0)
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
 						})
 
+						Context("when the requester is known", func() {
+							BeforeEach(func() {
+								fakeaccess.RequesterReturns("some-requester")
+							})
+
+							It("saves it as authored by them", func() {
+								author, _, _, _, _ := dbTeam.SavePipelineAsArgsForCall(0)
+								Expect(author).To(Equal("some-requester"))
+							})
+						})
+
+						Context("when a resource type names an image in a registry", func() {
+							BeforeEach(func() {
+								pipelineConfig.ResourceTypes = append(pipelineConfig.ResourceTypes, atc.ResourceType{
+									Name:   "image-resource",
+									Type:   "docker-image",
+									Source: atc.Source{"repository": "some/image", "tag": "some-tag"},
+								})
+
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+								request.Body = gbytes.BufferWithBytes(payload)
+
+								fakeImageResolver.ResolveReturns("sha256:some-digest", nil)
+							})
+
+							It("saves it pinned to the digest of the image", func() {
+								Expect(fakeImageResolver.ResolveCallCount()).To(Equal(1))
+								_, repository, tag := fakeImageResolver.ResolveArgsForCall(0)
+								Expect(repository).To(Equal("some/image"))
+								Expect(tag).To(Equal("some-tag"))
+
+								_, _, savedConfig, _, _ := dbTeam.SavePipelineAsArgsForCall(0)
+								Expect(savedConfig.ResourceTypes[0].Digest).To(BeEmpty())
+								Expect(savedConfig.ResourceTypes[1].Digest).To(Equal("sha256:some-digest"))
+							})
+
+							Context("when the image cannot be resolved", func() {
+								BeforeEach(func() {
+									fakeImageResolver.ResolveReturns("", errors.New("nope"))
+								})
+
+								It("saves it unpinned, with a warning", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+									_, _, savedConfig, _, _ := dbTeam.SavePipelineAsArgsForCall(0)
+									Expect(savedConfig.ResourceTypes[1].Digest).To(BeEmpty())
+
+									var body configserver.SaveConfigResponse
+									Expect(json.NewDecoder(response.Body).Decode(&body)).To(Succeed())
+									Expect(body.Warnings).To(ContainElement(atc.Warning{
+										Type:    "resource_type",
+										Message: "resource type 'image-resource' is not pinned by digest, as its image could not be resolved: nope",
+									}))
+								})
+							})
+						})
+
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+								dbTeam.SavePipelineAsReturns(nil, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -494,7 +555,7 @@ var _ = Describe("Config API", func() {
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
-								dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+								dbTeam.SavePipelineAsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -524,7 +585,7 @@ var _ = Describe("Config API", func() {
 							})
 
 							It("does not save it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 							})
 						})
 					})
@@ -544,9 +605,9 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+							_, name, savedConfig, id, pipelineState := dbTeam.SavePipelineAsArgsForCall(0)
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -554,9 +615,9 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not give the DB a map of empty interfaces to empty interfaces", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-							_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+							_, _, savedConfig, _, _ := dbTeam.SavePipelineAsArgsForCall(0)
 							Expect(savedConfig).To(Equal(pipelineConfig))
 
 							_, err := json.Marshal(pipelineConfig)
@@ -593,9 +654,9 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+								_, name, savedConfig, id, pipelineState := dbTeam.SavePipelineAsArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
@@ -642,7 +703,7 @@ jobs:
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
-								dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+								dbTeam.SavePipelineAsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -652,7 +713,7 @@ jobs:
 
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+								dbTeam.SavePipelineAsReturns(nil, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -686,7 +747,7 @@ jobs:
 							})
 
 							It("does not save it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+								Expect(dbTeam.SavePipelineAsCallCount()).To(BeZero())
 							})
 						})
 					})
@@ -732,9 +793,9 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+								_, name, savedConfig, id, pipelineState := dbTeam.SavePipelineAsArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(pipelineConfig))
 								Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -744,7 +805,7 @@ jobs:
 							Context("when it's the first time the pipeline has been created", func() {
 								BeforeEach(func() {
 									returnedPipeline := new(dbfakes.FakePipeline)
-									dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+									dbTeam.SavePipelineAsReturns(returnedPipeline, true, nil)
 								})
 
 								It("returns 201", func() {
@@ -754,7 +815,7 @@ jobs:
 
 							Context("and saving it fails", func() {
 								BeforeEach(func() {
-									dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+									dbTeam.SavePipelineAsReturns(nil, false, errors.New("oh no!"))
 								})
 
 								It("returns 500", func() {
@@ -785,7 +846,7 @@ jobs:
 								})
 
 								It("does not save it", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+									Expect(dbTeam.SavePipelineAsCallCount()).To(BeZero())
 								})
 							})
 
@@ -912,7 +973,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 								})
 							})
 
@@ -951,7 +1012,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 								})
 							})
 						})
@@ -973,7 +1034,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 					})
 				})
 
@@ -1006,9 +1067,9 @@ jobs:
 					})
 
 					It("saves it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+						Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-						name, savedConfig, id, _ := dbTeam.SavePipelineArgsForCall(0)
+						_, name, savedConfig, id, _ := dbTeam.SavePipelineAsArgsForCall(0)
 						Expect(name).To(Equal("a-pipeline"))
 						Expect(savedConfig).To(Equal(atc.Config{
 							Jobs: atc.JobConfigs{
@@ -1057,7 +1118,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 					})
 				})
 			})
@@ -1081,7 +1142,7 @@ jobs:
 				})
 
 				It("does not save it", func() {
-					Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+					Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 				})
 			})
 		})
@@ -1096,7 +1157,245 @@ jobs:
 			})
 
 			It("does not save the config", func() {
-				Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+				Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions", func() {
+		var (
+			response     *http.Response
+			fakePipeline *dbfakes.FakePipeline
+		)
+
+		BeforeEach(func() {
+			fakePipeline = new(dbfakes.FakePipeline)
+			dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
+			dbTeam.PipelineReturns(fakePipeline, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/config/revisions?since=5&limit=2")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				fakePipeline.ConfigRevisionsReturns([]db.ConfigRevision{
+					{
+						ID:            4,
+						ConfigVersion: 42,
+						Config:        atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}},
+						Diff:          []atc.ConfigChange{{Kind: "job", Name: "some-job", Change: atc.ConfigChangeAdded}},
+						Author:        "some-author",
+						CreatedAt:     time.Unix(100, 0),
+					},
+				}, db.Pagination{
+					Next: &db.Page{Since: 4, Limit: 2},
+				}, nil)
+			})
+
+			It("returns the pipeline's config revisions", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				teamName := dbTeamFactory.FindTeamArgsForCall(0)
+				Expect(teamName).To(Equal("a-team"))
+				Expect(dbTeam.PipelineArgsForCall(0)).To(Equal("a-pipeline"))
+				Expect(fakePipeline.ConfigRevisionsArgsForCall(0)).To(Equal(db.Page{Since: 5, Limit: 2}))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				var revisions []atc.ConfigRevision
+				err = json.Unmarshal(body, &revisions)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revisions).To(Equal([]atc.ConfigRevision{
+					{
+						ID:            4,
+						ConfigVersion: 42,
+						Config:        &atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}},
+						Diff:          []atc.ConfigChange{{Kind: "job", Name: "some-job", Change: atc.ConfigChangeAdded}},
+						Author:        "some-author",
+						CreatedAt:     100,
+					},
+				}))
+			})
+
+			It("returns Link headers per rfc5988", func() {
+				Expect(response.Header["Link"]).To(ConsistOf([]string{
+					fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/a-pipeline/config/revisions?since=4&limit=2>; rel="next"`, externalURL),
+				}))
+			})
+
+			Context("when the pipeline is not found", func() {
+				BeforeEach(func() {
+					dbTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the revisions fails", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigRevisionsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakePipeline.ConfigRevisionsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions/:revision_id/rollback", func() {
+		var (
+			response      *http.Response
+			fakePipeline  *dbfakes.FakePipeline
+			revision      db.ConfigRevision
+			configVersion string
+		)
+
+		BeforeEach(func() {
+			fakePipeline = new(dbfakes.FakePipeline)
+			fakePipeline.ConfigVersionReturns(43)
+			configVersion = ""
+
+			savedPipeline := new(dbfakes.FakePipeline)
+			savedPipeline.ConfigVersionReturns(44)
+			dbTeam.SavePipelineAsReturns(savedPipeline, false, nil)
+
+			dbTeamFactory.FindTeamReturns(dbTeam, true, nil)
+			dbTeam.PipelineReturns(fakePipeline, true, nil)
+
+			revision = db.ConfigRevision{
+				ID:            4,
+				ConfigVersion: 42,
+				Config:        atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}},
+			}
+			fakePipeline.ConfigRevisionReturns(revision, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.RollbackConfigRevision, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+				"revision_id":   "4",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			if configVersion != "" {
+				req.Header.Set(atc.ConfigVersionHeader, configVersion)
+			}
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.RequesterReturns("some-requester")
+			})
+
+			It("saves the revision's config as the pipeline's", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(fakePipeline.ConfigRevisionArgsForCall(0)).To(Equal(4))
+
+				Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
+				author, name, config, from, pausedState := dbTeam.SavePipelineAsArgsForCall(0)
+				Expect(author).To(Equal("some-requester"))
+				Expect(name).To(Equal("a-pipeline"))
+				Expect(config).To(Equal(revision.Config))
+				Expect(from).To(Equal(db.ConfigVersion(43)))
+				Expect(pausedState).To(Equal(db.PipelineNoChange))
+			})
+
+			It("returns the new config version", func() {
+				Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("44"))
+			})
+
+			Context("when a config version is given", func() {
+				BeforeEach(func() {
+					configVersion = "41"
+				})
+
+				It("rolls back only if the pipeline is still at that version", func() {
+					_, _, _, from, _ := dbTeam.SavePipelineAsArgsForCall(0)
+					Expect(from).To(Equal(db.ConfigVersion(41)))
+				})
+			})
+
+			Context("when the config version is malformed", func() {
+				BeforeEach(func() {
+					configVersion = "forty-one"
+				})
+
+				It("returns 400 without rolling back", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.SavePipelineAsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the revision is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigRevisionReturns(db.ConfigRevision{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(dbTeam.SavePipelineAsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the pipeline changed in the meantime", func() {
+				BeforeEach(func() {
+					dbTeam.SavePipelineAsReturns(nil, false, db.ErrConfigComparisonFailed)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when saving fails", func() {
+				BeforeEach(func() {
+					dbTeam.SavePipelineAsReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 without rolling back", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbTeam.SavePipelineAsCallCount()).To(BeZero())
 			})
 		})
 	})
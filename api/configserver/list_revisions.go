@@ -0,0 +1,96 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListConfigRevisions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-config-revisions")
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamName := rata.Param(r, "team_name")
+
+	until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+	since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit == 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("team-not-found", lager.Data{"team": teamName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("pipeline-not-found", lager.Data{"pipeline": pipelineName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	revisions, pagination, err := pipeline.ConfigRevisions(db.Page{Until: until, Since: since, Limit: limit})
+	if err != nil {
+		logger.Error("failed-to-get-config-revisions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Next != nil {
+		s.addConfigRevisionsLink(w, teamName, pipelineName, atc.PaginationQuerySince, pagination.Next.Since, limit, atc.LinkRelNext)
+	}
+
+	if pagination.Previous != nil {
+		s.addConfigRevisionsLink(w, teamName, pipelineName, atc.PaginationQueryUntil, pagination.Previous.Until, limit, atc.LinkRelPrevious)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	presented := make([]atc.ConfigRevision, len(revisions))
+	for i, revision := range revisions {
+		presented[i] = present.ConfigRevision(revision)
+	}
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-config-revisions", err)
+	}
+}
+
+func (s *Server) addConfigRevisionsLink(w http.ResponseWriter, teamName string, pipelineName string, query string, id int, limit int, rel string) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/teams/%s/pipelines/%s/config/revisions?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		teamName,
+		pipelineName,
+		query,
+		id,
+		atc.PaginationQueryLimit,
+		limit,
+		rel,
+	))
+}
@@ -0,0 +1,109 @@
+package configserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/api/configversion"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/lifecycle"
+	"github.com/tedsuo/rata"
+)
+
+// RollbackConfigRevision sets the pipeline back to the config of one of its
+// revisions, recording it as a new revision. As with SaveConfig, a config
+// version may be given to guard against concurrent changes.
+func (s *Server) RollbackConfigRevision(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("rollback-config-revision")
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamName := rata.Param(r, "team_name")
+
+	revisionID, err := strconv.Atoi(rata.Param(r, "revision_id"))
+	if err != nil {
+		logger.Debug("malformed-revision-id", lager.Data{"revision": rata.Param(r, "revision_id")})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("team-not-found", lager.Data{"team": teamName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("pipeline-not-found", lager.Data{"pipeline": pipelineName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	revision, found, err := pipeline.ConfigRevision(revisionID)
+	if err != nil {
+		logger.Error("failed-to-find-config-revision", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("config-revision-not-found", lager.Data{"revision": revisionID})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	version, err := configversion.FromRequest(r)
+	if err != nil {
+		logger.Debug("malformed-config-version", lager.Data{"version": r.Header.Get(atc.ConfigVersionHeader)})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if version == db.AnyConfigVersion {
+		version = pipeline.ConfigVersion()
+	}
+
+	savedPipeline, _, err := team.SavePipelineAs(
+		accessor.GetAccessor(r).Requester(),
+		pipelineName,
+		revision.Config,
+		version,
+		db.PipelineNoChange,
+	)
+	if err != nil {
+		if err == db.ErrConfigComparisonFailed {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		logger.Error("failed-to-save-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("rolled-back", lager.Data{"revision": revisionID})
+
+	lifecycle.Event{
+		Type:         lifecycle.PipelineSet,
+		TeamName:     teamName,
+		PipelineName: pipelineName,
+	}.Publish(logger)
+
+	configversion.SetHeader(w, savedPipeline.ConfigVersion())
+	w.WriteHeader(http.StatusOK)
+}
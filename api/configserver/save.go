@@ -13,8 +13,12 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/imageresolver"
+	"github.com/concourse/atc/lifecycle"
 	"github.com/mitchellh/mapstructure"
 	"github.com/tedsuo/rata"
 	"gopkg.in/yaml.v2"
@@ -106,6 +110,17 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if s.imageResolver != nil {
+		var pinWarnings []atc.Warning
+		config.ResourceTypes, pinWarnings = imageresolver.PinResourceTypes(
+			lagerctx.NewContext(r.Context(), session),
+			s.imageResolver,
+			config.ResourceTypes,
+		)
+
+		warnings = append(warnings, pinWarnings...)
+	}
+
 	session.Info("saving")
 
 	pipelineName := rata.Param(r, "pipeline_name")
@@ -124,7 +139,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState)
+	_, created, err := team.SavePipelineAs(accessor.GetAccessor(r).Requester(), pipelineName, config, version, pausedState)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -134,6 +149,12 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 	session.Info("saved")
 
+	lifecycle.Event{
+		Type:         lifecycle.PipelineSet,
+		TeamName:     teamName,
+		PipelineName: pipelineName,
+	}.Publish(session)
+
 	if created {
 		w.WriteHeader(http.StatusCreated)
 	} else {
@@ -250,6 +271,7 @@ func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePause
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
 			atc.SanitizeDecodeHook,
 			atc.VersionConfigDecodeHook,
+			atc.TagsDecodeHook,
 		),
 	}
 
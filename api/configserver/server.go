@@ -3,19 +3,29 @@ package configserver
 import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/imageresolver"
 )
 
 type Server struct {
-	logger      lager.Logger
-	teamFactory db.TeamFactory
+	logger        lager.Logger
+	teamFactory   db.TeamFactory
+	externalURL   string
+	imageResolver imageresolver.Resolver
 }
 
+// NewServer constructs the config server. If an image resolver is given,
+// resource types are pinned to the digests of their images as pipelines are
+// set.
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
+	externalURL string,
+	imageResolver imageresolver.Resolver,
 ) *Server {
 	return &Server{
-		logger:      logger,
-		teamFactory: teamFactory,
+		logger:        logger,
+		teamFactory:   teamFactory,
+		externalURL:   externalURL,
+		imageResolver: imageResolver,
 	}
 }
@@ -0,0 +1,31 @@
+package configversion
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// FromRequest returns the config version that the request expects the
+// pipeline to be at, or db.AnyConfigVersion if the request does not say.
+func FromRequest(r *http.Request) (db.ConfigVersion, error) {
+	version := db.AnyConfigVersion
+
+	if configVersionStr := r.Header.Get(atc.ConfigVersionHeader); len(configVersionStr) != 0 {
+		_, err := fmt.Sscanf(configVersionStr, "%d", &version)
+		if err != nil {
+			return db.AnyConfigVersion, err
+		}
+	}
+
+	return version, nil
+}
+
+// SetHeader tells the client the config version the pipeline is now at, so
+// that it can be given with its next change. It must be called before the
+// response's status is written.
+func SetHeader(w http.ResponseWriter, version db.ConfigVersion) {
+	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", version))
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/imageresolver"
 	"github.com/concourse/atc/mainredirect"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/wrappa"
@@ -47,6 +48,8 @@ func NewHandler(
 	volumeFactory db.VolumeFactory,
 	containerRepository db.ContainerRepository,
 	dbBuildFactory db.BuildFactory,
+	dbAuditLog db.AuditLog,
+	dbCacheUsageLedger db.CacheUsageLedger,
 
 	peerURL string,
 	eventHandlerFactory buildserver.EventHandlerFactory,
@@ -70,6 +73,7 @@ func NewHandler(
 	workerVersion string,
 	variablesFactory creds.VariablesFactory,
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
+	imageResolver imageresolver.Resolver,
 ) (http.Handler, error) {
 
 	absCLIDownloadsDir, err := filepath.Abs(cliDownloadsDir)
@@ -82,23 +86,25 @@ func NewHandler(
 	teamHandlerFactory := NewTeamScopedHandlerFactory(logger, dbTeamFactory)
 
 	buildServer := buildserver.NewServer(logger, externalURL, peerURL, engine, workerClient, dbTeamFactory, dbBuildFactory, eventHandlerFactory, drain)
-	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL, variablesFactory, dbJobFactory)
+	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL, variablesFactory, dbJobFactory, dbPipelineFactory)
 	resourceServer := resourceserver.NewServer(logger, scannerFactory)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL, engine)
-	configServer := configserver.NewServer(logger, dbTeamFactory)
+	configServer := configserver.NewServer(logger, dbTeamFactory, externalURL, imageResolver)
 	workerServer := workerserver.NewServer(logger, dbTeamFactory, dbWorkerFactory, workerProvider)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
 	containerServer := containerserver.NewServer(logger, workerClient, variablesFactory, interceptTimeoutFactory)
 	volumesServer := volumeserver.NewServer(logger, volumeFactory)
-	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
+	teamServer := teamserver.NewServer(logger, dbTeamFactory, dbAuditLog, dbCacheUsageLedger, externalURL)
 	infoServer := infoserver.NewServer(logger, version, workerVersion)
 	legacyServer := legacyserver.NewServer(logger)
 
 	handlers := map[string]http.Handler{
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:              http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:             http.HandlerFunc(configServer.SaveConfig),
+		atc.ListConfigRevisions:    http.HandlerFunc(configServer.ListConfigRevisions),
+		atc.RollbackConfigRevision: http.HandlerFunc(configServer.RollbackConfigRevision),
 
 		atc.ListBuilds:              http.HandlerFunc(buildServer.ListBuilds),
 		atc.CreateBuild:             teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
@@ -106,10 +112,16 @@ func NewHandler(
 		atc.BuildResources:          buildHandlerFactory.HandlerFor(buildServer.BuildResources),
 		atc.AbortBuild:              buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
 		atc.GetBuildPlan:            buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
+		atc.GetBuildPlanGraph:       buildHandlerFactory.HandlerFor(buildServer.GetBuildPlanGraph),
 		atc.GetBuildPreparation:     buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
+		atc.GetBuildEnvironment:     buildHandlerFactory.HandlerFor(buildServer.GetBuildEnvironment),
+		atc.GetBuildUsage:           buildHandlerFactory.HandlerFor(buildServer.GetBuildUsage),
 		atc.BuildEvents:             buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.SendInputToBuildPlan:    buildHandlerFactory.HandlerFor(buildServer.SendInputToBuildPlan),
 		atc.ReadOutputFromBuildPlan: buildHandlerFactory.HandlerFor(buildServer.ReadOutputFromBuildPlan),
+		atc.ReplayBuildPlan:         buildHandlerFactory.HandlerFor(buildServer.ReplayBuildPlan),
+		atc.GetBuildPlanReplay:      buildHandlerFactory.HandlerFor(buildServer.GetBuildPlanReplay),
+		atc.RerunBuildPlan:          buildHandlerFactory.HandlerFor(buildServer.RerunBuildPlan),
 
 		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
@@ -120,6 +132,8 @@ func NewHandler(
 		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
 		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
 		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
+		atc.PauseJobs:      teamHandlerFactory.HandlerFor(jobServer.PauseJobs),
+		atc.UnpauseJobs:    teamHandlerFactory.HandlerFor(jobServer.UnpauseJobs),
 		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
 		atc.MainJobBadge:   mainredirect.Handler{atc.Routes, atc.JobBadge},
 
@@ -137,6 +151,7 @@ func NewHandler(
 		atc.ListPipelineBuilds:  pipelineHandlerFactory.HandlerFor(pipelineServer.ListPipelineBuilds),
 		atc.CreatePipelineBuild: pipelineHandlerFactory.HandlerFor(pipelineServer.CreateBuild),
 		atc.PipelineBadge:       pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineBadge),
+		atc.GetBuildCalendar:    pipelineHandlerFactory.HandlerFor(pipelineServer.GetBuildCalendar),
 
 		atc.ListResources:        pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
 		atc.GetResource:          pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
@@ -145,21 +160,35 @@ func NewHandler(
 		atc.CheckResource:        pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 		atc.CheckResourceWebHook: pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
 
-		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
-		atc.GetResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.GetResourceVersion),
-		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
-		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
-		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
-		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
-		atc.GetResourceCausality:          pipelineHandlerFactory.HandlerFor(versionServer.GetCausality),
-
-		atc.ListWorkers:     http.HandlerFunc(workerServer.ListWorkers),
-		atc.RegisterWorker:  http.HandlerFunc(workerServer.RegisterWorker),
-		atc.LandWorker:      http.HandlerFunc(workerServer.LandWorker),
-		atc.RetireWorker:    http.HandlerFunc(workerServer.RetireWorker),
-		atc.PruneWorker:     http.HandlerFunc(workerServer.PruneWorker),
-		atc.HeartbeatWorker: http.HandlerFunc(workerServer.HeartbeatWorker),
-		atc.DeleteWorker:    http.HandlerFunc(workerServer.DeleteWorker),
+		atc.ListResourceWebhookTokens:  pipelineHandlerFactory.HandlerFor(resourceServer.ListResourceWebhookTokens),
+		atc.CreateResourceWebhookToken: pipelineHandlerFactory.HandlerFor(resourceServer.CreateResourceWebhookToken),
+		atc.DeleteResourceWebhookToken: pipelineHandlerFactory.HandlerFor(resourceServer.DeleteResourceWebhookToken),
+		atc.ListResourceCheckResults:   pipelineHandlerFactory.HandlerFor(resourceServer.ListResourceCheckResults),
+
+		atc.ListResourceVersions:            pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
+		atc.GetResourceVersion:              pipelineHandlerFactory.HandlerFor(versionServer.GetResourceVersion),
+		atc.EnableResourceVersion:           pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
+		atc.DisableResourceVersion:          pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
+		atc.AnnotateResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.AnnotateResourceVersion),
+		atc.RemoveResourceVersionAnnotation: pipelineHandlerFactory.HandlerFor(versionServer.RemoveResourceVersionAnnotation),
+		atc.SearchResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.SearchResourceVersions),
+		atc.ListBuildsWithVersionAsInput:    pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
+		atc.ListBuildsWithVersionAsOutput:   pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
+		atc.GetResourceCausality:            pipelineHandlerFactory.HandlerFor(versionServer.GetCausality),
+
+		atc.ListWorkers:          http.HandlerFunc(workerServer.ListWorkers),
+		atc.RegisterWorker:       http.HandlerFunc(workerServer.RegisterWorker),
+		atc.LandWorker:           http.HandlerFunc(workerServer.LandWorker),
+		atc.RetireWorker:         http.HandlerFunc(workerServer.RetireWorker),
+		atc.PruneWorker:          http.HandlerFunc(workerServer.PruneWorker),
+		atc.HeartbeatWorker:      http.HandlerFunc(workerServer.HeartbeatWorker),
+		atc.DeleteWorker:         http.HandlerFunc(workerServer.DeleteWorker),
+		atc.ListWorkerContainers: http.HandlerFunc(workerServer.ListWorkerContainers),
+		atc.ListWorkerVolumes:    http.HandlerFunc(workerServer.ListWorkerVolumes),
+
+		atc.ListWorkerMaintenanceWindows: http.HandlerFunc(workerServer.ListWorkerMaintenanceWindows),
+		atc.ScheduleWorkerMaintenance:    http.HandlerFunc(workerServer.ScheduleWorkerMaintenance),
+		atc.CancelWorkerMaintenance:      http.HandlerFunc(workerServer.CancelWorkerMaintenance),
 
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
@@ -177,11 +206,14 @@ func NewHandler(
 		atc.LegacyGetAuthToken:    http.HandlerFunc(legacyServer.GetAuthToken),
 		atc.LegacyGetUser:         http.HandlerFunc(legacyServer.GetUser),
 
-		atc.ListTeams:      http.HandlerFunc(teamServer.ListTeams),
-		atc.SetTeam:        http.HandlerFunc(teamServer.SetTeam),
-		atc.RenameTeam:     http.HandlerFunc(teamServer.RenameTeam),
-		atc.DestroyTeam:    http.HandlerFunc(teamServer.DestroyTeam),
-		atc.ListTeamBuilds: http.HandlerFunc(teamServer.ListTeamBuilds),
+		atc.ListTeams:           http.HandlerFunc(teamServer.ListTeams),
+		atc.SetTeam:             http.HandlerFunc(teamServer.SetTeam),
+		atc.RenameTeam:          http.HandlerFunc(teamServer.RenameTeam),
+		atc.DestroyTeam:         http.HandlerFunc(teamServer.DestroyTeam),
+		atc.ListTeamBuilds:      http.HandlerFunc(teamServer.ListTeamBuilds),
+		atc.ListTeamAuditEvents: http.HandlerFunc(teamServer.ListTeamAuditEvents),
+		atc.GetTeamCacheUsage:   http.HandlerFunc(teamServer.GetTeamCacheUsage),
+		atc.ListTeamCacheUsages: http.HandlerFunc(teamServer.ListTeamCacheUsages),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
@@ -79,9 +79,14 @@ var _ = Describe("Jobs API", func() {
 
 	Describe("GET /api/v1/jobs", func() {
 		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/jobs", nil)
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/jobs"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			req.Header.Set("Content-Type", "application/json")
@@ -239,6 +244,74 @@ var _ = Describe("Jobs API", func() {
 			})
 		})
 
+		Context("when filtering by label", func() {
+			BeforeEach(func() {
+				fakeJob.PipelineIDReturns(42)
+
+				otherJob := new(dbfakes.FakeJob)
+				otherJob.PipelineIDReturns(43)
+				otherJob.ConfigReturns(atc.JobConfig{
+					Name:   "other-job",
+					Labels: atc.Labels{"env": "staging"},
+				})
+
+				stagingPipeline := new(dbfakes.FakePipeline)
+				stagingPipeline.IDReturns(42)
+				stagingPipeline.LabelsReturns(atc.Labels{"env": "staging"})
+
+				productionPipeline := new(dbfakes.FakePipeline)
+				productionPipeline.IDReturns(43)
+				productionPipeline.LabelsReturns(atc.Labels{"env": "production"})
+
+				dbPipelineFactory.VisiblePipelinesReturns([]db.Pipeline{stagingPipeline, productionPipeline}, nil)
+
+				dbJobFactory.VisibleJobsReturns(db.Dashboard{
+					{Job: fakeJob},
+					{Job: otherJob},
+				}, nil)
+
+				query = "?label=env=staging"
+			})
+
+			It("returns only the jobs whose labels or pipeline's labels match", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var jobs []atc.Job
+				err := json.NewDecoder(response.Body).Decode(&jobs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(jobs).To(HaveLen(2))
+			})
+
+			Context("when a job's own labels override its pipeline's", func() {
+				BeforeEach(func() {
+					fakeJob.ConfigReturns(atc.JobConfig{
+						Name:   "some-job",
+						Labels: atc.Labels{"env": "production"},
+					})
+				})
+
+				It("does not return the job", func() {
+					var jobs []atc.Job
+					err := json.NewDecoder(response.Body).Decode(&jobs)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(jobs).To(HaveLen(1))
+					Expect(jobs[0].Name).To(Equal("other-job"))
+				})
+			})
+
+			Context("when the label selector is invalid", func() {
+				BeforeEach(func() {
+					query = "?label=bogus"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+
 		Context("when authenticated", func() {
 			BeforeEach(func() {
 				fakeaccess.TeamNamesReturns([]string{"some-team"})
@@ -810,11 +883,16 @@ var _ = Describe("Jobs API", func() {
 		var response *http.Response
 		var dashboardResponse db.Dashboard
 		var groups []atc.GroupConfig
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs")
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs" + query)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -1146,6 +1224,39 @@ var _ = Describe("Jobs API", func() {
 					})
 				})
 
+				Context("when filtering by label", func() {
+					BeforeEach(func() {
+						fakePipeline.LabelsReturns(atc.Labels{"env": "staging"})
+
+						job1.ConfigReturns(atc.JobConfig{
+							Name:   "job-1",
+							Labels: atc.Labels{"env": "production"},
+						})
+
+						query = "?label=env=staging"
+					})
+
+					It("returns only the jobs whose labels or pipeline's labels match", func() {
+						var jobs []atc.Job
+						err := json.NewDecoder(response.Body).Decode(&jobs)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(jobs).To(HaveLen(2))
+						Expect(jobs[0].Name).To(Equal("job-2"))
+						Expect(jobs[1].Name).To(Equal("job-3"))
+					})
+
+					Context("when the label selector is invalid", func() {
+						BeforeEach(func() {
+							query = "?label=bogus"
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+				})
+
 				Context("when getting the dashboard fails", func() {
 					Context("with an unknown error", func() {
 						BeforeEach(func() {
@@ -1456,17 +1567,27 @@ var _ = Describe("Jobs API", func() {
 							fakeResource2.TypeReturns("some-other-type")
 
 							fakePipeline.ResourcesReturns(db.Resources{fakeResource, fakeResource2}, nil)
+
+							fakeaccess.RequesterReturns("some-team")
 						})
 
 						It("triggers using the current config", func() {
 							Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
 
-							_, job, resources, resourceTypes := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+							_, job, _, resources, resourceTypes := fakeScheduler.TriggerImmediatelyArgsForCall(0)
 							Expect(job).To(Equal(fakeJob))
 							Expect(resources).To(Equal(db.Resources{fakeResource, fakeResource2}))
 							Expect(resourceTypes).To(Equal(versionedResourceTypes))
 						})
 
+						It("records the requester as the cause of the build", func() {
+							_, _, cause, _, _ := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+							Expect(cause).To(Equal(db.BuildTriggerCause{
+								Type:      db.BuildTriggerManual,
+								Requester: "some-team",
+							}))
+						})
+
 						It("returns 200 OK", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusOK))
 						})
@@ -1898,6 +2019,8 @@ var _ = Describe("Jobs API", func() {
 			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/job-name/pause", nil)
 			Expect(err).NotTo(HaveOccurred())
 
+			request.Header.Set(atc.ConfigVersionHeader, "42")
+
 			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -1911,7 +2034,7 @@ var _ = Describe("Jobs API", func() {
 					fakeaccess.IsAuthorizedReturns(true)
 
 					fakePipeline.JobReturns(fakeJob, true, nil)
-					fakeJob.PauseReturns(nil)
+					fakeJob.PauseReturns(db.ConfigVersion(43), nil)
 				})
 
 				It("finds the job on the pipeline and pauses it", func() {
@@ -1919,8 +2042,20 @@ var _ = Describe("Jobs API", func() {
 					Expect(jobName).To(Equal("job-name"))
 
 					Expect(fakeJob.PauseCallCount()).To(Equal(1))
+					Expect(fakeJob.PauseArgsForCall(0)).To(Equal(db.ConfigVersion(42)))
 
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("43"))
+				})
+
+				Context("when the pipeline has changed since the given config version", func() {
+					BeforeEach(func() {
+						fakeJob.PauseReturns(0, db.ErrConfigComparisonFailed)
+					})
+
+					It("returns a 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
 				})
 
 				Context("when the job is not found", func() {
@@ -1945,7 +2080,7 @@ var _ = Describe("Jobs API", func() {
 
 				Context("when the job fails to be paused", func() {
 					BeforeEach(func() {
-						fakeJob.PauseReturns(errors.New("some-error"))
+						fakeJob.PauseReturns(0, errors.New("some-error"))
 					})
 
 					It("returns a 500", func() {
@@ -1989,7 +2124,7 @@ var _ = Describe("Jobs API", func() {
 					fakeaccess.IsAuthenticatedReturns(true)
 
 					fakePipeline.JobReturns(fakeJob, true, nil)
-					fakeJob.UnpauseReturns(nil)
+					fakeJob.UnpauseReturns(db.ConfigVersion(43), nil)
 				})
 
 				It("finds the job on the pipeline and unpauses it", func() {
@@ -2023,7 +2158,7 @@ var _ = Describe("Jobs API", func() {
 
 				Context("when the job fails to be unpaused", func() {
 					BeforeEach(func() {
-						fakeJob.UnpauseReturns(errors.New("some-error"))
+						fakeJob.UnpauseReturns(0, errors.New("some-error"))
 					})
 
 					It("returns a 500", func() {
@@ -2043,6 +2178,272 @@ var _ = Describe("Jobs API", func() {
 			})
 		})
 	})
+
+	Describe("PUT /api/v1/teams/:team_name/jobs/pause", func() {
+		var response *http.Response
+		var query string
+		var configVersion string
+
+		var stagingJob, productionJob *dbfakes.FakeJob
+
+		BeforeEach(func() {
+			query = "?label=env=staging"
+			configVersion = ""
+
+			stagingJob = new(dbfakes.FakeJob)
+			stagingJob.IDReturns(1)
+			stagingJob.NameReturns("staging-job")
+
+			productionJob = new(dbfakes.FakeJob)
+			productionJob.IDReturns(2)
+			productionJob.NameReturns("production-job")
+			productionJob.ConfigReturns(atc.JobConfig{
+				Name:   "production-job",
+				Labels: atc.Labels{"env": "production"},
+			})
+
+			fakePipeline.LabelsReturns(atc.Labels{"env": "staging"})
+			fakePipeline.JobsReturns(db.Jobs{stagingJob, productionJob}, nil)
+
+			dbTeam.PipelinesReturns([]db.Pipeline{fakePipeline}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/jobs/pause"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			if configVersion != "" {
+				request.Header.Set(atc.ConfigVersionHeader, configVersion)
+			}
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeam.PauseJobsReturns(db.ConfigVersion(43), nil)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("pauses the team's jobs matching the labels at once, whatever the team's config version", func() {
+				Expect(dbTeam.PauseJobsCallCount()).To(Equal(1))
+				jobIDs, from := dbTeam.PauseJobsArgsForCall(0)
+				Expect(jobIDs).To(Equal([]int{1}))
+				Expect(from).To(Equal(db.AnyConfigVersion))
+			})
+
+			It("returns the team's new config version", func() {
+				Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("43"))
+			})
+
+			Context("when a config version is given", func() {
+				BeforeEach(func() {
+					configVersion = "42"
+				})
+
+				It("pauses the jobs only if none of the team's pipelines has changed since", func() {
+					Expect(dbTeam.PauseJobsCallCount()).To(Equal(1))
+					_, from := dbTeam.PauseJobsArgsForCall(0)
+					Expect(from).To(Equal(db.ConfigVersion(42)))
+				})
+			})
+
+			Context("when one of the team's pipelines has changed since the given config version", func() {
+				BeforeEach(func() {
+					configVersion = "42"
+					dbTeam.PauseJobsReturns(0, db.ErrConfigComparisonFailed)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when no labels are given", func() {
+				BeforeEach(func() {
+					query = ""
+				})
+
+				It("returns 400 and pauses nothing", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.PauseJobsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the label selector is invalid", func() {
+				BeforeEach(func() {
+					query = "?label=bogus"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when getting the jobs fails", func() {
+				BeforeEach(func() {
+					fakePipeline.JobsReturns(nil, errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the jobs fail to be paused", func() {
+				BeforeEach(func() {
+					dbTeam.PauseJobsReturns(0, errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 401 and pauses nothing", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbTeam.PauseJobsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/jobs/unpause", func() {
+		var response *http.Response
+		var query string
+		var configVersion string
+
+		var stagingJob, productionJob *dbfakes.FakeJob
+
+		BeforeEach(func() {
+			query = "?label=env=staging"
+			configVersion = ""
+
+			stagingJob = new(dbfakes.FakeJob)
+			stagingJob.IDReturns(1)
+			stagingJob.NameReturns("staging-job")
+
+			productionJob = new(dbfakes.FakeJob)
+			productionJob.IDReturns(2)
+			productionJob.NameReturns("production-job")
+			productionJob.ConfigReturns(atc.JobConfig{
+				Name:   "production-job",
+				Labels: atc.Labels{"env": "production"},
+			})
+
+			fakePipeline.LabelsReturns(atc.Labels{"env": "staging"})
+			fakePipeline.JobsReturns(db.Jobs{stagingJob, productionJob}, nil)
+
+			dbTeam.PipelinesReturns([]db.Pipeline{fakePipeline}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/jobs/unpause"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			if configVersion != "" {
+				request.Header.Set(atc.ConfigVersionHeader, configVersion)
+			}
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeam.UnpauseJobsReturns(db.ConfigVersion(43), nil)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("unpauses the team's jobs matching the labels at once, whatever the team's config version", func() {
+				Expect(dbTeam.UnpauseJobsCallCount()).To(Equal(1))
+				jobIDs, from := dbTeam.UnpauseJobsArgsForCall(0)
+				Expect(jobIDs).To(Equal([]int{1}))
+				Expect(from).To(Equal(db.AnyConfigVersion))
+			})
+
+			It("returns the team's new config version", func() {
+				Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("43"))
+			})
+
+			Context("when a config version is given", func() {
+				BeforeEach(func() {
+					configVersion = "42"
+				})
+
+				It("unpauses the jobs only if none of the team's pipelines has changed since", func() {
+					Expect(dbTeam.UnpauseJobsCallCount()).To(Equal(1))
+					_, from := dbTeam.UnpauseJobsArgsForCall(0)
+					Expect(from).To(Equal(db.ConfigVersion(42)))
+				})
+			})
+
+			Context("when one of the team's pipelines has changed since the given config version", func() {
+				BeforeEach(func() {
+					configVersion = "42"
+					dbTeam.UnpauseJobsReturns(0, db.ErrConfigComparisonFailed)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when no labels are given", func() {
+				BeforeEach(func() {
+					query = ""
+				})
+
+				It("returns 400 and unpauses nothing", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeam.UnpauseJobsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the jobs fail to be unpaused", func() {
+				BeforeEach(func() {
+					dbTeam.UnpauseJobsReturns(0, errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 401 and unpauses nothing", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbTeam.UnpauseJobsCallCount()).To(BeZero())
+			})
+		})
+	})
 })
 
 func fakeDBResourceType(t atc.VersionedResourceType) *dbfakes.FakeResourceType {
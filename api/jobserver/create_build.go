@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/db"
 )
@@ -51,7 +52,12 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		build, _, err := scheduler.TriggerImmediately(logger, job, resources, versionedResourceTypes)
+		cause := db.BuildTriggerCause{
+			Type:      db.BuildTriggerManual,
+			Requester: accessor.GetAccessor(r).Requester(),
+		}
+
+		build, _, err := scheduler.TriggerImmediately(logger, job, cause, resources, versionedResourceTypes)
 		if err != nil {
 			logger.Error("failed-to-trigger", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,38 @@
+package jobserver
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// jobLabels are the labels a job is selected by: its pipeline's labels along
+// with its own, which take precedence.
+func jobLabels(pipelineLabels atc.Labels, job db.Job) atc.Labels {
+	return pipelineLabels.Merge(job.Config().Labels)
+}
+
+// matchingJobs finds every job of the team's pipelines matching the
+// selector, keyed by their pipeline.
+func matchingJobs(team db.Team, selector atc.Labels) (map[db.Pipeline]db.Jobs, error) {
+	pipelines, err := team.Pipelines()
+	if err != nil {
+		return nil, err
+	}
+
+	matching := map[db.Pipeline]db.Jobs{}
+
+	for _, pipeline := range pipelines {
+		jobs, err := pipeline.Jobs()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, job := range jobs {
+			if jobLabels(pipeline.Labels(), job).Matches(selector) {
+				matching[pipeline] = append(matching[pipeline], job)
+			}
+		}
+	}
+
+	return matching, nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/db"
@@ -15,6 +16,13 @@ func (s *Server) ListJobs(pipeline db.Pipeline) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var jobs []atc.Job
 
+		selector, err := atc.ParseLabelSelector(r.URL.Query()["label"])
+		if err != nil {
+			logger.Info("invalid-label-selector", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		include := r.FormValue("include")
 		dashboard, err := pipeline.Dashboard(include)
 
@@ -27,6 +35,10 @@ func (s *Server) ListJobs(pipeline db.Pipeline) http.Handler {
 		teamName := r.FormValue(":team_name")
 
 		for _, job := range dashboard {
+			if !jobLabels(pipeline.Labels(), job.Job).Matches(selector) {
+				continue
+			}
+
 			jobs = append(
 				jobs,
 				present.Job(
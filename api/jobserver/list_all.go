@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/present"
@@ -14,6 +15,27 @@ func (s *Server) ListAllJobs(w http.ResponseWriter, r *http.Request) {
 
 	acc := accessor.GetAccessor(r)
 
+	selector, err := atc.ParseLabelSelector(r.URL.Query()["label"])
+	if err != nil {
+		logger.Info("invalid-label-selector", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pipelineLabels := map[int]atc.Labels{}
+	if len(selector) > 0 {
+		pipelines, err := s.pipelineFactory.VisiblePipelines(acc.TeamNames())
+		if err != nil {
+			logger.Error("failed-to-get-all-visible-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		for _, pipeline := range pipelines {
+			pipelineLabels[pipeline.ID()] = pipeline.Labels()
+		}
+	}
+
 	dashboard, err := s.jobFactory.VisibleJobs(acc.TeamNames())
 	if err != nil {
 		logger.Error("failed-to-get-all-visible-jobs", err)
@@ -24,6 +46,10 @@ func (s *Server) ListAllJobs(w http.ResponseWriter, r *http.Request) {
 	var jobs []atc.Job
 
 	for _, job := range dashboard {
+		if !jobLabels(pipelineLabels[job.Job.PipelineID()], job.Job).Matches(selector) {
+			continue
+		}
+
 		jobs = append(
 			jobs,
 			present.Job(
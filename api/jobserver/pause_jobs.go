@@ -0,0 +1,80 @@
+package jobserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/configversion"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/lifecycle"
+)
+
+func (s *Server) PauseJobs(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("pause-jobs")
+
+		selector, err := atc.ParseLabelSelector(r.URL.Query()["label"])
+		if err != nil {
+			logger.Info("invalid-label-selector", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(selector) == 0 {
+			logger.Info("missing-label-selector")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		from, err := configversion.FromRequest(r)
+		if err != nil {
+			logger.Error("malformed-config-version", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		matching, err := matchingJobs(team, selector)
+		if err != nil {
+			logger.Error("failed-to-get-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		jobIDs := []int{}
+		for _, jobs := range matching {
+			for _, job := range jobs {
+				jobIDs = append(jobIDs, job.ID())
+			}
+		}
+
+		// all at once, so that the jobs are left as they were if any of the
+		// team's pipelines has changed since the version was read
+		version, err := team.PauseJobs(jobIDs, from)
+		if err != nil {
+			if err == db.ErrConfigComparisonFailed {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
+			logger.Error("failed-to-pause-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		for pipeline, jobs := range matching {
+			for _, job := range jobs {
+				lifecycle.Event{
+					Type:         lifecycle.JobPaused,
+					TeamName:     team.Name(),
+					PipelineName: pipeline.Name(),
+					JobName:      job.Name(),
+				}.Publish(logger)
+			}
+		}
+
+		configversion.SetHeader(w, version)
+		w.WriteHeader(http.StatusOK)
+	})
+}
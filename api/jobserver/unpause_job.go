@@ -3,7 +3,9 @@ package jobserver
 import (
 	"net/http"
 
+	"github.com/concourse/atc/api/configversion"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/lifecycle"
 	"github.com/tedsuo/rata"
 )
 
@@ -12,6 +14,13 @@ func (s *Server) UnpauseJob(pipeline db.Pipeline) http.Handler {
 		logger := s.logger.Session("unpause-job")
 		jobName := rata.Param(r, "job_name")
 
+		from, err := configversion.FromRequest(r)
+		if err != nil {
+			logger.Error("malformed-config-version", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		job, found, err := pipeline.Job(jobName)
 		if err != nil {
 			logger.Error("failed-to-get-job", err)
@@ -24,13 +33,26 @@ func (s *Server) UnpauseJob(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		err = job.Unpause()
+		version, err := job.Unpause(from)
 		if err != nil {
+			if err == db.ErrConfigComparisonFailed {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
 			logger.Error("failed-to-unpause-job", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
+		lifecycle.Event{
+			Type:         lifecycle.JobUnpaused,
+			TeamName:     pipeline.TeamName(),
+			PipelineName: pipeline.Name(),
+			JobName:      jobName,
+		}.Publish(logger)
+
+		configversion.SetHeader(w, version)
 		w.WriteHeader(http.StatusOK)
 	})
 }
@@ -95,9 +95,14 @@ var _ = Describe("Pipelines API", func() {
 
 	Describe("GET /api/v1/pipelines", func() {
 		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/pipelines", nil)
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/pipelines"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			req.Header.Set("Content-Type", "application/json")
@@ -217,13 +222,61 @@ var _ = Describe("Pipelines API", func() {
 				})
 			})
 		})
+
+		Context("when filtering by label", func() {
+			BeforeEach(func() {
+				publicPipeline.LabelsReturns(atc.Labels{"env": "staging", "tier": "web"})
+				anotherPublicPipeline.LabelsReturns(atc.Labels{"env": "production", "tier": "web"})
+
+				query = "?label=env=staging&label=tier=web"
+			})
+
+			It("returns only the pipelines matching every label", func() {
+				var pipelines []atc.Pipeline
+				err := json.NewDecoder(response.Body).Decode(&pipelines)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pipelines).To(Equal([]atc.Pipeline{
+					{
+						ID:       1,
+						Name:     "public-pipeline",
+						TeamName: "main",
+						Paused:   true,
+						Public:   true,
+						Groups: atc.GroupConfigs{
+							{
+								Name:      "group2",
+								Jobs:      []string{"job3", "job4"},
+								Resources: []string{"resource3", "resource4"},
+							},
+						},
+						Labels: atc.Labels{"env": "staging", "tier": "web"},
+					},
+				}))
+			})
+
+			Context("when the label selector is invalid", func() {
+				BeforeEach(func() {
+					query = "?label=bogus"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
 	})
 
 	Describe("GET /api/v1/teams/:team_name/pipelines", func() {
 		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/main/pipelines", nil)
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/main/pipelines"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			req.Header.Set("Content-Type", "application/json")
@@ -251,6 +304,26 @@ var _ = Describe("Pipelines API", func() {
 				Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("main"))
 			})
 
+			Context("when the team's pipelines are at a config version", func() {
+				BeforeEach(func() {
+					fakeTeam.ConfigVersionReturns(db.ConfigVersion(42), nil)
+				})
+
+				It("returns it", func() {
+					Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("42"))
+				})
+			})
+
+			Context("when getting the team's config version fails", func() {
+				BeforeEach(func() {
+					fakeTeam.ConfigVersionReturns(0, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
 			It("returns all team's pipelines", func() {
 				body, err := ioutil.ReadAll(response.Body)
 				Expect(err).NotTo(HaveOccurred())
@@ -295,6 +368,34 @@ var _ = Describe("Pipelines API", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 				})
 			})
+
+			Context("when filtering by label", func() {
+				BeforeEach(func() {
+					privatePipeline.LabelsReturns(atc.Labels{"env": "staging"})
+
+					query = "?label=env=staging"
+				})
+
+				It("returns only the team's pipelines matching the label", func() {
+					var pipelines []atc.Pipeline
+					err := json.NewDecoder(response.Body).Decode(&pipelines)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(pipelines).To(HaveLen(1))
+					Expect(pipelines[0].Name).To(Equal("private-pipeline"))
+					Expect(pipelines[0].Labels).To(Equal(atc.Labels{"env": "staging"}))
+				})
+
+				Context("when the label selector is invalid", func() {
+					BeforeEach(func() {
+						query = "?label=bogus"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
 		})
 
 		Context("when authenticated as another team", func() {
@@ -805,15 +906,27 @@ var _ = Describe("Pipelines API", func() {
 					Expect(pipelineName).To(Equal("a-pipeline-name"))
 				})
 
-				It("deletes the named pipeline from the database", func() {
-					Expect(dbPipeline.DestroyCallCount()).To(Equal(1))
+				It("archives the named pipeline rather than destroying it", func() {
+					Expect(dbPipeline.ArchiveCallCount()).To(Equal(1))
+					Expect(dbPipeline.ArchiveArgsForCall(0)).To(Equal(db.AnyConfigVersion))
+					Expect(dbPipeline.DestroyCallCount()).To(BeZero())
+				})
+
+				Context("when the pipeline has changed since the given config version", func() {
+					BeforeEach(func() {
+						dbPipeline.ArchiveReturns(0, db.ErrConfigComparisonFailed)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
 				})
 
-				Context("when an error occurs destroying the pipeline", func() {
+				Context("when an error occurs archiving the pipeline", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
 						err := errors.New("disaster!")
-						dbPipeline.DestroyReturns(err)
+						dbPipeline.ArchiveReturns(0, err)
 					})
 
 					It("returns a 500 Internal Server Error", func() {
@@ -846,6 +959,11 @@ var _ = Describe("Pipelines API", func() {
 
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/pause", func() {
 		var response *http.Response
+		var configVersion string
+
+		BeforeEach(func() {
+			configVersion = ""
+		})
 
 		JustBeforeEach(func() {
 			var err error
@@ -853,6 +971,10 @@ var _ = Describe("Pipelines API", func() {
 			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/pause", nil)
 			Expect(err).NotTo(HaveOccurred())
 
+			if configVersion != "" {
+				request.Header.Set(atc.ConfigVersionHeader, configVersion)
+			}
+
 			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -881,18 +1003,65 @@ var _ = Describe("Pipelines API", func() {
 				Context("when pausing the pipeline succeeds", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
-						dbPipeline.PauseReturns(nil)
+						dbPipeline.PauseReturns(db.ConfigVersion(43), nil)
 					})
 
 					It("returns 200", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusOK))
 					})
+
+					It("pauses the pipeline whatever its config version", func() {
+						Expect(dbPipeline.PauseCallCount()).To(Equal(1))
+						Expect(dbPipeline.PauseArgsForCall(0)).To(Equal(db.AnyConfigVersion))
+					})
+
+					It("returns the new config version", func() {
+						Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("43"))
+					})
+
+					Context("when a config version is given", func() {
+						BeforeEach(func() {
+							configVersion = "42"
+						})
+
+						It("pauses the pipeline only if it is still at that version", func() {
+							Expect(dbPipeline.PauseCallCount()).To(Equal(1))
+							Expect(dbPipeline.PauseArgsForCall(0)).To(Equal(db.ConfigVersion(42)))
+						})
+					})
+				})
+
+				Context("when the config version is malformed", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(dbPipeline, true, nil)
+						configVersion = "forty-two"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not pause the pipeline", func() {
+						Expect(dbPipeline.PauseCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the pipeline has changed since the given config version", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(dbPipeline, true, nil)
+						configVersion = "42"
+						dbPipeline.PauseReturns(0, db.ErrConfigComparisonFailed)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
 				})
 
 				Context("when pausing the pipeline fails", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
-						dbPipeline.PauseReturns(errors.New("welp"))
+						dbPipeline.PauseReturns(0, errors.New("welp"))
 					})
 
 					It("returns 500", func() {
@@ -961,7 +1130,7 @@ var _ = Describe("Pipelines API", func() {
 				Context("when unpausing the pipeline succeeds", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
-						dbPipeline.UnpauseReturns(nil)
+						dbPipeline.UnpauseReturns(db.ConfigVersion(43), nil)
 					})
 
 					It("returns 200", func() {
@@ -972,7 +1141,7 @@ var _ = Describe("Pipelines API", func() {
 				Context("when unpausing the pipeline fails", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
-						dbPipeline.UnpauseReturns(errors.New("welp"))
+						dbPipeline.UnpauseReturns(0, errors.New("welp"))
 					})
 
 					It("returns 500", func() {
@@ -1041,7 +1210,7 @@ var _ = Describe("Pipelines API", func() {
 				Context("when exposing the pipeline succeeds", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
-						dbPipeline.ExposeReturns(nil)
+						dbPipeline.ExposeReturns(db.ConfigVersion(43), nil)
 					})
 
 					It("returns 200", func() {
@@ -1052,7 +1221,7 @@ var _ = Describe("Pipelines API", func() {
 				Context("when exposing the pipeline fails", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
-						dbPipeline.ExposeReturns(errors.New("welp"))
+						dbPipeline.ExposeReturns(0, errors.New("welp"))
 					})
 
 					It("returns 500", func() {
@@ -1119,7 +1288,7 @@ var _ = Describe("Pipelines API", func() {
 				Context("when hiding the pipeline succeeds", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
-						dbPipeline.HideReturns(nil)
+						dbPipeline.HideReturns(db.ConfigVersion(43), nil)
 					})
 
 					It("returns 200", func() {
@@ -1130,7 +1299,7 @@ var _ = Describe("Pipelines API", func() {
 				Context("when hiding the pipeline fails", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
-						dbPipeline.HideReturns(errors.New("welp"))
+						dbPipeline.HideReturns(0, errors.New("welp"))
 					})
 
 					It("returns 500", func() {
@@ -1164,8 +1333,10 @@ var _ = Describe("Pipelines API", func() {
 	Describe("PUT /api/v1/teams/:team_name/pipelines/ordering", func() {
 		var response *http.Response
 		var body io.Reader
+		var configVersion string
 
 		BeforeEach(func() {
+			configVersion = ""
 			body = bytes.NewBufferString(`
 				[
 					"a-pipeline",
@@ -1183,6 +1354,10 @@ var _ = Describe("Pipelines API", func() {
 			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/ordering", body)
 			Expect(err).NotTo(HaveOccurred())
 
+			if configVersion != "" {
+				request.Header.Set(atc.ConfigVersionHeader, configVersion)
+			}
+
 			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -1215,12 +1390,12 @@ var _ = Describe("Pipelines API", func() {
 
 				Context("when ordering the pipelines succeeds", func() {
 					BeforeEach(func() {
-						fakeTeam.OrderPipelinesReturns(nil)
+						fakeTeam.OrderPipelinesReturns(db.ConfigVersion(43), nil)
 					})
 
-					It("orders the pipelines", func() {
+					It("orders the pipelines whatever the team's config version", func() {
 						Expect(fakeTeam.OrderPipelinesCallCount()).To(Equal(1))
-						pipelineNames := fakeTeam.OrderPipelinesArgsForCall(0)
+						pipelineNames, from := fakeTeam.OrderPipelinesArgsForCall(0)
 						Expect(pipelineNames).To(Equal(
 							[]string{
 								"a-pipeline",
@@ -1230,17 +1405,58 @@ var _ = Describe("Pipelines API", func() {
 								"just-kidding",
 							},
 						))
-
+						Expect(from).To(Equal(db.AnyConfigVersion))
 					})
 
 					It("returns 200", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusOK))
 					})
+
+					It("returns the team's new config version", func() {
+						Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("43"))
+					})
+
+					Context("when a config version is given", func() {
+						BeforeEach(func() {
+							configVersion = "42"
+						})
+
+						It("orders the pipelines only if none of them has changed since", func() {
+							Expect(fakeTeam.OrderPipelinesCallCount()).To(Equal(1))
+							_, from := fakeTeam.OrderPipelinesArgsForCall(0)
+							Expect(from).To(Equal(db.ConfigVersion(42)))
+						})
+					})
+				})
+
+				Context("when the config version is malformed", func() {
+					BeforeEach(func() {
+						configVersion = "forty-two"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not order the pipelines", func() {
+						Expect(fakeTeam.OrderPipelinesCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when one of the team's pipelines has changed since the given config version", func() {
+					BeforeEach(func() {
+						configVersion = "42"
+						fakeTeam.OrderPipelinesReturns(0, db.ErrConfigComparisonFailed)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
 				})
 
 				Context("when ordering the pipelines fails", func() {
 					BeforeEach(func() {
-						fakeTeam.OrderPipelinesReturns(errors.New("welp"))
+						fakeTeam.OrderPipelinesReturns(0, errors.New("welp"))
 					})
 
 					It("returns 500", func() {
@@ -1442,13 +1658,15 @@ var _ = Describe("Pipelines API", func() {
 
 				It("renames the pipeline to the name provided", func() {
 					Expect(dbPipeline.RenameCallCount()).To(Equal(1))
-					Expect(dbPipeline.RenameArgsForCall(0)).To(Equal("some-new-name"))
+					newName, from := dbPipeline.RenameArgsForCall(0)
+					Expect(newName).To(Equal("some-new-name"))
+					Expect(from).To(Equal(db.AnyConfigVersion))
 				})
 
 				Context("when an error occurs on update", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
-						dbPipeline.RenameReturns(errors.New("whoops"))
+						dbPipeline.RenameReturns(0, errors.New("whoops"))
 					})
 
 					It("returns a 500 internal server error", func() {
@@ -1650,6 +1868,239 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/builds/calendar", func() {
+		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			fakePipeline.NameReturns("some-pipeline")
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/builds/calendar" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(true)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				Context("with public and private jobs", func() {
+					BeforeEach(func() {
+						queryParams = "?from=100&to=200000"
+
+						publicJob := new(dbfakes.FakeJob)
+						publicJob.NameReturns("public-job")
+						publicJob.ConfigReturns(atc.JobConfig{Public: true})
+
+						privateJob := new(dbfakes.FakeJob)
+						privateJob.NameReturns("private-job")
+
+						fakePipeline.JobsReturns(db.Jobs{publicJob, privateJob}, nil)
+
+						fakePipeline.BuildCalendarReturns([]db.BuildCalendarEntry{
+							{JobName: "private-job", Start: time.Unix(86400, 0), Builds: 1},
+							{JobName: "public-job", Start: time.Unix(86400, 0), Builds: 2},
+						}, nil)
+					})
+
+					It("returns only the public jobs' entries", func() {
+						var calendar atc.BuildCalendar
+						err := json.NewDecoder(response.Body).Decode(&calendar)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(calendar.Entries).To(HaveLen(1))
+						Expect(calendar.Entries[0].JobName).To(Equal("public-job"))
+					})
+				})
+
+				Context("when getting the jobs fails", func() {
+					BeforeEach(func() {
+						fakePipeline.JobsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when no params are passed", func() {
+				It("gets the last week of builds by UTC day", func() {
+					Expect(fakePipeline.BuildCalendarCallCount()).To(Equal(1))
+
+					from, to, interval, location := fakePipeline.BuildCalendarArgsForCall(0)
+					Expect(to.Sub(from)).To(Equal(7 * 24 * time.Hour))
+					Expect(interval).To(Equal(db.BuildCalendarDaily))
+					Expect(location).To(Equal(time.UTC))
+				})
+			})
+
+			Context("when all the params are passed", func() {
+				BeforeEach(func() {
+					queryParams = "?from=100&to=200&interval=hour&timezone=America/New_York"
+				})
+
+				It("passes them through", func() {
+					Expect(fakePipeline.BuildCalendarCallCount()).To(Equal(1))
+
+					from, to, interval, location := fakePipeline.BuildCalendarArgsForCall(0)
+					Expect(from).To(Equal(time.Unix(100, 0)))
+					Expect(to).To(Equal(time.Unix(200, 0)))
+					Expect(interval).To(Equal(db.BuildCalendarHourly))
+					Expect(location.String()).To(Equal("America/New_York"))
+				})
+			})
+
+			Context("when getting the calendar succeeds", func() {
+				BeforeEach(func() {
+					queryParams = "?from=100&to=200000"
+
+					fakePipeline.BuildCalendarReturns([]db.BuildCalendarEntry{
+						{
+							JobName:   "some-job",
+							Start:     time.Unix(86400, 0),
+							Builds:    3,
+							Succeeded: 1,
+							Failed:    1,
+							Aborted:   1,
+							Duration:  90 * time.Second,
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the calendar", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"interval": "day",
+						"timezone": "UTC",
+						"from": 100,
+						"to": 200000,
+						"entries": [
+							{
+								"job_name": "some-job",
+								"start": 86400,
+								"builds": 3,
+								"succeeded": 1,
+								"failed": 1,
+								"errored": 0,
+								"aborted": 1,
+								"duration": 90
+							}
+						]
+					}`))
+				})
+			})
+
+			Context("when the interval is invalid", func() {
+				BeforeEach(func() {
+					queryParams = "?interval=week"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakePipeline.BuildCalendarCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the timezone is unknown", func() {
+				BeforeEach(func() {
+					queryParams = "?timezone=Mars/Olympus_Mons"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the range is empty", func() {
+				BeforeEach(func() {
+					queryParams = "?from=200&to=100"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the range is too long for the interval", func() {
+				BeforeEach(func() {
+					queryParams = "?from=0&to=3000000&interval=hour"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakePipeline.BuildCalendarCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the range is too long", func() {
+				BeforeEach(func() {
+					queryParams = "?from=0&to=40000000"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakePipeline.BuildCalendarCallCount()).To(BeZero())
+				})
+			})
+
+			It("gets the entries of every job", func() {
+				Expect(fakePipeline.JobsCallCount()).To(BeZero())
+			})
+
+			Context("when getting the calendar fails", func() {
+				BeforeEach(func() {
+					fakePipeline.BuildCalendarReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/builds", func() {
 		var plan atc.Plan
 		var response *http.Response
@@ -1665,7 +2116,7 @@ var _ = Describe("Pipelines API", func() {
 				},
 			}
 
-			dbPipeline.CreateOneOffBuildStub = func() (db.Build, error) {
+			dbPipeline.CreateOneOffBuildStub = func(db.BuildTriggerCause) (db.Build, error) {
 				Expect(dbTeamFactory.FindTeamCallCount()).To(Equal(1))
 				teamName := dbTeamFactory.FindTeamArgsForCall(0)
 				build.IDReturns(42)
@@ -2,13 +2,16 @@ package pipelineserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
 )
 
 func (s *Server) CreateBuild(pipelineDB db.Pipeline) http.Handler {
@@ -22,7 +25,10 @@ func (s *Server) CreateBuild(pipelineDB db.Pipeline) http.Handler {
 			return
 		}
 
-		build, err := pipelineDB.CreateOneOffBuild()
+		build, err := pipelineDB.CreateOneOffBuild(db.BuildTriggerCause{
+			Type:      db.BuildTriggerManual,
+			Requester: accessor.GetAccessor(r).Requester(),
+		})
 		if err != nil {
 			logger.Error("failed-to-create-one-off-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -30,6 +36,13 @@ func (s *Server) CreateBuild(pipelineDB db.Pipeline) http.Handler {
 		}
 
 		engineBuild, err := s.engine.CreateBuild(logger, build, plan)
+		if rejected, ok := err.(engine.AdmissionRejectedError); ok {
+			logger.Info("build-rejected", lager.Data{"reason": rejected.Reason})
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintln(w, rejected.Error())
+			return
+		}
+
 		if err != nil {
 			logger.Error("failed-to-start-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
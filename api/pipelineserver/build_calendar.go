@@ -0,0 +1,145 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+// defaultBuildCalendarRange is the range covered when no start is given.
+const defaultBuildCalendarRange = 7 * 24 * time.Hour
+
+// maxBuildCalendarRanges bound the range of a calendar by its interval, so
+// that a request cannot aggregate a pipeline's entire build history.
+var maxBuildCalendarRanges = map[db.BuildCalendarInterval]time.Duration{
+	db.BuildCalendarHourly: 31 * 24 * time.Hour,
+	db.BuildCalendarDaily:  366 * 24 * time.Hour,
+}
+
+func (s *Server) GetBuildCalendar(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-build-calendar", lager.Data{
+			"pipeline": pipeline.Name(),
+		})
+
+		interval := db.BuildCalendarInterval(r.FormValue("interval"))
+		switch interval {
+		case "":
+			interval = db.BuildCalendarDaily
+		case db.BuildCalendarDaily, db.BuildCalendarHourly:
+		default:
+			http.Error(w, "interval must be 'hour' or 'day'", http.StatusBadRequest)
+			return
+		}
+
+		timezone := r.FormValue("timezone")
+		if timezone == "" {
+			timezone = "UTC"
+		}
+
+		location, err := time.LoadLocation(timezone)
+		if err != nil || location == time.Local {
+			http.Error(w, "unknown timezone: "+timezone, http.StatusBadRequest)
+			return
+		}
+
+		to := time.Now()
+		if r.FormValue("to") != "" {
+			unix, err := strconv.ParseInt(r.FormValue("to"), 10, 64)
+			if err != nil {
+				http.Error(w, "to must be a unix timestamp", http.StatusBadRequest)
+				return
+			}
+
+			to = time.Unix(unix, 0)
+		}
+
+		from := to.Add(-defaultBuildCalendarRange)
+		if r.FormValue("from") != "" {
+			unix, err := strconv.ParseInt(r.FormValue("from"), 10, 64)
+			if err != nil {
+				http.Error(w, "from must be a unix timestamp", http.StatusBadRequest)
+				return
+			}
+
+			from = time.Unix(unix, 0)
+		}
+
+		if !from.Before(to) {
+			http.Error(w, "from must be before to", http.StatusBadRequest)
+			return
+		}
+
+		maxRange := maxBuildCalendarRanges[interval]
+		if to.Sub(from) > maxRange {
+			http.Error(w, "range must not exceed "+maxRange.String()+" for interval '"+string(interval)+"'", http.StatusBadRequest)
+			return
+		}
+
+		entries, err := pipeline.BuildCalendar(from, to, interval, location)
+		if err != nil {
+			logger.Error("failed-to-get-build-calendar", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// the pipeline may be public with some of its jobs private, whose
+		// builds are only shown to the team
+		if !accessor.GetAccessor(r).IsAuthorized(r.FormValue(":team_name")) {
+			entries, err = publicBuildCalendarEntries(pipeline, entries)
+			if err != nil {
+				logger.Error("failed-to-get-jobs", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		calendar := atc.BuildCalendar{
+			Interval: string(interval),
+			Timezone: location.String(),
+			From:     from.Unix(),
+			To:       to.Unix(),
+			Entries:  make([]atc.BuildCalendarEntry, len(entries)),
+		}
+
+		for i, entry := range entries {
+			calendar.Entries[i] = present.BuildCalendarEntry(entry)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(calendar)
+		if err != nil {
+			logger.Error("failed-to-encode-build-calendar", err)
+		}
+	})
+}
+
+func publicBuildCalendarEntries(pipeline db.Pipeline, entries []db.BuildCalendarEntry) ([]db.BuildCalendarEntry, error) {
+	jobs, err := pipeline.Jobs()
+	if err != nil {
+		return nil, err
+	}
+
+	public := map[string]bool{}
+	for _, job := range jobs {
+		public[job.Name()] = job.Config().Public
+	}
+
+	publicEntries := []db.BuildCalendarEntry{}
+	for _, entry := range entries {
+		if public[entry.JobName] {
+			publicEntries = append(publicEntries, entry)
+		}
+	}
+
+	return publicEntries, nil
+}
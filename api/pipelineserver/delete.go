@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/configversion"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/lifecycle"
 )
 
 func (s *Server) DeletePipeline(pipelineDB db.Pipeline) http.Handler {
@@ -15,8 +17,22 @@ func (s *Server) DeletePipeline(pipelineDB db.Pipeline) http.Handler {
 
 		logger.Info("start")
 
-		err := pipelineDB.Destroy()
+		from, err := configversion.FromRequest(r)
 		if err != nil {
+			logger.Error("malformed-config-version", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// archived rather than destroyed, so that it can be restored until it
+		// is purged by garbage collection
+		_, err = pipelineDB.Archive(from)
+		if err != nil {
+			if err == db.ErrConfigComparisonFailed {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
 			logger.Error("failed", err)
 
 			w.WriteHeader(http.StatusInternalServerError)
@@ -25,6 +41,12 @@ func (s *Server) DeletePipeline(pipelineDB db.Pipeline) http.Handler {
 
 		logger.Info("done")
 
+		lifecycle.Event{
+			Type:         lifecycle.PipelineDestroyed,
+			TeamName:     pipelineDB.TeamName(),
+			PipelineName: pipelineDB.Name(),
+		}.Publish(logger)
+
 		w.WriteHeader(http.StatusNoContent)
 	})
 }
@@ -3,19 +3,33 @@ package pipelineserver
 import (
 	"net/http"
 
+	"github.com/concourse/atc/api/configversion"
 	"github.com/concourse/atc/db"
 )
 
 func (s *Server) ExposePipeline(pipeline db.Pipeline) http.Handler {
 	logger := s.logger.Session("expose-pipeline")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		err := pipeline.Expose()
+		from, err := configversion.FromRequest(r)
 		if err != nil {
+			logger.Error("malformed-config-version", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		version, err := pipeline.Expose(from)
+		if err != nil {
+			if err == db.ErrConfigComparisonFailed {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
 			logger.Error("failed-to-expose-pipeline", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
+		configversion.SetHeader(w, version)
 		w.WriteHeader(http.StatusOK)
 	})
 }
@@ -0,0 +1,18 @@
+package pipelineserver
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func selectPipelines(pipelines []db.Pipeline, selector atc.Labels) []db.Pipeline {
+	selected := []db.Pipeline{}
+
+	for _, pipeline := range pipelines {
+		if pipeline.Labels().Matches(selector) {
+			selected = append(selected, pipeline)
+		}
+	}
+
+	return selected
+}
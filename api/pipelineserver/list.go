@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/api/configversion"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/db"
 )
@@ -25,10 +28,28 @@ func (s *Server) ListPipelines(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	selector, err := atc.ParseLabelSelector(r.URL.Query()["label"])
+	if err != nil {
+		logger.Info("invalid-label-selector", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	var pipelines []db.Pipeline
 	acc := accessor.GetAccessor(r)
 
 	if acc.IsAuthorized(requestTeamName) {
+		var version db.ConfigVersion
+		version, err = team.ConfigVersion()
+		if err != nil {
+			logger.Error("failed-to-get-config-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// given when reordering the pipelines or pausing jobs across them
+		configversion.SetHeader(w, version)
+
 		pipelines, err = team.Pipelines()
 	} else {
 		pipelines, err = team.PublicPipelines()
@@ -42,7 +63,7 @@ func (s *Server) ListPipelines(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 
-	err = json.NewEncoder(w).Encode(present.Pipelines(pipelines))
+	err = json.NewEncoder(w).Encode(present.Pipelines(selectPipelines(pipelines, selector)))
 	if err != nil {
 		logger.Error("failed-to-encode-pipelines", err)
 		w.WriteHeader(http.StatusInternalServerError)
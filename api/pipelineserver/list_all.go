@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/present"
 )
@@ -14,6 +16,13 @@ func (s *Server) ListAllPipelines(w http.ResponseWriter, r *http.Request) {
 
 	acc := accessor.GetAccessor(r)
 
+	selector, err := atc.ParseLabelSelector(r.URL.Query()["label"])
+	if err != nil {
+		logger.Info("invalid-label-selector", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	pipelines, err := s.pipelineFactory.VisiblePipelines(acc.TeamNames())
 	if err != nil {
 		logger.Error("failed-to-get-all-visible-pipelines", err)
@@ -22,7 +31,7 @@ func (s *Server) ListAllPipelines(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(present.Pipelines(pipelines))
+	err = json.NewEncoder(w).Encode(present.Pipelines(selectPipelines(pipelines, selector)))
 	if err != nil {
 		logger.Error("failed-to-encode-pipelines", err)
 		w.WriteHeader(http.StatusInternalServerError)
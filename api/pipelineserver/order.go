@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/configversion"
+	"github.com/concourse/atc/db"
 )
 
 func (s *Server) OrderPipelines(w http.ResponseWriter, r *http.Request) {
@@ -31,8 +33,20 @@ func (s *Server) OrderPipelines(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = team.OrderPipelines(pipelineNames)
+	from, err := configversion.FromRequest(r)
 	if err != nil {
+		logger.Error("malformed-config-version", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	version, err := team.OrderPipelines(pipelineNames, from)
+	if err != nil {
+		if err == db.ErrConfigComparisonFailed {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
 		logger.Error("failed-to-order-pipelines", err, lager.Data{
 			"pipeline-names": pipelineNames,
 		})
@@ -40,5 +54,6 @@ func (s *Server) OrderPipelines(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	configversion.SetHeader(w, version)
 	w.WriteHeader(http.StatusOK)
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/configversion"
 	"github.com/concourse/atc/db"
 )
 
@@ -13,6 +14,13 @@ func (s *Server) RenamePipeline(pipeline db.Pipeline) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger := s.logger.Session("rename-pipeline")
 
+		from, err := configversion.FromRequest(r)
+		if err != nil {
+			logger.Error("malformed-config-version", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		data, err := ioutil.ReadAll(r.Body)
 		if err != nil {
 			logger.Error("failed-to-read-body", err)
@@ -28,13 +36,19 @@ func (s *Server) RenamePipeline(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		err = pipeline.Rename(rename.NewName)
+		version, err := pipeline.Rename(rename.NewName, from)
 		if err != nil {
+			if err == db.ErrConfigComparisonFailed {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
 			logger.Error("failed-to-update-name", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
+		configversion.SetHeader(w, version)
 		w.WriteHeader(http.StatusNoContent)
 	})
 }
@@ -3,19 +3,40 @@ package pipelineserver
 import (
 	"net/http"
 
+	"github.com/concourse/atc/api/configversion"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/lifecycle"
 )
 
 func (s *Server) UnpausePipeline(pipelineDB db.Pipeline) http.Handler {
 	logger := s.logger.Session("unpause-pipeline")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		err := pipelineDB.Unpause()
+		from, err := configversion.FromRequest(r)
 		if err != nil {
+			logger.Error("malformed-config-version", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		version, err := pipelineDB.Unpause(from)
+		if err != nil {
+			if err == db.ErrConfigComparisonFailed {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
 			logger.Error("failed-to-unpause-pipeline", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
+		lifecycle.Event{
+			Type:         lifecycle.PipelineUnpaused,
+			TeamName:     pipelineDB.TeamName(),
+			PipelineName: pipelineDB.Name(),
+		}.Publish(logger)
+
+		configversion.SetHeader(w, version)
 		w.WriteHeader(http.StatusOK)
 	})
 }
@@ -0,0 +1,18 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func AuditEvent(event db.AuditEvent) atc.AuditEvent {
+	return atc.AuditEvent{
+		ID:         event.ID,
+		TeamName:   event.TeamName,
+		Requester:  event.Requester,
+		Action:     event.Action,
+		Subject:    event.Subject,
+		RemoteAddr: event.RemoteAddr,
+		CreatedAt:  event.CreatedAt.Unix(),
+	}
+}
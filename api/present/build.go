@@ -26,6 +26,9 @@ func Build(build db.Build) atc.Build {
 		TeamName:     build.TeamName(),
 		Status:       string(build.Status()),
 		APIURL:       apiURL,
+		RerunOf:      build.RerunOf(),
+		RerunFrom:    string(build.RerunFrom()),
+		ArchivedFrom: build.ArchivedFrom(),
 	}
 
 	if !build.StartTime().IsZero() {
@@ -40,5 +43,21 @@ func Build(build db.Build) atc.Build {
 		atcBuild.ReapTime = build.ReapTime().Unix()
 	}
 
+	if cause := build.TriggerCause(); cause.Type != "" {
+		atcBuild.TriggerCause = &atc.BuildTriggerCause{
+			Type:      string(cause.Type),
+			Resource:  cause.Resource,
+			Version:   atc.Version(cause.Version),
+			Requester: cause.Requester,
+		}
+	}
+
+	if cause := build.AbortCause(); cause != (db.BuildAbortCause{}) {
+		atcBuild.AbortCause = &atc.BuildAbortCause{
+			Requester: cause.Requester,
+			Reason:    cause.Reason,
+		}
+	}
+
 	return atcBuild
 }
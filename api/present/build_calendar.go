@@ -0,0 +1,19 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func BuildCalendarEntry(entry db.BuildCalendarEntry) atc.BuildCalendarEntry {
+	return atc.BuildCalendarEntry{
+		JobName:   entry.JobName,
+		Start:     entry.Start.Unix(),
+		Builds:    entry.Builds,
+		Succeeded: entry.Succeeded,
+		Failed:    entry.Failed,
+		Errored:   entry.Errored,
+		Aborted:   entry.Aborted,
+		Duration:  int64(entry.Duration.Seconds()),
+	}
+}
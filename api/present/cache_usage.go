@@ -0,0 +1,15 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func TeamCacheUsage(usage db.TeamCacheUsage) atc.TeamCacheUsage {
+	return atc.TeamCacheUsage{
+		TeamName:           usage.TeamName,
+		ResourceCacheBytes: uint64(usage.ResourceCacheBytes),
+		TaskCacheBytes:     uint64(usage.TaskCacheBytes),
+		UpdatedAt:          usage.UpdatedAt.Unix(),
+	}
+}
@@ -0,0 +1,19 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func ConfigRevision(revision db.ConfigRevision) atc.ConfigRevision {
+	config := revision.Config
+
+	return atc.ConfigRevision{
+		ID:            revision.ID,
+		ConfigVersion: int(revision.ConfigVersion),
+		Config:        &config,
+		Diff:          revision.Diff,
+		Author:        revision.Author,
+		CreatedAt:     revision.CreatedAt.Unix(),
+	}
+}
@@ -29,3 +29,44 @@ func Container(container db.Container) atc.Container {
 		User:             meta.User,
 	}
 }
+
+func WorkerContainer(workerName string, container db.WorkerContainer) atc.WorkerContainer {
+	meta := container.Metadata
+
+	volumes := make([]atc.WorkerContainerVolume, len(container.Volumes))
+	for i, volume := range container.Volumes {
+		volumes[i] = atc.WorkerContainerVolume{
+			ID:           volume.Handle,
+			Path:         volume.Path,
+			ParentHandle: volume.ParentHandle,
+			Size:         volume.Size,
+		}
+	}
+
+	return atc.WorkerContainer{
+		Container: atc.Container{
+			ID:         container.Handle,
+			WorkerName: workerName,
+
+			Type: string(meta.Type),
+
+			PipelineID: meta.PipelineID,
+			JobID:      meta.JobID,
+			BuildID:    meta.BuildID,
+
+			PipelineName: meta.PipelineName,
+			JobName:      meta.JobName,
+			BuildName:    meta.BuildName,
+
+			StepName: meta.StepName,
+			Attempt:  meta.Attempt,
+
+			WorkingDirectory: meta.WorkingDirectory,
+			User:             meta.User,
+		},
+
+		State:    container.State,
+		TeamName: container.TeamName,
+		Volumes:  volumes,
+	}
+}
@@ -32,10 +32,11 @@ func Job(
 	sanitizedInputs := []atc.JobInput{}
 	for _, input := range job.Config().Inputs() {
 		sanitizedInputs = append(sanitizedInputs, atc.JobInput{
-			Name:     input.Name,
-			Resource: input.Resource,
-			Passed:   input.Passed,
-			Trigger:  input.Trigger,
+			Name:      input.Name,
+			Resource:  input.Resource,
+			Passed:    input.Passed,
+			Annotated: input.Annotated,
+			Trigger:   input.Trigger,
 		})
 	}
 
@@ -64,5 +65,6 @@ func Job(
 		Outputs: sanitizedOutputs,
 
 		Groups: job.Tags(),
+		Labels: job.Config().Labels,
 	}
 }
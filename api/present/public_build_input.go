@@ -19,5 +19,7 @@ func PublicBuildInput(input db.BuildInput, pipelineID int) atc.PublicBuildInput
 		Metadata:        metadata,
 		PipelineID:      pipelineID,
 		FirstOccurrence: input.FirstOccurrence,
+
+		Annotations: VersionAnnotations(input.Annotations),
 	}
 }
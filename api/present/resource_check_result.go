@@ -0,0 +1,17 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func ResourceCheckResult(result db.ResourceCheckResult) atc.ResourceCheckResult {
+	return atc.ResourceCheckResult{
+		ID:           result.ID,
+		StartTime:    result.StartTime.Unix(),
+		EndTime:      result.EndTime.Unix(),
+		Succeeded:    result.Succeeded(),
+		Error:        result.Error,
+		VersionCount: result.VersionCount,
+	}
+}
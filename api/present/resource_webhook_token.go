@@ -0,0 +1,19 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func ResourceWebhookToken(token db.ResourceWebhookToken) atc.ResourceWebhookToken {
+	presented := atc.ResourceWebhookToken{
+		ID:        token.ID,
+		CreatedAt: token.CreatedAt.Unix(),
+	}
+
+	if !token.LastUsedAt.IsZero() {
+		presented.LastUsedAt = token.LastUsedAt.Unix()
+	}
+
+	return presented
+}
@@ -26,5 +26,17 @@ func SavedVersionedResource(svr db.SavedVersionedResource) atc.VersionedResource
 		Type:     svr.Type,
 		Version:  atc.Version(svr.Version),
 		Metadata: metadata,
+
+		Annotations: VersionAnnotations(svr.Annotations),
+	}
+}
+
+func VersionAnnotations(annotations []db.VersionAnnotation) []atc.VersionAnnotation {
+	var presented []atc.VersionAnnotation
+
+	for _, annotation := range annotations {
+		presented = append(presented, atc.VersionAnnotation(annotation))
 	}
+
+	return presented
 }
@@ -69,3 +69,18 @@ func toVolumeBaseResourceType(dbResourceType *db.UsedWorkerBaseResourceType) *at
 		Version: dbResourceType.Version,
 	}
 }
+
+func WorkerVolume(workerName string, volume db.WorkerVolume) atc.WorkerVolume {
+	return atc.WorkerVolume{
+		ID:           volume.Handle,
+		WorkerName:   workerName,
+		Type:         string(volume.Type),
+		State:        volume.State,
+		TeamName:     volume.TeamName,
+		Size:         volume.Size,
+		PipelineName: volume.PipelineName,
+		JobName:      volume.JobName,
+		StepName:     volume.StepName,
+		Path:         volume.Path,
+	}
+}
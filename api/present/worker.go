@@ -34,5 +34,10 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		State:            string(workerInfo.State()),
 		StartTime:        workerInfo.StartTime(),
 		Version:          version,
+		Ephemeral:        workerInfo.Ephemeral(),
+		MemoryPressure:   workerInfo.MemoryPressure(),
+		DiskPressure:     workerInfo.DiskPressure(),
+		DiskUsage:        workerInfo.DiskUsage(),
+		InMaintenance:    workerInfo.InMaintenance(),
 	}
 }
@@ -411,7 +411,7 @@ var _ = Describe("Resources API", func() {
 
 				Context("when pausing the resource succeeds", func() {
 					BeforeEach(func() {
-						fakeResource.PauseReturns(nil)
+						fakeResource.PauseReturns(db.ConfigVersion(43), nil)
 					})
 
 					It("returns 200", func() {
@@ -431,7 +431,7 @@ var _ = Describe("Resources API", func() {
 
 				Context("when pausing the resource fails", func() {
 					BeforeEach(func() {
-						fakeResource.PauseReturns(errors.New("welp"))
+						fakeResource.PauseReturns(0, errors.New("welp"))
 					})
 
 					It("returns 500", func() {
@@ -492,7 +492,7 @@ var _ = Describe("Resources API", func() {
 
 				Context("when unpausing the resource succeeds", func() {
 					BeforeEach(func() {
-						fakeResource.UnpauseReturns(nil)
+						fakeResource.UnpauseReturns(db.ConfigVersion(43), nil)
 					})
 
 					It("returns 200", func() {
@@ -512,7 +512,7 @@ var _ = Describe("Resources API", func() {
 
 				Context("when unpausing the resource fails", func() {
 					BeforeEach(func() {
-						fakeResource.UnpauseReturns(errors.New("welp"))
+						fakeResource.UnpauseReturns(0, errors.New("welp"))
 					})
 
 					It("returns 500", func() {
@@ -570,10 +570,11 @@ var _ = Describe("Resources API", func() {
 			})
 
 			It("tries to scan with no version specified", func() {
-				Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
-				_, actualResourceName, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+				Expect(fakeScanner.ScanFromVersionToDepthCallCount()).To(Equal(1))
+				_, actualResourceName, actualFromVersion, actualDepth := fakeScanner.ScanFromVersionToDepthArgsForCall(0)
 				Expect(actualResourceName).To(Equal("resource-name"))
 				Expect(actualFromVersion).To(BeNil())
+				Expect(actualDepth).To(Equal(1))
 			})
 
 			It("returns 200", func() {
@@ -590,13 +591,81 @@ var _ = Describe("Resources API", func() {
 				})
 
 				It("tries to scan with the version specified", func() {
-					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
-					_, actualResourceName, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+					Expect(fakeScanner.ScanFromVersionToDepthCallCount()).To(Equal(1))
+					_, actualResourceName, actualFromVersion, _ := fakeScanner.ScanFromVersionToDepthArgsForCall(0)
 					Expect(actualResourceName).To(Equal("resource-name"))
 					Expect(actualFromVersion).To(Equal(checkRequestBody.From))
 				})
 			})
 
+			Context("when checking to a depth", func() {
+				BeforeEach(func() {
+					checkRequestBody = atc.CheckRequestBody{
+						Depth: 5,
+					}
+				})
+
+				It("tries to scan to the depth", func() {
+					Expect(fakeScanner.ScanFromVersionToDepthCallCount()).To(Equal(1))
+					_, _, _, actualDepth := fakeScanner.ScanFromVersionToDepthArgsForCall(0)
+					Expect(actualDepth).To(Equal(5))
+				})
+			})
+
+			Context("when the depth is negative", func() {
+				BeforeEach(func() {
+					checkRequestBody = atc.CheckRequestBody{
+						Depth: -1,
+					}
+				})
+
+				It("returns 400 without scanning", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeScanner.ScanFromVersionToDepthCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the depth is as large as allowed", func() {
+				BeforeEach(func() {
+					checkRequestBody = atc.CheckRequestBody{
+						Depth: 20,
+					}
+				})
+
+				It("scans to the depth", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					_, _, _, actualDepth := fakeScanner.ScanFromVersionToDepthArgsForCall(0)
+					Expect(actualDepth).To(Equal(20))
+				})
+			})
+
+			Context("when the depth is larger than allowed", func() {
+				BeforeEach(func() {
+					checkRequestBody = atc.CheckRequestBody{
+						Depth: 21,
+					}
+				})
+
+				It("returns 400 without scanning", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeScanner.ScanFromVersionToDepthCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when checking from scratch with a version specified", func() {
+				BeforeEach(func() {
+					checkRequestBody = atc.CheckRequestBody{
+						From:        atc.Version{"some-version-key": "some-version-value"},
+						FromScratch: true,
+					}
+				})
+
+				It("returns 400 without scanning", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeScanner.ScanFromVersionToDepthCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the resource already has versions", func() {
 				BeforeEach(func() {
 					returnedVersion := db.SavedVersionedResource{
@@ -620,11 +689,25 @@ var _ = Describe("Resources API", func() {
 				})
 
 				It("tries to scan with the latest version when no version is passed", func() {
-					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
-					_, actualResourceName, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+					Expect(fakeScanner.ScanFromVersionToDepthCallCount()).To(Equal(1))
+					_, actualResourceName, actualFromVersion, _ := fakeScanner.ScanFromVersionToDepthArgsForCall(0)
 					Expect(actualResourceName).To(Equal("resource-name"))
 					Expect(actualFromVersion).To(Equal(atc.Version{"some": "version"}))
 				})
+
+				Context("when checking from scratch", func() {
+					BeforeEach(func() {
+						checkRequestBody = atc.CheckRequestBody{
+							FromScratch: true,
+						}
+					})
+
+					It("tries to scan with no version", func() {
+						Expect(fakeScanner.ScanFromVersionToDepthCallCount()).To(Equal(1))
+						_, _, actualFromVersion, _ := fakeScanner.ScanFromVersionToDepthArgsForCall(0)
+						Expect(actualFromVersion).To(BeNil())
+					})
+				})
 			})
 
 			Context("when failing to get latest version for resource", func() {
@@ -637,13 +720,13 @@ var _ = Describe("Resources API", func() {
 				})
 
 				It("does not scan from version", func() {
-					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(0))
+					Expect(fakeScanner.ScanFromVersionToDepthCallCount()).To(Equal(0))
 				})
 			})
 
 			Context("when checking fails with ResourceNotFoundError", func() {
 				BeforeEach(func() {
-					fakeScanner.ScanFromVersionReturns(db.ResourceNotFoundError{})
+					fakeScanner.ScanFromVersionToDepthReturns(db.ResourceNotFoundError{})
 				})
 
 				It("returns 404", func() {
@@ -653,7 +736,7 @@ var _ = Describe("Resources API", func() {
 
 			Context("when checking the resource fails internally", func() {
 				BeforeEach(func() {
-					fakeScanner.ScanFromVersionReturns(errors.New("welp"))
+					fakeScanner.ScanFromVersionToDepthReturns(errors.New("welp"))
 				})
 
 				It("returns 500", func() {
@@ -663,7 +746,7 @@ var _ = Describe("Resources API", func() {
 
 			Context("when checking the resource fails with ErrResourceScriptFailed", func() {
 				BeforeEach(func() {
-					fakeScanner.ScanFromVersionReturns(
+					fakeScanner.ScanFromVersionToDepthReturns(
 						resource.ErrResourceScriptFailed{
 							ExitStatus: 42,
 							Stderr:     "my tooth",
@@ -853,6 +936,314 @@ var _ = Describe("Resources API", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 				})
 			})
+
+			Context("when the token is one of the resource's webhook tokens", func() {
+				BeforeEach(func() {
+					fakeResource.WebhookTokenReturns("")
+					fakeResource.UseWebhookTokenReturns(true, nil)
+				})
+
+				It("checks the resource", func() {
+					Expect(fakeResource.UseWebhookTokenArgsForCall(0)).To(Equal("fake-token"))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when looking up the resource's webhook tokens fails", func() {
+				BeforeEach(func() {
+					fakeResource.WebhookTokenReturns("")
+					fakeResource.UseWebhookTokenReturns(false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/webhook_tokens", func() {
+		var (
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/webhook_tokens")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				fakeResource.WebhookTokensReturns([]db.ResourceWebhookToken{
+					{ID: 1, CreatedAt: time.Unix(100, 0)},
+					{ID: 2, CreatedAt: time.Unix(200, 0), LastUsedAt: time.Unix(300, 0)},
+				}, nil)
+			})
+
+			It("returns the tokens without their secrets", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{"id": 1, "created_at": 100},
+					{"id": 2, "created_at": 200, "last_used_at": 300}
+				]`))
+			})
+
+			Context("when the resource can not be found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the tokens fails", func() {
+				BeforeEach(func() {
+					fakeResource.WebhookTokensReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeResource.WebhookTokensCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/webhook_tokens", func() {
+		var (
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Post(server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/webhook_tokens", "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				fakeResource.CreateWebhookTokenReturns(db.ResourceWebhookToken{ID: 3, CreatedAt: time.Unix(100, 0)}, "some-secret", nil)
+			})
+
+			It("returns the token along with its secret", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{"id": 3, "token": "some-secret", "created_at": 100}`))
+			})
+
+			Context("when creating the token fails", func() {
+				BeforeEach(func() {
+					fakeResource.CreateWebhookTokenReturns(db.ResourceWebhookToken{}, "", errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeResource.CreateWebhookTokenCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/webhook_tokens/:token_id", func() {
+		var (
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+			tokenID      string
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+
+			tokenID = "3"
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/webhook_tokens/"+tokenID, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the token exists", func() {
+				BeforeEach(func() {
+					fakeResource.DeleteWebhookTokenReturns(true, nil)
+				})
+
+				It("deletes it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(fakeResource.DeleteWebhookTokenArgsForCall(0)).To(Equal(3))
+				})
+			})
+
+			Context("when the token does not exist", func() {
+				BeforeEach(func() {
+					fakeResource.DeleteWebhookTokenReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the token id is malformed", func() {
+				BeforeEach(func() {
+					tokenID = "nope"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeResource.DeleteWebhookTokenCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check_results", func() {
+		var (
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+			query        string
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check_results" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				fakeResource.CheckResultsReturns([]db.ResourceCheckResult{
+					{ID: 2, StartTime: time.Unix(300, 0), EndTime: time.Unix(310, 0), Error: "some-error"},
+					{ID: 1, StartTime: time.Unix(100, 0), EndTime: time.Unix(105, 0), VersionCount: 3},
+				}, nil)
+			})
+
+			It("returns the latest check results", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{"id": 2, "start_time": 300, "end_time": 310, "succeeded": false, "error": "some-error", "version_count": 0},
+					{"id": 1, "start_time": 100, "end_time": 105, "succeeded": true, "version_count": 3}
+				]`))
+
+				Expect(fakeResource.CheckResultsArgsForCall(0)).To(Equal(atc.PaginationAPIDefaultLimit))
+			})
+
+			Context("when a limit is given", func() {
+				BeforeEach(func() {
+					query = "?limit=5"
+				})
+
+				It("returns up to that many results", func() {
+					Expect(fakeResource.CheckResultsArgsForCall(0)).To(Equal(5))
+				})
+			})
+
+			Context("when the resource can not be found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the check results fails", func() {
+				BeforeEach(func() {
+					fakeResource.CheckResultsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeResource.CheckResultsCallCount()).To(BeZero())
+			})
 		})
 	})
 })
@@ -11,6 +11,11 @@ import (
 	"github.com/tedsuo/rata"
 )
 
+// maxCheckDepth bounds the number of checks a single request may run. They
+// run one after another while the request waits, holding the resource's
+// checking lock.
+const maxCheckDepth = 20
+
 func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 	logger := s.logger.Session("check-resource")
 
@@ -25,8 +30,31 @@ func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		if reqBody.Depth < 0 {
+			logger.Info("malformed-request", lager.Data{"error": "negative depth"})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if reqBody.Depth > maxCheckDepth {
+			logger.Info("malformed-request", lager.Data{"error": "depth too large", "depth": reqBody.Depth, "max-depth": maxCheckDepth})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if reqBody.FromScratch && reqBody.From != nil {
+			logger.Info("malformed-request", lager.Data{"error": "from version given when checking from scratch"})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		depth := reqBody.Depth
+		if depth == 0 {
+			depth = 1
+		}
+
 		fromVersion := reqBody.From
-		if fromVersion == nil {
+		if fromVersion == nil && !reqBody.FromScratch {
 			latestVersion, found, err := dbPipeline.GetLatestVersionedResource(resourceName)
 			if err != nil {
 				logger.Info("failed-to-get-latest-versioned-resource", lager.Data{"error": err.Error()})
@@ -41,7 +69,7 @@ func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 
 		scanner := s.scannerFactory.NewResourceScanner(dbPipeline)
 
-		err = scanner.ScanFromVersion(logger, resourceName, fromVersion)
+		err = scanner.ScanFromVersionToDepth(logger, resourceName, fromVersion, depth)
 		switch scanErr := err.(type) {
 		case resource.ErrResourceScriptFailed:
 			checkResponseBody := atc.CheckResponseBody{
@@ -0,0 +1,46 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListResourceCheckResults(dbPipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-resource-check-results")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit <= 0 {
+			limit = atc.PaginationAPIDefaultLimit
+		}
+
+		dbResource, found := s.findResource(logger, dbPipeline, w, r)
+		if !found {
+			return
+		}
+
+		dbResults, err := dbResource.CheckResults(limit)
+		if err != nil {
+			logger.Error("failed-to-get-check-results", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		results := []atc.ResourceCheckResult{}
+		for _, result := range dbResults {
+			results = append(results, present.ResourceCheckResult(result))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(results)
+		if err != nil {
+			logger.Error("failed-to-encode-check-results", err)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package resourceserver
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"net/http"
 
@@ -37,12 +38,22 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		token := pipelineResource.WebhookToken()
-		if token != webhookToken {
-			logger.Info("invalid-token", lager.Data{"error": fmt.Sprintf("invalid token for webhook %s", webhookToken)})
+		valid := subtle.ConstantTimeCompare([]byte(pipelineResource.WebhookToken()), []byte(webhookToken)) == 1
+		if !valid {
+			valid, err = pipelineResource.UseWebhookToken(webhookToken)
+			if err != nil {
+				logger.Error("failed-to-use-webhook-token", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		if !valid {
+			logger.Info("invalid-token", lager.Data{"resource": resourceName})
 			w.WriteHeader(http.StatusUnauthorized)
 			return
 		}
+
 		var fromVersion atc.Version
 		latestVersion, found, err := dbPipeline.GetLatestVersionedResource(resourceName)
 		if err != nil {
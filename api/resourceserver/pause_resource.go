@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/configversion"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/lifecycle"
 	"github.com/tedsuo/rata"
 )
 
@@ -13,6 +15,13 @@ func (s *Server) PauseResource(dbPipeline db.Pipeline) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resourceName := rata.Param(r, "resource_name")
 
+		from, err := configversion.FromRequest(r)
+		if err != nil {
+			logger.Error("malformed-config-version", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		dbResource, found, err := dbPipeline.Resource(resourceName)
 		if err != nil {
 			logger.Error("failed-to-get-resource", err)
@@ -26,13 +35,26 @@ func (s *Server) PauseResource(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		err = dbResource.Pause()
+		version, err := dbResource.Pause(from)
 		if err != nil {
+			if err == db.ErrConfigComparisonFailed {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
 			logger.Error("failed-to-pause-resource", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
+		lifecycle.Event{
+			Type:         lifecycle.ResourcePaused,
+			TeamName:     dbPipeline.TeamName(),
+			PipelineName: dbPipeline.Name(),
+			ResourceName: resourceName,
+		}.Publish(logger)
+
+		configversion.SetHeader(w, version)
 		w.WriteHeader(http.StatusOK)
 	})
 }
@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/configversion"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/lifecycle"
 	"github.com/tedsuo/rata"
 )
 
@@ -16,6 +18,13 @@ func (s *Server) UnpauseResource(dbPipeline db.Pipeline) http.Handler {
 			"resource": resourceName,
 		})
 
+		from, err := configversion.FromRequest(r)
+		if err != nil {
+			logger.Error("malformed-config-version", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		dbResource, found, err := dbPipeline.Resource(resourceName)
 		if err != nil {
 			logger.Error("failed-to-get-resource", err)
@@ -29,13 +38,26 @@ func (s *Server) UnpauseResource(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		err = dbResource.Unpause()
+		version, err := dbResource.Unpause(from)
 		if err != nil {
+			if err == db.ErrConfigComparisonFailed {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
 			logger.Error("failed-to-unpause", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
+		lifecycle.Event{
+			Type:         lifecycle.ResourceUnpaused,
+			TeamName:     dbPipeline.TeamName(),
+			PipelineName: dbPipeline.Name(),
+			ResourceName: resourceName,
+		}.Publish(logger)
+
+		configversion.SetHeader(w, version)
 		w.WriteHeader(http.StatusOK)
 	})
 }
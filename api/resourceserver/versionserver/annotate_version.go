@@ -0,0 +1,106 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/configversion"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) AnnotateResourceVersion(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("annotate-resource-version")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		versionedResourceID, err := strconv.Atoi(rata.Param(r, "resource_version_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var annotation atc.VersionAnnotation
+		err = json.NewDecoder(r.Body).Decode(&annotation)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if annotation.Value == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		from, err := configversion.FromRequest(r)
+		if err != nil {
+			logger.Error("malformed-config-version", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		version, found, err := pipeline.AnnotateVersionedResource(versionedResourceID, db.VersionAnnotation{
+			Name:  rata.Param(r, "annotation_name"),
+			Value: annotation.Value,
+			URL:   annotation.URL,
+		}, from)
+		if err != nil {
+			if err == db.ErrConfigComparisonFailed {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
+			logger.Error("failed-to-annotate-versioned-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		configversion.SetHeader(w, version)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (s *Server) RemoveResourceVersionAnnotation(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("remove-resource-version-annotation")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		versionedResourceID, err := strconv.Atoi(rata.Param(r, "resource_version_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		from, err := configversion.FromRequest(r)
+		if err != nil {
+			logger.Error("malformed-config-version", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		version, found, err := pipeline.RemoveVersionedResourceAnnotation(versionedResourceID, rata.Param(r, "annotation_name"), from)
+		if err != nil {
+			if err == db.ErrConfigComparisonFailed {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
+			logger.Error("failed-to-remove-versioned-resource-annotation", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		configversion.SetHeader(w, version)
+		w.WriteHeader(http.StatusOK)
+	})
+}
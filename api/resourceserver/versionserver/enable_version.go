@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/concourse/atc/api/configversion"
 	"github.com/concourse/atc/db"
 	"github.com/tedsuo/rata"
 )
@@ -17,13 +18,26 @@ func (s *Server) EnableResourceVersion(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		err = pipeline.EnableVersionedResource(versionedResourceID)
+		from, err := configversion.FromRequest(r)
 		if err != nil {
+			logger.Error("malformed-config-version", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		version, err := pipeline.EnableVersionedResource(versionedResourceID, from)
+		if err != nil {
+			if err == db.ErrConfigComparisonFailed {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+
 			logger.Error("failed-to-enable-versioned-resource", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
+		configversion.SetHeader(w, version)
 		w.WriteHeader(http.StatusOK)
 	})
 }
@@ -0,0 +1,75 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) SearchResourceVersions(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("search-resource-versions")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.FormValue("value")
+		if value == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit == 0 {
+			limit = atc.PaginationAPIDefaultLimit
+		}
+
+		versions, err := pipeline.SearchResourceVersions(db.ResourceVersionSearch{
+			Value:    value,
+			Field:    r.FormValue("field"),
+			Resource: r.FormValue("resource"),
+			Limit:    limit,
+		})
+		if err != nil {
+			logger.Error("failed-to-search-resource-versions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		versionedResourceIDs := make([]int, len(versions))
+		for i, version := range versions {
+			versionedResourceIDs[i] = version.ID
+		}
+
+		buildsByVersion, err := pipeline.GetBuildsWithVersionsAsInput(versionedResourceIDs)
+		if err != nil {
+			logger.Error("failed-to-get-builds-with-versions-as-input", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		results := []atc.ResourceVersionSearchResult{}
+		for _, version := range versions {
+			presentedBuilds := []atc.Build{}
+			for _, build := range buildsByVersion[version.ID] {
+				presentedBuilds = append(presentedBuilds, present.Build(build))
+			}
+
+			results = append(results, atc.ResourceVersionSearchResult{
+				VersionedResource: present.SavedVersionedResource(version),
+				InputTo:           presentedBuilds,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(results)
+		if err != nil {
+			logger.Error("failed-to-encode-results", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListResourceWebhookTokens(dbPipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-resource-webhook-tokens")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dbResource, found := s.findResource(logger, dbPipeline, w, r)
+		if !found {
+			return
+		}
+
+		dbTokens, err := dbResource.WebhookTokens()
+		if err != nil {
+			logger.Error("failed-to-get-webhook-tokens", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		tokens := []atc.ResourceWebhookToken{}
+		for _, token := range dbTokens {
+			tokens = append(tokens, present.ResourceWebhookToken(token))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(tokens)
+		if err != nil {
+			logger.Error("failed-to-encode-webhook-tokens", err)
+		}
+	})
+}
+
+func (s *Server) CreateResourceWebhookToken(dbPipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("create-resource-webhook-token")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dbResource, found := s.findResource(logger, dbPipeline, w, r)
+		if !found {
+			return
+		}
+
+		dbToken, secret, err := dbResource.CreateWebhookToken()
+		if err != nil {
+			logger.Error("failed-to-create-webhook-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		token := present.ResourceWebhookToken(dbToken)
+		token.Token = secret
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(token)
+		if err != nil {
+			logger.Error("failed-to-encode-webhook-token", err)
+		}
+	})
+}
+
+func (s *Server) DeleteResourceWebhookToken(dbPipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("delete-resource-webhook-token")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenID, err := strconv.Atoi(rata.Param(r, "token_id"))
+		if err != nil {
+			logger.Debug("malformed-token-id", lager.Data{"token_id": rata.Param(r, "token_id")})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		dbResource, found := s.findResource(logger, dbPipeline, w, r)
+		if !found {
+			return
+		}
+
+		deleted, err := dbResource.DeleteWebhookToken(tokenID)
+		if err != nil {
+			logger.Error("failed-to-delete-webhook-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !deleted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (s *Server) findResource(logger lager.Logger, dbPipeline db.Pipeline, w http.ResponseWriter, r *http.Request) (db.Resource, bool) {
+	resourceName := rata.Param(r, "resource_name")
+
+	dbResource, found, err := dbPipeline.Resource(resourceName)
+	if err != nil {
+		logger.Error("failed-to-get-resource", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if !found {
+		logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+
+	return dbResource, true
+}
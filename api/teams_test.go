@@ -783,4 +783,264 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/audit_events", func() {
+		var (
+			response    *http.Response
+			queryParams string
+		)
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/audit_events" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbAuditLog.TeamEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authorized for the team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbAuditLog.TeamEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized for the team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				queryParams = "?since=5&limit=2"
+
+				fakeTeam.IDReturns(7)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				dbAuditLog.TeamEventsReturns([]db.AuditEvent{
+					{
+						ID:         4,
+						TeamID:     7,
+						TeamName:   "some-team",
+						Requester:  "some-team",
+						Action:     "PauseJob",
+						Subject:    map[string]string{"pipeline_name": "some-pipeline", "job_name": "some-job"},
+						RemoteAddr: "1.2.3.4",
+						CreatedAt:  time.Unix(100, 0),
+					},
+				}, db.Pagination{
+					Next: &db.Page{Since: 4, Limit: 2},
+				}, nil)
+			})
+
+			It("returns the team's events", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+
+				teamID, page := dbAuditLog.TeamEventsArgsForCall(0)
+				Expect(teamID).To(Equal(7))
+				Expect(page).To(Equal(db.Page{Since: 5, Limit: 2}))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 4,
+						"team_name": "some-team",
+						"requester": "some-team",
+						"action": "PauseJob",
+						"subject": {"pipeline_name": "some-pipeline", "job_name": "some-job"},
+						"remote_addr": "1.2.3.4",
+						"created_at": 100
+					}
+				]`))
+			})
+
+			It("returns Link headers per rfc5988", func() {
+				Expect(response.Header["Link"]).To(ConsistOf([]string{
+					fmt.Sprintf(`<%s/api/v1/teams/some-team/audit_events?since=4&limit=2>; rel="next"`, externalURL),
+				}))
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(dbAuditLog.TeamEventsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when finding the team fails", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					dbAuditLog.TeamEventsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/cache_usage", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/cache_usage")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized for the team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbCacheUsageLedger.TeamUsageCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized for the team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+
+				dbCacheUsageLedger.TeamUsageReturns(db.TeamCacheUsage{
+					TeamName:           "some-team",
+					ResourceCacheBytes: 1024,
+					TaskCacheBytes:     2048,
+					UpdatedAt:          time.Unix(100, 0),
+				}, true, nil)
+			})
+
+			It("returns the team's cache usage", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				Expect(dbCacheUsageLedger.TeamUsageArgsForCall(0)).To(Equal("some-team"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"team_name": "some-team",
+					"resource_cache_bytes": 1024,
+					"task_cache_bytes": 2048,
+					"updated_at": 100
+				}`))
+			})
+
+			Context("when the usage has not been totalled up yet", func() {
+				BeforeEach(func() {
+					dbCacheUsageLedger.TeamUsageReturns(db.TeamCacheUsage{}, false, nil)
+				})
+
+				It("returns no usage", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"team_name": "some-team",
+						"resource_cache_bytes": 0,
+						"task_cache_bytes": 0
+					}`))
+				})
+			})
+
+			Context("when getting the usage fails", func() {
+				BeforeEach(func() {
+					dbCacheUsageLedger.TeamUsageReturns(db.TeamCacheUsage{}, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/cache_usage", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/cache_usage")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbCacheUsageLedger.TeamUsagesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(true)
+
+				dbCacheUsageLedger.TeamUsagesReturns([]db.TeamCacheUsage{
+					{TeamName: "other-team", ResourceCacheBytes: 1, UpdatedAt: time.Unix(100, 0)},
+					{TeamName: "some-team", TaskCacheBytes: 2, UpdatedAt: time.Unix(100, 0)},
+				}, nil)
+			})
+
+			It("returns the cache usage of every team", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{"team_name": "other-team", "resource_cache_bytes": 1, "task_cache_bytes": 0, "updated_at": 100},
+					{"team_name": "some-team", "resource_cache_bytes": 0, "task_cache_bytes": 2, "updated_at": 100}
+				]`))
+			})
+		})
+	})
 })
@@ -0,0 +1,61 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+)
+
+func (s *Server) GetTeamCacheUsage(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-team-cache-usage")
+
+	teamName := r.FormValue(":team_name")
+
+	usage, found, err := s.cacheUsages.TeamUsage(teamName)
+	if err != nil {
+		logger.Error("failed-to-get-team-cache-usage", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// the usage of a team is not known until garbage collection has totalled
+	// it up, so leave it blank rather than failing in the meantime
+	presented := atc.TeamCacheUsage{TeamName: teamName}
+	if found {
+		presented = present.TeamCacheUsage(usage)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-team-cache-usage", err)
+	}
+}
+
+func (s *Server) ListTeamCacheUsages(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-team-cache-usages")
+
+	usages, err := s.cacheUsages.TeamUsages()
+	if err != nil {
+		logger.Error("failed-to-get-team-cache-usages", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	presented := make([]atc.TeamCacheUsage, len(usages))
+	for i, usage := range usages {
+		presented[i] = present.TeamCacheUsage(usage)
+	}
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-team-cache-usages", err)
+	}
+}
@@ -0,0 +1,79 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListTeamAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-team-audit-events")
+
+	teamName := r.FormValue(":team_name")
+
+	until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+	since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit == 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	events, pagination, err := s.auditLog.TeamEvents(team.ID(), db.Page{Until: until, Since: since, Limit: limit})
+	if err != nil {
+		logger.Error("failed-to-get-team-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Next != nil {
+		s.addAuditEventsLink(w, teamName, atc.PaginationQuerySince, pagination.Next.Since, limit, atc.LinkRelNext)
+	}
+
+	if pagination.Previous != nil {
+		s.addAuditEventsLink(w, teamName, atc.PaginationQueryUntil, pagination.Previous.Until, limit, atc.LinkRelPrevious)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	presented := make([]atc.AuditEvent, len(events))
+	for i, event := range events {
+		presented[i] = present.AuditEvent(event)
+	}
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-audit-events", err)
+	}
+}
+
+func (s *Server) addAuditEventsLink(w http.ResponseWriter, teamName string, query string, id int, limit int, rel string) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/teams/%s/audit_events?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		teamName,
+		query,
+		id,
+		atc.PaginationQueryLimit,
+		limit,
+		rel,
+	))
+}
@@ -8,17 +8,23 @@ import (
 type Server struct {
 	logger      lager.Logger
 	teamFactory db.TeamFactory
+	auditLog    db.AuditLog
+	cacheUsages db.CacheUsageLedger
 	externalURL string
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
+	auditLog db.AuditLog,
+	cacheUsages db.CacheUsageLedger,
 	externalURL string,
 ) *Server {
 	return &Server{
 		logger:      logger,
 		teamFactory: teamFactory,
+		auditLog:    auditLog,
+		cacheUsages: cacheUsages,
 		externalURL: externalURL,
 	}
 }
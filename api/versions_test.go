@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
@@ -153,6 +155,13 @@ var _ = Describe("Versions API", func() {
 									},
 								},
 							},
+							Annotations: []db.VersionAnnotation{
+								{
+									Name:  "qa",
+									Value: "signed off",
+									URL:   "https://tickets.example.com/123",
+								},
+							},
 						},
 						{
 							ID:      2,
@@ -200,6 +209,13 @@ var _ = Describe("Versions API", func() {
 								"name":"some",
 								"value":"metadata"
 							}
+						],
+						"annotations": [
+							{
+								"name":"qa",
+								"value":"signed off",
+								"url":"https://tickets.example.com/123"
+							}
 						]
 					},
 					{
@@ -283,11 +299,12 @@ var _ = Describe("Versions API", func() {
 
 				Context("when enabling the resource succeeds", func() {
 					BeforeEach(func() {
-						fakePipeline.EnableVersionedResourceReturns(nil)
+						fakePipeline.EnableVersionedResourceReturns(db.ConfigVersion(43), nil)
 					})
 
 					It("enabled the right versioned resource", func() {
-						Expect(fakePipeline.EnableVersionedResourceArgsForCall(0)).To(Equal(42))
+						resourceID, _ := fakePipeline.EnableVersionedResourceArgsForCall(0)
+						Expect(resourceID).To(Equal(42))
 					})
 
 					It("returns 200", func() {
@@ -297,7 +314,7 @@ var _ = Describe("Versions API", func() {
 
 				Context("when enabling the resource fails", func() {
 					BeforeEach(func() {
-						fakePipeline.EnableVersionedResourceReturns(errors.New("welp"))
+						fakePipeline.EnableVersionedResourceReturns(0, errors.New("welp"))
 					})
 
 					It("returns 500", func() {
@@ -351,11 +368,12 @@ var _ = Describe("Versions API", func() {
 
 				Context("when enabling the resource succeeds", func() {
 					BeforeEach(func() {
-						fakePipeline.DisableVersionedResourceReturns(nil)
+						fakePipeline.DisableVersionedResourceReturns(db.ConfigVersion(43), nil)
 					})
 
 					It("disabled the right versioned resource", func() {
-						Expect(fakePipeline.DisableVersionedResourceArgsForCall(0)).To(Equal(42))
+						resourceID, _ := fakePipeline.DisableVersionedResourceArgsForCall(0)
+						Expect(resourceID).To(Equal(42))
 					})
 
 					It("returns 200", func() {
@@ -365,7 +383,7 @@ var _ = Describe("Versions API", func() {
 
 				Context("when enabling the resource fails", func() {
 					BeforeEach(func() {
-						fakePipeline.DisableVersionedResourceReturns(errors.New("welp"))
+						fakePipeline.DisableVersionedResourceReturns(0, errors.New("welp"))
 					})
 
 					It("returns 500", func() {
@@ -394,6 +412,280 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/annotations/:annotation_name", func() {
+		var response *http.Response
+		var body string
+		var configVersion string
+
+		BeforeEach(func() {
+			body = `{"value":"signed off","url":"https://tickets.example.com/123"}`
+			configVersion = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/annotations/qa", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			if configVersion != "" {
+				request.Header.Set(atc.ConfigVersionHeader, configVersion)
+			}
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when annotating the version succeeds", func() {
+					BeforeEach(func() {
+						fakePipeline.AnnotateVersionedResourceReturns(db.ConfigVersion(43), true, nil)
+					})
+
+					It("annotated the right versioned resource", func() {
+						versionedResourceID, annotation, from := fakePipeline.AnnotateVersionedResourceArgsForCall(0)
+						Expect(versionedResourceID).To(Equal(42))
+						Expect(annotation).To(Equal(db.VersionAnnotation{
+							Name:  "qa",
+							Value: "signed off",
+							URL:   "https://tickets.example.com/123",
+						}))
+						Expect(from).To(Equal(db.AnyConfigVersion))
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns the new config version", func() {
+						Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("43"))
+					})
+
+					Context("when a config version is given", func() {
+						BeforeEach(func() {
+							configVersion = "42"
+						})
+
+						It("annotates the version only if the pipeline is still at that version", func() {
+							_, _, from := fakePipeline.AnnotateVersionedResourceArgsForCall(0)
+							Expect(from).To(Equal(db.ConfigVersion(42)))
+						})
+					})
+				})
+
+				Context("when the pipeline has changed since the given config version", func() {
+					BeforeEach(func() {
+						configVersion = "42"
+						fakePipeline.AnnotateVersionedResourceReturns(0, false, db.ErrConfigComparisonFailed)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when the config version is malformed", func() {
+					BeforeEach(func() {
+						configVersion = "forty-two"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not annotate the version", func() {
+						Expect(fakePipeline.AnnotateVersionedResourceCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the version is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.AnnotateVersionedResourceReturns(0, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when annotating the version fails", func() {
+					BeforeEach(func() {
+						fakePipeline.AnnotateVersionedResourceReturns(0, false, errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the annotation has no value", func() {
+					BeforeEach(func() {
+						body = `{"url":"https://tickets.example.com/123"}`
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not annotate the version", func() {
+						Expect(fakePipeline.AnnotateVersionedResourceCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the request body is malformed", func() {
+					BeforeEach(func() {
+						body = `{`
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns Unauthorized", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/annotations/:annotation_name", func() {
+		var response *http.Response
+		var configVersion string
+
+		BeforeEach(func() {
+			configVersion = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/annotations/qa", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			if configVersion != "" {
+				request.Header.Set(atc.ConfigVersionHeader, configVersion)
+			}
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when removing the annotation succeeds", func() {
+					BeforeEach(func() {
+						fakePipeline.RemoveVersionedResourceAnnotationReturns(db.ConfigVersion(43), true, nil)
+					})
+
+					It("removed the right annotation", func() {
+						versionedResourceID, name, from := fakePipeline.RemoveVersionedResourceAnnotationArgsForCall(0)
+						Expect(versionedResourceID).To(Equal(42))
+						Expect(name).To(Equal("qa"))
+						Expect(from).To(Equal(db.AnyConfigVersion))
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns the new config version", func() {
+						Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("43"))
+					})
+				})
+
+				Context("when the pipeline has changed since the given config version", func() {
+					BeforeEach(func() {
+						configVersion = "42"
+						fakePipeline.RemoveVersionedResourceAnnotationReturns(0, false, db.ErrConfigComparisonFailed)
+					})
+
+					It("removes the annotation only if the pipeline is still at that version", func() {
+						_, _, from := fakePipeline.RemoveVersionedResourceAnnotationArgsForCall(0)
+						Expect(from).To(Equal(db.ConfigVersion(42)))
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when the annotation is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.RemoveVersionedResourceAnnotationReturns(0, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when removing the annotation fails", func() {
+					BeforeEach(func() {
+						fakePipeline.RemoveVersionedResourceAnnotationReturns(0, false, errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns Unauthorized", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", func() {
 		var response *http.Response
 		var stringVersionID string
@@ -705,4 +997,168 @@ var _ = Describe("Versions API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resource_versions", func() {
+		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = "?value=abc123&field=ref&resource=some-resource"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resource_versions"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(false)
+					fakeaccess.IsAuthenticatedReturns(true)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(true)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			It("searches with the given query and the default limit", func() {
+				Expect(fakePipeline.SearchResourceVersionsCallCount()).To(Equal(1))
+				Expect(fakePipeline.SearchResourceVersionsArgsForCall(0)).To(Equal(db.ResourceVersionSearch{
+					Value:    "abc123",
+					Field:    "ref",
+					Resource: "some-resource",
+					Limit:    100,
+				}))
+			})
+
+			Context("when no value is given", func() {
+				BeforeEach(func() {
+					query = "?field=ref"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not search", func() {
+					Expect(fakePipeline.SearchResourceVersionsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when versions are found", func() {
+				BeforeEach(func() {
+					fakePipeline.SearchResourceVersionsReturns([]db.SavedVersionedResource{
+						{
+							ID:      123,
+							Enabled: true,
+							VersionedResource: db.VersionedResource{
+								Resource: "some-resource",
+								Type:     "git",
+								Version:  db.ResourceVersion{"ref": "abc123def"},
+								Metadata: db.ResourceMetadataFields{{Name: "author", Value: "someone"}},
+							},
+						},
+					}, nil)
+
+					build := new(dbfakes.FakeBuild)
+					build.IDReturns(1024)
+					build.NameReturns("5")
+					build.JobNameReturns("some-job")
+					build.PipelineNameReturns("a-pipeline")
+					build.TeamNameReturns("a-team")
+					build.StatusReturns(db.BuildStatusSucceeded)
+					build.StartTimeReturns(time.Unix(1, 0))
+					build.EndTimeReturns(time.Unix(100, 0))
+
+					fakePipeline.GetBuildsWithVersionsAsInputReturns(map[int][]db.Build{123: {build}}, nil)
+				})
+
+				It("returns each version with the builds it was an input to", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					Expect(fakePipeline.GetBuildsWithVersionsAsInputCallCount()).To(Equal(1))
+					Expect(fakePipeline.GetBuildsWithVersionsAsInputArgsForCall(0)).To(Equal([]int{123}))
+					Expect(fakePipeline.GetBuildsWithVersionAsInputCallCount()).To(BeZero())
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"versioned_resource": {
+								"id": 123,
+								"enabled": true,
+								"resource": "some-resource",
+								"type": "git",
+								"version": {"ref": "abc123def"},
+								"metadata": [{"name": "author", "value": "someone"}]
+							},
+							"input_to": [
+								{
+									"id": 1024,
+									"team_name": "a-team",
+									"name": "5",
+									"status": "succeeded",
+									"job_name": "some-job",
+									"api_url": "/api/v1/builds/1024",
+									"pipeline_name": "a-pipeline",
+									"start_time": 1,
+									"end_time": 100
+								}
+							]
+						}
+					]`))
+				})
+
+				Context("when getting the builds fails", func() {
+					BeforeEach(func() {
+						fakePipeline.GetBuildsWithVersionsAsInputReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when searching fails", func() {
+				BeforeEach(func() {
+					fakePipeline.SearchResourceVersionsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
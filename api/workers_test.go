@@ -657,6 +657,258 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/workers/:worker_name/containers", func() {
+		var (
+			response   *http.Response
+			workerName string
+			fakeWorker *dbfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/workers/"+workerName+"/containers", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeWorker = new(dbfakes.FakeWorker)
+			workerName = "some-worker"
+			fakeWorker.NameReturns(workerName)
+			fakeWorker.TeamNameReturns("some-team")
+			fakeWorker.ContainersReturns([]db.WorkerContainer{
+				{
+					Handle:   "some-handle",
+					State:    "created",
+					TeamName: "some-team",
+					Metadata: db.ContainerMetadata{
+						Type:         db.ContainerTypeTask,
+						StepName:     "some-step",
+						PipelineID:   1,
+						JobID:        2,
+						BuildID:      3,
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						BuildName:    "4",
+					},
+					Volumes: []db.WorkerContainerVolume{
+						{
+							Handle:       "some-volume",
+							Path:         "/tmp/build/some-cache",
+							ParentHandle: "some-cache-volume",
+							Size:         1024,
+						},
+					},
+				},
+			}, nil)
+
+			fakeaccess.IsAuthenticatedReturns(true)
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+		})
+
+		Context("when the request is authenticated as system", func() {
+			BeforeEach(func() {
+				fakeaccess.IsSystemReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns Content-Type 'application/json'", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("returns the containers with their owners and volumes", func() {
+				Expect(dbWorkerFactory.GetWorkerArgsForCall(0)).To(Equal(workerName))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": "some-handle",
+						"worker_name": "some-worker",
+						"type": "task",
+						"step_name": "some-step",
+						"pipeline_id": 1,
+						"job_id": 2,
+						"build_id": 3,
+						"pipeline_name": "some-pipeline",
+						"job_name": "some-job",
+						"build_name": "4",
+						"state": "created",
+						"team_name": "some-team",
+						"volumes": [
+							{
+								"id": "some-volume",
+								"path": "/tmp/build/some-cache",
+								"parent_handle": "some-cache-volume",
+								"size": 1024
+							}
+						]
+					}
+				]`))
+			})
+
+			Context("when listing the containers fails", func() {
+				BeforeEach(func() {
+					fakeWorker.ContainersReturns(nil, errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when the request is authorized as the wrong team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/workers/:worker_name/volumes", func() {
+		var (
+			response   *http.Response
+			workerName string
+			fakeWorker *dbfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/workers/"+workerName+"/volumes", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeWorker = new(dbfakes.FakeWorker)
+			workerName = "some-worker"
+			fakeWorker.NameReturns(workerName)
+			fakeWorker.TeamNameReturns("some-team")
+			fakeWorker.VolumesReturns([]db.WorkerVolume{
+				{
+					Handle:       "some-cache-volume",
+					State:        "created",
+					Type:         db.VolumeTypeTaskCache,
+					TeamName:     "some-team",
+					Size:         4096,
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					StepName:     "some-step",
+					Path:         "some-cache",
+				},
+			}, nil)
+
+			fakeaccess.IsAuthenticatedReturns(true)
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+		})
+
+		Context("when the request is authenticated as system", func() {
+			BeforeEach(func() {
+				fakeaccess.IsSystemReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns Content-Type 'application/json'", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("returns the volumes with their owners and sizes", func() {
+				Expect(dbWorkerFactory.GetWorkerArgsForCall(0)).To(Equal(workerName))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": "some-cache-volume",
+						"worker_name": "some-worker",
+						"type": "task-cache",
+						"state": "created",
+						"team_name": "some-team",
+						"size": 4096,
+						"pipeline_name": "some-pipeline",
+						"job_name": "some-job",
+						"step_name": "some-step",
+						"path": "some-cache"
+					}
+				]`))
+			})
+
+			Context("when listing the volumes fails", func() {
+				BeforeEach(func() {
+					fakeWorker.VolumesReturns(nil, errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when the request is authorized as the wrong team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/workers/:worker_name/prune", func() {
 		var (
 			response   *http.Response
@@ -820,6 +1072,54 @@ var _ = Describe("Workers API", func() {
 			Expect(t).To(Equal(ttl))
 		})
 
+		It("does not save any container usages", func() {
+			Expect(fakeWorker.SaveContainerUsagesCallCount()).To(BeZero())
+		})
+
+		Context("when the worker reports the usage of its containers", func() {
+			var usages []atc.ContainerUsage
+
+			BeforeEach(func() {
+				usages = []atc.ContainerUsage{
+					{Handle: "some-handle", CPUUsage: 1, MemoryUsage: 2, DiskUsage: 3},
+				}
+
+				worker.ContainerUsages = usages
+			})
+
+			It("saves them", func() {
+				Expect(fakeWorker.SaveContainerUsagesCallCount()).To(Equal(1))
+				Expect(fakeWorker.SaveContainerUsagesArgsForCall(0)).To(Equal(usages))
+			})
+
+			Context("when saving them fails", func() {
+				BeforeEach(func() {
+					fakeWorker.SaveContainerUsagesReturns(errors.New("disaster"))
+				})
+
+				It("still returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when the worker reports the sizes of its volumes", func() {
+			var usages []atc.VolumeUsage
+
+			BeforeEach(func() {
+				usages = []atc.VolumeUsage{
+					{Handle: "some-handle", Size: 1024},
+				}
+
+				worker.VolumeUsages = usages
+			})
+
+			It("saves them", func() {
+				Expect(fakeWorker.SaveVolumeUsagesCallCount()).To(Equal(1))
+				Expect(fakeWorker.SaveVolumeUsagesArgsForCall(0)).To(Equal(usages))
+			})
+		})
+
 		Context("when the TTL is invalid", func() {
 			BeforeEach(func() {
 				ttlStr = "invalid-duration"
@@ -974,4 +1274,104 @@ var _ = Describe("Workers API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/workers/:worker_name/maintenance_windows", func() {
+		var (
+			response   *http.Response
+			body       string
+			fakeWorker *dbfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/workers/some-worker/maintenance_windows", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			body = `{"starts_at":100,"ends_at":200}`
+
+			fakeWorker = new(dbfakes.FakeWorker)
+			fakeWorker.NameReturns("some-worker")
+			fakeWorker.ScheduleMaintenanceReturns(atc.WorkerMaintenanceWindow{ID: 1, StartsAt: 100, EndsAt: 200}, nil)
+
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsSystemReturns(true)
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+		})
+
+		It("schedules the window and returns it", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+			startsAt, endsAt := fakeWorker.ScheduleMaintenanceArgsForCall(0)
+			Expect(startsAt.Unix()).To(Equal(int64(100)))
+			Expect(endsAt.Unix()).To(Equal(int64(200)))
+
+			respBody, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(respBody).To(MatchJSON(`{"id":1,"starts_at":100,"ends_at":200}`))
+		})
+
+		Context("when the window does not end after it starts", func() {
+			BeforeEach(func() {
+				body = `{"starts_at":200,"ends_at":100}`
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(fakeWorker.ScheduleMaintenanceCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the worker does not exist", func() {
+			BeforeEach(func() {
+				dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/workers/:worker_name/maintenance_windows/:window_id", func() {
+		var (
+			response   *http.Response
+			fakeWorker *dbfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/workers/some-worker/maintenance_windows/1", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeWorker = new(dbfakes.FakeWorker)
+			fakeWorker.CancelMaintenanceReturns(true, nil)
+
+			fakeaccess.IsAuthenticatedReturns(true)
+			fakeaccess.IsSystemReturns(true)
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+		})
+
+		It("cancels the window", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			Expect(fakeWorker.CancelMaintenanceArgsForCall(0)).To(Equal(1))
+		})
+
+		Context("when the worker has no such window", func() {
+			BeforeEach(func() {
+				fakeWorker.CancelMaintenanceReturns(false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
 })
@@ -0,0 +1,47 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+)
+
+func (s *Server) ListWorkerContainers(w http.ResponseWriter, r *http.Request) {
+	workerName := r.FormValue(":worker_name")
+	logger := s.logger.Session("list-worker-containers", lager.Data{"worker": workerName})
+
+	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-finding-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	containers, err := worker.Containers()
+	if err != nil {
+		logger.Error("failed-to-list-containers", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedContainers := make([]atc.WorkerContainer, len(containers))
+	for i, container := range containers {
+		presentedContainers[i] = present.WorkerContainer(worker.Name(), container)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(presentedContainers)
+	if err != nil {
+		logger.Error("failed-to-encode-containers", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -64,6 +64,22 @@ func (s *Server) HeartbeatWorker(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if len(registration.ContainerUsages) > 0 {
+		err = savedWorker.SaveContainerUsages(registration.ContainerUsages)
+		if err != nil {
+			// the usages are only informational; the worker is still alive
+			logger.Error("failed-to-save-container-usages", err)
+		}
+	}
+
+	if len(registration.VolumeUsages) > 0 {
+		err = savedWorker.SaveVolumeUsages(registration.VolumeUsages)
+		if err != nil {
+			// as above
+			logger.Error("failed-to-save-volume-usages", err)
+		}
+	}
+
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(present.Worker(savedWorker))
 	if err != nil {
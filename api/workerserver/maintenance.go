@@ -0,0 +1,136 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListWorkerMaintenanceWindows(w http.ResponseWriter, r *http.Request) {
+	workerName := r.FormValue(":worker_name")
+	logger := s.logger.Session("list-worker-maintenance-windows", lager.Data{"worker": workerName})
+
+	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-finding-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	windows, err := worker.MaintenanceWindows()
+	if err != nil {
+		logger.Error("failed-to-list-maintenance-windows", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(windows)
+	if err != nil {
+		logger.Error("failed-to-encode-maintenance-windows", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) ScheduleWorkerMaintenance(w http.ResponseWriter, r *http.Request) {
+	workerName := r.FormValue(":worker_name")
+	logger := s.logger.Session("schedule-worker-maintenance", lager.Data{"worker": workerName})
+
+	var window atc.WorkerMaintenanceWindow
+	err := json.NewDecoder(r.Body).Decode(&window)
+	if err != nil {
+		logger.Error("failed-to-decode-maintenance-window", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = window.Validate()
+	if err != nil {
+		logger.Error("invalid-maintenance-window", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-finding-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	scheduled, err := worker.ScheduleMaintenance(
+		time.Unix(window.StartsAt, 0),
+		time.Unix(window.EndsAt, 0),
+	)
+	if err != nil {
+		if err == db.ErrWorkerNotPresent {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		logger.Error("failed-to-schedule-maintenance", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(scheduled)
+	if err != nil {
+		logger.Error("failed-to-encode-maintenance-window", err)
+	}
+}
+
+func (s *Server) CancelWorkerMaintenance(w http.ResponseWriter, r *http.Request) {
+	workerName := r.FormValue(":worker_name")
+	logger := s.logger.Session("cancel-worker-maintenance", lager.Data{"worker": workerName})
+
+	windowID, err := strconv.Atoi(r.FormValue(":window_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-finding-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	found, err = worker.CancelMaintenance(windowID)
+	if err != nil {
+		logger.Error("failed-to-cancel-maintenance", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,47 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+)
+
+func (s *Server) ListWorkerVolumes(w http.ResponseWriter, r *http.Request) {
+	workerName := r.FormValue(":worker_name")
+	logger := s.logger.Session("list-worker-volumes", lager.Data{"worker": workerName})
+
+	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-finding-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	volumes, err := worker.Volumes()
+	if err != nil {
+		logger.Error("failed-to-list-volumes", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presentedVolumes := make([]atc.WorkerVolume, len(volumes))
+	for i, volume := range volumes {
+		presentedVolumes[i] = present.WorkerVolume(worker.Name(), volume)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(presentedVolumes)
+	if err != nil {
+		logger.Error("failed-to-encode-volumes", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,13 @@
+package artifactcache_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestArtifactCache(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Artifact Cache Suite")
+}
@@ -0,0 +1,152 @@
+package artifactcache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// Reaper evicts entries from a store.
+type Reaper interface {
+	Run() error
+}
+
+type dirReaper struct {
+	logger lager.Logger
+
+	dir     string
+	maxAge  time.Duration
+	maxSize int64
+}
+
+// NewDirReaper constructs a Reaper for a directory used by a dir store. It
+// evicts entries which have not been saved or restored within maxAge, then
+// the least recently used entries until the rest total at most maxSize bytes.
+// Zero disables either limit.
+//
+// Temporary files left behind by saves that never finished are removed once
+// they are older than maxAge, or than an hour if there is no maximum age.
+func NewDirReaper(logger lager.Logger, dir string, maxAge time.Duration, maxSize int64) Reaper {
+	return &dirReaper{
+		logger: logger,
+
+		dir:     dir,
+		maxAge:  maxAge,
+		maxSize: maxSize,
+	}
+}
+
+// abandonedSaveAge is how old a temporary file must be to be considered left
+// behind by a failed save when there is no maximum age.
+const abandonedSaveAge = time.Hour
+
+type dirEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func (reaper *dirReaper) Run() error {
+	logger := reaper.logger.Session("run")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	teamDirs, err := ioutil.ReadDir(reaper.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	now := time.Now()
+
+	abandonedAge := reaper.maxAge
+	if abandonedAge == 0 {
+		abandonedAge = abandonedSaveAge
+	}
+
+	entries := []dirEntry{}
+	for _, teamDir := range teamDirs {
+		if !teamDir.IsDir() {
+			continue
+		}
+
+		files, err := ioutil.ReadDir(filepath.Join(reaper.dir, teamDir.Name()))
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			if file.IsDir() {
+				continue
+			}
+
+			entry := dirEntry{
+				path:    filepath.Join(reaper.dir, teamDir.Name(), file.Name()),
+				size:    file.Size(),
+				modTime: file.ModTime(),
+			}
+
+			if strings.HasPrefix(file.Name(), ".save-") {
+				if now.Sub(entry.modTime) > abandonedAge {
+					reaper.remove(logger, entry, "abandoned")
+				}
+
+				continue
+			}
+
+			if reaper.maxAge != 0 && now.Sub(entry.modTime) > reaper.maxAge {
+				reaper.remove(logger, entry, "expired")
+				continue
+			}
+
+			entries = append(entries, entry)
+		}
+	}
+
+	if reaper.maxSize == 0 {
+		return nil
+	}
+
+	var totalSize int64
+	for _, entry := range entries {
+		totalSize += entry.size
+	}
+
+	// least recently used first
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+
+	for _, entry := range entries {
+		if totalSize <= reaper.maxSize {
+			break
+		}
+
+		if reaper.remove(logger, entry, "over-size") {
+			totalSize -= entry.size
+		}
+	}
+
+	return nil
+}
+
+func (reaper *dirReaper) remove(logger lager.Logger, entry dirEntry, reason string) bool {
+	err := os.Remove(entry.path)
+	if err != nil && !os.IsNotExist(err) {
+		logger.Error("failed-to-remove-entry", err, lager.Data{"path": entry.path})
+		return false
+	}
+
+	logger.Debug("removed-entry", lager.Data{"path": entry.path, "reason": reason})
+
+	return true
+}
@@ -0,0 +1,123 @@
+package artifactcache_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/artifactcache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DirReaper", func() {
+	var (
+		logger *lagertest.TestLogger
+		dir    string
+
+		maxAge  time.Duration
+		maxSize int64
+
+		runErr error
+	)
+
+	writeEntry := func(name string, size int, age time.Duration) string {
+		path := filepath.Join(dir, "1", name)
+		Expect(ioutil.WriteFile(path, make([]byte, size), 0644)).To(Succeed())
+
+		modTime := time.Now().Add(-age)
+		Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+
+		return path
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		var err error
+		dir, err = ioutil.TempDir("", "artifact-cache")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(os.Mkdir(filepath.Join(dir, "1"), 0755)).To(Succeed())
+
+		maxAge = 0
+		maxSize = 0
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		runErr = artifactcache.NewDirReaper(logger, dir, maxAge, maxSize).Run()
+	})
+
+	Context("with a maximum age", func() {
+		var fresh, stale string
+
+		BeforeEach(func() {
+			maxAge = time.Hour
+
+			fresh = writeEntry("fresh.tgz", 10, time.Minute)
+			stale = writeEntry("stale.tgz", 10, 2*time.Hour)
+		})
+
+		It("evicts the entries older than it", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fresh).To(BeAnExistingFile())
+			Expect(stale).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Context("with a maximum size", func() {
+		var oldest, older, newest string
+
+		BeforeEach(func() {
+			maxSize = 25
+
+			oldest = writeEntry("oldest.tgz", 10, 3*time.Minute)
+			older = writeEntry("older.tgz", 10, 2*time.Minute)
+			newest = writeEntry("newest.tgz", 10, time.Minute)
+		})
+
+		It("evicts the least recently used entries until the rest fit", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(oldest).ToNot(BeAnExistingFile())
+			Expect(older).To(BeAnExistingFile())
+			Expect(newest).To(BeAnExistingFile())
+		})
+	})
+
+	Context("with no limits", func() {
+		var entry, abandoned, saving string
+
+		BeforeEach(func() {
+			entry = writeEntry("entry.tgz", 10, 24*time.Hour)
+			abandoned = writeEntry(".save-abandoned", 10, 2*time.Hour)
+			saving = writeEntry(".save-in-progress", 10, time.Minute)
+		})
+
+		It("keeps every entry", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(entry).To(BeAnExistingFile())
+		})
+
+		It("removes saves that were abandoned, but not those in progress", func() {
+			Expect(abandoned).ToNot(BeAnExistingFile())
+			Expect(saving).To(BeAnExistingFile())
+		})
+	})
+
+	Context("when the directory does not exist yet", func() {
+		BeforeEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		It("does nothing", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,105 @@
+package artifactcache
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+type dirStore struct {
+	dir string
+}
+
+// NewDirStore constructs a Store which keeps each entry as a file in the
+// given directory. The directory may be a mounted blobstore in order to share
+// the cache between ATCs. Entries are kept until they are evicted by the
+// Reaper constructed by NewDirReaper.
+func NewDirStore(dir string) Store {
+	return &dirStore{
+		dir: dir,
+	}
+}
+
+func (store *dirStore) Save(logger lager.Logger, teamID int, key string, tgzStream io.Reader) error {
+	entryPath := store.entryPath(teamID, key)
+
+	err := os.MkdirAll(filepath.Dir(entryPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that concurrent restores never see a
+	// partially written entry
+	tmpFile, err := ioutil.TempFile(filepath.Dir(entryPath), ".save-")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmpFile, tgzStream)
+	if err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return err
+	}
+
+	err = tmpFile.Close()
+	if err != nil {
+		os.Remove(tmpFile.Name())
+		return err
+	}
+
+	err = os.Rename(tmpFile.Name(), entryPath)
+	if err != nil {
+		os.Remove(tmpFile.Name())
+		return err
+	}
+
+	logger.Debug("saved", lager.Data{"team-id": teamID, "key": key})
+
+	return nil
+}
+
+func (store *dirStore) Restore(logger lager.Logger, teamID int, key string) (io.ReadCloser, bool, error) {
+	entryPath := store.entryPath(teamID, key)
+
+	file, err := os.Open(entryPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	// mark the entry as used, so that it is evicted after those that are not
+	now := time.Now()
+	err = os.Chtimes(entryPath, now, now)
+	if err != nil {
+		logger.Error("failed-to-mark-entry-as-used", err, lager.Data{"team-id": teamID, "key": key})
+	}
+
+	return file, true, nil
+}
+
+func (store *dirStore) Delete(logger lager.Logger, teamID int, key string) error {
+	err := os.Remove(store.entryPath(teamID, key))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	logger.Debug("deleted", lager.Data{"team-id": teamID, "key": key})
+
+	return nil
+}
+
+func (store *dirStore) entryPath(teamID int, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(store.dir, strconv.Itoa(teamID), fmt.Sprintf("%x.tgz", sum))
+}
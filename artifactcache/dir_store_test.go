@@ -0,0 +1,123 @@
+package artifactcache_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/artifactcache"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DirStore", func() {
+	var (
+		logger *lagertest.TestLogger
+		dir    string
+		store  artifactcache.Store
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		var err error
+		dir, err = ioutil.TempDir("", "artifact-cache")
+		Expect(err).ToNot(HaveOccurred())
+
+		store = artifactcache.NewDirStore(dir)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Context("when nothing has been saved under the key", func() {
+		It("does not find an entry", func() {
+			_, found, err := store.Restore(logger, 1, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when an entry has been saved under the key", func() {
+		BeforeEach(func() {
+			Expect(store.Save(logger, 1, "some-key", bytes.NewBufferString("some-tgz"))).To(Succeed())
+		})
+
+		It("restores the saved stream", func() {
+			stream, found, err := store.Restore(logger, 1, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			defer stream.Close()
+
+			Expect(ioutil.ReadAll(stream)).To(Equal([]byte("some-tgz")))
+		})
+
+		It("does not leave temporary files behind", func() {
+			entries, err := ioutil.ReadDir(filepath.Join(dir, "1"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+		})
+
+		It("marks the entry as used when restoring it", func() {
+			entries, err := ioutil.ReadDir(filepath.Join(dir, "1"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+
+			entryPath := filepath.Join(dir, "1", entries[0].Name())
+			old := time.Now().Add(-time.Hour)
+			Expect(os.Chtimes(entryPath, old, old)).To(Succeed())
+
+			stream, found, err := store.Restore(logger, 1, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			stream.Close()
+
+			info, err := os.Stat(entryPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.ModTime()).To(BeTemporally(">", old.Add(time.Minute)))
+		})
+
+		It("does not restore the entry for other teams", func() {
+			_, found, err := store.Restore(logger, 2, "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the entry is deleted", func() {
+			BeforeEach(func() {
+				Expect(store.Delete(logger, 1, "some-key")).To(Succeed())
+			})
+
+			It("no longer finds it", func() {
+				_, found, err := store.Restore(logger, 1, "some-key")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("can be deleted again", func() {
+				Expect(store.Delete(logger, 1, "some-key")).To(Succeed())
+			})
+		})
+
+		Context("when a new entry is saved under the same key", func() {
+			BeforeEach(func() {
+				Expect(store.Save(logger, 1, "some-key", bytes.NewBufferString("some-other-tgz"))).To(Succeed())
+			})
+
+			It("replaces the entry", func() {
+				stream, found, err := store.Restore(logger, 1, "some-key")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				defer stream.Close()
+
+				Expect(ioutil.ReadAll(stream)).To(Equal([]byte("some-other-tgz")))
+			})
+		})
+	})
+})
@@ -0,0 +1,25 @@
+package artifactcache
+
+import (
+	"io"
+
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter . Store
+
+// Store persists artifacts saved by `save_cache` steps so that they can be
+// restored by `restore_cache` steps in later builds. Entries are scoped to a
+// team, so that teams cannot read each other's caches by guessing keys.
+type Store interface {
+	// Save persists the given tgz stream under the key, replacing any previous
+	// entry.
+	Save(logger lager.Logger, teamID int, key string, tgzStream io.Reader) error
+
+	// Restore opens the tgz stream saved under the key. The caller must close
+	// the stream.
+	Restore(logger lager.Logger, teamID int, key string) (io.ReadCloser, bool, error)
+
+	// Delete removes the entry saved under the key, if there is one.
+	Delete(logger lager.Logger, teamID int, key string) error
+}
@@ -0,0 +1,13 @@
+package artifactscan_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestArtifactScan(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Artifact Scan Suite")
+}
@@ -0,0 +1,81 @@
+package artifactscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+)
+
+// UnexpectedResponseError is returned when the scanner responds with anything
+// other than 200 OK.
+type UnexpectedResponseError struct {
+	StatusCode int
+}
+
+func (err UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("scanner responded with unexpected status: %d", err.StatusCode)
+}
+
+type httpScanner struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPScanner constructs a Scanner which POSTs each artifact's tgz stream
+// to the given URL, with the artifact's name as the "name" query parameter.
+// The scanner is expected to respond with a JSON Result. ICAP scanners can be
+// used through an HTTP gateway.
+func NewHTTPScanner(scannerURL string, client *http.Client) Scanner {
+	return &httpScanner{
+		url:    scannerURL,
+		client: client,
+	}
+}
+
+func (scanner *httpScanner) Scan(ctx context.Context, name string, tgzStream io.Reader) (Result, error) {
+	logger := lagerctx.FromContext(ctx).Session("scan", lager.Data{"artifact": name})
+
+	reqURL, err := url.Parse(scanner.url)
+	if err != nil {
+		return Result{}, err
+	}
+
+	query := reqURL.Query()
+	query.Set("name", name)
+	reqURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequest("POST", reqURL.String(), tgzStream)
+	if err != nil {
+		return Result{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/gzip")
+
+	resp, err := scanner.client.Do(req.WithContext(ctx))
+	if err != nil {
+		logger.Error("failed-to-reach-scanner", err)
+		return Result{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		logger.Info("unexpected-response", lager.Data{"status": resp.StatusCode})
+		return Result{}, UnexpectedResponseError{StatusCode: resp.StatusCode}
+	}
+
+	var result Result
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		logger.Error("failed-to-decode-result", err)
+		return Result{}, err
+	}
+
+	return result, nil
+}
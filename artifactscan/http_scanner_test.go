@@ -0,0 +1,101 @@
+package artifactscan_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/concourse/atc/artifactscan"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("HTTPScanner", func() {
+	var (
+		server  *ghttp.Server
+		scanner artifactscan.Scanner
+
+		result  artifactscan.Result
+		scanErr error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		scanner = artifactscan.NewHTTPScanner(server.URL()+"/scan", http.DefaultClient)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		result, scanErr = scanner.Scan(context.Background(), "some-output", bytes.NewBufferString("some-tgz"))
+	})
+
+	Context("when the scanner finds nothing", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/scan", "name=some-output"),
+					ghttp.VerifyContentType("application/gzip"),
+					ghttp.VerifyBody([]byte("some-tgz")),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"findings": []string{},
+					}),
+				),
+			)
+		})
+
+		It("posts the artifact to the scanner", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns a clean result", func() {
+			Expect(scanErr).ToNot(HaveOccurred())
+			Expect(result.Clean()).To(BeTrue())
+		})
+	})
+
+	Context("when the scanner finds something", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+					"findings": []string{"some-virus"},
+				}),
+			)
+		})
+
+		It("returns the findings", func() {
+			Expect(scanErr).ToNot(HaveOccurred())
+			Expect(result.Clean()).To(BeFalse())
+			Expect(result.Findings).To(Equal([]string{"some-virus"}))
+		})
+	})
+
+	Context("when the scanner responds with an unexpected status", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, ""),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(scanErr).To(Equal(artifactscan.UnexpectedResponseError{
+				StatusCode: http.StatusServiceUnavailable,
+			}))
+		})
+	})
+
+	Context("when the scanner responds with invalid JSON", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, "{"),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(scanErr).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package artifactscan
+
+import (
+	"context"
+	"io"
+)
+
+//go:generate counterfeiter . Scanner
+
+// Scanner inspects artifacts produced by builds, e.g. for viruses or leaked
+// secrets, so that they can be vetted before being published.
+type Scanner interface {
+	// Scan reads the tgz stream of the named artifact and returns anything
+	// found in it.
+	Scan(ctx context.Context, name string, tgzStream io.Reader) (Result, error)
+}
+
+// Result lists what the scanner found in an artifact, e.g. the names of
+// detected viruses or the paths of files containing secrets.
+type Result struct {
+	Findings []string `json:"findings"`
+}
+
+// Clean is true if nothing was found in the artifact.
+func (result Result) Clean() bool {
+	return len(result.Findings) == 0
+}
+
+// Policy determines what happens to a build when one of its artifacts is not
+// clean, or cannot be scanned.
+type Policy string
+
+const (
+	// PolicyFail errors the step that produced the artifact.
+	PolicyFail Policy = "fail"
+
+	// PolicyAnnotate prints the findings to the step's output and carries on.
+	PolicyAnnotate Policy = "annotate"
+)
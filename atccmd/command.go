@@ -8,38 +8,50 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	_ "net/http/pprof"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/admission"
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/auth"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/api/containerserver"
+	"github.com/concourse/atc/artifactcache"
+	"github.com/concourse/atc/artifactscan"
 	"github.com/concourse/atc/builds"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/creds/noop"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/bus"
+	"github.com/concourse/atc/db/compression"
 	"github.com/concourse/atc/db/encryption"
+	"github.com/concourse/atc/db/eventarchive"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/migration"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/gc"
+	"github.com/concourse/atc/imageresolver"
+	"github.com/concourse/atc/lifecycle"
 	"github.com/concourse/atc/lockrunner"
 	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/pipelines"
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/image"
+	"github.com/concourse/atc/worker/transport"
 	"github.com/concourse/atc/wrappa"
 	"github.com/concourse/flag"
 	"github.com/concourse/retryhttp"
@@ -53,6 +65,7 @@ import (
 	"github.com/tedsuo/ifrit/http_server"
 	"github.com/tedsuo/ifrit/sigmon"
 	"github.com/xoebus/zest"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/concourse/skymarshal/provider"
 
@@ -69,6 +82,9 @@ import (
 	// dynamically registered metric emitters
 	_ "github.com/concourse/atc/metric/emitter"
 
+	// dynamically registered lifecycle event sinks
+	_ "github.com/concourse/atc/lifecycle/sink"
+
 	// dynamically registered credential managers
 	_ "github.com/concourse/atc/creds/credhub"
 	_ "github.com/concourse/atc/creds/kubernetes"
@@ -79,6 +95,10 @@ import (
 var defaultDriverName = "postgres"
 var retryingDriverName = "too-many-connections-retrying"
 
+// imageResolveTimeout bounds each request made to a registry when pinning
+// resource types to the digests of their images.
+const imageResolveTimeout = 10 * time.Second
+
 type ATCCommand struct {
 	Migration Migration `group:"Migration Options"`
 
@@ -103,12 +123,25 @@ type ATCCommand struct {
 
 	Postgres flag.PostgresConfig `group:"PostgreSQL Configuration" namespace:"postgres"`
 
+	PostgresReplica struct {
+		DataSource string `long:"data-source" description:"Connection string of a read-only replica of the database, e.g. a hot standby, to send reads which tolerate a few seconds of staleness to, such as listing builds and versions and loading dashboards. Reads are sent to the primary while the replica lags further behind."`
+	} `group:"PostgreSQL Read Replica" namespace:"postgres-replica"`
+
+	ConnectionPools struct {
+		API       int `long:"api" default:"32" description:"Maximum number of connections the API may open to the database."`
+		Scheduler int `long:"scheduler" default:"16" description:"Maximum number of connections scheduling and tracking builds may open to the database."`
+		Radar     int `long:"radar" default:"16" description:"Maximum number of connections checking resources may open to the database."`
+		GC        int `long:"gc" default:"8" description:"Maximum number of connections garbage collection may open to the database, so that slow collection cannot starve the API or the scheduler."`
+	} `group:"Database Connection Pools" namespace:"max-conns"`
+
 	CredentialManagement struct{} `group:"Credential Management"`
 	CredentialManagers   creds.Managers
 
 	EncryptionKey    flag.Cipher `long:"encryption-key"     description:"A 16 or 32 length key used to encrypt sensitive information before storing it in the database."`
 	OldEncryptionKey flag.Cipher `long:"old-encryption-key" description:"Encryption key previously used for encrypting sensitive information. If provided without a new key, data is encrypted. If provided with a new key, data is re-encrypted."`
 
+	EventCompression string `long:"event-compression" choice:"gzip" choice:"zstd" description:"Codec with which to compress build events before storing them in the database. Events already stored are read back however they were stored."`
+
 	DebugBindIP   flag.IP `long:"debug-bind-ip"   default:"127.0.0.1" description:"IP address on which to listen for the pprof debugger endpoints."`
 	DebugBindPort uint16  `long:"debug-bind-port" default:"8079"      description:"Port on which to listen for the pprof debugger endpoints."`
 
@@ -116,13 +149,37 @@ type ATCCommand struct {
 
 	InterceptIdleTimeout              time.Duration `long:"intercept-idle-timeout" default:"0m" description:"Length of time for a intercepted session to be idle before terminating."`
 	ResourceCheckingInterval          time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
-	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" description:"Method by which a worker is selected during container placement."`
+	CheckContainerTTL                 time.Duration `long:"check-container-ttl" default:"0" description:"How long to keep each check container around for, to be reused by the checks run within it rather than creating a container for each. Must not be shorter than the resource checking interval. 0 means between 5m and 1h, depending on how long the workers have been up."`
+	PinResourceTypeDigests            bool          `long:"pin-resource-type-digests" description:"Resolve the tags of resource type images to their digests in their registries as pipelines are set, and use those exact images until the pipeline is next set. Resource types which cannot be resolved, e.g. because they use credentials, are left unpinned with a warning."`
+	GlobalResources                   bool          `long:"enable-global-resources" description:"Check resources with the same type and source across pipelines and teams only once between them, sharing the versions found with each of them."`
+	MaxConcurrentChecks               int           `long:"max-concurrent-checks" default:"0" description:"Maximum number of interval checks to run at once across all pipelines. Checks of resources blocking pending builds are run first. 0 means no limit."`
+	MaxChecksPerSecond                float64       `long:"max-checks-per-second" default:"0" description:"Maximum number of interval checks to start each second across all pipelines, spacing them out evenly. 0 means no limit."`
+	ResourceCheckingJitter            float64       `long:"resource-checking-jitter" default:"0" description:"Fraction of each resource's checking interval by which its checks are randomly delayed, so that resources with the same interval don't all check at once. 0 means no jitter."`
+	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" description:"Method by which a worker is selected during container placement."`
+	MaxActiveTasksPerWorker           int           `long:"max-active-tasks-per-worker" default:"0" description:"Maximum number of tasks running at once on each worker. Tasks wait for a worker with a free slot once every worker is at the limit. 0 means no limit."`
+	MigrateChecksFromPressuredWorkers bool          `long:"migrate-checks-from-pressured-workers" description:"Move check containers off of workers reporting memory or disk pressure, rather than only avoiding placing new containers on them."`
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
+	GlobalResourceCaches              bool          `long:"global-resource-caches" description:"Share the caches of fetched resources, including image resources, across teams on each worker. Teams with identical resource configuration then reuse each other's fetches. Leave unset to keep each team's caches isolated."`
+
+	WarmResourceTypes         flag.File     `long:"warm-resource-types" description:"YAML file listing resource types, as in a pipeline, whose images are fetched onto the workers ahead of time and kept warm. Any team fetching the same version of one of the images, e.g. for a resource type declared in its pipeline, uses the warm copy instead of pulling it again."`
+	WarmResourceTypesInterval time.Duration `long:"warm-resource-types-interval" default:"1h" description:"Interval on which to check for newer versions of the warm resource type images, fetching them onto the workers."`
 
 	CLIArtifactsDir flag.Dir `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	ArtifactCacheDir     flag.Dir      `long:"artifact-cache-dir" description:"Directory in which to persist artifacts saved by save_cache steps, e.g. a mounted blobstore. If omitted, save_cache and restore_cache steps are skipped."`
+	ArtifactCacheMaxAge  time.Duration `long:"artifact-cache-max-age" default:"168h" description:"Length of time after which artifacts that have not been saved or restored are evicted from the artifact cache. Zero means no limit."`
+	ArtifactCacheMaxSize int64         `long:"artifact-cache-max-size" default:"0" description:"Maximum total size in bytes of the artifact cache. The least recently used artifacts are evicted beyond it. Zero means no limit."`
+
+	TaskCheckpointDir    flag.Dir      `long:"task-checkpoint-dir" description:"Directory in which to persist the checkpoints of running tasks. Must be shared by every ATC, e.g. a mounted blobstore, so that whichever ATC resumes a build can restore them. If omitted, tasks whose container is lost start over."`
+	TaskCheckpointMaxAge time.Duration `long:"task-checkpoint-max-age" default:"24h" description:"Length of time after which checkpoints left behind, e.g. by tasks whose build was lost, are evicted. Must be longer than any task runs between checkpoints. Zero means no limit."`
+
 	Developer struct {
 		Noop bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
+
+		NoopEngine      bool     `long:"noop-engine"      description:"Succeed all new builds immediately without running their steps. Useful for load testing the scheduler."`
+		NoopEngineTeams []string `long:"noop-engine-team" description:"Succeed new builds of the given team immediately without running their steps. Can be specified multiple times." value-name:"TEAM"`
+
+		PipelineEngines map[string]string `long:"pipeline-engine" description:"Run new builds of the given pipeline with the named engine, e.g. an experimental one. Builds already running are unaffected. Can be specified multiple times." value-name:"TEAM/PIPELINE:ENGINE"`
 	} `group:"Developer Options"`
 
 	Worker struct {
@@ -137,8 +194,13 @@ type ATCCommand struct {
 
 		YellerAPIKey      string `long:"yeller-api-key"     description:"Yeller API key. If specified, all errors logged will be emitted."`
 		YellerEnvironment string `long:"yeller-environment" description:"Environment to tag on all Yeller events emitted."`
+
+		APILatencyBudget       time.Duration            `long:"api-latency-budget"       description:"Length of time after which API requests are logged as slow and their response time is reported as a warning. Zero disables slow request logging."`
+		APIRouteLatencyBudgets map[string]time.Duration `long:"api-route-latency-budget" description:"A latency budget for one API route, overriding --api-latency-budget. Can be specified multiple times." value-name:"ROUTE:DURATION"`
 	} `group:"Metrics & Diagnostics"`
 
+	LifecycleEvents struct{} `group:"Lifecycle Events"`
+
 	Server struct {
 		XFrameOptions string `long:"x-frame-options" description:"The value to set for X-Frame-Options. If omitted, the header is not set."`
 	} `group:"Web Server"`
@@ -148,21 +210,106 @@ type ATCCommand struct {
 	GC struct {
 		Interval          time.Duration `long:"interval" default:"30s" description:"Interval on which to perform garbage collection."`
 		WorkerConcurrency int           `long:"worker-concurrency" default:"50" description:"Maximum number of delete operations to have in flight per worker."`
+
+		MaxVolumeAge     time.Duration    `long:"max-volume-age" description:"Reclaim cache volumes not in use once they have gone unused for longer than this. Zero means no limit."`
+		MaxDiskUsage     int              `long:"max-disk-usage" description:"Reclaim the oldest cache volumes not in use by any container on workers reporting a higher percentage of their disk in use. Zero means no limit."`
+		TeamVolumeQuotas map[string]int   `long:"team-volume-quota" description:"Maximum number of cache volumes not in use by any container to keep for a team, reclaiming the oldest beyond it. Can be specified multiple times." value-name:"TEAM:COUNT"`
+		TeamCacheQuotas  map[string]int64 `long:"team-cache-quota" description:"Maximum disk space in bytes to let the resource caches and task caches of a team use across all workers, reclaiming its oldest cache volumes not in use by any container beyond it. Sizes are as reported by workers with their heartbeats; volumes of unknown size are not reclaimed for it. Can be specified multiple times." value-name:"TEAM:BYTES"`
+
+		EphemeralWorkerGracePeriod time.Duration `long:"ephemeral-worker-grace-period" default:"2m" description:"Length of time an ephemeral worker may stay stalled, having missed its heartbeats, before it is deleted along with its containers and volumes."`
+
+		ArchivedPipelineRetention time.Duration `long:"archived-pipeline-retention" default:"168h" description:"Length of time a destroyed pipeline is kept archived, so that it can be restored, before it is permanently deleted along with its builds. Zero keeps archived pipelines forever."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
 
+	BuildHeartbeatInterval time.Duration `long:"build-heartbeat-interval" default:"10s" description:"Interval on which to record a heartbeat for each build being run."`
+	BuildHeartbeatTimeout  time.Duration `long:"build-heartbeat-timeout" default:"1m" description:"Length of time after a build's last heartbeat before it is marked as orphaned, presuming its ATC dead, and is adopted by another ATC."`
+	BuildLease             string        `long:"build-lease" default:"heartbeat" choice:"heartbeat" choice:"advisory-lock" description:"How an ATC holds on to the builds it runs. With 'advisory-lock', no heartbeats are recorded; a build is orphaned once the Postgres advisory lock its ATC tracks it with is lost, after the heartbeat timeout has passed since it started."`
+
+	DrainTimeout time.Duration `long:"drain-timeout" default:"0s" description:"Length of time to let running build steps finish when shutting down, without starting any more, before leaving the builds to be resumed by another ATC. Zero disables draining."`
+
+	AbortCleanupBudget time.Duration `long:"abort-cleanup-budget" default:"0s" description:"Length of time each step of an aborted build is given to run its hooks and stop its containers before being forcibly terminated. Zero means no limit."`
+
+	BuildRetryBudget int `long:"build-retry-budget" default:"0" description:"Maximum number of times the steps of a build may be retried in total, however deeply their retries are nested. Once spent, retried steps fail with their last attempt. Zero means no limit."`
+
+	AdmissionControllerURL     flag.URL      `long:"admission-controller-url" description:"URL to POST the plan of each new build to for admission, e.g. that of a policy agent. The controller may reject the build or respond with a modified plan. If omitted, all builds are admitted."`
+	AdmissionControllerTimeout time.Duration `long:"admission-controller-timeout" default:"10s" description:"Timeout of each request to the admission controller. Builds are not created when it times out."`
+
+	AllowedResourceTypes []string `long:"allowed-resource-type" description:"Resource type that steps may use. Custom resource types are allowed if the type providing them is. If omitted, every resource type is allowed. Can be specified multiple times." value-name:"TYPE"`
+
+	MaxRunningBuilds int `long:"max-running-builds" default:"0" description:"Maximum number of builds this ATC runs at once. Any more are queued until a running build finishes, e.g. when resuming every build after a restart. Zero means no limit."`
+
+	ArtifactScanner struct {
+		URL    flag.URL `long:"url" description:"URL to POST each task output to for scanning, e.g. for viruses or secrets. The scanner must respond with a JSON object listing its findings. If omitted, outputs are not scanned."`
+		Policy string   `long:"policy" default:"fail" choice:"fail" choice:"annotate" description:"Whether to fail the task or only print the findings when an output is not clean or cannot be scanned."`
+	} `group:"Artifact Scanning" namespace:"artifact-scanner"`
+
+	Tracing struct {
+		ZipkinURL   flag.URL      `long:"zipkin-url" description:"URL to report the spans of each build's steps to, in Zipkin's v2 JSON format, e.g. http://zipkin:9411/api/v2/spans. Jaeger and OpenTelemetry collectors accept this format too. If omitted, builds are not traced."`
+		ServiceName string        `long:"service-name" default:"concourse" description:"Service name to report spans under."`
+		Interval    time.Duration `long:"interval" default:"5s" description:"Interval on which finished spans are reported."`
+		BufferSize  int           `long:"buffer-size" default:"10000" description:"Maximum number of finished spans to hold between reports. Any more are dropped."`
+	} `group:"Tracing" namespace:"tracing"`
+
+	NoisyNeighbors struct {
+		Window    int     `long:"window" default:"10" description:"Number of previous runs of a task to compare each run against. Zero disables detection."`
+		Threshold float64 `long:"threshold" default:"1.5" description:"Factor by which both a task's duration and the number of containers on its worker must exceed their usual values for the task to be flagged."`
+	} `group:"Noisy Neighbor Detection" namespace:"noisy-neighbors"`
+
+	JobLimits struct {
+		MaxStepTimeout       time.Duration `long:"max-step-timeout" description:"Maximum timeout a pipeline may configure for a step. Longer timeouts are lowered to this. Zero means no limit."`
+		MaxBuildLogsToRetain int           `long:"max-build-logs-to-retain" description:"Maximum number of builds a job may retain logs for, including jobs configured to retain every build. Zero means no limit."`
+	} `group:"Job Limits" namespace:"job-limits"`
+
+	DefaultTimeouts struct {
+		Get   time.Duration `long:"get" default:"1h" description:"Timeout of get steps which do not configure one, including those run by enclosing steps without a timeout. Zero means no timeout."`
+		Put   time.Duration `long:"put" default:"1h" description:"Timeout of put steps which do not configure one, including those run by enclosing steps without a timeout. Zero means no timeout."`
+		Check time.Duration `long:"check" default:"1h" description:"Timeout of each check of a resource or resource type. Zero means no timeout."`
+	} `group:"Default Timeouts" namespace:"default-timeout"`
+
+	VolumeStreaming struct {
+		Encodings      []string `long:"encoding" choice:"gzip" description:"Content encoding to compress artifacts streamed between workers with, once the worker is known to support it. If omitted, artifacts are streamed as-is."`
+		BandwidthLimit int64    `long:"bandwidth-limit" default:"0" description:"Maximum number of bytes per second each artifact is streamed to or from a worker at. Zero means no limit."`
+
+		ChunkSize     int64         `long:"chunk-size" default:"0" description:"Maximum number of bytes of an artifact to request from a worker at a time, if the worker serves byte ranges. Zero means the whole artifact is requested at once."`
+		Retries       int           `long:"retries" default:"3" description:"Number of times to resume an artifact stream out of a worker which breaks before failing the step. Streams are resumed from the last byte received if the worker serves byte ranges of the same stream, and streams directly between workers are restarted."`
+		RetryInterval time.Duration `long:"retry-interval" default:"1s" description:"Length of time to wait before resuming a broken artifact stream."`
+
+		P2PSigningKey flag.PrivateKey `long:"p2p-signing-key" description:"File containing an RSA private key to sign the tokens authorizing workers to stream inputs directly to each other, whose public key each worker must trust. Workers must be able to reach each other's Baggageclaim URL. If omitted, inputs are streamed through the ATC."`
+		P2PTokenTTL   time.Duration   `long:"p2p-token-ttl" default:"1h" description:"Length of time a worker may use a token to stream an input to another worker for."`
+	} `group:"Volume Streaming" namespace:"volume-streaming"`
+
+	ResourceCacheMirror struct {
+		Interval  time.Duration `long:"interval" default:"0s" description:"Interval on which to copy the most frequently fetched resource caches onto workers running no builds, so that steps fetching them are not all placed on the workers holding them. Zero disables mirroring."`
+		HotCaches int           `long:"hot-caches" default:"5" description:"Number of the most frequently fetched resource caches on each worker to mirror."`
+		Replicas  int           `long:"replicas" default:"2" description:"Number of workers to hold each mirrored resource cache on."`
+	} `group:"Resource Cache Mirroring" namespace:"resource-cache-mirror"`
+
+	EventArchive struct {
+		eventarchive.Config
+
+		After     time.Duration `long:"after" default:"24h" description:"Length of time after a build completes before its events are offloaded."`
+		Interval  time.Duration `long:"interval" default:"1m" description:"Interval on which to offload the events of completed builds."`
+		BatchSize int           `long:"batch-size" default:"100" description:"Maximum number of builds to offload the events of per interval."`
+	} `group:"Build Event Archive" namespace:"event-archive"`
+
+	NotificationsBus bus.Config `group:"Notifications Bus" namespace:"notifications-bus"`
+
+	ReadOnly bool `long:"read-only" description:"Serve API reads and event streams without scheduling, checking resources, tracking builds, or collecting garbage, and reject every request that would modify state. For running a warm standby against a replicated database."`
+
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
 }
 
 type Migration struct {
-	CurrentDBVersion   bool `long:"current-db-version" description:"Print the current database version and exit"`
-	SupportedDBVersion bool `long:"supported-db-version" description:"Print the max supported database version and exit"`
-	MigrateDBToVersion int  `long:"migrate-db-to-version" description:"Migrate to the specified database version and exit"`
+	CurrentDBVersion   bool   `long:"current-db-version" description:"Print the current database version and exit"`
+	SupportedDBVersion bool   `long:"supported-db-version" description:"Print the max supported database version and exit"`
+	MigrateDBToVersion int    `long:"migrate-db-to-version" description:"Migrate to the specified database version and exit"`
+	RekeyTeam          string `long:"rekey-team" value-name:"TEAM" description:"Generate a new data key for the team, re-encrypt the configs of its pipelines with it, and exit. Requires an encryption key."`
 }
 
 func (m *Migration) CommandProvided() bool {
-	return m.CurrentDBVersion || m.SupportedDBVersion || m.MigrateDBToVersion > 0
+	return m.CurrentDBVersion || m.SupportedDBVersion || m.MigrateDBToVersion > 0 || m.RekeyTeam != ""
 }
 
 func (cmd *ATCCommand) RunMigrationCommand() error {
@@ -175,6 +322,9 @@ func (cmd *ATCCommand) RunMigrationCommand() error {
 	if cmd.Migration.MigrateDBToVersion > 0 {
 		return cmd.migrateDBToVersion()
 	}
+	if cmd.Migration.RekeyTeam != "" {
+		return cmd.rekeyTeam()
+	}
 	return nil
 }
 
@@ -249,9 +399,56 @@ func (cmd *ATCCommand) migrateDBToVersion() error {
 	return nil
 }
 
+func (cmd *ATCCommand) rekeyTeam() error {
+	if cmd.EncryptionKey.AEAD == nil {
+		return db.ErrTeamKeysRequireEncryption
+	}
+
+	newKey := encryption.NewKey(cmd.EncryptionKey.AEAD)
+
+	var oldKey *encryption.Key
+	if cmd.OldEncryptionKey.AEAD != nil {
+		oldKey = encryption.NewKey(cmd.OldEncryptionKey.AEAD)
+	}
+
+	logger, _ := cmd.constructLogger()
+
+	lockConn, err := cmd.constructLockConn(defaultDriverName)
+	if err != nil {
+		return err
+	}
+	defer lockConn.Close()
+
+	lockFactory := lock.NewLockFactory(lockConn)
+
+	dbConn, err := db.Open(logger.Session("db"), defaultDriverName, cmd.Postgres.ConnectionString(), newKey, oldKey, "rekey", lockFactory)
+	if err != nil {
+		return fmt.Errorf("failed to migrate database: %s", err)
+	}
+	defer dbConn.Close()
+
+	team, found, err := db.NewTeamFactory(dbConn, lockFactory).FindTeam(cmd.Migration.RekeyTeam)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("team not found: %s", cmd.Migration.RekeyTeam)
+	}
+
+	err = team.Rekey()
+	if err != nil {
+		return fmt.Errorf("Could not rekey team: %s Reason: %s", cmd.Migration.RekeyTeam, err.Error())
+	}
+
+	fmt.Println("Successfully rekeyed team:", cmd.Migration.RekeyTeam)
+	return nil
+}
+
 func (cmd *ATCCommand) WireDynamicFlags(commandFlags *flags.Command) {
 	var authGroup *flags.Group
 	var metricsGroup *flags.Group
+	var lifecycleGroup *flags.Group
 	var credsGroup *flags.Group
 
 	groups := commandFlags.Groups()
@@ -270,7 +467,11 @@ func (cmd *ATCCommand) WireDynamicFlags(commandFlags *flags.Command) {
 			metricsGroup = group
 		}
 
-		if metricsGroup != nil && authGroup != nil && credsGroup != nil {
+		if lifecycleGroup == nil && group.ShortDescription == "Lifecycle Events" {
+			lifecycleGroup = group
+		}
+
+		if metricsGroup != nil && lifecycleGroup != nil && authGroup != nil && credsGroup != nil {
 			break
 		}
 
@@ -289,6 +490,10 @@ func (cmd *ATCCommand) WireDynamicFlags(commandFlags *flags.Command) {
 		panic("could not find Credential Management group for registering managers")
 	}
 
+	if lifecycleGroup == nil {
+		panic("could not find Lifecycle Events group for registering sinks")
+	}
+
 	authConfigs := make(provider.AuthConfigs)
 	for name, p := range provider.GetProviders() {
 		authConfigs[name] = p.AddAuthGroup(authGroup)
@@ -303,6 +508,8 @@ func (cmd *ATCCommand) WireDynamicFlags(commandFlags *flags.Command) {
 
 	metric.WireEmitters(metricsGroup)
 
+	lifecycle.WireSinks(lifecycleGroup)
+
 }
 
 func (cmd *ATCCommand) Execute(args []string) error {
@@ -326,6 +533,7 @@ func (cmd *ATCCommand) constructMembers(
 	connectionName string,
 	logger lager.Logger,
 	reconfigurableSink *lager.ReconfigurableSink,
+	buildLimiter *engine.BuildLimiter,
 ) ([]grouper.Member, error) {
 	if len(positionalArguments) != 0 {
 		return nil, fmt.Errorf("unexpected positional arguments: %v", positionalArguments)
@@ -346,6 +554,7 @@ func (cmd *ATCCommand) constructMembers(
 	}
 
 	var variablesFactory creds.VariablesFactory = noop.NewNoopFactory()
+	var credentialManager string
 	for name, manager := range cmd.CredentialManagers {
 		if !manager.IsConfigured() {
 			continue
@@ -363,6 +572,8 @@ func (cmd *ATCCommand) constructMembers(
 			return nil, err
 		}
 
+		credentialManager = name
+
 		break
 	}
 
@@ -391,6 +602,8 @@ func (cmd *ATCCommand) constructMembers(
 	bus := dbConn.Bus()
 	teamFactory := db.NewTeamFactory(dbConn, lockFactory)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory)
+	dbAuditLog := db.NewAuditLog(dbConn)
+	dbCacheUsageLedger := db.NewCacheUsageLedger(dbConn)
 	dbVolumeFactory := db.NewVolumeFactory(dbConn)
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
@@ -404,6 +617,7 @@ func (cmd *ATCCommand) constructMembers(
 	dbResourceConfigCheckSessionFactory := db.NewResourceConfigCheckSessionFactory(dbConn, lockFactory)
 	dbWorkerBaseResourceTypeFactory := db.NewWorkerBaseResourceTypeFactory(dbConn)
 	dbWorkerTaskCacheFactory := db.NewWorkerTaskCacheFactory(dbConn)
+	dbWorkerWarmResourceTypeFactory := db.NewWorkerWarmResourceTypeFactory(dbConn)
 	resourceFetcherFactory := resource.NewFetcherFactory(lockFactory, clock.NewClock(), dbResourceCacheFactory)
 
 	imageResourceFetcherFactory := image.NewImageResourceFetcherFactory(
@@ -413,6 +627,23 @@ func (cmd *ATCCommand) constructMembers(
 		clock.NewClock(),
 	)
 
+	var p2pStreamer worker.P2PStreamer
+	if cmd.VolumeStreaming.P2PSigningKey.PrivateKey != nil {
+		p2pStreamer = worker.NewP2PStreamer(
+			dbWorkerFactory,
+			&http.Client{
+				Transport: &http.Transport{
+					DisableKeepAlives: true,
+				},
+			},
+			cmd.VolumeStreaming.P2PSigningKey.PrivateKey,
+			cmd.VolumeStreaming.P2PTokenTTL,
+			cmd.VolumeStreaming.Retries,
+			cmd.VolumeStreaming.RetryInterval,
+			clock.NewClock(),
+		)
+	}
+
 	workerProvider := worker.NewDBWorkerProvider(
 		lockFactory,
 		retryhttp.NewExponentialBackOffFactory(5*time.Minute),
@@ -426,28 +657,86 @@ func (cmd *ATCCommand) constructMembers(
 		dbWorkerFactory,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
+		transport.StreamConfig{
+			Encodings:      cmd.VolumeStreaming.Encodings,
+			BandwidthLimit: cmd.VolumeStreaming.BandwidthLimit,
+			ChunkSize:      cmd.VolumeStreaming.ChunkSize,
+			Retries:        cmd.VolumeStreaming.Retries,
+			RetryInterval:  cmd.VolumeStreaming.RetryInterval,
+		},
+		p2pStreamer,
+		cmd.GlobalResourceCaches,
 	)
 
-	workerClient := cmd.constructWorkerPool(
+	workerClient, err := cmd.constructWorkerPool(
 		logger,
 		workerProvider,
 	)
+	if err != nil {
+		return nil, err
+	}
 
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	resourceFactory := resource.NewResourceFactory(workerClient)
-	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, variablesFactory)
+	var orphanReaper engine.OrphanReaper
+	if cmd.BuildLease == "advisory-lock" {
+		orphanReaper = engine.NewLockOrphanReaper(
+			logger.Session("orphan-reaper"),
+			dbBuildFactory,
+			cmd.BuildHeartbeatTimeout,
+		)
+	} else {
+		orphanReaper = engine.NewOrphanReaper(
+			logger.Session("orphan-reaper"),
+			dbBuildFactory,
+			cmd.BuildHeartbeatTimeout,
+		)
+	}
+
+	var tracer exec.Tracer = exec.NoopTracer{}
+	var zipkinTracer *tracing.ZipkinTracer
+	if cmd.Tracing.ZipkinURL.URL != nil {
+		zipkinTracer = tracing.NewZipkinTracer(
+			logger.Session("tracer"),
+			cmd.Tracing.ZipkinURL.String(),
+			cmd.Tracing.ServiceName,
+			&http.Client{Timeout: 10 * time.Second},
+			cmd.Tracing.Interval,
+			cmd.Tracing.BufferSize,
+		)
+
+		tracer = zipkinTracer
+	}
+
+	engine, err := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, variablesFactory, dbWorkerFactory, credentialManager, buildLimiter, tracer)
+	if err != nil {
+		return nil, err
+	}
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		resourceFactory,
 		dbResourceConfigCheckSessionFactory,
 		cmd.ResourceCheckingInterval,
+		cmd.DefaultTimeouts.Check,
+		radar.CheckContainerExpiries(cmd.CheckContainerTTL),
+		cmd.GlobalResources,
 		engine,
+		cmd.jobLimits(),
+		radar.RateLimitChecks(
+			radar.NewCheckQueue(cmd.MaxConcurrentChecks),
+			clock.NewClock(),
+			cmd.MaxChecksPerSecond,
+		),
+		cmd.ResourceCheckingJitter,
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
 		resourceFactory,
 		dbResourceConfigCheckSessionFactory,
 		cmd.ResourceCheckingInterval,
+		cmd.DefaultTimeouts.Check,
+		radar.CheckContainerExpiries(cmd.CheckContainerTTL),
+		cmd.GlobalResources,
 		cmd.ExternalURL.String(),
 		variablesFactory,
 	)
@@ -457,14 +746,16 @@ func (cmd *ATCCommand) constructMembers(
 		return nil, err
 	}
 
-	_, err = teamFactory.CreateDefaultTeamIfNotExists()
-	if err != nil {
-		return nil, err
-	}
+	if !cmd.ReadOnly {
+		_, err = teamFactory.CreateDefaultTeamIfNotExists()
+		if err != nil {
+			return nil, err
+		}
 
-	err = cmd.configureAuthForDefaultTeam(teamFactory)
-	if err != nil {
-		return nil, err
+		err = cmd.configureAuthForDefaultTeam(teamFactory)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	drain := make(chan struct{})
@@ -493,6 +784,8 @@ func (cmd *ATCCommand) constructMembers(
 		dbVolumeFactory,
 		dbContainerRepository,
 		dbBuildFactory,
+		dbAuditLog,
+		dbCacheUsageLedger,
 		signingKey,
 		engine,
 		workerClient,
@@ -515,7 +808,7 @@ func (cmd *ATCCommand) constructMembers(
 		return nil, err
 	}
 
-	webHandler = metric.WrapHandler(logger, "web", webHandler)
+	webHandler = metric.WrapHandler(logger, "web", 0, webHandler)
 
 	var httpHandler, httpsHandler http.Handler
 	if cmd.isTLSEnabled() {
@@ -557,6 +850,11 @@ func (cmd *ATCCommand) constructMembers(
 		)
 	}
 
+	warmResourceTypes, err := cmd.warmResourceTypes()
+	if err != nil {
+		return nil, err
+	}
+
 	members := []grouper.Member{
 		{"drainer", drainer{
 			logger: logger.Session("drain"),
@@ -566,7 +864,11 @@ func (cmd *ATCCommand) constructMembers(
 				dbBuildFactory,
 				engine,
 			),
-			bus: bus,
+			bus:      bus,
+			readOnly: cmd.ReadOnly,
+
+			engine:       engine,
+			drainTimeout: cmd.DrainTimeout,
 		}},
 
 		{"debug", http_server.New(
@@ -574,9 +876,20 @@ func (cmd *ATCCommand) constructMembers(
 			http.DefaultServeMux,
 		)},
 
-		{"pipelines", pipelines.SyncRunner{
-			Syncer: cmd.constructPipelineSyncer(
-				logger.Session("syncer"),
+		{"radar", pipelines.SyncRunner{
+			Syncer: cmd.constructRadarSyncer(
+				logger.Session("radar-syncer"),
+				dbPipelineFactory,
+				radarSchedulerFactory,
+				variablesFactory,
+			),
+			Interval: 10 * time.Second,
+			Clock:    clock.NewClock(),
+		}},
+
+		{"scheduler", pipelines.SyncRunner{
+			Syncer: cmd.constructSchedulerSyncer(
+				logger.Session("scheduler-syncer"),
 				dbPipelineFactory,
 				radarSchedulerFactory,
 				variablesFactory,
@@ -609,6 +922,7 @@ func (cmd *ATCCommand) constructMembers(
 				gc.NewWorkerCollector(
 					logger.Session("worker-collector"),
 					dbWorkerLifecycle,
+					cmd.GC.EphemeralWorkerGracePeriod,
 				),
 				gc.NewResourceCacheUseCollector(
 					logger.Session("resource-cache-use-collector"),
@@ -655,6 +969,28 @@ func (cmd *ATCCommand) constructMembers(
 				gc.NewResourceConfigCheckSessionCollector(
 					logger.Session("resource-config-check-session-collector"),
 					resourceConfigCheckSessionLifecycle,
+					cmd.MigrateChecksFromPressuredWorkers,
+				),
+				gc.NewVolumePolicyCollector(
+					logger.Session("volume-policy-collector"),
+					dbVolumeFactory,
+					gc.VolumePolicy{
+						MaxAge:          cmd.GC.MaxVolumeAge,
+						MaxDiskUsage:    cmd.GC.MaxDiskUsage,
+						TeamQuotas:      cmd.GC.TeamVolumeQuotas,
+						TeamCacheQuotas: cmd.GC.TeamCacheQuotas,
+					},
+					clock.NewClock(),
+				),
+				gc.NewArchivedPipelineCollector(
+					logger.Session("archived-pipeline-collector"),
+					dbPipelineFactory,
+					cmd.GC.ArchivedPipelineRetention,
+					clock.NewClock(),
+				),
+				gc.NewCacheUsageCollector(
+					logger.Session("cache-usage-collector"),
+					dbCacheUsageLedger,
 				),
 			),
 			"collector",
@@ -663,18 +999,62 @@ func (cmd *ATCCommand) constructMembers(
 			cmd.GC.Interval,
 		)},
 
+		{"retiring-worker-collector", lockrunner.NewRunner(
+			logger.Session("retiring-worker-collector-runner"),
+			gc.NewRetiringWorkerCollector(
+				logger.Session("retiring-worker-collector"),
+				dbWorkerLifecycle,
+				worker.NewTaskCacheReplicator(
+					workerProvider,
+					dbWorkerFactory,
+					dbWorkerTaskCacheFactory,
+					p2pStreamer,
+					clock.NewClock(),
+				),
+			),
+			"retiring-worker-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
+
 		{"build-reaper", lockrunner.NewRunner(
 			logger.Session("build-reaper-runner"),
 			gc.NewBuildReaper(
 				logger.Session("build-reaper"),
 				dbPipelineFactory,
 				500,
+				cmd.jobLimits(),
 			),
 			"build-reaper",
 			lockFactory,
 			clock.NewClock(),
 			30*time.Second,
 		)},
+
+		{"orphan-reaper", lockrunner.NewRunner(
+			logger.Session("orphan-reaper-runner"),
+			orphanReaper,
+			"orphan-reaper",
+			lockFactory,
+			clock.NewClock(),
+			cmd.BuildHeartbeatInterval,
+		)},
+
+		{"resource-type-warmer", lockrunner.NewRunner(
+			logger.Session("resource-type-warmer-runner"),
+			worker.NewResourceTypeWarmer(
+				logger.Session("resource-type-warmer"),
+				workerProvider,
+				teamFactory,
+				dbWorkerWarmResourceTypeFactory,
+				warmResourceTypes,
+			),
+			"resource-type-warmer",
+			lockFactory,
+			clock.NewClock(),
+			cmd.WarmResourceTypesInterval,
+		)},
 	}
 
 	if cmd.TelemetryOptIn {
@@ -687,6 +1067,76 @@ func (cmd *ATCCommand) constructMembers(
 		}()
 	}
 
+	if zipkinTracer != nil {
+		members = append(members, grouper.Member{"tracer", zipkinTracer})
+	}
+
+	if cmd.ResourceCacheMirror.Interval != 0 {
+		members = append(members, grouper.Member{"resource-cache-mirror", lockrunner.NewRunner(
+			logger.Session("resource-cache-mirror-runner"),
+			worker.NewResourceCacheMirror(
+				logger.Session("resource-cache-mirror"),
+				workerProvider,
+				dbVolumeFactory,
+				p2pStreamer,
+				cmd.ResourceCacheMirror.HotCaches,
+				cmd.ResourceCacheMirror.Replicas,
+			),
+			"resource-cache-mirror",
+			lockFactory,
+			clock.NewClock(),
+			cmd.ResourceCacheMirror.Interval,
+		)})
+	}
+
+	if cmd.ArtifactCacheDir != "" {
+		members = append(members, grouper.Member{"artifact-cache-reaper", lockrunner.NewRunner(
+			logger.Session("artifact-cache-reaper-runner"),
+			artifactcache.NewDirReaper(
+				logger.Session("artifact-cache-reaper"),
+				cmd.ArtifactCacheDir.Path(),
+				cmd.ArtifactCacheMaxAge,
+				cmd.ArtifactCacheMaxSize,
+			),
+			"artifact-cache-reaper",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)})
+	}
+
+	if cmd.TaskCheckpointDir != "" {
+		members = append(members, grouper.Member{"task-checkpoint-reaper", lockrunner.NewRunner(
+			logger.Session("task-checkpoint-reaper-runner"),
+			artifactcache.NewDirReaper(
+				logger.Session("task-checkpoint-reaper"),
+				cmd.TaskCheckpointDir.Path(),
+				cmd.TaskCheckpointMaxAge,
+				0,
+			),
+			"task-checkpoint-reaper",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)})
+	}
+
+	if cmd.EventArchive.IsConfigured() {
+		members = append(members, grouper.Member{"build-event-archiver", lockrunner.NewRunner(
+			logger.Session("build-event-archiver-runner"),
+			gc.NewBuildEventArchiver(
+				logger.Session("build-event-archiver"),
+				dbBuildFactory,
+				cmd.EventArchive.After,
+				cmd.EventArchive.BatchSize,
+			),
+			"build-event-archiver",
+			lockFactory,
+			clock.NewClock(),
+			cmd.EventArchive.Interval,
+		)})
+	}
+
 	if cmd.Worker.GardenURL.URL != nil {
 		members = cmd.appendStaticWorker(logger, dbWorkerFactory, members)
 	}
@@ -755,39 +1205,73 @@ func (cmd *ATCCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	}
 	go metric.PeriodicallyEmit(logger.Session("periodic-metrics"), 10*time.Second)
 
+	if err := lifecycle.Initialize(logger.Session("lifecycle")); err != nil {
+		return nil, err
+	}
+
+	// every component runs builds through an engine of its own, so they share
+	// one limiter for --max-running-builds to apply to the ATC as a whole
+	buildLimiter := engine.NewBuildLimiter(cmd.MaxRunningBuilds)
+
 	apiMembers, err := cmd.constructMembers(positionalArguments, []string{
 		"debug",
 		"web-tls",
 		"web",
 	},
-		32,
+		cmd.ConnectionPools.API,
 		"api",
 		logger,
 		reconfigurableSink,
+		buildLimiter,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	serviceMembers, err := cmd.constructMembers(positionalArguments, []string{
-		"drainer",
-		"pipelines",
-		"builds",
-		"collector",
-		"build-reaper",
-		"static-worker",
-	},
-		32,
-		"backend",
-		logger,
-		reconfigurableSink,
-	)
-	if err != nil {
-		return nil, err
+	members = append(members, apiMembers...)
+
+	// each component gets a pool of its own, so that e.g. slow garbage
+	// collection cannot hold every connection the scheduler needs
+	backendComponents := []struct {
+		connectionName string
+		maxConns       int
+		memberNames    []string
+	}{
+		{"scheduler", cmd.ConnectionPools.Scheduler, []string{"drainer", "scheduler", "builds", "static-worker"}},
+		{"radar", cmd.ConnectionPools.Radar, []string{"radar"}},
+		{"gc", cmd.ConnectionPools.GC, []string{
+			"collector",
+			"build-reaper",
+			"orphan-reaper",
+			"resource-type-warmer",
+			"resource-cache-mirror",
+			"build-event-archiver",
+		}},
 	}
 
-	members = append(members, apiMembers...)
-	members = append(members, serviceMembers...)
+	for _, component := range backendComponents {
+		memberNames := component.memberNames
+		if cmd.ReadOnly {
+			if component.connectionName != "scheduler" {
+				continue
+			}
+
+			memberNames = []string{"drainer"}
+		}
+
+		componentMembers, err := cmd.constructMembers(positionalArguments, memberNames,
+			component.maxConns,
+			component.connectionName,
+			logger,
+			reconfigurableSink,
+			buildLimiter,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, componentMembers...)
+	}
 
 	return onReady(grouper.NewParallel(os.Interrupt, members), func() {
 		logData := lager.Data{
@@ -880,9 +1364,112 @@ func (cmd *ATCCommand) validate() error {
 		)
 	}
 
+	for route := range cmd.Metrics.APIRouteLatencyBudgets {
+		if _, found := atc.Routes.FindRouteByName(route); !found {
+			errs = multierror.Append(
+				errs,
+				fmt.Errorf("unknown API route for latency budget: %s", route),
+			)
+		}
+	}
+
+	if cmd.ReadOnly && cmd.OldEncryptionKey.AEAD != nil {
+		errs = multierror.Append(
+			errs,
+			errors.New("cannot rotate the encryption key in read-only mode; rotate it on the primary instead"),
+		)
+	}
+
+	if cmd.BuildHeartbeatInterval <= 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--build-heartbeat-interval must be greater than zero"),
+		)
+	} else if cmd.BuildHeartbeatTimeout <= cmd.BuildHeartbeatInterval {
+		errs = multierror.Append(
+			errs,
+			errors.New("--build-heartbeat-timeout must be greater than --build-heartbeat-interval"),
+		)
+	}
+
+	if cmd.CheckContainerTTL < 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--check-container-ttl must not be negative"),
+		)
+	} else if cmd.CheckContainerTTL > 0 && cmd.CheckContainerTTL < cmd.ResourceCheckingInterval {
+		errs = multierror.Append(
+			errs,
+			errors.New("--check-container-ttl must not be shorter than --resource-checking-interval, or no check container would be reused"),
+		)
+	}
+
+	if cmd.MaxConcurrentChecks < 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--max-concurrent-checks must not be negative"),
+		)
+	}
+
+	if cmd.MaxChecksPerSecond < 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--max-checks-per-second must not be negative"),
+		)
+	}
+
+	if cmd.ResourceCheckingJitter < 0 || cmd.ResourceCheckingJitter > 1 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--resource-checking-jitter must be between 0 and 1"),
+		)
+	}
+
+	if cmd.DefaultTimeouts.Get < 0 || cmd.DefaultTimeouts.Put < 0 || cmd.DefaultTimeouts.Check < 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--default-timeout-get, --default-timeout-put and --default-timeout-check must not be negative"),
+		)
+	}
+
+	if cmd.ArtifactCacheMaxAge < 0 || cmd.ArtifactCacheMaxSize < 0 || cmd.TaskCheckpointMaxAge < 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--artifact-cache-max-age, --artifact-cache-max-size and --task-checkpoint-max-age must not be negative"),
+		)
+	}
+
+	if cmd.Tracing.Interval <= 0 || cmd.Tracing.BufferSize <= 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--tracing-interval and --tracing-buffer-size must be positive"),
+		)
+	}
+
+	if cmd.AdmissionControllerTimeout <= 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--admission-controller-timeout must be positive"),
+		)
+	}
+
 	return errs.ErrorOrNil()
 }
 
+// stepMiddlewares wrap every step of every build, within the logging and
+// tracing of each step.
+func (cmd *ATCCommand) stepMiddlewares() []exec.StepMiddleware {
+	middlewares := []exec.StepMiddleware{
+		exec.MetricsMiddleware,
+	}
+
+	if len(cmd.AllowedResourceTypes) > 0 {
+		middlewares = append(middlewares, exec.PolicyMiddleware(exec.NewResourceTypePolicy(cmd.AllowedResourceTypes)))
+	}
+
+	return middlewares
+}
+
 func (cmd *ATCCommand) nonTLSBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.BindIP, cmd.BindPort)
 }
@@ -895,6 +1482,46 @@ func (cmd *ATCCommand) debugBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.DebugBindIP, cmd.DebugBindPort)
 }
 
+func (cmd *ATCCommand) jobLimits() atc.JobLimits {
+	return atc.JobLimits{
+		MaxStepTimeout:       cmd.JobLimits.MaxStepTimeout,
+		MaxBuildLogsToRetain: cmd.JobLimits.MaxBuildLogsToRetain,
+	}
+}
+
+// warmResourceTypes loads the resource types whose images are kept warm on
+// the workers, if any are configured.
+func (cmd *ATCCommand) warmResourceTypes() (atc.ResourceTypes, error) {
+	if cmd.WarmResourceTypes == "" {
+		return atc.ResourceTypes{}, nil
+	}
+
+	content, err := ioutil.ReadFile(string(cmd.WarmResourceTypes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read warm resource types: %s", err)
+	}
+
+	var resourceTypes atc.ResourceTypes
+	err = yaml.Unmarshal(content, &resourceTypes)
+	if err != nil {
+		return nil, fmt.Errorf("malformed warm resource types: %s", err)
+	}
+
+	return resourceTypes, nil
+}
+
+// featureFlags reports which optional features affecting builds are enabled,
+// for their environment snapshots.
+func (cmd *ATCCommand) featureFlags() map[string]bool {
+	return map[string]bool{
+		"artifact-cache":       cmd.ArtifactCacheDir != "",
+		"task-checkpoints":     cmd.TaskCheckpointDir != "",
+		"artifact-scanner":     cmd.ArtifactScanner.URL.URL != nil,
+		"noisy-neighbors":      cmd.NoisyNeighbors.Window > 0,
+		"admission-controller": cmd.AdmissionControllerURL.URL != nil,
+	}
+}
+
 func (cmd *ATCCommand) constructLogger() (lager.Logger, *lager.ReconfigurableSink) {
 	logger, reconfigurableSink := cmd.Logger.Logger("atc")
 
@@ -924,20 +1551,72 @@ func (cmd *ATCCommand) constructDBConn(
 	connectionName string,
 	lockFactory lock.LockFactory,
 ) (db.Conn, error) {
-	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), newKey, oldKey, connectionName, lockFactory)
-	if err != nil {
-		return nil, fmt.Errorf("failed to migrate database: %s", err)
+	var dbConn db.Conn
+	var err error
+	if cmd.ReadOnly {
+		dbConn, err = db.OpenReadOnly(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), newKey, connectionName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %s", err)
+		}
+	} else {
+		dbConn, err = db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), newKey, oldKey, connectionName, lockFactory)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate database: %s", err)
+		}
 	}
 
 	// Instrument with Metrics
 	dbConn = metric.CountQueries(dbConn)
 	metric.Databases = append(metric.Databases, dbConn)
+	metric.DatabaseMaxConns[connectionName] = maxConn
 
 	// Instrument with Logging
 	if cmd.LogDBQueries {
 		dbConn = db.Log(logger.Session("log-conn"), dbConn)
 	}
 
+	if cmd.PostgresReplica.DataSource != "" {
+		replicaConn, err := db.OpenReadOnly(logger.Session("db-replica"), driverName, cmd.PostgresReplica.DataSource, newKey, connectionName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database replica: %s", err)
+		}
+
+		replicaConn = metric.CountQueries(replicaConn)
+
+		if cmd.LogDBQueries {
+			replicaConn = db.Log(logger.Session("log-replica-conn"), replicaConn)
+		}
+
+		dbConn = db.ReadFromReplica(dbConn, replicaConn)
+	}
+
+	if cmd.EventCompression != "" {
+		codec, err := compression.Lookup(cmd.EventCompression)
+		if err != nil {
+			return nil, err
+		}
+
+		dbConn = db.CompressEvents(dbConn, codec)
+	}
+
+	if cmd.EventArchive.IsConfigured() {
+		store, err := eventarchive.NewStore(cmd.EventArchive.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		dbConn = db.ArchiveEvents(dbConn, store)
+	}
+
+	if cmd.NotificationsBus.IsConfigured() {
+		notificationsBus, err := bus.NewBus(logger.Session("notifications-bus"), cmd.NotificationsBus)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to notifications bus: %s", err)
+		}
+
+		dbConn = db.NotifyThrough(dbConn, notificationsBus)
+	}
+
 	// Prepare
 	dbConn.SetMaxOpenConns(maxConn)
 
@@ -960,20 +1639,17 @@ func (cmd *ATCCommand) constructLockConn(driverName string) (*sql.DB, error) {
 func (cmd *ATCCommand) constructWorkerPool(
 	logger lager.Logger,
 	workerProvider worker.WorkerProvider,
-) worker.Client {
-
-	var strategy worker.ContainerPlacementStrategy
-	switch cmd.ContainerPlacementStrategy {
-	case "random":
-		strategy = worker.NewRandomPlacementStrategy()
-	default:
-		strategy = worker.NewVolumeLocalityPlacementStrategy()
+) (worker.Client, error) {
+	strategy, err := worker.NewContainerPlacementStrategy(cmd.ContainerPlacementStrategy)
+	if err != nil {
+		return nil, err
 	}
 
 	return worker.NewPool(
 		workerProvider,
 		strategy,
-	)
+		cmd.MaxActiveTasksPerWorker,
+	), nil
 }
 
 func (cmd *ATCCommand) loadOrGenerateSigningKey() (*rsa.PrivateKey, error) {
@@ -1044,24 +1720,104 @@ func (cmd *ATCCommand) constructEngine(
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
 	variablesFactory creds.VariablesFactory,
-) engine.Engine {
+	dbWorkerFactory db.WorkerFactory,
+	credentialManager string,
+	buildLimiter *engine.BuildLimiter,
+	tracer exec.Tracer,
+) (engine.Engine, error) {
+	var artifactCache artifactcache.Store
+	if cmd.ArtifactCacheDir != "" {
+		artifactCache = artifactcache.NewDirStore(cmd.ArtifactCacheDir.Path())
+	}
+
+	var checkpoints artifactcache.Store
+	if cmd.TaskCheckpointDir != "" {
+		checkpoints = artifactcache.NewDirStore(cmd.TaskCheckpointDir.Path())
+	}
+
+	var artifactScanner artifactscan.Scanner
+	if cmd.ArtifactScanner.URL.URL != nil {
+		artifactScanner = artifactscan.NewHTTPScanner(cmd.ArtifactScanner.URL.String(), http.DefaultClient)
+	}
+
+	var noisyNeighbors exec.NoisyNeighborDetector
+	if cmd.NoisyNeighbors.Window > 0 {
+		noisyNeighbors = exec.NewNoisyNeighborDetector(cmd.NoisyNeighbors.Window, cmd.NoisyNeighbors.Threshold)
+	}
+
 	gardenFactory := exec.NewGardenFactory(
 		workerClient,
 		resourceFetcher,
 		resourceFactory,
 		dbResourceCacheFactory,
 		variablesFactory,
+		artifactCache,
+		checkpoints,
+		tracer,
+		noisyNeighbors,
+		artifactScanner,
+		artifactscan.Policy(cmd.ArtifactScanner.Policy),
+		exec.DefaultTimeouts{
+			Get: cmd.DefaultTimeouts.Get,
+			Put: cmd.DefaultTimeouts.Put,
+		},
+		cmd.stepMiddlewares()...,
 	)
 
 	execV2Engine := engine.NewExecEngine(
 		gardenFactory,
 		engine.NewBuildDelegateFactory(),
 		cmd.ExternalURL.String(),
+		cmd.AbortCleanupBudget,
+		cmd.BuildRetryBudget,
 	)
 
 	execV1Engine := engine.NewExecV1DummyEngine()
 
-	return engine.NewDBEngine(engine.Engines{execV2Engine, execV1Engine}, cmd.PeerURL.String())
+	noopEngine := engine.NewNoopEngine()
+
+	engines := engine.Engines{execV2Engine, execV1Engine, noopEngine}
+	if cmd.Developer.NoopEngine {
+		engines = engine.Engines{noopEngine, execV2Engine, execV1Engine}
+	}
+
+	registry := engine.NewEngineRegistry(engines)
+
+	for _, team := range cmd.Developer.NoopEngineTeams {
+		err := registry.SetTeamEngine(team, engine.NoopEngineName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for pipeline, engineName := range cmd.Developer.PipelineEngines {
+		segments := strings.SplitN(pipeline, "/", 2)
+		if len(segments) != 2 {
+			return nil, fmt.Errorf("invalid pipeline for --pipeline-engine (must be TEAM/PIPELINE): %s", pipeline)
+		}
+
+		err := registry.SetPipelineEngine(segments[0], segments[1], engineName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var admissionController engine.AdmissionController
+	if cmd.AdmissionControllerURL.URL != nil {
+		admissionController = admission.NewHTTPController(cmd.AdmissionControllerURL.String(), &http.Client{
+			Timeout: cmd.AdmissionControllerTimeout,
+		})
+	}
+
+	snapshotter := engine.NewEnvironmentSnapshotter(dbWorkerFactory, Version, credentialManager, cmd.featureFlags())
+
+	heartbeatInterval := cmd.BuildHeartbeatInterval
+	if cmd.BuildLease == "advisory-lock" {
+		// builds are held by their tracking locks alone
+		heartbeatInterval = 0
+	}
+
+	return engine.NewDBEngine(registry, cmd.PeerURL.String(), buildLimiter, heartbeatInterval, admissionController, snapshotter), nil
 }
 
 func (cmd *ATCCommand) constructHTTPHandler(
@@ -1103,6 +1859,8 @@ func (cmd *ATCCommand) constructAPIHandler(
 	dbVolumeFactory db.VolumeFactory,
 	dbContainerRepository db.ContainerRepository,
 	dbBuildFactory db.BuildFactory,
+	dbAuditLog db.AuditLog,
+	dbCacheUsageLedger db.CacheUsageLedger,
 	signingKey *rsa.PrivateKey,
 	engine engine.Engine,
 	workerClient worker.Client,
@@ -1119,7 +1877,8 @@ func (cmd *ATCCommand) constructAPIHandler(
 	checkWorkerTeamAccessHandlerFactory := auth.NewCheckWorkerTeamAccessHandlerFactory(dbWorkerFactory)
 
 	apiWrapper := wrappa.MultiWrappa{
-		wrappa.NewAPIMetricsWrappa(logger),
+		wrappa.NewAPIMetricsWrappa(logger, cmd.Metrics.APILatencyBudget, cmd.Metrics.APIRouteLatencyBudgets),
+		wrappa.NewAuditWrappa(logger, dbAuditLog),
 		wrappa.NewAPIAuthWrappa(
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
@@ -1129,6 +1888,10 @@ func (cmd *ATCCommand) constructAPIHandler(
 		wrappa.NewConcourseVersionWrappa(Version),
 	}
 
+	if cmd.ReadOnly {
+		apiWrapper = append(wrappa.MultiWrappa{wrappa.NewReadOnlyWrappa()}, apiWrapper...)
+	}
+
 	return api.NewHandler(
 		logger,
 		cmd.ExternalURL.String(),
@@ -1143,6 +1906,8 @@ func (cmd *ATCCommand) constructAPIHandler(
 		dbVolumeFactory,
 		dbContainerRepository,
 		dbBuildFactory,
+		dbAuditLog,
+		dbCacheUsageLedger,
 
 		cmd.PeerURL.String(),
 		buildserver.NewEventHandler,
@@ -1165,9 +1930,22 @@ func (cmd *ATCCommand) constructAPIHandler(
 		WorkerVersion,
 		variablesFactory,
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
+		cmd.imageResolver(),
 	)
 }
 
+// imageResolver returns the resolver with which resource types are pinned to
+// the digests of their images as pipelines are set, if enabled.
+func (cmd *ATCCommand) imageResolver() imageresolver.Resolver {
+	if !cmd.PinResourceTypeDigests {
+		return nil
+	}
+
+	return imageresolver.NewRegistryResolver(&http.Client{
+		Timeout: imageResolveTimeout,
+	})
+}
+
 type tlsRedirectHandler struct {
 	externalHost string
 	baseHandler  http.Handler
@@ -1188,7 +1966,7 @@ func (h tlsRedirectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (cmd *ATCCommand) constructPipelineSyncer(
+func (cmd *ATCCommand) constructRadarSyncer(
 	logger lager.Logger,
 	pipelineFactory db.PipelineFactory,
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
@@ -1199,28 +1977,35 @@ func (cmd *ATCCommand) constructPipelineSyncer(
 		pipelineFactory,
 		func(pipeline db.Pipeline) ifrit.Runner {
 			variables := variablesFactory.NewVariables(pipeline.TeamName(), pipeline.Name())
-			return grouper.NewParallel(os.Interrupt, grouper.Members{
-				{
-					pipeline.ScopedName("radar"),
-					radar.NewRunner(
-						logger.Session(pipeline.ScopedName("radar")),
-						cmd.Developer.Noop,
-						radarSchedulerFactory.BuildScanRunnerFactory(pipeline, cmd.ExternalURL.String(), variables),
-						pipeline,
-						1*time.Minute,
-					),
-				},
-				{
-					pipeline.ScopedName("scheduler"),
-					&scheduler.Runner{
-						Logger:    logger.Session(pipeline.ScopedName("scheduler")),
-						Pipeline:  pipeline,
-						Scheduler: radarSchedulerFactory.BuildScheduler(pipeline, cmd.ExternalURL.String(), variables),
-						Noop:      cmd.Developer.Noop,
-						Interval:  10 * time.Second,
-					},
-				},
-			})
+			return radar.NewRunner(
+				logger.Session(pipeline.ScopedName("radar")),
+				cmd.Developer.Noop,
+				radarSchedulerFactory.BuildScanRunnerFactory(pipeline, cmd.ExternalURL.String(), variables),
+				pipeline,
+				1*time.Minute,
+			)
+		},
+	)
+}
+
+func (cmd *ATCCommand) constructSchedulerSyncer(
+	logger lager.Logger,
+	pipelineFactory db.PipelineFactory,
+	radarSchedulerFactory pipelines.RadarSchedulerFactory,
+	variablesFactory creds.VariablesFactory,
+) *pipelines.Syncer {
+	return pipelines.NewSyncer(
+		logger,
+		pipelineFactory,
+		func(pipeline db.Pipeline) ifrit.Runner {
+			variables := variablesFactory.NewVariables(pipeline.TeamName(), pipeline.Name())
+			return &scheduler.Runner{
+				Logger:    logger.Session(pipeline.ScopedName("scheduler")),
+				Pipeline:  pipeline,
+				Scheduler: radarSchedulerFactory.BuildScheduler(pipeline, cmd.ExternalURL.String(), variables),
+				Noop:      cmd.Developer.Noop,
+				Interval:  10 * time.Second,
+			}
 		},
 	)
 }
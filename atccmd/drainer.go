@@ -1,11 +1,15 @@
 package atccmd
 
 import (
+	"context"
 	"os"
+	"time"
 
 	"github.com/concourse/atc/builds"
+	"github.com/concourse/atc/engine"
 
 	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/atc/db"
 )
 
@@ -14,6 +18,16 @@ type drainer struct {
 	drain   chan<- struct{}
 	tracker builds.BuildTracker
 	bus     db.NotificationsBus
+
+	// engine is drained for up to drainTimeout before the tracker is
+	// released, so that running steps can finish rather than being resumed
+	// by another ATC. Draining is skipped if drainTimeout is zero.
+	engine       engine.Engine
+	drainTimeout time.Duration
+
+	// readOnly skips releasing builds and notifying other ATCs, as an ATC in
+	// read-only mode tracks no builds and cannot send notifications.
+	readOnly bool
 }
 
 func (d drainer) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
@@ -21,6 +35,15 @@ func (d drainer) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 
 	<-signals
 
+	if d.readOnly {
+		close(d.drain)
+		return nil
+	}
+
+	if d.drainTimeout > 0 {
+		d.drainEngine()
+	}
+
 	d.logger.Info("releasing-tracker")
 	d.tracker.Release()
 	d.logger.Info("released-tracker")
@@ -31,3 +54,19 @@ func (d drainer) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 
 	return d.bus.Notify("atc_shutdown")
 }
+
+func (d drainer) drainEngine() {
+	logger := d.logger.Session("drain-engine")
+
+	ctx, cancel := context.WithTimeout(lagerctx.NewContext(context.Background(), logger), d.drainTimeout)
+	defer cancel()
+
+	logger.Info("draining", lager.Data{"timeout": d.drainTimeout.String()})
+
+	report := d.engine.Drain(ctx)
+
+	logger.Info("drained", lager.Data{
+		"drained":     report.Drained,
+		"interrupted": report.Interrupted,
+	})
+}
@@ -0,0 +1,92 @@
+package atccmd
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/scheduler/loadtest"
+	"github.com/concourse/flag"
+)
+
+// SchedulerBenchCommand replays synthetic pipelines through the scheduler
+// against a throwaway database and reports its throughput. Builds are run
+// with the noop engine, so no workers are needed.
+type SchedulerBenchCommand struct {
+	Logger flag.Lager
+
+	Postgres flag.PostgresConfig `group:"PostgreSQL Configuration" namespace:"postgres"`
+
+	Team string `long:"team" default:"scheduler-bench" description:"Name of the team to create the synthetic pipelines in. Must not already exist."`
+
+	Pipelines    int    `long:"pipelines"     default:"10"    description:"Number of synthetic pipelines to schedule."`
+	Jobs         int    `long:"jobs"          default:"10"    description:"Number of jobs in each pipeline."`
+	Resources    int    `long:"resources"     default:"5"     description:"Number of resources in each pipeline."`
+	ResourceType string `long:"resource-type" default:"bench" description:"Type of the synthetic resources."`
+	Versions     int    `long:"versions"      default:"20"    description:"Number of versions of each resource to replay, one per tick."`
+}
+
+func (cmd *SchedulerBenchCommand) Execute(args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("unexpected positional arguments: %v", args)
+	}
+
+	if cmd.Resources <= 0 {
+		return fmt.Errorf("--resources must be greater than zero")
+	}
+
+	logger, _ := cmd.Logger.Logger("scheduler-bench")
+
+	lockConn, err := sql.Open(defaultDriverName, cmd.Postgres.ConnectionString())
+	if err != nil {
+		return err
+	}
+
+	defer lockConn.Close()
+
+	lockFactory := lock.NewLockFactory(lockConn)
+
+	dbConn, err := db.Open(logger.Session("db"), defaultDriverName, cmd.Postgres.ConnectionString(), nil, nil, "scheduler-bench", lockFactory)
+	if err != nil {
+		return fmt.Errorf("failed to migrate database: %s", err)
+	}
+
+	defer dbConn.Close()
+
+	dbConn = metric.CountQueries(dbConn)
+
+	buildEngine := engine.NewDBEngine(engine.NewEngineRegistry(engine.Engines{engine.NewNoopEngine()}), "", nil, 0, nil, nil)
+
+	// wait for the builds started during the run to finish before closing the
+	// connection out from under them
+	defer buildEngine.ReleaseAll(logger.Session("release"))
+
+	harness := &loadtest.Harness{
+		Logger:      logger,
+		TeamFactory: db.NewTeamFactory(dbConn, lockFactory),
+		Engine:      buildEngine,
+		Config: loadtest.Config{
+			TeamName:             cmd.Team,
+			Pipelines:            cmd.Pipelines,
+			JobsPerPipeline:      cmd.Jobs,
+			ResourcesPerPipeline: cmd.Resources,
+			ResourceType:         cmd.ResourceType,
+			Versions:             cmd.Versions,
+		},
+	}
+
+	report, err := harness.Run()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("ticks:    %d (%.2f/sec)\n", report.Ticks, report.TicksPerSecond())
+	fmt.Printf("builds:   %d (%.2f/sec)\n", report.Builds, report.BuildsPerSecond())
+	fmt.Printf("queries:  %d (%.2f/tick)\n", report.Queries, report.QueriesPerTick())
+	fmt.Printf("duration: %s\n", report.Duration)
+
+	return nil
+}
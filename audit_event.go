@@ -0,0 +1,13 @@
+package atc
+
+// AuditEvent records an action taken through the API on behalf of a team,
+// e.g. setting a pipeline or aborting a build.
+type AuditEvent struct {
+	ID         int               `json:"id"`
+	TeamName   string            `json:"team_name"`
+	Requester  string            `json:"requester"`
+	Action     string            `json:"action"`
+	Subject    map[string]string `json:"subject,omitempty"`
+	RemoteAddr string            `json:"remote_addr,omitempty"`
+	CreatedAt  int64             `json:"created_at"`
+}
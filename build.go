@@ -9,6 +9,8 @@ const (
 	StatusFailed    BuildStatus = "failed"
 	StatusErrored   BuildStatus = "errored"
 	StatusAborted   BuildStatus = "aborted"
+
+	StatusPipelineDestroyed BuildStatus = "pipeline-destroyed"
 )
 
 type Build struct {
@@ -22,6 +24,30 @@ type Build struct {
 	StartTime    int64  `json:"start_time,omitempty"`
 	EndTime      int64  `json:"end_time,omitempty"`
 	ReapTime     int64  `json:"reap_time,omitempty"`
+
+	TriggerCause *BuildTriggerCause `json:"trigger_cause,omitempty"`
+	AbortCause   *BuildAbortCause   `json:"abort_cause,omitempty"`
+
+	RerunOf   int    `json:"rerun_of,omitempty"`
+	RerunFrom string `json:"rerun_from,omitempty"`
+
+	ArchivedFrom string `json:"archived_from,omitempty"`
+}
+
+// BuildTriggerCause describes why a build was created: "input" for a new
+// Version of the trigger input Resource, or "manual" or "rerun" for a build
+// requested through the API by the Requester.
+type BuildTriggerCause struct {
+	Type      string  `json:"type"`
+	Resource  string  `json:"resource,omitempty"`
+	Version   Version `json:"version,omitempty"`
+	Requester string  `json:"requester,omitempty"`
+}
+
+// BuildAbortCause describes who aborted a build, a team or "system", and why.
+type BuildAbortCause struct {
+	Requester string `json:"requester"`
+	Reason    string `json:"reason,omitempty"`
 }
 
 func (b Build) IsRunning() bool {
@@ -60,3 +86,34 @@ type BuildPreparation struct {
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
 }
+
+// BuildPlanReplay describes the container created to replay a task step. It
+// can be hijacked like any other container until it expires.
+type BuildPlanReplay struct {
+	ContainerID string `json:"container_id"`
+}
+
+// BuildCalendar counts the builds of each job in a pipeline started within
+// each hour or day of a time range. From, To, and each entry's Start are Unix
+// timestamps.
+type BuildCalendar struct {
+	Interval string               `json:"interval"`
+	Timezone string               `json:"timezone"`
+	From     int64                `json:"from"`
+	To       int64                `json:"to"`
+	Entries  []BuildCalendarEntry `json:"entries"`
+}
+
+// BuildCalendarEntry counts the builds of a job started within one interval.
+// Duration is the total number of seconds spent running those which have
+// finished.
+type BuildCalendarEntry struct {
+	JobName   string `json:"job_name"`
+	Start     int64  `json:"start"`
+	Builds    int    `json:"builds"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+	Errored   int    `json:"errored"`
+	Aborted   int    `json:"aborted"`
+	Duration  int64  `json:"duration"`
+}
@@ -0,0 +1,29 @@
+package atc
+
+// BuildEnvironment is a snapshot of the cluster configuration affecting a
+// build, taken as it finishes so that it can be audited later. It is never
+// modified once saved.
+type BuildEnvironment struct {
+	ATCVersion        string                         `json:"atc_version,omitempty"`
+	CredentialManager string                         `json:"credential_manager,omitempty"`
+	Workers           []BuildEnvironmentWorker       `json:"workers"`
+	ResourceTypes     []BuildEnvironmentResourceType `json:"resource_types"`
+	FeatureFlags      map[string]bool                `json:"feature_flags,omitempty"`
+}
+
+// BuildEnvironmentWorker is a worker the build ran containers on, along with
+// the versions of the resource types it provides.
+type BuildEnvironmentWorker struct {
+	Name          string            `json:"name"`
+	Version       string            `json:"version,omitempty"`
+	Platform      string            `json:"platform"`
+	ResourceTypes map[string]string `json:"resource_types,omitempty"`
+}
+
+// BuildEnvironmentResourceType is a resource type configured by the build's
+// pipeline, along with the version of it last checked.
+type BuildEnvironmentResourceType struct {
+	Name    string  `json:"name"`
+	Type    string  `json:"type"`
+	Version Version `json:"version,omitempty"`
+}
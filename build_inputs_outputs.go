@@ -13,6 +13,8 @@ type PublicBuildInput struct {
 	Metadata        []MetadataField `json:"metadata"`
 	PipelineID      int             `json:"pipeline_id"`
 	FirstOccurrence bool            `json:"first_occurrence"`
+
+	Annotations []VersionAnnotation `json:"annotations,omitempty"`
 }
 
 type VersionedResource struct {
@@ -22,4 +24,21 @@ type VersionedResource struct {
 	Resource string          `json:"resource"`
 	Version  Version         `json:"version"`
 	Enabled  bool            `json:"enabled"`
+
+	Annotations []VersionAnnotation `json:"annotations,omitempty"`
+}
+
+// VersionAnnotation is a note attached to a resource version by an external
+// system, e.g. a QA sign-off or a link to a ticket.
+type VersionAnnotation struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+// ResourceVersionSearchResult is a resource version found by searching its
+// version and metadata fields, along with the builds it was an input to.
+type ResourceVersionSearchResult struct {
+	VersionedResource VersionedResource `json:"versioned_resource"`
+	InputTo           []Build           `json:"input_to"`
 }
@@ -0,0 +1,15 @@
+package atc
+
+// BuildLogRetention is a pipeline's policy for how long its jobs keep their
+// build logs before the build reaper deletes them. A zero value keeps every
+// build's logs.
+//
+// A build's logs are reaped once it falls outside any configured limit,
+// unless it is one of its job's most recent MinimumSucceeded succeeded
+// builds. Jobs configuring build_logs_to_retain keep that many builds in
+// place of Builds.
+type BuildLogRetention struct {
+	Builds           int `yaml:"builds,omitempty" json:"builds,omitempty" mapstructure:"builds"`
+	Days             int `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
+	MinimumSucceeded int `yaml:"minimum_succeeded,omitempty" json:"minimum_succeeded,omitempty" mapstructure:"minimum_succeeded"`
+}
@@ -26,36 +26,67 @@ type Tracker struct {
 	engine       engine.Engine
 }
 
+// TrackBatchSize is the number of started builds fetched at once while
+// tracking, so that each batch is looked up and, if need be, errored with a
+// single query.
+const TrackBatchSize = 500
+
 func (bt *Tracker) Track() {
 	tLog := bt.logger.Session("track")
 
 	tLog.Debug("start")
 	defer tLog.Debug("done")
-	builds, err := bt.buildFactory.GetAllStartedBuilds()
-	if err != nil {
-		tLog.Error("failed-to-lookup-started-builds", err)
-	}
 
-	for _, build := range builds {
-		btLog := tLog.WithData(lager.Data{
-			"build":    build.ID(),
-			"pipeline": build.PipelineName(),
-			"job":      build.JobName(),
-		})
-
-		engineBuild, err := bt.engine.LookupBuild(btLog, build)
+	afterID := 0
+	for {
+		builds, err := bt.buildFactory.StartedBuilds(afterID, TrackBatchSize)
 		if err != nil {
-			btLog.Error("failed-to-lookup-build", err)
+			tLog.Error("failed-to-lookup-started-builds", err)
+			return
+		}
+
+		erroredBuilds := map[int]error{}
+		var unresumableBuilds []db.Build
+
+		for _, build := range builds {
+			btLog := tLog.WithData(lager.Data{
+				"build":    build.ID(),
+				"pipeline": build.PipelineName(),
+				"job":      build.JobName(),
+			})
+
+			afterID = build.ID()
 
-			err := build.FinishWithError(err)
+			engineBuild, err := bt.engine.LookupBuild(btLog, build)
 			if err != nil {
-				btLog.Error("failed-to-mark-build-as-errored", err)
+				btLog.Error("failed-to-lookup-build", err)
+				erroredBuilds[build.ID()] = err
+				unresumableBuilds = append(unresumableBuilds, build)
+				continue
 			}
 
-			continue
+			go engineBuild.Resume(btLog)
 		}
 
-		go engineBuild.Resume(btLog)
+		if len(erroredBuilds) > 0 {
+			err = bt.buildFactory.FinishBuildsWithErrors(erroredBuilds)
+			if err != nil {
+				tLog.Error("failed-to-mark-builds-as-errored", err)
+
+				// a single build failing to be errored would otherwise keep
+				// the whole batch from being errored on every track
+				for _, build := range unresumableBuilds {
+					err = build.FinishWithError(erroredBuilds[build.ID()])
+					if err != nil {
+						tLog.Error("failed-to-mark-build-as-errored", err, lager.Data{"build": build.ID()})
+					}
+				}
+			}
+		}
+
+		if len(builds) < TrackBatchSize {
+			return
+		}
 	}
 }
 
@@ -47,13 +47,17 @@ var _ = Describe("Tracker", func() {
 				new(dbfakes.FakeBuild),
 				new(dbfakes.FakeBuild),
 			}
+			for i, build := range inFlightBuilds {
+				build.IDReturns(i + 1)
+			}
+
 			returnedBuilds := []db.Build{
 				inFlightBuilds[0],
 				inFlightBuilds[1],
 				inFlightBuilds[2],
 			}
 
-			fakeBuildFactory.GetAllStartedBuildsReturns(returnedBuilds, nil)
+			fakeBuildFactory.StartedBuildsReturns(returnedBuilds, nil)
 
 			engineBuilds = []*enginefakes.FakeBuild{}
 			fakeEngine.LookupBuildStub = func(logger lager.Logger, build db.Build) (engine.Build, error) {
@@ -71,25 +75,89 @@ var _ = Describe("Tracker", func() {
 			Eventually(engineBuilds[2].ResumeCallCount).Should(Equal(1))
 		})
 
+		It("fetches the started builds in a batch", func() {
+			tracker.Track()
+
+			Expect(fakeBuildFactory.StartedBuildsCallCount()).To(Equal(1))
+			afterID, limit := fakeBuildFactory.StartedBuildsArgsForCall(0)
+			Expect(afterID).To(Equal(0))
+			Expect(limit).To(Equal(builds.TrackBatchSize))
+
+			Expect(fakeBuildFactory.FinishBuildsWithErrorsCallCount()).To(BeZero())
+		})
+
+		Context("when a batch is full", func() {
+			BeforeEach(func() {
+				fullBatch := []db.Build{}
+				for i := 0; i < builds.TrackBatchSize; i++ {
+					build := new(dbfakes.FakeBuild)
+					build.IDReturns(100 + i)
+					fullBatch = append(fullBatch, build)
+				}
+
+				fakeBuildFactory.StartedBuildsReturnsOnCall(0, fullBatch, nil)
+				fakeBuildFactory.StartedBuildsReturnsOnCall(1, []db.Build{}, nil)
+			})
+
+			It("fetches the next batch after the last build", func() {
+				tracker.Track()
+
+				Expect(fakeBuildFactory.StartedBuildsCallCount()).To(Equal(2))
+				afterID, _ := fakeBuildFactory.StartedBuildsArgsForCall(1)
+				Expect(afterID).To(Equal(100 + builds.TrackBatchSize - 1))
+			})
+		})
+
+		Context("when fetching the started builds fails", func() {
+			BeforeEach(func() {
+				fakeBuildFactory.StartedBuildsReturns(nil, errors.New("nope"))
+			})
+
+			It("does not resume any builds", func() {
+				tracker.Track()
+
+				Expect(fakeEngine.LookupBuildCallCount()).To(BeZero())
+			})
+		})
+
 		Context("when a build cannot be looked up", func() {
 			BeforeEach(func() {
 				fakeEngine.LookupBuildReturns(nil, errors.New("nope"))
 			})
 
-			It("saves its status as errored", func() {
+			It("saves their statuses as errored in a batch", func() {
 				tracker.Track()
 
-				Expect(inFlightBuilds[0].FinishWithErrorCallCount()).To(Equal(1))
-				savedErr1 := inFlightBuilds[0].FinishWithErrorArgsForCall(0)
-				Expect(savedErr1).To(Equal(errors.New("nope")))
+				Expect(fakeBuildFactory.FinishBuildsWithErrorsCallCount()).To(Equal(1))
+				Expect(fakeBuildFactory.FinishBuildsWithErrorsArgsForCall(0)).To(Equal(map[int]error{
+					1: errors.New("nope"),
+					2: errors.New("nope"),
+					3: errors.New("nope"),
+				}))
+			})
+
+			It("does not error them one by one", func() {
+				tracker.Track()
+
+				for _, build := range inFlightBuilds {
+					Expect(build.FinishWithErrorCallCount()).To(BeZero())
+				}
+			})
+
+			Context("when erroring the batch fails", func() {
+				BeforeEach(func() {
+					fakeBuildFactory.FinishBuildsWithErrorsReturns(errors.New("batch failed"))
+					inFlightBuilds[0].FinishWithErrorReturns(errors.New("poisoned"))
+				})
 
-				Expect(inFlightBuilds[1].FinishWithErrorCallCount()).To(Equal(1))
-				savedErr2 := inFlightBuilds[1].FinishWithErrorArgsForCall(0)
-				Expect(savedErr2).To(Equal(errors.New("nope")))
+				It("errors each build on its own, so that one cannot block the rest", func() {
+					tracker.Track()
 
-				Expect(inFlightBuilds[2].FinishWithErrorCallCount()).To(Equal(1))
-				savedErr3 := inFlightBuilds[2].FinishWithErrorArgsForCall(0)
-				Expect(savedErr3).To(Equal(errors.New("nope")))
+					for _, build := range inFlightBuilds {
+						Expect(build.FinishWithErrorCallCount()).To(Equal(1))
+						Expect(build.FinishWithErrorArgsForCall(0)).To(Equal(errors.New("nope")))
+					}
+				})
 			})
 		})
 	})
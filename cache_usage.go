@@ -0,0 +1,21 @@
+package atc
+
+// VolumeUsage is the disk space used by a volume, as reported by its worker
+// with each heartbeat.
+type VolumeUsage struct {
+	Handle string `json:"handle"`
+
+	// Size is in bytes.
+	Size uint64 `json:"size"`
+}
+
+// TeamCacheUsage is the disk space used by the resource caches and task
+// caches of a team across all workers, in bytes, as last totalled up by
+// garbage collection. Resource caches shared across teams are not counted
+// against any of them.
+type TeamCacheUsage struct {
+	TeamName           string `json:"team_name"`
+	ResourceCacheBytes uint64 `json:"resource_cache_bytes"`
+	TaskCacheBytes     uint64 `json:"task_cache_bytes"`
+	UpdatedAt          int64  `json:"updated_at,omitempty"`
+}
@@ -24,6 +24,9 @@ type Config struct {
 	Resources     ResourceConfigs `yaml:"resources" json:"resources" mapstructure:"resources"`
 	ResourceTypes ResourceTypes   `yaml:"resource_types" json:"resource_types" mapstructure:"resource_types"`
 	Jobs          JobConfigs      `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
+	Labels        Labels          `yaml:"labels,omitempty" json:"labels,omitempty" mapstructure:"labels"`
+
+	BuildLogRetention *BuildLogRetention `yaml:"build_log_retention,omitempty" json:"build_log_retention,omitempty" mapstructure:"build_log_retention"`
 }
 
 type RawConfig string
@@ -66,6 +69,10 @@ type ResourceType struct {
 	Privileged bool   `yaml:"privileged,omitempty" json:"privileged" mapstructure:"privileged"`
 	Tags       Tags   `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
 	Params     Params `yaml:"params,omitempty" json:"params" mapstructure:"params"`
+
+	// Digest pins the image of the resource type, rather than checking for
+	// the latest image with its tag.
+	Digest string `yaml:"digest,omitempty" json:"digest,omitempty" mapstructure:"digest"`
 }
 
 type ResourceTypes []ResourceType
@@ -214,6 +221,8 @@ type PlanConfig struct {
 
 	// a nested chain of steps to run
 	Do *PlanSequence `yaml:"do,omitempty" json:"do,omitempty" mapstructure:"do"`
+	// run the remaining steps of a 'do' after one fails, still failing overall
+	ContinueOnFailure bool `yaml:"continue_on_failure,omitempty" json:"continue_on_failure,omitempty" mapstructure:"continue_on_failure"`
 
 	// corresponds to an Aggregate plan, keyed by the name of each sub-plan
 	Aggregate *PlanSequence `yaml:"aggregate,omitempty" json:"aggregate,omitempty" mapstructure:"aggregate"`
@@ -223,8 +232,12 @@ type PlanConfig struct {
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
 	// jobs that this resource must have made it through
 	Passed []string `yaml:"passed,omitempty" json:"passed,omitempty" mapstructure:"passed"`
+	// annotations the version must carry, e.g. qa: passed
+	Annotated map[string]string `yaml:"annotated,omitempty" json:"annotated,omitempty" mapstructure:"annotated"`
 	// whether to trigger based on this resource changing
 	Trigger bool `yaml:"trigger,omitempty" json:"trigger,omitempty" mapstructure:"trigger"`
+	// nested path at which to place the fetched artifact in tasks, e.g. src/github.com/concourse/atc
+	Path string `yaml:"path,omitempty" json:"path,omitempty" mapstructure:"path"`
 
 	// name of 'output', e.g. rootfs-tarball
 	Put string `yaml:"put,omitempty" json:"put,omitempty" mapstructure:"put"`
@@ -241,6 +254,9 @@ type PlanConfig struct {
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
+	// run the task in the container of the previous task of the build that
+	// set it, if they have the same image, platform and inputs and no outputs
+	ReuseContainer bool `yaml:"reuse_container,omitempty" json:"reuse_container,omitempty" mapstructure:"reuse_container"`
 
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
@@ -273,6 +289,38 @@ type PlanConfig struct {
 	// used on any step to swallow failures and errors
 	Try *PlanConfig `yaml:"try,omitempty" json:"try,omitempty" mapstructure:"try"`
 
+	// corresponds to SaveCache and RestoreCache plans, respectively
+	// name of the artifact to save to the cache, e.g. gopath
+	SaveCache string `yaml:"save_cache,omitempty" json:"save_cache,omitempty" mapstructure:"save_cache"`
+	// name under which to register the artifact restored from the cache
+	RestoreCache string `yaml:"restore_cache,omitempty" json:"restore_cache,omitempty" mapstructure:"restore_cache"`
+	// key identifying the cache entry, e.g. go-deps-{{checksum "repo/Gopkg.lock"}}
+	CacheKey string `yaml:"key,omitempty" json:"key,omitempty" mapstructure:"key"`
+
+	// corresponds to a WaitFor plan
+	// name of the condition to wait for, e.g. staging-healthy; the resource of
+	// the same name is checked unless `resource` or `http` is given
+	WaitFor string `yaml:"wait_for,omitempty" json:"wait_for,omitempty" mapstructure:"wait_for"`
+	// endpoint to poll instead of checking a resource
+	HTTP *WaitForHTTPConfig `yaml:"http,omitempty" json:"http,omitempty" mapstructure:"http"`
+	// how long to wait between polls, e.g. 30s
+	Interval string `yaml:"interval,omitempty" json:"interval,omitempty" mapstructure:"interval"`
+
+	// corresponds to a Promote plan
+	// name of the promoted artifact, e.g. prod-image; the version is put to the
+	// resource of the same name unless `resource` is given
+	Promote string `yaml:"promote,omitempty" json:"promote,omitempty" mapstructure:"promote"`
+	// resource whose version is promoted, e.g. staging-image; it must be
+	// fetched by a get step in the same job
+	From string `yaml:"from,omitempty" json:"from,omitempty" mapstructure:"from"`
+
+	// corresponds to a SetVar plan
+	// name of the build variable to set, e.g. release-version; later steps of
+	// the build may interpolate it as ((release-version))
+	SetVar string `yaml:"set_var,omitempty" json:"set_var,omitempty" mapstructure:"set_var"`
+	// value of the variable, which may itself interpolate variables
+	Value string `yaml:"value,omitempty" json:"value,omitempty" mapstructure:"value"`
+
 	// used on any step to interrupt the step after a given duration
 	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
 
@@ -283,6 +331,9 @@ type PlanConfig struct {
 	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
 
 	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
+
+	// used on any step to organize it in build viewers
+	Display *StepDisplay `yaml:"display,omitempty" json:"display,omitempty" mapstructure:"display"`
 }
 
 func (config PlanConfig) Name() string {
@@ -302,6 +353,26 @@ func (config PlanConfig) Name() string {
 		return config.Task
 	}
 
+	if config.SaveCache != "" {
+		return config.SaveCache
+	}
+
+	if config.RestoreCache != "" {
+		return config.RestoreCache
+	}
+
+	if config.WaitFor != "" {
+		return config.WaitFor
+	}
+
+	if config.Promote != "" {
+		return config.Promote
+	}
+
+	if config.SetVar != "" {
+		return config.SetVar
+	}
+
 	return ""
 }
 
@@ -321,6 +392,16 @@ func (config PlanConfig) ResourceName() string {
 		return resourceName
 	}
 
+	resourceName = config.WaitFor
+	if resourceName != "" {
+		return resourceName
+	}
+
+	resourceName = config.Promote
+	if resourceName != "" {
+		return resourceName
+	}
+
 	panic("no resource name!")
 }
 
@@ -328,6 +409,26 @@ func (config PlanConfig) Hooks() Hooks {
 	return Hooks{Abort: config.Abort, Failure: config.Failure, Ensure: config.Ensure, Success: config.Success}
 }
 
+// WaitForHTTPConfig configures a wait_for step to poll an HTTP endpoint until
+// it responds with the expected status.
+type WaitForHTTPConfig struct {
+	// url to GET, e.g. https://staging.example.com/health
+	URL string `yaml:"url" json:"url" mapstructure:"url"`
+	// status to wait for; any 2xx status if omitted
+	Status int `yaml:"status,omitempty" json:"status,omitempty" mapstructure:"status"`
+}
+
+// StepDisplay is metadata for build viewers on how to present a step and the
+// steps nested within it. It has no effect on how the step is run.
+type StepDisplay struct {
+	// name of the group to show the step under, e.g. integration tests
+	Group string `yaml:"group,omitempty" json:"group,omitempty" mapstructure:"group"`
+	// whether to collapse the step's output until it is expanded
+	Collapsed bool `yaml:"collapsed,omitempty" json:"collapsed,omitempty" mapstructure:"collapsed"`
+	// text to show alongside the step
+	Description string `yaml:"description,omitempty" json:"description,omitempty" mapstructure:"description"`
+}
+
 type ResourceConfigs []ResourceConfig
 
 func (resources ResourceConfigs) Lookup(name string) (ResourceConfig, bool) {
@@ -0,0 +1,134 @@
+package atc
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// ConfigRevision is a config a pipeline was set to, along with who set it and
+// how it differed from the revision before it.
+type ConfigRevision struct {
+	ID            int            `json:"id"`
+	ConfigVersion int            `json:"config_version"`
+	Config        *Config        `json:"config,omitempty"`
+	Diff          []ConfigChange `json:"diff"`
+	Author        string         `json:"author,omitempty"`
+	CreatedAt     int64          `json:"created_at"`
+}
+
+type ConfigChangeType string
+
+const (
+	ConfigChangeAdded   ConfigChangeType = "added"
+	ConfigChangeRemoved ConfigChangeType = "removed"
+	ConfigChangeChanged ConfigChangeType = "changed"
+)
+
+// ConfigChange is a group, resource, resource type or job which was added,
+// removed or changed from one config to another. Changes to the pipeline's
+// labels or build log retention have no name.
+type ConfigChange struct {
+	Kind   string           `json:"kind"`
+	Name   string           `json:"name,omitempty"`
+	Change ConfigChangeType `json:"change"`
+}
+
+// DiffConfigs returns what changed from one config to the other, in the
+// order they are declared in each config.
+func DiffConfigs(from Config, to Config) []ConfigChange {
+	changes := []ConfigChange{}
+	changes = append(changes, diffNamed("group", namedGroups(from), namedGroups(to))...)
+	changes = append(changes, diffNamed("resource", namedResources(from), namedResources(to))...)
+	changes = append(changes, diffNamed("resource_type", namedResourceTypes(from), namedResourceTypes(to))...)
+	changes = append(changes, diffNamed("job", namedJobs(from), namedJobs(to))...)
+
+	if !sameConfig(from.Labels, to.Labels) {
+		changes = append(changes, ConfigChange{Kind: "labels", Change: ConfigChangeChanged})
+	}
+
+	if !sameConfig(from.BuildLogRetention, to.BuildLogRetention) {
+		changes = append(changes, ConfigChange{Kind: "build_log_retention", Change: ConfigChangeChanged})
+	}
+
+	return changes
+}
+
+// sameConfig compares configs by their JSON, as a config decoded from YAML may
+// hold e.g. integers where the same config decoded from JSON holds floats.
+func sameConfig(a interface{}, b interface{}) bool {
+	aPayload, aErr := json.Marshal(a)
+	bPayload, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aPayload, bPayload)
+}
+
+type namedConfig struct {
+	name   string
+	config interface{}
+}
+
+func diffNamed(kind string, from []namedConfig, to []namedConfig) []ConfigChange {
+	changes := []ConfigChange{}
+
+	for _, previous := range from {
+		if _, found := findNamed(to, previous.name); !found {
+			changes = append(changes, ConfigChange{Kind: kind, Name: previous.name, Change: ConfigChangeRemoved})
+		}
+	}
+
+	for _, current := range to {
+		previous, found := findNamed(from, current.name)
+		if !found {
+			changes = append(changes, ConfigChange{Kind: kind, Name: current.name, Change: ConfigChangeAdded})
+		} else if !sameConfig(previous.config, current.config) {
+			changes = append(changes, ConfigChange{Kind: kind, Name: current.name, Change: ConfigChangeChanged})
+		}
+	}
+
+	return changes
+}
+
+func findNamed(configs []namedConfig, name string) (namedConfig, bool) {
+	for _, config := range configs {
+		if config.name == name {
+			return config, true
+		}
+	}
+
+	return namedConfig{}, false
+}
+
+func namedGroups(config Config) []namedConfig {
+	named := []namedConfig{}
+	for _, group := range config.Groups {
+		named = append(named, namedConfig{group.Name, group})
+	}
+
+	return named
+}
+
+func namedResources(config Config) []namedConfig {
+	named := []namedConfig{}
+	for _, resource := range config.Resources {
+		named = append(named, namedConfig{resource.Name, resource})
+	}
+
+	return named
+}
+
+func namedResourceTypes(config Config) []namedConfig {
+	named := []namedConfig{}
+	for _, resourceType := range config.ResourceTypes {
+		named = append(named, namedConfig{resourceType.Name, resourceType})
+	}
+
+	return named
+}
+
+func namedJobs(config Config) []namedConfig {
+	named := []namedConfig{}
+	for _, job := range config.Jobs {
+		named = append(named, namedConfig{job.Name, job})
+	}
+
+	return named
+}
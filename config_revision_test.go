@@ -0,0 +1,62 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffConfigs", func() {
+	var from Config
+
+	BeforeEach(func() {
+		from = Config{
+			Resources: ResourceConfigs{
+				{Name: "some-resource", Type: "git", Source: Source{"uri": "some-uri"}},
+				{Name: "removed-resource", Type: "git"},
+			},
+			Jobs: JobConfigs{
+				{Name: "some-job", Serial: true},
+				{Name: "unchanged-job"},
+			},
+		}
+	})
+
+	It("returns nothing for the same config", func() {
+		Expect(DiffConfigs(from, from)).To(BeEmpty())
+	})
+
+	It("returns what was added, removed and changed", func() {
+		to := Config{
+			Resources: ResourceConfigs{
+				{Name: "some-resource", Type: "git", Source: Source{"uri": "other-uri"}},
+			},
+			Jobs: JobConfigs{
+				{Name: "some-job", Serial: true},
+				{Name: "unchanged-job"},
+				{Name: "added-job"},
+			},
+			Labels: Labels{"env": "staging"},
+		}
+
+		Expect(DiffConfigs(from, to)).To(Equal([]ConfigChange{
+			{Kind: "resource", Name: "removed-resource", Change: ConfigChangeRemoved},
+			{Kind: "resource", Name: "some-resource", Change: ConfigChangeChanged},
+			{Kind: "job", Name: "added-job", Change: ConfigChangeAdded},
+			{Kind: "labels", Change: ConfigChangeChanged},
+		}))
+	})
+
+	It("does not consider numbers decoded differently to have changed", func() {
+		from.Resources[0].Source = Source{"depth": 1}
+
+		to := from
+		to.Resources = ResourceConfigs{
+			{Name: "some-resource", Type: "git", Source: Source{"depth": float64(1)}},
+			from.Resources[1],
+		}
+
+		Expect(DiffConfigs(from, to)).To(BeEmpty())
+	})
+})
@@ -24,3 +24,23 @@ type Container struct {
 	User             string `json:"user,omitempty"`
 	WorkingDirectory string `json:"working_directory,omitempty"`
 }
+
+// WorkerContainer is a container on a worker along with the team it belongs
+// to and the volumes mounted in it, for finding out what is using up the
+// worker.
+type WorkerContainer struct {
+	Container
+
+	State    string                  `json:"state"`
+	TeamName string                  `json:"team_name,omitempty"`
+	Volumes  []WorkerContainerVolume `json:"volumes"`
+}
+
+// WorkerContainerVolume is a volume mounted in a container. The parent is the
+// cache or other volume it is a copy-on-write of, if any.
+type WorkerContainerVolume struct {
+	ID           string `json:"id"`
+	Path         string `json:"path"`
+	ParentHandle string `json:"parent_handle,omitempty"`
+	Size         int64  `json:"size,omitempty"`
+}
@@ -0,0 +1,34 @@
+package atc
+
+// ContainerUsage is the resource usage of a container, as reported by its
+// worker with each heartbeat.
+type ContainerUsage struct {
+	Handle string `json:"handle"`
+
+	// CPUUsage is the CPU time used by the container so far, in nanoseconds.
+	CPUUsage uint64 `json:"cpu_usage"`
+
+	// MemoryUsage and DiskUsage are in bytes.
+	MemoryUsage uint64 `json:"memory_usage"`
+	DiskUsage   uint64 `json:"disk_usage"`
+}
+
+// BuildContainerUsage is the resource usage recorded for one of a build's
+// containers, along with the step it ran.
+type BuildContainerUsage struct {
+	Handle   string `json:"handle"`
+	PlanID   PlanID `json:"plan_id"`
+	StepName string `json:"step_name"`
+	Type     string `json:"type"`
+
+	// Samples are the most recent usages reported for the container, oldest
+	// first.
+	Samples []ContainerUsageSample `json:"samples"`
+}
+
+type ContainerUsageSample struct {
+	Time        int64  `json:"time"`
+	CPUUsage    uint64 `json:"cpu_usage"`
+	MemoryUsage uint64 `json:"memory_usage"`
+	DiskUsage   uint64 `json:"disk_usage"`
+}
@@ -0,0 +1,24 @@
+package creds
+
+type String struct {
+	variablesResolver Variables
+	rawString         string
+}
+
+func NewString(variables Variables, str string) String {
+	return String{
+		variablesResolver: variables,
+		rawString:         str,
+	}
+}
+
+func (s String) Evaluate() (string, error) {
+	var str string
+
+	err := evaluate(s.variablesResolver, s.rawString, &str)
+	if err != nil {
+		return "", err
+	}
+
+	return str, nil
+}
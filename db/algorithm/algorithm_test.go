@@ -819,4 +819,106 @@ var _ = DescribeTable("Input resolving",
 			},
 		},
 	}),
+
+	Entry("resolves the latest version carrying the required annotations", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+			Annotations: []AnnotationRow{
+				{Version: "rxv1", Name: "qa", Value: "passed"},
+				{Version: "rxv2", Name: "qa", Value: "passed"},
+				{Version: "rxv3", Name: "qa", Value: "failed"},
+			},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", Annotated: map[string]string{"qa": "passed"}},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
+	Entry("requires annotations in addition to passed constraints", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "simple-a", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "simple-a", BuildID: 3, Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+			Annotations: []AnnotationRow{
+				{Version: "rxv1", Name: "qa", Value: "passed"},
+				{Version: "rxv3", Name: "change", Value: "approved"},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:      "resource-x",
+				Resource:  "resource-x",
+				Passed:    []string{"simple-a"},
+				Annotated: map[string]string{"qa": "passed"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+			},
+		},
+	}),
+
+	Entry("does not resolve a version when none carries the required annotations", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			},
+			Annotations: []AnnotationRow{
+				{Version: "rxv1", Name: "qa", Value: "pending"},
+			},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", Annotated: map[string]string{"qa": "passed"}},
+		},
+
+		Result: Result{
+			OK:     false,
+			Values: map[string]string{},
+		},
+	}),
+
+	Entry("does not resolve a pinned version that lacks the required annotations", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+			Annotations: []AnnotationRow{
+				{Version: "rxv2", Name: "qa", Value: "passed"},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:      "resource-x",
+				Resource:  "resource-x",
+				Version:   Version{Pinned: "rxv1"},
+				Annotated: map[string]string{"qa": "passed"},
+			},
+		},
+
+		Result: Result{
+			OK:     false,
+			Values: map[string]string{},
+		},
+	}),
 )
@@ -1,11 +1,19 @@
 package algorithm
 
 type VersionsDB struct {
+	// Generation identifies the state of the pipeline the versions were
+	// loaded from: versions loaded with the same non-zero generation are the
+	// same. Zero means it is unknown.
+	Generation int64
+
 	ResourceVersions []ResourceVersion
 	BuildOutputs     []BuildOutput
 	BuildInputs      []BuildInput
 	JobIDs           map[string]int
 	ResourceIDs      map[string]int
+
+	// annotations of each version, keyed by version ID and then by name
+	VersionAnnotations map[int]map[string]string
 }
 
 type ResourceVersion struct {
@@ -70,6 +78,36 @@ func (db VersionsDB) LatestVersionOfResource(resourceID int) (VersionCandidate,
 	return candidate, found
 }
 
+func (db VersionsDB) LatestAnnotatedVersionOfResource(resourceID int, annotations map[string]string) (VersionCandidate, bool) {
+	var candidate VersionCandidate
+	var found bool
+
+	for _, v := range db.ResourceVersions {
+		if v.ResourceID == resourceID && v.CheckOrder > candidate.CheckOrder && db.IsVersionAnnotated(v.VersionID, annotations) {
+			candidate = VersionCandidate{
+				VersionID:  v.VersionID,
+				CheckOrder: v.CheckOrder,
+			}
+
+			found = true
+		}
+	}
+
+	return candidate, found
+}
+
+// IsVersionAnnotated returns whether the version carries every one of the
+// given annotations with the given value.
+func (db VersionsDB) IsVersionAnnotated(versionID int, annotations map[string]string) bool {
+	for name, value := range annotations {
+		if db.VersionAnnotations[versionID][name] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (db VersionsDB) FindVersionOfResource(resourceID int, versionID int) (VersionCandidate, bool) {
 	var candidate VersionCandidate
 	var found bool
@@ -6,6 +6,7 @@ type InputConfig struct {
 	Name            string
 	JobName         string
 	Passed          JobSet
+	Annotated       map[string]string
 	UseEveryVersion bool
 	PinnedVersionID int
 	ResourceID      int
@@ -28,6 +29,8 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 
 				if inputConfig.PinnedVersionID != 0 {
 					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
+				} else if len(inputConfig.Annotated) != 0 {
+					versionCandidate, found = db.LatestAnnotatedVersionOfResource(inputConfig.ResourceID, inputConfig.Annotated)
 				} else {
 					versionCandidate, found = db.LatestVersionOfResource(inputConfig.ResourceID)
 				}
@@ -53,6 +56,14 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 			}
 		}
 
+		if len(inputConfig.Annotated) != 0 {
+			versionCandidates = versionCandidates.Annotated(db, inputConfig.Annotated)
+
+			if versionCandidates.IsEmpty() {
+				return nil, false
+			}
+		}
+
 		existingBuildResolver := &ExistingBuildResolver{
 			BuildInputs: db.BuildInputs,
 			JobID:       inputConfig.JobID,
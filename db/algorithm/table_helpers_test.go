@@ -14,6 +14,13 @@ type DB struct {
 	BuildInputs  []DBRow
 	BuildOutputs []DBRow
 	Resources    []DBRow
+	Annotations  []AnnotationRow
+}
+
+type AnnotationRow struct {
+	Version string
+	Name    string
+	Value   string
 }
 
 type DBRow struct {
@@ -35,10 +42,11 @@ type Example struct {
 type Inputs []Input
 
 type Input struct {
-	Name     string
-	Resource string
-	Passed   []string
-	Version  Version
+	Name      string
+	Resource  string
+	Passed    []string
+	Annotated map[string]string
+	Version   Version
 }
 
 type Version struct {
@@ -138,6 +146,18 @@ func (example Example) Run() {
 				JobID:           jobIDs.ID(row.Job),
 			})
 		}
+		for _, row := range example.DB.Annotations {
+			if db.VersionAnnotations == nil {
+				db.VersionAnnotations = map[int]map[string]string{}
+			}
+
+			versionID := versionIDs.ID(row.Version)
+			if db.VersionAnnotations[versionID] == nil {
+				db.VersionAnnotations[versionID] = map[string]string{}
+			}
+
+			db.VersionAnnotations[versionID][row.Name] = row.Value
+		}
 	}
 
 	inputConfigs := make(algorithm.InputConfigs, len(example.Inputs))
@@ -155,6 +175,7 @@ func (example Example) Run() {
 		inputConfigs[i] = algorithm.InputConfig{
 			Name:            input.Name,
 			Passed:          passed,
+			Annotated:       input.Annotated,
 			ResourceID:      resourceIDs.ID(input.Resource),
 			UseEveryVersion: input.Version.Every,
 			PinnedVersionID: versionID,
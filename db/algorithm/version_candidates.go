@@ -103,6 +103,19 @@ func (candidates VersionCandidates) PruneVersionsOfOtherBuildIDs(jobID int, buil
 	return newCandidates
 }
 
+// Annotated returns the candidates whose version carries every one of the
+// given annotations.
+func (candidates VersionCandidates) Annotated(db *VersionsDB, annotations map[string]string) VersionCandidates {
+	newCandidates := VersionCandidates{constraints: candidates.constraints}
+	for _, version := range candidates.versions {
+		if db.IsVersionAnnotated(version.id, annotations) {
+			newCandidates.Merge(version)
+		}
+	}
+
+	return newCandidates
+}
+
 type VersionsIter struct {
 	offset      int
 	versions    Versions
@@ -0,0 +1,22 @@
+package db
+
+import "github.com/concourse/atc/db/eventarchive"
+
+// ArchiveEvents returns a Conn whose builds can offload their events to the
+// given store once completed, and read them back from it.
+func ArchiveEvents(conn Conn, store eventarchive.Store) Conn {
+	return &archiveConn{
+		Conn:  conn,
+		store: store,
+	}
+}
+
+type archiveConn struct {
+	Conn
+
+	store eventarchive.Store
+}
+
+func (c *archiveConn) EventArchive() eventarchive.Store {
+	return c.store
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// AuditEvent records an action taken through the API on behalf of a team.
+type AuditEvent struct {
+	ID         int
+	TeamID     int
+	TeamName   string
+	Requester  string
+	Action     string
+	Subject    map[string]string
+	RemoteAddr string
+	CreatedAt  time.Time
+}
+
+//go:generate counterfeiter . AuditLog
+
+type AuditLog interface {
+	Record(AuditEvent) error
+	TeamEvents(teamID int, page Page) ([]AuditEvent, Pagination, error)
+}
+
+type auditLog struct {
+	conn Conn
+}
+
+// NewAuditLog returns an AuditLog of who did what to each team. Events are
+// keyed by team ID, so that a team keeps its history when renamed and a new
+// team with a destroyed team's name does not inherit it. Events outlive the
+// team they record.
+func NewAuditLog(conn Conn) AuditLog {
+	return &auditLog{
+		conn: conn,
+	}
+}
+
+var auditEventsQuery = psql.Select("id", "team_id", "team_name", "requester", "action", "subject", "remote_addr", "created_at").
+	From("audit_events")
+
+func (l *auditLog) Record(event AuditEvent) error {
+	subject := event.Subject
+	if subject == nil {
+		subject = map[string]string{}
+	}
+
+	subjectPayload, err := json.Marshal(subject)
+	if err != nil {
+		return err
+	}
+
+	// events recorded by team name are for whichever team has that name now
+	var teamID interface{} = event.TeamID
+	if event.TeamID == 0 {
+		teamID = sq.Expr("(SELECT id FROM teams WHERE name = ?)", event.TeamName)
+	}
+
+	_, err = psql.Insert("audit_events").
+		Columns("team_id", "team_name", "requester", "action", "subject", "remote_addr").
+		Values(teamID, event.TeamName, event.Requester, event.Action, subjectPayload, event.RemoteAddr).
+		RunWith(l.conn).
+		Exec()
+	return err
+}
+
+func (l *auditLog) TeamEvents(teamID int, page Page) ([]AuditEvent, Pagination, error) {
+	scope := auditEventsQuery.Where(sq.Eq{"team_id": teamID})
+
+	query := scope
+	var reverse bool
+	if page.Until != 0 {
+		query = query.Where(sq.Gt{"id": page.Until}).OrderBy("id ASC")
+		reverse = true
+	} else if page.Since != 0 {
+		query = query.Where(sq.Lt{"id": page.Since}).OrderBy("id DESC")
+	} else {
+		query = query.OrderBy("id DESC")
+	}
+
+	rows, err := query.Limit(uint64(page.Limit)).RunWith(l.conn).Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Close(rows)
+
+	events := []AuditEvent{}
+	for rows.Next() {
+		var event AuditEvent
+		var subject []byte
+
+		err = rows.Scan(&event.ID, &event.TeamID, &event.TeamName, &event.Requester, &event.Action, &subject, &event.RemoteAddr, &event.CreatedAt)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		err = json.Unmarshal(subject, &event.Subject)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		if reverse {
+			events = append([]AuditEvent{event}, events...)
+		} else {
+			events = append(events, event)
+		}
+	}
+
+	if len(events) == 0 {
+		return events, Pagination{}, nil
+	}
+
+	first := events[0]
+	last := events[len(events)-1]
+
+	var pagination Pagination
+
+	var newer bool
+	err = l.conn.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM audit_events WHERE team_id = $1 AND id > $2)
+	`, teamID, first.ID).Scan(&newer)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	if newer {
+		pagination.Previous = &Page{
+			Until: first.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	var older bool
+	err = l.conn.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM audit_events WHERE team_id = $1 AND id < $2)
+	`, teamID, last.ID).Scan(&older)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	if older {
+		pagination.Next = &Page{
+			Since: last.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	return events, pagination, nil
+}
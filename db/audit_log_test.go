@@ -0,0 +1,138 @@
+package db_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditLog", func() {
+	var (
+		auditLog db.AuditLog
+
+		team      db.Team
+		otherTeam db.Team
+	)
+
+	BeforeEach(func() {
+		auditLog = db.NewAuditLog(dbConn)
+
+		var err error
+		team, err = teamFactory.CreateTeam(atc.Team{Name: "some-team"})
+		Expect(err).ToNot(HaveOccurred())
+
+		otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Record", func() {
+		It("records the event for the team", func() {
+			err := auditLog.Record(db.AuditEvent{
+				TeamID:     team.ID(),
+				TeamName:   "some-team",
+				Requester:  "some-requester",
+				Action:     "SaveConfig",
+				Subject:    map[string]string{"pipeline_name": "some-pipeline"},
+				RemoteAddr: "1.2.3.4",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			events, _, err := auditLog.TeamEvents(team.ID(), db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+
+			Expect(events[0].TeamID).To(Equal(team.ID()))
+			Expect(events[0].TeamName).To(Equal("some-team"))
+			Expect(events[0].Requester).To(Equal("some-requester"))
+			Expect(events[0].Action).To(Equal("SaveConfig"))
+			Expect(events[0].Subject).To(Equal(map[string]string{"pipeline_name": "some-pipeline"}))
+			Expect(events[0].RemoteAddr).To(Equal("1.2.3.4"))
+			Expect(events[0].CreatedAt).ToNot(BeZero())
+		})
+
+		Context("when only the team name is given", func() {
+			It("records the event for the team with that name", func() {
+				err := auditLog.Record(db.AuditEvent{TeamName: "some-team", Requester: "some-team", Action: "PauseJob"})
+				Expect(err).ToNot(HaveOccurred())
+
+				events, _, err := auditLog.TeamEvents(team.ID(), db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(events).To(HaveLen(1))
+				Expect(events[0].TeamID).To(Equal(team.ID()))
+			})
+		})
+	})
+
+	Describe("TeamEvents", func() {
+		var ids []int
+
+		BeforeEach(func() {
+			ids = nil
+
+			for i := 0; i < 3; i++ {
+				err := auditLog.Record(db.AuditEvent{TeamID: team.ID(), TeamName: "some-team", Requester: "some-team", Action: "PauseJob"})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = auditLog.Record(db.AuditEvent{TeamID: otherTeam.ID(), TeamName: "other-team", Requester: "other-team", Action: "PauseJob"})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			events, _, err := auditLog.TeamEvents(team.ID(), db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+		})
+
+		It("returns only the team's events, newest first", func() {
+			Expect(ids).To(HaveLen(3))
+			Expect(ids[0]).To(BeNumerically(">", ids[1]))
+			Expect(ids[1]).To(BeNumerically(">", ids[2]))
+		})
+
+		It("keeps the team's events when it is renamed", func() {
+			Expect(team.Rename("renamed-team")).To(Succeed())
+
+			events, _, err := auditLog.TeamEvents(team.ID(), db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(3))
+		})
+
+		It("does not give a destroyed team's events to a new team with its name", func() {
+			Expect(team.Delete()).To(Succeed())
+
+			newTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			events, _, err := auditLog.TeamEvents(newTeam.ID(), db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		})
+
+		It("paginates the team's events", func() {
+			events, pagination, err := auditLog.TeamEvents(team.ID(), db.Page{Limit: 2})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].ID).To(Equal(ids[0]))
+			Expect(events[1].ID).To(Equal(ids[1]))
+			Expect(pagination.Previous).To(BeNil())
+			Expect(pagination.Next).To(Equal(&db.Page{Since: ids[1], Limit: 2}))
+
+			events, pagination, err = auditLog.TeamEvents(team.ID(), *pagination.Next)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].ID).To(Equal(ids[2]))
+			Expect(pagination.Previous).To(Equal(&db.Page{Until: ids[2], Limit: 2}))
+			Expect(pagination.Next).To(BeNil())
+
+			events, pagination, err = auditLog.TeamEvents(team.ID(), *pagination.Previous)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].ID).To(Equal(ids[0]))
+			Expect(events[1].ID).To(Equal(ids[1]))
+		})
+	})
+})
@@ -26,9 +26,68 @@ const (
 	BuildStatusSucceeded BuildStatus = "succeeded"
 	BuildStatusFailed    BuildStatus = "failed"
 	BuildStatusErrored   BuildStatus = "errored"
+
+	// BuildStatusPipelineDestroyed is for builds which were still running
+	// when their pipeline was destroyed. They are kept, archived under the
+	// team, rather than being deleted along with the pipeline.
+	BuildStatusPipelineDestroyed BuildStatus = "pipeline-destroyed"
+)
+
+type BuildTriggerType string
+
+const (
+	BuildTriggerInput  BuildTriggerType = "input"
+	BuildTriggerManual BuildTriggerType = "manual"
+	BuildTriggerRerun  BuildTriggerType = "rerun"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.public_plan, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.tracked_by").
+// BuildTriggerCause records why a build was created: either a new version of
+// one of the job's trigger inputs, or a request made through the API.
+// Builds created before causes were recorded have a zero value.
+type BuildTriggerCause struct {
+	Type BuildTriggerType `json:"type"`
+
+	Resource string          `json:"resource,omitempty"`
+	Version  ResourceVersion `json:"version,omitempty"`
+
+	Requester string `json:"requester,omitempty"`
+}
+
+func (cause BuildTriggerCause) value() (interface{}, error) {
+	if cause.Type == "" {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(cause)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(payload), nil
+}
+
+// BuildAbortCause records who aborted a build, either a team through the API
+// or the system, and why. Builds which were not aborted, or were aborted
+// before causes were recorded, have a zero value.
+type BuildAbortCause struct {
+	Requester string `json:"requester"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+func (cause BuildAbortCause) value() (interface{}, error) {
+	if cause == (BuildAbortCause{}) {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(cause)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(payload), nil
+}
+
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.trigger_cause, b.abort_cause, b.rerun_of, b.rerun_from, b.scheduled, b.engine, b.engine_metadata, b.public_plan, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.tracked_by, b.heartbeat, b.orphaned, b.archived_from, b.events_archived").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
@@ -53,14 +112,32 @@ type Build interface {
 	EndTime() time.Time
 	ReapTime() time.Time
 	Tracker() string
+	LastHeartbeat() time.Time
+	IsOrphaned() bool
 	IsManuallyTriggered() bool
+	TriggerCause() BuildTriggerCause
+	AbortCause() BuildAbortCause
+	RerunOf() int
+	RerunFrom() atc.PlanID
+
+	// RerunOfBuild returns the build that the build reruns, if any.
+	RerunOfBuild() (Build, bool, error)
 	IsScheduled() bool
 	IsRunning() bool
 
+	// ArchivedFrom is the "pipeline/job" the build ran in, if it was archived
+	// as its pipeline was destroyed.
+	ArchivedFrom() string
+
+	// EventsArchived is whether the build's events have been offloaded from
+	// the database to the event archive.
+	EventsArchived() bool
+
 	Reload() (bool, error)
 
 	AcquireTrackingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error)
 	TrackedBy(peerURL string) error
+	Heartbeat() error
 
 	Interceptible() (bool, error)
 	Preparation() (BuildPreparation, bool, error)
@@ -74,6 +151,15 @@ type Build interface {
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
 
+	// SaveEvents saves the events in order within a single transaction,
+	// notifying subscribers once they have all been saved.
+	SaveEvents(events []atc.Event) error
+
+	// ArchiveEvents offloads the events of the completed build to the event
+	// archive, deleting them from the database. Events are read back from the
+	// archive from then on.
+	ArchiveEvents() error
+
 	SaveInput(input BuildInput) error
 	SaveOutput(vr VersionedResource) error
 	UseInputs(inputs []BuildInput) error
@@ -85,9 +171,28 @@ type Build interface {
 	Pipeline() (Pipeline, bool, error)
 
 	Delete() (bool, error)
-	MarkAsAborted() error
+	MarkAsAborted(cause BuildAbortCause) error
 	AbortNotifier() (Notifier, error)
 	Schedule() (bool, error)
+
+	SchedulingCheckpoint() (BuildSchedulingCheckpoint, error)
+	SaveSchedulingCheckpoint(BuildSchedulingCheckpoint) error
+
+	StepStates() (map[atc.PlanID]json.RawMessage, error)
+	SaveStepState(atc.PlanID, json.RawMessage) error
+
+	Rerun(from atc.PlanID, reused []atc.PlanID, cause BuildTriggerCause) (Build, error)
+
+	EventCheckpoint(subscriber string) (uint, bool, error)
+	SaveEventCheckpoint(subscriber string, eventID uint) error
+
+	Environment() (atc.BuildEnvironment, bool, error)
+	SaveEnvironment(atc.BuildEnvironment) error
+	WorkerNames() ([]string, error)
+
+	ContainerUsages() ([]atc.BuildContainerUsage, error)
+
+	ReplayContainer(planID atc.PlanID) (CreatedContainer, bool, error)
 }
 
 type build struct {
@@ -105,6 +210,11 @@ type build struct {
 	jobName      string
 
 	isManuallyTriggered bool
+	triggerCause        BuildTriggerCause
+	abortCause          BuildAbortCause
+
+	rerunOf   int
+	rerunFrom atc.PlanID
 
 	engine         string
 	engineMetadata string
@@ -114,32 +224,48 @@ type build struct {
 	endTime   time.Time
 	reapTime  time.Time
 
-	trackedBy string
+	trackedBy     string
+	lastHeartbeat time.Time
+	orphaned      bool
+
+	archivedFrom   string
+	eventsArchived bool
 
 	conn        Conn
 	lockFactory lock.LockFactory
 }
 
 var ErrBuildDisappeared = errors.New("build-disappeared-from-db")
-
-func (b *build) ID() int                      { return b.id }
-func (b *build) Name() string                 { return b.name }
-func (b *build) JobID() int                   { return b.jobID }
-func (b *build) JobName() string              { return b.jobName }
-func (b *build) PipelineID() int              { return b.pipelineID }
-func (b *build) PipelineName() string         { return b.pipelineName }
-func (b *build) TeamID() int                  { return b.teamID }
-func (b *build) TeamName() string             { return b.teamName }
-func (b *build) IsManuallyTriggered() bool    { return b.isManuallyTriggered }
-func (b *build) Engine() string               { return b.engine }
-func (b *build) EngineMetadata() string       { return b.engineMetadata }
-func (b *build) PublicPlan() *json.RawMessage { return b.publicPlan }
-func (b *build) StartTime() time.Time         { return b.startTime }
-func (b *build) EndTime() time.Time           { return b.endTime }
-func (b *build) ReapTime() time.Time          { return b.reapTime }
-func (b *build) Status() BuildStatus          { return b.status }
-func (b *build) Tracker() string              { return b.trackedBy }
-func (b *build) IsScheduled() bool            { return b.scheduled }
+var ErrBuildNotCompleted = errors.New("build-not-completed")
+var ErrNoEventArchive = errors.New("build events are archived, but no event archive is configured")
+var ErrBuildEnvironmentAlreadySaved = errors.New("build-environment-already-saved")
+
+func (b *build) ID() int                         { return b.id }
+func (b *build) Name() string                    { return b.name }
+func (b *build) JobID() int                      { return b.jobID }
+func (b *build) JobName() string                 { return b.jobName }
+func (b *build) PipelineID() int                 { return b.pipelineID }
+func (b *build) PipelineName() string            { return b.pipelineName }
+func (b *build) TeamID() int                     { return b.teamID }
+func (b *build) TeamName() string                { return b.teamName }
+func (b *build) IsManuallyTriggered() bool       { return b.isManuallyTriggered }
+func (b *build) TriggerCause() BuildTriggerCause { return b.triggerCause }
+func (b *build) AbortCause() BuildAbortCause     { return b.abortCause }
+func (b *build) RerunOf() int                    { return b.rerunOf }
+func (b *build) RerunFrom() atc.PlanID           { return b.rerunFrom }
+func (b *build) Engine() string                  { return b.engine }
+func (b *build) EngineMetadata() string          { return b.engineMetadata }
+func (b *build) PublicPlan() *json.RawMessage    { return b.publicPlan }
+func (b *build) StartTime() time.Time            { return b.startTime }
+func (b *build) EndTime() time.Time              { return b.endTime }
+func (b *build) ReapTime() time.Time             { return b.reapTime }
+func (b *build) Status() BuildStatus             { return b.status }
+func (b *build) Tracker() string                 { return b.trackedBy }
+func (b *build) LastHeartbeat() time.Time        { return b.lastHeartbeat }
+func (b *build) IsOrphaned() bool                { return b.orphaned }
+func (b *build) IsScheduled() bool               { return b.scheduled }
+func (b *build) ArchivedFrom() string            { return b.archivedFrom }
+func (b *build) EventsArchived() bool            { return b.eventsArchived }
 
 func (b *build) IsRunning() bool {
 	switch b.status {
@@ -263,17 +389,18 @@ func (b *build) Start(engine, metadata string, plan atc.Plan) (bool, error) {
 		return false, err
 	}
 
-	_, err = b.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY next_builds_per_job`)
-	if err != nil {
-		return false, err
-	}
-
 	return true, nil
 }
 
 func (b *build) FinishWithError(cause error) error {
+	kind := atc.ErrorKindUnknown
+	if kinded, ok := cause.(atc.KindedError); ok {
+		kind = kinded.ErrorKind()
+	}
+
 	err := b.SaveEvent(event.Error{
 		Message: cause.Error(),
+		Kind:    kind,
 	})
 	if err != nil {
 		return err
@@ -290,23 +417,38 @@ func (b *build) Finish(status BuildStatus) error {
 
 	defer Rollback(tx)
 
-	var endTime time.Time
+	var (
+		finished string
+		endTime  time.Time
+	)
 
+	// a build whose pipeline was destroyed while it ran keeps that status
 	err = psql.Update("builds").
-		Set("status", status).
+		Set("status", sq.Expr("CASE WHEN status = ? THEN status ELSE ? END", string(BuildStatusPipelineDestroyed), string(status))).
 		Set("end_time", sq.Expr("now()")).
 		Set("completed", true).
 		Set("engine_metadata", nil).
 		Set("nonce", nil).
 		Where(sq.Eq{"id": b.id}).
-		Suffix("RETURNING end_time").
+		Where(sq.Or{
+			sq.NotEq{"status": string(BuildStatusPipelineDestroyed)},
+			sq.Eq{"completed": false},
+		}).
+		Suffix("RETURNING status, end_time").
 		RunWith(tx).
 		QueryRow().
-		Scan(&endTime)
+		Scan(&finished, &endTime)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			// the build was already finished as its pipeline was destroyed
+			return nil
+		}
+
 		return err
 	}
 
+	status = BuildStatus(finished)
+
 	err = b.saveEvent(tx, event.Status{
 		Status: atc.BuildStatus(status),
 		Time:   endTime.Unix(),
@@ -322,6 +464,13 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
+	if b.jobID != 0 && status != BuildStatusPipelineDestroyed {
+		err = updateDashboardBuilds(tx, b.jobID, b.id)
+		if err != nil {
+			return err
+		}
+	}
+
 	if b.jobID != 0 && status == BuildStatusSucceeded {
 		_, err = psql.Delete("build_image_resource_caches birc USING builds b").
 			Where(sq.Expr("birc.build_id = b.id")).
@@ -339,23 +488,7 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
-	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
-	if err != nil {
-		return err
-	}
-
-	_, err = b.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY latest_completed_builds_per_job`)
-	if err != nil {
-		return err
-	}
-
-	_, err = b.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY next_builds_per_job`)
-	if err != nil {
-		return err
-	}
-
-	_, err = b.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY transition_builds_per_job`)
-	return err
+	return b.conn.Bus().Notify(buildEventsChannel(b.id))
 }
 
 func (b *build) Delete() (bool, error) {
@@ -378,6 +511,20 @@ func (b *build) Delete() (bool, error) {
 		return false, ErrBuildDisappeared
 	}
 
+	if b.jobID != 0 {
+		err = refreshDashboardBuilds(b.conn, b.jobID)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if b.eventsArchived {
+		err = deleteArchivedEvents(b.conn, []int{b.id})
+		if err != nil {
+			return false, err
+		}
+	}
+
 	return true, nil
 }
 
@@ -387,26 +534,68 @@ func (b *build) Delete() (bool, error) {
 // notification on abort channel.
 // Setting status as aborted will also make Start() return false in case where
 // build was aborted before it was started.
-func (b *build) MarkAsAborted() error {
-	_, err := psql.Update("builds").
+// The cause is saved with the build and emitted as an event, replacing the
+// cause of any earlier abort.
+func (b *build) MarkAsAborted(cause BuildAbortCause) error {
+	abortCause, err := cause.value()
+	if err != nil {
+		return err
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Update("builds").
 		Set("status", string(BuildStatusAborted)).
+		Set("abort_cause", abortCause).
 		Where(sq.Eq{"id": b.id}).
-		RunWith(b.conn).
+		RunWith(tx).
 		Exec()
 	if err != nil {
 		return err
 	}
 
+	if b.jobID != 0 {
+		err = updateDashboardBuilds(tx, b.jobID, b.id)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = b.saveEvent(tx, event.AbortRequested{
+		Time:      time.Now().Unix(),
+		Requester: cause.Requester,
+		Reason:    cause.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	if err != nil {
+		return err
+	}
+
 	return b.conn.Bus().Notify(buildAbortChannel(b.id))
 }
 
 // AbortNotifier returns a Notifier that can be watched for when the build
-// is marked as aborted. Once the build is marked as aborted it will send a
-// notification to finish the build to ATC that is tracking this build.
+// is marked as aborted, or archived as its pipeline is destroyed. Once the
+// build is marked as aborted it will send a notification to finish the build
+// to ATC that is tracking this build.
 func (b *build) AbortNotifier() (Notifier, error) {
 	return newConditionNotifier(b.conn.Bus(), buildAbortChannel(b.id), func() (bool, error) {
 		var aborted bool
-		err := psql.Select("status = 'aborted'").
+		err := psql.Select("status IN ('aborted', 'pipeline-destroyed')").
 			From("builds").
 			Where(sq.Eq{"id": b.id}).
 			RunWith(b.conn).
@@ -422,17 +611,478 @@ func (b *build) Schedule() (bool, error) {
 		Set("scheduled", true).
 		Where(sq.Eq{"id": b.id}).
 		RunWith(b.conn).
-		Exec()
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+func (b *build) SchedulingCheckpoint() (BuildSchedulingCheckpoint, error) {
+	var checkpoint sql.NullString
+
+	err := psql.Select("scheduling_checkpoint").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&checkpoint)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildSchedulingCheckpointNone, ErrBuildDisappeared
+		}
+		return BuildSchedulingCheckpointNone, err
+	}
+
+	return BuildSchedulingCheckpoint(checkpoint.String), nil
+}
+
+func (b *build) SaveSchedulingCheckpoint(checkpoint BuildSchedulingCheckpoint) error {
+	result, err := psql.Update("builds").
+		Set("scheduling_checkpoint", string(checkpoint)).
+		Where(sq.Eq{
+			"id":     b.id,
+			"status": "pending",
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrBuildDisappeared
+	}
+
+	return nil
+}
+
+// StepStates returns the state saved by each of the build's steps, so that
+// the build can be resumed by another ATC. The state is opaque to the
+// database; it is up to the engine to interpret it.
+func (b *build) StepStates() (map[atc.PlanID]json.RawMessage, error) {
+	rows, err := psql.Select("plan_id", "state").
+		From("build_step_states").
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	states := map[atc.PlanID]json.RawMessage{}
+	for rows.Next() {
+		var planID string
+		var state string
+		err := rows.Scan(&planID, &state)
+		if err != nil {
+			return nil, err
+		}
+
+		states[atc.PlanID(planID)] = json.RawMessage(state)
+	}
+
+	return states, nil
+}
+
+func (b *build) SaveStepState(planID atc.PlanID, state json.RawMessage) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("build_step_states").
+		Set("state", string(state)).
+		Where(sq.Eq{
+			"build_id": b.id,
+			"plan_id":  string(planID),
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		_, err = psql.Insert("build_step_states").
+			Columns("build_id", "plan_id", "state").
+			Values(b.id, string(planID), string(state)).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqFKeyViolationErrCode {
+				return ErrBuildDisappeared
+			}
+
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Rerun creates a pending build which runs the build's plan again from the
+// step with the given plan ID. It belongs to the same job, if any, and uses
+// the same inputs. The scheduler starts a job's rerun once the job can run it.
+//
+// The state saved by the reused steps, i.e. those preceding the one to rerun
+// from, is copied to the new build so that it is restored instead of running
+// them again.
+func (b *build) Rerun(from atc.PlanID, reused []atc.PlanID, cause BuildTriggerCause) (Build, error) {
+	triggerCause, err := cause.value()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	vals := map[string]interface{}{
+		"name":          sq.Expr("nextval('one_off_name')"),
+		"team_id":       b.teamID,
+		"status":        BuildStatusPending,
+		"trigger_cause": triggerCause,
+		"rerun_of":      b.id,
+		"rerun_from":    string(from),
+	}
+
+	if b.pipelineID != 0 {
+		vals["pipeline_id"] = b.pipelineID
+	}
+
+	if b.jobID != 0 {
+		var buildName string
+		err = psql.Update("jobs").
+			Set("build_number_seq", sq.Expr("build_number_seq + 1")).
+			Where(sq.Eq{"id": b.jobID}).
+			Suffix("RETURNING build_number_seq").
+			RunWith(tx).
+			QueryRow().
+			Scan(&buildName)
+		if err != nil {
+			return nil, err
+		}
+
+		vals["name"] = buildName
+		vals["job_id"] = b.jobID
+		vals["manually_triggered"] = true
+	}
+
+	rerun := &build{conn: b.conn, lockFactory: b.lockFactory}
+	err = createBuild(tx, rerun, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.jobID != 0 {
+		err = updateDashboardBuilds(tx, b.jobID, rerun.id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO build_inputs (build_id, versioned_resource_id, name)
+		SELECT $1, versioned_resource_id, name
+		FROM build_inputs
+		WHERE build_id = $2
+	`, rerun.id, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, planID := range reused {
+		_, err = tx.Exec(`
+			INSERT INTO build_step_states (build_id, plan_id, state)
+			SELECT $1, plan_id, state
+			FROM build_step_states
+			WHERE build_id = $2 AND plan_id = $3
+		`, rerun.id, b.id, string(planID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return rerun, nil
+}
+
+// EventCheckpoint returns the ID of the last event delivered to the
+// subscriber, if any has been saved.
+func (b *build) EventCheckpoint(subscriber string) (uint, bool, error) {
+	var eventID uint
+
+	err := psql.Select("event_id").
+		From("build_event_checkpoints").
+		Where(sq.Eq{
+			"build_id":   b.id,
+			"subscriber": subscriber,
+		}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&eventID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	return eventID, true, nil
+}
+
+func (b *build) SaveEventCheckpoint(subscriber string, eventID uint) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("build_event_checkpoints").
+		Set("event_id", eventID).
+		Where(sq.Eq{
+			"build_id":   b.id,
+			"subscriber": subscriber,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		_, err = psql.Insert("build_event_checkpoints").
+			Columns("build_id", "subscriber", "event_id").
+			Values(b.id, subscriber, eventID).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqFKeyViolationErrCode {
+				return ErrBuildDisappeared
+			}
+
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Environment returns the snapshot of the cluster configuration taken as the
+// build finished, if any has been saved.
+func (b *build) Environment() (atc.BuildEnvironment, bool, error) {
+	var payload string
+
+	err := psql.Select("environment").
+		From("build_environments").
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&payload)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.BuildEnvironment{}, false, nil
+		}
+		return atc.BuildEnvironment{}, false, err
+	}
+
+	var environment atc.BuildEnvironment
+	err = json.Unmarshal([]byte(payload), &environment)
+	if err != nil {
+		return atc.BuildEnvironment{}, false, err
+	}
+
+	return environment, true, nil
+}
+
+// SaveEnvironment records the snapshot of the cluster configuration taken as
+// the build finished. The snapshot is kept for audits, so it may only be saved
+// once; ErrBuildEnvironmentAlreadySaved is returned if there already is one.
+func (b *build) SaveEnvironment(environment atc.BuildEnvironment) error {
+	payload, err := json.Marshal(environment)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("build_environments").
+		Columns("build_id", "environment").
+		Values(b.id, string(payload)).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case pqUniqueViolationErrCode:
+				return ErrBuildEnvironmentAlreadySaved
+			case pqFKeyViolationErrCode:
+				return ErrBuildDisappeared
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// ReplayContainer returns the container replaying the step, once it has been
+// created.
+func (b *build) ReplayContainer(planID atc.PlanID) (CreatedContainer, bool, error) {
+	row := selectContainers().
+		Where(sq.Eq{
+			"build_id": b.id,
+			"plan_id":  ReplayPlanID(planID),
+		}).
+		RunWith(b.conn).
+		QueryRow()
+
+	_, createdContainer, _, _, err := scanContainer(row, b.conn)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	if createdContainer == nil {
+		return nil, false, nil
+	}
+
+	return createdContainer, true, nil
+}
+
+// WorkerNames returns the names of the workers the build's containers were
+// created on, in order.
+func (b *build) WorkerNames() ([]string, error) {
+	rows, err := psql.Select("DISTINCT worker_name").
+		From("containers").
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("worker_name").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// ContainerUsages returns the resource usages recorded for the build's
+// containers, in the order they were first reported.
+func (b *build) ContainerUsages() ([]atc.BuildContainerUsage, error) {
+	rows, err := psql.Select(
+		"container_handle",
+		"plan_id",
+		"step_name",
+		"type",
+		"sampled_at",
+		"cpu_usage",
+		"memory_usage",
+		"disk_usage",
+	).
+		From("container_usage_samples").
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("id ASC").
+		RunWith(b.conn).
+		Query()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return false, err
+	defer Close(rows)
+
+	usages := []atc.BuildContainerUsage{}
+	indexes := map[string]int{}
+
+	for rows.Next() {
+		var (
+			usage     atc.BuildContainerUsage
+			sample    atc.ContainerUsageSample
+			sampledAt time.Time
+		)
+
+		err = rows.Scan(
+			&usage.Handle,
+			&usage.PlanID,
+			&usage.StepName,
+			&usage.Type,
+			&sampledAt,
+			&sample.CPUUsage,
+			&sample.MemoryUsage,
+			&sample.DiskUsage,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		sample.Time = sampledAt.Unix()
+
+		i, found := indexes[usage.Handle]
+		if !found {
+			i = len(usages)
+			indexes[usage.Handle] = i
+
+			usage.Samples = []atc.ContainerUsageSample{}
+			usages = append(usages, usage)
+		}
+
+		usages[i].Samples = append(usages[i].Samples, sample)
 	}
 
-	return rows == 1, nil
+	return usages, rows.Err()
 }
 
 func (b *build) Pipeline() (Pipeline, bool, error) {
@@ -457,6 +1107,32 @@ func (b *build) Pipeline() (Pipeline, bool, error) {
 	return pipeline, true, nil
 }
 
+func (b *build) RerunOfBuild() (Build, bool, error) {
+	if b.rerunOf == 0 {
+		return nil, false, nil
+	}
+
+	original := &build{
+		conn:        b.conn,
+		lockFactory: b.lockFactory,
+	}
+
+	row := buildsQuery.
+		Where(sq.Eq{"b.id": b.rerunOf}).
+		RunWith(b.conn).
+		QueryRow()
+
+	err := scanBuild(original, row, b.conn.EncryptionStrategy())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return original, true, nil
+}
+
 func (b *build) SaveImageResourceVersion(rc *UsedResourceCache) error {
 	_, err := psql.Insert("build_image_resource_caches").
 		Columns("resource_cache_id", "build_id").
@@ -492,9 +1168,38 @@ func (b *build) AcquireTrackingLock(logger lager.Logger, interval time.Duration)
 	return lock, true, nil
 }
 
+// TrackedBy records the ATC tracking the build, along with its first
+// heartbeat. An orphaned build is thereby adopted.
 func (b *build) TrackedBy(peerURL string) error {
 	rows, err := psql.Update("builds").
 		Set("tracked_by", peerURL).
+		Set("heartbeat", sq.Expr("now()")).
+		Set("orphaned", false).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrBuildDisappeared
+	}
+
+	return nil
+}
+
+// Heartbeat records that the ATC tracking the build is still running it. A
+// build marked as orphaned in the meantime is reclaimed.
+func (b *build) Heartbeat() error {
+	rows, err := psql.Update("builds").
+		Set("heartbeat", sq.Expr("now()")).
+		Set("orphaned", false).
 		Where(sq.Eq{"id": b.id}).
 		RunWith(b.conn).
 		Exec()
@@ -594,7 +1299,20 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 
 	configInputs := job.Config().Inputs()
 
-	nextBuildInputs, found, err := job.GetNextBuildInputs()
+	checkpoint, err := b.SchedulingCheckpoint()
+	if err != nil {
+		return BuildPreparation{}, false, err
+	}
+
+	var nextBuildInputs []BuildInput
+	if checkpoint.Reached(BuildSchedulingCheckpointInputsResolved) {
+		// the build's inputs have already been chosen; report them rather than
+		// whatever the next inputs for the job are now
+		nextBuildInputs, _, err = b.Resources()
+		found = true
+	} else {
+		nextBuildInputs, found, err = job.GetNextBuildInputs()
+	}
 	if err != nil {
 		return BuildPreparation{}, false, err
 	}
@@ -676,6 +1394,10 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 }
 
 func (b *build) Events(from uint) (EventSource, error) {
+	if b.eventsArchived {
+		return b.archivedEvents(from)
+	}
+
 	notifier, err := newConditionNotifier(b.conn.Bus(), buildEventsChannel(b.id), func() (bool, error) {
 		return true, nil
 	})
@@ -683,20 +1405,207 @@ func (b *build) Events(from uint) (EventSource, error) {
 		return nil, err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-
 	return newBuildEventSource(
 		b.id,
-		table,
+		b.eventsTable(),
 		b.conn,
 		notifier,
 		from,
 	), nil
 }
 
+func (b *build) archivedEvents(from uint) (EventSource, error) {
+	archive := b.conn.EventArchive()
+	if archive == nil {
+		return nil, ErrNoEventArchive
+	}
+
+	payload, err := archive.Get(archivedEventsKey(b.id))
+	if err != nil {
+		return nil, err
+	}
+
+	var events []event.Envelope
+	err = json.Unmarshal(payload, &events)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(from) > len(events) {
+		from = uint(len(events))
+	}
+
+	return newArchivedEventSource(events[from:]), nil
+}
+
+func (b *build) ArchiveEvents() error {
+	archive := b.conn.EventArchive()
+	if archive == nil {
+		return ErrNoEventArchive
+	}
+
+	if b.eventsArchived {
+		return nil
+	}
+
+	if b.IsRunning() {
+		return ErrBuildNotCompleted
+	}
+
+	rows, err := psql.Select("type", "version", "payload", "codec", "compressed_payload").
+		From(b.eventsTable()).
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("event_id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	events := []event.Envelope{}
+	for rows.Next() {
+		var t, v, p string
+		var codec sql.NullString
+		var compressed []byte
+		err := rows.Scan(&t, &v, &p, &codec, &compressed)
+		if err != nil {
+			return err
+		}
+
+		data := json.RawMessage(p)
+		if codec.Valid {
+			data, err = decompressEvent(codec.String, compressed)
+			if err != nil {
+				return err
+			}
+		}
+
+		events = append(events, event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+		})
+	}
+
+	payload, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	err = archive.Put(archivedEventsKey(b.id), payload)
+	if err != nil {
+		return err
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	// events may still be saved for an aborted build until it completes
+	result, err := psql.Update("builds").
+		Set("events_archived", true).
+		Where(sq.Eq{
+			"id":        b.id,
+			"completed": true,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrBuildNotCompleted
+	}
+
+	_, err = psql.Delete(b.eventsTable()).
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.eventsArchived = true
+
+	return nil
+}
+
+func (b *build) eventsTable() string {
+	if b.pipelineID != 0 {
+		return fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	}
+
+	return fmt.Sprintf("team_build_events_%d", b.teamID)
+}
+
+func archivedEventsKey(buildID int) string {
+	return fmt.Sprintf("builds/%d/events.json", buildID)
+}
+
+// archivedEventBuildIDs returns the IDs of the builds matching the condition
+// whose events are in the event archive.
+func archivedEventBuildIDs(tx Tx, condition sq.Sqlizer) ([]int, error) {
+	rows, err := psql.Select("id").
+		From("builds").
+		Where(condition).
+		Where(sq.Eq{"events_archived": true}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var buildIDs []int
+	for rows.Next() {
+		var buildID int
+		err = rows.Scan(&buildID)
+		if err != nil {
+			return nil, err
+		}
+
+		buildIDs = append(buildIDs, buildID)
+	}
+
+	return buildIDs, rows.Err()
+}
+
+// deleteArchivedEvents deletes the events of the builds from the event
+// archive, if one is configured. It is called once the builds no longer refer
+// to their archived events, so that they are never served once deleted.
+func deleteArchivedEvents(conn Conn, buildIDs []int) error {
+	archive := conn.EventArchive()
+	if archive == nil {
+		return nil
+	}
+
+	for _, buildID := range buildIDs {
+		err := archive.Delete(archivedEventsKey(buildID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (b *build) SaveEvent(event atc.Event) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -718,6 +1627,33 @@ func (b *build) SaveEvent(event atc.Event) error {
 	return b.conn.Bus().Notify(buildEventsChannel(b.id))
 }
 
+func (b *build) SaveEvents(events []atc.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	for _, event := range events {
+		err = b.saveEvent(tx, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return b.conn.Bus().Notify(buildEventsChannel(b.id))
+}
+
 func (b *build) SaveInput(input BuildInput) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -801,7 +1737,7 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 	outputs := []BuildOutput{}
 
 	rows, err := b.conn.Query(`
-		SELECT i.name, r.name, v.type, v.version, v.metadata,
+		SELECT i.name, v.id, r.name, v.type, v.version, v.metadata,
 		NOT EXISTS (
 			SELECT 1
 			FROM build_inputs ci, builds cb
@@ -828,13 +1764,16 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 
 	defer Close(rows)
 
+	inputVersionIDs := []int{}
+
 	for rows.Next() {
 		var inputName string
+		var versionedResourceID int
 		var vr VersionedResource
 		var firstOccurrence bool
 
 		var version, metadata string
-		err = rows.Scan(&inputName, &vr.Resource, &vr.Type, &version, &metadata, &firstOccurrence)
+		err = rows.Scan(&inputName, &versionedResourceID, &vr.Resource, &vr.Type, &version, &metadata, &firstOccurrence)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -849,6 +1788,8 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 			return nil, nil, err
 		}
 
+		inputVersionIDs = append(inputVersionIDs, versionedResourceID)
+
 		inputs = append(inputs, BuildInput{
 			Name:              inputName,
 			VersionedResource: vr,
@@ -856,6 +1797,15 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 		})
 	}
 
+	annotations, err := versionAnnotations(b.conn, inputVersionIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, versionedResourceID := range inputVersionIDs {
+		inputs[i].Annotations = annotations[versionedResourceID]
+	}
+
 	rows, err = b.conn.Query(`
 		SELECT r.name, v.type, v.version, v.metadata
 		FROM versioned_resources v, build_outputs o, builds b, resources r
@@ -979,15 +1929,16 @@ func buildEventSeq(buildid int) string {
 
 func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy) error {
 	var (
-		jobID, pipelineID                                                    sql.NullInt64
+		jobID, pipelineID, rerunOf                                           sql.NullInt64
 		engine, engineMetadata, jobName, pipelineName, publicPlan, trackedBy sql.NullString
-		startTime, endTime, reapTime                                         pq.NullTime
-		nonce                                                                sql.NullString
+		triggerCause, abortCause, rerunFrom                                  sql.NullString
+		startTime, endTime, reapTime, heartbeat                              pq.NullTime
+		nonce, archivedFrom                                                  sql.NullString
 
 		status string
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &publicPlan, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &trackedBy)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &triggerCause, &abortCause, &rerunOf, &rerunFrom, &b.scheduled, &engine, &engineMetadata, &publicPlan, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &trackedBy, &heartbeat, &b.orphaned, &archivedFrom, &b.eventsArchived)
 	if err != nil {
 		return err
 	}
@@ -1002,6 +1953,10 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.endTime = endTime.Time
 	b.reapTime = reapTime.Time
 	b.trackedBy = trackedBy.String
+	b.lastHeartbeat = heartbeat.Time
+	b.rerunOf = int(rerunOf.Int64)
+	b.rerunFrom = atc.PlanID(rerunFrom.String)
+	b.archivedFrom = archivedFrom.String
 
 	var (
 		noncense                *string
@@ -1025,27 +1980,83 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		}
 	}
 
+	if triggerCause.Valid {
+		err = json.Unmarshal([]byte(triggerCause.String), &b.triggerCause)
+		if err != nil {
+			return err
+		}
+	}
+
+	if abortCause.Valid {
+		err = json.Unmarshal([]byte(abortCause.String), &b.abortCause)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// saveEvent looks up the build's pipeline rather than trusting the one it was
+// loaded with, as a pipeline destroyed while the build runs moves its events
+// to the team's table. The build's row is locked until the event is saved so
+// that the event is not left behind by a move in progress.
 func (b *build) saveEvent(tx Tx, event atc.Event) error {
 	payload, err := json.Marshal(event)
 	if err != nil {
 		return err
 	}
 
+	var pipelineID sql.NullInt64
+	err = psql.Select("pipeline_id").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		Suffix("FOR SHARE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&pipelineID)
+	if err != nil {
+		return err
+	}
+
 	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	if pipelineID.Valid {
+		table = fmt.Sprintf("pipeline_build_events_%d", pipelineID.Int64)
+	}
+
+	vals := map[string]interface{}{
+		"event_id": sq.Expr("nextval('" + buildEventSeq(b.id) + "')"),
+		"build_id": b.id,
+		"type":     string(event.EventType()),
+		"version":  string(event.Version()),
+		"payload":  payload,
+	}
+
+	codec := b.conn.EventCodec()
+	if codec != nil && len(payload) >= minCompressedEventSize {
+		compressed, err := codec.Compress(payload)
+		if err != nil {
+			return err
+		}
+
+		if len(compressed) < len(payload) {
+			vals["payload"] = ""
+			vals["codec"] = codec.Name()
+			vals["compressed_payload"] = compressed
+		}
 	}
+
 	_, err = psql.Insert(table).
-		Columns("event_id", "build_id", "type", "version", "payload").
-		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload).
+		SetMap(vals).
 		RunWith(tx).
 		Exec()
 	return err
 }
 
+// minCompressedEventSize is the size below which event payloads are not worth
+// compressing.
+const minCompressedEventSize = 512
+
 func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
 	var buildID int
 	err := psql.Insert("builds").
@@ -1,11 +1,13 @@
 package db
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"sync"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/compression"
 	"github.com/concourse/atc/event"
 )
 
@@ -110,7 +112,7 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		}
 
 		rows, err := source.conn.Query(`
-			SELECT type, version, payload
+			SELECT type, version, payload, codec, compressed_payload
 			FROM `+source.table+`
 			WHERE build_id = $1
 			ORDER BY event_id ASC
@@ -131,7 +133,9 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 			cursor++
 
 			var t, v, p string
-			err := rows.Scan(&t, &v, &p)
+			var codec sql.NullString
+			var compressed []byte
+			err := rows.Scan(&t, &v, &p, &codec, &compressed)
 			if err != nil {
 				_ = rows.Close()
 
@@ -142,6 +146,17 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 
 			data := json.RawMessage(p)
 
+			if codec.Valid {
+				data, err = decompressEvent(codec.String, compressed)
+				if err != nil {
+					_ = rows.Close()
+
+					source.err = err
+					close(source.events)
+					return
+				}
+			}
+
 			ev := event.Envelope{
 				Data:    &data,
 				Event:   atc.EventType(t),
@@ -179,3 +194,52 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		}
 	}
 }
+
+func decompressEvent(name string, compressed []byte) (json.RawMessage, error) {
+	codec, err := compression.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.Decompress(compressed)
+}
+
+func newArchivedEventSource(events []event.Envelope) *archivedEventSource {
+	return &archivedEventSource{
+		events: events,
+	}
+}
+
+// archivedEventSource replays the events of a build read back from the event
+// archive. As the build has completed, no more events will follow them.
+type archivedEventSource struct {
+	lock   sync.Mutex
+	events []event.Envelope
+	closed bool
+}
+
+func (source *archivedEventSource) Next() (event.Envelope, error) {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	if source.closed {
+		return event.Envelope{}, ErrBuildEventStreamClosed
+	}
+
+	if len(source.events) == 0 {
+		return event.Envelope{}, ErrEndOfBuildEventStream
+	}
+
+	e := source.events[0]
+	source.events = source.events[1:]
+
+	return e, nil
+}
+
+func (source *archivedEventSource) Close() error {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	source.closed = true
+	return nil
+}
@@ -2,9 +2,14 @@ package db
 
 import (
 	"database/sql"
+	"fmt"
+	"sort"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
 )
 
 //go:generate counterfeiter . BuildFactory
@@ -14,8 +19,16 @@ type BuildFactory interface {
 	VisibleBuilds([]string, Page) ([]Build, Pagination, error)
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
+	StartedBuilds(afterID int, limit int) ([]Build, error)
+	FinishBuildsWithErrors(causes map[int]error) error
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
+	MarkOrphanedBuilds(timeout time.Duration) ([]int, error)
+	MarkUntrackedBuilds(grace time.Duration) ([]int, error)
+
+	// BuildsToArchive returns up to limit builds which completed longer ago
+	// than the age and whose events are still in the database, oldest first.
+	BuildsToArchive(age time.Duration, limit int) ([]Build, error)
 }
 
 type buildFactory struct {
@@ -92,6 +105,67 @@ func (f *buildFactory) MarkNonInterceptibleBuilds() error {
 	return err
 }
 
+// MarkOrphanedBuilds marks the started builds whose last heartbeat is older
+// than the timeout as orphaned, i.e. their ATC is presumed dead, and returns
+// their IDs. Builds which have not yet been tracked are timed from their start.
+func (f *buildFactory) MarkOrphanedBuilds(timeout time.Duration) ([]int, error) {
+	return f.markOrphaned(
+		sq.Expr("now() - COALESCE(heartbeat, start_time) > (? || ' SECONDS')::INTERVAL", int(timeout.Seconds())),
+	)
+}
+
+// MarkUntrackedBuilds marks the started builds whose tracking lock is held by
+// no ATC as orphaned, and returns their IDs. As the lock is session-scoped, it
+// is lost as soon as its ATC dies, so no heartbeat need be recorded. Builds
+// started within the grace period are left alone, as their ATC may not have
+// acquired the lock yet.
+func (f *buildFactory) MarkUntrackedBuilds(grace time.Duration) ([]int, error) {
+	return f.markOrphaned(sq.And{
+		sq.Expr("now() - start_time > (? || ' SECONDS')::INTERVAL", int(grace.Seconds())),
+		sq.Expr(`NOT EXISTS (
+			SELECT 1
+			FROM pg_locks l
+			JOIN pg_database d ON d.oid = l.database
+			WHERE l.locktype = 'advisory'
+			AND d.datname = current_database()
+			AND l.classid = ?
+			AND l.objid = builds.id
+			AND l.objsubid = 2
+		)`, lock.LockTypeBuildTracking),
+	})
+}
+
+func (f *buildFactory) markOrphaned(lapsed sq.Sqlizer) ([]int, error) {
+	rows, err := psql.Update("builds").
+		Set("orphaned", true).
+		Where(sq.Eq{
+			"status":   string(BuildStatusStarted),
+			"orphaned": false,
+		}).
+		Where(lapsed).
+		Suffix("RETURNING id").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	rows, err := buildsQuery.
 		Where(sq.Eq{"b.status": BuildStatusStarted}).
@@ -118,10 +192,200 @@ func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	return bs, nil
 }
 
+// BuildsToArchive returns at most limit completed builds, oldest first, which
+// ended more than age ago and whose events are still in the database.
+func (f *buildFactory) BuildsToArchive(age time.Duration, limit int) ([]Build, error) {
+	rows, err := buildsQuery.
+		Where(sq.Eq{
+			"b.completed":       true,
+			"b.events_archived": false,
+			"b.reap_time":       nil,
+		}).
+		Where(sq.Expr("b.end_time < now() - (? || ' SECONDS')::INTERVAL", int(age.Seconds()))).
+		OrderBy("b.end_time ASC").
+		Limit(uint64(limit)).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	bs := []Build{}
+
+	for rows.Next() {
+		b := &build{conn: f.conn, lockFactory: f.lockFactory}
+		err := scanBuild(b, rows, f.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, b)
+	}
+
+	return bs, rows.Err()
+}
+
+// StartedBuilds returns at most limit started builds, oldest first, whose IDs
+// are greater than afterID, so that they can be fetched in batches.
+func (f *buildFactory) StartedBuilds(afterID int, limit int) ([]Build, error) {
+	rows, err := buildsQuery.
+		Where(sq.Eq{"b.status": BuildStatusStarted}).
+		Where(sq.Gt{"b.id": afterID}).
+		OrderBy("b.id ASC").
+		Limit(uint64(limit)).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	bs := []Build{}
+
+	for rows.Next() {
+		b := &build{conn: f.conn, lockFactory: f.lockFactory}
+		err := scanBuild(b, rows, f.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, b)
+	}
+
+	return bs, nil
+}
+
+// FinishBuildsWithErrors finishes each of the builds as errored with its
+// cause, as FinishWithError does, but in a single transaction. Builds which
+// have already finished as their pipeline was destroyed are skipped.
+func (f *buildFactory) FinishBuildsWithErrors(causes map[int]error) error {
+	if len(causes) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(causes))
+	for id := range causes {
+		ids = append(ids, id)
+	}
+
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Update("builds").
+		Set("status", BuildStatusErrored).
+		Set("end_time", sq.Expr("now()")).
+		Set("completed", true).
+		Set("engine_metadata", nil).
+		Set("nonce", nil).
+		Where(sq.Eq{"id": ids}).
+		Where(sq.NotEq{"status": string(BuildStatusPipelineDestroyed)}).
+		Suffix("RETURNING id, team_id, COALESCE(pipeline_id, 0), COALESCE(job_id, 0), end_time").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	finished := []*build{}
+	endTimes := map[int]time.Time{}
+	for rows.Next() {
+		b := &build{conn: f.conn, lockFactory: f.lockFactory}
+
+		var endTime time.Time
+		err := rows.Scan(&b.id, &b.teamID, &b.pipelineID, &b.jobID, &endTime)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		finished = append(finished, b)
+		endTimes[b.id] = endTime
+	}
+
+	err = rows.Err()
+	Close(rows)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range finished {
+		cause := causes[b.id]
+
+		kind := atc.ErrorKindUnknown
+		if kinded, ok := cause.(atc.KindedError); ok {
+			kind = kinded.ErrorKind()
+		}
+
+		err = b.saveEvent(tx, event.Error{
+			Message: cause.Error(),
+			Kind:    kind,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = b.saveEvent(tx, event.Status{
+			Status: atc.StatusErrored,
+			Time:   endTimes[b.id].Unix(),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`
+			DROP SEQUENCE %s
+		`, buildEventSeq(b.id)))
+		if err != nil {
+			return err
+		}
+	}
+
+	// in the order they were created, so that each job's builds are updated
+	// as they would be if the builds had finished one at a time
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].id < finished[j].id
+	})
+
+	for _, b := range finished {
+		if b.jobID == 0 {
+			continue
+		}
+
+		err = updateDashboardBuilds(tx, b.jobID, b.id)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	for _, b := range finished {
+		err = f.conn.Bus().Notify(buildEventsChannel(b.id))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, conn Conn, lockFactory lock.LockFactory) ([]Build, Pagination, error) {
 	var rows *sql.Rows
 	var err error
 
+	// the unordered query scopes the probes for further pages below
+	scope := buildsQuery
+
 	var reverse bool
 	if page.Since == 0 && page.Until == 0 {
 		buildsQuery = buildsQuery.OrderBy("b.id DESC").Limit(uint64(page.Limit))
@@ -132,7 +396,9 @@ func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, conn Conn,
 		buildsQuery = buildsQuery.Where(sq.Lt{"b.id": page.Since}).OrderBy("b.id DESC").Limit(uint64(page.Limit))
 	}
 
-	rows, err = buildsQuery.RunWith(conn).Query()
+	runner := reader(conn, buildListStaleness)
+
+	rows, err = buildsQuery.RunWith(runner).Query()
 	if err != nil {
 		return nil, Pagination{}, err
 	}
@@ -161,30 +427,29 @@ func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, conn Conn,
 		return builds, Pagination{}, nil
 	}
 
-	var minID int
-	var maxID int
-	err = psql.Select("COALESCE(MAX(id), 0)", "COALESCE(MIN(id), 0)").
-		From("builds").
-		RunWith(conn).
-		QueryRow().
-		Scan(&maxID, &minID)
-	if err != nil {
-		return nil, Pagination{}, err
-	}
-
 	first := builds[0]
 	last := builds[len(builds)-1]
 
 	var pagination Pagination
 
-	if first.ID() < maxID {
+	newer, err := anyBuilds(scope.Where(sq.Gt{"b.id": first.ID()}), runner)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	if newer {
 		pagination.Previous = &Page{
 			Until: first.ID(),
 			Limit: page.Limit,
 		}
 	}
 
-	if last.ID() > minID {
+	older, err := anyBuilds(scope.Where(sq.Lt{"b.id": last.ID()}), runner)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	if older {
 		pagination.Next = &Page{
 			Since: last.ID(),
 			Limit: page.Limit,
@@ -193,3 +458,14 @@ func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, conn Conn,
 
 	return builds, pagination, nil
 }
+
+func anyBuilds(buildsQuery sq.SelectBuilder, runner Runner) (bool, error) {
+	rows, err := buildsQuery.Limit(1).RunWith(runner).Query()
+	if err != nil {
+		return false, err
+	}
+
+	defer Close(rows)
+
+	return rows.Next(), rows.Err()
+}
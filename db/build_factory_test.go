@@ -1,7 +1,12 @@
 package db_test
 
 import (
+	"errors"
+	"time"
+
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
 
 	"github.com/concourse/atc"
 	. "github.com/onsi/ginkgo"
@@ -28,7 +33,7 @@ var _ = Describe("BuildFactory", func() {
 
 		BeforeEach(func() {
 			var err error
-			createdBuild, err = team.CreateOneOffBuild()
+			createdBuild, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			foundBuild, found, err = buildFactory.Build(createdBuild.ID())
@@ -45,7 +50,7 @@ var _ = Describe("BuildFactory", func() {
 		Context("one-off builds", func() {
 			DescribeTable("completed builds",
 				func(status db.BuildStatus, matcher types.GomegaMatcher) {
-					b, err := defaultTeam.CreateOneOffBuild()
+					b, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 					Expect(err).NotTo(HaveOccurred())
 
 					var i bool
@@ -66,7 +71,7 @@ var _ = Describe("BuildFactory", func() {
 			)
 
 			It("non-completed is interceptible", func() {
-				b, err := defaultTeam.CreateOneOffBuild()
+				b, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				var i bool
@@ -81,10 +86,10 @@ var _ = Describe("BuildFactory", func() {
 		Context("pipeline builds", func() {
 
 			It("[#139963615] marks builds that aren't the latest as non-interceptible, ", func() {
-				build1, err := defaultJob.CreateBuild()
+				build1, err := defaultJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
-				build2, err := defaultJob.CreateBuild()
+				build2, err := defaultJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				err = build1.Finish(db.BuildStatusErrored)
@@ -105,10 +110,10 @@ var _ = Describe("BuildFactory", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				pb1, err := j.CreateBuild()
+				pb1, err := j.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
-				pb2, err := j.CreateBuild()
+				pb2, err := j.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				err = pb1.Finish(db.BuildStatusErrored)
@@ -140,7 +145,7 @@ var _ = Describe("BuildFactory", func() {
 
 			DescribeTable("completed builds",
 				func(status db.BuildStatus, matcher types.GomegaMatcher) {
-					b, err := defaultJob.CreateBuild()
+					b, err := defaultJob.CreateBuild(db.BuildTriggerCause{})
 					Expect(err).NotTo(HaveOccurred())
 
 					var i bool
@@ -161,7 +166,7 @@ var _ = Describe("BuildFactory", func() {
 			)
 
 			It("does not mark non-completed builds", func() {
-				b, err := defaultJob.CreateBuild()
+				b, err := defaultJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				var i bool
@@ -195,7 +200,7 @@ var _ = Describe("BuildFactory", func() {
 		var build4 db.Build
 
 		BeforeEach(func() {
-			build1, err = team.CreateOneOffBuild()
+			build1, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
@@ -206,25 +211,25 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build2, err = privateJob.CreateBuild()
+			build2, err = privateJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).NotTo(HaveOccurred())
-			err = publicPipeline.Expose()
+			_, err = publicPipeline.Expose(db.AnyConfigVersion)
 			Expect(err).NotTo(HaveOccurred())
 
 			publicJob, found, err := publicPipeline.Job("some-job")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build3, err = publicJob.CreateBuild()
+			build3, err = publicJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
 			Expect(err).NotTo(HaveOccurred())
 
-			build4, err = otherTeam.CreateOneOffBuild()
+			build4, err = otherTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -242,7 +247,7 @@ var _ = Describe("BuildFactory", func() {
 		var publicBuild db.Build
 
 		BeforeEach(func() {
-			_, err := team.CreateOneOffBuild()
+			_, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
@@ -253,19 +258,19 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = privateJob.CreateBuild()
+			_, err = privateJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).NotTo(HaveOccurred())
-			err = publicPipeline.Expose()
+			_, err = publicPipeline.Expose(db.AnyConfigVersion)
 			Expect(err).NotTo(HaveOccurred())
 
 			publicJob, found, err := publicPipeline.Job("some-job")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			publicBuild, err = publicJob.CreateBuild()
+			publicBuild, err = publicJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -296,13 +301,13 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build1DB, err = team.CreateOneOffBuild()
+			build1DB, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
-			build2DB, err = job.CreateBuild()
+			build2DB, err = job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err = team.CreateOneOffBuild()
+			_, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			started, err := build1DB.Start("some-engine", `{"so":"meta"}`, atc.Plan{})
@@ -326,4 +331,217 @@ var _ = Describe("BuildFactory", func() {
 			Expect(builds).To(ConsistOf(build1DB, build2DB))
 		})
 	})
+
+	Describe("StartedBuilds", func() {
+		var startedBuilds []db.Build
+
+		BeforeEach(func() {
+			startedBuilds = []db.Build{}
+
+			for i := 0; i < 3; i++ {
+				build, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
+				Expect(err).NotTo(HaveOccurred())
+
+				started, err := build.Start("some-engine", `{"so":"meta"}`, atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				_, err = build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+
+				startedBuilds = append(startedBuilds, build)
+			}
+
+			_, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns at most the limit of started builds, oldest first", func() {
+			builds, err := buildFactory.StartedBuilds(0, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(Equal(startedBuilds[:2]))
+		})
+
+		It("returns the started builds after the given ID", func() {
+			builds, err := buildFactory.StartedBuilds(startedBuilds[1].ID(), 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(Equal(startedBuilds[2:]))
+		})
+	})
+
+	Describe("FinishBuildsWithErrors", func() {
+		var (
+			build1 db.Build
+			build2 db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			build1, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			build2, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = buildFactory.FinishBuildsWithErrors(map[int]error{
+				build1.ID(): errors.New("some-error"),
+				build2.ID(): errors.New("some-other-error"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("finishes each build as errored with its cause", func() {
+			for build, message := range map[db.Build]string{
+				build1: "some-error",
+				build2: "some-other-error",
+			} {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.Status()).To(Equal(db.BuildStatusErrored))
+				Expect(build.EngineMetadata()).To(BeEmpty())
+
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(events.Next()).To(Equal(envelope(event.Error{
+					Message: message,
+					Kind:    atc.ErrorKindUnknown,
+				})))
+
+				Expect(events.Next()).To(Equal(envelope(event.Status{
+					Status: atc.StatusErrored,
+					Time:   build.EndTime().Unix(),
+				})))
+
+				db.Close(events)
+			}
+		})
+	})
+
+	Describe("MarkOrphanedBuilds", func() {
+		var (
+			lapsedBuild  db.Build
+			aliveBuild   db.Build
+			pendingBuild db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			lapsedBuild, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			aliveBuild, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingBuild, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, build := range []db.Build{lapsedBuild, aliveBuild} {
+				started, err := build.Start("some-engine", `{"so":"meta"}`, atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				Expect(build.TrackedBy("http://1.2.3.4:8080")).To(Succeed())
+			}
+
+			_, err = dbConn.Exec(`UPDATE builds SET heartbeat = now() - '1 hour'::INTERVAL WHERE id = $1`, lapsedBuild.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE builds SET start_time = now() - '1 hour'::INTERVAL WHERE id = $1`, pendingBuild.ID())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("marks started builds whose heartbeat has lapsed as orphaned", func() {
+			orphaned, err := buildFactory.MarkOrphanedBuilds(time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orphaned).To(ConsistOf(lapsedBuild.ID()))
+
+			for build, isOrphaned := range map[db.Build]bool{
+				lapsedBuild:  true,
+				aliveBuild:   false,
+				pendingBuild: false,
+			} {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.IsOrphaned()).To(Equal(isOrphaned))
+			}
+		})
+
+		It("does not mark them again", func() {
+			_, err := buildFactory.MarkOrphanedBuilds(time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+
+			orphaned, err := buildFactory.MarkOrphanedBuilds(time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orphaned).To(BeEmpty())
+		})
+	})
+
+	Describe("MarkUntrackedBuilds", func() {
+		var (
+			untrackedBuild db.Build
+			trackedBuild   db.Build
+			recentBuild    db.Build
+
+			trackingLock lock.Lock
+		)
+
+		BeforeEach(func() {
+			var err error
+			untrackedBuild, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			trackedBuild, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			recentBuild, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, build := range []db.Build{untrackedBuild, trackedBuild, recentBuild} {
+				started, err := build.Start("some-engine", `{"so":"meta"}`, atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+			}
+
+			_, err = dbConn.Exec(`UPDATE builds SET start_time = now() - '1 hour'::INTERVAL WHERE id IN ($1, $2)`, untrackedBuild.ID(), trackedBuild.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			var acquired bool
+			trackingLock, acquired, err = trackedBuild.AcquireTrackingLock(logger, time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+		})
+
+		AfterEach(func() {
+			Expect(trackingLock.Release()).To(Succeed())
+		})
+
+		It("marks started builds whose tracking lock is not held as orphaned", func() {
+			orphaned, err := buildFactory.MarkUntrackedBuilds(time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orphaned).To(ConsistOf(untrackedBuild.ID()))
+
+			for build, isOrphaned := range map[db.Build]bool{
+				untrackedBuild: true,
+				trackedBuild:   false,
+				recentBuild:    false,
+			} {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.IsOrphaned()).To(Equal(isOrphaned))
+			}
+		})
+
+		It("does not mark them again", func() {
+			_, err := buildFactory.MarkUntrackedBuilds(time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+
+			orphaned, err := buildFactory.MarkUntrackedBuilds(time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orphaned).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,28 @@
+package db
+
+// BuildSchedulingCheckpoint records how far the scheduler got in preparing a
+// pending build, so that scheduling can resume from it after a failure rather
+// than preparing the build again from scratch.
+//
+// Checkpoints are ordered; a build at a given checkpoint has also passed all
+// of the ones before it.
+type BuildSchedulingCheckpoint string
+
+const (
+	BuildSchedulingCheckpointNone           BuildSchedulingCheckpoint = ""
+	BuildSchedulingCheckpointInputsScanned  BuildSchedulingCheckpoint = "inputs_scanned"
+	BuildSchedulingCheckpointInputsResolved BuildSchedulingCheckpoint = "inputs_resolved"
+	BuildSchedulingCheckpointPlanCreated    BuildSchedulingCheckpoint = "plan_created"
+)
+
+var buildSchedulingCheckpointOrder = map[BuildSchedulingCheckpoint]int{
+	BuildSchedulingCheckpointNone:           0,
+	BuildSchedulingCheckpointInputsScanned:  1,
+	BuildSchedulingCheckpointInputsResolved: 2,
+	BuildSchedulingCheckpointPlanCreated:    3,
+}
+
+// Reached returns true if the checkpoint is at or beyond the given one.
+func (checkpoint BuildSchedulingCheckpoint) Reached(other BuildSchedulingCheckpoint) bool {
+	return buildSchedulingCheckpointOrder[checkpoint] >= buildSchedulingCheckpointOrder[other]
+}
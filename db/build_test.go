@@ -1,13 +1,19 @@
 package db_test
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
+	"github.com/concourse/atc/db/compression"
+	"github.com/concourse/atc/db/eventarchive"
+	"github.com/concourse/atc/db/eventarchive/eventarchivefakes"
 	"github.com/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -26,7 +32,7 @@ var _ = Describe("Build", func() {
 
 	Describe("Reload", func() {
 		It("updates the model", func() {
-			build, err := team.CreateOneOffBuild()
+			build, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 			started, err := build.Start("engine", `{"meta":"data"}`, atc.Plan{})
 			Expect(err).NotTo(HaveOccurred())
@@ -72,7 +78,7 @@ var _ = Describe("Build", func() {
 			}
 
 			var err error
-			build, err = team.CreateOneOffBuild()
+			build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			started, err := build.Start("engine", `{"meta":"data"}`, plan)
@@ -117,7 +123,7 @@ var _ = Describe("Build", func() {
 
 		BeforeEach(func() {
 			var err error
-			build, err = team.CreateOneOffBuild()
+			build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -130,13 +136,75 @@ var _ = Describe("Build", func() {
 
 			Expect(build.Tracker()).To(Equal("http://1.2.3.4:8080"))
 		})
+
+		It("records a heartbeat", func() {
+			Expect(build.TrackedBy("http://1.2.3.4:8080")).To(Succeed())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(build.LastHeartbeat()).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		Context("when the build was orphaned", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE builds SET orphaned = true WHERE id = $1`, build.ID())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("adopts it", func() {
+				Expect(build.TrackedBy("http://1.2.3.4:8080")).To(Succeed())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(build.IsOrphaned()).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Heartbeat", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE builds SET heartbeat = now() - '1 hour'::INTERVAL, orphaned = true WHERE id = $1`, build.ID())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("records a heartbeat and reclaims the build", func() {
+			Expect(build.Heartbeat()).To(Succeed())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(build.LastHeartbeat()).To(BeTemporally("~", time.Now(), time.Minute))
+			Expect(build.IsOrphaned()).To(BeFalse())
+		})
+
+		Context("when the build has been deleted", func() {
+			BeforeEach(func() {
+				_, err := build.Delete()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns ErrBuildDisappeared", func() {
+				Expect(build.Heartbeat()).To(Equal(db.ErrBuildDisappeared))
+			})
+		})
 	})
 
 	Describe("Finish", func() {
 		var build db.Build
 		BeforeEach(func() {
 			var err error
-			build, err = team.CreateOneOffBuild()
+			build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			err = build.Finish(db.BuildStatusSucceeded)
@@ -179,10 +247,13 @@ var _ = Describe("Build", func() {
 		var build db.Build
 		BeforeEach(func() {
 			var err error
-			build, err = team.CreateOneOffBuild()
+			build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
-			err = build.MarkAsAborted()
+			err = build.MarkAsAborted(db.BuildAbortCause{
+				Requester: "some-team",
+				Reason:    "some-reason",
+			})
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -192,11 +263,37 @@ var _ = Describe("Build", func() {
 			Expect(found).To(BeTrue())
 			Expect(build.Status()).To(Equal(db.BuildStatusAborted))
 		})
+
+		It("saves the cause", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.AbortCause()).To(Equal(db.BuildAbortCause{
+				Requester: "some-team",
+				Reason:    "some-reason",
+			}))
+		})
+
+		It("emits the cause as an event", func() {
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			envelope, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(envelope.Event).To(Equal(event.EventTypeAbortRequested))
+
+			ev, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev.(event.AbortRequested).Requester).To(Equal("some-team"))
+			Expect(ev.(event.AbortRequested).Reason).To(Equal("some-reason"))
+		})
 	})
 
 	Describe("Events", func() {
 		It("saves and emits status events", func() {
-			build, err := team.CreateOneOffBuild()
+			build, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			By("allowing you to subscribe when no events have yet occurred")
@@ -238,9 +335,35 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SaveEvents", func() {
+		It("saves the events in order", func() {
+			build, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			err = build.SaveEvents([]atc.Event{
+				event.Log{Payload: "some "},
+				event.Log{Payload: "log"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{
+				Payload: "some ",
+			})))
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{
+				Payload: "log",
+			})))
+		})
+	})
+
 	Describe("SaveEvent", func() {
 		It("saves and propagates events correctly", func() {
-			build, err := team.CreateOneOffBuild()
+			build, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			By("allowing you to subscribe when no events have yet occurred")
@@ -315,6 +438,206 @@ var _ = Describe("Build", func() {
 				return err
 			}).Should(Equal(db.ErrBuildEventStreamClosed))
 		})
+
+		Context("when events are compressed", func() {
+			var build db.Build
+			var bigLog string
+
+			BeforeEach(func() {
+				codec, err := compression.Lookup(compression.ZstdName)
+				Expect(err).NotTo(HaveOccurred())
+
+				compressingTeamFactory := db.NewTeamFactory(db.CompressEvents(dbConn, codec), lockFactory)
+
+				compressingTeam, found, err := compressingTeamFactory.FindTeam("some-team")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = compressingTeam.CreateOneOffBuild(db.BuildTriggerCause{})
+				Expect(err).NotTo(HaveOccurred())
+
+				bigLog = strings.Repeat("all work and no play makes jack a dull boy\n", 100)
+
+				err = build.SaveEvent(event.Log{Payload: "small"})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveEvent(event.Log{Payload: bigLog})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("compresses only the events worth compressing", func() {
+				rows, err := dbConn.Query(`SELECT codec FROM build_events WHERE build_id = $1 ORDER BY event_id`, build.ID())
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(rows)
+
+				codecs := []sql.NullString{}
+				for rows.Next() {
+					var codec sql.NullString
+					Expect(rows.Scan(&codec)).To(Succeed())
+					codecs = append(codecs, codec)
+				}
+
+				Expect(codecs).To(Equal([]sql.NullString{
+					{},
+					{String: compression.ZstdName, Valid: true},
+				}))
+			})
+
+			It("reads back compressed and uncompressed events alike", func() {
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "small"})))
+				Expect(events.Next()).To(Equal(envelope(event.Log{Payload: bigLog})))
+			})
+		})
+	})
+
+	Describe("ArchiveEvents", func() {
+		var (
+			fakeStore     *eventarchivefakes.FakeStore
+			objects       map[string][]byte
+			archivingTeam db.Team
+			build         db.Build
+		)
+
+		BeforeEach(func() {
+			objects = map[string][]byte{}
+
+			fakeStore = new(eventarchivefakes.FakeStore)
+			fakeStore.PutStub = func(key string, data []byte) error {
+				objects[key] = data
+				return nil
+			}
+			fakeStore.GetStub = func(key string) ([]byte, error) {
+				data, found := objects[key]
+				if !found {
+					return nil, eventarchive.ErrNotFound
+				}
+
+				return data, nil
+			}
+
+			archivingTeamFactory := db.NewTeamFactory(db.ArchiveEvents(dbConn, fakeStore), lockFactory)
+
+			var found bool
+			var err error
+			archivingTeam, found, err = archivingTeamFactory.FindTeam("some-team")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err = archivingTeam.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "some-output"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is still running", func() {
+			It("refuses to archive it", func() {
+				Expect(build.ArchiveEvents()).To(Equal(db.ErrBuildNotCompleted))
+				Expect(fakeStore.PutCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the build has completed", func() {
+			BeforeEach(func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = build.ArchiveEvents()
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err = build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("uploads the events and removes them from the database", func() {
+				Expect(fakeStore.PutCallCount()).To(Equal(1))
+
+				key, _ := fakeStore.PutArgsForCall(0)
+				Expect(key).To(Equal(fmt.Sprintf("builds/%d/events.json", build.ID())))
+
+				Expect(build.EventsArchived()).To(BeTrue())
+
+				var count int
+				err := dbConn.QueryRow(`SELECT COUNT(*) FROM build_events WHERE build_id = $1`, build.ID()).Scan(&count)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(BeZero())
+			})
+
+			It("replays the archived events", func() {
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "some-output"})))
+				Expect(events.Next()).To(Equal(envelope(event.Status{
+					Status: atc.StatusSucceeded,
+					Time:   build.EndTime().Unix(),
+				})))
+
+				_, err = events.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+			})
+
+			It("is a no-op when archived again", func() {
+				Expect(build.ArchiveEvents()).To(Succeed())
+				Expect(fakeStore.PutCallCount()).To(Equal(1))
+			})
+
+			Context("when the build is reaped", func() {
+				BeforeEach(func() {
+					pipeline, _, err := archivingTeam.SavePipeline("some-pipeline", atc.Config{}, db.ConfigVersion(0), db.PipelineUnpaused)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = pipeline.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+					Expect(err).NotTo(HaveOccurred())
+
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+
+				It("deletes the archived events", func() {
+					Expect(fakeStore.DeleteCallCount()).To(Equal(1))
+					Expect(fakeStore.DeleteArgsForCall(0)).To(Equal(fmt.Sprintf("builds/%d/events.json", build.ID())))
+				})
+
+				It("no longer replays them", func() {
+					Expect(build.EventsArchived()).To(BeFalse())
+
+					events, err := build.Events(0)
+					Expect(err).NotTo(HaveOccurred())
+
+					defer db.Close(events)
+
+					_, err = events.Next()
+					Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+				})
+			})
+
+			Context("when the build's team is deleted", func() {
+				BeforeEach(func() {
+					err := archivingTeam.Delete()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("deletes the archived events", func() {
+					Expect(fakeStore.DeleteCallCount()).To(Equal(1))
+					Expect(fakeStore.DeleteArgsForCall(0)).To(Equal(fmt.Sprintf("builds/%d/events.json", build.ID())))
+				})
+			})
+		})
 	})
 
 	Describe("SaveInput", func() {
@@ -347,7 +670,7 @@ var _ = Describe("Build", func() {
 		})
 
 		It("saves the build's input", func() {
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			versionedResource := db.VersionedResource{
@@ -414,7 +737,7 @@ var _ = Describe("Build", func() {
 		})
 
 		It("can save a build's output", func() {
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			versionedResource := db.VersionedResource{
@@ -495,7 +818,7 @@ var _ = Describe("Build", func() {
 		})
 
 		It("returns build inputs and outputs", func() {
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			// save a normal 'get'
@@ -522,7 +845,7 @@ var _ = Describe("Build", func() {
 		})
 
 		It("fails to save build output if resource does not exist", func() {
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			vr := db.VersionedResource{
@@ -567,7 +890,7 @@ var _ = Describe("Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild()
+				build, err = job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -580,7 +903,7 @@ var _ = Describe("Build", func() {
 		Context("when a one off build", func() {
 			BeforeEach(func() {
 				var err error
-				build, err = team.CreateOneOffBuild()
+				build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -611,7 +934,7 @@ var _ = Describe("Build", func() {
 
 		Context("for one-off build", func() {
 			BeforeEach(func() {
-				build, err = team.CreateOneOffBuild()
+				build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				expectedBuildPrep.BuildID = build.ID()
@@ -675,7 +998,7 @@ var _ = Describe("Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild()
+				build, err = job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				expectedBuildPrep.BuildID = build.ID()
@@ -736,7 +1059,7 @@ var _ = Describe("Build", func() {
 
 				Context("when pipeline is paused", func() {
 					BeforeEach(func() {
-						err := pipeline.Pause()
+						_, err := pipeline.Pause(db.AnyConfigVersion)
 						Expect(err).NotTo(HaveOccurred())
 
 						expectedBuildPrep.PausedPipeline = db.BuildPreparationStatusBlocking
@@ -752,7 +1075,7 @@ var _ = Describe("Build", func() {
 
 				Context("when job is paused", func() {
 					BeforeEach(func() {
-						err := job.Pause()
+						_, err := job.Pause(db.AnyConfigVersion)
 						Expect(err).NotTo(HaveOccurred())
 
 						expectedBuildPrep.PausedJob = db.BuildPreparationStatusBlocking
@@ -937,7 +1260,7 @@ var _ = Describe("Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild()
+				build, err = job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(build.IsScheduled()).To(BeFalse())
 			})
@@ -973,11 +1296,365 @@ var _ = Describe("Build", func() {
 				})
 			})
 		})
+
+		Describe("SchedulingCheckpoint", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+						},
+					},
+				}, db.ConfigVersion(1), db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+
+				job, found, err := pipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = job.CreateBuild(db.BuildTriggerCause{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("starts with no checkpoint", func() {
+				checkpoint, err := build.SchedulingCheckpoint()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(checkpoint).To(Equal(db.BuildSchedulingCheckpointNone))
+			})
+
+			It("returns the saved checkpoint", func() {
+				err := build.SaveSchedulingCheckpoint(db.BuildSchedulingCheckpointInputsResolved)
+				Expect(err).ToNot(HaveOccurred())
+
+				checkpoint, err := build.SchedulingCheckpoint()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(checkpoint).To(Equal(db.BuildSchedulingCheckpointInputsResolved))
+			})
+
+			Context("when the build is no longer pending", func() {
+				BeforeEach(func() {
+					err := build.Finish(db.BuildStatusErrored)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("does not save the checkpoint", func() {
+					err := build.SaveSchedulingCheckpoint(db.BuildSchedulingCheckpointInputsResolved)
+					Expect(err).To(Equal(db.ErrBuildDisappeared))
+				})
+			})
+		})
+	})
+
+	Describe("StepStates", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("starts with no states", func() {
+			states, err := build.StepStates()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(states).To(BeEmpty())
+		})
+
+		It("returns the saved states by plan", func() {
+			err := build.SaveStepState("some-plan", json.RawMessage(`{"succeeded":false}`))
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveStepState("some-other-plan", json.RawMessage(`{"succeeded":true}`))
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveStepState("some-plan", json.RawMessage(`{"succeeded":true}`))
+			Expect(err).ToNot(HaveOccurred())
+
+			states, err := build.StepStates()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(states).To(HaveLen(2))
+			Expect(states["some-plan"]).To(MatchJSON(`{"succeeded":true}`))
+			Expect(states["some-other-plan"]).To(MatchJSON(`{"succeeded":true}`))
+		})
+
+		Context("when the build is deleted", func() {
+			BeforeEach(func() {
+				_, err := build.Delete()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not save the state", func() {
+				err := build.SaveStepState("some-plan", json.RawMessage(`{}`))
+				Expect(err).To(Equal(db.ErrBuildDisappeared))
+			})
+		})
+	})
+
+	Describe("Rerun", func() {
+		var job db.Job
+		var build db.Build
+
+		BeforeEach(func() {
+			pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource", Type: "some-type"},
+				},
+			}, db.ConfigVersion(1), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			job, found, err = pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err = job.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveInput(db.BuildInput{
+				Name: "some-input",
+				VersionedResource: db.VersionedResource{
+					Resource: "some-resource",
+					Type:     "some-type",
+					Version:  db.ResourceVersion{"some": "version"},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveStepState("some-put", json.RawMessage(`{"succeeded":true}`))
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveStepState("some-later-put", json.RawMessage(`{"succeeded":false}`))
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("creates a pending build of the same job recording what it reruns", func() {
+			rerun, err := build.Rerun("some-plan", nil, db.BuildTriggerCause{
+				Type:      db.BuildTriggerRerun,
+				Requester: "some-team",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(rerun.ID()).ToNot(Equal(build.ID()))
+			Expect(rerun.Name()).To(Equal("2"))
+			Expect(rerun.JobName()).To(Equal("some-job"))
+			Expect(rerun.Status()).To(Equal(db.BuildStatusPending))
+			Expect(rerun.RerunOf()).To(Equal(build.ID()))
+			Expect(rerun.RerunFrom()).To(Equal(atc.PlanID("some-plan")))
+			Expect(rerun.TriggerCause().Type).To(Equal(db.BuildTriggerRerun))
+		})
+
+		It("uses the same inputs", func() {
+			rerun, err := build.Rerun("some-plan", nil, db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			inputs, err := rerun.GetVersionedResources()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inputs).To(HaveLen(1))
+			Expect(inputs[0].Version).To(Equal(db.ResourceVersion{"some": "version"}))
+		})
+
+		It("copies the state of the reused steps only", func() {
+			rerun, err := build.Rerun("some-plan", []atc.PlanID{"some-put", "some-get"}, db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			states, err := rerun.StepStates()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(states).To(HaveLen(1))
+			Expect(states["some-put"]).To(MatchJSON(`{"succeeded":true}`))
+		})
+
+		It("can find the build it reruns", func() {
+			rerun, err := build.Rerun("some-plan", nil, db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			original, found, err := rerun.RerunOfBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(original.ID()).To(Equal(build.ID()))
+
+			_, found, err = build.RerunOfBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the build is a one-off", func() {
+			BeforeEach(func() {
+				var err error
+				build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("creates another one-off build", func() {
+				rerun, err := build.Rerun("some-plan", nil, db.BuildTriggerCause{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rerun.JobName()).To(BeEmpty())
+				Expect(rerun.TeamName()).To(Equal("some-team"))
+				Expect(rerun.RerunOf()).To(Equal(build.ID()))
+			})
+		})
+	})
+
+	Describe("EventCheckpoint", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("starts with no checkpoint", func() {
+			_, found, err := build.EventCheckpoint("some-subscriber")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns the last checkpoint saved for the subscriber", func() {
+			err := build.SaveEventCheckpoint("some-subscriber", 3)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveEventCheckpoint("some-subscriber", 7)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveEventCheckpoint("some-other-subscriber", 1)
+			Expect(err).ToNot(HaveOccurred())
+
+			eventID, found, err := build.EventCheckpoint("some-subscriber")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(eventID).To(Equal(uint(7)))
+
+			eventID, found, err = build.EventCheckpoint("some-other-subscriber")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(eventID).To(Equal(uint(1)))
+		})
+
+		Context("when the build is deleted", func() {
+			BeforeEach(func() {
+				_, err := build.Delete()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not save the checkpoint", func() {
+				err := build.SaveEventCheckpoint("some-subscriber", 1)
+				Expect(err).To(Equal(db.ErrBuildDisappeared))
+			})
+		})
+	})
+
+	Describe("Environment", func() {
+		var (
+			build       db.Build
+			environment atc.BuildEnvironment
+		)
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			environment = atc.BuildEnvironment{
+				ATCVersion:        "1.2.3",
+				CredentialManager: "vault",
+				Workers: []atc.BuildEnvironmentWorker{
+					{
+						Name:          "some-worker",
+						Version:       "2.1",
+						Platform:      "linux",
+						ResourceTypes: map[string]string{"git": "some-git-version"},
+					},
+				},
+				ResourceTypes: []atc.BuildEnvironmentResourceType{
+					{
+						Name:    "some-type",
+						Type:    "docker-image",
+						Version: atc.Version{"digest": "some-digest"},
+					},
+				},
+				FeatureFlags: map[string]bool{"artifact-cache": true},
+			}
+		})
+
+		It("starts with no environment", func() {
+			_, found, err := build.Environment()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns the environment saved", func() {
+			err := build.SaveEnvironment(environment)
+			Expect(err).ToNot(HaveOccurred())
+
+			savedEnvironment, found, err := build.Environment()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedEnvironment).To(Equal(environment))
+		})
+
+		It("does not replace the environment once saved", func() {
+			err := build.SaveEnvironment(environment)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveEnvironment(atc.BuildEnvironment{ATCVersion: "4.5.6"})
+			Expect(err).To(Equal(db.ErrBuildEnvironmentAlreadySaved))
+
+			savedEnvironment, _, err := build.Environment()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(savedEnvironment).To(Equal(environment))
+		})
+
+		Context("when the build is deleted", func() {
+			BeforeEach(func() {
+				_, err := build.Delete()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not save the environment", func() {
+				err := build.SaveEnvironment(environment)
+				Expect(err).To(Equal(db.ErrBuildDisappeared))
+			})
+		})
+	})
+
+	Describe("WorkerNames", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns no workers for a build without containers", func() {
+			Expect(build.WorkerNames()).To(BeEmpty())
+		})
+
+		Context("when the build has containers", func() {
+			BeforeEach(func() {
+				for _, planID := range []atc.PlanID{"some-plan", "some-other-plan"} {
+					_, err := team.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), planID), db.ContainerMetadata{Type: "task"})
+					Expect(err).ToNot(HaveOccurred())
+				}
+			})
+
+			It("returns the workers they were created on, once each", func() {
+				Expect(build.WorkerNames()).To(Equal([]string{defaultWorker.Name()}))
+			})
+		})
 	})
 
 	Describe("Resources", func() {
 		It("can get (no) resources from a one-off build", func() {
-			oneOffBuild, err := team.CreateOneOffBuild()
+			oneOffBuild, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			inputs, outputs, err := oneOffBuild.Resources()
@@ -1021,7 +1698,7 @@ var _ = Describe("Build", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err = job.CreateBuild()
+			build, err = job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			versionedResource := db.VersionedResource{
@@ -1094,12 +1771,13 @@ var _ = Describe("Build", func() {
 
 		BeforeEach(func() {
 			var err error
-			build, err = team.CreateOneOffBuild()
+			build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
+
+			cause = errors.New("disaster")
 		})
 
 		JustBeforeEach(func() {
-			cause = errors.New("disaster")
 			err := build.FinishWithError(cause)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -1117,9 +1795,28 @@ var _ = Describe("Build", func() {
 
 			Expect(events.Next()).To(Equal(envelope(event.Error{
 				Message: "disaster",
+				Kind:    atc.ErrorKindUnknown,
 			})))
 		})
 
+		Context("when the cause knows its kind", func() {
+			BeforeEach(func() {
+				cause = kindedError{kind: atc.ErrorKindWorkerUnavailable}
+			})
+
+			It("creates an Error event of its kind", func() {
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(Equal(envelope(event.Error{
+					Message: "kinded disaster",
+					Kind:    atc.ErrorKindWorkerUnavailable,
+				})))
+			})
+		})
+
 		It("updates build status", func() {
 			found, err := build.Reload()
 			Expect(err).NotTo(HaveOccurred())
@@ -1129,6 +1826,13 @@ var _ = Describe("Build", func() {
 	})
 })
 
+type kindedError struct {
+	kind atc.ErrorKind
+}
+
+func (kindedError) Error() string                { return "kinded disaster" }
+func (err kindedError) ErrorKind() atc.ErrorKind { return err.kind }
+
 func envelope(ev atc.Event) event.Envelope {
 	payload, err := json.Marshal(ev)
 	Expect(err).ToNot(HaveOccurred())
@@ -0,0 +1,125 @@
+package bus
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+// Config configures the message bus to send database notifications, such as
+// new build events, through instead of Postgres LISTEN/NOTIFY.
+type Config struct {
+	Driver string `long:"driver" choice:"nats" choice:"redis" description:"Message bus to send notifications through, e.g. of new build events, for installations with more listeners than Postgres notifications keep up with. If omitted, Postgres LISTEN/NOTIFY is used."`
+	URL    string `long:"url" description:"URL of the message bus, e.g. nats://127.0.0.1:4222 or redis://127.0.0.1:6379/0."`
+	Prefix string `long:"prefix" default:"concourse." description:"Prefix for the subject or channel each notification is sent on."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.Driver != ""
+}
+
+// NewBus connects to the configured message bus. Every ATC sharing a
+// database must be configured with the same bus, as notifications are only
+// sent through one of them.
+func NewBus(logger lager.Logger, config Config) (db.NotificationsBus, error) {
+	if config.URL == "" {
+		return nil, errors.New("notifications bus url must be specified")
+	}
+
+	switch config.Driver {
+	case "nats":
+		return newNATSBus(config)
+	case "redis":
+		return newRedisBus(logger, config)
+	default:
+		return nil, fmt.Errorf("unknown notifications bus driver: %s", config.Driver)
+	}
+}
+
+// listeners keeps the listeners of each channel, subscribing to a channel
+// on the bus while it has any.
+type listeners struct {
+	notifications  map[string]map[chan bool]struct{}
+	notificationsL sync.Mutex
+}
+
+func newListeners() listeners {
+	return listeners{
+		notifications: make(map[string]map[chan bool]struct{}),
+	}
+}
+
+func (l *listeners) add(channel string, subscribe func() error) (chan bool, error) {
+	l.notificationsL.Lock()
+	defer l.notificationsL.Unlock()
+
+	sinks, found := l.notifications[channel]
+	if !found {
+		err := subscribe()
+		if err != nil {
+			return nil, err
+		}
+
+		sinks = map[chan bool]struct{}{}
+		l.notifications[channel] = sinks
+	}
+
+	// buffer so that notifications can be nonblocking (only need one at a time)
+	notify := make(chan bool, 1)
+	sinks[notify] = struct{}{}
+
+	return notify, nil
+}
+
+func (l *listeners) remove(channel string, notify chan bool, unsubscribe func() error) error {
+	l.notificationsL.Lock()
+	defer l.notificationsL.Unlock()
+
+	sinks, found := l.notifications[channel]
+	if !found {
+		return nil
+	}
+
+	delete(sinks, notify)
+
+	if len(sinks) > 0 {
+		return nil
+	}
+
+	delete(l.notifications, channel)
+
+	return unsubscribe()
+}
+
+// notify alerts the listeners of the channel, nonblocking, as a listener
+// with a notification already queued up will check for anything new anyway.
+func (l *listeners) notify(channel string, received bool) {
+	l.notificationsL.Lock()
+	defer l.notificationsL.Unlock()
+
+	for sink := range l.notifications[channel] {
+		select {
+		case sink <- received:
+		default:
+		}
+	}
+}
+
+// notifyAll alerts every listener that notifications may have been missed,
+// e.g. while reconnecting, so they can check for anything that changed.
+func (l *listeners) notifyAll() {
+	l.notificationsL.Lock()
+	defer l.notificationsL.Unlock()
+
+	for _, sinks := range l.notifications {
+		for sink := range sinks {
+			select {
+			case sink <- false:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package bus_test
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db/bus"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewBus", func() {
+	var logger *lagertest.TestLogger
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	It("requires a url", func() {
+		_, err := bus.NewBus(logger, bus.Config{Driver: "nats"})
+		Expect(err).To(MatchError("notifications bus url must be specified"))
+	})
+
+	It("rejects unknown drivers", func() {
+		_, err := bus.NewBus(logger, bus.Config{Driver: "carrier-pigeon", URL: "coop://roof"})
+		Expect(err).To(MatchError("unknown notifications bus driver: carrier-pigeon"))
+	})
+
+	It("rejects malformed redis urls", func() {
+		_, err := bus.NewBus(logger, bus.Config{Driver: "redis", URL: "http://127.0.0.1"})
+		Expect(err).To(HaveOccurred())
+	})
+})
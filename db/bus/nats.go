@@ -0,0 +1,69 @@
+package bus
+
+import (
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/nats"
+)
+
+type natsBus struct {
+	listeners
+
+	conn   *nats.Conn
+	prefix string
+
+	subscriptions map[string]*nats.Subscription
+}
+
+func newNATSBus(config Config) (db.NotificationsBus, error) {
+	bus := &natsBus{
+		listeners: newListeners(),
+
+		prefix: config.Prefix,
+
+		subscriptions: make(map[string]*nats.Subscription),
+	}
+
+	conn, err := nats.Connect(config.URL, func() {
+		// messages published while disconnected are not redelivered
+		bus.notifyAll()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bus.conn = conn
+
+	return bus, nil
+}
+
+func (bus *natsBus) Listen(channel string) (chan bool, error) {
+	return bus.add(channel, func() error {
+		sub, err := bus.conn.Subscribe(bus.prefix+channel, func([]byte) {
+			bus.notify(channel, true)
+		})
+		if err != nil {
+			return err
+		}
+
+		bus.subscriptions[channel] = sub
+
+		return nil
+	})
+}
+
+func (bus *natsBus) Notify(channel string) error {
+	return bus.conn.Publish(bus.prefix+channel, nil)
+}
+
+func (bus *natsBus) Unlisten(channel string, notify chan bool) error {
+	return bus.remove(channel, notify, func() error {
+		sub := bus.subscriptions[channel]
+		delete(bus.subscriptions, channel)
+
+		return sub.Unsubscribe()
+	})
+}
+
+func (bus *natsBus) Close() error {
+	return bus.conn.Close()
+}
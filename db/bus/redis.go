@@ -0,0 +1,224 @@
+package bus
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+// redisRetryInterval is how long to wait before reconnecting after the
+// connection to Redis fails.
+const redisRetryInterval = time.Second
+
+type redisBus struct {
+	listeners
+
+	logger lager.Logger
+
+	address redisAddress
+	prefix  string
+
+	// publisher is redialed on the next Notify after it fails
+	publisher  *redisConn
+	publisherL sync.Mutex
+
+	// subscriber is redialed by dispatchNotifications after it fails
+	subscriber  *redisConn
+	subscriberL sync.Mutex
+
+	closed int32
+}
+
+func newRedisBus(logger lager.Logger, config Config) (db.NotificationsBus, error) {
+	address, err := parseRedisURL(config.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	publisher, err := dialRedis(address)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriber, err := dialRedis(address)
+	if err != nil {
+		_ = publisher.Close()
+		return nil, err
+	}
+
+	bus := &redisBus{
+		listeners: newListeners(),
+
+		logger: logger,
+
+		address: address,
+		prefix:  config.Prefix,
+
+		publisher:  publisher,
+		subscriber: subscriber,
+	}
+
+	go bus.dispatchNotifications(subscriber)
+
+	return bus, nil
+}
+
+func (bus *redisBus) Listen(channel string) (chan bool, error) {
+	return bus.add(channel, func() error {
+		return bus.sendToSubscriber("SUBSCRIBE", bus.prefix+channel)
+	})
+}
+
+func (bus *redisBus) Notify(channel string) error {
+	bus.publisherL.Lock()
+	defer bus.publisherL.Unlock()
+
+	if bus.publisher == nil {
+		publisher, err := dialRedis(bus.address)
+		if err != nil {
+			return err
+		}
+
+		bus.publisher = publisher
+	}
+
+	_, err := bus.publisher.do("PUBLISH", bus.prefix+channel, "")
+	if err != nil {
+		if _, ok := err.(redisError); !ok {
+			_ = bus.publisher.Close()
+			bus.publisher = nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (bus *redisBus) Unlisten(channel string, notify chan bool) error {
+	return bus.remove(channel, notify, func() error {
+		return bus.sendToSubscriber("UNSUBSCRIBE", bus.prefix+channel)
+	})
+}
+
+func (bus *redisBus) Close() error {
+	atomic.StoreInt32(&bus.closed, 1)
+
+	bus.publisherL.Lock()
+	if bus.publisher != nil {
+		_ = bus.publisher.Close()
+		bus.publisher = nil
+	}
+	bus.publisherL.Unlock()
+
+	bus.subscriberL.Lock()
+	defer bus.subscriberL.Unlock()
+
+	return bus.subscriber.Close()
+}
+
+// sendToSubscriber sends a command on the subscriber connection. Its reply
+// is received by dispatchNotifications; if the connection has failed, the
+// channels are resubscribed to once it is redialed.
+func (bus *redisBus) sendToSubscriber(args ...string) error {
+	bus.subscriberL.Lock()
+	defer bus.subscriberL.Unlock()
+
+	err := bus.subscriber.send(args...)
+	if err != nil {
+		bus.logger.Error("failed-to-send-to-subscriber", err, lager.Data{"command": args[0]})
+	}
+
+	return nil
+}
+
+func (bus *redisBus) dispatchNotifications(subscriber *redisConn) {
+	for {
+		reply, err := subscriber.receive()
+		if atomic.LoadInt32(&bus.closed) == 1 {
+			return
+		}
+
+		if err != nil {
+			bus.logger.Error("failed-to-receive", err)
+
+			// messages published while disconnected are not redelivered
+			bus.notifyAll()
+
+			subscriber = bus.resubscribe()
+			if subscriber == nil {
+				return
+			}
+
+			continue
+		}
+
+		message, ok := reply.([]interface{})
+		if !ok || len(message) != 3 {
+			continue
+		}
+
+		kind, _ := message[0].(string)
+		channel, _ := message[1].(string)
+
+		switch kind {
+		case "subscribe":
+			// channels are resubscribed to after reconnecting, so anything
+			// published while disconnected may have been missed
+			bus.notify(strings.TrimPrefix(channel, bus.prefix), false)
+		case "message":
+			bus.notify(strings.TrimPrefix(channel, bus.prefix), true)
+		}
+	}
+}
+
+// resubscribe redials the subscriber connection until it succeeds or the bus
+// is closed, subscribing to every channel that has listeners.
+func (bus *redisBus) resubscribe() *redisConn {
+	for {
+		time.Sleep(redisRetryInterval)
+
+		if atomic.LoadInt32(&bus.closed) == 1 {
+			return nil
+		}
+
+		subscriber, err := dialRedis(bus.address)
+		if err != nil {
+			bus.logger.Error("failed-to-reconnect", err)
+			continue
+		}
+
+		// hold the listeners so that none are added on the old connection
+		// while subscribing on the new one
+		bus.notificationsL.Lock()
+		bus.subscriberL.Lock()
+
+		_ = bus.subscriber.Close()
+		bus.subscriber = subscriber
+
+		if atomic.LoadInt32(&bus.closed) == 1 {
+			_ = subscriber.Close()
+		}
+
+		for channel := range bus.notifications {
+			err = subscriber.send("SUBSCRIBE", bus.prefix+channel)
+			if err != nil {
+				break
+			}
+		}
+
+		bus.subscriberL.Unlock()
+		bus.notificationsL.Unlock()
+
+		if err != nil {
+			bus.logger.Error("failed-to-resubscribe", err)
+		}
+
+		// any failure is noticed when receiving
+		return subscriber
+	}
+}
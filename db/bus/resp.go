@@ -0,0 +1,211 @@
+package bus
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	redisDefaultPort = "6379"
+	redisDialTimeout = 10 * time.Second
+)
+
+// redisError is an error reply from Redis.
+type redisError string
+
+func (err redisError) Error() string {
+	return "redis: " + string(err)
+}
+
+// redisAddress is where to connect to Redis, as given by a URL such as
+// redis://:password@127.0.0.1:6379/0.
+type redisAddress struct {
+	host     string
+	password string
+	database int
+}
+
+func parseRedisURL(rawURL string) (redisAddress, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return redisAddress{}, err
+	}
+
+	if u.Scheme != "redis" {
+		return redisAddress{}, fmt.Errorf("unsupported redis url scheme: %s", u.Scheme)
+	}
+
+	if u.Hostname() == "" {
+		return redisAddress{}, errors.New("redis url must specify a host")
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = redisDefaultPort
+	}
+
+	address := redisAddress{
+		host: net.JoinHostPort(u.Hostname(), port),
+	}
+
+	if u.User != nil {
+		address.password, _ = u.User.Password()
+	}
+
+	database := strings.Trim(u.Path, "/")
+	if database != "" {
+		address.database, err = strconv.Atoi(database)
+		if err != nil {
+			return redisAddress{}, fmt.Errorf("invalid redis database: %s", database)
+		}
+	}
+
+	return address, nil
+}
+
+// redisConn is a connection to Redis speaking just enough of its protocol
+// to publish and subscribe.
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	writer *bufio.Writer
+}
+
+// dialRedis connects to Redis, authenticating and selecting the database if
+// the address says to.
+func dialRedis(address redisAddress) (*redisConn, error) {
+	netConn, err := net.DialTimeout("tcp", address.host, redisDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &redisConn{
+		conn:   netConn,
+		reader: bufio.NewReader(netConn),
+		writer: bufio.NewWriter(netConn),
+	}
+
+	if address.password != "" {
+		_, err = conn.do("AUTH", address.password)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if address.database != 0 {
+		_, err = conn.do("SELECT", strconv.Itoa(address.database))
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+// do sends a command and returns its reply.
+func (conn *redisConn) do(args ...string) (interface{}, error) {
+	err := conn.send(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.receive()
+	if err != nil {
+		return nil, err
+	}
+
+	if replyErr, ok := reply.(redisError); ok {
+		return nil, replyErr
+	}
+
+	return reply, nil
+}
+
+// send sends a command without waiting for its reply.
+func (conn *redisConn) send(args ...string) error {
+	fmt.Fprintf(conn.writer, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(conn.writer, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	return conn.writer.Flush()
+}
+
+// receive reads the next reply, which is a string, redisError, int64, nil,
+// or a []interface{} of them.
+func (conn *redisConn) receive() (interface{}, error) {
+	line, err := conn.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("empty reply from redis")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+
+	case '-':
+		return redisError(line[1:]), nil
+
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if size < 0 {
+			return nil, nil
+		}
+
+		data := make([]byte, size+len("\r\n"))
+		_, err = io.ReadFull(conn.reader, data)
+		if err != nil {
+			return nil, err
+		}
+
+		return string(data[:size]), nil
+
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if count < 0 {
+			return nil, nil
+		}
+
+		elements := make([]interface{}, count)
+		for i := range elements {
+			elements[i], err = conn.receive()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return elements, nil
+
+	default:
+		return nil, fmt.Errorf("malformed reply from redis: %s", line)
+	}
+}
+
+func (conn *redisConn) Close() error {
+	return conn.conn.Close()
+}
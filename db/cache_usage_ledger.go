@@ -0,0 +1,131 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// TeamCacheUsage is the disk space used by the resource caches and task
+// caches of a team across all workers, in bytes.
+type TeamCacheUsage struct {
+	TeamName           string
+	ResourceCacheBytes int64
+	TaskCacheBytes     int64
+	UpdatedAt          time.Time
+}
+
+//go:generate counterfeiter . CacheUsageLedger
+
+// CacheUsageLedger keeps the disk space used by each team's caches, totalled
+// up from the volume sizes reported by workers with their heartbeats.
+type CacheUsageLedger interface {
+	// Aggregate totals up the disk space used by each team's caches again.
+	// Volumes are attributed to the team they belong to, so resource caches
+	// shared across teams are not counted against any of them, and volumes
+	// whose size has not been reported count for nothing.
+	Aggregate() error
+
+	// TeamUsages returns the usage of every team as of the last time it was
+	// aggregated, ordered by team name.
+	TeamUsages() ([]TeamCacheUsage, error)
+
+	// TeamUsage returns the usage of the team as of the last time it was
+	// aggregated, returning false if it has not been yet.
+	TeamUsage(teamName string) (TeamCacheUsage, bool, error)
+}
+
+type cacheUsageLedger struct {
+	conn Conn
+}
+
+func NewCacheUsageLedger(conn Conn) CacheUsageLedger {
+	return &cacheUsageLedger{
+		conn: conn,
+	}
+}
+
+var teamCacheUsagesQuery = psql.Select("t.name", "u.resource_cache_bytes", "u.task_cache_bytes", "u.updated_at").
+	From("team_cache_usages u").
+	Join("teams t ON t.id = u.team_id")
+
+func (l *cacheUsageLedger) Aggregate() error {
+	tx, err := l.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = tx.Exec(`DELETE FROM team_cache_usages`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO team_cache_usages (team_id, resource_cache_bytes, task_cache_bytes, updated_at)
+		SELECT t.id,
+			COALESCE(sum(CASE WHEN v.worker_resource_cache_id IS NOT NULL THEN v.size END), 0),
+			COALESCE(sum(CASE WHEN v.worker_task_cache_id IS NOT NULL THEN v.size END), 0),
+			now()
+		FROM teams t
+		LEFT JOIN volumes v
+			ON v.team_id = t.id
+			AND v.state = $1
+			AND (v.worker_resource_cache_id IS NOT NULL OR v.worker_task_cache_id IS NOT NULL)
+		GROUP BY t.id
+	`, string(VolumeStateCreated))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (l *cacheUsageLedger) TeamUsages() ([]TeamCacheUsage, error) {
+	rows, err := teamCacheUsagesQuery.
+		OrderBy("t.name ASC").
+		RunWith(l.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	usages := []TeamCacheUsage{}
+	for rows.Next() {
+		usage, err := scanTeamCacheUsage(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		usages = append(usages, usage)
+	}
+
+	return usages, rows.Err()
+}
+
+func (l *cacheUsageLedger) TeamUsage(teamName string) (TeamCacheUsage, bool, error) {
+	usage, err := scanTeamCacheUsage(teamCacheUsagesQuery.
+		Where(sq.Eq{"LOWER(t.name)": strings.ToLower(teamName)}).
+		RunWith(l.conn).
+		QueryRow())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return TeamCacheUsage{}, false, nil
+		}
+
+		return TeamCacheUsage{}, false, err
+	}
+
+	return usage, true, nil
+}
+
+func scanTeamCacheUsage(row scannable) (TeamCacheUsage, error) {
+	var usage TeamCacheUsage
+	err := row.Scan(&usage.TeamName, &usage.ResourceCacheBytes, &usage.TaskCacheBytes, &usage.UpdatedAt)
+	return usage, err
+}
@@ -0,0 +1,58 @@
+package db_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CacheUsageLedger", func() {
+	var ledger db.CacheUsageLedger
+
+	BeforeEach(func() {
+		ledger = db.NewCacheUsageLedger(dbConn)
+	})
+
+	Describe("Aggregate", func() {
+		BeforeEach(func() {
+			taskCache, err := workerTaskCacheFactory.FindOrCreate(defaultJob.ID(), "some-step", "some-path", defaultWorker.Name())
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeFactory.CreateTaskCacheVolume(defaultTeam.ID(), taskCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			createdVolume, err := creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = defaultWorker.SaveVolumeUsages([]atc.VolumeUsage{
+				{Handle: createdVolume.Handle(), Size: 4096},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("totals up the disk space used by each team's caches", func() {
+			err := ledger.Aggregate()
+			Expect(err).ToNot(HaveOccurred())
+
+			usage, found, err := ledger.TeamUsage(defaultTeam.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(usage.TeamName).To(Equal(defaultTeam.Name()))
+			Expect(usage.TaskCacheBytes).To(Equal(int64(4096)))
+			Expect(usage.ResourceCacheBytes).To(BeZero())
+			Expect(usage.UpdatedAt).ToNot(BeZero())
+
+			usages, err := ledger.TeamUsages()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usages).To(ContainElement(usage))
+		})
+
+		It("does not know the usage of a team before it has been aggregated", func() {
+			_, found, err := ledger.TeamUsage(defaultTeam.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// AnyConfigVersion can be given in place of a pipeline's config version to
+// make a change to it regardless of what has changed since it was read.
+const AnyConfigVersion ConfigVersion = 0
+
+// compareAndSwap makes a change to a pipeline, its jobs or its resources in
+// the same transaction as moving the pipeline on to a new config version, so
+// that the change fails with ErrConfigComparisonFailed if the pipeline is no
+// longer at the version it was expected to be at. The new version is
+// returned.
+func compareAndSwap(conn Conn, pipelineID int, from ConfigVersion, change func(tx Tx) error) (ConfigVersion, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	where := sq.Eq{"id": pipelineID}
+	if from != AnyConfigVersion {
+		where["version"] = from
+	}
+
+	var version ConfigVersion
+	err = psql.Update("pipelines").
+		Set("version", sq.Expr("nextval('config_version_seq')")).
+		Where(where).
+		Suffix("RETURNING version").
+		RunWith(tx).
+		QueryRow().
+		Scan(&version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrConfigComparisonFailed
+		}
+
+		return 0, err
+	}
+
+	err = change(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// compareAndSwapTeam makes a change spanning a team's pipelines, such as
+// reordering them or pausing jobs across them, in the same transaction as
+// moving every one of them on to a new config version. The team's config
+// version is the latest of its pipelines', so the change fails with
+// ErrConfigComparisonFailed if any of them has changed since it was read.
+// The team's new version is returned.
+func compareAndSwapTeam(conn Conn, teamID int, from ConfigVersion, change func(tx Tx) error) (ConfigVersion, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	var current ConfigVersion
+	err = tx.QueryRow(`
+		SELECT COALESCE(max(version), 0)
+		FROM (SELECT version FROM pipelines WHERE team_id = $1 FOR UPDATE) p
+	`, teamID).Scan(&current)
+	if err != nil {
+		return 0, err
+	}
+
+	if from != AnyConfigVersion && from != current {
+		return 0, ErrConfigComparisonFailed
+	}
+
+	err = change(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	var version ConfigVersion
+	err = tx.QueryRow(`
+		WITH bumped AS (
+			UPDATE pipelines
+			SET version = nextval('config_version_seq')
+			WHERE team_id = $1
+			RETURNING version
+		)
+		SELECT COALESCE(max(version), 0) FROM bumped
+	`, teamID).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
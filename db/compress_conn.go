@@ -0,0 +1,23 @@
+package db
+
+import "github.com/concourse/atc/db/compression"
+
+// CompressEvents returns a Conn which compresses the payloads of build events
+// it saves with the given codec. Events are read back regardless of how, or
+// whether, they were compressed.
+func CompressEvents(conn Conn, codec compression.Codec) Conn {
+	return &compressConn{
+		Conn:  conn,
+		codec: codec,
+	}
+}
+
+type compressConn struct {
+	Conn
+
+	codec compression.Codec
+}
+
+func (c *compressConn) EventCodec() compression.Codec {
+	return c.codec
+}
@@ -0,0 +1,38 @@
+package compression
+
+import "fmt"
+
+// Codec compresses data stored in the database. Its name is stored alongside
+// the compressed data so that it can always be decompressed, whichever codec
+// is configured for writing.
+type Codec interface {
+	Name() string
+	Compress([]byte) ([]byte, error)
+	Decompress([]byte) ([]byte, error)
+}
+
+var codecs = map[string]Codec{}
+
+func register(codec Codec) {
+	codecs[codec.Name()] = codec
+}
+
+// Lookup returns the codec with the given name.
+func Lookup(name string) (Codec, error) {
+	codec, found := codecs[name]
+	if !found {
+		return nil, UnknownCodecError{Name: name}
+	}
+
+	return codec, nil
+}
+
+// UnknownCodecError is returned when looking up a codec which does not exist,
+// e.g. one used by a newer ATC.
+type UnknownCodecError struct {
+	Name string
+}
+
+func (err UnknownCodecError) Error() string {
+	return fmt.Sprintf("unknown compression codec: %s", err.Name)
+}
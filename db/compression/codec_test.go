@@ -0,0 +1,53 @@
+package compression_test
+
+import (
+	"bytes"
+
+	"github.com/concourse/atc/db/compression"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Codecs", func() {
+	data := bytes.Repeat([]byte("some log output\n"), 100)
+
+	for _, name := range []string{compression.GzipName, compression.ZstdName} {
+		name := name
+
+		Describe(name, func() {
+			var codec compression.Codec
+
+			BeforeEach(func() {
+				var err error
+				codec, err = compression.Lookup(name)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is named", func() {
+				Expect(codec.Name()).To(Equal(name))
+			})
+
+			It("compresses data which it can decompress", func() {
+				compressed, err := codec.Compress(data)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(compressed)).To(BeNumerically("<", len(data)))
+
+				decompressed, err := codec.Decompress(compressed)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decompressed).To(Equal(data))
+			})
+
+			It("fails to decompress data it did not compress", func() {
+				_, err := codec.Decompress([]byte("not compressed"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	}
+
+	Describe("Lookup", func() {
+		It("returns an error for an unknown codec", func() {
+			_, err := compression.Lookup("bogus")
+			Expect(err).To(Equal(compression.UnknownCodecError{Name: "bogus"}))
+		})
+	})
+})
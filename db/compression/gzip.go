@@ -0,0 +1,48 @@
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+)
+
+const GzipName = "gzip"
+
+func init() {
+	register(gzipCodec{})
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Name() string {
+	return GzipName
+}
+
+func (gzipCodec) Compress(data []byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	writer := gzip.NewWriter(buf)
+
+	_, err := writer.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (gzipCodec) Decompress(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
@@ -0,0 +1,41 @@
+package compression
+
+import (
+	"github.com/klauspost/compress/zstd"
+)
+
+const ZstdName = "zstd"
+
+func init() {
+	register(newZstdCodec())
+}
+
+// zstdCodec shares one encoder and decoder, which are safe for concurrent use
+// through EncodeAll and DecodeAll.
+type zstdCodec struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newZstdCodec() zstdCodec {
+	// neither constructor can fail without options
+	encoder, _ := zstd.NewWriter(nil)
+	decoder, _ := zstd.NewReader(nil)
+
+	return zstdCodec{
+		encoder: encoder,
+		decoder: decoder,
+	}
+}
+
+func (zstdCodec) Name() string {
+	return ZstdName
+}
+
+func (codec zstdCodec) Compress(data []byte) ([]byte, error) {
+	return codec.encoder.EncodeAll(data, nil), nil
+}
+
+func (codec zstdCodec) Decompress(data []byte) ([]byte, error) {
+	return codec.decoder.DecodeAll(data, nil)
+}
@@ -0,0 +1,222 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/encryption"
+)
+
+// ConfigRevision is a config a pipeline was set to. One is recorded each
+// time the pipeline is saved, so that it can be rolled back to.
+type ConfigRevision struct {
+	ID            int
+	ConfigVersion ConfigVersion
+	Config        atc.Config
+	Diff          []atc.ConfigChange
+	Author        string
+	CreatedAt     time.Time
+}
+
+// The configs of revisions are encrypted like those of the pipeline's jobs
+// and resources, so they are read along with the team's data key. Their diffs
+// only name what changed, so are stored as they are.
+var configRevisionsQuery = psql.Select("r.id", "r.config_version", "r.config", "r.nonce", "r.team_keyed", "t.data_key", "t.data_key_nonce", "r.diff", "r.author", "r.created_at").
+	From("pipeline_config_revisions r").
+	Join("pipelines p ON p.id = r.pipeline_id").
+	Join("teams t ON t.id = p.team_id")
+
+// saveConfigRevision records the config the pipeline was just saved with,
+// encrypted as the pipeline's other configs were, along with how it differs
+// from the previous revision. Pipelines saved before revisions were recorded
+// have nothing to differ from until their first revision is recorded.
+func saveConfigRevision(tx Tx, master encryption.Strategy, enc configEncryption, pipelineID int, version ConfigVersion, config atc.Config, author string, created bool) error {
+	previous, found, err := scanConfigRevisionConfig(
+		master,
+		configRevisionsQuery.
+			Where(sq.Eq{"r.pipeline_id": pipelineID}).
+			OrderBy("r.id DESC").
+			Limit(1).
+			RunWith(tx).
+			QueryRow(),
+	)
+	if err != nil {
+		return err
+	}
+
+	diff := []atc.ConfigChange{}
+	if found {
+		diff = atc.DiffConfigs(previous, config)
+	} else if created {
+		diff = atc.DiffConfigs(atc.Config{}, config)
+	}
+
+	configPayload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	encryptedPayload, nonce, err := enc.strategy.Encrypt(configPayload)
+	if err != nil {
+		return err
+	}
+
+	diffPayload, err := json.Marshal(diff)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("pipeline_config_revisions").
+		Columns("pipeline_id", "config_version", "config", "nonce", "team_keyed", "diff", "author").
+		Values(pipelineID, version, encryptedPayload, nonce, enc.teamKeyed, diffPayload, author).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+func scanConfigRevisionConfig(master encryption.Strategy, row scannable) (atc.Config, bool, error) {
+	revision, err := scanConfigRevision(master, row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.Config{}, false, nil
+		}
+
+		return atc.Config{}, false, err
+	}
+
+	return revision.Config, true, nil
+}
+
+func (p *pipeline) ConfigRevision(id int) (ConfigRevision, bool, error) {
+	revision, err := scanConfigRevision(
+		p.conn.EncryptionStrategy(),
+		configRevisionsQuery.
+			Where(sq.Eq{
+				"r.id":          id,
+				"r.pipeline_id": p.id,
+			}).
+			RunWith(p.conn).
+			QueryRow(),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ConfigRevision{}, false, nil
+		}
+
+		return ConfigRevision{}, false, err
+	}
+
+	return revision, true, nil
+}
+
+func (p *pipeline) ConfigRevisions(page Page) ([]ConfigRevision, Pagination, error) {
+	query := configRevisionsQuery.Where(sq.Eq{"r.pipeline_id": p.id})
+
+	var reverse bool
+	if page.Until != 0 {
+		query = query.Where(sq.Gt{"r.id": page.Until}).OrderBy("r.id ASC")
+		reverse = true
+	} else if page.Since != 0 {
+		query = query.Where(sq.Lt{"r.id": page.Since}).OrderBy("r.id DESC")
+	} else {
+		query = query.OrderBy("r.id DESC")
+	}
+
+	rows, err := query.Limit(uint64(page.Limit)).RunWith(p.conn).Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Close(rows)
+
+	revisions := []ConfigRevision{}
+	for rows.Next() {
+		revision, err := scanConfigRevision(p.conn.EncryptionStrategy(), rows)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		if reverse {
+			revisions = append([]ConfigRevision{revision}, revisions...)
+		} else {
+			revisions = append(revisions, revision)
+		}
+	}
+
+	if len(revisions) == 0 {
+		return revisions, Pagination{}, nil
+	}
+
+	first := revisions[0]
+	last := revisions[len(revisions)-1]
+
+	var pagination Pagination
+
+	var newer bool
+	err = p.conn.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM pipeline_config_revisions WHERE pipeline_id = $1 AND id > $2)
+	`, p.id, first.ID).Scan(&newer)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	if newer {
+		pagination.Previous = &Page{
+			Until: first.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	var older bool
+	err = p.conn.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM pipeline_config_revisions WHERE pipeline_id = $1 AND id < $2)
+	`, p.id, last.ID).Scan(&older)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	if older {
+		pagination.Next = &Page{
+			Since: last.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	return revisions, pagination, nil
+}
+
+func scanConfigRevision(master encryption.Strategy, row scannable) (ConfigRevision, error) {
+	var (
+		revision  ConfigRevision
+		config    string
+		nonce     sql.NullString
+		teamKeyed bool
+		dataKey   teamDataKey
+		diff      []byte
+	)
+
+	err := row.Scan(&revision.ID, &revision.ConfigVersion, &config, &nonce, &teamKeyed, &dataKey.key, &dataKey.nonce, &diff, &revision.Author, &revision.CreatedAt)
+	if err != nil {
+		return ConfigRevision{}, err
+	}
+
+	decryptedConfig, err := dataKey.decryptConfig(master, config, nonce, teamKeyed)
+	if err != nil {
+		return ConfigRevision{}, err
+	}
+
+	err = json.Unmarshal(decryptedConfig, &revision.Config)
+	if err != nil {
+		return ConfigRevision{}, err
+	}
+
+	err = json.Unmarshal(diff, &revision.Diff)
+	if err != nil {
+		return ConfigRevision{}, err
+	}
+
+	return revision, nil
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
@@ -107,6 +108,48 @@ func (c buildStepContainerOwner) sqlMap() map[string]interface{} {
 	}
 }
 
+// NewReplayContainerOwner references a step within a build which is being
+// replayed for debugging, with an expiry. The container is kept around until
+// the expiry is reached, even once the build is no longer interceptible.
+func NewReplayContainerOwner(
+	buildID int,
+	planID atc.PlanID,
+	expiresAt time.Time,
+) ContainerOwner {
+	return replayContainerOwner{
+		BuildID:   buildID,
+		PlanID:    ReplayPlanID(planID),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// ReplayPlanID distinguishes the container replaying a step from the step's
+// own container.
+func ReplayPlanID(planID atc.PlanID) atc.PlanID {
+	return planID + "/replay"
+}
+
+type replayContainerOwner struct {
+	BuildID   int
+	PlanID    atc.PlanID
+	ExpiresAt time.Time
+}
+
+func (c replayContainerOwner) Find(Conn) (sq.Eq, bool, error) {
+	return sq.Eq{
+		"build_id": c.BuildID,
+		"plan_id":  c.PlanID,
+	}, true, nil
+}
+
+func (c replayContainerOwner) Create(Tx, string) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"build_id":          c.BuildID,
+		"plan_id":           c.PlanID,
+		"replay_expires_at": c.ExpiresAt,
+	}, nil
+}
+
 // NewResourceConfigCheckSessionContainerOwner references a resource config and
 // worker base resource type, with an expiry. When the resource config or
 // worker base resource type disappear, or the expiry is reached, the container
@@ -117,7 +160,7 @@ func NewResourceConfigCheckSessionContainerOwner(
 ) ContainerOwner {
 	return resourceConfigCheckSessionContainerOwner{
 		resourceConfigCheckSession: resourceConfigCheckSession,
-		teamID: teamID,
+		teamID:                     teamID,
 	}
 }
 
@@ -184,3 +227,56 @@ func (c resourceConfigCheckSessionContainerOwner) Create(tx Tx, workerName strin
 		"worker_resource_config_check_session_id": wrccsID,
 	}, nil
 }
+
+// NewWarmResourceTypeContainerOwner references the image kept warm on a
+// worker for a base resource type. The container is only created to fetch
+// the image, so once it is no longer creating it can be removed.
+func NewWarmResourceTypeContainerOwner(
+	workerName string,
+	name string,
+) ContainerOwner {
+	return warmResourceTypeContainerOwner{
+		workerName: workerName,
+		name:       name,
+	}
+}
+
+type warmResourceTypeContainerOwner struct {
+	workerName string
+	name       string
+}
+
+func (c warmResourceTypeContainerOwner) Find(conn Conn) (sq.Eq, bool, error) {
+	var id int
+	err := psql.Select("id").
+		From("worker_warm_resource_types").
+		Where(sq.Eq{
+			"worker_name": c.workerName,
+			"name":        c.name,
+		}).
+		RunWith(conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return sq.Eq{
+		"worker_warm_resource_type_id": id,
+	}, true, nil
+}
+
+func (c warmResourceTypeContainerOwner) Create(tx Tx, workerName string) (map[string]interface{}, error) {
+	id, err := findOrCreateWorkerWarmResourceType(tx, workerName, c.name)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"worker_warm_resource_type_id": id,
+	}, nil
+}
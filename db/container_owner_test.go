@@ -102,4 +102,59 @@ var _ = Describe("ContainerOwner", func() {
 			})
 		})
 	})
+
+	Describe("WarmResourceTypeContainerOwner", func() {
+		var (
+			owner        db.ContainerOwner
+			foundColumns sq.Eq
+			found        bool
+		)
+
+		BeforeEach(func() {
+			owner = db.NewWarmResourceTypeContainerOwner(defaultWorker.Name(), "some-warm-type")
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			foundColumns, found, err = owner.Find(dbConn)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the warm resource type exists on the worker", func() {
+			var createdColumns map[string]interface{}
+
+			BeforeEach(func() {
+				tx, err := dbConn.Begin()
+				Expect(err).ToNot(HaveOccurred())
+
+				createdColumns, err = owner.Create(tx, defaultWorker.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(createdColumns).ToNot(BeEmpty())
+
+				Expect(tx.Commit()).To(Succeed())
+			})
+
+			It("finds it", func() {
+				Expect(foundColumns).To(BeEquivalentTo(createdColumns))
+				Expect(found).To(BeTrue())
+			})
+
+			It("reuses it when creating another container", func() {
+				tx, err := dbConn.Begin()
+				Expect(err).ToNot(HaveOccurred())
+
+				columns, err := owner.Create(tx, defaultWorker.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(columns).To(Equal(createdColumns))
+
+				Expect(tx.Commit()).To(Succeed())
+			})
+		})
+
+		Context("when the warm resource type doesn't exist on the worker", func() {
+			It("doesn't find it", func() {
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
 })
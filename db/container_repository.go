@@ -30,10 +30,15 @@ func (repository *containerRepository) FindOrphanedContainers() ([]CreatingConta
 				"c.image_check_container_id":                nil,
 				"c.image_get_container_id":                  nil,
 				"c.worker_resource_config_check_session_id": nil,
+				"c.worker_warm_resource_type_id":            nil,
 			},
 			sq.And{
 				sq.NotEq{"c.build_id": nil},
 				sq.Eq{"b.interceptible": false},
+				sq.Or{
+					sq.Eq{"c.replay_expires_at": nil},
+					sq.Expr("c.replay_expires_at < NOW()"),
+				},
 			},
 			sq.And{
 				sq.NotEq{"c.image_check_container_id": nil},
@@ -43,6 +48,10 @@ func (repository *containerRepository) FindOrphanedContainers() ([]CreatingConta
 				sq.NotEq{"c.image_get_container_id": nil},
 				sq.NotEq{"igc.state": ContainerStateCreating},
 			},
+			sq.And{
+				sq.NotEq{"c.worker_warm_resource_type_id": nil},
+				sq.NotEq{"c.state": ContainerStateCreating},
+			},
 		}).
 		ToSql()
 	if err != nil {
@@ -194,7 +194,7 @@ var _ = Describe("ContainerRepository", func() {
 
 			BeforeEach(func() {
 				var err error
-				build, err = defaultJob.CreateBuild()
+				build, err = defaultJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				creatingContainer, err = defaultTeam.CreateContainer(
@@ -294,6 +294,73 @@ var _ = Describe("ContainerRepository", func() {
 			})
 		})
 
+		Describe("containers replaying a step of a build", func() {
+			var (
+				creatingContainer db.CreatingContainer
+				build             db.Build
+				expiresAt         time.Time
+			)
+
+			BeforeEach(func() {
+				expiresAt = time.Now().Add(time.Hour)
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				build, err = defaultJob.CreateBuild(db.BuildTriggerCause{})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SetInterceptible(false)
+				Expect(err).NotTo(HaveOccurred())
+
+				creatingContainer, err = defaultTeam.CreateContainer(
+					defaultWorker.Name(),
+					db.NewReplayContainerOwner(build.ID(), "simple-plan", expiresAt),
+					fullMetadata,
+				)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when the replay has not expired", func() {
+				It("does not find the container for deletion, even though the build is not interceptible", func() {
+					creatingContainers, createdContainers, destroyingContainers, err := containerRepository.FindOrphanedContainers()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(creatingContainers).To(BeEmpty())
+					Expect(createdContainers).To(BeEmpty())
+					Expect(destroyingContainers).To(BeEmpty())
+				})
+
+				It("is found as the build's replay container once created", func() {
+					_, found, err := build.ReplayContainer("simple-plan")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+
+					_, err = creatingContainer.Created()
+					Expect(err).NotTo(HaveOccurred())
+
+					container, found, err := build.ReplayContainer("simple-plan")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(container.Handle()).To(Equal(creatingContainer.Handle()))
+				})
+			})
+
+			Context("when the replay has expired", func() {
+				BeforeEach(func() {
+					expiresAt = time.Now().Add(-time.Minute)
+				})
+
+				It("finds the container for deletion", func() {
+					creatingContainers, _, _, err := containerRepository.FindOrphanedContainers()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(creatingContainers).To(HaveLen(1))
+					Expect(creatingContainers[0].Handle()).To(Equal(creatingContainer.Handle()))
+				})
+			})
+		})
+
 		Describe("containers for checking images for creating containers", func() {
 			var (
 				creatingTaskContainer db.CreatingContainer
@@ -303,7 +370,7 @@ var _ = Describe("ContainerRepository", func() {
 
 			BeforeEach(func() {
 				var err error
-				build, err = defaultJob.CreateBuild()
+				build, err = defaultJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				creatingTaskContainer, err = defaultTeam.CreateContainer(
@@ -372,7 +439,7 @@ var _ = Describe("ContainerRepository", func() {
 
 			BeforeEach(func() {
 				var err error
-				build, err = defaultJob.CreateBuild()
+				build, err = defaultJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				creatingTaskContainer, err = defaultTeam.CreateContainer(
@@ -25,7 +25,7 @@ var _ = Describe("Container", func() {
 
 	BeforeEach(func() {
 		var err error
-		build, err = defaultTeam.CreateOneOffBuild()
+		build, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 		Expect(err).NotTo(HaveOccurred())
 
 		creatingContainer, err = defaultTeam.CreateContainer(
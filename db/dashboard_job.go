@@ -1,5 +1,12 @@
 package db
 
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db/lock"
+)
+
 type DashboardJob struct {
 	Job Job
 
@@ -9,3 +16,241 @@ type DashboardJob struct {
 }
 
 type Dashboard []DashboardJob
+
+// The builds shown for each job on the dashboard are kept on the job's row
+// and updated as each of its builds is created or changes status, so that
+// reading the dashboard never has to search through the builds themselves.
+var dashboardQuery = jobsQuery.Columns("j.next_build_id", "j.latest_completed_build_id", "j.transition_build_id")
+
+var runningBuildStatuses = []string{string(BuildStatusPending), string(BuildStatusStarted)}
+
+type dashboardBuildIDs struct {
+	next, finished, transition sql.NullInt64
+}
+
+// dashboardRow scans the job columns of a dashboardQuery row into the job,
+// leaving the IDs of its builds to be scanned along with them.
+type dashboardRow struct {
+	row scannable
+	ids *dashboardBuildIDs
+}
+
+func (r dashboardRow) Scan(dest ...interface{}) error {
+	return r.row.Scan(append(dest, &r.ids.next, &r.ids.finished, &r.ids.transition)...)
+}
+
+// scanDashboard scans the jobs of a dashboardQuery and loads all of their
+// builds with one further query. Transition builds are only loaded if asked
+// for.
+func scanDashboard(conn Conn, lockFactory lock.LockFactory, runner Runner, rows *sql.Rows, withTransitionBuilds bool) (Dashboard, error) {
+	defer Close(rows)
+
+	jobs := Jobs{}
+	jobBuildIDs := []dashboardBuildIDs{}
+	buildIDs := []int64{}
+
+	for rows.Next() {
+		job := &job{conn: conn, lockFactory: lockFactory}
+
+		var ids dashboardBuildIDs
+		err := scanJob(job, dashboardRow{row: rows, ids: &ids})
+		if err != nil {
+			return nil, err
+		}
+
+		if !withTransitionBuilds {
+			ids.transition = sql.NullInt64{}
+		}
+
+		for _, id := range []sql.NullInt64{ids.next, ids.finished, ids.transition} {
+			if id.Valid {
+				buildIDs = append(buildIDs, id.Int64)
+			}
+		}
+
+		jobs = append(jobs, job)
+		jobBuildIDs = append(jobBuildIDs, ids)
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	builds := map[int64]Build{}
+	if len(buildIDs) > 0 {
+		buildRows, err := buildsQuery.
+			Where(sq.Eq{"b.id": buildIDs}).
+			RunWith(runner).
+			Query()
+		if err != nil {
+			return nil, err
+		}
+
+		defer Close(buildRows)
+
+		for buildRows.Next() {
+			build := &build{conn: conn, lockFactory: lockFactory}
+			err := scanBuild(build, buildRows, conn.EncryptionStrategy())
+			if err != nil {
+				return nil, err
+			}
+
+			builds[int64(build.ID())] = build
+		}
+
+		err = buildRows.Err()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dashboard := Dashboard{}
+	for i, job := range jobs {
+		dashboardJob := DashboardJob{Job: job}
+
+		ids := jobBuildIDs[i]
+
+		if build, found := builds[ids.next.Int64]; ids.next.Valid && found {
+			dashboardJob.NextBuild = build
+		}
+
+		if build, found := builds[ids.finished.Int64]; ids.finished.Valid && found {
+			dashboardJob.FinishedBuild = build
+		}
+
+		if build, found := builds[ids.transition.Int64]; ids.transition.Valid && found {
+			dashboardJob.TransitionBuild = build
+		}
+
+		dashboard = append(dashboard, dashboardJob)
+	}
+
+	return dashboard, nil
+}
+
+// updateDashboardBuilds brings the dashboard builds of the job up to date
+// with one of its builds, which has just been created or changed status.
+//
+// A build finishing after a newer one has leaves the job's builds to be
+// found again with refreshDashboardBuilds, which is slower but only happens
+// when builds of the job run in parallel.
+func updateDashboardBuilds(tx Tx, jobID int, buildID int) error {
+	var (
+		status         string
+		ids            dashboardBuildIDs
+		finishedStatus sql.NullString
+	)
+
+	err := psql.Select("b.status", "j.next_build_id", "j.latest_completed_build_id", "j.transition_build_id", "f.status").
+		From("builds b").
+		Join("jobs j ON j.id = b.job_id").
+		LeftJoin("builds f ON f.id = j.latest_completed_build_id").
+		Where(sq.Eq{
+			"b.id": buildID,
+			"j.id": jobID,
+		}).
+		Suffix("FOR UPDATE OF j").
+		RunWith(tx).
+		QueryRow().
+		Scan(&status, &ids.next, &ids.finished, &ids.transition, &finishedStatus)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// the build has since been detached from the job
+			return nil
+		}
+
+		return err
+	}
+
+	id := sql.NullInt64{Int64: int64(buildID), Valid: true}
+
+	if status == string(BuildStatusPending) || status == string(BuildStatusStarted) {
+		if !ids.next.Valid || id.Int64 < ids.next.Int64 {
+			ids.next = id
+		}
+	} else {
+		switch {
+		case !ids.finished.Valid || id.Int64 > ids.finished.Int64:
+			if !ids.transition.Valid || finishedStatus.String != status {
+				ids.transition = id
+			}
+
+			ids.finished = id
+
+		case id.Int64 == ids.finished.Int64 && finishedStatus.String == status:
+			// finished as it was marked when it was aborted
+
+		default:
+			return refreshDashboardBuilds(tx, jobID)
+		}
+
+		if ids.next.Valid && ids.next.Int64 == id.Int64 {
+			err = psql.Select("min(id)").
+				From("builds").
+				Where(sq.Eq{
+					"job_id": jobID,
+					"status": runningBuildStatuses,
+				}).
+				RunWith(tx).
+				QueryRow().
+				Scan(&ids.next)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = psql.Update("jobs").
+		Set("next_build_id", ids.next).
+		Set("latest_completed_build_id", ids.finished).
+		Set("transition_build_id", ids.transition).
+		Where(sq.Eq{"id": jobID}).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+// refreshDashboardBuilds finds the dashboard builds of the job from all of
+// its builds.
+func refreshDashboardBuilds(runner Runner, jobID int) error {
+	_, err := runner.Exec(`
+		UPDATE jobs j
+		SET next_build_id = (
+				SELECT min(b.id)
+				FROM builds b
+				WHERE b.job_id = j.id
+				AND b.status IN ('pending', 'started')
+			),
+			latest_completed_build_id = (
+				SELECT max(b.id)
+				FROM builds b
+				WHERE b.job_id = j.id
+				AND b.status NOT IN ('pending', 'started')
+			)
+		WHERE j.id = $1
+	`, jobID)
+	if err != nil {
+		return err
+	}
+
+	_, err = runner.Exec(`
+		UPDATE jobs j
+		SET transition_build_id = (
+			SELECT min(b.id)
+			FROM builds b
+			WHERE b.job_id = j.id
+			AND b.status NOT IN ('pending', 'started')
+			AND b.id > COALESCE((
+				SELECT max(d.id)
+				FROM builds d, builds l
+				WHERE l.id = j.latest_completed_build_id
+				AND d.job_id = j.id
+				AND d.status NOT IN ('pending', 'started')
+				AND d.status <> l.status
+			), 0)
+		)
+		WHERE j.id = $1
+	`, jobID)
+	return err
+}
@@ -410,10 +410,12 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
-	MarkAsAbortedStub        func() error
+	MarkAsAbortedStub        func(db.BuildAbortCause) error
 	markAsAbortedMutex       sync.RWMutex
-	markAsAbortedArgsForCall []struct{}
-	markAsAbortedReturns     struct {
+	markAsAbortedArgsForCall []struct {
+		arg1 db.BuildAbortCause
+	}
+	markAsAbortedReturns struct {
 		result1 error
 	}
 	markAsAbortedReturnsOnCall map[int]struct {
@@ -441,6 +443,268 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	SchedulingCheckpointStub        func() (db.BuildSchedulingCheckpoint, error)
+	schedulingCheckpointMutex       sync.RWMutex
+	schedulingCheckpointArgsForCall []struct{}
+	schedulingCheckpointReturns     struct {
+		result1 db.BuildSchedulingCheckpoint
+		result2 error
+	}
+	schedulingCheckpointReturnsOnCall map[int]struct {
+		result1 db.BuildSchedulingCheckpoint
+		result2 error
+	}
+	SaveSchedulingCheckpointStub        func(db.BuildSchedulingCheckpoint) error
+	saveSchedulingCheckpointMutex       sync.RWMutex
+	saveSchedulingCheckpointArgsForCall []struct {
+		arg1 db.BuildSchedulingCheckpoint
+	}
+	saveSchedulingCheckpointReturns struct {
+		result1 error
+	}
+	saveSchedulingCheckpointReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StepStatesStub        func() (map[atc.PlanID]json.RawMessage, error)
+	stepStatesMutex       sync.RWMutex
+	stepStatesArgsForCall []struct{}
+	stepStatesReturns     struct {
+		result1 map[atc.PlanID]json.RawMessage
+		result2 error
+	}
+	stepStatesReturnsOnCall map[int]struct {
+		result1 map[atc.PlanID]json.RawMessage
+		result2 error
+	}
+	SaveStepStateStub        func(atc.PlanID, json.RawMessage) error
+	saveStepStateMutex       sync.RWMutex
+	saveStepStateArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 json.RawMessage
+	}
+	saveStepStateReturns struct {
+		result1 error
+	}
+	saveStepStateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EventCheckpointStub        func(string) (uint, bool, error)
+	eventCheckpointMutex       sync.RWMutex
+	eventCheckpointArgsForCall []struct {
+		arg1 string
+	}
+	eventCheckpointReturns struct {
+		result1 uint
+		result2 bool
+		result3 error
+	}
+	eventCheckpointReturnsOnCall map[int]struct {
+		result1 uint
+		result2 bool
+		result3 error
+	}
+	SaveEventCheckpointStub        func(string, uint) error
+	saveEventCheckpointMutex       sync.RWMutex
+	saveEventCheckpointArgsForCall []struct {
+		arg1 string
+		arg2 uint
+	}
+	saveEventCheckpointReturns struct {
+		result1 error
+	}
+	saveEventCheckpointReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TriggerCauseStub        func() db.BuildTriggerCause
+	triggerCauseMutex       sync.RWMutex
+	triggerCauseArgsForCall []struct{}
+	triggerCauseReturns     struct {
+		result1 db.BuildTriggerCause
+	}
+	triggerCauseReturnsOnCall map[int]struct {
+		result1 db.BuildTriggerCause
+	}
+	AbortCauseStub        func() db.BuildAbortCause
+	abortCauseMutex       sync.RWMutex
+	abortCauseArgsForCall []struct{}
+	abortCauseReturns     struct {
+		result1 db.BuildAbortCause
+	}
+	abortCauseReturnsOnCall map[int]struct {
+		result1 db.BuildAbortCause
+	}
+	RerunOfStub        func() int
+	rerunOfMutex       sync.RWMutex
+	rerunOfArgsForCall []struct{}
+	rerunOfReturns     struct {
+		result1 int
+	}
+	rerunOfReturnsOnCall map[int]struct {
+		result1 int
+	}
+	RerunFromStub        func() atc.PlanID
+	rerunFromMutex       sync.RWMutex
+	rerunFromArgsForCall []struct{}
+	rerunFromReturns     struct {
+		result1 atc.PlanID
+	}
+	rerunFromReturnsOnCall map[int]struct {
+		result1 atc.PlanID
+	}
+	RerunStub        func(atc.PlanID, []atc.PlanID, db.BuildTriggerCause) (db.Build, error)
+	rerunMutex       sync.RWMutex
+	rerunArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 []atc.PlanID
+		arg3 db.BuildTriggerCause
+	}
+	rerunReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	rerunReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
+	LastHeartbeatStub        func() time.Time
+	lastHeartbeatMutex       sync.RWMutex
+	lastHeartbeatArgsForCall []struct{}
+	lastHeartbeatReturns     struct {
+		result1 time.Time
+	}
+	lastHeartbeatReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	IsOrphanedStub        func() bool
+	isOrphanedMutex       sync.RWMutex
+	isOrphanedArgsForCall []struct{}
+	isOrphanedReturns     struct {
+		result1 bool
+	}
+	isOrphanedReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	HeartbeatStub        func() error
+	heartbeatMutex       sync.RWMutex
+	heartbeatArgsForCall []struct{}
+	heartbeatReturns     struct {
+		result1 error
+	}
+	heartbeatReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ArchivedFromStub        func() string
+	archivedFromMutex       sync.RWMutex
+	archivedFromArgsForCall []struct{}
+	archivedFromReturns     struct {
+		result1 string
+	}
+	archivedFromReturnsOnCall map[int]struct {
+		result1 string
+	}
+	EnvironmentStub        func() (atc.BuildEnvironment, bool, error)
+	environmentMutex       sync.RWMutex
+	environmentArgsForCall []struct{}
+	environmentReturns     struct {
+		result1 atc.BuildEnvironment
+		result2 bool
+		result3 error
+	}
+	environmentReturnsOnCall map[int]struct {
+		result1 atc.BuildEnvironment
+		result2 bool
+		result3 error
+	}
+	SaveEnvironmentStub        func(atc.BuildEnvironment) error
+	saveEnvironmentMutex       sync.RWMutex
+	saveEnvironmentArgsForCall []struct {
+		arg1 atc.BuildEnvironment
+	}
+	saveEnvironmentReturns struct {
+		result1 error
+	}
+	saveEnvironmentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ContainerUsagesStub        func() ([]atc.BuildContainerUsage, error)
+	containerUsagesMutex       sync.RWMutex
+	containerUsagesArgsForCall []struct{}
+	containerUsagesReturns     struct {
+		result1 []atc.BuildContainerUsage
+		result2 error
+	}
+	containerUsagesReturnsOnCall map[int]struct {
+		result1 []atc.BuildContainerUsage
+		result2 error
+	}
+	EventsArchivedStub        func() bool
+	eventsArchivedMutex       sync.RWMutex
+	eventsArchivedArgsForCall []struct{}
+	eventsArchivedReturns     struct {
+		result1 bool
+	}
+	eventsArchivedReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	ArchiveEventsStub        func() error
+	archiveEventsMutex       sync.RWMutex
+	archiveEventsArgsForCall []struct{}
+	archiveEventsReturns     struct {
+		result1 error
+	}
+	archiveEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveEventsStub        func([]atc.Event) error
+	saveEventsMutex       sync.RWMutex
+	saveEventsArgsForCall []struct {
+		events []atc.Event
+	}
+	saveEventsReturns struct {
+		result1 error
+	}
+	saveEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RerunOfBuildStub        func() (db.Build, bool, error)
+	rerunOfBuildMutex       sync.RWMutex
+	rerunOfBuildArgsForCall []struct{}
+	rerunOfBuildReturns     struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	rerunOfBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	ReplayContainerStub        func(atc.PlanID) (db.CreatedContainer, bool, error)
+	replayContainerMutex       sync.RWMutex
+	replayContainerArgsForCall []struct {
+		planID atc.PlanID
+	}
+	replayContainerReturns struct {
+		result1 db.CreatedContainer
+		result2 bool
+		result3 error
+	}
+	replayContainerReturnsOnCall map[int]struct {
+		result1 db.CreatedContainer
+		result2 bool
+		result3 error
+	}
+	WorkerNamesStub        func() ([]string, error)
+	workerNamesMutex       sync.RWMutex
+	workerNamesArgsForCall []struct{}
+	workerNamesReturns     struct {
+		result1 []string
+		result2 error
+	}
+	workerNamesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -2111,14 +2375,16 @@ func (fake *FakeBuild) DeleteReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeBuild) MarkAsAborted() error {
+func (fake *FakeBuild) MarkAsAborted(arg1 db.BuildAbortCause) error {
 	fake.markAsAbortedMutex.Lock()
 	ret, specificReturn := fake.markAsAbortedReturnsOnCall[len(fake.markAsAbortedArgsForCall)]
-	fake.markAsAbortedArgsForCall = append(fake.markAsAbortedArgsForCall, struct{}{})
-	fake.recordInvocation("MarkAsAborted", []interface{}{})
+	fake.markAsAbortedArgsForCall = append(fake.markAsAbortedArgsForCall, struct {
+		arg1 db.BuildAbortCause
+	}{arg1})
+	fake.recordInvocation("MarkAsAborted", []interface{}{arg1})
 	fake.markAsAbortedMutex.Unlock()
 	if fake.MarkAsAbortedStub != nil {
-		return fake.MarkAsAbortedStub()
+		return fake.MarkAsAbortedStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -2132,6 +2398,12 @@ func (fake *FakeBuild) MarkAsAbortedCallCount() int {
 	return len(fake.markAsAbortedArgsForCall)
 }
 
+func (fake *FakeBuild) MarkAsAbortedArgsForCall(i int) db.BuildAbortCause {
+	fake.markAsAbortedMutex.RLock()
+	defer fake.markAsAbortedMutex.RUnlock()
+	return fake.markAsAbortedArgsForCall[i].arg1
+}
+
 func (fake *FakeBuild) MarkAsAbortedReturns(result1 error) {
 	fake.MarkAsAbortedStub = nil
 	fake.markAsAbortedReturns = struct {
@@ -2237,6 +2509,1083 @@ func (fake *FakeBuild) ScheduleReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) SchedulingCheckpoint() (db.BuildSchedulingCheckpoint, error) {
+	fake.schedulingCheckpointMutex.Lock()
+	ret, specificReturn := fake.schedulingCheckpointReturnsOnCall[len(fake.schedulingCheckpointArgsForCall)]
+	fake.schedulingCheckpointArgsForCall = append(fake.schedulingCheckpointArgsForCall, struct{}{})
+	fake.recordInvocation("SchedulingCheckpoint", []interface{}{})
+	fake.schedulingCheckpointMutex.Unlock()
+	if fake.SchedulingCheckpointStub != nil {
+		return fake.SchedulingCheckpointStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.schedulingCheckpointReturns.result1, fake.schedulingCheckpointReturns.result2
+}
+
+func (fake *FakeBuild) SchedulingCheckpointCallCount() int {
+	fake.schedulingCheckpointMutex.RLock()
+	defer fake.schedulingCheckpointMutex.RUnlock()
+	return len(fake.schedulingCheckpointArgsForCall)
+}
+
+func (fake *FakeBuild) SchedulingCheckpointReturns(result1 db.BuildSchedulingCheckpoint, result2 error) {
+	fake.SchedulingCheckpointStub = nil
+	fake.schedulingCheckpointReturns = struct {
+		result1 db.BuildSchedulingCheckpoint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SchedulingCheckpointReturnsOnCall(i int, result1 db.BuildSchedulingCheckpoint, result2 error) {
+	fake.SchedulingCheckpointStub = nil
+	if fake.schedulingCheckpointReturnsOnCall == nil {
+		fake.schedulingCheckpointReturnsOnCall = make(map[int]struct {
+			result1 db.BuildSchedulingCheckpoint
+			result2 error
+		})
+	}
+	fake.schedulingCheckpointReturnsOnCall[i] = struct {
+		result1 db.BuildSchedulingCheckpoint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SaveSchedulingCheckpoint(arg1 db.BuildSchedulingCheckpoint) error {
+	fake.saveSchedulingCheckpointMutex.Lock()
+	ret, specificReturn := fake.saveSchedulingCheckpointReturnsOnCall[len(fake.saveSchedulingCheckpointArgsForCall)]
+	fake.saveSchedulingCheckpointArgsForCall = append(fake.saveSchedulingCheckpointArgsForCall, struct {
+		arg1 db.BuildSchedulingCheckpoint
+	}{arg1})
+	fake.recordInvocation("SaveSchedulingCheckpoint", []interface{}{arg1})
+	fake.saveSchedulingCheckpointMutex.Unlock()
+	if fake.SaveSchedulingCheckpointStub != nil {
+		return fake.SaveSchedulingCheckpointStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveSchedulingCheckpointReturns.result1
+}
+
+func (fake *FakeBuild) SaveSchedulingCheckpointCallCount() int {
+	fake.saveSchedulingCheckpointMutex.RLock()
+	defer fake.saveSchedulingCheckpointMutex.RUnlock()
+	return len(fake.saveSchedulingCheckpointArgsForCall)
+}
+
+func (fake *FakeBuild) SaveSchedulingCheckpointArgsForCall(i int) db.BuildSchedulingCheckpoint {
+	fake.saveSchedulingCheckpointMutex.RLock()
+	defer fake.saveSchedulingCheckpointMutex.RUnlock()
+	return fake.saveSchedulingCheckpointArgsForCall[i].arg1
+}
+
+func (fake *FakeBuild) SaveSchedulingCheckpointReturns(result1 error) {
+	fake.SaveSchedulingCheckpointStub = nil
+	fake.saveSchedulingCheckpointReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveSchedulingCheckpointReturnsOnCall(i int, result1 error) {
+	fake.SaveSchedulingCheckpointStub = nil
+	if fake.saveSchedulingCheckpointReturnsOnCall == nil {
+		fake.saveSchedulingCheckpointReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveSchedulingCheckpointReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) StepStates() (map[atc.PlanID]json.RawMessage, error) {
+	fake.stepStatesMutex.Lock()
+	ret, specificReturn := fake.stepStatesReturnsOnCall[len(fake.stepStatesArgsForCall)]
+	fake.stepStatesArgsForCall = append(fake.stepStatesArgsForCall, struct{}{})
+	fake.recordInvocation("StepStates", []interface{}{})
+	fake.stepStatesMutex.Unlock()
+	if fake.StepStatesStub != nil {
+		return fake.StepStatesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.stepStatesReturns.result1, fake.stepStatesReturns.result2
+}
+
+func (fake *FakeBuild) StepStatesCallCount() int {
+	fake.stepStatesMutex.RLock()
+	defer fake.stepStatesMutex.RUnlock()
+	return len(fake.stepStatesArgsForCall)
+}
+
+func (fake *FakeBuild) StepStatesReturns(result1 map[atc.PlanID]json.RawMessage, result2 error) {
+	fake.StepStatesStub = nil
+	fake.stepStatesReturns = struct {
+		result1 map[atc.PlanID]json.RawMessage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) StepStatesReturnsOnCall(i int, result1 map[atc.PlanID]json.RawMessage, result2 error) {
+	fake.StepStatesStub = nil
+	if fake.stepStatesReturnsOnCall == nil {
+		fake.stepStatesReturnsOnCall = make(map[int]struct {
+			result1 map[atc.PlanID]json.RawMessage
+			result2 error
+		})
+	}
+	fake.stepStatesReturnsOnCall[i] = struct {
+		result1 map[atc.PlanID]json.RawMessage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SaveStepState(arg1 atc.PlanID, arg2 json.RawMessage) error {
+	fake.saveStepStateMutex.Lock()
+	ret, specificReturn := fake.saveStepStateReturnsOnCall[len(fake.saveStepStateArgsForCall)]
+	fake.saveStepStateArgsForCall = append(fake.saveStepStateArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 json.RawMessage
+	}{arg1, arg2})
+	fake.recordInvocation("SaveStepState", []interface{}{arg1, arg2})
+	fake.saveStepStateMutex.Unlock()
+	if fake.SaveStepStateStub != nil {
+		return fake.SaveStepStateStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveStepStateReturns.result1
+}
+
+func (fake *FakeBuild) SaveStepStateCallCount() int {
+	fake.saveStepStateMutex.RLock()
+	defer fake.saveStepStateMutex.RUnlock()
+	return len(fake.saveStepStateArgsForCall)
+}
+
+func (fake *FakeBuild) SaveStepStateArgsForCall(i int) (atc.PlanID, json.RawMessage) {
+	fake.saveStepStateMutex.RLock()
+	defer fake.saveStepStateMutex.RUnlock()
+	return fake.saveStepStateArgsForCall[i].arg1, fake.saveStepStateArgsForCall[i].arg2
+}
+
+func (fake *FakeBuild) SaveStepStateReturns(result1 error) {
+	fake.SaveStepStateStub = nil
+	fake.saveStepStateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveStepStateReturnsOnCall(i int, result1 error) {
+	fake.SaveStepStateStub = nil
+	if fake.saveStepStateReturnsOnCall == nil {
+		fake.saveStepStateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveStepStateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) EventCheckpoint(arg1 string) (uint, bool, error) {
+	fake.eventCheckpointMutex.Lock()
+	ret, specificReturn := fake.eventCheckpointReturnsOnCall[len(fake.eventCheckpointArgsForCall)]
+	fake.eventCheckpointArgsForCall = append(fake.eventCheckpointArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("EventCheckpoint", []interface{}{arg1})
+	fake.eventCheckpointMutex.Unlock()
+	if fake.EventCheckpointStub != nil {
+		return fake.EventCheckpointStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.eventCheckpointReturns.result1, fake.eventCheckpointReturns.result2, fake.eventCheckpointReturns.result3
+}
+
+func (fake *FakeBuild) EventCheckpointCallCount() int {
+	fake.eventCheckpointMutex.RLock()
+	defer fake.eventCheckpointMutex.RUnlock()
+	return len(fake.eventCheckpointArgsForCall)
+}
+
+func (fake *FakeBuild) EventCheckpointArgsForCall(i int) string {
+	fake.eventCheckpointMutex.RLock()
+	defer fake.eventCheckpointMutex.RUnlock()
+	return fake.eventCheckpointArgsForCall[i].arg1
+}
+
+func (fake *FakeBuild) EventCheckpointReturns(result1 uint, result2 bool, result3 error) {
+	fake.EventCheckpointStub = nil
+	fake.eventCheckpointReturns = struct {
+		result1 uint
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) EventCheckpointReturnsOnCall(i int, result1 uint, result2 bool, result3 error) {
+	fake.EventCheckpointStub = nil
+	if fake.eventCheckpointReturnsOnCall == nil {
+		fake.eventCheckpointReturnsOnCall = make(map[int]struct {
+			result1 uint
+			result2 bool
+			result3 error
+		})
+	}
+	fake.eventCheckpointReturnsOnCall[i] = struct {
+		result1 uint
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) SaveEventCheckpoint(arg1 string, arg2 uint) error {
+	fake.saveEventCheckpointMutex.Lock()
+	ret, specificReturn := fake.saveEventCheckpointReturnsOnCall[len(fake.saveEventCheckpointArgsForCall)]
+	fake.saveEventCheckpointArgsForCall = append(fake.saveEventCheckpointArgsForCall, struct {
+		arg1 string
+		arg2 uint
+	}{arg1, arg2})
+	fake.recordInvocation("SaveEventCheckpoint", []interface{}{arg1, arg2})
+	fake.saveEventCheckpointMutex.Unlock()
+	if fake.SaveEventCheckpointStub != nil {
+		return fake.SaveEventCheckpointStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveEventCheckpointReturns.result1
+}
+
+func (fake *FakeBuild) SaveEventCheckpointCallCount() int {
+	fake.saveEventCheckpointMutex.RLock()
+	defer fake.saveEventCheckpointMutex.RUnlock()
+	return len(fake.saveEventCheckpointArgsForCall)
+}
+
+func (fake *FakeBuild) SaveEventCheckpointArgsForCall(i int) (string, uint) {
+	fake.saveEventCheckpointMutex.RLock()
+	defer fake.saveEventCheckpointMutex.RUnlock()
+	return fake.saveEventCheckpointArgsForCall[i].arg1, fake.saveEventCheckpointArgsForCall[i].arg2
+}
+
+func (fake *FakeBuild) SaveEventCheckpointReturns(result1 error) {
+	fake.SaveEventCheckpointStub = nil
+	fake.saveEventCheckpointReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEventCheckpointReturnsOnCall(i int, result1 error) {
+	fake.SaveEventCheckpointStub = nil
+	if fake.saveEventCheckpointReturnsOnCall == nil {
+		fake.saveEventCheckpointReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveEventCheckpointReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) TriggerCause() db.BuildTriggerCause {
+	fake.triggerCauseMutex.Lock()
+	ret, specificReturn := fake.triggerCauseReturnsOnCall[len(fake.triggerCauseArgsForCall)]
+	fake.triggerCauseArgsForCall = append(fake.triggerCauseArgsForCall, struct{}{})
+	fake.recordInvocation("TriggerCause", []interface{}{})
+	fake.triggerCauseMutex.Unlock()
+	if fake.TriggerCauseStub != nil {
+		return fake.TriggerCauseStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.triggerCauseReturns.result1
+}
+
+func (fake *FakeBuild) TriggerCauseCallCount() int {
+	fake.triggerCauseMutex.RLock()
+	defer fake.triggerCauseMutex.RUnlock()
+	return len(fake.triggerCauseArgsForCall)
+}
+
+func (fake *FakeBuild) TriggerCauseReturns(result1 db.BuildTriggerCause) {
+	fake.TriggerCauseStub = nil
+	fake.triggerCauseReturns = struct {
+		result1 db.BuildTriggerCause
+	}{result1}
+}
+
+func (fake *FakeBuild) TriggerCauseReturnsOnCall(i int, result1 db.BuildTriggerCause) {
+	fake.TriggerCauseStub = nil
+	if fake.triggerCauseReturnsOnCall == nil {
+		fake.triggerCauseReturnsOnCall = make(map[int]struct {
+			result1 db.BuildTriggerCause
+		})
+	}
+	fake.triggerCauseReturnsOnCall[i] = struct {
+		result1 db.BuildTriggerCause
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortCause() db.BuildAbortCause {
+	fake.abortCauseMutex.Lock()
+	ret, specificReturn := fake.abortCauseReturnsOnCall[len(fake.abortCauseArgsForCall)]
+	fake.abortCauseArgsForCall = append(fake.abortCauseArgsForCall, struct{}{})
+	fake.recordInvocation("AbortCause", []interface{}{})
+	fake.abortCauseMutex.Unlock()
+	if fake.AbortCauseStub != nil {
+		return fake.AbortCauseStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.abortCauseReturns.result1
+}
+
+func (fake *FakeBuild) AbortCauseCallCount() int {
+	fake.abortCauseMutex.RLock()
+	defer fake.abortCauseMutex.RUnlock()
+	return len(fake.abortCauseArgsForCall)
+}
+
+func (fake *FakeBuild) AbortCauseReturns(result1 db.BuildAbortCause) {
+	fake.AbortCauseStub = nil
+	fake.abortCauseReturns = struct {
+		result1 db.BuildAbortCause
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortCauseReturnsOnCall(i int, result1 db.BuildAbortCause) {
+	fake.AbortCauseStub = nil
+	if fake.abortCauseReturnsOnCall == nil {
+		fake.abortCauseReturnsOnCall = make(map[int]struct {
+			result1 db.BuildAbortCause
+		})
+	}
+	fake.abortCauseReturnsOnCall[i] = struct {
+		result1 db.BuildAbortCause
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOf() int {
+	fake.rerunOfMutex.Lock()
+	ret, specificReturn := fake.rerunOfReturnsOnCall[len(fake.rerunOfArgsForCall)]
+	fake.rerunOfArgsForCall = append(fake.rerunOfArgsForCall, struct{}{})
+	fake.recordInvocation("RerunOf", []interface{}{})
+	fake.rerunOfMutex.Unlock()
+	if fake.RerunOfStub != nil {
+		return fake.RerunOfStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.rerunOfReturns.result1
+}
+
+func (fake *FakeBuild) RerunOfCallCount() int {
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	return len(fake.rerunOfArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfReturns(result1 int) {
+	fake.RerunOfStub = nil
+	fake.rerunOfReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOfReturnsOnCall(i int, result1 int) {
+	fake.RerunOfStub = nil
+	if fake.rerunOfReturnsOnCall == nil {
+		fake.rerunOfReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.rerunOfReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunFrom() atc.PlanID {
+	fake.rerunFromMutex.Lock()
+	ret, specificReturn := fake.rerunFromReturnsOnCall[len(fake.rerunFromArgsForCall)]
+	fake.rerunFromArgsForCall = append(fake.rerunFromArgsForCall, struct{}{})
+	fake.recordInvocation("RerunFrom", []interface{}{})
+	fake.rerunFromMutex.Unlock()
+	if fake.RerunFromStub != nil {
+		return fake.RerunFromStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.rerunFromReturns.result1
+}
+
+func (fake *FakeBuild) RerunFromCallCount() int {
+	fake.rerunFromMutex.RLock()
+	defer fake.rerunFromMutex.RUnlock()
+	return len(fake.rerunFromArgsForCall)
+}
+
+func (fake *FakeBuild) RerunFromReturns(result1 atc.PlanID) {
+	fake.RerunFromStub = nil
+	fake.rerunFromReturns = struct {
+		result1 atc.PlanID
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunFromReturnsOnCall(i int, result1 atc.PlanID) {
+	fake.RerunFromStub = nil
+	if fake.rerunFromReturnsOnCall == nil {
+		fake.rerunFromReturnsOnCall = make(map[int]struct {
+			result1 atc.PlanID
+		})
+	}
+	fake.rerunFromReturnsOnCall[i] = struct {
+		result1 atc.PlanID
+	}{result1}
+}
+
+func (fake *FakeBuild) Rerun(arg1 atc.PlanID, arg2 []atc.PlanID, arg3 db.BuildTriggerCause) (db.Build, error) {
+	var arg2Copy []atc.PlanID
+	if arg2 != nil {
+		arg2Copy = make([]atc.PlanID, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.rerunMutex.Lock()
+	ret, specificReturn := fake.rerunReturnsOnCall[len(fake.rerunArgsForCall)]
+	fake.rerunArgsForCall = append(fake.rerunArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 []atc.PlanID
+		arg3 db.BuildTriggerCause
+	}{arg1, arg2Copy, arg3})
+	fake.recordInvocation("Rerun", []interface{}{arg1, arg2Copy, arg3})
+	fake.rerunMutex.Unlock()
+	if fake.RerunStub != nil {
+		return fake.RerunStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.rerunReturns.result1, fake.rerunReturns.result2
+}
+
+func (fake *FakeBuild) RerunCallCount() int {
+	fake.rerunMutex.RLock()
+	defer fake.rerunMutex.RUnlock()
+	return len(fake.rerunArgsForCall)
+}
+
+func (fake *FakeBuild) RerunArgsForCall(i int) (atc.PlanID, []atc.PlanID, db.BuildTriggerCause) {
+	fake.rerunMutex.RLock()
+	defer fake.rerunMutex.RUnlock()
+	return fake.rerunArgsForCall[i].arg1, fake.rerunArgsForCall[i].arg2, fake.rerunArgsForCall[i].arg3
+}
+
+func (fake *FakeBuild) RerunReturns(result1 db.Build, result2 error) {
+	fake.RerunStub = nil
+	fake.rerunReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) RerunReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.RerunStub = nil
+	if fake.rerunReturnsOnCall == nil {
+		fake.rerunReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.rerunReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) LastHeartbeat() time.Time {
+	fake.lastHeartbeatMutex.Lock()
+	ret, specificReturn := fake.lastHeartbeatReturnsOnCall[len(fake.lastHeartbeatArgsForCall)]
+	fake.lastHeartbeatArgsForCall = append(fake.lastHeartbeatArgsForCall, struct{}{})
+	fake.recordInvocation("LastHeartbeat", []interface{}{})
+	fake.lastHeartbeatMutex.Unlock()
+	if fake.LastHeartbeatStub != nil {
+		return fake.LastHeartbeatStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.lastHeartbeatReturns.result1
+}
+
+func (fake *FakeBuild) LastHeartbeatCallCount() int {
+	fake.lastHeartbeatMutex.RLock()
+	defer fake.lastHeartbeatMutex.RUnlock()
+	return len(fake.lastHeartbeatArgsForCall)
+}
+
+func (fake *FakeBuild) LastHeartbeatReturns(result1 time.Time) {
+	fake.LastHeartbeatStub = nil
+	fake.lastHeartbeatReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) LastHeartbeatReturnsOnCall(i int, result1 time.Time) {
+	fake.LastHeartbeatStub = nil
+	if fake.lastHeartbeatReturnsOnCall == nil {
+		fake.lastHeartbeatReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.lastHeartbeatReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeBuild) IsOrphaned() bool {
+	fake.isOrphanedMutex.Lock()
+	ret, specificReturn := fake.isOrphanedReturnsOnCall[len(fake.isOrphanedArgsForCall)]
+	fake.isOrphanedArgsForCall = append(fake.isOrphanedArgsForCall, struct{}{})
+	fake.recordInvocation("IsOrphaned", []interface{}{})
+	fake.isOrphanedMutex.Unlock()
+	if fake.IsOrphanedStub != nil {
+		return fake.IsOrphanedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.isOrphanedReturns.result1
+}
+
+func (fake *FakeBuild) IsOrphanedCallCount() int {
+	fake.isOrphanedMutex.RLock()
+	defer fake.isOrphanedMutex.RUnlock()
+	return len(fake.isOrphanedArgsForCall)
+}
+
+func (fake *FakeBuild) IsOrphanedReturns(result1 bool) {
+	fake.IsOrphanedStub = nil
+	fake.isOrphanedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsOrphanedReturnsOnCall(i int, result1 bool) {
+	fake.IsOrphanedStub = nil
+	if fake.isOrphanedReturnsOnCall == nil {
+		fake.isOrphanedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isOrphanedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) Heartbeat() error {
+	fake.heartbeatMutex.Lock()
+	ret, specificReturn := fake.heartbeatReturnsOnCall[len(fake.heartbeatArgsForCall)]
+	fake.heartbeatArgsForCall = append(fake.heartbeatArgsForCall, struct{}{})
+	fake.recordInvocation("Heartbeat", []interface{}{})
+	fake.heartbeatMutex.Unlock()
+	if fake.HeartbeatStub != nil {
+		return fake.HeartbeatStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.heartbeatReturns.result1
+}
+
+func (fake *FakeBuild) HeartbeatCallCount() int {
+	fake.heartbeatMutex.RLock()
+	defer fake.heartbeatMutex.RUnlock()
+	return len(fake.heartbeatArgsForCall)
+}
+
+func (fake *FakeBuild) HeartbeatReturns(result1 error) {
+	fake.HeartbeatStub = nil
+	fake.heartbeatReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) HeartbeatReturnsOnCall(i int, result1 error) {
+	fake.HeartbeatStub = nil
+	if fake.heartbeatReturnsOnCall == nil {
+		fake.heartbeatReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.heartbeatReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ArchivedFrom() string {
+	fake.archivedFromMutex.Lock()
+	ret, specificReturn := fake.archivedFromReturnsOnCall[len(fake.archivedFromArgsForCall)]
+	fake.archivedFromArgsForCall = append(fake.archivedFromArgsForCall, struct{}{})
+	fake.recordInvocation("ArchivedFrom", []interface{}{})
+	fake.archivedFromMutex.Unlock()
+	if fake.ArchivedFromStub != nil {
+		return fake.ArchivedFromStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.archivedFromReturns.result1
+}
+
+func (fake *FakeBuild) ArchivedFromCallCount() int {
+	fake.archivedFromMutex.RLock()
+	defer fake.archivedFromMutex.RUnlock()
+	return len(fake.archivedFromArgsForCall)
+}
+
+func (fake *FakeBuild) ArchivedFromReturns(result1 string) {
+	fake.ArchivedFromStub = nil
+	fake.archivedFromReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) ArchivedFromReturnsOnCall(i int, result1 string) {
+	fake.ArchivedFromStub = nil
+	if fake.archivedFromReturnsOnCall == nil {
+		fake.archivedFromReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.archivedFromReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) Environment() (atc.BuildEnvironment, bool, error) {
+	fake.environmentMutex.Lock()
+	ret, specificReturn := fake.environmentReturnsOnCall[len(fake.environmentArgsForCall)]
+	fake.environmentArgsForCall = append(fake.environmentArgsForCall, struct{}{})
+	fake.recordInvocation("Environment", []interface{}{})
+	fake.environmentMutex.Unlock()
+	if fake.EnvironmentStub != nil {
+		return fake.EnvironmentStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.environmentReturns.result1, fake.environmentReturns.result2, fake.environmentReturns.result3
+}
+
+func (fake *FakeBuild) EnvironmentCallCount() int {
+	fake.environmentMutex.RLock()
+	defer fake.environmentMutex.RUnlock()
+	return len(fake.environmentArgsForCall)
+}
+
+func (fake *FakeBuild) EnvironmentReturns(result1 atc.BuildEnvironment, result2 bool, result3 error) {
+	fake.EnvironmentStub = nil
+	fake.environmentReturns = struct {
+		result1 atc.BuildEnvironment
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) EnvironmentReturnsOnCall(i int, result1 atc.BuildEnvironment, result2 bool, result3 error) {
+	fake.EnvironmentStub = nil
+	if fake.environmentReturnsOnCall == nil {
+		fake.environmentReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildEnvironment
+			result2 bool
+			result3 error
+		})
+	}
+	fake.environmentReturnsOnCall[i] = struct {
+		result1 atc.BuildEnvironment
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) SaveEnvironment(arg1 atc.BuildEnvironment) error {
+	fake.saveEnvironmentMutex.Lock()
+	ret, specificReturn := fake.saveEnvironmentReturnsOnCall[len(fake.saveEnvironmentArgsForCall)]
+	fake.saveEnvironmentArgsForCall = append(fake.saveEnvironmentArgsForCall, struct {
+		arg1 atc.BuildEnvironment
+	}{arg1})
+	fake.recordInvocation("SaveEnvironment", []interface{}{arg1})
+	fake.saveEnvironmentMutex.Unlock()
+	if fake.SaveEnvironmentStub != nil {
+		return fake.SaveEnvironmentStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveEnvironmentReturns.result1
+}
+
+func (fake *FakeBuild) SaveEnvironmentCallCount() int {
+	fake.saveEnvironmentMutex.RLock()
+	defer fake.saveEnvironmentMutex.RUnlock()
+	return len(fake.saveEnvironmentArgsForCall)
+}
+
+func (fake *FakeBuild) SaveEnvironmentArgsForCall(i int) atc.BuildEnvironment {
+	fake.saveEnvironmentMutex.RLock()
+	defer fake.saveEnvironmentMutex.RUnlock()
+	return fake.saveEnvironmentArgsForCall[i].arg1
+}
+
+func (fake *FakeBuild) SaveEnvironmentReturns(result1 error) {
+	fake.SaveEnvironmentStub = nil
+	fake.saveEnvironmentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEnvironmentReturnsOnCall(i int, result1 error) {
+	fake.SaveEnvironmentStub = nil
+	if fake.saveEnvironmentReturnsOnCall == nil {
+		fake.saveEnvironmentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveEnvironmentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ContainerUsages() ([]atc.BuildContainerUsage, error) {
+	fake.containerUsagesMutex.Lock()
+	ret, specificReturn := fake.containerUsagesReturnsOnCall[len(fake.containerUsagesArgsForCall)]
+	fake.containerUsagesArgsForCall = append(fake.containerUsagesArgsForCall, struct{}{})
+	fake.recordInvocation("ContainerUsages", []interface{}{})
+	fake.containerUsagesMutex.Unlock()
+	if fake.ContainerUsagesStub != nil {
+		return fake.ContainerUsagesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.containerUsagesReturns.result1, fake.containerUsagesReturns.result2
+}
+
+func (fake *FakeBuild) ContainerUsagesCallCount() int {
+	fake.containerUsagesMutex.RLock()
+	defer fake.containerUsagesMutex.RUnlock()
+	return len(fake.containerUsagesArgsForCall)
+}
+
+func (fake *FakeBuild) ContainerUsagesReturns(result1 []atc.BuildContainerUsage, result2 error) {
+	fake.ContainerUsagesStub = nil
+	fake.containerUsagesReturns = struct {
+		result1 []atc.BuildContainerUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ContainerUsagesReturnsOnCall(i int, result1 []atc.BuildContainerUsage, result2 error) {
+	fake.ContainerUsagesStub = nil
+	if fake.containerUsagesReturnsOnCall == nil {
+		fake.containerUsagesReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildContainerUsage
+			result2 error
+		})
+	}
+	fake.containerUsagesReturnsOnCall[i] = struct {
+		result1 []atc.BuildContainerUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventsArchived() bool {
+	fake.eventsArchivedMutex.Lock()
+	ret, specificReturn := fake.eventsArchivedReturnsOnCall[len(fake.eventsArchivedArgsForCall)]
+	fake.eventsArchivedArgsForCall = append(fake.eventsArchivedArgsForCall, struct{}{})
+	fake.recordInvocation("EventsArchived", []interface{}{})
+	fake.eventsArchivedMutex.Unlock()
+	if fake.EventsArchivedStub != nil {
+		return fake.EventsArchivedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.eventsArchivedReturns.result1
+}
+
+func (fake *FakeBuild) EventsArchivedCallCount() int {
+	fake.eventsArchivedMutex.RLock()
+	defer fake.eventsArchivedMutex.RUnlock()
+	return len(fake.eventsArchivedArgsForCall)
+}
+
+func (fake *FakeBuild) EventsArchivedReturns(result1 bool) {
+	fake.EventsArchivedStub = nil
+	fake.eventsArchivedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) EventsArchivedReturnsOnCall(i int, result1 bool) {
+	fake.EventsArchivedStub = nil
+	if fake.eventsArchivedReturnsOnCall == nil {
+		fake.eventsArchivedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.eventsArchivedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) ArchiveEvents() error {
+	fake.archiveEventsMutex.Lock()
+	ret, specificReturn := fake.archiveEventsReturnsOnCall[len(fake.archiveEventsArgsForCall)]
+	fake.archiveEventsArgsForCall = append(fake.archiveEventsArgsForCall, struct{}{})
+	fake.recordInvocation("ArchiveEvents", []interface{}{})
+	fake.archiveEventsMutex.Unlock()
+	if fake.ArchiveEventsStub != nil {
+		return fake.ArchiveEventsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.archiveEventsReturns.result1
+}
+
+func (fake *FakeBuild) ArchiveEventsCallCount() int {
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
+	return len(fake.archiveEventsArgsForCall)
+}
+
+func (fake *FakeBuild) ArchiveEventsReturns(result1 error) {
+	fake.ArchiveEventsStub = nil
+	fake.archiveEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ArchiveEventsReturnsOnCall(i int, result1 error) {
+	fake.ArchiveEventsStub = nil
+	if fake.archiveEventsReturnsOnCall == nil {
+		fake.archiveEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.archiveEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEvents(events []atc.Event) error {
+	var eventsCopy []atc.Event
+	if events != nil {
+		eventsCopy = make([]atc.Event, len(events))
+		copy(eventsCopy, events)
+	}
+	fake.saveEventsMutex.Lock()
+	ret, specificReturn := fake.saveEventsReturnsOnCall[len(fake.saveEventsArgsForCall)]
+	fake.saveEventsArgsForCall = append(fake.saveEventsArgsForCall, struct {
+		events []atc.Event
+	}{eventsCopy})
+	fake.recordInvocation("SaveEvents", []interface{}{eventsCopy})
+	fake.saveEventsMutex.Unlock()
+	if fake.SaveEventsStub != nil {
+		return fake.SaveEventsStub(events)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveEventsReturns.result1
+}
+
+func (fake *FakeBuild) SaveEventsCallCount() int {
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
+	return len(fake.saveEventsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveEventsArgsForCall(i int) []atc.Event {
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
+	return fake.saveEventsArgsForCall[i].events
+}
+
+func (fake *FakeBuild) SaveEventsReturns(result1 error) {
+	fake.SaveEventsStub = nil
+	fake.saveEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEventsReturnsOnCall(i int, result1 error) {
+	fake.SaveEventsStub = nil
+	if fake.saveEventsReturnsOnCall == nil {
+		fake.saveEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOfBuild() (db.Build, bool, error) {
+	fake.rerunOfBuildMutex.Lock()
+	ret, specificReturn := fake.rerunOfBuildReturnsOnCall[len(fake.rerunOfBuildArgsForCall)]
+	fake.rerunOfBuildArgsForCall = append(fake.rerunOfBuildArgsForCall, struct{}{})
+	fake.recordInvocation("RerunOfBuild", []interface{}{})
+	fake.rerunOfBuildMutex.Unlock()
+	if fake.RerunOfBuildStub != nil {
+		return fake.RerunOfBuildStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.rerunOfBuildReturns.result1, fake.rerunOfBuildReturns.result2, fake.rerunOfBuildReturns.result3
+}
+
+func (fake *FakeBuild) RerunOfBuildCallCount() int {
+	fake.rerunOfBuildMutex.RLock()
+	defer fake.rerunOfBuildMutex.RUnlock()
+	return len(fake.rerunOfBuildArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfBuildReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.RerunOfBuildStub = nil
+	fake.rerunOfBuildReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) RerunOfBuildReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.RerunOfBuildStub = nil
+	if fake.rerunOfBuildReturnsOnCall == nil {
+		fake.rerunOfBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.rerunOfBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ReplayContainer(planID atc.PlanID) (db.CreatedContainer, bool, error) {
+	fake.replayContainerMutex.Lock()
+	ret, specificReturn := fake.replayContainerReturnsOnCall[len(fake.replayContainerArgsForCall)]
+	fake.replayContainerArgsForCall = append(fake.replayContainerArgsForCall, struct {
+		planID atc.PlanID
+	}{planID})
+	fake.recordInvocation("ReplayContainer", []interface{}{planID})
+	fake.replayContainerMutex.Unlock()
+	if fake.ReplayContainerStub != nil {
+		return fake.ReplayContainerStub(planID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.replayContainerReturns.result1, fake.replayContainerReturns.result2, fake.replayContainerReturns.result3
+}
+
+func (fake *FakeBuild) ReplayContainerCallCount() int {
+	fake.replayContainerMutex.RLock()
+	defer fake.replayContainerMutex.RUnlock()
+	return len(fake.replayContainerArgsForCall)
+}
+
+func (fake *FakeBuild) ReplayContainerArgsForCall(i int) atc.PlanID {
+	fake.replayContainerMutex.RLock()
+	defer fake.replayContainerMutex.RUnlock()
+	return fake.replayContainerArgsForCall[i].planID
+}
+
+func (fake *FakeBuild) ReplayContainerReturns(result1 db.CreatedContainer, result2 bool, result3 error) {
+	fake.ReplayContainerStub = nil
+	fake.replayContainerReturns = struct {
+		result1 db.CreatedContainer
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ReplayContainerReturnsOnCall(i int, result1 db.CreatedContainer, result2 bool, result3 error) {
+	fake.ReplayContainerStub = nil
+	if fake.replayContainerReturnsOnCall == nil {
+		fake.replayContainerReturnsOnCall = make(map[int]struct {
+			result1 db.CreatedContainer
+			result2 bool
+			result3 error
+		})
+	}
+	fake.replayContainerReturnsOnCall[i] = struct {
+		result1 db.CreatedContainer
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) WorkerNames() ([]string, error) {
+	fake.workerNamesMutex.Lock()
+	ret, specificReturn := fake.workerNamesReturnsOnCall[len(fake.workerNamesArgsForCall)]
+	fake.workerNamesArgsForCall = append(fake.workerNamesArgsForCall, struct{}{})
+	fake.recordInvocation("WorkerNames", []interface{}{})
+	fake.workerNamesMutex.Unlock()
+	if fake.WorkerNamesStub != nil {
+		return fake.WorkerNamesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.workerNamesReturns.result1, fake.workerNamesReturns.result2
+}
+
+func (fake *FakeBuild) WorkerNamesCallCount() int {
+	fake.workerNamesMutex.RLock()
+	defer fake.workerNamesMutex.RUnlock()
+	return len(fake.workerNamesArgsForCall)
+}
+
+func (fake *FakeBuild) WorkerNamesReturns(result1 []string, result2 error) {
+	fake.WorkerNamesStub = nil
+	fake.workerNamesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) WorkerNamesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.WorkerNamesStub = nil
+	if fake.workerNamesReturnsOnCall == nil {
+		fake.workerNamesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.workerNamesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2322,6 +3671,54 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortNotifierMutex.RUnlock()
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
+	fake.schedulingCheckpointMutex.RLock()
+	defer fake.schedulingCheckpointMutex.RUnlock()
+	fake.saveSchedulingCheckpointMutex.RLock()
+	defer fake.saveSchedulingCheckpointMutex.RUnlock()
+	fake.stepStatesMutex.RLock()
+	defer fake.stepStatesMutex.RUnlock()
+	fake.saveStepStateMutex.RLock()
+	defer fake.saveStepStateMutex.RUnlock()
+	fake.eventCheckpointMutex.RLock()
+	defer fake.eventCheckpointMutex.RUnlock()
+	fake.saveEventCheckpointMutex.RLock()
+	defer fake.saveEventCheckpointMutex.RUnlock()
+	fake.triggerCauseMutex.RLock()
+	defer fake.triggerCauseMutex.RUnlock()
+	fake.abortCauseMutex.RLock()
+	defer fake.abortCauseMutex.RUnlock()
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	fake.rerunFromMutex.RLock()
+	defer fake.rerunFromMutex.RUnlock()
+	fake.rerunMutex.RLock()
+	defer fake.rerunMutex.RUnlock()
+	fake.lastHeartbeatMutex.RLock()
+	defer fake.lastHeartbeatMutex.RUnlock()
+	fake.isOrphanedMutex.RLock()
+	defer fake.isOrphanedMutex.RUnlock()
+	fake.heartbeatMutex.RLock()
+	defer fake.heartbeatMutex.RUnlock()
+	fake.archivedFromMutex.RLock()
+	defer fake.archivedFromMutex.RUnlock()
+	fake.environmentMutex.RLock()
+	defer fake.environmentMutex.RUnlock()
+	fake.saveEnvironmentMutex.RLock()
+	defer fake.saveEnvironmentMutex.RUnlock()
+	fake.containerUsagesMutex.RLock()
+	defer fake.containerUsagesMutex.RUnlock()
+	fake.eventsArchivedMutex.RLock()
+	defer fake.eventsArchivedMutex.RUnlock()
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
+	fake.rerunOfBuildMutex.RLock()
+	defer fake.rerunOfBuildMutex.RUnlock()
+	fake.replayContainerMutex.RLock()
+	defer fake.replayContainerMutex.RUnlock()
+	fake.workerNamesMutex.RLock()
+	defer fake.workerNamesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/atc/db"
 )
@@ -74,6 +75,71 @@ type FakeBuildFactory struct {
 	markNonInterceptibleBuildsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	MarkOrphanedBuildsStub        func(time.Duration) ([]int, error)
+	markOrphanedBuildsMutex       sync.RWMutex
+	markOrphanedBuildsArgsForCall []struct {
+		arg1 time.Duration
+	}
+	markOrphanedBuildsReturns struct {
+		result1 []int
+		result2 error
+	}
+	markOrphanedBuildsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
+	StartedBuildsStub        func(int, int) ([]db.Build, error)
+	startedBuildsMutex       sync.RWMutex
+	startedBuildsArgsForCall []struct {
+		arg1 int
+		arg2 int
+	}
+	startedBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	startedBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	FinishBuildsWithErrorsStub        func(map[int]error) error
+	finishBuildsWithErrorsMutex       sync.RWMutex
+	finishBuildsWithErrorsArgsForCall []struct {
+		arg1 map[int]error
+	}
+	finishBuildsWithErrorsReturns struct {
+		result1 error
+	}
+	finishBuildsWithErrorsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	BuildsToArchiveStub        func(time.Duration, int) ([]db.Build, error)
+	buildsToArchiveMutex       sync.RWMutex
+	buildsToArchiveArgsForCall []struct {
+		age   time.Duration
+		limit int
+	}
+	buildsToArchiveReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	buildsToArchiveReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	MarkUntrackedBuildsStub        func(time.Duration) ([]int, error)
+	markUntrackedBuildsMutex       sync.RWMutex
+	markUntrackedBuildsArgsForCall []struct {
+		grace time.Duration
+	}
+	markUntrackedBuildsReturns struct {
+		result1 []int
+		result2 error
+	}
+	markUntrackedBuildsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -329,6 +395,260 @@ func (fake *FakeBuildFactory) MarkNonInterceptibleBuildsReturnsOnCall(i int, res
 	}{result1}
 }
 
+func (fake *FakeBuildFactory) MarkOrphanedBuilds(arg1 time.Duration) ([]int, error) {
+	fake.markOrphanedBuildsMutex.Lock()
+	ret, specificReturn := fake.markOrphanedBuildsReturnsOnCall[len(fake.markOrphanedBuildsArgsForCall)]
+	fake.markOrphanedBuildsArgsForCall = append(fake.markOrphanedBuildsArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("MarkOrphanedBuilds", []interface{}{arg1})
+	fake.markOrphanedBuildsMutex.Unlock()
+	if fake.MarkOrphanedBuildsStub != nil {
+		return fake.MarkOrphanedBuildsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.markOrphanedBuildsReturns.result1, fake.markOrphanedBuildsReturns.result2
+}
+
+func (fake *FakeBuildFactory) MarkOrphanedBuildsCallCount() int {
+	fake.markOrphanedBuildsMutex.RLock()
+	defer fake.markOrphanedBuildsMutex.RUnlock()
+	return len(fake.markOrphanedBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) MarkOrphanedBuildsArgsForCall(i int) time.Duration {
+	fake.markOrphanedBuildsMutex.RLock()
+	defer fake.markOrphanedBuildsMutex.RUnlock()
+	return fake.markOrphanedBuildsArgsForCall[i].arg1
+}
+
+func (fake *FakeBuildFactory) MarkOrphanedBuildsReturns(result1 []int, result2 error) {
+	fake.MarkOrphanedBuildsStub = nil
+	fake.markOrphanedBuildsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) MarkOrphanedBuildsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.MarkOrphanedBuildsStub = nil
+	if fake.markOrphanedBuildsReturnsOnCall == nil {
+		fake.markOrphanedBuildsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.markOrphanedBuildsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) StartedBuilds(arg1 int, arg2 int) ([]db.Build, error) {
+	fake.startedBuildsMutex.Lock()
+	ret, specificReturn := fake.startedBuildsReturnsOnCall[len(fake.startedBuildsArgsForCall)]
+	fake.startedBuildsArgsForCall = append(fake.startedBuildsArgsForCall, struct {
+		arg1 int
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("StartedBuilds", []interface{}{arg1, arg2})
+	fake.startedBuildsMutex.Unlock()
+	if fake.StartedBuildsStub != nil {
+		return fake.StartedBuildsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.startedBuildsReturns.result1, fake.startedBuildsReturns.result2
+}
+
+func (fake *FakeBuildFactory) StartedBuildsCallCount() int {
+	fake.startedBuildsMutex.RLock()
+	defer fake.startedBuildsMutex.RUnlock()
+	return len(fake.startedBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) StartedBuildsArgsForCall(i int) (int, int) {
+	fake.startedBuildsMutex.RLock()
+	defer fake.startedBuildsMutex.RUnlock()
+	return fake.startedBuildsArgsForCall[i].arg1, fake.startedBuildsArgsForCall[i].arg2
+}
+
+func (fake *FakeBuildFactory) StartedBuildsReturns(result1 []db.Build, result2 error) {
+	fake.StartedBuildsStub = nil
+	fake.startedBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) StartedBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.StartedBuildsStub = nil
+	if fake.startedBuildsReturnsOnCall == nil {
+		fake.startedBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.startedBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) FinishBuildsWithErrors(arg1 map[int]error) error {
+	fake.finishBuildsWithErrorsMutex.Lock()
+	ret, specificReturn := fake.finishBuildsWithErrorsReturnsOnCall[len(fake.finishBuildsWithErrorsArgsForCall)]
+	fake.finishBuildsWithErrorsArgsForCall = append(fake.finishBuildsWithErrorsArgsForCall, struct {
+		arg1 map[int]error
+	}{arg1})
+	fake.recordInvocation("FinishBuildsWithErrors", []interface{}{arg1})
+	fake.finishBuildsWithErrorsMutex.Unlock()
+	if fake.FinishBuildsWithErrorsStub != nil {
+		return fake.FinishBuildsWithErrorsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.finishBuildsWithErrorsReturns.result1
+}
+
+func (fake *FakeBuildFactory) FinishBuildsWithErrorsCallCount() int {
+	fake.finishBuildsWithErrorsMutex.RLock()
+	defer fake.finishBuildsWithErrorsMutex.RUnlock()
+	return len(fake.finishBuildsWithErrorsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) FinishBuildsWithErrorsArgsForCall(i int) map[int]error {
+	fake.finishBuildsWithErrorsMutex.RLock()
+	defer fake.finishBuildsWithErrorsMutex.RUnlock()
+	return fake.finishBuildsWithErrorsArgsForCall[i].arg1
+}
+
+func (fake *FakeBuildFactory) FinishBuildsWithErrorsReturns(result1 error) {
+	fake.FinishBuildsWithErrorsStub = nil
+	fake.finishBuildsWithErrorsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildFactory) FinishBuildsWithErrorsReturnsOnCall(i int, result1 error) {
+	fake.FinishBuildsWithErrorsStub = nil
+	if fake.finishBuildsWithErrorsReturnsOnCall == nil {
+		fake.finishBuildsWithErrorsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.finishBuildsWithErrorsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildFactory) BuildsToArchive(age time.Duration, limit int) ([]db.Build, error) {
+	fake.buildsToArchiveMutex.Lock()
+	ret, specificReturn := fake.buildsToArchiveReturnsOnCall[len(fake.buildsToArchiveArgsForCall)]
+	fake.buildsToArchiveArgsForCall = append(fake.buildsToArchiveArgsForCall, struct {
+		age   time.Duration
+		limit int
+	}{age, limit})
+	fake.recordInvocation("BuildsToArchive", []interface{}{age, limit})
+	fake.buildsToArchiveMutex.Unlock()
+	if fake.BuildsToArchiveStub != nil {
+		return fake.BuildsToArchiveStub(age, limit)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.buildsToArchiveReturns.result1, fake.buildsToArchiveReturns.result2
+}
+
+func (fake *FakeBuildFactory) BuildsToArchiveCallCount() int {
+	fake.buildsToArchiveMutex.RLock()
+	defer fake.buildsToArchiveMutex.RUnlock()
+	return len(fake.buildsToArchiveArgsForCall)
+}
+
+func (fake *FakeBuildFactory) BuildsToArchiveArgsForCall(i int) (time.Duration, int) {
+	fake.buildsToArchiveMutex.RLock()
+	defer fake.buildsToArchiveMutex.RUnlock()
+	return fake.buildsToArchiveArgsForCall[i].age, fake.buildsToArchiveArgsForCall[i].limit
+}
+
+func (fake *FakeBuildFactory) BuildsToArchiveReturns(result1 []db.Build, result2 error) {
+	fake.BuildsToArchiveStub = nil
+	fake.buildsToArchiveReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) BuildsToArchiveReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.BuildsToArchiveStub = nil
+	if fake.buildsToArchiveReturnsOnCall == nil {
+		fake.buildsToArchiveReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.buildsToArchiveReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) MarkUntrackedBuilds(grace time.Duration) ([]int, error) {
+	fake.markUntrackedBuildsMutex.Lock()
+	ret, specificReturn := fake.markUntrackedBuildsReturnsOnCall[len(fake.markUntrackedBuildsArgsForCall)]
+	fake.markUntrackedBuildsArgsForCall = append(fake.markUntrackedBuildsArgsForCall, struct {
+		grace time.Duration
+	}{grace})
+	fake.recordInvocation("MarkUntrackedBuilds", []interface{}{grace})
+	fake.markUntrackedBuildsMutex.Unlock()
+	if fake.MarkUntrackedBuildsStub != nil {
+		return fake.MarkUntrackedBuildsStub(grace)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.markUntrackedBuildsReturns.result1, fake.markUntrackedBuildsReturns.result2
+}
+
+func (fake *FakeBuildFactory) MarkUntrackedBuildsCallCount() int {
+	fake.markUntrackedBuildsMutex.RLock()
+	defer fake.markUntrackedBuildsMutex.RUnlock()
+	return len(fake.markUntrackedBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) MarkUntrackedBuildsArgsForCall(i int) time.Duration {
+	fake.markUntrackedBuildsMutex.RLock()
+	defer fake.markUntrackedBuildsMutex.RUnlock()
+	return fake.markUntrackedBuildsArgsForCall[i].grace
+}
+
+func (fake *FakeBuildFactory) MarkUntrackedBuildsReturns(result1 []int, result2 error) {
+	fake.MarkUntrackedBuildsStub = nil
+	fake.markUntrackedBuildsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) MarkUntrackedBuildsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.MarkUntrackedBuildsStub = nil
+	if fake.markUntrackedBuildsReturnsOnCall == nil {
+		fake.markUntrackedBuildsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.markUntrackedBuildsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -342,6 +662,16 @@ func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	defer fake.getAllStartedBuildsMutex.RUnlock()
 	fake.markNonInterceptibleBuildsMutex.RLock()
 	defer fake.markNonInterceptibleBuildsMutex.RUnlock()
+	fake.markOrphanedBuildsMutex.RLock()
+	defer fake.markOrphanedBuildsMutex.RUnlock()
+	fake.startedBuildsMutex.RLock()
+	defer fake.startedBuildsMutex.RUnlock()
+	fake.finishBuildsWithErrorsMutex.RLock()
+	defer fake.finishBuildsWithErrorsMutex.RUnlock()
+	fake.buildsToArchiveMutex.RLock()
+	defer fake.buildsToArchiveMutex.RUnlock()
+	fake.markUntrackedBuildsMutex.RLock()
+	defer fake.markUntrackedBuildsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"sync"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/compression"
 	"github.com/concourse/atc/db/encryption"
+	"github.com/concourse/atc/db/eventarchive"
 )
 
 type FakeConn struct {
@@ -149,6 +152,35 @@ type FakeConn struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	EventCodecStub        func() compression.Codec
+	eventCodecMutex       sync.RWMutex
+	eventCodecArgsForCall []struct{}
+	eventCodecReturns     struct {
+		result1 compression.Codec
+	}
+	eventCodecReturnsOnCall map[int]struct {
+		result1 compression.Codec
+	}
+	EventArchiveStub        func() eventarchive.Store
+	eventArchiveMutex       sync.RWMutex
+	eventArchiveArgsForCall []struct{}
+	eventArchiveReturns     struct {
+		result1 eventarchive.Store
+	}
+	eventArchiveReturnsOnCall map[int]struct {
+		result1 eventarchive.Store
+	}
+	ReplicaStub        func(time.Duration) db.Runner
+	replicaMutex       sync.RWMutex
+	replicaArgsForCall []struct {
+		maxStaleness time.Duration
+	}
+	replicaReturns struct {
+		result1 db.Runner
+	}
+	replicaReturnsOnCall map[int]struct {
+		result1 db.Runner
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -728,6 +760,134 @@ func (fake *FakeConn) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeConn) EventCodec() compression.Codec {
+	fake.eventCodecMutex.Lock()
+	ret, specificReturn := fake.eventCodecReturnsOnCall[len(fake.eventCodecArgsForCall)]
+	fake.eventCodecArgsForCall = append(fake.eventCodecArgsForCall, struct{}{})
+	fake.recordInvocation("EventCodec", []interface{}{})
+	fake.eventCodecMutex.Unlock()
+	if fake.EventCodecStub != nil {
+		return fake.EventCodecStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.eventCodecReturns.result1
+}
+
+func (fake *FakeConn) EventCodecCallCount() int {
+	fake.eventCodecMutex.RLock()
+	defer fake.eventCodecMutex.RUnlock()
+	return len(fake.eventCodecArgsForCall)
+}
+
+func (fake *FakeConn) EventCodecReturns(result1 compression.Codec) {
+	fake.EventCodecStub = nil
+	fake.eventCodecReturns = struct {
+		result1 compression.Codec
+	}{result1}
+}
+
+func (fake *FakeConn) EventCodecReturnsOnCall(i int, result1 compression.Codec) {
+	fake.EventCodecStub = nil
+	if fake.eventCodecReturnsOnCall == nil {
+		fake.eventCodecReturnsOnCall = make(map[int]struct {
+			result1 compression.Codec
+		})
+	}
+	fake.eventCodecReturnsOnCall[i] = struct {
+		result1 compression.Codec
+	}{result1}
+}
+
+func (fake *FakeConn) EventArchive() eventarchive.Store {
+	fake.eventArchiveMutex.Lock()
+	ret, specificReturn := fake.eventArchiveReturnsOnCall[len(fake.eventArchiveArgsForCall)]
+	fake.eventArchiveArgsForCall = append(fake.eventArchiveArgsForCall, struct{}{})
+	fake.recordInvocation("EventArchive", []interface{}{})
+	fake.eventArchiveMutex.Unlock()
+	if fake.EventArchiveStub != nil {
+		return fake.EventArchiveStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.eventArchiveReturns.result1
+}
+
+func (fake *FakeConn) EventArchiveCallCount() int {
+	fake.eventArchiveMutex.RLock()
+	defer fake.eventArchiveMutex.RUnlock()
+	return len(fake.eventArchiveArgsForCall)
+}
+
+func (fake *FakeConn) EventArchiveReturns(result1 eventarchive.Store) {
+	fake.EventArchiveStub = nil
+	fake.eventArchiveReturns = struct {
+		result1 eventarchive.Store
+	}{result1}
+}
+
+func (fake *FakeConn) EventArchiveReturnsOnCall(i int, result1 eventarchive.Store) {
+	fake.EventArchiveStub = nil
+	if fake.eventArchiveReturnsOnCall == nil {
+		fake.eventArchiveReturnsOnCall = make(map[int]struct {
+			result1 eventarchive.Store
+		})
+	}
+	fake.eventArchiveReturnsOnCall[i] = struct {
+		result1 eventarchive.Store
+	}{result1}
+}
+
+func (fake *FakeConn) Replica(maxStaleness time.Duration) db.Runner {
+	fake.replicaMutex.Lock()
+	ret, specificReturn := fake.replicaReturnsOnCall[len(fake.replicaArgsForCall)]
+	fake.replicaArgsForCall = append(fake.replicaArgsForCall, struct {
+		maxStaleness time.Duration
+	}{maxStaleness})
+	fake.recordInvocation("Replica", []interface{}{maxStaleness})
+	fake.replicaMutex.Unlock()
+	if fake.ReplicaStub != nil {
+		return fake.ReplicaStub(maxStaleness)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.replicaReturns.result1
+}
+
+func (fake *FakeConn) ReplicaCallCount() int {
+	fake.replicaMutex.RLock()
+	defer fake.replicaMutex.RUnlock()
+	return len(fake.replicaArgsForCall)
+}
+
+func (fake *FakeConn) ReplicaArgsForCall(i int) time.Duration {
+	fake.replicaMutex.RLock()
+	defer fake.replicaMutex.RUnlock()
+	return fake.replicaArgsForCall[i].maxStaleness
+}
+
+func (fake *FakeConn) ReplicaReturns(result1 db.Runner) {
+	fake.ReplicaStub = nil
+	fake.replicaReturns = struct {
+		result1 db.Runner
+	}{result1}
+}
+
+func (fake *FakeConn) ReplicaReturnsOnCall(i int, result1 db.Runner) {
+	fake.ReplicaStub = nil
+	if fake.replicaReturnsOnCall == nil {
+		fake.replicaReturnsOnCall = make(map[int]struct {
+			result1 db.Runner
+		})
+	}
+	fake.replicaReturnsOnCall[i] = struct {
+		result1 db.Runner
+	}{result1}
+}
+
 func (fake *FakeConn) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -759,6 +919,12 @@ func (fake *FakeConn) Invocations() map[string][][]interface{} {
 	defer fake.closeMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.eventCodecMutex.RLock()
+	defer fake.eventCodecMutex.RUnlock()
+	fake.eventArchiveMutex.RLock()
+	defer fake.eventArchiveMutex.RUnlock()
+	fake.replicaMutex.RLock()
+	defer fake.replicaMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
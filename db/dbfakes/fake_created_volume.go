@@ -78,10 +78,11 @@ type FakeCreatedVolume struct {
 	workerNameReturnsOnCall map[int]struct {
 		result1 string
 	}
-	InitializeResourceCacheStub        func(*db.UsedResourceCache) error
+	InitializeResourceCacheStub        func(*db.UsedResourceCache, int) error
 	initializeResourceCacheMutex       sync.RWMutex
 	initializeResourceCacheArgsForCall []struct {
 		arg1 *db.UsedResourceCache
+		arg2 int
 	}
 	initializeResourceCacheReturns struct {
 		result1 error
@@ -157,6 +158,24 @@ type FakeCreatedVolume struct {
 		result3 string
 		result4 error
 	}
+	InitializeResourceCacheReplicaStub        func() error
+	initializeResourceCacheReplicaMutex       sync.RWMutex
+	initializeResourceCacheReplicaArgsForCall []struct{}
+	initializeResourceCacheReplicaReturns     struct {
+		result1 error
+	}
+	initializeResourceCacheReplicaReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RecordFetchStub        func() error
+	recordFetchMutex       sync.RWMutex
+	recordFetchArgsForCall []struct{}
+	recordFetchReturns     struct {
+		result1 error
+	}
+	recordFetchReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -456,16 +475,17 @@ func (fake *FakeCreatedVolume) WorkerNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakeCreatedVolume) InitializeResourceCache(arg1 *db.UsedResourceCache) error {
+func (fake *FakeCreatedVolume) InitializeResourceCache(arg1 *db.UsedResourceCache, arg2 int) error {
 	fake.initializeResourceCacheMutex.Lock()
 	ret, specificReturn := fake.initializeResourceCacheReturnsOnCall[len(fake.initializeResourceCacheArgsForCall)]
 	fake.initializeResourceCacheArgsForCall = append(fake.initializeResourceCacheArgsForCall, struct {
 		arg1 *db.UsedResourceCache
-	}{arg1})
-	fake.recordInvocation("InitializeResourceCache", []interface{}{arg1})
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("InitializeResourceCache", []interface{}{arg1, arg2})
 	fake.initializeResourceCacheMutex.Unlock()
 	if fake.InitializeResourceCacheStub != nil {
-		return fake.InitializeResourceCacheStub(arg1)
+		return fake.InitializeResourceCacheStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -479,10 +499,10 @@ func (fake *FakeCreatedVolume) InitializeResourceCacheCallCount() int {
 	return len(fake.initializeResourceCacheArgsForCall)
 }
 
-func (fake *FakeCreatedVolume) InitializeResourceCacheArgsForCall(i int) *db.UsedResourceCache {
+func (fake *FakeCreatedVolume) InitializeResourceCacheArgsForCall(i int) (*db.UsedResourceCache, int) {
 	fake.initializeResourceCacheMutex.RLock()
 	defer fake.initializeResourceCacheMutex.RUnlock()
-	return fake.initializeResourceCacheArgsForCall[i].arg1
+	return fake.initializeResourceCacheArgsForCall[i].arg1, fake.initializeResourceCacheArgsForCall[i].arg2
 }
 
 func (fake *FakeCreatedVolume) InitializeResourceCacheReturns(result1 error) {
@@ -769,6 +789,86 @@ func (fake *FakeCreatedVolume) TaskIdentifierReturnsOnCall(i int, result1 string
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeCreatedVolume) InitializeResourceCacheReplica() error {
+	fake.initializeResourceCacheReplicaMutex.Lock()
+	ret, specificReturn := fake.initializeResourceCacheReplicaReturnsOnCall[len(fake.initializeResourceCacheReplicaArgsForCall)]
+	fake.initializeResourceCacheReplicaArgsForCall = append(fake.initializeResourceCacheReplicaArgsForCall, struct{}{})
+	fake.recordInvocation("InitializeResourceCacheReplica", []interface{}{})
+	fake.initializeResourceCacheReplicaMutex.Unlock()
+	if fake.InitializeResourceCacheReplicaStub != nil {
+		return fake.InitializeResourceCacheReplicaStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.initializeResourceCacheReplicaReturns.result1
+}
+
+func (fake *FakeCreatedVolume) InitializeResourceCacheReplicaCallCount() int {
+	fake.initializeResourceCacheReplicaMutex.RLock()
+	defer fake.initializeResourceCacheReplicaMutex.RUnlock()
+	return len(fake.initializeResourceCacheReplicaArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) InitializeResourceCacheReplicaReturns(result1 error) {
+	fake.InitializeResourceCacheReplicaStub = nil
+	fake.initializeResourceCacheReplicaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) InitializeResourceCacheReplicaReturnsOnCall(i int, result1 error) {
+	fake.InitializeResourceCacheReplicaStub = nil
+	if fake.initializeResourceCacheReplicaReturnsOnCall == nil {
+		fake.initializeResourceCacheReplicaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.initializeResourceCacheReplicaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) RecordFetch() error {
+	fake.recordFetchMutex.Lock()
+	ret, specificReturn := fake.recordFetchReturnsOnCall[len(fake.recordFetchArgsForCall)]
+	fake.recordFetchArgsForCall = append(fake.recordFetchArgsForCall, struct{}{})
+	fake.recordInvocation("RecordFetch", []interface{}{})
+	fake.recordFetchMutex.Unlock()
+	if fake.RecordFetchStub != nil {
+		return fake.RecordFetchStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.recordFetchReturns.result1
+}
+
+func (fake *FakeCreatedVolume) RecordFetchCallCount() int {
+	fake.recordFetchMutex.RLock()
+	defer fake.recordFetchMutex.RUnlock()
+	return len(fake.recordFetchArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) RecordFetchReturns(result1 error) {
+	fake.RecordFetchStub = nil
+	fake.recordFetchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) RecordFetchReturnsOnCall(i int, result1 error) {
+	fake.RecordFetchStub = nil
+	if fake.recordFetchReturnsOnCall == nil {
+		fake.recordFetchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordFetchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -800,6 +900,10 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.baseResourceTypeMutex.RUnlock()
 	fake.taskIdentifierMutex.RLock()
 	defer fake.taskIdentifierMutex.RUnlock()
+	fake.initializeResourceCacheReplicaMutex.RLock()
+	defer fake.initializeResourceCacheReplicaMutex.RUnlock()
+	fake.recordFetchMutex.RLock()
+	defer fake.recordFetchMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
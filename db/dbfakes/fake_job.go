@@ -111,28 +111,38 @@ type FakeJob struct {
 		result1 bool
 		result2 error
 	}
-	PauseStub        func() error
+	PauseStub        func(db.ConfigVersion) (db.ConfigVersion, error)
 	pauseMutex       sync.RWMutex
-	pauseArgsForCall []struct{}
-	pauseReturns     struct {
-		result1 error
+	pauseArgsForCall []struct {
+		from db.ConfigVersion
+	}
+	pauseReturns struct {
+		result1 db.ConfigVersion
+		result2 error
 	}
 	pauseReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
-	UnpauseStub        func() error
+	UnpauseStub        func(db.ConfigVersion) (db.ConfigVersion, error)
 	unpauseMutex       sync.RWMutex
-	unpauseArgsForCall []struct{}
-	unpauseReturns     struct {
-		result1 error
+	unpauseArgsForCall []struct {
+		from db.ConfigVersion
+	}
+	unpauseReturns struct {
+		result1 db.ConfigVersion
+		result2 error
 	}
 	unpauseReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
-	CreateBuildStub        func() (db.Build, error)
+	CreateBuildStub        func(db.BuildTriggerCause) (db.Build, error)
 	createBuildMutex       sync.RWMutex
-	createBuildArgsForCall []struct{}
-	createBuildReturns     struct {
+	createBuildArgsForCall []struct {
+		arg1 db.BuildTriggerCause
+	}
+	createBuildReturns struct {
 		result1 db.Build
 		result2 error
 	}
@@ -194,10 +204,12 @@ type FakeJob struct {
 	updateFirstLoggedBuildIDReturnsOnCall map[int]struct {
 		result1 error
 	}
-	EnsurePendingBuildExistsStub        func() error
+	EnsurePendingBuildExistsStub        func(db.BuildTriggerCause) error
 	ensurePendingBuildExistsMutex       sync.RWMutex
-	ensurePendingBuildExistsArgsForCall []struct{}
-	ensurePendingBuildExistsReturns     struct {
+	ensurePendingBuildExistsArgsForCall []struct {
+		arg1 db.BuildTriggerCause
+	}
+	ensurePendingBuildExistsReturns struct {
 		result1 error
 	}
 	ensurePendingBuildExistsReturnsOnCall map[int]struct {
@@ -308,6 +320,47 @@ type FakeJob struct {
 		result2 bool
 		result3 error
 	}
+	FirstBuildIDToRetainStub        func(atc.BuildLogRetention) (int, bool, error)
+	firstBuildIDToRetainMutex       sync.RWMutex
+	firstBuildIDToRetainArgsForCall []struct {
+		retention atc.BuildLogRetention
+	}
+	firstBuildIDToRetainReturns struct {
+		result1 int
+		result2 bool
+		result3 error
+	}
+	firstBuildIDToRetainReturnsOnCall map[int]struct {
+		result1 int
+		result2 bool
+		result3 error
+	}
+	LatestSucceededBuildIDsStub        func(int) ([]int, error)
+	latestSucceededBuildIDsMutex       sync.RWMutex
+	latestSucceededBuildIDsArgsForCall []struct {
+		limit int
+	}
+	latestSucceededBuildIDsReturns struct {
+		result1 []int
+		result2 error
+	}
+	latestSucceededBuildIDsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
+	UnreapedBuildIDsBeforeStub        func(int) ([]int, error)
+	unreapedBuildIDsBeforeMutex       sync.RWMutex
+	unreapedBuildIDsBeforeArgsForCall []struct {
+		buildID int
+	}
+	unreapedBuildIDsBeforeReturns struct {
+		result1 []int
+		result2 error
+	}
+	unreapedBuildIDsBeforeReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -755,19 +808,21 @@ func (fake *FakeJob) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeJob) Pause() error {
+func (fake *FakeJob) Pause(from db.ConfigVersion) (db.ConfigVersion, error) {
 	fake.pauseMutex.Lock()
 	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
-	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct{}{})
-	fake.recordInvocation("Pause", []interface{}{})
+	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
+		from db.ConfigVersion
+	}{from})
+	fake.recordInvocation("Pause", []interface{}{from})
 	fake.pauseMutex.Unlock()
 	if fake.PauseStub != nil {
-		return fake.PauseStub()
+		return fake.PauseStub(from)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.pauseReturns.result1
+	return fake.pauseReturns.result1, fake.pauseReturns.result2
 }
 
 func (fake *FakeJob) PauseCallCount() int {
@@ -776,38 +831,49 @@ func (fake *FakeJob) PauseCallCount() int {
 	return len(fake.pauseArgsForCall)
 }
 
-func (fake *FakeJob) PauseReturns(result1 error) {
+func (fake *FakeJob) PauseArgsForCall(i int) db.ConfigVersion {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return fake.pauseArgsForCall[i].from
+}
+
+func (fake *FakeJob) PauseReturns(result1 db.ConfigVersion, result2 error) {
 	fake.PauseStub = nil
 	fake.pauseReturns = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeJob) PauseReturnsOnCall(i int, result1 error) {
+func (fake *FakeJob) PauseReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
 	fake.PauseStub = nil
 	if fake.pauseReturnsOnCall == nil {
 		fake.pauseReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.ConfigVersion
+			result2 error
 		})
 	}
 	fake.pauseReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeJob) Unpause() error {
+func (fake *FakeJob) Unpause(from db.ConfigVersion) (db.ConfigVersion, error) {
 	fake.unpauseMutex.Lock()
 	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
-	fake.unpauseArgsForCall = append(fake.unpauseArgsForCall, struct{}{})
-	fake.recordInvocation("Unpause", []interface{}{})
+	fake.unpauseArgsForCall = append(fake.unpauseArgsForCall, struct {
+		from db.ConfigVersion
+	}{from})
+	fake.recordInvocation("Unpause", []interface{}{from})
 	fake.unpauseMutex.Unlock()
 	if fake.UnpauseStub != nil {
-		return fake.UnpauseStub()
+		return fake.UnpauseStub(from)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.unpauseReturns.result1
+	return fake.unpauseReturns.result1, fake.unpauseReturns.result2
 }
 
 func (fake *FakeJob) UnpauseCallCount() int {
@@ -816,33 +882,44 @@ func (fake *FakeJob) UnpauseCallCount() int {
 	return len(fake.unpauseArgsForCall)
 }
 
-func (fake *FakeJob) UnpauseReturns(result1 error) {
+func (fake *FakeJob) UnpauseArgsForCall(i int) db.ConfigVersion {
+	fake.unpauseMutex.RLock()
+	defer fake.unpauseMutex.RUnlock()
+	return fake.unpauseArgsForCall[i].from
+}
+
+func (fake *FakeJob) UnpauseReturns(result1 db.ConfigVersion, result2 error) {
 	fake.UnpauseStub = nil
 	fake.unpauseReturns = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeJob) UnpauseReturnsOnCall(i int, result1 error) {
+func (fake *FakeJob) UnpauseReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
 	fake.UnpauseStub = nil
 	if fake.unpauseReturnsOnCall == nil {
 		fake.unpauseReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.ConfigVersion
+			result2 error
 		})
 	}
 	fake.unpauseReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeJob) CreateBuild() (db.Build, error) {
+func (fake *FakeJob) CreateBuild(arg1 db.BuildTriggerCause) (db.Build, error) {
 	fake.createBuildMutex.Lock()
 	ret, specificReturn := fake.createBuildReturnsOnCall[len(fake.createBuildArgsForCall)]
-	fake.createBuildArgsForCall = append(fake.createBuildArgsForCall, struct{}{})
-	fake.recordInvocation("CreateBuild", []interface{}{})
+	fake.createBuildArgsForCall = append(fake.createBuildArgsForCall, struct {
+		arg1 db.BuildTriggerCause
+	}{arg1})
+	fake.recordInvocation("CreateBuild", []interface{}{arg1})
 	fake.createBuildMutex.Unlock()
 	if fake.CreateBuildStub != nil {
-		return fake.CreateBuildStub()
+		return fake.CreateBuildStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -856,6 +933,12 @@ func (fake *FakeJob) CreateBuildCallCount() int {
 	return len(fake.createBuildArgsForCall)
 }
 
+func (fake *FakeJob) CreateBuildArgsForCall(i int) db.BuildTriggerCause {
+	fake.createBuildMutex.RLock()
+	defer fake.createBuildMutex.RUnlock()
+	return fake.createBuildArgsForCall[i].arg1
+}
+
 func (fake *FakeJob) CreateBuildReturns(result1 db.Build, result2 error) {
 	fake.CreateBuildStub = nil
 	fake.createBuildReturns = struct {
@@ -1080,14 +1163,16 @@ func (fake *FakeJob) UpdateFirstLoggedBuildIDReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeJob) EnsurePendingBuildExists() error {
+func (fake *FakeJob) EnsurePendingBuildExists(arg1 db.BuildTriggerCause) error {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	ret, specificReturn := fake.ensurePendingBuildExistsReturnsOnCall[len(fake.ensurePendingBuildExistsArgsForCall)]
-	fake.ensurePendingBuildExistsArgsForCall = append(fake.ensurePendingBuildExistsArgsForCall, struct{}{})
-	fake.recordInvocation("EnsurePendingBuildExists", []interface{}{})
+	fake.ensurePendingBuildExistsArgsForCall = append(fake.ensurePendingBuildExistsArgsForCall, struct {
+		arg1 db.BuildTriggerCause
+	}{arg1})
+	fake.recordInvocation("EnsurePendingBuildExists", []interface{}{arg1})
 	fake.ensurePendingBuildExistsMutex.Unlock()
 	if fake.EnsurePendingBuildExistsStub != nil {
-		return fake.EnsurePendingBuildExistsStub()
+		return fake.EnsurePendingBuildExistsStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1101,6 +1186,12 @@ func (fake *FakeJob) EnsurePendingBuildExistsCallCount() int {
 	return len(fake.ensurePendingBuildExistsArgsForCall)
 }
 
+func (fake *FakeJob) EnsurePendingBuildExistsArgsForCall(i int) db.BuildTriggerCause {
+	fake.ensurePendingBuildExistsMutex.RLock()
+	defer fake.ensurePendingBuildExistsMutex.RUnlock()
+	return fake.ensurePendingBuildExistsArgsForCall[i].arg1
+}
+
 func (fake *FakeJob) EnsurePendingBuildExistsReturns(result1 error) {
 	fake.EnsurePendingBuildExistsStub = nil
 	fake.ensurePendingBuildExistsReturns = struct {
@@ -1551,6 +1642,162 @@ func (fake *FakeJob) GetNextPendingBuildBySerialGroupReturnsOnCall(i int, result
 	}{result1, result2, result3}
 }
 
+func (fake *FakeJob) FirstBuildIDToRetain(retention atc.BuildLogRetention) (int, bool, error) {
+	fake.firstBuildIDToRetainMutex.Lock()
+	ret, specificReturn := fake.firstBuildIDToRetainReturnsOnCall[len(fake.firstBuildIDToRetainArgsForCall)]
+	fake.firstBuildIDToRetainArgsForCall = append(fake.firstBuildIDToRetainArgsForCall, struct {
+		retention atc.BuildLogRetention
+	}{retention})
+	fake.recordInvocation("FirstBuildIDToRetain", []interface{}{retention})
+	fake.firstBuildIDToRetainMutex.Unlock()
+	if fake.FirstBuildIDToRetainStub != nil {
+		return fake.FirstBuildIDToRetainStub(retention)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.firstBuildIDToRetainReturns.result1, fake.firstBuildIDToRetainReturns.result2, fake.firstBuildIDToRetainReturns.result3
+}
+
+func (fake *FakeJob) FirstBuildIDToRetainCallCount() int {
+	fake.firstBuildIDToRetainMutex.RLock()
+	defer fake.firstBuildIDToRetainMutex.RUnlock()
+	return len(fake.firstBuildIDToRetainArgsForCall)
+}
+
+func (fake *FakeJob) FirstBuildIDToRetainArgsForCall(i int) atc.BuildLogRetention {
+	fake.firstBuildIDToRetainMutex.RLock()
+	defer fake.firstBuildIDToRetainMutex.RUnlock()
+	return fake.firstBuildIDToRetainArgsForCall[i].retention
+}
+
+func (fake *FakeJob) FirstBuildIDToRetainReturns(result1 int, result2 bool, result3 error) {
+	fake.FirstBuildIDToRetainStub = nil
+	fake.firstBuildIDToRetainReturns = struct {
+		result1 int
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) FirstBuildIDToRetainReturnsOnCall(i int, result1 int, result2 bool, result3 error) {
+	fake.FirstBuildIDToRetainStub = nil
+	if fake.firstBuildIDToRetainReturnsOnCall == nil {
+		fake.firstBuildIDToRetainReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 bool
+			result3 error
+		})
+	}
+	fake.firstBuildIDToRetainReturnsOnCall[i] = struct {
+		result1 int
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) LatestSucceededBuildIDs(limit int) ([]int, error) {
+	fake.latestSucceededBuildIDsMutex.Lock()
+	ret, specificReturn := fake.latestSucceededBuildIDsReturnsOnCall[len(fake.latestSucceededBuildIDsArgsForCall)]
+	fake.latestSucceededBuildIDsArgsForCall = append(fake.latestSucceededBuildIDsArgsForCall, struct {
+		limit int
+	}{limit})
+	fake.recordInvocation("LatestSucceededBuildIDs", []interface{}{limit})
+	fake.latestSucceededBuildIDsMutex.Unlock()
+	if fake.LatestSucceededBuildIDsStub != nil {
+		return fake.LatestSucceededBuildIDsStub(limit)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.latestSucceededBuildIDsReturns.result1, fake.latestSucceededBuildIDsReturns.result2
+}
+
+func (fake *FakeJob) LatestSucceededBuildIDsCallCount() int {
+	fake.latestSucceededBuildIDsMutex.RLock()
+	defer fake.latestSucceededBuildIDsMutex.RUnlock()
+	return len(fake.latestSucceededBuildIDsArgsForCall)
+}
+
+func (fake *FakeJob) LatestSucceededBuildIDsArgsForCall(i int) int {
+	fake.latestSucceededBuildIDsMutex.RLock()
+	defer fake.latestSucceededBuildIDsMutex.RUnlock()
+	return fake.latestSucceededBuildIDsArgsForCall[i].limit
+}
+
+func (fake *FakeJob) LatestSucceededBuildIDsReturns(result1 []int, result2 error) {
+	fake.LatestSucceededBuildIDsStub = nil
+	fake.latestSucceededBuildIDsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) LatestSucceededBuildIDsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.LatestSucceededBuildIDsStub = nil
+	if fake.latestSucceededBuildIDsReturnsOnCall == nil {
+		fake.latestSucceededBuildIDsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.latestSucceededBuildIDsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) UnreapedBuildIDsBefore(buildID int) ([]int, error) {
+	fake.unreapedBuildIDsBeforeMutex.Lock()
+	ret, specificReturn := fake.unreapedBuildIDsBeforeReturnsOnCall[len(fake.unreapedBuildIDsBeforeArgsForCall)]
+	fake.unreapedBuildIDsBeforeArgsForCall = append(fake.unreapedBuildIDsBeforeArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("UnreapedBuildIDsBefore", []interface{}{buildID})
+	fake.unreapedBuildIDsBeforeMutex.Unlock()
+	if fake.UnreapedBuildIDsBeforeStub != nil {
+		return fake.UnreapedBuildIDsBeforeStub(buildID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.unreapedBuildIDsBeforeReturns.result1, fake.unreapedBuildIDsBeforeReturns.result2
+}
+
+func (fake *FakeJob) UnreapedBuildIDsBeforeCallCount() int {
+	fake.unreapedBuildIDsBeforeMutex.RLock()
+	defer fake.unreapedBuildIDsBeforeMutex.RUnlock()
+	return len(fake.unreapedBuildIDsBeforeArgsForCall)
+}
+
+func (fake *FakeJob) UnreapedBuildIDsBeforeArgsForCall(i int) int {
+	fake.unreapedBuildIDsBeforeMutex.RLock()
+	defer fake.unreapedBuildIDsBeforeMutex.RUnlock()
+	return fake.unreapedBuildIDsBeforeArgsForCall[i].buildID
+}
+
+func (fake *FakeJob) UnreapedBuildIDsBeforeReturns(result1 []int, result2 error) {
+	fake.UnreapedBuildIDsBeforeStub = nil
+	fake.unreapedBuildIDsBeforeReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) UnreapedBuildIDsBeforeReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.UnreapedBuildIDsBeforeStub = nil
+	if fake.unreapedBuildIDsBeforeReturnsOnCall == nil {
+		fake.unreapedBuildIDsBeforeReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.unreapedBuildIDsBeforeReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1610,6 +1857,12 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
 	fake.getNextPendingBuildBySerialGroupMutex.RLock()
 	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
+	fake.firstBuildIDToRetainMutex.RLock()
+	defer fake.firstBuildIDToRetainMutex.RUnlock()
+	fake.latestSucceededBuildIDsMutex.RLock()
+	defer fake.latestSucceededBuildIDsMutex.RUnlock()
+	fake.unreapedBuildIDsBeforeMutex.RLock()
+	defer fake.unreapedBuildIDsBeforeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
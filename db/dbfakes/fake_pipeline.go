@@ -230,27 +230,33 @@ type FakePipeline struct {
 		result2 bool
 		result3 error
 	}
-	DisableVersionedResourceStub        func(versionedResourceID int) error
+	DisableVersionedResourceStub        func(int, db.ConfigVersion) (db.ConfigVersion, error)
 	disableVersionedResourceMutex       sync.RWMutex
 	disableVersionedResourceArgsForCall []struct {
 		versionedResourceID int
+		from                db.ConfigVersion
 	}
 	disableVersionedResourceReturns struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
 	disableVersionedResourceReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
-	EnableVersionedResourceStub        func(versionedResourceID int) error
+	EnableVersionedResourceStub        func(int, db.ConfigVersion) (db.ConfigVersion, error)
 	enableVersionedResourceMutex       sync.RWMutex
 	enableVersionedResourceArgsForCall []struct {
 		versionedResourceID int
+		from                db.ConfigVersion
 	}
 	enableVersionedResourceReturns struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
 	enableVersionedResourceReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
 	GetBuildsWithVersionAsInputStub        func(versionedResourceID int) ([]db.Build, error)
 	getBuildsWithVersionAsInputMutex       sync.RWMutex
@@ -460,41 +466,57 @@ type FakePipeline struct {
 		result1 db.Dashboard
 		result2 error
 	}
-	ExposeStub        func() error
+	ExposeStub        func(db.ConfigVersion) (db.ConfigVersion, error)
 	exposeMutex       sync.RWMutex
-	exposeArgsForCall []struct{}
-	exposeReturns     struct {
-		result1 error
+	exposeArgsForCall []struct {
+		from db.ConfigVersion
+	}
+	exposeReturns struct {
+		result1 db.ConfigVersion
+		result2 error
 	}
 	exposeReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
-	HideStub        func() error
+	HideStub        func(db.ConfigVersion) (db.ConfigVersion, error)
 	hideMutex       sync.RWMutex
-	hideArgsForCall []struct{}
-	hideReturns     struct {
-		result1 error
+	hideArgsForCall []struct {
+		from db.ConfigVersion
+	}
+	hideReturns struct {
+		result1 db.ConfigVersion
+		result2 error
 	}
 	hideReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
-	PauseStub        func() error
+	PauseStub        func(db.ConfigVersion) (db.ConfigVersion, error)
 	pauseMutex       sync.RWMutex
-	pauseArgsForCall []struct{}
-	pauseReturns     struct {
-		result1 error
+	pauseArgsForCall []struct {
+		from db.ConfigVersion
+	}
+	pauseReturns struct {
+		result1 db.ConfigVersion
+		result2 error
 	}
 	pauseReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
-	UnpauseStub        func() error
+	UnpauseStub        func(db.ConfigVersion) (db.ConfigVersion, error)
 	unpauseMutex       sync.RWMutex
-	unpauseArgsForCall []struct{}
-	unpauseReturns     struct {
-		result1 error
+	unpauseArgsForCall []struct {
+		from db.ConfigVersion
+	}
+	unpauseReturns struct {
+		result1 db.ConfigVersion
+		result2 error
 	}
 	unpauseReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
 	DestroyStub        func() error
 	destroyMutex       sync.RWMutex
@@ -505,21 +527,26 @@ type FakePipeline struct {
 	destroyReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RenameStub        func(string) error
+	RenameStub        func(string, db.ConfigVersion) (db.ConfigVersion, error)
 	renameMutex       sync.RWMutex
 	renameArgsForCall []struct {
-		arg1 string
+		name string
+		from db.ConfigVersion
 	}
 	renameReturns struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
 	renameReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
-	CreateOneOffBuildStub        func() (db.Build, error)
+	CreateOneOffBuildStub        func(db.BuildTriggerCause) (db.Build, error)
 	createOneOffBuildMutex       sync.RWMutex
-	createOneOffBuildArgsForCall []struct{}
-	createOneOffBuildReturns     struct {
+	createOneOffBuildArgsForCall []struct {
+		arg1 db.BuildTriggerCause
+	}
+	createOneOffBuildReturns struct {
 		result1 db.Build
 		result2 error
 	}
@@ -527,6 +554,245 @@ type FakePipeline struct {
 		result1 db.Build
 		result2 error
 	}
+	AnnotateVersionedResourceStub        func(int, db.VersionAnnotation, db.ConfigVersion) (db.ConfigVersion, bool, error)
+	annotateVersionedResourceMutex       sync.RWMutex
+	annotateVersionedResourceArgsForCall []struct {
+		versionedResourceID int
+		annotation          db.VersionAnnotation
+		from                db.ConfigVersion
+	}
+	annotateVersionedResourceReturns struct {
+		result1 db.ConfigVersion
+		result2 bool
+		result3 error
+	}
+	annotateVersionedResourceReturnsOnCall map[int]struct {
+		result1 db.ConfigVersion
+		result2 bool
+		result3 error
+	}
+	RemoveVersionedResourceAnnotationStub        func(int, string, db.ConfigVersion) (db.ConfigVersion, bool, error)
+	removeVersionedResourceAnnotationMutex       sync.RWMutex
+	removeVersionedResourceAnnotationArgsForCall []struct {
+		versionedResourceID int
+		name                string
+		from                db.ConfigVersion
+	}
+	removeVersionedResourceAnnotationReturns struct {
+		result1 db.ConfigVersion
+		result2 bool
+		result3 error
+	}
+	removeVersionedResourceAnnotationReturnsOnCall map[int]struct {
+		result1 db.ConfigVersion
+		result2 bool
+		result3 error
+	}
+	BuildCalendarStub        func(time.Time, time.Time, db.BuildCalendarInterval, *time.Location) ([]db.BuildCalendarEntry, error)
+	buildCalendarMutex       sync.RWMutex
+	buildCalendarArgsForCall []struct {
+		arg1 time.Time
+		arg2 time.Time
+		arg3 db.BuildCalendarInterval
+		arg4 *time.Location
+	}
+	buildCalendarReturns struct {
+		result1 []db.BuildCalendarEntry
+		result2 error
+	}
+	buildCalendarReturnsOnCall map[int]struct {
+		result1 []db.BuildCalendarEntry
+		result2 error
+	}
+	LabelsStub        func() atc.Labels
+	labelsMutex       sync.RWMutex
+	labelsArgsForCall []struct{}
+	labelsReturns     struct {
+		result1 atc.Labels
+	}
+	labelsReturnsOnCall map[int]struct {
+		result1 atc.Labels
+	}
+	BuildLogRetentionStub        func() atc.BuildLogRetention
+	buildLogRetentionMutex       sync.RWMutex
+	buildLogRetentionArgsForCall []struct{}
+	buildLogRetentionReturns     struct {
+		result1 atc.BuildLogRetention
+	}
+	buildLogRetentionReturnsOnCall map[int]struct {
+		result1 atc.BuildLogRetention
+	}
+	ConfigRevisionStub        func(int) (db.ConfigRevision, bool, error)
+	configRevisionMutex       sync.RWMutex
+	configRevisionArgsForCall []struct {
+		id int
+	}
+	configRevisionReturns struct {
+		result1 db.ConfigRevision
+		result2 bool
+		result3 error
+	}
+	configRevisionReturnsOnCall map[int]struct {
+		result1 db.ConfigRevision
+		result2 bool
+		result3 error
+	}
+	ConfigRevisionsStub        func(db.Page) ([]db.ConfigRevision, db.Pagination, error)
+	configRevisionsMutex       sync.RWMutex
+	configRevisionsArgsForCall []struct {
+		page db.Page
+	}
+	configRevisionsReturns struct {
+		result1 []db.ConfigRevision
+		result2 db.Pagination
+		result3 error
+	}
+	configRevisionsReturnsOnCall map[int]struct {
+		result1 []db.ConfigRevision
+		result2 db.Pagination
+		result3 error
+	}
+	SearchResourceVersionsStub        func(db.ResourceVersionSearch) ([]db.SavedVersionedResource, error)
+	searchResourceVersionsMutex       sync.RWMutex
+	searchResourceVersionsArgsForCall []struct {
+		arg1 db.ResourceVersionSearch
+	}
+	searchResourceVersionsReturns struct {
+		result1 []db.SavedVersionedResource
+		result2 error
+	}
+	searchResourceVersionsReturnsOnCall map[int]struct {
+		result1 []db.SavedVersionedResource
+		result2 error
+	}
+	ArchivedAtStub        func() time.Time
+	archivedAtMutex       sync.RWMutex
+	archivedAtArgsForCall []struct{}
+	archivedAtReturns     struct {
+		result1 time.Time
+	}
+	archivedAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	ArchiveStub        func(db.ConfigVersion) (db.ConfigVersion, error)
+	archiveMutex       sync.RWMutex
+	archiveArgsForCall []struct {
+		from db.ConfigVersion
+	}
+	archiveReturns struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	archiveReturnsOnCall map[int]struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	RestoreStub        func(db.ConfigVersion) (db.ConfigVersion, error)
+	restoreMutex       sync.RWMutex
+	restoreArgsForCall []struct {
+		from db.ConfigVersion
+	}
+	restoreReturns struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	restoreReturnsOnCall map[int]struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	SnapshotStub        func() (atc.PipelineSnapshot, error)
+	snapshotMutex       sync.RWMutex
+	snapshotArgsForCall []struct{}
+	snapshotReturns     struct {
+		result1 atc.PipelineSnapshot
+		result2 error
+	}
+	snapshotReturnsOnCall map[int]struct {
+		result1 atc.PipelineSnapshot
+		result2 error
+	}
+	AcquireResourceConfigCheckingLockWithIntervalCheckStub        func(lager.Logger, *db.UsedResourceConfig, time.Duration, bool) (lock.Lock, bool, error)
+	acquireResourceConfigCheckingLockWithIntervalCheckMutex       sync.RWMutex
+	acquireResourceConfigCheckingLockWithIntervalCheckArgsForCall []struct {
+		logger             lager.Logger
+		usedResourceConfig *db.UsedResourceConfig
+		interval           time.Duration
+		immediate          bool
+	}
+	acquireResourceConfigCheckingLockWithIntervalCheckReturns struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	acquireResourceConfigCheckingLockWithIntervalCheckReturnsOnCall map[int]struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	SaveResourceConfigVersionsStub        func(*db.UsedResourceConfig, []atc.Version) error
+	saveResourceConfigVersionsMutex       sync.RWMutex
+	saveResourceConfigVersionsArgsForCall []struct {
+		arg1 *db.UsedResourceConfig
+		arg2 []atc.Version
+	}
+	saveResourceConfigVersionsReturns struct {
+		result1 error
+	}
+	saveResourceConfigVersionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	LatestResourceConfigVersionStub        func(*db.UsedResourceConfig) (atc.Version, bool, error)
+	latestResourceConfigVersionMutex       sync.RWMutex
+	latestResourceConfigVersionArgsForCall []struct {
+		arg1 *db.UsedResourceConfig
+	}
+	latestResourceConfigVersionReturns struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
+	latestResourceConfigVersionReturnsOnCall map[int]struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
+	DestroyIfArchivedBeforeStub        func(time.Time) (bool, error)
+	destroyIfArchivedBeforeMutex       sync.RWMutex
+	destroyIfArchivedBeforeArgsForCall []struct {
+		arg1 time.Time
+	}
+	destroyIfArchivedBeforeReturns struct {
+		result1 bool
+		result2 error
+	}
+	destroyIfArchivedBeforeReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	GetBuildsWithVersionsAsInputStub        func([]int) (map[int][]db.Build, error)
+	getBuildsWithVersionsAsInputMutex       sync.RWMutex
+	getBuildsWithVersionsAsInputArgsForCall []struct {
+		versionedResourceIDs []int
+	}
+	getBuildsWithVersionsAsInputReturns struct {
+		result1 map[int][]db.Build
+		result2 error
+	}
+	getBuildsWithVersionsAsInputReturnsOnCall map[int]struct {
+		result1 map[int][]db.Build
+		result2 error
+	}
+	PendingJobsAwaitingInputsStub        func() (map[string][]string, error)
+	pendingJobsAwaitingInputsMutex       sync.RWMutex
+	pendingJobsAwaitingInputsArgsForCall []struct{}
+	pendingJobsAwaitingInputsReturns     struct {
+		result1 map[string][]string
+		result2 error
+	}
+	pendingJobsAwaitingInputsReturnsOnCall map[int]struct {
+		result1 map[string][]string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1403,21 +1669,22 @@ func (fake *FakePipeline) VersionedResourceReturnsOnCall(i int, result1 db.Saved
 	}{result1, result2, result3}
 }
 
-func (fake *FakePipeline) DisableVersionedResource(versionedResourceID int) error {
+func (fake *FakePipeline) DisableVersionedResource(versionedResourceID int, from db.ConfigVersion) (db.ConfigVersion, error) {
 	fake.disableVersionedResourceMutex.Lock()
 	ret, specificReturn := fake.disableVersionedResourceReturnsOnCall[len(fake.disableVersionedResourceArgsForCall)]
 	fake.disableVersionedResourceArgsForCall = append(fake.disableVersionedResourceArgsForCall, struct {
 		versionedResourceID int
-	}{versionedResourceID})
-	fake.recordInvocation("DisableVersionedResource", []interface{}{versionedResourceID})
+		from                db.ConfigVersion
+	}{versionedResourceID, from})
+	fake.recordInvocation("DisableVersionedResource", []interface{}{versionedResourceID, from})
 	fake.disableVersionedResourceMutex.Unlock()
 	if fake.DisableVersionedResourceStub != nil {
-		return fake.DisableVersionedResourceStub(versionedResourceID)
+		return fake.DisableVersionedResourceStub(versionedResourceID, from)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.disableVersionedResourceReturns.result1
+	return fake.disableVersionedResourceReturns.result1, fake.disableVersionedResourceReturns.result2
 }
 
 func (fake *FakePipeline) DisableVersionedResourceCallCount() int {
@@ -1426,46 +1693,50 @@ func (fake *FakePipeline) DisableVersionedResourceCallCount() int {
 	return len(fake.disableVersionedResourceArgsForCall)
 }
 
-func (fake *FakePipeline) DisableVersionedResourceArgsForCall(i int) int {
+func (fake *FakePipeline) DisableVersionedResourceArgsForCall(i int) (int, db.ConfigVersion) {
 	fake.disableVersionedResourceMutex.RLock()
 	defer fake.disableVersionedResourceMutex.RUnlock()
-	return fake.disableVersionedResourceArgsForCall[i].versionedResourceID
+	return fake.disableVersionedResourceArgsForCall[i].versionedResourceID, fake.disableVersionedResourceArgsForCall[i].from
 }
 
-func (fake *FakePipeline) DisableVersionedResourceReturns(result1 error) {
+func (fake *FakePipeline) DisableVersionedResourceReturns(result1 db.ConfigVersion, result2 error) {
 	fake.DisableVersionedResourceStub = nil
 	fake.disableVersionedResourceReturns = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePipeline) DisableVersionedResourceReturnsOnCall(i int, result1 error) {
+func (fake *FakePipeline) DisableVersionedResourceReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
 	fake.DisableVersionedResourceStub = nil
 	if fake.disableVersionedResourceReturnsOnCall == nil {
 		fake.disableVersionedResourceReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.ConfigVersion
+			result2 error
 		})
 	}
 	fake.disableVersionedResourceReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePipeline) EnableVersionedResource(versionedResourceID int) error {
+func (fake *FakePipeline) EnableVersionedResource(versionedResourceID int, from db.ConfigVersion) (db.ConfigVersion, error) {
 	fake.enableVersionedResourceMutex.Lock()
 	ret, specificReturn := fake.enableVersionedResourceReturnsOnCall[len(fake.enableVersionedResourceArgsForCall)]
 	fake.enableVersionedResourceArgsForCall = append(fake.enableVersionedResourceArgsForCall, struct {
 		versionedResourceID int
-	}{versionedResourceID})
-	fake.recordInvocation("EnableVersionedResource", []interface{}{versionedResourceID})
+		from                db.ConfigVersion
+	}{versionedResourceID, from})
+	fake.recordInvocation("EnableVersionedResource", []interface{}{versionedResourceID, from})
 	fake.enableVersionedResourceMutex.Unlock()
 	if fake.EnableVersionedResourceStub != nil {
-		return fake.EnableVersionedResourceStub(versionedResourceID)
+		return fake.EnableVersionedResourceStub(versionedResourceID, from)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.enableVersionedResourceReturns.result1
+	return fake.enableVersionedResourceReturns.result1, fake.enableVersionedResourceReturns.result2
 }
 
 func (fake *FakePipeline) EnableVersionedResourceCallCount() int {
@@ -1474,29 +1745,32 @@ func (fake *FakePipeline) EnableVersionedResourceCallCount() int {
 	return len(fake.enableVersionedResourceArgsForCall)
 }
 
-func (fake *FakePipeline) EnableVersionedResourceArgsForCall(i int) int {
+func (fake *FakePipeline) EnableVersionedResourceArgsForCall(i int) (int, db.ConfigVersion) {
 	fake.enableVersionedResourceMutex.RLock()
 	defer fake.enableVersionedResourceMutex.RUnlock()
-	return fake.enableVersionedResourceArgsForCall[i].versionedResourceID
+	return fake.enableVersionedResourceArgsForCall[i].versionedResourceID, fake.enableVersionedResourceArgsForCall[i].from
 }
 
-func (fake *FakePipeline) EnableVersionedResourceReturns(result1 error) {
+func (fake *FakePipeline) EnableVersionedResourceReturns(result1 db.ConfigVersion, result2 error) {
 	fake.EnableVersionedResourceStub = nil
 	fake.enableVersionedResourceReturns = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePipeline) EnableVersionedResourceReturnsOnCall(i int, result1 error) {
+func (fake *FakePipeline) EnableVersionedResourceReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
 	fake.EnableVersionedResourceStub = nil
 	if fake.enableVersionedResourceReturnsOnCall == nil {
 		fake.enableVersionedResourceReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.ConfigVersion
+			result2 error
 		})
 	}
 	fake.enableVersionedResourceReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakePipeline) GetBuildsWithVersionAsInput(versionedResourceID int) ([]db.Build, error) {
@@ -2264,19 +2538,21 @@ func (fake *FakePipeline) DashboardReturnsOnCall(i int, result1 db.Dashboard, re
 	}{result1, result2}
 }
 
-func (fake *FakePipeline) Expose() error {
+func (fake *FakePipeline) Expose(from db.ConfigVersion) (db.ConfigVersion, error) {
 	fake.exposeMutex.Lock()
 	ret, specificReturn := fake.exposeReturnsOnCall[len(fake.exposeArgsForCall)]
-	fake.exposeArgsForCall = append(fake.exposeArgsForCall, struct{}{})
-	fake.recordInvocation("Expose", []interface{}{})
+	fake.exposeArgsForCall = append(fake.exposeArgsForCall, struct {
+		from db.ConfigVersion
+	}{from})
+	fake.recordInvocation("Expose", []interface{}{from})
 	fake.exposeMutex.Unlock()
 	if fake.ExposeStub != nil {
-		return fake.ExposeStub()
+		return fake.ExposeStub(from)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.exposeReturns.result1
+	return fake.exposeReturns.result1, fake.exposeReturns.result2
 }
 
 func (fake *FakePipeline) ExposeCallCount() int {
@@ -2285,38 +2561,49 @@ func (fake *FakePipeline) ExposeCallCount() int {
 	return len(fake.exposeArgsForCall)
 }
 
-func (fake *FakePipeline) ExposeReturns(result1 error) {
+func (fake *FakePipeline) ExposeArgsForCall(i int) db.ConfigVersion {
+	fake.exposeMutex.RLock()
+	defer fake.exposeMutex.RUnlock()
+	return fake.exposeArgsForCall[i].from
+}
+
+func (fake *FakePipeline) ExposeReturns(result1 db.ConfigVersion, result2 error) {
 	fake.ExposeStub = nil
 	fake.exposeReturns = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePipeline) ExposeReturnsOnCall(i int, result1 error) {
+func (fake *FakePipeline) ExposeReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
 	fake.ExposeStub = nil
 	if fake.exposeReturnsOnCall == nil {
 		fake.exposeReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.ConfigVersion
+			result2 error
 		})
 	}
 	fake.exposeReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePipeline) Hide() error {
+func (fake *FakePipeline) Hide(from db.ConfigVersion) (db.ConfigVersion, error) {
 	fake.hideMutex.Lock()
 	ret, specificReturn := fake.hideReturnsOnCall[len(fake.hideArgsForCall)]
-	fake.hideArgsForCall = append(fake.hideArgsForCall, struct{}{})
-	fake.recordInvocation("Hide", []interface{}{})
+	fake.hideArgsForCall = append(fake.hideArgsForCall, struct {
+		from db.ConfigVersion
+	}{from})
+	fake.recordInvocation("Hide", []interface{}{from})
 	fake.hideMutex.Unlock()
 	if fake.HideStub != nil {
-		return fake.HideStub()
+		return fake.HideStub(from)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.hideReturns.result1
+	return fake.hideReturns.result1, fake.hideReturns.result2
 }
 
 func (fake *FakePipeline) HideCallCount() int {
@@ -2325,38 +2612,49 @@ func (fake *FakePipeline) HideCallCount() int {
 	return len(fake.hideArgsForCall)
 }
 
-func (fake *FakePipeline) HideReturns(result1 error) {
+func (fake *FakePipeline) HideArgsForCall(i int) db.ConfigVersion {
+	fake.hideMutex.RLock()
+	defer fake.hideMutex.RUnlock()
+	return fake.hideArgsForCall[i].from
+}
+
+func (fake *FakePipeline) HideReturns(result1 db.ConfigVersion, result2 error) {
 	fake.HideStub = nil
 	fake.hideReturns = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePipeline) HideReturnsOnCall(i int, result1 error) {
+func (fake *FakePipeline) HideReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
 	fake.HideStub = nil
 	if fake.hideReturnsOnCall == nil {
 		fake.hideReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.ConfigVersion
+			result2 error
 		})
 	}
 	fake.hideReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePipeline) Pause() error {
+func (fake *FakePipeline) Pause(from db.ConfigVersion) (db.ConfigVersion, error) {
 	fake.pauseMutex.Lock()
 	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
-	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct{}{})
-	fake.recordInvocation("Pause", []interface{}{})
+	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
+		from db.ConfigVersion
+	}{from})
+	fake.recordInvocation("Pause", []interface{}{from})
 	fake.pauseMutex.Unlock()
 	if fake.PauseStub != nil {
-		return fake.PauseStub()
+		return fake.PauseStub(from)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.pauseReturns.result1
+	return fake.pauseReturns.result1, fake.pauseReturns.result2
 }
 
 func (fake *FakePipeline) PauseCallCount() int {
@@ -2365,38 +2663,49 @@ func (fake *FakePipeline) PauseCallCount() int {
 	return len(fake.pauseArgsForCall)
 }
 
-func (fake *FakePipeline) PauseReturns(result1 error) {
+func (fake *FakePipeline) PauseArgsForCall(i int) db.ConfigVersion {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return fake.pauseArgsForCall[i].from
+}
+
+func (fake *FakePipeline) PauseReturns(result1 db.ConfigVersion, result2 error) {
 	fake.PauseStub = nil
 	fake.pauseReturns = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePipeline) PauseReturnsOnCall(i int, result1 error) {
+func (fake *FakePipeline) PauseReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
 	fake.PauseStub = nil
 	if fake.pauseReturnsOnCall == nil {
 		fake.pauseReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.ConfigVersion
+			result2 error
 		})
 	}
 	fake.pauseReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePipeline) Unpause() error {
+func (fake *FakePipeline) Unpause(from db.ConfigVersion) (db.ConfigVersion, error) {
 	fake.unpauseMutex.Lock()
 	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
-	fake.unpauseArgsForCall = append(fake.unpauseArgsForCall, struct{}{})
-	fake.recordInvocation("Unpause", []interface{}{})
+	fake.unpauseArgsForCall = append(fake.unpauseArgsForCall, struct {
+		from db.ConfigVersion
+	}{from})
+	fake.recordInvocation("Unpause", []interface{}{from})
 	fake.unpauseMutex.Unlock()
 	if fake.UnpauseStub != nil {
-		return fake.UnpauseStub()
+		return fake.UnpauseStub(from)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.unpauseReturns.result1
+	return fake.unpauseReturns.result1, fake.unpauseReturns.result2
 }
 
 func (fake *FakePipeline) UnpauseCallCount() int {
@@ -2405,23 +2714,32 @@ func (fake *FakePipeline) UnpauseCallCount() int {
 	return len(fake.unpauseArgsForCall)
 }
 
-func (fake *FakePipeline) UnpauseReturns(result1 error) {
-	fake.UnpauseStub = nil
-	fake.unpauseReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakePipeline) UnpauseArgsForCall(i int) db.ConfigVersion {
+	fake.unpauseMutex.RLock()
+	defer fake.unpauseMutex.RUnlock()
+	return fake.unpauseArgsForCall[i].from
 }
 
-func (fake *FakePipeline) UnpauseReturnsOnCall(i int, result1 error) {
+func (fake *FakePipeline) UnpauseReturns(result1 db.ConfigVersion, result2 error) {
+	fake.UnpauseStub = nil
+	fake.unpauseReturns = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) UnpauseReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
 	fake.UnpauseStub = nil
 	if fake.unpauseReturnsOnCall == nil {
 		fake.unpauseReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.ConfigVersion
+			result2 error
 		})
 	}
 	fake.unpauseReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakePipeline) Destroy() error {
@@ -2464,21 +2782,22 @@ func (fake *FakePipeline) DestroyReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakePipeline) Rename(arg1 string) error {
+func (fake *FakePipeline) Rename(name string, from db.ConfigVersion) (db.ConfigVersion, error) {
 	fake.renameMutex.Lock()
 	ret, specificReturn := fake.renameReturnsOnCall[len(fake.renameArgsForCall)]
 	fake.renameArgsForCall = append(fake.renameArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("Rename", []interface{}{arg1})
+		name string
+		from db.ConfigVersion
+	}{name, from})
+	fake.recordInvocation("Rename", []interface{}{name, from})
 	fake.renameMutex.Unlock()
 	if fake.RenameStub != nil {
-		return fake.RenameStub(arg1)
+		return fake.RenameStub(name, from)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.renameReturns.result1
+	return fake.renameReturns.result1, fake.renameReturns.result2
 }
 
 func (fake *FakePipeline) RenameCallCount() int {
@@ -2487,39 +2806,44 @@ func (fake *FakePipeline) RenameCallCount() int {
 	return len(fake.renameArgsForCall)
 }
 
-func (fake *FakePipeline) RenameArgsForCall(i int) string {
+func (fake *FakePipeline) RenameArgsForCall(i int) (string, db.ConfigVersion) {
 	fake.renameMutex.RLock()
 	defer fake.renameMutex.RUnlock()
-	return fake.renameArgsForCall[i].arg1
+	return fake.renameArgsForCall[i].name, fake.renameArgsForCall[i].from
 }
 
-func (fake *FakePipeline) RenameReturns(result1 error) {
+func (fake *FakePipeline) RenameReturns(result1 db.ConfigVersion, result2 error) {
 	fake.RenameStub = nil
 	fake.renameReturns = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePipeline) RenameReturnsOnCall(i int, result1 error) {
+func (fake *FakePipeline) RenameReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
 	fake.RenameStub = nil
 	if fake.renameReturnsOnCall == nil {
 		fake.renameReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.ConfigVersion
+			result2 error
 		})
 	}
 	fake.renameReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePipeline) CreateOneOffBuild() (db.Build, error) {
+func (fake *FakePipeline) CreateOneOffBuild(arg1 db.BuildTriggerCause) (db.Build, error) {
 	fake.createOneOffBuildMutex.Lock()
 	ret, specificReturn := fake.createOneOffBuildReturnsOnCall[len(fake.createOneOffBuildArgsForCall)]
-	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct{}{})
-	fake.recordInvocation("CreateOneOffBuild", []interface{}{})
+	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct {
+		arg1 db.BuildTriggerCause
+	}{arg1})
+	fake.recordInvocation("CreateOneOffBuild", []interface{}{arg1})
 	fake.createOneOffBuildMutex.Unlock()
 	if fake.CreateOneOffBuildStub != nil {
-		return fake.CreateOneOffBuildStub()
+		return fake.CreateOneOffBuildStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2533,6 +2857,12 @@ func (fake *FakePipeline) CreateOneOffBuildCallCount() int {
 	return len(fake.createOneOffBuildArgsForCall)
 }
 
+func (fake *FakePipeline) CreateOneOffBuildArgsForCall(i int) db.BuildTriggerCause {
+	fake.createOneOffBuildMutex.RLock()
+	defer fake.createOneOffBuildMutex.RUnlock()
+	return fake.createOneOffBuildArgsForCall[i].arg1
+}
+
 func (fake *FakePipeline) CreateOneOffBuildReturns(result1 db.Build, result2 error) {
 	fake.CreateOneOffBuildStub = nil
 	fake.createOneOffBuildReturns = struct {
@@ -2555,6 +2885,911 @@ func (fake *FakePipeline) CreateOneOffBuildReturnsOnCall(i int, result1 db.Build
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) AnnotateVersionedResource(versionedResourceID int, annotation db.VersionAnnotation, from db.ConfigVersion) (db.ConfigVersion, bool, error) {
+	fake.annotateVersionedResourceMutex.Lock()
+	ret, specificReturn := fake.annotateVersionedResourceReturnsOnCall[len(fake.annotateVersionedResourceArgsForCall)]
+	fake.annotateVersionedResourceArgsForCall = append(fake.annotateVersionedResourceArgsForCall, struct {
+		versionedResourceID int
+		annotation          db.VersionAnnotation
+		from                db.ConfigVersion
+	}{versionedResourceID, annotation, from})
+	fake.recordInvocation("AnnotateVersionedResource", []interface{}{versionedResourceID, annotation, from})
+	fake.annotateVersionedResourceMutex.Unlock()
+	if fake.AnnotateVersionedResourceStub != nil {
+		return fake.AnnotateVersionedResourceStub(versionedResourceID, annotation, from)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.annotateVersionedResourceReturns.result1, fake.annotateVersionedResourceReturns.result2, fake.annotateVersionedResourceReturns.result3
+}
+
+func (fake *FakePipeline) AnnotateVersionedResourceCallCount() int {
+	fake.annotateVersionedResourceMutex.RLock()
+	defer fake.annotateVersionedResourceMutex.RUnlock()
+	return len(fake.annotateVersionedResourceArgsForCall)
+}
+
+func (fake *FakePipeline) AnnotateVersionedResourceArgsForCall(i int) (int, db.VersionAnnotation, db.ConfigVersion) {
+	fake.annotateVersionedResourceMutex.RLock()
+	defer fake.annotateVersionedResourceMutex.RUnlock()
+	return fake.annotateVersionedResourceArgsForCall[i].versionedResourceID, fake.annotateVersionedResourceArgsForCall[i].annotation, fake.annotateVersionedResourceArgsForCall[i].from
+}
+
+func (fake *FakePipeline) AnnotateVersionedResourceReturns(result1 db.ConfigVersion, result2 bool, result3 error) {
+	fake.AnnotateVersionedResourceStub = nil
+	fake.annotateVersionedResourceReturns = struct {
+		result1 db.ConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) AnnotateVersionedResourceReturnsOnCall(i int, result1 db.ConfigVersion, result2 bool, result3 error) {
+	fake.AnnotateVersionedResourceStub = nil
+	if fake.annotateVersionedResourceReturnsOnCall == nil {
+		fake.annotateVersionedResourceReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.annotateVersionedResourceReturnsOnCall[i] = struct {
+		result1 db.ConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) RemoveVersionedResourceAnnotation(versionedResourceID int, name string, from db.ConfigVersion) (db.ConfigVersion, bool, error) {
+	fake.removeVersionedResourceAnnotationMutex.Lock()
+	ret, specificReturn := fake.removeVersionedResourceAnnotationReturnsOnCall[len(fake.removeVersionedResourceAnnotationArgsForCall)]
+	fake.removeVersionedResourceAnnotationArgsForCall = append(fake.removeVersionedResourceAnnotationArgsForCall, struct {
+		versionedResourceID int
+		name                string
+		from                db.ConfigVersion
+	}{versionedResourceID, name, from})
+	fake.recordInvocation("RemoveVersionedResourceAnnotation", []interface{}{versionedResourceID, name, from})
+	fake.removeVersionedResourceAnnotationMutex.Unlock()
+	if fake.RemoveVersionedResourceAnnotationStub != nil {
+		return fake.RemoveVersionedResourceAnnotationStub(versionedResourceID, name, from)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.removeVersionedResourceAnnotationReturns.result1, fake.removeVersionedResourceAnnotationReturns.result2, fake.removeVersionedResourceAnnotationReturns.result3
+}
+
+func (fake *FakePipeline) RemoveVersionedResourceAnnotationCallCount() int {
+	fake.removeVersionedResourceAnnotationMutex.RLock()
+	defer fake.removeVersionedResourceAnnotationMutex.RUnlock()
+	return len(fake.removeVersionedResourceAnnotationArgsForCall)
+}
+
+func (fake *FakePipeline) RemoveVersionedResourceAnnotationArgsForCall(i int) (int, string, db.ConfigVersion) {
+	fake.removeVersionedResourceAnnotationMutex.RLock()
+	defer fake.removeVersionedResourceAnnotationMutex.RUnlock()
+	return fake.removeVersionedResourceAnnotationArgsForCall[i].versionedResourceID, fake.removeVersionedResourceAnnotationArgsForCall[i].name, fake.removeVersionedResourceAnnotationArgsForCall[i].from
+}
+
+func (fake *FakePipeline) RemoveVersionedResourceAnnotationReturns(result1 db.ConfigVersion, result2 bool, result3 error) {
+	fake.RemoveVersionedResourceAnnotationStub = nil
+	fake.removeVersionedResourceAnnotationReturns = struct {
+		result1 db.ConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) RemoveVersionedResourceAnnotationReturnsOnCall(i int, result1 db.ConfigVersion, result2 bool, result3 error) {
+	fake.RemoveVersionedResourceAnnotationStub = nil
+	if fake.removeVersionedResourceAnnotationReturnsOnCall == nil {
+		fake.removeVersionedResourceAnnotationReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.removeVersionedResourceAnnotationReturnsOnCall[i] = struct {
+		result1 db.ConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) BuildCalendar(arg1 time.Time, arg2 time.Time, arg3 db.BuildCalendarInterval, arg4 *time.Location) ([]db.BuildCalendarEntry, error) {
+	fake.buildCalendarMutex.Lock()
+	ret, specificReturn := fake.buildCalendarReturnsOnCall[len(fake.buildCalendarArgsForCall)]
+	fake.buildCalendarArgsForCall = append(fake.buildCalendarArgsForCall, struct {
+		arg1 time.Time
+		arg2 time.Time
+		arg3 db.BuildCalendarInterval
+		arg4 *time.Location
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("BuildCalendar", []interface{}{arg1, arg2, arg3, arg4})
+	fake.buildCalendarMutex.Unlock()
+	if fake.BuildCalendarStub != nil {
+		return fake.BuildCalendarStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.buildCalendarReturns.result1, fake.buildCalendarReturns.result2
+}
+
+func (fake *FakePipeline) BuildCalendarCallCount() int {
+	fake.buildCalendarMutex.RLock()
+	defer fake.buildCalendarMutex.RUnlock()
+	return len(fake.buildCalendarArgsForCall)
+}
+
+func (fake *FakePipeline) BuildCalendarArgsForCall(i int) (time.Time, time.Time, db.BuildCalendarInterval, *time.Location) {
+	fake.buildCalendarMutex.RLock()
+	defer fake.buildCalendarMutex.RUnlock()
+	return fake.buildCalendarArgsForCall[i].arg1, fake.buildCalendarArgsForCall[i].arg2, fake.buildCalendarArgsForCall[i].arg3, fake.buildCalendarArgsForCall[i].arg4
+}
+
+func (fake *FakePipeline) BuildCalendarReturns(result1 []db.BuildCalendarEntry, result2 error) {
+	fake.BuildCalendarStub = nil
+	fake.buildCalendarReturns = struct {
+		result1 []db.BuildCalendarEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) BuildCalendarReturnsOnCall(i int, result1 []db.BuildCalendarEntry, result2 error) {
+	fake.BuildCalendarStub = nil
+	if fake.buildCalendarReturnsOnCall == nil {
+		fake.buildCalendarReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildCalendarEntry
+			result2 error
+		})
+	}
+	fake.buildCalendarReturnsOnCall[i] = struct {
+		result1 []db.BuildCalendarEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) Labels() atc.Labels {
+	fake.labelsMutex.Lock()
+	ret, specificReturn := fake.labelsReturnsOnCall[len(fake.labelsArgsForCall)]
+	fake.labelsArgsForCall = append(fake.labelsArgsForCall, struct{}{})
+	fake.recordInvocation("Labels", []interface{}{})
+	fake.labelsMutex.Unlock()
+	if fake.LabelsStub != nil {
+		return fake.LabelsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.labelsReturns.result1
+}
+
+func (fake *FakePipeline) LabelsCallCount() int {
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
+	return len(fake.labelsArgsForCall)
+}
+
+func (fake *FakePipeline) LabelsReturns(result1 atc.Labels) {
+	fake.LabelsStub = nil
+	fake.labelsReturns = struct {
+		result1 atc.Labels
+	}{result1}
+}
+
+func (fake *FakePipeline) LabelsReturnsOnCall(i int, result1 atc.Labels) {
+	fake.LabelsStub = nil
+	if fake.labelsReturnsOnCall == nil {
+		fake.labelsReturnsOnCall = make(map[int]struct {
+			result1 atc.Labels
+		})
+	}
+	fake.labelsReturnsOnCall[i] = struct {
+		result1 atc.Labels
+	}{result1}
+}
+
+func (fake *FakePipeline) BuildLogRetention() atc.BuildLogRetention {
+	fake.buildLogRetentionMutex.Lock()
+	ret, specificReturn := fake.buildLogRetentionReturnsOnCall[len(fake.buildLogRetentionArgsForCall)]
+	fake.buildLogRetentionArgsForCall = append(fake.buildLogRetentionArgsForCall, struct{}{})
+	fake.recordInvocation("BuildLogRetention", []interface{}{})
+	fake.buildLogRetentionMutex.Unlock()
+	if fake.BuildLogRetentionStub != nil {
+		return fake.BuildLogRetentionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.buildLogRetentionReturns.result1
+}
+
+func (fake *FakePipeline) BuildLogRetentionCallCount() int {
+	fake.buildLogRetentionMutex.RLock()
+	defer fake.buildLogRetentionMutex.RUnlock()
+	return len(fake.buildLogRetentionArgsForCall)
+}
+
+func (fake *FakePipeline) BuildLogRetentionReturns(result1 atc.BuildLogRetention) {
+	fake.BuildLogRetentionStub = nil
+	fake.buildLogRetentionReturns = struct {
+		result1 atc.BuildLogRetention
+	}{result1}
+}
+
+func (fake *FakePipeline) BuildLogRetentionReturnsOnCall(i int, result1 atc.BuildLogRetention) {
+	fake.BuildLogRetentionStub = nil
+	if fake.buildLogRetentionReturnsOnCall == nil {
+		fake.buildLogRetentionReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildLogRetention
+		})
+	}
+	fake.buildLogRetentionReturnsOnCall[i] = struct {
+		result1 atc.BuildLogRetention
+	}{result1}
+}
+
+func (fake *FakePipeline) ConfigRevision(id int) (db.ConfigRevision, bool, error) {
+	fake.configRevisionMutex.Lock()
+	ret, specificReturn := fake.configRevisionReturnsOnCall[len(fake.configRevisionArgsForCall)]
+	fake.configRevisionArgsForCall = append(fake.configRevisionArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("ConfigRevision", []interface{}{id})
+	fake.configRevisionMutex.Unlock()
+	if fake.ConfigRevisionStub != nil {
+		return fake.ConfigRevisionStub(id)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.configRevisionReturns.result1, fake.configRevisionReturns.result2, fake.configRevisionReturns.result3
+}
+
+func (fake *FakePipeline) ConfigRevisionCallCount() int {
+	fake.configRevisionMutex.RLock()
+	defer fake.configRevisionMutex.RUnlock()
+	return len(fake.configRevisionArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigRevisionArgsForCall(i int) int {
+	fake.configRevisionMutex.RLock()
+	defer fake.configRevisionMutex.RUnlock()
+	return fake.configRevisionArgsForCall[i].id
+}
+
+func (fake *FakePipeline) ConfigRevisionReturns(result1 db.ConfigRevision, result2 bool, result3 error) {
+	fake.ConfigRevisionStub = nil
+	fake.configRevisionReturns = struct {
+		result1 db.ConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigRevisionReturnsOnCall(i int, result1 db.ConfigRevision, result2 bool, result3 error) {
+	fake.ConfigRevisionStub = nil
+	if fake.configRevisionReturnsOnCall == nil {
+		fake.configRevisionReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigRevision
+			result2 bool
+			result3 error
+		})
+	}
+	fake.configRevisionReturnsOnCall[i] = struct {
+		result1 db.ConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigRevisions(page db.Page) ([]db.ConfigRevision, db.Pagination, error) {
+	fake.configRevisionsMutex.Lock()
+	ret, specificReturn := fake.configRevisionsReturnsOnCall[len(fake.configRevisionsArgsForCall)]
+	fake.configRevisionsArgsForCall = append(fake.configRevisionsArgsForCall, struct {
+		page db.Page
+	}{page})
+	fake.recordInvocation("ConfigRevisions", []interface{}{page})
+	fake.configRevisionsMutex.Unlock()
+	if fake.ConfigRevisionsStub != nil {
+		return fake.ConfigRevisionsStub(page)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.configRevisionsReturns.result1, fake.configRevisionsReturns.result2, fake.configRevisionsReturns.result3
+}
+
+func (fake *FakePipeline) ConfigRevisionsCallCount() int {
+	fake.configRevisionsMutex.RLock()
+	defer fake.configRevisionsMutex.RUnlock()
+	return len(fake.configRevisionsArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigRevisionsArgsForCall(i int) db.Page {
+	fake.configRevisionsMutex.RLock()
+	defer fake.configRevisionsMutex.RUnlock()
+	return fake.configRevisionsArgsForCall[i].page
+}
+
+func (fake *FakePipeline) ConfigRevisionsReturns(result1 []db.ConfigRevision, result2 db.Pagination, result3 error) {
+	fake.ConfigRevisionsStub = nil
+	fake.configRevisionsReturns = struct {
+		result1 []db.ConfigRevision
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigRevisionsReturnsOnCall(i int, result1 []db.ConfigRevision, result2 db.Pagination, result3 error) {
+	fake.ConfigRevisionsStub = nil
+	if fake.configRevisionsReturnsOnCall == nil {
+		fake.configRevisionsReturnsOnCall = make(map[int]struct {
+			result1 []db.ConfigRevision
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.configRevisionsReturnsOnCall[i] = struct {
+		result1 []db.ConfigRevision
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) SearchResourceVersions(arg1 db.ResourceVersionSearch) ([]db.SavedVersionedResource, error) {
+	fake.searchResourceVersionsMutex.Lock()
+	ret, specificReturn := fake.searchResourceVersionsReturnsOnCall[len(fake.searchResourceVersionsArgsForCall)]
+	fake.searchResourceVersionsArgsForCall = append(fake.searchResourceVersionsArgsForCall, struct {
+		arg1 db.ResourceVersionSearch
+	}{arg1})
+	fake.recordInvocation("SearchResourceVersions", []interface{}{arg1})
+	fake.searchResourceVersionsMutex.Unlock()
+	if fake.SearchResourceVersionsStub != nil {
+		return fake.SearchResourceVersionsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.searchResourceVersionsReturns.result1, fake.searchResourceVersionsReturns.result2
+}
+
+func (fake *FakePipeline) SearchResourceVersionsCallCount() int {
+	fake.searchResourceVersionsMutex.RLock()
+	defer fake.searchResourceVersionsMutex.RUnlock()
+	return len(fake.searchResourceVersionsArgsForCall)
+}
+
+func (fake *FakePipeline) SearchResourceVersionsArgsForCall(i int) db.ResourceVersionSearch {
+	fake.searchResourceVersionsMutex.RLock()
+	defer fake.searchResourceVersionsMutex.RUnlock()
+	return fake.searchResourceVersionsArgsForCall[i].arg1
+}
+
+func (fake *FakePipeline) SearchResourceVersionsReturns(result1 []db.SavedVersionedResource, result2 error) {
+	fake.SearchResourceVersionsStub = nil
+	fake.searchResourceVersionsReturns = struct {
+		result1 []db.SavedVersionedResource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SearchResourceVersionsReturnsOnCall(i int, result1 []db.SavedVersionedResource, result2 error) {
+	fake.SearchResourceVersionsStub = nil
+	if fake.searchResourceVersionsReturnsOnCall == nil {
+		fake.searchResourceVersionsReturnsOnCall = make(map[int]struct {
+			result1 []db.SavedVersionedResource
+			result2 error
+		})
+	}
+	fake.searchResourceVersionsReturnsOnCall[i] = struct {
+		result1 []db.SavedVersionedResource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ArchivedAt() time.Time {
+	fake.archivedAtMutex.Lock()
+	ret, specificReturn := fake.archivedAtReturnsOnCall[len(fake.archivedAtArgsForCall)]
+	fake.archivedAtArgsForCall = append(fake.archivedAtArgsForCall, struct{}{})
+	fake.recordInvocation("ArchivedAt", []interface{}{})
+	fake.archivedAtMutex.Unlock()
+	if fake.ArchivedAtStub != nil {
+		return fake.ArchivedAtStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.archivedAtReturns.result1
+}
+
+func (fake *FakePipeline) ArchivedAtCallCount() int {
+	fake.archivedAtMutex.RLock()
+	defer fake.archivedAtMutex.RUnlock()
+	return len(fake.archivedAtArgsForCall)
+}
+
+func (fake *FakePipeline) ArchivedAtReturns(result1 time.Time) {
+	fake.ArchivedAtStub = nil
+	fake.archivedAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakePipeline) ArchivedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.ArchivedAtStub = nil
+	if fake.archivedAtReturnsOnCall == nil {
+		fake.archivedAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.archivedAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakePipeline) Archive(from db.ConfigVersion) (db.ConfigVersion, error) {
+	fake.archiveMutex.Lock()
+	ret, specificReturn := fake.archiveReturnsOnCall[len(fake.archiveArgsForCall)]
+	fake.archiveArgsForCall = append(fake.archiveArgsForCall, struct {
+		from db.ConfigVersion
+	}{from})
+	fake.recordInvocation("Archive", []interface{}{from})
+	fake.archiveMutex.Unlock()
+	if fake.ArchiveStub != nil {
+		return fake.ArchiveStub(from)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.archiveReturns.result1, fake.archiveReturns.result2
+}
+
+func (fake *FakePipeline) ArchiveCallCount() int {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return len(fake.archiveArgsForCall)
+}
+
+func (fake *FakePipeline) ArchiveArgsForCall(i int) db.ConfigVersion {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return fake.archiveArgsForCall[i].from
+}
+
+func (fake *FakePipeline) ArchiveReturns(result1 db.ConfigVersion, result2 error) {
+	fake.ArchiveStub = nil
+	fake.archiveReturns = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ArchiveReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
+	fake.ArchiveStub = nil
+	if fake.archiveReturnsOnCall == nil {
+		fake.archiveReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigVersion
+			result2 error
+		})
+	}
+	fake.archiveReturnsOnCall[i] = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) Restore(from db.ConfigVersion) (db.ConfigVersion, error) {
+	fake.restoreMutex.Lock()
+	ret, specificReturn := fake.restoreReturnsOnCall[len(fake.restoreArgsForCall)]
+	fake.restoreArgsForCall = append(fake.restoreArgsForCall, struct {
+		from db.ConfigVersion
+	}{from})
+	fake.recordInvocation("Restore", []interface{}{from})
+	fake.restoreMutex.Unlock()
+	if fake.RestoreStub != nil {
+		return fake.RestoreStub(from)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.restoreReturns.result1, fake.restoreReturns.result2
+}
+
+func (fake *FakePipeline) RestoreCallCount() int {
+	fake.restoreMutex.RLock()
+	defer fake.restoreMutex.RUnlock()
+	return len(fake.restoreArgsForCall)
+}
+
+func (fake *FakePipeline) RestoreArgsForCall(i int) db.ConfigVersion {
+	fake.restoreMutex.RLock()
+	defer fake.restoreMutex.RUnlock()
+	return fake.restoreArgsForCall[i].from
+}
+
+func (fake *FakePipeline) RestoreReturns(result1 db.ConfigVersion, result2 error) {
+	fake.RestoreStub = nil
+	fake.restoreReturns = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) RestoreReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
+	fake.RestoreStub = nil
+	if fake.restoreReturnsOnCall == nil {
+		fake.restoreReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigVersion
+			result2 error
+		})
+	}
+	fake.restoreReturnsOnCall[i] = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) Snapshot() (atc.PipelineSnapshot, error) {
+	fake.snapshotMutex.Lock()
+	ret, specificReturn := fake.snapshotReturnsOnCall[len(fake.snapshotArgsForCall)]
+	fake.snapshotArgsForCall = append(fake.snapshotArgsForCall, struct{}{})
+	fake.recordInvocation("Snapshot", []interface{}{})
+	fake.snapshotMutex.Unlock()
+	if fake.SnapshotStub != nil {
+		return fake.SnapshotStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.snapshotReturns.result1, fake.snapshotReturns.result2
+}
+
+func (fake *FakePipeline) SnapshotCallCount() int {
+	fake.snapshotMutex.RLock()
+	defer fake.snapshotMutex.RUnlock()
+	return len(fake.snapshotArgsForCall)
+}
+
+func (fake *FakePipeline) SnapshotReturns(result1 atc.PipelineSnapshot, result2 error) {
+	fake.SnapshotStub = nil
+	fake.snapshotReturns = struct {
+		result1 atc.PipelineSnapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SnapshotReturnsOnCall(i int, result1 atc.PipelineSnapshot, result2 error) {
+	fake.SnapshotStub = nil
+	if fake.snapshotReturnsOnCall == nil {
+		fake.snapshotReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineSnapshot
+			result2 error
+		})
+	}
+	fake.snapshotReturnsOnCall[i] = struct {
+		result1 atc.PipelineSnapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) AcquireResourceConfigCheckingLockWithIntervalCheck(logger lager.Logger, usedResourceConfig *db.UsedResourceConfig, interval time.Duration, immediate bool) (lock.Lock, bool, error) {
+	fake.acquireResourceConfigCheckingLockWithIntervalCheckMutex.Lock()
+	ret, specificReturn := fake.acquireResourceConfigCheckingLockWithIntervalCheckReturnsOnCall[len(fake.acquireResourceConfigCheckingLockWithIntervalCheckArgsForCall)]
+	fake.acquireResourceConfigCheckingLockWithIntervalCheckArgsForCall = append(fake.acquireResourceConfigCheckingLockWithIntervalCheckArgsForCall, struct {
+		logger             lager.Logger
+		usedResourceConfig *db.UsedResourceConfig
+		interval           time.Duration
+		immediate          bool
+	}{logger, usedResourceConfig, interval, immediate})
+	fake.recordInvocation("AcquireResourceConfigCheckingLockWithIntervalCheck", []interface{}{logger, usedResourceConfig, interval, immediate})
+	fake.acquireResourceConfigCheckingLockWithIntervalCheckMutex.Unlock()
+	if fake.AcquireResourceConfigCheckingLockWithIntervalCheckStub != nil {
+		return fake.AcquireResourceConfigCheckingLockWithIntervalCheckStub(logger, usedResourceConfig, interval, immediate)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.acquireResourceConfigCheckingLockWithIntervalCheckReturns.result1, fake.acquireResourceConfigCheckingLockWithIntervalCheckReturns.result2, fake.acquireResourceConfigCheckingLockWithIntervalCheckReturns.result3
+}
+
+func (fake *FakePipeline) AcquireResourceConfigCheckingLockWithIntervalCheckCallCount() int {
+	fake.acquireResourceConfigCheckingLockWithIntervalCheckMutex.RLock()
+	defer fake.acquireResourceConfigCheckingLockWithIntervalCheckMutex.RUnlock()
+	return len(fake.acquireResourceConfigCheckingLockWithIntervalCheckArgsForCall)
+}
+
+func (fake *FakePipeline) AcquireResourceConfigCheckingLockWithIntervalCheckArgsForCall(i int) (lager.Logger, *db.UsedResourceConfig, time.Duration, bool) {
+	fake.acquireResourceConfigCheckingLockWithIntervalCheckMutex.RLock()
+	defer fake.acquireResourceConfigCheckingLockWithIntervalCheckMutex.RUnlock()
+	return fake.acquireResourceConfigCheckingLockWithIntervalCheckArgsForCall[i].logger, fake.acquireResourceConfigCheckingLockWithIntervalCheckArgsForCall[i].usedResourceConfig, fake.acquireResourceConfigCheckingLockWithIntervalCheckArgsForCall[i].interval, fake.acquireResourceConfigCheckingLockWithIntervalCheckArgsForCall[i].immediate
+}
+
+func (fake *FakePipeline) AcquireResourceConfigCheckingLockWithIntervalCheckReturns(result1 lock.Lock, result2 bool, result3 error) {
+	fake.AcquireResourceConfigCheckingLockWithIntervalCheckStub = nil
+	fake.acquireResourceConfigCheckingLockWithIntervalCheckReturns = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) AcquireResourceConfigCheckingLockWithIntervalCheckReturnsOnCall(i int, result1 lock.Lock, result2 bool, result3 error) {
+	fake.AcquireResourceConfigCheckingLockWithIntervalCheckStub = nil
+	if fake.acquireResourceConfigCheckingLockWithIntervalCheckReturnsOnCall == nil {
+		fake.acquireResourceConfigCheckingLockWithIntervalCheckReturnsOnCall = make(map[int]struct {
+			result1 lock.Lock
+			result2 bool
+			result3 error
+		})
+	}
+	fake.acquireResourceConfigCheckingLockWithIntervalCheckReturnsOnCall[i] = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) SaveResourceConfigVersions(arg1 *db.UsedResourceConfig, arg2 []atc.Version) error {
+	var arg2Copy []atc.Version
+	if arg2 != nil {
+		arg2Copy = make([]atc.Version, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.saveResourceConfigVersionsMutex.Lock()
+	ret, specificReturn := fake.saveResourceConfigVersionsReturnsOnCall[len(fake.saveResourceConfigVersionsArgsForCall)]
+	fake.saveResourceConfigVersionsArgsForCall = append(fake.saveResourceConfigVersionsArgsForCall, struct {
+		arg1 *db.UsedResourceConfig
+		arg2 []atc.Version
+	}{arg1, arg2Copy})
+	fake.recordInvocation("SaveResourceConfigVersions", []interface{}{arg1, arg2Copy})
+	fake.saveResourceConfigVersionsMutex.Unlock()
+	if fake.SaveResourceConfigVersionsStub != nil {
+		return fake.SaveResourceConfigVersionsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveResourceConfigVersionsReturns.result1
+}
+
+func (fake *FakePipeline) SaveResourceConfigVersionsCallCount() int {
+	fake.saveResourceConfigVersionsMutex.RLock()
+	defer fake.saveResourceConfigVersionsMutex.RUnlock()
+	return len(fake.saveResourceConfigVersionsArgsForCall)
+}
+
+func (fake *FakePipeline) SaveResourceConfigVersionsArgsForCall(i int) (*db.UsedResourceConfig, []atc.Version) {
+	fake.saveResourceConfigVersionsMutex.RLock()
+	defer fake.saveResourceConfigVersionsMutex.RUnlock()
+	return fake.saveResourceConfigVersionsArgsForCall[i].arg1, fake.saveResourceConfigVersionsArgsForCall[i].arg2
+}
+
+func (fake *FakePipeline) SaveResourceConfigVersionsReturns(result1 error) {
+	fake.SaveResourceConfigVersionsStub = nil
+	fake.saveResourceConfigVersionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) SaveResourceConfigVersionsReturnsOnCall(i int, result1 error) {
+	fake.SaveResourceConfigVersionsStub = nil
+	if fake.saveResourceConfigVersionsReturnsOnCall == nil {
+		fake.saveResourceConfigVersionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveResourceConfigVersionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) LatestResourceConfigVersion(arg1 *db.UsedResourceConfig) (atc.Version, bool, error) {
+	fake.latestResourceConfigVersionMutex.Lock()
+	ret, specificReturn := fake.latestResourceConfigVersionReturnsOnCall[len(fake.latestResourceConfigVersionArgsForCall)]
+	fake.latestResourceConfigVersionArgsForCall = append(fake.latestResourceConfigVersionArgsForCall, struct {
+		arg1 *db.UsedResourceConfig
+	}{arg1})
+	fake.recordInvocation("LatestResourceConfigVersion", []interface{}{arg1})
+	fake.latestResourceConfigVersionMutex.Unlock()
+	if fake.LatestResourceConfigVersionStub != nil {
+		return fake.LatestResourceConfigVersionStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.latestResourceConfigVersionReturns.result1, fake.latestResourceConfigVersionReturns.result2, fake.latestResourceConfigVersionReturns.result3
+}
+
+func (fake *FakePipeline) LatestResourceConfigVersionCallCount() int {
+	fake.latestResourceConfigVersionMutex.RLock()
+	defer fake.latestResourceConfigVersionMutex.RUnlock()
+	return len(fake.latestResourceConfigVersionArgsForCall)
+}
+
+func (fake *FakePipeline) LatestResourceConfigVersionArgsForCall(i int) *db.UsedResourceConfig {
+	fake.latestResourceConfigVersionMutex.RLock()
+	defer fake.latestResourceConfigVersionMutex.RUnlock()
+	return fake.latestResourceConfigVersionArgsForCall[i].arg1
+}
+
+func (fake *FakePipeline) LatestResourceConfigVersionReturns(result1 atc.Version, result2 bool, result3 error) {
+	fake.LatestResourceConfigVersionStub = nil
+	fake.latestResourceConfigVersionReturns = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) LatestResourceConfigVersionReturnsOnCall(i int, result1 atc.Version, result2 bool, result3 error) {
+	fake.LatestResourceConfigVersionStub = nil
+	if fake.latestResourceConfigVersionReturnsOnCall == nil {
+		fake.latestResourceConfigVersionReturnsOnCall = make(map[int]struct {
+			result1 atc.Version
+			result2 bool
+			result3 error
+		})
+	}
+	fake.latestResourceConfigVersionReturnsOnCall[i] = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) DestroyIfArchivedBefore(arg1 time.Time) (bool, error) {
+	fake.destroyIfArchivedBeforeMutex.Lock()
+	ret, specificReturn := fake.destroyIfArchivedBeforeReturnsOnCall[len(fake.destroyIfArchivedBeforeArgsForCall)]
+	fake.destroyIfArchivedBeforeArgsForCall = append(fake.destroyIfArchivedBeforeArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("DestroyIfArchivedBefore", []interface{}{arg1})
+	fake.destroyIfArchivedBeforeMutex.Unlock()
+	if fake.DestroyIfArchivedBeforeStub != nil {
+		return fake.DestroyIfArchivedBeforeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.destroyIfArchivedBeforeReturns.result1, fake.destroyIfArchivedBeforeReturns.result2
+}
+
+func (fake *FakePipeline) DestroyIfArchivedBeforeCallCount() int {
+	fake.destroyIfArchivedBeforeMutex.RLock()
+	defer fake.destroyIfArchivedBeforeMutex.RUnlock()
+	return len(fake.destroyIfArchivedBeforeArgsForCall)
+}
+
+func (fake *FakePipeline) DestroyIfArchivedBeforeArgsForCall(i int) time.Time {
+	fake.destroyIfArchivedBeforeMutex.RLock()
+	defer fake.destroyIfArchivedBeforeMutex.RUnlock()
+	return fake.destroyIfArchivedBeforeArgsForCall[i].arg1
+}
+
+func (fake *FakePipeline) DestroyIfArchivedBeforeReturns(result1 bool, result2 error) {
+	fake.DestroyIfArchivedBeforeStub = nil
+	fake.destroyIfArchivedBeforeReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) DestroyIfArchivedBeforeReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.DestroyIfArchivedBeforeStub = nil
+	if fake.destroyIfArchivedBeforeReturnsOnCall == nil {
+		fake.destroyIfArchivedBeforeReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.destroyIfArchivedBeforeReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) GetBuildsWithVersionsAsInput(versionedResourceIDs []int) (map[int][]db.Build, error) {
+	var versionedResourceIDsCopy []int
+	if versionedResourceIDs != nil {
+		versionedResourceIDsCopy = make([]int, len(versionedResourceIDs))
+		copy(versionedResourceIDsCopy, versionedResourceIDs)
+	}
+	fake.getBuildsWithVersionsAsInputMutex.Lock()
+	ret, specificReturn := fake.getBuildsWithVersionsAsInputReturnsOnCall[len(fake.getBuildsWithVersionsAsInputArgsForCall)]
+	fake.getBuildsWithVersionsAsInputArgsForCall = append(fake.getBuildsWithVersionsAsInputArgsForCall, struct {
+		versionedResourceIDs []int
+	}{versionedResourceIDsCopy})
+	fake.recordInvocation("GetBuildsWithVersionsAsInput", []interface{}{versionedResourceIDsCopy})
+	fake.getBuildsWithVersionsAsInputMutex.Unlock()
+	if fake.GetBuildsWithVersionsAsInputStub != nil {
+		return fake.GetBuildsWithVersionsAsInputStub(versionedResourceIDs)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getBuildsWithVersionsAsInputReturns.result1, fake.getBuildsWithVersionsAsInputReturns.result2
+}
+
+func (fake *FakePipeline) GetBuildsWithVersionsAsInputCallCount() int {
+	fake.getBuildsWithVersionsAsInputMutex.RLock()
+	defer fake.getBuildsWithVersionsAsInputMutex.RUnlock()
+	return len(fake.getBuildsWithVersionsAsInputArgsForCall)
+}
+
+func (fake *FakePipeline) GetBuildsWithVersionsAsInputArgsForCall(i int) []int {
+	fake.getBuildsWithVersionsAsInputMutex.RLock()
+	defer fake.getBuildsWithVersionsAsInputMutex.RUnlock()
+	return fake.getBuildsWithVersionsAsInputArgsForCall[i].versionedResourceIDs
+}
+
+func (fake *FakePipeline) GetBuildsWithVersionsAsInputReturns(result1 map[int][]db.Build, result2 error) {
+	fake.GetBuildsWithVersionsAsInputStub = nil
+	fake.getBuildsWithVersionsAsInputReturns = struct {
+		result1 map[int][]db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) GetBuildsWithVersionsAsInputReturnsOnCall(i int, result1 map[int][]db.Build, result2 error) {
+	fake.GetBuildsWithVersionsAsInputStub = nil
+	if fake.getBuildsWithVersionsAsInputReturnsOnCall == nil {
+		fake.getBuildsWithVersionsAsInputReturnsOnCall = make(map[int]struct {
+			result1 map[int][]db.Build
+			result2 error
+		})
+	}
+	fake.getBuildsWithVersionsAsInputReturnsOnCall[i] = struct {
+		result1 map[int][]db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) PendingJobsAwaitingInputs() (map[string][]string, error) {
+	fake.pendingJobsAwaitingInputsMutex.Lock()
+	ret, specificReturn := fake.pendingJobsAwaitingInputsReturnsOnCall[len(fake.pendingJobsAwaitingInputsArgsForCall)]
+	fake.pendingJobsAwaitingInputsArgsForCall = append(fake.pendingJobsAwaitingInputsArgsForCall, struct{}{})
+	fake.recordInvocation("PendingJobsAwaitingInputs", []interface{}{})
+	fake.pendingJobsAwaitingInputsMutex.Unlock()
+	if fake.PendingJobsAwaitingInputsStub != nil {
+		return fake.PendingJobsAwaitingInputsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.pendingJobsAwaitingInputsReturns.result1, fake.pendingJobsAwaitingInputsReturns.result2
+}
+
+func (fake *FakePipeline) PendingJobsAwaitingInputsCallCount() int {
+	fake.pendingJobsAwaitingInputsMutex.RLock()
+	defer fake.pendingJobsAwaitingInputsMutex.RUnlock()
+	return len(fake.pendingJobsAwaitingInputsArgsForCall)
+}
+
+func (fake *FakePipeline) PendingJobsAwaitingInputsReturns(result1 map[string][]string, result2 error) {
+	fake.PendingJobsAwaitingInputsStub = nil
+	fake.pendingJobsAwaitingInputsReturns = struct {
+		result1 map[string][]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) PendingJobsAwaitingInputsReturnsOnCall(i int, result1 map[string][]string, result2 error) {
+	fake.PendingJobsAwaitingInputsStub = nil
+	if fake.pendingJobsAwaitingInputsReturnsOnCall == nil {
+		fake.pendingJobsAwaitingInputsReturnsOnCall = make(map[int]struct {
+			result1 map[string][]string
+			result2 error
+		})
+	}
+	fake.pendingJobsAwaitingInputsReturnsOnCall[i] = struct {
+		result1 map[string][]string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2644,6 +3879,42 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.renameMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
+	fake.annotateVersionedResourceMutex.RLock()
+	defer fake.annotateVersionedResourceMutex.RUnlock()
+	fake.removeVersionedResourceAnnotationMutex.RLock()
+	defer fake.removeVersionedResourceAnnotationMutex.RUnlock()
+	fake.buildCalendarMutex.RLock()
+	defer fake.buildCalendarMutex.RUnlock()
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
+	fake.buildLogRetentionMutex.RLock()
+	defer fake.buildLogRetentionMutex.RUnlock()
+	fake.configRevisionMutex.RLock()
+	defer fake.configRevisionMutex.RUnlock()
+	fake.configRevisionsMutex.RLock()
+	defer fake.configRevisionsMutex.RUnlock()
+	fake.searchResourceVersionsMutex.RLock()
+	defer fake.searchResourceVersionsMutex.RUnlock()
+	fake.archivedAtMutex.RLock()
+	defer fake.archivedAtMutex.RUnlock()
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	fake.restoreMutex.RLock()
+	defer fake.restoreMutex.RUnlock()
+	fake.snapshotMutex.RLock()
+	defer fake.snapshotMutex.RUnlock()
+	fake.acquireResourceConfigCheckingLockWithIntervalCheckMutex.RLock()
+	defer fake.acquireResourceConfigCheckingLockWithIntervalCheckMutex.RUnlock()
+	fake.saveResourceConfigVersionsMutex.RLock()
+	defer fake.saveResourceConfigVersionsMutex.RUnlock()
+	fake.latestResourceConfigVersionMutex.RLock()
+	defer fake.latestResourceConfigVersionMutex.RUnlock()
+	fake.destroyIfArchivedBeforeMutex.RLock()
+	defer fake.destroyIfArchivedBeforeMutex.RUnlock()
+	fake.getBuildsWithVersionsAsInputMutex.RLock()
+	defer fake.getBuildsWithVersionsAsInputMutex.RUnlock()
+	fake.pendingJobsAwaitingInputsMutex.RLock()
+	defer fake.pendingJobsAwaitingInputsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
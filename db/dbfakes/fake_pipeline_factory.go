@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/atc/db"
 )
@@ -43,6 +44,19 @@ type FakePipelineFactory struct {
 		result1 []db.Pipeline
 		result2 error
 	}
+	ArchivedPipelinesStub        func(time.Time) ([]db.Pipeline, error)
+	archivedPipelinesMutex       sync.RWMutex
+	archivedPipelinesArgsForCall []struct {
+		arg1 time.Time
+	}
+	archivedPipelinesReturns struct {
+		result1 []db.Pipeline
+		result2 error
+	}
+	archivedPipelinesReturnsOnCall map[int]struct {
+		result1 []db.Pipeline
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -189,6 +203,57 @@ func (fake *FakePipelineFactory) AllPipelinesReturnsOnCall(i int, result1 []db.P
 	}{result1, result2}
 }
 
+func (fake *FakePipelineFactory) ArchivedPipelines(arg1 time.Time) ([]db.Pipeline, error) {
+	fake.archivedPipelinesMutex.Lock()
+	ret, specificReturn := fake.archivedPipelinesReturnsOnCall[len(fake.archivedPipelinesArgsForCall)]
+	fake.archivedPipelinesArgsForCall = append(fake.archivedPipelinesArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("ArchivedPipelines", []interface{}{arg1})
+	fake.archivedPipelinesMutex.Unlock()
+	if fake.ArchivedPipelinesStub != nil {
+		return fake.ArchivedPipelinesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.archivedPipelinesReturns.result1, fake.archivedPipelinesReturns.result2
+}
+
+func (fake *FakePipelineFactory) ArchivedPipelinesCallCount() int {
+	fake.archivedPipelinesMutex.RLock()
+	defer fake.archivedPipelinesMutex.RUnlock()
+	return len(fake.archivedPipelinesArgsForCall)
+}
+
+func (fake *FakePipelineFactory) ArchivedPipelinesArgsForCall(i int) time.Time {
+	fake.archivedPipelinesMutex.RLock()
+	defer fake.archivedPipelinesMutex.RUnlock()
+	return fake.archivedPipelinesArgsForCall[i].arg1
+}
+
+func (fake *FakePipelineFactory) ArchivedPipelinesReturns(result1 []db.Pipeline, result2 error) {
+	fake.ArchivedPipelinesStub = nil
+	fake.archivedPipelinesReturns = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineFactory) ArchivedPipelinesReturnsOnCall(i int, result1 []db.Pipeline, result2 error) {
+	fake.ArchivedPipelinesStub = nil
+	if fake.archivedPipelinesReturnsOnCall == nil {
+		fake.archivedPipelinesReturnsOnCall = make(map[int]struct {
+			result1 []db.Pipeline
+			result2 error
+		})
+	}
+	fake.archivedPipelinesReturnsOnCall[i] = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -198,6 +263,8 @@ func (fake *FakePipelineFactory) Invocations() map[string][][]interface{} {
 	defer fake.publicPipelinesMutex.RUnlock()
 	fake.allPipelinesMutex.RLock()
 	defer fake.allPipelinesMutex.RUnlock()
+	fake.archivedPipelinesMutex.RLock()
+	defer fake.archivedPipelinesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -129,23 +129,31 @@ type FakeResource struct {
 	setResourceConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PauseStub        func() error
+	PauseStub        func(db.ConfigVersion) (db.ConfigVersion, error)
 	pauseMutex       sync.RWMutex
-	pauseArgsForCall []struct{}
-	pauseReturns     struct {
-		result1 error
+	pauseArgsForCall []struct {
+		from db.ConfigVersion
+	}
+	pauseReturns struct {
+		result1 db.ConfigVersion
+		result2 error
 	}
 	pauseReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
-	UnpauseStub        func() error
+	UnpauseStub        func(db.ConfigVersion) (db.ConfigVersion, error)
 	unpauseMutex       sync.RWMutex
-	unpauseArgsForCall []struct{}
-	unpauseReturns     struct {
-		result1 error
+	unpauseArgsForCall []struct {
+		from db.ConfigVersion
+	}
+	unpauseReturns struct {
+		result1 db.ConfigVersion
+		result2 error
 	}
 	unpauseReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
@@ -158,6 +166,80 @@ type FakeResource struct {
 		result1 bool
 		result2 error
 	}
+	WebhookTokensStub        func() ([]db.ResourceWebhookToken, error)
+	webhookTokensMutex       sync.RWMutex
+	webhookTokensArgsForCall []struct{}
+	webhookTokensReturns     struct {
+		result1 []db.ResourceWebhookToken
+		result2 error
+	}
+	webhookTokensReturnsOnCall map[int]struct {
+		result1 []db.ResourceWebhookToken
+		result2 error
+	}
+	CreateWebhookTokenStub        func() (db.ResourceWebhookToken, string, error)
+	createWebhookTokenMutex       sync.RWMutex
+	createWebhookTokenArgsForCall []struct{}
+	createWebhookTokenReturns     struct {
+		result1 db.ResourceWebhookToken
+		result2 string
+		result3 error
+	}
+	createWebhookTokenReturnsOnCall map[int]struct {
+		result1 db.ResourceWebhookToken
+		result2 string
+		result3 error
+	}
+	DeleteWebhookTokenStub        func(int) (bool, error)
+	deleteWebhookTokenMutex       sync.RWMutex
+	deleteWebhookTokenArgsForCall []struct {
+		id int
+	}
+	deleteWebhookTokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteWebhookTokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UseWebhookTokenStub        func(string) (bool, error)
+	useWebhookTokenMutex       sync.RWMutex
+	useWebhookTokenArgsForCall []struct {
+		token string
+	}
+	useWebhookTokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	useWebhookTokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	SaveCheckResultStub        func(db.ResourceCheckResult) error
+	saveCheckResultMutex       sync.RWMutex
+	saveCheckResultArgsForCall []struct {
+		result db.ResourceCheckResult
+	}
+	saveCheckResultReturns struct {
+		result1 error
+	}
+	saveCheckResultReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CheckResultsStub        func(int) ([]db.ResourceCheckResult, error)
+	checkResultsMutex       sync.RWMutex
+	checkResultsArgsForCall []struct {
+		limit int
+	}
+	checkResultsReturns struct {
+		result1 []db.ResourceCheckResult
+		result2 error
+	}
+	checkResultsReturnsOnCall map[int]struct {
+		result1 []db.ResourceCheckResult
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -690,19 +772,21 @@ func (fake *FakeResource) SetResourceConfigReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeResource) Pause() error {
+func (fake *FakeResource) Pause(from db.ConfigVersion) (db.ConfigVersion, error) {
 	fake.pauseMutex.Lock()
 	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
-	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct{}{})
-	fake.recordInvocation("Pause", []interface{}{})
+	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
+		from db.ConfigVersion
+	}{from})
+	fake.recordInvocation("Pause", []interface{}{from})
 	fake.pauseMutex.Unlock()
 	if fake.PauseStub != nil {
-		return fake.PauseStub()
+		return fake.PauseStub(from)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.pauseReturns.result1
+	return fake.pauseReturns.result1, fake.pauseReturns.result2
 }
 
 func (fake *FakeResource) PauseCallCount() int {
@@ -711,38 +795,49 @@ func (fake *FakeResource) PauseCallCount() int {
 	return len(fake.pauseArgsForCall)
 }
 
-func (fake *FakeResource) PauseReturns(result1 error) {
+func (fake *FakeResource) PauseArgsForCall(i int) db.ConfigVersion {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return fake.pauseArgsForCall[i].from
+}
+
+func (fake *FakeResource) PauseReturns(result1 db.ConfigVersion, result2 error) {
 	fake.PauseStub = nil
 	fake.pauseReturns = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeResource) PauseReturnsOnCall(i int, result1 error) {
+func (fake *FakeResource) PauseReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
 	fake.PauseStub = nil
 	if fake.pauseReturnsOnCall == nil {
 		fake.pauseReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.ConfigVersion
+			result2 error
 		})
 	}
 	fake.pauseReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeResource) Unpause() error {
+func (fake *FakeResource) Unpause(from db.ConfigVersion) (db.ConfigVersion, error) {
 	fake.unpauseMutex.Lock()
 	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
-	fake.unpauseArgsForCall = append(fake.unpauseArgsForCall, struct{}{})
-	fake.recordInvocation("Unpause", []interface{}{})
+	fake.unpauseArgsForCall = append(fake.unpauseArgsForCall, struct {
+		from db.ConfigVersion
+	}{from})
+	fake.recordInvocation("Unpause", []interface{}{from})
 	fake.unpauseMutex.Unlock()
 	if fake.UnpauseStub != nil {
-		return fake.UnpauseStub()
+		return fake.UnpauseStub(from)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.unpauseReturns.result1
+	return fake.unpauseReturns.result1, fake.unpauseReturns.result2
 }
 
 func (fake *FakeResource) UnpauseCallCount() int {
@@ -751,23 +846,32 @@ func (fake *FakeResource) UnpauseCallCount() int {
 	return len(fake.unpauseArgsForCall)
 }
 
-func (fake *FakeResource) UnpauseReturns(result1 error) {
+func (fake *FakeResource) UnpauseArgsForCall(i int) db.ConfigVersion {
+	fake.unpauseMutex.RLock()
+	defer fake.unpauseMutex.RUnlock()
+	return fake.unpauseArgsForCall[i].from
+}
+
+func (fake *FakeResource) UnpauseReturns(result1 db.ConfigVersion, result2 error) {
 	fake.UnpauseStub = nil
 	fake.unpauseReturns = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeResource) UnpauseReturnsOnCall(i int, result1 error) {
+func (fake *FakeResource) UnpauseReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
 	fake.UnpauseStub = nil
 	if fake.unpauseReturnsOnCall == nil {
 		fake.unpauseReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.ConfigVersion
+			result2 error
 		})
 	}
 	fake.unpauseReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeResource) Reload() (bool, error) {
@@ -813,6 +917,296 @@ func (fake *FakeResource) ReloadReturnsOnCall(i int, result1 bool, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeResource) WebhookTokens() ([]db.ResourceWebhookToken, error) {
+	fake.webhookTokensMutex.Lock()
+	ret, specificReturn := fake.webhookTokensReturnsOnCall[len(fake.webhookTokensArgsForCall)]
+	fake.webhookTokensArgsForCall = append(fake.webhookTokensArgsForCall, struct{}{})
+	fake.recordInvocation("WebhookTokens", []interface{}{})
+	fake.webhookTokensMutex.Unlock()
+	if fake.WebhookTokensStub != nil {
+		return fake.WebhookTokensStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.webhookTokensReturns.result1, fake.webhookTokensReturns.result2
+}
+
+func (fake *FakeResource) WebhookTokensCallCount() int {
+	fake.webhookTokensMutex.RLock()
+	defer fake.webhookTokensMutex.RUnlock()
+	return len(fake.webhookTokensArgsForCall)
+}
+
+func (fake *FakeResource) WebhookTokensReturns(result1 []db.ResourceWebhookToken, result2 error) {
+	fake.WebhookTokensStub = nil
+	fake.webhookTokensReturns = struct {
+		result1 []db.ResourceWebhookToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) WebhookTokensReturnsOnCall(i int, result1 []db.ResourceWebhookToken, result2 error) {
+	fake.WebhookTokensStub = nil
+	if fake.webhookTokensReturnsOnCall == nil {
+		fake.webhookTokensReturnsOnCall = make(map[int]struct {
+			result1 []db.ResourceWebhookToken
+			result2 error
+		})
+	}
+	fake.webhookTokensReturnsOnCall[i] = struct {
+		result1 []db.ResourceWebhookToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) CreateWebhookToken() (db.ResourceWebhookToken, string, error) {
+	fake.createWebhookTokenMutex.Lock()
+	ret, specificReturn := fake.createWebhookTokenReturnsOnCall[len(fake.createWebhookTokenArgsForCall)]
+	fake.createWebhookTokenArgsForCall = append(fake.createWebhookTokenArgsForCall, struct{}{})
+	fake.recordInvocation("CreateWebhookToken", []interface{}{})
+	fake.createWebhookTokenMutex.Unlock()
+	if fake.CreateWebhookTokenStub != nil {
+		return fake.CreateWebhookTokenStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.createWebhookTokenReturns.result1, fake.createWebhookTokenReturns.result2, fake.createWebhookTokenReturns.result3
+}
+
+func (fake *FakeResource) CreateWebhookTokenCallCount() int {
+	fake.createWebhookTokenMutex.RLock()
+	defer fake.createWebhookTokenMutex.RUnlock()
+	return len(fake.createWebhookTokenArgsForCall)
+}
+
+func (fake *FakeResource) CreateWebhookTokenReturns(result1 db.ResourceWebhookToken, result2 string, result3 error) {
+	fake.CreateWebhookTokenStub = nil
+	fake.createWebhookTokenReturns = struct {
+		result1 db.ResourceWebhookToken
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResource) CreateWebhookTokenReturnsOnCall(i int, result1 db.ResourceWebhookToken, result2 string, result3 error) {
+	fake.CreateWebhookTokenStub = nil
+	if fake.createWebhookTokenReturnsOnCall == nil {
+		fake.createWebhookTokenReturnsOnCall = make(map[int]struct {
+			result1 db.ResourceWebhookToken
+			result2 string
+			result3 error
+		})
+	}
+	fake.createWebhookTokenReturnsOnCall[i] = struct {
+		result1 db.ResourceWebhookToken
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResource) DeleteWebhookToken(id int) (bool, error) {
+	fake.deleteWebhookTokenMutex.Lock()
+	ret, specificReturn := fake.deleteWebhookTokenReturnsOnCall[len(fake.deleteWebhookTokenArgsForCall)]
+	fake.deleteWebhookTokenArgsForCall = append(fake.deleteWebhookTokenArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("DeleteWebhookToken", []interface{}{id})
+	fake.deleteWebhookTokenMutex.Unlock()
+	if fake.DeleteWebhookTokenStub != nil {
+		return fake.DeleteWebhookTokenStub(id)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.deleteWebhookTokenReturns.result1, fake.deleteWebhookTokenReturns.result2
+}
+
+func (fake *FakeResource) DeleteWebhookTokenCallCount() int {
+	fake.deleteWebhookTokenMutex.RLock()
+	defer fake.deleteWebhookTokenMutex.RUnlock()
+	return len(fake.deleteWebhookTokenArgsForCall)
+}
+
+func (fake *FakeResource) DeleteWebhookTokenArgsForCall(i int) int {
+	fake.deleteWebhookTokenMutex.RLock()
+	defer fake.deleteWebhookTokenMutex.RUnlock()
+	return fake.deleteWebhookTokenArgsForCall[i].id
+}
+
+func (fake *FakeResource) DeleteWebhookTokenReturns(result1 bool, result2 error) {
+	fake.DeleteWebhookTokenStub = nil
+	fake.deleteWebhookTokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) DeleteWebhookTokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.DeleteWebhookTokenStub = nil
+	if fake.deleteWebhookTokenReturnsOnCall == nil {
+		fake.deleteWebhookTokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteWebhookTokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) UseWebhookToken(token string) (bool, error) {
+	fake.useWebhookTokenMutex.Lock()
+	ret, specificReturn := fake.useWebhookTokenReturnsOnCall[len(fake.useWebhookTokenArgsForCall)]
+	fake.useWebhookTokenArgsForCall = append(fake.useWebhookTokenArgsForCall, struct {
+		token string
+	}{token})
+	fake.recordInvocation("UseWebhookToken", []interface{}{token})
+	fake.useWebhookTokenMutex.Unlock()
+	if fake.UseWebhookTokenStub != nil {
+		return fake.UseWebhookTokenStub(token)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.useWebhookTokenReturns.result1, fake.useWebhookTokenReturns.result2
+}
+
+func (fake *FakeResource) UseWebhookTokenCallCount() int {
+	fake.useWebhookTokenMutex.RLock()
+	defer fake.useWebhookTokenMutex.RUnlock()
+	return len(fake.useWebhookTokenArgsForCall)
+}
+
+func (fake *FakeResource) UseWebhookTokenArgsForCall(i int) string {
+	fake.useWebhookTokenMutex.RLock()
+	defer fake.useWebhookTokenMutex.RUnlock()
+	return fake.useWebhookTokenArgsForCall[i].token
+}
+
+func (fake *FakeResource) UseWebhookTokenReturns(result1 bool, result2 error) {
+	fake.UseWebhookTokenStub = nil
+	fake.useWebhookTokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) UseWebhookTokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.UseWebhookTokenStub = nil
+	if fake.useWebhookTokenReturnsOnCall == nil {
+		fake.useWebhookTokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.useWebhookTokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) SaveCheckResult(result db.ResourceCheckResult) error {
+	fake.saveCheckResultMutex.Lock()
+	ret, specificReturn := fake.saveCheckResultReturnsOnCall[len(fake.saveCheckResultArgsForCall)]
+	fake.saveCheckResultArgsForCall = append(fake.saveCheckResultArgsForCall, struct {
+		result db.ResourceCheckResult
+	}{result})
+	fake.recordInvocation("SaveCheckResult", []interface{}{result})
+	fake.saveCheckResultMutex.Unlock()
+	if fake.SaveCheckResultStub != nil {
+		return fake.SaveCheckResultStub(result)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveCheckResultReturns.result1
+}
+
+func (fake *FakeResource) SaveCheckResultCallCount() int {
+	fake.saveCheckResultMutex.RLock()
+	defer fake.saveCheckResultMutex.RUnlock()
+	return len(fake.saveCheckResultArgsForCall)
+}
+
+func (fake *FakeResource) SaveCheckResultArgsForCall(i int) db.ResourceCheckResult {
+	fake.saveCheckResultMutex.RLock()
+	defer fake.saveCheckResultMutex.RUnlock()
+	return fake.saveCheckResultArgsForCall[i].result
+}
+
+func (fake *FakeResource) SaveCheckResultReturns(result1 error) {
+	fake.SaveCheckResultStub = nil
+	fake.saveCheckResultReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) SaveCheckResultReturnsOnCall(i int, result1 error) {
+	fake.SaveCheckResultStub = nil
+	if fake.saveCheckResultReturnsOnCall == nil {
+		fake.saveCheckResultReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveCheckResultReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) CheckResults(limit int) ([]db.ResourceCheckResult, error) {
+	fake.checkResultsMutex.Lock()
+	ret, specificReturn := fake.checkResultsReturnsOnCall[len(fake.checkResultsArgsForCall)]
+	fake.checkResultsArgsForCall = append(fake.checkResultsArgsForCall, struct {
+		limit int
+	}{limit})
+	fake.recordInvocation("CheckResults", []interface{}{limit})
+	fake.checkResultsMutex.Unlock()
+	if fake.CheckResultsStub != nil {
+		return fake.CheckResultsStub(limit)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.checkResultsReturns.result1, fake.checkResultsReturns.result2
+}
+
+func (fake *FakeResource) CheckResultsCallCount() int {
+	fake.checkResultsMutex.RLock()
+	defer fake.checkResultsMutex.RUnlock()
+	return len(fake.checkResultsArgsForCall)
+}
+
+func (fake *FakeResource) CheckResultsArgsForCall(i int) int {
+	fake.checkResultsMutex.RLock()
+	defer fake.checkResultsMutex.RUnlock()
+	return fake.checkResultsArgsForCall[i].limit
+}
+
+func (fake *FakeResource) CheckResultsReturns(result1 []db.ResourceCheckResult, result2 error) {
+	fake.CheckResultsStub = nil
+	fake.checkResultsReturns = struct {
+		result1 []db.ResourceCheckResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) CheckResultsReturnsOnCall(i int, result1 []db.ResourceCheckResult, result2 error) {
+	fake.CheckResultsStub = nil
+	if fake.checkResultsReturnsOnCall == nil {
+		fake.checkResultsReturnsOnCall = make(map[int]struct {
+			result1 []db.ResourceCheckResult
+			result2 error
+		})
+	}
+	fake.checkResultsReturnsOnCall[i] = struct {
+		result1 []db.ResourceCheckResult
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -848,6 +1242,18 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.unpauseMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.webhookTokensMutex.RLock()
+	defer fake.webhookTokensMutex.RUnlock()
+	fake.createWebhookTokenMutex.RLock()
+	defer fake.createWebhookTokenMutex.RUnlock()
+	fake.deleteWebhookTokenMutex.RLock()
+	defer fake.deleteWebhookTokenMutex.RUnlock()
+	fake.useWebhookTokenMutex.RLock()
+	defer fake.useWebhookTokenMutex.RUnlock()
+	fake.saveCheckResultMutex.RLock()
+	defer fake.saveCheckResultMutex.RUnlock()
+	fake.checkResultsMutex.RLock()
+	defer fake.checkResultsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -26,6 +26,15 @@ type FakeResourceConfigCheckSessionLifecycle struct {
 	cleanExpiredResourceConfigCheckSessionsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CleanResourceConfigCheckSessionsOnPressuredWorkersStub        func() error
+	cleanResourceConfigCheckSessionsOnPressuredWorkersMutex       sync.RWMutex
+	cleanResourceConfigCheckSessionsOnPressuredWorkersArgsForCall []struct{}
+	cleanResourceConfigCheckSessionsOnPressuredWorkersReturns     struct {
+		result1 error
+	}
+	cleanResourceConfigCheckSessionsOnPressuredWorkersReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -110,6 +119,46 @@ func (fake *FakeResourceConfigCheckSessionLifecycle) CleanExpiredResourceConfigC
 	}{result1}
 }
 
+func (fake *FakeResourceConfigCheckSessionLifecycle) CleanResourceConfigCheckSessionsOnPressuredWorkers() error {
+	fake.cleanResourceConfigCheckSessionsOnPressuredWorkersMutex.Lock()
+	ret, specificReturn := fake.cleanResourceConfigCheckSessionsOnPressuredWorkersReturnsOnCall[len(fake.cleanResourceConfigCheckSessionsOnPressuredWorkersArgsForCall)]
+	fake.cleanResourceConfigCheckSessionsOnPressuredWorkersArgsForCall = append(fake.cleanResourceConfigCheckSessionsOnPressuredWorkersArgsForCall, struct{}{})
+	fake.recordInvocation("CleanResourceConfigCheckSessionsOnPressuredWorkers", []interface{}{})
+	fake.cleanResourceConfigCheckSessionsOnPressuredWorkersMutex.Unlock()
+	if fake.CleanResourceConfigCheckSessionsOnPressuredWorkersStub != nil {
+		return fake.CleanResourceConfigCheckSessionsOnPressuredWorkersStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.cleanResourceConfigCheckSessionsOnPressuredWorkersReturns.result1
+}
+
+func (fake *FakeResourceConfigCheckSessionLifecycle) CleanResourceConfigCheckSessionsOnPressuredWorkersCallCount() int {
+	fake.cleanResourceConfigCheckSessionsOnPressuredWorkersMutex.RLock()
+	defer fake.cleanResourceConfigCheckSessionsOnPressuredWorkersMutex.RUnlock()
+	return len(fake.cleanResourceConfigCheckSessionsOnPressuredWorkersArgsForCall)
+}
+
+func (fake *FakeResourceConfigCheckSessionLifecycle) CleanResourceConfigCheckSessionsOnPressuredWorkersReturns(result1 error) {
+	fake.CleanResourceConfigCheckSessionsOnPressuredWorkersStub = nil
+	fake.cleanResourceConfigCheckSessionsOnPressuredWorkersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceConfigCheckSessionLifecycle) CleanResourceConfigCheckSessionsOnPressuredWorkersReturnsOnCall(i int, result1 error) {
+	fake.CleanResourceConfigCheckSessionsOnPressuredWorkersStub = nil
+	if fake.cleanResourceConfigCheckSessionsOnPressuredWorkersReturnsOnCall == nil {
+		fake.cleanResourceConfigCheckSessionsOnPressuredWorkersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cleanResourceConfigCheckSessionsOnPressuredWorkersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResourceConfigCheckSessionLifecycle) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -117,6 +166,8 @@ func (fake *FakeResourceConfigCheckSessionLifecycle) Invocations() map[string][]
 	defer fake.cleanInactiveResourceConfigCheckSessionsMutex.RUnlock()
 	fake.cleanExpiredResourceConfigCheckSessionsMutex.RLock()
 	defer fake.cleanExpiredResourceConfigCheckSessionsMutex.RUnlock()
+	fake.cleanResourceConfigCheckSessionsOnPressuredWorkersMutex.RLock()
+	defer fake.cleanResourceConfigCheckSessionsOnPressuredWorkersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
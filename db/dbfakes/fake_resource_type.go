@@ -114,6 +114,15 @@ type FakeResourceType struct {
 		result1 bool
 		result2 error
 	}
+	DigestStub        func() string
+	digestMutex       sync.RWMutex
+	digestArgsForCall []struct{}
+	digestReturns     struct {
+		result1 string
+	}
+	digestReturnsOnCall map[int]struct {
+		result1 string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -577,6 +586,46 @@ func (fake *FakeResourceType) ReloadReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeResourceType) Digest() string {
+	fake.digestMutex.Lock()
+	ret, specificReturn := fake.digestReturnsOnCall[len(fake.digestArgsForCall)]
+	fake.digestArgsForCall = append(fake.digestArgsForCall, struct{}{})
+	fake.recordInvocation("Digest", []interface{}{})
+	fake.digestMutex.Unlock()
+	if fake.DigestStub != nil {
+		return fake.DigestStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.digestReturns.result1
+}
+
+func (fake *FakeResourceType) DigestCallCount() int {
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
+	return len(fake.digestArgsForCall)
+}
+
+func (fake *FakeResourceType) DigestReturns(result1 string) {
+	fake.DigestStub = nil
+	fake.digestReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResourceType) DigestReturnsOnCall(i int, result1 string) {
+	fake.DigestStub = nil
+	if fake.digestReturnsOnCall == nil {
+		fake.digestReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.digestReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -602,6 +651,8 @@ func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	defer fake.saveVersionMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
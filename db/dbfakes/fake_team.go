@@ -135,21 +135,26 @@ type FakeTeam struct {
 		result1 []db.Pipeline
 		result2 error
 	}
-	OrderPipelinesStub        func([]string) error
+	OrderPipelinesStub        func([]string, db.ConfigVersion) (db.ConfigVersion, error)
 	orderPipelinesMutex       sync.RWMutex
 	orderPipelinesArgsForCall []struct {
-		arg1 []string
+		pipelineNames []string
+		from          db.ConfigVersion
 	}
 	orderPipelinesReturns struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
 	orderPipelinesReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.ConfigVersion
+		result2 error
 	}
-	CreateOneOffBuildStub        func() (db.Build, error)
+	CreateOneOffBuildStub        func(db.BuildTriggerCause) (db.Build, error)
 	createOneOffBuildMutex       sync.RWMutex
-	createOneOffBuildArgsForCall []struct{}
-	createOneOffBuildReturns     struct {
+	createOneOffBuildArgsForCall []struct {
+		arg1 db.BuildTriggerCause
+	}
+	createOneOffBuildReturns struct {
 		result1 db.Build
 		result2 error
 	}
@@ -343,6 +348,98 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SavePipelineAsStub        func(string, string, atc.Config, db.ConfigVersion, db.PipelinePausedState) (db.Pipeline, bool, error)
+	savePipelineAsMutex       sync.RWMutex
+	savePipelineAsArgsForCall []struct {
+		author       string
+		pipelineName string
+		config       atc.Config
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+	}
+	savePipelineAsReturns struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	savePipelineAsReturnsOnCall map[int]struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	ArchivedPipelinesStub        func() ([]db.Pipeline, error)
+	archivedPipelinesMutex       sync.RWMutex
+	archivedPipelinesArgsForCall []struct{}
+	archivedPipelinesReturns     struct {
+		result1 []db.Pipeline
+		result2 error
+	}
+	archivedPipelinesReturnsOnCall map[int]struct {
+		result1 []db.Pipeline
+		result2 error
+	}
+	ImportPipelineSnapshotStub        func(string, atc.PipelineSnapshot) (db.Pipeline, error)
+	importPipelineSnapshotMutex       sync.RWMutex
+	importPipelineSnapshotArgsForCall []struct {
+		pipelineName string
+		snapshot     atc.PipelineSnapshot
+	}
+	importPipelineSnapshotReturns struct {
+		result1 db.Pipeline
+		result2 error
+	}
+	importPipelineSnapshotReturnsOnCall map[int]struct {
+		result1 db.Pipeline
+		result2 error
+	}
+	RekeyStub        func() error
+	rekeyMutex       sync.RWMutex
+	rekeyArgsForCall []struct{}
+	rekeyReturns     struct {
+		result1 error
+	}
+	rekeyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ConfigVersionStub        func() (db.ConfigVersion, error)
+	configVersionMutex       sync.RWMutex
+	configVersionArgsForCall []struct{}
+	configVersionReturns     struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	configVersionReturnsOnCall map[int]struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	PauseJobsStub        func([]int, db.ConfigVersion) (db.ConfigVersion, error)
+	pauseJobsMutex       sync.RWMutex
+	pauseJobsArgsForCall []struct {
+		jobIDs []int
+		from   db.ConfigVersion
+	}
+	pauseJobsReturns struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	pauseJobsReturnsOnCall map[int]struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	UnpauseJobsStub        func([]int, db.ConfigVersion) (db.ConfigVersion, error)
+	unpauseJobsMutex       sync.RWMutex
+	unpauseJobsArgsForCall []struct {
+		jobIDs []int
+		from   db.ConfigVersion
+	}
+	unpauseJobsReturns struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	unpauseJobsReturnsOnCall map[int]struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -835,26 +932,27 @@ func (fake *FakeTeam) VisiblePipelinesReturnsOnCall(i int, result1 []db.Pipeline
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) OrderPipelines(arg1 []string) error {
-	var arg1Copy []string
-	if arg1 != nil {
-		arg1Copy = make([]string, len(arg1))
-		copy(arg1Copy, arg1)
+func (fake *FakeTeam) OrderPipelines(pipelineNames []string, from db.ConfigVersion) (db.ConfigVersion, error) {
+	var pipelineNamesCopy []string
+	if pipelineNames != nil {
+		pipelineNamesCopy = make([]string, len(pipelineNames))
+		copy(pipelineNamesCopy, pipelineNames)
 	}
 	fake.orderPipelinesMutex.Lock()
 	ret, specificReturn := fake.orderPipelinesReturnsOnCall[len(fake.orderPipelinesArgsForCall)]
 	fake.orderPipelinesArgsForCall = append(fake.orderPipelinesArgsForCall, struct {
-		arg1 []string
-	}{arg1Copy})
-	fake.recordInvocation("OrderPipelines", []interface{}{arg1Copy})
+		pipelineNames []string
+		from          db.ConfigVersion
+	}{pipelineNamesCopy, from})
+	fake.recordInvocation("OrderPipelines", []interface{}{pipelineNamesCopy, from})
 	fake.orderPipelinesMutex.Unlock()
 	if fake.OrderPipelinesStub != nil {
-		return fake.OrderPipelinesStub(arg1)
+		return fake.OrderPipelinesStub(pipelineNames, from)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.orderPipelinesReturns.result1
+	return fake.orderPipelinesReturns.result1, fake.orderPipelinesReturns.result2
 }
 
 func (fake *FakeTeam) OrderPipelinesCallCount() int {
@@ -863,39 +961,44 @@ func (fake *FakeTeam) OrderPipelinesCallCount() int {
 	return len(fake.orderPipelinesArgsForCall)
 }
 
-func (fake *FakeTeam) OrderPipelinesArgsForCall(i int) []string {
+func (fake *FakeTeam) OrderPipelinesArgsForCall(i int) ([]string, db.ConfigVersion) {
 	fake.orderPipelinesMutex.RLock()
 	defer fake.orderPipelinesMutex.RUnlock()
-	return fake.orderPipelinesArgsForCall[i].arg1
+	return fake.orderPipelinesArgsForCall[i].pipelineNames, fake.orderPipelinesArgsForCall[i].from
 }
 
-func (fake *FakeTeam) OrderPipelinesReturns(result1 error) {
+func (fake *FakeTeam) OrderPipelinesReturns(result1 db.ConfigVersion, result2 error) {
 	fake.OrderPipelinesStub = nil
 	fake.orderPipelinesReturns = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTeam) OrderPipelinesReturnsOnCall(i int, result1 error) {
+func (fake *FakeTeam) OrderPipelinesReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
 	fake.OrderPipelinesStub = nil
 	if fake.orderPipelinesReturnsOnCall == nil {
 		fake.orderPipelinesReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.ConfigVersion
+			result2 error
 		})
 	}
 	fake.orderPipelinesReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTeam) CreateOneOffBuild() (db.Build, error) {
+func (fake *FakeTeam) CreateOneOffBuild(arg1 db.BuildTriggerCause) (db.Build, error) {
 	fake.createOneOffBuildMutex.Lock()
 	ret, specificReturn := fake.createOneOffBuildReturnsOnCall[len(fake.createOneOffBuildArgsForCall)]
-	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct{}{})
-	fake.recordInvocation("CreateOneOffBuild", []interface{}{})
+	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct {
+		arg1 db.BuildTriggerCause
+	}{arg1})
+	fake.recordInvocation("CreateOneOffBuild", []interface{}{arg1})
 	fake.createOneOffBuildMutex.Unlock()
 	if fake.CreateOneOffBuildStub != nil {
-		return fake.CreateOneOffBuildStub()
+		return fake.CreateOneOffBuildStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -909,6 +1012,12 @@ func (fake *FakeTeam) CreateOneOffBuildCallCount() int {
 	return len(fake.createOneOffBuildArgsForCall)
 }
 
+func (fake *FakeTeam) CreateOneOffBuildArgsForCall(i int) db.BuildTriggerCause {
+	fake.createOneOffBuildMutex.RLock()
+	defer fake.createOneOffBuildMutex.RUnlock()
+	return fake.createOneOffBuildArgsForCall[i].arg1
+}
+
 func (fake *FakeTeam) CreateOneOffBuildReturns(result1 db.Build, result2 error) {
 	fake.CreateOneOffBuildStub = nil
 	fake.createOneOffBuildReturns = struct {
@@ -1611,6 +1720,356 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) SavePipelineAs(author string, pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.Pipeline, bool, error) {
+	fake.savePipelineAsMutex.Lock()
+	ret, specificReturn := fake.savePipelineAsReturnsOnCall[len(fake.savePipelineAsArgsForCall)]
+	fake.savePipelineAsArgsForCall = append(fake.savePipelineAsArgsForCall, struct {
+		author       string
+		pipelineName string
+		config       atc.Config
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+	}{author, pipelineName, config, from, pausedState})
+	fake.recordInvocation("SavePipelineAs", []interface{}{author, pipelineName, config, from, pausedState})
+	fake.savePipelineAsMutex.Unlock()
+	if fake.SavePipelineAsStub != nil {
+		return fake.SavePipelineAsStub(author, pipelineName, config, from, pausedState)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.savePipelineAsReturns.result1, fake.savePipelineAsReturns.result2, fake.savePipelineAsReturns.result3
+}
+
+func (fake *FakeTeam) SavePipelineAsCallCount() int {
+	fake.savePipelineAsMutex.RLock()
+	defer fake.savePipelineAsMutex.RUnlock()
+	return len(fake.savePipelineAsArgsForCall)
+}
+
+func (fake *FakeTeam) SavePipelineAsArgsForCall(i int) (string, string, atc.Config, db.ConfigVersion, db.PipelinePausedState) {
+	fake.savePipelineAsMutex.RLock()
+	defer fake.savePipelineAsMutex.RUnlock()
+	return fake.savePipelineAsArgsForCall[i].author, fake.savePipelineAsArgsForCall[i].pipelineName, fake.savePipelineAsArgsForCall[i].config, fake.savePipelineAsArgsForCall[i].from, fake.savePipelineAsArgsForCall[i].pausedState
+}
+
+func (fake *FakeTeam) SavePipelineAsReturns(result1 db.Pipeline, result2 bool, result3 error) {
+	fake.SavePipelineAsStub = nil
+	fake.savePipelineAsReturns = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SavePipelineAsReturnsOnCall(i int, result1 db.Pipeline, result2 bool, result3 error) {
+	fake.SavePipelineAsStub = nil
+	if fake.savePipelineAsReturnsOnCall == nil {
+		fake.savePipelineAsReturnsOnCall = make(map[int]struct {
+			result1 db.Pipeline
+			result2 bool
+			result3 error
+		})
+	}
+	fake.savePipelineAsReturnsOnCall[i] = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ArchivedPipelines() ([]db.Pipeline, error) {
+	fake.archivedPipelinesMutex.Lock()
+	ret, specificReturn := fake.archivedPipelinesReturnsOnCall[len(fake.archivedPipelinesArgsForCall)]
+	fake.archivedPipelinesArgsForCall = append(fake.archivedPipelinesArgsForCall, struct{}{})
+	fake.recordInvocation("ArchivedPipelines", []interface{}{})
+	fake.archivedPipelinesMutex.Unlock()
+	if fake.ArchivedPipelinesStub != nil {
+		return fake.ArchivedPipelinesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.archivedPipelinesReturns.result1, fake.archivedPipelinesReturns.result2
+}
+
+func (fake *FakeTeam) ArchivedPipelinesCallCount() int {
+	fake.archivedPipelinesMutex.RLock()
+	defer fake.archivedPipelinesMutex.RUnlock()
+	return len(fake.archivedPipelinesArgsForCall)
+}
+
+func (fake *FakeTeam) ArchivedPipelinesReturns(result1 []db.Pipeline, result2 error) {
+	fake.ArchivedPipelinesStub = nil
+	fake.archivedPipelinesReturns = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ArchivedPipelinesReturnsOnCall(i int, result1 []db.Pipeline, result2 error) {
+	fake.ArchivedPipelinesStub = nil
+	if fake.archivedPipelinesReturnsOnCall == nil {
+		fake.archivedPipelinesReturnsOnCall = make(map[int]struct {
+			result1 []db.Pipeline
+			result2 error
+		})
+	}
+	fake.archivedPipelinesReturnsOnCall[i] = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ImportPipelineSnapshot(pipelineName string, snapshot atc.PipelineSnapshot) (db.Pipeline, error) {
+	fake.importPipelineSnapshotMutex.Lock()
+	ret, specificReturn := fake.importPipelineSnapshotReturnsOnCall[len(fake.importPipelineSnapshotArgsForCall)]
+	fake.importPipelineSnapshotArgsForCall = append(fake.importPipelineSnapshotArgsForCall, struct {
+		pipelineName string
+		snapshot     atc.PipelineSnapshot
+	}{pipelineName, snapshot})
+	fake.recordInvocation("ImportPipelineSnapshot", []interface{}{pipelineName, snapshot})
+	fake.importPipelineSnapshotMutex.Unlock()
+	if fake.ImportPipelineSnapshotStub != nil {
+		return fake.ImportPipelineSnapshotStub(pipelineName, snapshot)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.importPipelineSnapshotReturns.result1, fake.importPipelineSnapshotReturns.result2
+}
+
+func (fake *FakeTeam) ImportPipelineSnapshotCallCount() int {
+	fake.importPipelineSnapshotMutex.RLock()
+	defer fake.importPipelineSnapshotMutex.RUnlock()
+	return len(fake.importPipelineSnapshotArgsForCall)
+}
+
+func (fake *FakeTeam) ImportPipelineSnapshotArgsForCall(i int) (string, atc.PipelineSnapshot) {
+	fake.importPipelineSnapshotMutex.RLock()
+	defer fake.importPipelineSnapshotMutex.RUnlock()
+	return fake.importPipelineSnapshotArgsForCall[i].pipelineName, fake.importPipelineSnapshotArgsForCall[i].snapshot
+}
+
+func (fake *FakeTeam) ImportPipelineSnapshotReturns(result1 db.Pipeline, result2 error) {
+	fake.ImportPipelineSnapshotStub = nil
+	fake.importPipelineSnapshotReturns = struct {
+		result1 db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ImportPipelineSnapshotReturnsOnCall(i int, result1 db.Pipeline, result2 error) {
+	fake.ImportPipelineSnapshotStub = nil
+	if fake.importPipelineSnapshotReturnsOnCall == nil {
+		fake.importPipelineSnapshotReturnsOnCall = make(map[int]struct {
+			result1 db.Pipeline
+			result2 error
+		})
+	}
+	fake.importPipelineSnapshotReturnsOnCall[i] = struct {
+		result1 db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) Rekey() error {
+	fake.rekeyMutex.Lock()
+	ret, specificReturn := fake.rekeyReturnsOnCall[len(fake.rekeyArgsForCall)]
+	fake.rekeyArgsForCall = append(fake.rekeyArgsForCall, struct{}{})
+	fake.recordInvocation("Rekey", []interface{}{})
+	fake.rekeyMutex.Unlock()
+	if fake.RekeyStub != nil {
+		return fake.RekeyStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.rekeyReturns.result1
+}
+
+func (fake *FakeTeam) RekeyCallCount() int {
+	fake.rekeyMutex.RLock()
+	defer fake.rekeyMutex.RUnlock()
+	return len(fake.rekeyArgsForCall)
+}
+
+func (fake *FakeTeam) RekeyReturns(result1 error) {
+	fake.RekeyStub = nil
+	fake.rekeyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) RekeyReturnsOnCall(i int, result1 error) {
+	fake.RekeyStub = nil
+	if fake.rekeyReturnsOnCall == nil {
+		fake.rekeyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rekeyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) ConfigVersion() (db.ConfigVersion, error) {
+	fake.configVersionMutex.Lock()
+	ret, specificReturn := fake.configVersionReturnsOnCall[len(fake.configVersionArgsForCall)]
+	fake.configVersionArgsForCall = append(fake.configVersionArgsForCall, struct{}{})
+	fake.recordInvocation("ConfigVersion", []interface{}{})
+	fake.configVersionMutex.Unlock()
+	if fake.ConfigVersionStub != nil {
+		return fake.ConfigVersionStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.configVersionReturns.result1, fake.configVersionReturns.result2
+}
+
+func (fake *FakeTeam) ConfigVersionCallCount() int {
+	fake.configVersionMutex.RLock()
+	defer fake.configVersionMutex.RUnlock()
+	return len(fake.configVersionArgsForCall)
+}
+
+func (fake *FakeTeam) ConfigVersionReturns(result1 db.ConfigVersion, result2 error) {
+	fake.ConfigVersionStub = nil
+	fake.configVersionReturns = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ConfigVersionReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
+	fake.ConfigVersionStub = nil
+	if fake.configVersionReturnsOnCall == nil {
+		fake.configVersionReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigVersion
+			result2 error
+		})
+	}
+	fake.configVersionReturnsOnCall[i] = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PauseJobs(jobIDs []int, from db.ConfigVersion) (db.ConfigVersion, error) {
+	var jobIDsCopy []int
+	if jobIDs != nil {
+		jobIDsCopy = make([]int, len(jobIDs))
+		copy(jobIDsCopy, jobIDs)
+	}
+	fake.pauseJobsMutex.Lock()
+	ret, specificReturn := fake.pauseJobsReturnsOnCall[len(fake.pauseJobsArgsForCall)]
+	fake.pauseJobsArgsForCall = append(fake.pauseJobsArgsForCall, struct {
+		jobIDs []int
+		from   db.ConfigVersion
+	}{jobIDsCopy, from})
+	fake.recordInvocation("PauseJobs", []interface{}{jobIDsCopy, from})
+	fake.pauseJobsMutex.Unlock()
+	if fake.PauseJobsStub != nil {
+		return fake.PauseJobsStub(jobIDs, from)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.pauseJobsReturns.result1, fake.pauseJobsReturns.result2
+}
+
+func (fake *FakeTeam) PauseJobsCallCount() int {
+	fake.pauseJobsMutex.RLock()
+	defer fake.pauseJobsMutex.RUnlock()
+	return len(fake.pauseJobsArgsForCall)
+}
+
+func (fake *FakeTeam) PauseJobsArgsForCall(i int) ([]int, db.ConfigVersion) {
+	fake.pauseJobsMutex.RLock()
+	defer fake.pauseJobsMutex.RUnlock()
+	return fake.pauseJobsArgsForCall[i].jobIDs, fake.pauseJobsArgsForCall[i].from
+}
+
+func (fake *FakeTeam) PauseJobsReturns(result1 db.ConfigVersion, result2 error) {
+	fake.PauseJobsStub = nil
+	fake.pauseJobsReturns = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PauseJobsReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
+	fake.PauseJobsStub = nil
+	if fake.pauseJobsReturnsOnCall == nil {
+		fake.pauseJobsReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigVersion
+			result2 error
+		})
+	}
+	fake.pauseJobsReturnsOnCall[i] = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UnpauseJobs(jobIDs []int, from db.ConfigVersion) (db.ConfigVersion, error) {
+	var jobIDsCopy []int
+	if jobIDs != nil {
+		jobIDsCopy = make([]int, len(jobIDs))
+		copy(jobIDsCopy, jobIDs)
+	}
+	fake.unpauseJobsMutex.Lock()
+	ret, specificReturn := fake.unpauseJobsReturnsOnCall[len(fake.unpauseJobsArgsForCall)]
+	fake.unpauseJobsArgsForCall = append(fake.unpauseJobsArgsForCall, struct {
+		jobIDs []int
+		from   db.ConfigVersion
+	}{jobIDsCopy, from})
+	fake.recordInvocation("UnpauseJobs", []interface{}{jobIDsCopy, from})
+	fake.unpauseJobsMutex.Unlock()
+	if fake.UnpauseJobsStub != nil {
+		return fake.UnpauseJobsStub(jobIDs, from)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.unpauseJobsReturns.result1, fake.unpauseJobsReturns.result2
+}
+
+func (fake *FakeTeam) UnpauseJobsCallCount() int {
+	fake.unpauseJobsMutex.RLock()
+	defer fake.unpauseJobsMutex.RUnlock()
+	return len(fake.unpauseJobsArgsForCall)
+}
+
+func (fake *FakeTeam) UnpauseJobsArgsForCall(i int) ([]int, db.ConfigVersion) {
+	fake.unpauseJobsMutex.RLock()
+	defer fake.unpauseJobsMutex.RUnlock()
+	return fake.unpauseJobsArgsForCall[i].jobIDs, fake.unpauseJobsArgsForCall[i].from
+}
+
+func (fake *FakeTeam) UnpauseJobsReturns(result1 db.ConfigVersion, result2 error) {
+	fake.UnpauseJobsStub = nil
+	fake.unpauseJobsReturns = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UnpauseJobsReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
+	fake.UnpauseJobsStub = nil
+	if fake.unpauseJobsReturnsOnCall == nil {
+		fake.unpauseJobsReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigVersion
+			result2 error
+		})
+	}
+	fake.unpauseJobsReturnsOnCall[i] = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1666,6 +2125,20 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createContainerMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.savePipelineAsMutex.RLock()
+	defer fake.savePipelineAsMutex.RUnlock()
+	fake.archivedPipelinesMutex.RLock()
+	defer fake.archivedPipelinesMutex.RUnlock()
+	fake.importPipelineSnapshotMutex.RLock()
+	defer fake.importPipelineSnapshotMutex.RUnlock()
+	fake.rekeyMutex.RLock()
+	defer fake.rekeyMutex.RUnlock()
+	fake.configVersionMutex.RLock()
+	defer fake.configVersionMutex.RUnlock()
+	fake.pauseJobsMutex.RLock()
+	defer fake.pauseJobsMutex.RUnlock()
+	fake.unpauseJobsMutex.RLock()
+	defer fake.unpauseJobsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -85,11 +85,12 @@ type FakeVolumeFactory struct {
 		result1 db.CreatingVolume
 		result2 error
 	}
-	FindResourceCacheVolumeStub        func(string, *db.UsedResourceCache) (db.CreatedVolume, bool, error)
+	FindResourceCacheVolumeStub        func(string, int, *db.UsedResourceCache) (db.CreatedVolume, bool, error)
 	findResourceCacheVolumeMutex       sync.RWMutex
 	findResourceCacheVolumeArgsForCall []struct {
 		arg1 string
-		arg2 *db.UsedResourceCache
+		arg2 int
+		arg3 *db.UsedResourceCache
 	}
 	findResourceCacheVolumeReturns struct {
 		result1 db.CreatedVolume
@@ -213,6 +214,57 @@ type FakeVolumeFactory struct {
 		result2 bool
 		result3 error
 	}
+	GetReclaimableVolumesStub        func() ([]db.ReclaimableVolume, error)
+	getReclaimableVolumesMutex       sync.RWMutex
+	getReclaimableVolumesArgsForCall []struct{}
+	getReclaimableVolumesReturns     struct {
+		result1 []db.ReclaimableVolume
+		result2 error
+	}
+	getReclaimableVolumesReturnsOnCall map[int]struct {
+		result1 []db.ReclaimableVolume
+		result2 error
+	}
+	GetHotResourceCacheVolumesStub        func(int) ([]db.HotResourceCacheVolume, error)
+	getHotResourceCacheVolumesMutex       sync.RWMutex
+	getHotResourceCacheVolumesArgsForCall []struct {
+		arg1 int
+	}
+	getHotResourceCacheVolumesReturns struct {
+		result1 []db.HotResourceCacheVolume
+		result2 error
+	}
+	getHotResourceCacheVolumesReturnsOnCall map[int]struct {
+		result1 []db.HotResourceCacheVolume
+		result2 error
+	}
+	FindResourceCacheReplicaWorkersStub        func(db.HotResourceCacheVolume) ([]string, error)
+	findResourceCacheReplicaWorkersMutex       sync.RWMutex
+	findResourceCacheReplicaWorkersArgsForCall []struct {
+		arg1 db.HotResourceCacheVolume
+	}
+	findResourceCacheReplicaWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	findResourceCacheReplicaWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	CreateResourceCacheReplicaVolumeStub        func(string, db.HotResourceCacheVolume) (db.CreatingVolume, error)
+	createResourceCacheReplicaVolumeMutex       sync.RWMutex
+	createResourceCacheReplicaVolumeArgsForCall []struct {
+		arg1 string
+		arg2 db.HotResourceCacheVolume
+	}
+	createResourceCacheReplicaVolumeReturns struct {
+		result1 db.CreatingVolume
+		result2 error
+	}
+	createResourceCacheReplicaVolumeReturnsOnCall map[int]struct {
+		result1 db.CreatingVolume
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -486,17 +538,18 @@ func (fake *FakeVolumeFactory) CreateBaseResourceTypeVolumeReturnsOnCall(i int,
 	}{result1, result2}
 }
 
-func (fake *FakeVolumeFactory) FindResourceCacheVolume(arg1 string, arg2 *db.UsedResourceCache) (db.CreatedVolume, bool, error) {
+func (fake *FakeVolumeFactory) FindResourceCacheVolume(arg1 string, arg2 int, arg3 *db.UsedResourceCache) (db.CreatedVolume, bool, error) {
 	fake.findResourceCacheVolumeMutex.Lock()
 	ret, specificReturn := fake.findResourceCacheVolumeReturnsOnCall[len(fake.findResourceCacheVolumeArgsForCall)]
 	fake.findResourceCacheVolumeArgsForCall = append(fake.findResourceCacheVolumeArgsForCall, struct {
 		arg1 string
-		arg2 *db.UsedResourceCache
-	}{arg1, arg2})
-	fake.recordInvocation("FindResourceCacheVolume", []interface{}{arg1, arg2})
+		arg2 int
+		arg3 *db.UsedResourceCache
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("FindResourceCacheVolume", []interface{}{arg1, arg2, arg3})
 	fake.findResourceCacheVolumeMutex.Unlock()
 	if fake.FindResourceCacheVolumeStub != nil {
-		return fake.FindResourceCacheVolumeStub(arg1, arg2)
+		return fake.FindResourceCacheVolumeStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -510,10 +563,10 @@ func (fake *FakeVolumeFactory) FindResourceCacheVolumeCallCount() int {
 	return len(fake.findResourceCacheVolumeArgsForCall)
 }
 
-func (fake *FakeVolumeFactory) FindResourceCacheVolumeArgsForCall(i int) (string, *db.UsedResourceCache) {
+func (fake *FakeVolumeFactory) FindResourceCacheVolumeArgsForCall(i int) (string, int, *db.UsedResourceCache) {
 	fake.findResourceCacheVolumeMutex.RLock()
 	defer fake.findResourceCacheVolumeMutex.RUnlock()
-	return fake.findResourceCacheVolumeArgsForCall[i].arg1, fake.findResourceCacheVolumeArgsForCall[i].arg2
+	return fake.findResourceCacheVolumeArgsForCall[i].arg1, fake.findResourceCacheVolumeArgsForCall[i].arg2, fake.findResourceCacheVolumeArgsForCall[i].arg3
 }
 
 func (fake *FakeVolumeFactory) FindResourceCacheVolumeReturns(result1 db.CreatedVolume, result2 bool, result3 error) {
@@ -949,6 +1002,203 @@ func (fake *FakeVolumeFactory) FindCreatedVolumeReturnsOnCall(i int, result1 db.
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeFactory) GetReclaimableVolumes() ([]db.ReclaimableVolume, error) {
+	fake.getReclaimableVolumesMutex.Lock()
+	ret, specificReturn := fake.getReclaimableVolumesReturnsOnCall[len(fake.getReclaimableVolumesArgsForCall)]
+	fake.getReclaimableVolumesArgsForCall = append(fake.getReclaimableVolumesArgsForCall, struct{}{})
+	fake.recordInvocation("GetReclaimableVolumes", []interface{}{})
+	fake.getReclaimableVolumesMutex.Unlock()
+	if fake.GetReclaimableVolumesStub != nil {
+		return fake.GetReclaimableVolumesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getReclaimableVolumesReturns.result1, fake.getReclaimableVolumesReturns.result2
+}
+
+func (fake *FakeVolumeFactory) GetReclaimableVolumesCallCount() int {
+	fake.getReclaimableVolumesMutex.RLock()
+	defer fake.getReclaimableVolumesMutex.RUnlock()
+	return len(fake.getReclaimableVolumesArgsForCall)
+}
+
+func (fake *FakeVolumeFactory) GetReclaimableVolumesReturns(result1 []db.ReclaimableVolume, result2 error) {
+	fake.GetReclaimableVolumesStub = nil
+	fake.getReclaimableVolumesReturns = struct {
+		result1 []db.ReclaimableVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeFactory) GetReclaimableVolumesReturnsOnCall(i int, result1 []db.ReclaimableVolume, result2 error) {
+	fake.GetReclaimableVolumesStub = nil
+	if fake.getReclaimableVolumesReturnsOnCall == nil {
+		fake.getReclaimableVolumesReturnsOnCall = make(map[int]struct {
+			result1 []db.ReclaimableVolume
+			result2 error
+		})
+	}
+	fake.getReclaimableVolumesReturnsOnCall[i] = struct {
+		result1 []db.ReclaimableVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeFactory) GetHotResourceCacheVolumes(arg1 int) ([]db.HotResourceCacheVolume, error) {
+	fake.getHotResourceCacheVolumesMutex.Lock()
+	ret, specificReturn := fake.getHotResourceCacheVolumesReturnsOnCall[len(fake.getHotResourceCacheVolumesArgsForCall)]
+	fake.getHotResourceCacheVolumesArgsForCall = append(fake.getHotResourceCacheVolumesArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("GetHotResourceCacheVolumes", []interface{}{arg1})
+	fake.getHotResourceCacheVolumesMutex.Unlock()
+	if fake.GetHotResourceCacheVolumesStub != nil {
+		return fake.GetHotResourceCacheVolumesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getHotResourceCacheVolumesReturns.result1, fake.getHotResourceCacheVolumesReturns.result2
+}
+
+func (fake *FakeVolumeFactory) GetHotResourceCacheVolumesCallCount() int {
+	fake.getHotResourceCacheVolumesMutex.RLock()
+	defer fake.getHotResourceCacheVolumesMutex.RUnlock()
+	return len(fake.getHotResourceCacheVolumesArgsForCall)
+}
+
+func (fake *FakeVolumeFactory) GetHotResourceCacheVolumesArgsForCall(i int) int {
+	fake.getHotResourceCacheVolumesMutex.RLock()
+	defer fake.getHotResourceCacheVolumesMutex.RUnlock()
+	return fake.getHotResourceCacheVolumesArgsForCall[i].arg1
+}
+
+func (fake *FakeVolumeFactory) GetHotResourceCacheVolumesReturns(result1 []db.HotResourceCacheVolume, result2 error) {
+	fake.GetHotResourceCacheVolumesStub = nil
+	fake.getHotResourceCacheVolumesReturns = struct {
+		result1 []db.HotResourceCacheVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeFactory) GetHotResourceCacheVolumesReturnsOnCall(i int, result1 []db.HotResourceCacheVolume, result2 error) {
+	fake.GetHotResourceCacheVolumesStub = nil
+	if fake.getHotResourceCacheVolumesReturnsOnCall == nil {
+		fake.getHotResourceCacheVolumesReturnsOnCall = make(map[int]struct {
+			result1 []db.HotResourceCacheVolume
+			result2 error
+		})
+	}
+	fake.getHotResourceCacheVolumesReturnsOnCall[i] = struct {
+		result1 []db.HotResourceCacheVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeFactory) FindResourceCacheReplicaWorkers(arg1 db.HotResourceCacheVolume) ([]string, error) {
+	fake.findResourceCacheReplicaWorkersMutex.Lock()
+	ret, specificReturn := fake.findResourceCacheReplicaWorkersReturnsOnCall[len(fake.findResourceCacheReplicaWorkersArgsForCall)]
+	fake.findResourceCacheReplicaWorkersArgsForCall = append(fake.findResourceCacheReplicaWorkersArgsForCall, struct {
+		arg1 db.HotResourceCacheVolume
+	}{arg1})
+	fake.recordInvocation("FindResourceCacheReplicaWorkers", []interface{}{arg1})
+	fake.findResourceCacheReplicaWorkersMutex.Unlock()
+	if fake.FindResourceCacheReplicaWorkersStub != nil {
+		return fake.FindResourceCacheReplicaWorkersStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findResourceCacheReplicaWorkersReturns.result1, fake.findResourceCacheReplicaWorkersReturns.result2
+}
+
+func (fake *FakeVolumeFactory) FindResourceCacheReplicaWorkersCallCount() int {
+	fake.findResourceCacheReplicaWorkersMutex.RLock()
+	defer fake.findResourceCacheReplicaWorkersMutex.RUnlock()
+	return len(fake.findResourceCacheReplicaWorkersArgsForCall)
+}
+
+func (fake *FakeVolumeFactory) FindResourceCacheReplicaWorkersArgsForCall(i int) db.HotResourceCacheVolume {
+	fake.findResourceCacheReplicaWorkersMutex.RLock()
+	defer fake.findResourceCacheReplicaWorkersMutex.RUnlock()
+	return fake.findResourceCacheReplicaWorkersArgsForCall[i].arg1
+}
+
+func (fake *FakeVolumeFactory) FindResourceCacheReplicaWorkersReturns(result1 []string, result2 error) {
+	fake.FindResourceCacheReplicaWorkersStub = nil
+	fake.findResourceCacheReplicaWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeFactory) FindResourceCacheReplicaWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.FindResourceCacheReplicaWorkersStub = nil
+	if fake.findResourceCacheReplicaWorkersReturnsOnCall == nil {
+		fake.findResourceCacheReplicaWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.findResourceCacheReplicaWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeFactory) CreateResourceCacheReplicaVolume(arg1 string, arg2 db.HotResourceCacheVolume) (db.CreatingVolume, error) {
+	fake.createResourceCacheReplicaVolumeMutex.Lock()
+	ret, specificReturn := fake.createResourceCacheReplicaVolumeReturnsOnCall[len(fake.createResourceCacheReplicaVolumeArgsForCall)]
+	fake.createResourceCacheReplicaVolumeArgsForCall = append(fake.createResourceCacheReplicaVolumeArgsForCall, struct {
+		arg1 string
+		arg2 db.HotResourceCacheVolume
+	}{arg1, arg2})
+	fake.recordInvocation("CreateResourceCacheReplicaVolume", []interface{}{arg1, arg2})
+	fake.createResourceCacheReplicaVolumeMutex.Unlock()
+	if fake.CreateResourceCacheReplicaVolumeStub != nil {
+		return fake.CreateResourceCacheReplicaVolumeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createResourceCacheReplicaVolumeReturns.result1, fake.createResourceCacheReplicaVolumeReturns.result2
+}
+
+func (fake *FakeVolumeFactory) CreateResourceCacheReplicaVolumeCallCount() int {
+	fake.createResourceCacheReplicaVolumeMutex.RLock()
+	defer fake.createResourceCacheReplicaVolumeMutex.RUnlock()
+	return len(fake.createResourceCacheReplicaVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeFactory) CreateResourceCacheReplicaVolumeArgsForCall(i int) (string, db.HotResourceCacheVolume) {
+	fake.createResourceCacheReplicaVolumeMutex.RLock()
+	defer fake.createResourceCacheReplicaVolumeMutex.RUnlock()
+	return fake.createResourceCacheReplicaVolumeArgsForCall[i].arg1, fake.createResourceCacheReplicaVolumeArgsForCall[i].arg2
+}
+
+func (fake *FakeVolumeFactory) CreateResourceCacheReplicaVolumeReturns(result1 db.CreatingVolume, result2 error) {
+	fake.CreateResourceCacheReplicaVolumeStub = nil
+	fake.createResourceCacheReplicaVolumeReturns = struct {
+		result1 db.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeFactory) CreateResourceCacheReplicaVolumeReturnsOnCall(i int, result1 db.CreatingVolume, result2 error) {
+	fake.CreateResourceCacheReplicaVolumeStub = nil
+	if fake.createResourceCacheReplicaVolumeReturnsOnCall == nil {
+		fake.createResourceCacheReplicaVolumeReturnsOnCall = make(map[int]struct {
+			result1 db.CreatingVolume
+			result2 error
+		})
+	}
+	fake.createResourceCacheReplicaVolumeReturnsOnCall[i] = struct {
+		result1 db.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -980,6 +1230,14 @@ func (fake *FakeVolumeFactory) Invocations() map[string][][]interface{} {
 	defer fake.getFailedVolumesMutex.RUnlock()
 	fake.findCreatedVolumeMutex.RLock()
 	defer fake.findCreatedVolumeMutex.RUnlock()
+	fake.getReclaimableVolumesMutex.RLock()
+	defer fake.getReclaimableVolumesMutex.RUnlock()
+	fake.getHotResourceCacheVolumesMutex.RLock()
+	defer fake.getHotResourceCacheVolumesMutex.RUnlock()
+	fake.findResourceCacheReplicaWorkersMutex.RLock()
+	defer fake.findResourceCacheReplicaWorkersMutex.RUnlock()
+	fake.createResourceCacheReplicaVolumeMutex.RLock()
+	defer fake.createResourceCacheReplicaVolumeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -223,6 +223,185 @@ type FakeWorker struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	BuildContainersStub        func() int
+	buildContainersMutex       sync.RWMutex
+	buildContainersArgsForCall []struct{}
+	buildContainersReturns     struct {
+		result1 int
+	}
+	buildContainersReturnsOnCall map[int]struct {
+		result1 int
+	}
+	ActiveTasksStub        func() int
+	activeTasksMutex       sync.RWMutex
+	activeTasksArgsForCall []struct{}
+	activeTasksReturns     struct {
+		result1 int
+	}
+	activeTasksReturnsOnCall map[int]struct {
+		result1 int
+	}
+	EphemeralStub        func() bool
+	ephemeralMutex       sync.RWMutex
+	ephemeralArgsForCall []struct{}
+	ephemeralReturns     struct {
+		result1 bool
+	}
+	ephemeralReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	ExpiredStub        func() bool
+	expiredMutex       sync.RWMutex
+	expiredArgsForCall []struct{}
+	expiredReturns     struct {
+		result1 bool
+	}
+	expiredReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	MemoryPressureStub        func() bool
+	memoryPressureMutex       sync.RWMutex
+	memoryPressureArgsForCall []struct{}
+	memoryPressureReturns     struct {
+		result1 bool
+	}
+	memoryPressureReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	DiskPressureStub        func() bool
+	diskPressureMutex       sync.RWMutex
+	diskPressureArgsForCall []struct{}
+	diskPressureReturns     struct {
+		result1 bool
+	}
+	diskPressureReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	DiskUsageStub        func() int
+	diskUsageMutex       sync.RWMutex
+	diskUsageArgsForCall []struct{}
+	diskUsageReturns     struct {
+		result1 int
+	}
+	diskUsageReturnsOnCall map[int]struct {
+		result1 int
+	}
+	ContainersStub        func() ([]db.WorkerContainer, error)
+	containersMutex       sync.RWMutex
+	containersArgsForCall []struct{}
+	containersReturns     struct {
+		result1 []db.WorkerContainer
+		result2 error
+	}
+	containersReturnsOnCall map[int]struct {
+		result1 []db.WorkerContainer
+		result2 error
+	}
+	SaveContainerUsagesStub        func([]atc.ContainerUsage) error
+	saveContainerUsagesMutex       sync.RWMutex
+	saveContainerUsagesArgsForCall []struct {
+		arg1 []atc.ContainerUsage
+	}
+	saveContainerUsagesReturns struct {
+		result1 error
+	}
+	saveContainerUsagesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InMaintenanceStub        func() bool
+	inMaintenanceMutex       sync.RWMutex
+	inMaintenanceArgsForCall []struct{}
+	inMaintenanceReturns     struct {
+		result1 bool
+	}
+	inMaintenanceReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	MaintenanceWindowsStub        func() ([]atc.WorkerMaintenanceWindow, error)
+	maintenanceWindowsMutex       sync.RWMutex
+	maintenanceWindowsArgsForCall []struct{}
+	maintenanceWindowsReturns     struct {
+		result1 []atc.WorkerMaintenanceWindow
+		result2 error
+	}
+	maintenanceWindowsReturnsOnCall map[int]struct {
+		result1 []atc.WorkerMaintenanceWindow
+		result2 error
+	}
+	ScheduleMaintenanceStub        func(time.Time, time.Time) (atc.WorkerMaintenanceWindow, error)
+	scheduleMaintenanceMutex       sync.RWMutex
+	scheduleMaintenanceArgsForCall []struct {
+		startsAt time.Time
+		endsAt   time.Time
+	}
+	scheduleMaintenanceReturns struct {
+		result1 atc.WorkerMaintenanceWindow
+		result2 error
+	}
+	scheduleMaintenanceReturnsOnCall map[int]struct {
+		result1 atc.WorkerMaintenanceWindow
+		result2 error
+	}
+	CancelMaintenanceStub        func(int) (bool, error)
+	cancelMaintenanceMutex       sync.RWMutex
+	cancelMaintenanceArgsForCall []struct {
+		id int
+	}
+	cancelMaintenanceReturns struct {
+		result1 bool
+		result2 error
+	}
+	cancelMaintenanceReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	SaveVolumeUsagesStub        func([]atc.VolumeUsage) error
+	saveVolumeUsagesMutex       sync.RWMutex
+	saveVolumeUsagesArgsForCall []struct {
+		arg1 []atc.VolumeUsage
+	}
+	saveVolumeUsagesReturns struct {
+		result1 error
+	}
+	saveVolumeUsagesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReserveTaskSlotStub        func(db.ContainerOwner, int) (bool, error)
+	reserveTaskSlotMutex       sync.RWMutex
+	reserveTaskSlotArgsForCall []struct {
+		owner db.ContainerOwner
+		max   int
+	}
+	reserveTaskSlotReturns struct {
+		result1 bool
+		result2 error
+	}
+	reserveTaskSlotReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ReleaseTaskSlotStub        func(db.ContainerOwner) error
+	releaseTaskSlotMutex       sync.RWMutex
+	releaseTaskSlotArgsForCall []struct {
+		owner db.ContainerOwner
+	}
+	releaseTaskSlotReturns struct {
+		result1 error
+	}
+	releaseTaskSlotReturnsOnCall map[int]struct {
+		result1 error
+	}
+	VolumesStub        func() ([]db.WorkerVolume, error)
+	volumesMutex       sync.RWMutex
+	volumesArgsForCall []struct{}
+	volumesReturns     struct {
+		result1 []db.WorkerVolume
+		result2 error
+	}
+	volumesReturnsOnCall map[int]struct {
+		result1 []db.WorkerVolume
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1156,6 +1335,764 @@ func (fake *FakeWorker) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) BuildContainers() int {
+	fake.buildContainersMutex.Lock()
+	ret, specificReturn := fake.buildContainersReturnsOnCall[len(fake.buildContainersArgsForCall)]
+	fake.buildContainersArgsForCall = append(fake.buildContainersArgsForCall, struct{}{})
+	fake.recordInvocation("BuildContainers", []interface{}{})
+	fake.buildContainersMutex.Unlock()
+	if fake.BuildContainersStub != nil {
+		return fake.BuildContainersStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.buildContainersReturns.result1
+}
+
+func (fake *FakeWorker) BuildContainersCallCount() int {
+	fake.buildContainersMutex.RLock()
+	defer fake.buildContainersMutex.RUnlock()
+	return len(fake.buildContainersArgsForCall)
+}
+
+func (fake *FakeWorker) BuildContainersReturns(result1 int) {
+	fake.BuildContainersStub = nil
+	fake.buildContainersReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) BuildContainersReturnsOnCall(i int, result1 int) {
+	fake.BuildContainersStub = nil
+	if fake.buildContainersReturnsOnCall == nil {
+		fake.buildContainersReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.buildContainersReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ActiveTasks() int {
+	fake.activeTasksMutex.Lock()
+	ret, specificReturn := fake.activeTasksReturnsOnCall[len(fake.activeTasksArgsForCall)]
+	fake.activeTasksArgsForCall = append(fake.activeTasksArgsForCall, struct{}{})
+	fake.recordInvocation("ActiveTasks", []interface{}{})
+	fake.activeTasksMutex.Unlock()
+	if fake.ActiveTasksStub != nil {
+		return fake.ActiveTasksStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.activeTasksReturns.result1
+}
+
+func (fake *FakeWorker) ActiveTasksCallCount() int {
+	fake.activeTasksMutex.RLock()
+	defer fake.activeTasksMutex.RUnlock()
+	return len(fake.activeTasksArgsForCall)
+}
+
+func (fake *FakeWorker) ActiveTasksReturns(result1 int) {
+	fake.ActiveTasksStub = nil
+	fake.activeTasksReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ActiveTasksReturnsOnCall(i int, result1 int) {
+	fake.ActiveTasksStub = nil
+	if fake.activeTasksReturnsOnCall == nil {
+		fake.activeTasksReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.activeTasksReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) Ephemeral() bool {
+	fake.ephemeralMutex.Lock()
+	ret, specificReturn := fake.ephemeralReturnsOnCall[len(fake.ephemeralArgsForCall)]
+	fake.ephemeralArgsForCall = append(fake.ephemeralArgsForCall, struct{}{})
+	fake.recordInvocation("Ephemeral", []interface{}{})
+	fake.ephemeralMutex.Unlock()
+	if fake.EphemeralStub != nil {
+		return fake.EphemeralStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.ephemeralReturns.result1
+}
+
+func (fake *FakeWorker) EphemeralCallCount() int {
+	fake.ephemeralMutex.RLock()
+	defer fake.ephemeralMutex.RUnlock()
+	return len(fake.ephemeralArgsForCall)
+}
+
+func (fake *FakeWorker) EphemeralReturns(result1 bool) {
+	fake.EphemeralStub = nil
+	fake.ephemeralReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) EphemeralReturnsOnCall(i int, result1 bool) {
+	fake.EphemeralStub = nil
+	if fake.ephemeralReturnsOnCall == nil {
+		fake.ephemeralReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.ephemeralReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) Expired() bool {
+	fake.expiredMutex.Lock()
+	ret, specificReturn := fake.expiredReturnsOnCall[len(fake.expiredArgsForCall)]
+	fake.expiredArgsForCall = append(fake.expiredArgsForCall, struct{}{})
+	fake.recordInvocation("Expired", []interface{}{})
+	fake.expiredMutex.Unlock()
+	if fake.ExpiredStub != nil {
+		return fake.ExpiredStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.expiredReturns.result1
+}
+
+func (fake *FakeWorker) ExpiredCallCount() int {
+	fake.expiredMutex.RLock()
+	defer fake.expiredMutex.RUnlock()
+	return len(fake.expiredArgsForCall)
+}
+
+func (fake *FakeWorker) ExpiredReturns(result1 bool) {
+	fake.ExpiredStub = nil
+	fake.expiredReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) ExpiredReturnsOnCall(i int, result1 bool) {
+	fake.ExpiredStub = nil
+	if fake.expiredReturnsOnCall == nil {
+		fake.expiredReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.expiredReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) MemoryPressure() bool {
+	fake.memoryPressureMutex.Lock()
+	ret, specificReturn := fake.memoryPressureReturnsOnCall[len(fake.memoryPressureArgsForCall)]
+	fake.memoryPressureArgsForCall = append(fake.memoryPressureArgsForCall, struct{}{})
+	fake.recordInvocation("MemoryPressure", []interface{}{})
+	fake.memoryPressureMutex.Unlock()
+	if fake.MemoryPressureStub != nil {
+		return fake.MemoryPressureStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.memoryPressureReturns.result1
+}
+
+func (fake *FakeWorker) MemoryPressureCallCount() int {
+	fake.memoryPressureMutex.RLock()
+	defer fake.memoryPressureMutex.RUnlock()
+	return len(fake.memoryPressureArgsForCall)
+}
+
+func (fake *FakeWorker) MemoryPressureReturns(result1 bool) {
+	fake.MemoryPressureStub = nil
+	fake.memoryPressureReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) MemoryPressureReturnsOnCall(i int, result1 bool) {
+	fake.MemoryPressureStub = nil
+	if fake.memoryPressureReturnsOnCall == nil {
+		fake.memoryPressureReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.memoryPressureReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) DiskPressure() bool {
+	fake.diskPressureMutex.Lock()
+	ret, specificReturn := fake.diskPressureReturnsOnCall[len(fake.diskPressureArgsForCall)]
+	fake.diskPressureArgsForCall = append(fake.diskPressureArgsForCall, struct{}{})
+	fake.recordInvocation("DiskPressure", []interface{}{})
+	fake.diskPressureMutex.Unlock()
+	if fake.DiskPressureStub != nil {
+		return fake.DiskPressureStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.diskPressureReturns.result1
+}
+
+func (fake *FakeWorker) DiskPressureCallCount() int {
+	fake.diskPressureMutex.RLock()
+	defer fake.diskPressureMutex.RUnlock()
+	return len(fake.diskPressureArgsForCall)
+}
+
+func (fake *FakeWorker) DiskPressureReturns(result1 bool) {
+	fake.DiskPressureStub = nil
+	fake.diskPressureReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) DiskPressureReturnsOnCall(i int, result1 bool) {
+	fake.DiskPressureStub = nil
+	if fake.diskPressureReturnsOnCall == nil {
+		fake.diskPressureReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.diskPressureReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) DiskUsage() int {
+	fake.diskUsageMutex.Lock()
+	ret, specificReturn := fake.diskUsageReturnsOnCall[len(fake.diskUsageArgsForCall)]
+	fake.diskUsageArgsForCall = append(fake.diskUsageArgsForCall, struct{}{})
+	fake.recordInvocation("DiskUsage", []interface{}{})
+	fake.diskUsageMutex.Unlock()
+	if fake.DiskUsageStub != nil {
+		return fake.DiskUsageStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.diskUsageReturns.result1
+}
+
+func (fake *FakeWorker) DiskUsageCallCount() int {
+	fake.diskUsageMutex.RLock()
+	defer fake.diskUsageMutex.RUnlock()
+	return len(fake.diskUsageArgsForCall)
+}
+
+func (fake *FakeWorker) DiskUsageReturns(result1 int) {
+	fake.DiskUsageStub = nil
+	fake.diskUsageReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) DiskUsageReturnsOnCall(i int, result1 int) {
+	fake.DiskUsageStub = nil
+	if fake.diskUsageReturnsOnCall == nil {
+		fake.diskUsageReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.diskUsageReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) Containers() ([]db.WorkerContainer, error) {
+	fake.containersMutex.Lock()
+	ret, specificReturn := fake.containersReturnsOnCall[len(fake.containersArgsForCall)]
+	fake.containersArgsForCall = append(fake.containersArgsForCall, struct{}{})
+	fake.recordInvocation("Containers", []interface{}{})
+	fake.containersMutex.Unlock()
+	if fake.ContainersStub != nil {
+		return fake.ContainersStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.containersReturns.result1, fake.containersReturns.result2
+}
+
+func (fake *FakeWorker) ContainersCallCount() int {
+	fake.containersMutex.RLock()
+	defer fake.containersMutex.RUnlock()
+	return len(fake.containersArgsForCall)
+}
+
+func (fake *FakeWorker) ContainersReturns(result1 []db.WorkerContainer, result2 error) {
+	fake.ContainersStub = nil
+	fake.containersReturns = struct {
+		result1 []db.WorkerContainer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ContainersReturnsOnCall(i int, result1 []db.WorkerContainer, result2 error) {
+	fake.ContainersStub = nil
+	if fake.containersReturnsOnCall == nil {
+		fake.containersReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerContainer
+			result2 error
+		})
+	}
+	fake.containersReturnsOnCall[i] = struct {
+		result1 []db.WorkerContainer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) SaveContainerUsages(arg1 []atc.ContainerUsage) error {
+	var arg1Copy []atc.ContainerUsage
+	if arg1 != nil {
+		arg1Copy = make([]atc.ContainerUsage, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveContainerUsagesMutex.Lock()
+	ret, specificReturn := fake.saveContainerUsagesReturnsOnCall[len(fake.saveContainerUsagesArgsForCall)]
+	fake.saveContainerUsagesArgsForCall = append(fake.saveContainerUsagesArgsForCall, struct {
+		arg1 []atc.ContainerUsage
+	}{arg1Copy})
+	fake.recordInvocation("SaveContainerUsages", []interface{}{arg1Copy})
+	fake.saveContainerUsagesMutex.Unlock()
+	if fake.SaveContainerUsagesStub != nil {
+		return fake.SaveContainerUsagesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveContainerUsagesReturns.result1
+}
+
+func (fake *FakeWorker) SaveContainerUsagesCallCount() int {
+	fake.saveContainerUsagesMutex.RLock()
+	defer fake.saveContainerUsagesMutex.RUnlock()
+	return len(fake.saveContainerUsagesArgsForCall)
+}
+
+func (fake *FakeWorker) SaveContainerUsagesArgsForCall(i int) []atc.ContainerUsage {
+	fake.saveContainerUsagesMutex.RLock()
+	defer fake.saveContainerUsagesMutex.RUnlock()
+	return fake.saveContainerUsagesArgsForCall[i].arg1
+}
+
+func (fake *FakeWorker) SaveContainerUsagesReturns(result1 error) {
+	fake.SaveContainerUsagesStub = nil
+	fake.saveContainerUsagesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) SaveContainerUsagesReturnsOnCall(i int, result1 error) {
+	fake.SaveContainerUsagesStub = nil
+	if fake.saveContainerUsagesReturnsOnCall == nil {
+		fake.saveContainerUsagesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveContainerUsagesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) InMaintenance() bool {
+	fake.inMaintenanceMutex.Lock()
+	ret, specificReturn := fake.inMaintenanceReturnsOnCall[len(fake.inMaintenanceArgsForCall)]
+	fake.inMaintenanceArgsForCall = append(fake.inMaintenanceArgsForCall, struct{}{})
+	fake.recordInvocation("InMaintenance", []interface{}{})
+	fake.inMaintenanceMutex.Unlock()
+	if fake.InMaintenanceStub != nil {
+		return fake.InMaintenanceStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.inMaintenanceReturns.result1
+}
+
+func (fake *FakeWorker) InMaintenanceCallCount() int {
+	fake.inMaintenanceMutex.RLock()
+	defer fake.inMaintenanceMutex.RUnlock()
+	return len(fake.inMaintenanceArgsForCall)
+}
+
+func (fake *FakeWorker) InMaintenanceReturns(result1 bool) {
+	fake.InMaintenanceStub = nil
+	fake.inMaintenanceReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) InMaintenanceReturnsOnCall(i int, result1 bool) {
+	fake.InMaintenanceStub = nil
+	if fake.inMaintenanceReturnsOnCall == nil {
+		fake.inMaintenanceReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.inMaintenanceReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) MaintenanceWindows() ([]atc.WorkerMaintenanceWindow, error) {
+	fake.maintenanceWindowsMutex.Lock()
+	ret, specificReturn := fake.maintenanceWindowsReturnsOnCall[len(fake.maintenanceWindowsArgsForCall)]
+	fake.maintenanceWindowsArgsForCall = append(fake.maintenanceWindowsArgsForCall, struct{}{})
+	fake.recordInvocation("MaintenanceWindows", []interface{}{})
+	fake.maintenanceWindowsMutex.Unlock()
+	if fake.MaintenanceWindowsStub != nil {
+		return fake.MaintenanceWindowsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.maintenanceWindowsReturns.result1, fake.maintenanceWindowsReturns.result2
+}
+
+func (fake *FakeWorker) MaintenanceWindowsCallCount() int {
+	fake.maintenanceWindowsMutex.RLock()
+	defer fake.maintenanceWindowsMutex.RUnlock()
+	return len(fake.maintenanceWindowsArgsForCall)
+}
+
+func (fake *FakeWorker) MaintenanceWindowsReturns(result1 []atc.WorkerMaintenanceWindow, result2 error) {
+	fake.MaintenanceWindowsStub = nil
+	fake.maintenanceWindowsReturns = struct {
+		result1 []atc.WorkerMaintenanceWindow
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) MaintenanceWindowsReturnsOnCall(i int, result1 []atc.WorkerMaintenanceWindow, result2 error) {
+	fake.MaintenanceWindowsStub = nil
+	if fake.maintenanceWindowsReturnsOnCall == nil {
+		fake.maintenanceWindowsReturnsOnCall = make(map[int]struct {
+			result1 []atc.WorkerMaintenanceWindow
+			result2 error
+		})
+	}
+	fake.maintenanceWindowsReturnsOnCall[i] = struct {
+		result1 []atc.WorkerMaintenanceWindow
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ScheduleMaintenance(startsAt time.Time, endsAt time.Time) (atc.WorkerMaintenanceWindow, error) {
+	fake.scheduleMaintenanceMutex.Lock()
+	ret, specificReturn := fake.scheduleMaintenanceReturnsOnCall[len(fake.scheduleMaintenanceArgsForCall)]
+	fake.scheduleMaintenanceArgsForCall = append(fake.scheduleMaintenanceArgsForCall, struct {
+		startsAt time.Time
+		endsAt   time.Time
+	}{startsAt, endsAt})
+	fake.recordInvocation("ScheduleMaintenance", []interface{}{startsAt, endsAt})
+	fake.scheduleMaintenanceMutex.Unlock()
+	if fake.ScheduleMaintenanceStub != nil {
+		return fake.ScheduleMaintenanceStub(startsAt, endsAt)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.scheduleMaintenanceReturns.result1, fake.scheduleMaintenanceReturns.result2
+}
+
+func (fake *FakeWorker) ScheduleMaintenanceCallCount() int {
+	fake.scheduleMaintenanceMutex.RLock()
+	defer fake.scheduleMaintenanceMutex.RUnlock()
+	return len(fake.scheduleMaintenanceArgsForCall)
+}
+
+func (fake *FakeWorker) ScheduleMaintenanceArgsForCall(i int) (time.Time, time.Time) {
+	fake.scheduleMaintenanceMutex.RLock()
+	defer fake.scheduleMaintenanceMutex.RUnlock()
+	return fake.scheduleMaintenanceArgsForCall[i].startsAt, fake.scheduleMaintenanceArgsForCall[i].endsAt
+}
+
+func (fake *FakeWorker) ScheduleMaintenanceReturns(result1 atc.WorkerMaintenanceWindow, result2 error) {
+	fake.ScheduleMaintenanceStub = nil
+	fake.scheduleMaintenanceReturns = struct {
+		result1 atc.WorkerMaintenanceWindow
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ScheduleMaintenanceReturnsOnCall(i int, result1 atc.WorkerMaintenanceWindow, result2 error) {
+	fake.ScheduleMaintenanceStub = nil
+	if fake.scheduleMaintenanceReturnsOnCall == nil {
+		fake.scheduleMaintenanceReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerMaintenanceWindow
+			result2 error
+		})
+	}
+	fake.scheduleMaintenanceReturnsOnCall[i] = struct {
+		result1 atc.WorkerMaintenanceWindow
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) CancelMaintenance(id int) (bool, error) {
+	fake.cancelMaintenanceMutex.Lock()
+	ret, specificReturn := fake.cancelMaintenanceReturnsOnCall[len(fake.cancelMaintenanceArgsForCall)]
+	fake.cancelMaintenanceArgsForCall = append(fake.cancelMaintenanceArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("CancelMaintenance", []interface{}{id})
+	fake.cancelMaintenanceMutex.Unlock()
+	if fake.CancelMaintenanceStub != nil {
+		return fake.CancelMaintenanceStub(id)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.cancelMaintenanceReturns.result1, fake.cancelMaintenanceReturns.result2
+}
+
+func (fake *FakeWorker) CancelMaintenanceCallCount() int {
+	fake.cancelMaintenanceMutex.RLock()
+	defer fake.cancelMaintenanceMutex.RUnlock()
+	return len(fake.cancelMaintenanceArgsForCall)
+}
+
+func (fake *FakeWorker) CancelMaintenanceArgsForCall(i int) int {
+	fake.cancelMaintenanceMutex.RLock()
+	defer fake.cancelMaintenanceMutex.RUnlock()
+	return fake.cancelMaintenanceArgsForCall[i].id
+}
+
+func (fake *FakeWorker) CancelMaintenanceReturns(result1 bool, result2 error) {
+	fake.CancelMaintenanceStub = nil
+	fake.cancelMaintenanceReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) CancelMaintenanceReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.CancelMaintenanceStub = nil
+	if fake.cancelMaintenanceReturnsOnCall == nil {
+		fake.cancelMaintenanceReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.cancelMaintenanceReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) SaveVolumeUsages(arg1 []atc.VolumeUsage) error {
+	var arg1Copy []atc.VolumeUsage
+	if arg1 != nil {
+		arg1Copy = make([]atc.VolumeUsage, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveVolumeUsagesMutex.Lock()
+	ret, specificReturn := fake.saveVolumeUsagesReturnsOnCall[len(fake.saveVolumeUsagesArgsForCall)]
+	fake.saveVolumeUsagesArgsForCall = append(fake.saveVolumeUsagesArgsForCall, struct {
+		arg1 []atc.VolumeUsage
+	}{arg1Copy})
+	fake.recordInvocation("SaveVolumeUsages", []interface{}{arg1Copy})
+	fake.saveVolumeUsagesMutex.Unlock()
+	if fake.SaveVolumeUsagesStub != nil {
+		return fake.SaveVolumeUsagesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveVolumeUsagesReturns.result1
+}
+
+func (fake *FakeWorker) SaveVolumeUsagesCallCount() int {
+	fake.saveVolumeUsagesMutex.RLock()
+	defer fake.saveVolumeUsagesMutex.RUnlock()
+	return len(fake.saveVolumeUsagesArgsForCall)
+}
+
+func (fake *FakeWorker) SaveVolumeUsagesArgsForCall(i int) []atc.VolumeUsage {
+	fake.saveVolumeUsagesMutex.RLock()
+	defer fake.saveVolumeUsagesMutex.RUnlock()
+	return fake.saveVolumeUsagesArgsForCall[i].arg1
+}
+
+func (fake *FakeWorker) SaveVolumeUsagesReturns(result1 error) {
+	fake.SaveVolumeUsagesStub = nil
+	fake.saveVolumeUsagesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) SaveVolumeUsagesReturnsOnCall(i int, result1 error) {
+	fake.SaveVolumeUsagesStub = nil
+	if fake.saveVolumeUsagesReturnsOnCall == nil {
+		fake.saveVolumeUsagesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveVolumeUsagesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) ReserveTaskSlot(owner db.ContainerOwner, max int) (bool, error) {
+	fake.reserveTaskSlotMutex.Lock()
+	ret, specificReturn := fake.reserveTaskSlotReturnsOnCall[len(fake.reserveTaskSlotArgsForCall)]
+	fake.reserveTaskSlotArgsForCall = append(fake.reserveTaskSlotArgsForCall, struct {
+		owner db.ContainerOwner
+		max   int
+	}{owner, max})
+	fake.recordInvocation("ReserveTaskSlot", []interface{}{owner, max})
+	fake.reserveTaskSlotMutex.Unlock()
+	if fake.ReserveTaskSlotStub != nil {
+		return fake.ReserveTaskSlotStub(owner, max)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.reserveTaskSlotReturns.result1, fake.reserveTaskSlotReturns.result2
+}
+
+func (fake *FakeWorker) ReserveTaskSlotCallCount() int {
+	fake.reserveTaskSlotMutex.RLock()
+	defer fake.reserveTaskSlotMutex.RUnlock()
+	return len(fake.reserveTaskSlotArgsForCall)
+}
+
+func (fake *FakeWorker) ReserveTaskSlotArgsForCall(i int) (db.ContainerOwner, int) {
+	fake.reserveTaskSlotMutex.RLock()
+	defer fake.reserveTaskSlotMutex.RUnlock()
+	return fake.reserveTaskSlotArgsForCall[i].owner, fake.reserveTaskSlotArgsForCall[i].max
+}
+
+func (fake *FakeWorker) ReserveTaskSlotReturns(result1 bool, result2 error) {
+	fake.ReserveTaskSlotStub = nil
+	fake.reserveTaskSlotReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReserveTaskSlotReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ReserveTaskSlotStub = nil
+	if fake.reserveTaskSlotReturnsOnCall == nil {
+		fake.reserveTaskSlotReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.reserveTaskSlotReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReleaseTaskSlot(owner db.ContainerOwner) error {
+	fake.releaseTaskSlotMutex.Lock()
+	ret, specificReturn := fake.releaseTaskSlotReturnsOnCall[len(fake.releaseTaskSlotArgsForCall)]
+	fake.releaseTaskSlotArgsForCall = append(fake.releaseTaskSlotArgsForCall, struct {
+		owner db.ContainerOwner
+	}{owner})
+	fake.recordInvocation("ReleaseTaskSlot", []interface{}{owner})
+	fake.releaseTaskSlotMutex.Unlock()
+	if fake.ReleaseTaskSlotStub != nil {
+		return fake.ReleaseTaskSlotStub(owner)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.releaseTaskSlotReturns.result1
+}
+
+func (fake *FakeWorker) ReleaseTaskSlotCallCount() int {
+	fake.releaseTaskSlotMutex.RLock()
+	defer fake.releaseTaskSlotMutex.RUnlock()
+	return len(fake.releaseTaskSlotArgsForCall)
+}
+
+func (fake *FakeWorker) ReleaseTaskSlotArgsForCall(i int) db.ContainerOwner {
+	fake.releaseTaskSlotMutex.RLock()
+	defer fake.releaseTaskSlotMutex.RUnlock()
+	return fake.releaseTaskSlotArgsForCall[i].owner
+}
+
+func (fake *FakeWorker) ReleaseTaskSlotReturns(result1 error) {
+	fake.ReleaseTaskSlotStub = nil
+	fake.releaseTaskSlotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) ReleaseTaskSlotReturnsOnCall(i int, result1 error) {
+	fake.ReleaseTaskSlotStub = nil
+	if fake.releaseTaskSlotReturnsOnCall == nil {
+		fake.releaseTaskSlotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseTaskSlotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) Volumes() ([]db.WorkerVolume, error) {
+	fake.volumesMutex.Lock()
+	ret, specificReturn := fake.volumesReturnsOnCall[len(fake.volumesArgsForCall)]
+	fake.volumesArgsForCall = append(fake.volumesArgsForCall, struct{}{})
+	fake.recordInvocation("Volumes", []interface{}{})
+	fake.volumesMutex.Unlock()
+	if fake.VolumesStub != nil {
+		return fake.VolumesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.volumesReturns.result1, fake.volumesReturns.result2
+}
+
+func (fake *FakeWorker) VolumesCallCount() int {
+	fake.volumesMutex.RLock()
+	defer fake.volumesMutex.RUnlock()
+	return len(fake.volumesArgsForCall)
+}
+
+func (fake *FakeWorker) VolumesReturns(result1 []db.WorkerVolume, result2 error) {
+	fake.VolumesStub = nil
+	fake.volumesReturns = struct {
+		result1 []db.WorkerVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) VolumesReturnsOnCall(i int, result1 []db.WorkerVolume, result2 error) {
+	fake.VolumesStub = nil
+	if fake.volumesReturnsOnCall == nil {
+		fake.volumesReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerVolume
+			result2 error
+		})
+	}
+	fake.volumesReturnsOnCall[i] = struct {
+		result1 []db.WorkerVolume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1205,6 +2142,40 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.pruneMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.inMaintenanceMutex.RLock()
+	defer fake.inMaintenanceMutex.RUnlock()
+	fake.maintenanceWindowsMutex.RLock()
+	defer fake.maintenanceWindowsMutex.RUnlock()
+	fake.scheduleMaintenanceMutex.RLock()
+	defer fake.scheduleMaintenanceMutex.RUnlock()
+	fake.cancelMaintenanceMutex.RLock()
+	defer fake.cancelMaintenanceMutex.RUnlock()
+	fake.buildContainersMutex.RLock()
+	defer fake.buildContainersMutex.RUnlock()
+	fake.activeTasksMutex.RLock()
+	defer fake.activeTasksMutex.RUnlock()
+	fake.ephemeralMutex.RLock()
+	defer fake.ephemeralMutex.RUnlock()
+	fake.expiredMutex.RLock()
+	defer fake.expiredMutex.RUnlock()
+	fake.memoryPressureMutex.RLock()
+	defer fake.memoryPressureMutex.RUnlock()
+	fake.diskPressureMutex.RLock()
+	defer fake.diskPressureMutex.RUnlock()
+	fake.diskUsageMutex.RLock()
+	defer fake.diskUsageMutex.RUnlock()
+	fake.containersMutex.RLock()
+	defer fake.containersMutex.RUnlock()
+	fake.saveContainerUsagesMutex.RLock()
+	defer fake.saveContainerUsagesMutex.RUnlock()
+	fake.saveVolumeUsagesMutex.RLock()
+	defer fake.saveVolumeUsagesMutex.RUnlock()
+	fake.reserveTaskSlotMutex.RLock()
+	defer fake.reserveTaskSlotMutex.RUnlock()
+	fake.releaseTaskSlotMutex.RLock()
+	defer fake.releaseTaskSlotMutex.RUnlock()
+	fake.volumesMutex.RLock()
+	defer fake.volumesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/atc/db"
 )
@@ -41,6 +42,30 @@ type FakeWorkerLifecycle struct {
 		result1 []string
 		result2 error
 	}
+	DeleteUnresponsiveEphemeralWorkersStub        func(gracePeriod time.Duration) ([]string, error)
+	deleteUnresponsiveEphemeralWorkersMutex       sync.RWMutex
+	deleteUnresponsiveEphemeralWorkersArgsForCall []struct {
+		gracePeriod time.Duration
+	}
+	deleteUnresponsiveEphemeralWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	deleteUnresponsiveEphemeralWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	FindFinishedRetiringWorkersStub        func() ([]string, error)
+	findFinishedRetiringWorkersMutex       sync.RWMutex
+	findFinishedRetiringWorkersArgsForCall []struct{}
+	findFinishedRetiringWorkersReturns     struct {
+		result1 []string
+		result2 error
+	}
+	findFinishedRetiringWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -174,6 +199,100 @@ func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersReturnsOnCall(i in
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkers(gracePeriod time.Duration) ([]string, error) {
+	fake.deleteUnresponsiveEphemeralWorkersMutex.Lock()
+	ret, specificReturn := fake.deleteUnresponsiveEphemeralWorkersReturnsOnCall[len(fake.deleteUnresponsiveEphemeralWorkersArgsForCall)]
+	fake.deleteUnresponsiveEphemeralWorkersArgsForCall = append(fake.deleteUnresponsiveEphemeralWorkersArgsForCall, struct {
+		gracePeriod time.Duration
+	}{gracePeriod})
+	fake.recordInvocation("DeleteUnresponsiveEphemeralWorkers", []interface{}{gracePeriod})
+	fake.deleteUnresponsiveEphemeralWorkersMutex.Unlock()
+	if fake.DeleteUnresponsiveEphemeralWorkersStub != nil {
+		return fake.DeleteUnresponsiveEphemeralWorkersStub(gracePeriod)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.deleteUnresponsiveEphemeralWorkersReturns.result1, fake.deleteUnresponsiveEphemeralWorkersReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersCallCount() int {
+	fake.deleteUnresponsiveEphemeralWorkersMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersMutex.RUnlock()
+	return len(fake.deleteUnresponsiveEphemeralWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersArgsForCall(i int) time.Duration {
+	fake.deleteUnresponsiveEphemeralWorkersMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersMutex.RUnlock()
+	return fake.deleteUnresponsiveEphemeralWorkersArgsForCall[i].gracePeriod
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersReturns(result1 []string, result2 error) {
+	fake.DeleteUnresponsiveEphemeralWorkersStub = nil
+	fake.deleteUnresponsiveEphemeralWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteUnresponsiveEphemeralWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.DeleteUnresponsiveEphemeralWorkersStub = nil
+	if fake.deleteUnresponsiveEphemeralWorkersReturnsOnCall == nil {
+		fake.deleteUnresponsiveEphemeralWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.deleteUnresponsiveEphemeralWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindFinishedRetiringWorkers() ([]string, error) {
+	fake.findFinishedRetiringWorkersMutex.Lock()
+	ret, specificReturn := fake.findFinishedRetiringWorkersReturnsOnCall[len(fake.findFinishedRetiringWorkersArgsForCall)]
+	fake.findFinishedRetiringWorkersArgsForCall = append(fake.findFinishedRetiringWorkersArgsForCall, struct{}{})
+	fake.recordInvocation("FindFinishedRetiringWorkers", []interface{}{})
+	fake.findFinishedRetiringWorkersMutex.Unlock()
+	if fake.FindFinishedRetiringWorkersStub != nil {
+		return fake.FindFinishedRetiringWorkersStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findFinishedRetiringWorkersReturns.result1, fake.findFinishedRetiringWorkersReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) FindFinishedRetiringWorkersCallCount() int {
+	fake.findFinishedRetiringWorkersMutex.RLock()
+	defer fake.findFinishedRetiringWorkersMutex.RUnlock()
+	return len(fake.findFinishedRetiringWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) FindFinishedRetiringWorkersReturns(result1 []string, result2 error) {
+	fake.FindFinishedRetiringWorkersStub = nil
+	fake.findFinishedRetiringWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) FindFinishedRetiringWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.FindFinishedRetiringWorkersStub = nil
+	if fake.findFinishedRetiringWorkersReturnsOnCall == nil {
+		fake.findFinishedRetiringWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.findFinishedRetiringWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerLifecycle) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -183,6 +302,10 @@ func (fake *FakeWorkerLifecycle) Invocations() map[string][][]interface{} {
 	defer fake.landFinishedLandingWorkersMutex.RUnlock()
 	fake.deleteFinishedRetiringWorkersMutex.RLock()
 	defer fake.deleteFinishedRetiringWorkersMutex.RUnlock()
+	fake.deleteUnresponsiveEphemeralWorkersMutex.RLock()
+	defer fake.deleteUnresponsiveEphemeralWorkersMutex.RUnlock()
+	fake.findFinishedRetiringWorkersMutex.RLock()
+	defer fake.findFinishedRetiringWorkersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
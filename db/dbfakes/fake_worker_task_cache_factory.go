@@ -42,6 +42,19 @@ type FakeWorkerTaskCacheFactory struct {
 		result1 *db.UsedWorkerTaskCache
 		result2 error
 	}
+	FindVolumesOnWorkerStub        func(string) ([]db.WorkerTaskCacheVolume, error)
+	findVolumesOnWorkerMutex       sync.RWMutex
+	findVolumesOnWorkerArgsForCall []struct {
+		arg1 string
+	}
+	findVolumesOnWorkerReturns struct {
+		result1 []db.WorkerTaskCacheVolume
+		result2 error
+	}
+	findVolumesOnWorkerReturnsOnCall map[int]struct {
+		result1 []db.WorkerTaskCacheVolume
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -157,6 +170,57 @@ func (fake *FakeWorkerTaskCacheFactory) FindOrCreateReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerTaskCacheFactory) FindVolumesOnWorker(arg1 string) ([]db.WorkerTaskCacheVolume, error) {
+	fake.findVolumesOnWorkerMutex.Lock()
+	ret, specificReturn := fake.findVolumesOnWorkerReturnsOnCall[len(fake.findVolumesOnWorkerArgsForCall)]
+	fake.findVolumesOnWorkerArgsForCall = append(fake.findVolumesOnWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("FindVolumesOnWorker", []interface{}{arg1})
+	fake.findVolumesOnWorkerMutex.Unlock()
+	if fake.FindVolumesOnWorkerStub != nil {
+		return fake.FindVolumesOnWorkerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findVolumesOnWorkerReturns.result1, fake.findVolumesOnWorkerReturns.result2
+}
+
+func (fake *FakeWorkerTaskCacheFactory) FindVolumesOnWorkerCallCount() int {
+	fake.findVolumesOnWorkerMutex.RLock()
+	defer fake.findVolumesOnWorkerMutex.RUnlock()
+	return len(fake.findVolumesOnWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerTaskCacheFactory) FindVolumesOnWorkerArgsForCall(i int) string {
+	fake.findVolumesOnWorkerMutex.RLock()
+	defer fake.findVolumesOnWorkerMutex.RUnlock()
+	return fake.findVolumesOnWorkerArgsForCall[i].arg1
+}
+
+func (fake *FakeWorkerTaskCacheFactory) FindVolumesOnWorkerReturns(result1 []db.WorkerTaskCacheVolume, result2 error) {
+	fake.FindVolumesOnWorkerStub = nil
+	fake.findVolumesOnWorkerReturns = struct {
+		result1 []db.WorkerTaskCacheVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerTaskCacheFactory) FindVolumesOnWorkerReturnsOnCall(i int, result1 []db.WorkerTaskCacheVolume, result2 error) {
+	fake.FindVolumesOnWorkerStub = nil
+	if fake.findVolumesOnWorkerReturnsOnCall == nil {
+		fake.findVolumesOnWorkerReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerTaskCacheVolume
+			result2 error
+		})
+	}
+	fake.findVolumesOnWorkerReturnsOnCall[i] = struct {
+		result1 []db.WorkerTaskCacheVolume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerTaskCacheFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -164,6 +228,8 @@ func (fake *FakeWorkerTaskCacheFactory) Invocations() map[string][][]interface{}
 	defer fake.findMutex.RUnlock()
 	fake.findOrCreateMutex.RLock()
 	defer fake.findOrCreateMutex.RUnlock()
+	fake.findVolumesOnWorkerMutex.RLock()
+	defer fake.findVolumesOnWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
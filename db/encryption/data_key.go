@@ -0,0 +1,57 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+)
+
+const dataKeySize = 32
+
+// NewDataKey generates a key for encrypting data with, returning it along with
+// its value wrapped (i.e. encrypted) by the master strategy so that it can be
+// stored alongside the data.
+func NewDataKey(master Strategy) (*Key, string, *string, error) {
+	raw := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, raw); err != nil {
+		return nil, "", nil, err
+	}
+
+	key, err := dataKey(raw)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	wrapped, nonce, err := master.Encrypt(raw)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return key, wrapped, nonce, nil
+}
+
+// UnwrapDataKey decrypts a data key generated by NewDataKey with the master
+// strategy that wrapped it.
+func UnwrapDataKey(master Strategy, wrapped string, nonce *string) (*Key, error) {
+	raw, err := master.Decrypt(wrapped, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return dataKey(raw)
+}
+
+func dataKey(raw []byte) (*Key, error) {
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKey(aesgcm), nil
+}
@@ -0,0 +1,55 @@
+package encryption_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+
+	"github.com/concourse/atc/db/encryption"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Data Key", func() {
+	var master *encryption.Key
+
+	BeforeEach(func() {
+		block, err := aes.NewCipher([]byte("AES256Key-32Characters1234567890"))
+		Expect(err).ToNot(HaveOccurred())
+
+		aesgcm, err := cipher.NewGCM(block)
+		Expect(err).ToNot(HaveOccurred())
+
+		master = encryption.NewKey(aesgcm)
+	})
+
+	It("can be unwrapped with the master key to decrypt what it encrypted", func() {
+		key, wrapped, nonce, err := encryption.NewDataKey(master)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nonce).ToNot(BeNil())
+
+		encryptedText, textNonce, err := key.Encrypt([]byte("exampleplaintext"))
+		Expect(err).ToNot(HaveOccurred())
+
+		unwrapped, err := encryption.UnwrapDataKey(master, wrapped, nonce)
+		Expect(err).ToNot(HaveOccurred())
+
+		decryptedText, err := unwrapped.Decrypt(encryptedText, textNonce)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decryptedText).To(Equal([]byte("exampleplaintext")))
+
+		_, err = master.Decrypt(encryptedText, textNonce)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("when the master strategy does not encrypt", func() {
+		It("refuses to unwrap a key that was never wrapped", func() {
+			_, wrapped, nonce, err := encryption.NewDataKey(encryption.NewNoEncryption())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nonce).To(BeNil())
+
+			_, err = encryption.UnwrapDataKey(master, wrapped, nonce)
+			Expect(err).To(Equal(encryption.ErrDataIsNotEncrypted))
+		})
+	})
+})
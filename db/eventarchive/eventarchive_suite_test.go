@@ -0,0 +1,13 @@
+package eventarchive_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestEventArchive(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Event Archive Suite")
+}
@@ -0,0 +1,88 @@
+package eventarchive
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+const gcsEndpoint = "https://storage.googleapis.com"
+
+type s3Store struct {
+	api    s3iface.S3API
+	bucket string
+	prefix string
+}
+
+func newS3Store(config Config) (Store, error) {
+	awsConfig := &aws.Config{
+		Region: aws.String(config.Region),
+	}
+
+	if config.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(config.Endpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	if config.AccessKeyID != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewS3Store(s3.New(sess), config.Bucket, config.Prefix), nil
+}
+
+// NewS3Store constructs a Store keeping each object in the bucket, under the
+// prefix.
+func NewS3Store(api s3iface.S3API, bucket string, prefix string) Store {
+	return &s3Store{
+		api:    api,
+		bucket: bucket,
+		prefix: prefix,
+	}
+}
+
+func (store *s3Store) Put(key string, data []byte) error {
+	_, err := store.api.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.prefix + key),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+func (store *s3Store) Get(key string) ([]byte, error) {
+	output, err := store.api.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.prefix + key),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	defer output.Body.Close()
+
+	return ioutil.ReadAll(output.Body)
+}
+
+func (store *s3Store) Delete(key string) error {
+	_, err := store.api.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.prefix + key),
+	})
+	return err
+}
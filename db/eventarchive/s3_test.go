@@ -0,0 +1,90 @@
+package eventarchive_test
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/concourse/atc/db/eventarchive"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type mockS3Service struct {
+	s3iface.S3API
+
+	objects map[string][]byte
+}
+
+func (mock *mockS3Service) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	Expect(aws.StringValue(input.Bucket)).To(Equal("some-bucket"))
+
+	data, err := ioutil.ReadAll(input.Body)
+	Expect(err).NotTo(HaveOccurred())
+
+	mock.objects[aws.StringValue(input.Key)] = data
+
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (mock *mockS3Service) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	Expect(aws.StringValue(input.Bucket)).To(Equal("some-bucket"))
+
+	data, found := mock.objects[aws.StringValue(input.Key)]
+	if !found {
+		return nil, awserr.New(s3.ErrCodeNoSuchKey, "no such key", nil)
+	}
+
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (mock *mockS3Service) DeleteObject(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	Expect(aws.StringValue(input.Bucket)).To(Equal("some-bucket"))
+
+	delete(mock.objects, aws.StringValue(input.Key))
+
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+var _ = Describe("S3Store", func() {
+	var (
+		mockService *mockS3Service
+		store       eventarchive.Store
+	)
+
+	BeforeEach(func() {
+		mockService = &mockS3Service{objects: map[string][]byte{}}
+		store = eventarchive.NewS3Store(mockService, "some-bucket", "some-prefix/")
+	})
+
+	It("stores objects under the prefix", func() {
+		err := store.Put("some-key", []byte("some-data"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mockService.objects).To(HaveKeyWithValue("some-prefix/some-key", []byte("some-data")))
+
+		data, err := store.Get("some-key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal([]byte("some-data")))
+	})
+
+	It("returns ErrNotFound for missing objects", func() {
+		_, err := store.Get("some-key")
+		Expect(err).To(Equal(eventarchive.ErrNotFound))
+	})
+
+	It("deletes objects", func() {
+		err := store.Put("some-key", []byte("some-data"))
+		Expect(err).NotTo(HaveOccurred())
+
+		err = store.Delete("some-key")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = store.Get("some-key")
+		Expect(err).To(Equal(eventarchive.ErrNotFound))
+	})
+})
@@ -0,0 +1,61 @@
+package eventarchive
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned when getting an object which is not in the store.
+var ErrNotFound = errors.New("archived object not found")
+
+//go:generate counterfeiter . Store
+
+// Store holds the event streams of completed builds once they are offloaded
+// from the database.
+type Store interface {
+	Put(key string, data []byte) error
+	Get(key string) ([]byte, error)
+	Delete(key string) error
+}
+
+// Config configures the store to archive build events in.
+type Config struct {
+	Driver string `long:"driver" choice:"s3" choice:"gcs" description:"Object store to offload the events of completed builds to. If omitted, events are kept in the database."`
+	Bucket string `long:"bucket" description:"Bucket to store archived build events in."`
+	Prefix string `long:"prefix" default:"build-events/" description:"Prefix for the key of each archived build's events."`
+
+	Region          string `long:"region" description:"Region of the bucket. Only used by the s3 driver." env:"AWS_REGION"`
+	Endpoint        string `long:"endpoint" description:"Endpoint of an S3-compatible object store to use instead of AWS."`
+	AccessKeyID     string `long:"access-key-id" description:"Access key ID for the bucket. For the gcs driver, an HMAC key of a service account."`
+	SecretAccessKey string `long:"secret-access-key" description:"Secret access key for the bucket. For the gcs driver, the secret of the HMAC key."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.Driver != ""
+}
+
+// NewStore constructs the configured store.
+func NewStore(config Config) (Store, error) {
+	if config.Bucket == "" {
+		return nil, errors.New("event archive bucket must be specified")
+	}
+
+	switch config.Driver {
+	case "s3":
+		return newS3Store(config)
+	case "gcs":
+		// Cloud Storage is reached through its S3-compatible XML API, which
+		// authenticates with the HMAC keys of a service account.
+		if config.Endpoint == "" {
+			config.Endpoint = gcsEndpoint
+		}
+
+		if config.Region == "" {
+			config.Region = "auto"
+		}
+
+		return newS3Store(config)
+	default:
+		return nil, fmt.Errorf("unknown event archive driver: %s", config.Driver)
+	}
+}
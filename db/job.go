@@ -27,15 +27,18 @@ type Job interface {
 
 	Reload() (bool, error)
 
-	Pause() error
-	Unpause() error
+	Pause(from ConfigVersion) (ConfigVersion, error)
+	Unpause(from ConfigVersion) (ConfigVersion, error)
 
-	CreateBuild() (Build, error)
+	CreateBuild(cause BuildTriggerCause) (Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	Build(name string) (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
-	EnsurePendingBuildExists() error
+	FirstBuildIDToRetain(retention atc.BuildLogRetention) (int, bool, error)
+	LatestSucceededBuildIDs(limit int) ([]int, error)
+	UnreapedBuildIDsBefore(buildID int) ([]int, error)
+	EnsurePendingBuildExists(cause BuildTriggerCause) error
 	GetPendingBuilds() ([]Build, error)
 
 	GetIndependentBuildInputs() ([]BuildInput, error)
@@ -49,7 +52,7 @@ type Job interface {
 	GetNextPendingBuildBySerialGroup(serialGroups []string) (Build, bool, error)
 }
 
-var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "array_to_json(j.tags)").
+var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "array_to_json(j.tags)", "j.team_keyed", "t.data_key", "t.data_key_nonce").
 	From("jobs j, pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id").
 	Where(sq.Expr("j.pipeline_id = p.id"))
@@ -119,12 +122,12 @@ func (j *job) Reload() (bool, error) {
 	return true, nil
 }
 
-func (j *job) Pause() error {
-	return j.updatePausedJob(true)
+func (j *job) Pause(from ConfigVersion) (ConfigVersion, error) {
+	return j.updatePausedJob(true, from)
 }
 
-func (j *job) Unpause() error {
-	return j.updatePausedJob(false)
+func (j *job) Unpause(from ConfigVersion) (ConfigVersion, error) {
+	return j.updatePausedJob(false, from)
 }
 
 func (j *job) FinishedAndNextBuild() (Build, Build, error) {
@@ -181,6 +184,123 @@ func (j *job) UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error {
 	return nil
 }
 
+// FirstBuildIDToRetain returns the ID of the oldest build whose logs are kept
+// under the retention policy's number of builds and days. The logs of every
+// build before it may be reaped, except for those of the most recent
+// succeeded builds the policy keeps regardless, as given by
+// LatestSucceededBuildIDs. If no build's logs may be reaped, false is
+// returned.
+func (j *job) FirstBuildIDToRetain(retention atc.BuildLogRetention) (int, bool, error) {
+	var firstBuildToRetain int64
+
+	if retention.Builds > 0 {
+		var oldestRecent sql.NullInt64
+		err := j.conn.QueryRow(`
+			SELECT MIN(id)
+			FROM (
+				SELECT id
+				FROM builds
+				WHERE job_id = $1
+				ORDER BY id DESC
+				LIMIT $2
+			) recent
+		`, j.id, retention.Builds).Scan(&oldestRecent)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if oldestRecent.Valid {
+			firstBuildToRetain = oldestRecent.Int64
+		}
+	}
+
+	if retention.Days > 0 {
+		var oldestUnexpired, latest sql.NullInt64
+		err := j.conn.QueryRow(`
+			SELECT
+				MIN(CASE WHEN end_time IS NULL OR end_time > now() - $2::integer * interval '1 day' THEN id END),
+				MAX(id)
+			FROM builds
+			WHERE job_id = $1
+		`, j.id, retention.Days).Scan(&oldestUnexpired, &latest)
+		if err != nil {
+			return 0, false, err
+		}
+
+		firstUnexpired := latest.Int64 + 1
+		if oldestUnexpired.Valid {
+			firstUnexpired = oldestUnexpired.Int64
+		}
+
+		if latest.Valid && firstUnexpired > firstBuildToRetain {
+			firstBuildToRetain = firstUnexpired
+		}
+	}
+
+	if firstBuildToRetain == 0 {
+		return 0, false, nil
+	}
+
+	return int(firstBuildToRetain), true, nil
+}
+
+// LatestSucceededBuildIDs returns the IDs of up to the limit of the job's most
+// recent succeeded builds, newest first.
+func (j *job) LatestSucceededBuildIDs(limit int) ([]int, error) {
+	rows, err := psql.Select("id").
+		From("builds").
+		Where(sq.Eq{
+			"job_id": j.id,
+			"status": BuildStatusSucceeded,
+		}).
+		OrderBy("id DESC").
+		Limit(uint64(limit)).
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBuildIDs(rows)
+}
+
+// UnreapedBuildIDsBefore returns the IDs of the job's builds before the given
+// one whose logs have not been reaped, oldest first.
+func (j *job) UnreapedBuildIDsBefore(buildID int) ([]int, error) {
+	rows, err := psql.Select("id").
+		From("builds").
+		Where(sq.Eq{
+			"job_id":    j.id,
+			"reap_time": nil,
+		}).
+		Where(sq.Lt{"id": buildID}).
+		OrderBy("id ASC").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBuildIDs(rows)
+}
+
+func scanBuildIDs(rows *sql.Rows) ([]int, error) {
+	defer Close(rows)
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
 func (j *job) Builds(page Page) ([]Build, Pagination, error) {
 	query := buildsQuery.Where(sq.Eq{"j.id": j.id})
 
@@ -196,7 +316,9 @@ func (j *job) Builds(page Page) ([]Build, Pagination, error) {
 		query = query.Where(sq.Lt{"b.id": page.Since}).OrderBy("b.id DESC").Limit(limit)
 	}
 
-	rows, err := query.RunWith(j.conn).Query()
+	runner := reader(j.conn, buildListStaleness)
+
+	rows, err := query.RunWith(runner).Query()
 	if err != nil {
 		return nil, Pagination{}, err
 	}
@@ -231,7 +353,7 @@ func (j *job) Builds(page Page) ([]Build, Pagination, error) {
 			"j.name":        j.name,
 			"j.pipeline_id": j.pipelineID,
 		}).
-		RunWith(j.conn).
+		RunWith(runner).
 		QueryRow().
 		Scan(&maxID, &minID)
 	if err != nil {
@@ -451,7 +573,12 @@ func (j *job) DeleteNextInputMapping() error {
 	return tx.Commit()
 }
 
-func (j *job) EnsurePendingBuildExists() error {
+func (j *job) EnsurePendingBuildExists(cause BuildTriggerCause) error {
+	triggerCause, err := cause.value()
+	if err != nil {
+		return err
+	}
+
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return err
@@ -465,12 +592,12 @@ func (j *job) EnsurePendingBuildExists() error {
 	}
 
 	rows, err := tx.Query(`
-		INSERT INTO builds (name, job_id, pipeline_id, team_id, status)
-		SELECT $1, $2, $3, $4, 'pending'
+		INSERT INTO builds (name, job_id, pipeline_id, team_id, status, trigger_cause)
+		SELECT $1, $2, $3, $4, 'pending', $5
 		WHERE NOT EXISTS
 			(SELECT id FROM builds WHERE job_id = $2 AND status = 'pending')
 		RETURNING id
-	`, buildName, j.id, j.pipelineID, j.teamID)
+	`, buildName, j.id, j.pipelineID, j.teamID, triggerCause)
 	if err != nil {
 		return err
 	}
@@ -494,6 +621,11 @@ func (j *job) EnsurePendingBuildExists() error {
 			return err
 		}
 
+		err = updateDashboardBuilds(tx, j.id, buildID)
+		if err != nil {
+			return err
+		}
+
 		return tx.Commit()
 	}
 
@@ -542,7 +674,12 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 	return builds, nil
 }
 
-func (j *job) CreateBuild() (Build, error) {
+func (j *job) CreateBuild(cause BuildTriggerCause) (Build, error) {
+	triggerCause, err := cause.value()
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -563,17 +700,18 @@ func (j *job) CreateBuild() (Build, error) {
 		"team_id":            j.teamID,
 		"status":             BuildStatusPending,
 		"manually_triggered": true,
+		"trigger_cause":      triggerCause,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	err = tx.Commit()
+	err = updateDashboardBuilds(tx, j.id, build.id)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = j.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY next_builds_per_job`)
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
@@ -612,26 +750,28 @@ func (j *job) updateSerialGroups(serialGroups []string) error {
 	return tx.Commit()
 }
 
-func (j *job) updatePausedJob(pause bool) error {
-	result, err := psql.Update("jobs").
-		Set("paused", pause).
-		Where(sq.Eq{"id": j.id}).
-		RunWith(j.conn).
-		Exec()
-	if err != nil {
-		return err
-	}
+func (j *job) updatePausedJob(pause bool, from ConfigVersion) (ConfigVersion, error) {
+	return compareAndSwap(j.conn, j.pipelineID, from, func(tx Tx) error {
+		result, err := psql.Update("jobs").
+			Set("paused", pause).
+			Where(sq.Eq{"id": j.id}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
 
-	if rowsAffected != 1 {
-		return nonOneRowAffectedError{rowsAffected}
-	}
+		if rowsAffected != 1 {
+			return nonOneRowAffectedError{rowsAffected}
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (j *job) getBuildInputs(table string) ([]BuildInput, error) {
@@ -842,21 +982,16 @@ func scanJob(j *job, row scannable) error {
 		nonce      sql.NullString
 		tagsBlob   []byte
 		tags       []string
+		teamKeyed  bool
+		dataKey    teamDataKey
 	)
 
-	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, &tagsBlob)
+	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, &tagsBlob, &teamKeyed, &dataKey.key, &dataKey.nonce)
 	if err != nil {
 		return err
 	}
 
-	es := j.conn.EncryptionStrategy()
-
-	var noncense *string
-	if nonce.Valid {
-		noncense = &nonce.String
-	}
-
-	decryptedConfig, err := es.Decrypt(string(configBlob), noncense)
+	decryptedConfig, err := dataKey.decryptConfig(j.conn.EncryptionStrategy(), string(configBlob), nonce, teamKeyed)
 	if err != nil {
 		return err
 	}
@@ -24,108 +24,44 @@ func NewJobFactory(conn Conn, lockFactory lock.LockFactory) JobFactory {
 }
 
 func (j *jobFactory) VisibleJobs(teamNames []string) (Dashboard, error) {
-	rows, err := jobsQuery.
-		Where(sq.Eq{
-			"t.name":   teamNames,
-			"j.active": true,
-		}).
-		OrderBy("j.id ASC").
-		RunWith(j.conn).
-		Query()
-	if err != nil {
-		return nil, err
-	}
-
-	currentTeamJobs, err := scanJobs(j.conn, j.lockFactory, rows)
-	if err != nil {
-		return nil, err
-	}
+	runner := reader(j.conn, dashboardStaleness)
 
-	rows, err = jobsQuery.
-		Where(sq.NotEq{
-			"t.name": teamNames,
+	rows, err := dashboardQuery.
+		Where(sq.Or{
+			sq.Eq{"t.name": teamNames},
+			sq.Eq{"p.public": true},
 		}).
 		Where(sq.Eq{
-			"p.public": true,
-			"j.active": true,
+			"j.active":      true,
+			"p.archived_at": nil,
 		}).
 		OrderBy("j.id ASC").
-		RunWith(j.conn).
+		RunWith(runner).
 		Query()
 	if err != nil {
 		return nil, err
 	}
 
-	otherTeamPublicJobs, err := scanJobs(j.conn, j.lockFactory, rows)
+	dashboard, err := scanDashboard(j.conn, j.lockFactory, runner, rows, true)
 	if err != nil {
 		return nil, err
 	}
 
-	jobs := append(currentTeamJobs, otherTeamPublicJobs...)
-
-	var jobIDs []int
-	for _, job := range jobs {
-		jobIDs = append(jobIDs, job.ID())
-	}
-
-	nextBuilds, err := j.getBuildsFrom("next_builds_per_job", jobIDs)
-	if err != nil {
-		return nil, err
+	isCurrentTeam := map[string]bool{}
+	for _, teamName := range teamNames {
+		isCurrentTeam[teamName] = true
 	}
 
-	finishedBuilds, err := j.getBuildsFrom("latest_completed_builds_per_job", jobIDs)
-	if err != nil {
-		return nil, err
-	}
-
-	transitionBuilds, err := j.getBuildsFrom("transition_builds_per_job", jobIDs)
-	if err != nil {
-		return nil, err
-	}
-
-	dashboard := Dashboard{}
-	for _, job := range jobs {
-		dashboardJob := DashboardJob{Job: job}
-
-		if nextBuild, found := nextBuilds[job.ID()]; found {
-			dashboardJob.NextBuild = nextBuild
-		}
-
-		if finishedBuild, found := finishedBuilds[job.ID()]; found {
-			dashboardJob.FinishedBuild = finishedBuild
-		}
-
-		if transitionBuild, found := transitionBuilds[job.ID()]; found {
-			dashboardJob.TransitionBuild = transitionBuild
-		}
-
-		dashboard = append(dashboard, dashboardJob)
-	}
-
-	return dashboard, nil
-}
-
-func (j *jobFactory) getBuildsFrom(view string, jobIDs []int) (map[int]Build, error) {
-	rows, err := buildsQuery.
-		From(view + " b").
-		Where(sq.Eq{"j.id": jobIDs}).
-		RunWith(j.conn).Query()
-	if err != nil {
-		return nil, err
-	}
-
-	defer Close(rows)
-
-	builds := make(map[int]Build)
-
-	for rows.Next() {
-		build := &build{conn: j.conn, lockFactory: j.lockFactory}
-		err := scanBuild(build, rows, j.conn.EncryptionStrategy())
-		if err != nil {
-			return nil, err
+	// the current teams' jobs come before other teams' public jobs
+	currentTeamJobs := Dashboard{}
+	otherTeamPublicJobs := Dashboard{}
+	for _, dashboardJob := range dashboard {
+		if isCurrentTeam[dashboardJob.Job.TeamName()] {
+			currentTeamJobs = append(currentTeamJobs, dashboardJob)
+		} else {
+			otherTeamPublicJobs = append(otherTeamPublicJobs, dashboardJob)
 		}
-		builds[build.JobID()] = build
 	}
 
-	return builds, nil
+	return append(currentTeamJobs, otherTeamPublicJobs...), nil
 }
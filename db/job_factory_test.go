@@ -25,7 +25,7 @@ var _ = Describe("Job Factory", func() {
 				},
 			}, db.ConfigVersion(0), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(publicPipeline.Expose()).To(Succeed())
+			Expect(publicPipeline.Expose(db.AnyConfigVersion)).ToNot(BeZero())
 
 			_, _, err = otherTeam.SavePipeline("private-pipeline", atc.Config{
 				Jobs: atc.JobConfigs{
@@ -49,13 +49,13 @@ var _ = Describe("Job Factory", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			transitionBuild, err := job.CreateBuild()
+			transitionBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = transitionBuild.Finish(db.BuildStatusSucceeded)
 			Expect(err).ToNot(HaveOccurred())
 
-			finishedBuild, err := job.CreateBuild()
+			finishedBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = finishedBuild.Finish(db.BuildStatusSucceeded)
@@ -65,7 +65,7 @@ var _ = Describe("Job Factory", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			nextBuild, err := job.CreateBuild()
+			nextBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"})
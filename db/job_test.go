@@ -98,7 +98,7 @@ var _ = Describe("Job", func() {
 		})
 
 		It("can be paused", func() {
-			err := job.Pause()
+			_, err := job.Pause(db.AnyConfigVersion)
 			Expect(err).NotTo(HaveOccurred())
 
 			found, err := job.Reload()
@@ -109,7 +109,7 @@ var _ = Describe("Job", func() {
 		})
 
 		It("can be unpaused", func() {
-			err := job.Unpause()
+			_, err := job.Unpause(db.AnyConfigVersion)
 			Expect(err).NotTo(HaveOccurred())
 
 			found, err := job.Reload()
@@ -118,6 +118,39 @@ var _ = Describe("Job", func() {
 
 			Expect(job.Paused()).To(BeFalse())
 		})
+
+		It("moves the pipeline on to a new config version", func() {
+			version, err := job.Pause(pipeline.ConfigVersion())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(BeNumerically(">", pipeline.ConfigVersion()))
+
+			found, err := pipeline.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipeline.ConfigVersion()).To(Equal(version))
+		})
+
+		Context("when the pipeline has changed since the given config version", func() {
+			var staleVersion db.ConfigVersion
+
+			BeforeEach(func() {
+				staleVersion = pipeline.ConfigVersion()
+
+				_, err := pipeline.Expose(db.AnyConfigVersion)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("fails without pausing the job", func() {
+				_, err := job.Pause(staleVersion)
+				Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+
+				found, err := job.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(job.Paused()).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("FinishedAndNextBuild", func() {
@@ -148,13 +181,13 @@ var _ = Describe("Job", func() {
 			Expect(next).To(BeNil())
 			Expect(finished).To(BeNil())
 
-			finishedBuild, err := job.CreateBuild()
+			finishedBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			err = finishedBuild.Finish(db.BuildStatusSucceeded)
 			Expect(err).NotTo(HaveOccurred())
 
-			otherFinishedBuild, err := otherJob.CreateBuild()
+			otherFinishedBuild, err := otherJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			err = otherFinishedBuild.Finish(db.BuildStatusSucceeded)
@@ -166,14 +199,14 @@ var _ = Describe("Job", func() {
 			Expect(next).To(BeNil())
 			Expect(finished.ID()).To(Equal(finishedBuild.ID()))
 
-			nextBuild, err := job.CreateBuild()
+			nextBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			started, err := nextBuild.Start("some-engine", `{"id":"1"}`, atc.Plan{})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(started).To(BeTrue())
 
-			otherNextBuild, err := otherJob.CreateBuild()
+			otherNextBuild, err := otherJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			otherStarted, err := otherNextBuild.Start("some-engine", `{"id":"1"}`, atc.Plan{})
@@ -186,7 +219,7 @@ var _ = Describe("Job", func() {
 			Expect(next.ID()).To(Equal(nextBuild.ID()))
 			Expect(finished.ID()).To(Equal(finishedBuild.ID()))
 
-			anotherRunningBuild, err := job.CreateBuild()
+			anotherRunningBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			finished, next, err = job.FinishedAndNextBuild()
@@ -244,6 +277,134 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("FirstBuildIDToRetain", func() {
+		var builds []db.Build
+
+		BeforeEach(func() {
+			builds = nil
+
+			for _, status := range []db.BuildStatus{
+				db.BuildStatusSucceeded,
+				db.BuildStatusFailed,
+				db.BuildStatusSucceeded,
+				db.BuildStatusFailed,
+				db.BuildStatusFailed,
+			} {
+				build, err := job.CreateBuild(db.BuildTriggerCause{})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(status)
+				Expect(err).NotTo(HaveOccurred())
+
+				builds = append(builds, build)
+			}
+		})
+
+		ageBuild := func(build db.Build, days int) {
+			_, err := dbConn.Exec(`UPDATE builds SET end_time = now() - $2::integer * interval '1 day' WHERE id = $1`, build.ID(), days)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("retains the most recent builds", func() {
+			id, found, err := job.FirstBuildIDToRetain(atc.BuildLogRetention{Builds: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(id).To(Equal(builds[3].ID()))
+		})
+
+		It("retains builds which finished within the number of days", func() {
+			ageBuild(builds[0], 40)
+			ageBuild(builds[1], 35)
+
+			id, found, err := job.FirstBuildIDToRetain(atc.BuildLogRetention{Days: 30})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(id).To(Equal(builds[2].ID()))
+		})
+
+		It("retains only builds within every limit", func() {
+			ageBuild(builds[0], 40)
+			ageBuild(builds[1], 35)
+			ageBuild(builds[2], 31)
+
+			id, found, err := job.FirstBuildIDToRetain(atc.BuildLogRetention{Builds: 4, Days: 30})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(id).To(Equal(builds[3].ID()))
+		})
+
+		It("reaps every build once they have all expired", func() {
+			for _, build := range builds {
+				ageBuild(build, 40)
+			}
+
+			id, found, err := job.FirstBuildIDToRetain(atc.BuildLogRetention{Days: 30})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(id).To(Equal(builds[4].ID() + 1))
+		})
+
+		It("does not hold back the cutoff for the minimum number of succeeded builds", func() {
+			id, found, err := job.FirstBuildIDToRetain(atc.BuildLogRetention{Builds: 1, MinimumSucceeded: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(id).To(Equal(builds[4].ID()))
+		})
+
+		It("retains every build while there are fewer than the number to retain", func() {
+			id, found, err := job.FirstBuildIDToRetain(atc.BuildLogRetention{Builds: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(id).To(Equal(builds[0].ID()))
+		})
+	})
+
+	Describe("LatestSucceededBuildIDs", func() {
+		It("returns the most recent succeeded builds, newest first", func() {
+			var succeeded []int
+			for _, status := range []db.BuildStatus{
+				db.BuildStatusSucceeded,
+				db.BuildStatusSucceeded,
+				db.BuildStatusFailed,
+				db.BuildStatusSucceeded,
+				db.BuildStatusFailed,
+			} {
+				build, err := job.CreateBuild(db.BuildTriggerCause{})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(status)
+				Expect(err).NotTo(HaveOccurred())
+
+				if status == db.BuildStatusSucceeded {
+					succeeded = append(succeeded, build.ID())
+				}
+			}
+
+			ids, err := job.LatestSucceededBuildIDs(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal([]int{succeeded[2], succeeded[1]}))
+		})
+	})
+
+	Describe("UnreapedBuildIDsBefore", func() {
+		It("returns the builds before the given one whose logs have not been reaped", func() {
+			var builds []db.Build
+			for i := 0; i < 4; i++ {
+				build, err := job.CreateBuild(db.BuildTriggerCause{})
+				Expect(err).NotTo(HaveOccurred())
+
+				builds = append(builds, build)
+			}
+
+			err := pipeline.DeleteBuildEventsByBuildIDs([]int{builds[0].ID()})
+			Expect(err).NotTo(HaveOccurred())
+
+			ids, err := job.UnreapedBuildIDsBefore(builds[3].ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal([]int{builds[1].ID(), builds[2].ID()}))
+		})
+	})
+
 	Context("Builds", func() {
 		var (
 			builds       [10]db.Build
@@ -263,10 +424,10 @@ var _ = Describe("Job", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := someJob.CreateBuild()
+				build, err := someJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = someOtherJob.CreateBuild()
+				_, err = someOtherJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				builds[i] = build
@@ -339,12 +500,12 @@ var _ = Describe("Job", func() {
 		Context("when a build exists", func() {
 			BeforeEach(func() {
 				var err error
-				firstBuild, err = job.CreateBuild()
+				firstBuild, err = job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("finds the latest build", func() {
-				secondBuild, err := job.CreateBuild()
+				secondBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				build, found, err := job.Build("latest")
@@ -386,15 +547,15 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				_, err = job.CreateBuild()
+				_, err = job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
-				startedBuild, err = job.CreateBuild()
+				startedBuild, err = job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 				_, err = startedBuild.Start("", "{}", atc.Plan{})
 				Expect(err).NotTo(HaveOccurred())
 
-				scheduledBuild, err = job.CreateBuild()
+				scheduledBuild, err = job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				scheduled, err := scheduledBuild.Schedule()
@@ -402,7 +563,7 @@ var _ = Describe("Job", func() {
 				Expect(scheduled).To(BeTrue())
 
 				for _, s := range []db.BuildStatus{db.BuildStatusSucceeded, db.BuildStatusFailed, db.BuildStatusErrored, db.BuildStatusAborted} {
-					finishedBuild, err := job.CreateBuild()
+					finishedBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 					Expect(err).NotTo(HaveOccurred())
 
 					scheduled, err = finishedBuild.Schedule()
@@ -417,7 +578,7 @@ var _ = Describe("Job", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				_, err = otherJob.CreateBuild()
+				_, err = otherJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -439,14 +600,14 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				_, err = job.CreateBuild()
+				_, err = job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				otherSerialJob, found, err := pipeline.Job("other-serial-group-job")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				serialGroupBuild, err = otherSerialJob.CreateBuild()
+				serialGroupBuild, err = otherSerialJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				scheduled, err := serialGroupBuild.Schedule()
@@ -457,7 +618,7 @@ var _ = Describe("Job", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				differentSerialGroupBuild, err := differentSerialJob.CreateBuild()
+				differentSerialGroupBuild, err := differentSerialJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				scheduled, err = differentSerialGroupBuild.Schedule()
@@ -494,10 +655,10 @@ var _ = Describe("Job", func() {
 			var actualBuild db.Build
 
 			BeforeEach(func() {
-				_, err := job1.CreateBuild()
+				_, err := job1.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
-				actualBuild, err = job2.CreateBuild()
+				actualBuild, err = job2.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				err = job2.SaveNextInputMapping(nil)
@@ -513,13 +674,13 @@ var _ = Describe("Job", func() {
 		})
 
 		It("should return the next most pending build in a group of jobs", func() {
-			buildOne, err := job1.CreateBuild()
+			buildOne, err := job1.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
-			buildTwo, err := job1.CreateBuild()
+			buildTwo, err := job1.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
-			buildThree, err := job2.CreateBuild()
+			buildThree, err := job2.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			err = job1.SaveNextInputMapping(nil)
@@ -532,7 +693,7 @@ var _ = Describe("Job", func() {
 			Expect(found).To(BeTrue())
 			Expect(build.ID()).To(Equal(buildOne.ID()))
 
-			err = job1.Pause()
+			_, err = job1.Pause(db.AnyConfigVersion)
 			Expect(err).NotTo(HaveOccurred())
 
 			build, found, err = job1.GetNextPendingBuildBySerialGroup([]string{"serial-group"})
@@ -540,7 +701,7 @@ var _ = Describe("Job", func() {
 			Expect(found).To(BeTrue())
 			Expect(build.ID()).To(Equal(buildThree.ID()))
 
-			err = job1.Unpause()
+			_, err = job1.Unpause(db.AnyConfigVersion)
 			Expect(err).NotTo(HaveOccurred())
 
 			build, found, err = job2.GetNextPendingBuildBySerialGroup([]string{"serial-group", "really-different-group"})
@@ -605,7 +766,7 @@ var _ = Describe("Job", func() {
 			Expect(found).To(BeTrue())
 
 			// save metadata for v1
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 			err = build.SaveInput(db.BuildInput{
 				Name: "some-input",
@@ -867,7 +1028,7 @@ var _ = Describe("Job", func() {
 		})
 
 		It("fails to save build input if resource does not exist", func() {
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			vr := db.VersionedResource{
@@ -886,7 +1047,7 @@ var _ = Describe("Job", func() {
 		})
 
 		It("updates metadata of existing versioned resources", func() {
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			err = build.SaveInput(db.BuildInput{
@@ -933,7 +1094,7 @@ var _ = Describe("Job", func() {
 		})
 
 		It("does not clobber metadata of existing versioned resources", func() {
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			withMetadata := vr1
@@ -994,11 +1155,19 @@ var _ = Describe("Job", func() {
 			otherPipeline, _, err = team.SavePipeline("some-other-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			build1DB, err = job.CreateBuild()
+			build1DB, err = job.CreateBuild(db.BuildTriggerCause{
+				Type:      db.BuildTriggerManual,
+				Requester: "some-requester",
+			})
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(build1DB.ID()).NotTo(BeZero())
 			Expect(build1DB.JobName()).To(Equal("some-job"))
+			Expect(build1DB.IsManuallyTriggered()).To(BeTrue())
+			Expect(build1DB.TriggerCause()).To(Equal(db.BuildTriggerCause{
+				Type:      db.BuildTriggerManual,
+				Requester: "some-requester",
+			}))
 			Expect(build1DB.Name()).To(Equal("1"))
 			Expect(build1DB.Status()).To(Equal(db.BuildStatusPending))
 			Expect(build1DB.IsScheduled()).To(BeFalse())
@@ -1026,7 +1195,7 @@ var _ = Describe("Job", func() {
 
 		Context("and another build for a different pipeline is created with the same job name", func() {
 			BeforeEach(func() {
-				otherBuild, err := otherJob.CreateBuild()
+				otherBuild, err := otherJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(otherBuild.ID()).NotTo(BeZero())
@@ -1118,7 +1287,7 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				build2DB, err = job.CreateBuild()
+				build2DB, err = job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(build2DB.ID()).NotTo(BeZero())
@@ -1149,7 +1318,7 @@ var _ = Describe("Job", func() {
 	Describe("EnsurePendingBuildExists", func() {
 		Context("when only a started build exists", func() {
 			BeforeEach(func() {
-				build1, err := job.CreateBuild()
+				build1, err := job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				started, err := build1.Start("some-engine", `{"some":"metadata"}`, atc.Plan{})
@@ -1158,19 +1327,35 @@ var _ = Describe("Job", func() {
 			})
 
 			It("creates a build", func() {
-				err := job.EnsurePendingBuildExists()
+				err := job.EnsurePendingBuildExists(db.BuildTriggerCause{})
+				Expect(err).NotTo(HaveOccurred())
+
+				pendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+			})
+
+			It("records the cause of the build", func() {
+				cause := db.BuildTriggerCause{
+					Type:     db.BuildTriggerInput,
+					Resource: "some-resource",
+					Version:  db.ResourceVersion{"ref": "abc"},
+				}
+
+				err := job.EnsurePendingBuildExists(cause)
 				Expect(err).NotTo(HaveOccurred())
 
 				pendingBuilds, err := job.GetPendingBuilds()
 				Expect(err).NotTo(HaveOccurred())
 				Expect(pendingBuilds).To(HaveLen(1))
+				Expect(pendingBuilds[0].TriggerCause()).To(Equal(cause))
 			})
 
 			It("doesn't create another build the second time it's called", func() {
-				err := job.EnsurePendingBuildExists()
+				err := job.EnsurePendingBuildExists(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
-				err = job.EnsurePendingBuildExists()
+				err = job.EnsurePendingBuildExists(db.BuildTriggerCause{})
 				Expect(err).NotTo(HaveOccurred())
 
 				builds2, err := job.GetPendingBuilds()
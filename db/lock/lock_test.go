@@ -312,7 +312,7 @@ var _ = Describe("Locks", func() {
 
 		BeforeEach(func() {
 			var err error
-			build, err = team.CreateOneOffBuild()
+			build, err = team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 		})
 
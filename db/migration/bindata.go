@@ -24,6 +24,90 @@
 // db/migration/migrations/1522176230_add_tags_to_jobs.up.sql
 // db/migration/migrations/1522178770_add_job_tags.down.sql
 // db/migration/migrations/1522178770_add_job_tags.up.go
+// db/migration/migrations/1523366312_add_scheduling_checkpoint_to_builds.down.sql
+// db/migration/migrations/1523366312_add_scheduling_checkpoint_to_builds.up.sql
+// db/migration/migrations/1523627153_create_versioned_resource_annotations.up.sql
+// db/migration/migrations/1523627153_create_versioned_resource_annotations.down.sql
+// db/migration/migrations/1523973214_create_build_step_states.up.sql
+// db/migration/migrations/1523973214_create_build_step_states.down.sql
+// db/migration/migrations/1524058691_create_build_event_checkpoints.up.sql
+// db/migration/migrations/1524058691_create_build_event_checkpoints.down.sql
+// db/migration/migrations/1524146375_add_builds_pipeline_id_start_time_index.up.sql
+// db/migration/migrations/1524146375_add_builds_pipeline_id_start_time_index.down.sql
+// db/migration/migrations/1524233412_add_trigger_cause_to_builds.up.sql
+// db/migration/migrations/1524233412_add_trigger_cause_to_builds.down.sql
+// db/migration/migrations/1524319807_add_codec_to_build_events.up.sql
+// db/migration/migrations/1524319807_add_codec_to_build_events.down.go
+// db/migration/migrations/1524406215_add_abort_cause_to_builds.up.sql
+// db/migration/migrations/1524406215_add_abort_cause_to_builds.down.sql
+// db/migration/migrations/1524493007_add_rerun_to_builds.up.sql
+// db/migration/migrations/1524493007_add_rerun_to_builds.down.sql
+// db/migration/migrations/1524493008_add_heartbeat_to_builds.up.sql
+// db/migration/migrations/1524493008_add_heartbeat_to_builds.down.sql
+// db/migration/migrations/1524493009_add_labels_to_pipelines.up.sql
+// db/migration/migrations/1524493009_add_labels_to_pipelines.down.sql
+// db/migration/migrations/1524493010_add_pipeline_destroyed_build_status.up.sql
+// db/migration/migrations/1524493010_add_pipeline_destroyed_build_status.down.sql
+// db/migration/migrations/1524493011_add_archived_from_to_builds.up.sql
+// db/migration/migrations/1524493011_add_archived_from_to_builds.down.sql
+// db/migration/migrations/1524493012_create_build_environments.up.sql
+// db/migration/migrations/1524493012_create_build_environments.down.sql
+// db/migration/migrations/1524493013_add_ephemeral_to_workers.up.sql
+// db/migration/migrations/1524493013_add_ephemeral_to_workers.down.sql
+// db/migration/migrations/1524493014_add_pressure_to_workers.up.sql
+// db/migration/migrations/1524493014_add_pressure_to_workers.down.sql
+// db/migration/migrations/1524493015_add_volume_gc_policy_columns.up.sql
+// db/migration/migrations/1524493015_add_volume_gc_policy_columns.down.sql
+// db/migration/migrations/1524493016_add_team_id_to_worker_resource_caches.up.sql
+// db/migration/migrations/1524493016_add_team_id_to_worker_resource_caches.down.sql
+// db/migration/migrations/1524493017_create_worker_warm_resource_types.up.sql
+// db/migration/migrations/1524493017_create_worker_warm_resource_types.down.sql
+// db/migration/migrations/1524493018_add_fetch_counts_to_worker_resource_caches.up.sql
+// db/migration/migrations/1524493018_add_fetch_counts_to_worker_resource_caches.down.sql
+// db/migration/migrations/1524493019_create_container_usage_samples.up.sql
+// db/migration/migrations/1524493019_create_container_usage_samples.down.sql
+// db/migration/migrations/1523714483_create_worker_maintenance_windows.up.sql
+// db/migration/migrations/1523714483_create_worker_maintenance_windows.down.sql
+// db/migration/migrations/1524493021_add_events_archived_to_builds.up.sql
+// db/migration/migrations/1524493021_add_events_archived_to_builds.down.sql
+// db/migration/migrations/1524493022_index_team_build_events.up.go
+// db/migration/migrations/1524493022_index_team_build_events.down.go
+// db/migration/migrations/1524493023_add_keyset_pagination_indexes.up.sql
+// db/migration/migrations/1524493023_add_keyset_pagination_indexes.down.sql
+// db/migration/migrations/1524493024_create_audit_events.up.sql
+// db/migration/migrations/1524493024_create_audit_events.down.sql
+// db/migration/migrations/1524493025_add_build_log_retention_to_pipelines.up.sql
+// db/migration/migrations/1524493025_add_build_log_retention_to_pipelines.down.sql
+// db/migration/migrations/1524493026_create_pipeline_config_revisions.up.sql
+// db/migration/migrations/1524493026_create_pipeline_config_revisions.down.sql
+// db/migration/migrations/1524493027_create_versioned_resource_fields.up.sql
+// db/migration/migrations/1524493027_create_versioned_resource_fields.down.sql
+// db/migration/migrations/1524493028_add_archived_at_to_pipelines.up.sql
+// db/migration/migrations/1524493028_add_archived_at_to_pipelines.down.sql
+// db/migration/migrations/1524493029_materialize_dashboard_builds_on_jobs.up.sql
+// db/migration/migrations/1524493029_materialize_dashboard_builds_on_jobs.down.sql
+// db/migration/migrations/1524493030_create_team_cache_usages.up.sql
+// db/migration/migrations/1524493030_create_team_cache_usages.down.sql
+// db/migration/migrations/1524493031_add_team_data_keys.up.sql
+// db/migration/migrations/1524493031_add_team_data_keys.down.go
+// db/migration/migrations/1524493032_create_resource_webhook_tokens.up.sql
+// db/migration/migrations/1524493032_create_resource_webhook_tokens.down.sql
+// db/migration/migrations/1524493033_create_resource_config_versions.up.sql
+// db/migration/migrations/1524493033_create_resource_config_versions.down.sql
+// db/migration/migrations/1524493034_create_resource_check_results.up.sql
+// db/migration/migrations/1524493034_create_resource_check_results.down.sql
+// db/migration/migrations/1524493035_create_worker_task_slots.up.sql
+// db/migration/migrations/1524493035_create_worker_task_slots.down.sql
+// db/migration/migrations/1524493036_add_last_used_at_to_volumes.up.sql
+// db/migration/migrations/1524493036_add_last_used_at_to_volumes.down.sql
+// db/migration/migrations/1524493037_add_stalled_at_to_workers.up.sql
+// db/migration/migrations/1524493037_add_stalled_at_to_workers.down.sql
+// db/migration/migrations/1524493038_add_replay_expires_at_to_containers.up.sql
+// db/migration/migrations/1524493038_add_replay_expires_at_to_containers.down.sql
+// db/migration/migrations/1524493039_key_audit_events_by_team_id.up.sql
+// db/migration/migrations/1524493039_key_audit_events_by_team_id.down.sql
+// db/migration/migrations/1524493040_add_unique_index_to_worker_resource_caches.up.sql
+// db/migration/migrations/1524493040_add_unique_index_to_worker_resource_caches.down.sql
 // DO NOT EDIT!
 
 package migration
@@ -571,6 +655,1686 @@ func _1522178770_add_job_tagsUpGo() (*asset, error) {
 	return a, nil
 }
 
+var __1523366312_add_scheduling_checkpoint_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x4e\xce\x48\x4d\x29\xcd\xc9\xcc\x4b\x8f\x07\xb2\x92\xb3\x0b\xf2\x33\xf3\x4a\xac\xb9\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x73\x20\x9c\x42\x47\x00\x00\x00")
+
+func _1523366312_add_scheduling_checkpoint_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523366312_add_scheduling_checkpoint_to_buildsDownSql,
+		"1523366312_add_scheduling_checkpoint_to_builds.down.sql",
+	)
+}
+
+func _1523366312_add_scheduling_checkpoint_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1523366312_add_scheduling_checkpoint_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523366312_add_scheduling_checkpoint_to_builds.down.sql", size: 71, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1523366312_add_scheduling_checkpoint_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x4e\xce\x48\x4d\x29\xcd\xc9\xcc\x4b\x8f\x07\xb2\x92\xb3\x0b\xf2\x33\xf3\x4a\x14\x4a\x52\x2b\x4a\xac\xb9\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x85\xda\x75\x41\x4b\x00\x00\x00")
+
+func _1523366312_add_scheduling_checkpoint_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523366312_add_scheduling_checkpoint_to_buildsUpSql,
+		"1523366312_add_scheduling_checkpoint_to_builds.up.sql",
+	)
+}
+
+func _1523366312_add_scheduling_checkpoint_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1523366312_add_scheduling_checkpoint_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523366312_add_scheduling_checkpoint_to_builds.up.sql", size: 75, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1523627153_create_versioned_resource_annotationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x91\xc1\x4e\xc3\x30\x10\x44\xef\xf9\x8a\x95\x2f\x6d\xa4\xfe\x41\x4e\xae\xbb\xa9\x2c\x1c\x1b\x1c\xe7\xd0\x53\x14\xd1\x05\x45\x84\x44\x72\x9c\x0a\xfe\x1e\x07\x1a\x0e\x90\x0a\x09\x1f\x77\x66\x35\x6f\xd6\x7b\x3c\x4a\x9d\x25\x00\xc2\x22\x77\x08\x8e\xef\x15\x02\xbb\x90\x1f\xdb\xa1\xa7\x73\xed\x69\x1c\x26\xff\x48\x75\xd3\xf7\x43\x68\x42\x9c\x8e\x0c\xb6\x71\x63\x7e\xac\x3d\x33\x18\xc9\xb7\x4d\xb7\x5b\x46\x2b\xbb\xb3\xab\xed\x03\x3d\x93\x07\x6d\x1c\xe8\x4a\xa9\x6f\x7f\xdf\xbc\x12\x83\x40\x6f\xe1\xb7\x76\x69\xba\xe9\xa6\x38\xf9\xee\x87\x04\x07\xcc\x79\xa5\x1c\x6c\x36\x8b\xeb\xde\xca\x82\xdb\x13\xdc\xe1\x09\xb6\x33\x6e\xba\x28\xc2\xe8\xd2\x59\x2e\xb5\xfb\xab\x6f\xbd\x5a\xa9\x7e\x7a\xa1\x77\x06\xb9\xb1\x28\x8f\xfa\x1a\xb0\x5e\x3e\x05\x8b\x39\x5a\xd4\x02\xcb\xb5\xb0\x91\x7d\xa1\x81\xd1\xb1\x82\xc2\xf8\x11\x82\x97\x82\x1f\xf0\x1f\xb0\xf3\x3d\xeb\x4f\xb4\x4a\xcb\x87\x0a\x6f\x52\xed\xae\xb7\x4f\x63\x46\x9a\x25\xc2\x14\x85\x74\x59\xf2\x01\xfa\x98\x6f\xef\x13\x02\x00\x00")
+
+func _1523627153_create_versioned_resource_annotationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523627153_create_versioned_resource_annotationsUpSql,
+		"1523627153_create_versioned_resource_annotations.up.sql",
+	)
+}
+
+func _1523627153_create_versioned_resource_annotationsUpSql() (*asset, error) {
+	bytes, err := _1523627153_create_versioned_resource_annotationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523627153_create_versioned_resource_annotations.up.sql", size: 531, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1523627153_create_versioned_resource_annotationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x2a\x4b\x2d\x2a\xce\xcc\xcf\x4b\x4d\x89\x2f\x4a\x2d\xce\x2f\x2d\x4a\x4e\x8d\x4f\xcc\xcb\xcb\x2f\x49\x2c\x01\x8a\x16\x2b\x59\x73\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\x40\x97\xea\x69\x3e\x00\x00\x00")
+
+func _1523627153_create_versioned_resource_annotationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523627153_create_versioned_resource_annotationsDownSql,
+		"1523627153_create_versioned_resource_annotations.down.sql",
+	)
+}
+
+func _1523627153_create_versioned_resource_annotationsDownSql() (*asset, error) {
+	bytes, err := _1523627153_create_versioned_resource_annotationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523627153_create_versioned_resource_annotations.down.sql", size: 62, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1523973214_create_build_step_statesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x75\x90\xcf\x0a\x83\x30\x0c\x87\xef\x3e\x45\xe8\x49\xc1\x37\xf0\x54\x6b\x94\xb2\xda\x8e\xda\x1d\x3c\x89\x63\xdd\x90\x89\xc8\xec\x60\x7b\xfb\x75\xa2\x08\xfb\x93\x43\x2e\xbf\x2f\x5f\x42\x52\x2c\xb8\x4c\x02\x00\xa6\x91\x1a\x04\x43\x53\x81\x40\x8e\xf7\xae\x3f\x35\x93\xb3\xa3\x6f\xad\xb3\x13\x81\xd0\x43\xef\x5a\xb2\xee\x44\xa0\x1b\x9c\xbd\xd8\x1b\x48\x65\x40\x1e\x84\x88\x57\x64\xec\xdb\x61\x26\x9c\x7d\xb8\xef\x78\x56\xfe\x09\xf7\x9a\x97\x54\xd7\xb0\xc3\x1a\xc2\x6d\x57\xbc\x49\xa3\x15\x65\x4a\x56\x46\x53\x2e\xcd\x8f\x83\x9b\x75\xb4\x39\x5f\xed\x93\x40\xae\x34\xf2\x42\x7e\x7a\x23\xd0\x98\xa3\x46\xc9\xb0\x5a\x2c\x13\x09\xc9\x9c\x28\x09\x19\x0a\xf4\x5f\x61\xb4\x62\x34\x43\xbf\x37\x4a\x02\xa6\xca\x92\x9b\x24\x78\x01\x83\x44\x81\x72\x3b\x01\x00\x00")
+
+func _1523973214_create_build_step_statesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523973214_create_build_step_statesUpSql,
+		"1523973214_create_build_step_states.up.sql",
+	)
+}
+
+func _1523973214_create_build_step_statesUpSql() (*asset, error) {
+	bytes, err := _1523973214_create_build_step_statesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523973214_create_build_step_states.up.sql", size: 315, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1523973214_create_build_step_statesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x4a\x2a\xcd\xcc\x49\x89\x2f\x2e\x49\x2d\x00\x12\x89\x25\xa9\xc5\x4a\xd6\x5c\xce\xfe\xbe\xbe\x9e\x21\xd6\x5c\x00\x8a\xd9\x3a\xca\x31\x00\x00\x00")
+
+func _1523973214_create_build_step_statesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523973214_create_build_step_statesDownSql,
+		"1523973214_create_build_step_states.down.sql",
+	)
+}
+
+func _1523973214_create_build_step_statesDownSql() (*asset, error) {
+	bytes, err := _1523973214_create_build_step_statesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523973214_create_build_step_states.down.sql", size: 49, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524058691_create_build_event_checkpointsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x90\xcd\x0a\x82\x40\x10\x80\xef\x3e\xc5\xb0\x27\x05\xdf\xc0\xd3\xba\x8e\xb2\xa4\xbb\xb1\x6e\x07\x4f\x82\xba\x95\x18\x16\xfe\x44\xbd\x7d\x56\x4a\x54\xd4\x5c\xe7\xe3\xfb\x98\xf1\x31\xe2\xc2\xb3\x00\x98\x42\xaa\x11\x34\xf5\x63\x04\x52\x8c\xf5\xa1\xca\xcd\xd9\xb4\x43\x5e\xee\x4d\xd9\x9c\x8e\x75\x3b\xf4\x04\xec\x09\xbd\xcf\x4c\xd4\x15\x81\x69\x61\x76\xa6\x03\x21\x35\x88\x4d\x1c\xbb\x0b\xd2\x8f\x45\x5f\x76\x75\x61\x3a\x02\x83\xb9\x0c\xdf\xc4\x33\xf0\x4f\xb2\x56\x3c\xa1\x2a\x83\x15\x66\x60\xbf\xa2\xee\x9b\xdd\x59\x68\x26\x45\xaa\x15\xe5\x42\xff\x3c\x21\x5f\x1c\xf9\xb6\x31\x57\x02\xa1\x54\xc8\x23\xf1\x19\x70\x40\x61\x88\x0a\x05\xc3\x74\x76\xf5\xc4\x26\x8f\x8d\x14\x10\x60\x8c\xd3\xb7\x18\x4d\x19\x0d\x70\xaa\x3b\x9e\xc5\x64\x92\x70\xed\x59\x37\x7d\x46\x3e\x6b\x53\x01\x00\x00")
+
+func _1524058691_create_build_event_checkpointsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524058691_create_build_event_checkpointsUpSql,
+		"1524058691_create_build_event_checkpoints.up.sql",
+	)
+}
+
+func _1524058691_create_build_event_checkpointsUpSql() (*asset, error) {
+	bytes, err := _1524058691_create_build_event_checkpointsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524058691_create_build_event_checkpoints.up.sql", size: 339, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524058691_create_build_event_checkpointsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x4a\x2a\xcd\xcc\x49\x89\x4f\x2d\x4b\xcd\x2b\x89\x4f\xce\x48\x4d\xce\x2e\xc8\xcf\xcc\x2b\x29\x56\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x98\xb4\x09\x62\x37\x00\x00\x00")
+
+func _1524058691_create_build_event_checkpointsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524058691_create_build_event_checkpointsDownSql,
+		"1524058691_create_build_event_checkpoints.down.sql",
+	)
+}
+
+func _1524058691_create_build_event_checkpointsDownSql() (*asset, error) {
+	bytes, err := _1524058691_create_build_event_checkpointsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524058691_create_build_event_checkpoints.down.sql", size: 55, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524146375_add_builds_pipeline_id_start_time_indexUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\x48\x2a\xcd\xcc\x49\x29\x8e\x2f\xc8\x2c\x48\xcd\xc9\xcc\x4b\x8d\xcf\x4c\x89\x2f\x2e\x49\x2c\x2a\x89\x2f\xc9\xcc\x4d\x55\xf0\xf7\x83\x2a\x50\x08\x0d\xf6\xf4\x73\x57\x48\x2a\x29\x4a\x4d\x55\xd0\x40\x52\xad\xa3\x80\x50\xae\x69\xcd\xe5\xec\xef\xeb\xeb\x19\x62\xcd\x05\x00\x83\x9d\xe8\xd8\x6d\x00\x00\x00")
+
+func _1524146375_add_builds_pipeline_id_start_time_indexUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524146375_add_builds_pipeline_id_start_time_indexUpSql,
+		"1524146375_add_builds_pipeline_id_start_time_index.up.sql",
+	)
+}
+
+func _1524146375_add_builds_pipeline_id_start_time_indexUpSql() (*asset, error) {
+	bytes, err := _1524146375_add_builds_pipeline_id_start_time_indexUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524146375_add_builds_pipeline_id_start_time_index.up.sql", size: 109, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524146375_add_builds_pipeline_id_start_time_indexDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x48\x2a\xcd\xcc\x49\x29\x8e\x2f\xc8\x2c\x48\xcd\xc9\xcc\x4b\x8d\xcf\x4c\x89\x2f\x2e\x49\x2c\x2a\x89\x2f\xc9\xcc\x4d\xb5\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x9e\x2f\xd1\x43\x3b\x00\x00\x00")
+
+func _1524146375_add_builds_pipeline_id_start_time_indexDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524146375_add_builds_pipeline_id_start_time_indexDownSql,
+		"1524146375_add_builds_pipeline_id_start_time_index.down.sql",
+	)
+}
+
+func _1524146375_add_builds_pipeline_id_start_time_indexDownSql() (*asset, error) {
+	bytes, err := _1524146375_add_builds_pipeline_id_start_time_indexDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524146375_add_builds_pipeline_id_start_time_index.down.sql", size: 59, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524233412_add_trigger_cause_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x57\xd1\x6e\x9b\x30\x14\x7d\xcf\x57\xdc\xb7\x12\xa9\x8a\xb4\xd7\x66\xad\x44\x83\xd3\x7a\x22\xd0\x11\xb2\xae\x9b\x26\x64\xc0\xcb\x9c\x11\x07\x81\x23\xb5\x7f\xbf\x4b\x02\xc3\x21\x90\x34\x5b\xa4\xed\xa1\x3c\x61\xdf\x73\xae\x2f\xf8\x9c\x8b\xb9\x25\x77\xd4\x19\xf6\x00\x4c\xdb\x27\x1e\xf8\xe6\xad\x4d\x20\x5c\x8b\x24\xce\xc1\xb4\x2c\x18\xb9\xf6\x6c\xe2\x80\xca\xc4\x7c\xce\xb3\x20\x62\xeb\x9c\xc3\x22\x5f\xc9\x61\x0f\x49\x96\xe7\x3e\xc0\xc4\x44\x26\x35\x6d\xfa\x85\x58\xf0\x89\x92\x47\x44\x33\x99\x0b\x25\x56\x32\xd8\xa6\x0a\x52\xe4\x2e\x56\xe1\xb0\x9b\x23\xf9\xb3\x7a\x3d\x3a\x61\x8a\xe7\x2a\x88\x56\xcb\x34\xe1\x8a\xc7\x7b\x4c\xa4\x8e\x3c\x82\xbc\xd3\xc9\x60\x4e\x91\x0d\x8f\xd4\xbf\xaf\xa0\x1b\x40\x20\x76\x30\x60\x14\xa8\xea\x9a\x12\x9b\x8c\x7c\x58\xb2\x67\x23\x0c\xde\x0d\x44\xdc\x2f\x20\x15\x4f\x47\x02\x8c\x3d\x77\x02\x46\xf9\x92\x11\xbd\x1b\x05\xf8\xe0\x52\x07\x70\x91\x1c\x16\xe0\x3a\x60\x18\x0b\xcc\x07\xd7\x05\x74\x80\xd3\x98\xaf\xdf\xef\xef\x90\x1e\xef\x89\x47\x60\xb3\x72\xae\x98\x5a\xe7\xf0\xfe\x06\x77\xd4\x06\xc3\xf4\x3c\xf3\xe9\xeb\x45\xca\x65\x2c\xe4\xfc\xe2\xea\x6a\x5b\xd2\x16\x75\x09\x17\x78\x93\xe1\x2b\x68\x04\xbe\x35\xf2\xdf\x79\xee\xec\x01\x6e\x9f\xb4\x12\xb4\xf8\x06\x5b\xbe\x80\x10\x4b\xbd\x2c\x63\xe1\x40\xb2\x25\xaf\x47\xe5\xa2\xf5\x38\xfa\xc1\xe3\x75\xc2\xe3\x1d\x48\xa6\x02\x25\x74\x1a\x96\xbe\x37\x33\x17\x72\x6f\x1c\x2c\xb9\x62\x31\x53\xac\x0e\xfc\xde\xe2\x7a\x6a\x5b\x7d\x3d\xce\x38\x4b\x1b\xe9\x15\x67\xcb\x1d\xcc\x92\xc9\x35\x4b\x92\x97\xa0\xb4\x81\x9e\x4f\x48\xc5\xb3\x88\xa7\x4a\x84\x89\x96\x43\xae\x64\xa4\x0d\xd3\x75\x98\x88\x28\x48\x13\x26\xb5\x49\x91\xf2\xa4\x28\x5c\x5f\x0b\xbd\x13\xfd\x2c\x34\xf9\xa2\xcf\x69\xee\xeb\xb5\x68\xa8\xa7\x2b\xa7\x53\xb3\xc9\x56\x4d\xc9\xa0\x0a\x15\x9a\x1a\xd4\x6a\xda\x28\xde\x71\xc1\x32\x7d\x73\x58\x1b\x68\xe6\xd0\x8f\x33\x02\xd4\xb1\xc8\xe7\x63\xde\x29\x92\xba\xce\x51\x87\xcd\xa6\xd4\xb9\x83\x50\x65\x9c\x83\x81\x05\x14\xab\x79\x64\xec\x91\xe9\xfd\xb9\xcd\xde\xd2\x57\xfe\xca\xe0\x42\xfe\x1f\x06\xbf\x06\xd3\x79\x7a\xf3\xf7\x9b\xbf\xcf\xeb\xef\x16\xbb\x94\x9e\x6e\x33\xd2\x69\x3e\x6e\xfd\xc4\x1f\xf2\x6e\xe7\x39\x42\x77\x70\x19\x09\xf9\xf7\x55\xc6\x83\x9a\xd2\xe5\xe0\x5a\xe2\x97\x0d\x5f\x36\x3e\xde\x38\x6c\xb3\xf5\x01\x5f\xdb\x64\xec\xef\x9b\xbb\x5e\x10\xf7\x63\x31\xd8\x37\xf7\x0e\xf3\x58\xeb\xcc\x5b\x92\xe6\x87\xbb\x46\xe3\x5c\x90\x97\xf7\xf8\x90\x8e\x75\xde\x43\xc3\x9f\x76\x15\x8b\x4e\x7d\xea\xe0\x4d\xf1\x6c\x95\x87\xfb\x6f\xcd\xe6\x9f\x36\x9b\x5a\x93\x9d\x16\xdb\x4a\xb1\x16\x62\x17\xb0\xa1\xcf\x4a\x97\x46\x27\x1e\xbd\x07\x74\x0a\xce\xcc\xb6\x2b\x99\x9e\x4f\xa4\xe0\x7a\x45\x3e\xac\xf8\x06\x0e\x55\x50\x56\xeb\x7a\x16\xfe\x19\x15\x32\xae\xf6\x7b\x23\xcc\x57\xb7\xd4\xce\x2e\x56\x36\xd6\xee\x2e\x77\x5a\x7b\x3d\xf0\xd7\x35\x72\x27\x13\xea\x0f\x7b\xbf\x00\xad\x1d\x14\xd7\xee\x0d\x00\x00")
+
+func _1524233412_add_trigger_cause_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524233412_add_trigger_cause_to_buildsUpSql,
+		"1524233412_add_trigger_cause_to_builds.up.sql",
+	)
+}
+
+func _1524233412_add_trigger_cause_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1524233412_add_trigger_cause_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524233412_add_trigger_cause_to_builds.up.sql", size: 3566, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524233412_add_trigger_cause_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x57\x5d\x6f\x9b\x30\x14\x7d\xcf\xaf\xb8\x6f\x25\x52\x14\x69\xaf\xcd\x5a\x89\x04\x27\xf5\x44\xa0\x73\xc8\xba\x6e\x9a\x90\x01\x2f\x73\x46\x1c\x04\x8e\xd4\xfe\xfb\x9a\xaf\x41\x08\x24\x4d\x57\x4d\x7b\x08\x4f\xb6\xef\xb9\x1f\xc6\xe7\x5c\xc3\x18\xcd\xb0\x35\xea\x01\x18\xc4\xbe\x87\xb9\xee\x20\x82\x75\x13\x7f\x43\x06\x7c\xc1\xe8\x01\x64\x4c\x45\xc2\x25\xdf\x0a\xd7\xdb\xf1\x30\x48\xdc\x88\xc5\xee\x7a\xeb\x1d\xf1\x11\xec\x49\xbe\x1e\x1d\x52\xc9\x12\xe9\xfa\xdb\x4d\x14\x32\xc9\x82\x03\x4f\xe5\x3a\x21\x48\xf9\x9d\xef\x0c\xfa\x42\x79\xc3\x03\x76\xee\x4a\x68\x06\x70\xf9\x1e\x06\xb4\x14\x55\x3e\x0b\x64\xa2\x89\x03\x1b\xfa\xa4\x79\xee\x87\x21\x0f\xfa\x29\xa4\xf4\xab\x23\x01\xa6\xc4\x9e\x83\x96\x27\x05\x85\xde\xb7\x02\x7c\xb2\xb1\x05\x2a\x49\x02\x6b\xb0\x2d\xd0\xb4\xb5\x8a\x07\x37\x29\x74\xa8\x96\x55\xbc\x7e\xbf\xbf\xe7\xf4\x70\x87\x08\x82\x2c\x73\x22\xa9\xdc\x25\xf0\xf1\x16\x74\xd3\x04\x4d\x27\x44\x7f\xfc\x7e\x15\x31\x11\x70\xb1\xba\xba\xbe\xce\x4b\xca\x51\x03\xb8\x52\x83\x58\xbd\x82\x86\xe1\x47\x23\xfe\x8c\xd8\xcb\x7b\x18\x3f\xd6\x4a\xa8\xd9\x33\x6c\xf1\x02\x3c\x55\xea\xa0\xb0\x79\x43\x41\x37\xac\x9a\x15\x49\xab\xb9\xff\x8b\x05\xbb\x90\x05\x7b\x90\x58\xba\x92\xd7\xdd\x54\xe9\x07\x2b\x2b\x2e\x0e\xe6\xee\x86\x49\x1a\x50\x49\x2b\xc3\x9f\x23\xae\x96\xf2\xea\xab\x79\xcc\x68\xd4\x08\x2f\x19\xdd\xec\x61\x36\x54\xec\x68\x18\x3e\xbb\x32\xe6\xab\x15\x8b\xeb\xf1\xb8\x90\x2c\xf6\x59\x24\xb9\x17\xd6\x62\x88\xad\xf0\x6b\xd3\x68\xe7\x85\xdc\x77\xa3\x90\x8a\xda\x22\x8f\x58\x98\x16\x5e\xcf\xa5\xb4\xe3\xff\x4e\x39\xf9\xdc\x6b\x21\x4b\xaf\x4e\x91\x4e\x72\x86\x39\x6d\xc2\x61\x69\x4a\xc9\x33\xac\x68\x93\x51\xdb\xb2\xc1\xd0\x1d\x7d\x54\x29\x65\x69\xe1\xcf\x4b\x04\xd8\x32\xd0\xd7\x53\x22\x49\x83\xda\xd6\x49\x29\x2d\x17\xd8\x9a\x81\x27\x63\xc6\x40\x53\x05\xa4\xd9\x08\x9a\x12\xb4\xb8\x7b\x6f\x55\xb7\x34\x90\xbf\x52\x32\x17\xff\x87\x92\x6f\x40\xb7\x1e\x2f\x42\xbe\x08\xf9\x8d\x42\x6e\xd1\x45\x21\xde\x36\xc5\x9c\x27\xd8\xd6\x4b\xfb\x98\x48\x3b\xbf\x0c\xea\x52\x2d\x2c\x1e\xfb\xb9\x8d\x99\x5b\xb9\x74\x49\xb5\xe2\xf2\xa0\x21\xc0\xc6\x75\xac\xa6\x6d\xfa\x3d\x22\x60\x13\x4d\x9d\x43\x15\x57\x09\xd5\x79\xac\x87\x87\x2a\xde\xf3\x3c\xd5\x23\x93\x96\xa0\xc9\xf1\xf6\xd0\xb8\xe9\x93\x62\xac\x36\x69\x19\xef\xfb\x19\xf0\xd6\xf6\x61\xe0\x85\x83\x2d\x35\x48\xf7\x56\x8a\xb5\x7f\xe9\x2a\xff\xa6\xab\x54\xe4\xeb\xd4\x52\xce\xb9\x8a\x71\x5d\xc0\x06\x11\x4b\x02\x6a\x9d\x78\x25\x32\xc0\x0b\xb0\x96\xa6\x59\xf2\xf1\xfd\xd8\x08\x36\x49\xe3\xa9\x8a\x6f\xe1\x58\x05\x45\xb5\x36\x31\x10\xc9\xf8\x5a\x1e\x6c\xc6\xc0\x57\xf7\xce\xce\x76\x55\x74\xd0\xee\x76\x76\x5e\x1f\x3d\xf2\xc3\xa4\xfc\x74\x53\xe1\xc1\xd1\xc7\x26\x2a\x36\x9d\xff\x16\x4d\x6c\x73\x39\x4f\x8b\xc8\x58\xe7\xfa\x74\x97\xb0\x51\x6f\x62\xcf\xe7\xd8\x19\xf5\x5e\x00\x6b\xd7\xb3\xd7\xa5\x0d\x00\x00")
+
+func _1524233412_add_trigger_cause_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524233412_add_trigger_cause_to_buildsDownSql,
+		"1524233412_add_trigger_cause_to_builds.down.sql",
+	)
+}
+
+func _1524233412_add_trigger_cause_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1524233412_add_trigger_cause_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524233412_add_trigger_cause_to_builds.down.sql", size: 3493, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524319807_add_codec_to_build_eventsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x89\x4f\x2d\x4b\xcd\x2b\x29\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\xce\x4f\x49\x4d\x56\x28\x49\xad\x28\xd1\x41\x15\xce\x2d\x28\x4a\x2d\x2e\x4e\x4d\x89\x2f\x48\xac\xcc\xc9\x4f\x4c\x51\x48\xaa\x2c\x49\x4d\xb4\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x02\x79\x24\xe7\x66\x00\x00\x00")
+
+func _1524319807_add_codec_to_build_eventsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524319807_add_codec_to_build_eventsUpSql,
+		"1524319807_add_codec_to_build_events.up.sql",
+	)
+}
+
+func _1524319807_add_codec_to_build_eventsUpSql() (*asset, error) {
+	bytes, err := _1524319807_add_codec_to_build_eventsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524319807_add_codec_to_build_events.up.sql", size: 102, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524319807_add_codec_to_build_eventsDownGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x54\x5d\x6f\xda\x30\x14\x7d\x8e\x7f\xc5\x5d\x34\x4d\xc9\x84\xc2\x68\x3b\xed\x43\xca\x03\x90\x4c\xab\x94\x02\x85\xa0\x3d\x54\x55\x14\x1c\xc3\xac\x26\x76\xe4\x38\x2b\xa8\xe2\xbf\xcf\x4e\x1c\x0a\x74\x0f\x68\x2f\x88\x7b\x7d\xcf\xbd\xe7\xf8\x1e\xa7\x4c\xf1\x53\xba\x21\x50\xd0\x8d\x48\x25\xe5\xac\x42\x88\x16\x25\x17\x12\xec\x0d\x95\xbf\xeb\x95\x87\x79\xd1\xc7\x9c\x61\x5e\x8b\x8a\xf4\x53\x89\xfb\xd9\x4a\x25\x8a\x52\x90\xaa\x52\x08\x1b\x21\xb9\x2b\x09\x74\x29\x92\x85\x7f\x08\x93\x50\x49\x51\x63\x09\x2f\xc8\x1a\xd5\x34\xcf\x6e\x03\xa0\x4c\x22\xab\x39\xec\x82\x31\xcf\x08\x06\xd0\xb5\x94\x6d\x90\x35\x4b\x77\x39\x4f\x33\x78\x78\x5c\xed\x24\x41\x7b\x84\xd6\x35\xc3\xe0\x54\x24\x5f\xc3\xc7\x57\x92\x2e\x04\xfc\x99\x25\x83\xcf\x57\x37\xd7\x83\x6f\x5f\x3f\x7d\x71\x5c\x20\x42\x70\xa1\xc7\xc9\x6d\x4f\x07\xf0\xdd\x07\x8d\xf3\x82\x91\x37\x22\x1b\xca\x1c\x17\x59\x74\xdd\x1c\xbd\xf3\x81\xd1\x5c\x17\x5b\x82\xc8\x5a\x30\x9d\x45\x96\x9a\x67\x65\x64\x4d\x04\xe8\xb1\xaa\xa7\x2e\x48\xc0\x07\xb9\xf5\xe6\x3c\xcf\x57\xea\xb6\x74\x97\xbd\xfa\x41\x96\xe0\xcf\xd5\x61\x92\xaa\xb8\xaf\x89\xd8\x39\xf6\x22\x8c\xc2\x71\x0c\x2b\x2d\x3a\xa1\x99\xaa\xd0\x8a\x9b\x7f\x58\xcb\xed\x1d\xdd\x54\x52\x1a\xc1\x3f\xe6\xd3\x3b\x03\x69\xca\x2b\xf8\xf5\x33\x9c\x87\x2d\x02\x6e\x17\x30\x99\xc6\x30\x59\x46\x91\x7d\x91\x08\xd3\x43\xf1\x7a\x78\x3c\xdb\xcb\xcb\x1e\x59\x6b\x75\x51\x9a\xbd\x37\x21\x5b\x69\x64\x36\x10\x8d\x78\x5b\xaf\x4f\xd5\x3c\xbf\xc5\x2c\x70\xca\x9c\x0f\x4d\xb9\x67\x36\xdb\x03\x13\x9b\xe5\x1e\xe2\x71\x2b\xd8\x44\x66\xbb\x4a\xc1\x3f\x24\x9c\x68\xb0\xda\xa1\xad\x0a\x1f\xd2\xb2\x24\x2c\x73\xda\xd8\x5c\xa8\xdb\x2a\xd5\x52\x12\x93\xd2\xec\x45\xca\x94\x9d\x0d\x52\xf7\x35\x77\x6e\xd6\x74\xe4\x5b\x2f\xe2\xfc\xa9\x2e\x9d\x23\xa6\x17\x33\x33\x6b\x3b\x6a\xab\xd0\x5e\x40\xba\xf6\xce\xff\x09\x4e\xda\x86\x8d\x9b\xc2\x2d\xc1\x8e\xbd\x9c\x05\xc3\x38\x3c\x75\xc6\x22\x8c\xa1\xf3\x8d\x0f\xef\x07\xc6\x2a\x9d\xe1\x74\xee\x0a\x86\x93\xe0\x60\x3c\x9d\xb9\xb6\x7b\xe6\x95\x39\x06\xeb\x9a\x5b\x7b\x5d\xe2\xc9\x0e\x2f\x23\xdd\xf0\x7e\x4b\x7b\x18\xc5\xe1\x1c\xe2\xe1\x28\x3a\xe3\x1e\xcc\xa7\x33\x18\x4f\xa3\xe5\xdd\xa4\x7b\x0d\xa7\xa9\xf3\x97\x71\x99\xdf\x4d\xac\xe6\x8f\x79\x51\x50\xe5\x69\xf5\xed\xf8\x0b\x86\xa4\x74\x46\xdc\x04\x00\x00")
+
+func _1524319807_add_codec_to_build_eventsDownGoBytes() ([]byte, error) {
+	return bindataRead(
+		__1524319807_add_codec_to_build_eventsDownGo,
+		"1524319807_add_codec_to_build_events.down.go",
+	)
+}
+
+func _1524319807_add_codec_to_build_eventsDownGo() (*asset, error) {
+	bytes, err := _1524319807_add_codec_to_build_eventsDownGoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524319807_add_codec_to_build_events.down.go", size: 1244, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524406215_add_abort_cause_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x57\xd1\x6e\x9b\x30\x14\x7d\xcf\x57\xdc\xb7\x12\xa9\x8a\xb4\xd7\x66\xad\x44\x83\xdb\x7a\x22\xa6\x23\x64\x5d\x37\x4d\xc8\x04\x2f\x73\x46\x0c\x02\x47\x6a\xff\xbe\x26\xc0\xec\x10\x48\x9b\xad\x9a\xfa\xd0\x3c\x61\xdf\x73\xee\xbd\xc0\x39\x37\xe6\x12\x5d\x63\x32\x1e\x00\xd8\x6e\x80\x7c\x08\xec\x4b\x17\x41\xb4\xe1\x49\x5c\x80\xed\x38\x30\xf1\xdc\xf9\x94\x00\x8d\xd2\x5c\x86\x0b\xba\x29\x18\xac\x8a\x54\x8c\x07\x8a\xe2\xf8\xde\x2d\x4c\x6d\xc5\xc3\xb6\x8b\xbf\x21\x07\xbe\x60\x74\x07\x32\xa7\xa2\xe0\x92\xa7\x22\xac\x12\x85\x19\xcb\xc3\x55\x1a\x8d\xfb\x39\x82\x3d\xc8\x97\xa3\x13\x2a\x59\xa1\xda\x49\xd7\x59\xc2\x24\x8b\xf7\x98\x8a\x3a\xf1\x91\xe2\x1d\x4f\x06\x7b\xa6\xd8\x70\x87\x83\x9b\x06\xba\x05\x84\x7c\x07\x03\x56\x89\x6a\x7e\x33\xe4\xa2\x49\x00\x6b\xfa\x60\x45\xe1\x87\x11\x8f\x87\x25\xa4\xe1\x99\x48\x80\x2b\xdf\x9b\x82\x55\x3f\x62\x85\xde\x8d\x02\x7c\xf2\x30\x01\x55\xa4\x80\x15\x78\x04\x2c\x6b\xa5\xf2\xc1\x79\x09\x1d\xa9\x6d\x95\x6f\x38\x1c\xee\x90\xee\x6e\x90\x8f\x60\x5b\xb9\x90\x54\x6e\x0a\xf8\x78\xa1\xde\xa7\x0b\x96\xed\xfb\xf6\xfd\xf7\x93\x8c\x89\x98\x8b\xe5\xc9\xd9\x59\xd5\x52\x85\x3a\x85\x13\x75\x91\xab\x47\xd0\x0a\xfc\x68\xe5\xbf\xf6\xbd\xf9\x2d\x5c\xde\x1b\x2d\x18\xf1\x2d\xb6\x7e\x00\x91\x6a\xf5\xb4\x8e\x45\x23\x41\xd7\x4c\xaf\xea\xa2\x7a\xbd\xf8\xc5\xe2\x4d\xc2\xe2\x1d\x88\x52\x99\xe4\x26\x4d\xb5\xbe\xb7\xb3\xe4\x62\x6f\x1d\xae\x99\xa4\x31\x95\x54\x07\xfe\xbc\x62\xbd\x55\x75\xaf\xd7\x39\xa3\x59\x2b\xbd\x64\x74\xbd\x83\x59\x53\xb1\xa1\x49\xf2\x18\xca\x9c\x2f\x97\x2c\x37\xf3\x71\x21\x59\xbe\x60\x99\xe4\x51\x62\xe4\x10\xa9\x58\x18\xcb\x6c\x13\x25\x7c\x11\x66\x09\x15\xc6\x26\xcf\x58\x52\x36\x6e\xd6\x52\xde\x59\xfc\x2e\x35\xf9\x68\xee\x6d\xcb\x56\xee\xd3\xdb\x86\x25\x07\x1d\xc2\x1a\x98\x72\xea\x15\x72\x52\x49\x2c\x19\x35\xa1\x52\x68\x23\x2d\xb1\xad\x0d\x88\x07\x8e\x1d\xd8\x63\xed\xaa\x39\xc1\x9f\xe7\x08\x30\x71\xd0\xd7\xe7\x0c\x55\x26\xf5\xc8\xb3\xb6\x9b\xcf\x30\xb9\x86\x48\xe6\x8c\x81\xa5\x1a\x28\xab\xf9\xe8\xca\x47\xb3\x9b\xd7\x9e\x00\x1d\xc3\xe6\x9f\x5c\xcf\xc5\xdb\x70\xfd\x39\xd8\xe4\xfe\xdd\xf4\xef\xa6\xff\x0f\xa6\xef\xf0\x50\x6d\xf4\x2e\x77\x1d\x67\xee\xce\xc3\xc0\x21\x43\xf7\x9e\x38\x4c\x5b\xd7\x91\x88\xfd\x4c\x73\x16\x6a\x4a\x9f\xad\xb5\xee\x4f\x5b\x66\x6d\xfd\xcd\xab\x65\x97\xd7\x0f\x98\xdd\x45\x57\xc1\xbe\xe3\x75\x41\xf5\x3e\x56\xa3\x7d\xc7\xef\x30\x9f\x9b\xa7\x45\x47\xd2\xe2\xf0\x28\x69\x9d\x20\x8a\xfa\x5a\xdd\x24\x71\x5e\xf7\x78\xf1\xb7\xa3\xc6\xc1\xb3\x00\x13\x75\x51\xde\x5b\x63\xec\xe1\xfb\x04\x7a\x7b\x13\x48\x0b\xb5\xd7\x77\x95\x3e\xb5\x3a\xfb\x80\x2d\xd1\x36\x62\xb5\x7a\xf1\xca\x90\x80\x67\x40\xe6\xae\xdb\x68\xf7\xf5\x94\x0b\x9e\x5f\xe6\x53\x1d\x5f\xc0\xa1\x0e\xea\x6e\x3d\xdf\x51\x9f\x55\xa5\xb6\x1b\x11\x6c\xd5\xfa\xe2\x39\xdb\x3b\xda\xea\x69\xdb\x3f\xfa\x8e\x9b\xb9\x07\x3e\xda\x26\xde\x74\x8a\x83\xf1\xe0\x09\xfe\x8f\xa1\x6c\x2b\x0e\x00\x00")
+
+func _1524406215_add_abort_cause_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524406215_add_abort_cause_to_buildsUpSql,
+		"1524406215_add_abort_cause_to_builds.up.sql",
+	)
+}
+
+func _1524406215_add_abort_cause_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1524406215_add_abort_cause_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524406215_add_abort_cause_to_builds.up.sql", size: 3627, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524406215_add_abort_cause_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x57\x5d\x6f\x9b\x30\x14\x7d\xcf\xaf\xb8\x6f\x25\x52\x15\x69\xaf\xcd\x5a\x89\x06\xa7\xf5\x44\x4c\xe7\x90\x75\xdd\x34\x21\x03\x5e\xe6\x8c\x38\x08\x1c\xa9\xfd\xf7\x33\x5f\x83\x10\x48\x9a\x2d\xd2\xf6\x50\x9e\x6c\xdf\x73\x3f\x8c\xcf\xb9\x98\x5b\x74\x87\xc9\x78\x00\x60\x51\xe7\x01\x66\xa6\x8b\x28\x36\x6d\xfc\x05\x59\xf0\x09\xa3\x47\x50\x09\x93\xa9\x50\x62\x23\x3d\x7f\x2b\xa2\x30\xf5\x62\x9e\x78\xab\x8d\x7f\xc0\x47\xf2\x67\xf5\x7a\x74\xc4\x14\x4f\x95\x17\x6c\xd6\x71\xc4\x15\x0f\xf7\x3c\xb5\xeb\x84\x22\xed\x77\xba\x33\x98\x73\xed\x0d\x8f\xd8\xbd\xaf\xa0\x39\xc0\x13\x3b\x18\x30\x32\x54\xf5\xcc\x91\x8d\x26\x2e\xac\xd9\xb3\xe1\x7b\xef\x46\x22\x1c\x66\x90\xca\xaf\x89\x04\x98\x52\x67\x06\x46\x91\x14\x34\x7a\xd7\x0a\xf0\xc1\xc1\x04\x74\x92\x14\x56\xe0\x10\x30\x8c\x95\x8e\x07\xd7\x19\x74\xa4\x97\x75\xbc\xe1\x70\xb8\xe3\xf4\x78\x8f\x28\x82\x3c\x73\xaa\x98\xda\xa6\xf0\xfe\x06\x4c\xdb\x06\xc3\xa4\xd4\x7c\xfa\x7a\x11\x73\x19\x0a\xb9\xbc\xb8\xba\x2a\x4a\x2a\x50\x97\x70\xa1\x07\x89\x7e\x05\x2d\xc3\xb7\x56\xfc\x3b\xea\x2c\x1e\xe0\xf6\xa9\x51\x42\xc3\x9e\x63\xcb\x17\xe0\xeb\x52\x2f\x4b\x9b\x3f\x92\x6c\xcd\xeb\x59\x99\xb4\x9e\x07\x3f\x78\xb8\x8d\x78\xb8\x03\x49\x94\xa7\x44\xd3\x4d\x97\xbe\xb7\xb2\x14\x72\x6f\xee\xad\xb9\x62\x21\x53\xac\x36\xfc\x3e\xe2\x7a\xa9\xa8\xbe\x9e\x27\x9c\xc5\xad\xf0\x8a\xb3\xf5\x0e\x66\xcd\xe4\x96\x45\xd1\x8b\xa7\x12\xb1\x5c\xf2\xa4\x19\x4f\x48\xc5\x93\x80\xc7\x4a\xf8\x51\x23\x86\xdc\xc8\xa0\x31\x8d\xb7\x7e\x24\x02\x2f\x8e\x98\x6c\x2c\x8a\x98\x47\x59\xe1\xcd\x5c\x5a\x3b\xc1\xcf\x8c\x93\x2f\xcd\xb5\x3c\xad\x17\xb0\x6d\xca\x07\x1d\x1c\x1a\x34\x99\xd3\xcb\xd9\xa8\x60\x53\x34\xaa\x4c\x19\xa7\x46\x35\x9b\x72\xc6\x13\x07\x2c\xd3\x35\xc7\xb5\x80\x16\x04\x7f\x5c\x20\xc0\xc4\x42\x9f\x8f\x69\x27\x0b\xea\x90\xa3\x0a\x5b\xcc\x31\xb9\x03\x5f\x25\x9c\x83\xa1\x0b\xc8\xb2\x51\x34\xa5\x68\x7e\x7f\x6e\xb1\x77\xf4\x95\xbf\x12\xb8\x90\xff\x87\xc0\xaf\xc1\x24\x4f\x6f\xfa\x7e\xd3\xf7\x79\xf5\xdd\x21\x97\x52\xd3\x5d\x42\x3a\x4d\xc7\x9d\x9f\xf8\x43\xda\xed\xbd\x47\x34\x15\x5c\x5a\x7c\xfe\x7d\x93\x70\xaf\x76\xe9\x53\x70\x4d\xf1\xcb\x96\x2e\x5b\x1f\x6f\x3d\xed\x92\xf5\x01\x5d\xdb\x68\xea\xee\x8b\xbb\x4e\xa8\xcf\x63\x35\xda\x17\xf7\x8e\xe7\xb1\xd6\x99\x76\x04\x4d\x0f\x77\x8d\xd6\xbd\x20\x2d\xc7\x7a\x93\xc4\x3a\xef\xa5\xe1\x4f\xbb\x8a\x85\xe7\x2e\x26\x7a\x90\xed\xad\xd2\xf0\xf0\xad\xd9\xfc\xd3\x66\x53\x73\xb2\x57\x62\x05\x15\x6b\x22\xf6\x01\x5b\xfc\xac\x78\x69\xf4\xe2\xb5\xf6\x00\xcf\x81\x2c\x6c\xbb\xa2\xe9\xf9\x48\x0a\x0e\xcd\xe2\xe9\x8a\x6f\xe0\x50\x05\x65\xb5\x0e\xb5\x10\xcd\x69\x5c\x9d\x77\x4e\xcc\x57\xb7\xd4\xde\x2e\x56\x36\xd6\xfe\x2e\x77\x5a\x7b\x3d\xf0\xd7\xa5\xfd\x4c\x5b\xe3\xc1\x35\x6f\x6d\x54\x6e\xba\xf8\xb7\x9a\x38\xf6\x62\x46\x80\xf9\x1b\xad\x95\x9c\x13\xe3\xc1\xc4\x99\xcd\xb0\x3b\x1e\xfc\x02\x96\x09\x51\x0a\xe8\x0d\x00\x00")
+
+func _1524406215_add_abort_cause_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524406215_add_abort_cause_to_buildsDownSql,
+		"1524406215_add_abort_cause_to_builds.down.sql",
+	)
+}
+
+func _1524406215_add_abort_cause_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1524406215_add_abort_cause_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524406215_add_abort_cause_to_builds.down.sql", size: 3560, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493007_add_rerun_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x57\x4d\x6f\x9b\x40\x10\xbd\xfb\x57\xcc\x2d\x58\xb2\x2c\xf5\x1a\x37\x91\x88\xd9\x24\x54\x18\x52\xc0\x4d\xd3\xaa\x42\x0b\x6c\x5c\x5c\x58\x10\x2c\x52\xf2\xef\x3b\x8b\xa1\x60\x0c\x4e\xd2\xe6\x90\x4a\xe1\xc4\xce\xce\x17\xb3\xef\x3d\x2d\x17\xe4\x4a\x37\x17\x13\x00\xd5\x70\x89\x0d\xae\x7a\x61\x10\xf0\xcb\x28\x0e\x0b\x34\xa2\x59\xd3\x60\x69\x19\xeb\x95\x09\x39\xcb\x4b\xee\xa5\xf7\x10\x71\xc1\x36\x2c\x07\x9b\x5c\x12\x9b\x98\x4b\xe2\xd4\x11\xa0\x44\xe1\x14\x2c\x13\x34\x62\x10\x97\x80\x43\x5c\x30\xd7\x86\x31\x1b\x4e\x75\x9f\xa7\x09\x08\xf6\x20\x16\x13\x74\xd0\x6c\xeb\x06\x56\x2a\x76\xa1\xab\x86\xfe\x8d\x68\xf0\x45\x27\xb7\x20\x72\xca\x8b\x48\x44\x29\xf7\x76\x45\xbc\x8c\xe5\xde\x36\xf5\x17\xe3\x31\x1c\x73\x3e\xdf\x3b\xa6\x82\x15\xc2\x0b\xd2\x24\x8b\x99\x60\xe1\x41\x24\x86\x2e\x6d\x82\x71\x2f\x0f\x06\xd5\x91\xdf\x7e\xab\xbb\xd7\x8d\x6b\xe5\xe0\x45\x7b\x3e\xa0\x54\x13\xaa\x1f\x07\xa7\xb7\x74\x21\xa1\x0f\x8a\xef\x7d\x98\xcb\x99\xaa\xf5\x88\x31\xae\xeb\x09\x70\x69\x5b\x2b\x50\xea\xf1\xa3\xf7\xfe\x2e\xc0\x27\x4b\x37\x01\x8b\x14\xb0\x95\x07\xa3\x28\x5b\xcc\x07\x67\xd2\x75\x8e\x66\xcc\x37\x9d\x4e\xf7\x82\x6e\xaf\xf1\x50\xa1\xaa\x5c\x08\x2a\xca\x02\x3e\x9e\x23\x3a\x0c\x50\x54\xdb\x56\xef\xbe\x9f\x64\x8c\x87\x11\xdf\x9c\x9c\x9e\xee\x5a\xda\x79\xcd\xe0\x04\x5f\x72\x1c\x41\x6f\xe3\x47\x2f\xff\x95\x6d\xad\x6f\xe0\xe2\xae\xd3\x42\x67\xbf\xf2\xad\x07\xe0\x63\xab\xb3\x7a\xcf\x9f\x73\x9a\xb0\x76\x55\x17\x6d\xd7\xc1\x4f\x16\x96\x31\x0b\xf7\x5c\x72\xe1\x89\xa8\x1b\x86\xad\x1f\x58\x36\x11\x3f\x58\x7b\x09\x13\x34\xa4\x82\xb6\x1b\x7f\x8e\xb8\x35\xed\xba\x6f\xd7\x39\xa3\x59\x2f\xbd\x60\x34\xd9\xf3\x49\x28\x2f\x69\x1c\x3f\x7a\x22\x8f\x36\xc8\xa2\x6e\x3e\x49\xac\x3c\x60\x99\x88\xfc\xb8\x93\x83\xa7\x3c\xe8\x2c\xb3\xd2\x8f\xa3\xc0\xcb\x62\xca\x3b\xc6\x28\x63\xb1\x6c\xbc\x5b\x0b\xb9\x13\xfc\x92\x98\x7c\xec\xda\xaa\xb2\x5e\x40\xcb\xa2\x93\x94\xfa\x29\x0e\xab\x67\x6c\x18\xdf\xb7\x48\xe2\x4e\x06\xe0\x37\xe9\x82\x6e\x14\xee\xf1\x0e\x88\xf1\xbc\xd9\x92\x70\x9c\xb7\x40\xac\xc8\x62\x5a\xa0\xa9\xae\xba\x68\xb9\xb7\x36\xf5\xcf\x6b\x02\xba\xa9\x91\xaf\x4f\xd1\x4e\x26\xb5\xcc\x27\xc9\xb9\x76\x74\xf3\x0a\x7c\x91\x33\x56\x49\x97\xac\x86\xa2\x66\x13\xe7\xfa\xb5\x75\x62\x40\x92\xfe\x49\x1b\x22\xfe\x36\xb4\xe1\x0c\x54\xf3\xee\x5d\x1a\xde\xa5\xe1\xcd\x48\xc3\x00\xd3\x6a\x39\x18\xe2\xe0\xcb\x24\x60\xf0\x62\x71\x8c\xf6\xa3\xb7\x97\x2e\xf9\xeb\x1d\x9f\xdd\xa7\x39\xf3\xda\x90\x31\xf2\xb7\xec\x98\xf5\x28\xdd\xbb\x32\xe0\x72\x48\x11\x8e\x48\x82\x41\x2e\xdd\x43\x5d\x68\x0b\xe2\x79\x6c\xe7\x87\xba\xb0\x17\xf9\x94\xea\x16\x03\x49\x8b\xe3\x82\xd3\xbb\x8d\x14\xf5\x3b\x7e\xa4\xa9\xbd\xee\x55\xe5\x6f\x05\x49\xd3\x1d\x57\x37\xf1\x45\x7e\x5b\x43\xff\xe9\xbb\x4e\xfd\xaf\x3a\xd5\xc2\x79\x94\x9d\x3b\x14\xb7\x18\x1e\x73\xec\x41\xbb\x81\xb4\x32\xea\x8f\xb4\x05\xdd\xa9\xfe\x9c\x1a\x84\xbf\x1e\xbe\xc1\xb2\x65\x3e\xec\xf8\x1c\x8e\x75\x50\x77\x6b\xd9\x1a\xfe\x16\x4a\x06\x34\x50\xa9\x30\xfd\x6c\x35\x1e\x15\xc0\x5a\x93\xc7\x05\xf2\x65\xca\x7c\xe4\x37\x71\x69\xad\x56\xba\xbb\x98\xfc\x06\xd0\x97\xb7\xab\xeb\x0e\x00\x00")
+
+func _1524493007_add_rerun_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493007_add_rerun_to_buildsUpSql,
+		"1524493007_add_rerun_to_builds.up.sql",
+	)
+}
+
+func _1524493007_add_rerun_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1524493007_add_rerun_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493007_add_rerun_to_builds.up.sql", size: 3819, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493007_add_rerun_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x57\x5d\x6f\x9b\x30\x14\x7d\xcf\xaf\xb8\x6f\x25\x52\x14\x69\xaf\xcd\x5a\x89\x06\xa7\x65\x22\xd0\x39\x64\x5d\x36\x4d\x96\x01\x37\x73\x06\x06\x81\x91\xda\x7f\x3f\x43\x60\x10\x02\x69\xbb\x55\x53\x1f\x9a\x27\xfb\xde\x73\x3f\x0c\xe7\xdc\x98\x2b\x74\x6d\xda\xb3\x11\x80\x81\x9d\x5b\x58\xea\x2e\xc2\xa6\x6e\x99\xdf\x90\x01\x5f\x4c\x74\x07\x32\xa5\x22\xe3\x92\xc7\x82\x78\x39\x0f\x83\x8c\x24\x2c\x25\xbb\xd8\x3b\x11\x23\xd8\x83\x7c\x3e\x3a\xa4\x92\x65\x92\xf8\x71\x94\x84\x4c\xb2\xe0\x28\x52\x85\xce\x31\x52\x71\x2f\x0f\x06\x7d\xa5\xa2\xe1\xce\x74\x6f\x6a\x68\x09\x20\xfc\x00\x03\x5a\x81\xaa\x7f\x2b\x64\xa1\xb9\x0b\x11\x7d\xd0\x3c\xf2\x61\xca\x83\x71\x01\xa9\xe3\xda\x48\x80\x05\x76\x96\xa0\xed\x8b\x82\x42\x1f\x7a\x01\x3e\x39\xa6\x0d\xaa\x48\x06\x3b\x70\x6c\xd0\xb4\x9d\xca\x07\x17\x05\x74\xaa\xcc\x2a\xdf\x78\x3c\x3e\x08\xba\xbb\x41\x18\x41\x59\x39\x93\x54\xe6\x19\x7c\xbc\x04\xdd\xb2\x40\xd3\x31\xd6\x37\xdf\xcf\x12\x26\x02\x2e\xb6\x67\xe7\xe7\xfb\x96\xf6\xa8\x09\x9c\xa9\x45\xaa\x1e\x41\xc7\xf1\xa3\x93\xff\x1a\x3b\xeb\x5b\xb8\xda\xb4\x5a\x68\xf9\x4b\x6c\xf5\x00\x3c\xd5\xea\xa4\xf2\x79\x53\x41\x23\xd6\xec\xaa\xa2\xcd\xde\xff\xc9\x82\x3c\x64\xc1\x01\x24\x95\x44\xf2\x76\x98\x6a\xfd\xc8\xb2\xe5\xe2\x68\x4f\x22\x26\x69\x40\x25\x6d\x1c\x7f\x5e\x71\x63\xda\x77\xdf\xec\x53\x46\x93\x4e\x7a\xc9\x68\x74\x80\x89\xa8\xc8\x69\x18\x3e\x12\x99\xf2\xed\x96\xa5\xed\x7c\x5c\x48\x96\xfa\x2c\x91\xdc\x0b\x5b\x39\x44\x2c\xfc\xd6\x36\xc9\xbd\x90\xfb\x24\x09\xa9\x68\x19\x79\xc2\xc2\xa2\xf1\x76\x2d\xa5\x1d\xff\x57\xc1\xc9\xc7\xb6\xad\x2c\x4b\x7c\x9a\x67\xad\xa4\xd4\x8b\xd5\xc3\x2a\x8d\xa3\x1e\x62\x8d\xda\x74\x1a\x24\x72\xb8\xa7\x58\x38\xad\x5d\x05\xd1\xa6\x0d\xc5\x4a\x19\xd8\x0e\x18\xba\xab\xcf\x1a\x55\xad\x6d\xf3\xf3\x1a\x81\x69\x1b\xe8\xeb\x53\x82\x2a\x92\x3a\xf6\x93\xb2\x5b\xaf\x4c\xfb\x1a\x3c\x99\x32\x06\x9a\x6a\xa0\xa8\x86\xd1\x02\xa3\xd5\xcd\x6b\x4f\x80\x9e\x61\xf3\x4f\xaa\xe7\xe2\x6d\xa8\xfe\x02\x74\x7b\xf3\x2e\xfa\x77\xd1\xff\x07\xd1\xf7\x68\xa8\x12\x7a\x9f\xba\x5e\x26\xee\xde\xcb\xc0\x29\x41\x0f\xde\x38\xda\xb2\xae\x3c\x1e\xbb\x8f\x53\x46\x9a\x90\x21\x59\x37\xbc\x9f\x74\xc4\xda\xf9\x9b\x57\xdb\x3e\xad\x9f\x10\xbb\x85\x16\xee\xb1\xe2\x9b\x82\xea\x7d\xec\xa6\xc7\x8a\x3f\x88\x7c\x6a\x9e\x66\x3d\x49\xb3\xd3\xa3\xa4\x73\x83\xc8\xaa\xb5\x3a\xa4\x6d\xbc\xee\xf5\xe2\x6f\x47\x8d\x61\xae\x5c\xd3\x56\x8b\xe2\x6c\xb5\xb0\xc7\xef\x13\xe8\xed\x4d\xa0\x86\xa8\x83\xba\xdb\xf3\xb3\x61\xe7\x10\xb0\x43\xda\x9a\xac\xda\x20\x5e\x09\x12\xcc\x15\xd8\x6b\xcb\xaa\xb9\xfb\x7a\xcc\x05\x07\x17\xf9\x54\xc7\x97\x70\xaa\x83\xaa\x5b\x07\x1b\x08\x97\xdc\xae\x49\x50\xb2\xf5\xd9\x73\x76\x70\xb4\x55\xd3\x76\x78\xf4\xbd\x6c\xe6\x9e\xf8\x68\x53\x71\xba\xa5\xf0\xe0\xea\x57\x16\xaa\x0e\x5d\x1e\xa0\xfc\x3c\x9b\x3b\xd6\x7a\x69\x43\xca\xd2\x5c\x90\xf8\x7e\x32\xe0\xb9\x4f\xe3\x68\x36\x9a\x3b\xcb\xa5\xe9\xce\x46\xbf\x01\x81\x9b\xb5\x09\x44\x0e\x00\x00")
+
+func _1524493007_add_rerun_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493007_add_rerun_to_buildsDownSql,
+		"1524493007_add_rerun_to_builds.down.sql",
+	)
+}
+
+func _1524493007_add_rerun_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1524493007_add_rerun_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493007_add_rerun_to_builds.down.sql", size: 3652, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493008_add_heartbeat_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x57\xdd\x6e\x9b\x30\x18\xbd\xcf\x53\x7c\x77\x25\x52\x14\x69\xb7\xcd\x5a\x89\x06\xa7\x65\x22\xd0\x11\x58\xd6\x4d\x13\x32\xc1\x49\x9c\x81\x41\xc6\xd1\xda\x3d\xfd\x0c\x81\x9a\x90\x90\xb6\x5b\x2f\xb6\xa9\x5c\xe1\xef\x1f\x73\xce\x91\x7d\x85\xae\x4d\x7b\xd4\x03\xd0\x2d\x0f\xb9\xe0\xe9\x57\x16\x82\x70\x4b\xe3\x28\x97\x46\x69\x36\x0c\x18\x3b\x96\x3f\xb5\x61\x4d\x30\x17\x21\xc1\x02\x04\x4d\x48\x2e\x70\x92\xc1\x0f\x2a\xd6\xe5\x12\x7e\xa6\x8c\x0c\xda\x29\x29\xcf\xd6\x98\x91\x08\xc2\x34\x8d\x09\x66\x60\x3b\x1e\xd8\xbe\x65\x81\x81\x26\xba\x6f\x79\xb0\xc4\x71\x4e\x46\x3d\x99\x68\xb8\xce\x2d\x4c\x75\x39\x85\xa9\x5b\xe6\x17\x64\xc0\x27\x13\xcd\x41\x70\xcc\x72\x2a\x68\xca\x82\xdd\x58\x41\x46\x78\xb0\x49\xc3\x51\x77\x0e\x23\xf7\xe2\xf9\xd1\x31\x16\xf2\x6b\x82\x45\x9a\x64\x31\x11\x24\x3a\xc8\x94\xa9\x63\x17\xc9\xbc\x97\x27\x83\x3e\x2b\xf6\x64\x6e\x7a\x37\x75\x68\x19\x10\xd0\xbd\x18\xd0\xca\x9d\xab\x9e\x19\xb2\xd0\xd8\x83\x04\xdf\x6b\x61\xf0\x6e\x48\xa3\x7e\x11\x52\xe7\x35\x23\x01\x26\xae\x33\x05\x6d\xd7\x14\x64\xf4\xbe\x17\xe0\x83\x63\xda\x20\x9b\xe4\xb0\x01\xc7\x06\x4d\xdb\xc8\x7a\x70\x51\x84\x0e\xa5\x59\xd6\xeb\xf7\xfb\x7b\x49\xf3\x1b\xe4\x22\x28\x3b\xcb\x7f\x2c\xb6\x39\xbc\xbf\x94\xe8\xb0\x40\xd3\x5d\x57\xbf\xfb\x7a\x96\x11\x16\x51\xb6\x3a\x3b\x3f\xdf\x8d\xb4\x8b\x1a\xc0\x99\x7c\xe1\x72\x0b\x5a\x8e\x6f\xad\xfa\xd7\xae\xe3\xdf\xc2\xd5\x5d\x63\x84\x86\xbf\x8c\xad\x36\x20\x94\xa3\x0e\x2a\x5f\x38\x64\x38\x21\x6a\x55\x35\x55\xeb\xc5\x9a\x44\xdb\x98\x44\x7b\x21\x5c\x04\x05\x38\x95\x4d\x8e\x7e\x60\x59\x51\x76\xb0\x0e\x12\x22\x70\x84\x05\x56\x8e\xc7\x5f\xac\x4c\xbb\xe9\xd5\x9a\x13\x9c\xb5\xca\x0b\x82\x93\xbd\x98\x04\xb3\x2d\x8e\xe3\x87\x40\x70\xba\x5a\x11\xde\xac\x47\x99\x20\x7c\x41\x32\x41\xc3\xb8\x51\x83\xa5\x6c\xd1\x58\x66\xdb\x30\xa6\x8b\x20\x8b\x31\x6b\x18\x69\x46\xe2\x62\xf0\x66\x2f\xc9\x9d\xc5\xf7\x02\x93\x0f\x4d\x5b\xd9\x36\x58\xe0\x6d\xde\x28\x8a\xc3\x54\x6e\x56\xcb\xc8\x09\xdf\xb2\x20\x5d\xb6\x2d\x4b\x9e\x26\xca\xf6\xa8\x0b\xca\x54\xf3\xbe\x77\x04\xa3\xbd\x26\x32\x3b\x39\x11\xef\xd0\x1a\x0f\x6b\x57\x81\xd9\xa1\x42\x6b\xc9\x28\xdb\x01\x43\xf7\xf4\x91\x22\xa8\x6f\x9b\x1f\x7d\x04\xa6\x6d\xa0\xcf\x4f\x71\xb3\x28\xea\xd8\x4f\x32\xd8\x9f\x99\xf6\x35\x84\x82\x13\x02\x9a\x1c\xa0\xe8\xe6\xa2\x89\x8b\x66\x37\xaf\x2d\x26\x47\x74\xeb\x8f\x04\x84\xb2\xbf\x43\x40\x2e\x40\xb7\xef\xde\xf4\xe3\x4d\x3f\xfe\x2d\xfd\x38\x42\xc7\x4a\x33\x8e\x11\xf5\x65\x3a\x71\xf4\x88\x72\x4a\x1b\x3a\xcf\x41\x4d\x85\xa8\x3c\x21\x59\xa6\x9c\x04\x2a\xa5\x4b\x21\x14\x85\x06\x2d\xde\xb7\x0e\x1f\x72\x79\x4c\x36\x4e\xe8\x86\x85\x26\xde\xa1\x78\xa8\x86\xf2\x7f\x6c\x86\x87\xe2\xb1\x97\xf9\x94\x34\xe7\x47\x8a\xe6\xa7\x55\xa9\x75\xae\xc9\xab\x77\xf9\x91\xb6\xf1\xba\x87\x9e\xdf\x55\x2d\xc3\x9c\x79\xa6\x2d\x5f\x8a\x6f\xab\x35\xa2\xff\x26\x66\xff\xb5\x98\x29\xcc\x77\x52\x78\x07\x75\x05\xf4\xae\xc0\x16\xfe\x6b\xdc\x6b\x9d\xf1\x92\xdb\x60\xce\xca\x6b\x59\x4d\x83\xd7\x23\x01\x38\x6e\x51\x4f\x4e\x7c\x09\xa7\x26\xa8\xa6\x75\x5c\x43\xde\x42\x0b\x9a\xd4\x78\x2a\x81\xff\x6c\xc9\xee\x54\xc9\x4a\xb8\xbb\x55\xf4\x65\xf2\x7d\xe2\x56\x3a\x76\xa6\x53\xd3\x1b\xf5\x7e\x01\x6a\x02\xec\x20\x5a\x0f\x00\x00")
+
+func _1524493008_add_heartbeat_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493008_add_heartbeat_to_buildsUpSql,
+		"1524493008_add_heartbeat_to_builds.up.sql",
+	)
+}
+
+func _1524493008_add_heartbeat_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1524493008_add_heartbeat_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493008_add_heartbeat_to_builds.up.sql", size: 3930, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493008_add_heartbeat_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x57\x5d\x6f\x9b\x30\x14\x7d\xcf\xaf\xb8\x6f\x25\x52\x14\x69\xaf\xcd\x5a\x89\x06\xb7\xf5\x44\xa0\x23\x64\x59\x37\x4d\xc8\x80\x9b\x3a\x03\x83\x8c\x91\xda\x7f\x5f\x43\x60\x10\x02\x69\xbb\xf5\xa1\x93\x9a\x27\xec\x7b\xee\x87\xc9\x39\x47\xe6\x02\x5d\x61\x6b\x36\x02\x30\x1c\xfb\x06\x16\xba\x8b\x1c\xac\x9b\xf8\x07\x32\xe0\x1b\x46\x6b\x90\x82\xf0\x8c\x49\x96\x70\xcf\xcf\x59\x14\x66\x5e\x4a\x85\xb7\x4d\xfc\x23\x39\x9c\x3e\xc8\x97\xa3\x23\x22\x69\x26\xbd\x20\x89\xd3\x88\x4a\x1a\x1e\x64\xaa\xd4\xb9\x83\x54\xde\xeb\x93\x41\x5f\xaa\x6c\x58\x63\xf7\xba\x86\x96\x00\x8f\xed\x61\x40\x2b\x50\xf5\x6f\x89\x4c\x34\x77\x21\x26\x0f\x9a\xef\x7d\x9a\xb2\x70\x5c\x40\xea\xbc\x36\x12\xe0\xd2\xb1\x17\xa0\xed\x9a\x82\x42\xef\x47\x01\xbe\xd8\xd8\x02\xd5\x24\x83\x2d\xd8\x16\x68\xda\x56\xd5\x83\xb3\x02\x3a\x55\xdb\xaa\xde\x78\x3c\xde\x4b\x5a\x5f\x23\x07\x41\xd9\x39\x93\x44\xe6\x19\x7c\x3e\x07\xdd\x34\x41\xd3\x1d\x47\xbf\xfd\x79\x92\x52\x1e\x32\xbe\x39\x39\x3d\xdd\x8d\xb4\x43\x4d\xe0\x44\x3d\x08\xf5\x0a\x3a\x81\x5f\x9d\xfa\x57\x8e\xbd\xba\x81\x8b\xdb\xd6\x08\xad\x78\x89\xad\x5e\x80\xaf\x46\x9d\x54\x31\x7f\xca\x49\x4c\x9b\x55\xd5\xb4\x59\x07\xf7\x34\xcc\x23\x1a\xee\x41\x84\xf4\x24\x6b\xa7\xa9\xd1\x0f\x76\x36\x8c\x1f\xac\xbd\x98\x4a\x12\x12\x49\x9a\xc0\x9f\xbf\xb8\xd9\xda\x4d\xdf\xac\x05\x25\x69\xa7\xbc\xa4\x24\xde\xc3\xc4\x84\xe7\x24\x8a\x1e\x3d\x29\xd8\x66\x43\x45\xbb\x1e\xe3\x92\x8a\x80\xa6\x92\xf9\x51\xab\x06\x4f\x78\xd0\x5a\xa6\xb9\x1f\xb1\xc0\x4b\x23\xc2\x5b\x9b\x2c\xa5\x51\x31\x78\xbb\x97\xd2\x4e\xf0\xbb\xe0\xe4\x63\x7b\xaf\x6c\xeb\x05\x24\xcf\x5a\x45\x89\x9f\xa8\x97\xd5\xd9\x14\x54\xe4\xdc\x4b\xee\xba\x3b\x77\x22\x89\x47\x3d\xf4\x1b\xb5\x49\x37\x48\xf7\x68\x47\xc4\x68\x5a\x87\x0a\x3a\x4e\x1b\x22\x96\x62\xb1\x6c\x30\x74\x57\x9f\x35\xda\x5b\x59\xf8\xeb\x0a\x01\xb6\x0c\xf4\xfd\x39\xd9\x15\x45\x6d\xeb\x59\x71\xae\x96\xd8\xba\x02\x5f\x0a\x4a\x41\x53\x03\x14\xdd\x1c\x74\xe9\xa0\xe5\xf5\x5b\xfb\x44\x8f\x25\xfd\x93\x37\x30\xfe\x3e\xbc\xe1\x0c\x74\xeb\xf6\xc3\x1a\x3e\xac\xe1\xdd\x58\x43\x8f\xd2\x2a\x3b\xe8\xd3\xe0\xeb\x2c\xa0\xf7\x62\x71\x4c\xf6\x83\xb7\x97\xb6\xf8\xab\x88\x4f\xef\x12\x41\xbd\x26\x65\x48\xfc\x8d\x3a\x26\x1d\x49\x77\xae\x0c\x6a\xd9\xe7\x08\x47\x2c\xc1\x44\x97\xee\xa1\x2f\x34\x0d\xd5\xff\xb1\x9d\x1e\xfa\xc2\x5e\xe6\x73\xae\x9b\xf5\x14\xcd\x8e\x1b\x4e\xe7\x36\x92\x55\xcf\xea\x90\x96\xf1\xb6\x57\x95\xbf\x35\x24\x03\x2f\x5d\x6c\xa9\x87\xe2\x6c\xb5\xfc\xc7\x1f\x3e\xf5\xbf\xfa\x54\x43\xe7\x41\x75\xee\x58\xdc\x70\x78\x08\xd8\xa1\x76\x4d\x69\x6d\x10\xaf\x64\x0b\x78\x09\xd6\xca\x34\x6b\x86\xbf\x1d\xbf\xc1\x76\x8a\x7a\x6a\xe2\x73\x38\x36\x41\x35\xad\xed\x18\xc8\x29\x15\x50\x53\xa5\xe4\xf4\x8b\xdd\x78\xd0\x00\x2b\x4f\x1e\x36\xc8\xd7\x39\xf3\x91\xcf\x44\x95\xa7\x9b\x0a\x0f\xae\x7e\x61\xa2\xea\xd0\xe5\x01\xca\x0f\xc2\xb9\x6d\xae\x16\x16\xdc\x53\xf5\xc6\x7c\x4a\xe4\xe4\x20\x94\x88\xf4\x9e\x70\x1a\xce\x46\x73\x7b\xb1\xc0\xee\x6c\xf4\x04\xed\x0b\x04\x65\xb5\x0e\x00\x00")
+
+func _1524493008_add_heartbeat_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493008_add_heartbeat_to_buildsDownSql,
+		"1524493008_add_heartbeat_to_builds.down.sql",
+	)
+}
+
+func _1524493008_add_heartbeat_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1524493008_add_heartbeat_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493008_add_heartbeat_to_builds.down.sql", size: 3765, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493009_add_labels_to_pipelinesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2c\x48\xcd\xc9\xcc\x4b\x2d\x06\x8a\x03\x65\x5c\x5c\x14\x9c\xfd\x7d\x42\x7d\xfd\x14\x72\x12\x93\x52\x73\x8a\x15\x4a\x52\x2b\x4a\xac\xb9\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x8b\xbc\x72\xbe\x43\x00\x00\x00")
+
+func _1524493009_add_labels_to_pipelinesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493009_add_labels_to_pipelinesUpSql,
+		"1524493009_add_labels_to_pipelines.up.sql",
+	)
+}
+
+func _1524493009_add_labels_to_pipelinesUpSql() (*asset, error) {
+	bytes, err := _1524493009_add_labels_to_pipelinesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493009_add_labels_to_pipelines.up.sql", size: 67, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493009_add_labels_to_pipelinesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2c\x48\xcd\xc9\xcc\x4b\x2d\x06\x8a\x2b\x28\xb8\x04\xf9\x07\x28\x38\xfb\xfb\x84\xfa\xfa\x29\xe4\x24\x26\xa5\xe6\x14\x5b\x73\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\xda\xac\x1c\xbf\x3f\x00\x00\x00")
+
+func _1524493009_add_labels_to_pipelinesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493009_add_labels_to_pipelinesDownSql,
+		"1524493009_add_labels_to_pipelines.down.sql",
+	)
+}
+
+func _1524493009_add_labels_to_pipelinesDownSql() (*asset, error) {
+	bytes, err := _1524493009_add_labels_to_pipelinesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493009_add_labels_to_pipelines.down.sql", size: 63, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493010_add_pipeline_destroyed_build_statusUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x0d\xca\x41\x0a\xc2\x30\x10\x05\xd0\x7d\x4f\xf1\x77\x5d\xe5\x04\xae\x0a\x8d\x50\x28\x2a\x36\x8a\xae\x64\xda\x0c\x3a\xd0\x4e\x4a\x33\x51\xbc\xbd\xbe\xf5\x73\x0e\x14\xa3\xe8\x13\x84\x37\xcd\x85\x61\x09\xa4\x60\x2d\x0b\x26\x52\x4d\x86\x91\x11\x93\x32\x3e\x62\x2f\xd1\x7f\xb4\x8d\x34\xd3\x64\x92\xb4\x6a\xfa\xe0\xcf\x08\xf7\x93\xc7\x58\x64\x8e\x8f\x6c\x64\x25\xa3\x69\x5b\x5c\x9b\xfe\xe2\xd1\xed\x71\x38\x06\xf8\x5b\x37\x84\x01\xf5\x2a\x2b\xcf\xa2\xec\x22\x67\xdb\xd2\x97\x63\xbd\xab\x7e\x92\x68\x27\x70\x87\x00\x00\x00")
+
+func _1524493010_add_pipeline_destroyed_build_statusUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493010_add_pipeline_destroyed_build_statusUpSql,
+		"1524493010_add_pipeline_destroyed_build_status.up.sql",
+	)
+}
+
+func _1524493010_add_pipeline_destroyed_build_statusUpSql() (*asset, error) {
+	bytes, err := _1524493010_add_pipeline_destroyed_build_statusUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493010_add_pipeline_destroyed_build_status.up.sql", size: 135, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493010_add_pipeline_destroyed_build_statusDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4d\xca\xb1\x0a\xc2\x30\x14\x05\xd0\xbd\x5f\x71\xb7\x4e\xf9\x82\xe2\x20\x18\x70\x14\xad\x38\x27\xe6\x16\x03\x69\x52\xf2\xde\x2b\xf8\xf7\x0a\x2e\xce\xe7\x38\x87\x3d\x14\xa3\xe0\x19\x6a\x6d\x8a\x48\x74\xae\x6d\x67\xc2\xd2\xdb\x8a\x50\xc1\x6a\xeb\x04\x7d\xf1\x57\x91\x05\x85\x8b\xc2\xaa\x09\xd3\x70\xbf\x9c\x8e\xb3\x47\xb4\x5c\x92\xe0\xe6\x67\x88\x06\x35\xc1\x01\x63\x88\xad\x2b\xd3\x88\xc7\xd9\x5f\xfd\x1f\x6c\x79\x63\xc9\x95\x2e\x51\xb4\xb7\xf7\xf7\x4c\xc3\x07\x39\x61\xac\x7b\x8d\x00\x00\x00")
+
+func _1524493010_add_pipeline_destroyed_build_statusDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493010_add_pipeline_destroyed_build_statusDownSql,
+		"1524493010_add_pipeline_destroyed_build_status.down.sql",
+	)
+}
+
+func _1524493010_add_pipeline_destroyed_build_statusDownSql() (*asset, error) {
+	bytes, err := _1524493010_add_pipeline_destroyed_build_statusDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493010_add_pipeline_destroyed_build_status.down.sql", size: 141, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493011_add_archived_from_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x57\x4d\x6f\x9b\x40\x10\xbd\xfb\x57\xcc\x2d\x58\x8a\x2c\xf5\x1a\x37\x91\x88\xd9\x24\x54\x18\x52\x8c\xeb\xa6\x55\x85\x16\xbc\xb1\xd7\x85\x05\x2d\x4b\x95\xfc\xfb\x0e\x06\x0a\xc6\xc6\x49\xda\x1c\xaa\x36\x9c\xd8\x99\x37\x1f\xbb\xcc\x7b\x5a\x2e\xc9\xb5\x69\x8f\x07\x00\xba\xe5\x11\x17\x3c\xfd\xd2\x22\x10\xe4\x3c\x5a\x66\x68\x44\xb3\x61\xc0\xc4\xb1\xe6\x53\x1b\xa8\x0c\xd7\xfc\x07\x5b\xfa\xf7\x32\x89\x41\xb1\x07\x35\x1e\x20\xc6\x70\x9d\x5b\x98\xea\x18\x6d\xea\x96\xf9\x85\x18\xf0\xc9\x24\x0b\x50\x92\x8a\x8c\x2b\x9e\x08\xbf\x4c\xe7\xa7\x4c\xfa\x9b\x24\x18\xf7\xc7\x08\xcc\xf9\x7c\x74\x44\x15\xcb\x94\x1f\x26\x71\x1a\x31\x85\x7d\x75\x23\x31\x74\xe2\x12\x8c\x7b\x79\x30\xe8\xb3\x62\xfb\x0b\xd3\xbb\xa9\xa1\x5b\x80\xcf\x77\x30\xa0\x6d\x0f\xa9\x7a\x66\xc4\x22\x13\x0f\x62\xfa\xa0\x05\xfe\xbb\x11\x5f\x0e\x0b\x48\x1d\xd7\x46\x02\x5c\xb9\xce\x14\xb4\xb2\x28\x20\x7a\xd7\x0b\xf0\xc1\x31\x6d\xc0\x22\x19\x6c\xc0\xb1\x41\xd3\x36\x98\x0f\xce\x0b\xe8\x08\xcd\x98\x6f\x38\x1c\xee\x04\x2d\x6e\x88\x4b\x60\x5b\x39\x53\x54\xe5\x19\xbc\xbf\xc0\xaf\x6a\x81\xa6\xbb\xae\x7e\xf7\xf5\x24\x65\x62\xc9\xc5\xea\xe4\xec\xac\x6c\xa9\x44\x9d\xc2\x09\xbe\x48\x3c\x82\x8e\xe3\x5b\x27\xff\xb5\xeb\xcc\x6f\xe1\xf2\xae\xd5\x42\xcb\xbf\xc5\x56\x07\x10\x60\xab\xa7\x95\x2f\x18\x09\x1a\xb3\x66\x55\x15\x6d\xd6\xe1\x9a\x2d\xf3\x88\x2d\x77\x20\x52\xf9\x8a\xb7\xc3\xb0\xf5\x3d\xcb\x8a\x8b\xbd\xb5\x1f\x33\x45\x97\x54\xd1\xc6\xf1\xeb\x13\x37\xa6\xb2\xfb\x66\x2d\x19\x4d\x3b\xe9\x15\xa3\xf1\x0e\x26\xa6\x22\xa7\x51\xf4\xe8\x2b\xc9\x57\x2b\x26\xdb\xf9\xb8\x50\x4c\x86\x2c\x55\x3c\x88\x5a\x39\x44\x22\xc2\xd6\x32\xcd\x83\x88\x87\x7e\x1a\x51\xd1\x32\xf2\x94\x45\x45\xe3\xed\x5a\xc8\x9d\xf0\x7b\x31\x93\x8f\x6d\xdb\xb6\xac\x1f\xd2\x3c\x6b\x25\xa5\x41\x82\x87\xd5\x31\x4a\x26\x73\xe1\x27\xf7\x5d\x4b\x41\xdc\xc6\xb6\x66\x78\xce\x01\xa3\xaa\x31\x25\x32\x5d\x53\xd1\xde\xda\x0e\xe9\x07\x07\x46\x77\xd0\x1e\xd8\x5e\xaa\x44\xe5\x10\x47\xa3\xda\x55\x8c\xf2\xa8\x19\xe2\x2d\xd1\x6c\x07\x0c\xdd\xd3\xc7\x0d\x6f\xe7\xb6\xf9\x71\x4e\xc0\xb4\x0d\xf2\xf9\x29\xca\x16\x49\x1d\xfb\x49\x62\xcf\x67\xa6\x7d\x0d\x81\x92\x8c\x81\x86\x0d\x14\xd5\x5c\x72\xe5\x92\xd9\xcd\x6b\x6b\xcc\x01\x39\xfb\x23\x5d\xe1\xe2\xef\xd0\x95\x73\xd0\xed\xbb\x37\x59\x79\x93\x95\x7f\x42\x56\x0e\xb0\xb4\x92\x92\x43\xfc\x7d\x99\x7c\x1c\xbc\xd0\x1c\x93\x8c\xde\x5b\x53\x5b\x38\x2a\x4f\xc0\xee\x13\xc9\xfc\x26\xa4\x4f\x38\x1a\x66\x9d\x76\xe4\xa0\x73\x55\xc1\xe5\x21\x35\x39\x22\x27\x16\xb9\xf2\xf6\x35\xa5\x29\x88\xdf\x63\x33\xda\xd7\x94\x9d\xc8\xa7\x14\x3b\x3b\x90\x34\x3b\x2e\x56\x9d\x5b\x50\x56\xbd\xe3\x26\x6d\xe3\x75\xaf\x48\xbf\x2b\x66\x86\x39\xf3\x4c\x1b\x5f\x8a\xbd\xd5\xd2\x31\x7c\xd3\xb8\xff\x51\xe3\x1a\x2a\xf4\x32\xbb\x64\x40\x33\xff\x7d\xc0\x0e\x2d\x6a\x3a\x68\xbd\x78\xa4\x3c\x98\x33\xb0\xe7\x96\x55\xb3\xe3\xf5\xb8\x01\x8e\x5b\xe4\xc3\x8e\x2f\xe0\x58\x07\x55\xb7\x8e\x6b\xe0\x2f\x68\xc1\x9e\x7a\xcc\xb6\x7c\x78\xb6\x92\xf7\x8a\x67\xa5\xe7\xfd\xe2\xfa\x32\x55\x3f\xf2\x6b\x3b\x71\xa6\x53\xd3\x1b\x0f\x7e\x02\x92\xf2\xca\xe3\x57\x0f\x00\x00")
+
+func _1524493011_add_archived_from_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493011_add_archived_from_to_buildsUpSql,
+		"1524493011_add_archived_from_to_builds.up.sql",
+	)
+}
+
+func _1524493011_add_archived_from_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1524493011_add_archived_from_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493011_add_archived_from_to_builds.up.sql", size: 3927, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493011_add_archived_from_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x57\x5d\x6f\x9b\x30\x14\x7d\xcf\xaf\xb8\x6f\x25\x52\x15\x69\xaf\xcd\x5a\x89\x06\xb7\x65\x22\xd0\x11\xb2\xac\x9b\x26\x64\xc0\x4d\x9c\x81\x41\xc6\x4c\xed\xbf\x9f\x21\x30\x03\x09\x69\xbb\xf5\x61\x9b\x9a\x27\x7c\x7d\xee\x87\xe1\x9c\x23\xe7\x12\x5d\x9b\xf6\x74\x04\x60\xb8\xce\x2d\xcc\x75\x0f\xb9\xa6\x6e\x99\x5f\x90\x01\x9f\x4c\xb4\x02\xc1\x31\xcb\xa9\xa0\x29\xf3\x83\x82\xc6\x51\xee\x67\x84\xfb\xdb\x34\x38\x92\xc3\xc8\x83\x78\x3e\x3a\xc6\x82\xe4\xc2\x0f\xd3\x24\x8b\x89\x20\xd1\x5e\xa6\x4c\x9d\xb9\x48\xe6\xbd\x3c\x19\xf4\x85\xcc\x86\x95\xe9\xdd\x34\xd0\x0a\xe0\xd3\x0e\x06\xb4\x12\xd5\xfc\x16\xc8\x42\x33\x0f\x12\xfc\xa0\x05\xfe\xbb\x09\x8d\xc6\x25\xa4\xc9\x6b\x23\x01\xae\x5c\x67\x0e\xda\xae\x29\x48\x74\x77\x17\xe0\x83\x63\xda\x20\x9b\xe4\xb0\x05\xc7\x06\x4d\xdb\xca\x7a\x70\x5e\x42\x27\x32\x2c\xeb\x8d\xc7\xe3\x4e\xd2\xea\x06\xb9\x08\xaa\xce\xb9\xc0\xa2\xc8\xe1\xfd\x05\xe8\x96\x05\x9a\xee\xba\xfa\xdd\xd7\x93\x8c\xb0\x88\xb2\xf5\xc9\xd9\xd9\x6e\xa4\x1d\xea\x14\x4e\xe4\x03\x97\xaf\xa0\xb7\xf1\xad\x57\xff\xda\x75\x96\xb7\x70\x79\xd7\x1a\xa1\xb5\x5f\x61\xeb\x17\x10\xc8\x51\x4f\xeb\xbd\x60\xc2\x70\x42\xd4\xaa\x6e\xaa\xd6\xe1\x86\x44\x45\x4c\xa2\x0e\x84\x0b\x5f\xd0\x76\x9a\x1c\x7d\x2f\xb2\xa6\x6c\x6f\xed\x27\x44\xe0\x08\x0b\xac\x36\x7e\x7d\x62\x15\xda\x4d\xaf\xd6\x9c\xe0\xac\x57\x5e\x10\x9c\x74\x30\x09\x66\x05\x8e\xe3\x47\x5f\x70\xba\x5e\x13\xde\xae\x47\x99\x20\x3c\x24\x99\xa0\x41\xdc\xaa\xc1\x52\x16\xb6\x96\x59\x11\xc4\x34\xf4\xb3\x18\xb3\x56\x90\x66\x24\x2e\x07\x6f\xf7\x92\xda\x09\xbf\x97\x9c\x7c\x6c\xc7\xaa\xb6\x7e\x88\x8b\xbc\x55\x14\x07\xa9\x7c\x59\xbd\x20\x27\xbc\x60\x7e\x7a\xdf\x8f\xdc\xf3\x34\x51\xb1\x0d\x91\xef\x39\x20\x58\xa8\x50\xca\xb3\x0d\x66\xa4\xfe\xb0\x5d\x8e\x8e\xda\xcc\x1c\xd4\x44\xbc\x63\x6b\x3c\x69\xb6\x4a\xce\x4e\x14\x5b\x2b\x45\xd9\x0e\x18\xba\xa7\x4f\x95\x40\x97\xb6\xf9\x71\x89\xc0\xb4\x0d\xf4\xf9\x29\x6d\x96\x45\x1d\xfb\x49\x05\x2f\x17\xa6\x7d\x0d\x81\xe0\x84\x80\x26\x07\x28\xbb\xb9\xe8\xca\x45\x8b\x9b\xd7\x36\x93\x03\xbe\xf5\x47\x06\x42\xd9\xdf\x61\x20\xe7\xa0\xdb\x77\x6f\xfe\xf1\xe6\x1f\xff\x96\x7f\x1c\x90\x63\xed\x19\x87\x84\xfa\x32\x9f\x38\x78\x45\x39\xe6\x0d\x83\xf7\xa0\xb6\x43\xd4\x3b\x01\xb9\x4f\x39\xf1\x55\xca\x90\x43\x28\x09\x9d\xf6\x74\xdf\xbb\x7c\xc8\xe5\x21\xdb\x38\xe2\x1b\x16\xba\xf2\xf6\xcd\x43\x35\x94\xdf\x63\x3b\xd9\x37\x8f\x4e\xe6\x53\xd6\x9c\x1f\x28\x9a\x1f\x77\xa5\xde\xbd\x26\xaf\x9f\xe5\x21\x6d\xe3\x75\x2f\x3d\xbf\xeb\x5a\x86\xb9\xf0\x4c\x5b\x3e\x94\x67\x6b\x3c\x62\xfc\x66\x66\xff\xb5\x99\x29\xce\x0f\x4a\x78\x47\x75\x45\xf4\x21\x60\x8f\xff\x0d\xef\xb5\x41\xbc\xd4\x36\x98\x0b\xb0\x97\x96\xd5\xc8\xe0\xf5\x44\x00\x8e\x5b\xd6\x93\x13\x5f\xc0\xb1\x09\xea\x69\x1d\xd7\x40\x6e\x25\x93\x86\x4f\x15\xf1\x9f\x6d\xd9\x83\x2e\x59\x1b\xf7\xb0\x8b\xbe\xcc\xbe\x8f\xfc\x2b\x95\x79\xba\x25\xf1\xe0\xe9\x97\x16\xaa\x0f\x5d\x1d\xa0\xfa\xff\x39\x73\xac\xe5\xdc\x06\xcc\xc3\x0d\xfd\x21\xe9\x58\xd2\x67\x3a\x9a\x39\xf3\xb9\xe9\x4d\x47\x3f\x01\x0a\xe3\xd2\xdf\x0e\x0f\x00\x00")
+
+func _1524493011_add_archived_from_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493011_add_archived_from_to_buildsDownSql,
+		"1524493011_add_archived_from_to_builds.down.sql",
+	)
+}
+
+func _1524493011_add_archived_from_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1524493011_add_archived_from_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493011_add_archived_from_to_builds.down.sql", size: 3854, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493012_create_build_environmentsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x8f\x41\x0e\x82\x30\x10\x45\xf7\x9c\x62\xd2\x15\x24\xde\x80\x55\x29\x03\x69\x84\xa9\x29\x75\xc1\x8a\xc4\x58\x4d\xa3\xd6\x04\xaa\xd1\xdb\x0b\x06\xd4\x85\xb3\xfd\x2f\xff\xfd\xc9\xb0\x94\x94\x46\x00\x42\x23\x37\x08\x86\x67\x15\x02\xdb\xdd\xdc\x79\xdf\x59\x7f\x77\xfd\xd5\x5f\xac\x0f\x03\x83\x78\xa4\xa6\x9b\x43\xb7\x67\xe0\x7c\xb0\x47\xdb\x03\x29\x03\xb4\xad\x2a\xd8\x68\x59\x73\xdd\xc2\x1a\xdb\xd5\x82\xff\xb4\x30\x08\xf6\x11\x3e\xf8\x82\x08\x45\x8d\xd1\x5c\x92\xf9\x67\xee\x16\x5f\x77\x38\xd9\x27\x83\x42\x69\x94\x25\x4d\x0e\x88\xbf\x63\x12\xd0\x58\xa0\x46\x12\xd8\xcc\x35\x03\x8b\xd9\x3b\x51\x04\x39\x56\x38\xfe\x27\x78\x23\x78\x8e\xa3\x38\x49\x23\xa1\xea\x5a\x9a\x34\x7a\x01\x2f\x30\x6a\x9c\x05\x01\x00\x00")
+
+func _1524493012_create_build_environmentsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493012_create_build_environmentsUpSql,
+		"1524493012_create_build_environments.up.sql",
+	)
+}
+
+func _1524493012_create_build_environmentsUpSql() (*asset, error) {
+	bytes, err := _1524493012_create_build_environmentsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493012_create_build_environments.up.sql", size: 261, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493012_create_build_environmentsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x4a\x2a\xcd\xcc\x49\x89\x4f\xcd\x2b\xcb\x2c\xca\xcf\xcb\x4d\xcd\x2b\x29\x56\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xa6\xe8\xa4\x3e\x32\x00\x00\x00")
+
+func _1524493012_create_build_environmentsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493012_create_build_environmentsDownSql,
+		"1524493012_create_build_environments.down.sql",
+	)
+}
+
+func _1524493012_create_build_environmentsDownSql() (*asset, error) {
+	bytes, err := _1524493012_create_build_environmentsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493012_create_build_environments.down.sql", size: 50, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493013_add_ephemeral_to_workersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x05\xc1\x41\x0a\x80\x20\x10\x05\xd0\xbd\xa7\xf8\xf7\x70\x65\x6a\x11\x8c\x0a\x31\x1e\xc0\x60\x22\xc8\x32\x74\xd1\xf5\x7b\x6f\xf2\xcb\x1a\xb5\x02\x0c\xb1\xdf\xc0\x66\x22\x8f\xaf\xf5\x4b\xfa\x80\x71\x0e\x36\x51\x0e\x11\xf2\x9e\x72\x4b\x2f\x15\x7b\x6b\x55\xca\x83\x98\x18\x31\x13\xc1\xf9\xd9\x64\x62\x1c\xa5\x0e\xd1\xca\xa6\x10\x56\xd6\xea\x07\xe7\x6f\xe7\x6e\x5a\x00\x00\x00")
+
+func _1524493013_add_ephemeral_to_workersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493013_add_ephemeral_to_workersUpSql,
+		"1524493013_add_ephemeral_to_workers.up.sql",
+	)
+}
+
+func _1524493013_add_ephemeral_to_workersUpSql() (*asset, error) {
+	bytes, err := _1524493013_add_ephemeral_to_workersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493013_add_ephemeral_to_workers.up.sql", size: 90, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493013_add_ephemeral_to_workersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x2d\xc8\x48\xcd\x4d\x2d\x4a\xcc\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xdc\xeb\x57\x67\x3c\x00\x00\x00")
+
+func _1524493013_add_ephemeral_to_workersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493013_add_ephemeral_to_workersDownSql,
+		"1524493013_add_ephemeral_to_workers.down.sql",
+	)
+}
+
+func _1524493013_add_ephemeral_to_workersDownSql() (*asset, error) {
+	bytes, err := _1524493013_add_ephemeral_to_workersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493013_add_ephemeral_to_workers.down.sql", size: 60, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493014_add_pressure_to_workersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\xcc\x41\x0a\x83\x30\x10\x05\xd0\xbd\xa7\xf8\xf7\x70\x15\x4d\x2c\xc2\x24\x81\x32\x59\x8b\xc5\x11\x44\x6d\xca\x0c\xa5\x78\xfb\x5e\xc1\x77\x80\xd7\x85\xc7\x98\xda\x06\x70\xc4\xe1\x09\x76\x1d\x05\xfc\xaa\xee\xa2\x06\xe7\x3d\xfa\x4c\x25\x26\x9c\x72\x56\xbd\xa6\x8f\x8a\xd9\x57\x05\xaf\x5a\x0f\x99\xdf\x48\x99\x91\x0a\x11\x7c\x18\x5c\x21\xc6\x3a\x1f\x26\x37\xc2\x65\xb3\xfd\x7e\xd7\xe7\x18\x47\x6e\x9b\x3f\xdf\xc2\x55\x83\xaf\x00\x00\x00")
+
+func _1524493014_add_pressure_to_workersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493014_add_pressure_to_workersUpSql,
+		"1524493014_add_pressure_to_workers.up.sql",
+	)
+}
+
+func _1524493014_add_pressure_to_workersUpSql() (*asset, error) {
+	bytes, err := _1524493014_add_pressure_to_workersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493014_add_pressure_to_workers.up.sql", size: 175, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493014_add_pressure_to_workersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x4d\xcd\xcd\x2f\xaa\x8c\x2f\x28\x4a\x2d\x2e\x2e\x2d\x4a\x25\x46\x4b\x4a\x66\x71\x36\x92\x06\x67\x7f\x5f\x5f\xcf\x10\x6b\x2e\x00\xc0\x76\x61\xf0\x73\x00\x00\x00")
+
+func _1524493014_add_pressure_to_workersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493014_add_pressure_to_workersDownSql,
+		"1524493014_add_pressure_to_workers.down.sql",
+	)
+}
+
+func _1524493014_add_pressure_to_workersDownSql() (*asset, error) {
+	bytes, err := _1524493014_add_pressure_to_workersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493014_add_pressure_to_workers.down.sql", size: 115, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493015_add_volume_gc_policy_columnsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\xcc\xbd\x0a\xc2\x30\x18\x46\xe1\xbd\x57\xf1\x8e\xba\xb9\x67\x4a\xdb\x28\x85\xfc\x80\xa4\x73\x09\xf6\xa3\x86\xda\x46\x92\xd4\x80\x57\xaf\xb8\xa9\xe3\xe1\xc0\x53\x8b\x53\xa7\x59\x05\x70\x69\xc5\x19\x96\xd7\x52\xe0\x11\x6e\xdb\x42\x09\xbc\x6d\xd1\x18\xd9\x2b\x8d\x4b\x24\x97\x69\x1c\x5c\x46\xf6\xef\x97\xdd\x72\x47\xf1\xf9\xfa\x49\x3c\xc3\x4a\xd0\xc6\x42\xf7\x52\xa2\x15\x47\xde\x4b\x8b\x35\x94\xdd\xfe\x17\x2f\x21\xce\x14\xbf\xf0\xd1\xa7\x79\xd8\x92\x9b\x08\x7e\xcd\x34\x51\xfc\xb7\x0e\xac\x6a\x8c\x52\x9d\x65\xd5\x0b\xe2\x4f\x7d\x26\xb4\x00\x00\x00")
+
+func _1524493015_add_volume_gc_policy_columnsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493015_add_volume_gc_policy_columnsUpSql,
+		"1524493015_add_volume_gc_policy_columns.up.sql",
+	)
+}
+
+func _1524493015_add_volume_gc_policy_columnsUpSql() (*asset, error) {
+	bytes, err := _1524493015_add_volume_gc_policy_columnsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493015_add_volume_gc_policy_columns.up.sql", size: 180, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493015_add_volume_gc_policy_columnsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcb\xcf\x29\xcd\x4d\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x2e\x4a\x4d\x2c\x49\x4d\x89\x4f\x2c\x41\x57\x5d\x9e\x5f\x94\x9d\x5a\x84\xaa\x3a\x25\xb3\x38\x3b\xbe\xb4\x38\x31\x3d\xd5\x9a\xcb\xd9\xdf\xd7\xd7\x33\xc4\x9a\x0b\x00\xe9\x19\x84\x10\x6b\x00\x00\x00")
+
+func _1524493015_add_volume_gc_policy_columnsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493015_add_volume_gc_policy_columnsDownSql,
+		"1524493015_add_volume_gc_policy_columns.down.sql",
+	)
+}
+
+func _1524493015_add_volume_gc_policy_columnsDownSql() (*asset, error) {
+	bytes, err := _1524493015_add_volume_gc_policy_columnsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493015_add_volume_gc_policy_columns.down.sql", size: 107, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493016_add_team_id_to_worker_resource_cachesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x75\x8e\xc1\x0a\x82\x40\x18\x84\xef\x3e\xc5\x1c\xf5\x19\x3c\xad\xbb\x53\x08\xeb\x0a\xeb\x06\xdd\x16\xd1\x9f\x92\x28\x61\x35\x7a\xfd\x2c\xea\xe8\x75\x66\xf8\xbe\xa9\x78\xac\x5d\x99\x01\xca\x06\x7a\x04\x55\x59\xe2\x35\xa7\x9b\xa4\x98\x64\x99\x9f\x69\x90\x38\xf4\xc3\x55\x16\x28\x63\xa0\x5b\x7b\x6a\x1c\x56\xe9\xef\x71\x1a\x31\x3d\x56\xb9\x48\x82\xe7\x81\x9e\x4e\xb3\xfb\x56\x0b\xf2\x69\x2c\xd0\x3a\x18\x5a\x06\x42\xab\x4e\x2b\xc3\x8f\x48\x7b\xaa\x2d\xa9\x9d\xe1\x79\xc7\x14\xff\xf8\x0d\xb0\xf3\x25\xff\x4d\x8a\x32\xd3\x6d\xd3\xd4\xa1\xcc\xde\x60\xf2\x66\x04\xcb\x00\x00\x00")
+
+func _1524493016_add_team_id_to_worker_resource_cachesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493016_add_team_id_to_worker_resource_cachesUpSql,
+		"1524493016_add_team_id_to_worker_resource_caches.up.sql",
+	)
+}
+
+func _1524493016_add_team_id_to_worker_resource_cachesUpSql() (*asset, error) {
+	bytes, err := _1524493016_add_team_id_to_worker_resource_cachesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493016_add_team_id_to_worker_resource_caches.up.sql", size: 203, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493016_add_team_id_to_worker_resource_cachesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x28\xcf\x2f\xca\x4e\x2d\x8a\x2f\x4a\x2d\xce\x2f\x2d\x4a\x4e\x8d\x4f\x4e\x4c\xce\x48\x2d\x8e\x2f\x49\x4d\xcc\x8d\xcf\x4c\x01\x29\x76\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\xc5\xa1\x1a\x62\x9e\xb3\xbf\x4f\xa8\xaf\x9f\x02\x5c\xa7\xb3\xbf\xaf\xaf\x67\x88\x35\x17\x00\x4d\xad\xad\x8c\x76\x00\x00\x00")
+
+func _1524493016_add_team_id_to_worker_resource_cachesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493016_add_team_id_to_worker_resource_cachesDownSql,
+		"1524493016_add_team_id_to_worker_resource_caches.down.sql",
+	)
+}
+
+func _1524493016_add_team_id_to_worker_resource_cachesDownSql() (*asset, error) {
+	bytes, err := _1524493016_add_team_id_to_worker_resource_cachesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493016_add_team_id_to_worker_resource_caches.down.sql", size: 118, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493017_create_worker_warm_resource_typesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x92\x41\x6e\x83\x30\x10\x45\xf7\x9c\x62\xe4\x15\x48\xb9\x01\x2b\x07\x26\x91\x55\x30\xad\x31\x52\xb3\xb2\x10\x75\x5b\x94\x06\x2a\x43\x95\xe6\xf6\x35\x29\x09\x90\xa4\x48\xa9\x17\xde\xcc\xf7\xfc\x37\xdf\xb3\xc4\x35\xe3\xbe\x03\x10\x08\xa4\x12\x41\xd2\x65\x84\x40\xf6\xb5\xd9\x6a\xa3\xf6\xb9\xd9\x29\xa3\x9b\xfa\xcb\x14\x5a\xb5\x87\x4f\xdd\x10\x70\xad\xba\x3b\xa4\x7c\x21\xd0\x68\x53\xe6\x1f\xf0\x28\x58\x4c\xc5\x06\x1e\x70\xb3\x38\x95\xfb\x1e\x55\xbe\xd3\x04\x5a\xfd\xdd\x02\x4f\x24\xf0\x2c\x8a\xce\x92\xb9\xda\xd9\xb6\xc8\x8b\x77\xad\x3a\xb3\xb2\x6a\xf5\x9b\x36\x27\x49\x90\xf0\x54\x0a\xca\xb8\x9c\x03\x56\x23\x0e\xf5\xba\xd5\x07\x02\xab\x44\x20\x5b\xf3\x8e\x16\xdc\x09\xa7\x07\x02\x57\x28\x90\x07\x98\x9e\x9a\x36\xc4\x25\x7d\x31\xe1\x10\x62\x84\x36\xa7\x80\xa6\x01\x0d\xf1\x4e\x94\xab\x99\x6e\x02\x5d\x4f\x3e\xc5\x9a\xd6\x3b\xbc\xa3\x64\x80\x4b\x71\x9a\xe5\x3f\x82\x3a\x5e\x47\xb6\x8c\xb3\xa7\x0c\x2f\x72\x5a\xf4\x7f\xe7\x59\x07\xcf\x77\x86\xfd\x61\x3c\xc4\x67\xb8\x27\x82\x8e\xfb\x6f\x3d\xb8\x57\x0f\x7e\xfd\x68\x24\x51\xf4\xeb\x5a\xd4\x55\x9b\x97\x95\xfd\x2a\xa0\x61\x68\xc7\x8d\xb2\x78\xa6\x69\x67\xda\xaf\xd2\x38\xd7\x39\x08\xeb\x7a\x23\x5f\xff\x72\xee\x01\x44\xcd\xba\xdb\x56\x23\x66\x77\x4e\x6b\xa7\x0d\x92\x38\x66\xd2\x77\x7e\x00\xf2\x4e\xf2\x8e\xac\x03\x00\x00")
+
+func _1524493017_create_worker_warm_resource_typesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493017_create_worker_warm_resource_typesUpSql,
+		"1524493017_create_worker_warm_resource_types.up.sql",
+	)
+}
+
+func _1524493017_create_worker_warm_resource_typesUpSql() (*asset, error) {
+	bytes, err := _1524493017_create_worker_warm_resource_typesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493017_create_worker_warm_resource_types.up.sql", size: 940, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493017_create_worker_warm_resource_typesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x48\xce\xcf\x2b\x49\xcc\xcc\x4b\x2d\x2a\x8e\x2f\xcf\x2f\xca\x4e\x2d\x8a\x2f\x4f\x2c\xca\x8d\x2f\x4a\x2d\xce\x2f\x2d\x4a\x4e\x8d\x2f\xa9\x2c\x48\x8d\xcf\x4c\x01\xe9\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x45\xd2\x06\x31\xcc\xd9\xdf\x27\xd4\xd7\x4f\x01\xbf\x11\x30\x9b\x21\x46\xe0\x54\x5b\x6c\xcd\xe5\xec\xef\xeb\xeb\x19\x62\xcd\x05\x00\x82\xf1\x7f\xd0\xb2\x00\x00\x00")
+
+func _1524493017_create_worker_warm_resource_typesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493017_create_worker_warm_resource_typesDownSql,
+		"1524493017_create_worker_warm_resource_types.down.sql",
+	)
+}
+
+func _1524493017_create_worker_warm_resource_typesDownSql() (*asset, error) {
+	bytes, err := _1524493017_create_worker_warm_resource_typesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493017_create_worker_warm_resource_types.down.sql", size: 178, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493018_add_fetch_counts_to_worker_resource_cachesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x90\x41\x8b\xc2\x30\x10\x85\xef\xfd\x15\x73\x54\xd8\x83\xf7\x9e\x6a\x3b\x2e\x85\x34\x85\x9a\xc2\xde\x86\x90\x8e\xdb\x60\x6d\x24\x4d\x15\xfc\xf5\x9b\x75\x71\x11\x51\xf0\x38\xc3\x7b\x6f\xbe\x37\x6b\xfc\x2c\x65\x9a\x00\x64\x42\x61\x03\x2a\x5b\x0b\x84\xb3\xf3\x7b\xf6\xe4\x79\x72\xb3\x37\x4c\x46\x9b\x9e\xa7\x28\x8a\xb2\xa2\x80\xbc\x16\x6d\x25\x61\xc7\xc1\xf4\x64\xdc\x3c\x06\xb0\x63\xe0\x6f\xf6\x20\x6b\x05\xb2\x15\x02\x0a\xdc\x64\xad\x50\xb0\xfa\x78\xb4\x0d\x7a\x0a\x74\xf5\x72\x47\x3a\x40\xb0\x07\x9e\x82\x3e\x1c\xe1\x6c\x43\x7f\x1d\xe1\xe2\x46\x4e\x93\x07\xaa\x93\x1b\xe6\x28\xbd\xcf\xf2\x7c\x1c\xac\xd1\xe4\x76\xf4\x94\x99\x6c\xf7\x8f\xd6\xe0\x06\x1b\x94\x39\x6e\x5f\xf4\x83\x85\xed\x96\x50\xcb\xc8\x2e\x50\x21\x6c\xf1\xaf\xcb\xef\x77\xf2\x06\xb3\xb8\x2a\x65\x81\x5f\x37\x10\x7a\xe7\x7a\x8c\xbb\x71\x2f\xde\xd0\x2f\xd3\x24\xaf\xab\xaa\x54\x69\xf2\x03\x45\x71\x7b\x16\x9a\x01\x00\x00")
+
+func _1524493018_add_fetch_counts_to_worker_resource_cachesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493018_add_fetch_counts_to_worker_resource_cachesUpSql,
+		"1524493018_add_fetch_counts_to_worker_resource_caches.up.sql",
+	)
+}
+
+func _1524493018_add_fetch_counts_to_worker_resource_cachesUpSql() (*asset, error) {
+	bytes, err := _1524493018_add_fetch_counts_to_worker_resource_cachesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493018_add_fetch_counts_to_worker_resource_caches.up.sql", size: 410, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493018_add_fetch_counts_to_worker_resource_cachesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x8c\x49\x0e\x02\x21\x14\x05\xf7\x9c\xe2\x1f\xc0\x1b\xb0\xea\x81\x18\x12\xa0\x4d\x07\x13\x77\x3f\x84\xa6\x63\x47\x14\xc3\xa0\xd7\x17\x35\x2e\x1c\x16\x6e\xdf\xab\xaa\x96\xad\xb9\xa2\x04\xa0\x1f\x87\x0d\x70\xd5\xb3\x1d\x5c\x82\x2f\x47\x97\x30\xba\xb3\x5f\xac\xc1\x30\xe3\x35\xc4\x83\x8b\x75\x49\xa1\x44\xeb\xd0\x1a\xbb\x77\xb8\x4c\x77\xb3\x11\x9a\x8d\xa0\x9b\x56\xb0\x97\xfa\xac\x75\x83\xd8\x4a\x05\x7f\x65\x3e\x3a\x3f\xc1\x54\x21\x78\x4b\x7b\x93\x32\xce\x2e\xd7\x73\x42\x93\x57\x5f\xc0\xe3\x43\x1b\xca\x29\x53\xd2\x0d\x52\x72\x4d\xc9\x0d\x7b\x91\x2b\x5d\xf4\x00\x00\x00")
+
+func _1524493018_add_fetch_counts_to_worker_resource_cachesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493018_add_fetch_counts_to_worker_resource_cachesDownSql,
+		"1524493018_add_fetch_counts_to_worker_resource_caches.down.sql",
+	)
+}
+
+func _1524493018_add_fetch_counts_to_worker_resource_cachesDownSql() (*asset, error) {
+	bytes, err := _1524493018_add_fetch_counts_to_worker_resource_cachesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493018_add_fetch_counts_to_worker_resource_caches.down.sql", size: 244, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493019_create_container_usage_samplesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x92\xc1\x6e\xc2\x30\x0c\x86\xef\x3c\x85\x95\x0b\x45\xe2\x0d\x38\x85\x62\x50\xb5\x92\x4e\xa1\x48\xe3\x14\x85\x35\x83\x88\x36\xad\x9a\x20\xc6\x9e\x7e\x69\x19\x54\xa3\x43\x2c\xb7\xc4\x9f\xed\xfc\xfe\x3d\xc5\x45\xc4\x26\x03\x80\x90\x23\x4d\x11\x52\x3a\x8d\x11\xc8\x7b\x69\x9c\xd4\x46\xd5\xe2\x68\xe5\x4e\x09\x2b\x8b\x2a\x57\x96\x40\xe0\xd1\xe6\x10\x9d\x11\xd8\xea\x9d\x55\xb5\x96\x39\xbc\xf2\x68\x49\xf9\x06\x5e\x70\x33\xbe\x12\xdb\xa3\xce\x33\xd1\x70\xda\x38\xb5\x53\x35\xb0\x24\x05\xb6\x8e\xe3\x1b\xd2\xb5\xd9\x4b\x93\xe5\x8a\x80\x53\x9f\xae\xcf\x55\xb9\x34\x6d\xa5\x5f\x61\x98\xe1\x9c\xae\xe3\x14\x86\xc3\x1b\x69\x9d\xaa\x84\x91\xc5\x7d\xa9\xbf\x58\x77\xae\xfe\x83\x5d\xb4\x67\x42\x3a\x0f\xeb\x42\x59\xe7\x1f\xe0\xa4\xdd\xbe\xbd\xc2\x57\x69\x54\xbf\x80\x29\x4f\xc1\xa8\x13\x5a\x1d\x2f\x93\x6c\x87\xe6\xe7\xd1\xd7\x58\xa8\xa2\xac\xcf\xcf\xa8\x4c\xdb\xc3\x13\x26\x4c\xd8\x2a\xe5\x34\x62\xe9\x43\x1f\xc5\xd5\x1b\xf1\x71\x50\x67\x02\xf3\x84\x63\xb4\x60\x8d\x7d\x10\x74\xc6\x8d\x80\xe3\x1c\x39\xb2\x10\x57\x3f\x7e\x5a\x12\x90\x36\x92\x30\x2f\x35\x46\xbf\x32\x21\x5d\x85\x74\x86\xbe\xfb\x68\x32\xe8\x36\x29\x62\x33\x7c\x83\xa7\x1f\xb8\x5f\x81\xa6\xf0\x83\x24\x08\xae\x59\x63\xb8\x4f\x1b\x83\xce\x7c\xfb\x30\x59\x2e\xa3\x74\x32\xf8\x06\xaf\x94\xd6\x53\xd7\x02\x00\x00")
+
+func _1524493019_create_container_usage_samplesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493019_create_container_usage_samplesUpSql,
+		"1524493019_create_container_usage_samples.up.sql",
+	)
+}
+
+func _1524493019_create_container_usage_samplesUpSql() (*asset, error) {
+	bytes, err := _1524493019_create_container_usage_samplesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493019_create_container_usage_samples.up.sql", size: 727, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493019_create_container_usage_samplesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x48\xce\xcf\x2b\x49\xcc\xcc\x4b\x2d\x8a\x2f\x2d\x4e\x4c\x4f\x8d\x2f\x4e\xcc\x2d\xc8\x49\x2d\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x90\x98\x78\x2a\x35\x00\x00\x00")
+
+func _1524493019_create_container_usage_samplesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493019_create_container_usage_samplesDownSql,
+		"1524493019_create_container_usage_samples.down.sql",
+	)
+}
+
+func _1524493019_create_container_usage_samplesDownSql() (*asset, error) {
+	bytes, err := _1524493019_create_container_usage_samplesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493019_create_container_usage_samples.down.sql", size: 53, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1523714483_create_worker_maintenance_windowsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x90\x41\x4b\xc4\x30\x10\x85\xef\xfd\x15\x43\x4e\x5d\xd8\x7f\xd0\x53\x36\x9d\x5d\x82\x6d\x22\x69\x04\xf7\x14\x82\x8d\x18\xd6\xa6\xd2\x04\xaa\xfe\x7a\x53\xe9\xea\x8a\x28\x9a\xdb\x30\xdf\xbc\xbc\xf7\x76\x78\xe0\xa2\x2a\x00\x98\x42\xaa\x11\x34\xdd\x35\x08\x64\x1e\xa7\x93\x9b\xcc\x60\x7d\x48\x2e\xd8\x70\xe7\xcc\xec\x43\x3f\xce\x91\x40\x99\xe9\xe5\x11\xdf\x13\x88\x6e\xf2\xf6\x11\xae\x15\x6f\xa9\x3a\xc2\x15\x1e\xb7\xe7\xf5\xaa\x11\xec\xe0\x08\x24\xf7\x9c\x40\x48\x0d\xe2\xa6\x69\x3e\x90\x98\xec\x94\xa2\xb1\x29\x03\x7e\x70\x79\x1c\x9e\x60\xf6\xe9\xe1\x7d\x84\xd7\x31\xb8\xef\x47\x2e\xf4\xff\x3c\x61\x52\x74\x5a\x51\x2e\xf4\x6f\xc9\xcc\x85\x61\x73\x7f\x72\x2f\x04\xf6\x52\x21\x3f\x88\x25\x16\x94\x5f\x02\x6d\x40\xe1\x1e\x15\x0a\x86\xdd\x59\x34\x92\x92\xac\x4b\x29\xa0\xc6\x06\x73\xa1\x8c\x76\x8c\xd6\x98\x9d\x6c\xaa\xe2\xb3\x67\x2e\x6a\xbc\x85\xbf\x99\x59\xd4\x7e\x26\xa1\xbc\x40\xb7\xb0\xb6\x93\x3f\x63\xb2\x6d\xb9\xae\x8a\x37\xc9\x9b\x44\x42\xe3\x01\x00\x00")
+
+func _1523714483_create_worker_maintenance_windowsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523714483_create_worker_maintenance_windowsUpSql,
+		"1523714483_create_worker_maintenance_windows.up.sql",
+	)
+}
+
+func _1523714483_create_worker_maintenance_windowsUpSql() (*asset, error) {
+	bytes, err := _1523714483_create_worker_maintenance_windowsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523714483_create_worker_maintenance_windows.up.sql", size: 483, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1523714483_create_worker_maintenance_windowsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x8a\xcf\x4d\xcc\xcc\x2b\x49\xcd\x4b\xcc\x4b\x4e\x8d\x2f\xcf\xcc\x4b\xc9\x2f\x2f\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x15\xf8\xb3\x2c\x38\x00\x00\x00")
+
+func _1523714483_create_worker_maintenance_windowsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1523714483_create_worker_maintenance_windowsDownSql,
+		"1523714483_create_worker_maintenance_windows.down.sql",
+	)
+}
+
+func _1523714483_create_worker_maintenance_windowsDownSql() (*asset, error) {
+	bytes, err := _1523714483_create_worker_maintenance_windowsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1523714483_create_worker_maintenance_windows.down.sql", size: 56, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493021_add_events_archived_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x97\x51\x6f\x9b\x30\x10\xc7\xdf\xf3\x29\xee\xad\x44\xaa\x22\xed\xb5\x59\x2b\xd1\xe0\xb4\x4c\x04\x3a\x42\xd6\x75\xd3\x84\x0c\x38\x09\x19\x18\x64\x4c\xd5\x7e\xfb\x1a\x02\xb5\x43\x43\xda\x6e\x7d\x98\xa6\xe4\x09\x9f\xcf\x77\x87\x7d\xff\x5f\xcc\x25\xba\x32\xed\xf1\x00\x40\xb7\x3c\xe4\x82\xa7\x5f\x5a\x08\x82\x32\x4e\xa2\x02\x74\xc3\x80\x89\x63\x2d\x66\x36\x90\x7b\x42\x79\xe1\x63\x16\xae\xe3\x7b\x12\x41\x90\x65\x09\xc1\x14\x6c\xc7\x03\x7b\x61\x59\x60\xa0\xa9\xbe\xb0\x3c\x58\xe2\xa4\x20\xe3\x81\x08\x38\x71\x91\xee\x21\x30\x6d\x03\x7d\x6f\x22\xfa\x25\x6d\x23\xf8\x61\x96\xe6\x09\xe1\xe2\x29\x8e\x1e\xc0\xb1\xdb\xa4\x1a\xa1\x91\xcf\xe3\x94\x0c\xe1\xf6\x1a\xb9\x08\x9e\x1d\x41\xb7\x8d\x3a\x61\xa7\x98\x3a\x9b\xe1\x3a\x37\x30\x13\x09\x5d\x53\xb7\xcc\x1f\xc8\x80\x6f\x26\xba\x05\xce\x30\x2d\x62\x1e\x67\xd4\x6f\x4a\xc8\x09\xf3\x37\x59\x30\xee\x5f\x43\xc9\x03\x7f\xbb\x77\x82\x39\x29\xb8\xf2\x3a\xdd\x95\x72\x2b\xde\xbd\x18\xf4\xb9\x58\x0d\xb7\xa6\x77\xdd\xba\xd6\x0e\x62\xcb\x54\x1f\xd0\x2a\xaf\xf6\x37\x47\x16\x9a\x78\x90\xe2\x07\x2d\xf0\x3f\x8d\xe2\x68\x58\xb9\xb4\xeb\x54\x4f\x80\xa9\xeb\xcc\x40\x6b\x76\x5e\x78\xef\xce\x02\x7c\x71\x4c\x1b\x44\x92\x02\x36\xd5\x11\x69\xda\x46\xc4\x83\xf3\xca\x75\x24\xcc\x22\xde\x70\x38\xdc\x59\xb4\x3d\xb3\x3a\x73\xc1\x31\x2f\x0b\xf8\x7c\x21\x7a\xcb\x02\x4d\x77\x5d\xfd\xee\xe7\x49\x2e\xce\x37\xa6\xab\x93\xb3\xb3\x6d\x49\x5b\xaf\x53\x38\x11\x0f\x4c\x6c\x41\x67\xe2\x57\x27\xfe\x95\xeb\x2c\x6e\xe0\xf2\x4e\x29\x41\x99\xaf\x7d\x9b\x0d\x08\x44\xa9\xa7\xcd\x5c\x30\xa2\x38\x25\x72\xd4\x24\x95\xe3\x70\x4d\xa2\x32\x21\xd1\x8e\x0b\xe3\x75\x23\x4a\x5b\xdb\x9a\xaa\x65\x15\xd3\x17\x63\x3f\x25\x1c\x47\x98\x63\x39\xf1\x7c\xc4\xd2\xb4\xad\x5e\x8e\x19\xc1\x79\x27\x3c\x27\x38\xdd\xf1\x49\x31\x2d\x71\x92\x3c\xfa\x9c\xc5\xab\x15\x61\x6a\xbc\x98\x72\xc2\x42\x92\xf3\x38\x48\x94\x18\x34\xa3\xa1\x32\xcc\xcb\x20\x89\x43\x3f\x4f\x30\x55\x8c\x71\x4e\x92\xaa\x70\x35\x97\xd0\x4e\xf8\xbb\xea\xc9\x47\xd5\x56\xa7\xf5\x43\x5c\x16\x4a\x50\x1c\x64\x62\xb3\x3a\x46\x46\x58\x49\xfd\x6c\xd9\xb5\x2c\x59\x96\x4a\xdb\x9a\x88\x7d\x0e\x08\xe6\xd2\x94\xb1\x7c\x8d\xa9\xfa\x6a\xcf\xd4\xd8\x5d\xdb\x01\xc1\x60\x4f\x4f\x0f\xd4\x4e\xee\xd5\x50\xb2\xed\xee\x64\xd4\x4e\x55\x3d\x3e\x92\xdd\x5d\x2b\xd0\x76\xc0\xd0\x3d\x7d\x2c\x05\xbd\xb0\xcd\xaf\x8b\x16\x71\xaf\x68\xb9\x0a\xea\xd8\xaf\x2a\x7e\x31\x37\xed\x2b\x08\x38\x23\x04\x34\x51\x40\x95\xcd\x45\x53\x17\xcd\xaf\x3f\x1a\x3e\x7b\x38\xf7\x57\xc0\x89\xe9\xbf\x01\x9c\x73\xf1\x47\x71\x77\xe4\xcd\x91\x37\xff\x37\x6f\xf6\xc8\xb7\x61\xcc\x3e\x61\xbf\x8f\x2b\x7b\xaf\x40\x87\x58\xd2\x7b\xcf\x52\x89\xd2\xcc\x04\x64\x99\x31\xe2\xcb\x25\x7d\x44\x91\x92\x3b\xed\x70\xa2\x73\xb9\x11\xc3\x7d\x98\x39\xc0\x19\x0b\x4d\xbd\x97\xb0\x91\x09\xc5\x79\x6c\x46\x2f\x61\xb3\xb3\xf2\x35\x94\x17\x7b\x82\x16\x87\x29\xd6\xb9\x37\x15\xcd\xf3\xb0\xbe\xfa\x7e\xe8\xa5\xea\x4f\x29\x67\x98\x73\xcf\xb4\xc5\x43\xf5\x6e\x2d\x53\x86\x47\xf8\x1d\xe1\xa7\xc0\x4f\x6a\xa4\x57\xf2\x5b\x69\x48\x61\xf4\x39\x76\xf4\xd2\xea\x44\xeb\xf5\x17\x2c\x00\x73\x5e\x7f\x94\xb6\xb2\xf9\x38\xd1\x80\xe3\x56\xf1\x44\xc5\x17\x70\xa8\x82\xa6\x5a\xc7\x35\xc4\x37\x75\x25\xab\xb6\xff\x6a\xa1\xbc\x19\xf1\xbd\x54\x6d\x40\xdf\x4f\xdd\xf7\xe1\xfe\xc0\x57\xf2\xc4\x99\xcd\x4c\x6f\x3c\x78\x02\xb7\x27\x5c\xcc\x28\x10\x00\x00")
+
+func _1524493021_add_events_archived_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493021_add_events_archived_to_buildsUpSql,
+		"1524493021_add_events_archived_to_builds.up.sql",
+	)
+}
+
+func _1524493021_add_events_archived_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1524493021_add_events_archived_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493021_add_events_archived_to_builds.up.sql", size: 4136, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493021_add_events_archived_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x57\x5d\x6f\x9b\x30\x14\x7d\xcf\xaf\xb8\x6f\x25\x52\x15\x69\xaf\xcd\x5a\x89\x06\xb7\x65\x22\xd0\x11\xb2\xae\x9b\x26\x64\xc0\x4d\x9c\x11\x83\x8c\xa9\xda\x7f\x3f\xf3\x55\x13\x02\x69\xbb\xf5\x61\xda\xca\x93\x7d\xef\xb9\x1f\x98\x7b\x8e\xcc\x39\xba\x34\xed\xe9\x08\xc0\x70\x9d\x6b\x98\xeb\x1e\x72\x4d\xdd\x32\xbf\x21\x03\xbe\x98\xe8\x06\x04\xc7\x2c\xa3\x82\x26\xcc\x0f\x72\x1a\x47\x99\x9f\x12\xee\x6f\x92\xe0\x40\x0c\x23\x0f\xe2\xe5\xe8\x18\x0b\x92\x09\x3f\x4c\xb6\x69\x4c\x04\x89\xf6\x22\x65\xe8\xcc\x45\x32\xee\xf5\xc1\xa0\x2f\x64\x34\xdc\x98\xde\x55\x03\x2d\x01\x3e\xdd\xc1\x80\x56\xa0\x9a\x67\x81\x2c\x34\xf3\x60\x8b\x1f\xb4\xc0\xff\x30\xa1\xd1\xb8\x80\x34\x71\x6d\x24\xc0\x85\xeb\xcc\x41\xab\x8a\x82\x44\xef\x7a\x01\x3e\x39\xa6\x0d\xb2\x48\x06\x1b\x70\x6c\xd0\xb4\x8d\xcc\x07\xa7\x05\x74\x22\xcd\x32\xdf\x78\x3c\xde\x09\xba\xb9\x42\x2e\x82\xb2\x72\x26\xb0\xc8\x33\xf8\x78\x06\xba\x65\x81\xa6\xbb\xae\x7e\xfb\xfd\x28\x25\x2c\xa2\x6c\x75\x74\x72\x52\xb5\x54\xa1\x8e\xe1\x48\x2e\xb8\x3c\x82\x8e\xe3\x47\x27\xff\xa5\xeb\x2c\xaf\xe1\xfc\xb6\xd5\x42\xcb\x5f\x62\xeb\x03\x08\x64\xab\xc7\xb5\x2f\x98\x30\xbc\x25\x6a\x57\x17\x55\xfb\x70\x4d\xa2\x3c\x26\xd1\x0e\x84\x0b\x5f\xd0\x76\x98\x6c\x7d\xcf\xb2\xa2\x6c\x6f\xef\x6f\x89\xc0\x11\x16\x58\x39\x9e\x3e\xb1\x32\x55\xdd\xab\x3d\x27\x38\xed\xa4\x17\x04\x6f\x77\x30\x5b\xcc\x72\x1c\xc7\x8f\xbe\xe0\x74\xb5\x22\xbc\x9d\x8f\x32\x41\x78\x48\x52\x41\x83\xb8\x95\x83\x25\x2c\x6c\x6d\xd3\x3c\x88\x69\xe8\xa7\x31\x66\x2d\x23\x4d\x49\x5c\x34\xde\xae\x25\xb9\x13\xfe\x2c\x66\xf2\xb1\x6d\x2b\xcb\xfa\x21\xce\xb3\x56\x52\x1c\x24\xf2\xb0\x3a\x46\x4e\x78\xce\xfc\xe4\xae\x6b\xb9\xe3\xc9\x56\xd9\xd6\x44\x9e\x73\x40\xb0\x50\xa6\x84\xa7\x6b\xcc\xda\xaf\x86\x79\xb8\xa6\xf7\xb2\x97\x22\x76\xd4\x33\xba\xa3\xf6\xc0\x0e\x52\x25\xae\x86\x38\x9e\x34\xae\x62\x94\x27\x6a\x88\x4b\xa2\xd9\x0e\x18\xba\xa7\x4f\x15\x6f\x97\xb6\xf9\x79\x89\xc0\xb4\x0d\xf4\xf5\x39\xca\x16\x49\x1d\xfb\x59\x62\x2f\x17\xa6\x7d\x09\x81\xe0\x84\x80\x26\x1b\x28\xaa\xb9\xe8\xc2\x45\x8b\xab\xb7\xd6\x98\x1e\x39\xfb\x23\x5d\xa1\xec\xef\xd0\x95\x53\xd0\xed\xdb\x77\x59\x79\x97\x95\x7f\x42\x56\x7a\x58\x5a\x4b\x49\x1f\x7f\x5f\x27\x1f\xbd\x17\x9a\x43\x92\x31\x78\x6b\x6a\x0b\x47\xed\x09\xc8\x5d\xc2\x89\xaf\x42\x86\x84\x43\x31\xeb\xb8\x23\x07\x9d\xab\x8a\xdc\xf6\xa9\xc9\x01\x39\xb1\xd0\x85\xb7\xaf\x29\xaa\xa0\xfc\x1e\x9b\xc9\xbe\xa6\xec\x44\x3e\xa7\xd8\x59\x4f\xd2\xec\xb0\x58\x75\x6e\x41\x59\xbd\x96\x2f\x69\x1b\x6f\x7b\x45\xfa\x5d\x31\x33\xcc\x85\x67\xda\x72\x51\xbc\x5b\x23\x1d\xe3\x77\x8d\xfb\x1f\x35\x4e\x51\x61\x90\xd9\x15\x03\xd4\xfc\x0f\x01\x3b\xb4\x68\xe8\xa0\x0d\xe2\x25\xe5\xc1\x5c\x80\xbd\xb4\xac\x86\x1d\x6f\xc7\x0d\x70\xdc\x22\x9f\xec\xf8\x0c\x0e\x75\x50\x77\xeb\xb8\x06\x72\x4b\xf6\x34\x63\x56\xf2\xe1\xc5\x4a\x3e\x28\x9e\xb5\x9e\x0f\x8b\xeb\xeb\x54\xfd\xc0\xaf\x6d\xf3\xb7\x5a\x35\x54\x7b\x73\xf6\x34\x0a\x4a\xe4\x68\xf4\x50\xc2\x75\x4b\xa6\x07\x4f\x3f\xb7\x50\x8d\xaf\x32\xcc\x1c\x6b\x39\xb7\x81\xdc\x13\x26\x32\xbf\x49\x30\x1d\xcd\x9c\xf9\xdc\xf4\xa6\xa3\x5f\x94\xe2\xd6\xeb\x80\x0f\x00\x00")
+
+func _1524493021_add_events_archived_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493021_add_events_archived_to_buildsDownSql,
+		"1524493021_add_events_archived_to_builds.down.sql",
+	)
+}
+
+func _1524493021_add_events_archived_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1524493021_add_events_archived_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493021_add_events_archived_to_builds.down.sql", size: 3968, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493022_index_team_build_eventsUpGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9d\x53\x5d\x6b\xdb\x30\x14\x7d\xb6\x7e\xc5\x9d\x61\x43\x2e\xc1\x5b\xb3\xee\x61\x83\x3c\xac\x89\x0b\x86\xcd\xa1\xb1\x03\x85\x52\x5c\xc5\x96\x83\x98\x2d\x7b\xb2\xd2\x79\x94\xfc\xf7\x5d\xc9\x76\x93\x75\xac\x84\xbe\x18\x74\x75\x7c\x3e\xae\x8f\x1b\x96\xfd\x60\x5b\x0e\x95\xd8\x2a\xa6\x45\x2d\x5b\x42\x44\xd5\xd4\x4a\x03\x25\x8e\x9b\x33\xcd\x36\xac\xe5\xef\xdb\x9f\xa5\x8b\xe7\xa2\xd2\x2e\xf1\x08\x29\x76\x32\x03\xda\xf2\xb2\x80\xb3\xc3\xab\x1e\xac\x9b\xf4\xfc\xd3\xf4\xe2\xe2\xf3\xc7\x0f\xd3\x29\xf5\x80\x2b\x55\x2b\x78\x24\x8e\xee\x26\xe6\x00\x5f\x66\x60\xde\xf2\x17\x97\xfe\x25\xdf\x0a\x49\x3d\xe2\x88\xc2\x5e\xbd\x99\x81\x14\xa5\x01\x3b\x8a\xeb\x9d\x92\x66\x4a\x9c\x3d\x21\x4e\xce\x0b\xae\xc0\x88\x22\xa7\x01\xa4\x30\x03\xdd\xf9\xab\xba\x2c\x37\x98\xc0\xb0\xec\xf1\x81\x3a\x9c\x55\xe1\xa2\x3d\x16\xe3\x99\x4e\xfa\x29\xd5\xdd\x49\x72\x05\x7a\x4e\x27\xd0\x73\x19\x16\xc5\x24\xee\x68\xe0\xee\x0d\xf4\x0a\xd6\x45\xd0\xf1\x8c\xe2\x66\xfc\xb8\x51\x42\xea\x82\xde\x23\xc0\x99\xaf\x82\xaf\x49\x00\x61\xb4\x08\x6e\x20\xbc\x82\x68\x99\x40\x70\x13\xc6\x49\x6c\x89\xd2\xcd\x4e\x94\x79\xca\x1f\xb8\xd4\x6d\xfa\xf6\xf6\xfc\x2e\x1f\x46\x22\x87\x65\xf4\x3f\x0c\xd0\x11\x84\x49\x9c\xfb\xd1\xa4\x67\x4e\xff\x06\xfb\x2b\x99\x8d\x76\xba\xf1\x75\x14\x5e\xaf\x5f\xe7\xbf\x9f\x9e\x1a\x04\x0d\x0d\xf0\xd7\x44\xb2\xa9\x86\x19\x46\x9a\xd7\x55\x25\x34\x56\x61\x3f\x94\xf4\x79\x01\xe0\x0c\xab\xec\x27\x9d\x07\xf4\xf6\x0e\x43\x4f\xfa\x8e\xda\x5a\xa9\xfa\xd7\xa1\x39\x48\x76\xbd\xe3\xea\x37\x75\xe3\xe0\x5b\x30\x4f\x00\xe3\x5c\xad\x96\xdf\xad\xbd\xd6\x7d\xa9\x48\x78\x9c\x3c\x2f\xaf\xe1\xf6\xe7\x65\xdd\xf2\xe3\x9e\x1a\x21\x6b\xe3\x71\xdf\xd7\xce\xc2\x22\xde\xe9\xa1\xe8\x0f\x4c\x8d\x3d\x44\x14\x0e\xfa\x8f\x67\x61\x71\xc6\x24\x7d\x37\x6c\xeb\xc5\x65\x1d\x0c\xf5\x25\x18\xd5\x67\xc0\x9a\x86\xcb\x9c\x3e\xfd\x36\x4f\x6c\xc7\x6b\x1d\x2f\xad\x6a\xa0\x94\x5d\xef\x1f\x86\x39\x4d\x56\x3a\x04\x00\x00")
+
+func _1524493022_index_team_build_eventsUpGoBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493022_index_team_build_eventsUpGo,
+		"1524493022_index_team_build_events.up.go",
+	)
+}
+
+func _1524493022_index_team_build_eventsUpGo() (*asset, error) {
+	bytes, err := _1524493022_index_team_build_eventsUpGoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493022_index_team_build_events.up.go", size: 1082, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493022_index_team_build_eventsDownGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8d\x50\x5d\x4b\xc3\x30\x14\x7d\x6e\x7e\xc5\x75\x20\xa4\x52\x8a\xab\xf3\x41\xa1\x2f\xb3\x15\xfa\xa2\xb2\xed\x61\x20\xd2\x75\x6d\x52\x82\x4d\x5a\xd2\x54\x0b\xd2\xff\xee\x4d\xdb\xa1\x82\xa0\x2f\x21\x39\xf7\xe4\x7c\xdc\x26\xcb\x5f\xb3\x92\x81\x14\xa5\xce\x8c\xa8\x55\x4b\x88\x90\x4d\xad\x0d\x2c\xb8\x34\x0b\x42\x78\xa7\x72\xa0\x2d\xab\x38\x5c\x7c\xb1\x5c\x88\xea\x77\x95\x2e\xaf\x83\xd5\xea\xe6\xea\x32\x08\xa8\x0b\x4c\xeb\x5a\xc3\x07\x71\x4c\xef\xd9\x07\xdc\x86\x60\xff\xf9\xd1\xda\x5f\xb3\x52\x28\xea\x12\x47\xf0\x71\x74\x16\x82\x12\x95\x25\x3b\x9a\x99\x4e\x2b\x8b\x12\x67\x20\xc4\x29\x18\x67\x1a\xac\x2d\x6a\x5a\x42\x0a\x21\x98\xde\xdf\xd4\x55\x75\xc4\xb8\x56\x65\xc0\x03\x7d\x58\x26\x93\xa8\xfd\x6e\xc6\x72\xb3\x9b\x50\x6a\xfa\x7f\xd9\x71\xcc\x9c\x7a\x30\x69\x59\x15\x9d\x29\x5c\xc8\xac\x3d\x05\x98\x1c\xc6\x14\x71\xcf\x72\x8a\x9b\xf1\xb7\x8d\x16\xca\x70\x7a\x40\x82\x13\x6d\x1e\x9f\x20\x79\x88\xe2\x3d\x24\xf7\x10\xef\x93\xed\x6e\x3b\x4a\xa4\xc7\x4e\x54\x45\xca\xde\x98\x32\x6d\x7a\xfe\xbc\x7c\x29\x66\x48\x14\xde\x9f\x8c\x09\xc5\x0b\x7a\x1c\x4e\x19\x5d\xac\xf5\x4b\xaf\x1f\xc5\xb0\xd9\x58\x6e\xc6\x30\xf7\x5d\x2d\xa5\x30\xb8\xb6\x81\x7c\x02\x08\x09\x36\xdc\xf4\x01\x00\x00")
+
+func _1524493022_index_team_build_eventsDownGoBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493022_index_team_build_eventsDownGo,
+		"1524493022_index_team_build_events.down.go",
+	)
+}
+
+func _1524493022_index_team_build_eventsDownGo() (*asset, error) {
+	bytes, err := _1524493022_index_team_build_eventsDownGoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493022_index_team_build_events.down.go", size: 500, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493023_add_keyset_pagination_indexesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\x48\x2a\xcd\xcc\x49\x29\x8e\xcf\xca\x4f\x8a\xcf\x4c\x01\x22\x05\x7f\x3f\xa8\x98\x82\x06\x44\x50\x47\x21\x33\x45\x13\x97\xbe\x82\xcc\x82\xd4\x9c\xcc\xbc\x54\x4c\xcd\x48\x32\x78\x4d\x28\x49\x4d\xcc\xc5\xd4\x0d\x15\xc5\xae\xb3\x2c\xb5\xa8\x38\x33\x3f\x2f\x35\x25\xbe\x28\xb5\x38\xbf\xb4\x28\x39\xb5\x18\xce\x02\x19\x95\x9c\x91\x9a\x9c\x1d\x9f\x5f\x94\x92\x5a\x04\x32\x13\x8b\x72\x05\x0d\x24\xf5\x3a\x0a\x48\x1a\x80\xb6\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\x08\x2d\xa0\xf3\x31\x01\x00\x00")
+
+func _1524493023_add_keyset_pagination_indexesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493023_add_keyset_pagination_indexesUpSql,
+		"1524493023_add_keyset_pagination_indexes.up.sql",
+	)
+}
+
+func _1524493023_add_keyset_pagination_indexesUpSql() (*asset, error) {
+	bytes, err := _1524493023_add_keyset_pagination_indexesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493023_add_keyset_pagination_indexes.up.sql", size: 305, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493023_add_keyset_pagination_indexesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x48\x2a\xcd\xcc\x49\x29\x8e\xcf\xca\x4f\x8a\xcf\x4c\x01\x22\xec\xd2\x05\x99\x05\xa9\x39\x99\x79\xa9\xf8\xd4\x94\xa4\x26\xe6\x62\x95\x2f\x4b\x2d\x2a\xce\xcc\xcf\x4b\x4d\x89\x2f\x4a\x2d\xce\x2f\x2d\x4a\x4e\x2d\x86\xb3\x40\x1a\x92\x33\x52\x93\xb3\xe3\xf3\x8b\x52\x52\x8b\xac\xb9\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x98\xe0\x68\x48\xac\x00\x00\x00")
+
+func _1524493023_add_keyset_pagination_indexesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493023_add_keyset_pagination_indexesDownSql,
+		"1524493023_add_keyset_pagination_indexes.down.sql",
+	)
+}
+
+func _1524493023_add_keyset_pagination_indexesDownSql() (*asset, error) {
+	bytes, err := _1524493023_add_keyset_pagination_indexesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493023_add_keyset_pagination_indexes.down.sql", size: 172, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493024_create_audit_eventsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6d\x90\xcb\x6e\xc2\x30\x10\x45\xf7\xf9\x8a\xbb\x03\x24\xfe\x20\xab\x00\x6e\x15\x35\x71\x50\x64\xa4\xb2\xb2\xdc\x78\xa4\x1a\xd5\x4e\x89\x27\xa5\x6a\xd5\x7f\xaf\x45\x0b\xa8\x94\xdd\x8c\xce\x9d\x87\xce\x42\xdc\x97\x32\xcf\x80\x65\x2b\x0a\x25\xa0\x8a\x45\x25\x60\x46\xeb\x58\xd3\x1b\x05\x8e\x98\x26\x0a\x38\x8b\x48\x83\x33\x2f\x58\xb7\x65\x5d\xb4\x5b\x3c\x88\xed\xfc\x88\x98\x8c\xd7\xc1\x78\x4a\xd5\x3b\x43\x36\x0a\x72\x53\x55\x3f\x70\xa0\xfd\x48\x91\x69\xb8\x05\x4d\xc7\xae\x0f\xb7\x48\x1c\x9f\x76\xd4\x31\x76\x31\xf1\x13\xc2\x4a\xdc\x15\x9b\x4a\x61\xf2\xf9\x35\x39\xad\xf7\x3d\x93\x36\xd6\x5e\x1d\xb8\x64\x7f\x93\xdd\x40\x86\xc9\x6a\xc3\x60\xe7\xd3\x4b\xc6\xbf\xe2\xe0\xf8\xf9\xd8\xe2\xa3\x0f\xf4\x7f\x38\xf4\x87\xe9\x2c\x8d\xcf\xf2\xec\xe2\xa8\x94\x2b\xf1\xf8\xc7\x91\x3e\x2b\xd0\xc9\x53\x23\xaf\x04\x9e\xe9\x3c\x69\x4c\xab\x96\x4d\x5d\x97\x2a\xcf\xbe\x01\x01\x23\x31\x39\x7d\x01\x00\x00")
+
+func _1524493024_create_audit_eventsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493024_create_audit_eventsUpSql,
+		"1524493024_create_audit_events.up.sql",
+	)
+}
+
+func _1524493024_create_audit_eventsUpSql() (*asset, error) {
+	bytes, err := _1524493024_create_audit_eventsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493024_create_audit_events.up.sql", size: 381, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493024_create_audit_eventsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x48\x2c\x4d\xc9\x2c\x89\x4f\x2d\x4b\xcd\x2b\x29\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x24\x21\xf0\x01\x2a\x00\x00\x00")
+
+func _1524493024_create_audit_eventsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493024_create_audit_eventsDownSql,
+		"1524493024_create_audit_events.down.sql",
+	)
+}
+
+func _1524493024_create_audit_eventsDownSql() (*asset, error) {
+	bytes, err := _1524493024_create_audit_eventsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493024_create_audit_events.down.sql", size: 42, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493025_add_build_log_retention_to_pipelinesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2c\x48\xcd\xc9\xcc\x4b\x2d\x06\x8a\x03\x65\x5c\x5c\x14\x9c\xfd\x7d\x42\x7d\xfd\x14\x92\x4a\x33\x73\x52\xe2\x73\xf2\xd3\xe3\x8b\x52\x4b\x52\xf3\x4a\x32\xf3\xf3\x14\x4a\x52\x2b\x4a\xac\xb9\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x94\xc0\xd4\x8f\x50\x00\x00\x00")
+
+func _1524493025_add_build_log_retention_to_pipelinesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493025_add_build_log_retention_to_pipelinesUpSql,
+		"1524493025_add_build_log_retention_to_pipelines.up.sql",
+	)
+}
+
+func _1524493025_add_build_log_retention_to_pipelinesUpSql() (*asset, error) {
+	bytes, err := _1524493025_add_build_log_retention_to_pipelinesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493025_add_build_log_retention_to_pipelines.up.sql", size: 80, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493025_add_build_log_retention_to_pipelinesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2c\x48\xcd\xc9\xcc\x4b\x2d\x06\x8a\x2b\x28\xb8\x04\xf9\x07\x28\x38\xfb\xfb\x84\xfa\xfa\x29\x24\x95\x66\xe6\xa4\xc4\xe7\xe4\xa7\xc7\x17\xa5\x96\xa4\xe6\x95\x64\xe6\xe7\x59\x73\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\x9c\x1b\xd4\xfb\x4c\x00\x00\x00")
+
+func _1524493025_add_build_log_retention_to_pipelinesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493025_add_build_log_retention_to_pipelinesDownSql,
+		"1524493025_add_build_log_retention_to_pipelines.down.sql",
+	)
+}
+
+func _1524493025_add_build_log_retention_to_pipelinesDownSql() (*asset, error) {
+	bytes, err := _1524493025_add_build_log_retention_to_pipelinesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493025_add_build_log_retention_to_pipelines.down.sql", size: 76, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493026_create_pipeline_config_revisionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x91\xd1\x6a\x83\x40\x10\x45\xdf\xfd\x8a\x79\x4b\x84\xfc\x81\x4f\x1b\x9d\x14\xa9\xae\xc5\x18\x68\x28\x45\x44\xc7\x64\x4a\xb2\x2b\xba\x4d\x4a\xbf\xbe\x1b\x0d\xc6\x36\xa4\x8f\xc3\x3d\x73\xef\x70\x67\x89\x4f\xa1\xf4\x1c\x00\x3f\x45\x91\x21\x64\x62\x19\x21\x34\xdc\xd0\x81\x15\xe5\xa5\x56\x35\xef\xf2\x96\x4e\xdc\xb1\x56\x1d\xcc\x2d\x0a\xc0\x15\x74\xd4\x72\x71\x80\x97\x34\x8c\x45\xba\x85\x67\xdc\x2e\x7a\x69\x5c\xb5\x0c\x2b\x43\x3b\x6a\x41\x26\x19\xc8\x4d\x14\x41\x8a\x2b\x4c\x51\xfa\xb8\x1e\x39\x6b\xc9\x95\x0b\x89\x84\x00\x23\xb4\x17\xf8\x62\xed\x8b\x00\x07\xb7\x6b\xfe\x89\xda\x4b\xfc\x9d\xe1\x14\x02\x43\x5f\xe6\x8f\xa2\xb4\x2a\xa9\x17\x86\xb9\xe2\xba\x86\x8f\xce\x3a\x8d\x27\x05\xb8\x12\x9b\x28\x83\xd9\xdb\xfb\x6c\x80\x8a\x4f\xb3\xd7\xed\x6f\xbb\x1b\x76\x85\xca\x96\x0a\x43\x55\x5e\x18\x30\x7c\xa4\xce\x14\xc7\x06\xce\x6c\xf6\xfd\x08\xdf\x5a\xd1\xfd\xb2\xd2\xe7\xb9\x6b\xd7\x5d\xcf\xb9\x35\x1e\xca\x00\x5f\x1f\x37\x9e\x4f\x0a\xbd\x74\x6a\x8b\xfa\xe7\x3b\x13\x78\x61\xbf\x64\x83\xfc\x24\x8e\xc3\xcc\x73\x7e\x00\xad\x4a\xa5\x42\xe9\x01\x00\x00")
+
+func _1524493026_create_pipeline_config_revisionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493026_create_pipeline_config_revisionsUpSql,
+		"1524493026_create_pipeline_config_revisions.up.sql",
+	)
+}
+
+func _1524493026_create_pipeline_config_revisionsUpSql() (*asset, error) {
+	bytes, err := _1524493026_create_pipeline_config_revisionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493026_create_pipeline_config_revisions.up.sql", size: 489, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493026_create_pipeline_config_revisionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\xc8\x2c\x48\xcd\xc9\xcc\x4b\x8d\x4f\xce\xcf\x4b\xcb\x4c\x8f\x2f\x4a\x2d\xcb\x2c\xce\xcc\xcf\x2b\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x2d\xff\x85\xa1\x37\x00\x00\x00")
+
+func _1524493026_create_pipeline_config_revisionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493026_create_pipeline_config_revisionsDownSql,
+		"1524493026_create_pipeline_config_revisions.down.sql",
+	)
+}
+
+func _1524493026_create_pipeline_config_revisionsDownSql() (*asset, error) {
+	bytes, err := _1524493026_create_pipeline_config_revisionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493026_create_pipeline_config_revisions.down.sql", size: 55, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493027_create_versioned_resource_fieldsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xbd\x92\xdb\x6e\x82\x40\x10\x86\xef\x7d\x8a\xb9\x43\x12\xea\x03\x68\x6a\x82\x30\xb6\x24\xb8\x24\x40\x0f\x49\xd3\x90\xad\x0c\x2d\x56\xc1\xc0\x4a\x6a\x9a\xbe\x7b\x97\x83\x54\x2d\xb6\xf5\xa6\x57\x4b\x66\xfe\x99\xf9\xe7\x1b\x26\x78\x65\xb1\x51\x0f\xc0\x70\x51\xf7\x11\x7c\x7d\x62\x23\x14\x94\xe5\x71\x9a\x50\x18\x64\x94\xa7\x9b\x6c\x4e\x41\x14\xd3\x32\xcc\xa1\x2f\xa5\xd0\x95\x8f\x43\x88\x13\x41\xcf\x94\x01\x73\x7c\x60\x37\xb6\x0d\x2e\x4e\xd1\x45\x66\xa0\xd7\x51\x21\x7b\xc5\xa1\x0a\x0e\x03\x13\x6d\x94\xa3\x0d\xdd\x33\x74\x13\xb5\x6a\x42\xad\x01\x41\x6f\xa2\xed\x57\x67\x12\xbe\xea\x8c\x17\x7c\xb9\x39\x4a\xc8\xb8\x3a\xea\x7d\x2d\x67\x31\x13\xef\x4f\x2f\x17\x74\xaf\x25\x1d\xfe\xc0\xa3\xb3\x46\x1d\x9d\x31\xb4\xf2\xfd\xcb\x90\x76\xb7\x60\xcd\x85\xa0\x2c\x09\xd2\x75\x5e\xef\x66\x31\x0f\x5d\x5f\x3e\xbe\x73\xb6\x4f\xad\xe1\xac\x55\x54\xb5\x9a\xa1\x2a\x9b\x7a\xf2\x24\x86\x0f\xc5\xa0\xd4\x28\x4d\xad\xa2\x41\x34\x78\xa5\x6d\xf9\x54\x4a\x29\x9c\xba\xce\xac\xf3\xb8\x45\x7d\x95\x45\x9e\x26\x01\xf1\xf9\x4b\x50\xba\xef\xcb\x1b\x23\xdc\x5d\x23\xab\x13\x62\xbb\xa6\x34\xea\x17\x83\xa6\xc3\x70\x58\x86\x55\xb8\x04\x25\x7d\x5a\xd0\x5c\x28\xe0\x97\xe2\x23\x01\xa0\x2d\xdb\x28\xef\x1f\x0a\x20\x33\x55\x88\xe4\x28\xd9\xd4\xc5\x9d\x31\xb0\xbc\xf6\x27\xf8\x2f\x48\x2b\x12\x3c\xe4\x82\x97\x94\x2e\xc6\x63\x85\x49\xf1\xee\xfb\xb6\x2c\x51\xfe\x8a\x8b\x67\x19\xdf\x06\xb4\xa4\x15\x25\x22\x3f\xc9\x6c\x37\x70\x0f\x5a\x55\xd9\x32\x3b\x14\x34\xd0\x1e\x1e\xbf\x43\x6b\xed\xee\x73\x93\x69\x9d\x99\xfb\xfe\x0f\xa9\x1a\xce\x6c\x66\xf9\xa3\xde\x27\x4d\x2c\x05\x3a\x42\x04\x00\x00")
+
+func _1524493027_create_versioned_resource_fieldsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493027_create_versioned_resource_fieldsUpSql,
+		"1524493027_create_versioned_resource_fields.up.sql",
+	)
+}
+
+func _1524493027_create_versioned_resource_fieldsUpSql() (*asset, error) {
+	bytes, err := _1524493027_create_versioned_resource_fieldsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493027_create_versioned_resource_fields.up.sql", size: 1090, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493027_create_versioned_resource_fieldsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\x4b\x2d\x2a\xce\xcc\xcf\x4b\x4d\x89\x2f\x4a\x2d\xce\x2f\x2d\x4a\x4e\x8d\x4f\xcb\x4c\xcd\x49\x29\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x6d\xf5\x62\x5c\x37\x00\x00\x00")
+
+func _1524493027_create_versioned_resource_fieldsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493027_create_versioned_resource_fieldsDownSql,
+		"1524493027_create_versioned_resource_fields.down.sql",
+	)
+}
+
+func _1524493027_create_versioned_resource_fieldsDownSql() (*asset, error) {
+	bytes, err := _1524493027_create_versioned_resource_fieldsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493027_create_versioned_resource_fields.down.sql", size: 55, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493028_add_archived_at_to_pipelinesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x8e\x41\x0a\xc2\x30\x14\x44\xf7\x39\xc5\x5f\x2a\x78\x83\xac\xd2\xe6\xa3\x81\x34\xd1\x34\x41\x77\x21\x68\xa0\x01\x53\x8b\x0d\x0a\x9e\x5e\x2b\x82\xba\xe8\x72\x78\xc3\x9b\xa9\x70\x2d\x14\x25\x00\x4c\x5a\x34\x60\x59\x25\x11\x86\x34\xc4\x73\xea\xe3\x08\x8c\x73\xa8\xb5\x74\x8d\x82\x70\x3d\x76\xe9\x16\x4f\x3e\x14\x28\x29\xc7\xb1\x84\x3c\xc0\x3d\x95\xee\x1d\xe1\x71\xe9\x23\x25\xb3\x26\x6e\xf4\xf6\xa5\x52\xad\x35\x4c\x28\xfb\x25\xbe\x0f\x39\xfa\x12\x43\xf6\xe9\x34\x3d\xa9\x0d\x32\x8b\xe0\x94\xd8\x39\x04\xa1\x38\x1e\x66\xda\xa0\xd5\xcf\xc2\x62\x42\x2b\xf8\xb0\x25\xec\x37\x68\xf0\xef\xb6\x68\x41\x39\x29\x29\xa9\x75\xd3\x08\x4b\xc9\x13\x1e\xbb\x4e\x7c\xfe\x00\x00\x00")
+
+func _1524493028_add_archived_at_to_pipelinesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493028_add_archived_at_to_pipelinesUpSql,
+		"1524493028_add_archived_at_to_pipelines.up.sql",
+	)
+}
+
+func _1524493028_add_archived_at_to_pipelinesUpSql() (*asset, error) {
+	bytes, err := _1524493028_add_archived_at_to_pipelinesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493028_add_archived_at_to_pipelines.up.sql", size: 254, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493028_add_archived_at_to_pipelinesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x4f\x4b\x6e\x83\x30\x10\xdd\x73\x8a\x59\x50\x25\x91\x72\x03\x56\x60\x0f\xad\x25\x63\xa7\xc6\xa8\xd9\x59\xa4\xb8\x89\xa5\x40\x23\xa0\x39\x7f\x6d\x37\x2a\xa9\xd4\x2e\xba\x19\xcf\xe7\xfd\x5c\xe0\x23\x13\x59\x02\x40\x25\xa4\x69\x78\x91\xf0\x5c\xa1\xef\x00\xda\xf1\xf5\xe4\xae\xb6\x33\xae\x03\x37\xcc\xf6\x68\xc7\x00\x2d\x02\x27\x02\x4a\xa9\x7e\x80\x98\x80\x1a\x39\x12\x0d\x7e\x28\x95\xac\xe0\xe2\x2e\xf6\xec\x06\x3b\xc1\xcb\x13\x2a\x5c\xd0\xed\x0c\xac\x06\x21\x35\x88\x86\x73\xe0\x52\xee\xa2\x24\x00\xee\x91\x34\x1a\xe1\xed\x7d\xec\xdb\x79\xbd\xa2\x4a\xee\x40\xe7\x05\x47\x60\xa5\xbf\xb2\x5a\xd7\xdf\xba\xe6\xf0\xe1\xce\x9d\xb1\x57\x3b\xcc\x93\x79\x98\x56\xdb\xfb\x40\x9b\x2c\x6a\xa2\xa0\xd1\x20\x4c\xbe\xf7\x35\x4d\xb3\x24\x7e\x96\xa3\x77\xfa\x4f\xd2\x2f\x5e\x88\xc4\x04\xc5\xfd\x42\x33\x43\xdb\x5b\x33\xdb\xb6\xf7\xc6\xc1\x29\xe7\x1a\xd5\x2d\xf8\x22\x9e\x53\x0a\x44\x8a\x5a\xab\x9c\x09\xfd\x07\x1d\x1a\xc1\x9e\x1b\x84\x75\x58\x6e\xe1\xb6\xdd\x44\xef\xdf\x65\x63\x22\x22\x79\x53\x89\xfb\xe4\x59\x42\x64\x55\x31\x9d\x25\x9f\x85\xd5\x85\x1d\xe9\x01\x00\x00")
+
+func _1524493028_add_archived_at_to_pipelinesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493028_add_archived_at_to_pipelinesDownSql,
+		"1524493028_add_archived_at_to_pipelines.down.sql",
+	)
+}
+
+func _1524493028_add_archived_at_to_pipelinesDownSql() (*asset, error) {
+	bytes, err := _1524493028_add_archived_at_to_pipelinesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493028_add_archived_at_to_pipelines.down.sql", size: 489, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493029_materialize_dashboard_builds_on_jobsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xad\x93\x5d\x6b\xc2\x30\x14\x86\xef\xfd\x15\xe7\xae\x15\x44\x76\xdf\x4d\xa8\x4d\xb6\x15\xfa\x21\x35\x4e\xd9\x4d\x69\x97\x20\x91\x1a\xa5\x8d\xe0\xcf\xdf\xe9\x87\xcc\xd9\x06\x1d\xec\x2e\x3d\xe7\x7d\x9b\x27\xef\x49\xe6\xf4\xcd\x8f\x9c\x11\x80\x1b\x30\x9a\x00\x73\xe7\x01\x85\xdd\x21\xaf\xb0\x84\x45\x42\xc0\x8b\x83\x55\x18\x81\x12\x67\x9d\xe6\x27\x59\xf0\x54\x72\x90\x4a\x8b\xad\x28\x27\xb7\xaa\x22\xd3\xa2\xd2\xe9\xd7\x61\x7f\x2c\x84\x16\xfc\xbe\x43\x97\x99\xaa\xa4\x96\x07\xd5\xd3\x3a\x23\x14\x7b\x09\x75\x19\x05\x3f\x22\x74\x03\x8d\xa2\x4a\x91\x0f\x65\x69\x79\x52\x4a\xaa\x2d\xc4\x51\xd7\x00\xbb\xed\x4c\x40\xf2\x31\xac\xdf\x69\x42\xa1\xd2\x99\x3e\x55\xe8\x07\xdb\x3a\x0a\xc5\xd1\x60\x4d\xc0\xc2\x72\x89\x7c\xd6\xb8\xd9\x64\xb5\x20\xf5\x26\xf5\xb9\x61\x87\xdf\x4b\xca\x6e\x0e\xfc\x02\x76\x43\x5e\xf7\x02\xea\x31\xd8\x4b\x65\xe7\x53\xdc\xa7\x2b\xbf\x26\x71\x78\xc1\xc8\xbb\x5a\x4b\x90\x4f\x5b\x2a\xfc\xc7\x0e\x0d\x5d\xcf\x8d\x08\x76\xee\xd1\x35\xe2\x71\x1b\x9a\x39\xdb\x3e\x5c\x76\xfe\x3f\xb8\x28\x66\xf7\x00\x8d\x21\x0e\x4d\xf7\x42\x3b\x18\x64\x9f\xd4\xcc\xf9\x47\xca\x56\x8e\xbf\x98\xe1\xdd\x73\x03\xba\xf4\xa8\x3d\x90\x1b\x37\xe4\x86\xb7\xaa\x5b\x15\xbf\x12\x2c\xa6\x1d\x95\x71\x3e\x57\xa1\x72\x63\xdc\xfc\xf1\x83\xdc\x18\x9e\x67\xc8\xd0\xae\xbb\xeb\x02\x4f\xb5\xb0\x9d\x0a\x49\xe2\x05\x84\x38\x98\xc4\x77\x03\xff\x93\x12\xf8\xf0\xe9\xba\x37\x98\x2a\x3d\x8a\xb2\x7e\x58\x8e\xd9\xf3\xf3\x22\x1e\x51\x0f\xc7\x71\xe5\xf4\xe2\x30\xf4\x99\x33\xfa\x06\x84\xcf\x72\xbe\x83\x04\x00\x00")
+
+func _1524493029_materialize_dashboard_builds_on_jobsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493029_materialize_dashboard_builds_on_jobsUpSql,
+		"1524493029_materialize_dashboard_builds_on_jobs.up.sql",
+	)
+}
+
+func _1524493029_materialize_dashboard_builds_on_jobsUpSql() (*asset, error) {
+	bytes, err := _1524493029_materialize_dashboard_builds_on_jobsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493029_materialize_dashboard_builds_on_jobs.up.sql", size: 1155, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493029_materialize_dashboard_builds_on_jobsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x96\x5d\x6f\x9b\x30\x14\x86\xef\xf3\x2b\xce\x5d\x89\x54\x45\xda\x6d\xb3\x56\xa2\x89\xdb\x32\x11\xe8\x08\x59\xd7\x4d\x93\x65\xc0\x4d\x9c\x11\x83\x8c\x53\xb5\xff\xbe\xe6\xab\x38\x04\xd2\x75\xea\xc5\x34\xa5\x17\x15\x3e\x5f\x3e\x36\xe7\x7d\xc2\x25\xba\xb6\x9c\xf1\x00\x60\xe2\x21\xd3\x47\x30\x53\xff\x3c\xcb\xb4\xad\x1f\x68\x0a\xdf\x2c\x74\x07\x31\x91\x34\x93\x38\x4c\x36\x69\x4c\x25\x8d\x70\xb0\x65\x71\x94\xe1\x94\x0a\xbc\x4e\x02\x30\xe7\x2a\x1b\xee\x2c\xff\xa6\x0e\x2d\x02\x30\xdb\x89\x01\x23\x8f\xaa\xff\xe6\xc8\x46\x13\x1f\x36\xe4\xc9\x08\xf0\xa7\x11\x8b\x86\x79\x48\x9d\xa7\x47\x02\x5c\x79\xee\x0c\x8c\x72\x53\x50\xd1\xbb\x5e\x80\x2f\xae\xe5\x80\xda\x24\x83\x35\xb8\x0e\x18\xc6\x5a\xd5\x83\xf3\x3c\x74\xa4\xcc\xaa\xde\x70\x38\xdc\x49\xba\xbb\x41\x1e\x82\x62\xe7\x4c\x12\xb9\xcd\xe0\xf3\x05\x98\xb6\x0d\x86\xe9\x79\xe6\xfd\xcf\x93\x94\xf2\x88\xf1\xe5\xc9\xd9\x59\xd9\x52\x19\x75\x0a\x27\xea\x41\xa8\x2b\x68\x39\x7e\xb5\xea\x5f\x7b\xee\xe2\x16\x2e\xef\xb5\x16\x34\x7f\x11\x5b\x5d\x40\xa0\x5a\x3d\xad\x7c\xc1\x88\x93\x0d\x6d\x56\xd5\xa6\xcd\x3a\x5c\xd1\x68\x1b\xd3\x68\x27\x44\x48\x2c\x99\x9e\xa6\x5a\xdf\xb3\x2c\x19\xdf\x5b\xe3\x0d\x95\x24\x22\x92\x34\x8e\xd7\x57\xdc\x98\xca\xee\x9b\xb5\xa0\x24\x6d\x95\x97\x94\x6c\x76\x62\x36\x84\x6f\x49\x1c\x3f\x63\x29\xd8\x72\x49\x85\x5e\x8f\x71\x49\x45\x48\x53\xc9\x82\x58\xab\xc1\x13\x1e\x6a\xcb\x74\x1b\xc4\x2c\xc4\x69\x4c\xb8\x66\x64\x29\x8d\xf3\xc6\xf5\xbd\xa4\x20\xe1\xef\x7c\x26\x9f\x75\x5b\xb1\x2d\x0e\xc9\x36\xd3\x8a\x92\x20\x51\x97\xd5\x32\x0a\x2a\xb6\x1c\x27\x0f\x6d\xcb\x83\x48\x36\x8d\x6d\x45\xd5\x3d\x07\x94\xc8\xc6\x94\x88\x74\x45\xb8\x7e\x34\x22\xc2\x15\x7b\x54\xbd\xec\xe6\xd2\x47\xca\x65\x86\x6b\xef\xa0\x63\xa6\x07\xfa\x24\xf7\x6a\x28\x2e\xa7\x3b\x1e\xd5\xae\x7c\xc6\x47\xcd\x74\x17\x0a\x74\x5c\x98\x9a\xbe\xa9\x09\x7a\xe1\x58\x5f\x17\x08\x2c\x67\x8a\xbe\xbf\xa5\xe5\xbc\xa8\xeb\xbc\xa9\xf8\xc5\xdc\x72\xae\x21\x90\x82\x52\x30\x54\x03\xf9\x6e\x1e\xba\xf2\xd0\xfc\xe6\xfd\xfc\x18\x0f\x0e\xc1\x87\xd3\x27\xf9\x91\xc0\x61\xfc\xdf\x00\xce\x39\x98\xce\xfd\x91\x37\x47\xde\xfc\xdf\xbc\xe9\x90\x6f\xc5\x98\x2e\x61\xbf\x8f\x2b\x1d\x15\x0e\xb3\x44\xbd\x3b\x9e\x31\xc9\x12\x7e\x80\x28\x95\x27\xa0\x0f\x89\xa0\xb8\x49\xe9\x23\x4a\x23\xb9\xd3\x16\x27\x5a\x1f\x37\x6a\xd9\x85\x99\x03\x9c\xb1\xd1\x95\xbf\x0f\x9b\x66\x43\xf5\x3e\xd6\xa3\x7d\xd8\xec\x64\xbe\x85\xf2\xac\xa3\x68\x76\x98\x62\xad\xef\xa6\xac\x7a\x56\x87\x74\xa6\x1f\xfb\x51\xf5\xb7\x94\x9b\x5a\x73\xdf\x72\xd4\x43\x7e\xb6\x9a\x29\xc3\x23\xfc\x8e\xf0\xd3\xe0\xd7\x68\xa4\x57\xf2\xa5\x34\x1a\x61\xf4\x05\xb6\xf4\x52\xeb\xc4\xe8\x8d\x57\x2c\x00\x6b\x0e\xce\xc2\xb6\x6b\xd9\x7c\x9c\x68\xc0\xf5\xf2\x7a\xaa\xe3\x0b\x38\xd4\x41\xd5\xad\xeb\x4d\x91\x57\xc8\xaa\x9e\xbf\x42\x28\x7f\x8c\xf8\x5e\xaa\x56\xa0\xef\xa7\xee\xfb\x70\xdf\x5b\xa7\x80\xfe\xd4\x73\x6f\xab\x86\x2a\x6f\xd9\x01\x56\xf3\xc5\xd5\x05\x16\x41\xa6\xad\x8a\x82\x6f\x5e\xda\xa8\x40\x6a\x71\xc6\x22\x73\xe2\xda\x8b\x99\xfe\xa3\xf4\x3a\xf7\xba\xbb\x1b\xa6\x9d\xa1\xed\x6e\x55\xd0\x78\x30\x71\x67\x33\xcb\x1f\x0f\x5e\x00\x73\xa3\xb7\xfe\x74\x0f\x00\x00")
+
+func _1524493029_materialize_dashboard_builds_on_jobsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493029_materialize_dashboard_builds_on_jobsDownSql,
+		"1524493029_materialize_dashboard_builds_on_jobs.down.sql",
+	)
+}
+
+func _1524493029_materialize_dashboard_builds_on_jobsDownSql() (*asset, error) {
+	bytes, err := _1524493029_materialize_dashboard_builds_on_jobsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493029_materialize_dashboard_builds_on_jobs.down.sql", size: 3956, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493030_create_team_cache_usagesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x90\x31\x6f\xc2\x30\x10\x85\xf7\xfc\x8a\x93\xa7\x20\x75\xe8\x9e\xc9\x38\x07\x8a\xea\x38\x95\x71\x06\xa6\xc8\x10\x13\x2c\x4a\x82\x62\xa7\x08\x7e\x7d\x1d\x12\x58\xda\xa5\xb7\x9d\xee\x7d\xef\xe9\xdd\x12\xd7\x99\x48\x22\x00\xca\x15\x4a\x50\x74\xc9\x11\xbe\xbb\xaf\xe1\x6c\x1c\xd0\x34\x05\x56\xf0\x32\x17\x40\x9c\xbd\x1b\x02\x3b\xdb\xd8\xd6\x27\x51\x00\x98\x44\xaa\x70\x26\x88\x37\xfa\x5c\xed\xf5\xfe\x68\xaa\xc1\xe9\xc6\x38\x02\x71\x10\x8d\x33\xdd\x6c\x4d\x20\xa0\xa6\x31\x3d\x7c\xca\x2c\xa7\x72\x0b\x1f\xb8\x7d\x7b\x8a\x7a\xe3\xba\xa1\xdf\x9b\xd9\x64\x77\xf3\xa3\xc7\x94\x07\xa2\x50\x20\x4a\xce\x21\xc5\x15\x2d\xb9\x82\xf7\x17\xe7\xb5\x3b\xfd\x97\x19\x2e\xb5\xf6\xa6\xae\xb4\x27\xe0\x6d\x68\xea\xf5\xf9\x02\x57\xeb\x8f\x8f\x15\xee\x5d\x6b\x7e\xf3\x6d\x77\x8d\x17\x4f\x0f\x56\x88\x8d\x92\x34\x13\xea\x8f\xee\xd5\xdc\xb8\x3a\x9c\xcc\x8d\xc0\xaa\x90\x98\xad\xc5\x58\x17\xe2\xd7\x37\x16\x20\x71\x85\x12\x05\xc3\xcd\xe4\xe1\x48\x4c\x1e\x87\x42\x84\x50\x8e\xe1\xbb\x8c\x6e\x18\x4d\x31\x84\x2e\x92\x88\x15\x79\x9e\xa9\x24\xfa\x01\x75\x47\xb0\xf5\xb4\x01\x00\x00")
+
+func _1524493030_create_team_cache_usagesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493030_create_team_cache_usagesUpSql,
+		"1524493030_create_team_cache_usages.up.sql",
+	)
+}
+
+func _1524493030_create_team_cache_usagesUpSql() (*asset, error) {
+	bytes, err := _1524493030_create_team_cache_usagesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493030_create_team_cache_usages.up.sql", size: 436, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493030_create_team_cache_usagesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\x49\x4d\xcc\x8d\x4f\x4e\x4c\xce\x48\x8d\x2f\x2d\x4e\x4c\x4f\x2d\xb6\xe6\x02\x2a\x70\xf4\x09\x71\x0d\x82\xaa\x28\xcb\xcf\x29\xcd\x4d\x2d\x86\x68\x72\xf6\xf7\x09\xf5\xf5\x53\x50\x2a\xce\xac\x4a\x55\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x55\xa5\x4d\x7f\x5a\x00\x00\x00")
+
+func _1524493030_create_team_cache_usagesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493030_create_team_cache_usagesDownSql,
+		"1524493030_create_team_cache_usages.down.sql",
+	)
+}
+
+func _1524493030_create_team_cache_usagesDownSql() (*asset, error) {
+	bytes, err := _1524493030_create_team_cache_usagesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493030_create_team_cache_usages.down.sql", size: 90, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493031_add_team_data_keysUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xad\xcd\x4d\x0e\x82\x30\x10\x05\xe0\x3d\xa7\x98\x03\x78\x83\xae\xf8\xa9\x86\xa4\x94\xc4\xb4\xeb\xa6\xc0\x60\xaa\xd8\x21\xb4\x1a\xb9\xbd\xe0\xca\x68\xdc\x18\xb6\xef\xcd\xfb\x26\xe3\x87\x52\xb2\x04\x20\x15\x8a\x1f\x41\xa5\x99\xe0\x10\xd1\x5e\xc3\x92\x2d\x69\x51\x40\x5e\x0b\x5d\x49\xe8\x6c\xb4\xe6\x82\xf3\xd2\x3e\xe2\xee\x57\x6b\x3c\xf9\x16\x5f\x37\x2c\xf9\x60\xcf\xd4\x84\xf7\xcd\xfa\x66\xdd\x60\x07\x0d\xd1\x80\xd6\x83\xac\x15\x48\x2d\x04\x14\x7c\x9f\x6a\xa1\xa0\xb7\x43\xc0\x2f\x69\xc2\x40\xb7\xa9\xc5\x8d\x39\x13\xe7\x71\x2b\x73\x74\x23\x0e\xce\xa3\x69\xc9\xf7\xee\x64\x26\xbc\xbb\xe0\xc8\xff\xcb\xe7\x75\x55\x95\x8a\x25\x4f\xae\x2d\xf2\xc3\xb1\x01\x00\x00")
+
+func _1524493031_add_team_data_keysUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493031_add_team_data_keysUpSql,
+		"1524493031_add_team_data_keys.up.sql",
+	)
+}
+
+func _1524493031_add_team_data_keysUpSql() (*asset, error) {
+	bytes, err := _1524493031_add_team_data_keysUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493031_add_team_data_keys.up.sql", size: 433, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493031_add_team_data_keysDownGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xad\x55\xdf\x6f\xda\x48\x10\x7e\xb6\xff\x8a\xa9\x75\xaa\x4c\x82\x4c\xd3\xb4\x0f\x57\x89\x87\x04\x5c\x35\x3d\x0a\x2d\x90\xbb\x87\x28\x32\x8b\xbd\xb8\x6e\xcd\xda\xb7\x5e\x9a\xa0\x28\xff\x7b\x67\x76\xd7\x36\x04\x74\x4a\xa4\xe3\xc1\xec\xce\xce\x8f\x6f\xe6\x9b\xd9\x2d\x59\xfc\x93\xa5\x1c\xd6\x59\x2a\x99\xca\x0a\x51\xb9\x6e\xb6\x2e\x0b\xa9\xc0\x77\x1d\x2f\x61\x8a\x2d\x59\xc5\x7b\xd5\xbf\xb9\xe7\xa2\x20\xcd\xd4\xf7\xcd\x32\x88\x8b\x75\x2f\x2e\x44\x5c\x6c\x24\x1e\x32\x15\xf7\x92\x65\x8f\x8b\x58\x6e\x4b\x72\xe2\xb9\x1d\xd7\xfd\xc5\x24\x28\xce\xd6\x7f\xf1\x2d\x4f\xe6\x6c\x99\xf3\x0a\xfa\x70\x73\x5b\x29\x99\x89\xf4\xc1\xfb\x51\x2c\x2b\xaf\x0b\x9e\xe4\x15\xba\x89\xf9\xde\x26\x52\xdb\xd2\x48\xca\xac\xe4\x79\x26\x78\x84\xf1\x56\x59\x1a\x49\xfe\x2b\xab\x08\xa8\xf7\xe8\xba\xa4\xd5\x06\x19\x68\x0d\xc0\x00\x9b\x58\xc1\x83\xeb\x5c\x0d\x81\x7e\x99\x50\xae\xd3\x1e\x62\x74\xd7\x19\x23\x7a\x0e\xf5\x16\x5d\xad\x36\x22\x06\xbf\xe2\xf9\x0a\x4e\xda\x6a\x74\x60\x58\xdc\x89\xe8\xec\xfd\xdb\x77\xef\xfe\x3c\x7f\x73\x7e\xe6\x77\x80\x4b\x59\x48\x72\xaf\xee\xbb\xb4\x81\x0f\x7d\x20\xbb\x60\x78\x19\x5c\xf2\x34\x13\x7e\xc7\x75\xb2\x95\x3e\x7a\xd5\x07\x91\xe5\xa4\xec\x48\xae\x36\x52\x90\xd4\x75\x30\x9e\x93\xf0\x15\x97\x40\x61\xd1\x27\x29\x44\x58\x1e\x75\x1f\x4c\x8b\x3c\x5f\x22\x2d\xe4\xe5\x11\x3f\xae\x23\x8b\xbb\xaa\x89\x84\x1a\xdf\x36\x5c\x6e\x7d\x6f\x16\x8e\xc2\xc1\x1c\xb2\xa4\x0b\xc4\x53\xf4\x93\x6f\xdb\x55\x24\x74\x82\x1f\xa7\x93\x2f\xba\x40\x15\xfc\xf3\x29\x9c\x86\xcd\x39\x5c\xcd\x60\x3c\x99\xc3\xf8\x7a\x34\xf2\x9e\x85\xd7\x96\xb9\x22\x10\x6b\x56\xde\x60\x55\x6f\x4f\x5a\xce\x03\x3c\x7b\x78\x74\x9d\x15\xd6\x86\x00\x07\x63\x7e\xaf\x6c\x66\x75\x2b\x20\x1f\x9a\x0b\x2d\x20\x24\x68\xd3\x30\xb2\x2b\x34\xec\x60\xd3\x05\xe3\x4d\x9e\xcf\x8c\x06\xaa\x10\xc4\xbe\x71\x3f\x8b\x99\xf0\x5f\x1b\xaf\x5d\x78\x6d\x0d\xdb\x95\x76\x81\x89\x1d\xc9\x6c\x2f\x35\x9d\x9b\x8e\x6d\x2a\x76\xd2\xe0\x41\xc3\x5d\x5f\xc1\xdf\x2c\xcf\x12\xe3\xc0\xa8\xf6\xf7\x83\x05\xb3\xda\x52\xbb\xd4\x74\x58\xd2\x76\xca\x74\x2d\xee\x24\x2b\x87\xc6\x4e\xf7\x1b\xd9\x31\xc5\x53\xcb\x9e\xce\x42\xbc\x08\x7d\x4d\xcd\x8d\x29\xc7\x2d\x22\xc3\xe8\x86\xb6\x5e\x0f\xcc\xe4\x54\x90\x16\x40\x8d\x05\x0a\xff\x39\x22\xad\x61\xf1\x04\xee\x70\xae\x41\x7d\xc7\x8b\x80\x55\x0a\xdb\x52\x9b\x13\x95\x51\x17\x14\xcd\x2e\x65\x21\x99\x48\xf9\xc1\x4c\x13\x26\xd2\xac\xa9\xa0\xe6\x7a\xaa\x5c\x59\xe4\x47\x1b\x79\x41\x47\x8e\xed\xe6\x38\xa0\x7e\x8e\x03\x83\x99\x56\xba\x14\x5a\x45\x37\xf3\xe2\x54\xe3\x39\x5d\x40\xac\x85\x9f\x27\x57\x63\xa8\x2f\x89\x0a\x4a\x98\xe0\x16\x9d\x60\x0d\xe2\xa0\xb9\x3c\xb2\x44\x2b\x9b\x19\x88\x03\x82\x45\x53\xc0\x8d\xf8\x62\x3c\x44\x1b\x2d\xd4\x86\x7f\x9c\x91\x78\xd1\xb5\x39\x11\x0f\xc7\x88\xd8\x67\xc2\x50\xe1\xd4\xc5\xfe\x40\x77\xdd\x93\xab\x89\xe6\xc3\x39\x3a\x21\xb5\x9d\x2e\xcc\x81\x91\x56\x38\xe8\x7d\x63\x11\xe8\xf6\xb7\xeb\x81\xad\x5a\xbd\x6f\x86\xe0\x38\xfe\x27\x09\xd8\x0c\x9a\x14\xfa\xc0\xca\x92\x8b\xc4\xb7\x82\xae\xed\xa4\x4e\x9b\xad\x6d\x91\x16\xbc\xa1\xbd\xf6\x60\xc2\x24\xdc\xb6\x59\xc3\x3d\x96\x3e\x18\x1a\xa9\xff\xbf\x42\x6f\x3a\xda\x8e\xd0\xfe\x05\x5d\x0f\x5a\x10\x1a\x35\xbf\x41\xf6\xe2\x40\x91\xf1\xac\xdb\x38\xbc\xe7\xb1\xbf\xb8\xfe\x3a\xbc\x98\x87\x3b\xfd\x39\x0b\xe7\x75\x61\xa8\xa5\x2c\x22\x5a\xbf\xb5\x97\xb1\x69\xb6\x73\x6c\xb4\x43\xdc\x0d\xbb\x2f\x81\x66\x3f\x1a\xe3\x21\xc2\x8b\xd1\x3c\x9c\xc2\xfc\xe2\x72\x84\x30\xe1\xd4\x0e\xf6\x29\xae\x87\xd3\xc9\x57\x18\x4c\x46\xd7\x5f\xc6\xd0\xce\xc6\xe2\x79\x17\x90\x0e\xf7\xdf\xd1\xcc\x0b\xb4\x1b\xa5\x7d\xb1\x8e\x49\xcd\xeb\xb5\x78\xd6\xbb\x64\xf7\x18\x77\x50\xac\xd7\x19\x4e\x14\x3e\xe7\xbf\x01\xb9\x25\x8c\xa9\xd8\x08\x00\x00")
+
+func _1524493031_add_team_data_keysDownGoBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493031_add_team_data_keysDownGo,
+		"1524493031_add_team_data_keys.down.go",
+	)
+}
+
+func _1524493031_add_team_data_keysDownGo() (*asset, error) {
+	bytes, err := _1524493031_add_team_data_keysDownGoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493031_add_team_data_keys.down.go", size: 2264, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493032_create_resource_webhook_tokensUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x90\x41\x6e\x83\x30\x10\x45\xf7\x9c\x62\x96\x41\xea\x0d\x58\x39\x30\xa9\x50\x8d\x69\x09\x48\xcd\xca\x72\xc3\x28\x58\x24\xb8\xc2\x8e\x48\x7a\xfa\xba\x20\x41\xba\x08\x4b\x6b\xde\xbc\xef\xf9\x5b\x7c\x4d\x45\x14\x00\xc4\x05\xb2\x12\xa1\x64\x5b\x8e\xd0\x93\x35\xd7\xfe\x48\x72\xa0\xaf\xc6\x98\x56\x3a\xd3\x52\x67\x61\xe3\x41\x00\x5d\x83\xa5\x5e\xab\x33\xbc\x17\x69\xc6\x8a\x03\xbc\xe1\xe1\x65\x1c\xcd\x8b\x9e\xd1\x9d\xa3\x13\xf5\x20\xf2\x12\x44\xc5\x39\x14\xb8\xc3\x02\x45\x8c\xfb\x99\xf3\x4a\x5d\x87\x90\x0b\x48\x90\xa3\xcf\x8f\xd9\x3e\x66\x09\x4e\xb6\x31\x55\x36\xca\x36\xe0\xe8\xe6\x66\xd3\x34\x3d\xf6\xa4\x1c\xd5\x52\x39\x70\xfa\x42\xd6\xa9\xcb\x37\x0c\xda\x35\xe3\x13\x7e\x4c\x47\x4b\x76\x82\x3b\x56\xf1\x12\x3a\x33\x6c\xc2\x69\xff\xac\xac\x93\x57\xbb\x6e\xf0\x64\x18\x05\x4b\x3f\x95\x48\x3f\x2a\x84\x54\x24\xf8\xf9\xac\x26\xb9\xfc\x5b\xb6\x74\xff\xbb\xee\x69\xa1\x0b\xfa\x2f\x66\xdd\xff\xd8\xf2\x9a\xfc\x81\xf3\xf6\x38\xcf\xb2\xb4\x8c\x82\x5f\x38\xfe\x8a\x84\xf3\x01\x00\x00")
+
+func _1524493032_create_resource_webhook_tokensUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493032_create_resource_webhook_tokensUpSql,
+		"1524493032_create_resource_webhook_tokens.up.sql",
+	)
+}
+
+func _1524493032_create_resource_webhook_tokensUpSql() (*asset, error) {
+	bytes, err := _1524493032_create_resource_webhook_tokensUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493032_create_resource_webhook_tokens.up.sql", size: 499, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493032_create_resource_webhook_tokensDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xce\x2f\x2d\x4a\x4e\x8d\x2f\x4f\x4d\xca\xc8\xcf\xcf\x8e\x2f\xc9\xcf\x4e\xcd\x2b\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x81\x7a\x55\x95\x35\x00\x00\x00")
+
+func _1524493032_create_resource_webhook_tokensDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493032_create_resource_webhook_tokensDownSql,
+		"1524493032_create_resource_webhook_tokens.down.sql",
+	)
+}
+
+func _1524493032_create_resource_webhook_tokensDownSql() (*asset, error) {
+	bytes, err := _1524493032_create_resource_webhook_tokensDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493032_create_resource_webhook_tokens.down.sql", size: 53, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493033_create_resource_config_versionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x51\x4d\x4f\xc2\x40\x14\xbc\xf7\x57\xcc\x0d\x48\x30\x29\x07\x63\xb4\xa7\xa5\x7d\x98\xc6\xed\x56\x4b\x9b\xc8\xa9\x21\xed\x0a\x1b\xa0\x35\xdd\xf5\xf3\xd7\xbb\x52\x50\xa4\x12\x63\xf2\x2e\x9b\x99\x79\x33\xf3\x76\x4c\xd7\xa1\xf0\x1c\xc0\x4f\x88\xa5\x84\x94\x8d\x39\xa1\x91\xba\x7e\x6a\x0a\x99\x17\x75\xf5\xa0\x16\xf9\xb3\x6c\xb4\xaa\x2b\x8d\xbe\x65\x02\xaa\x84\x96\x8d\x9a\xaf\x71\x9b\x84\x11\x4b\x66\xb8\xa1\xd9\x70\x0b\x1d\x2b\x2d\x55\x55\x46\x2e\x64\x03\x11\xa7\x10\x19\xe7\x48\x68\x42\x09\x09\x9f\xa6\xc7\x74\x6b\xa0\xca\x01\x62\x81\x80\x38\xd9\x38\x3e\x9b\xfa\x2c\xa0\x76\xf7\x2e\x05\x8c\x7c\x35\x5f\xdb\x5a\xa8\x58\xca\x62\x95\xd7\x4d\x69\x7d\x3a\x7e\x01\x4d\x58\xc6\x53\xb8\x96\x3a\xf0\x9c\xef\xb2\x99\x08\xef\x32\x42\x28\x02\xba\x3f\xd9\x39\xef\x56\xda\x63\xf9\x4a\xbe\x7d\x86\x3d\x7d\xae\xae\x76\x88\x4d\x79\xde\xdf\x31\x06\x3f\xe2\xfc\x91\xe3\xb0\xe3\xbf\x4d\x0f\xc4\xad\x27\xe3\x29\x25\xbf\x7f\xb7\x06\x0b\x02\xf8\x31\xcf\x22\x81\xf5\x5c\x9b\xd6\x5a\x96\x30\x6a\x23\xb5\x99\x6f\x1e\xf1\xa2\xcc\x72\xfb\xc4\x7b\x5d\xc9\xee\xad\x7b\xa3\xcb\x0b\xf7\xcc\x1d\xd9\x81\xeb\x5e\x6d\xa7\xe7\x39\x7e\x1c\x45\x61\xea\x39\x1f\x45\x20\x4a\x43\x77\x02\x00\x00")
+
+func _1524493033_create_resource_config_versionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493033_create_resource_config_versionsUpSql,
+		"1524493033_create_resource_config_versions.up.sql",
+	)
+}
+
+func _1524493033_create_resource_config_versionsUpSql() (*asset, error) {
+	bytes, err := _1524493033_create_resource_config_versionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493033_create_resource_config_versions.up.sql", size: 631, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493033_create_resource_config_versionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xce\x2f\x2d\x4a\x4e\x8d\x4f\xce\xcf\x4b\xcb\x4c\x8f\x2f\x4b\x2d\x2a\xce\xcc\xcf\x2b\xb6\xe6\x02\xaa\x73\xf4\x09\x71\x0d\xc2\xae\xb0\x18\x62\x88\xb3\xbf\x4f\xa8\xaf\x9f\x42\x4e\x62\x71\x49\x7c\x72\x46\x6a\x72\x76\x6a\x8a\x35\x97\xb3\xbf\xaf\xaf\x67\x88\x35\x17\x00\xb1\xec\x5e\x6b\x70\x00\x00\x00")
+
+func _1524493033_create_resource_config_versionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493033_create_resource_config_versionsDownSql,
+		"1524493033_create_resource_config_versions.down.sql",
+	)
+}
+
+func _1524493033_create_resource_config_versionsDownSql() (*asset, error) {
+	bytes, err := _1524493033_create_resource_config_versionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493033_create_resource_config_versions.down.sql", size: 112, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493034_create_resource_check_resultsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x90\xb1\x6a\xc3\x30\x10\x86\x77\x3f\xc5\x8d\x0d\x64\xe8\xee\x49\x91\x2e\xc5\x54\x96\x83\xac\x40\x33\x89\x60\x1f\x89\x68\x22\x07\x49\x6e\x4b\x9f\xbe\x4a\x0c\x6e\xa0\x64\xe8\x22\xf8\xd1\x77\xff\x71\xdf\x0a\x5f\x2a\x55\x16\x00\x5c\x23\x33\x08\x86\xad\x24\x42\xa0\x38\x8c\xa1\x23\xdb\x1d\xa9\x7b\xb7\x39\x8e\xa7\x14\xe1\x29\x73\x00\xae\x87\x48\xc1\xed\x4f\xb0\xd1\x55\xcd\xf4\x0e\x5e\x71\xb7\xbc\x7d\xcd\x73\x99\x71\x3e\xd1\x81\x02\xa8\xc6\x80\xda\x4a\x09\x1a\xd7\xa8\x51\x71\x6c\x67\x2e\x57\xba\x7e\x01\x8d\x02\x81\x12\xf3\x7a\xce\x5a\xce\x04\x4e\x6d\x31\xed\x43\xb2\xc9\x9d\x09\xae\x4f\x8e\xe7\x0b\x7c\xba\x74\xbc\x45\xf8\x1e\x3c\xcd\xed\xd3\x04\xf9\xfe\x7f\x7c\x08\x43\x80\x44\x5f\x69\xca\x1f\x14\xa2\x1b\xbc\xed\x86\xd1\xa7\xbf\x17\x08\x5c\xb3\xad\x34\xf0\x9c\xe1\x45\x59\xfc\x5a\xab\x94\xc0\xb7\x07\xd6\xec\xbd\x94\x7c\xe9\x23\xb7\x77\xd8\xf2\xea\x58\x60\xcb\xf3\x12\xde\xd4\x75\x65\xca\xe2\x07\x1c\x9f\xe4\xb9\xa9\x01\x00\x00")
+
+func _1524493034_create_resource_check_resultsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493034_create_resource_check_resultsUpSql,
+		"1524493034_create_resource_check_results.up.sql",
+	)
+}
+
+func _1524493034_create_resource_check_resultsUpSql() (*asset, error) {
+	bytes, err := _1524493034_create_resource_check_resultsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493034_create_resource_check_results.up.sql", size: 425, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493034_create_resource_check_resultsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xce\x2f\x2d\x4a\x4e\x8d\x4f\xce\x48\x4d\xce\x8e\x07\x72\x4b\x73\x4a\x8a\xad\xb9\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\xce\x05\x78\x24\x34\x00\x00\x00")
+
+func _1524493034_create_resource_check_resultsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493034_create_resource_check_resultsDownSql,
+		"1524493034_create_resource_check_results.down.sql",
+	)
+}
+
+func _1524493034_create_resource_check_resultsDownSql() (*asset, error) {
+	bytes, err := _1524493034_create_resource_check_resultsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493034_create_resource_check_results.down.sql", size: 52, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493035_create_worker_task_slotsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x90\xc1\x0e\x82\x30\x0c\x86\xef\x3c\x45\x8f\x90\xf0\x06\x9c\xc6\xa8\x66\x71\x0c\x33\x30\x91\xd3\x82\x61\x31\x04\x04\x03\x33\xfa\xf8\x0e\x94\x88\x11\xaf\xed\xd7\xaf\xfd\x1b\xe2\x96\x89\xc0\x01\xa0\x12\x49\x86\x90\x91\x90\x23\xdc\xbb\xbe\xd6\xbd\x32\xc5\x50\xab\xa1\xe9\xcc\x00\xae\x45\x60\xae\xb7\xc5\x45\x83\xd1\x0f\x03\x22\xc9\x40\x1c\x38\x07\x89\x1b\x94\x28\x28\xa6\x6f\xc8\x8e\x8c\x98\x07\x89\x80\x08\x39\x5a\x37\x25\x29\x25\x11\xfa\x93\xea\x74\xab\x9a\x52\x55\x25\x54\xad\xd1\x67\xdd\xaf\xaa\x26\xc8\x9a\xaa\xf2\xaf\xe7\xda\x14\xed\xa8\xf9\x3a\xe7\xd5\xda\x4b\x16\x13\x99\xc3\x0e\x73\x70\xe7\x7d\xfe\x3c\xe1\x59\xc6\x0b\x9c\x4f\x74\x26\x22\x3c\xfe\x46\x57\xcb\xd0\xf6\x88\x95\xdf\x2c\x08\x6b\xa4\x49\x1c\xb3\x2c\x70\x9e\xd2\x9f\x58\x89\x5b\x01\x00\x00")
+
+func _1524493035_create_worker_task_slotsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493035_create_worker_task_slotsUpSql,
+		"1524493035_create_worker_task_slots.up.sql",
+	)
+}
+
+func _1524493035_create_worker_task_slotsUpSql() (*asset, error) {
+	bytes, err := _1524493035_create_worker_task_slotsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493035_create_worker_task_slots.up.sql", size: 347, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493035_create_worker_task_slotsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x8a\x2f\x49\x2c\xce\x8e\x2f\xce\xc9\x2f\x29\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x74\x06\x18\x10\x2f\x00\x00\x00")
+
+func _1524493035_create_worker_task_slotsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493035_create_worker_task_slotsDownSql,
+		"1524493035_create_worker_task_slots.down.sql",
+	)
+}
+
+func _1524493035_create_worker_task_slotsDownSql() (*asset, error) {
+	bytes, err := _1524493035_create_worker_task_slotsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493035_create_worker_task_slots.down.sql", size: 47, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493036_add_last_used_at_to_volumesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x55\xcc\x4b\x0a\xc2\x30\x14\x46\xe1\x79\x57\xf1\x0f\x75\x0d\xc1\x41\xda\x44\x29\xe4\x21\x7a\x33\x2e\x41\x03\x16\xda\x46\xcc\xad\x05\x57\xaf\x76\x22\x0e\x0f\x07\xbe\x5a\x1f\x5a\x27\x2a\x40\x1a\xd2\x27\x90\xac\x8d\xc6\x33\x0f\xf3\x98\x0a\xa4\x52\x68\xbc\x09\xd6\x61\x88\x85\xbb\xb9\xa4\x6b\x17\x19\xdc\x7f\x2e\xc7\xf1\x8e\xa5\xe7\xdb\x9a\x78\xe5\x29\xc1\x79\x82\x0b\xc6\x40\xe9\xbd\x0c\x86\x30\xe5\x65\xb3\xfd\xf2\xe1\xa8\x24\xfd\xe4\xb3\xa6\x7f\x72\x87\xcb\x23\x45\x5e\x43\x54\x8d\xb7\xb6\x25\x51\xbd\x01\xd0\x68\x82\xe9\x9e\x00\x00\x00")
+
+func _1524493036_add_last_used_at_to_volumesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493036_add_last_used_at_to_volumesUpSql,
+		"1524493036_add_last_used_at_to_volumes.up.sql",
+	)
+}
+
+func _1524493036_add_last_used_at_to_volumesUpSql() (*asset, error) {
+	bytes, err := _1524493036_add_last_used_at_to_volumesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493036_add_last_used_at_to_volumes.up.sql", size: 158, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493036_add_last_used_at_to_volumesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcb\xcf\x29\xcd\x4d\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x49\x2c\x2e\x89\x2f\x2d\x4e\x4d\x89\x4f\x2c\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x0f\x4b\x42\x2d\x3f\x00\x00\x00")
+
+func _1524493036_add_last_used_at_to_volumesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493036_add_last_used_at_to_volumesDownSql,
+		"1524493036_add_last_used_at_to_volumes.down.sql",
+	)
+}
+
+func _1524493036_add_last_used_at_to_volumesDownSql() (*asset, error) {
+	bytes, err := _1524493036_add_last_used_at_to_volumesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493036_add_last_used_at_to_volumes.down.sql", size: 63, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493037_add_stalled_at_to_workersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x2e\x49\xcc\xc9\x49\x4d\x89\x4f\x2c\x51\x28\xc9\xcc\x4d\x05\x72\x73\x0b\x14\xca\x33\x4b\x32\xc0\x5c\x85\xaa\xfc\xbc\x54\x90\x29\xa1\x01\x2e\x8e\x21\x08\x03\x82\x5d\x43\x90\x75\xda\x2a\xe4\xe5\x97\x6b\x68\x2a\x84\x7b\xb8\x06\xb9\x82\x24\x4a\x52\x81\x62\xea\x50\x15\xea\xd6\x5c\xce\xfe\xbe\xbe\x9e\x21\xd6\x5c\x00\xac\x86\xa5\xa0\x96\x00\x00\x00")
+
+func _1524493037_add_stalled_at_to_workersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493037_add_stalled_at_to_workersUpSql,
+		"1524493037_add_stalled_at_to_workers.up.sql",
+	)
+}
+
+func _1524493037_add_stalled_at_to_workersUpSql() (*asset, error) {
+	bytes, err := _1524493037_add_stalled_at_to_workersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493037_add_stalled_at_to_workers.up.sql", size: 150, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493037_add_stalled_at_to_workersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x2e\x49\xcc\xc9\x49\x4d\x89\x4f\x2c\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xa7\x79\x60\x58\x3d\x00\x00\x00")
+
+func _1524493037_add_stalled_at_to_workersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493037_add_stalled_at_to_workersDownSql,
+		"1524493037_add_stalled_at_to_workers.down.sql",
+	)
+}
+
+func _1524493037_add_stalled_at_to_workersDownSql() (*asset, error) {
+	bytes, err := _1524493037_add_stalled_at_to_workersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493037_add_stalled_at_to_workers.down.sql", size: 61, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493038_add_replay_expires_at_to_containersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x1d\xc7\x4b\x0a\x80\x20\x14\x05\xd0\xb9\xab\xb8\xfb\x70\x64\x25\x21\x68\x41\xd8\x38\x24\x1e\x24\x94\x89\x3e\xe8\xb3\xfa\xa0\x33\x3b\x8d\xee\xcd\x20\x05\xa0\xac\xd7\x13\xbc\x6a\xac\xc6\x7a\x26\x0e\x31\x51\xa9\x50\x5d\x87\x76\xb4\xb3\x1b\x50\x28\xef\xe1\x59\xe8\xce\xb1\x50\x5d\x02\x83\xe3\x41\x95\xc3\x91\x71\x45\xde\xfe\xe2\x3d\x13\x49\xd1\x8e\xce\x19\x2f\xc5\x07\x30\xbf\xa2\xc9\x5f\x00\x00\x00")
+
+func _1524493038_add_replay_expires_at_to_containersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493038_add_replay_expires_at_to_containersUpSql,
+		"1524493038_add_replay_expires_at_to_containers.up.sql",
+	)
+}
+
+func _1524493038_add_replay_expires_at_to_containersUpSql() (*asset, error) {
+	bytes, err := _1524493038_add_replay_expires_at_to_containersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493038_add_replay_expires_at_to_containers.up.sql", size: 95, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493038_add_replay_expires_at_to_containersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xce\xcf\x2b\x49\xcc\xcc\x4b\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x28\x4a\x2d\xc8\x49\xac\x8c\x4f\xad\x28\xc8\x2c\x4a\x2d\x8e\x4f\x2c\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x91\x68\xb2\x69\x47\x00\x00\x00")
+
+func _1524493038_add_replay_expires_at_to_containersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493038_add_replay_expires_at_to_containersDownSql,
+		"1524493038_add_replay_expires_at_to_containers.down.sql",
+	)
+}
+
+func _1524493038_add_replay_expires_at_to_containersDownSql() (*asset, error) {
+	bytes, err := _1524493038_add_replay_expires_at_to_containersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493038_add_replay_expires_at_to_containers.down.sql", size: 71, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493039_key_audit_events_by_team_idUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x75\x8e\xb1\x0a\xc2\x30\x18\x84\xf7\x3c\xc5\x3f\x2a\x48\x5f\x20\x38\xa4\xcd\xaf\x06\x9a\xa4\xc4\x14\xdd\x4a\x20\x41\x32\xb4\x83\x8d\x3e\xbf\x69\x90\x42\x05\xc7\xbb\xfb\xee\xb8\x1a\xcf\x42\x51\x02\xc0\x5a\x8b\x06\x2c\xab\x5b\x04\xf7\xf2\x31\x0d\xe1\x1d\xa6\x34\x03\xe3\x1c\x1a\xdd\xf6\x52\x41\x0a\x6e\x1c\xa2\x87\x38\xa5\xf0\x08\x4f\x4a\x72\xaf\xef\x38\xb3\x3f\x15\x97\xfd\x2b\xda\x95\x3f\x42\xaa\xa2\xcf\xe6\xc9\x68\x59\xdc\x19\x52\x96\xb7\x0b\x1a\xcc\xd9\xe4\xc6\x90\x21\x57\x95\xc2\xa2\xca\x34\x37\xba\x03\xa1\x38\xde\x37\xf3\xc3\x4a\xe5\xed\x02\x36\x06\x97\x0f\xff\xd0\xe8\x97\x13\x5a\x6d\x4f\xee\xbe\xd9\x01\xa2\xdf\x53\xd2\x68\x29\x85\xa5\xe4\x03\x60\x24\xe1\xac\x11\x01\x00\x00")
+
+func _1524493039_key_audit_events_by_team_idUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493039_key_audit_events_by_team_idUpSql,
+		"1524493039_key_audit_events_by_team_id.up.sql",
+	)
+}
+
+func _1524493039_key_audit_events_by_team_idUpSql() (*asset, error) {
+	bytes, err := _1524493039_key_audit_events_by_team_idUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493039_key_audit_events_by_team_id.up.sql", size: 273, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493039_key_audit_events_by_team_idDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x48\x2c\x4d\xc9\x2c\x89\x4f\x2d\x4b\xcd\x2b\x29\x8e\x2f\x49\x4d\xcc\x8d\xcf\x4c\x01\x22\x6b\x2e\xa0\x32\xe7\x20\x57\xc7\x10\x57\x9c\x0a\xf3\x12\x73\x53\x81\x4a\x15\xfc\xfd\x50\x24\x15\x34\xe0\xb2\x3a\x0a\x99\x29\x9a\x60\xa3\x1c\x7d\x42\x5c\x83\x14\x42\x1c\x9d\x7c\x5c\x51\x15\x83\x9d\xe2\xec\xef\x13\xea\xeb\xa7\x00\xb5\xde\x9a\xcb\xd9\xdf\xd7\xd7\x33\xc4\x9a\x0b\x00\x71\x60\x86\x33\xb1\x00\x00\x00")
+
+func _1524493039_key_audit_events_by_team_idDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493039_key_audit_events_by_team_idDownSql,
+		"1524493039_key_audit_events_by_team_id.down.sql",
+	)
+}
+
+func _1524493039_key_audit_events_by_team_idDownSql() (*asset, error) {
+	bytes, err := _1524493039_key_audit_events_by_team_idDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493039_key_audit_events_by_team_id.down.sql", size: 177, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493040_add_unique_index_to_worker_resource_cachesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb5\x93\x5f\x4f\x83\x30\x14\xc5\xdf\xf9\x14\xf7\x11\x12\xb3\xf8\x8e\x9a\xf0\xe7\x3a\x9b\x0c\x8a\xa5\xa8\x7b\x6a\x10\x6a\x24\x0c\x21\xb0\xb1\xec\xdb\x5b\x3a\x36\xb7\x64\x9b\x9a\xe8\x5b\xd3\xdb\x9e\xf3\xbb\xb7\xa7\x2e\x4e\x49\x68\x1b\x00\x1e\x43\x87\x23\x70\x0c\x22\xca\x1c\x36\x07\xee\xb8\x33\x84\x7c\xd5\x2c\x8a\x2c\x5d\x4a\xb1\xae\xdb\x52\xb6\xa2\x95\x5d\xbd\x6a\x33\x29\xb2\x34\x7b\x97\x1d\xd0\x10\x3c\x1a\x04\x84\x83\xcf\x68\x04\x4e\xac\xb4\x00\x62\x9c\xa1\xc7\xa1\xc8\xaf\xa0\x94\xb2\x11\x45\x0e\xf7\x8c\x06\x60\xea\xea\x51\xbd\x2a\x3e\xcc\x22\xb7\x80\x3e\x21\x03\x33\x72\x18\x27\x9c\x28\x55\x77\x0e\xc7\x5e\x62\x38\x3d\x52\xbc\xa6\x9d\xfc\x42\x59\x6e\x9a\x6d\xd5\xa3\xce\x0c\x63\x0f\xcd\xa5\x4c\x2b\xbd\x73\x6d\x59\x8a\x69\x07\x31\xba\x6b\x94\xd3\xfd\xe8\x13\x16\xac\xdb\x4c\xaf\x9e\x1f\x90\xa1\xc2\x84\x9b\xbb\x9d\x86\x6d\xa8\x4a\x12\xf9\xc3\xb4\xfa\x7a\xb1\xaa\xd4\x10\x7a\xd5\x10\x3f\xad\x38\xb4\x7e\x0b\xf9\xe4\x90\x40\xfb\x7f\x3b\xd9\xfc\x80\xa0\x9f\x5c\x14\xbf\x44\xd5\x4a\xed\x23\xea\x37\xf1\xaf\x80\x3f\xf5\x19\x59\x7d\x95\x00\xc5\x7a\xe1\x29\xf6\x8f\x90\xc4\x24\x9c\xfe\x0e\x47\x5d\x9d\x1c\xfb\x8d\xf9\x4e\x42\xf2\x98\x20\x90\xd0\xc7\x97\x33\xbe\x23\xae\x0e\x95\xce\x51\x29\x37\x43\xcc\xcf\xd8\x9a\x7f\x93\x52\xdb\xd8\x7e\x23\xdb\xf8\x04\xee\x39\x40\x82\x93\x03\x00\x00")
+
+func _1524493040_add_unique_index_to_worker_resource_cachesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493040_add_unique_index_to_worker_resource_cachesUpSql,
+		"1524493040_add_unique_index_to_worker_resource_caches.up.sql",
+	)
+}
+
+func _1524493040_add_unique_index_to_worker_resource_cachesUpSql() (*asset, error) {
+	bytes, err := _1524493040_add_unique_index_to_worker_resource_cachesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493040_add_unique_index_to_worker_resource_caches.up.sql", size: 915, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1524493040_add_unique_index_to_worker_resource_cachesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x28\xcf\x2f\xca\x4e\x2d\x8a\x2f\x4a\x2d\xce\x2f\x2d\x4a\x4e\x8d\x4f\x4e\x4c\xce\x48\x2d\x86\x50\xf1\x25\x95\x05\x40\x22\x35\x31\x37\x3e\x3b\xb5\xd2\x9a\xcb\xd9\xdf\xd7\xd7\x33\xc4\x9a\x0b\x00\x53\x89\xf5\xbf\x48\x00\x00\x00")
+
+func _1524493040_add_unique_index_to_worker_resource_cachesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1524493040_add_unique_index_to_worker_resource_cachesDownSql,
+		"1524493040_add_unique_index_to_worker_resource_caches.down.sql",
+	)
+}
+
+func _1524493040_add_unique_index_to_worker_resource_cachesDownSql() (*asset, error) {
+	bytes, err := _1524493040_add_unique_index_to_worker_resource_cachesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1524493040_add_unique_index_to_worker_resource_caches.down.sql", size: 72, mode: os.FileMode(420), modTime: time.Unix(1522342009, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -647,6 +2411,90 @@ var _bindata = map[string]func() (*asset, error){
 	"1522176230_add_tags_to_jobs.up.sql": _1522176230_add_tags_to_jobsUpSql,
 	"1522178770_add_job_tags.down.sql": _1522178770_add_job_tagsDownSql,
 	"1522178770_add_job_tags.up.go": _1522178770_add_job_tagsUpGo,
+	"1523366312_add_scheduling_checkpoint_to_builds.down.sql": _1523366312_add_scheduling_checkpoint_to_buildsDownSql,
+	"1523366312_add_scheduling_checkpoint_to_builds.up.sql": _1523366312_add_scheduling_checkpoint_to_buildsUpSql,
+	"1523627153_create_versioned_resource_annotations.up.sql": _1523627153_create_versioned_resource_annotationsUpSql,
+	"1523627153_create_versioned_resource_annotations.down.sql": _1523627153_create_versioned_resource_annotationsDownSql,
+	"1523973214_create_build_step_states.up.sql": _1523973214_create_build_step_statesUpSql,
+	"1523973214_create_build_step_states.down.sql": _1523973214_create_build_step_statesDownSql,
+	"1524058691_create_build_event_checkpoints.up.sql": _1524058691_create_build_event_checkpointsUpSql,
+	"1524058691_create_build_event_checkpoints.down.sql": _1524058691_create_build_event_checkpointsDownSql,
+	"1524146375_add_builds_pipeline_id_start_time_index.up.sql": _1524146375_add_builds_pipeline_id_start_time_indexUpSql,
+	"1524146375_add_builds_pipeline_id_start_time_index.down.sql": _1524146375_add_builds_pipeline_id_start_time_indexDownSql,
+	"1524233412_add_trigger_cause_to_builds.up.sql": _1524233412_add_trigger_cause_to_buildsUpSql,
+	"1524233412_add_trigger_cause_to_builds.down.sql": _1524233412_add_trigger_cause_to_buildsDownSql,
+	"1524319807_add_codec_to_build_events.up.sql": _1524319807_add_codec_to_build_eventsUpSql,
+	"1524319807_add_codec_to_build_events.down.go": _1524319807_add_codec_to_build_eventsDownGo,
+	"1524406215_add_abort_cause_to_builds.up.sql": _1524406215_add_abort_cause_to_buildsUpSql,
+	"1524406215_add_abort_cause_to_builds.down.sql": _1524406215_add_abort_cause_to_buildsDownSql,
+	"1524493007_add_rerun_to_builds.up.sql": _1524493007_add_rerun_to_buildsUpSql,
+	"1524493007_add_rerun_to_builds.down.sql": _1524493007_add_rerun_to_buildsDownSql,
+	"1524493008_add_heartbeat_to_builds.up.sql": _1524493008_add_heartbeat_to_buildsUpSql,
+	"1524493008_add_heartbeat_to_builds.down.sql": _1524493008_add_heartbeat_to_buildsDownSql,
+	"1524493009_add_labels_to_pipelines.up.sql": _1524493009_add_labels_to_pipelinesUpSql,
+	"1524493009_add_labels_to_pipelines.down.sql": _1524493009_add_labels_to_pipelinesDownSql,
+	"1524493010_add_pipeline_destroyed_build_status.up.sql": _1524493010_add_pipeline_destroyed_build_statusUpSql,
+	"1524493010_add_pipeline_destroyed_build_status.down.sql": _1524493010_add_pipeline_destroyed_build_statusDownSql,
+	"1524493011_add_archived_from_to_builds.up.sql": _1524493011_add_archived_from_to_buildsUpSql,
+	"1524493011_add_archived_from_to_builds.down.sql": _1524493011_add_archived_from_to_buildsDownSql,
+	"1524493012_create_build_environments.up.sql": _1524493012_create_build_environmentsUpSql,
+	"1524493012_create_build_environments.down.sql": _1524493012_create_build_environmentsDownSql,
+	"1524493013_add_ephemeral_to_workers.up.sql": _1524493013_add_ephemeral_to_workersUpSql,
+	"1524493013_add_ephemeral_to_workers.down.sql": _1524493013_add_ephemeral_to_workersDownSql,
+	"1524493014_add_pressure_to_workers.up.sql": _1524493014_add_pressure_to_workersUpSql,
+	"1524493014_add_pressure_to_workers.down.sql": _1524493014_add_pressure_to_workersDownSql,
+	"1524493015_add_volume_gc_policy_columns.up.sql": _1524493015_add_volume_gc_policy_columnsUpSql,
+	"1524493015_add_volume_gc_policy_columns.down.sql": _1524493015_add_volume_gc_policy_columnsDownSql,
+	"1524493016_add_team_id_to_worker_resource_caches.up.sql": _1524493016_add_team_id_to_worker_resource_cachesUpSql,
+	"1524493016_add_team_id_to_worker_resource_caches.down.sql": _1524493016_add_team_id_to_worker_resource_cachesDownSql,
+	"1524493017_create_worker_warm_resource_types.up.sql": _1524493017_create_worker_warm_resource_typesUpSql,
+	"1524493017_create_worker_warm_resource_types.down.sql": _1524493017_create_worker_warm_resource_typesDownSql,
+	"1524493018_add_fetch_counts_to_worker_resource_caches.up.sql": _1524493018_add_fetch_counts_to_worker_resource_cachesUpSql,
+	"1524493018_add_fetch_counts_to_worker_resource_caches.down.sql": _1524493018_add_fetch_counts_to_worker_resource_cachesDownSql,
+	"1524493019_create_container_usage_samples.up.sql": _1524493019_create_container_usage_samplesUpSql,
+	"1524493019_create_container_usage_samples.down.sql": _1524493019_create_container_usage_samplesDownSql,
+	"1523714483_create_worker_maintenance_windows.up.sql": _1523714483_create_worker_maintenance_windowsUpSql,
+	"1523714483_create_worker_maintenance_windows.down.sql": _1523714483_create_worker_maintenance_windowsDownSql,
+	"1524493021_add_events_archived_to_builds.up.sql": _1524493021_add_events_archived_to_buildsUpSql,
+	"1524493021_add_events_archived_to_builds.down.sql": _1524493021_add_events_archived_to_buildsDownSql,
+	"1524493022_index_team_build_events.up.go": _1524493022_index_team_build_eventsUpGo,
+	"1524493022_index_team_build_events.down.go": _1524493022_index_team_build_eventsDownGo,
+	"1524493023_add_keyset_pagination_indexes.up.sql": _1524493023_add_keyset_pagination_indexesUpSql,
+	"1524493023_add_keyset_pagination_indexes.down.sql": _1524493023_add_keyset_pagination_indexesDownSql,
+	"1524493024_create_audit_events.up.sql": _1524493024_create_audit_eventsUpSql,
+	"1524493024_create_audit_events.down.sql": _1524493024_create_audit_eventsDownSql,
+	"1524493025_add_build_log_retention_to_pipelines.up.sql": _1524493025_add_build_log_retention_to_pipelinesUpSql,
+	"1524493025_add_build_log_retention_to_pipelines.down.sql": _1524493025_add_build_log_retention_to_pipelinesDownSql,
+	"1524493026_create_pipeline_config_revisions.up.sql": _1524493026_create_pipeline_config_revisionsUpSql,
+	"1524493026_create_pipeline_config_revisions.down.sql": _1524493026_create_pipeline_config_revisionsDownSql,
+	"1524493027_create_versioned_resource_fields.up.sql": _1524493027_create_versioned_resource_fieldsUpSql,
+	"1524493027_create_versioned_resource_fields.down.sql": _1524493027_create_versioned_resource_fieldsDownSql,
+	"1524493028_add_archived_at_to_pipelines.up.sql": _1524493028_add_archived_at_to_pipelinesUpSql,
+	"1524493028_add_archived_at_to_pipelines.down.sql": _1524493028_add_archived_at_to_pipelinesDownSql,
+	"1524493029_materialize_dashboard_builds_on_jobs.up.sql": _1524493029_materialize_dashboard_builds_on_jobsUpSql,
+	"1524493029_materialize_dashboard_builds_on_jobs.down.sql": _1524493029_materialize_dashboard_builds_on_jobsDownSql,
+	"1524493030_create_team_cache_usages.up.sql": _1524493030_create_team_cache_usagesUpSql,
+	"1524493030_create_team_cache_usages.down.sql": _1524493030_create_team_cache_usagesDownSql,
+	"1524493031_add_team_data_keys.up.sql": _1524493031_add_team_data_keysUpSql,
+	"1524493031_add_team_data_keys.down.go": _1524493031_add_team_data_keysDownGo,
+	"1524493032_create_resource_webhook_tokens.up.sql": _1524493032_create_resource_webhook_tokensUpSql,
+	"1524493032_create_resource_webhook_tokens.down.sql": _1524493032_create_resource_webhook_tokensDownSql,
+	"1524493033_create_resource_config_versions.up.sql": _1524493033_create_resource_config_versionsUpSql,
+	"1524493033_create_resource_config_versions.down.sql": _1524493033_create_resource_config_versionsDownSql,
+	"1524493034_create_resource_check_results.up.sql": _1524493034_create_resource_check_resultsUpSql,
+	"1524493034_create_resource_check_results.down.sql": _1524493034_create_resource_check_resultsDownSql,
+	"1524493035_create_worker_task_slots.up.sql": _1524493035_create_worker_task_slotsUpSql,
+	"1524493035_create_worker_task_slots.down.sql": _1524493035_create_worker_task_slotsDownSql,
+	"1524493036_add_last_used_at_to_volumes.up.sql": _1524493036_add_last_used_at_to_volumesUpSql,
+	"1524493036_add_last_used_at_to_volumes.down.sql": _1524493036_add_last_used_at_to_volumesDownSql,
+	"1524493037_add_stalled_at_to_workers.up.sql": _1524493037_add_stalled_at_to_workersUpSql,
+	"1524493037_add_stalled_at_to_workers.down.sql": _1524493037_add_stalled_at_to_workersDownSql,
+	"1524493038_add_replay_expires_at_to_containers.up.sql": _1524493038_add_replay_expires_at_to_containersUpSql,
+	"1524493038_add_replay_expires_at_to_containers.down.sql": _1524493038_add_replay_expires_at_to_containersDownSql,
+	"1524493039_key_audit_events_by_team_id.up.sql": _1524493039_key_audit_events_by_team_idUpSql,
+	"1524493039_key_audit_events_by_team_id.down.sql": _1524493039_key_audit_events_by_team_idDownSql,
+	"1524493040_add_unique_index_to_worker_resource_caches.up.sql": _1524493040_add_unique_index_to_worker_resource_cachesUpSql,
+	"1524493040_add_unique_index_to_worker_resource_caches.down.sql": _1524493040_add_unique_index_to_worker_resource_cachesDownSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -713,6 +2561,90 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1522176230_add_tags_to_jobs.up.sql": &bintree{_1522176230_add_tags_to_jobsUpSql, map[string]*bintree{}},
 	"1522178770_add_job_tags.down.sql": &bintree{_1522178770_add_job_tagsDownSql, map[string]*bintree{}},
 	"1522178770_add_job_tags.up.go": &bintree{_1522178770_add_job_tagsUpGo, map[string]*bintree{}},
+	"1523366312_add_scheduling_checkpoint_to_builds.down.sql": &bintree{_1523366312_add_scheduling_checkpoint_to_buildsDownSql, map[string]*bintree{}},
+	"1523366312_add_scheduling_checkpoint_to_builds.up.sql": &bintree{_1523366312_add_scheduling_checkpoint_to_buildsUpSql, map[string]*bintree{}},
+	"1523627153_create_versioned_resource_annotations.up.sql": &bintree{_1523627153_create_versioned_resource_annotationsUpSql, map[string]*bintree{}},
+	"1523627153_create_versioned_resource_annotations.down.sql": &bintree{_1523627153_create_versioned_resource_annotationsDownSql, map[string]*bintree{}},
+	"1523973214_create_build_step_states.up.sql": &bintree{_1523973214_create_build_step_statesUpSql, map[string]*bintree{}},
+	"1523973214_create_build_step_states.down.sql": &bintree{_1523973214_create_build_step_statesDownSql, map[string]*bintree{}},
+	"1524058691_create_build_event_checkpoints.up.sql": &bintree{_1524058691_create_build_event_checkpointsUpSql, map[string]*bintree{}},
+	"1524058691_create_build_event_checkpoints.down.sql": &bintree{_1524058691_create_build_event_checkpointsDownSql, map[string]*bintree{}},
+	"1524146375_add_builds_pipeline_id_start_time_index.up.sql": &bintree{_1524146375_add_builds_pipeline_id_start_time_indexUpSql, map[string]*bintree{}},
+	"1524146375_add_builds_pipeline_id_start_time_index.down.sql": &bintree{_1524146375_add_builds_pipeline_id_start_time_indexDownSql, map[string]*bintree{}},
+	"1524233412_add_trigger_cause_to_builds.up.sql": &bintree{_1524233412_add_trigger_cause_to_buildsUpSql, map[string]*bintree{}},
+	"1524233412_add_trigger_cause_to_builds.down.sql": &bintree{_1524233412_add_trigger_cause_to_buildsDownSql, map[string]*bintree{}},
+	"1524319807_add_codec_to_build_events.up.sql": &bintree{_1524319807_add_codec_to_build_eventsUpSql, map[string]*bintree{}},
+	"1524319807_add_codec_to_build_events.down.go": &bintree{_1524319807_add_codec_to_build_eventsDownGo, map[string]*bintree{}},
+	"1524406215_add_abort_cause_to_builds.up.sql": &bintree{_1524406215_add_abort_cause_to_buildsUpSql, map[string]*bintree{}},
+	"1524406215_add_abort_cause_to_builds.down.sql": &bintree{_1524406215_add_abort_cause_to_buildsDownSql, map[string]*bintree{}},
+	"1524493007_add_rerun_to_builds.up.sql": &bintree{_1524493007_add_rerun_to_buildsUpSql, map[string]*bintree{}},
+	"1524493007_add_rerun_to_builds.down.sql": &bintree{_1524493007_add_rerun_to_buildsDownSql, map[string]*bintree{}},
+	"1524493008_add_heartbeat_to_builds.up.sql": &bintree{_1524493008_add_heartbeat_to_buildsUpSql, map[string]*bintree{}},
+	"1524493008_add_heartbeat_to_builds.down.sql": &bintree{_1524493008_add_heartbeat_to_buildsDownSql, map[string]*bintree{}},
+	"1524493009_add_labels_to_pipelines.up.sql": &bintree{_1524493009_add_labels_to_pipelinesUpSql, map[string]*bintree{}},
+	"1524493009_add_labels_to_pipelines.down.sql": &bintree{_1524493009_add_labels_to_pipelinesDownSql, map[string]*bintree{}},
+	"1524493010_add_pipeline_destroyed_build_status.up.sql": &bintree{_1524493010_add_pipeline_destroyed_build_statusUpSql, map[string]*bintree{}},
+	"1524493010_add_pipeline_destroyed_build_status.down.sql": &bintree{_1524493010_add_pipeline_destroyed_build_statusDownSql, map[string]*bintree{}},
+	"1524493011_add_archived_from_to_builds.up.sql": &bintree{_1524493011_add_archived_from_to_buildsUpSql, map[string]*bintree{}},
+	"1524493011_add_archived_from_to_builds.down.sql": &bintree{_1524493011_add_archived_from_to_buildsDownSql, map[string]*bintree{}},
+	"1524493012_create_build_environments.up.sql": &bintree{_1524493012_create_build_environmentsUpSql, map[string]*bintree{}},
+	"1524493012_create_build_environments.down.sql": &bintree{_1524493012_create_build_environmentsDownSql, map[string]*bintree{}},
+	"1524493013_add_ephemeral_to_workers.up.sql": &bintree{_1524493013_add_ephemeral_to_workersUpSql, map[string]*bintree{}},
+	"1524493013_add_ephemeral_to_workers.down.sql": &bintree{_1524493013_add_ephemeral_to_workersDownSql, map[string]*bintree{}},
+	"1524493014_add_pressure_to_workers.up.sql": &bintree{_1524493014_add_pressure_to_workersUpSql, map[string]*bintree{}},
+	"1524493014_add_pressure_to_workers.down.sql": &bintree{_1524493014_add_pressure_to_workersDownSql, map[string]*bintree{}},
+	"1524493015_add_volume_gc_policy_columns.up.sql": &bintree{_1524493015_add_volume_gc_policy_columnsUpSql, map[string]*bintree{}},
+	"1524493015_add_volume_gc_policy_columns.down.sql": &bintree{_1524493015_add_volume_gc_policy_columnsDownSql, map[string]*bintree{}},
+	"1524493016_add_team_id_to_worker_resource_caches.up.sql": &bintree{_1524493016_add_team_id_to_worker_resource_cachesUpSql, map[string]*bintree{}},
+	"1524493016_add_team_id_to_worker_resource_caches.down.sql": &bintree{_1524493016_add_team_id_to_worker_resource_cachesDownSql, map[string]*bintree{}},
+	"1524493017_create_worker_warm_resource_types.up.sql": &bintree{_1524493017_create_worker_warm_resource_typesUpSql, map[string]*bintree{}},
+	"1524493017_create_worker_warm_resource_types.down.sql": &bintree{_1524493017_create_worker_warm_resource_typesDownSql, map[string]*bintree{}},
+	"1524493018_add_fetch_counts_to_worker_resource_caches.up.sql": &bintree{_1524493018_add_fetch_counts_to_worker_resource_cachesUpSql, map[string]*bintree{}},
+	"1524493018_add_fetch_counts_to_worker_resource_caches.down.sql": &bintree{_1524493018_add_fetch_counts_to_worker_resource_cachesDownSql, map[string]*bintree{}},
+	"1524493019_create_container_usage_samples.up.sql": &bintree{_1524493019_create_container_usage_samplesUpSql, map[string]*bintree{}},
+	"1524493019_create_container_usage_samples.down.sql": &bintree{_1524493019_create_container_usage_samplesDownSql, map[string]*bintree{}},
+	"1523714483_create_worker_maintenance_windows.up.sql": &bintree{_1523714483_create_worker_maintenance_windowsUpSql, map[string]*bintree{}},
+	"1523714483_create_worker_maintenance_windows.down.sql": &bintree{_1523714483_create_worker_maintenance_windowsDownSql, map[string]*bintree{}},
+	"1524493021_add_events_archived_to_builds.up.sql": &bintree{_1524493021_add_events_archived_to_buildsUpSql, map[string]*bintree{}},
+	"1524493021_add_events_archived_to_builds.down.sql": &bintree{_1524493021_add_events_archived_to_buildsDownSql, map[string]*bintree{}},
+	"1524493022_index_team_build_events.up.go": &bintree{_1524493022_index_team_build_eventsUpGo, map[string]*bintree{}},
+	"1524493022_index_team_build_events.down.go": &bintree{_1524493022_index_team_build_eventsDownGo, map[string]*bintree{}},
+	"1524493023_add_keyset_pagination_indexes.up.sql": &bintree{_1524493023_add_keyset_pagination_indexesUpSql, map[string]*bintree{}},
+	"1524493023_add_keyset_pagination_indexes.down.sql": &bintree{_1524493023_add_keyset_pagination_indexesDownSql, map[string]*bintree{}},
+	"1524493024_create_audit_events.up.sql": &bintree{_1524493024_create_audit_eventsUpSql, map[string]*bintree{}},
+	"1524493024_create_audit_events.down.sql": &bintree{_1524493024_create_audit_eventsDownSql, map[string]*bintree{}},
+	"1524493025_add_build_log_retention_to_pipelines.up.sql": &bintree{_1524493025_add_build_log_retention_to_pipelinesUpSql, map[string]*bintree{}},
+	"1524493025_add_build_log_retention_to_pipelines.down.sql": &bintree{_1524493025_add_build_log_retention_to_pipelinesDownSql, map[string]*bintree{}},
+	"1524493026_create_pipeline_config_revisions.up.sql": &bintree{_1524493026_create_pipeline_config_revisionsUpSql, map[string]*bintree{}},
+	"1524493026_create_pipeline_config_revisions.down.sql": &bintree{_1524493026_create_pipeline_config_revisionsDownSql, map[string]*bintree{}},
+	"1524493027_create_versioned_resource_fields.up.sql": &bintree{_1524493027_create_versioned_resource_fieldsUpSql, map[string]*bintree{}},
+	"1524493027_create_versioned_resource_fields.down.sql": &bintree{_1524493027_create_versioned_resource_fieldsDownSql, map[string]*bintree{}},
+	"1524493028_add_archived_at_to_pipelines.up.sql": &bintree{_1524493028_add_archived_at_to_pipelinesUpSql, map[string]*bintree{}},
+	"1524493028_add_archived_at_to_pipelines.down.sql": &bintree{_1524493028_add_archived_at_to_pipelinesDownSql, map[string]*bintree{}},
+	"1524493029_materialize_dashboard_builds_on_jobs.up.sql": &bintree{_1524493029_materialize_dashboard_builds_on_jobsUpSql, map[string]*bintree{}},
+	"1524493029_materialize_dashboard_builds_on_jobs.down.sql": &bintree{_1524493029_materialize_dashboard_builds_on_jobsDownSql, map[string]*bintree{}},
+	"1524493030_create_team_cache_usages.up.sql": &bintree{_1524493030_create_team_cache_usagesUpSql, map[string]*bintree{}},
+	"1524493030_create_team_cache_usages.down.sql": &bintree{_1524493030_create_team_cache_usagesDownSql, map[string]*bintree{}},
+	"1524493031_add_team_data_keys.up.sql": &bintree{_1524493031_add_team_data_keysUpSql, map[string]*bintree{}},
+	"1524493031_add_team_data_keys.down.go": &bintree{_1524493031_add_team_data_keysDownGo, map[string]*bintree{}},
+	"1524493032_create_resource_webhook_tokens.up.sql": &bintree{_1524493032_create_resource_webhook_tokensUpSql, map[string]*bintree{}},
+	"1524493032_create_resource_webhook_tokens.down.sql": &bintree{_1524493032_create_resource_webhook_tokensDownSql, map[string]*bintree{}},
+	"1524493033_create_resource_config_versions.up.sql": &bintree{_1524493033_create_resource_config_versionsUpSql, map[string]*bintree{}},
+	"1524493033_create_resource_config_versions.down.sql": &bintree{_1524493033_create_resource_config_versionsDownSql, map[string]*bintree{}},
+	"1524493034_create_resource_check_results.up.sql": &bintree{_1524493034_create_resource_check_resultsUpSql, map[string]*bintree{}},
+	"1524493034_create_resource_check_results.down.sql": &bintree{_1524493034_create_resource_check_resultsDownSql, map[string]*bintree{}},
+	"1524493035_create_worker_task_slots.up.sql": &bintree{_1524493035_create_worker_task_slotsUpSql, map[string]*bintree{}},
+	"1524493035_create_worker_task_slots.down.sql": &bintree{_1524493035_create_worker_task_slotsDownSql, map[string]*bintree{}},
+	"1524493036_add_last_used_at_to_volumes.up.sql": &bintree{_1524493036_add_last_used_at_to_volumesUpSql, map[string]*bintree{}},
+	"1524493036_add_last_used_at_to_volumes.down.sql": &bintree{_1524493036_add_last_used_at_to_volumesDownSql, map[string]*bintree{}},
+	"1524493037_add_stalled_at_to_workers.up.sql": &bintree{_1524493037_add_stalled_at_to_workersUpSql, map[string]*bintree{}},
+	"1524493037_add_stalled_at_to_workers.down.sql": &bintree{_1524493037_add_stalled_at_to_workersDownSql, map[string]*bintree{}},
+	"1524493038_add_replay_expires_at_to_containers.up.sql": &bintree{_1524493038_add_replay_expires_at_to_containersUpSql, map[string]*bintree{}},
+	"1524493038_add_replay_expires_at_to_containers.down.sql": &bintree{_1524493038_add_replay_expires_at_to_containersDownSql, map[string]*bintree{}},
+	"1524493039_key_audit_events_by_team_id.up.sql": &bintree{_1524493039_key_audit_events_by_team_idUpSql, map[string]*bintree{}},
+	"1524493039_key_audit_events_by_team_id.down.sql": &bintree{_1524493039_key_audit_events_by_team_idDownSql, map[string]*bintree{}},
+	"1524493040_add_unique_index_to_worker_resource_caches.up.sql": &bintree{_1524493040_add_unique_index_to_worker_resource_cachesUpSql, map[string]*bintree{}},
+	"1524493040_add_unique_index_to_worker_resource_caches.down.sql": &bintree{_1524493040_add_unique_index_to_worker_resource_cachesDownSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
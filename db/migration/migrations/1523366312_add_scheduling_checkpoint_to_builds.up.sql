@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN scheduling_checkpoint text;
+COMMIT;
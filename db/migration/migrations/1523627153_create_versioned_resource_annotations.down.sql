@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE "versioned_resource_annotations";
+COMMIT;
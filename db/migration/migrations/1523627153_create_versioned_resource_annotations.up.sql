@@ -0,0 +1,12 @@
+BEGIN;
+  CREATE TABLE "versioned_resource_annotations" (
+      "id" serial,
+      "versioned_resource_id" integer NOT NULL,
+      "name" text NOT NULL,
+      "value" text NOT NULL,
+      "url" text NOT NULL DEFAULT '',
+      PRIMARY KEY ("id"),
+      CONSTRAINT "versioned_resource_annotations_versioned_resource_id_fkey" FOREIGN KEY ("versioned_resource_id") REFERENCES "versioned_resources"("id") ON DELETE CASCADE,
+      CONSTRAINT "versioned_resource_annotations_name_key" UNIQUE ("versioned_resource_id", "name")
+  );
+COMMIT;
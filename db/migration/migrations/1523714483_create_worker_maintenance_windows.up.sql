@@ -0,0 +1,11 @@
+BEGIN;
+  CREATE TABLE "worker_maintenance_windows" (
+      "id" serial PRIMARY KEY,
+      "worker_name" text NOT NULL,
+      "starts_at" timestamp with time zone NOT NULL,
+      "ends_at" timestamp with time zone NOT NULL,
+      CONSTRAINT "worker_maintenance_windows_worker_name_fkey" FOREIGN KEY ("worker_name") REFERENCES "workers"("name") ON DELETE CASCADE
+  );
+
+  CREATE INDEX worker_maintenance_windows_worker_name ON worker_maintenance_windows (worker_name, ends_at);
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+  CREATE TABLE "build_step_states" (
+      "build_id" integer NOT NULL,
+      "plan_id" text NOT NULL,
+      "state" text NOT NULL,
+      PRIMARY KEY ("build_id", "plan_id"),
+      CONSTRAINT "build_step_states_build_id_fkey" FOREIGN KEY ("build_id") REFERENCES "builds"("id") ON DELETE CASCADE
+  );
+COMMIT;
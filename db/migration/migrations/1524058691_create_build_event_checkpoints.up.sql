@@ -0,0 +1,9 @@
+BEGIN;
+  CREATE TABLE "build_event_checkpoints" (
+      "build_id" integer NOT NULL,
+      "subscriber" text NOT NULL,
+      "event_id" integer NOT NULL,
+      PRIMARY KEY ("build_id", "subscriber"),
+      CONSTRAINT "build_event_checkpoints_build_id_fkey" FOREIGN KEY ("build_id") REFERENCES "builds"("id") ON DELETE CASCADE
+  );
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP INDEX builds_pipeline_id_start_time;
+COMMIT;
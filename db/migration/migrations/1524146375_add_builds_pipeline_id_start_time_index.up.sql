@@ -0,0 +1,3 @@
+BEGIN;
+  CREATE INDEX builds_pipeline_id_start_time ON builds USING btree (pipeline_id, start_time);
+COMMIT;
@@ -0,0 +1,62 @@
+package migrations
+
+import "github.com/concourse/atc/db/compression"
+
+type compressedEvent struct {
+	BuildID int
+	EventID int
+	Codec   string
+	Payload []byte
+}
+
+func (self *migrations) Down_1524319807() error {
+	tx, err := self.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.Query("SELECT build_id, event_id, codec, compressed_payload FROM build_events WHERE codec IS NOT NULL")
+	if err != nil {
+		return err
+	}
+
+	events := []compressedEvent{}
+	for rows.Next() {
+		event := compressedEvent{}
+
+		err = rows.Scan(&event.BuildID, &event.EventID, &event.Codec, &event.Payload)
+		if err != nil {
+			return err
+		}
+
+		events = append(events, event)
+	}
+
+	for _, event := range events {
+		codec, err := compression.Lookup(event.Codec)
+		if err != nil {
+			return err
+		}
+
+		payload, err := codec.Decompress(event.Payload)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec("UPDATE build_events SET payload = $1 WHERE build_id = $2 AND event_id = $3", string(payload), event.BuildID, event.EventID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec("ALTER TABLE build_events DROP COLUMN codec, DROP COLUMN compressed_payload")
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE build_events ADD COLUMN codec text, ADD COLUMN compressed_payload bytea;
+COMMIT;
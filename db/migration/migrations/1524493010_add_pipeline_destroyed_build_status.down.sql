@@ -0,0 +1,2 @@
+-- values cannot be removed from an enum; the value is left unused
+UPDATE builds SET status = 'aborted' WHERE status = 'pipeline-destroyed';
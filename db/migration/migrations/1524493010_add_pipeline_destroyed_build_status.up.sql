@@ -0,0 +1,2 @@
+-- adding a value to an enum cannot be done within a transaction
+ALTER TYPE build_status ADD VALUE IF NOT EXISTS 'pipeline-destroyed';
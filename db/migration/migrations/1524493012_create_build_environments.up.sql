@@ -0,0 +1,7 @@
+BEGIN;
+  CREATE TABLE "build_environments" (
+      "build_id" integer NOT NULL PRIMARY KEY,
+      "environment" text NOT NULL,
+      CONSTRAINT "build_environments_build_id_fkey" FOREIGN KEY ("build_id") REFERENCES "builds"("id") ON DELETE CASCADE
+  );
+COMMIT;
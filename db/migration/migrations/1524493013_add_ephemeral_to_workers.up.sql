@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE workers ADD COLUMN ephemeral boolean NOT NULL DEFAULT false;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE workers DROP COLUMN memory_pressure;
+  ALTER TABLE workers DROP COLUMN disk_pressure;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE workers ADD COLUMN memory_pressure boolean NOT NULL DEFAULT false;
+  ALTER TABLE workers ADD COLUMN disk_pressure boolean NOT NULL DEFAULT false;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE volumes DROP COLUMN created_at;
+  ALTER TABLE workers DROP COLUMN disk_usage;
+COMMIT;
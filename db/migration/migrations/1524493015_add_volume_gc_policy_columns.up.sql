@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE volumes ADD COLUMN created_at timestamp with time zone NOT NULL DEFAULT now();
+  ALTER TABLE workers ADD COLUMN disk_usage integer NOT NULL DEFAULT 0;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  DROP INDEX worker_resource_caches_team_id;
+  ALTER TABLE worker_resource_caches DROP COLUMN team_id;
+COMMIT;
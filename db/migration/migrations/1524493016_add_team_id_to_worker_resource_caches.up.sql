@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE worker_resource_caches ADD COLUMN team_id integer REFERENCES teams (id) ON DELETE CASCADE;
+  CREATE INDEX worker_resource_caches_team_id ON worker_resource_caches (team_id);
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+  DROP INDEX containers_worker_warm_resource_type_id;
+  ALTER TABLE containers DROP COLUMN worker_warm_resource_type_id;
+
+  DROP TABLE worker_warm_resource_types;
+COMMIT;
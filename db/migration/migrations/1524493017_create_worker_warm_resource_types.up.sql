@@ -0,0 +1,16 @@
+BEGIN;
+  CREATE TABLE "worker_warm_resource_types" (
+      "id" serial PRIMARY KEY,
+      "worker_name" text NOT NULL,
+      "name" text NOT NULL,
+      "resource_cache_id" integer,
+      CONSTRAINT "worker_warm_resource_types_worker_name_fkey" FOREIGN KEY ("worker_name") REFERENCES "workers"("name") ON DELETE CASCADE,
+      CONSTRAINT "worker_warm_resource_types_resource_cache_id_fkey" FOREIGN KEY ("resource_cache_id") REFERENCES "resource_caches"("id") ON DELETE SET NULL,
+      CONSTRAINT "worker_warm_resource_types_worker_name_name_key" UNIQUE ("worker_name", "name")
+  );
+
+  CREATE INDEX worker_warm_resource_types_resource_cache_id ON worker_warm_resource_types (resource_cache_id);
+
+  ALTER TABLE containers ADD COLUMN worker_warm_resource_type_id integer REFERENCES worker_warm_resource_types (id) ON DELETE SET NULL;
+  CREATE INDEX containers_worker_warm_resource_type_id ON containers (worker_warm_resource_type_id);
+COMMIT;
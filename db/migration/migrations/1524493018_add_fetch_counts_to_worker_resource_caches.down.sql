@@ -0,0 +1,8 @@
+BEGIN;
+  DROP INDEX volumes_replica_of_worker_resource_cache_id;
+  ALTER TABLE volumes DROP COLUMN replica_of_worker_resource_cache_id;
+
+  ALTER TABLE worker_resource_caches
+    DROP COLUMN last_fetched_at,
+    DROP COLUMN fetch_count;
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+  ALTER TABLE worker_resource_caches
+    ADD COLUMN fetch_count integer NOT NULL DEFAULT 0,
+    ADD COLUMN last_fetched_at timestamp with time zone;
+
+  ALTER TABLE volumes ADD COLUMN replica_of_worker_resource_cache_id integer REFERENCES worker_resource_caches (id) ON DELETE SET NULL;
+  CREATE INDEX volumes_replica_of_worker_resource_cache_id ON volumes (replica_of_worker_resource_cache_id);
+COMMIT;
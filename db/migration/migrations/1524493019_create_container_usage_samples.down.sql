@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE container_usage_samples;
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+  CREATE TABLE "container_usage_samples" (
+      "id" bigserial PRIMARY KEY,
+      "build_id" integer NOT NULL,
+      "container_handle" text NOT NULL,
+      "plan_id" text NOT NULL DEFAULT '',
+      "step_name" text NOT NULL DEFAULT '',
+      "type" text NOT NULL DEFAULT '',
+      "sampled_at" timestamp with time zone NOT NULL DEFAULT now(),
+      "cpu_usage" bigint NOT NULL,
+      "memory_usage" bigint NOT NULL,
+      "disk_usage" bigint NOT NULL,
+      CONSTRAINT "container_usage_samples_build_id_fkey" FOREIGN KEY ("build_id") REFERENCES "builds"("id") ON DELETE CASCADE
+  );
+
+  CREATE INDEX container_usage_samples_build_id_container_handle ON container_usage_samples (build_id, container_handle, id);
+COMMIT;
@@ -0,0 +1,30 @@
+package migrations
+
+import "fmt"
+
+func (self *migrations) Down_1524493022() error {
+	tx, err := self.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	teamIDs, err := selectTeamIDs(tx)
+	if err != nil {
+		return err
+	}
+
+	for _, teamID := range teamIDs {
+		_, err = tx.Exec(fmt.Sprintf(`
+			DROP INDEX IF EXISTS team_build_events_%[1]d_build_id, team_build_events_%[1]d_build_id_event_id
+		`, teamID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
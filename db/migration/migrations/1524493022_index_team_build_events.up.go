@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func (self *migrations) Up_1524493022() error {
+	tx, err := self.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	teamIDs, err := selectTeamIDs(tx)
+	if err != nil {
+		return err
+	}
+
+	for _, teamID := range teamIDs {
+		_, err = tx.Exec(fmt.Sprintf(`
+			CREATE INDEX IF NOT EXISTS team_build_events_%[1]d_build_id ON team_build_events_%[1]d (build_id)
+		`, teamID))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`
+			CREATE UNIQUE INDEX IF NOT EXISTS team_build_events_%[1]d_build_id_event_id ON team_build_events_%[1]d (build_id, event_id)
+		`, teamID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func selectTeamIDs(tx *sql.Tx) ([]int, error) {
+	rows, err := tx.Query("SELECT id FROM teams")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	teamIDs := []int{}
+	for rows.Next() {
+		var teamID int
+		err = rows.Scan(&teamID)
+		if err != nil {
+			return nil, err
+		}
+
+		teamIDs = append(teamIDs, teamID)
+	}
+
+	return teamIDs, rows.Err()
+}
@@ -0,0 +1,6 @@
+BEGIN;
+  DROP INDEX builds_job_id_id;
+  DROP INDEX builds_pipeline_id_id;
+  DROP INDEX builds_team_id_id;
+  DROP INDEX versioned_resources_resource_id_check_order;
+COMMIT;
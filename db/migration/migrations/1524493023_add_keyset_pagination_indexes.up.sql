@@ -0,0 +1,6 @@
+BEGIN;
+  CREATE INDEX builds_job_id_id ON builds (job_id, id);
+  CREATE INDEX builds_pipeline_id_id ON builds (pipeline_id, id);
+  CREATE INDEX builds_team_id_id ON builds (team_id, id);
+  CREATE INDEX versioned_resources_resource_id_check_order ON versioned_resources (resource_id, check_order);
+COMMIT;
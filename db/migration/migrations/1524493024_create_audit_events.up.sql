@@ -0,0 +1,13 @@
+BEGIN;
+  CREATE TABLE audit_events (
+    id serial PRIMARY KEY,
+    team_name text NOT NULL,
+    requester text NOT NULL,
+    action text NOT NULL,
+    subject json NOT NULL DEFAULT '{}',
+    remote_addr text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+  );
+
+  CREATE INDEX audit_events_team_name_id ON audit_events (team_name, id);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE pipelines
+    DROP COLUMN build_log_retention;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE pipeline_config_revisions;
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+  CREATE TABLE pipeline_config_revisions (
+    id serial PRIMARY KEY,
+    pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+    config_version integer NOT NULL,
+    config text NOT NULL,
+    nonce text,
+    diff json NOT NULL DEFAULT '[]',
+    author text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+  );
+
+  CREATE INDEX pipeline_config_revisions_pipeline_id_id ON pipeline_config_revisions (pipeline_id, id);
+COMMIT;
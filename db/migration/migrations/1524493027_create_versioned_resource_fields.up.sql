@@ -0,0 +1,24 @@
+BEGIN;
+  CREATE TABLE versioned_resource_fields (
+    versioned_resource_id integer NOT NULL REFERENCES versioned_resources (id) ON DELETE CASCADE,
+    source text NOT NULL,
+    name text NOT NULL,
+    value text NOT NULL
+  );
+
+  CREATE INDEX versioned_resource_fields_versioned_resource_id ON versioned_resource_fields (versioned_resource_id);
+  CREATE INDEX versioned_resource_fields_value ON versioned_resource_fields (value text_pattern_ops);
+
+  INSERT INTO versioned_resource_fields (versioned_resource_id, source, name, value)
+  SELECT v.id, 'version', f.key, f.value
+  FROM versioned_resources v,
+    json_each_text(CASE WHEN json_typeof(v.version::json) = 'object' THEN v.version::json ELSE '{}' END) f
+  WHERE f.value IS NOT NULL;
+
+  INSERT INTO versioned_resource_fields (versioned_resource_id, source, name, value)
+  SELECT v.id, 'metadata', f->>'Name', f->>'Value'
+  FROM versioned_resources v,
+    json_array_elements(CASE WHEN json_typeof(v.metadata::json) = 'array' THEN v.metadata::json ELSE '[]' END) f
+  WHERE f->>'Name' IS NOT NULL
+  AND f->>'Value' IS NOT NULL;
+COMMIT;
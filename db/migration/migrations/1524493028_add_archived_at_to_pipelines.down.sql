@@ -0,0 +1,18 @@
+BEGIN;
+  DO $$
+  DECLARE
+    archived_id integer;
+  BEGIN
+    FOR archived_id IN SELECT id FROM pipelines WHERE archived_at IS NOT NULL LOOP
+      EXECUTE format('DROP TABLE IF EXISTS pipeline_build_events_%s', archived_id);
+    END LOOP;
+  END
+  $$;
+
+  DELETE FROM pipelines WHERE archived_at IS NOT NULL;
+
+  DROP INDEX pipelines_name_team_id;
+  ALTER TABLE pipelines ADD CONSTRAINT pipelines_name_team_id UNIQUE (name, team_id);
+
+  ALTER TABLE pipelines DROP COLUMN archived_at;
+COMMIT;
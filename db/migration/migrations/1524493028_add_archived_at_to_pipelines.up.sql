@@ -0,0 +1,6 @@
+BEGIN;
+  ALTER TABLE pipelines ADD COLUMN archived_at timestamp with time zone;
+
+  ALTER TABLE pipelines DROP CONSTRAINT pipelines_name_team_id;
+  CREATE UNIQUE INDEX pipelines_name_team_id ON pipelines (name, team_id) WHERE archived_at IS NULL;
+COMMIT;
@@ -0,0 +1,42 @@
+BEGIN;
+  ALTER TABLE jobs
+    ADD COLUMN next_build_id integer,
+    ADD COLUMN latest_completed_build_id integer,
+    ADD COLUMN transition_build_id integer;
+
+  CREATE INDEX builds_job_id_running ON builds (job_id, id) WHERE status IN ('pending', 'started');
+
+  UPDATE jobs j
+  SET next_build_id = (
+      SELECT min(b.id)
+      FROM builds b
+      WHERE b.job_id = j.id
+      AND b.status IN ('pending', 'started')
+    ),
+    latest_completed_build_id = (
+      SELECT max(b.id)
+      FROM builds b
+      WHERE b.job_id = j.id
+      AND b.status NOT IN ('pending', 'started')
+    );
+
+  UPDATE jobs j
+  SET transition_build_id = (
+    SELECT min(b.id)
+    FROM builds b
+    WHERE b.job_id = j.id
+    AND b.status NOT IN ('pending', 'started')
+    AND b.id > COALESCE((
+      SELECT max(d.id)
+      FROM builds d, builds l
+      WHERE l.id = j.latest_completed_build_id
+      AND d.job_id = j.id
+      AND d.status NOT IN ('pending', 'started')
+      AND d.status <> l.status
+    ), 0)
+  );
+
+  DROP MATERIALIZED VIEW transition_builds_per_job;
+  DROP MATERIALIZED VIEW next_builds_per_job;
+  DROP MATERIALIZED VIEW latest_completed_builds_per_job;
+COMMIT;
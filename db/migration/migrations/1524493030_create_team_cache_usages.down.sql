@@ -0,0 +1,5 @@
+BEGIN;
+  DROP TABLE team_cache_usages;
+
+  ALTER TABLE volumes DROP COLUMN "size";
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+  ALTER TABLE volumes ADD COLUMN "size" bigint;
+
+  CREATE TABLE "team_cache_usages" (
+      "team_id" integer PRIMARY KEY,
+      "resource_cache_bytes" bigint NOT NULL DEFAULT 0,
+      "task_cache_bytes" bigint NOT NULL DEFAULT 0,
+      "updated_at" timestamp with time zone NOT NULL DEFAULT now(),
+      CONSTRAINT "team_cache_usages_team_id_fkey" FOREIGN KEY ("team_id") REFERENCES "teams"("id") ON DELETE CASCADE
+  );
+COMMIT;
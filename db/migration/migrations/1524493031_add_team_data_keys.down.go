@@ -0,0 +1,112 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/concourse/atc/db/encryption"
+)
+
+var teamKeyedTables = []string{"jobs", "resources", "resource_types", "pipeline_config_revisions"}
+
+type teamKeyedConfig struct {
+	ID     int
+	Config string
+	Nonce  string
+}
+
+func (self *migrations) Down_1524493031() error {
+	tx, err := self.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.Query("SELECT id, data_key, data_key_nonce FROM teams WHERE data_key IS NOT NULL")
+	if err != nil {
+		return err
+	}
+
+	teamKeys := map[int]*encryption.Key{}
+	for rows.Next() {
+		var teamID int
+		var dataKey string
+		var dataKeyNonce sql.NullString
+
+		err = rows.Scan(&teamID, &dataKey, &dataKeyNonce)
+		if err != nil {
+			return err
+		}
+
+		var nonce *string
+		if dataKeyNonce.Valid {
+			nonce = &dataKeyNonce.String
+		}
+
+		key, err := encryption.UnwrapDataKey(self.Strategy, dataKey, nonce)
+		if err != nil {
+			return err
+		}
+
+		teamKeys[teamID] = key
+	}
+
+	// configs go back to being encrypted with the master key
+	for _, table := range teamKeyedTables {
+		for teamID, key := range teamKeys {
+			rows, err := tx.Query(`
+				SELECT c.id, c.config, c.nonce
+				FROM `+table+` c
+				JOIN pipelines p ON p.id = c.pipeline_id
+				WHERE c.team_keyed
+				AND p.team_id = $1
+			`, teamID)
+			if err != nil {
+				return err
+			}
+
+			configs := []teamKeyedConfig{}
+			for rows.Next() {
+				config := teamKeyedConfig{}
+
+				err = rows.Scan(&config.ID, &config.Config, &config.Nonce)
+				if err != nil {
+					return err
+				}
+
+				configs = append(configs, config)
+			}
+
+			for _, config := range configs {
+				decrypted, err := key.Decrypt(config.Config, &config.Nonce)
+				if err != nil {
+					return err
+				}
+
+				encrypted, nonce, err := self.Strategy.Encrypt(decrypted)
+				if err != nil {
+					return err
+				}
+
+				_, err = tx.Exec(`UPDATE `+table+` SET config = $1, nonce = $2 WHERE id = $3`, encrypted, nonce, config.ID)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		_, err = tx.Exec(`ALTER TABLE ` + table + ` DROP COLUMN team_keyed`)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`ALTER TABLE teams DROP COLUMN data_key, DROP COLUMN data_key_nonce`)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,13 @@
+BEGIN;
+  ALTER TABLE teams
+    ADD COLUMN data_key text,
+    ADD COLUMN data_key_nonce text;
+
+  ALTER TABLE jobs ADD COLUMN team_keyed boolean NOT NULL DEFAULT false;
+
+  ALTER TABLE resources ADD COLUMN team_keyed boolean NOT NULL DEFAULT false;
+
+  ALTER TABLE resource_types ADD COLUMN team_keyed boolean NOT NULL DEFAULT false;
+
+  ALTER TABLE pipeline_config_revisions ADD COLUMN team_keyed boolean NOT NULL DEFAULT false;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE resource_webhook_tokens;
+COMMIT;
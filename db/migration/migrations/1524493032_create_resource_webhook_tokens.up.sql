@@ -0,0 +1,13 @@
+BEGIN;
+  CREATE TABLE resource_webhook_tokens (
+    id serial PRIMARY KEY,
+    resource_id integer NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    token_hash text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_used_at timestamp with time zone
+  );
+
+  CREATE UNIQUE INDEX resource_webhook_tokens_token_hash_key ON resource_webhook_tokens (token_hash);
+
+  CREATE INDEX resource_webhook_tokens_resource_id ON resource_webhook_tokens (resource_id);
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+  CREATE TABLE resource_config_versions (
+    id serial PRIMARY KEY,
+    resource_config_id integer NOT NULL REFERENCES resource_configs (id) ON DELETE CASCADE,
+    version text NOT NULL,
+    check_order integer NOT NULL DEFAULT 0
+  );
+
+  CREATE UNIQUE INDEX resource_config_versions_resource_config_id_version_key ON resource_config_versions (resource_config_id, md5(version));
+
+  CREATE INDEX resource_config_versions_check_order ON resource_config_versions (resource_config_id, check_order);
+
+  ALTER TABLE resource_configs ADD COLUMN last_checked timestamp with time zone NOT NULL DEFAULT '1970-01-01 00:00:00';
+COMMIT;
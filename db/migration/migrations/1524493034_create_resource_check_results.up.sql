@@ -0,0 +1,12 @@
+BEGIN;
+  CREATE TABLE resource_check_results (
+    id serial PRIMARY KEY,
+    resource_id integer NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    start_time timestamp with time zone NOT NULL,
+    end_time timestamp with time zone NOT NULL,
+    error text,
+    version_count integer NOT NULL DEFAULT 0
+  );
+
+  CREATE INDEX resource_check_results_resource_id ON resource_check_results (resource_id, id DESC);
+COMMIT;
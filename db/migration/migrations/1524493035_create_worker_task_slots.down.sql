@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE worker_task_slots;
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+  CREATE TABLE worker_task_slots (
+    worker_name text NOT NULL REFERENCES workers (name) ON DELETE CASCADE,
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    PRIMARY KEY (build_id, plan_id)
+  );
+
+  CREATE INDEX worker_task_slots_worker_name ON worker_task_slots (worker_name);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE volumes DROP COLUMN last_used_at;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE volumes ADD COLUMN last_used_at timestamp with time zone NOT NULL DEFAULT now();
+  UPDATE volumes SET last_used_at = created_at;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE workers ADD COLUMN stalled_at timestamp with time zone;
+  UPDATE workers SET stalled_at = now() WHERE state = 'stalled';
+COMMIT;
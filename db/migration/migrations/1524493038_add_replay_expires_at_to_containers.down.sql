@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE containers DROP COLUMN replay_expires_at;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE containers ADD COLUMN replay_expires_at timestamp with time zone;
+COMMIT;
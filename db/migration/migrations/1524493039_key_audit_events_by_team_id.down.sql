@@ -0,0 +1,7 @@
+BEGIN;
+  DROP INDEX audit_events_team_id_id;
+
+  CREATE INDEX audit_events_team_name_id ON audit_events (team_name, id);
+
+  ALTER TABLE audit_events DROP COLUMN team_id;
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+  ALTER TABLE audit_events ADD COLUMN team_id integer;
+
+  UPDATE audit_events a
+  SET team_id = t.id
+  FROM teams t
+  WHERE t.name = a.team_name;
+
+  DROP INDEX audit_events_team_name_id;
+
+  CREATE INDEX audit_events_team_id_id ON audit_events (team_id, id);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP INDEX worker_resource_caches_cache_type_team_key;
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+  CREATE TEMPORARY TABLE duplicate_worker_resource_caches ON COMMIT DROP AS
+    SELECT id, keep_id FROM (
+      SELECT id, min(id) OVER (PARTITION BY resource_cache_id, worker_base_resource_type_id, COALESCE(team_id, 0)) AS keep_id
+      FROM worker_resource_caches
+    ) wrc
+    WHERE id <> keep_id;
+
+  UPDATE volumes v SET worker_resource_cache_id = d.keep_id
+    FROM duplicate_worker_resource_caches d
+    WHERE v.worker_resource_cache_id = d.id;
+
+  UPDATE volumes v SET replica_of_worker_resource_cache_id = d.keep_id
+    FROM duplicate_worker_resource_caches d
+    WHERE v.replica_of_worker_resource_cache_id = d.id;
+
+  DELETE FROM worker_resource_caches wrc
+    USING duplicate_worker_resource_caches d
+    WHERE wrc.id = d.id;
+
+  CREATE UNIQUE INDEX worker_resource_caches_cache_type_team_key ON worker_resource_caches (resource_cache_id, worker_base_resource_type_id, COALESCE(team_id, 0));
+COMMIT;
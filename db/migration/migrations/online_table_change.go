@@ -0,0 +1,783 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	defaultOnlineBatchSize       = 10000
+	defaultOnlineLockTimeout     = 5 * time.Second
+	defaultOnlineLockAttempts    = 10
+	defaultOnlineLockRetryPeriod = 5 * time.Second
+)
+
+var ErrTableReferencesItself = errors.New("table references itself by foreign keys; drop them before changing it online and add them back afterwards")
+var ErrTableHasTriggers = errors.New("table has triggers; drop them before changing it online and add them back afterwards")
+var ErrTableHasNoKey = errors.New("table has no primary key or unique index on non-null columns to copy it by")
+
+// OnlineTableChange alters a large table without locking it for the length of
+// time it takes to rewrite it.
+//
+// The alterations are applied to an empty copy of the table, which is kept in
+// sync with the original by a trigger while the existing rows are copied over
+// in batches, each in its own short transaction. Once the copy has caught up
+// it is swapped in for the original, which is dropped. Only the installation
+// of the trigger and the swap need an exclusive lock, and neither waits for it
+// for longer than LockTimeout at a time so as not to hold up everything queued
+// behind it.
+//
+// The table is copied in order of a unique key, which may span several
+// columns of any type, so it must have a primary key or a unique index on
+// non-null columns. Columns present in both the original and the altered
+// table are copied over; added columns take their defaults. Indexes, defaults
+// and constraints are carried over. Foreign keys referencing the table are
+// moved over to the altered table as it is swapped in and validated
+// afterwards, but the table must not reference itself and must not have any
+// triggers of its own.
+//
+// If a change is interrupted, running it again starts over.
+type OnlineTableChange struct {
+	Table string
+
+	// Alterations are the ALTER TABLE actions to apply, e.g. "ADD COLUMN foo
+	// text" or "ALTER COLUMN bar TYPE bigint".
+	Alterations []string
+
+	// Key is the unique, non-null columns to copy the table in batches by,
+	// defaulting to those of its primary key, or else of its first unique
+	// index on non-null columns.
+	Key []string
+
+	// BatchSize is the number of rows to copy in each batch.
+	BatchSize int
+
+	// BatchPause is the length of time to wait between batches, to leave
+	// headroom for everything else using the database.
+	BatchPause time.Duration
+
+	LockTimeout  time.Duration
+	LockAttempts int
+}
+
+func (change OnlineTableChange) Run(db *sql.DB) error {
+	if change.BatchSize == 0 {
+		change.BatchSize = defaultOnlineBatchSize
+	}
+
+	if change.LockTimeout == 0 {
+		change.LockTimeout = defaultOnlineLockTimeout
+	}
+
+	if change.LockAttempts == 0 {
+		change.LockAttempts = defaultOnlineLockAttempts
+	}
+
+	err := change.checkTable(db)
+	if err != nil {
+		return err
+	}
+
+	if len(change.Key) == 0 {
+		change.Key, err = change.uniqueKey(db)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = change.cleanUp(db)
+	if err != nil {
+		return err
+	}
+
+	err = change.createShadow(db)
+	if err != nil {
+		return err
+	}
+
+	columns, err := change.sharedColumns(db)
+	if err != nil {
+		return err
+	}
+
+	err = change.installTrigger(db, columns)
+	if err != nil {
+		return err
+	}
+
+	err = change.backfill(db, columns)
+	if err != nil {
+		return err
+	}
+
+	referencing, err := change.referencingForeignKeys(db)
+	if err != nil {
+		return err
+	}
+
+	err = change.swap(db, referencing)
+	if err != nil {
+		return err
+	}
+
+	return change.validateForeignKeys(db, referencing)
+}
+
+func (change OnlineTableChange) shadowTable() string {
+	return "_" + change.Table + "_new"
+}
+
+func (change OnlineTableChange) syncFunction() string {
+	return "_" + change.Table + "_online_sync"
+}
+
+func (change OnlineTableChange) checkTable(db *sql.DB) error {
+	var referencesItself bool
+	err := db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM pg_constraint WHERE contype = 'f' AND confrelid = $1::regclass AND conrelid = $1::regclass
+		)
+	`, change.Table).Scan(&referencesItself)
+	if err != nil {
+		return err
+	}
+
+	if referencesItself {
+		return ErrTableReferencesItself
+	}
+
+	var triggered bool
+	err = db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM pg_trigger WHERE NOT tgisinternal AND tgrelid = $1::regclass AND tgname != $2
+		)
+	`, change.Table, change.syncFunction()).Scan(&triggered)
+	if err != nil {
+		return err
+	}
+
+	if triggered {
+		return ErrTableHasTriggers
+	}
+
+	return nil
+}
+
+// uniqueKey returns the columns of the table's primary key, or else of its
+// first unique index on non-null columns.
+func (change OnlineTableChange) uniqueKey(db *sql.DB) ([]string, error) {
+	var key []string
+	err := db.QueryRow(`
+		SELECT array_agg(a.attname::text ORDER BY k.ordinality)
+		FROM pg_index i
+		CROSS JOIN LATERAL unnest(i.indkey::int2[]) WITH ORDINALITY k (attnum, ordinality)
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum
+		WHERE i.indrelid = $1::regclass
+		AND i.indisunique
+		AND i.indpred IS NULL
+		AND i.indexprs IS NULL
+		GROUP BY i.indexrelid, i.indisprimary
+		HAVING bool_and(a.attnotnull)
+		ORDER BY i.indisprimary DESC, i.indexrelid
+		LIMIT 1
+	`, change.Table).Scan(pq.Array(&key))
+	if err == sql.ErrNoRows {
+		return nil, ErrTableHasNoKey
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// cleanUp removes whatever is left over from an interrupted run.
+func (change OnlineTableChange) cleanUp(db *sql.DB) error {
+	return change.withShortLock(db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(fmt.Sprintf(`DROP TRIGGER IF EXISTS %s ON %s`, pq.QuoteIdentifier(change.syncFunction()), pq.QuoteIdentifier(change.Table)))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`DROP FUNCTION IF EXISTS %s()`, pq.QuoteIdentifier(change.syncFunction())))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s`, pq.QuoteIdentifier(change.shadowTable())))
+		return err
+	})
+}
+
+func (change OnlineTableChange) createShadow(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(fmt.Sprintf(`CREATE TABLE %s (LIKE %s INCLUDING ALL)`, pq.QuoteIdentifier(change.shadowTable()), pq.QuoteIdentifier(change.Table)))
+	if err != nil {
+		return err
+	}
+
+	// foreign keys aren't copied by LIKE, but since constraint names only have
+	// to be unique per table they can keep theirs
+	rows, err := tx.Query(`
+		SELECT conname, pg_get_constraintdef(oid)
+		FROM pg_constraint
+		WHERE contype = 'f' AND conrelid = $1::regclass
+	`, change.Table)
+	if err != nil {
+		return err
+	}
+
+	foreignKeys := map[string]string{}
+	for rows.Next() {
+		var name, definition string
+		err = rows.Scan(&name, &definition)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		foreignKeys[name] = definition
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	for name, definition := range foreignKeys {
+		_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE %s ADD CONSTRAINT %s %s`, pq.QuoteIdentifier(change.shadowTable()), pq.QuoteIdentifier(name), definition))
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(change.Alterations) > 0 {
+		_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE %s %s`, pq.QuoteIdentifier(change.shadowTable()), strings.Join(change.Alterations, ", ")))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (change OnlineTableChange) sharedColumns(db *sql.DB) ([]string, error) {
+	rows, err := db.Query(`
+		SELECT n.column_name
+		FROM information_schema.columns n
+		JOIN information_schema.columns o
+			ON o.column_name = n.column_name
+			AND o.table_schema = n.table_schema
+			AND o.table_name = $1
+		WHERE n.table_name = $2
+		AND n.table_schema = current_schema()
+		ORDER BY n.ordinal_position
+	`, change.Table, change.shadowTable())
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	columns := []string{}
+	for rows.Next() {
+		var column string
+		err = rows.Scan(&column)
+		if err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, column)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	shared := map[string]bool{}
+	for _, column := range columns {
+		shared[column] = true
+	}
+
+	for _, column := range change.Key {
+		if !shared[column] {
+			return nil, fmt.Errorf("key column '%s' must be in both the original and the altered table", column)
+		}
+	}
+
+	return columns, nil
+}
+
+// keyColumns returns the key's columns quoted and prefixed, e.g. with "OLD."
+// or "", for use in a row constructor.
+func (change OnlineTableChange) keyColumns(prefix string) string {
+	quoted := make([]string, len(change.Key))
+	for i, column := range change.Key {
+		quoted[i] = prefix + pq.QuoteIdentifier(column)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// keyParams returns placeholders for a value of the key, numbered from first.
+func (change OnlineTableChange) keyParams(first int) string {
+	params := make([]string, len(change.Key))
+	for i := range change.Key {
+		params[i] = fmt.Sprintf("$%d", first+i)
+	}
+
+	return strings.Join(params, ", ")
+}
+
+func (change OnlineTableChange) installTrigger(db *sql.DB, columns []string) error {
+	quoted := make([]string, len(columns))
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = pq.QuoteIdentifier(column)
+		values[i] = "NEW." + pq.QuoteIdentifier(column)
+	}
+
+	shadow := pq.QuoteIdentifier(change.shadowTable())
+	function := pq.QuoteIdentifier(change.syncFunction())
+
+	_, err := db.Exec(fmt.Sprintf(`
+		CREATE FUNCTION %[1]s() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP IN ('UPDATE', 'DELETE') THEN
+				DELETE FROM %[2]s WHERE (%[3]s) = (%[4]s);
+			END IF;
+
+			IF TG_OP IN ('INSERT', 'UPDATE') THEN
+				INSERT INTO %[2]s (%[5]s) VALUES (%[6]s);
+			END IF;
+
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql
+	`, function, shadow, change.keyColumns(""), change.keyColumns("OLD."), strings.Join(quoted, ", "), strings.Join(values, ", ")))
+	if err != nil {
+		return err
+	}
+
+	// creating the trigger waits for every transaction writing to the table to
+	// finish, so any row committed before it is installed is visible to the
+	// backfill
+	return change.withShortLock(db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(fmt.Sprintf(`
+			CREATE TRIGGER %[1]s AFTER INSERT OR UPDATE OR DELETE ON %[2]s
+			FOR EACH ROW EXECUTE PROCEDURE %[1]s()
+		`, function, pq.QuoteIdentifier(change.Table)))
+		return err
+	})
+}
+
+func (change OnlineTableChange) backfill(db *sql.DB, columns []string) error {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = pq.QuoteIdentifier(column)
+	}
+
+	table := pq.QuoteIdentifier(change.Table)
+	key := change.keyColumns("")
+
+	// rows inserted from now on are copied over by the trigger, so only those
+	// up to the current last key need copying; keys are read and compared as
+	// text, which postgres converts back to the key columns' types
+	texts := make([]string, len(change.Key))
+	for i, column := range change.Key {
+		texts[i] = pq.QuoteIdentifier(column) + "::text"
+	}
+
+	last, found, err := change.scanKey(db.QueryRow(fmt.Sprintf(`
+		SELECT %[1]s FROM %[2]s ORDER BY %[3]s DESC LIMIT 1
+	`, strings.Join(texts, ", "), table, descending(change.Key))))
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return nil
+	}
+
+	var from []interface{}
+	for {
+		// the end of the batch is the key BatchSize rows on, or the last key if
+		// there are fewer rows left than that
+		args := append([]interface{}{}, last...)
+		lowerBound := ""
+		if from != nil {
+			args = append(args, from...)
+			lowerBound = fmt.Sprintf("AND (%s) > (%s)", key, change.keyParams(len(last)+1))
+		}
+
+		to, found, err := change.scanKey(db.QueryRow(fmt.Sprintf(`
+			SELECT %[1]s FROM %[2]s
+			WHERE (%[3]s) <= (%[4]s) %[5]s
+			ORDER BY %[3]s
+			LIMIT 1 OFFSET %[6]d
+		`, strings.Join(texts, ", "), table, key, change.keyParams(1), lowerBound, change.BatchSize-1), args...))
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			to = last
+		}
+
+		err = change.copyBatch(db, strings.Join(quoted, ", "), from, to)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return nil
+		}
+
+		from = to
+
+		if change.BatchPause > 0 {
+			time.Sleep(change.BatchPause)
+		}
+	}
+}
+
+func (change OnlineTableChange) scanKey(row *sql.Row) ([]interface{}, bool, error) {
+	values := make([]string, len(change.Key))
+	dest := make([]interface{}, len(change.Key))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	err := row.Scan(dest...)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	key := make([]interface{}, len(values))
+	for i, value := range values {
+		key[i] = value
+	}
+
+	return key, true, nil
+}
+
+func descending(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = pq.QuoteIdentifier(column) + " DESC"
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// copyBatch copies the rows with keys after from, if given, up to and
+// including to.
+func (change OnlineTableChange) copyBatch(db *sql.DB, columns string, from []interface{}, to []interface{}) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	table := pq.QuoteIdentifier(change.Table)
+	shadow := pq.QuoteIdentifier(change.shadowTable())
+	key := change.keyColumns("")
+
+	args := append([]interface{}{}, to...)
+	condition := fmt.Sprintf("(%s) <= (%s)", key, change.keyParams(1))
+	if from != nil {
+		args = append(args, from...)
+		condition += fmt.Sprintf(" AND (%s) > (%s)", key, change.keyParams(len(to)+1))
+	}
+
+	// hold the rows still while they're copied, so that the trigger can't
+	// apply a newer version of one before the batch copies an older one
+	_, err = tx.Exec(fmt.Sprintf(`
+		SELECT 1 FROM %[1]s WHERE %[2]s FOR SHARE
+	`, table, condition), args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`
+		DELETE FROM %[1]s WHERE %[2]s
+	`, shadow, condition), args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`
+		INSERT INTO %[1]s (%[3]s)
+		SELECT %[3]s FROM %[2]s WHERE %[4]s
+	`, shadow, table, columns, condition), args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// foreignKey is a foreign key of another table referencing the one being
+// changed.
+type foreignKey struct {
+	table      string
+	name       string
+	definition string
+}
+
+func (change OnlineTableChange) referencingForeignKeys(db *sql.DB) ([]foreignKey, error) {
+	rows, err := db.Query(`
+		SELECT conrelid::regclass::text, conname, pg_get_constraintdef(oid)
+		FROM pg_constraint
+		WHERE contype = 'f' AND confrelid = $1::regclass
+	`, change.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	foreignKeys := []foreignKey{}
+	for rows.Next() {
+		var fk foreignKey
+		err = rows.Scan(&fk.table, &fk.name, &fk.definition)
+		if err != nil {
+			return nil, err
+		}
+
+		foreignKeys = append(foreignKeys, fk)
+	}
+
+	return foreignKeys, rows.Err()
+}
+
+// validateForeignKeys checks the rows of the referencing tables against the
+// altered table once it has been swapped in. The foreign keys are enforced
+// for new writes from the swap onwards; validating them only takes a lock
+// which lets the referencing tables be written to in the meantime.
+func (change OnlineTableChange) validateForeignKeys(db *sql.DB, foreignKeys []foreignKey) error {
+	for _, fk := range foreignKeys {
+		_, err := db.Exec(fmt.Sprintf(`ALTER TABLE %s VALIDATE CONSTRAINT %s`, fk.table, pq.QuoteIdentifier(fk.name)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var indexNamePattern = regexp.MustCompile(`INDEX \S+ ON`)
+
+func (change OnlineTableChange) swap(db *sql.DB, foreignKeys []foreignKey) error {
+	return change.withShortLock(db, func(tx *sql.Tx) error {
+		table := pq.QuoteIdentifier(change.Table)
+		shadow := pq.QuoteIdentifier(change.shadowTable())
+
+		_, err := tx.Exec(fmt.Sprintf(`LOCK TABLE %s IN ACCESS EXCLUSIVE MODE`, table))
+		if err != nil {
+			return err
+		}
+
+		// the original can't be dropped while it's referenced
+		for _, fk := range foreignKeys {
+			_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE %s DROP CONSTRAINT %s`, fk.table, pq.QuoteIdentifier(fk.name)))
+			if err != nil {
+				return err
+			}
+		}
+
+		originalIndexes, err := indexDefinitions(tx, change.Table)
+		if err != nil {
+			return err
+		}
+
+		// serial columns' sequences would be dropped along with the original
+		sequences, err := ownedSequences(tx, change.Table, change.shadowTable())
+		if err != nil {
+			return err
+		}
+
+		for sequence, column := range sequences {
+			_, err = tx.Exec(fmt.Sprintf(`ALTER SEQUENCE %s OWNED BY %s.%s`, sequence, shadow, pq.QuoteIdentifier(column)))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`DROP TABLE %s`, table))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`DROP FUNCTION %s()`, pq.QuoteIdentifier(change.syncFunction())))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE %s RENAME TO %s`, shadow, table))
+		if err != nil {
+			return err
+		}
+
+		// give the copied indexes (and the constraints backed by them) the
+		// names of the ones they replace
+		copiedIndexes, err := indexDefinitions(tx, change.Table)
+		if err != nil {
+			return err
+		}
+
+		for copiedName, definition := range copiedIndexes {
+			for originalName, originalDefinition := range originalIndexes {
+				if definition != originalDefinition || copiedName == originalName {
+					continue
+				}
+
+				_, err = tx.Exec(fmt.Sprintf(`ALTER INDEX %s RENAME TO %s`, pq.QuoteIdentifier(copiedName), pq.QuoteIdentifier(originalName)))
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// the definitions refer to the table by name, which is now the altered
+		// one's; they are validated once the lock is released
+		for _, fk := range foreignKeys {
+			_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE %s ADD CONSTRAINT %s %s NOT VALID`, fk.table, pq.QuoteIdentifier(fk.name), fk.definition))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func indexDefinitions(tx *sql.Tx, table string) (map[string]string, error) {
+	rows, err := tx.Query(`
+		SELECT c.relname, pg_get_indexdef(i.indexrelid)
+		FROM pg_index i
+		JOIN pg_class c ON c.oid = i.indexrelid
+		WHERE i.indrelid = $1::regclass
+	`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	definitions := map[string]string{}
+	for rows.Next() {
+		var name, definition string
+		err = rows.Scan(&name, &definition)
+		if err != nil {
+			return nil, err
+		}
+
+		definitions[name] = indexNamePattern.ReplaceAllString(definition, "INDEX ON")
+	}
+
+	return definitions, rows.Err()
+}
+
+func ownedSequences(tx *sql.Tx, table string, shadow string) (map[string]string, error) {
+	rows, err := tx.Query(`
+		SELECT pg_get_serial_sequence($1, a.attname), a.attname
+		FROM pg_attribute a
+		WHERE a.attrelid = $1::regclass
+		AND a.attnum > 0
+		AND NOT a.attisdropped
+		AND pg_get_serial_sequence($1, a.attname) IS NOT NULL
+		AND EXISTS (
+			SELECT 1 FROM pg_attribute s
+			WHERE s.attrelid = $2::regclass
+			AND s.attname = a.attname
+			AND NOT s.attisdropped
+		)
+	`, table, shadow)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	sequences := map[string]string{}
+	for rows.Next() {
+		var sequence, column string
+		err = rows.Scan(&sequence, &column)
+		if err != nil {
+			return nil, err
+		}
+
+		sequences[sequence] = column
+	}
+
+	return sequences, rows.Err()
+}
+
+// withShortLock runs the statements in a transaction that gives up on any
+// lock it can't get within the lock timeout, trying again a few times before
+// failing, so that it never holds up queries for long by queueing behind a
+// long-running transaction.
+func (change OnlineTableChange) withShortLock(db *sql.DB, statements func(*sql.Tx) error) error {
+	var err error
+	for attempt := 1; attempt <= change.LockAttempts; attempt++ {
+		err = change.tryWithShortLock(db, statements)
+		if err == nil {
+			return nil
+		}
+
+		if pqErr, ok := err.(*pq.Error); !ok || pqErr.Code.Name() != "lock_not_available" {
+			return err
+		}
+
+		time.Sleep(defaultOnlineLockRetryPeriod)
+	}
+
+	return err
+}
+
+func (change OnlineTableChange) tryWithShortLock(db *sql.DB, statements func(*sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(fmt.Sprintf(`SET LOCAL lock_timeout = %d`, change.LockTimeout/time.Millisecond))
+	if err != nil {
+		return err
+	}
+
+	err = statements(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,269 @@
+package migration_test
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/concourse/atc/db/migration/migrations"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OnlineTableChange", func() {
+	var db *sql.DB
+
+	BeforeEach(func() {
+		db = postgresRunner.OpenDB()
+
+		_, err := db.Exec(`
+			CREATE TABLE widgets (
+				id serial PRIMARY KEY,
+				name text NOT NULL,
+				size integer
+			);
+
+			CREATE UNIQUE INDEX widgets_name ON widgets (name);
+
+			INSERT INTO widgets (name, size)
+			SELECT 'widget-' || i, i FROM generate_series(1, 25) i;
+
+			DELETE FROM widgets WHERE id = 10;
+		`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		_ = db.Close()
+	})
+
+	It("applies the alterations, keeping the rows, indexes and sequences", func() {
+		err := migrations.OnlineTableChange{
+			Table: "widgets",
+			Alterations: []string{
+				"ADD COLUMN color text NOT NULL DEFAULT 'blue'",
+				"ALTER COLUMN size TYPE bigint",
+			},
+			BatchSize: 7,
+		}.Run(db)
+		Expect(err).NotTo(HaveOccurred())
+
+		var count int
+		var sum int64
+		err = db.QueryRow(`SELECT count(*), sum(size) FROM widgets WHERE color = 'blue'`).Scan(&count, &sum)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(24))
+		Expect(sum).To(Equal(int64(25*26/2 - 10)))
+
+		var indexes []string
+		rows, err := db.Query(`SELECT indexname FROM pg_indexes WHERE tablename = 'widgets' ORDER BY indexname`)
+		Expect(err).NotTo(HaveOccurred())
+
+		for rows.Next() {
+			var index string
+			Expect(rows.Scan(&index)).To(Succeed())
+			indexes = append(indexes, index)
+		}
+
+		Expect(indexes).To(Equal([]string{"widgets_name", "widgets_pkey"}))
+
+		var id int
+		err = db.QueryRow(`INSERT INTO widgets (name) VALUES ('widget-26') RETURNING id`).Scan(&id)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(26))
+
+		_, err = db.Exec(`INSERT INTO widgets (name) VALUES ('widget-26')`)
+		Expect(err).To(HaveOccurred())
+
+		var leftovers int
+		err = db.QueryRow(`SELECT count(*) FROM pg_class WHERE relname = '_widgets_new'`).Scan(&leftovers)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leftovers).To(BeZero())
+	})
+
+	It("starts over when a previous run was interrupted", func() {
+		_, err := db.Exec(`CREATE TABLE _widgets_new (LIKE widgets INCLUDING ALL)`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`INSERT INTO _widgets_new (id, name) VALUES (1, 'stale')`)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = migrations.OnlineTableChange{
+			Table:       "widgets",
+			Alterations: []string{"DROP COLUMN size"},
+		}.Run(db)
+		Expect(err).NotTo(HaveOccurred())
+
+		var name string
+		err = db.QueryRow(`SELECT name FROM widgets WHERE id = 1`).Scan(&name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("widget-1"))
+	})
+
+	It("keeps up with writes made while it copies the table", func() {
+		_, err := db.Exec(`CREATE TABLE widgets_expected AS SELECT * FROM widgets`)
+		Expect(err).NotTo(HaveOccurred())
+
+		stop := make(chan struct{})
+		writing := make(chan struct{})
+
+		go func() {
+			defer GinkgoRecover()
+			defer close(writing)
+
+			for i := 1; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				// every write is made to both tables or neither, as writes
+				// may fail while the change swaps the table in
+				tx, err := db.Begin()
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, table := range []string{"widgets", "widgets_expected"} {
+					_, err = tx.Exec(fmt.Sprintf(`
+						UPDATE %[1]s SET size = size + 100 WHERE id = %[2]d;
+						DELETE FROM %[1]s WHERE id = %[3]d;
+						INSERT INTO %[1]s (id, name, size) VALUES (%[4]d, 'written-%[4]d', %[4]d)
+					`, table, i%25+1, (i*7)%25+1, 1000+i))
+					if err != nil {
+						break
+					}
+				}
+
+				if err != nil {
+					_ = tx.Rollback()
+				} else {
+					_ = tx.Commit()
+				}
+
+				time.Sleep(time.Millisecond)
+			}
+		}()
+
+		err = migrations.OnlineTableChange{
+			Table:       "widgets",
+			Alterations: []string{"ADD COLUMN color text"},
+			BatchSize:   3,
+			BatchPause:  10 * time.Millisecond,
+		}.Run(db)
+		Expect(err).NotTo(HaveOccurred())
+
+		close(stop)
+		<-writing
+
+		var differences int
+		err = db.QueryRow(`
+			SELECT count(*) FROM (
+				(SELECT id, name, size FROM widgets EXCEPT SELECT id, name, size FROM widgets_expected)
+				UNION ALL
+				(SELECT id, name, size FROM widgets_expected EXCEPT SELECT id, name, size FROM widgets)
+			) d
+		`).Scan(&differences)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(differences).To(BeZero())
+	})
+
+	Context("when the table has a composite key", func() {
+		BeforeEach(func() {
+			_, err := db.Exec(`
+				CREATE TABLE parts (
+					kind text NOT NULL,
+					number integer NOT NULL,
+					weight integer,
+					PRIMARY KEY (kind, number)
+				);
+
+				INSERT INTO parts (kind, number, weight)
+				SELECT k, n, n FROM unnest(ARRAY['bolt', 'nut', 'washer']) k, generate_series(1, 5) n;
+			`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("copies it in batches by the key", func() {
+			err := migrations.OnlineTableChange{
+				Table:       "parts",
+				Alterations: []string{"ALTER COLUMN weight TYPE bigint"},
+				BatchSize:   4,
+			}.Run(db)
+			Expect(err).NotTo(HaveOccurred())
+
+			var count int
+			var sum int64
+			err = db.QueryRow(`SELECT count(*), sum(weight) FROM parts`).Scan(&count, &sum)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(15))
+			Expect(sum).To(Equal(int64(3 * 15)))
+		})
+	})
+
+	Context("when the table has no unique key", func() {
+		BeforeEach(func() {
+			_, err := db.Exec(`CREATE TABLE logs (line text)`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("refuses to change it", func() {
+			err := migrations.OnlineTableChange{
+				Table:       "logs",
+				Alterations: []string{"ADD COLUMN time timestamp"},
+			}.Run(db)
+			Expect(err).To(Equal(migrations.ErrTableHasNoKey))
+		})
+	})
+
+	Context("when the table is referenced by a foreign key", func() {
+		BeforeEach(func() {
+			_, err := db.Exec(`
+				CREATE TABLE gadgets (widget_id integer CONSTRAINT gadgets_widget_fkey REFERENCES widgets (id) ON DELETE CASCADE);
+				INSERT INTO gadgets (widget_id) VALUES (1), (2);
+			`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("moves the foreign key over to the altered table", func() {
+			err := migrations.OnlineTableChange{
+				Table:       "widgets",
+				Alterations: []string{"ADD COLUMN color text"},
+			}.Run(db)
+			Expect(err).NotTo(HaveOccurred())
+
+			var validated bool
+			err = db.QueryRow(`
+				SELECT convalidated FROM pg_constraint
+				WHERE conname = 'gadgets_widget_fkey' AND confrelid = 'widgets'::regclass
+			`).Scan(&validated)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(validated).To(BeTrue())
+
+			_, err = db.Exec(`INSERT INTO gadgets (widget_id) VALUES (10)`)
+			Expect(err).To(HaveOccurred())
+
+			_, err = db.Exec(`DELETE FROM widgets WHERE id = 1`)
+			Expect(err).NotTo(HaveOccurred())
+
+			var count int
+			err = db.QueryRow(`SELECT count(*) FROM gadgets`).Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+	})
+
+	Context("when the table references itself", func() {
+		BeforeEach(func() {
+			_, err := db.Exec(`ALTER TABLE widgets ADD COLUMN parent_id integer REFERENCES widgets (id)`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("refuses to change it", func() {
+			err := migrations.OnlineTableChange{
+				Table:       "widgets",
+				Alterations: []string{"ADD COLUMN color text"},
+			}.Run(db)
+			Expect(err).To(Equal(migrations.ErrTableReferencesItself))
+		})
+	})
+})
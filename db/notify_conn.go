@@ -0,0 +1,29 @@
+package db
+
+// NotifyThrough returns a Conn which sends and listens for notifications, such
+// as of new build events, on the given bus rather than its own.
+func NotifyThrough(conn Conn, bus NotificationsBus) Conn {
+	return &notifyConn{
+		Conn: conn,
+		bus:  bus,
+	}
+}
+
+type notifyConn struct {
+	Conn
+
+	bus NotificationsBus
+}
+
+func (c *notifyConn) Bus() NotificationsBus {
+	return c.bus
+}
+
+func (c *notifyConn) Close() error {
+	err := c.bus.Close()
+	if err != nil {
+		return err
+	}
+
+	return c.Conn.Close()
+}
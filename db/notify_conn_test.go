@@ -0,0 +1,42 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotifyThrough", func() {
+	var (
+		fakeConn *dbfakes.FakeConn
+		bus      db.NotificationsBus
+
+		conn db.Conn
+	)
+
+	BeforeEach(func() {
+		fakeConn = new(dbfakes.FakeConn)
+		bus = db.NewPollingNotificationsBus(time.Hour)
+
+		conn = db.NotifyThrough(fakeConn, bus)
+	})
+
+	It("notifies through the given bus", func() {
+		Expect(conn.Bus()).To(BeIdenticalTo(bus))
+		Expect(fakeConn.BusCallCount()).To(BeZero())
+	})
+
+	It("closes the bus along with the connection", func() {
+		notify, err := conn.Bus().Listen("some-channel")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(conn.Close()).To(Succeed())
+		Expect(fakeConn.CloseCallCount()).To(Equal(1))
+
+		Expect(bus.Unlisten("some-channel", notify)).To(Succeed())
+	})
+})
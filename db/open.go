@@ -12,7 +12,9 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db/compression"
 	"github.com/concourse/atc/db/encryption"
+	"github.com/concourse/atc/db/eventarchive"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/migration"
 	multierror "github.com/hashicorp/go-multierror"
@@ -24,6 +26,9 @@ import (
 type Conn interface {
 	Bus() NotificationsBus
 	EncryptionStrategy() encryption.Strategy
+	EventCodec() compression.Codec
+	EventArchive() eventarchive.Store
+	Replica(maxStaleness time.Duration) Runner
 
 	Ping() error
 	Driver() driver.Driver
@@ -89,6 +94,11 @@ func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *e
 			if err != nil {
 				return nil, err
 			}
+
+			err = encryptWithTeamKeys(logger.Session("encrypt-with-team-keys"), sqlDb, newKey, oldKey)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		listener := pq.NewListener(sqlDataSource, time.Second, time.Minute, nil)
@@ -103,6 +113,51 @@ func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *e
 	}
 }
 
+// OpenReadOnly connects to a database that this ATC must not write to, e.g.
+// a hot standby replicating from the primary's database. Unlike Open, it
+// neither migrates the database nor encrypts or rotates the encryption of its
+// data; both are left to the primary, which must be running the same version.
+//
+// Hot standbys do not support LISTEN, so the returned connection's
+// NotificationsBus notifies listeners on an interval instead.
+func OpenReadOnly(logger lager.Logger, sqlDriver string, sqlDataSource string, key *encryption.Key, connectionName string) (Conn, error) {
+	var strategy encryption.Strategy
+	if key != nil {
+		strategy = key
+	} else {
+		strategy = encryption.NewNoEncryption()
+	}
+
+	sqlDb, err := sql.Open(sqlDriver, sqlDataSource)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		err := sqlDb.Ping()
+		if err != nil {
+			if shouldRetry(err) {
+				logger.Error("failed-to-open-db-retrying", err)
+				time.Sleep(5 * time.Second)
+				continue
+			}
+
+			_ = sqlDb.Close()
+			return nil, err
+		}
+
+		return &db{
+			DB: sqlDb,
+
+			bus:        NewPollingNotificationsBus(readOnlyPollingInterval),
+			encryption: strategy,
+			name:       connectionName,
+		}, nil
+	}
+}
+
+const readOnlyPollingInterval = time.Second
+
 func shouldRetry(err error) bool {
 	if strings.Contains(err.Error(), "dial ") {
 		return true
@@ -115,12 +170,12 @@ func shouldRetry(err error) bool {
 	return false
 }
 
+// encryptedColumns are encrypted with the master key. The configs of each
+// team's pipelines are encrypted with the team's data key instead; see
+// teamKeyedColumns.
 var encryptedColumns = map[string]string{
-	"teams":          "auth",
-	"resources":      "config",
-	"jobs":           "config",
-	"resource_types": "config",
-	"builds":         "engine_metadata",
+	"teams":  "auth",
+	"builds": "engine_metadata",
 }
 
 func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *encryption.Key) error {
@@ -248,7 +303,7 @@ func decryptToPlaintext(logger lager.Logger, sqlDB *sql.DB, oldKey *encryption.K
 		}
 	}
 
-	return nil
+	return decryptTeamConfigsToPlaintext(logger, sqlDB, oldKey)
 }
 
 var ErrEncryptedWithUnknownKey = errors.New("row encrypted with neither old nor new key")
@@ -324,7 +379,7 @@ func encryptWithNewKey(logger lager.Logger, sqlDB *sql.DB, newKey *encryption.Ke
 		}
 	}
 
-	return nil
+	return rewrapTeamDataKeys(logger, sqlDB, newKey, oldKey)
 }
 
 type db struct {
@@ -347,6 +402,18 @@ func (db *db) EncryptionStrategy() encryption.Strategy {
 	return db.encryption
 }
 
+func (db *db) EventCodec() compression.Codec {
+	return nil
+}
+
+func (db *db) EventArchive() eventarchive.Store {
+	return nil
+}
+
+func (db *db) Replica(time.Duration) Runner {
+	return nil
+}
+
 func (db *db) Close() error {
 	var errs error
 	dbErr := db.DB.Close()
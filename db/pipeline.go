@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -14,6 +15,8 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
+	"github.com/lib/pq"
 )
 
 type ErrResourceNotFound struct {
@@ -24,6 +27,17 @@ func (e ErrResourceNotFound) Error() string {
 	return fmt.Sprintf("resource '%s' not found", e.Name)
 }
 
+type ErrJobNotFound struct {
+	Name string
+}
+
+func (e ErrJobNotFound) Error() string {
+	return fmt.Sprintf("job '%s' not found", e.Name)
+}
+
+var ErrPipelineNameInUse = errors.New("another pipeline of the team has the same name")
+var ErrPipelineNotArchived = errors.New("pipeline is not archived")
+
 //go:generate counterfeiter . Pipeline
 
 type Cause struct {
@@ -31,17 +45,46 @@ type Cause struct {
 	BuildID             int `json:"build_id"`
 }
 
+// BuildCalendarInterval is the period builds are grouped by in a calendar.
+type BuildCalendarInterval string
+
+const (
+	BuildCalendarHourly BuildCalendarInterval = "hour"
+	BuildCalendarDaily  BuildCalendarInterval = "day"
+)
+
+// BuildCalendarEntry counts the builds of a job started within one interval,
+// and the total time spent running those that have finished.
+type BuildCalendarEntry struct {
+	JobName string
+	Start   time.Time
+
+	Builds    int
+	Succeeded int
+	Failed    int
+	Errored   int
+	Aborted   int
+
+	Duration time.Duration
+}
+
 type Pipeline interface {
 	ID() int
 	Name() string
 	TeamID() int
 	TeamName() string
 	Groups() atc.GroupConfigs
+	Labels() atc.Labels
+	BuildLogRetention() atc.BuildLogRetention
 	ConfigVersion() ConfigVersion
 	Public() bool
 	Paused() bool
+	ArchivedAt() time.Time
 	ScopedName(string) string
 
+	ConfigRevision(id int) (ConfigRevision, bool, error)
+	ConfigRevisions(page Page) ([]ConfigRevision, Pagination, error)
+
 	CheckPaused() (bool, error)
 	Reload() (bool, error)
 
@@ -50,6 +93,7 @@ type Pipeline interface {
 	SetResourceCheckError(Resource, error) error
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
 	GetResourceVersions(resourceName string, page Page) ([]SavedVersionedResource, Pagination, bool, error)
+	SearchResourceVersions(ResourceVersionSearch) ([]SavedVersionedResource, error)
 
 	GetAllPendingBuilds() (map[string][]Build, error)
 
@@ -57,11 +101,15 @@ type Pipeline interface {
 	GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (SavedVersionedResource, bool, error)
 
 	VersionedResource(versionedResourceID int) (SavedVersionedResource, bool, error)
-	DisableVersionedResource(versionedResourceID int) error
-	EnableVersionedResource(versionedResourceID int) error
+	DisableVersionedResource(versionedResourceID int, from ConfigVersion) (ConfigVersion, error)
+	EnableVersionedResource(versionedResourceID int, from ConfigVersion) (ConfigVersion, error)
+	AnnotateVersionedResource(versionedResourceID int, annotation VersionAnnotation, from ConfigVersion) (ConfigVersion, bool, error)
+	RemoveVersionedResourceAnnotation(versionedResourceID int, name string, from ConfigVersion) (ConfigVersion, bool, error)
 	GetBuildsWithVersionAsInput(versionedResourceID int) ([]Build, error)
+	GetBuildsWithVersionsAsInput(versionedResourceIDs []int) (map[int][]Build, error)
 	GetBuildsWithVersionAsOutput(versionedResourceID int) ([]Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
+	BuildCalendar(from time.Time, to time.Time, interval BuildCalendarInterval, location *time.Location) ([]BuildCalendarEntry, error)
 
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 
@@ -75,6 +123,16 @@ type Pipeline interface {
 		immediate bool,
 	) (lock.Lock, bool, error)
 
+	AcquireResourceConfigCheckingLockWithIntervalCheck(
+		logger lager.Logger,
+		usedResourceConfig *UsedResourceConfig,
+		interval time.Duration,
+		immediate bool,
+	) (lock.Lock, bool, error)
+
+	SaveResourceConfigVersions(*UsedResourceConfig, []atc.Version) error
+	LatestResourceConfigVersion(*UsedResourceConfig) (atc.Version, bool, error)
+
 	AcquireResourceTypeCheckingLockWithIntervalCheck(
 		logger lager.Logger,
 		resourceTypeName string,
@@ -93,18 +151,24 @@ type Pipeline interface {
 
 	Job(name string) (Job, bool, error)
 	Jobs() (Jobs, error)
+	PendingJobsAwaitingInputs() (map[string][]string, error)
 	Dashboard(include string) (Dashboard, error)
 
-	Expose() error
-	Hide() error
+	Snapshot() (atc.PipelineSnapshot, error)
 
-	Pause() error
-	Unpause() error
+	Expose(from ConfigVersion) (ConfigVersion, error)
+	Hide(from ConfigVersion) (ConfigVersion, error)
 
+	Pause(from ConfigVersion) (ConfigVersion, error)
+	Unpause(from ConfigVersion) (ConfigVersion, error)
+
+	Archive(from ConfigVersion) (ConfigVersion, error)
+	Restore(from ConfigVersion) (ConfigVersion, error)
 	Destroy() error
-	Rename(string) error
+	DestroyIfArchivedBefore(time.Time) (bool, error)
+	Rename(name string, from ConfigVersion) (ConfigVersion, error)
 
-	CreateOneOffBuild() (Build, error)
+	CreateOneOffBuild(cause BuildTriggerCause) (Build, error)
 }
 
 type pipeline struct {
@@ -113,9 +177,12 @@ type pipeline struct {
 	teamID        int
 	teamName      string
 	groups        atc.GroupConfigs
+	labels        atc.Labels
+	retention     atc.BuildLogRetention
 	configVersion ConfigVersion
 	paused        bool
 	public        bool
+	archivedAt    time.Time
 
 	cachedAt   time.Time
 	versionsDB *algorithm.VersionsDB
@@ -137,11 +204,18 @@ var pipelinesQuery = psql.Select(`
 		p.team_id,
 		t.name,
 		p.paused,
-		p.public
+		p.public,
+		p.labels,
+		p.build_log_retention,
+		p.archived_at
 	`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
 
+// activePipelinesQuery leaves out archived pipelines, which are only loaded
+// by their ID, e.g. for their builds, or in order to restore or purge them.
+var activePipelinesQuery = pipelinesQuery.Where(sq.Eq{"p.archived_at": nil})
+
 const (
 	PipelinePaused   PipelinePausedState = "paused"
 	PipelineUnpaused PipelinePausedState = "unpaused"
@@ -176,10 +250,17 @@ func (p *pipeline) Name() string                 { return p.name }
 func (p *pipeline) TeamID() int                  { return p.teamID }
 func (p *pipeline) TeamName() string             { return p.teamName }
 func (p *pipeline) Groups() atc.GroupConfigs     { return p.groups }
+func (p *pipeline) Labels() atc.Labels           { return p.labels }
 func (p *pipeline) ConfigVersion() ConfigVersion { return p.configVersion }
 func (p *pipeline) Public() bool                 { return p.public }
 func (p *pipeline) Paused() bool                 { return p.paused }
 
+func (p *pipeline) BuildLogRetention() atc.BuildLogRetention { return p.retention }
+
+// ArchivedAt is when the pipeline was archived, or the zero time if it is
+// not archived.
+func (p *pipeline) ArchivedAt() time.Time { return p.archivedAt }
+
 func (p *pipeline) ScopedName(n string) string {
 	return p.name + ":" + n
 }
@@ -304,6 +385,11 @@ func (p *pipeline) CreateJobBuild(jobName string) (Build, error) {
 		return nil, err
 	}
 
+	err = updateDashboardBuilds(tx, jobID, buildID)
+	if err != nil {
+		return nil, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -364,6 +450,11 @@ func (p *pipeline) GetAllPendingBuilds() (map[string][]Build, error) {
 }
 
 func (p *pipeline) SaveResourceVersions(config atc.ResourceConfig, versions []atc.Version) error {
+	batch, err := newVersionBatch(versions)
+	if err != nil {
+		return err
+	}
+
 	tx, err := p.conn.Begin()
 	if err != nil {
 		return err
@@ -371,38 +462,20 @@ func (p *pipeline) SaveResourceVersions(config atc.ResourceConfig, versions []at
 
 	defer Rollback(tx)
 
-	for _, version := range versions {
-		vr := VersionedResource{
-			Resource: config.Name,
-			Type:     config.Type,
-			Version:  ResourceVersion(version),
-		}
-
-		versionJSON, err := json.Marshal(vr.Version)
-		if err != nil {
-			return err
-		}
-
-		var resourceID int
-		err = psql.Select("id").
-			From("resources").
-			Where(sq.Eq{
-				"name":        vr.Resource,
-				"pipeline_id": p.id,
-			}).RunWith(tx).QueryRow().Scan(&resourceID)
-		if err != nil {
-			return err
-		}
-
-		_, _, err = p.saveVersionedResource(tx, resourceID, vr)
-		if err != nil {
-			return err
-		}
+	var resourceID int
+	err = psql.Select("id").
+		From("resources").
+		Where(sq.Eq{
+			"name":        config.Name,
+			"pipeline_id": p.id,
+		}).RunWith(tx).QueryRow().Scan(&resourceID)
+	if err != nil {
+		return err
+	}
 
-		err = p.incrementCheckOrderWhenNewerVersion(tx, resourceID, vr.Type, string(versionJSON))
-		if err != nil {
-			return err
-		}
+	err = saveVersions(tx, resourceID, config.Type, batch)
+	if err != nil {
+		return err
 	}
 
 	return tx.Commit()
@@ -432,9 +505,11 @@ func (p *pipeline) GetResourceVersions(resourceName string, page Page) ([]SavedV
 		WHERE v.resource_id = $1
 	`
 
+	runner := reader(p.conn, resourceVersionStaleness)
+
 	var rows *sql.Rows
 	if page.Until != 0 {
-		rows, err = p.conn.Query(fmt.Sprintf(`
+		rows, err = runner.Query(fmt.Sprintf(`
 			SELECT sub.*
 				FROM (
 						%s
@@ -448,7 +523,7 @@ func (p *pipeline) GetResourceVersions(resourceName string, page Page) ([]SavedV
 			return nil, Pagination{}, false, err
 		}
 	} else if page.Since != 0 {
-		rows, err = p.conn.Query(fmt.Sprintf(`
+		rows, err = runner.Query(fmt.Sprintf(`
 			%s
 				AND v.check_order < (SELECT check_order FROM versioned_resources WHERE id = $2)
 			ORDER BY v.check_order DESC
@@ -458,7 +533,7 @@ func (p *pipeline) GetResourceVersions(resourceName string, page Page) ([]SavedV
 			return nil, Pagination{}, false, err
 		}
 	} else if page.To != 0 {
-		rows, err = p.conn.Query(fmt.Sprintf(`
+		rows, err = runner.Query(fmt.Sprintf(`
 			SELECT sub.*
 				FROM (
 						%s
@@ -472,7 +547,7 @@ func (p *pipeline) GetResourceVersions(resourceName string, page Page) ([]SavedV
 			return nil, Pagination{}, false, err
 		}
 	} else if page.From != 0 {
-		rows, err = p.conn.Query(fmt.Sprintf(`
+		rows, err = runner.Query(fmt.Sprintf(`
 			%s
 				AND v.check_order <= (SELECT check_order FROM versioned_resources WHERE id = $2)
 			ORDER BY v.check_order DESC
@@ -482,7 +557,7 @@ func (p *pipeline) GetResourceVersions(resourceName string, page Page) ([]SavedV
 			return nil, Pagination{}, false, err
 		}
 	} else {
-		rows, err = p.conn.Query(fmt.Sprintf(`
+		rows, err = runner.Query(fmt.Sprintf(`
 			%s
 			ORDER BY v.check_order DESC
 			LIMIT $2
@@ -530,10 +605,24 @@ func (p *pipeline) GetResourceVersions(resourceName string, page Page) ([]SavedV
 		return []SavedVersionedResource{}, Pagination{}, true, nil
 	}
 
+	versionedResourceIDs := make([]int, len(savedVersionedResources))
+	for i, svr := range savedVersionedResources {
+		versionedResourceIDs[i] = svr.ID
+	}
+
+	annotations, err := versionAnnotations(runner, versionedResourceIDs)
+	if err != nil {
+		return nil, Pagination{}, false, err
+	}
+
+	for i, svr := range savedVersionedResources {
+		savedVersionedResources[i].Annotations = annotations[svr.ID]
+	}
+
 	var minCheckOrder int
 	var maxCheckOrder int
 
-	err = p.conn.QueryRow(`
+	err = runner.QueryRow(`
 		SELECT COALESCE(MAX(v.check_order), 0) as maxCheckOrder,
 			COALESCE(MIN(v.check_order), 0) as minCheckOrder
 		FROM versioned_resources v
@@ -685,15 +774,127 @@ func (p *pipeline) VersionedResource(versionedResourceID int) (SavedVersionedRes
 		return SavedVersionedResource{}, false, err
 	}
 
+	annotations, err := versionAnnotations(p.conn, []int{svr.ID})
+	if err != nil {
+		return SavedVersionedResource{}, false, err
+	}
+
+	svr.Annotations = annotations[svr.ID]
+
 	return svr, true, nil
 }
 
-func (p *pipeline) DisableVersionedResource(versionedResourceID int) error {
-	return p.toggleVersionedResource(versionedResourceID, false)
+func (p *pipeline) DisableVersionedResource(versionedResourceID int, from ConfigVersion) (ConfigVersion, error) {
+	return p.toggleVersionedResource(versionedResourceID, false, from)
+}
+
+func (p *pipeline) EnableVersionedResource(versionedResourceID int, from ConfigVersion) (ConfigVersion, error) {
+	return p.toggleVersionedResource(versionedResourceID, true, from)
+}
+
+// errVersionedResourceNotFound aborts a change to a versioned resource which
+// does not belong to the pipeline.
+var errVersionedResourceNotFound = errors.New("versioned resource not found")
+
+func (p *pipeline) AnnotateVersionedResource(versionedResourceID int, annotation VersionAnnotation, from ConfigVersion) (ConfigVersion, bool, error) {
+	version, err := compareAndSwap(p.conn, p.id, from, func(tx Tx) error {
+		found, err := p.ownsVersionedResource(tx, versionedResourceID)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return errVersionedResourceNotFound
+		}
+
+		result, err := psql.Update("versioned_resource_annotations").
+			Set("value", annotation.Value).
+			Set("url", annotation.URL).
+			Where(sq.Eq{
+				"versioned_resource_id": versionedResourceID,
+				"name":                  annotation.Name,
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			_, err = psql.Insert("versioned_resource_annotations").
+				Columns("versioned_resource_id", "name", "value", "url").
+				Values(versionedResourceID, annotation.Name, annotation.Value, annotation.URL).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return err
+			}
+		}
+
+		return touchVersionedResource(tx, versionedResourceID)
+	})
+	if err != nil {
+		if err == errVersionedResourceNotFound {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	p.configVersion = version
+
+	return version, true, nil
 }
 
-func (p *pipeline) EnableVersionedResource(versionedResourceID int) error {
-	return p.toggleVersionedResource(versionedResourceID, true)
+func (p *pipeline) RemoveVersionedResourceAnnotation(versionedResourceID int, name string, from ConfigVersion) (ConfigVersion, bool, error) {
+	version, err := compareAndSwap(p.conn, p.id, from, func(tx Tx) error {
+		found, err := p.ownsVersionedResource(tx, versionedResourceID)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return errVersionedResourceNotFound
+		}
+
+		result, err := psql.Delete("versioned_resource_annotations").
+			Where(sq.Eq{
+				"versioned_resource_id": versionedResourceID,
+				"name":                  name,
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return errVersionedResourceNotFound
+		}
+
+		return touchVersionedResource(tx, versionedResourceID)
+	})
+	if err != nil {
+		if err == errVersionedResourceNotFound {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	p.configVersion = version
+
+	return version, true, nil
 }
 
 func (p *pipeline) GetBuildsWithVersionAsInput(versionedResourceID int) ([]Build, error) {
@@ -722,6 +923,87 @@ func (p *pipeline) GetBuildsWithVersionAsInput(versionedResourceID int) ([]Build
 	return builds, err
 }
 
+// GetBuildsWithVersionsAsInput returns the builds each of the versioned
+// resources was an input to, keyed by versioned resource ID, with the same
+// two queries however many versions are given.
+func (p *pipeline) GetBuildsWithVersionsAsInput(versionedResourceIDs []int) (map[int][]Build, error) {
+	buildsByVersion := map[int][]Build{}
+	if len(versionedResourceIDs) == 0 {
+		return buildsByVersion, nil
+	}
+
+	rows, err := psql.Select("DISTINCT versioned_resource_id, build_id").
+		From("build_inputs").
+		Where(sq.Eq{"versioned_resource_id": versionedResourceIDs}).
+		OrderBy("build_id ASC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	buildIDsByVersion := map[int][]int{}
+	buildIDs := []int{}
+	for rows.Next() {
+		var versionedResourceID, buildID int
+		err = rows.Scan(&versionedResourceID, &buildID)
+		if err != nil {
+			return nil, err
+		}
+
+		buildIDsByVersion[versionedResourceID] = append(buildIDsByVersion[versionedResourceID], buildID)
+		buildIDs = append(buildIDs, buildID)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buildIDs) == 0 {
+		return buildsByVersion, nil
+	}
+
+	buildRows, err := buildsQuery.
+		Where(sq.Eq{"b.id": buildIDs}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(buildRows)
+
+	builds := map[int]Build{}
+	for buildRows.Next() {
+		build := &build{conn: p.conn, lockFactory: p.lockFactory}
+		err = scanBuild(build, buildRows, p.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		builds[build.ID()] = build
+	}
+
+	err = buildRows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	for versionedResourceID, ids := range buildIDsByVersion {
+		for _, id := range ids {
+			build, found := builds[id]
+			if found {
+				buildsByVersion[versionedResourceID] = append(buildsByVersion[versionedResourceID], build)
+			}
+		}
+	}
+
+	return buildsByVersion, nil
+}
+
 func (p *pipeline) GetBuildsWithVersionAsOutput(versionedResourceID int) ([]Build, error) {
 	rows, err := buildsQuery.
 		JoinClause("LEFT OUTER JOIN build_outputs bo ON bo.build_id = b.id").
@@ -772,6 +1054,74 @@ func (p *pipeline) Builds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(buildsQuery.Where(sq.Eq{"b.pipeline_id": p.id}), page, p.conn, p.lockFactory)
 }
 
+// BuildCalendar counts the builds of each job started between from and to,
+// grouped by the hour or day they started in. Intervals begin at the start of
+// the hour or day in the given location, so that e.g. nightly builds fall on
+// the same day regardless of daylight saving time. Intervals without builds
+// are omitted.
+func (p *pipeline) BuildCalendar(from time.Time, to time.Time, interval BuildCalendarInterval, location *time.Location) ([]BuildCalendarEntry, error) {
+	rows, err := p.conn.Query(`
+		SELECT j.name,
+			date_trunc($1, b.start_time AT TIME ZONE $2) AS bucket,
+			COUNT(*),
+			COUNT(CASE WHEN b.status = 'succeeded' THEN 1 END),
+			COUNT(CASE WHEN b.status = 'failed' THEN 1 END),
+			COUNT(CASE WHEN b.status = 'errored' THEN 1 END),
+			COUNT(CASE WHEN b.status = 'aborted' THEN 1 END),
+			COALESCE(SUM(EXTRACT(EPOCH FROM b.end_time - b.start_time)), 0)
+		FROM builds b
+		INNER JOIN jobs j ON j.id = b.job_id
+		WHERE b.pipeline_id = $3
+		AND b.start_time >= $4
+		AND b.start_time < $5
+		GROUP BY j.name, bucket
+		ORDER BY bucket ASC, j.name ASC
+	`, string(interval), location.String(), p.id, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	entries := []BuildCalendarEntry{}
+	for rows.Next() {
+		var entry BuildCalendarEntry
+		var bucket time.Time
+		var seconds float64
+
+		err := rows.Scan(
+			&entry.JobName,
+			&bucket,
+			&entry.Builds,
+			&entry.Succeeded,
+			&entry.Failed,
+			&entry.Errored,
+			&entry.Aborted,
+			&seconds,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// the bucket is the wall clock time in the given location, which the
+		// driver returns as UTC
+		entry.Start = time.Date(
+			bucket.Year(),
+			bucket.Month(),
+			bucket.Day(),
+			bucket.Hour(),
+			0, 0, 0,
+			location,
+		)
+
+		entry.Duration = time.Duration(seconds * float64(time.Second))
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
 func (p *pipeline) Resources() (Resources, error) {
 	rows, err := resourcesQuery.Where(sq.Eq{"r.pipeline_id": p.id}).RunWith(p.conn).Query()
 	if err != nil {
@@ -795,7 +1145,7 @@ func (p *pipeline) Resources() (Resources, error) {
 }
 
 func (p *pipeline) ResourceTypes() (ResourceTypes, error) {
-	rows, err := resourceTypesQuery.Where(sq.Eq{"pipeline_id": p.id}).RunWith(p.conn).Query()
+	rows, err := resourceTypesQuery.Where(sq.Eq{"rt.pipeline_id": p.id}).RunWith(p.conn).Query()
 	if err != nil {
 		return nil, err
 	}
@@ -818,8 +1168,8 @@ func (p *pipeline) ResourceTypes() (ResourceTypes, error) {
 
 func (p *pipeline) ResourceType(name string) (ResourceType, bool, error) {
 	row := resourceTypesQuery.Where(sq.Eq{
-		"pipeline_id": p.id,
-		"name":        name,
+		"rt.pipeline_id": p.id,
+		"rt.name":        name,
 	}).RunWith(p.conn).QueryRow()
 
 	resourceType := &resourceType{conn: p.conn}
@@ -873,151 +1223,507 @@ func (p *pipeline) Jobs() (Jobs, error) {
 	return jobs, err
 }
 
-func (p *pipeline) Dashboard(include string) (Dashboard, error) {
-	dashboard := Dashboard{}
-
-	rows, err := jobsQuery.
+// PendingJobsAwaitingInputs returns the jobs with a pending build whose next
+// inputs have not been determined, each with the names of its inputs that
+// have a version available when passed constraints are not considered.
+func (p *pipeline) PendingJobsAwaitingInputs() (map[string][]string, error) {
+	rows, err := psql.Select("j.name, i.input_name").
+		From("jobs j").
+		LeftJoin("independent_build_inputs i ON i.job_id = j.id").
 		Where(sq.Eq{
-			"pipeline_id": p.id,
-			"active":      true,
+			"j.pipeline_id":       p.id,
+			"j.active":            true,
+			"j.inputs_determined": false,
 		}).
-		OrderBy("j.id ASC").
+		Where("EXISTS (SELECT 1 FROM builds b WHERE b.job_id = j.id AND b.status = ?)", string(BuildStatusPending)).
 		RunWith(p.conn).
 		Query()
 	if err != nil {
 		return nil, err
 	}
 
-	jobs, err := scanJobs(p.conn, p.lockFactory, rows)
-	if err != nil {
-		return nil, err
-	}
-
-	nextBuilds, err := p.getBuildsFrom("next_builds_per_job")
-	if err != nil {
-		return nil, err
-	}
-
-	finishedBuilds, err := p.getBuildsFrom("latest_completed_builds_per_job")
-	if err != nil {
-		return nil, err
-	}
-
-	var transitionBuilds map[string]Build
+	defer Close(rows)
 
-	if include == "transitionBuilds" {
-		transitionBuilds, err = p.getBuildsFrom("transition_builds_per_job")
+	jobInputs := map[string][]string{}
+	for rows.Next() {
+		var jobName string
+		var inputName sql.NullString
+		err = rows.Scan(&jobName, &inputName)
 		if err != nil {
 			return nil, err
 		}
-	}
-
-	for _, job := range jobs {
-		dashboardJob := DashboardJob{
-			Job: job,
-		}
-
-		if nextBuild, found := nextBuilds[job.Name()]; found {
-			dashboardJob.NextBuild = nextBuild
-		}
 
-		if finishedBuild, found := finishedBuilds[job.Name()]; found {
-			dashboardJob.FinishedBuild = finishedBuild
+		inputs := jobInputs[jobName]
+		if inputName.Valid {
+			inputs = append(inputs, inputName.String)
 		}
 
-		if transitionBuild, found := transitionBuilds[job.Name()]; found {
-			dashboardJob.TransitionBuild = transitionBuild
-		}
+		jobInputs[jobName] = inputs
+	}
 
-		dashboard = append(dashboard, dashboardJob)
+	err = rows.Err()
+	if err != nil {
+		return nil, err
 	}
 
-	return dashboard, nil
+	return jobInputs, nil
 }
 
-func (p *pipeline) Pause() error {
-	_, err := psql.Update("pipelines").
-		Set("paused", true).
+func (p *pipeline) Dashboard(include string) (Dashboard, error) {
+	runner := reader(p.conn, dashboardStaleness)
+
+	rows, err := dashboardQuery.
 		Where(sq.Eq{
-			"id": p.id,
+			"pipeline_id": p.id,
+			"active":      true,
 		}).
-		RunWith(p.conn).
-		Exec()
+		OrderBy("j.id ASC").
+		RunWith(runner).
+		Query()
+	if err != nil {
+		return nil, err
+	}
 
-	return err
+	return scanDashboard(p.conn, p.lockFactory, runner, rows, include == "transitionBuilds")
 }
 
-func (p *pipeline) Unpause() error {
-	_, err := psql.Update("pipelines").
-		Set("paused", false).
-		Where(sq.Eq{
-			"id": p.id,
-		}).
-		RunWith(p.conn).
-		Exec()
+func (p *pipeline) Pause(from ConfigVersion) (ConfigVersion, error) {
+	return p.update(from, "paused", true)
+}
 
-	return err
+func (p *pipeline) Unpause(from ConfigVersion) (ConfigVersion, error) {
+	return p.update(from, "paused", false)
 }
 
-func (p *pipeline) Hide() error {
-	_, err := psql.Update("pipelines").
-		Set("public", false).
-		Where(sq.Eq{
-			"id": p.id,
-		}).
-		RunWith(p.conn).
-		Exec()
+func (p *pipeline) Hide(from ConfigVersion) (ConfigVersion, error) {
+	return p.update(from, "public", false)
+}
 
-	return err
+func (p *pipeline) Expose(from ConfigVersion) (ConfigVersion, error) {
+	return p.update(from, "public", true)
 }
 
-func (p *pipeline) Expose() error {
-	_, err := psql.Update("pipelines").
-		Set("public", true).
-		Where(sq.Eq{
-			"id": p.id,
-		}).
-		RunWith(p.conn).
-		Exec()
+func (p *pipeline) Rename(name string, from ConfigVersion) (ConfigVersion, error) {
+	return p.update(from, "name", name)
+}
 
-	return err
+// Archive hides the pipeline in place of destroying it, so that it can still
+// be restored. It is paused, so that it is no longer checked or scheduled,
+// and left out of every list of pipelines and jobs, and its name is free to
+// be used by another pipeline. Its builds are kept, but those still pending
+// or running are aborted. Archiving a pipeline which is already archived
+// changes nothing, and returns the version it is at.
+func (p *pipeline) Archive(from ConfigVersion) (ConfigVersion, error) {
+	var (
+		archivedAt time.Time
+		aborted    []int
+	)
+
+	version, err := compareAndSwap(p.conn, p.id, from, func(tx Tx) error {
+		err := psql.Update("pipelines").
+			Set("archived_at", sq.Expr("now()")).
+			Set("paused", true).
+			Where(sq.Eq{
+				"id":          p.id,
+				"archived_at": nil,
+			}).
+			Suffix("RETURNING archived_at").
+			RunWith(tx).
+			QueryRow().
+			Scan(&archivedAt)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errPipelineAlreadyArchived
+			}
+
+			return err
+		}
+
+		aborted, err = p.abortRunningBuilds(tx, BuildAbortCause{Reason: "pipeline archived"})
+		return err
+	})
+	if err != nil {
+		if err == errPipelineAlreadyArchived {
+			return p.configVersion, nil
+		}
+
+		if err == ErrConfigComparisonFailed && from == AnyConfigVersion {
+			// the pipeline no longer exists
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	p.archivedAt = archivedAt
+	p.paused = true
+	p.configVersion = version
+
+	for _, buildID := range aborted {
+		err = p.conn.Bus().Notify(buildEventsChannel(buildID))
+		if err != nil {
+			return 0, err
+		}
+
+		err = p.conn.Bus().Notify(buildAbortChannel(buildID))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return version, nil
 }
 
-func (p *pipeline) Rename(name string) error {
-	_, err := psql.Update("pipelines").
-		Set("name", name).
+// errPipelineAlreadyArchived aborts archiving a pipeline which is already
+// archived.
+var errPipelineAlreadyArchived = errors.New("pipeline already archived")
+
+// abortRunningBuilds marks the pipeline's pending and started builds as
+// aborted. Builds which an engine has started are left for it to finish once
+// it is notified; those no engine has started yet are finished right away,
+// as nothing else would.
+func (p *pipeline) abortRunningBuilds(tx Tx, cause BuildAbortCause) ([]int, error) {
+	abortCause, err := cause.value()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := psql.Update("builds").
+		Set("status", string(BuildStatusAborted)).
+		Set("abort_cause", abortCause).
 		Where(sq.Eq{
-			"id": p.id,
+			"pipeline_id": p.id,
+			"status":      []string{string(BuildStatusPending), string(BuildStatusStarted)},
 		}).
-		RunWith(p.conn).
-		Exec()
+		Suffix("RETURNING id, job_id, engine, now()").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
 
-	return err
+	defer Close(rows)
+
+	var abortedAt time.Time
+
+	builds := []*build{}
+	unstarted := map[int]bool{}
+	for rows.Next() {
+		var (
+			buildID int
+			jobID   sql.NullInt64
+			engine  sql.NullString
+		)
+
+		err = rows.Scan(&buildID, &jobID, &engine, &abortedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		builds = append(builds, &build{
+			id:         buildID,
+			jobID:      int(jobID.Int64),
+			pipelineID: p.id,
+			teamID:     p.teamID,
+			conn:       p.conn,
+		})
+
+		unstarted[buildID] = engine.String == ""
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	aborted := []int{}
+	for _, b := range builds {
+		err = b.saveEvent(tx, event.AbortRequested{
+			Time:      abortedAt.Unix(),
+			Requester: cause.Requester,
+			Reason:    cause.Reason,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if unstarted[b.id] {
+			_, err = psql.Update("builds").
+				Set("end_time", abortedAt).
+				Set("completed", true).
+				Set("nonce", nil).
+				Where(sq.Eq{"id": b.id}).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, err
+			}
+
+			err = b.saveEvent(tx, event.Status{
+				Status: atc.StatusAborted,
+				Time:   abortedAt.Unix(),
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = tx.Exec(fmt.Sprintf(`
+				DROP SEQUENCE %s
+			`, buildEventSeq(b.id)))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if b.jobID != 0 {
+			err = updateDashboardBuilds(tx, b.jobID, b.id)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		aborted = append(aborted, b.id)
+	}
+
+	return aborted, nil
+}
+
+// Restore brings an archived pipeline back, still paused. It fails with
+// ErrPipelineNameInUse if another pipeline of the team has since been given
+// its name, and with ErrPipelineNotArchived if the pipeline is not archived,
+// or no longer exists.
+func (p *pipeline) Restore(from ConfigVersion) (ConfigVersion, error) {
+	version, err := compareAndSwap(p.conn, p.id, from, func(tx Tx) error {
+		result, err := psql.Update("pipelines").
+			Set("archived_at", nil).
+			Where(sq.Eq{"id": p.id}).
+			Where(sq.NotEq{"archived_at": nil}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+				return ErrPipelineNameInUse
+			}
+
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return ErrPipelineNotArchived
+		}
+
+		return nil
+	})
+	if err != nil {
+		if err == ErrConfigComparisonFailed && from == AnyConfigVersion {
+			return 0, ErrPipelineNotArchived
+		}
+
+		return 0, err
+	}
+
+	p.archivedAt = time.Time{}
+	p.configVersion = version
+
+	return version, nil
 }
 
+// Destroy deletes the pipeline along with its jobs, resources and builds.
+// Builds still running are kept instead: they are moved to the team along
+// with their events and aborted. Those an engine is running are finished as
+// pipeline-destroyed once it has stopped them, writing any remaining events
+// to the team's table.
 func (p *pipeline) Destroy() error {
+	_, err := p.destroy(nil)
+	return err
+}
+
+// DestroyIfArchivedBefore destroys the pipeline only if it is still archived
+// and was archived before the given time, returning whether it was. The
+// pipeline is locked while it is destroyed, so that it cannot be restored in
+// the meantime.
+func (p *pipeline) DestroyIfArchivedBefore(archivedBefore time.Time) (bool, error) {
+	return p.destroy(sq.Lt{"archived_at": archivedBefore})
+}
+
+func (p *pipeline) destroy(condition sq.Sqlizer) (bool, error) {
 	tx, err := p.conn.Begin()
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	defer Rollback(tx)
 
+	query := psql.Select("id").
+		From("pipelines").
+		Where(sq.Eq{"id": p.id}).
+		Suffix("FOR UPDATE")
+
+	if condition != nil {
+		query = query.Where(condition)
+	}
+
+	var id int
+	err = query.RunWith(tx).QueryRow().Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	archived, err := p.archiveRunningBuilds(tx)
+	if err != nil {
+		return false, err
+	}
+
+	archivedEventIDs, err := archivedEventBuildIDs(tx, sq.Eq{"pipeline_id": p.id})
+	if err != nil {
+		return false, err
+	}
+
 	_, err = tx.Exec(fmt.Sprintf(`
 		DROP TABLE pipeline_build_events_%d
 	`, p.id))
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	_, err = tx.Exec(`
 		DELETE FROM pipelines WHERE id = $1;
 	`, p.id)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	err = deleteArchivedEvents(p.conn, archivedEventIDs)
+	if err != nil {
+		return true, err
+	}
+
+	for _, buildID := range archived {
+		err = p.conn.Bus().Notify(buildEventsChannel(buildID))
+		if err != nil {
+			return true, err
+		}
+
+		err = p.conn.Bus().Notify(buildAbortChannel(buildID))
+		if err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+// archiveRunningBuilds detaches the pipeline's pending and started builds from
+// it, recording the "pipeline/job" they ran in, and moves their events to the
+// team's table. They are made non-interceptible so that their containers are
+// garbage collected once the builds are aborted.
+//
+// Builds which no engine has started yet are finished right away. Those an
+// engine is running keep their event sequence, so that the engine can go on
+// saving events until it finishes them.
+func (p *pipeline) archiveRunningBuilds(tx Tx) ([]int, error) {
+	rows, err := psql.Update("builds b").
+		Set("status", string(BuildStatusPipelineDestroyed)).
+		Set("interceptible", false).
+		Set("archived_from", sq.Expr("? || COALESCE('/' || (SELECT j.name FROM jobs j WHERE j.id = b.job_id), '')", p.name)).
+		Set("job_id", nil).
+		Set("pipeline_id", nil).
+		Where(sq.Eq{
+			"b.pipeline_id": p.id,
+			"b.status":      []string{string(BuildStatusPending), string(BuildStatusStarted)},
+		}).
+		Suffix("RETURNING b.id, b.engine").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	archived := []int{}
+	unstarted := map[int]bool{}
+	for rows.Next() {
+		var (
+			buildID int
+			engine  sql.NullString
+		)
+
+		err = rows.Scan(&buildID, &engine)
+		if err != nil {
+			return nil, err
+		}
+
+		archived = append(archived, buildID)
+		unstarted[buildID] = engine.String == ""
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, buildID := range archived {
+		_, err = tx.Exec(fmt.Sprintf(`
+			INSERT INTO team_build_events_%d
+			SELECT * FROM pipeline_build_events_%d WHERE build_id = $1
+		`, p.teamID, p.id), buildID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !unstarted[buildID] {
+			continue
+		}
+
+		var endTime time.Time
+		err = psql.Update("builds").
+			Set("end_time", sq.Expr("now()")).
+			Set("completed", true).
+			Set("engine_metadata", nil).
+			Set("nonce", nil).
+			Where(sq.Eq{"id": buildID}).
+			Suffix("RETURNING end_time").
+			RunWith(tx).
+			QueryRow().
+			Scan(&endTime)
+		if err != nil {
+			return nil, err
+		}
+
+		build := &build{id: buildID, teamID: p.teamID, conn: p.conn}
+
+		err = build.saveEvent(tx, event.Status{
+			Status: atc.StatusPipelineDestroyed,
+			Time:   endTime.Unix(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`
+			DROP SEQUENCE %s
+		`, buildEventSeq(buildID)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return archived, nil
 }
 
 func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
@@ -1031,11 +1737,15 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	}
 
 	db := &algorithm.VersionsDB{
+		Generation: latestModifiedTime.UnixNano(),
+
 		BuildOutputs:     []algorithm.BuildOutput{},
 		BuildInputs:      []algorithm.BuildInput{},
 		ResourceVersions: []algorithm.ResourceVersion{},
 		JobIDs:           map[string]int{},
 		ResourceIDs:      map[string]int{},
+
+		VersionAnnotations: map[int]map[string]string{},
 	}
 
 	rows, err := psql.Select("v.id, v.check_order, r.id, o.build_id, b.job_id").
@@ -1177,6 +1887,34 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.ResourceIDs[name] = id
 	}
 
+	rows, err = psql.Select("a.versioned_resource_id, a.name, a.value").
+		From("versioned_resource_annotations a").
+		Join("versioned_resources v ON v.id = a.versioned_resource_id").
+		Join("resources r ON r.id = v.resource_id").
+		Where(sq.Eq{"r.pipeline_id": p.id}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var versionID int
+		var name, value string
+		err = rows.Scan(&versionID, &name, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		if db.VersionAnnotations[versionID] == nil {
+			db.VersionAnnotations[versionID] = map[string]string{}
+		}
+
+		db.VersionAnnotations[versionID][name] = value
+	}
+
 	p.versionsDB = db
 	p.cachedAt = latestModifiedTime
 
@@ -1205,6 +1943,11 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 
 	defer Rollback(tx)
 
+	archivedIDs, err := archivedEventBuildIDs(tx, sq.Eq{"id": buildIDs})
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(`
    DELETE FROM build_events
 	 WHERE build_id IN (`+strings.Join(indexStrings, ",")+`)
@@ -1213,9 +1956,11 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 		return err
 	}
 
+	// archived events are no longer read once the build is reaped, as for the
+	// events deleted from the database
 	_, err = tx.Exec(`
 		UPDATE builds
-		SET reap_time = now()
+		SET reap_time = now(), events_archived = false
 		WHERE id IN (`+strings.Join(indexStrings, ",")+`)
 	`, interfaceBuildIDs...)
 	if err != nil {
@@ -1223,7 +1968,11 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	}
 
 	err = tx.Commit()
-	return err
+	if err != nil {
+		return err
+	}
+
+	return deleteArchivedEvents(p.conn, archivedIDs)
 }
 
 func (p *pipeline) AcquireSchedulingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
@@ -1327,7 +2076,12 @@ func (p *pipeline) saveOutput(buildID int, vr VersionedResource) error {
 	return tx.Commit()
 }
 
-func (p *pipeline) CreateOneOffBuild() (Build, error) {
+func (p *pipeline) CreateOneOffBuild(cause BuildTriggerCause) (Build, error) {
+	triggerCause, err := cause.value()
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := p.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -1337,10 +2091,11 @@ func (p *pipeline) CreateOneOffBuild() (Build, error) {
 
 	build := &build{conn: p.conn, lockFactory: p.lockFactory}
 	err = createBuild(tx, build, map[string]interface{}{
-		"name":        sq.Expr("nextval('one_off_name')"),
-		"pipeline_id": p.id,
-		"team_id":     p.teamID,
-		"status":      BuildStatusPending,
+		"name":          sq.Expr("nextval('one_off_name')"),
+		"pipeline_id":   p.id,
+		"team_id":       p.teamID,
+		"status":        BuildStatusPending,
+		"trigger_cause": triggerCause,
 	})
 	if err != nil {
 		return nil, err
@@ -1465,6 +2220,13 @@ func (p *pipeline) saveVersionedResource(tx Tx, resourceID int, vr VersionedReso
 	}
 
 	created := rowsAffected != 0
+	if created || len(vr.Metadata) > 0 {
+		err = indexVersionedResourceFields(tx, id, vr.Version, vr.Metadata)
+		if err != nil {
+			return SavedVersionedResource{}, false, err
+		}
+	}
+
 	return SavedVersionedResource{
 		ID:           id,
 		Enabled:      enabled,
@@ -1494,27 +2256,88 @@ func (p *pipeline) incrementCheckOrderWhenNewerVersion(tx Tx, resourceID int, re
 	return err
 }
 
-func (p *pipeline) toggleVersionedResource(versionedResourceID int, enable bool) error {
-	rows, err := psql.Update("versioned_resources").
-		Set("enabled", enable).
-		Set("modified_time", sq.Expr("now()")).
-		Where(sq.Eq{"id": versionedResourceID}).
-		RunWith(p.conn).
-		Exec()
-	if err != nil {
+// update sets a column of the pipeline, moving it on to a new config
+// version.
+func (p *pipeline) update(from ConfigVersion, column string, value interface{}) (ConfigVersion, error) {
+	version, err := compareAndSwap(p.conn, p.id, from, func(tx Tx) error {
+		_, err := psql.Update("pipelines").
+			Set(column, value).
+			Where(sq.Eq{
+				"id": p.id,
+			}).
+			RunWith(tx).
+			Exec()
+
 		return err
+	})
+	if err != nil {
+		return 0, err
 	}
 
-	rowsAffected, err := rows.RowsAffected()
+	p.configVersion = version
+
+	return version, nil
+}
+
+func (p *pipeline) toggleVersionedResource(versionedResourceID int, enable bool, from ConfigVersion) (ConfigVersion, error) {
+	version, err := compareAndSwap(p.conn, p.id, from, func(tx Tx) error {
+		rows, err := psql.Update("versioned_resources").
+			Set("enabled", enable).
+			Set("modified_time", sq.Expr("now()")).
+			Where(sq.Eq{"id": versionedResourceID}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := rows.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected != 1 {
+			return nonOneRowAffectedError{rowsAffected}
+		}
+
+		return nil
+	})
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	if rowsAffected != 1 {
-		return nonOneRowAffectedError{rowsAffected}
+	p.configVersion = version
+
+	return version, nil
+}
+
+func (p *pipeline) ownsVersionedResource(tx Tx, versionedResourceID int) (bool, error) {
+	var exists bool
+	err := tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM versioned_resources v
+			JOIN resources r ON r.id = v.resource_id
+			WHERE v.id = $1
+			AND r.pipeline_id = $2
+		)
+	`, versionedResourceID, p.id).Scan(&exists)
+	if err != nil {
+		return false, err
 	}
 
-	return nil
+	return exists, nil
+}
+
+// touchVersionedResource bumps the version's modified time so that the
+// cached versions DB, which gates inputs on annotations, is reloaded.
+func touchVersionedResource(tx Tx, versionedResourceID int) error {
+	_, err := psql.Update("versioned_resources").
+		Set("modified_time", sq.Expr("now()")).
+		Where(sq.Eq{"id": versionedResourceID}).
+		RunWith(tx).
+		Exec()
+	return err
 }
 
 func (p *pipeline) getLatestModifiedTime() (time.Time, error) {
@@ -1551,31 +2374,6 @@ func (p *pipeline) getLatestModifiedTime() (time.Time, error) {
 	return maxModifiedTime, err
 }
 
-func (p *pipeline) getBuildsFrom(view string) (map[string]Build, error) {
-	rows, err := buildsQuery.
-		From(view + " b").
-		Where(sq.Eq{"b.pipeline_id": p.id}).
-		RunWith(p.conn).Query()
-	if err != nil {
-		return nil, err
-	}
-
-	defer Close(rows)
-
-	nextBuilds := make(map[string]Build)
-
-	for rows.Next() {
-		build := &build{conn: p.conn, lockFactory: p.lockFactory}
-		err := scanBuild(build, rows, p.conn.EncryptionStrategy())
-		if err != nil {
-			return nil, err
-		}
-		nextBuilds[build.JobName()] = build
-	}
-
-	return nextBuilds, nil
-}
-
 func getNewBuildNameForJob(tx Tx, jobName string, pipelineID int) (string, int, error) {
 	var buildName string
 	var jobID int
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc/db/lock"
 )
@@ -10,6 +12,7 @@ import (
 type PipelineFactory interface {
 	VisiblePipelines([]string) ([]Pipeline, error)
 	AllPipelines() ([]Pipeline, error)
+	ArchivedPipelines(archivedBefore time.Time) ([]Pipeline, error)
 }
 
 type pipelineFactory struct {
@@ -25,7 +28,7 @@ func NewPipelineFactory(conn Conn, lockFactory lock.LockFactory) PipelineFactory
 }
 
 func (f *pipelineFactory) VisiblePipelines(teamNames []string) ([]Pipeline, error) {
-	rows, err := pipelinesQuery.
+	rows, err := activePipelinesQuery.
 		Where(sq.Eq{"t.name": teamNames}).
 		OrderBy("team_id ASC", "ordering ASC").
 		RunWith(f.conn).
@@ -39,7 +42,7 @@ func (f *pipelineFactory) VisiblePipelines(teamNames []string) ([]Pipeline, erro
 		return nil, err
 	}
 
-	rows, err = pipelinesQuery.
+	rows, err = activePipelinesQuery.
 		Where(sq.NotEq{"t.name": teamNames}).
 		Where(sq.Eq{"public": true}).
 		OrderBy("team_id ASC", "ordering ASC").
@@ -58,7 +61,7 @@ func (f *pipelineFactory) VisiblePipelines(teamNames []string) ([]Pipeline, erro
 }
 
 func (f *pipelineFactory) AllPipelines() ([]Pipeline, error) {
-	rows, err := pipelinesQuery.
+	rows, err := activePipelinesQuery.
 		OrderBy("ordering").
 		RunWith(f.conn).
 		Query()
@@ -68,3 +71,18 @@ func (f *pipelineFactory) AllPipelines() ([]Pipeline, error) {
 
 	return scanPipelines(f.conn, f.lockFactory, rows)
 }
+
+// ArchivedPipelines returns every team's pipelines which were archived before
+// the given time.
+func (f *pipelineFactory) ArchivedPipelines(archivedBefore time.Time) ([]Pipeline, error) {
+	rows, err := pipelinesQuery.
+		Where(sq.Lt{"p.archived_at": archivedBefore}).
+		OrderBy("p.archived_at ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPipelines(f.conn, f.lockFactory, rows)
+}
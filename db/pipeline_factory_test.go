@@ -50,7 +50,7 @@ var _ = Describe("Pipeline Factory", func() {
 				},
 			}, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(pipeline3.Expose()).To(Succeed())
+			Expect(pipeline3.Expose(db.AnyConfigVersion)).ToNot(BeZero())
 			Expect(pipeline3.Reload()).To(BeTrue())
 		})
 
@@ -104,7 +104,7 @@ var _ = Describe("Pipeline Factory", func() {
 				},
 			}, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(pipeline1.Expose()).To(Succeed())
+			Expect(pipeline1.Expose(db.AnyConfigVersion)).ToNot(BeZero())
 			Expect(pipeline1.Reload()).To(BeTrue())
 
 			pipeline2, _, err = defaultTeam.SavePipeline("fake-pipeline-two", atc.Config{
@@ -121,7 +121,7 @@ var _ = Describe("Pipeline Factory", func() {
 				},
 			}, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(pipeline3.Expose()).To(Succeed())
+			Expect(pipeline3.Expose(db.AnyConfigVersion)).ToNot(BeZero())
 			Expect(pipeline3.Reload()).To(BeTrue())
 		})
 
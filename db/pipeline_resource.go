@@ -18,7 +18,8 @@ type SavedVersionedResource struct {
 	Enabled      bool
 	ModifiedTime time.Time
 	VersionedResource
-	CheckOrder int
+	CheckOrder  int
+	Annotations []VersionAnnotation
 }
 
 type SavedVersionedResources []SavedVersionedResource
@@ -62,6 +63,7 @@ type BuildInput struct {
 	VersionedResource
 
 	FirstOccurrence bool
+	Annotations     []VersionAnnotation
 }
 
 type BuildOutput struct {
@@ -0,0 +1,650 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// ErrSnapshotVersionNotFound is returned when importing a snapshot with a
+// build input or output which is not one of the snapshot's versions.
+type ErrSnapshotVersionNotFound struct {
+	Resource string
+	Version  atc.Version
+}
+
+func (e ErrSnapshotVersionNotFound) Error() string {
+	return fmt.Sprintf("version %v of resource '%s' not found in snapshot", e.Version, e.Resource)
+}
+
+// Snapshot exports the pipeline's config along with the versions of its
+// resources and the finished builds of its jobs, for
+// Team.ImportPipelineSnapshot to import.
+//
+// Everything is read in one repeatable read transaction, so that builds
+// finishing during the export can't refer to versions missing from it.
+func (p *pipeline) Snapshot() (atc.PipelineSnapshot, error) {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return atc.PipelineSnapshot{}, err
+	}
+
+	defer Rollback(tx)
+
+	_, err = tx.Exec(`SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY`)
+	if err != nil {
+		return atc.PipelineSnapshot{}, err
+	}
+
+	current := &pipeline{conn: p.conn, lockFactory: p.lockFactory}
+	err = scanPipeline(current, pipelinesQuery.Where(sq.Eq{"p.id": p.id}).RunWith(tx).QueryRow())
+	if err != nil {
+		return atc.PipelineSnapshot{}, err
+	}
+
+	jobs, err := current.snapshotJobs(tx)
+	if err != nil {
+		return atc.PipelineSnapshot{}, err
+	}
+
+	resources, err := current.snapshotResources(tx)
+	if err != nil {
+		return atc.PipelineSnapshot{}, err
+	}
+
+	resourceTypes, err := current.snapshotResourceTypes(tx)
+	if err != nil {
+		return atc.PipelineSnapshot{}, err
+	}
+
+	snapshot := atc.PipelineSnapshot{
+		Config: atc.Config{
+			Groups:        current.Groups(),
+			Resources:     resources.Configs(),
+			ResourceTypes: resourceTypes.Configs(),
+			Jobs:          jobs.Configs(),
+			Labels:        current.Labels(),
+		},
+		Paused:    current.Paused(),
+		Public:    current.Public(),
+		Resources: []atc.ResourceSnapshot{},
+		Jobs:      []atc.JobSnapshot{},
+	}
+
+	retention := current.BuildLogRetention()
+	if retention != (atc.BuildLogRetention{}) {
+		snapshot.Config.BuildLogRetention = &retention
+	}
+
+	// versions of a resource's previous types are left behind, and so are
+	// the builds' inputs and outputs of them
+	resourceTypeByName := map[string]string{}
+	for _, resource := range resources {
+		resourceTypeByName[resource.Name()] = resource.Type()
+
+		resourceSnapshot, err := current.snapshotResource(tx, resource)
+		if err != nil {
+			return atc.PipelineSnapshot{}, err
+		}
+
+		snapshot.Resources = append(snapshot.Resources, resourceSnapshot)
+	}
+
+	inputs, err := current.snapshotBuildVersions(
+		tx,
+		psql.Select("i.build_id", "i.name", "r.name", "v.type", "v.version").
+			From("build_inputs i").
+			Join("builds b ON b.id = i.build_id").
+			Join("versioned_resources v ON v.id = i.versioned_resource_id").
+			Join("resources r ON r.id = v.resource_id").
+			OrderBy("i.name ASC"),
+		resourceTypeByName,
+	)
+	if err != nil {
+		return atc.PipelineSnapshot{}, err
+	}
+
+	outputs, err := current.snapshotBuildVersions(
+		tx,
+		psql.Select("o.build_id", "''", "r.name", "v.type", "v.version").
+			From("build_outputs o").
+			Join("builds b ON b.id = o.build_id").
+			Join("versioned_resources v ON v.id = o.versioned_resource_id").
+			Join("resources r ON r.id = v.resource_id").
+			OrderBy("r.name ASC"),
+		resourceTypeByName,
+	)
+	if err != nil {
+		return atc.PipelineSnapshot{}, err
+	}
+
+	for _, job := range jobs {
+		jobSnapshot, err := current.snapshotJob(tx, job, inputs, outputs)
+		if err != nil {
+			return atc.PipelineSnapshot{}, err
+		}
+
+		snapshot.Jobs = append(snapshot.Jobs, jobSnapshot)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return atc.PipelineSnapshot{}, err
+	}
+
+	return snapshot, nil
+}
+
+func (p *pipeline) snapshotJobs(tx Tx) (Jobs, error) {
+	rows, err := jobsQuery.
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+			"active":      true,
+		}).
+		OrderBy("j.id ASC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanJobs(p.conn, p.lockFactory, rows)
+}
+
+func (p *pipeline) snapshotResources(tx Tx) (Resources, error) {
+	rows, err := resourcesQuery.Where(sq.Eq{"r.pipeline_id": p.id}).RunWith(tx).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var resources Resources
+	for rows.Next() {
+		newResource := &resource{conn: p.conn}
+		err := scanResource(newResource, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, newResource)
+	}
+
+	return resources, rows.Err()
+}
+
+func (p *pipeline) snapshotResourceTypes(tx Tx) (ResourceTypes, error) {
+	rows, err := resourceTypesQuery.Where(sq.Eq{"rt.pipeline_id": p.id}).RunWith(tx).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	resourceTypes := ResourceTypes{}
+	for rows.Next() {
+		newResourceType := &resourceType{conn: p.conn}
+		err := scanResourceType(newResourceType, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceTypes = append(resourceTypes, newResourceType)
+	}
+
+	return resourceTypes, rows.Err()
+}
+
+func (p *pipeline) snapshotResource(tx Tx, resource Resource) (atc.ResourceSnapshot, error) {
+	rows, err := psql.Select("id", "version", "metadata", "enabled").
+		From("versioned_resources").
+		Where(sq.Eq{
+			"resource_id": resource.ID(),
+			"type":        resource.Type(),
+		}).
+		OrderBy("check_order ASC", "id ASC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return atc.ResourceSnapshot{}, err
+	}
+
+	defer Close(rows)
+
+	ids := []int{}
+	versions := []atc.VersionSnapshot{}
+	for rows.Next() {
+		var (
+			id                            int
+			versionString, metadataString string
+			version                       atc.VersionSnapshot
+			metadata                      ResourceMetadataFields
+		)
+
+		err = rows.Scan(&id, &versionString, &metadataString, &version.Enabled)
+		if err != nil {
+			return atc.ResourceSnapshot{}, err
+		}
+
+		err = json.Unmarshal([]byte(versionString), &version.Version)
+		if err != nil {
+			return atc.ResourceSnapshot{}, err
+		}
+
+		err = json.Unmarshal([]byte(metadataString), &metadata)
+		if err != nil {
+			return atc.ResourceSnapshot{}, err
+		}
+
+		if len(metadata) > 0 {
+			version.Metadata = metadata.ToATCMetadata()
+		}
+
+		ids = append(ids, id)
+		versions = append(versions, version)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return atc.ResourceSnapshot{}, err
+	}
+
+	annotations, err := versionAnnotations(tx, ids)
+	if err != nil {
+		return atc.ResourceSnapshot{}, err
+	}
+
+	for i, id := range ids {
+		for _, annotation := range annotations[id] {
+			versions[i].Annotations = append(versions[i].Annotations, atc.VersionAnnotation{
+				Name:  annotation.Name,
+				Value: annotation.Value,
+				URL:   annotation.URL,
+			})
+		}
+	}
+
+	return atc.ResourceSnapshot{
+		Name:     resource.Name(),
+		Paused:   resource.Paused(),
+		Versions: versions,
+	}, nil
+}
+
+// snapshotBuildVersions runs a query for the inputs or outputs of the
+// pipeline's builds, keyed by build ID.
+func (p *pipeline) snapshotBuildVersions(tx Tx, query sq.SelectBuilder, resourceTypeByName map[string]string) (map[int][]atc.BuildVersionSnapshot, error) {
+	rows, err := query.
+		Where(sq.Eq{
+			"b.pipeline_id": p.id,
+			"r.active":      true,
+		}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	buildVersions := map[int][]atc.BuildVersionSnapshot{}
+	for rows.Next() {
+		var (
+			buildID                    int
+			versionType, versionString string
+			buildVersion               atc.BuildVersionSnapshot
+		)
+
+		err = rows.Scan(&buildID, &buildVersion.Name, &buildVersion.Resource, &versionType, &versionString)
+		if err != nil {
+			return nil, err
+		}
+
+		if resourceTypeByName[buildVersion.Resource] != versionType {
+			continue
+		}
+
+		err = json.Unmarshal([]byte(versionString), &buildVersion.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		buildVersions[buildID] = append(buildVersions[buildID], buildVersion)
+	}
+
+	return buildVersions, rows.Err()
+}
+
+func (p *pipeline) snapshotJob(tx Tx, job Job, inputs map[int][]atc.BuildVersionSnapshot, outputs map[int][]atc.BuildVersionSnapshot) (atc.JobSnapshot, error) {
+	rows, err := psql.Select("id", "name", "status", "manually_triggered", "start_time", "end_time").
+		From("builds").
+		Where(sq.Eq{"job_id": job.ID()}).
+		Where(sq.NotEq{"status": runningBuildStatuses}).
+		OrderBy("id ASC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return atc.JobSnapshot{}, err
+	}
+
+	defer Close(rows)
+
+	builds := []atc.BuildSnapshot{}
+	for rows.Next() {
+		var (
+			id                 int
+			build              atc.BuildSnapshot
+			startTime, endTime pq.NullTime
+		)
+
+		err = rows.Scan(&id, &build.Name, &build.Status, &build.ManuallyTriggered, &startTime, &endTime)
+		if err != nil {
+			return atc.JobSnapshot{}, err
+		}
+
+		if startTime.Valid {
+			build.StartTime = startTime.Time.Unix()
+		}
+
+		if endTime.Valid {
+			build.EndTime = endTime.Time.Unix()
+		}
+
+		build.Inputs = inputs[id]
+		build.Outputs = outputs[id]
+
+		builds = append(builds, build)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return atc.JobSnapshot{}, err
+	}
+
+	return atc.JobSnapshot{
+		Name:   job.Name(),
+		Paused: job.Paused(),
+		Builds: builds,
+	}, nil
+}
+
+// snapshotVersionIDs are the IDs of an imported snapshot's versions, by the
+// name of their resource and then their version's JSON.
+type snapshotVersionIDs map[string]map[string]int
+
+func (ids snapshotVersionIDs) find(resource string, version atc.Version) (int, error) {
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return 0, err
+	}
+
+	id, found := ids[resource][string(versionJSON)]
+	if !found {
+		return 0, ErrSnapshotVersionNotFound{Resource: resource, Version: version}
+	}
+
+	return id, nil
+}
+
+// ImportPipelineSnapshot creates a pipeline from a snapshot of one, along
+// with its resources' versions and its jobs' builds. It fails with
+// ErrPipelineNameInUse rather than change an existing pipeline.
+func (t *team) ImportPipelineSnapshot(pipelineName string, snapshot atc.PipelineSnapshot) (Pipeline, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	var exists bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM pipelines
+			WHERE name = $1
+			AND team_id = $2
+			AND archived_at IS NULL
+		)
+	`, pipelineName, t.id).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, ErrPipelineNameInUse
+	}
+
+	pausedState := PipelineUnpaused
+	if snapshot.Paused {
+		pausedState = PipelinePaused
+	}
+
+	pipeline, _, err := t.savePipeline(tx, "", pipelineName, snapshot.Config, ConfigVersion(0), pausedState)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = psql.Update("pipelines").
+		Set("public", snapshot.Public).
+		Where(sq.Eq{"id": pipeline.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline.public = snapshot.Public
+
+	versionIDs := snapshotVersionIDs{}
+	for _, resourceSnapshot := range snapshot.Resources {
+		versionIDs[resourceSnapshot.Name], err = importResourceSnapshot(tx, pipeline.id, snapshot.Config, resourceSnapshot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, jobSnapshot := range snapshot.Jobs {
+		err = importJobSnapshot(tx, pipeline, jobSnapshot, versionIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return pipeline, nil
+}
+
+func importResourceSnapshot(tx Tx, pipelineID int, config atc.Config, snapshot atc.ResourceSnapshot) (map[string]int, error) {
+	resourceConfig, found := config.Resources.Lookup(snapshot.Name)
+	if !found {
+		return nil, ErrResourceNotFound{Name: snapshot.Name}
+	}
+
+	var resourceID int
+	err := psql.Update("resources").
+		Set("paused", snapshot.Paused).
+		Where(sq.Eq{
+			"pipeline_id": pipelineID,
+			"name":        snapshot.Name,
+			"active":      true,
+		}).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&resourceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrResourceNotFound{Name: snapshot.Name}
+		}
+
+		return nil, err
+	}
+
+	ids := map[string]int{}
+	for i, version := range snapshot.Versions {
+		versionJSON, err := json.Marshal(version.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		metadata := NewResourceMetadataFields(version.Metadata)
+
+		metadataJSON, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		var id int
+		err = psql.Insert("versioned_resources").
+			SetMap(map[string]interface{}{
+				"resource_id":   resourceID,
+				"type":          resourceConfig.Type,
+				"version":       string(versionJSON),
+				"metadata":      string(metadataJSON),
+				"enabled":       version.Enabled,
+				"check_order":   i + 1,
+				"modified_time": sq.Expr("now()"),
+			}).
+			Suffix("RETURNING id").
+			RunWith(tx).
+			QueryRow().
+			Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		err = indexVersionedResourceFields(tx, id, ResourceVersion(version.Version), metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, annotation := range version.Annotations {
+			_, err = psql.Insert("versioned_resource_annotations").
+				Columns("versioned_resource_id", "name", "value", "url").
+				Values(id, annotation.Name, annotation.Value, annotation.URL).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		ids[string(versionJSON)] = id
+	}
+
+	return ids, nil
+}
+
+func importJobSnapshot(tx Tx, pipeline *pipeline, snapshot atc.JobSnapshot, versionIDs snapshotVersionIDs) error {
+	var jobID int
+	err := psql.Update("jobs").
+		Set("paused", snapshot.Paused).
+		Where(sq.Eq{
+			"pipeline_id": pipeline.id,
+			"name":        snapshot.Name,
+			"active":      true,
+		}).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&jobID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrJobNotFound{Name: snapshot.Name}
+		}
+
+		return err
+	}
+
+	// new builds of the job carry on numbering from its imported builds
+	buildNumber := 0
+
+	for _, build := range snapshot.Builds {
+		var buildID int
+		err = psql.Insert("builds").
+			SetMap(map[string]interface{}{
+				"name":               build.Name,
+				"job_id":             jobID,
+				"pipeline_id":        pipeline.id,
+				"team_id":            pipeline.teamID,
+				"status":             string(build.Status),
+				"manually_triggered": build.ManuallyTriggered,
+				"start_time":         snapshotTime(build.StartTime),
+				"end_time":           snapshotTime(build.EndTime),
+				"completed":          true,
+			}).
+			Suffix("RETURNING id").
+			RunWith(tx).
+			QueryRow().
+			Scan(&buildID)
+		if err != nil {
+			return err
+		}
+
+		for _, input := range build.Inputs {
+			versionID, err := versionIDs.find(input.Resource, input.Version)
+			if err != nil {
+				return err
+			}
+
+			_, err = psql.Insert("build_inputs").
+				Columns("build_id", "versioned_resource_id", "name").
+				Values(buildID, versionID, input.Name).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, output := range build.Outputs {
+			versionID, err := versionIDs.find(output.Resource, output.Version)
+			if err != nil {
+				return err
+			}
+
+			_, err = psql.Insert("build_outputs").
+				Columns("build_id", "versioned_resource_id").
+				Values(buildID, versionID).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return err
+			}
+		}
+
+		if number, err := strconv.Atoi(build.Name); err == nil && number > buildNumber {
+			buildNumber = number
+		}
+	}
+
+	_, err = psql.Update("jobs").
+		Set("build_number_seq", sq.Expr("GREATEST(build_number_seq, ?)", buildNumber)).
+		Where(sq.Eq{"id": jobID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return refreshDashboardBuilds(tx, jobID)
+}
+
+func snapshotTime(unix int64) interface{} {
+	if unix == 0 {
+		return nil
+	}
+
+	return time.Unix(unix, 0)
+}
@@ -0,0 +1,207 @@
+package db_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipeline snapshots", func() {
+	var (
+		pipeline  db.Pipeline
+		otherTeam db.Team
+
+		snapshot atc.PipelineSnapshot
+	)
+
+	BeforeEach(func() {
+		var err error
+		pipeline, _, err = defaultTeam.SavePipeline("snapshotted-pipeline", atc.Config{
+			Resources: atc.ResourceConfigs{
+				{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{Get: "some-resource"},
+						{Put: "some-resource"},
+					},
+				},
+			},
+		}, db.ConfigVersion(0), db.PipelineUnpaused)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(pipeline.Expose(db.AnyConfigVersion)).ToNot(BeZero())
+
+		resourceConfig := atc.ResourceConfig{
+			Name:   "some-resource",
+			Type:   "some-type",
+			Source: atc.Source{"some": "source"},
+		}
+
+		err = pipeline.SaveResourceVersions(resourceConfig, []atc.Version{{"ref": "v1"}, {"ref": "v2"}})
+		Expect(err).ToNot(HaveOccurred())
+
+		v1, found, err := pipeline.GetVersionedResourceByVersion(atc.Version{"ref": "v1"}, "some-resource")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(pipeline.DisableVersionedResource(v1.ID, db.AnyConfigVersion)).ToNot(BeZero())
+
+		v2, found, err := pipeline.GetVersionedResourceByVersion(atc.Version{"ref": "v2"}, "some-resource")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		_, _, err = pipeline.AnnotateVersionedResource(v2.ID, db.VersionAnnotation{Name: "qa", Value: "passed"}, db.AnyConfigVersion)
+		Expect(err).ToNot(HaveOccurred())
+
+		job, found, err := pipeline.Job("some-job")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		build, err := job.CreateBuild(db.BuildTriggerCause{})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = build.SaveInput(db.BuildInput{
+			Name: "some-resource",
+			VersionedResource: db.VersionedResource{
+				Resource: "some-resource",
+				Type:     "some-type",
+				Version:  db.ResourceVersion{"ref": "v2"},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = build.SaveOutput(db.VersionedResource{
+			Resource: "some-resource",
+			Type:     "some-type",
+			Version:  db.ResourceVersion{"ref": "v3"},
+			Metadata: db.ResourceMetadataFields{{Name: "author", Value: "someone"}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+		_, err = job.CreateBuild(db.BuildTriggerCause{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(job.Pause(db.AnyConfigVersion)).ToNot(BeZero())
+
+		otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		snapshot, err = pipeline.Snapshot()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Snapshot", func() {
+		It("exports the pipeline's versions and finished builds", func() {
+			Expect(snapshot.Public).To(BeTrue())
+			Expect(snapshot.Paused).To(BeFalse())
+			Expect(snapshot.Config.Jobs).To(HaveLen(1))
+			Expect(snapshot.Config.Resources).To(HaveLen(1))
+
+			Expect(snapshot.Resources).To(Equal([]atc.ResourceSnapshot{
+				{
+					Name: "some-resource",
+					Versions: []atc.VersionSnapshot{
+						{Version: atc.Version{"ref": "v1"}, Enabled: false},
+						{
+							Version:     atc.Version{"ref": "v2"},
+							Enabled:     true,
+							Annotations: []atc.VersionAnnotation{{Name: "qa", Value: "passed"}},
+						},
+						{
+							Version:  atc.Version{"ref": "v3"},
+							Metadata: []atc.MetadataField{{Name: "author", Value: "someone"}},
+							Enabled:  true,
+						},
+					},
+				},
+			}))
+
+			Expect(snapshot.Jobs).To(HaveLen(1))
+			Expect(snapshot.Jobs[0].Name).To(Equal("some-job"))
+			Expect(snapshot.Jobs[0].Paused).To(BeTrue())
+			Expect(snapshot.Jobs[0].Builds).To(HaveLen(1))
+
+			build := snapshot.Jobs[0].Builds[0]
+			Expect(build.Name).To(Equal("1"))
+			Expect(build.Status).To(Equal(atc.StatusSucceeded))
+			Expect(build.EndTime).ToNot(BeZero())
+			Expect(build.Inputs).To(Equal([]atc.BuildVersionSnapshot{
+				{Name: "some-resource", Resource: "some-resource", Version: atc.Version{"ref": "v2"}},
+			}))
+			Expect(build.Outputs).To(Equal([]atc.BuildVersionSnapshot{
+				{Resource: "some-resource", Version: atc.Version{"ref": "v3"}},
+			}))
+		})
+	})
+
+	Describe("ImportPipelineSnapshot", func() {
+		It("creates a pipeline with the same state", func() {
+			imported, err := otherTeam.ImportPipelineSnapshot("imported-pipeline", snapshot)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(imported.Name()).To(Equal("imported-pipeline"))
+			Expect(imported.Public()).To(BeTrue())
+
+			importedSnapshot, err := imported.Snapshot()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(importedSnapshot).To(Equal(snapshot))
+
+			By("finding the imported versions by their fields")
+			versions, err := imported.SearchResourceVersions(db.ResourceVersionSearch{Value: "someone"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(HaveLen(1))
+			Expect(versions[0].Version).To(Equal(db.ResourceVersion{"ref": "v3"}))
+
+			By("numbering new builds after the imported ones")
+			job, found, err := imported.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.Name()).To(Equal("2"))
+
+			By("showing the imported builds on the dashboard")
+			dashboard, err := imported.Dashboard("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dashboard).To(HaveLen(1))
+			Expect(dashboard[0].FinishedBuild.Name()).To(Equal("1"))
+		})
+
+		Context("when the team already has a pipeline with the name", func() {
+			It("fails without changing it", func() {
+				_, err := defaultTeam.ImportPipelineSnapshot("snapshotted-pipeline", snapshot)
+				Expect(err).To(Equal(db.ErrPipelineNameInUse))
+			})
+		})
+
+		Context("when a build has a version which is not in the snapshot", func() {
+			JustBeforeEach(func() {
+				snapshot.Resources[0].Versions = snapshot.Resources[0].Versions[:1]
+			})
+
+			It("fails", func() {
+				_, err := otherTeam.ImportPipelineSnapshot("imported-pipeline", snapshot)
+				Expect(err).To(Equal(db.ErrSnapshotVersionNotFound{
+					Resource: "some-resource",
+					Version:  atc.Version{"ref": "v2"},
+				}))
+
+				_, found, err := otherTeam.Pipeline("imported-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -127,7 +127,7 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when the pipeline is unpaused", func() {
 			BeforeEach(func() {
-				Expect(pipeline.Unpause()).To(Succeed())
+				Expect(pipeline.Unpause(db.AnyConfigVersion)).ToNot(BeZero())
 			})
 
 			It("returns the pipeline is paused", func() {
@@ -137,7 +137,7 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when the pipeline is paused", func() {
 			BeforeEach(func() {
-				Expect(pipeline.Pause()).To(Succeed())
+				Expect(pipeline.Pause(db.AnyConfigVersion)).ToNot(BeZero())
 			})
 
 			It("returns the pipeline is paused", func() {
@@ -148,7 +148,7 @@ var _ = Describe("Pipeline", func() {
 
 	Describe("Pause", func() {
 		JustBeforeEach(func() {
-			Expect(pipeline.Pause()).To(Succeed())
+			Expect(pipeline.Pause(db.AnyConfigVersion)).ToNot(BeZero())
 
 			found, err := pipeline.Reload()
 			Expect(err).ToNot(HaveOccurred())
@@ -157,18 +157,35 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when the pipeline is unpaused", func() {
 			BeforeEach(func() {
-				Expect(pipeline.Unpause()).To(Succeed())
+				Expect(pipeline.Unpause(db.AnyConfigVersion)).ToNot(BeZero())
 			})
 
 			It("pauses the pipeline", func() {
 				Expect(pipeline.Paused()).To(BeTrue())
 			})
 		})
+
+		Context("when the pipeline has changed since the given config version", func() {
+			It("fails without pausing the pipeline", func() {
+				staleVersion := pipeline.ConfigVersion()
+
+				_, err := pipeline.Unpause(db.AnyConfigVersion)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = pipeline.Pause(staleVersion)
+				Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+
+				found, err := pipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(pipeline.Paused()).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("Unpause", func() {
 		JustBeforeEach(func() {
-			Expect(pipeline.Unpause()).To(Succeed())
+			Expect(pipeline.Unpause(db.AnyConfigVersion)).ToNot(BeZero())
 
 			found, err := pipeline.Reload()
 			Expect(err).ToNot(HaveOccurred())
@@ -177,7 +194,7 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when the pipeline is paused", func() {
 			BeforeEach(func() {
-				Expect(pipeline.Pause()).To(Succeed())
+				Expect(pipeline.Pause(db.AnyConfigVersion)).ToNot(BeZero())
 			})
 
 			It("unpauses the pipeline", func() {
@@ -188,7 +205,7 @@ var _ = Describe("Pipeline", func() {
 
 	Describe("Rename", func() {
 		JustBeforeEach(func() {
-			Expect(pipeline.Rename("oopsies")).To(Succeed())
+			Expect(pipeline.Rename("oopsies", db.AnyConfigVersion)).ToNot(BeZero())
 		})
 
 		It("renames the pipeline", func() {
@@ -379,7 +396,7 @@ var _ = Describe("Pipeline", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					build, err := job.CreateBuild()
+					build, err := job.CreateBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 
 					err = build.SaveInput(db.BuildInput{
@@ -403,7 +420,7 @@ var _ = Describe("Pipeline", func() {
 
 			Context("when a version is disabled", func() {
 				BeforeEach(func() {
-					err := pipeline.DisableVersionedResource(10)
+					_, err := pipeline.DisableVersionedResource(10, db.AnyConfigVersion)
 					Expect(err).ToNot(HaveOccurred())
 
 					expectedVersions[9].Enabled = false
@@ -602,6 +619,54 @@ var _ = Describe("Pipeline", func() {
 			Expect(latestVR.CheckOrder).To(Equal(4))
 		})
 
+		It("saves repeated versions once, ordered by their last occurrence", func() {
+			err := pipeline.SaveResourceVersions(resourceConfig, []atc.Version{
+				{"ref": "v1"},
+				{"ref": "v2"},
+				{"ref": "v1"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			versions, _, found, err := pipeline.GetResourceVersions(resource.Name(), db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(versions).To(HaveLen(2))
+			Expect(versions[0].Version).To(Equal(db.ResourceVersion{"ref": "v1"}))
+			Expect(versions[0].CheckOrder).To(Equal(2))
+			Expect(versions[1].Version).To(Equal(db.ResourceVersion{"ref": "v2"}))
+			Expect(versions[1].CheckOrder).To(Equal(1))
+		})
+
+		It("saves more versions than fit in a single batch, in order", func() {
+			manyVersions := []atc.Version{}
+			for i := 1; i <= 2500; i++ {
+				manyVersions = append(manyVersions, atc.Version{"ref": fmt.Sprintf("v%d", i)})
+			}
+
+			err := pipeline.SaveResourceVersions(resourceConfig, manyVersions)
+			Expect(err).ToNot(HaveOccurred())
+
+			versions, _, found, err := pipeline.GetResourceVersions(resource.Name(), db.Page{Limit: 3000})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(versions).To(HaveLen(2500))
+
+			for i, version := range versions {
+				Expect(version.Version).To(Equal(db.ResourceVersion{"ref": fmt.Sprintf("v%d", 2500-i)}))
+				Expect(version.CheckOrder).To(Equal(2500 - i))
+			}
+		})
+
+		It("makes the new versions searchable", func() {
+			err := pipeline.SaveResourceVersions(resourceConfig, originalVersionSlice)
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := pipeline.SearchResourceVersions(db.ResourceVersionSearch{Value: "v3", Field: "ref"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(HaveLen(1))
+			Expect(found[0].Version).To(Equal(db.ResourceVersion{"ref": "v3"}))
+		})
+
 		Context("resource not found", func() {
 			BeforeEach(func() {
 				resourceConfig = atc.ResourceConfig{
@@ -671,7 +736,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build.SaveInput(db.BuildInput{
@@ -690,7 +755,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(savedVersions).To(HaveLen(2))
-			err = pipeline.DisableVersionedResource(savedVersions[0].ID)
+			_, err = pipeline.DisableVersionedResource(savedVersions[0].ID, db.AnyConfigVersion)
 			Expect(err).ToNot(HaveOccurred())
 
 			savedVersion2 = savedVersions[1]
@@ -1078,7 +1143,7 @@ var _ = Describe("Pipeline", func() {
 			}))
 
 			By("including outputs of successful builds")
-			build1DB, err := aJob.CreateBuild()
+			build1DB, err := aJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build1DB.SaveOutput(savedVR1.VersionedResource)
@@ -1125,7 +1190,7 @@ var _ = Describe("Pipeline", func() {
 			}))
 
 			By("not including outputs of failed builds")
-			build2DB, err := aJob.CreateBuild()
+			build2DB, err := aJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build2DB.SaveOutput(savedVR1.VersionedResource)
@@ -1174,7 +1239,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			otherPipelineBuild, err := anotherJob.CreateBuild()
+			otherPipelineBuild, err := anotherJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = otherPipelineBuild.SaveOutput(otherPipelineSavedVR.VersionedResource)
@@ -1223,7 +1288,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build1DB, err = aJob.CreateBuild()
+			build1DB, err = aJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build1DB.SaveInput(db.BuildInput{
@@ -1318,7 +1383,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(otherPipelineSavedVR.Version).To(Equal(db.ResourceVersion{"version": "3"}))
 
 			By("including disabled versions")
-			err = dbPipeline.DisableVersionedResource(savedVR2.ID)
+			_, err = dbPipeline.DisableVersionedResource(savedVR2.ID, db.AnyConfigVersion)
 			Expect(err).ToNot(HaveOccurred())
 
 			latestVR, found, err := dbPipeline.GetLatestVersionedResource(resource.Name())
@@ -1330,10 +1395,10 @@ var _ = Describe("Pipeline", func() {
 
 		Describe("enabling and disabling versioned resources", func() {
 			It("returns an error if the resource or version is bogus", func() {
-				err := dbPipeline.EnableVersionedResource(42)
+				_, err := dbPipeline.EnableVersionedResource(42, db.AnyConfigVersion)
 				Expect(err).To(HaveOccurred())
 
-				err = dbPipeline.DisableVersionedResource(42)
+				_, err = dbPipeline.DisableVersionedResource(42, db.AnyConfigVersion)
 				Expect(err).To(HaveOccurred())
 			})
 
@@ -1354,7 +1419,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(savedVR.Version).To(Equal(db.ResourceVersion{"version": "1"}))
 				initialTime := savedVR.ModifiedTime
 
-				err = dbPipeline.DisableVersionedResource(savedVR.ID)
+				_, err = dbPipeline.DisableVersionedResource(savedVR.ID, db.AnyConfigVersion)
 				Expect(err).ToNot(HaveOccurred())
 
 				disabledVR := savedVR
@@ -1371,7 +1436,7 @@ var _ = Describe("Pipeline", func() {
 
 				tmp_modified_time := latestVR.ModifiedTime
 
-				err = dbPipeline.EnableVersionedResource(savedVR.ID)
+				_, err = dbPipeline.EnableVersionedResource(savedVR.ID, db.AnyConfigVersion)
 				Expect(err).ToNot(HaveOccurred())
 
 				enabledVR := savedVR
@@ -1403,7 +1468,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 
 				beforeVR, found, err := dbPipeline.GetLatestVersionedResource(resource.Name())
@@ -1445,7 +1510,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 
 				beforeVR, found, err := dbPipeline.GetLatestVersionedResource(resource.Name())
@@ -1477,6 +1542,126 @@ var _ = Describe("Pipeline", func() {
 			})
 		})
 
+		Describe("annotating versioned resources", func() {
+			var savedVR db.SavedVersionedResource
+
+			BeforeEach(func() {
+				err := dbPipeline.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				savedVR, found, err = dbPipeline.GetLatestVersionedResource(resource.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("shows the annotations when fetching the version", func() {
+				_, found, err := dbPipeline.AnnotateVersionedResource(savedVR.ID, db.VersionAnnotation{
+					Name:  "qa",
+					Value: "signed off",
+					URL:   "https://tickets.example.com/123",
+				}, db.AnyConfigVersion)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, found, err = dbPipeline.AnnotateVersionedResource(savedVR.ID, db.VersionAnnotation{
+					Name:  "change",
+					Value: "CHG-42",
+				}, db.AnyConfigVersion)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				vr, found, err := dbPipeline.VersionedResource(savedVR.ID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(vr.Annotations).To(Equal([]db.VersionAnnotation{
+					{Name: "change", Value: "CHG-42"},
+					{Name: "qa", Value: "signed off", URL: "https://tickets.example.com/123"},
+				}))
+
+				versions, _, found, err := dbPipeline.GetResourceVersions(resource.Name(), db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(versions).To(HaveLen(1))
+				Expect(versions[0].Annotations).To(Equal(vr.Annotations))
+			})
+
+			It("replaces an existing annotation with the same name", func() {
+				_, _, err := dbPipeline.AnnotateVersionedResource(savedVR.ID, db.VersionAnnotation{
+					Name:  "qa",
+					Value: "pending",
+				}, db.AnyConfigVersion)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = dbPipeline.AnnotateVersionedResource(savedVR.ID, db.VersionAnnotation{
+					Name:  "qa",
+					Value: "signed off",
+				}, db.AnyConfigVersion)
+				Expect(err).ToNot(HaveOccurred())
+
+				vr, _, err := dbPipeline.VersionedResource(savedVR.ID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vr.Annotations).To(Equal([]db.VersionAnnotation{
+					{Name: "qa", Value: "signed off"},
+				}))
+			})
+
+			It("removes annotations", func() {
+				_, _, err := dbPipeline.AnnotateVersionedResource(savedVR.ID, db.VersionAnnotation{
+					Name:  "qa",
+					Value: "signed off",
+				}, db.AnyConfigVersion)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, found, err := dbPipeline.RemoveVersionedResourceAnnotation(savedVR.ID, "qa", db.AnyConfigVersion)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				vr, _, err := dbPipeline.VersionedResource(savedVR.ID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vr.Annotations).To(BeEmpty())
+
+				_, found, err = dbPipeline.RemoveVersionedResourceAnnotation(savedVR.ID, "qa", db.AnyConfigVersion)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("checks the pipeline's config version", func() {
+				from := dbPipeline.ConfigVersion()
+
+				version, _, err := dbPipeline.AnnotateVersionedResource(savedVR.ID, db.VersionAnnotation{
+					Name:  "qa",
+					Value: "signed off",
+				}, from)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(version).To(BeNumerically(">", from))
+
+				_, _, err = dbPipeline.RemoveVersionedResourceAnnotation(savedVR.ID, "qa", from)
+				Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+
+				_, found, err := dbPipeline.RemoveVersionedResourceAnnotation(savedVR.ID, "qa", version)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("does not annotate versions of other pipelines", func() {
+				_, found, err := otherDBPipeline.AnnotateVersionedResource(savedVR.ID, db.VersionAnnotation{
+					Name:  "qa",
+					Value: "signed off",
+				}, db.AnyConfigVersion)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				vr, _, err := dbPipeline.VersionedResource(savedVR.ID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vr.Annotations).To(BeEmpty())
+			})
+		})
+
 		Describe("saving versioned resources", func() {
 			It("updates the latest versioned resource", func() {
 				err := dbPipeline.SaveResourceVersions(
@@ -1608,7 +1793,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build1, err := aJob.CreateBuild()
+				build1, err := aJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 
 				err = pipelineDB.SaveResourceVersions(atc.ResourceConfig{
@@ -1665,13 +1850,13 @@ var _ = Describe("Pipeline", func() {
 				err = build1.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
 
-				err = pipelineDB.DisableVersionedResource(disabledVersion.ID)
+				_, err = pipelineDB.DisableVersionedResource(disabledVersion.ID, db.AnyConfigVersion)
 				Expect(err).ToNot(HaveOccurred())
 
-				err = pipelineDB.DisableVersionedResource(enabledVersion.ID)
+				_, err = pipelineDB.DisableVersionedResource(enabledVersion.ID, db.AnyConfigVersion)
 				Expect(err).ToNot(HaveOccurred())
 
-				err = pipelineDB.EnableVersionedResource(enabledVersion.ID)
+				_, err = pipelineDB.EnableVersionedResource(enabledVersion.ID, db.AnyConfigVersion)
 				Expect(err).ToNot(HaveOccurred())
 
 				versions, err := pipelineDB.LoadVersionsDB()
@@ -1760,7 +1945,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			By("populating build inputs")
@@ -1782,6 +1967,9 @@ var _ = Describe("Pipeline", func() {
 			err = build.SaveEvent(event.StartTask{})
 			Expect(err).ToNot(HaveOccurred())
 
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
 			err = pipeline.Destroy()
 			Expect(err).ToNot(HaveOccurred())
 
@@ -1797,12 +1985,363 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeFalse())
 		})
+
+		Context("when a build of the pipeline is running", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				job, found, err := pipeline.Job("job-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = job.CreateBuild(db.BuildTriggerCause{})
+				Expect(err).ToNot(HaveOccurred())
+
+				started, err := build.Start("exec.v2", `{"meta":"data"}`, atc.Plan{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				err = build.SaveEvent(event.StartTask{})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pipeline.Destroy()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("archives the build under the team", func() {
+				found, err := build.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(build.Status()).To(Equal(db.BuildStatusPipelineDestroyed))
+				Expect(build.ArchivedFrom()).To(Equal(pipeline.Name() + "/job-name"))
+				Expect(build.PipelineID()).To(BeZero())
+				Expect(build.JobID()).To(BeZero())
+				Expect(build.TeamID()).To(Equal(team.ID()))
+			})
+
+			It("leaves the build to be finished by its engine", func() {
+				found, err := build.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.IsRunning()).To(BeTrue())
+			})
+
+			It("signals the build's tracker to abort it", func() {
+				notifier, err := build.AbortNotifier()
+				Expect(err).ToNot(HaveOccurred())
+
+				defer notifier.Close()
+
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+
+			Context("when the engine saves more events and finishes the build", func() {
+				BeforeEach(func() {
+					err := build.SaveEvent(event.FinishTask{ExitStatus: 1})
+					Expect(err).ToNot(HaveOccurred())
+
+					err = build.Finish(db.BuildStatusAborted)
+					Expect(err).ToNot(HaveOccurred())
+
+					found, err := build.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+
+				It("finishes it as pipeline-destroyed", func() {
+					Expect(build.Status()).To(Equal(db.BuildStatusPipelineDestroyed))
+					Expect(build.EndTime()).NotTo(BeZero())
+					Expect(build.IsRunning()).To(BeFalse())
+				})
+
+				It("keeps all of the build's events, followed by its status", func() {
+					events, err := build.Events(0)
+					Expect(err).ToNot(HaveOccurred())
+
+					defer db.Close(events)
+
+					Expect(events.Next()).To(Equal(envelope(event.Status{
+						Status: atc.StatusStarted,
+						Time:   build.StartTime().Unix(),
+					})))
+
+					Expect(events.Next()).To(Equal(envelope(event.StartTask{})))
+
+					Expect(events.Next()).To(Equal(envelope(event.FinishTask{ExitStatus: 1})))
+
+					Expect(events.Next()).To(Equal(envelope(event.Status{
+						Status: atc.StatusPipelineDestroyed,
+						Time:   build.EndTime().Unix(),
+					})))
+				})
+
+				It("no longer lets the build be finished", func() {
+					err := build.Finish(db.BuildStatusSucceeded)
+					Expect(err).ToNot(HaveOccurred())
+
+					found, err := build.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.Status()).To(Equal(db.BuildStatusPipelineDestroyed))
+				})
+			})
+		})
+
+		Context("when a build of the pipeline has not been started", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				job, found, err := pipeline.Job("job-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = job.CreateBuild(db.BuildTriggerCause{})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pipeline.Destroy()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("finishes it as pipeline-destroyed", func() {
+				found, err := build.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(build.Status()).To(Equal(db.BuildStatusPipelineDestroyed))
+				Expect(build.EndTime()).NotTo(BeZero())
+				Expect(build.IsRunning()).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Archive", func() {
+		var (
+			build        db.Build
+			runningBuild db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			build, err = job.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			runningBuild, err = job.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			started, err := runningBuild.Start("exec.v2", `{"meta":"data"}`, atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			_, err = pipeline.Archive(db.AnyConfigVersion)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("pauses the pipeline", func() {
+			Expect(pipeline.ArchivedAt()).ToNot(BeZero())
+			Expect(pipeline.Paused()).To(BeTrue())
+
+			found, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipeline.Paused()).To(BeTrue())
+			Expect(pipeline.ArchivedAt()).ToNot(BeZero())
+		})
+
+		It("hides the pipeline from its team", func() {
+			_, found, err := team.Pipeline("fake-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			pipelines, err := team.Pipelines()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelines).To(BeEmpty())
+
+			archived, err := team.ArchivedPipelines()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(archived).To(HaveLen(1))
+			Expect(archived[0].ID()).To(Equal(pipeline.ID()))
+		})
+
+		It("hides the pipeline and its jobs from every team", func() {
+			pipelines, err := db.NewPipelineFactory(dbConn, lockFactory).AllPipelines()
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, p := range pipelines {
+				Expect(p.ID()).ToNot(Equal(pipeline.ID()))
+			}
+
+			dashboard, err := db.NewJobFactory(dbConn, lockFactory).VisibleJobs([]string{team.Name()})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dashboard).To(BeEmpty())
+		})
+
+		It("keeps its builds", func() {
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.PipelineID()).To(Equal(pipeline.ID()))
+		})
+
+		It("aborts and finishes its pending builds", func() {
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusAborted))
+			Expect(build.AbortCause()).To(Equal(db.BuildAbortCause{Reason: "pipeline archived"}))
+			Expect(build.EndTime()).NotTo(BeZero())
+
+			events, err := build.Events(0)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.AbortRequested{
+				Time:   build.EndTime().Unix(),
+				Reason: "pipeline archived",
+			})))
+
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusAborted,
+				Time:   build.EndTime().Unix(),
+			})))
+		})
+
+		It("aborts its running builds, signalling their trackers", func() {
+			found, err := runningBuild.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(runningBuild.Status()).To(Equal(db.BuildStatusAborted))
+			Expect(runningBuild.AbortCause()).To(Equal(db.BuildAbortCause{Reason: "pipeline archived"}))
+			Expect(runningBuild.PipelineID()).To(Equal(pipeline.ID()))
+
+			notifier, err := runningBuild.AbortNotifier()
+			Expect(err).ToNot(HaveOccurred())
+
+			defer notifier.Close()
+
+			Eventually(notifier.Notify()).Should(Receive())
+
+			err = runningBuild.Finish(db.BuildStatusAborted)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("frees its name", func() {
+			_, created, err := team.SavePipeline("fake-pipeline", pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+		})
+
+		It("is found by the pipeline factory once archived for long enough", func() {
+			pipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
+
+			archived, err := pipelineFactory.ArchivedPipelines(pipeline.ArchivedAt())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(archived).To(BeEmpty())
+
+			archived, err = pipelineFactory.ArchivedPipelines(pipeline.ArchivedAt().Add(time.Second))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(archived).To(HaveLen(1))
+			Expect(archived[0].ID()).To(Equal(pipeline.ID()))
+		})
+
+		Describe("DestroyIfArchivedBefore", func() {
+			It("keeps the pipeline if it was archived since", func() {
+				destroyed, err := pipeline.DestroyIfArchivedBefore(pipeline.ArchivedAt())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(destroyed).To(BeFalse())
+
+				archived, err := team.ArchivedPipelines()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(archived).To(HaveLen(1))
+			})
+
+			It("destroys the pipeline if it was archived before", func() {
+				destroyed, err := pipeline.DestroyIfArchivedBefore(pipeline.ArchivedAt().Add(time.Second))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(destroyed).To(BeTrue())
+
+				archived, err := team.ArchivedPipelines()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(archived).To(BeEmpty())
+			})
+
+			Context("when the pipeline has been restored", func() {
+				BeforeEach(func() {
+					archived, err := team.ArchivedPipelines()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(archived).To(HaveLen(1))
+
+					_, err = archived[0].Restore(db.AnyConfigVersion)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("keeps the pipeline", func() {
+					destroyed, err := pipeline.DestroyIfArchivedBefore(pipeline.ArchivedAt().Add(time.Second))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(destroyed).To(BeFalse())
+
+					_, found, err := team.Pipeline("fake-pipeline")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+			})
+		})
+
+		Describe("Restore", func() {
+			Context("when the pipeline has changed since the given version", func() {
+				It("fails", func() {
+					_, err := pipeline.Restore(db.ConfigVersion(1))
+					Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+				})
+			})
+
+			It("brings the pipeline back, still paused", func() {
+				_, err := pipeline.Restore(db.AnyConfigVersion)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pipeline.ArchivedAt()).To(BeZero())
+
+				restored, found, err := team.Pipeline("fake-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(restored.ID()).To(Equal(pipeline.ID()))
+				Expect(restored.Paused()).To(BeTrue())
+			})
+
+			Context("when the pipeline is no longer archived", func() {
+				BeforeEach(func() {
+					_, err := pipeline.Restore(db.AnyConfigVersion)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("fails", func() {
+					_, err := pipeline.Restore(db.AnyConfigVersion)
+					Expect(err).To(Equal(db.ErrPipelineNotArchived))
+				})
+			})
+
+			Context("when another pipeline has been given its name", func() {
+				BeforeEach(func() {
+					_, _, err := team.SavePipeline("fake-pipeline", pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("fails", func() {
+					_, err := pipeline.Restore(db.AnyConfigVersion)
+					Expect(err).To(Equal(db.ErrPipelineNameInUse))
+
+					archived, err := team.ArchivedPipelines()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(archived).To(HaveLen(1))
+				})
+			})
+		})
 	})
 
 	Describe("GetPendingBuilds/GetAllPendingBuilds", func() {
 		Context("when a build is created", func() {
 			BeforeEach(func() {
-				_, err := job.CreateBuild()
+				_, err := job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -1819,6 +2358,73 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("PendingJobsAwaitingInputs", func() {
+		It("returns no jobs when no builds are pending", func() {
+			jobs, err := pipeline.PendingJobsAwaitingInputs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(jobs).To(BeEmpty())
+		})
+
+		Context("when a build is pending", func() {
+			BeforeEach(func() {
+				_, err := job.CreateBuild(db.BuildTriggerCause{})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the job without any available inputs", func() {
+				jobs, err := pipeline.PendingJobsAwaitingInputs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobs).To(HaveLen(1))
+				Expect(jobs).To(HaveKey("job-name"))
+				Expect(jobs["job-name"]).To(BeEmpty())
+			})
+
+			Context("when an input has a version available", func() {
+				BeforeEach(func() {
+					err := pipeline.SaveResourceVersions(
+						atc.ResourceConfig{
+							Name:   "some-resource",
+							Type:   "some-type",
+							Source: atc.Source{"some": "source"},
+						},
+						[]atc.Version{{"version": "v1"}},
+					)
+					Expect(err).ToNot(HaveOccurred())
+
+					versions, _, found, err := pipeline.GetResourceVersions("some-resource", db.Page{Limit: 1})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = job.SaveIndependentInputMapping(algorithm.InputMapping{
+						"some-input": {VersionID: versions[0].ID, FirstOccurrence: true},
+					})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns the input with the job", func() {
+					jobs, err := pipeline.PendingJobsAwaitingInputs()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(jobs).To(Equal(map[string][]string{
+						"job-name": {"some-input"},
+					}))
+				})
+			})
+
+			Context("when the job's next inputs have been determined", func() {
+				BeforeEach(func() {
+					err := job.SaveNextInputMapping(algorithm.InputMapping{})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("does not return the job", func() {
+					jobs, err := pipeline.PendingJobsAwaitingInputs()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(jobs).To(BeEmpty())
+				})
+			})
+		})
+	})
+
 	Describe("VersionsDB caching", func() {
 		var otherPipeline db.Pipeline
 		BeforeEach(func() {
@@ -1853,7 +2459,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild()
+				build, err = job.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 
 				err = pipeline.SaveResourceVersions(atc.ResourceConfig{
@@ -1901,7 +2507,7 @@ var _ = Describe("Pipeline", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					otherBuild, err := job.CreateBuild()
+					otherBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 
 					err = otherPipeline.SaveResourceVersions(atc.ResourceConfig{
@@ -1931,6 +2537,38 @@ var _ = Describe("Pipeline", func() {
 			})
 		})
 
+		Context("when versions are annotated", func() {
+			It("will not cache VersionsDB and includes the annotations", func() {
+				err := pipeline.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				savedVR, found, err := pipeline.GetLatestVersionedResource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, found, err = pipeline.AnnotateVersionedResource(savedVR.ID, db.VersionAnnotation{
+					Name:  "qa",
+					Value: "passed",
+				}, db.AnyConfigVersion)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				annotatedVersionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versionsDB != annotatedVersionsDB).To(BeTrue(), "Expected VersionsDB to be different objects")
+				Expect(annotatedVersionsDB.VersionAnnotations).To(Equal(map[int]map[string]string{
+					savedVR.ID: {"qa": "passed"},
+				}))
+			})
+		})
+
 		Context("when versioned resources are added", func() {
 			It("will cache VersionsDB if no change has occured", func() {
 				err := pipeline.SaveResourceVersions(atc.ResourceConfig{
@@ -2048,7 +2686,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			firstJobBuild, err := job.CreateBuild()
+			firstJobBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			actualDashboard, err = pipeline.Dashboard("")
@@ -2080,7 +2718,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			secondJobBuild, err := job.CreateBuild()
+			secondJobBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			actualDashboard, err = pipeline.Dashboard("")
@@ -2115,7 +2753,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			otherJobBuild, err := otherJob.CreateBuild()
+			otherJobBuild, err := otherJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = otherJobBuild.Finish(db.BuildStatusFailed)
@@ -2125,7 +2763,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			jobBuild, err := job.CreateBuild()
+			jobBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = jobBuild.Finish(db.BuildStatusFailed)
@@ -2146,7 +2784,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = job.CreateBuild()
+			_, err = job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			actualDashboard, err = pipeline.Dashboard("transitionBuilds")
@@ -2159,13 +2797,13 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			transitionBuild, err := job.CreateBuild()
+			transitionBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = transitionBuild.Finish(db.BuildStatusFailed)
 			Expect(err).ToNot(HaveOccurred())
 
-			jobBuild, err = job.CreateBuild()
+			jobBuild, err = job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = jobBuild.Finish(db.BuildStatusFailed)
@@ -2182,43 +2820,43 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			jobBuild, err = job.CreateBuild()
+			jobBuild, err = job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = jobBuild.Finish(db.BuildStatusFailed)
 			Expect(err).ToNot(HaveOccurred())
 
-			jobBuild, err = job.CreateBuild()
+			jobBuild, err = job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = jobBuild.Finish(db.BuildStatusSucceeded)
 			Expect(err).ToNot(HaveOccurred())
 
-			otherJobBuild, err = otherJob.CreateBuild()
+			otherJobBuild, err = otherJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = otherJobBuild.Finish(db.BuildStatusFailed)
 			Expect(err).ToNot(HaveOccurred())
 
-			transitionBuild, err = job.CreateBuild()
+			transitionBuild, err = job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = transitionBuild.Finish(db.BuildStatusFailed)
 			Expect(err).ToNot(HaveOccurred())
 
-			otherJobBuild, err = otherJob.CreateBuild()
+			otherJobBuild, err = otherJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = otherJobBuild.Finish(db.BuildStatusSucceeded)
 			Expect(err).ToNot(HaveOccurred())
 
-			jobBuild, err = job.CreateBuild()
+			jobBuild, err = job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = jobBuild.Finish(db.BuildStatusFailed)
 			Expect(err).ToNot(HaveOccurred())
 
-			_, err = job.CreateBuild()
+			_, err = job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			actualDashboard, err = pipeline.Dashboard("")
@@ -2231,11 +2869,59 @@ var _ = Describe("Pipeline", func() {
 
 			Expect(actualDashboard[0].TransitionBuild.ID()).To(Equal(transitionBuild.ID()))
 		})
+
+		It("keeps the dashboard builds up to date when builds finish out of order", func() {
+			olderBuild, err := job.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			newerBuild, err := job.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = newerBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			dashboard, err := pipeline.Dashboard("transitionBuilds")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dashboard[0].NextBuild.ID()).To(Equal(olderBuild.ID()))
+			Expect(dashboard[0].FinishedBuild.ID()).To(Equal(newerBuild.ID()))
+			Expect(dashboard[0].TransitionBuild.ID()).To(Equal(newerBuild.ID()))
+
+			err = olderBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			dashboard, err = pipeline.Dashboard("transitionBuilds")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dashboard[0].NextBuild).To(BeNil())
+			Expect(dashboard[0].FinishedBuild.ID()).To(Equal(newerBuild.ID()))
+			Expect(dashboard[0].TransitionBuild.ID()).To(Equal(olderBuild.ID()))
+		})
+
+		It("no longer returns a finished build once it is deleted", func() {
+			olderBuild, err := job.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = olderBuild.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			newerBuild, err := job.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = newerBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = newerBuild.Delete()
+			Expect(err).ToNot(HaveOccurred())
+
+			dashboard, err := pipeline.Dashboard("transitionBuilds")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dashboard[0].FinishedBuild.ID()).To(Equal(olderBuild.ID()))
+			Expect(dashboard[0].TransitionBuild.ID()).To(Equal(olderBuild.ID()))
+		})
 	})
 
 	Describe("DeleteBuildEventsByBuildIDs", func() {
 		It("deletes all build logs corresponding to the given build ids", func() {
-			build1DB, err := team.CreateOneOffBuild()
+			build1DB, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build1DB.SaveEvent(event.Log{
@@ -2243,7 +2929,7 @@ var _ = Describe("Pipeline", func() {
 			})
 			Expect(err).ToNot(HaveOccurred())
 
-			build2DB, err := team.CreateOneOffBuild()
+			build2DB, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build2DB.SaveEvent(event.Log{
@@ -2251,7 +2937,7 @@ var _ = Describe("Pipeline", func() {
 			})
 			Expect(err).ToNot(HaveOccurred())
 
-			build3DB, err := team.CreateOneOffBuild()
+			build3DB, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build3DB.Finish(db.BuildStatusSucceeded)
@@ -2263,7 +2949,7 @@ var _ = Describe("Pipeline", func() {
 			err = build2DB.Finish(db.BuildStatusSucceeded)
 			Expect(err).ToNot(HaveOccurred())
 
-			build4DB, err := team.CreateOneOffBuild()
+			build4DB, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			By("doing nothing if the list is empty")
@@ -2374,16 +3060,18 @@ var _ = Describe("Pipeline", func() {
 		var expectedBuilds []db.Build
 
 		BeforeEach(func() {
+			expectedBuilds = nil
+
 			job, found, err := pipeline.Job("job-name")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err := job.CreateBuild()
+			secondBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -2391,7 +3079,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = someOtherJob.CreateBuild()
+			_, err = someOtherJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			dbBuild, found, err := buildFactory.Build(build.ID())
@@ -2460,6 +3148,21 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(builds).To(Equal([]db.Build{}))
 		})
+
+		Describe("GetBuildsWithVersionsAsInput", func() {
+			It("returns the builds for which each version was an input", func() {
+				builds, err := pipeline.GetBuildsWithVersionsAsInput([]int{savedVersionedResourceID, savedVersionedResourceID + 100})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[savedVersionedResourceID]).To(ConsistOf(expectedBuilds))
+			})
+
+			It("returns no builds when no versions are given", func() {
+				builds, err := pipeline.GetBuildsWithVersionsAsInput(nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+		})
 	})
 
 	Describe("GetBuildsWithVersionAsOutput", func() {
@@ -2471,11 +3174,11 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err := job.CreateBuild()
+			secondBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -2483,7 +3186,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = someOtherJob.CreateBuild()
+			_, err = someOtherJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			dbBuild, found, err := buildFactory.Build(build.ID())
@@ -2549,22 +3252,111 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("SearchResourceVersions", func() {
+		var taggedID, otherTaggedID, untaggedID int
+
+		saveVersion := func(resourceName string, ref string, metadata ...db.ResourceMetadataField) int {
+			job, found, err := pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveOutput(db.VersionedResource{
+				Resource: resourceName,
+				Type:     "some-type",
+				Version:  db.ResourceVersion{"ref": ref},
+				Metadata: metadata,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			versionedResources, err := build.GetVersionedResources()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versionedResources).To(HaveLen(1))
+
+			return versionedResources[0].ID
+		}
+
+		search := func(search db.ResourceVersionSearch) []int {
+			versions, err := pipeline.SearchResourceVersions(search)
+			Expect(err).ToNot(HaveOccurred())
+
+			ids := []int{}
+			for _, version := range versions {
+				ids = append(ids, version.ID)
+			}
+
+			return ids
+		}
+
+		BeforeEach(func() {
+			taggedID = saveVersion("some-resource", "abc123def", db.ResourceMetadataField{Name: "tag", Value: "v1.0"})
+			otherTaggedID = saveVersion("some-other-resource", "abc456def", db.ResourceMetadataField{Name: "tag", Value: "v1.1"})
+			untaggedID = saveVersion("some-resource", "fed321cba", db.ResourceMetadataField{Name: "author", Value: "abc"})
+		})
+
+		It("finds versions by a prefix of any of their fields, newest first", func() {
+			Expect(search(db.ResourceVersionSearch{Value: "abc"})).To(Equal([]int{untaggedID, otherTaggedID, taggedID}))
+			Expect(search(db.ResourceVersionSearch{Value: "abc123"})).To(Equal([]int{taggedID}))
+			Expect(search(db.ResourceVersionSearch{Value: "v1."})).To(Equal([]int{otherTaggedID, taggedID}))
+		})
+
+		It("restricts the search to the given field", func() {
+			Expect(search(db.ResourceVersionSearch{Value: "abc", Field: "ref"})).To(Equal([]int{otherTaggedID, taggedID}))
+			Expect(search(db.ResourceVersionSearch{Value: "abc", Field: "author"})).To(Equal([]int{untaggedID}))
+		})
+
+		It("restricts the search to the given resource", func() {
+			Expect(search(db.ResourceVersionSearch{Value: "v1", Resource: "some-resource"})).To(Equal([]int{taggedID}))
+		})
+
+		It("limits the number of versions found", func() {
+			Expect(search(db.ResourceVersionSearch{Value: "abc", Limit: 2})).To(Equal([]int{untaggedID, otherTaggedID}))
+		})
+
+		It("matches wildcards literally", func() {
+			Expect(search(db.ResourceVersionSearch{Value: "v1_"})).To(BeEmpty())
+			Expect(search(db.ResourceVersionSearch{Value: "%"})).To(BeEmpty())
+		})
+
+		It("searches the metadata the version was last saved with", func() {
+			job, found, err := pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveOutput(db.VersionedResource{
+				Resource: "some-resource",
+				Type:     "some-type",
+				Version:  db.ResourceVersion{"ref": "abc123def"},
+				Metadata: []db.ResourceMetadataField{{Name: "tag", Value: "v2.0"}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(search(db.ResourceVersionSearch{Value: "v1.0"})).To(BeEmpty())
+			Expect(search(db.ResourceVersionSearch{Value: "v2.0"})).To(Equal([]int{taggedID}))
+		})
+	})
+
 	Describe("Builds", func() {
 		var expectedBuilds []db.Build
 
 		BeforeEach(func() {
-			_, err := team.CreateOneOffBuild()
+			_, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			job, found, err := pipeline.Job("job-name")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err := job.CreateBuild()
+			secondBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -2572,7 +3364,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			thirdBuild, err := someOtherJob.CreateBuild()
+			thirdBuild, err := someOtherJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, thirdBuild)
 		})
@@ -2583,4 +3375,98 @@ var _ = Describe("Pipeline", func() {
 			Expect(builds).To(ConsistOf(expectedBuilds))
 		})
 	})
+
+	Describe("BuildCalendar", func() {
+		var (
+			location *time.Location
+			from     time.Time
+			to       time.Time
+		)
+
+		startBuild := func(job db.Job, status db.BuildStatus, start time.Time, duration time.Duration) {
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`
+				UPDATE builds
+				SET status = $1, start_time = $2, end_time = $3
+				WHERE id = $4
+			`, string(status), start, start.Add(duration), build.ID())
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			var err error
+			location, err = time.LoadLocation("America/New_York")
+			Expect(err).ToNot(HaveOccurred())
+
+			from = time.Date(2018, 3, 10, 0, 0, 0, 0, location)
+			to = time.Date(2018, 3, 13, 0, 0, 0, 0, location)
+
+			otherJob, found, err := pipeline.Job("some-other-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			// 11pm local on either side of the switch to daylight saving time
+			startBuild(job, db.BuildStatusSucceeded, time.Date(2018, 3, 10, 23, 0, 0, 0, location), time.Minute)
+			startBuild(job, db.BuildStatusFailed, time.Date(2018, 3, 10, 23, 30, 0, 0, location), 2*time.Minute)
+			startBuild(job, db.BuildStatusSucceeded, time.Date(2018, 3, 11, 23, 0, 0, 0, location), time.Minute)
+			startBuild(otherJob, db.BuildStatusErrored, time.Date(2018, 3, 11, 23, 15, 0, 0, location), time.Minute)
+
+			// outside of the range
+			startBuild(job, db.BuildStatusSucceeded, time.Date(2018, 3, 13, 0, 0, 0, 0, location), time.Minute)
+
+			// not started
+			_, err = job.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts builds per job and day in the given location", func() {
+			entries, err := pipeline.BuildCalendar(from, to, db.BuildCalendarDaily, location)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(HaveLen(3))
+
+			Expect(entries[0].JobName).To(Equal("job-name"))
+			Expect(entries[0].Start.Equal(time.Date(2018, 3, 10, 0, 0, 0, 0, location))).To(BeTrue())
+			Expect(entries[0].Builds).To(Equal(2))
+			Expect(entries[0].Succeeded).To(Equal(1))
+			Expect(entries[0].Failed).To(Equal(1))
+			Expect(entries[0].Duration).To(Equal(3 * time.Minute))
+
+			Expect(entries[1].JobName).To(Equal("job-name"))
+			Expect(entries[1].Start.Equal(time.Date(2018, 3, 11, 0, 0, 0, 0, location))).To(BeTrue())
+			Expect(entries[1].Builds).To(Equal(1))
+
+			Expect(entries[2].JobName).To(Equal("some-other-job"))
+			Expect(entries[2].Start.Equal(time.Date(2018, 3, 11, 0, 0, 0, 0, location))).To(BeTrue())
+			Expect(entries[2].Builds).To(Equal(1))
+			Expect(entries[2].Errored).To(Equal(1))
+		})
+
+		It("counts builds per hour", func() {
+			entries, err := pipeline.BuildCalendar(from, to, db.BuildCalendarHourly, location)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(HaveLen(3))
+
+			Expect(entries[0].Start.Equal(time.Date(2018, 3, 10, 23, 0, 0, 0, location))).To(BeTrue())
+			Expect(entries[0].Builds).To(Equal(2))
+		})
+
+		It("groups days by UTC when asked to", func() {
+			entries, err := pipeline.BuildCalendar(from, to, db.BuildCalendarDaily, time.UTC)
+			Expect(err).ToNot(HaveOccurred())
+
+			var starts []time.Time
+			for _, entry := range entries {
+				starts = append(starts, entry.Start)
+			}
+
+			// 11pm in New York is the following day in UTC
+			Expect(starts).To(ConsistOf(
+				time.Date(2018, 3, 11, 0, 0, 0, 0, time.UTC),
+				time.Date(2018, 3, 12, 0, 0, 0, 0, time.UTC),
+				time.Date(2018, 3, 12, 0, 0, 0, 0, time.UTC),
+			))
+		})
+	})
 })
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNotifyUnsupported is returned when notifying a channel on a
+// NotificationsBus that can only be listened to.
+var ErrNotifyUnsupported = errors.New("notifications cannot be sent on a read-only connection")
+
+type pollingNotificationsBus struct {
+	interval time.Duration
+
+	listeners  map[chan bool]chan struct{}
+	listenersL sync.Mutex
+}
+
+// NewPollingNotificationsBus returns a NotificationsBus which notifies every
+// listener on each interval, regardless of channel, for databases which do
+// not support LISTEN. Listeners check for changes whenever they are notified,
+// so this trades promptness for extra queries.
+func NewPollingNotificationsBus(interval time.Duration) NotificationsBus {
+	return &pollingNotificationsBus{
+		interval: interval,
+
+		listeners: make(map[chan bool]chan struct{}),
+	}
+}
+
+func (bus *pollingNotificationsBus) Listen(channel string) (chan bool, error) {
+	// buffer so that notifications can be nonblocking (only need one at a time)
+	notify := make(chan bool, 1)
+	stop := make(chan struct{})
+
+	bus.listenersL.Lock()
+	bus.listeners[notify] = stop
+	bus.listenersL.Unlock()
+
+	go bus.poll(notify, stop)
+
+	return notify, nil
+}
+
+func (bus *pollingNotificationsBus) Notify(channel string) error {
+	return ErrNotifyUnsupported
+}
+
+func (bus *pollingNotificationsBus) Unlisten(channel string, notify chan bool) error {
+	bus.listenersL.Lock()
+	stop, found := bus.listeners[notify]
+	delete(bus.listeners, notify)
+	bus.listenersL.Unlock()
+
+	if found {
+		close(stop)
+	}
+
+	return nil
+}
+
+func (bus *pollingNotificationsBus) Close() error {
+	bus.listenersL.Lock()
+	for notify, stop := range bus.listeners {
+		delete(bus.listeners, notify)
+		close(stop)
+	}
+	bus.listenersL.Unlock()
+
+	return nil
+}
+
+func (bus *pollingNotificationsBus) poll(notify chan bool, stop chan struct{}) {
+	ticker := time.NewTicker(bus.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			select {
+			case notify <- true:
+			default:
+			}
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PollingNotificationsBus", func() {
+	var bus db.NotificationsBus
+
+	BeforeEach(func() {
+		bus = db.NewPollingNotificationsBus(10 * time.Millisecond)
+	})
+
+	AfterEach(func() {
+		Expect(bus.Close()).To(Succeed())
+	})
+
+	It("notifies listeners on each interval", func() {
+		notify, err := bus.Listen("some-channel")
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(notify).Should(Receive(BeTrue()))
+		Eventually(notify).Should(Receive(BeTrue()))
+	})
+
+	It("stops notifying listeners once they unlisten", func() {
+		notify, err := bus.Listen("some-channel")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(bus.Unlisten("some-channel", notify)).To(Succeed())
+
+		// drain a notification sent before unlistening
+		select {
+		case <-notify:
+		default:
+		}
+
+		Consistently(notify, 50*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("does not support notifying", func() {
+		Expect(bus.Notify("some-channel")).To(Equal(db.ErrNotifyUnsupported))
+	})
+})
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . ReclaimableVolume
+
+// ReclaimableVolume is a volume holding a resource cache or task cache which
+// is not in use by any container, and so can be reclaimed to free up space on
+// its worker. The cache is fetched or built again the next time it is needed.
+type ReclaimableVolume interface {
+	Handle() string
+	WorkerName() string
+	TeamName() string
+	CreatedAt() time.Time
+
+	// LastUsedAt is when the volume was last mounted into a container, or
+	// when it was created if it has not been since.
+	LastUsedAt() time.Time
+
+	// WorkerDiskUsage is the percentage of the volume's worker's disk in use,
+	// as reported with its last heartbeat.
+	WorkerDiskUsage() int
+
+	// Size is the size of the volume in bytes, as last reported by its
+	// worker, or 0 if it has not been reported.
+	Size() int64
+
+	// TeamCacheUsage is the disk space in bytes used by all of the caches of
+	// the volume's team, as last aggregated by the CacheUsageLedger.
+	TeamCacheUsage() int64
+
+	// Reclaim releases the cache the volume holds, so that it is garbage
+	// collected as an orphaned volume. It returns false if the volume came to
+	// be used in the meantime.
+	Reclaim() (bool, error)
+}
+
+type reclaimableVolume struct {
+	id                    int
+	handle                string
+	workerName            string
+	teamName              string
+	createdAt             time.Time
+	lastUsedAt            time.Time
+	workerDiskUsage       int
+	size                  int64
+	teamCacheUsage        int64
+	workerResourceCacheID *int
+	workerTaskCacheID     *int
+
+	conn Conn
+}
+
+func (volume *reclaimableVolume) Handle() string        { return volume.handle }
+func (volume *reclaimableVolume) WorkerName() string    { return volume.workerName }
+func (volume *reclaimableVolume) TeamName() string      { return volume.teamName }
+func (volume *reclaimableVolume) CreatedAt() time.Time  { return volume.createdAt }
+func (volume *reclaimableVolume) LastUsedAt() time.Time { return volume.lastUsedAt }
+func (volume *reclaimableVolume) WorkerDiskUsage() int  { return volume.workerDiskUsage }
+func (volume *reclaimableVolume) Size() int64           { return volume.size }
+func (volume *reclaimableVolume) TeamCacheUsage() int64 { return volume.teamCacheUsage }
+
+// resourceCacheInUse finds the uses of the resource cache held by the volume
+// v, by builds, containers and resources, and by builds keeping the caches of
+// their images.
+const resourceCacheInUse = `
+	SELECT 1 FROM worker_resource_caches wrc
+	WHERE wrc.id = v.worker_resource_cache_id
+	AND (
+		EXISTS (SELECT 1 FROM resource_cache_uses rcu WHERE rcu.resource_cache_id = wrc.resource_cache_id)
+		OR EXISTS (SELECT 1 FROM build_image_resource_caches birc WHERE birc.resource_cache_id = wrc.resource_cache_id)
+	)
+`
+
+func (volume *reclaimableVolume) Reclaim() (bool, error) {
+	table, id := "worker_task_caches", volume.workerTaskCacheID
+	if volume.workerResourceCacheID != nil {
+		table, id = "worker_resource_caches", volume.workerResourceCacheID
+	}
+
+	if id == nil {
+		return false, nil
+	}
+
+	// the volume's reference to the cache is set to NULL by the foreign key,
+	// leaving it orphaned
+	result, err := psql.Delete(table).
+		Where(sq.Eq{"id": *id}).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM volumes WHERE parent_id = ? OR (id = ? AND container_id IS NOT NULL))", volume.id, volume.id)).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM volumes v WHERE v.id = ? AND EXISTS ("+resourceCacheInUse+"))", volume.id)).
+		RunWith(volume.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
@@ -0,0 +1,201 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Runner runs queries outside of a transaction, e.g. on a Conn or a replica.
+type Runner interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) squirrel.RowScanner
+}
+
+// How far behind the primary a replica may be for each kind of read routed to
+// it. Reads are sent to the primary while the replica lags further.
+const (
+	buildListStaleness       = 5 * time.Second
+	resourceVersionStaleness = 10 * time.Second
+	dashboardStaleness       = 5 * time.Second
+)
+
+// replicaLagTTL is how long a replica's measured lag is trusted before it is
+// measured again.
+const replicaLagTTL = time.Second
+
+// replicaLagTimeout is how long to wait for a replica to report its lag before
+// giving up on it, and replicaFailureBackoff is how long reads are then sent
+// to the primary before the replica is measured again.
+const (
+	replicaLagTimeout     = time.Second
+	replicaFailureBackoff = 10 * time.Second
+)
+
+var (
+	errReplicaLagUnknown  = errors.New("replica lag not yet measured")
+	errReplicaLagTimedOut = errors.New("timed out measuring replica lag")
+)
+
+// ReadFromReplica returns a Conn which sends reads that tolerate staleness,
+// e.g. listing builds, to the given replica, so long as it is not lagging
+// too far behind. Everything else, including every write and transaction, is
+// sent to the primary.
+func ReadFromReplica(conn Conn, replica Conn) Conn {
+	return &replicaConn{
+		Conn:    conn,
+		replica: replica,
+	}
+}
+
+type replicaConn struct {
+	Conn
+
+	replica Conn
+
+	lagL       sync.Mutex
+	lag        time.Duration
+	lagErr     error
+	measuredAt time.Time
+	measuring  bool
+}
+
+type lagMeasurement struct {
+	lag time.Duration
+	err error
+}
+
+func (c *replicaConn) Replica(maxStaleness time.Duration) Runner {
+	lag, err := c.replicaLag()
+	if err != nil || lag > maxStaleness {
+		return nil
+	}
+
+	return c.replica
+}
+
+func (c *replicaConn) SetMaxIdleConns(n int) {
+	c.Conn.SetMaxIdleConns(n)
+	c.replica.SetMaxIdleConns(n)
+}
+
+func (c *replicaConn) SetMaxOpenConns(n int) {
+	c.Conn.SetMaxOpenConns(n)
+	c.replica.SetMaxOpenConns(n)
+}
+
+func (c *replicaConn) Close() error {
+	err := c.replica.Close()
+	if err != nil {
+		return err
+	}
+
+	return c.Conn.Close()
+}
+
+// replicaLag returns the replica's last measured lag, measuring it again once
+// it is out of date. Only one measurement runs at a time, and lagL is not held
+// while it runs, so a hung replica holds up a single read for at most
+// replicaLagTimeout rather than queueing every read behind it. Everything else
+// is given the last measurement in the meantime.
+func (c *replicaConn) replicaLag() (time.Duration, error) {
+	c.lagL.Lock()
+
+	ttl := replicaLagTTL
+	if c.lagErr != nil {
+		ttl = replicaFailureBackoff
+	}
+
+	if c.measuring || time.Since(c.measuredAt) < ttl {
+		lag, err := c.lag, c.lagErr
+		if c.measuredAt.IsZero() {
+			err = errReplicaLagUnknown
+		}
+
+		c.lagL.Unlock()
+		return lag, err
+	}
+
+	c.measuring = true
+	c.lagL.Unlock()
+
+	measured := make(chan lagMeasurement, 1)
+	go func() {
+		lag, err := c.measureReplicaLag()
+		measured <- lagMeasurement{lag, err}
+	}()
+
+	var measurement lagMeasurement
+	timedOut := false
+
+	timer := time.NewTimer(replicaLagTimeout)
+	defer timer.Stop()
+
+	select {
+	case measurement = <-measured:
+	case <-timer.C:
+		measurement = lagMeasurement{err: errReplicaLagTimedOut}
+		timedOut = true
+	}
+
+	c.lagL.Lock()
+	c.lag = measurement.lag
+	c.lagErr = measurement.err
+	c.measuredAt = time.Now()
+	if !timedOut {
+		c.measuring = false
+	}
+	c.lagL.Unlock()
+
+	if timedOut {
+		// don't measure again until the hung query returns, so that they do
+		// not pile up on the replica
+		go func() {
+			<-measured
+
+			c.lagL.Lock()
+			c.measuring = false
+			c.lagL.Unlock()
+		}()
+	}
+
+	return measurement.lag, measurement.err
+}
+
+func (c *replicaConn) measureReplicaLag() (time.Duration, error) {
+	var inRecovery bool
+	var lagSeconds sql.NullFloat64
+	err := c.replica.QueryRow(`
+		SELECT pg_is_in_recovery(), EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())
+	`).Scan(&inRecovery, &lagSeconds)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case !inRecovery:
+		// not replicating at all, e.g. the primary itself
+		return 0, nil
+	case !lagSeconds.Valid:
+		// nothing has been replayed yet
+		return time.Duration(math.MaxInt64), nil
+	default:
+		return time.Duration(lagSeconds.Float64 * float64(time.Second)), nil
+	}
+}
+
+// reader returns the replica of conn to read from if it is within
+// maxStaleness of the primary, and otherwise conn itself.
+func reader(conn Conn, maxStaleness time.Duration) Runner {
+	replica := conn.Replica(maxStaleness)
+	if replica != nil {
+		return replica
+	}
+
+	return conn
+}
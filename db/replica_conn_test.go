@@ -0,0 +1,124 @@
+package db_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReadFromReplica", func() {
+	var (
+		replica     db.Conn
+		replicaConn db.Conn
+	)
+
+	BeforeEach(func() {
+		replica = postgresRunner.OpenConn()
+		replicaConn = db.ReadFromReplica(dbConn, replica)
+	})
+
+	AfterEach(func() {
+		_ = replica.Close()
+	})
+
+	Context("when the replica is not behind", func() {
+		It("reads from the replica", func() {
+			Expect(replicaConn.Replica(time.Second)).To(BeIdenticalTo(replica))
+		})
+
+		It("serves builds from it", func() {
+			build, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			team := db.NewTeamFactory(replicaConn, lockFactory).GetByID(defaultTeam.ID())
+
+			builds, _, err := team.Builds(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(build.ID()))
+		})
+	})
+
+	Context("when the replica's lag cannot be measured", func() {
+		var fakeReplica *dbfakes.FakeConn
+
+		BeforeEach(func() {
+			fakeReplica = new(dbfakes.FakeConn)
+			fakeReplica.QueryRowReturns(errorScanner{errors.New("nope")})
+
+			replicaConn = db.ReadFromReplica(dbConn, fakeReplica)
+		})
+
+		It("reads from the primary", func() {
+			Expect(replicaConn.Replica(time.Second)).To(BeNil())
+		})
+
+		It("does not measure it again straight away", func() {
+			Expect(replicaConn.Replica(time.Second)).To(BeNil())
+			Expect(replicaConn.Replica(time.Second)).To(BeNil())
+			Expect(fakeReplica.QueryRowCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the replica does not report its lag", func() {
+		var (
+			fakeReplica *dbfakes.FakeConn
+			unblock     chan struct{}
+		)
+
+		BeforeEach(func() {
+			unblock = make(chan struct{})
+
+			fakeReplica = new(dbfakes.FakeConn)
+			fakeReplica.QueryRowStub = func(string, ...interface{}) squirrel.RowScanner {
+				<-unblock
+				return errorScanner{errors.New("nope")}
+			}
+
+			replicaConn = db.ReadFromReplica(dbConn, fakeReplica)
+		})
+
+		AfterEach(func() {
+			close(unblock)
+		})
+
+		It("gives up on it and reads from the primary", func() {
+			start := time.Now()
+			Expect(replicaConn.Replica(time.Second)).To(BeNil())
+			Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+		})
+
+		It("does not hold up other reads while waiting for it", func() {
+			done := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(done)
+				replicaConn.Replica(time.Second)
+			}()
+
+			Eventually(fakeReplica.QueryRowCallCount).Should(Equal(1))
+
+			start := time.Now()
+			Expect(replicaConn.Replica(time.Second)).To(BeNil())
+			Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+
+			Eventually(done, 5*time.Second).Should(BeClosed())
+			Expect(replicaConn.Replica(time.Second)).To(BeNil())
+			Expect(fakeReplica.QueryRowCallCount()).To(Equal(1))
+		})
+	})
+})
+
+type errorScanner struct {
+	err error
+}
+
+func (scanner errorScanner) Scan(...interface{}) error {
+	return scanner.err
+}
@@ -30,15 +30,24 @@ type Resource interface {
 
 	SetResourceConfig(int) error
 
-	Pause() error
-	Unpause() error
+	Pause(from ConfigVersion) (ConfigVersion, error)
+	Unpause(from ConfigVersion) (ConfigVersion, error)
+
+	WebhookTokens() ([]ResourceWebhookToken, error)
+	CreateWebhookToken() (ResourceWebhookToken, string, error)
+	DeleteWebhookToken(id int) (bool, error)
+	UseWebhookToken(token string) (bool, error)
+
+	SaveCheckResult(result ResourceCheckResult) error
+	CheckResults(limit int) ([]ResourceCheckResult, error)
 
 	Reload() (bool, error)
 }
 
-var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, r.paused, r.last_checked, r.pipeline_id, p.name, r.nonce").
+var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, r.paused, r.last_checked, r.pipeline_id, p.name, r.nonce, r.team_keyed, t.data_key, t.data_key_nonce").
 	From("resources r").
 	Join("pipelines p ON p.id = r.pipeline_id").
+	Join("teams t ON t.id = p.team_id").
 	Where(sq.Eq{"r.active": true})
 
 type resource struct {
@@ -127,42 +136,73 @@ func (r *resource) Reload() (bool, error) {
 	return true, nil
 }
 
-func (r *resource) Pause() error {
-	_, err := psql.Update("resources").
-		Set("paused", true).
-		Where(sq.Eq{
-			"id": r.id,
-		}).
-		RunWith(r.conn).
-		Exec()
+func (r *resource) Pause(from ConfigVersion) (ConfigVersion, error) {
+	return compareAndSwap(r.conn, r.pipelineID, from, func(tx Tx) error {
+		_, err := psql.Update("resources").
+			Set("paused", true).
+			Where(sq.Eq{
+				"id": r.id,
+			}).
+			RunWith(tx).
+			Exec()
 
-	return err
+		return err
+	})
 }
 
-func (r *resource) Unpause() error {
-	_, err := psql.Update("resources").
-		Set("paused", false).
-		Where(sq.Eq{
-			"id": r.id,
-		}).
-		RunWith(r.conn).
-		Exec()
+func (r *resource) Unpause(from ConfigVersion) (ConfigVersion, error) {
+	return compareAndSwap(r.conn, r.pipelineID, from, func(tx Tx) error {
+		_, err := psql.Update("resources").
+			Set("paused", false).
+			Where(sq.Eq{
+				"id": r.id,
+			}).
+			RunWith(tx).
+			Exec()
 
-	return err
+		return err
+	})
 }
 
+// SetResourceConfig records the config the resource is checked with. When
+// the config changes, any history of versions shared by the resources with
+// the new config is saved to the resource's versions.
 func (r *resource) SetResourceConfig(resourceConfigID int) error {
-	_, err := psql.Update("resources").
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("resources").
 		Set("resource_config_id", resourceConfigID).
 		Where(sq.Eq{"id": r.id}).
 		Where(sq.Or{
 			sq.Eq{"resource_config_id": nil},
 			sq.NotEq{"resource_config_id": resourceConfigID},
 		}).
-		RunWith(r.conn).
+		RunWith(tx).
 		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return nil
+	}
+
+	err = backfillResourceConfigVersions(tx, r.id, r.type_, resourceConfigID)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return tx.Commit()
 }
 
 func scanResource(r *resource, row scannable) error {
@@ -170,23 +210,18 @@ func scanResource(r *resource, row scannable) error {
 		configBlob      []byte
 		checkErr, nonce sql.NullString
 		lastChecked     pq.NullTime
+		teamKeyed       bool
+		dataKey         teamDataKey
 	)
 
-	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &r.paused, &lastChecked, &r.pipelineID, &r.pipelineName, &nonce)
+	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &r.paused, &lastChecked, &r.pipelineID, &r.pipelineName, &nonce, &teamKeyed, &dataKey.key, &dataKey.nonce)
 	if err != nil {
 		return err
 	}
 
 	r.lastChecked = lastChecked.Time
 
-	es := r.conn.EncryptionStrategy()
-
-	var noncense *string
-	if nonce.Valid {
-		noncense = &nonce.String
-	}
-
-	decryptedConfig, err := es.Decrypt(string(configBlob), noncense)
+	decryptedConfig, err := dataKey.decryptConfig(r.conn.EncryptionStrategy(), string(configBlob), nonce, teamKeyed)
 	if err != nil {
 		return err
 	}
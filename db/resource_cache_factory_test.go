@@ -140,7 +140,7 @@ var _ = Describe("ResourceCacheFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 		}
 
-		build, err = defaultTeam.CreateOneOffBuild()
+		build, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 		Expect(err).NotTo(HaveOccurred())
 
 		logger = lagertest.NewTestLogger("test")
@@ -73,6 +73,15 @@ func (f *resourceCacheLifecycle) CleanUpInvalidCaches(logger lager.Logger) error
 		return err
 	}
 
+	warmResourceTypeCacheIds, _, err := sq.
+		Select("resource_cache_id").
+		From("worker_warm_resource_types").
+		Where(sq.NotEq{"resource_cache_id": nil}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
 	nextBuildInputsCacheIds, _, err := sq.
 		Select("r_cache.id").
 		From("next_build_inputs nbi").
@@ -94,6 +103,7 @@ func (f *resourceCacheLifecycle) CleanUpInvalidCaches(logger lager.Logger) error
 			stillInUseCacheIds,
 			resourceConfigCacheIds,
 			buildImageCacheIds,
+			warmResourceTypeCacheIds,
 			nextBuildInputsCacheIds,
 		}, " UNION ") + ")").
 		Suffix("RETURNING id").
@@ -25,13 +25,13 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 	Describe("CleanUpInvalidCaches", func() {
 		Context("the resource cache is used by a build", func() {
 			resourceCacheForOneOffBuild := func() (*db.UsedResourceCache, db.Build) {
-				build, err := defaultTeam.CreateOneOffBuild()
+				build, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 				return createResourceCacheWithUser(db.ForBuild(build.ID())), build
 			}
 
 			resourceCacheForJobBuild := func() (*db.UsedResourceCache, db.Build) {
-				build, err := defaultJob.CreateBuild()
+				build, err := defaultJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 				return createResourceCacheWithUser(db.ForBuild(build.ID())), build
 			}
@@ -309,7 +309,7 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 
 		Context("when the cache is for a resource version used as an input for the next build of a job", func() {
 			It("does not remove the cache", func() {
-				err := defaultPipeline.Unpause()
+				_, err := defaultPipeline.Unpause(db.AnyConfigVersion)
 				Expect(err).ToNot(HaveOccurred())
 
 				resourceConfigCheckSession, err := resourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSession(
@@ -35,7 +35,7 @@ var _ = Describe("ResourceCache", func() {
 
 		BeforeEach(func() {
 			var err error
-			build, err = defaultTeam.CreateOneOffBuild()
+			build, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -116,7 +116,7 @@ var _ = Describe("ResourceCache", func() {
 			}, 0)
 			Expect(err).ToNot(HaveOccurred())
 
-			build, err := defaultTeam.CreateOneOffBuild()
+			build, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			container, err = defaultTeam.CreateContainer(
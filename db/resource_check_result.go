@@ -0,0 +1,106 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ResourceCheckResult is the outcome of a check of a resource: when it ran,
+// the error it failed with if any, and how many versions it returned.
+type ResourceCheckResult struct {
+	ID           int
+	StartTime    time.Time
+	EndTime      time.Time
+	Error        string
+	VersionCount int
+}
+
+// Succeeded returns whether the check finished without error.
+func (result ResourceCheckResult) Succeeded() bool {
+	return result.Error == ""
+}
+
+// resourceCheckResultsRetained is the number of check results kept for each
+// resource; older results are removed as new ones are saved.
+const resourceCheckResultsRetained = 100
+
+// SaveCheckResult saves the result of a check of the resource, removing the
+// oldest results beyond those retained.
+func (r *resource) SaveCheckResult(result ResourceCheckResult) error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var checkErr sql.NullString
+	if result.Error != "" {
+		checkErr = sql.NullString{String: result.Error, Valid: true}
+	}
+
+	_, err = psql.Insert("resource_check_results").
+		Columns("resource_id", "start_time", "end_time", "error", "version_count").
+		Values(r.id, result.StartTime, result.EndTime, checkErr, result.VersionCount).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM resource_check_results
+		WHERE resource_id = $1
+		AND id <= (
+			SELECT id
+			FROM resource_check_results
+			WHERE resource_id = $1
+			ORDER BY id DESC
+			OFFSET $2
+			LIMIT 1
+		)
+	`, r.id, resourceCheckResultsRetained)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CheckResults returns the latest results of checks of the resource, newest
+// first, up to the limit.
+func (r *resource) CheckResults(limit int) ([]ResourceCheckResult, error) {
+	rows, err := psql.Select("id", "start_time", "end_time", "error", "version_count").
+		From("resource_check_results").
+		Where(sq.Eq{"resource_id": r.id}).
+		OrderBy("id DESC").
+		Limit(uint64(limit)).
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	results := []ResourceCheckResult{}
+	for rows.Next() {
+		var (
+			result   ResourceCheckResult
+			checkErr sql.NullString
+		)
+
+		err = rows.Scan(&result.ID, &result.StartTime, &result.EndTime, &checkErr, &result.VersionCount)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Error = checkErr.String
+
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
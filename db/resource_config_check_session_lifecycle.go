@@ -9,6 +9,13 @@ import (
 type ResourceConfigCheckSessionLifecycle interface {
 	CleanInactiveResourceConfigCheckSessions() error
 	CleanExpiredResourceConfigCheckSessions() error
+
+	// CleanResourceConfigCheckSessionsOnPressuredWorkers releases the check
+	// containers of workers under memory or disk pressure, so that they are
+	// garbage collected and the next checks run on other workers. A check
+	// container is only released if another running worker which is not under
+	// pressure can run the check instead.
+	CleanResourceConfigCheckSessionsOnPressuredWorkers() error
 }
 
 type resourceConfigCheckSessionLifecycle struct {
@@ -55,6 +62,35 @@ func (lifecycle resourceConfigCheckSessionLifecycle) CleanInactiveResourceConfig
 	return err
 }
 
+func (lifecycle resourceConfigCheckSessionLifecycle) CleanResourceConfigCheckSessionsOnPressuredWorkers() error {
+	// another worker can take the check over if it has the same resource
+	// type, is visible to the team and takes the same tagged work
+	compatibleWorkerAvailable, _, err := sq.
+		Select("1").
+		From("worker_base_resource_types owbrt").
+		Join("workers ow ON ow.name = owbrt.worker_name").
+		Where(sq.Expr("owbrt.base_resource_type_id = wbrt.base_resource_type_id")).
+		Where(sq.Expr("ow.name <> w.name")).
+		Where(sq.Expr("ow.state = 'running'")).
+		Where(sq.Expr("NOT ow.memory_pressure AND NOT ow.disk_pressure")).
+		Where(sq.Expr("(ow.team_id IS NULL OR ow.team_id = wrccs.team_id)")).
+		Where(sq.Expr("COALESCE(ow.tags, '[]') = COALESCE(w.tags, '[]')")).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete("worker_resource_config_check_sessions wrccs USING worker_base_resource_types wbrt, workers w").
+		Where(sq.Expr("wbrt.id = wrccs.worker_base_resource_type_id")).
+		Where(sq.Expr("w.name = wbrt.worker_name")).
+		Where(sq.Expr("w.memory_pressure OR w.disk_pressure")).
+		Where("EXISTS (" + compatibleWorkerAvailable + ")").
+		RunWith(lifecycle.conn).
+		Exec()
+
+	return err
+}
+
 func (lifecycle resourceConfigCheckSessionLifecycle) CleanExpiredResourceConfigCheckSessions() error {
 	_, err := psql.Delete("resource_config_check_sessions").
 		Where(sq.Expr("expires_at < NOW()")).
@@ -20,6 +20,107 @@ var _ = Describe("ResourceConfigCheckSessionLifecycle", func() {
 		lifecycle = db.NewResourceConfigCheckSessionLifecycle(dbConn)
 	})
 
+	Describe("CleanResourceConfigCheckSessionsOnPressuredWorkers", func() {
+		var owner db.ContainerOwner
+
+		BeforeEach(func() {
+			resourceConfigCheckSession, err := resourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSession(logger,
+				defaultResource.Type(),
+				defaultResource.Source(),
+				creds.VersionedResourceTypes{},
+				db.ContainerOwnerExpiries{
+					GraceTime: 5 * time.Second,
+					Min:       1 * time.Minute,
+					Max:       1 * time.Minute,
+				},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			owner = db.NewResourceConfigCheckSessionContainerOwner(resourceConfigCheckSession, defaultTeam.ID())
+
+			_, err = defaultTeam.CreateContainer(defaultWorker.Name(), owner, db.ContainerMetadata{Type: db.ContainerTypeCheck})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("keeps check sessions on workers not under pressure", func() {
+			Expect(lifecycle.CleanResourceConfigCheckSessionsOnPressuredWorkers()).To(Succeed())
+
+			_, found, err := owner.Find(dbConn)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		Context("when the worker is under pressure", func() {
+			BeforeEach(func() {
+				By("reporting pressure with a heartbeat")
+				pressuredWorker := defaultWorkerPayload
+				pressuredWorker.DiskPressure = true
+				_, err := workerFactory.HeartbeatWorker(pressuredWorker, 0)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps check sessions when no other worker can run the checks", func() {
+				Expect(lifecycle.CleanResourceConfigCheckSessionsOnPressuredWorkers()).To(Succeed())
+
+				_, found, err := owner.Find(dbConn)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			Context("when another worker can run the checks", func() {
+				var otherWorker atc.Worker
+
+				BeforeEach(func() {
+					otherWorker = defaultWorkerPayload
+					otherWorker.Name = "other-worker"
+					otherWorker.GardenAddr = "1.2.3.5:7777"
+					otherWorker.BaggageclaimURL = "5.6.7.9:7878"
+				})
+
+				JustBeforeEach(func() {
+					_, err := workerFactory.SaveWorker(otherWorker, 0)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("removes check sessions on the worker under pressure", func() {
+					Expect(lifecycle.CleanResourceConfigCheckSessionsOnPressuredWorkers()).To(Succeed())
+
+					_, found, err := owner.Find(dbConn)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+
+				Context("when it is under pressure too", func() {
+					BeforeEach(func() {
+						otherWorker.MemoryPressure = true
+					})
+
+					It("keeps check sessions", func() {
+						Expect(lifecycle.CleanResourceConfigCheckSessionsOnPressuredWorkers()).To(Succeed())
+
+						_, found, err := owner.Find(dbConn)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeTrue())
+					})
+				})
+
+				Context("when it takes different tagged work", func() {
+					BeforeEach(func() {
+						otherWorker.Tags = []string{"some-tag"}
+					})
+
+					It("keeps check sessions", func() {
+						Expect(lifecycle.CleanResourceConfigCheckSessionsOnPressuredWorkers()).To(Succeed())
+
+						_, found, err := owner.Find(dbConn)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeTrue())
+					})
+				})
+			})
+		})
+	})
+
 	Describe("CleanInactiveResourceConfigCheckSessions", func() {
 		expiry := db.ContainerOwnerExpiries{
 			GraceTime: 5 * time.Second,
@@ -94,7 +195,7 @@ var _ = Describe("ResourceConfigCheckSessionLifecycle", func() {
 
 			It("removes check sessions for paused resources", func() {
 				By("pausing the resource")
-				Expect(defaultResource.Pause()).To(Succeed())
+				Expect(defaultResource.Pause(db.AnyConfigVersion)).ToNot(BeZero())
 
 				By("cleaning up inactive sessions")
 				Expect(lifecycle.CleanInactiveResourceConfigCheckSessions()).To(Succeed())
@@ -108,7 +209,7 @@ var _ = Describe("ResourceConfigCheckSessionLifecycle", func() {
 
 			It("removes check sessions for resources in paused pipelines", func() {
 				By("pausing the pipeline")
-				Expect(defaultPipeline.Pause()).To(Succeed())
+				Expect(defaultPipeline.Pause(db.AnyConfigVersion)).ToNot(BeZero())
 
 				By("cleaning up inactive sessions")
 				Expect(lifecycle.CleanInactiveResourceConfigCheckSessions()).To(Succeed())
@@ -188,7 +289,7 @@ var _ = Describe("ResourceConfigCheckSessionLifecycle", func() {
 
 			It("removes check sessions for resource types in paused pipelines", func() {
 				By("pausing the pipeline")
-				Expect(defaultPipeline.Pause()).To(Succeed())
+				Expect(defaultPipeline.Pause(db.AnyConfigVersion)).ToNot(BeZero())
 
 				By("cleaning up inactive sessions")
 				Expect(lifecycle.CleanInactiveResourceConfigCheckSessions()).To(Succeed())
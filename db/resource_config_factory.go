@@ -143,8 +143,20 @@ func (f *resourceConfigFactory) CleanUnreferencedConfigs() error {
 		return err
 	}
 
+	// the version history shared by the resources with a config is deleted
+	// along with it, so it is kept for as long as any of them use it
+	withSharedHistoryIds, _, err := sq.
+		Select("r.resource_config_id").
+		From("resources r").
+		Where(sq.Expr("r.active")).
+		Where(sq.Expr("EXISTS (SELECT 1 FROM resource_config_versions v WHERE v.resource_config_id = r.resource_config_id)")).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
 	_, err = psql.Delete("resource_configs").
-		Where("id NOT IN (" + usedByResourceConfigCheckSessionIds + " UNION " + usedByResourceCachesIds + ")").
+		Where("id NOT IN (" + usedByResourceConfigCheckSessionIds + " UNION " + usedByResourceCachesIds + " UNION " + withSharedHistoryIds + ")").
 		PlaceholderFormat(sq.Dollar).
 		RunWith(f.conn).Exec()
 	if err != nil {
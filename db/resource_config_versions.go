@@ -0,0 +1,296 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/lock"
+)
+
+// AcquireResourceConfigCheckingLockWithIntervalCheck acquires the lock for
+// checking the resource config once the interval has elapsed since it was
+// last checked for any resource, so that resources with the same type and
+// source across pipelines and teams are checked once between them.
+func (p *pipeline) AcquireResourceConfigCheckingLockWithIntervalCheck(
+	logger lager.Logger,
+	usedResourceConfig *UsedResourceConfig,
+	interval time.Duration,
+	immediate bool,
+) (lock.Lock, bool, error) {
+	lock, acquired, err := p.lockFactory.Acquire(
+		logger,
+		lock.NewResourceConfigCheckingLockID(usedResourceConfig.ID),
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !acquired {
+		return nil, false, nil
+	}
+
+	intervalUpdated, err := p.checkIfResourceConfigIntervalUpdated(usedResourceConfig.ID, interval, immediate)
+	if err != nil {
+		lockErr := lock.Release()
+		if lockErr != nil {
+			logger.Fatal("failed-to-release-lock", lockErr)
+		}
+		return nil, false, err
+	}
+
+	if !intervalUpdated {
+		lockErr := lock.Release()
+		if lockErr != nil {
+			logger.Fatal("failed-to-release-lock", lockErr)
+		}
+		return nil, false, nil
+	}
+
+	return lock, true, nil
+}
+
+func (p *pipeline) checkIfResourceConfigIntervalUpdated(
+	resourceConfigID int,
+	interval time.Duration,
+	immediate bool,
+) (bool, error) {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	params := []interface{}{resourceConfigID}
+
+	condition := ""
+	if !immediate {
+		condition = "AND now() - last_checked > ($2 || ' SECONDS')::INTERVAL"
+		params = append(params, interval.Seconds())
+	}
+
+	updated, err := checkIfRowsUpdated(tx, `
+			UPDATE resource_configs
+			SET last_checked = now()
+			WHERE id = $1
+		`+condition, params...)
+	if err != nil {
+		return false, err
+	}
+
+	if !updated {
+		return false, nil
+	}
+
+	// the check is on behalf of every resource with the config
+	_, err = psql.Update("resources").
+		Set("last_checked", sq.Expr("now()")).
+		Where(sq.Eq{
+			"resource_config_id": resourceConfigID,
+			"active":             true,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SaveResourceConfigVersions saves the versions to the history shared by
+// every resource with the config, and to the versions of each of them.
+func (p *pipeline) SaveResourceConfigVersions(usedResourceConfig *UsedResourceConfig, versions []atc.Version) error {
+	batch, err := newVersionBatch(versions)
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	for start := 0; start < len(batch); start += versionBatchSize {
+		end := start + versionBatchSize
+		if end > len(batch) {
+			end = len(batch)
+		}
+
+		err = saveResourceConfigVersionBatch(tx, usedResourceConfig.ID, batch[start:end])
+		if err != nil {
+			return err
+		}
+	}
+
+	resources, err := p.resourcesWithConfig(tx, usedResourceConfig.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range resources {
+		err = saveVersions(tx, r.id, r.type_, batch)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// LatestResourceConfigVersion returns the newest version of the history
+// shared by every resource with the config.
+func (p *pipeline) LatestResourceConfigVersion(usedResourceConfig *UsedResourceConfig) (atc.Version, bool, error) {
+	var versionJSON string
+	err := psql.Select("version").
+		From("resource_config_versions").
+		Where(sq.Eq{"resource_config_id": usedResourceConfig.ID}).
+		OrderBy("check_order DESC").
+		Limit(1).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&versionJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	var version atc.Version
+	err = json.Unmarshal([]byte(versionJSON), &version)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return version, true, nil
+}
+
+// resourcesWithConfig returns the active resources of every pipeline which
+// use the resource config.
+func (p *pipeline) resourcesWithConfig(tx Tx, resourceConfigID int) ([]*resource, error) {
+	rows, err := resourcesQuery.
+		Where(sq.Eq{"r.resource_config_id": resourceConfigID}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	resources := []*resource{}
+	for rows.Next() {
+		r := &resource{conn: p.conn}
+		err = scanResource(r, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, rows.Err()
+}
+
+// saveResourceConfigVersionBatch saves the versions of the batch to the
+// shared history, ordering them after every version already in it. Resources
+// with the same config may be checked at once, so versions saved by another
+// check in the meantime are skipped.
+func saveResourceConfigVersionBatch(tx Tx, resourceConfigID int, batch versionBatch) error {
+	values, versionArgs := batch.values(1)
+	args := append([]interface{}{resourceConfigID}, versionArgs...)
+
+	_, err := tx.Exec(`
+		INSERT INTO resource_config_versions (resource_config_id, version)
+		SELECT $1, b.version
+		FROM (VALUES `+values+`) AS b (version, position)
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM resource_config_versions
+			WHERE resource_config_id = $1
+			AND version = b.version
+		)
+		ON CONFLICT DO NOTHING
+	`, args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		WITH max_checkorder AS (
+			SELECT COALESCE(max(check_order), 0) co
+			FROM resource_config_versions
+			WHERE resource_config_id = $1
+		)
+
+		UPDATE resource_config_versions v
+		SET check_order = mc.co + b.position
+		FROM max_checkorder mc, (VALUES `+values+`) AS b (version, position)
+		WHERE v.resource_config_id = $1
+		AND v.version = b.version
+	`, args...)
+	return err
+}
+
+// backfillResourceConfigVersions saves the history shared by every resource
+// with the config to the versions of a resource which has just started
+// using it.
+func backfillResourceConfigVersions(tx Tx, resourceID int, resourceType string, resourceConfigID int) error {
+	rows, err := psql.Select("version").
+		From("resource_config_versions").
+		Where(sq.Eq{"resource_config_id": resourceConfigID}).
+		OrderBy("check_order ASC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	versions := []atc.Version{}
+	for rows.Next() {
+		var versionJSON string
+		err = rows.Scan(&versionJSON)
+		if err != nil {
+			return err
+		}
+
+		var version atc.Version
+		err = json.Unmarshal([]byte(versionJSON), &version)
+		if err != nil {
+			return err
+		}
+
+		versions = append(versions, version)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	if len(versions) == 0 {
+		return nil
+	}
+
+	batch, err := newVersionBatch(versions)
+	if err != nil {
+		return err
+	}
+
+	return saveVersions(tx, resourceID, resourceType, batch)
+}
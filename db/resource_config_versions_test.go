@@ -0,0 +1,150 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceConfigVersions", func() {
+	var (
+		otherPipeline  db.Pipeline
+		otherResource  db.Resource
+		resourceConfig *db.UsedResourceConfig
+	)
+
+	BeforeEach(func() {
+		var (
+			found bool
+			err   error
+		)
+
+		otherPipeline, _, err = defaultTeam.SavePipeline("other-pipeline", atc.Config{
+			Resources: atc.ResourceConfigs{
+				{
+					Name: "some-other-resource",
+					Type: "some-base-resource-type",
+					Source: atc.Source{
+						"some": "source",
+					},
+				},
+			},
+		}, db.ConfigVersion(0), db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		otherResource, found, err = otherPipeline.Resource("some-other-resource")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		pipelineResourceTypes, err := defaultPipeline.ResourceTypes()
+		Expect(err).ToNot(HaveOccurred())
+
+		resourceConfigCheckSession, err := resourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSession(
+			logger,
+			defaultResource.Type(),
+			defaultResource.Source(),
+			creds.NewVersionedResourceTypes(template.StaticVariables{}, pipelineResourceTypes.Deserialize()),
+			db.ContainerOwnerExpiries{
+				GraceTime: 1 * time.Minute,
+				Min:       5 * time.Minute,
+				Max:       5 * time.Minute,
+			},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		resourceConfig = resourceConfigCheckSession.ResourceConfig()
+
+		Expect(defaultResource.SetResourceConfig(resourceConfig.ID)).To(Succeed())
+	})
+
+	Describe("SaveResourceConfigVersions", func() {
+		BeforeEach(func() {
+			Expect(otherResource.SetResourceConfig(resourceConfig.ID)).To(Succeed())
+
+			err := defaultPipeline.SaveResourceConfigVersions(resourceConfig, []atc.Version{
+				{"version": "1"},
+				{"version": "2"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves the versions for every resource with the config", func() {
+			latest, found, err := defaultPipeline.GetLatestVersionedResource(defaultResource.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(latest.Version).To(Equal(db.ResourceVersion{"version": "2"}))
+
+			latest, found, err = otherPipeline.GetLatestVersionedResource(otherResource.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(latest.Version).To(Equal(db.ResourceVersion{"version": "2"}))
+		})
+
+		It("keeps the latest version of the shared history", func() {
+			version, found, err := otherPipeline.LatestResourceConfigVersion(resourceConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(version).To(Equal(atc.Version{"version": "2"}))
+		})
+
+		It("keeps the shared history while a resource uses the config", func() {
+			_, err := dbConn.Exec(`DELETE FROM resource_config_check_sessions`)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resourceConfigFactory.CleanUnreferencedConfigs()).To(Succeed())
+
+			_, found, err := otherPipeline.LatestResourceConfigVersion(resourceConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+
+	Describe("SetResourceConfig", func() {
+		BeforeEach(func() {
+			err := defaultPipeline.SaveResourceConfigVersions(resourceConfig, []atc.Version{
+				{"version": "1"},
+				{"version": "2"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves the shared history to a resource starting to use the config", func() {
+			_, found, err := otherPipeline.GetLatestVersionedResource(otherResource.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			Expect(otherResource.SetResourceConfig(resourceConfig.ID)).To(Succeed())
+
+			latest, found, err := otherPipeline.GetLatestVersionedResource(otherResource.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(latest.Version).To(Equal(db.ResourceVersion{"version": "2"}))
+		})
+	})
+
+	Describe("AcquireResourceConfigCheckingLockWithIntervalCheck", func() {
+		It("checks the config once per interval across pipelines", func() {
+			lock, acquired, err := defaultPipeline.AcquireResourceConfigCheckingLockWithIntervalCheck(logger, resourceConfig, time.Minute, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			Expect(lock.Release()).To(Succeed())
+
+			_, acquired, err = otherPipeline.AcquireResourceConfigCheckingLockWithIntervalCheck(logger, resourceConfig, time.Minute, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeFalse())
+
+			lock, acquired, err = otherPipeline.AcquireResourceConfigCheckingLockWithIntervalCheck(logger, resourceConfig, time.Minute, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			Expect(lock.Release()).To(Succeed())
+		})
+	})
+})
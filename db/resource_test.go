@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
@@ -125,7 +127,7 @@ var _ = Describe("Resource", func() {
 		})
 
 		It("pauses the resource", func() {
-			err = resource.Pause()
+			_, err = resource.Pause(db.AnyConfigVersion)
 			Expect(err).ToNot(HaveOccurred())
 
 			found, err = resource.Reload()
@@ -147,7 +149,7 @@ var _ = Describe("Resource", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			err = resource.Pause()
+			_, err = resource.Pause(db.AnyConfigVersion)
 			Expect(err).ToNot(HaveOccurred())
 
 			found, err = resource.Reload()
@@ -157,7 +159,7 @@ var _ = Describe("Resource", func() {
 		})
 
 		It("pauses the resource", func() {
-			err = resource.Unpause()
+			_, err = resource.Unpause(db.AnyConfigVersion)
 			Expect(err).ToNot(HaveOccurred())
 
 			found, err = resource.Reload()
@@ -167,4 +169,131 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("webhook tokens", func() {
+		var (
+			resource      db.Resource
+			otherResource db.Resource
+		)
+
+		BeforeEach(func() {
+			var (
+				found bool
+				err   error
+			)
+
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			otherResource, found, err = pipeline.Resource("some-other-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("creates tokens which can be used to check the resource", func() {
+			token, secret, err := resource.CreateWebhookToken()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret).To(HaveLen(64))
+			Expect(token.LastUsedAt).To(BeZero())
+
+			valid, err := resource.UseWebhookToken(secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(valid).To(BeTrue())
+
+			valid, err = otherResource.UseWebhookToken(secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(valid).To(BeFalse())
+
+			tokens, err := resource.WebhookTokens()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tokens).To(HaveLen(1))
+			Expect(tokens[0].ID).To(Equal(token.ID))
+			Expect(tokens[0].LastUsedAt).ToNot(BeZero())
+		})
+
+		It("rejects tokens once they are deleted", func() {
+			token, secret, err := resource.CreateWebhookToken()
+			Expect(err).ToNot(HaveOccurred())
+
+			deleted, err := otherResource.DeleteWebhookToken(token.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+
+			deleted, err = resource.DeleteWebhookToken(token.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			valid, err := resource.UseWebhookToken(secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(valid).To(BeFalse())
+
+			tokens, err := resource.WebhookTokens()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tokens).To(BeEmpty())
+		})
+	})
+
+	Describe("check results", func() {
+		var resource db.Resource
+
+		BeforeEach(func() {
+			var (
+				found bool
+				err   error
+			)
+
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("returns the latest results, newest first", func() {
+			err := resource.SaveCheckResult(db.ResourceCheckResult{
+				StartTime:    time.Unix(100, 0),
+				EndTime:      time.Unix(105, 0),
+				VersionCount: 3,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resource.SaveCheckResult(db.ResourceCheckResult{
+				StartTime: time.Unix(200, 0),
+				EndTime:   time.Unix(210, 0),
+				Error:     "some-error",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			results, err := resource.CheckResults(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(HaveLen(2))
+
+			Expect(results[0].StartTime.Unix()).To(Equal(int64(200)))
+			Expect(results[0].EndTime.Unix()).To(Equal(int64(210)))
+			Expect(results[0].Error).To(Equal("some-error"))
+			Expect(results[0].Succeeded()).To(BeFalse())
+
+			Expect(results[1].StartTime.Unix()).To(Equal(int64(100)))
+			Expect(results[1].VersionCount).To(Equal(3))
+			Expect(results[1].Succeeded()).To(BeTrue())
+
+			results, err = resource.CheckResults(1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Error).To(Equal("some-error"))
+		})
+
+		It("removes the oldest results beyond those retained", func() {
+			for i := 0; i < 105; i++ {
+				err := resource.SaveCheckResult(db.ResourceCheckResult{
+					StartTime: time.Unix(int64(i), 0),
+					EndTime:   time.Unix(int64(i), 0),
+				})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			results, err := resource.CheckResults(200)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(HaveLen(100))
+			Expect(results[99].StartTime.Unix()).To(Equal(int64(5)))
+		})
+	})
 })
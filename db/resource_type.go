@@ -27,6 +27,7 @@ type ResourceType interface {
 	Source() atc.Source
 	Params() atc.Params
 	Tags() atc.Tags
+	Digest() string
 
 	SetResourceConfig(int) error
 
@@ -50,6 +51,7 @@ func (resourceTypes ResourceTypes) Deserialize() atc.VersionedResourceTypes {
 				Params:     t.Params(),
 				Privileged: t.Privileged(),
 				Tags:       t.Tags(),
+				Digest:     t.Digest(),
 			},
 			Version: t.Version(),
 		})
@@ -69,15 +71,18 @@ func (resourceTypes ResourceTypes) Configs() atc.ResourceTypes {
 			Params:     r.Params(),
 			Privileged: r.Privileged(),
 			Tags:       r.Tags(),
+			Digest:     r.Digest(),
 		})
 	}
 
 	return configs
 }
 
-var resourceTypesQuery = psql.Select("id, name, type, config, version, nonce").
-	From("resource_types").
-	Where(sq.Eq{"active": true})
+var resourceTypesQuery = psql.Select("rt.id, rt.name, rt.type, rt.config, rt.version, rt.nonce, rt.team_keyed, t.data_key, t.data_key_nonce").
+	From("resource_types rt").
+	Join("pipelines p ON p.id = rt.pipeline_id").
+	Join("teams t ON t.id = p.team_id").
+	Where(sq.Eq{"rt.active": true})
 
 type resourceType struct {
 	id         int
@@ -87,6 +92,7 @@ type resourceType struct {
 	source     atc.Source
 	params     atc.Params
 	tags       atc.Tags
+	digest     string
 	version    atc.Version
 
 	conn Conn
@@ -99,6 +105,7 @@ func (t *resourceType) Privileged() bool   { return t.privileged }
 func (t *resourceType) Source() atc.Source { return t.source }
 func (t *resourceType) Params() atc.Params { return t.params }
 func (r *resourceType) Tags() atc.Tags     { return r.tags }
+func (t *resourceType) Digest() string     { return t.digest }
 
 func (t *resourceType) Version() atc.Version { return t.version }
 func (t *resourceType) SaveVersion(version atc.Version) error {
@@ -129,7 +136,7 @@ func (t *resourceType) SaveVersion(version atc.Version) error {
 }
 
 func (t *resourceType) Reload() (bool, error) {
-	row := resourceTypesQuery.Where(sq.Eq{"id": t.id}).RunWith(t.conn).QueryRow()
+	row := resourceTypesQuery.Where(sq.Eq{"rt.id": t.id}).RunWith(t.conn).QueryRow()
 
 	err := scanResourceType(t, row)
 	if err != nil {
@@ -158,9 +165,11 @@ func scanResourceType(t *resourceType, row scannable) error {
 	var (
 		configJSON     []byte
 		version, nonce sql.NullString
+		teamKeyed      bool
+		dataKey        teamDataKey
 	)
 
-	err := row.Scan(&t.id, &t.name, &t.type_, &configJSON, &version, &nonce)
+	err := row.Scan(&t.id, &t.name, &t.type_, &configJSON, &version, &nonce, &teamKeyed, &dataKey.key, &dataKey.nonce)
 	if err != nil {
 		return err
 	}
@@ -172,14 +181,7 @@ func scanResourceType(t *resourceType, row scannable) error {
 		}
 	}
 
-	es := t.conn.EncryptionStrategy()
-
-	var noncense *string
-	if nonce.Valid {
-		noncense = &nonce.String
-	}
-
-	decryptedConfig, err := es.Decrypt(string(configJSON), noncense)
+	decryptedConfig, err := dataKey.decryptConfig(t.conn.EncryptionStrategy(), string(configJSON), nonce, teamKeyed)
 	if err != nil {
 		return err
 	}
@@ -194,6 +196,12 @@ func scanResourceType(t *resourceType, row scannable) error {
 	t.params = config.Params
 	t.privileged = config.Privileged
 	t.tags = config.Tags
+	t.digest = config.Digest
+
+	// an image pinned by digest is the only version of the type
+	if t.digest != "" {
+		t.version = atc.Version{"digest": t.digest}
+	}
 
 	return nil
 }
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"encoding/json"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ResourceVersionSearch finds versions of a pipeline's resources by the
+// value of one of their version or metadata fields, e.g. a commit ref or
+// author.
+type ResourceVersionSearch struct {
+	// Value is matched as a prefix, so that abbreviated refs find the
+	// versions they abbreviate.
+	Value string
+
+	// Field restricts the search to version or metadata fields with this
+	// name. Every field is searched if it is empty.
+	Field string
+
+	// Resource restricts the search to the named resource. Every resource in
+	// the pipeline is searched if it is empty.
+	Resource string
+
+	Limit int
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// indexVersionedResourceFields replaces the searchable fields of the
+// versioned resource with its current version and metadata.
+func indexVersionedResourceFields(tx Tx, versionedResourceID int, version ResourceVersion, metadata ResourceMetadataFields) error {
+	_, err := psql.Delete("versioned_resource_fields").
+		Where(sq.Eq{"versioned_resource_id": versionedResourceID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	if len(version) == 0 && len(metadata) == 0 {
+		return nil
+	}
+
+	insert := psql.Insert("versioned_resource_fields").
+		Columns("versioned_resource_id", "source", "name", "value")
+
+	for name, value := range version {
+		insert = insert.Values(versionedResourceID, "version", name, value)
+	}
+
+	for _, field := range metadata {
+		insert = insert.Values(versionedResourceID, "metadata", field.Name, field.Value)
+	}
+
+	_, err = insert.RunWith(tx).Exec()
+	return err
+}
+
+func (p *pipeline) SearchResourceVersions(search ResourceVersionSearch) ([]SavedVersionedResource, error) {
+	// left with ? placeholders so that they're numbered along with the query
+	// they're embedded in
+	fields := sq.Select("1").
+		From("versioned_resource_fields f").
+		Where("f.versioned_resource_id = v.id").
+		Where("f.value LIKE ?", likeEscaper.Replace(search.Value)+"%")
+
+	if search.Field != "" {
+		fields = fields.Where(sq.Eq{"f.name": search.Field})
+	}
+
+	fieldsSQL, fieldsArgs, err := fields.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	query := psql.Select("v.id, v.enabled, v.type, v.version, v.metadata, r.name, v.check_order").
+		From("versioned_resources v").
+		Join("resources r ON r.id = v.resource_id").
+		Where(sq.Eq{
+			"r.pipeline_id": p.id,
+			"r.active":      true,
+		}).
+		Where("EXISTS ("+fieldsSQL+")", fieldsArgs...).
+		OrderBy("v.id DESC")
+
+	if search.Resource != "" {
+		query = query.Where(sq.Eq{"r.name": search.Resource})
+	}
+
+	if search.Limit != 0 {
+		query = query.Limit(uint64(search.Limit))
+	}
+
+	runner := reader(p.conn, resourceVersionStaleness)
+
+	rows, err := query.RunWith(runner).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	savedVersionedResources := []SavedVersionedResource{}
+	for rows.Next() {
+		var savedVersionedResource SavedVersionedResource
+
+		var versionString, metadataString string
+
+		err = rows.Scan(
+			&savedVersionedResource.ID,
+			&savedVersionedResource.Enabled,
+			&savedVersionedResource.Type,
+			&versionString,
+			&metadataString,
+			&savedVersionedResource.Resource,
+			&savedVersionedResource.CheckOrder,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(versionString), &savedVersionedResource.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(metadataString), &savedVersionedResource.Metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		savedVersionedResources = append(savedVersionedResources, savedVersionedResource)
+	}
+
+	if len(savedVersionedResources) == 0 {
+		return savedVersionedResources, nil
+	}
+
+	versionedResourceIDs := make([]int, len(savedVersionedResources))
+	for i, svr := range savedVersionedResources {
+		versionedResourceIDs[i] = svr.ID
+	}
+
+	annotations, err := versionAnnotations(runner, versionedResourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, svr := range savedVersionedResources {
+		savedVersionedResources[i].Annotations = annotations[svr.ID]
+	}
+
+	return savedVersionedResources, nil
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// ResourceWebhookToken is a token with which external systems can trigger a
+// check of a resource, in addition to the webhook token in its config. Only a
+// hash of the token is kept, so it cannot be shown again once created.
+type ResourceWebhookToken struct {
+	ID         int
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// webhookTokenSize is the number of random bytes in each webhook token.
+const webhookTokenSize = 32
+
+var resourceWebhookTokensQuery = psql.Select("id", "created_at", "last_used_at").
+	From("resource_webhook_tokens")
+
+func (r *resource) WebhookTokens() ([]ResourceWebhookToken, error) {
+	rows, err := resourceWebhookTokensQuery.
+		Where(sq.Eq{"resource_id": r.id}).
+		OrderBy("id ASC").
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	tokens := []ResourceWebhookToken{}
+	for rows.Next() {
+		token, err := scanResourceWebhookToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+func (r *resource) CreateWebhookToken() (ResourceWebhookToken, string, error) {
+	raw := make([]byte, webhookTokenSize)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return ResourceWebhookToken{}, "", err
+	}
+
+	secret := hex.EncodeToString(raw)
+
+	token, err := scanResourceWebhookToken(psql.Insert("resource_webhook_tokens").
+		Columns("resource_id", "token_hash").
+		Values(r.id, hashWebhookToken(secret)).
+		Suffix("RETURNING id, created_at, last_used_at").
+		RunWith(r.conn).
+		QueryRow())
+	if err != nil {
+		return ResourceWebhookToken{}, "", err
+	}
+
+	return token, secret, nil
+}
+
+func (r *resource) DeleteWebhookToken(id int) (bool, error) {
+	result, err := psql.Delete("resource_webhook_tokens").
+		Where(sq.Eq{
+			"id":          id,
+			"resource_id": r.id,
+		}).
+		RunWith(r.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// UseWebhookToken returns whether the token is one of the resource's webhook
+// tokens, recording that it was used if so.
+func (r *resource) UseWebhookToken(token string) (bool, error) {
+	result, err := psql.Update("resource_webhook_tokens").
+		Set("last_used_at", sq.Expr("now()")).
+		Where(sq.Eq{
+			"resource_id": r.id,
+			"token_hash":  hashWebhookToken(token),
+		}).
+		RunWith(r.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func hashWebhookToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func scanResourceWebhookToken(row scannable) (ResourceWebhookToken, error) {
+	var (
+		token      ResourceWebhookToken
+		lastUsedAt pq.NullTime
+	)
+
+	err := row.Scan(&token.ID, &token.CreatedAt, &lastUsedAt)
+	if err != nil {
+		return ResourceWebhookToken{}, err
+	}
+
+	token.LastUsedAt = lastUsedAt.Time
+
+	return token, nil
+}
@@ -38,14 +38,32 @@ type Team interface {
 		from ConfigVersion,
 		pausedState PipelinePausedState,
 	) (Pipeline, bool, error)
+	SavePipelineAs(
+		author string,
+		pipelineName string,
+		config atc.Config,
+		from ConfigVersion,
+		pausedState PipelinePausedState,
+	) (Pipeline, bool, error)
 
 	Pipeline(pipelineName string) (Pipeline, bool, error)
 	Pipelines() ([]Pipeline, error)
 	PublicPipelines() ([]Pipeline, error)
 	VisiblePipelines() ([]Pipeline, error)
-	OrderPipelines([]string) error
+	ArchivedPipelines() ([]Pipeline, error)
+
+	// ConfigVersion returns the latest config version of the team's
+	// pipelines, which changes whenever any of them does. It can be given to
+	// the changes spanning the team's pipelines to make them fail with
+	// ErrConfigComparisonFailed if any of the pipelines has changed since.
+	ConfigVersion() (ConfigVersion, error)
+	OrderPipelines(pipelineNames []string, from ConfigVersion) (ConfigVersion, error)
+	PauseJobs(jobIDs []int, from ConfigVersion) (ConfigVersion, error)
+	UnpauseJobs(jobIDs []int, from ConfigVersion) (ConfigVersion, error)
 
-	CreateOneOffBuild() (Build, error)
+	ImportPipelineSnapshot(pipelineName string, snapshot atc.PipelineSnapshot) (Pipeline, error)
+
+	CreateOneOffBuild(cause BuildTriggerCause) (Build, error)
 	PrivateAndPublicBuilds(Page) ([]Build, Pagination, error)
 	Builds(page Page) ([]Build, Pagination, error)
 
@@ -65,6 +83,8 @@ type Team interface {
 	CreateContainer(workerName string, owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
 
 	UpdateProviderAuth(auth map[string]*json.RawMessage) error
+
+	Rekey() error
 }
 
 type team struct {
@@ -92,6 +112,47 @@ func (t *team) Delete() error {
 
 	defer Rollback(tx)
 
+	// the team's pipelines are deleted along with it, so drop their events
+	// tables rather than leaving them behind
+	rows, err := psql.Select("id").
+		From("pipelines").
+		Where(sq.Eq{
+			"team_id": t.id,
+		}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	var pipelineIDs []int
+	for rows.Next() {
+		var pipelineID int
+		err = rows.Scan(&pipelineID)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		pipelineIDs = append(pipelineIDs, pipelineID)
+	}
+
+	Close(rows)
+
+	archivedEventIDs, err := archivedEventBuildIDs(tx, sq.Eq{"team_id": t.id})
+	if err != nil {
+		return err
+	}
+
+	for _, pipelineID := range pipelineIDs {
+		_, err = tx.Exec(fmt.Sprintf(`
+			DROP TABLE IF EXISTS pipeline_build_events_%d
+		`, pipelineID))
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err = psql.Delete("teams").
 		Where(sq.Eq{
 			"name": t.name,
@@ -103,15 +164,19 @@ func (t *team) Delete() error {
 		return err
 	}
 
-	teamBuildEvents := fmt.Sprintf("team_build_events_%d", int64(t.id))
-	_, err = psql.Delete(teamBuildEvents).
-		RunWith(tx).
-		Exec()
+	_, err = tx.Exec(fmt.Sprintf(`
+		DROP TABLE IF EXISTS team_build_events_%d
+	`, t.id))
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return deleteArchivedEvents(t.conn, archivedEventIDs)
 }
 
 func (t *team) Rename(name string) error {
@@ -398,11 +463,66 @@ func (t *team) SavePipeline(
 	from ConfigVersion,
 	pausedState PipelinePausedState,
 ) (Pipeline, bool, error) {
+	return t.SavePipelineAs("", pipelineName, config, from, pausedState)
+}
+
+// SavePipelineAs saves the pipeline as SavePipeline does, recording the
+// config as a revision by the given author.
+func (t *team) SavePipelineAs(
+	author string,
+	pipelineName string,
+	config atc.Config,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
+) (Pipeline, bool, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Rollback(tx)
+
+	pipeline, created, err := t.savePipeline(tx, author, pipelineName, config, from, pausedState)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return pipeline, created, nil
+}
+
+func (t *team) savePipeline(
+	tx Tx,
+	author string,
+	pipelineName string,
+	config atc.Config,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
+) (*pipeline, bool, error) {
 	groupsPayload, err := json.Marshal(config.Groups)
 	if err != nil {
 		return nil, false, err
 	}
 
+	labelsPayload, err := json.Marshal(config.Labels)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var retention atc.BuildLogRetention
+	if config.BuildLogRetention != nil {
+		retention = *config.BuildLogRetention
+	}
+
+	retentionPayload, err := json.Marshal(retention)
+	if err != nil {
+		return nil, false, err
+	}
+
 	jobGroups := make(map[string][]string)
 	for _, group := range config.Groups {
 		for _, job := range group.Jobs {
@@ -413,18 +533,12 @@ func (t *team) SavePipeline(
 	var created bool
 	var existingConfig int
 
-	tx, err := t.conn.Begin()
-	if err != nil {
-		return nil, false, err
-	}
-
-	defer Rollback(tx)
-
 	err = tx.QueryRow(`
 		SELECT COUNT(1)
 		FROM pipelines
 		WHERE name = $1
 	  AND team_id = $2
+	  AND archived_at IS NULL
 	`, pipelineName, t.id).Scan(&existingConfig)
 	if err != nil {
 		return nil, false, err
@@ -438,12 +552,14 @@ func (t *team) SavePipeline(
 
 		err = psql.Insert("pipelines").
 			SetMap(map[string]interface{}{
-				"name":     pipelineName,
-				"groups":   groupsPayload,
-				"version":  sq.Expr("nextval('config_version_seq')"),
-				"ordering": sq.Expr("currval('pipelines_id_seq')"),
-				"paused":   pausedState.Bool(),
-				"team_id":  t.id,
+				"name":                pipelineName,
+				"groups":              groupsPayload,
+				"labels":              labelsPayload,
+				"build_log_retention": retentionPayload,
+				"version":             sq.Expr("nextval('config_version_seq')"),
+				"ordering":            sq.Expr("currval('pipelines_id_seq')"),
+				"paused":              pausedState.Bool(),
+				"team_id":             t.id,
 			}).
 			Suffix("RETURNING id").
 			RunWith(tx).
@@ -478,11 +594,14 @@ func (t *team) SavePipeline(
 	} else {
 		update := psql.Update("pipelines").
 			Set("groups", groupsPayload).
+			Set("labels", labelsPayload).
+			Set("build_log_retention", retentionPayload).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
 			Where(sq.Eq{
-				"name":    pipelineName,
-				"version": from,
-				"team_id": t.id,
+				"name":        pipelineName,
+				"version":     from,
+				"team_id":     t.id,
+				"archived_at": nil,
 			}).
 			Suffix("RETURNING id")
 
@@ -539,22 +658,27 @@ func (t *team) SavePipeline(
 		}
 	}
 
+	enc, err := teamConfigEncryption(tx, t.conn.EncryptionStrategy(), t.id)
+	if err != nil {
+		return nil, false, err
+	}
+
 	for _, resource := range config.Resources {
-		err = t.saveResource(tx, resource, pipelineID)
+		err = t.saveResource(tx, enc, resource, pipelineID)
 		if err != nil {
 			return nil, false, err
 		}
 	}
 
 	for _, resourceType := range config.ResourceTypes {
-		err = t.saveResourceType(tx, resourceType, pipelineID)
+		err = t.saveResourceType(tx, enc, resourceType, pipelineID)
 		if err != nil {
 			return nil, false, err
 		}
 	}
 
 	for _, job := range config.Jobs {
-		err = t.saveJob(tx, job, pipelineID, jobGroups[job.Name])
+		err = t.saveJob(tx, enc, job, pipelineID, jobGroups[job.Name])
 		if err != nil {
 			return nil, false, err
 		}
@@ -585,7 +709,7 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
-	err = tx.Commit()
+	err = saveConfigRevision(tx, t.conn.EncryptionStrategy(), enc, pipelineID, pipeline.configVersion, config, author, created)
 	if err != nil {
 		return nil, false, err
 	}
@@ -598,7 +722,7 @@ func (t *team) Pipeline(pipelineName string) (Pipeline, bool, error) {
 
 	err := scanPipeline(
 		pipeline,
-		pipelinesQuery.
+		activePipelinesQuery.
 			Where(sq.Eq{
 				"p.team_id": t.id,
 				"p.name":    pipelineName,
@@ -617,7 +741,7 @@ func (t *team) Pipeline(pipelineName string) (Pipeline, bool, error) {
 }
 
 func (t *team) Pipelines() ([]Pipeline, error) {
-	rows, err := pipelinesQuery.
+	rows, err := activePipelinesQuery.
 		Where(sq.Eq{
 			"team_id": t.id,
 		}).
@@ -637,7 +761,7 @@ func (t *team) Pipelines() ([]Pipeline, error) {
 }
 
 func (t *team) PublicPipelines() ([]Pipeline, error) {
-	rows, err := pipelinesQuery.
+	rows, err := activePipelinesQuery.
 		Where(sq.Eq{
 			"team_id": t.id,
 			"public":  true,
@@ -657,8 +781,24 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 	return pipelines, nil
 }
 
-func (t *team) VisiblePipelines() ([]Pipeline, error) {
+// ArchivedPipelines returns the team's archived pipelines, most recently
+// archived first. Several may have the same name.
+func (t *team) ArchivedPipelines() ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
+		Where(sq.Eq{"team_id": t.id}).
+		Where(sq.NotEq{"p.archived_at": nil}).
+		OrderBy("p.archived_at DESC").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPipelines(t.conn, t.lockFactory, rows)
+}
+
+func (t *team) VisiblePipelines() ([]Pipeline, error) {
+	rows, err := activePipelinesQuery.
 		Where(sq.Eq{"team_id": t.id}).
 		OrderBy("team_id ASC", "ordering ASC").
 		RunWith(t.conn).
@@ -672,7 +812,7 @@ func (t *team) VisiblePipelines() ([]Pipeline, error) {
 		return nil, err
 	}
 
-	rows, err = pipelinesQuery.
+	rows, err = activePipelinesQuery.
 		Where(sq.NotEq{"team_id": t.id}).
 		Where(sq.Eq{"public": true}).
 		OrderBy("team_id ASC", "ordering ASC").
@@ -690,32 +830,74 @@ func (t *team) VisiblePipelines() ([]Pipeline, error) {
 	return append(currentTeamPipelines, otherTeamPublicPipelines...), nil
 }
 
-func (t *team) OrderPipelines(pipelineNames []string) error {
-	tx, err := t.conn.Begin()
+func (t *team) ConfigVersion() (ConfigVersion, error) {
+	var version ConfigVersion
+	err := psql.Select("COALESCE(max(version), 0)").
+		From("pipelines").
+		Where(sq.Eq{"team_id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&version)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	defer Rollback(tx)
+	return version, nil
+}
 
-	for i, name := range pipelineNames {
-		_, err := psql.Update("pipelines").
-			Set("ordering", i).
-			Where(sq.Eq{
-				"name":    name,
-				"team_id": t.id,
-			}).
-			RunWith(tx).
-			Exec()
-		if err != nil {
-			return err
+func (t *team) OrderPipelines(pipelineNames []string, from ConfigVersion) (ConfigVersion, error) {
+	return compareAndSwapTeam(t.conn, t.id, from, func(tx Tx) error {
+		for i, name := range pipelineNames {
+			_, err := psql.Update("pipelines").
+				Set("ordering", i).
+				Where(sq.Eq{
+					"name":        name,
+					"team_id":     t.id,
+					"archived_at": nil,
+				}).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return err
+			}
 		}
-	}
 
-	return tx.Commit()
+		return nil
+	})
+}
+
+func (t *team) PauseJobs(jobIDs []int, from ConfigVersion) (ConfigVersion, error) {
+	return t.updatePausedJobs(jobIDs, true, from)
+}
+
+func (t *team) UnpauseJobs(jobIDs []int, from ConfigVersion) (ConfigVersion, error) {
+	return t.updatePausedJobs(jobIDs, false, from)
 }
 
-func (t *team) CreateOneOffBuild() (Build, error) {
+// updatePausedJobs pauses or unpauses the team's jobs with the given IDs all
+// at once. IDs of jobs belonging to other teams are ignored.
+func (t *team) updatePausedJobs(jobIDs []int, paused bool, from ConfigVersion) (ConfigVersion, error) {
+	return compareAndSwapTeam(t.conn, t.id, from, func(tx Tx) error {
+		if len(jobIDs) == 0 {
+			return nil
+		}
+
+		_, err := psql.Update("jobs").
+			Set("paused", paused).
+			Where(sq.Eq{"id": jobIDs}).
+			Where(sq.Expr("pipeline_id IN (SELECT id FROM pipelines WHERE team_id = ?)", t.id)).
+			RunWith(tx).
+			Exec()
+		return err
+	})
+}
+
+func (t *team) CreateOneOffBuild(cause BuildTriggerCause) (Build, error) {
+	triggerCause, err := cause.value()
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := t.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -725,9 +907,10 @@ func (t *team) CreateOneOffBuild() (Build, error) {
 
 	build := &build{conn: t.conn, lockFactory: t.lockFactory}
 	err = createBuild(tx, build, map[string]interface{}{
-		"name":    sq.Expr("nextval('one_off_name')"),
-		"team_id": t.id,
-		"status":  BuildStatusPending,
+		"name":          sq.Expr("nextval('one_off_name')"),
+		"team_id":       t.id,
+		"status":        BuildStatusPending,
+		"trigger_cause": triggerCause,
 	})
 	if err != nil {
 		return nil, err
@@ -795,23 +978,40 @@ func (t *team) UpdateProviderAuth(auth map[string]*json.RawMessage) error {
 	return t.queryTeam(query, params)
 }
 
-func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) error {
+// Rekey generates a new data key for the team and re-encrypts the configs of
+// its pipelines with it.
+func (t *team) Rekey() error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = rekeyTeam(tx, t.conn.EncryptionStrategy(), t.id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (t *team) saveJob(tx Tx, enc configEncryption, job atc.JobConfig, pipelineID int, groups []string) error {
 	configPayload, err := json.Marshal(job)
 	if err != nil {
 		return err
 	}
 
-	es := t.conn.EncryptionStrategy()
-	encryptedPayload, nonce, err := es.Encrypt(configPayload)
+	encryptedPayload, nonce, err := enc.strategy.Encrypt(configPayload)
 	if err != nil {
 		return err
 	}
 
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE jobs
-		SET config = $3, interruptible = $4, active = true, nonce = $5, tags = $6
+		SET config = $3, interruptible = $4, active = true, nonce = $5, tags = $6, team_keyed = $7
 		WHERE name = $1 AND pipeline_id = $2
-	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, "{"+strings.Join(groups, ",")+"}")
+	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, "{"+strings.Join(groups, ",")+"}", enc.teamKeyed)
 	if err != nil {
 		return err
 	}
@@ -821,9 +1021,9 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO jobs (name, pipeline_id, config, interruptible, active, nonce, tags)
-		VALUES ($1, $2, $3, $4, true, $5, $6)
-	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, "{"+strings.Join(groups, ",")+"}")
+		INSERT INTO jobs (name, pipeline_id, config, interruptible, active, nonce, tags, team_keyed)
+		VALUES ($1, $2, $3, $4, true, $5, $6, $7)
+	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, "{"+strings.Join(groups, ",")+"}", enc.teamKeyed)
 
 	return swallowUniqueViolation(err)
 }
@@ -844,23 +1044,22 @@ func (t *team) registerSerialGroup(tx Tx, jobName, serialGroup string, pipelineI
 	return swallowUniqueViolation(err)
 }
 
-func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int) error {
+func (t *team) saveResource(tx Tx, enc configEncryption, resource atc.ResourceConfig, pipelineID int) error {
 	configPayload, err := json.Marshal(resource)
 	if err != nil {
 		return err
 	}
 
-	es := t.conn.EncryptionStrategy()
-	encryptedPayload, nonce, err := es.Encrypt(configPayload)
+	encryptedPayload, nonce, err := enc.strategy.Encrypt(configPayload)
 	if err != nil {
 		return err
 	}
 
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE resources
-		SET config = $3, active = true, nonce = $4
+		SET config = $3, active = true, nonce = $4, team_keyed = $5
 		WHERE name = $1 AND pipeline_id = $2
-	`, resource.Name, pipelineID, encryptedPayload, nonce)
+	`, resource.Name, pipelineID, encryptedPayload, nonce, enc.teamKeyed)
 	if err != nil {
 		return err
 	}
@@ -870,30 +1069,29 @@ func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int)
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO resources (name, pipeline_id, config, active, nonce)
-		VALUES ($1, $2, $3, true, $4)
-	`, resource.Name, pipelineID, encryptedPayload, nonce)
+		INSERT INTO resources (name, pipeline_id, config, active, nonce, team_keyed)
+		VALUES ($1, $2, $3, true, $4, $5)
+	`, resource.Name, pipelineID, encryptedPayload, nonce, enc.teamKeyed)
 
 	return swallowUniqueViolation(err)
 }
 
-func (t *team) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelineID int) error {
+func (t *team) saveResourceType(tx Tx, enc configEncryption, resourceType atc.ResourceType, pipelineID int) error {
 	configPayload, err := json.Marshal(resourceType)
 	if err != nil {
 		return err
 	}
 
-	es := t.conn.EncryptionStrategy()
-	encryptedPayload, nonce, err := es.Encrypt(configPayload)
+	encryptedPayload, nonce, err := enc.strategy.Encrypt(configPayload)
 	if err != nil {
 		return err
 	}
 
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE resource_types
-		SET config = $3, type = $4, active = true, nonce = $5
+		SET config = $3, type = $4, active = true, nonce = $5, team_keyed = $6
 		WHERE name = $1 AND pipeline_id = $2
-	`, resourceType.Name, pipelineID, encryptedPayload, resourceType.Type, nonce)
+	`, resourceType.Name, pipelineID, encryptedPayload, resourceType.Type, nonce, enc.teamKeyed)
 	if err != nil {
 		return err
 	}
@@ -903,9 +1101,9 @@ func (t *team) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelineID
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO resource_types (name, type, pipeline_id, config, active, nonce)
-		VALUES ($1, $2, $3, $4, true, $5)
-	`, resourceType.Name, resourceType.Type, pipelineID, encryptedPayload, nonce)
+		INSERT INTO resource_types (name, type, pipeline_id, config, active, nonce, team_keyed)
+		VALUES ($1, $2, $3, $4, true, $5, $6)
+	`, resourceType.Name, resourceType.Type, pipelineID, encryptedPayload, nonce, enc.teamKeyed)
 
 	return swallowUniqueViolation(err)
 }
@@ -966,12 +1164,15 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 }
 
 func scanPipeline(p *pipeline, scan scannable) error {
-	var groups sql.NullString
-	err := scan.Scan(&p.id, &p.name, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public)
+	var groups, labels, retention sql.NullString
+	var archivedAt pq.NullTime
+	err := scan.Scan(&p.id, &p.name, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &labels, &retention, &archivedAt)
 	if err != nil {
 		return err
 	}
 
+	p.archivedAt = archivedAt.Time
+
 	if groups.Valid {
 		var pipelineGroups atc.GroupConfigs
 		err = json.Unmarshal([]byte(groups.String), &pipelineGroups)
@@ -982,6 +1183,23 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		p.groups = pipelineGroups
 	}
 
+	if labels.Valid {
+		var pipelineLabels atc.Labels
+		err = json.Unmarshal([]byte(labels.String), &pipelineLabels)
+		if err != nil {
+			return err
+		}
+
+		p.labels = pipelineLabels
+	}
+
+	if retention.Valid {
+		err = json.Unmarshal([]byte(retention.String), &p.retention)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,537 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db/encryption"
+)
+
+var ErrTeamKeysRequireEncryption = errors.New("team data keys require an encryption key to be configured")
+
+// The configs of each team's pipelines are encrypted with a data key of the
+// team's own, stored with the team wrapped by the master encryption key. The
+// rows encrypted with it are marked as team_keyed; any others left encrypted
+// with the master key are re-encrypted with the team's key when the database
+// is opened.
+var teamKeyedColumns = map[string]string{
+	"resources":                 "config",
+	"jobs":                      "config",
+	"resource_types":            "config",
+	"pipeline_config_revisions": "config",
+}
+
+// teamDataKey is a team's data key as stored with it, wrapped by the master
+// encryption key.
+type teamDataKey struct {
+	key   sql.NullString
+	nonce sql.NullString
+}
+
+// unwrappedTeamDataKeys caches the data keys unwrapped from each wrapped
+// value, so that the master key isn't needed to decrypt the data key every
+// time a config is read. Re-keying a team wraps a different value, so entries
+// never go stale.
+var unwrappedTeamDataKeys = struct {
+	sync.Mutex
+	keys map[string]*encryption.Key
+}{keys: map[string]*encryption.Key{}}
+
+func (k teamDataKey) unwrap(master encryption.Strategy) (*encryption.Key, error) {
+	unwrappedTeamDataKeys.Lock()
+	key, found := unwrappedTeamDataKeys.keys[k.key.String]
+	unwrappedTeamDataKeys.Unlock()
+
+	if found {
+		return key, nil
+	}
+
+	var nonce *string
+	if k.nonce.Valid {
+		nonce = &k.nonce.String
+	}
+
+	key, err := encryption.UnwrapDataKey(master, k.key.String, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	unwrappedTeamDataKeys.Lock()
+	unwrappedTeamDataKeys.keys[k.key.String] = key
+	unwrappedTeamDataKeys.Unlock()
+
+	return key, nil
+}
+
+// decryptConfig decrypts a config of one of the team's pipelines, however it
+// was encrypted.
+func (k teamDataKey) decryptConfig(master encryption.Strategy, config string, nonce sql.NullString, teamKeyed bool) ([]byte, error) {
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	if !teamKeyed {
+		return master.Decrypt(config, noncense)
+	}
+
+	if !k.key.Valid {
+		return nil, ErrTeamKeysRequireEncryption
+	}
+
+	key, err := k.unwrap(master)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.Decrypt(config, noncense)
+}
+
+// configEncryption is how the configs of a team's pipelines are to be
+// encrypted as they are saved.
+type configEncryption struct {
+	strategy  encryption.Strategy
+	teamKeyed bool
+}
+
+// teamConfigEncryption returns how to encrypt the configs of the team's
+// pipelines, generating the team's data key if it does not have one yet. The
+// team is locked against being re-keyed for the rest of the transaction.
+//
+// Without a master key, configs are stored unencrypted.
+func teamConfigEncryption(tx Tx, master encryption.Strategy, teamID int) (configEncryption, error) {
+	if _, ok := master.(*encryption.Key); !ok {
+		return configEncryption{strategy: master}, nil
+	}
+
+	key, err := lockTeamDataKey(tx, master, teamID, "FOR SHARE")
+	if err != nil {
+		return configEncryption{}, err
+	}
+
+	return configEncryption{strategy: key, teamKeyed: true}, nil
+}
+
+func lockTeamDataKey(tx Tx, master encryption.Strategy, teamID int, lock string) (*encryption.Key, error) {
+	// concurrent transactions generating a key for the team at the same time
+	// wait on each other here, and only the first one's key is kept
+	newKey, wrapped, nonce, err := encryption.NewDataKey(master)
+	if err != nil {
+		return nil, err
+	}
+
+	generated, err := checkIfRowsUpdated(tx, `
+		UPDATE teams
+		SET data_key = $1, data_key_nonce = $2
+		WHERE id = $3 AND data_key IS NULL
+	`, wrapped, nonce, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if generated {
+		return newKey, nil
+	}
+
+	var dataKey teamDataKey
+	err = tx.QueryRow(`
+		SELECT data_key, data_key_nonce
+		FROM teams
+		WHERE id = $1
+		`+lock, teamID).Scan(&dataKey.key, &dataKey.nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return dataKey.unwrap(master)
+}
+
+type teamKeyedConfig struct {
+	id        int
+	config    string
+	nonce     sql.NullString
+	teamKeyed bool
+}
+
+// teamConfigs returns the configs of the team's pipelines stored in the
+// table, optionally only those not yet encrypted with the team's data key.
+func teamConfigs(tx Tx, table string, teamID int, onlyUnkeyed bool) ([]teamKeyedConfig, error) {
+	col := teamKeyedColumns[table]
+
+	query := `
+		SELECT c.id, c.` + col + `, c.nonce, c.team_keyed
+		FROM ` + table + ` c
+		JOIN pipelines p ON p.id = c.pipeline_id
+		WHERE p.team_id = $1
+		AND c.` + col + ` IS NOT NULL
+	`
+	if onlyUnkeyed {
+		query += `AND NOT c.team_keyed`
+	}
+
+	rows, err := tx.Query(query, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	configs := []teamKeyedConfig{}
+	for rows.Next() {
+		var config teamKeyedConfig
+		err = rows.Scan(&config.id, &config.config, &config.nonce, &config.teamKeyed)
+		if err != nil {
+			return nil, err
+		}
+
+		configs = append(configs, config)
+	}
+
+	return configs, rows.Err()
+}
+
+// decrypt decrypts the config with the team's data key if it was encrypted
+// with it, or else with whichever of the master keys it was encrypted with.
+func (config teamKeyedConfig) decrypt(teamKey *encryption.Key, masterKeys ...*encryption.Key) ([]byte, error) {
+	if !config.nonce.Valid {
+		return []byte(config.config), nil
+	}
+
+	if config.teamKeyed {
+		if teamKey == nil {
+			return nil, ErrEncryptedWithUnknownKey
+		}
+
+		return teamKey.Decrypt(config.config, &config.nonce.String)
+	}
+
+	for _, masterKey := range masterKeys {
+		decrypted, err := masterKey.Decrypt(config.config, &config.nonce.String)
+		if err == nil {
+			return decrypted, nil
+		}
+	}
+
+	return nil, ErrEncryptedWithUnknownKey
+}
+
+// encryptTeamConfigs encrypts the configs of the team's pipelines with the
+// new data key. Unless it differs from the team's current key, only those not
+// encrypted with the team's key yet are encrypted.
+func encryptTeamConfigs(tx Tx, teamID int, teamKey *encryption.Key, newTeamKey *encryption.Key, masterKeys ...*encryption.Key) (int, error) {
+	encrypted := 0
+
+	for table, col := range teamKeyedColumns {
+		configs, err := teamConfigs(tx, table, teamID, teamKey == newTeamKey)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, config := range configs {
+			decrypted, err := config.decrypt(teamKey, masterKeys...)
+			if err != nil {
+				return 0, err
+			}
+
+			encryptedConfig, nonce, err := newTeamKey.Encrypt(decrypted)
+			if err != nil {
+				return 0, err
+			}
+
+			_, err = tx.Exec(`
+				UPDATE `+table+`
+				SET `+col+` = $1, nonce = $2, team_keyed = true
+				WHERE id = $3
+			`, encryptedConfig, nonce, config.id)
+			if err != nil {
+				return 0, err
+			}
+
+			encrypted++
+		}
+	}
+
+	return encrypted, nil
+}
+
+// rekeyTeam generates a new data key for the team and re-encrypts the configs
+// of its pipelines with it.
+func rekeyTeam(tx Tx, master encryption.Strategy, teamID int) error {
+	masterKey, ok := master.(*encryption.Key)
+	if !ok {
+		return ErrTeamKeysRequireEncryption
+	}
+
+	oldKey, err := lockTeamDataKey(tx, masterKey, teamID, "FOR UPDATE")
+	if err != nil {
+		return err
+	}
+
+	newKey, wrapped, nonce, err := encryption.NewDataKey(masterKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = encryptTeamConfigs(tx, teamID, oldKey, newKey, masterKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE teams
+		SET data_key = $1, data_key_nonce = $2
+		WHERE id = $3
+	`, wrapped, nonce, teamID)
+	return err
+}
+
+// encryptWithTeamKeys encrypts the configs of every team's pipelines that are
+// not encrypted with the team's data key yet, e.g. because they were saved
+// without a master key or before teams had keys of their own. Those already
+// encrypted with the master key may be encrypted with either the new or the
+// old master key.
+func encryptWithTeamKeys(logger lager.Logger, sqlDB *sql.DB, newKey *encryption.Key, oldKey *encryption.Key) error {
+	masterKeys := []*encryption.Key{newKey}
+	if oldKey != nil {
+		masterKeys = append(masterKeys, oldKey)
+	}
+
+	teamIDs, err := allTeamIDs(sqlDB)
+	if err != nil {
+		return err
+	}
+
+	for _, teamID := range teamIDs {
+		tLog := logger.Session("team", lager.Data{
+			"team-id": teamID,
+		})
+
+		encrypted, err := withTx(sqlDB, func(tx Tx) (int, error) {
+			teamKey, err := lockTeamDataKey(tx, newKey, teamID, "FOR UPDATE")
+			if err != nil {
+				return 0, err
+			}
+
+			return encryptTeamConfigs(tx, teamID, teamKey, teamKey, masterKeys...)
+		})
+		if err != nil {
+			tLog.Error("failed-to-encrypt-with-team-key", err)
+			return err
+		}
+
+		if encrypted > 0 {
+			tLog.Info("encrypted-configs-with-team-key", lager.Data{
+				"rows": encrypted,
+			})
+		}
+	}
+
+	return nil
+}
+
+// rewrapTeamDataKeys wraps every team's data key with the new master key in
+// place of the old one, leaving the configs encrypted with them as they are.
+func rewrapTeamDataKeys(logger lager.Logger, sqlDB *sql.DB, newKey *encryption.Key, oldKey *encryption.Key) error {
+	rows, err := sqlDB.Query(`
+		SELECT id, data_key, data_key_nonce
+		FROM teams
+		WHERE data_key IS NOT NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	rewrapped := 0
+
+	for rows.Next() {
+		var (
+			id             int
+			dataKey, nonce string
+		)
+
+		err := rows.Scan(&id, &dataKey, &nonce)
+		if err != nil {
+			logger.Error("failed-to-scan", err)
+			return err
+		}
+
+		tLog := logger.Session("team", lager.Data{
+			"team-id": id,
+		})
+
+		raw, err := oldKey.Decrypt(dataKey, &nonce)
+		if err != nil {
+			_, err = newKey.Decrypt(dataKey, &nonce)
+			if err == nil {
+				tLog.Debug("already-wrapped-with-new-key")
+				continue
+			}
+
+			tLog.Error("failed-to-unwrap-with-either-key", err)
+			return ErrEncryptedWithUnknownKey
+		}
+
+		wrapped, newNonce, err := newKey.Encrypt(raw)
+		if err != nil {
+			tLog.Error("failed-to-wrap", err)
+			return err
+		}
+
+		_, err = sqlDB.Exec(`
+			UPDATE teams
+			SET data_key = $1, data_key_nonce = $2
+			WHERE id = $3
+		`, wrapped, newNonce, id)
+		if err != nil {
+			tLog.Error("failed-to-update", err)
+			return err
+		}
+
+		rewrapped++
+	}
+
+	if rewrapped > 0 {
+		logger.Info("re-wrapped-team-data-keys", lager.Data{
+			"teams": rewrapped,
+		})
+	}
+
+	return rows.Err()
+}
+
+// decryptTeamConfigsToPlaintext decrypts the configs of every team's
+// pipelines, whether they were encrypted with the team's data key or the old
+// master key, and forgets the teams' keys.
+func decryptTeamConfigsToPlaintext(logger lager.Logger, sqlDB *sql.DB, oldKey *encryption.Key) error {
+	teamIDs, err := allTeamIDs(sqlDB)
+	if err != nil {
+		return err
+	}
+
+	for _, teamID := range teamIDs {
+		tLog := logger.Session("team", lager.Data{
+			"team-id": teamID,
+		})
+
+		decrypted, err := withTx(sqlDB, func(tx Tx) (int, error) {
+			var dataKey teamDataKey
+			err := tx.QueryRow(`
+				SELECT data_key, data_key_nonce
+				FROM teams
+				WHERE id = $1
+				FOR UPDATE
+			`, teamID).Scan(&dataKey.key, &dataKey.nonce)
+			if err != nil {
+				return 0, err
+			}
+
+			var teamKey *encryption.Key
+			if dataKey.key.Valid {
+				teamKey, err = dataKey.unwrap(oldKey)
+				if err != nil {
+					return 0, err
+				}
+			}
+
+			decrypted := 0
+
+			for table, col := range teamKeyedColumns {
+				configs, err := teamConfigs(tx, table, teamID, false)
+				if err != nil {
+					return 0, err
+				}
+
+				for _, config := range configs {
+					if !config.nonce.Valid {
+						continue
+					}
+
+					plaintext, err := config.decrypt(teamKey, oldKey)
+					if err != nil {
+						return 0, err
+					}
+
+					_, err = tx.Exec(`
+						UPDATE `+table+`
+						SET `+col+` = $1, nonce = NULL, team_keyed = false
+						WHERE id = $2
+					`, plaintext, config.id)
+					if err != nil {
+						return 0, err
+					}
+
+					decrypted++
+				}
+			}
+
+			_, err = tx.Exec(`
+				UPDATE teams
+				SET data_key = NULL, data_key_nonce = NULL
+				WHERE id = $1
+			`, teamID)
+			if err != nil {
+				return 0, err
+			}
+
+			return decrypted, nil
+		})
+		if err != nil {
+			tLog.Error("failed-to-decrypt-team-configs", err)
+			return err
+		}
+
+		if decrypted > 0 {
+			tLog.Info("decrypted-team-configs", lager.Data{
+				"rows": decrypted,
+			})
+		}
+	}
+
+	return nil
+}
+
+func allTeamIDs(sqlDB *sql.DB) ([]int, error) {
+	rows, err := sqlDB.Query(`SELECT id FROM teams`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	teamIDs := []int{}
+	for rows.Next() {
+		var teamID int
+		err = rows.Scan(&teamID)
+		if err != nil {
+			return nil, err
+		}
+
+		teamIDs = append(teamIDs, teamID)
+	}
+
+	return teamIDs, rows.Err()
+}
+
+// withTx runs the statements in a transaction on a database that has not been
+// wrapped up as a Conn yet.
+func withTx(sqlDB *sql.DB, statements func(Tx) (int, error)) (int, error) {
+	sqlTx, err := sqlDB.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	tx := &dbTx{sqlTx, GlobalConnectionTracker.Track()}
+
+	defer Rollback(tx)
+
+	count, err := statements(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, tx.Commit()
+}
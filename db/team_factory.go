@@ -82,6 +82,20 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	_, err = tx.Exec(fmt.Sprintf(`
+		CREATE INDEX team_build_events_%[1]d_build_id ON team_build_events_%[1]d (build_id)
+	`, team.ID()))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`
+		CREATE UNIQUE INDEX team_build_events_%[1]d_build_id_event_id ON team_build_events_%[1]d (build_id, event_id)
+	`, team.ID()))
+	if err != nil {
+		return nil, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
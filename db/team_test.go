@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"encoding/json"
 	"strconv"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/db/encryption"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -39,12 +42,21 @@ var _ = Describe("Team", func() {
 	})
 
 	Describe("Delete", func() {
+		var pipeline db.Pipeline
+
 		BeforeEach(func() {
 			team, found, err := teamFactory.FindTeam("some-other-team")
 			Expect(team.Name()).To(Equal("some-other-team"))
 			Expect(found).To(BeTrue())
 			Expect(err).ToNot(HaveOccurred())
 
+			pipeline, _, err = otherTeam.SavePipeline("some-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+			}, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
 			err = otherTeam.Delete()
 			Expect(err).ToNot(HaveOccurred())
 		})
@@ -55,6 +67,17 @@ var _ = Describe("Team", func() {
 			Expect(found).To(BeFalse())
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		It("drops the build events tables of the team and its pipelines", func() {
+			var exists bool
+			err := dbConn.QueryRow(`SELECT to_regclass($1) IS NOT NULL`, "team_build_events_"+strconv.Itoa(otherTeam.ID())).Scan(&exists)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			err = dbConn.QueryRow(`SELECT to_regclass($1) IS NOT NULL`, "pipeline_build_events_"+strconv.Itoa(pipeline.ID())).Scan(&exists)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
 	})
 
 	Describe("Rename", func() {
@@ -279,7 +302,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			metaContainers = make(map[db.ContainerMetadata][]db.Container)
@@ -493,7 +516,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			creatingContainer, err := defaultTeam.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-job")), db.ContainerMetadata{Type: "task", StepName: "some-task"})
@@ -541,7 +564,7 @@ var _ = Describe("Team", func() {
 				Type:     "task",
 				StepName: "some-task",
 			}
-			defaultBuild, err = defaultTeam.CreateOneOffBuild()
+			defaultBuild, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -618,7 +641,7 @@ var _ = Describe("Team", func() {
 				Type:     "task",
 				StepName: "some-task",
 			}
-			build, err = defaultTeam.CreateOneOffBuild()
+			build, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			fakeOwner = new(dbfakes.FakeContainerOwner)
@@ -797,7 +820,7 @@ var _ = Describe("Team", func() {
 				}, db.ConfigVersion(1), db.PipelineUnpaused)
 				Expect(err).ToNot(HaveOccurred())
 
-				err = pipeline2.Expose()
+				_, err = pipeline2.Expose(db.AnyConfigVersion)
 				Expect(err).ToNot(HaveOccurred())
 
 				found, err := pipeline2.Reload()
@@ -846,7 +869,7 @@ var _ = Describe("Team", func() {
 				}, db.ConfigVersion(1), db.PipelineUnpaused)
 				Expect(err).ToNot(HaveOccurred())
 
-				Expect(pipeline2.Expose()).To(Succeed())
+				Expect(pipeline2.Expose(db.AnyConfigVersion)).ToNot(BeZero())
 				Expect(pipeline2.Reload()).To(BeTrue())
 			})
 
@@ -898,10 +921,10 @@ var _ = Describe("Team", func() {
 		})
 
 		It("orders pipelines that belong to team (case insensitive)", func() {
-			err := team.OrderPipelines([]string{"pipeline-name-b", "pipeline-name-a"})
+			_, err := team.OrderPipelines([]string{"pipeline-name-b", "pipeline-name-a"}, db.AnyConfigVersion)
 			Expect(err).ToNot(HaveOccurred())
 
-			err = otherTeam.OrderPipelines([]string{"pipeline-name-a", "pipeline-name-b"})
+			_, err = otherTeam.OrderPipelines([]string{"pipeline-name-a", "pipeline-name-b"}, db.AnyConfigVersion)
 			Expect(err).ToNot(HaveOccurred())
 
 			orderedPipelines, err := team.Pipelines()
@@ -917,6 +940,126 @@ var _ = Describe("Team", func() {
 			Expect(otherTeamOrderedPipelines[0].ID()).To(Equal(otherPipeline1.ID()))
 			Expect(otherTeamOrderedPipelines[1].ID()).To(Equal(otherPipeline2.ID()))
 		})
+
+		Context("when given the team's config version", func() {
+			var version db.ConfigVersion
+
+			BeforeEach(func() {
+				var err error
+				version, err = team.ConfigVersion()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(version).To(Equal(pipeline2.ConfigVersion()))
+			})
+
+			It("orders the pipelines and moves the team on to a new version", func() {
+				newVersion, err := team.OrderPipelines([]string{"pipeline-name-b", "pipeline-name-a"}, version)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newVersion).To(BeNumerically(">", version))
+
+				Expect(team.ConfigVersion()).To(Equal(newVersion))
+
+				otherVersion, err := otherTeam.ConfigVersion()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(otherVersion).To(Equal(otherPipeline2.ConfigVersion()))
+			})
+
+			Context("when one of the team's pipelines has changed since", func() {
+				BeforeEach(func() {
+					_, err := pipeline1.Pause(db.AnyConfigVersion)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("fails without ordering the pipelines", func() {
+					_, err := team.OrderPipelines([]string{"pipeline-name-b", "pipeline-name-a"}, version)
+					Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+
+					orderedPipelines, err := team.Pipelines()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(orderedPipelines[0].ID()).To(Equal(pipeline1.ID()))
+				})
+			})
+
+			Context("when one of the team's pipelines has been archived since", func() {
+				BeforeEach(func() {
+					_, err := pipeline1.Archive(db.AnyConfigVersion)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("fails", func() {
+					_, err := team.OrderPipelines([]string{"pipeline-name-b"}, version)
+					Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+				})
+			})
+		})
+	})
+
+	Describe("PauseJobs", func() {
+		var (
+			pipeline     db.Pipeline
+			job          db.Job
+			otherTeamJob db.Job
+		)
+
+		BeforeEach(func() {
+			config := atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+			}
+
+			var err error
+			pipeline, _, err = team.SavePipeline("some-pipeline", config, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			job, found, err = pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			otherPipeline, _, err := otherTeam.SavePipeline("some-pipeline", config, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherTeamJob, found, err = otherPipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("pauses the team's jobs and leaves other teams' alone", func() {
+			version, err := team.ConfigVersion()
+			Expect(err).ToNot(HaveOccurred())
+
+			newVersion, err := team.PauseJobs([]int{job.ID(), otherTeamJob.ID()}, version)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(newVersion).To(BeNumerically(">", version))
+
+			Expect(job.Reload()).To(BeTrue())
+			Expect(job.Paused()).To(BeTrue())
+
+			Expect(otherTeamJob.Reload()).To(BeTrue())
+			Expect(otherTeamJob.Paused()).To(BeFalse())
+
+			_, err = team.UnpauseJobs([]int{job.ID()}, newVersion)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(job.Reload()).To(BeTrue())
+			Expect(job.Paused()).To(BeFalse())
+		})
+
+		Context("when one of the team's pipelines has changed since", func() {
+			It("fails without pausing the jobs", func() {
+				version, err := team.ConfigVersion()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = pipeline.Hide(db.AnyConfigVersion)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = team.PauseJobs([]int{job.ID()}, version)
+				Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+
+				Expect(job.Reload()).To(BeTrue())
+				Expect(job.Paused()).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("CreateOneOffBuild", func() {
@@ -926,10 +1069,25 @@ var _ = Describe("Team", func() {
 		)
 
 		BeforeEach(func() {
-			oneOffBuild, err = team.CreateOneOffBuild()
+			oneOffBuild, err = team.CreateOneOffBuild(db.BuildTriggerCause{
+				Type:      db.BuildTriggerManual,
+				Requester: "some-requester",
+			})
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("records the cause of the build", func() {
+			Expect(oneOffBuild.TriggerCause()).To(Equal(db.BuildTriggerCause{
+				Type:      db.BuildTriggerManual,
+				Requester: "some-requester",
+			}))
+
+			found, err := oneOffBuild.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(oneOffBuild.TriggerCause().Requester).To(Equal("some-requester"))
+		})
+
 		It("can create one-off builds", func() {
 			Expect(oneOffBuild.ID()).ToNot(BeZero())
 			Expect(oneOffBuild.JobName()).To(BeZero())
@@ -959,7 +1117,7 @@ var _ = Describe("Team", func() {
 
 			BeforeEach(func() {
 				for i := 0; i < 3; i++ {
-					build, err := team.CreateOneOffBuild()
+					build, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 					allBuilds[i] = build
 				}
@@ -980,7 +1138,7 @@ var _ = Describe("Team", func() {
 				Expect(found).To(BeTrue())
 
 				for i := 3; i < 5; i++ {
-					build, err := job.CreateBuild()
+					build, err := job.CreateBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 					allBuilds[i] = build
 					pipelineBuilds[i-3] = build
@@ -1053,10 +1211,10 @@ var _ = Describe("Team", func() {
 					Expect(err).ToNot(HaveOccurred())
 
 					for i := 0; i < 3; i++ {
-						teamABuilds[i], err = caseInsensitiveTeamA.CreateOneOffBuild()
+						teamABuilds[i], err = caseInsensitiveTeamA.CreateOneOffBuild(db.BuildTriggerCause{})
 						Expect(err).ToNot(HaveOccurred())
 
-						teamBBuilds[i], err = caseInsensitiveTeamB.CreateOneOffBuild()
+						teamBBuilds[i], err = caseInsensitiveTeamB.CreateOneOffBuild(db.BuildTriggerCause{})
 						Expect(err).ToNot(HaveOccurred())
 					}
 				})
@@ -1079,7 +1237,7 @@ var _ = Describe("Team", func() {
 
 				Context("when other team builds are public", func() {
 					BeforeEach(func() {
-						err := pipeline.Expose()
+						_, err := pipeline.Expose(db.AnyConfigVersion)
 						Expect(err).ToNot(HaveOccurred())
 					})
 
@@ -1109,7 +1267,7 @@ var _ = Describe("Team", func() {
 		)
 
 		BeforeEach(func() {
-			oneOfAKind, err := team.CreateOneOffBuild()
+			oneOfAKind, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, oneOfAKind)
 
@@ -1130,11 +1288,11 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err := job.CreateBuild()
+			secondBuild, err := job.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -1142,7 +1300,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			thirdBuild, err := someOtherJob.CreateBuild()
+			thirdBuild, err := someOtherJob.CreateBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, thirdBuild)
 		})
@@ -1177,10 +1335,10 @@ var _ = Describe("Team", func() {
 				Expect(err).ToNot(HaveOccurred())
 
 				for i := 0; i < 3; i++ {
-					teamABuilds[i], err = caseInsensitiveTeamA.CreateOneOffBuild()
+					teamABuilds[i], err = caseInsensitiveTeamA.CreateOneOffBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 
-					teamBBuilds[i], err = caseInsensitiveTeamB.CreateOneOffBuild()
+					teamBBuilds[i], err = caseInsensitiveTeamB.CreateOneOffBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 				}
 			})
@@ -1199,11 +1357,31 @@ var _ = Describe("Team", func() {
 					Expect(len(builds)).To(Equal(3))
 					Expect(builds).To(ConsistOf(teamBBuilds))
 				})
+
+				It("paginates only over builds for requested team", func() {
+					builds, pagination, err := caseInsensitiveTeamA.Builds(db.Page{Limit: 10})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(builds)).To(Equal(3))
+					Expect(pagination.Previous).To(BeNil())
+					Expect(pagination.Next).To(BeNil())
+
+					builds, pagination, err = caseInsensitiveTeamA.Builds(db.Page{Limit: 2})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(builds).To(Equal([]db.Build{teamABuilds[2], teamABuilds[1]}))
+					Expect(pagination.Previous).To(BeNil())
+					Expect(pagination.Next).To(Equal(&db.Page{Since: teamABuilds[1].ID(), Limit: 2}))
+
+					builds, pagination, err = caseInsensitiveTeamA.Builds(*pagination.Next)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(builds).To(Equal([]db.Build{teamABuilds[0]}))
+					Expect(pagination.Previous).To(Equal(&db.Page{Until: teamABuilds[0].ID(), Limit: 2}))
+					Expect(pagination.Next).To(BeNil())
+				})
 			})
 
 			Context("when other team builds are public", func() {
 				BeforeEach(func() {
-					err := pipeline.Expose()
+					_, err := pipeline.Expose(db.AnyConfigVersion)
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -1382,6 +1560,94 @@ var _ = Describe("Team", func() {
 			Expect(pipeline.Paused()).To(BeTrue())
 		})
 
+		It("saves the pipeline's labels", func() {
+			config.Labels = atc.Labels{"env": "staging"}
+
+			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipeline.Labels()).To(Equal(atc.Labels{"env": "staging"}))
+
+			config.Labels = atc.Labels{"env": "production"}
+
+			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(savedPipeline.Labels()).To(Equal(atc.Labels{"env": "production"}))
+		})
+
+		It("records a revision of each config saved, with what changed", func() {
+			pipeline, _, err := team.SavePipelineAs("some-author", pipelineName, config, 0, db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+
+			changedConfig := config
+			changedConfig.Labels = atc.Labels{"env": "staging"}
+
+			savedPipeline, _, err := team.SavePipelineAs("other-author", pipelineName, changedConfig, pipeline.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+
+			revisions, _, err := savedPipeline.ConfigRevisions(db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revisions).To(HaveLen(2))
+
+			Expect(revisions[0].ConfigVersion).To(Equal(savedPipeline.ConfigVersion()))
+			Expect(revisions[0].Author).To(Equal("other-author"))
+			Expect(revisions[0].Config.Labels).To(Equal(atc.Labels{"env": "staging"}))
+			Expect(revisions[0].Diff).To(Equal([]atc.ConfigChange{{Kind: "labels", Change: atc.ConfigChangeChanged}}))
+			Expect(revisions[0].CreatedAt).ToNot(BeZero())
+
+			Expect(revisions[1].ConfigVersion).To(Equal(pipeline.ConfigVersion()))
+			Expect(revisions[1].Author).To(Equal("some-author"))
+			Expect(revisions[1].Diff).To(ContainElement(atc.ConfigChange{Kind: "job", Name: "some-job", Change: atc.ConfigChangeAdded}))
+
+			revision, found, err := savedPipeline.ConfigRevision(revisions[1].ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(revision).To(Equal(revisions[1]))
+
+			_, found, err = savedPipeline.ConfigRevision(revisions[0].ID + 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("paginates the pipeline's config revisions", func() {
+			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+
+			pipeline, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+
+			pipeline, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+
+			revisions, pagination, err := pipeline.ConfigRevisions(db.Page{Limit: 2})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revisions).To(HaveLen(2))
+			Expect(pagination.Previous).To(BeNil())
+			Expect(pagination.Next).To(Equal(&db.Page{Since: revisions[1].ID, Limit: 2}))
+
+			older, pagination, err := pipeline.ConfigRevisions(*pagination.Next)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(older).To(HaveLen(1))
+			Expect(older[0].Diff).To(ContainElement(atc.ConfigChange{Kind: "job", Name: "some-job", Change: atc.ConfigChangeAdded}))
+			Expect(pagination.Previous).To(Equal(&db.Page{Until: older[0].ID, Limit: 2}))
+			Expect(pagination.Next).To(BeNil())
+
+			Expect(revisions[1].Diff).To(BeEmpty())
+		})
+
+		It("saves the pipeline's build log retention", func() {
+			config.BuildLogRetention = &atc.BuildLogRetention{Builds: 100, Days: 30, MinimumSucceeded: 5}
+
+			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipeline.BuildLogRetention()).To(Equal(atc.BuildLogRetention{Builds: 100, Days: 30, MinimumSucceeded: 5}))
+
+			config.BuildLogRetention = nil
+
+			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(savedPipeline.BuildLogRetention()).To(BeZero())
+		})
+
 		It("creates all of the resources from the pipeline in the database", func() {
 			savedPipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
@@ -1974,7 +2240,7 @@ var _ = Describe("Team", func() {
 			}
 
 			var err error
-			build, err = defaultTeam.CreateOneOffBuild()
+			build, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			fakeOwner = new(dbfakes.FakeContainerOwner)
@@ -2077,4 +2343,107 @@ var _ = Describe("Team", func() {
 			})
 		})
 	})
+
+	Describe("Rekey", func() {
+		Context("without an encryption key", func() {
+			It("fails", func() {
+				Expect(team.Rekey()).To(Equal(db.ErrTeamKeysRequireEncryption))
+			})
+		})
+
+		Context("with an encryption key", func() {
+			var (
+				encryptedConn db.Conn
+				encryptedTeam db.Team
+				config        atc.Config
+			)
+
+			dataKey := func() string {
+				var key string
+				err := encryptedConn.QueryRow(`SELECT data_key FROM teams WHERE id = $1`, team.ID()).Scan(&key)
+				Expect(err).ToNot(HaveOccurred())
+				return key
+			}
+
+			BeforeEach(func() {
+				block, err := aes.NewCipher([]byte("AES256Key-32Characters1234567890"))
+				Expect(err).ToNot(HaveOccurred())
+
+				aesgcm, err := cipher.NewGCM(block)
+				Expect(err).ToNot(HaveOccurred())
+
+				config = atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "some-resource", Type: "some-type", Source: atc.Source{"secret": "some-secret"}},
+					},
+					Jobs: atc.JobConfigs{
+						{Name: "some-job"},
+					},
+				}
+
+				_, _, err = team.SavePipeline("plaintext-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+
+				encryptedConn, err = db.Open(logger, "postgres", postgresRunner.DataSourceName(), encryption.NewKey(aesgcm), nil, "test", nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				encryptedTeam, found, err = db.NewTeamFactory(encryptedConn, lockFactory).FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			AfterEach(func() {
+				Expect(encryptedConn.Close()).To(Succeed())
+			})
+
+			It("encrypts the configs saved without one with a key of the team's own", func() {
+				var teamKeyed bool
+				err := encryptedConn.QueryRow(`
+					SELECT r.team_keyed FROM resources r JOIN pipelines p ON p.id = r.pipeline_id WHERE p.name = 'plaintext-pipeline'
+				`).Scan(&teamKeyed)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(teamKeyed).To(BeTrue())
+
+				err = encryptedConn.QueryRow(`
+					SELECT r.team_keyed FROM pipeline_config_revisions r JOIN pipelines p ON p.id = r.pipeline_id WHERE p.name = 'plaintext-pipeline'
+				`).Scan(&teamKeyed)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(teamKeyed).To(BeTrue())
+
+				Expect(dataKey()).ToNot(BeEmpty())
+			})
+
+			It("re-encrypts the team's configs with a new key", func() {
+				_, _, err := encryptedTeam.SavePipeline("encrypted-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+
+				oldKey := dataKey()
+
+				Expect(encryptedTeam.Rekey()).To(Succeed())
+
+				Expect(dataKey()).ToNot(Equal(oldKey))
+
+				for _, name := range []string{"plaintext-pipeline", "encrypted-pipeline"} {
+					pipeline, found, err := encryptedTeam.Pipeline(name)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					resource, found, err := pipeline.Resource("some-resource")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(resource.Source()).To(Equal(atc.Source{"secret": "some-secret"}))
+
+					_, found, err = pipeline.Job("some-job")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					revisions, _, err := pipeline.ConfigRevisions(db.Page{Limit: 1})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(revisions).To(HaveLen(1))
+					Expect(revisions[0].Config.Resources[0].Source).To(Equal(atc.Source{"secret": "some-secret"}))
+				}
+			})
+		})
+	})
 })
@@ -0,0 +1,49 @@
+package db
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+// VersionAnnotation is a note attached to a resource version by an external
+// system, e.g. a QA sign-off or a link to a ticket.
+type VersionAnnotation struct {
+	Name  string
+	Value string
+	URL   string
+}
+
+// versionAnnotations loads the annotations of the given versioned resources,
+// keyed by versioned resource ID and ordered by name.
+func versionAnnotations(runner sq.Runner, versionedResourceIDs []int) (map[int][]VersionAnnotation, error) {
+	annotations := map[int][]VersionAnnotation{}
+
+	if len(versionedResourceIDs) == 0 {
+		return annotations, nil
+	}
+
+	rows, err := psql.Select("versioned_resource_id", "name", "value", "url").
+		From("versioned_resource_annotations").
+		Where(sq.Eq{"versioned_resource_id": versionedResourceIDs}).
+		OrderBy("name").
+		RunWith(runner).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var versionedResourceID int
+		var annotation VersionAnnotation
+
+		err = rows.Scan(&versionedResourceID, &annotation.Name, &annotation.Value, &annotation.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		annotations[versionedResourceID] = append(annotations[versionedResourceID], annotation)
+	}
+
+	return annotations, nil
+}
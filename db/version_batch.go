@@ -0,0 +1,194 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+// versionBatchSize is the most versions saved by a single statement, keeping
+// well clear of postgres's limit on the number of parameters.
+const versionBatchSize = 1000
+
+type batchedVersion struct {
+	version ResourceVersion
+	json    string
+}
+
+// versionBatch is a check's versions in the order they are to be saved, with
+// each saved only once.
+type versionBatch []batchedVersion
+
+// newVersionBatch dedupes versions, keeping the last occurrence of each, as
+// saving it again would have made it newer than everything before it.
+func newVersionBatch(versions []atc.Version) (versionBatch, error) {
+	seen := map[string]bool{}
+
+	reversed := versionBatch{}
+	for i := len(versions) - 1; i >= 0; i-- {
+		versionJSON, err := json.Marshal(versions[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[string(versionJSON)] {
+			continue
+		}
+
+		seen[string(versionJSON)] = true
+
+		reversed = append(reversed, batchedVersion{
+			version: ResourceVersion(versions[i]),
+			json:    string(versionJSON),
+		})
+	}
+
+	batch := make(versionBatch, len(reversed))
+	for i, version := range reversed {
+		batch[len(reversed)-1-i] = version
+	}
+
+	return batch, nil
+}
+
+// values returns a VALUES list of each version and its position in the
+// batch, with placeholders numbered after the given number of arguments.
+func (batch versionBatch) values(offset int) (string, []interface{}) {
+	rows := make([]string, len(batch))
+	args := make([]interface{}, len(batch))
+	for i, version := range batch {
+		rows[i] = fmt.Sprintf("($%d::text, %d)", offset+i+1, i+1)
+		args[i] = version.json
+	}
+
+	return strings.Join(rows, ", "), args
+}
+
+// saveVersions saves the batch in chunks of at most versionBatchSize.
+func saveVersions(tx Tx, resourceID int, resourceType string, batch versionBatch) error {
+	for start := 0; start < len(batch); start += versionBatchSize {
+		end := start + versionBatchSize
+		if end > len(batch) {
+			end = len(batch)
+		}
+
+		err := saveVersionBatch(tx, resourceID, resourceType, batch[start:end])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveVersionBatch saves the versions that have not been seen before and
+// then orders the whole batch after every other version of the resource,
+// as saving them one at a time would.
+func saveVersionBatch(tx Tx, resourceID int, resourceType string, batch versionBatch) error {
+	values, versionArgs := batch.values(2)
+	args := append([]interface{}{resourceID, resourceType}, versionArgs...)
+
+	created, err := insertBatchedVersions(tx, values, args)
+	if err != nil {
+		return err
+	}
+
+	err = indexBatchedVersionFields(tx, batch, created)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		WITH max_checkorder AS (
+			SELECT COALESCE(max(check_order), 0) co
+			FROM versioned_resources
+			WHERE resource_id = $1
+			AND type = $2
+		)
+
+		UPDATE versioned_resources v
+		SET check_order = mc.co + b.position
+		FROM max_checkorder mc, (VALUES `+values+`) AS b (version, position)
+		WHERE v.resource_id = $1
+		AND v.type = $2
+		AND v.version = b.version
+	`, args...)
+	return err
+}
+
+// insertBatchedVersions inserts the versions of the batch which do not exist
+// yet, returning the JSON of each version created by its ID. Versions inserted
+// by a concurrent check of the same resource in the meantime are skipped.
+func insertBatchedVersions(tx Tx, values string, args []interface{}) (map[int]string, error) {
+	rows, err := tx.Query(`
+		INSERT INTO versioned_resources (resource_id, type, version, metadata, modified_time)
+		SELECT $1, $2, b.version, 'null', now()
+		FROM (VALUES `+values+`) AS b (version, position)
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM versioned_resources
+			WHERE resource_id = $1
+			AND type = $2
+			AND version = b.version
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING id, version
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	created := map[int]string{}
+	for rows.Next() {
+		var id int
+		var version string
+		err = rows.Scan(&id, &version)
+		if err != nil {
+			return nil, err
+		}
+
+		created[id] = version
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// indexBatchedVersionFields makes the newly created versions of the batch
+// searchable. Versions saved by a check have no metadata yet.
+func indexBatchedVersionFields(tx Tx, batch versionBatch, created map[int]string) error {
+	if len(created) == 0 {
+		return nil
+	}
+
+	versions := map[string]ResourceVersion{}
+	for _, version := range batch {
+		versions[version.json] = version.version
+	}
+
+	insert := psql.Insert("versioned_resource_fields").
+		Columns("versioned_resource_id", "source", "name", "value")
+
+	fields := 0
+	for id, versionJSON := range created {
+		for name, value := range versions[versionJSON] {
+			insert = insert.Values(id, "version", name, value)
+			fields++
+		}
+	}
+
+	if fields == 0 {
+		return nil
+	}
+
+	_, err := insert.RunWith(tx).Exec()
+	return err
+}
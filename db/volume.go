@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/lib/pq"
@@ -18,6 +19,7 @@ var (
 	ErrVolumeStateTransitionFailed                = errors.New("could not transition volume state")
 	ErrVolumeMissing                              = errors.New("volume no longer in db")
 	ErrInvalidResourceCache                       = errors.New("invalid resource cache")
+	ErrReplicatedResourceCacheDisappeared         = errors.New("replicated resource cache disappeared")
 )
 
 type ErrVolumeMarkStateFailed struct {
@@ -148,7 +150,9 @@ type CreatedVolume interface {
 	CreateChildForContainer(CreatingContainer, string) (CreatingVolume, error)
 	Destroying() (DestroyingVolume, error)
 	WorkerName() string
-	InitializeResourceCache(*UsedResourceCache) error
+	InitializeResourceCache(*UsedResourceCache, int) error
+	InitializeResourceCacheReplica() error
+	RecordFetch() error
 	InitializeTaskCache(int, string, string) error
 	ContainerHandle() string
 	ParentHandle() string
@@ -333,13 +337,17 @@ func (volume *createdVolume) findWorkerBaseResourceTypeByBaseResourceTypeID(base
 	}, nil
 }
 
-func (volume *createdVolume) InitializeResourceCache(resourceCache *UsedResourceCache) error {
+// InitializeResourceCache makes the volume the resource cache's volume on its
+// worker for the team. A team ID of 0 shares the cache across teams, so the
+// volume no longer belongs to the team it was created for.
+func (volume *createdVolume) InitializeResourceCache(resourceCache *UsedResourceCache, teamID int) error {
 	var workerResourceCache *UsedWorkerResourceCache
 	err := safeFindOrCreate(volume.conn, func(tx Tx) error {
 		var err error
 		workerResourceCache, err = WorkerResourceCache{
 			WorkerName:    volume.WorkerName(),
 			ResourceCache: resourceCache,
+			TeamID:        teamID,
 		}.FindOrCreate(tx)
 		return err
 	})
@@ -347,9 +355,13 @@ func (volume *createdVolume) InitializeResourceCache(resourceCache *UsedResource
 		return err
 	}
 
-	rows, err := psql.Update("volumes").
-		Set("worker_resource_cache_id", workerResourceCache.ID).
-		Set("team_id", nil).
+	update := psql.Update("volumes").
+		Set("worker_resource_cache_id", workerResourceCache.ID)
+	if teamID == 0 {
+		update = update.Set("team_id", nil)
+	}
+
+	rows, err := update.
 		Where(sq.Eq{"id": volume.id}).
 		RunWith(volume.conn).
 		Exec()
@@ -377,6 +389,142 @@ func (volume *createdVolume) InitializeResourceCache(resourceCache *UsedResource
 	return nil
 }
 
+// InitializeResourceCacheReplica makes a volume created with
+// CreateResourceCacheReplicaVolume the volume of the resource cache it was
+// copied from, for the same team, on its own worker.
+func (volume *createdVolume) InitializeResourceCacheReplica() error {
+	tx, err := volume.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var (
+		resourceCacheID    int
+		baseResourceTypeID int
+		teamID             sql.NullInt64
+	)
+
+	err = psql.Select("wrc.resource_cache_id", "wbrt.base_resource_type_id", "wrc.team_id").
+		From("volumes v").
+		Join("worker_resource_caches wrc ON wrc.id = v.replica_of_worker_resource_cache_id").
+		Join("worker_base_resource_types wbrt ON wbrt.id = wrc.worker_base_resource_type_id").
+		Where(sq.Eq{"v.id": volume.id}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&resourceCacheID, &baseResourceTypeID, &teamID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrReplicatedResourceCacheDisappeared
+		}
+
+		return err
+	}
+
+	var workerBaseResourceTypeID int
+	err = psql.Select("id").
+		From("worker_base_resource_types").
+		Where(sq.Eq{
+			"worker_name":           volume.workerName,
+			"base_resource_type_id": baseResourceTypeID,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&workerBaseResourceTypeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrWorkerBaseResourceTypeDisappeared
+		}
+
+		return err
+	}
+
+	var team interface{}
+	if teamID.Valid {
+		team = teamID.Int64
+	}
+
+	// the worker may have fetched the cache itself while it was being copied
+	var workerResourceCacheID int
+	err = psql.Insert("worker_resource_caches").
+		Columns("resource_cache_id", "worker_base_resource_type_id", "team_id").
+		Values(resourceCacheID, workerBaseResourceTypeID, team).
+		Suffix("ON CONFLICT (resource_cache_id, worker_base_resource_type_id, COALESCE(team_id, 0)) DO UPDATE SET resource_cache_id = EXCLUDED.resource_cache_id RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&workerResourceCacheID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := psql.Update("volumes").
+		Set("worker_resource_cache_id", workerResourceCacheID).
+		Set("replica_of_worker_resource_cache_id", nil).
+		Where(sq.Eq{
+			"id":    volume.id,
+			"state": VolumeStateCreated,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrVolumeMissing
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	volume.resourceCacheID = resourceCacheID
+	volume.typ = VolumeTypeResource
+
+	return nil
+}
+
+const (
+	// resourceCacheFetchInterval is how often fetches of a resource cache are
+	// counted, so that a busy cache is not written to on every get.
+	resourceCacheFetchInterval = time.Minute
+
+	// resourceCacheFetchHalfLife is how long it takes for the fetch count of
+	// a resource cache to halve once it is no longer fetched.
+	resourceCacheFetchHalfLife = time.Hour
+)
+
+// decayedFetchCount is the fetch count of the worker resource cache aliased
+// as wrc, decayed by the time since it was last fetched.
+var decayedFetchCount = fmt.Sprintf(
+	`COALESCE(floor(wrc.fetch_count * power(0.5, extract(epoch FROM now() - wrc.last_fetched_at) / %d))::integer, 0)`,
+	int(resourceCacheFetchHalfLife.Seconds()),
+)
+
+// RecordFetch counts a fetch of the resource cache held by the volume, which
+// is used to find the caches worth copying onto other workers. At most one
+// fetch is counted per resourceCacheFetchInterval.
+func (volume *createdVolume) RecordFetch() error {
+	_, err := psql.Update("worker_resource_caches wrc").
+		Set("fetch_count", sq.Expr(decayedFetchCount+" + 1")).
+		Set("last_fetched_at", sq.Expr("now()")).
+		Where(sq.Expr("wrc.id = (SELECT worker_resource_cache_id FROM volumes WHERE id = ?)", volume.id)).
+		Where(sq.Or{
+			sq.Eq{"wrc.last_fetched_at": nil},
+			sq.Expr("wrc.last_fetched_at < now() - ? * interval '1 second'", resourceCacheFetchInterval.Seconds()),
+		}).
+		RunWith(volume.conn).
+		Exec()
+	return err
+}
+
 func (volume *createdVolume) InitializeTaskCache(jobID int, stepName string, path string) error {
 	var usedWorkerTaskCache *UsedWorkerTaskCache
 
@@ -484,6 +632,16 @@ func (volume *createdVolume) CreateChildForContainer(container CreatingContainer
 		return nil, err
 	}
 
+	// caches are reclaimed by how long they have gone unused
+	_, err = psql.Update("volumes").
+		Set("last_used_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": volume.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"errors"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/nu7hatch/gouuid"
@@ -19,7 +20,15 @@ type VolumeFactory interface {
 	FindBaseResourceTypeVolume(int, *UsedWorkerBaseResourceType) (CreatingVolume, CreatedVolume, error)
 	CreateBaseResourceTypeVolume(int, *UsedWorkerBaseResourceType) (CreatingVolume, error)
 
-	FindResourceCacheVolume(string, *UsedResourceCache) (CreatedVolume, bool, error)
+	FindResourceCacheVolume(workerName string, teamID int, resourceCache *UsedResourceCache) (CreatedVolume, bool, error)
+
+	// GetHotResourceCacheVolumes returns the volumes of the most frequently
+	// fetched resource caches on each running worker, up to the limit per
+	// worker, most fetched first. Fetch counts decay over time, so caches
+	// which are no longer fetched cool down.
+	GetHotResourceCacheVolumes(limit int) ([]HotResourceCacheVolume, error)
+	FindResourceCacheReplicaWorkers(HotResourceCacheVolume) ([]string, error)
+	CreateResourceCacheReplicaVolume(workerName string, source HotResourceCacheVolume) (CreatingVolume, error)
 
 	FindTaskCacheVolume(teamID int, uwtc *UsedWorkerTaskCache) (CreatingVolume, CreatedVolume, error)
 	CreateTaskCacheVolume(teamID int, uwtc *UsedWorkerTaskCache) (CreatingVolume, error)
@@ -32,9 +41,26 @@ type VolumeFactory interface {
 
 	GetFailedVolumes() ([]FailedVolume, error)
 
+	// GetReclaimableVolumes returns the cache volumes on running workers which
+	// are not in use by any container, build or resource, least recently used
+	// first.
+	GetReclaimableVolumes() ([]ReclaimableVolume, error)
+
 	FindCreatedVolume(handle string) (CreatedVolume, bool, error)
 }
 
+// HotResourceCacheVolume is the volume of a frequently fetched resource cache
+// on a worker.
+type HotResourceCacheVolume struct {
+	WorkerResourceCacheID int
+	ResourceCacheID       int
+	BaseResourceTypeName  string
+	WorkerName            string
+	TeamID                int
+	VolumeHandle          string
+	FetchCount            int
+}
+
 type volumeFactory struct {
 	conn Conn
 }
@@ -218,17 +244,21 @@ func (factory *volumeFactory) CreateResourceCertsVolume(workerName string, uwrc
 	return volume, nil
 }
 
-func (factory *volumeFactory) FindResourceCacheVolume(workerName string, resourceCache *UsedResourceCache) (CreatedVolume, bool, error) {
+// FindResourceCacheVolume finds the volume holding the resource cache on the
+// worker for the team, or the one shared across teams if the team ID is 0,
+// falling back to the warm image of the resource cache on the worker.
+func (factory *volumeFactory) FindResourceCacheVolume(workerName string, teamID int, resourceCache *UsedResourceCache) (CreatedVolume, bool, error) {
 	workerResourceCache, found, err := WorkerResourceCache{
 		WorkerName:    workerName,
 		ResourceCache: resourceCache,
+		TeamID:        teamID,
 	}.Find(factory.conn)
 	if err != nil {
 		return nil, false, err
 	}
 
 	if !found {
-		return nil, false, nil
+		return factory.findWarmResourceCacheVolume(workerName, resourceCache)
 	}
 
 	_, createdVolume, err := factory.findVolume(0, workerName, map[string]interface{}{
@@ -245,6 +275,169 @@ func (factory *volumeFactory) FindResourceCacheVolume(workerName string, resourc
 	return createdVolume, true, nil
 }
 
+// findWarmResourceCacheVolume finds the volume of the resource cache on the
+// worker if it is pinned there as a warm image. Warm images are shared by
+// every team: the resource cache identifies the exact type, source, params
+// and version of the image, so the volume holds what the team would otherwise
+// have fetched itself.
+func (factory *volumeFactory) findWarmResourceCacheVolume(workerName string, resourceCache *UsedResourceCache) (CreatedVolume, bool, error) {
+	var pinned bool
+	err := factory.conn.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM worker_warm_resource_types WHERE worker_name = $1 AND resource_cache_id = $2)
+	`, workerName, resourceCache.ID).Scan(&pinned)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !pinned {
+		return nil, false, nil
+	}
+
+	_, createdVolume, err := factory.findVolume(0, workerName, map[string]interface{}{
+		"wrc.resource_cache_id": resourceCache.ID,
+		"v.state":               string(VolumeStateCreated),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if createdVolume == nil {
+		return nil, false, nil
+	}
+
+	return createdVolume, true, nil
+}
+
+func (factory *volumeFactory) GetHotResourceCacheVolumes(limit int) ([]HotResourceCacheVolume, error) {
+	rows, err := factory.conn.Query(`
+		SELECT wrc_id, resource_cache_id, base_resource_type_name, worker_name, team_id, handle, fetch_count
+		FROM (
+			SELECT *, row_number() OVER (PARTITION BY worker_name ORDER BY fetch_count DESC, wrc_id ASC) AS rank
+			FROM (
+				SELECT
+					wrc.id AS wrc_id,
+					wrc.resource_cache_id,
+					brt.name AS base_resource_type_name,
+					v.worker_name,
+					wrc.team_id,
+					v.handle,
+					`+decayedFetchCount+` AS fetch_count
+				FROM volumes v
+				JOIN workers w ON w.name = v.worker_name
+				JOIN worker_resource_caches wrc ON wrc.id = v.worker_resource_cache_id
+				JOIN worker_base_resource_types wbrt ON wbrt.id = wrc.worker_base_resource_type_id
+				JOIN base_resource_types brt ON brt.id = wbrt.base_resource_type_id
+				WHERE v.state = $1
+				AND w.state = $2
+			) fetched
+			WHERE fetch_count > 0
+		) hot
+		WHERE rank <= $3
+		ORDER BY fetch_count DESC, wrc_id ASC
+	`, string(VolumeStateCreated), string(WorkerStateRunning), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	volumes := []HotResourceCacheVolume{}
+	for rows.Next() {
+		var (
+			volume HotResourceCacheVolume
+			teamID sql.NullInt64
+		)
+
+		err = rows.Scan(
+			&volume.WorkerResourceCacheID,
+			&volume.ResourceCacheID,
+			&volume.BaseResourceTypeName,
+			&volume.WorkerName,
+			&teamID,
+			&volume.VolumeHandle,
+			&volume.FetchCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if teamID.Valid {
+			volume.TeamID = int(teamID.Int64)
+		}
+
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, rows.Err()
+}
+
+// FindResourceCacheReplicaWorkers returns the names of the workers holding the
+// resource cache for the same team as the hot volume, including those it is
+// still being copied onto.
+func (factory *volumeFactory) FindResourceCacheReplicaWorkers(source HotResourceCacheVolume) ([]string, error) {
+	var teamID interface{}
+	if source.TeamID != 0 {
+		teamID = source.TeamID
+	}
+
+	rows, err := psql.Select("DISTINCT v.worker_name").
+		From("volumes v").
+		Join("worker_resource_caches wrc ON wrc.id = v.worker_resource_cache_id OR wrc.id = v.replica_of_worker_resource_cache_id").
+		Where(sq.Eq{
+			"wrc.resource_cache_id": source.ResourceCacheID,
+			"wrc.team_id":           teamID,
+		}).
+		Where(sq.Or{
+			sq.Eq{"v.state": string(VolumeStateCreating)},
+			sq.Eq{"v.state": string(VolumeStateCreated)},
+		}).
+		RunWith(factory.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	workerNames := []string{}
+	for rows.Next() {
+		var workerName string
+		err = rows.Scan(&workerName)
+		if err != nil {
+			return nil, err
+		}
+
+		workerNames = append(workerNames, workerName)
+	}
+
+	return workerNames, rows.Err()
+}
+
+// resourceCacheReplicaTimeout is how long a copy of a resource cache may take
+// before its volume is garbage collected.
+const resourceCacheReplicaTimeout = time.Hour
+
+// CreateResourceCacheReplicaVolume creates a volume on the worker for a copy of
+// the hot volume. The volume is kept from garbage collection while it is
+// copied, for up to resourceCacheReplicaTimeout, and becomes the resource
+// cache's volume on the worker once it is initialized with
+// InitializeResourceCacheReplica.
+func (factory *volumeFactory) CreateResourceCacheReplicaVolume(workerName string, source HotResourceCacheVolume) (CreatingVolume, error) {
+	volume, err := factory.createVolume(
+		source.TeamID,
+		workerName,
+		map[string]interface{}{
+			"replica_of_worker_resource_cache_id": source.WorkerResourceCacheID,
+		},
+		VolumeTypeResource,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return volume, nil
+}
+
 func (factory *volumeFactory) FindCreatedVolume(handle string) (CreatedVolume, bool, error) {
 	_, createdVolume, err := factory.findVolume(0, "", map[string]interface{}{
 		"v.handle": handle,
@@ -274,6 +467,10 @@ func (factory *volumeFactory) GetOrphanedVolumes() ([]CreatedVolume, []Destroyin
 			"v.worker_task_cache_id":         nil,
 			"v.worker_resource_certs_id":     nil,
 		}).
+		Where(sq.Or{
+			sq.Eq{"v.replica_of_worker_resource_cache_id": nil},
+			sq.Expr("v.created_at < now() - ? * interval '1 second'", resourceCacheReplicaTimeout.Seconds()),
+		}).
 		Where(sq.Or{
 			sq.Eq{"v.state": string(VolumeStateCreated)},
 			sq.Eq{"v.state": string(VolumeStateDestroying)},
@@ -316,6 +513,83 @@ func (factory *volumeFactory) GetOrphanedVolumes() ([]CreatedVolume, []Destroyin
 	return createdVolumes, destroyingVolumes, nil
 }
 
+func (factory *volumeFactory) GetReclaimableVolumes() ([]ReclaimableVolume, error) {
+	rows, err := psql.Select(
+		"v.id",
+		"v.handle",
+		"v.worker_name",
+		"COALESCE(t.name, '')",
+		"v.created_at",
+		"v.last_used_at",
+		"w.disk_usage",
+		"COALESCE(v.size, 0)",
+		"COALESCE(u.resource_cache_bytes + u.task_cache_bytes, 0)",
+		"v.worker_resource_cache_id",
+		"v.worker_task_cache_id",
+	).
+		From("volumes v").
+		Join("workers w ON v.worker_name = w.name").
+		LeftJoin("teams t ON v.team_id = t.id").
+		LeftJoin("team_cache_usages u ON v.team_id = u.team_id").
+		Where(sq.Eq{
+			"v.state":        string(VolumeStateCreated),
+			"v.container_id": nil,
+			"w.state":        string(WorkerStateRunning),
+		}).
+		Where(sq.Or{
+			sq.NotEq{"v.worker_resource_cache_id": nil},
+			sq.NotEq{"v.worker_task_cache_id": nil},
+		}).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM volumes cv WHERE cv.parent_id = v.id)")).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM worker_resource_caches wrc JOIN worker_warm_resource_types wwrt ON wwrt.resource_cache_id = wrc.resource_cache_id WHERE wrc.id = v.worker_resource_cache_id AND wwrt.worker_name = v.worker_name)")).
+		Where(sq.Expr("NOT EXISTS (" + resourceCacheInUse + ")")).
+		OrderBy("v.last_used_at ASC", "v.id ASC").
+		RunWith(factory.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	volumes := []ReclaimableVolume{}
+	for rows.Next() {
+		volume := &reclaimableVolume{conn: factory.conn}
+
+		var workerResourceCacheID, workerTaskCacheID sql.NullInt64
+		err = rows.Scan(
+			&volume.id,
+			&volume.handle,
+			&volume.workerName,
+			&volume.teamName,
+			&volume.createdAt,
+			&volume.lastUsedAt,
+			&volume.workerDiskUsage,
+			&volume.size,
+			&volume.teamCacheUsage,
+			&workerResourceCacheID,
+			&workerTaskCacheID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if workerResourceCacheID.Valid {
+			id := int(workerResourceCacheID.Int64)
+			volume.workerResourceCacheID = &id
+		}
+
+		if workerTaskCacheID.Valid {
+			id := int(workerTaskCacheID.Int64)
+			volume.workerTaskCacheID = &id
+		}
+
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, rows.Err()
+}
+
 func (factory *volumeFactory) GetFailedVolumes() ([]FailedVolume, error) {
 	query, args, err := psql.Select(volumeColumns...).
 		From("volumes v").
@@ -455,15 +729,17 @@ var volumeColumns = []string{
 	"v.worker_base_resource_type_id",
 	"v.worker_task_cache_id",
 	"v.worker_resource_certs_id",
-	`case
+	volumeTypeColumn,
+}
+
+const volumeTypeColumn = `case
 	when v.worker_base_resource_type_id is not NULL then 'resource-type'
 	when v.worker_resource_cache_id is not NULL then 'resource'
 	when v.container_id is not NULL then 'container'
 	when v.worker_task_cache_id is not NULL then 'task-cache'
 	when v.worker_resource_certs_id is not NULL then 'resource-certs'
 	else 'unknown'
-end`,
-}
+end`
 
 func scanVolume(row sq.RowScanner, conn Conn) (CreatingVolume, CreatedVolume, DestroyingVolume, FailedVolume, error) {
 	var id int
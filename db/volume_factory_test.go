@@ -20,7 +20,7 @@ var _ = Describe("VolumeFactory", func() {
 
 	BeforeEach(func() {
 		var err error
-		build, err = defaultTeam.CreateOneOffBuild()
+		build, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 		Expect(err).ToNot(HaveOccurred())
 
 		usedResourceCache, err = resourceCacheFactory.FindOrCreateResourceCache(
@@ -201,7 +201,7 @@ var _ = Describe("VolumeFactory", func() {
 			resourceCacheVolumeCreated, err := resourceCacheVolume.Created()
 			Expect(err).NotTo(HaveOccurred())
 
-			err = resourceCacheVolumeCreated.InitializeResourceCache(usedResourceCache)
+			err = resourceCacheVolumeCreated.InitializeResourceCache(usedResourceCache, 0)
 			Expect(err).NotTo(HaveOccurred())
 
 			tx, err := dbConn.Begin()
@@ -297,6 +297,132 @@ var _ = Describe("VolumeFactory", func() {
 		})
 	})
 
+	Describe("GetReclaimableVolumes", func() {
+		var taskCacheVolume db.CreatedVolume
+
+		BeforeEach(func() {
+			taskCache, err := workerTaskCacheFactory.FindOrCreate(defaultJob.ID(), "some-step", "some-path", defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+
+			creatingVolume, err := volumeFactory.CreateTaskCacheVolume(defaultTeam.ID(), taskCache)
+			Expect(err).NotTo(HaveOccurred())
+
+			taskCacheVolume, err = creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns cache volumes not in use by any container", func() {
+			volumes, err := volumeFactory.GetReclaimableVolumes()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(volumes).To(HaveLen(1))
+			Expect(volumes[0].Handle()).To(Equal(taskCacheVolume.Handle()))
+			Expect(volumes[0].WorkerName()).To(Equal(defaultWorker.Name()))
+			Expect(volumes[0].TeamName()).To(Equal(defaultTeam.Name()))
+			Expect(volumes[0].CreatedAt()).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		Context("when the cache volume is in use by a container", func() {
+			BeforeEach(func() {
+				creatingContainer, err := defaultTeam.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), "some-plan"), db.ContainerMetadata{
+					Type:     "task",
+					StepName: "some-task",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = taskCacheVolume.CreateChildForContainer(creatingContainer, "some-path")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not return it", func() {
+				volumes, err := volumeFactory.GetReclaimableVolumes()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(BeEmpty())
+			})
+
+			It("records when it was last used", func() {
+				var usedSinceCreated bool
+				err := dbConn.QueryRow(`SELECT last_used_at > created_at FROM volumes WHERE handle = $1`, taskCacheVolume.Handle()).Scan(&usedSinceCreated)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usedSinceCreated).To(BeTrue())
+			})
+		})
+
+		Context("when a resource cache volume is used by a build", func() {
+			var resourceCacheVolume db.CreatedVolume
+
+			BeforeEach(func() {
+				creatingContainer, err := defaultTeam.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), "some-get-plan"), db.ContainerMetadata{
+					Type:     "get",
+					StepName: "some-get",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				creatingVolume, err := volumeFactory.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, "some-path")
+				Expect(err).NotTo(HaveOccurred())
+
+				resourceCacheVolume, err = creatingVolume.Created()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = resourceCacheVolume.InitializeResourceCache(usedResourceCache, 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				// as when the get step's container has gone away
+				_, err = dbConn.Exec(`UPDATE volumes SET container_id = NULL WHERE handle = $1`, resourceCacheVolume.Handle())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not return it", func() {
+				volumes, err := volumeFactory.GetReclaimableVolumes()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(volumes).To(HaveLen(1))
+				Expect(volumes[0].Handle()).To(Equal(taskCacheVolume.Handle()))
+			})
+
+			Context("once nothing uses the cache", func() {
+				BeforeEach(func() {
+					_, err := dbConn.Exec(`DELETE FROM resource_cache_uses`)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns it", func() {
+					volumes, err := volumeFactory.GetReclaimableVolumes()
+					Expect(err).NotTo(HaveOccurred())
+
+					handles := []string{}
+					for _, volume := range volumes {
+						handles = append(handles, volume.Handle())
+					}
+
+					Expect(handles).To(ConsistOf(taskCacheVolume.Handle(), resourceCacheVolume.Handle()))
+				})
+			})
+		})
+
+		Describe("reclaiming a volume", func() {
+			It("orphans the volume, so that it is garbage collected", func() {
+				volumes, err := volumeFactory.GetReclaimableVolumes()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(HaveLen(1))
+
+				reclaimed, err := volumes[0].Reclaim()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reclaimed).To(BeTrue())
+
+				createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+				Expect(err).NotTo(HaveOccurred())
+
+				handles := []string{}
+				for _, volume := range createdVolumes {
+					handles = append(handles, volume.Handle())
+				}
+
+				Expect(handles).To(ContainElement(taskCacheVolume.Handle()))
+			})
+		})
+	})
+
 	Describe("GetFailedVolumes", func() {
 
 		var expectedFailedHandles []string
@@ -386,7 +512,7 @@ var _ = Describe("VolumeFactory", func() {
 		var usedResourceCache *db.UsedResourceCache
 
 		BeforeEach(func() {
-			build, err := defaultPipeline.CreateOneOffBuild()
+			build, err := defaultPipeline.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).NotTo(HaveOccurred())
 
 			usedResourceCache, err = resourceCacheFactory.FindOrCreateResourceCache(
@@ -434,16 +560,227 @@ var _ = Describe("VolumeFactory", func() {
 				existingVolume, err = resourceCacheVolume.Created()
 				Expect(err).NotTo(HaveOccurred())
 
-				err = existingVolume.InitializeResourceCache(usedResourceCache)
+				err = existingVolume.InitializeResourceCache(usedResourceCache, 0)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("returns created volume", func() {
-				createdVolume, found, err := volumeFactory.FindResourceCacheVolume(defaultWorker.Name(), usedResourceCache)
+				createdVolume, found, err := volumeFactory.FindResourceCacheVolume(defaultWorker.Name(), 0, usedResourceCache)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(createdVolume.Handle()).To(Equal(existingVolume.Handle()))
 				Expect(found).To(BeTrue())
 			})
 		})
+
+		Context("when another team's volume for the resource cache is on the worker", func() {
+			var (
+				otherTeam      db.Team
+				existingVolume db.CreatedVolume
+			)
+
+			BeforeEach(func() {
+				var err error
+				otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+				Expect(err).ToNot(HaveOccurred())
+
+				creatingContainer, err := defaultTeam.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), "some-plan"), db.ContainerMetadata{
+					Type:     "get",
+					StepName: "some-resource",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				resourceCacheVolume, err := volumeFactory.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, "some-path-4")
+				Expect(err).NotTo(HaveOccurred())
+
+				existingVolume, err = resourceCacheVolume.Created()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = existingVolume.InitializeResourceCache(usedResourceCache, defaultTeam.ID())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not return it", func() {
+				_, found, err := volumeFactory.FindResourceCacheVolume(defaultWorker.Name(), otherTeam.ID(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			Context("when the resource cache is pinned on the worker as a warm image", func() {
+				BeforeEach(func() {
+					err := db.NewWorkerWarmResourceTypeFactory(dbConn).Pin(defaultWorker.Name(), "some-warm-type", usedResourceCache)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns it", func() {
+					createdVolume, found, err := volumeFactory.FindResourceCacheVolume(defaultWorker.Name(), otherTeam.ID(), usedResourceCache)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(createdVolume.Handle()).To(Equal(existingVolume.Handle()))
+				})
+
+				It("does not return it for a worker it is not pinned on", func() {
+					_, found, err := volumeFactory.FindResourceCacheVolume("some-other-worker", otherTeam.ID(), usedResourceCache)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("resource cache replicas", func() {
+		var (
+			cacheVolume db.CreatedVolume
+			otherWorker db.Worker
+		)
+
+		BeforeEach(func() {
+			creatingContainer, err := defaultTeam.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), "some-plan"), db.ContainerMetadata{
+				Type:     "get",
+				StepName: "some-resource",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			creatingVolume, err := volumeFactory.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, "some-cache-path")
+			Expect(err).NotTo(HaveOccurred())
+
+			cacheVolume, err = creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = cacheVolume.InitializeResourceCache(usedResourceCache, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			otherWorkerPayload := defaultWorkerPayload
+			otherWorkerPayload.Name = "some-other-worker"
+			otherWorker, err = workerFactory.SaveWorker(otherWorkerPayload, 0)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		handles := func(volumes []db.CreatedVolume) []string {
+			handles := []string{}
+			for _, volume := range volumes {
+				handles = append(handles, volume.Handle())
+			}
+			return handles
+		}
+
+		hotVolumes := func() []db.HotResourceCacheVolume {
+			hot, err := volumeFactory.GetHotResourceCacheVolumes(5)
+			Expect(err).NotTo(HaveOccurred())
+			return hot
+		}
+
+		Describe("RecordFetch", func() {
+			It("counts at most one fetch per interval", func() {
+				Expect(hotVolumes()).To(BeEmpty())
+
+				Expect(cacheVolume.RecordFetch()).To(Succeed())
+				Expect(cacheVolume.RecordFetch()).To(Succeed())
+
+				hot := hotVolumes()
+				Expect(hot).To(HaveLen(1))
+				Expect(hot[0].VolumeHandle).To(Equal(cacheVolume.Handle()))
+				Expect(hot[0].FetchCount).To(Equal(1))
+			})
+
+			Context("when the cache has not been fetched for a while", func() {
+				BeforeEach(func() {
+					_, err := dbConn.Exec(`UPDATE worker_resource_caches SET fetch_count = 8, last_fetched_at = now() - interval '119 minutes' WHERE resource_cache_id = $1`, usedResourceCache.ID)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("decays its fetch count", func() {
+					Expect(hotVolumes()[0].FetchCount).To(Equal(2))
+
+					Expect(cacheVolume.RecordFetch()).To(Succeed())
+					Expect(hotVolumes()[0].FetchCount).To(Equal(3))
+				})
+			})
+		})
+
+		Describe("replicating the cache onto another worker", func() {
+			var replicaVolume db.CreatedVolume
+
+			BeforeEach(func() {
+				Expect(cacheVolume.RecordFetch()).To(Succeed())
+
+				creatingVolume, err := volumeFactory.CreateResourceCacheReplicaVolume(otherWorker.Name(), hotVolumes()[0])
+				Expect(err).NotTo(HaveOccurred())
+
+				replicaVolume, err = creatingVolume.Created()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("becomes the worker's volume for the cache once initialized", func() {
+				err := replicaVolume.InitializeResourceCacheReplica()
+				Expect(err).NotTo(HaveOccurred())
+
+				volume, found, err := volumeFactory.FindResourceCacheVolume(otherWorker.Name(), 0, usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(volume.Handle()).To(Equal(replicaVolume.Handle()))
+			})
+
+			Context("when the worker fetched the cache itself while it was being copied", func() {
+				BeforeEach(func() {
+					creatingContainer, err := defaultTeam.CreateContainer(otherWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), "some-other-plan"), db.ContainerMetadata{
+						Type:     "get",
+						StepName: "some-resource",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					creatingVolume, err := volumeFactory.CreateContainerVolume(defaultTeam.ID(), otherWorker.Name(), creatingContainer, "some-cache-path")
+					Expect(err).NotTo(HaveOccurred())
+
+					fetchedVolume, err := creatingVolume.Created()
+					Expect(err).NotTo(HaveOccurred())
+
+					err = fetchedVolume.InitializeResourceCache(usedResourceCache, 0)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("shares the worker's resource cache", func() {
+					err := replicaVolume.InitializeResourceCacheReplica()
+					Expect(err).NotTo(HaveOccurred())
+
+					var workerResourceCaches int
+					err = dbConn.QueryRow(`
+						SELECT count(*)
+						FROM worker_resource_caches wrc
+						JOIN worker_base_resource_types wbrt ON wbrt.id = wrc.worker_base_resource_type_id
+						WHERE wrc.resource_cache_id = $1
+						AND wbrt.worker_name = $2
+					`, usedResourceCache.ID, otherWorker.Name()).Scan(&workerResourceCaches)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(workerResourceCaches).To(Equal(1))
+				})
+			})
+
+			It("is not orphaned while it is being copied", func() {
+				createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(handles(createdVolumes)).NotTo(ContainElement(replicaVolume.Handle()))
+			})
+
+			Context("when it was never initialized", func() {
+				BeforeEach(func() {
+					_, err := dbConn.Exec(`UPDATE volumes SET created_at = now() - interval '2 hours' WHERE handle = $1`, replicaVolume.Handle())
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("is orphaned", func() {
+					createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(handles(createdVolumes)).To(ContainElement(replicaVolume.Handle()))
+				})
+
+				It("can no longer be initialized once it is being destroyed", func() {
+					_, err := replicaVolume.Destroying()
+					Expect(err).NotTo(HaveOccurred())
+
+					err = replicaVolume.InitializeResourceCacheReplica()
+					Expect(err).To(Equal(db.ErrVolumeMissing))
+				})
+			})
+		})
 	})
 })
@@ -192,7 +192,7 @@ var _ = Describe("Volume", func() {
 
 		BeforeEach(func() {
 			var err error
-			build, err = defaultTeam.CreateOneOffBuild()
+			build, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceCache, err = resourceCacheFactory.FindOrCreateResourceCache(
@@ -234,12 +234,12 @@ var _ = Describe("Volume", func() {
 			createdVolume, err = resourceCacheVolume.Created()
 			Expect(err).ToNot(HaveOccurred())
 
-			err = createdVolume.InitializeResourceCache(resourceCache)
+			err = createdVolume.InitializeResourceCache(resourceCache, 0)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
 		It("associates the volume to the resource cache", func() {
-			foundVolume, found, err := volumeFactory.FindResourceCacheVolume(defaultWorker.Name(), resourceCache)
+			foundVolume, found, err := volumeFactory.FindResourceCacheVolume(defaultWorker.Name(), 0, resourceCache)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(foundVolume.Handle()).To(Equal(createdVolume.Handle()))
 			Expect(found).To(BeTrue())
@@ -259,12 +259,46 @@ var _ = Describe("Volume", func() {
 				createdVolume, err = resourceCacheVolume.Created()
 				Expect(err).ToNot(HaveOccurred())
 
-				err = createdVolume.InitializeResourceCache(resourceCache)
+				err = createdVolume.InitializeResourceCache(resourceCache, 0)
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(createdVolume.Type()).To(Equal(db.VolumeTypeContainer))
 			})
 		})
+
+		Context("when initialized for a team", func() {
+			var teamVolume db.CreatedVolume
+
+			BeforeEach(func() {
+				creatingContainer, err := defaultTeam.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), "some-plan"), db.ContainerMetadata{
+					Type:     "get",
+					StepName: "some-resource",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				resourceCacheVolume, err := volumeFactory.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, "some-path")
+				Expect(err).ToNot(HaveOccurred())
+
+				teamVolume, err = resourceCacheVolume.Created()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = teamVolume.InitializeResourceCache(resourceCache, defaultTeam.ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps the team's cache separate from the shared one", func() {
+				foundVolume, found, err := volumeFactory.FindResourceCacheVolume(defaultWorker.Name(), defaultTeam.ID(), resourceCache)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundVolume.Handle()).To(Equal(teamVolume.Handle()))
+				Expect(foundVolume.TeamID()).To(Equal(defaultTeam.ID()))
+
+				foundVolume, found, err = volumeFactory.FindResourceCacheVolume(defaultWorker.Name(), 0, resourceCache)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundVolume.Handle()).To(Equal(createdVolume.Handle()))
+			})
+		})
 	})
 
 	Describe("createdVolume.InitializeTaskCache", func() {
@@ -275,7 +309,7 @@ var _ = Describe("Volume", func() {
 			)
 
 			BeforeEach(func() {
-				build, err := defaultTeam.CreateOneOffBuild()
+				build, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 
 				creatingContainer, err := defaultTeam.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), "some-plan"), db.ContainerMetadata{})
@@ -383,7 +417,7 @@ var _ = Describe("Volume", func() {
 
 	Describe("Resource cache volumes", func() {
 		It("returns volume type, resource type, resource version", func() {
-			build, err := defaultTeam.CreateOneOffBuild()
+			build, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
@@ -422,7 +456,7 @@ var _ = Describe("Volume", func() {
 
 			Expect(createdVolume.Type()).To(Equal(db.VolumeType(db.VolumeTypeContainer)))
 
-			err = createdVolume.InitializeResourceCache(resourceCache)
+			err = createdVolume.InitializeResourceCache(resourceCache, 0)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(createdVolume.Type()).To(Equal(db.VolumeType(db.VolumeTypeResource)))
@@ -434,7 +468,7 @@ var _ = Describe("Volume", func() {
 			Expect(volumeResourceType.ResourceType.Version).To(Equal(atc.Version{"some-custom-type": "version"}))
 			Expect(volumeResourceType.Version).To(Equal(atc.Version{"some": "version"}))
 
-			createdVolume, found, err := volumeFactory.FindResourceCacheVolume(defaultWorker.Name(), resourceCache)
+			createdVolume, found, err := volumeFactory.FindResourceCacheVolume(defaultWorker.Name(), 0, resourceCache)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(createdVolume.Type()).To(Equal(db.VolumeType(db.VolumeTypeResource)))
@@ -500,7 +534,7 @@ var _ = Describe("Volume", func() {
 		var creatingContainer db.CreatingContainer
 
 		BeforeEach(func() {
-			build, err := defaultTeam.CreateOneOffBuild()
+			build, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			creatingContainer, err = defaultTeam.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), "some-plan"), db.ContainerMetadata{
@@ -543,7 +577,7 @@ var _ = Describe("Volume", func() {
 			parentVolume, err = creatingParentVolume.Created()
 			Expect(err).ToNot(HaveOccurred())
 
-			err = parentVolume.InitializeResourceCache(usedResourceCache)
+			err = parentVolume.InitializeResourceCache(usedResourceCache, 0)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -7,6 +7,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
+	"github.com/lib/pq"
 )
 
 var (
@@ -38,6 +39,15 @@ type Worker interface {
 	HTTPSProxyURL() string
 	NoProxy() string
 	ActiveContainers() int
+
+	// BuildContainers is the number of containers on the worker which belong
+	// to builds.
+	BuildContainers() int
+
+	// ActiveTasks is the number of the worker's task slots held by tasks of
+	// builds still running.
+	ActiveTasks() int
+
 	ResourceTypes() []atc.WorkerResourceType
 	Platform() string
 	Tags() []string
@@ -45,15 +55,74 @@ type Worker interface {
 	TeamName() string
 	StartTime() int64
 	ExpiresAt() time.Time
+	Ephemeral() bool
+
+	// Expired is whether the worker's TTL had lapsed without a heartbeat
+	// when it was loaded.
+	Expired() bool
+
+	// MemoryPressure and DiskPressure are whether the worker reported running
+	// low on memory or disk with its last heartbeat.
+	MemoryPressure() bool
+	DiskPressure() bool
+
+	// DiskUsage is the percentage of the worker's volume disk in use, as
+	// reported with its last heartbeat.
+	DiskUsage() int
+
+	// InMaintenance is whether one of the worker's maintenance windows was in
+	// progress when it was loaded.
+	InMaintenance() bool
 
 	Reload() (bool, error)
 
+	// Containers returns the containers on the worker along with who they
+	// belong to and the volumes mounted in them.
+	Containers() ([]WorkerContainer, error)
+
+	// Volumes returns the volumes on the worker which are not mounted in any
+	// container, e.g. caches, along with who they belong to and their sizes.
+	Volumes() ([]WorkerVolume, error)
+
+	// SaveContainerUsages records the resource usages reported for the
+	// worker's build containers with their builds.
+	SaveContainerUsages([]atc.ContainerUsage) error
+
+	// SaveVolumeUsages records the sizes reported for the worker's volumes.
+	SaveVolumeUsages([]atc.VolumeUsage) error
+
+	// MaintenanceWindows returns the worker's maintenance windows which have
+	// not yet ended, in the order they start.
+	MaintenanceWindows() ([]atc.WorkerMaintenanceWindow, error)
+
+	// ScheduleMaintenance adds a window during which the worker is drained
+	// of new containers. Windows which have already ended are discarded.
+	ScheduleMaintenance(startsAt time.Time, endsAt time.Time) (atc.WorkerMaintenanceWindow, error)
+
+	// CancelMaintenance removes one of the worker's maintenance windows,
+	// returning false if it has no window with the id.
+	CancelMaintenance(id int) (bool, error)
+
+	// ReserveTaskSlot reserves one of the worker's task slots for the task
+	// owning the container, returning false if max of them are already held.
+	// A task which already holds one of the worker's slots keeps it.
+	ReserveTaskSlot(owner ContainerOwner, max int) (bool, error)
+
+	// ReleaseTaskSlot frees the slot held by the task owning the container
+	// once its process has exited.
+	ReleaseTaskSlot(owner ContainerOwner) error
+
 	Land() error
 	Retire() error
 	Prune() error
 	Delete() error
 }
 
+// ContainerUsageSamplesPerContainer is the number of resource usage samples
+// kept for each of a build's containers. Older samples are discarded as new
+// ones are saved.
+const ContainerUsageSamplesPerContainer = 120
+
 type worker struct {
 	conn Conn
 
@@ -66,6 +135,8 @@ type worker struct {
 	httpsProxyURL    string
 	noProxy          string
 	activeContainers int
+	buildContainers  int
+	activeTasks      int
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             []string
@@ -74,6 +145,12 @@ type worker struct {
 	startTime        int64
 	expiresAt        time.Time
 	certsPath        *string
+	ephemeral        bool
+	expired          bool
+	memoryPressure   bool
+	diskPressure     bool
+	diskUsage        int
+	inMaintenance    bool
 }
 
 func (worker *worker) Name() string                            { return worker.name }
@@ -86,6 +163,8 @@ func (worker *worker) HTTPProxyURL() string                    { return worker.h
 func (worker *worker) HTTPSProxyURL() string                   { return worker.httpsProxyURL }
 func (worker *worker) NoProxy() string                         { return worker.noProxy }
 func (worker *worker) ActiveContainers() int                   { return worker.activeContainers }
+func (worker *worker) BuildContainers() int                    { return worker.buildContainers }
+func (worker *worker) ActiveTasks() int                        { return worker.activeTasks }
 func (worker *worker) ResourceTypes() []atc.WorkerResourceType { return worker.resourceTypes }
 func (worker *worker) Platform() string                        { return worker.platform }
 func (worker *worker) Tags() []string                          { return worker.tags }
@@ -95,6 +174,12 @@ func (worker *worker) TeamName() string                        { return worker.t
 // TODO: normalize time values
 func (worker *worker) StartTime() int64     { return worker.startTime }
 func (worker *worker) ExpiresAt() time.Time { return worker.expiresAt }
+func (worker *worker) Ephemeral() bool      { return worker.ephemeral }
+func (worker *worker) Expired() bool        { return worker.expired }
+func (worker *worker) MemoryPressure() bool { return worker.memoryPressure }
+func (worker *worker) DiskPressure() bool   { return worker.diskPressure }
+func (worker *worker) DiskUsage() int       { return worker.diskUsage }
+func (worker *worker) InMaintenance() bool  { return worker.inMaintenance }
 
 func (worker *worker) Reload() (bool, error) {
 	row := workersQuery.Where(sq.Eq{"w.name": worker.name}).
@@ -232,3 +317,484 @@ func (worker *worker) ResourceCerts() (*UsedWorkerResourceCerts, bool, error) {
 
 	return nil, false, nil
 }
+
+// WorkerContainer is a container on a worker along with the team it belongs
+// to and the volumes mounted in it.
+type WorkerContainer struct {
+	Handle   string
+	State    string
+	TeamName string
+	Metadata ContainerMetadata
+	Volumes  []WorkerContainerVolume
+}
+
+// WorkerContainerVolume is a volume mounted in a container. The parent is the
+// cache or other volume it is a copy-on-write of, if any. The size is the
+// last one reported by the worker, or 0 if none has been.
+type WorkerContainerVolume struct {
+	Handle       string
+	Path         string
+	ParentHandle string
+	Size         int64
+}
+
+// WorkerVolume is a volume on a worker which is not mounted in any container,
+// along with the team it belongs to. Task caches also have the pipeline, job,
+// and step they are for, and the path they are mounted at. The size is the
+// last one reported by the worker, or 0 if none has been.
+type WorkerVolume struct {
+	Handle   string
+	State    string
+	Type     VolumeType
+	TeamName string
+	Size     int64
+
+	PipelineName string
+	JobName      string
+	StepName     string
+	Path         string
+}
+
+func (worker *worker) Containers() ([]WorkerContainer, error) {
+	columns := []string{"c.handle", "c.state", "t.name"}
+	for _, column := range containerMetadataColumns {
+		columns = append(columns, "c."+column)
+	}
+
+	rows, err := psql.Select(columns...).
+		From("containers c").
+		LeftJoin("teams t ON t.id = c.team_id").
+		Where(sq.Eq{"c.worker_name": worker.name}).
+		OrderBy("c.id").
+		RunWith(worker.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	containers := []WorkerContainer{}
+	indexes := map[string]int{}
+
+	for rows.Next() {
+		var (
+			container WorkerContainer
+			teamName  sql.NullString
+		)
+
+		targets := []interface{}{&container.Handle, &container.State, &teamName}
+		targets = append(targets, container.Metadata.ScanTargets()...)
+
+		err = rows.Scan(targets...)
+		if err != nil {
+			return nil, err
+		}
+
+		container.TeamName = teamName.String
+		container.Volumes = []WorkerContainerVolume{}
+
+		indexes[container.Handle] = len(containers)
+		containers = append(containers, container)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	volumeRows, err := psql.Select("c.handle", "v.handle", "v.path", "pv.handle", "COALESCE(v.size, 0)").
+		From("volumes v").
+		Join("containers c ON c.id = v.container_id").
+		LeftJoin("volumes pv ON pv.id = v.parent_id").
+		Where(sq.Eq{"v.worker_name": worker.name}).
+		OrderBy("v.id").
+		RunWith(worker.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(volumeRows)
+
+	for volumeRows.Next() {
+		var (
+			containerHandle string
+			volume          WorkerContainerVolume
+			path            sql.NullString
+			parentHandle    sql.NullString
+		)
+
+		err = volumeRows.Scan(&containerHandle, &volume.Handle, &path, &parentHandle, &volume.Size)
+		if err != nil {
+			return nil, err
+		}
+
+		volume.Path = path.String
+		volume.ParentHandle = parentHandle.String
+
+		i, found := indexes[containerHandle]
+		if !found {
+			// the container was created after the containers were listed
+			continue
+		}
+
+		containers[i].Volumes = append(containers[i].Volumes, volume)
+	}
+
+	err = volumeRows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return containers, nil
+}
+
+func (worker *worker) Volumes() ([]WorkerVolume, error) {
+	rows, err := psql.Select(
+		"v.handle",
+		"v.state",
+		volumeTypeColumn,
+		"t.name",
+		"COALESCE(v.size, 0)",
+		"p.name",
+		"j.name",
+		"wtc.step_name",
+		"wtc.path",
+	).
+		From("volumes v").
+		LeftJoin("teams t ON t.id = v.team_id").
+		LeftJoin("worker_task_caches wtc ON wtc.id = v.worker_task_cache_id").
+		LeftJoin("jobs j ON j.id = wtc.job_id").
+		LeftJoin("pipelines p ON p.id = j.pipeline_id").
+		Where(sq.Eq{
+			"v.worker_name":  worker.name,
+			"v.container_id": nil,
+		}).
+		OrderBy("v.id").
+		RunWith(worker.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	volumes := []WorkerVolume{}
+	for rows.Next() {
+		var (
+			volume                                          WorkerVolume
+			volumeType                                      string
+			teamName, pipelineName, jobName, stepName, path sql.NullString
+		)
+
+		err = rows.Scan(&volume.Handle, &volume.State, &volumeType, &teamName, &volume.Size, &pipelineName, &jobName, &stepName, &path)
+		if err != nil {
+			return nil, err
+		}
+
+		volume.Type = VolumeType(volumeType)
+		volume.TeamName = teamName.String
+		volume.PipelineName = pipelineName.String
+		volume.JobName = jobName.String
+		volume.StepName = stepName.String
+		volume.Path = path.String
+
+		volumes = append(volumes, volume)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return volumes, nil
+}
+
+func (worker *worker) SaveContainerUsages(usages []atc.ContainerUsage) error {
+	tx, err := worker.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	for _, usage := range usages {
+		var buildID int
+		err = tx.QueryRow(`
+			INSERT INTO container_usage_samples (build_id, container_handle, plan_id, step_name, type, cpu_usage, memory_usage, disk_usage)
+			SELECT c.build_id, c.handle, COALESCE(c.plan_id, ''), c.meta_step_name, c.meta_type, $3, $4, $5
+			FROM containers c
+			WHERE c.handle = $1
+			AND c.worker_name = $2
+			AND c.build_id IS NOT NULL
+			RETURNING build_id
+		`, usage.Handle, worker.name, usage.CPUUsage, usage.MemoryUsage, usage.DiskUsage).Scan(&buildID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				// not a build container
+				continue
+			}
+
+			return err
+		}
+
+		_, err = tx.Exec(`
+			DELETE FROM container_usage_samples
+			WHERE build_id = $1
+			AND container_handle = $2
+			AND id <= (
+				SELECT id FROM container_usage_samples
+				WHERE build_id = $1
+				AND container_handle = $2
+				ORDER BY id DESC
+				OFFSET $3 LIMIT 1
+			)
+		`, buildID, usage.Handle, ContainerUsageSamplesPerContainer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (worker *worker) SaveVolumeUsages(usages []atc.VolumeUsage) error {
+	handles := make([]string, len(usages))
+	sizes := make([]int64, len(usages))
+	for i, usage := range usages {
+		handles[i] = usage.Handle
+		sizes[i] = int64(usage.Size)
+	}
+
+	// a worker may report thousands of volumes with every heartbeat, so they
+	// are all updated by a single statement
+	_, err := worker.conn.Exec(`
+		UPDATE volumes v
+		SET size = u.size
+		FROM unnest($1::text[], $2::bigint[]) AS u (handle, size)
+		WHERE v.handle = u.handle
+		AND v.worker_name = $3
+	`, pq.Array(handles), pq.Array(sizes), worker.name)
+	return err
+}
+
+func (worker *worker) MaintenanceWindows() ([]atc.WorkerMaintenanceWindow, error) {
+	rows, err := psql.Select("id", "starts_at", "ends_at").
+		From("worker_maintenance_windows").
+		Where(sq.Eq{"worker_name": worker.name}).
+		Where(sq.Expr("ends_at > NOW()")).
+		OrderBy("starts_at", "id").
+		RunWith(worker.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	windows := []atc.WorkerMaintenanceWindow{}
+	for rows.Next() {
+		var (
+			id       int
+			startsAt time.Time
+			endsAt   time.Time
+		)
+
+		err = rows.Scan(&id, &startsAt, &endsAt)
+		if err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, atc.WorkerMaintenanceWindow{
+			ID:       id,
+			StartsAt: startsAt.Unix(),
+			EndsAt:   endsAt.Unix(),
+		})
+	}
+
+	return windows, nil
+}
+
+func (worker *worker) ScheduleMaintenance(startsAt time.Time, endsAt time.Time) (atc.WorkerMaintenanceWindow, error) {
+	window := atc.WorkerMaintenanceWindow{
+		StartsAt: startsAt.Unix(),
+		EndsAt:   endsAt.Unix(),
+	}
+
+	err := window.Validate()
+	if err != nil {
+		return atc.WorkerMaintenanceWindow{}, err
+	}
+
+	tx, err := worker.conn.Begin()
+	if err != nil {
+		return atc.WorkerMaintenanceWindow{}, err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("worker_maintenance_windows").
+		Where(sq.Eq{"worker_name": worker.name}).
+		Where(sq.Expr("ends_at <= NOW()")).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return atc.WorkerMaintenanceWindow{}, err
+	}
+
+	err = psql.Insert("worker_maintenance_windows").
+		Columns("worker_name", "starts_at", "ends_at").
+		Values(worker.name, startsAt, endsAt).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&window.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqFKeyViolationErrCode {
+			return atc.WorkerMaintenanceWindow{}, ErrWorkerNotPresent
+		}
+
+		return atc.WorkerMaintenanceWindow{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return atc.WorkerMaintenanceWindow{}, err
+	}
+
+	return window, nil
+}
+
+func (worker *worker) CancelMaintenance(id int) (bool, error) {
+	result, err := psql.Delete("worker_maintenance_windows").
+		Where(sq.Eq{
+			"id":          id,
+			"worker_name": worker.name,
+		}).
+		RunWith(worker.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected != 0, nil
+}
+
+func (worker *worker) ReserveTaskSlot(owner ContainerOwner, max int) (bool, error) {
+	ownerCols, found, err := owner.Find(worker.conn)
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	tx, err := worker.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	// slots are reserved one at a time on each worker, so that two tasks
+	// cannot both take its last one
+	var name string
+	err = psql.Select("name").
+		From("workers").
+		Where(sq.Eq{"name": worker.name}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	var held bool
+	err = psql.Select("COUNT(*) > 0").
+		From("worker_task_slots").
+		Where(ownerCols).
+		Where(sq.Eq{"worker_name": worker.name}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&held)
+	if err != nil {
+		return false, err
+	}
+
+	if held {
+		return true, nil
+	}
+
+	// slots held by tasks of builds which have since finished, e.g. as the
+	// build was aborted before its task could release its slot, are free
+	var taken int
+	err = psql.Select("COUNT(*)").
+		From("worker_task_slots s").
+		Join("builds b ON b.id = s.build_id").
+		Where(sq.Eq{
+			"s.worker_name": worker.name,
+			"b.status":      BuildStatusStarted,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&taken)
+	if err != nil {
+		return false, err
+	}
+
+	if taken >= max {
+		return false, nil
+	}
+
+	slot := map[string]interface{}{"worker_name": worker.name}
+	for col, val := range ownerCols {
+		slot[col] = val
+	}
+
+	// the task may have held a slot on another worker, e.g. if its container
+	// was lost along with the worker
+	_, err = psql.Insert("worker_task_slots").
+		SetMap(slot).
+		Suffix("ON CONFLICT (build_id, plan_id) DO UPDATE SET worker_name = EXCLUDED.worker_name").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (worker *worker) ReleaseTaskSlot(owner ContainerOwner) error {
+	ownerCols, found, err := owner.Find(worker.conn)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return nil
+	}
+
+	_, err = psql.Delete("worker_task_slots").
+		Where(ownerCols).
+		RunWith(worker.conn).
+		Exec()
+	return err
+}
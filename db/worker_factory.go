@@ -48,7 +48,31 @@ var workersQuery = psql.Select(`
 		t.name,
 		w.team_id,
 		w.start_time,
-		w.expires
+		w.expires,
+		w.ephemeral,
+		COALESCE(w.expires < NOW(), false),
+		w.memory_pressure,
+		w.disk_pressure,
+		w.disk_usage,
+		EXISTS (
+			SELECT 1
+			FROM worker_maintenance_windows mw
+			WHERE mw.worker_name = w.name
+			AND NOW() BETWEEN mw.starts_at AND mw.ends_at
+		),
+		(
+			SELECT COUNT(*)
+			FROM containers bc
+			WHERE bc.worker_name = w.name
+			AND bc.build_id IS NOT NULL
+		),
+		(
+			SELECT COUNT(*)
+			FROM worker_task_slots ts
+			JOIN builds tb ON tb.id = ts.build_id
+			WHERE ts.worker_name = w.name
+			AND tb.status = 'started'
+		)
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -152,6 +176,14 @@ func scanWorker(worker *worker, row scannable) error {
 		&teamID,
 		&startTime,
 		&expiresAt,
+		&worker.ephemeral,
+		&worker.expired,
+		&worker.memoryPressure,
+		&worker.diskPressure,
+		&worker.diskUsage,
+		&worker.inMaintenance,
+		&worker.buildContainers,
+		&worker.activeTasks,
 	)
 	if err != nil {
 		return err
@@ -265,6 +297,9 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		Set("addr", sq.Expr("("+addrSQL+")")).
 		Set("baggageclaim_url", sq.Expr("("+bcSQL+")")).
 		Set("active_containers", atcWorker.ActiveContainers).
+		Set("memory_pressure", atcWorker.MemoryPressure).
+		Set("disk_pressure", atcWorker.DiskPressure).
+		Set("disk_usage", atcWorker.DiskUsage).
 		Set("state", sq.Expr("("+cSQL+")")).
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
@@ -372,6 +407,10 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 					"start_time",
 					"team_id",
 					"state",
+					"ephemeral",
+					"memory_pressure",
+					"disk_pressure",
+					"disk_usage",
 				).
 				Values(
 					atcWorker.GardenAddr,
@@ -390,6 +429,10 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 					atcWorker.StartTime,
 					teamID,
 					string(workerState),
+					atcWorker.Ephemeral,
+					atcWorker.MemoryPressure,
+					atcWorker.DiskPressure,
+					atcWorker.DiskUsage,
 				).
 				RunWith(tx).
 				Exec()
@@ -421,6 +464,10 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			Set("version", workerVersion).
 			Set("start_time", atcWorker.StartTime).
 			Set("state", string(workerState)).
+			Set("ephemeral", atcWorker.Ephemeral).
+			Set("memory_pressure", atcWorker.MemoryPressure).
+			Set("disk_pressure", atcWorker.DiskPressure).
+			Set("disk_usage", atcWorker.DiskUsage).
 			Where(sq.Eq{
 				"name": atcWorker.Name,
 			}).
@@ -453,6 +500,10 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		teamName:         atcWorker.Team,
 		teamID:           workerTeamID,
 		startTime:        atcWorker.StartTime,
+		ephemeral:        atcWorker.Ephemeral,
+		memoryPressure:   atcWorker.MemoryPressure,
+		diskPressure:     atcWorker.DiskPressure,
+		diskUsage:        atcWorker.DiskUsage,
 		conn:             conn,
 	}
 
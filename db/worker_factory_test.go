@@ -81,6 +81,19 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(worker.ResourceTypes()).To(Equal(atcWorker.ResourceTypes))
 			})
 
+			It("saves whether the worker is ephemeral", func() {
+				atcWorker.Ephemeral = true
+
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+
+				worker, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(worker.Ephemeral()).To(BeTrue())
+				Expect(worker.Expired()).To(BeFalse())
+			})
+
 			It("removes old worker resource type", func() {
 				atcWorker.ResourceTypes = []atc.WorkerResourceType{
 					{
@@ -371,6 +384,59 @@ var _ = Describe("WorkerFactory", func() {
 		})
 	})
 
+	Describe("counting the containers of builds", func() {
+		BeforeEach(func() {
+			startedBuild, err := defaultJob.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := startedBuild.Start("exec.v2", "{}", atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			_, err = defaultTeam.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(startedBuild.ID(), "some-task-plan"), db.ContainerMetadata{Type: db.ContainerTypeTask})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = defaultTeam.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(startedBuild.ID(), "some-get-plan"), db.ContainerMetadata{Type: db.ContainerTypeGet})
+			Expect(err).NotTo(HaveOccurred())
+
+			reserved, err := defaultWorker.ReserveTaskSlot(db.NewBuildStepContainerOwner(startedBuild.ID(), "some-task-plan"), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+
+			_, err = defaultTeam.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(startedBuild.ID(), "some-finished-task-plan"), db.ContainerMetadata{Type: db.ContainerTypeTask})
+			Expect(err).NotTo(HaveOccurred())
+
+			reserved, err = defaultWorker.ReserveTaskSlot(db.NewBuildStepContainerOwner(startedBuild.ID(), "some-finished-task-plan"), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+
+			err = defaultWorker.ReleaseTaskSlot(db.NewBuildStepContainerOwner(startedBuild.ID(), "some-finished-task-plan"))
+			Expect(err).NotTo(HaveOccurred())
+
+			finishedBuild, err := defaultJob.CreateBuild(db.BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = defaultTeam.CreateContainer(defaultWorker.Name(), db.NewBuildStepContainerOwner(finishedBuild.ID(), "some-task-plan"), db.ContainerMetadata{Type: db.ContainerTypeTask})
+			Expect(err).NotTo(HaveOccurred())
+
+			reserved, err = defaultWorker.ReserveTaskSlot(db.NewBuildStepContainerOwner(finishedBuild.ID(), "some-task-plan"), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+
+			err = finishedBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts the worker's build containers and the running tasks of running builds", func() {
+			foundWorker, found, err := workerFactory.GetWorker(defaultWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(foundWorker.BuildContainers()).To(Equal(4))
+			Expect(foundWorker.ActiveTasks()).To(Equal(1))
+		})
+	})
+
 	Describe("HeartbeatWorker", func() {
 		var (
 			ttl              time.Duration
@@ -413,6 +479,23 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(*foundWorker.BaggageclaimURL()).To(Equal("some-bc-url"))
 			})
 
+			It("updates whether the worker is under pressure", func() {
+				atcWorker.MemoryPressure = true
+
+				foundWorker, err := workerFactory.HeartbeatWorker(atcWorker, ttl)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(foundWorker.MemoryPressure()).To(BeTrue())
+				Expect(foundWorker.DiskPressure()).To(BeFalse())
+
+				atcWorker.MemoryPressure = false
+				atcWorker.DiskPressure = true
+
+				foundWorker, err = workerFactory.HeartbeatWorker(atcWorker, ttl)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(foundWorker.MemoryPressure()).To(BeFalse())
+				Expect(foundWorker.DiskPressure()).To(BeTrue())
+			})
+
 			Context("when the current state is landing", func() {
 				BeforeEach(func() {
 					atcWorker.State = string(db.WorkerStateLanding)
@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 )
@@ -9,9 +10,12 @@ import (
 //go:generate counterfeiter . WorkerLifecycle
 
 type WorkerLifecycle interface {
+	DeleteUnresponsiveEphemeralWorkers(gracePeriod time.Duration) ([]string, error)
 	StallUnresponsiveWorkers() ([]string, error)
 	LandFinishedLandingWorkers() ([]string, error)
 	DeleteFinishedRetiringWorkers() ([]string, error)
+
+	FindFinishedRetiringWorkers() ([]string, error)
 }
 
 type workerLifecycle struct {
@@ -24,6 +28,32 @@ func NewWorkerLifecycle(conn Conn) WorkerLifecycle {
 	}
 }
 
+// DeleteUnresponsiveEphemeralWorkers deletes the ephemeral workers which have
+// been stalled for longer than the grace period, along with their containers
+// and volumes, so that their steps are placed on other workers rather than
+// waiting for them to come back. Workers only stall once their TTL lapses
+// without a heartbeat, so one late heartbeat does not lose a worker.
+func (lifecycle *workerLifecycle) DeleteUnresponsiveEphemeralWorkers(gracePeriod time.Duration) ([]string, error) {
+	query, args, err := psql.Delete("workers").
+		Where(sq.Eq{
+			"ephemeral": true,
+			"state":     string(WorkerStateStalled),
+		}).
+		Where(sq.Expr("stalled_at < NOW() - (? || ' SECONDS')::INTERVAL", int(gracePeriod.Seconds()))).
+		Suffix("RETURNING name").
+		ToSql()
+	if err != nil {
+		return []string{}, err
+	}
+
+	rows, err := lifecycle.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return workersAffected(rows)
+}
+
 func (lifecycle *workerLifecycle) StallUnresponsiveWorkers() ([]string, error) {
 	query, args, err := psql.Update("workers").
 		SetMap(map[string]interface{}{
@@ -31,6 +61,7 @@ func (lifecycle *workerLifecycle) StallUnresponsiveWorkers() ([]string, error) {
 			"addr":             nil,
 			"baggageclaim_url": nil,
 			"expires":          nil,
+			"stalled_at":       sq.Expr("NOW()"),
 		}).
 		Where(sq.Eq{"state": string(WorkerStateRunning)}).
 		Where(sq.Expr("expires < NOW()")).
@@ -55,28 +86,7 @@ func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkers() ([]string, err
 	// First we generate the subquery's SQL and args using
 	// sq.Select instead of psql.Select so that we get
 	// unordered placeholders instead of psql's ordered placeholders
-	subQ, subQArgs, err := sq.Select("w.name").
-		Distinct().
-		From("builds b").
-		Join("containers c ON b.id = c.build_id").
-		Join("workers w ON w.name = c.worker_name").
-		LeftJoin("jobs j ON j.id = b.job_id").
-		Where(sq.Or{
-			sq.Eq{
-				"b.status": string(BuildStatusStarted),
-			},
-			sq.Eq{
-				"b.status": string(BuildStatusPending),
-			},
-		}).
-		Where(sq.Or{
-			sq.Eq{
-				"j.interruptible": false,
-			},
-			sq.Eq{
-				"b.job_id": nil,
-			},
-		}).ToSql()
+	subQ, subQArgs, err := workersWithUninterruptibleBuilds()
 
 	if err != nil {
 		return []string{}, err
@@ -110,29 +120,37 @@ func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkers() ([]string, err
 	return workersAffected(rows)
 }
 
-func (lifecycle *workerLifecycle) LandFinishedLandingWorkers() ([]string, error) {
-	subQ, subQArgs, err := sq.Select("w.name").
-		Distinct().
-		From("builds b").
-		Join("containers c ON b.id = c.build_id").
-		Join("workers w ON w.name = c.worker_name").
-		LeftJoin("jobs j ON j.id = b.job_id").
-		Where(sq.Or{
-			sq.Eq{
-				"b.status": string(BuildStatusStarted),
-			},
-			sq.Eq{
-				"b.status": string(BuildStatusPending),
-			},
+// FindFinishedRetiringWorkers returns the retiring workers which
+// DeleteFinishedRetiringWorkers would delete, so that anything worth keeping
+// can be moved off of them first.
+func (lifecycle *workerLifecycle) FindFinishedRetiringWorkers() ([]string, error) {
+	subQ, subQArgs, err := workersWithUninterruptibleBuilds()
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := sq.Select("name").
+		From("workers").
+		Where(sq.Eq{
+			"state": string(WorkerStateRetiring),
 		}).
-		Where(sq.Or{
-			sq.Eq{
-				"j.interruptible": false,
-			},
-			sq.Eq{
-				"b.job_id": nil,
-			},
-		}).ToSql()
+		Where("name NOT IN ("+subQ+")", subQArgs...).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := lifecycle.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return workersAffected(rows)
+}
+
+func (lifecycle *workerLifecycle) LandFinishedLandingWorkers() ([]string, error) {
+	subQ, subQArgs, err := workersWithUninterruptibleBuilds()
 
 	if err != nil {
 		return nil, err
@@ -162,6 +180,34 @@ func (lifecycle *workerLifecycle) LandFinishedLandingWorkers() ([]string, error)
 	return workersAffected(rows)
 }
 
+// workersWithUninterruptibleBuilds selects the names of the workers which
+// have containers for pending or started builds that must not be interrupted,
+// with unordered placeholders so that it can be nested in another query.
+func workersWithUninterruptibleBuilds() (string, []interface{}, error) {
+	return sq.Select("w.name").
+		Distinct().
+		From("builds b").
+		Join("containers c ON b.id = c.build_id").
+		Join("workers w ON w.name = c.worker_name").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		Where(sq.Or{
+			sq.Eq{
+				"b.status": string(BuildStatusStarted),
+			},
+			sq.Eq{
+				"b.status": string(BuildStatusPending),
+			},
+		}).
+		Where(sq.Or{
+			sq.Eq{
+				"j.interruptible": false,
+			},
+			sq.Eq{
+				"b.job_id": nil,
+			},
+		}).ToSql()
+}
+
 func workersAffected(rows *sql.Rows) ([]string, error) {
 	var (
 		err         error
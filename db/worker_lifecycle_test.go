@@ -73,6 +73,86 @@ var _ = Describe("Worker Lifecycle", func() {
 		})
 	})
 
+	Describe("DeleteUnresponsiveEphemeralWorkers", func() {
+		Context("when the worker is ephemeral", func() {
+			BeforeEach(func() {
+				atcWorker.Ephemeral = true
+			})
+
+			Context("when the worker has heartbeated recently", func() {
+				BeforeEach(func() {
+					_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("leaves the worker alone", func() {
+					deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkers(0)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(deletedWorkers).To(BeEmpty())
+				})
+			})
+
+			Context("when the worker has not heartbeated recently", func() {
+				BeforeEach(func() {
+					_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("leaves the worker to be stalled first", func() {
+					deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkers(0)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(deletedWorkers).To(BeEmpty())
+				})
+			})
+
+			Context("when the worker has stalled", func() {
+				BeforeEach(func() {
+					_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = workerLifecycle.StallUnresponsiveWorkers()
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("leaves the worker alone within the grace period", func() {
+					deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkers(time.Minute)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(deletedWorkers).To(BeEmpty())
+				})
+
+				Context("for longer than the grace period", func() {
+					BeforeEach(func() {
+						_, err := dbConn.Exec(`UPDATE workers SET stalled_at = NOW() - '2 minutes'::interval WHERE name = 'some-name'`)
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("deletes the worker", func() {
+						deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkers(time.Minute)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(deletedWorkers).To(Equal([]string{"some-name"}))
+
+						_, found, err := workerFactory.GetWorker("some-name")
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeFalse())
+					})
+				})
+			})
+		})
+
+		Context("when the worker is not ephemeral and has not heartbeated recently", func() {
+			BeforeEach(func() {
+				_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("leaves the worker to be stalled", func() {
+				deletedWorkers, err := workerLifecycle.DeleteUnresponsiveEphemeralWorkers(0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("DeleteFinishedRetiringWorkers", func() {
 		var (
 			dbWorker db.Worker
@@ -132,7 +212,7 @@ var _ = Describe("Worker Lifecycle", func() {
 
 			DescribeTable("deleting workers with builds that are",
 				func(s db.BuildStatus, expectedExistence bool) {
-					dbBuild, err := defaultTeam.CreateOneOffBuild()
+					dbBuild, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 
 					switch s {
@@ -209,7 +289,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					dbBuild, err = job.CreateBuild()
+					dbBuild, err = job.CreateBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -241,7 +321,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					dbBuild, err = job.CreateBuild()
+					dbBuild, err = job.CreateBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -259,7 +339,7 @@ var _ = Describe("Worker Lifecycle", func() {
 			Context("when worker has one-off build", func() {
 				BeforeEach(func() {
 					var err error
-					dbBuild, err = defaultTeam.CreateOneOffBuild()
+					dbBuild, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -276,6 +356,60 @@ var _ = Describe("Worker Lifecycle", func() {
 		})
 	})
 
+	Describe("FindFinishedRetiringWorkers", func() {
+		JustBeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when worker is not retiring", func() {
+			BeforeEach(func() {
+				atcWorker.State = string(db.WorkerStateRunning)
+			})
+
+			It("does not find the worker", func() {
+				workers, err := workerLifecycle.FindFinishedRetiringWorkers()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workers).To(BeEmpty())
+			})
+		})
+
+		Context("when worker is retiring", func() {
+			BeforeEach(func() {
+				atcWorker.State = string(db.WorkerStateRetiring)
+			})
+
+			It("finds the worker without deleting it", func() {
+				workers, err := workerLifecycle.FindFinishedRetiringWorkers()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workers).To(Equal([]string{atcWorker.Name}))
+
+				_, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			Context("when the worker has a started one-off build", func() {
+				JustBeforeEach(func() {
+					dbBuild, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = dbBuild.Start("exec.v2", "{}", atc.Plan{})
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = defaultTeam.CreateContainer(atcWorker.Name, db.NewBuildStepContainerOwner(dbBuild.ID(), atc.PlanID(4)), db.ContainerMetadata{})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("does not find the worker", func() {
+					workers, err := workerLifecycle.FindFinishedRetiringWorkers()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(workers).To(BeEmpty())
+				})
+			})
+		})
+	})
+
 	Describe("LandFinishedLandingWorkers", func() {
 		var (
 			dbWorker db.Worker
@@ -371,7 +505,7 @@ var _ = Describe("Worker Lifecycle", func() {
 
 			DescribeTable("land workers with builds that are",
 				func(s db.BuildStatus, expectedState db.WorkerState) {
-					dbBuild, err := defaultTeam.CreateOneOffBuild()
+					dbBuild, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 
 					switch s {
@@ -451,7 +585,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					dbBuild, err = job.CreateBuild()
+					dbBuild, err = job.CreateBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -483,7 +617,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					dbBuild, err = job.CreateBuild()
+					dbBuild, err = job.CreateBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -501,7 +635,7 @@ var _ = Describe("Worker Lifecycle", func() {
 			Context("when worker has one-off build", func() {
 				BeforeEach(func() {
 					var err error
-					dbBuild, err = defaultTeam.CreateOneOffBuild()
+					dbBuild, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -11,6 +11,10 @@ import (
 type WorkerResourceCache struct {
 	WorkerName    string
 	ResourceCache *UsedResourceCache
+
+	// TeamID scopes the cache to the team's builds. Caches with no team are
+	// shared by every team using the worker.
+	TeamID int
 }
 
 type UsedWorkerResourceCache struct {
@@ -48,10 +52,12 @@ func (workerResourceCache WorkerResourceCache) FindOrCreate(tx Tx) (*UsedWorkerR
 		Columns(
 			"resource_cache_id",
 			"worker_base_resource_type_id",
+			"team_id",
 		).
 		Values(
 			workerResourceCache.ResourceCache.ID,
 			usedWorkerBaseResourceType.ID,
+			workerResourceCache.teamID(),
 		).
 		Suffix("RETURNING id").
 		RunWith(tx).
@@ -106,6 +112,7 @@ func (workerResourceCache WorkerResourceCache) find(runner sq.Runner, usedWorker
 		Where(sq.Eq{
 			"resource_cache_id":            workerResourceCache.ResourceCache.ID,
 			"worker_base_resource_type_id": usedWorkerBaseResourceType.ID,
+			"team_id":                      workerResourceCache.teamID(),
 		}).
 		RunWith(runner).
 		QueryRow().
@@ -120,3 +127,11 @@ func (workerResourceCache WorkerResourceCache) find(runner sq.Runner, usedWorker
 
 	return id, true, nil
 }
+
+func (workerResourceCache WorkerResourceCache) teamID() interface{} {
+	if workerResourceCache.TeamID == 0 {
+		return nil
+	}
+
+	return workerResourceCache.TeamID
+}
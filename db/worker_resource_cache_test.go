@@ -14,7 +14,7 @@ var _ = Describe("WorkerResourceCache", func() {
 
 	Describe("FindOrCreate", func() {
 		BeforeEach(func() {
-			build, err := defaultTeam.CreateOneOffBuild()
+			build, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
@@ -78,7 +78,7 @@ var _ = Describe("WorkerResourceCache", func() {
 		var findErr error
 
 		BeforeEach(func() {
-			build, err := defaultTeam.CreateOneOffBuild()
+			build, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
@@ -129,7 +129,7 @@ var _ = Describe("WorkerResourceCache", func() {
 				err = tx.Commit()
 				Expect(err).ToNot(HaveOccurred())
 
-				build, err := defaultTeam.CreateOneOffBuild()
+				build, err := defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 
 				resourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
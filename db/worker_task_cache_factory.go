@@ -18,6 +18,17 @@ type UsedWorkerTaskCache struct {
 type WorkerTaskCacheFactory interface {
 	Find(jobID int, stepName string, path string, workerName string) (*UsedWorkerTaskCache, bool, error)
 	FindOrCreate(jobID int, stepName string, path string, workerName string) (*UsedWorkerTaskCache, error)
+
+	FindVolumesOnWorker(workerName string) ([]WorkerTaskCacheVolume, error)
+}
+
+// WorkerTaskCacheVolume is a task cache along with the created volume holding
+// it on the cache's worker.
+type WorkerTaskCacheVolume struct {
+	WorkerTaskCache
+
+	TeamID       int
+	VolumeHandle string
 }
 
 type workerTaskCacheFactory struct {
@@ -80,6 +91,47 @@ func (f *workerTaskCacheFactory) FindOrCreate(jobID int, stepName string, path s
 	return usedWorkerTaskCache, nil
 }
 
+func (f *workerTaskCacheFactory) FindVolumesOnWorker(workerName string) ([]WorkerTaskCacheVolume, error) {
+	rows, err := psql.Select("wtc.job_id, wtc.step_name, wtc.path, v.team_id, v.handle").
+		From("worker_task_caches wtc").
+		Join("volumes v ON v.worker_task_cache_id = wtc.id").
+		Where(sq.Eq{
+			"wtc.worker_name": workerName,
+			"v.state":         string(VolumeStateCreated),
+		}).
+		OrderBy("wtc.id").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var volumes []WorkerTaskCacheVolume
+	for rows.Next() {
+		volume := WorkerTaskCacheVolume{
+			WorkerTaskCache: WorkerTaskCache{
+				WorkerName: workerName,
+			},
+		}
+
+		var teamID sql.NullInt64
+		err = rows.Scan(&volume.JobID, &volume.StepName, &volume.Path, &teamID, &volume.VolumeHandle)
+		if err != nil {
+			return nil, err
+		}
+
+		if teamID.Valid {
+			volume.TeamID = int(teamID.Int64)
+		}
+
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, nil
+}
+
 type WorkerTaskCache struct {
 	JobID      int
 	StepName   string
@@ -147,6 +147,236 @@ var _ = Describe("Worker", func() {
 		})
 	})
 
+	Describe("SaveContainerUsages", func() {
+		var (
+			build     Build
+			container CreatingContainer
+		)
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultTeam.CreateOneOffBuild(BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			container, err = defaultTeam.CreateContainer(
+				defaultWorker.Name(),
+				NewBuildStepContainerOwner(build.ID(), "some-plan"),
+				ContainerMetadata{
+					Type:     ContainerTypeTask,
+					StepName: "some-step",
+				},
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("records the usages of build containers with their builds", func() {
+			err := defaultWorker.SaveContainerUsages([]atc.ContainerUsage{
+				{Handle: container.Handle(), CPUUsage: 1, MemoryUsage: 2, DiskUsage: 3},
+				{Handle: "some-other-handle", CPUUsage: 4, MemoryUsage: 5, DiskUsage: 6},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = defaultWorker.SaveContainerUsages([]atc.ContainerUsage{
+				{Handle: container.Handle(), CPUUsage: 7, MemoryUsage: 8, DiskUsage: 9},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			usages, err := build.ContainerUsages()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usages).To(HaveLen(1))
+			Expect(usages[0].Handle).To(Equal(container.Handle()))
+			Expect(usages[0].PlanID).To(Equal(atc.PlanID("some-plan")))
+			Expect(usages[0].StepName).To(Equal("some-step"))
+			Expect(usages[0].Type).To(Equal("task"))
+			Expect(usages[0].Samples).To(HaveLen(2))
+			Expect(usages[0].Samples[0].MemoryUsage).To(Equal(uint64(2)))
+			Expect(usages[0].Samples[1].MemoryUsage).To(Equal(uint64(8)))
+		})
+
+		It("keeps only the most recent samples of each container", func() {
+			for i := 0; i < ContainerUsageSamplesPerContainer+5; i++ {
+				err := defaultWorker.SaveContainerUsages([]atc.ContainerUsage{
+					{Handle: container.Handle(), MemoryUsage: uint64(i)},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			usages, err := build.ContainerUsages()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usages[0].Samples).To(HaveLen(ContainerUsageSamplesPerContainer))
+			Expect(usages[0].Samples[0].MemoryUsage).To(Equal(uint64(5)))
+		})
+	})
+
+	Describe("Volumes", func() {
+		var cacheVolume CreatedVolume
+
+		BeforeEach(func() {
+			taskCache, err := workerTaskCacheFactory.FindOrCreate(defaultJob.ID(), "some-step", "some-path", defaultWorker.Name())
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeFactory.CreateTaskCacheVolume(defaultTeam.ID(), taskCache)
+			Expect(err).ToNot(HaveOccurred())
+
+			cacheVolume, err = creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = defaultWorker.SaveVolumeUsages([]atc.VolumeUsage{
+				{Handle: cacheVolume.Handle(), Size: 4096},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err := defaultTeam.CreateOneOffBuild(BuildTriggerCause{})
+			Expect(err).ToNot(HaveOccurred())
+
+			container, err := defaultTeam.CreateContainer(
+				defaultWorker.Name(),
+				NewBuildStepContainerOwner(build.ID(), "some-plan"),
+				ContainerMetadata{Type: ContainerTypeTask},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = volumeFactory.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), container, "some-mount")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the volumes not mounted in any container, with their owners and sizes", func() {
+			volumes, err := defaultWorker.Volumes()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(volumes).To(ContainElement(WorkerVolume{
+				Handle:       cacheVolume.Handle(),
+				State:        string(VolumeStateCreated),
+				Type:         VolumeTypeTaskCache,
+				TeamName:     defaultTeam.Name(),
+				Size:         4096,
+				PipelineName: defaultPipeline.Name(),
+				JobName:      defaultJob.Name(),
+				StepName:     "some-step",
+				Path:         "some-path",
+			}))
+
+			for _, volume := range volumes {
+				Expect(volume.Type).ToNot(Equal(VolumeTypeContainer))
+			}
+		})
+	})
+
+	Describe("ReserveTaskSlot", func() {
+		var build Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild(BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := build.Start("exec.v2", "{}", atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		It("reserves slots until max of them are held", func() {
+			reserved, err := defaultWorker.ReserveTaskSlot(NewBuildStepContainerOwner(build.ID(), "some-task"), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+
+			reserved, err = defaultWorker.ReserveTaskSlot(NewBuildStepContainerOwner(build.ID(), "some-other-task"), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeFalse())
+		})
+
+		It("lets a task keep the slot it holds", func() {
+			reserved, err := defaultWorker.ReserveTaskSlot(NewBuildStepContainerOwner(build.ID(), "some-task"), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+
+			reserved, err = defaultWorker.ReserveTaskSlot(NewBuildStepContainerOwner(build.ID(), "some-task"), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+		})
+
+		It("frees a slot once released", func() {
+			reserved, err := defaultWorker.ReserveTaskSlot(NewBuildStepContainerOwner(build.ID(), "some-task"), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+
+			Expect(defaultWorker.ReleaseTaskSlot(NewBuildStepContainerOwner(build.ID(), "some-task"))).To(Succeed())
+
+			reserved, err = defaultWorker.ReserveTaskSlot(NewBuildStepContainerOwner(build.ID(), "some-other-task"), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+		})
+
+		It("frees the slots of builds which have finished", func() {
+			reserved, err := defaultWorker.ReserveTaskSlot(NewBuildStepContainerOwner(build.ID(), "some-task"), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+
+			Expect(build.Finish(BuildStatusAborted)).To(Succeed())
+
+			otherBuild, err := defaultJob.CreateBuild(BuildTriggerCause{})
+			Expect(err).NotTo(HaveOccurred())
+
+			reserved, err = defaultWorker.ReserveTaskSlot(NewBuildStepContainerOwner(otherBuild.ID(), "some-task"), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+		})
+	})
+
+	Describe("ScheduleMaintenance", func() {
+		It("drains the worker only while a window is in progress", func() {
+			_, err := defaultWorker.ScheduleMaintenance(time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = defaultWorker.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaultWorker.InMaintenance()).To(BeFalse())
+
+			_, err = defaultWorker.ScheduleMaintenance(time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = defaultWorker.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaultWorker.InMaintenance()).To(BeTrue())
+		})
+
+		It("lists the windows which have not ended in the order they start", func() {
+			later, err := defaultWorker.ScheduleMaintenance(time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			sooner, err := defaultWorker.ScheduleMaintenance(time.Now().Add(time.Minute), time.Now().Add(time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = defaultWorker.ScheduleMaintenance(time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			windows, err := defaultWorker.MaintenanceWindows()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(windows).To(Equal([]atc.WorkerMaintenanceWindow{sooner, later}))
+		})
+
+		It("rejects windows which do not end after they start", func() {
+			_, err := defaultWorker.ScheduleMaintenance(time.Now(), time.Now().Add(-time.Hour))
+			Expect(err).To(Equal(atc.ErrInvalidMaintenanceWindow))
+		})
+
+		It("can cancel a window", func() {
+			window, err := defaultWorker.ScheduleMaintenance(time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := defaultWorker.CancelMaintenance(window.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			found, err = defaultWorker.CancelMaintenance(window.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, err = defaultWorker.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaultWorker.InMaintenance()).To(BeFalse())
+		})
+	})
+
 	Describe("Prune", func() {
 		Context("when worker exists", func() {
 			DescribeTable("worker in state",
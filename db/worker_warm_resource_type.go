@@ -0,0 +1,66 @@
+package db
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . WorkerWarmResourceTypeFactory
+
+// WorkerWarmResourceTypeFactory records which image is kept warm on a worker
+// for each warm resource type, so that any team fetching the very same image
+// onto the worker, e.g. for a type declared in its pipeline, uses it instead.
+type WorkerWarmResourceTypeFactory interface {
+	// Pin keeps the resource cache holding the image from being garbage
+	// collected for as long as it is the worker's image for the type. Any
+	// previously pinned cache is released.
+	Pin(workerName string, name string, resourceCache *UsedResourceCache) error
+
+	// Prune stops keeping warm the images of every type not among the names,
+	// e.g. once they are no longer configured.
+	Prune(names []string) error
+}
+
+type workerWarmResourceTypeFactory struct {
+	conn Conn
+}
+
+func NewWorkerWarmResourceTypeFactory(conn Conn) WorkerWarmResourceTypeFactory {
+	return &workerWarmResourceTypeFactory{
+		conn: conn,
+	}
+}
+
+func (f *workerWarmResourceTypeFactory) Pin(workerName string, name string, resourceCache *UsedResourceCache) error {
+	_, err := psql.Insert("worker_warm_resource_types").
+		Columns("worker_name", "name", "resource_cache_id").
+		Values(workerName, name, resourceCache.ID).
+		Suffix("ON CONFLICT (worker_name, name) DO UPDATE SET resource_cache_id = EXCLUDED.resource_cache_id").
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func (f *workerWarmResourceTypeFactory) Prune(names []string) error {
+	_, err := psql.Delete("worker_warm_resource_types").
+		Where(sq.NotEq{"name": names}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func findOrCreateWorkerWarmResourceType(tx Tx, workerName string, name string) (int, error) {
+	// the no-op update returns the id of a row inserted concurrently
+	var id int
+	err := psql.Insert("worker_warm_resource_types").
+		Columns("worker_name", "name").
+		Values(workerName, name).
+		Suffix("ON CONFLICT (worker_name, name) DO UPDATE SET name = EXCLUDED.name RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
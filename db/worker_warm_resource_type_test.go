@@ -0,0 +1,76 @@
+package db_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerWarmResourceTypeFactory", func() {
+	var (
+		factory db.WorkerWarmResourceTypeFactory
+
+		build db.Build
+	)
+
+	BeforeEach(func() {
+		factory = db.NewWorkerWarmResourceTypeFactory(dbConn)
+
+		var err error
+		build, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	resourceCacheOfVersion := func(version string) *db.UsedResourceCache {
+		resourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
+			logger,
+			db.ForBuild(build.ID()),
+			"some-base-resource-type",
+			atc.Version{"some": version},
+			atc.Source{"some": "source"},
+			atc.Params{},
+			creds.VersionedResourceTypes{},
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		return resourceCache
+	}
+
+	pinnedCacheID := func() int {
+		var id int
+		err := dbConn.QueryRow(`
+			SELECT resource_cache_id FROM worker_warm_resource_types WHERE worker_name = $1 AND name = $2
+		`, defaultWorker.Name(), "some-warm-type").Scan(&id)
+		Expect(err).NotTo(HaveOccurred())
+
+		return id
+	}
+
+	Describe("Pin", func() {
+		It("pins the resource cache on the worker", func() {
+			resourceCache := resourceCacheOfVersion("v1")
+
+			err := factory.Pin(defaultWorker.Name(), "some-warm-type", resourceCache)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pinnedCacheID()).To(Equal(resourceCache.ID))
+		})
+
+		Context("when another version is already pinned", func() {
+			BeforeEach(func() {
+				err := factory.Pin(defaultWorker.Name(), "some-warm-type", resourceCacheOfVersion("v1"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("replaces it", func() {
+				resourceCache := resourceCacheOfVersion("v2")
+
+				err := factory.Pin(defaultWorker.Name(), "some-warm-type", resourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinnedCacheID()).To(Equal(resourceCache.ID))
+			})
+		})
+	})
+})
@@ -0,0 +1,35 @@
+package engine
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . AdmissionController
+
+// AdmissionController is consulted with the full plan of each build before it
+// is created, e.g. to enforce the policies of an external agent such as OPA.
+// It returns the plan to run, which may have been modified, or an error to
+// reject the build.
+type AdmissionController interface {
+	Admit(logger lager.Logger, build db.Build, plan atc.Plan) (atc.Plan, error)
+}
+
+// AdmissionRejectedError is returned by an AdmissionController which does not
+// allow a build to run.
+type AdmissionRejectedError struct {
+	Reason string
+}
+
+func (err AdmissionRejectedError) Error() string {
+	return fmt.Sprintf("build rejected by admission controller: %s", err.Reason)
+}
+
+// ErrorKind classifies the rejected plan as invalid, as it would be rejected
+// again.
+func (err AdmissionRejectedError) ErrorKind() atc.ErrorKind {
+	return atc.ErrorKindConfigInvalid
+}
@@ -0,0 +1,25 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+// buildEvents implements the event methods of Build. Events are saved to the
+// database as a build runs, so they are replayed from there regardless of
+// the engine that ran it.
+type buildEvents struct {
+	build db.Build
+}
+
+func (events buildEvents) ReplayEvents(logger lager.Logger, from uint) (db.EventSource, error) {
+	return events.build.Events(from)
+}
+
+func (events buildEvents) EventCheckpoint(logger lager.Logger, subscriber string) (uint, bool, error) {
+	return events.build.EventCheckpoint(subscriber)
+}
+
+func (events buildEvents) CheckpointEvent(logger lager.Logger, subscriber string, eventID uint) error {
+	return events.build.SaveEventCheckpoint(subscriber, eventID)
+}
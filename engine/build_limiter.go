@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// BuildLimiter caps the number of builds run at once by an ATC, so that
+// resuming every build after a restart does not overwhelm it or the database.
+// Builds beyond the limit wait for a running build to finish, in the order
+// they arrived.
+//
+// An ATC runs several engines, one for each of its components, which must all
+// share the same limiter for the limit to hold.
+type BuildLimiter struct {
+	slots chan struct{}
+
+	claimed  map[int]bool
+	claimedL sync.Mutex
+}
+
+// NewBuildLimiter returns a limiter allowing up to limit builds to run at
+// once. A limit of zero means no limit.
+func NewBuildLimiter(limit int) *BuildLimiter {
+	if limit <= 0 {
+		return &BuildLimiter{}
+	}
+
+	return &BuildLimiter{
+		slots:   make(chan struct{}, limit),
+		claimed: map[int]bool{},
+	}
+}
+
+// claim returns false if the build is already queued or running, so that it
+// is not queued twice. Each successful claim must be followed by an unclaim.
+func (limiter *BuildLimiter) claim(buildID int) bool {
+	if limiter == nil || limiter.slots == nil {
+		return true
+	}
+
+	limiter.claimedL.Lock()
+	defer limiter.claimedL.Unlock()
+
+	if limiter.claimed[buildID] {
+		return false
+	}
+
+	limiter.claimed[buildID] = true
+
+	return true
+}
+
+func (limiter *BuildLimiter) unclaim(buildID int) {
+	if limiter == nil || limiter.slots == nil {
+		return
+	}
+
+	limiter.claimedL.Lock()
+	delete(limiter.claimed, buildID)
+	limiter.claimedL.Unlock()
+}
+
+// acquire waits until the build may run, returning false if released or
+// aborted is closed first. Each successful acquire must be followed by a
+// release.
+func (limiter *BuildLimiter) acquire(logger lager.Logger, released <-chan struct{}, aborted <-chan struct{}) bool {
+	if limiter == nil || limiter.slots == nil {
+		return true
+	}
+
+	select {
+	case limiter.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	logger.Info("queued", lager.Data{"limit": cap(limiter.slots)})
+
+	select {
+	case limiter.slots <- struct{}{}:
+		logger.Info("dequeued")
+		return true
+	case <-released:
+		return false
+	case <-aborted:
+		return false
+	}
+}
+
+func (limiter *BuildLimiter) release() {
+	if limiter == nil || limiter.slots == nil {
+		return
+	}
+
+	<-limiter.slots
+}
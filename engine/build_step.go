@@ -25,18 +25,15 @@ func (build *execBuild) buildAggregateStep(logger lager.Logger, plan atc.Plan) e
 func (build *execBuild) buildDoStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	logger = logger.Session("do")
 
-	var step exec.Step
-
-	step = exec.IdentityStep{}
+	steps := []exec.Step{}
 
-	for i := len(*plan.Do) - 1; i >= 0; i-- {
-		innerPlan := (*plan.Do)[i]
+	for _, innerPlan := range *plan.Do {
 		innerPlan.Attempts = plan.Attempts
-		previous := build.buildStep(logger, innerPlan)
-		step = exec.OnSuccess(previous, step)
+		step := build.buildStep(logger, innerPlan)
+		steps = append(steps, step)
 	}
 
-	return step
+	return exec.Do(plan.ID, plan.ContinueOnFailure, steps...)
 }
 
 func (build *execBuild) buildTimeoutStep(logger lager.Logger, plan atc.Plan) exec.Step {
@@ -94,10 +91,22 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		plan.Attempts,
 	)
 
+	if build.reused[plan.ID] {
+		return build.factory.RerunTask(
+			logger,
+			plan,
+			build.dbBuild,
+			build.runState().Variables(),
+			containerMetadata,
+			build.delegate.TaskDelegate(plan.ID),
+		)
+	}
+
 	return build.factory.Task(
 		logger,
 		plan,
 		build.dbBuild,
+		build.runState().Variables(),
 		containerMetadata,
 		build.delegate.TaskDelegate(plan.ID),
 	)
@@ -118,6 +127,7 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		logger,
 		plan,
 		build.dbBuild,
+		build.runState().Variables(),
 		build.stepMetadata,
 		containerMetadata,
 		build.delegate.GetDelegate(plan.ID),
@@ -135,13 +145,96 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		plan.Attempts,
 	)
 
-	return build.factory.Put(
+	return build.resumable(build.factory.Put(
 		logger,
 		plan,
 		build.dbBuild,
+		build.runState().Variables(),
 		build.stepMetadata,
 		containerMetadata,
 		build.delegate.PutDelegate(plan.ID),
+	), plan)
+}
+
+func (build *execBuild) buildPromoteStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("promote", lager.Data{
+		"name": plan.Promote.Name,
+	})
+
+	containerMetadata := build.containerMetadata(
+		db.ContainerTypePut,
+		plan.Promote.Name,
+		plan.Attempts,
+	)
+
+	return build.resumable(build.factory.Promote(
+		logger,
+		plan,
+		build.dbBuild,
+		build.runState().Variables(),
+		build.stepMetadata,
+		containerMetadata,
+		build.delegate.PromoteDelegate(plan.ID),
+	), plan)
+}
+
+func (build *execBuild) buildSaveCacheStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("save-cache", lager.Data{
+		"name": plan.SaveCache.Name,
+	})
+
+	return build.factory.SaveCache(
+		logger,
+		plan,
+		build.dbBuild,
+		build.delegate.BuildStepDelegate(plan.ID),
+	)
+}
+
+func (build *execBuild) buildSetVarStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("set-var", lager.Data{
+		"name": plan.SetVar.Name,
+	})
+
+	return build.factory.SetVar(
+		logger,
+		plan,
+		build.dbBuild,
+		build.delegate.SetVarDelegate(plan.ID),
+	)
+}
+
+func (build *execBuild) buildWaitForStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("wait-for", lager.Data{
+		"name": plan.WaitFor.Name,
+	})
+
+	containerMetadata := build.containerMetadata(
+		db.ContainerTypeCheck,
+		plan.WaitFor.Name,
+		plan.Attempts,
+	)
+
+	return build.factory.WaitFor(
+		logger,
+		plan,
+		build.dbBuild,
+		build.runState().Variables(),
+		containerMetadata,
+		build.delegate.WaitDelegate(plan.ID),
+	)
+}
+
+func (build *execBuild) buildRestoreCacheStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("restore-cache", lager.Data{
+		"name": plan.RestoreCache.Name,
+	})
+
+	return build.factory.RestoreCache(
+		logger,
+		plan,
+		build.dbBuild,
+		build.delegate.BuildStepDelegate(plan.ID),
 	)
 }
 
@@ -157,7 +250,7 @@ func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.
 		steps = append(steps, step)
 	}
 
-	return exec.Retry(steps...)
+	return exec.Retry(build.delegate.RetryDelegate(plan.ID), steps...)
 }
 
 func (build *execBuild) buildUserArtifactStep(logger lager.Logger, plan atc.Plan) exec.Step {
@@ -167,3 +260,14 @@ func (build *execBuild) buildUserArtifactStep(logger lager.Logger, plan atc.Plan
 func (build *execBuild) buildArtifactOutputStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	return exec.ArtifactOutput(plan.ID, worker.ArtifactName(plan.ArtifactOutput.Name), build.delegate.BuildStepDelegate(plan.ID))
 }
+
+// resumable records the outcome of a step with side effects, so that it is
+// not run again if the build is resumed by another ATC. Only puts and
+// promotes are journalled; see exec.ResumableStep for what is not.
+func (build *execBuild) resumable(step exec.Step, plan atc.Plan) exec.Step {
+	if build.journal == nil {
+		return step
+	}
+
+	return exec.Resumable(step, plan.ID, build.journal)
+}
@@ -58,9 +58,10 @@ func (delegate *BuildStepDelegate) Stderr() io.Writer {
 	)
 }
 
-func (delegate *BuildStepDelegate) Errored(logger lager.Logger, message string) {
+func (delegate *BuildStepDelegate) Errored(logger lager.Logger, message string, kind atc.ErrorKind) {
 	err := delegate.build.SaveEvent(event.Error{
 		Message: message,
+		Kind:    kind,
 		Origin: event.Origin{
 			ID: event.OriginID(delegate.planID),
 		},
@@ -70,6 +71,47 @@ func (delegate *BuildStepDelegate) Errored(logger lager.Logger, message string)
 	}
 }
 
+func (delegate *BuildStepDelegate) TerminatedDuringCleanup(logger lager.Logger) {
+	err := delegate.build.SaveEvent(event.TerminatedDuringCleanup{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+	})
+	if err != nil {
+		logger.Error("failed-to-save-terminated-during-cleanup-event", err)
+	}
+}
+
+func (delegate *BuildStepDelegate) Retrying(logger lager.Logger, attempt exec.StepAttempt, budgetRemaining *int) {
+	err := delegate.build.SaveEvent(event.RetryAttempt{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Attempt:         attempt.Number,
+		MaxAttempts:     attempt.Max,
+		BudgetRemaining: budgetRemaining,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-retry-attempt-event", err)
+	}
+}
+
+func (delegate *BuildStepDelegate) RetryBudgetExhausted(logger lager.Logger, attempt exec.StepAttempt) {
+	err := delegate.build.SaveEvent(event.RetryBudgetExhausted{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Attempt:     attempt.Number,
+		MaxAttempts: attempt.Max,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-retry-budget-exhausted-event", err)
+	}
+}
+
 func newDBEventWriter(build db.Build, origin event.Origin, clock clock.Clock) io.Writer {
 	return &dbEventWriter{
 		build:  build,
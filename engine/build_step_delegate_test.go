@@ -6,11 +6,14 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/exec"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -153,4 +156,73 @@ var _ = Describe("BuildStepDelegate", func() {
 			})
 		})
 	})
+
+	Describe("Errored", func() {
+		JustBeforeEach(func() {
+			delegate.Errored(lagertest.NewTestLogger("test"), "no workers", atc.ErrorKindWorkerUnavailable)
+		})
+
+		It("saves an error event of the error's kind", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Error{
+				Message: "no workers",
+				Kind:    atc.ErrorKindWorkerUnavailable,
+				Origin: event.Origin{
+					ID: event.OriginID("some-plan-id"),
+				},
+			}))
+		})
+	})
+
+	Describe("TerminatedDuringCleanup", func() {
+		JustBeforeEach(func() {
+			delegate.TerminatedDuringCleanup(lagertest.NewTestLogger("test"))
+		})
+
+		It("saves a terminated-during-cleanup event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.TerminatedDuringCleanup{
+				Time: 123456789,
+				Origin: event.Origin{
+					ID: event.OriginID("some-plan-id"),
+				},
+			}))
+		})
+	})
+
+	Describe("Retrying", func() {
+		It("saves a retry-attempt event with the remaining budget", func() {
+			remaining := 4
+			delegate.Retrying(lagertest.NewTestLogger("test"), exec.StepAttempt{Number: 2, Max: 3}, &remaining)
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.RetryAttempt{
+				Time: 123456789,
+				Origin: event.Origin{
+					ID: event.OriginID("some-plan-id"),
+				},
+				Attempt:         2,
+				MaxAttempts:     3,
+				BudgetRemaining: &remaining,
+			}))
+		})
+	})
+
+	Describe("RetryBudgetExhausted", func() {
+		JustBeforeEach(func() {
+			delegate.RetryBudgetExhausted(lagertest.NewTestLogger("test"), exec.StepAttempt{Number: 3, Max: 3})
+		})
+
+		It("saves a retry-budget-exhausted event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.RetryBudgetExhausted{
+				Time: 123456789,
+				Origin: event.Origin{
+					ID: event.OriginID("some-plan-id"),
+				},
+				Attempt:     3,
+				MaxAttempts: 3,
+			}))
+		})
+	})
 })
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -10,17 +12,37 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/lifecycle"
 	"github.com/concourse/atc/metric"
 )
 
 const trackLockDuration = time.Minute
 
-func NewDBEngine(engines Engines, peerURL string) Engine {
+// NewDBEngine constructs an engine which tracks builds in the database and
+// runs them using the engines in the registry, which chooses the engine for
+// each new build.
+//
+// Builds are queued when resumed until the limiter lets them run, if it is
+// non-nil. They are not tracked while queued, so that another ATC with room
+// to run them may do so.
+//
+// While a build is tracked, a heartbeat is recorded for it on the given
+// interval, so that it can be adopted by another ATC if this one dies. Zero
+// disables heartbeats.
+//
+// If admission is non-nil, it is consulted before each build is created. If
+// snapshotter is non-nil, the environment of each build is snapshotted as it
+// finishes, once the workers it ran on are known.
+func NewDBEngine(registry *EngineRegistry, peerURL string, limiter *BuildLimiter, heartbeatInterval time.Duration, admission AdmissionController, snapshotter EnvironmentSnapshotter) Engine {
 	return &dbEngine{
-		engines:   engines,
-		peerURL:   peerURL,
-		releaseCh: make(chan struct{}),
-		waitGroup: new(sync.WaitGroup),
+		registry:          registry,
+		peerURL:           peerURL,
+		releaseCh:         make(chan struct{}),
+		waitGroup:         new(sync.WaitGroup),
+		limiter:           limiter,
+		heartbeatInterval: heartbeatInterval,
+		admission:         admission,
+		snapshotter:       snapshotter,
 	}
 }
 
@@ -33,18 +55,42 @@ func (err UnknownEngineError) Error() string {
 }
 
 type dbEngine struct {
-	engines   Engines
-	peerURL   string
-	releaseCh chan struct{}
-	waitGroup *sync.WaitGroup
+	registry          *EngineRegistry
+	peerURL           string
+	releaseCh         chan struct{}
+	waitGroup         *sync.WaitGroup
+	limiter           *BuildLimiter
+	heartbeatInterval time.Duration
+	admission         AdmissionController
+	snapshotter       EnvironmentSnapshotter
 }
 
 func (*dbEngine) Name() string {
 	return "db"
 }
 
+// CreateBuild runs the plan past the AdmissionController, if any, before
+// creating the build with the engine chosen by the registry. A build which is
+// not admitted is finished with the error, whether it was rejected or the
+// controller failed, so that it is not retried.
 func (engine *dbEngine) CreateBuild(logger lager.Logger, build db.Build, plan atc.Plan) (Build, error) {
-	buildEngine := engine.engines[0]
+	if engine.admission != nil {
+		admittedPlan, err := engine.admission.Admit(logger, build, plan)
+		if err != nil {
+			logger.Error("build-not-admitted", err)
+
+			finishErr := build.FinishWithError(err)
+			if finishErr != nil {
+				logger.Error("failed-to-finish-build", finishErr)
+			}
+
+			return nil, err
+		}
+
+		plan = admittedPlan
+	}
+
+	buildEngine := engine.registry.EngineFor(logger, build, plan)
 
 	createdBuild, err := buildEngine.CreateBuild(logger, build, plan)
 	if err != nil {
@@ -56,26 +102,49 @@ func (engine *dbEngine) CreateBuild(logger lager.Logger, build db.Build, plan at
 		return nil, err
 	}
 
-	if !started {
-		createdBuild.Abort(logger.Session("aborted-immediately"))
+	if started {
+		// published here rather than when the build is resumed, which happens
+		// again whenever it is picked back up, e.g. after a restart
+		lifecycle.Event{
+			Type:         lifecycle.BuildStarted,
+			TeamName:     build.TeamName(),
+			PipelineName: build.PipelineName(),
+			JobName:      build.JobName(),
+			BuildID:      build.ID(),
+			BuildName:    build.Name(),
+		}.Publish(logger)
+	} else {
+		// the build was aborted before it could start, so its cause is already
+		// recorded
+		createdBuild.Abort(logger.Session("aborted-immediately"), db.BuildAbortCause{})
 	}
 
 	return &dbBuild{
-		engines:   engine.engines,
-		peerURL:   engine.peerURL,
-		releaseCh: engine.releaseCh,
-		waitGroup: engine.waitGroup,
-		build:     build,
+		registry:          engine.registry,
+		peerURL:           engine.peerURL,
+		releaseCh:         engine.releaseCh,
+		waitGroup:         engine.waitGroup,
+		limiter:           engine.limiter,
+		heartbeatInterval: engine.heartbeatInterval,
+		snapshotter:       engine.snapshotter,
+		build:             build,
+
+		buildEvents: buildEvents{build},
 	}, nil
 }
 
 func (engine *dbEngine) LookupBuild(logger lager.Logger, build db.Build) (Build, error) {
 	return &dbBuild{
-		engines:   engine.engines,
-		peerURL:   engine.peerURL,
-		releaseCh: engine.releaseCh,
-		waitGroup: engine.waitGroup,
-		build:     build,
+		registry:          engine.registry,
+		peerURL:           engine.peerURL,
+		releaseCh:         engine.releaseCh,
+		waitGroup:         engine.waitGroup,
+		limiter:           engine.limiter,
+		heartbeatInterval: engine.heartbeatInterval,
+		snapshotter:       engine.snapshotter,
+		build:             build,
+
+		buildEvents: buildEvents{build},
 	}, nil
 }
 
@@ -86,7 +155,7 @@ func (engine *dbEngine) ReleaseAll(logger lager.Logger) {
 
 	logger.Info("waiting-on-builds")
 
-	for _, e := range engine.engines {
+	for _, e := range engine.registry.Engines() {
 		e.ReleaseAll(logger)
 	}
 
@@ -95,19 +164,51 @@ func (engine *dbEngine) ReleaseAll(logger lager.Logger) {
 	logger.Info("finished-waiting-on-builds")
 }
 
+// Drain drains each of the engines, stopping their builds from starting any
+// more steps until the context is done. It should be followed by ReleaseAll
+// so that the drained builds can be resumed elsewhere.
+func (engine *dbEngine) Drain(ctx context.Context) DrainReport {
+	engines := engine.registry.Engines()
+	reports := make([]DrainReport, len(engines))
+
+	wg := new(sync.WaitGroup)
+	for i, e := range engines {
+		wg.Add(1)
+
+		go func(i int, e Engine) {
+			defer wg.Done()
+			reports[i] = e.Drain(ctx)
+		}(i, e)
+	}
+
+	wg.Wait()
+
+	var report DrainReport
+	for _, r := range reports {
+		report.merge(r)
+	}
+
+	return report
+}
+
 type dbBuild struct {
-	engines   Engines
-	peerURL   string
-	releaseCh chan struct{}
-	build     db.Build
-	waitGroup *sync.WaitGroup
+	buildEvents
+
+	registry          *EngineRegistry
+	peerURL           string
+	releaseCh         chan struct{}
+	build             db.Build
+	waitGroup         *sync.WaitGroup
+	limiter           *BuildLimiter
+	heartbeatInterval time.Duration
+	snapshotter       EnvironmentSnapshotter
 }
 
 func (build *dbBuild) Metadata() string {
 	return strconv.Itoa(build.build.ID())
 }
 
-func (build *dbBuild) Abort(logger lager.Logger) error {
+func (build *dbBuild) Abort(logger lager.Logger, cause db.BuildAbortCause) error {
 	// the order below is very important to avoid races with build creation.
 
 	lock, acquired, err := build.build.AcquireTrackingLock(logger, trackLockDuration)
@@ -119,7 +220,7 @@ func (build *dbBuild) Abort(logger lager.Logger) error {
 	if !acquired {
 		// someone else is tracking the build; abort it, which will notify them
 		logger.Info("notifying-other-tracker")
-		return build.build.MarkAsAborted()
+		return build.build.MarkAsAborted(cause)
 	}
 
 	defer lock.Release()
@@ -128,7 +229,7 @@ func (build *dbBuild) Abort(logger lager.Logger) error {
 
 	// first save the status so that CreateBuild will see a conflict when it
 	// tries to mark the build as started.
-	err = build.build.MarkAsAborted()
+	err = build.build.MarkAsAborted(cause)
 	if err != nil {
 		logger.Error("failed-to-abort-in-database", err)
 		return err
@@ -159,7 +260,7 @@ func (build *dbBuild) Abort(logger lager.Logger) error {
 		return build.build.Finish(db.BuildStatusAborted)
 	}
 
-	buildEngine, found := build.engines.Lookup(buildEngineName)
+	buildEngine, found := build.registry.Lookup(buildEngineName)
 	if !found {
 		logger.Error("unknown-engine", nil, lager.Data{"engine": buildEngineName})
 		return UnknownEngineError{buildEngineName}
@@ -173,13 +274,50 @@ func (build *dbBuild) Abort(logger lager.Logger) error {
 	}
 
 	// ...and abort it.
-	return engineBuild.Abort(logger)
+	return engineBuild.Abort(logger, cause)
 }
 
 func (build *dbBuild) Resume(logger lager.Logger) {
 	build.waitGroup.Add(1)
 	defer build.waitGroup.Done()
 
+	// the build is resumed again on every track, so only the first resume
+	// waits for the limiter
+	if !build.limiter.claim(build.build.ID()) {
+		logger.Debug("build-already-queued")
+		return
+	}
+
+	defer build.limiter.unclaim(build.build.ID())
+
+	// listen for aborts before queueing, so that a build aborted while it
+	// waits for the limiter is not left running
+	aborts, err := build.build.AbortNotifier()
+	if err != nil {
+		logger.Error("failed-to-listen-for-aborts", err)
+		return
+	}
+
+	defer aborts.Close()
+
+	// queue before tracking the build, so that the tracking lock is not held
+	// by a build that is not running
+	var abortedWhileQueued bool
+	if build.limiter.acquire(logger, build.releaseCh, aborts.Notify()) {
+		defer build.limiter.release()
+	} else {
+		select {
+		case <-build.releaseCh:
+			logger.Info("released-while-queued")
+			return
+		default:
+			// the build has been told to abort, so resuming it just finishes it
+			// without running any of its steps
+			logger.Info("aborted-while-queued")
+			abortedWhileQueued = true
+		}
+	}
+
 	lock, acquired, err := build.build.AcquireTrackingLock(logger, trackLockDuration)
 	if err != nil {
 		logger.Error("failed-to-get-lock", err)
@@ -193,12 +331,22 @@ func (build *dbBuild) Resume(logger lager.Logger) {
 
 	defer lock.Release()
 
+	if build.build.IsOrphaned() {
+		logger.Info("adopting-orphaned-build", lager.Data{
+			"previous-tracker": build.build.Tracker(),
+			"last-heartbeat":   build.build.LastHeartbeat(),
+		})
+	}
+
 	err = build.build.TrackedBy(build.peerURL)
 	if err != nil {
 		logger.Error("failed-to-update-build-tracker", err)
 		return
 	}
 
+	stopHeartbeat := build.heartbeat(logger)
+	defer stopHeartbeat()
+
 	found, err := build.build.Reload()
 	if err != nil {
 		logger.Error("failed-to-load-build-from-db", err)
@@ -223,7 +371,7 @@ func (build *dbBuild) Resume(logger lager.Logger) {
 		return
 	}
 
-	buildEngine, found := build.engines.Lookup(buildEngineName)
+	buildEngine, found := build.registry.Lookup(buildEngineName)
 	if !found {
 		err := UnknownEngineError{Engine: buildEngineName}
 		logger.Error("unknown-build-engine", err, lager.Data{
@@ -240,31 +388,32 @@ func (build *dbBuild) Resume(logger lager.Logger) {
 		return
 	}
 
-	aborts, err := build.build.AbortNotifier()
-	if err != nil {
-		logger.Error("failed-to-listen-for-aborts", err)
-		return
-	}
-
-	defer aborts.Close()
+	abort := func() {
+		logger.Info("aborting")
 
-	done := make(chan struct{})
-	defer close(done)
+		// whoever aborted the build has already recorded the cause
+		err := engineBuild.Abort(logger, db.BuildAbortCause{})
+		if err != nil {
+			logger.Error("failed-to-abort", err)
+		}
+	}
 
-	go func() {
-		select {
-		case <-aborts.Notify():
-			logger.Info("aborting")
+	if abortedWhileQueued {
+		abort()
+	} else {
+		done := make(chan struct{})
+		defer close(done)
 
-			err := engineBuild.Abort(logger)
-			if err != nil {
-				logger.Error("failed-to-abort", err)
+		go func() {
+			select {
+			case <-aborts.Notify():
+				abort()
+			case <-build.releaseCh:
+				logger.Info("releasing")
+			case <-done:
 			}
-		case <-build.releaseCh:
-			logger.Info("releasing")
-		case <-done:
-		}
-	}()
+		}()
+	}
 
 	metric.BuildStarted{
 		PipelineName: build.build.PipelineName(),
@@ -293,6 +442,10 @@ func (build *dbBuild) Resume(logger lager.Logger) {
 	}
 
 	if !build.build.IsRunning() {
+		if build.snapshotter != nil {
+			build.saveEnvironment(logger.Session("save-environment"))
+		}
+
 		metric.BuildFinished{
 			PipelineName:  build.build.PipelineName(),
 			JobName:       build.build.JobName(),
@@ -302,6 +455,16 @@ func (build *dbBuild) Resume(logger lager.Logger) {
 			BuildDuration: build.build.EndTime().Sub(build.build.StartTime()),
 			TeamName:      build.build.TeamName(),
 		}.Emit(logger)
+
+		lifecycle.Event{
+			Type:         lifecycle.BuildFinished,
+			TeamName:     build.build.TeamName(),
+			PipelineName: build.build.PipelineName(),
+			JobName:      build.build.JobName(),
+			BuildID:      build.build.ID(),
+			BuildName:    build.build.Name(),
+			BuildStatus:  string(build.build.Status()),
+		}.Publish(logger)
 	}
 }
 
@@ -312,7 +475,7 @@ func (build *dbBuild) ReceiveInput(logger lager.Logger, id atc.PlanID, input io.
 		return
 	}
 
-	buildEngine, found := build.engines.Lookup(buildEngineName)
+	buildEngine, found := build.registry.Lookup(buildEngineName)
 	if !found {
 		logger.Error("unknown-engine", nil, lager.Data{"engine": buildEngineName})
 		return
@@ -327,26 +490,133 @@ func (build *dbBuild) ReceiveInput(logger lager.Logger, id atc.PlanID, input io.
 	engineBuild.ReceiveInput(logger, id, input)
 }
 
-func (build *dbBuild) SendOutput(logger lager.Logger, id atc.PlanID, output io.Writer) {
+func (build *dbBuild) SendOutput(logger lager.Logger, id atc.PlanID, output io.Writer) error {
 	buildEngineName := build.build.Engine()
 	if buildEngineName == "" {
 		logger.Info("sending-input-to-build-with-no-engine")
-		return
+		return nil
 	}
 
-	buildEngine, found := build.engines.Lookup(buildEngineName)
+	buildEngine, found := build.registry.Lookup(buildEngineName)
 	if !found {
 		logger.Error("unknown-engine", nil, lager.Data{"engine": buildEngineName})
-		return
+		return UnknownEngineError{buildEngineName}
 	}
 
 	engineBuild, err := buildEngine.LookupBuild(logger, build.build)
 	if err != nil {
 		logger.Error("failed-to-lookup-build-in-engine", err)
+		return err
+	}
+
+	return engineBuild.SendOutput(logger, id, output)
+}
+
+func (build *dbBuild) Replay(logger lager.Logger, id atc.PlanID) error {
+	buildEngineName := build.build.Engine()
+	if buildEngineName == "" {
+		return errors.New("build has no engine")
+	}
+
+	buildEngine, found := build.registry.Lookup(buildEngineName)
+	if !found {
+		logger.Error("unknown-engine", nil, lager.Data{"engine": buildEngineName})
+		return UnknownEngineError{buildEngineName}
+	}
+
+	engineBuild, err := buildEngine.LookupBuild(logger, build.build)
+	if err != nil {
+		logger.Error("failed-to-lookup-build-in-engine", err)
+		return err
+	}
+
+	return engineBuild.Replay(logger, id)
+}
+
+func (build *dbBuild) PlanRerun(logger lager.Logger, id atc.PlanID) (Rerun, error) {
+	buildEngineName := build.build.Engine()
+	if buildEngineName == "" {
+		return Rerun{}, errors.New("build has no engine")
+	}
+
+	buildEngine, found := build.registry.Lookup(buildEngineName)
+	if !found {
+		logger.Error("unknown-engine", nil, lager.Data{"engine": buildEngineName})
+		return Rerun{}, UnknownEngineError{buildEngineName}
+	}
+
+	engineBuild, err := buildEngine.LookupBuild(logger, build.build)
+	if err != nil {
+		logger.Error("failed-to-lookup-build-in-engine", err)
+		return Rerun{}, err
+	}
+
+	return engineBuild.PlanRerun(logger, id)
+}
+
+func (build *dbBuild) PlanGraph(logger lager.Logger) (atc.PlanGraph, error) {
+	buildEngineName := build.build.Engine()
+	if buildEngineName == "" {
+		return atc.PlanGraph{}, errors.New("build has no engine")
+	}
+
+	buildEngine, found := build.registry.Lookup(buildEngineName)
+	if !found {
+		logger.Error("unknown-engine", nil, lager.Data{"engine": buildEngineName})
+		return atc.PlanGraph{}, UnknownEngineError{buildEngineName}
+	}
+
+	engineBuild, err := buildEngine.LookupBuild(logger, build.build)
+	if err != nil {
+		logger.Error("failed-to-lookup-build-in-engine", err)
+		return atc.PlanGraph{}, err
+	}
+
+	return engineBuild.PlanGraph(logger)
+}
+
+// heartbeat records a heartbeat for the build on the interval until the
+// returned function is called.
+func (build *dbBuild) heartbeat(logger lager.Logger) func() {
+	if build.heartbeatInterval == 0 {
+		return func() {}
+	}
+
+	stop := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(build.heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := build.build.Heartbeat()
+				if err != nil {
+					logger.Error("failed-to-record-heartbeat", err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return func() { close(stop) }
+}
+
+// saveEnvironment records the build's environment once it has finished.
+// Failing to do so is only logged.
+func (build *dbBuild) saveEnvironment(logger lager.Logger) {
+	environment, err := build.snapshotter.Snapshot(logger, build.build)
+	if err != nil {
+		logger.Error("failed-to-snapshot-environment", err)
 		return
 	}
 
-	engineBuild.SendOutput(logger, id, output)
+	err = build.build.SaveEnvironment(environment)
+	if err != nil {
+		logger.Error("failed-to-save-environment", err)
+	}
 }
 
 func (build *dbBuild) finishWithError(logger lager.Logger, finishErr error) {
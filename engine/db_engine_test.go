@@ -2,6 +2,7 @@ package engine_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -43,7 +44,10 @@ var _ = Describe("DBEngine", func() {
 		dbBuild = new(dbfakes.FakeBuild)
 		dbBuild.IDReturns(128)
 
-		dbEngine = NewDBEngine(Engines{fakeEngineA, fakeEngineB}, "http://10.2.3.4:8080")
+		registry := NewEngineRegistry(Engines{fakeEngineA, fakeEngineB})
+		Expect(registry.SetTeamEngine("some-noop-team", "fake-engine-b")).To(Succeed())
+
+		dbEngine = NewDBEngine(registry, "http://10.2.3.4:8080", nil, 0, nil, nil)
 	})
 
 	Describe("CreateBuild", func() {
@@ -117,6 +121,7 @@ var _ = Describe("DBEngine", func() {
 					Expect(fakeBuild.AbortCallCount()).To(Equal(1))
 				})
 			})
+
 		})
 
 		Context("when creating the build fails", func() {
@@ -134,6 +139,96 @@ var _ = Describe("DBEngine", func() {
 				Expect(dbBuild.StartCallCount()).To(Equal(0))
 			})
 		})
+
+		Context("when the build's team is configured to use another engine", func() {
+			BeforeEach(func() {
+				dbBuild.TeamNameReturns("some-noop-team")
+
+				fakeBuild := new(enginefakes.FakeBuild)
+				fakeBuild.MetadataReturns("some-metadata")
+
+				fakeEngineB.CreateBuildReturns(fakeBuild, nil)
+			})
+
+			It("creates the build using the team's engine", func() {
+				Expect(fakeEngineA.CreateBuildCallCount()).To(BeZero())
+				Expect(fakeEngineB.CreateBuildCallCount()).To(Equal(1))
+			})
+
+			It("starts the build in the database with the team's engine", func() {
+				Expect(dbBuild.StartCallCount()).To(Equal(1))
+
+				engine, _, _ := dbBuild.StartArgsForCall(0)
+				Expect(engine).To(Equal("fake-engine-b"))
+			})
+		})
+
+		Context("with an admission controller", func() {
+			var fakeAdmissionController *enginefakes.FakeAdmissionController
+
+			BeforeEach(func() {
+				fakeAdmissionController = new(enginefakes.FakeAdmissionController)
+
+				registry := NewEngineRegistry(Engines{fakeEngineA, fakeEngineB})
+				dbEngine = NewDBEngine(registry, "http://10.2.3.4:8080", nil, 0, fakeAdmissionController, nil)
+
+				fakeBuild := new(enginefakes.FakeBuild)
+				fakeBuild.MetadataReturns("some-metadata")
+				fakeEngineA.CreateBuildReturns(fakeBuild, nil)
+			})
+
+			Context("when the build is admitted", func() {
+				var admittedPlan atc.Plan
+
+				BeforeEach(func() {
+					admittedPlan = planFactory.NewPlan(atc.TaskPlan{
+						Name: "some-admitted-task",
+					})
+
+					fakeAdmissionController.AdmitReturns(admittedPlan, nil)
+				})
+
+				It("consults the controller with the full plan", func() {
+					Expect(fakeAdmissionController.AdmitCallCount()).To(Equal(1))
+
+					_, build, admittingPlan := fakeAdmissionController.AdmitArgsForCall(0)
+					Expect(build).To(Equal(dbBuild))
+					Expect(admittingPlan).To(Equal(plan))
+				})
+
+				It("creates and starts the build with the admitted plan", func() {
+					Expect(buildErr).NotTo(HaveOccurred())
+
+					_, _, createdPlan := fakeEngineA.CreateBuildArgsForCall(0)
+					Expect(createdPlan).To(Equal(admittedPlan))
+
+					_, _, startedPlan := dbBuild.StartArgsForCall(0)
+					Expect(startedPlan).To(Equal(admittedPlan))
+				})
+			})
+
+			Context("when the build is rejected", func() {
+				rejection := AdmissionRejectedError{Reason: "privileged tasks are forbidden"}
+
+				BeforeEach(func() {
+					fakeAdmissionController.AdmitReturns(atc.Plan{}, rejection)
+				})
+
+				It("returns the error", func() {
+					Expect(buildErr).To(Equal(rejection))
+				})
+
+				It("does not create or start the build", func() {
+					Expect(fakeEngineA.CreateBuildCallCount()).To(BeZero())
+					Expect(dbBuild.StartCallCount()).To(BeZero())
+				})
+
+				It("finishes the build with the error", func() {
+					Expect(dbBuild.FinishWithErrorCallCount()).To(Equal(1))
+					Expect(dbBuild.FinishWithErrorArgsForCall(0)).To(Equal(rejection))
+				})
+			})
+		})
 	})
 
 	Describe("LookupBuild", func() {
@@ -162,7 +257,7 @@ var _ = Describe("DBEngine", func() {
 			})
 
 			JustBeforeEach(func() {
-				abortErr = foundBuild.Abort(lagertest.NewTestLogger("test"))
+				abortErr = foundBuild.Abort(lagertest.NewTestLogger("test"), db.BuildAbortCause{Requester: "some-team", Reason: "some-reason"})
 			})
 
 			Context("when acquiring the lock succeeds", func() {
@@ -212,6 +307,184 @@ var _ = Describe("DBEngine", func() {
 		})
 	})
 
+	Describe("Drain", func() {
+		BeforeEach(func() {
+			fakeEngineA.DrainReturns(DrainReport{
+				Drained:     []int{1},
+				Interrupted: []int{2},
+			})
+
+			fakeEngineB.DrainReturns(DrainReport{
+				Drained: []int{3},
+			})
+		})
+
+		It("drains every engine with the same context", func() {
+			ctx := context.WithValue(context.Background(), "some", "value")
+
+			dbEngine.Drain(ctx)
+
+			Expect(fakeEngineA.DrainCallCount()).To(Equal(1))
+			Expect(fakeEngineA.DrainArgsForCall(0)).To(Equal(ctx))
+
+			Expect(fakeEngineB.DrainCallCount()).To(Equal(1))
+			Expect(fakeEngineB.DrainArgsForCall(0)).To(Equal(ctx))
+		})
+
+		It("combines their reports", func() {
+			report := dbEngine.Drain(context.Background())
+			Expect(report.Drained).To(ConsistOf(1, 3))
+			Expect(report.Interrupted).To(ConsistOf(2))
+		})
+	})
+
+	Describe("limiting running builds", func() {
+		var (
+			limitedEngine Engine
+
+			firstBuild  Build
+			secondBuild Build
+
+			secondDBBuild *dbfakes.FakeBuild
+
+			firstRealBuild  *enginefakes.FakeBuild
+			secondRealBuild *enginefakes.FakeBuild
+
+			finishFirst chan struct{}
+			abortSecond chan struct{}
+		)
+
+		BeforeEach(func() {
+			limitedEngine = NewDBEngine(NewEngineRegistry(Engines{fakeEngineA}), "http://10.2.3.4:8080", NewBuildLimiter(1), 0, nil, nil)
+
+			firstRealBuild = new(enginefakes.FakeBuild)
+			secondRealBuild = new(enginefakes.FakeBuild)
+
+			finishFirst = make(chan struct{})
+			abortSecond = make(chan struct{})
+			firstRealBuild.ResumeStub = func(lager.Logger) {
+				<-finishFirst
+			}
+
+			fakeEngineA.LookupBuildStub = func(_ lager.Logger, build db.Build) (Build, error) {
+				if build.ID() == 1 {
+					return firstRealBuild, nil
+				}
+
+				return secondRealBuild, nil
+			}
+
+			runningBuild := func(id int, aborts chan struct{}) *dbfakes.FakeBuild {
+				build := new(dbfakes.FakeBuild)
+				build.IDReturns(id)
+				build.AcquireTrackingLockReturns(new(lockfakes.FakeLock), true, nil)
+				build.ReloadReturns(true, nil)
+				build.IsRunningReturns(true)
+				build.EngineReturns("fake-engine-a")
+
+				notifier := new(dbfakes.FakeNotifier)
+				notifier.NotifyReturns(aborts)
+				build.AbortNotifierReturns(notifier, nil)
+
+				return build
+			}
+
+			var err error
+			firstBuild, err = limitedEngine.LookupBuild(logger, runningBuild(1, make(chan struct{})))
+			Expect(err).NotTo(HaveOccurred())
+
+			secondDBBuild = runningBuild(2, abortSecond)
+			secondBuild, err = limitedEngine.LookupBuild(logger, secondDBBuild)
+			Expect(err).NotTo(HaveOccurred())
+
+			go firstBuild.Resume(logger)
+			Eventually(firstRealBuild.ResumeCallCount).Should(Equal(1))
+		})
+
+		AfterEach(func() {
+			select {
+			case <-finishFirst:
+			default:
+				close(finishFirst)
+			}
+		})
+
+		It("queues builds beyond the limit until a running build finishes", func() {
+			resumed := make(chan struct{})
+			go func() {
+				secondBuild.Resume(logger)
+				close(resumed)
+			}()
+
+			Consistently(secondRealBuild.ResumeCallCount).Should(BeZero())
+
+			close(finishFirst)
+
+			Eventually(resumed).Should(BeClosed())
+			Expect(secondRealBuild.ResumeCallCount()).To(Equal(1))
+		})
+
+		It("gives up on queued builds when builds are released", func() {
+			resumed := make(chan struct{})
+			go func() {
+				secondBuild.Resume(logger)
+				close(resumed)
+			}()
+
+			go limitedEngine.ReleaseAll(logger)
+
+			Eventually(resumed).Should(BeClosed())
+			Expect(secondRealBuild.ResumeCallCount()).To(BeZero())
+		})
+
+		It("does not track queued builds", func() {
+			go secondBuild.Resume(logger)
+
+			Consistently(secondRealBuild.ResumeCallCount).Should(BeZero())
+			Expect(secondDBBuild.AcquireTrackingLockCallCount()).To(BeZero())
+
+			close(finishFirst)
+
+			Eventually(secondRealBuild.ResumeCallCount).Should(Equal(1))
+			Expect(secondDBBuild.AcquireTrackingLockCallCount()).To(Equal(1))
+		})
+
+		It("queues a build resumed again while queued only once", func() {
+			resumed := make(chan struct{})
+			go func() {
+				secondBuild.Resume(logger)
+				close(resumed)
+			}()
+
+			Eventually(secondDBBuild.AbortNotifierCallCount).Should(Equal(1))
+
+			secondBuild.Resume(logger)
+			Expect(secondDBBuild.AbortNotifierCallCount()).To(Equal(1))
+
+			close(finishFirst)
+
+			Eventually(resumed).Should(BeClosed())
+			Expect(secondRealBuild.ResumeCallCount()).To(Equal(1))
+		})
+
+		It("aborts queued builds without waiting for a running build to finish", func() {
+			resumed := make(chan struct{})
+			go func() {
+				secondBuild.Resume(logger)
+				close(resumed)
+			}()
+
+			Consistently(secondRealBuild.ResumeCallCount).Should(BeZero())
+
+			close(abortSecond)
+
+			Eventually(resumed).Should(BeClosed())
+			Expect(secondRealBuild.AbortCallCount()).To(Equal(1))
+			Expect(secondRealBuild.ResumeCallCount()).To(Equal(1))
+			Expect(firstRealBuild.AbortCallCount()).To(BeZero())
+		})
+	})
+
 	Describe("Builds", func() {
 		var build Build
 
@@ -225,7 +498,7 @@ var _ = Describe("DBEngine", func() {
 			var abortErr error
 
 			JustBeforeEach(func() {
-				abortErr = build.Abort(lagertest.NewTestLogger("test"))
+				abortErr = build.Abort(lagertest.NewTestLogger("test"), db.BuildAbortCause{Requester: "some-team", Reason: "some-reason"})
 			})
 
 			Context("when acquiring the lock succeeds", func() {
@@ -241,7 +514,7 @@ var _ = Describe("DBEngine", func() {
 						dbBuild.ReloadReturns(true, nil)
 						dbBuild.EngineReturns("fake-engine-b")
 
-						dbBuild.MarkAsAbortedStub = func() error {
+						dbBuild.MarkAsAbortedStub = func(db.BuildAbortCause) error {
 							Expect(dbBuild.AcquireTrackingLockCallCount()).To(Equal(1))
 
 							_, interval := dbBuild.AcquireTrackingLockArgsForCall(0)
@@ -276,12 +549,21 @@ var _ = Describe("DBEngine", func() {
 								Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
 							})
 
-							It("aborts the build via the db", func() {
+							It("aborts the build via the db, recording the cause", func() {
 								Expect(dbBuild.MarkAsAbortedCallCount()).To(Equal(1))
+								Expect(dbBuild.MarkAsAbortedArgsForCall(0)).To(Equal(db.BuildAbortCause{
+									Requester: "some-team",
+									Reason:    "some-reason",
+								}))
 							})
 
 							It("aborts the real build", func() {
 								Expect(realBuild.AbortCallCount()).To(Equal(1))
+								_, cause := realBuild.AbortArgsForCall(0)
+								Expect(cause).To(Equal(db.BuildAbortCause{
+									Requester: "some-team",
+									Reason:    "some-reason",
+								}))
 							})
 						})
 
@@ -446,6 +728,10 @@ var _ = Describe("DBEngine", func() {
 
 			BeforeEach(func() {
 				logger = lagertest.NewTestLogger("test")
+
+				notifier := new(dbfakes.FakeNotifier)
+				notifier.NotifyReturns(make(chan struct{}))
+				dbBuild.AbortNotifierReturns(notifier, nil)
 			})
 
 			JustBeforeEach(func() {
@@ -571,13 +857,110 @@ var _ = Describe("DBEngine", func() {
 								Expect(notifier.CloseCallCount()).To(Equal(1))
 							})
 
+							It("does not record heartbeats", func() {
+								Expect(dbBuild.HeartbeatCallCount()).To(BeZero())
+							})
+
+							Context("when heartbeats are enabled", func() {
+								BeforeEach(func() {
+									heartbeatEngine := NewDBEngine(
+										NewEngineRegistry(Engines{fakeEngineA, fakeEngineB}),
+										"http://10.2.3.4:8080",
+										nil,
+										10*time.Millisecond,
+										nil,
+										nil,
+									)
+
+									var err error
+									build, err = heartbeatEngine.LookupBuild(logger, dbBuild)
+									Expect(err).NotTo(HaveOccurred())
+
+									realBuild.ResumeStub = func(lager.Logger) {
+										Eventually(dbBuild.HeartbeatCallCount).Should(BeNumerically(">=", 2))
+									}
+								})
+
+								It("records heartbeats while the build runs", func() {
+									Expect(realBuild.ResumeCallCount()).To(Equal(1))
+								})
+
+								It("stops recording heartbeats once the build has been run", func() {
+									heartbeats := dbBuild.HeartbeatCallCount()
+									Consistently(dbBuild.HeartbeatCallCount, 100*time.Millisecond).Should(BeNumerically("<=", heartbeats+1))
+								})
+							})
+
+							Context("with an environment snapshotter", func() {
+								var (
+									fakeSnapshotter *enginefakes.FakeEnvironmentSnapshotter
+									environment     atc.BuildEnvironment
+								)
+
+								BeforeEach(func() {
+									fakeSnapshotter = new(enginefakes.FakeEnvironmentSnapshotter)
+
+									environment = atc.BuildEnvironment{ATCVersion: "1.2.3"}
+									fakeSnapshotter.SnapshotReturns(environment, nil)
+
+									snapshotEngine := NewDBEngine(NewEngineRegistry(Engines{fakeEngineA, fakeEngineB}), "http://10.2.3.4:8080", nil, 0, nil, fakeSnapshotter)
+
+									var err error
+									build, err = snapshotEngine.LookupBuild(logger, dbBuild)
+									Expect(err).NotTo(HaveOccurred())
+								})
+
+								It("does not snapshot the environment while the build is still running", func() {
+									Expect(fakeSnapshotter.SnapshotCallCount()).To(BeZero())
+								})
+
+								Context("when the build finishes", func() {
+									BeforeEach(func() {
+										realBuild.ResumeStub = func(lager.Logger) {
+											dbBuild.IsRunningReturns(false)
+										}
+									})
+
+									It("saves a snapshot of the build's environment", func() {
+										Expect(fakeSnapshotter.SnapshotCallCount()).To(Equal(1))
+										_, snapshotBuild := fakeSnapshotter.SnapshotArgsForCall(0)
+										Expect(snapshotBuild).To(Equal(dbBuild))
+
+										Expect(dbBuild.SaveEnvironmentCallCount()).To(Equal(1))
+										Expect(dbBuild.SaveEnvironmentArgsForCall(0)).To(Equal(environment))
+									})
+
+									Context("when snapshotting the environment fails", func() {
+										BeforeEach(func() {
+											fakeSnapshotter.SnapshotReturns(atc.BuildEnvironment{}, errors.New("nope"))
+										})
+
+										It("does not save an environment", func() {
+											Expect(dbBuild.SaveEnvironmentCallCount()).To(BeZero())
+										})
+									})
+								})
+							})
+
+							Context("when the build was orphaned", func() {
+								BeforeEach(func() {
+									dbBuild.IsOrphanedReturns(true)
+								})
+
+								It("adopts it", func() {
+									Expect(dbBuild.TrackedByCallCount()).To(Equal(1))
+									Expect(dbBuild.TrackedByArgsForCall(0)).To(Equal("http://10.2.3.4:8080"))
+									Expect(realBuild.ResumeCallCount()).To(Equal(1))
+								})
+							})
+
 							Context("when the build is aborted", func() {
 								var errAborted = errors.New("aborted")
 
 								BeforeEach(func() {
 									aborted := make(chan error)
 
-									realBuild.AbortStub = func(lager.Logger) error {
+									realBuild.AbortStub = func(lager.Logger, db.BuildAbortCause) error {
 										aborted <- errAborted
 										return nil
 									}
@@ -614,8 +997,8 @@ var _ = Describe("DBEngine", func() {
 								Expect(realBuild.ResumeCallCount()).To(BeZero())
 							})
 
-							It("releases the lock", func() {
-								Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+							It("does not track the build", func() {
+								Expect(dbBuild.AcquireTrackingLockCallCount()).To(BeZero())
 							})
 						})
 					})
@@ -743,6 +1126,8 @@ var _ = Describe("DBEngine", func() {
 				output *bytes.Buffer
 
 				realBuild *enginefakes.FakeBuild
+
+				sendErr error
 			)
 
 			BeforeEach(func() {
@@ -753,7 +1138,7 @@ var _ = Describe("DBEngine", func() {
 			})
 
 			JustBeforeEach(func() {
-				build.SendOutput(lagertest.NewTestLogger("test"), "some-plan-id", output)
+				sendErr = build.SendOutput(lagertest.NewTestLogger("test"), "some-plan-id", output)
 			})
 
 			It("delegates to the real build", func() {
@@ -762,6 +1147,89 @@ var _ = Describe("DBEngine", func() {
 				Expect(id).To(Equal(atc.PlanID("some-plan-id")))
 				Expect(out).To(Equal(output))
 			})
+
+			Context("when the real build fails to send the whole output", func() {
+				BeforeEach(func() {
+					realBuild.SendOutputReturns(errors.New("nope"))
+				})
+
+				It("returns the error", func() {
+					Expect(sendErr).To(MatchError("nope"))
+				})
+			})
+		})
+
+		Describe("Replay", func() {
+			var (
+				realBuild *enginefakes.FakeBuild
+
+				replayErr error
+			)
+
+			BeforeEach(func() {
+				dbBuild.EngineReturns("fake-engine-b")
+				realBuild = new(enginefakes.FakeBuild)
+				fakeEngineB.LookupBuildReturns(realBuild, nil)
+			})
+
+			JustBeforeEach(func() {
+				replayErr = build.Replay(lagertest.NewTestLogger("test"), "some-plan-id")
+			})
+
+			It("delegates to the real build", func() {
+				Expect(replayErr).ToNot(HaveOccurred())
+
+				Expect(realBuild.ReplayCallCount()).To(Equal(1))
+				_, id := realBuild.ReplayArgsForCall(0)
+				Expect(id).To(Equal(atc.PlanID("some-plan-id")))
+			})
+
+			Context("when the build's engine is unknown", func() {
+				BeforeEach(func() {
+					dbBuild.EngineReturns("bogus")
+				})
+
+				It("returns an error", func() {
+					Expect(replayErr).To(Equal(UnknownEngineError{"bogus"}))
+				})
+			})
+		})
+
+		Describe("ReplayEvents", func() {
+			It("returns the build's events from the database", func() {
+				fakeEventSource := new(dbfakes.FakeEventSource)
+				dbBuild.EventsReturns(fakeEventSource, nil)
+
+				events, err := build.ReplayEvents(lagertest.NewTestLogger("test"), 42)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(Equal(fakeEventSource))
+
+				Expect(dbBuild.EventsArgsForCall(0)).To(Equal(uint(42)))
+			})
+		})
+
+		Describe("CheckpointEvent", func() {
+			It("saves the subscriber's checkpoint to the database", func() {
+				err := build.CheckpointEvent(lagertest.NewTestLogger("test"), "some-subscriber", 42)
+				Expect(err).NotTo(HaveOccurred())
+
+				subscriber, eventID := dbBuild.SaveEventCheckpointArgsForCall(0)
+				Expect(subscriber).To(Equal("some-subscriber"))
+				Expect(eventID).To(Equal(uint(42)))
+			})
+		})
+
+		Describe("EventCheckpoint", func() {
+			It("returns the subscriber's checkpoint from the database", func() {
+				dbBuild.EventCheckpointReturns(42, true, nil)
+
+				eventID, found, err := build.EventCheckpoint(lagertest.NewTestLogger("test"), "some-subscriber")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(eventID).To(Equal(uint(42)))
+
+				Expect(dbBuild.EventCheckpointArgsForCall(0)).To(Equal("some-subscriber"))
+			})
 		})
 	})
 })
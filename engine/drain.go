@@ -0,0 +1,177 @@
+package engine
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/exec"
+)
+
+// drainGate keeps track of the steps running in each build, and refuses to
+// start any more once the engine is draining.
+type drainGate struct {
+	draining  chan struct{}
+	drainOnce *sync.Once
+
+	builds  map[int]*drainState
+	drained []int
+	buildsL *sync.Mutex
+
+	changed chan struct{}
+}
+
+type drainState struct {
+	running int
+	refused bool
+}
+
+func newDrainGate() *drainGate {
+	return &drainGate{
+		draining:  make(chan struct{}),
+		drainOnce: new(sync.Once),
+
+		builds:  map[int]*drainState{},
+		buildsL: new(sync.Mutex),
+
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// track starts keeping track of a build while it is run.
+func (gate *drainGate) track(buildID int) {
+	gate.buildsL.Lock()
+	gate.builds[buildID] = &drainState{}
+	gate.buildsL.Unlock()
+}
+
+// untrack stops keeping track of a build, returning whether any of its steps
+// were refused, in which case it must not be finished.
+func (gate *drainGate) untrack(buildID int) bool {
+	gate.buildsL.Lock()
+	defer gate.buildsL.Unlock()
+
+	state, found := gate.builds[buildID]
+	delete(gate.builds, buildID)
+
+	if found && state.refused {
+		gate.drained = append(gate.drained, buildID)
+		return true
+	}
+
+	return false
+}
+
+// start returns false if the engine is draining, and otherwise counts the
+// step as running until finish is called.
+func (gate *drainGate) start(buildID int) bool {
+	gate.buildsL.Lock()
+	defer gate.buildsL.Unlock()
+
+	state, found := gate.builds[buildID]
+	if !found {
+		state = &drainState{}
+		gate.builds[buildID] = state
+	}
+
+	select {
+	case <-gate.draining:
+		state.refused = true
+		return false
+	default:
+	}
+
+	state.running++
+
+	return true
+}
+
+func (gate *drainGate) finish(buildID int) {
+	gate.buildsL.Lock()
+	if state, found := gate.builds[buildID]; found {
+		state.running--
+	}
+	gate.buildsL.Unlock()
+
+	select {
+	case gate.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (gate *drainGate) drain(ctx context.Context) DrainReport {
+	logger := lagerctx.FromContext(ctx)
+
+	gate.drainOnce.Do(func() {
+		close(gate.draining)
+	})
+
+	for gate.runningSteps() > 0 {
+		select {
+		case <-gate.changed:
+		case <-ctx.Done():
+			logger.Info("deadline-exceeded")
+			return gate.report()
+		}
+	}
+
+	return gate.report()
+}
+
+func (gate *drainGate) runningSteps() int {
+	gate.buildsL.Lock()
+	defer gate.buildsL.Unlock()
+
+	running := 0
+	for _, state := range gate.builds {
+		running += state.running
+	}
+
+	return running
+}
+
+func (gate *drainGate) report() DrainReport {
+	gate.buildsL.Lock()
+	defer gate.buildsL.Unlock()
+
+	report := DrainReport{
+		Drained: append([]int{}, gate.drained...),
+	}
+
+	for buildID, state := range gate.builds {
+		if state.running > 0 {
+			report.Interrupted = append(report.Interrupted, buildID)
+		} else {
+			report.Drained = append(report.Drained, buildID)
+		}
+	}
+
+	sort.Ints(report.Drained)
+	sort.Ints(report.Interrupted)
+
+	return report
+}
+
+// drainableStep runs a step unless the engine has started draining, in which
+// case it returns ErrDraining without running it.
+type drainableStep struct {
+	step    exec.Step
+	buildID int
+	gate    *drainGate
+}
+
+func (step drainableStep) Run(ctx context.Context, state exec.RunState) error {
+	if !step.gate.start(step.buildID) {
+		lagerctx.FromContext(ctx).Info("not-starting-step-while-draining")
+		return ErrDraining
+	}
+
+	defer step.gate.finish(step.buildID)
+
+	return step.step.Run(ctx, state)
+}
+
+func (step drainableStep) Succeeded() bool {
+	return step.step.Succeeded()
+}
@@ -1,7 +1,10 @@
 package engine
 
 import (
+	"context"
+	"errors"
 	"io"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
@@ -16,6 +19,8 @@ type Engine interface {
 	CreateBuild(lager.Logger, db.Build, atc.Plan) (Build, error)
 	LookupBuild(lager.Logger, db.Build) (Build, error)
 	ReleaseAll(lager.Logger)
+
+	Drain(context.Context) DrainReport
 }
 
 //go:generate counterfeiter . Build
@@ -23,11 +28,66 @@ type Engine interface {
 type Build interface {
 	Metadata() string
 
-	Abort(lager.Logger) error
+	// Abort records the cause with the build before stopping it.
+	Abort(lager.Logger, db.BuildAbortCause) error
 	Resume(lager.Logger)
 
 	ReceiveInput(lager.Logger, atc.PlanID, io.ReadCloser)
-	SendOutput(lager.Logger, atc.PlanID, io.Writer)
+	SendOutput(lager.Logger, atc.PlanID, io.Writer) error
+
+	// Replay starts recreating the container of a task step for debugging,
+	// returning once the step has been found.
+	Replay(lager.Logger, atc.PlanID) error
+
+	// PlanRerun describes how to run the build again from the given step.
+	PlanRerun(lager.Logger, atc.PlanID) (Rerun, error)
+
+	// PlanGraph describes the build's plan as a graph of its steps.
+	PlanGraph(lager.Logger) (atc.PlanGraph, error)
+
+	ReplayEvents(lager.Logger, uint) (db.EventSource, error)
+
+	EventCheckpoint(lager.Logger, string) (uint, bool, error)
+
+	CheckpointEvent(lager.Logger, string, uint) error
+}
+
+// Rerun describes how a build is run again from one of its steps.
+type Rerun struct {
+	// Plan is the plan of the build, which the rerun runs in full.
+	Plan atc.Plan
+
+	// Reused lists the steps preceding the one to rerun from. Their results
+	// in the original build are reused where they are still around; gets are
+	// run again, which fetch from the resource cache unless it has expired.
+	Reused []atc.PlanID
+}
+
+// ReplayTimeout bounds how long replaying a step may take to fetch its inputs
+// and create its container.
+const ReplayTimeout = 10 * time.Minute
+
+var (
+	ErrStepNotFound      = errors.New("step not found in build plan")
+	ErrStepNotReplayable = errors.New("only task steps can be replayed")
+	ErrDraining          = errors.New("not starting step while draining")
+)
+
+// DrainReport lists the builds which were running when an engine was
+// drained. None of them are finished by the engine; they are left to be
+// resumed by another ATC.
+type DrainReport struct {
+	// Drained builds stopped between steps. Once resumed, they run from the
+	// start again, except for puts and promotes which already completed.
+	Drained []int
+
+	// Interrupted builds still had steps running when the deadline passed.
+	Interrupted []int
+}
+
+func (report *DrainReport) merge(other DrainReport) {
+	report.Drained = append(report.Drained, other.Drained...)
+	report.Interrupted = append(report.Interrupted, other.Interrupted...)
 }
 
 type Engines []Engine
@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 )
 
@@ -20,10 +21,11 @@ type FakeBuild struct {
 	metadataReturnsOnCall map[int]struct {
 		result1 string
 	}
-	AbortStub        func(lager.Logger) error
+	AbortStub        func(lager.Logger, db.BuildAbortCause) error
 	abortMutex       sync.RWMutex
 	abortArgsForCall []struct {
 		arg1 lager.Logger
+		arg2 db.BuildAbortCause
 	}
 	abortReturns struct {
 		result1 error
@@ -43,13 +45,101 @@ type FakeBuild struct {
 		arg2 atc.PlanID
 		arg3 io.ReadCloser
 	}
-	SendOutputStub        func(lager.Logger, atc.PlanID, io.Writer)
+	SendOutputStub        func(lager.Logger, atc.PlanID, io.Writer) error
 	sendOutputMutex       sync.RWMutex
 	sendOutputArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 atc.PlanID
 		arg3 io.Writer
 	}
+	sendOutputReturns struct {
+		result1 error
+	}
+	sendOutputReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReplayEventsStub        func(lager.Logger, uint) (db.EventSource, error)
+	replayEventsMutex       sync.RWMutex
+	replayEventsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 uint
+	}
+	replayEventsReturns struct {
+		result1 db.EventSource
+		result2 error
+	}
+	replayEventsReturnsOnCall map[int]struct {
+		result1 db.EventSource
+		result2 error
+	}
+	EventCheckpointStub        func(lager.Logger, string) (uint, bool, error)
+	eventCheckpointMutex       sync.RWMutex
+	eventCheckpointArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	eventCheckpointReturns struct {
+		result1 uint
+		result2 bool
+		result3 error
+	}
+	eventCheckpointReturnsOnCall map[int]struct {
+		result1 uint
+		result2 bool
+		result3 error
+	}
+	CheckpointEventStub        func(lager.Logger, string, uint) error
+	checkpointEventMutex       sync.RWMutex
+	checkpointEventArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 uint
+	}
+	checkpointEventReturns struct {
+		result1 error
+	}
+	checkpointEventReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PlanRerunStub        func(lager.Logger, atc.PlanID) (engine.Rerun, error)
+	planRerunMutex       sync.RWMutex
+	planRerunArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.PlanID
+	}
+	planRerunReturns struct {
+		result1 engine.Rerun
+		result2 error
+	}
+	planRerunReturnsOnCall map[int]struct {
+		result1 engine.Rerun
+		result2 error
+	}
+	PlanGraphStub        func(lager.Logger) (atc.PlanGraph, error)
+	planGraphMutex       sync.RWMutex
+	planGraphArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	planGraphReturns struct {
+		result1 atc.PlanGraph
+		result2 error
+	}
+	planGraphReturnsOnCall map[int]struct {
+		result1 atc.PlanGraph
+		result2 error
+	}
+	ReplayStub        func(lager.Logger, atc.PlanID) error
+	replayMutex       sync.RWMutex
+	replayArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.PlanID
+	}
+	replayReturns struct {
+		result1 error
+	}
+	replayReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -94,16 +184,17 @@ func (fake *FakeBuild) MetadataReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakeBuild) Abort(arg1 lager.Logger) error {
+func (fake *FakeBuild) Abort(arg1 lager.Logger, arg2 db.BuildAbortCause) error {
 	fake.abortMutex.Lock()
 	ret, specificReturn := fake.abortReturnsOnCall[len(fake.abortArgsForCall)]
 	fake.abortArgsForCall = append(fake.abortArgsForCall, struct {
 		arg1 lager.Logger
-	}{arg1})
-	fake.recordInvocation("Abort", []interface{}{arg1})
+		arg2 db.BuildAbortCause
+	}{arg1, arg2})
+	fake.recordInvocation("Abort", []interface{}{arg1, arg2})
 	fake.abortMutex.Unlock()
 	if fake.AbortStub != nil {
-		return fake.AbortStub(arg1)
+		return fake.AbortStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -117,10 +208,10 @@ func (fake *FakeBuild) AbortCallCount() int {
 	return len(fake.abortArgsForCall)
 }
 
-func (fake *FakeBuild) AbortArgsForCall(i int) lager.Logger {
+func (fake *FakeBuild) AbortArgsForCall(i int) (lager.Logger, db.BuildAbortCause) {
 	fake.abortMutex.RLock()
 	defer fake.abortMutex.RUnlock()
-	return fake.abortArgsForCall[i].arg1
+	return fake.abortArgsForCall[i].arg1, fake.abortArgsForCall[i].arg2
 }
 
 func (fake *FakeBuild) AbortReturns(result1 error) {
@@ -192,8 +283,9 @@ func (fake *FakeBuild) ReceiveInputArgsForCall(i int) (lager.Logger, atc.PlanID,
 	return fake.receiveInputArgsForCall[i].arg1, fake.receiveInputArgsForCall[i].arg2, fake.receiveInputArgsForCall[i].arg3
 }
 
-func (fake *FakeBuild) SendOutput(arg1 lager.Logger, arg2 atc.PlanID, arg3 io.Writer) {
+func (fake *FakeBuild) SendOutput(arg1 lager.Logger, arg2 atc.PlanID, arg3 io.Writer) error {
 	fake.sendOutputMutex.Lock()
+	ret, specificReturn := fake.sendOutputReturnsOnCall[len(fake.sendOutputArgsForCall)]
 	fake.sendOutputArgsForCall = append(fake.sendOutputArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 atc.PlanID
@@ -202,8 +294,12 @@ func (fake *FakeBuild) SendOutput(arg1 lager.Logger, arg2 atc.PlanID, arg3 io.Wr
 	fake.recordInvocation("SendOutput", []interface{}{arg1, arg2, arg3})
 	fake.sendOutputMutex.Unlock()
 	if fake.SendOutputStub != nil {
-		fake.SendOutputStub(arg1, arg2, arg3)
+		return fake.SendOutputStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	return fake.sendOutputReturns.result1
 }
 
 func (fake *FakeBuild) SendOutputCallCount() int {
@@ -218,6 +314,334 @@ func (fake *FakeBuild) SendOutputArgsForCall(i int) (lager.Logger, atc.PlanID, i
 	return fake.sendOutputArgsForCall[i].arg1, fake.sendOutputArgsForCall[i].arg2, fake.sendOutputArgsForCall[i].arg3
 }
 
+func (fake *FakeBuild) SendOutputReturns(result1 error) {
+	fake.SendOutputStub = nil
+	fake.sendOutputReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SendOutputReturnsOnCall(i int, result1 error) {
+	fake.SendOutputStub = nil
+	if fake.sendOutputReturnsOnCall == nil {
+		fake.sendOutputReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.sendOutputReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ReplayEvents(arg1 lager.Logger, arg2 uint) (db.EventSource, error) {
+	fake.replayEventsMutex.Lock()
+	ret, specificReturn := fake.replayEventsReturnsOnCall[len(fake.replayEventsArgsForCall)]
+	fake.replayEventsArgsForCall = append(fake.replayEventsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 uint
+	}{arg1, arg2})
+	fake.recordInvocation("ReplayEvents", []interface{}{arg1, arg2})
+	fake.replayEventsMutex.Unlock()
+	if fake.ReplayEventsStub != nil {
+		return fake.ReplayEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.replayEventsReturns.result1, fake.replayEventsReturns.result2
+}
+
+func (fake *FakeBuild) ReplayEventsCallCount() int {
+	fake.replayEventsMutex.RLock()
+	defer fake.replayEventsMutex.RUnlock()
+	return len(fake.replayEventsArgsForCall)
+}
+
+func (fake *FakeBuild) ReplayEventsArgsForCall(i int) (lager.Logger, uint) {
+	fake.replayEventsMutex.RLock()
+	defer fake.replayEventsMutex.RUnlock()
+	return fake.replayEventsArgsForCall[i].arg1, fake.replayEventsArgsForCall[i].arg2
+}
+
+func (fake *FakeBuild) ReplayEventsReturns(result1 db.EventSource, result2 error) {
+	fake.ReplayEventsStub = nil
+	fake.replayEventsReturns = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ReplayEventsReturnsOnCall(i int, result1 db.EventSource, result2 error) {
+	fake.ReplayEventsStub = nil
+	if fake.replayEventsReturnsOnCall == nil {
+		fake.replayEventsReturnsOnCall = make(map[int]struct {
+			result1 db.EventSource
+			result2 error
+		})
+	}
+	fake.replayEventsReturnsOnCall[i] = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventCheckpoint(arg1 lager.Logger, arg2 string) (uint, bool, error) {
+	fake.eventCheckpointMutex.Lock()
+	ret, specificReturn := fake.eventCheckpointReturnsOnCall[len(fake.eventCheckpointArgsForCall)]
+	fake.eventCheckpointArgsForCall = append(fake.eventCheckpointArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("EventCheckpoint", []interface{}{arg1, arg2})
+	fake.eventCheckpointMutex.Unlock()
+	if fake.EventCheckpointStub != nil {
+		return fake.EventCheckpointStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.eventCheckpointReturns.result1, fake.eventCheckpointReturns.result2, fake.eventCheckpointReturns.result3
+}
+
+func (fake *FakeBuild) EventCheckpointCallCount() int {
+	fake.eventCheckpointMutex.RLock()
+	defer fake.eventCheckpointMutex.RUnlock()
+	return len(fake.eventCheckpointArgsForCall)
+}
+
+func (fake *FakeBuild) EventCheckpointArgsForCall(i int) (lager.Logger, string) {
+	fake.eventCheckpointMutex.RLock()
+	defer fake.eventCheckpointMutex.RUnlock()
+	return fake.eventCheckpointArgsForCall[i].arg1, fake.eventCheckpointArgsForCall[i].arg2
+}
+
+func (fake *FakeBuild) EventCheckpointReturns(result1 uint, result2 bool, result3 error) {
+	fake.EventCheckpointStub = nil
+	fake.eventCheckpointReturns = struct {
+		result1 uint
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) EventCheckpointReturnsOnCall(i int, result1 uint, result2 bool, result3 error) {
+	fake.EventCheckpointStub = nil
+	if fake.eventCheckpointReturnsOnCall == nil {
+		fake.eventCheckpointReturnsOnCall = make(map[int]struct {
+			result1 uint
+			result2 bool
+			result3 error
+		})
+	}
+	fake.eventCheckpointReturnsOnCall[i] = struct {
+		result1 uint
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) CheckpointEvent(arg1 lager.Logger, arg2 string, arg3 uint) error {
+	fake.checkpointEventMutex.Lock()
+	ret, specificReturn := fake.checkpointEventReturnsOnCall[len(fake.checkpointEventArgsForCall)]
+	fake.checkpointEventArgsForCall = append(fake.checkpointEventArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 uint
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CheckpointEvent", []interface{}{arg1, arg2, arg3})
+	fake.checkpointEventMutex.Unlock()
+	if fake.CheckpointEventStub != nil {
+		return fake.CheckpointEventStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.checkpointEventReturns.result1
+}
+
+func (fake *FakeBuild) CheckpointEventCallCount() int {
+	fake.checkpointEventMutex.RLock()
+	defer fake.checkpointEventMutex.RUnlock()
+	return len(fake.checkpointEventArgsForCall)
+}
+
+func (fake *FakeBuild) CheckpointEventArgsForCall(i int) (lager.Logger, string, uint) {
+	fake.checkpointEventMutex.RLock()
+	defer fake.checkpointEventMutex.RUnlock()
+	return fake.checkpointEventArgsForCall[i].arg1, fake.checkpointEventArgsForCall[i].arg2, fake.checkpointEventArgsForCall[i].arg3
+}
+
+func (fake *FakeBuild) CheckpointEventReturns(result1 error) {
+	fake.CheckpointEventStub = nil
+	fake.checkpointEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) CheckpointEventReturnsOnCall(i int, result1 error) {
+	fake.CheckpointEventStub = nil
+	if fake.checkpointEventReturnsOnCall == nil {
+		fake.checkpointEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkpointEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) PlanRerun(arg1 lager.Logger, arg2 atc.PlanID) (engine.Rerun, error) {
+	fake.planRerunMutex.Lock()
+	ret, specificReturn := fake.planRerunReturnsOnCall[len(fake.planRerunArgsForCall)]
+	fake.planRerunArgsForCall = append(fake.planRerunArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.PlanID
+	}{arg1, arg2})
+	fake.recordInvocation("PlanRerun", []interface{}{arg1, arg2})
+	fake.planRerunMutex.Unlock()
+	if fake.PlanRerunStub != nil {
+		return fake.PlanRerunStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.planRerunReturns.result1, fake.planRerunReturns.result2
+}
+
+func (fake *FakeBuild) PlanRerunCallCount() int {
+	fake.planRerunMutex.RLock()
+	defer fake.planRerunMutex.RUnlock()
+	return len(fake.planRerunArgsForCall)
+}
+
+func (fake *FakeBuild) PlanRerunArgsForCall(i int) (lager.Logger, atc.PlanID) {
+	fake.planRerunMutex.RLock()
+	defer fake.planRerunMutex.RUnlock()
+	return fake.planRerunArgsForCall[i].arg1, fake.planRerunArgsForCall[i].arg2
+}
+
+func (fake *FakeBuild) PlanRerunReturns(result1 engine.Rerun, result2 error) {
+	fake.PlanRerunStub = nil
+	fake.planRerunReturns = struct {
+		result1 engine.Rerun
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) PlanRerunReturnsOnCall(i int, result1 engine.Rerun, result2 error) {
+	fake.PlanRerunStub = nil
+	if fake.planRerunReturnsOnCall == nil {
+		fake.planRerunReturnsOnCall = make(map[int]struct {
+			result1 engine.Rerun
+			result2 error
+		})
+	}
+	fake.planRerunReturnsOnCall[i] = struct {
+		result1 engine.Rerun
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) PlanGraph(arg1 lager.Logger) (atc.PlanGraph, error) {
+	fake.planGraphMutex.Lock()
+	ret, specificReturn := fake.planGraphReturnsOnCall[len(fake.planGraphArgsForCall)]
+	fake.planGraphArgsForCall = append(fake.planGraphArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("PlanGraph", []interface{}{arg1})
+	fake.planGraphMutex.Unlock()
+	if fake.PlanGraphStub != nil {
+		return fake.PlanGraphStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.planGraphReturns.result1, fake.planGraphReturns.result2
+}
+
+func (fake *FakeBuild) PlanGraphCallCount() int {
+	fake.planGraphMutex.RLock()
+	defer fake.planGraphMutex.RUnlock()
+	return len(fake.planGraphArgsForCall)
+}
+
+func (fake *FakeBuild) PlanGraphArgsForCall(i int) lager.Logger {
+	fake.planGraphMutex.RLock()
+	defer fake.planGraphMutex.RUnlock()
+	return fake.planGraphArgsForCall[i].arg1
+}
+
+func (fake *FakeBuild) PlanGraphReturns(result1 atc.PlanGraph, result2 error) {
+	fake.PlanGraphStub = nil
+	fake.planGraphReturns = struct {
+		result1 atc.PlanGraph
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) PlanGraphReturnsOnCall(i int, result1 atc.PlanGraph, result2 error) {
+	fake.PlanGraphStub = nil
+	if fake.planGraphReturnsOnCall == nil {
+		fake.planGraphReturnsOnCall = make(map[int]struct {
+			result1 atc.PlanGraph
+			result2 error
+		})
+	}
+	fake.planGraphReturnsOnCall[i] = struct {
+		result1 atc.PlanGraph
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Replay(arg1 lager.Logger, arg2 atc.PlanID) error {
+	fake.replayMutex.Lock()
+	ret, specificReturn := fake.replayReturnsOnCall[len(fake.replayArgsForCall)]
+	fake.replayArgsForCall = append(fake.replayArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.PlanID
+	}{arg1, arg2})
+	fake.recordInvocation("Replay", []interface{}{arg1, arg2})
+	fake.replayMutex.Unlock()
+	if fake.ReplayStub != nil {
+		return fake.ReplayStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.replayReturns.result1
+}
+
+func (fake *FakeBuild) ReplayCallCount() int {
+	fake.replayMutex.RLock()
+	defer fake.replayMutex.RUnlock()
+	return len(fake.replayArgsForCall)
+}
+
+func (fake *FakeBuild) ReplayArgsForCall(i int) (lager.Logger, atc.PlanID) {
+	fake.replayMutex.RLock()
+	defer fake.replayMutex.RUnlock()
+	return fake.replayArgsForCall[i].arg1, fake.replayArgsForCall[i].arg2
+}
+
+func (fake *FakeBuild) ReplayReturns(result1 error) {
+	fake.ReplayStub = nil
+	fake.replayReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ReplayReturnsOnCall(i int, result1 error) {
+	fake.ReplayStub = nil
+	if fake.replayReturnsOnCall == nil {
+		fake.replayReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.replayReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -231,6 +655,18 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.receiveInputMutex.RUnlock()
 	fake.sendOutputMutex.RLock()
 	defer fake.sendOutputMutex.RUnlock()
+	fake.replayEventsMutex.RLock()
+	defer fake.replayEventsMutex.RUnlock()
+	fake.eventCheckpointMutex.RLock()
+	defer fake.eventCheckpointMutex.RUnlock()
+	fake.checkpointEventMutex.RLock()
+	defer fake.checkpointEventMutex.RUnlock()
+	fake.planRerunMutex.RLock()
+	defer fake.planRerunMutex.RUnlock()
+	fake.planGraphMutex.RLock()
+	defer fake.planGraphMutex.RUnlock()
+	fake.replayMutex.RLock()
+	defer fake.replayMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
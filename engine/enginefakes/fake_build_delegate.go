@@ -62,6 +62,55 @@ type FakeBuildDelegate struct {
 		arg2 error
 		arg3 bool
 	}
+	WaitDelegateStub        func(atc.PlanID) exec.WaitDelegate
+	waitDelegateMutex       sync.RWMutex
+	waitDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	waitDelegateReturns struct {
+		result1 exec.WaitDelegate
+	}
+	waitDelegateReturnsOnCall map[int]struct {
+		result1 exec.WaitDelegate
+	}
+	PromoteDelegateStub        func(atc.PlanID) exec.PromoteDelegate
+	promoteDelegateMutex       sync.RWMutex
+	promoteDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	promoteDelegateReturns struct {
+		result1 exec.PromoteDelegate
+	}
+	promoteDelegateReturnsOnCall map[int]struct {
+		result1 exec.PromoteDelegate
+	}
+	RetryDelegateStub        func(atc.PlanID) exec.RetryDelegate
+	retryDelegateMutex       sync.RWMutex
+	retryDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	retryDelegateReturns struct {
+		result1 exec.RetryDelegate
+	}
+	retryDelegateReturnsOnCall map[int]struct {
+		result1 exec.RetryDelegate
+	}
+	SetVarDelegateStub        func(atc.PlanID) exec.SetVarDelegate
+	setVarDelegateMutex       sync.RWMutex
+	setVarDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	setVarDelegateReturns struct {
+		result1 exec.SetVarDelegate
+	}
+	setVarDelegateReturnsOnCall map[int]struct {
+		result1 exec.SetVarDelegate
+	}
+	ReleaseStub        func(lager.Logger)
+	releaseMutex       sync.RWMutex
+	releaseArgsForCall []struct {
+		arg1 lager.Logger
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -284,6 +333,222 @@ func (fake *FakeBuildDelegate) FinishArgsForCall(i int) (lager.Logger, error, bo
 	return fake.finishArgsForCall[i].arg1, fake.finishArgsForCall[i].arg2, fake.finishArgsForCall[i].arg3
 }
 
+func (fake *FakeBuildDelegate) WaitDelegate(arg1 atc.PlanID) exec.WaitDelegate {
+	fake.waitDelegateMutex.Lock()
+	ret, specificReturn := fake.waitDelegateReturnsOnCall[len(fake.waitDelegateArgsForCall)]
+	fake.waitDelegateArgsForCall = append(fake.waitDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("WaitDelegate", []interface{}{arg1})
+	fake.waitDelegateMutex.Unlock()
+	if fake.WaitDelegateStub != nil {
+		return fake.WaitDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.waitDelegateReturns.result1
+}
+
+func (fake *FakeBuildDelegate) WaitDelegateCallCount() int {
+	fake.waitDelegateMutex.RLock()
+	defer fake.waitDelegateMutex.RUnlock()
+	return len(fake.waitDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) WaitDelegateArgsForCall(i int) atc.PlanID {
+	fake.waitDelegateMutex.RLock()
+	defer fake.waitDelegateMutex.RUnlock()
+	return fake.waitDelegateArgsForCall[i].arg1
+}
+
+func (fake *FakeBuildDelegate) WaitDelegateReturns(result1 exec.WaitDelegate) {
+	fake.WaitDelegateStub = nil
+	fake.waitDelegateReturns = struct {
+		result1 exec.WaitDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) WaitDelegateReturnsOnCall(i int, result1 exec.WaitDelegate) {
+	fake.WaitDelegateStub = nil
+	if fake.waitDelegateReturnsOnCall == nil {
+		fake.waitDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.WaitDelegate
+		})
+	}
+	fake.waitDelegateReturnsOnCall[i] = struct {
+		result1 exec.WaitDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) PromoteDelegate(arg1 atc.PlanID) exec.PromoteDelegate {
+	fake.promoteDelegateMutex.Lock()
+	ret, specificReturn := fake.promoteDelegateReturnsOnCall[len(fake.promoteDelegateArgsForCall)]
+	fake.promoteDelegateArgsForCall = append(fake.promoteDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("PromoteDelegate", []interface{}{arg1})
+	fake.promoteDelegateMutex.Unlock()
+	if fake.PromoteDelegateStub != nil {
+		return fake.PromoteDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.promoteDelegateReturns.result1
+}
+
+func (fake *FakeBuildDelegate) PromoteDelegateCallCount() int {
+	fake.promoteDelegateMutex.RLock()
+	defer fake.promoteDelegateMutex.RUnlock()
+	return len(fake.promoteDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) PromoteDelegateArgsForCall(i int) atc.PlanID {
+	fake.promoteDelegateMutex.RLock()
+	defer fake.promoteDelegateMutex.RUnlock()
+	return fake.promoteDelegateArgsForCall[i].arg1
+}
+
+func (fake *FakeBuildDelegate) PromoteDelegateReturns(result1 exec.PromoteDelegate) {
+	fake.PromoteDelegateStub = nil
+	fake.promoteDelegateReturns = struct {
+		result1 exec.PromoteDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) PromoteDelegateReturnsOnCall(i int, result1 exec.PromoteDelegate) {
+	fake.PromoteDelegateStub = nil
+	if fake.promoteDelegateReturnsOnCall == nil {
+		fake.promoteDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.PromoteDelegate
+		})
+	}
+	fake.promoteDelegateReturnsOnCall[i] = struct {
+		result1 exec.PromoteDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) RetryDelegate(arg1 atc.PlanID) exec.RetryDelegate {
+	fake.retryDelegateMutex.Lock()
+	ret, specificReturn := fake.retryDelegateReturnsOnCall[len(fake.retryDelegateArgsForCall)]
+	fake.retryDelegateArgsForCall = append(fake.retryDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("RetryDelegate", []interface{}{arg1})
+	fake.retryDelegateMutex.Unlock()
+	if fake.RetryDelegateStub != nil {
+		return fake.RetryDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.retryDelegateReturns.result1
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateCallCount() int {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return len(fake.retryDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateArgsForCall(i int) atc.PlanID {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return fake.retryDelegateArgsForCall[i].arg1
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateReturns(result1 exec.RetryDelegate) {
+	fake.RetryDelegateStub = nil
+	fake.retryDelegateReturns = struct {
+		result1 exec.RetryDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateReturnsOnCall(i int, result1 exec.RetryDelegate) {
+	fake.RetryDelegateStub = nil
+	if fake.retryDelegateReturnsOnCall == nil {
+		fake.retryDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.RetryDelegate
+		})
+	}
+	fake.retryDelegateReturnsOnCall[i] = struct {
+		result1 exec.RetryDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) SetVarDelegate(arg1 atc.PlanID) exec.SetVarDelegate {
+	fake.setVarDelegateMutex.Lock()
+	ret, specificReturn := fake.setVarDelegateReturnsOnCall[len(fake.setVarDelegateArgsForCall)]
+	fake.setVarDelegateArgsForCall = append(fake.setVarDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("SetVarDelegate", []interface{}{arg1})
+	fake.setVarDelegateMutex.Unlock()
+	if fake.SetVarDelegateStub != nil {
+		return fake.SetVarDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setVarDelegateReturns.result1
+}
+
+func (fake *FakeBuildDelegate) SetVarDelegateCallCount() int {
+	fake.setVarDelegateMutex.RLock()
+	defer fake.setVarDelegateMutex.RUnlock()
+	return len(fake.setVarDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) SetVarDelegateArgsForCall(i int) atc.PlanID {
+	fake.setVarDelegateMutex.RLock()
+	defer fake.setVarDelegateMutex.RUnlock()
+	return fake.setVarDelegateArgsForCall[i].arg1
+}
+
+func (fake *FakeBuildDelegate) SetVarDelegateReturns(result1 exec.SetVarDelegate) {
+	fake.SetVarDelegateStub = nil
+	fake.setVarDelegateReturns = struct {
+		result1 exec.SetVarDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) SetVarDelegateReturnsOnCall(i int, result1 exec.SetVarDelegate) {
+	fake.SetVarDelegateStub = nil
+	if fake.setVarDelegateReturnsOnCall == nil {
+		fake.setVarDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.SetVarDelegate
+		})
+	}
+	fake.setVarDelegateReturnsOnCall[i] = struct {
+		result1 exec.SetVarDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) Release(arg1 lager.Logger) {
+	fake.releaseMutex.Lock()
+	fake.releaseArgsForCall = append(fake.releaseArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("Release", []interface{}{arg1})
+	fake.releaseMutex.Unlock()
+	if fake.ReleaseStub != nil {
+		fake.ReleaseStub(arg1)
+	}
+}
+
+func (fake *FakeBuildDelegate) ReleaseCallCount() int {
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	return len(fake.releaseArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) ReleaseArgsForCall(i int) lager.Logger {
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	return fake.releaseArgsForCall[i].arg1
+}
+
 func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -297,6 +562,16 @@ func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	defer fake.buildStepDelegateMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
+	fake.waitDelegateMutex.RLock()
+	defer fake.waitDelegateMutex.RUnlock()
+	fake.promoteDelegateMutex.RLock()
+	defer fake.promoteDelegateMutex.RUnlock()
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	fake.setVarDelegateMutex.RLock()
+	defer fake.setVarDelegateMutex.RUnlock()
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
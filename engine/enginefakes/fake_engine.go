@@ -2,6 +2,7 @@
 package enginefakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/lager"
@@ -54,6 +55,17 @@ type FakeEngine struct {
 	releaseAllArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	DrainStub        func(context.Context) engine.DrainReport
+	drainMutex       sync.RWMutex
+	drainArgsForCall []struct {
+		arg1 context.Context
+	}
+	drainReturns struct {
+		result1 engine.DrainReport
+	}
+	drainReturnsOnCall map[int]struct {
+		result1 engine.DrainReport
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -227,6 +239,54 @@ func (fake *FakeEngine) ReleaseAllArgsForCall(i int) lager.Logger {
 	return fake.releaseAllArgsForCall[i].arg1
 }
 
+func (fake *FakeEngine) Drain(arg1 context.Context) engine.DrainReport {
+	fake.drainMutex.Lock()
+	ret, specificReturn := fake.drainReturnsOnCall[len(fake.drainArgsForCall)]
+	fake.drainArgsForCall = append(fake.drainArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("Drain", []interface{}{arg1})
+	fake.drainMutex.Unlock()
+	if fake.DrainStub != nil {
+		return fake.DrainStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.drainReturns.result1
+}
+
+func (fake *FakeEngine) DrainCallCount() int {
+	fake.drainMutex.RLock()
+	defer fake.drainMutex.RUnlock()
+	return len(fake.drainArgsForCall)
+}
+
+func (fake *FakeEngine) DrainArgsForCall(i int) context.Context {
+	fake.drainMutex.RLock()
+	defer fake.drainMutex.RUnlock()
+	return fake.drainArgsForCall[i].arg1
+}
+
+func (fake *FakeEngine) DrainReturns(result1 engine.DrainReport) {
+	fake.DrainStub = nil
+	fake.drainReturns = struct {
+		result1 engine.DrainReport
+	}{result1}
+}
+
+func (fake *FakeEngine) DrainReturnsOnCall(i int, result1 engine.DrainReport) {
+	fake.DrainStub = nil
+	if fake.drainReturnsOnCall == nil {
+		fake.drainReturnsOnCall = make(map[int]struct {
+			result1 engine.DrainReport
+		})
+	}
+	fake.drainReturnsOnCall[i] = struct {
+		result1 engine.DrainReport
+	}{result1}
+}
+
 func (fake *FakeEngine) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -238,6 +298,8 @@ func (fake *FakeEngine) Invocations() map[string][][]interface{} {
 	defer fake.lookupBuildMutex.RUnlock()
 	fake.releaseAllMutex.RLock()
 	defer fake.releaseAllMutex.RUnlock()
+	fake.drainMutex.RLock()
+	defer fake.drainMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
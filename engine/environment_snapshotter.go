@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . EnvironmentSnapshotter
+
+// EnvironmentSnapshotter captures the cluster configuration affecting a build
+// as it finishes, so that it can be audited later.
+type EnvironmentSnapshotter interface {
+	Snapshot(logger lager.Logger, build db.Build) (atc.BuildEnvironment, error)
+}
+
+type environmentSnapshotter struct {
+	workerFactory     db.WorkerFactory
+	atcVersion        string
+	credentialManager string
+	featureFlags      map[string]bool
+}
+
+// NewEnvironmentSnapshotter constructs an EnvironmentSnapshotter recording
+// the workers the build ran containers on and the resource types of its
+// pipeline, along with the given cluster-wide settings.
+func NewEnvironmentSnapshotter(
+	workerFactory db.WorkerFactory,
+	atcVersion string,
+	credentialManager string,
+	featureFlags map[string]bool,
+) EnvironmentSnapshotter {
+	return &environmentSnapshotter{
+		workerFactory:     workerFactory,
+		atcVersion:        atcVersion,
+		credentialManager: credentialManager,
+		featureFlags:      featureFlags,
+	}
+}
+
+func (snapshotter *environmentSnapshotter) Snapshot(logger lager.Logger, build db.Build) (atc.BuildEnvironment, error) {
+	environment := atc.BuildEnvironment{
+		ATCVersion:        snapshotter.atcVersion,
+		CredentialManager: snapshotter.credentialManager,
+		Workers:           []atc.BuildEnvironmentWorker{},
+		ResourceTypes:     []atc.BuildEnvironmentResourceType{},
+		FeatureFlags:      snapshotter.featureFlags,
+	}
+
+	workerNames, err := build.WorkerNames()
+	if err != nil {
+		logger.Error("failed-to-find-build-workers", err)
+		return atc.BuildEnvironment{}, err
+	}
+
+	used := map[string]bool{}
+	for _, name := range workerNames {
+		used[name] = true
+	}
+
+	workers, err := snapshotter.workerFactory.VisibleWorkers([]string{build.TeamName()})
+	if err != nil {
+		logger.Error("failed-to-find-workers", err)
+		return atc.BuildEnvironment{}, err
+	}
+
+	for _, worker := range workers {
+		if !used[worker.Name()] {
+			continue
+		}
+
+		snapshot := atc.BuildEnvironmentWorker{
+			Name:          worker.Name(),
+			Platform:      worker.Platform(),
+			ResourceTypes: map[string]string{},
+		}
+
+		if worker.Version() != nil {
+			snapshot.Version = *worker.Version()
+		}
+
+		for _, resourceType := range worker.ResourceTypes() {
+			snapshot.ResourceTypes[resourceType.Type] = resourceType.Version
+		}
+
+		environment.Workers = append(environment.Workers, snapshot)
+	}
+
+	pipeline, found, err := build.Pipeline()
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		return atc.BuildEnvironment{}, err
+	}
+
+	if found {
+		resourceTypes, err := pipeline.ResourceTypes()
+		if err != nil {
+			logger.Error("failed-to-find-resource-types", err)
+			return atc.BuildEnvironment{}, err
+		}
+
+		for _, resourceType := range resourceTypes {
+			environment.ResourceTypes = append(environment.ResourceTypes, atc.BuildEnvironmentResourceType{
+				Name:    resourceType.Name(),
+				Type:    resourceType.Type(),
+				Version: resourceType.Version(),
+			})
+		}
+	}
+
+	return environment, nil
+}
@@ -0,0 +1,129 @@
+package engine_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/engine"
+)
+
+var _ = Describe("EnvironmentSnapshotter", func() {
+	var (
+		fakeWorkerFactory *dbfakes.FakeWorkerFactory
+		fakeBuild         *dbfakes.FakeBuild
+		fakePipeline      *dbfakes.FakePipeline
+
+		snapshotter EnvironmentSnapshotter
+
+		environment atc.BuildEnvironment
+		snapshotErr error
+	)
+
+	BeforeEach(func() {
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+
+		version := "2.1"
+
+		runningWorker := new(dbfakes.FakeWorker)
+		runningWorker.NameReturns("some-worker")
+		runningWorker.StateReturns(db.WorkerStateRunning)
+		runningWorker.VersionReturns(&version)
+		runningWorker.PlatformReturns("linux")
+		runningWorker.ResourceTypesReturns([]atc.WorkerResourceType{
+			{Type: "git", Version: "some-git-version"},
+		})
+
+		unusedWorker := new(dbfakes.FakeWorker)
+		unusedWorker.NameReturns("some-unused-worker")
+		unusedWorker.StateReturns(db.WorkerStateRunning)
+
+		fakeWorkerFactory.VisibleWorkersReturns([]db.Worker{runningWorker, unusedWorker}, nil)
+
+		fakeResourceType := new(dbfakes.FakeResourceType)
+		fakeResourceType.NameReturns("some-type")
+		fakeResourceType.TypeReturns("docker-image")
+		fakeResourceType.VersionReturns(atc.Version{"digest": "some-digest"})
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.ResourceTypesReturns(db.ResourceTypes{fakeResourceType}, nil)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.WorkerNamesReturns([]string{"some-worker"}, nil)
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+		snapshotter = NewEnvironmentSnapshotter(fakeWorkerFactory, "1.2.3", "vault", map[string]bool{"artifact-cache": true})
+	})
+
+	JustBeforeEach(func() {
+		environment, snapshotErr = snapshotter.Snapshot(lagertest.NewTestLogger("test"), fakeBuild)
+	})
+
+	It("snapshots the workers the build ran on and its pipeline's resource types", func() {
+		Expect(snapshotErr).NotTo(HaveOccurred())
+
+		Expect(fakeWorkerFactory.VisibleWorkersArgsForCall(0)).To(Equal([]string{"some-team"}))
+
+		Expect(environment).To(Equal(atc.BuildEnvironment{
+			ATCVersion:        "1.2.3",
+			CredentialManager: "vault",
+			Workers: []atc.BuildEnvironmentWorker{
+				{
+					Name:          "some-worker",
+					Version:       "2.1",
+					Platform:      "linux",
+					ResourceTypes: map[string]string{"git": "some-git-version"},
+				},
+			},
+			ResourceTypes: []atc.BuildEnvironmentResourceType{
+				{
+					Name:    "some-type",
+					Type:    "docker-image",
+					Version: atc.Version{"digest": "some-digest"},
+				},
+			},
+			FeatureFlags: map[string]bool{"artifact-cache": true},
+		}))
+	})
+
+	Context("when the build is a one-off", func() {
+		BeforeEach(func() {
+			fakeBuild.PipelineReturns(nil, false, nil)
+		})
+
+		It("snapshots no resource types", func() {
+			Expect(snapshotErr).NotTo(HaveOccurred())
+			Expect(environment.ResourceTypes).To(BeEmpty())
+		})
+	})
+
+	Context("when finding the workers the build ran on fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeBuild.WorkerNamesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(snapshotErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when finding the workers fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeWorkerFactory.VisibleWorkersReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(snapshotErr).To(Equal(disaster))
+		})
+	})
+})
@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+)
+
+const (
+	// eventBatchSize is the size of log payloads buffered after which they
+	// are saved right away, in bytes.
+	eventBatchSize = 64 * 1024
+
+	// eventBatchInterval is the longest a log event is buffered for before
+	// being saved.
+	eventBatchInterval = 100 * time.Millisecond
+
+	// eventBufferLimit is the size of log payloads which are kept buffered
+	// while saving them keeps failing, in bytes. Logs beyond it are dropped.
+	eventBufferLimit = 16 * eventBatchSize
+)
+
+// eventBatchingBuild buffers the log events of a build so that they can be
+// saved in batches, rather than each in a transaction of its own. Any other
+// event saves the buffered logs first, so events are still saved in the
+// order they were emitted in.
+type eventBatchingBuild struct {
+	db.Build
+
+	clock clock.Clock
+
+	lock         sync.Mutex
+	pending      []atc.Event
+	pendingBytes int
+	scheduled    bool
+}
+
+func newEventBatchingBuild(build db.Build, clock clock.Clock) *eventBatchingBuild {
+	return &eventBatchingBuild{
+		Build: build,
+		clock: clock,
+	}
+}
+
+func (b *eventBatchingBuild) SaveEvent(ev atc.Event) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	log, ok := ev.(event.Log)
+	if !ok {
+		err := b.flush()
+		if err != nil {
+			return err
+		}
+
+		return b.Build.SaveEvent(ev)
+	}
+
+	if b.pendingBytes >= eventBufferLimit {
+		// the buffered logs still can't be saved; drop this one, as it would
+		// have been if it were saved on its own, rather than buffering
+		// without bound
+		err := b.flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	b.pending = append(b.pending, log)
+	b.pendingBytes += len(log.Payload)
+
+	if b.pendingBytes >= eventBatchSize {
+		return b.flush()
+	}
+
+	if !b.scheduled {
+		b.scheduled = true
+		go b.flushAfter(b.clock.NewTimer(eventBatchInterval))
+	}
+
+	return nil
+}
+
+// Flush saves the buffered events. Events are kept buffered if saving them
+// fails, to be saved along with the next batch, up to eventBufferLimit.
+func (b *eventBatchingBuild) Flush() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.flush()
+}
+
+func (b *eventBatchingBuild) flushAfter(timer clock.Timer) {
+	<-timer.C()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.scheduled = false
+
+	// a failed flush keeps the events buffered; the next save or flush will
+	// try again and report the error
+	_ = b.flush()
+}
+
+func (b *eventBatchingBuild) flush() error {
+	if len(b.pending) == 0 {
+		return nil
+	}
+
+	err := b.Build.SaveEvents(b.pending)
+	if err != nil {
+		return err
+	}
+
+	b.pending = nil
+	b.pendingBytes = 0
+
+	return nil
+}
@@ -3,10 +3,12 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
@@ -25,23 +27,31 @@ type execEngine struct {
 	factory         exec.Factory
 	delegateFactory BuildDelegateFactory
 	externalURL     string
+	cleanupBudget   time.Duration
+	retryBudget     int
 
 	releaseCh     chan struct{}
 	trackedStates *sync.Map
+	drainGate     *drainGate
 }
 
 func NewExecEngine(
 	factory exec.Factory,
 	delegateFactory BuildDelegateFactory,
 	externalURL string,
+	cleanupBudget time.Duration,
+	retryBudget int,
 ) Engine {
 	return &execEngine{
 		factory:         factory,
 		delegateFactory: delegateFactory,
 		externalURL:     externalURL,
+		cleanupBudget:   cleanupBudget,
+		retryBudget:     retryBudget,
 
 		releaseCh:     make(chan struct{}),
 		trackedStates: new(sync.Map),
+		drainGate:     newDrainGate(),
 	}
 }
 
@@ -50,9 +60,11 @@ func (engine *execEngine) Name() string {
 }
 
 func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan atc.Plan) (Build, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(engine.buildContext())
 
 	return &execBuild{
+		buildEvents: buildEvents{build},
+
 		dbBuild: build,
 
 		stepMetadata: buildMetadata(build, engine.externalURL),
@@ -62,17 +74,19 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan
 		metadata: execMetadata{
 			Plan: plan,
 		},
+		reused: reusedSteps(build, plan),
 
 		ctx:    ctx,
 		cancel: cancel,
 
 		releaseCh:     engine.releaseCh,
 		trackedStates: engine.trackedStates,
+		drainGate:     engine.drainGate,
 	}, nil
 }
 
 func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Build, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(engine.buildContext())
 
 	var metadata execMetadata
 	err := json.Unmarshal([]byte(build.EngineMetadata()), &metadata)
@@ -82,6 +96,8 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 	}
 
 	return &execBuild{
+		buildEvents: buildEvents{build},
+
 		dbBuild: build,
 
 		stepMetadata: buildMetadata(build, engine.externalURL),
@@ -89,20 +105,42 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 		factory:  engine.factory,
 		delegate: engine.delegateFactory.Delegate(build),
 		metadata: metadata,
+		reused:   reusedSteps(build, metadata.Plan),
 
 		ctx:    ctx,
 		cancel: cancel,
 
 		releaseCh:     engine.releaseCh,
 		trackedStates: engine.trackedStates,
+		drainGate:     engine.drainGate,
 	}, nil
 }
 
+// buildContext returns the context to run a build with, giving it budgets of
+// its own for cleaning up and retrying steps. A retry budget of zero means no
+// limit.
+func (engine *execEngine) buildContext() context.Context {
+	ctx := exec.WithCleanupBudget(context.Background(), engine.cleanupBudget)
+
+	if engine.retryBudget > 0 {
+		ctx = exec.WithRetryBudget(ctx, exec.NewRetryBudget(engine.retryBudget))
+	}
+
+	return ctx
+}
+
 func (engine *execEngine) ReleaseAll(logger lager.Logger) {
 	logger.Info("calling-release-in-exec-engine")
 	close(engine.releaseCh)
 }
 
+// Drain stops builds from starting any more steps, and waits for the steps
+// already running to finish until the context is done. Builds are left
+// unfinished once their running steps have completed.
+func (engine *execEngine) Drain(ctx context.Context) DrainReport {
+	return engine.drainGate.drain(ctx)
+}
+
 func buildMetadata(build db.Build, externalURL string) StepMetadata {
 	return StepMetadata{
 		BuildID:      build.ID(),
@@ -115,6 +153,8 @@ func buildMetadata(build db.Build, externalURL string) StepMetadata {
 }
 
 type execBuild struct {
+	buildEvents
+
 	dbBuild      db.Build
 	stepMetadata StepMetadata
 
@@ -126,8 +166,14 @@ type execBuild struct {
 
 	releaseCh     chan struct{}
 	trackedStates *sync.Map
+	drainGate     *drainGate
 
 	metadata execMetadata
+
+	// reused are the steps preceding the one a rerun build is rerun from
+	reused map[atc.PlanID]bool
+
+	journal exec.StepJournal
 }
 
 func (build *execBuild) Metadata() string {
@@ -139,12 +185,24 @@ func (build *execBuild) Metadata() string {
 	return string(payload)
 }
 
-func (build *execBuild) Abort(lager.Logger) error {
+func (build *execBuild) Abort(lager.Logger, db.BuildAbortCause) error {
 	build.cancel()
 	return nil
 }
 
 func (build *execBuild) Resume(logger lager.Logger) {
+	journal, err := newStepJournal(build.dbBuild)
+	if err != nil {
+		// without the journal, steps with side effects could be run twice
+		logger.Error("failed-to-load-step-journal", err)
+		build.delegate.Finish(logger.Session("finish"), err, false)
+		return
+	}
+
+	build.journal = journal
+
+	build.drainGate.track(build.dbBuild.ID())
+
 	step := build.buildStep(logger, build.metadata.Plan)
 
 	runCtx := lagerctx.NewContext(build.ctx, logger)
@@ -161,8 +219,16 @@ func (build *execBuild) Resume(logger lager.Logger) {
 		select {
 		case <-build.releaseCh:
 			logger.Info("releasing")
+			build.drainGate.untrack(build.dbBuild.ID())
+			build.delegate.Release(logger.Session("release"))
 			return
 		case err := <-done:
+			if build.drainGate.untrack(build.dbBuild.ID()) {
+				logger.Info("drained")
+				build.delegate.Release(logger.Session("release"))
+				return
+			}
+
 			build.delegate.Finish(logger.Session("finish"), err, step.Succeeded())
 			return
 		}
@@ -173,8 +239,106 @@ func (build *execBuild) ReceiveInput(logger lager.Logger, plan atc.PlanID, strea
 	build.runState().SendUserInput(plan, stream)
 }
 
-func (build *execBuild) SendOutput(logger lager.Logger, plan atc.PlanID, output io.Writer) {
-	build.runState().ReadPlanOutput(plan, output)
+func (build *execBuild) SendOutput(logger lager.Logger, plan atc.PlanID, output io.Writer) error {
+	return build.runState().ReadPlanOutput(plan, output)
+}
+
+// Replay recreates the container of one of the build's task steps for
+// debugging. The versions fetched by the build's get steps are fetched again
+// into a run state of its own, from the resource cache where it is still
+// around, and the task's container is then created with whichever inputs are
+// available, without running its script.
+//
+// Only finding the step happens before returning; the rest runs in the
+// background, and is given up on after ReplayTimeout.
+func (build *execBuild) Replay(logger lager.Logger, planID atc.PlanID) error {
+	var taskPlan *atc.Plan
+	var getPlans []atc.Plan
+	eachPlan(build.metadata.Plan, func(plan atc.Plan) {
+		if plan.ID == planID {
+			found := plan
+			taskPlan = &found
+		}
+
+		if plan.Get != nil && plan.Get.Version != nil {
+			getPlans = append(getPlans, plan)
+		}
+	})
+
+	if taskPlan == nil {
+		return ErrStepNotFound
+	}
+
+	if taskPlan.Task == nil {
+		return ErrStepNotReplayable
+	}
+
+	go build.replay(logger.Session("replay", lager.Data{"plan": planID}), *taskPlan, getPlans)
+
+	return nil
+}
+
+func (build *execBuild) replay(logger lager.Logger, taskPlan atc.Plan, getPlans []atc.Plan) {
+	ctx, cancel := context.WithTimeout(lagerctx.NewContext(context.Background(), logger), ReplayTimeout)
+	defer cancel()
+
+	state := exec.NewRunState()
+
+	for _, plan := range getPlans {
+		getStep := build.factory.Get(
+			logger.Session("get", lager.Data{"name": plan.Get.Name}),
+			plan,
+			build.dbBuild,
+			state.Variables(),
+			build.stepMetadata,
+			build.containerMetadata(db.ContainerTypeGet, plan.Get.Name, plan.Attempts),
+			replayGetDelegate{},
+		)
+
+		// inputs that cannot be fetched are reported as missing by the replay
+		err := getStep.Run(ctx, state)
+		if err != nil {
+			logger.Error("failed-to-fetch-input", err, lager.Data{"name": plan.Get.Name})
+		}
+	}
+
+	replayStep := build.factory.ReplayTask(
+		logger.Session("replay-task"),
+		taskPlan,
+		build.dbBuild,
+		state.Variables(),
+		build.containerMetadata(db.ContainerTypeTask, taskPlan.Task.Name, taskPlan.Attempts),
+		replayTaskDelegate{},
+	)
+
+	err := replayStep.Run(ctx, state)
+	if err != nil {
+		logger.Error("failed-to-replay", err)
+		return
+	}
+
+	var result exec.ReplayResult
+	if state.Result(taskPlan.ID, &result) {
+		logger.Info("replayed", lager.Data{"container": result.ContainerHandle})
+	}
+}
+
+// PlanRerun returns the build's plan to be run again from the given step, and
+// the steps preceding it in the order in which they are visited.
+func (build *execBuild) PlanRerun(logger lager.Logger, from atc.PlanID) (Rerun, error) {
+	preceding, found := stepsPreceding(build.metadata.Plan, from)
+	if !found {
+		return Rerun{}, ErrStepNotFound
+	}
+
+	return Rerun{
+		Plan:   build.metadata.Plan,
+		Reused: preceding,
+	}, nil
+}
+
+func (build *execBuild) PlanGraph(logger lager.Logger) (atc.PlanGraph, error) {
+	return NewPlanGraph(build.metadata.Plan), nil
 }
 
 func (build *execBuild) runState() exec.RunState {
@@ -187,6 +351,21 @@ func (build *execBuild) clearRunState() {
 }
 
 func (build *execBuild) buildStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	step := build.buildPlanStep(logger, plan)
+
+	if plan.Display != nil {
+		return displayedStep{
+			step:    step,
+			build:   build.dbBuild,
+			planID:  plan.ID,
+			display: *plan.Display,
+		}
+	}
+
+	return step
+}
+
+func (build *execBuild) buildPlanStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	if plan.Aggregate != nil {
 		return build.buildAggregateStep(logger, plan)
 	}
@@ -220,32 +399,129 @@ func (build *execBuild) buildStep(logger lager.Logger, plan atc.Plan) exec.Step
 	}
 
 	if plan.Task != nil {
-		return build.buildTaskStep(logger, plan)
+		return build.drainable(build.buildTaskStep(logger, plan))
 	}
 
 	if plan.Get != nil {
-		return build.buildGetStep(logger, plan)
+		return build.drainable(build.buildGetStep(logger, plan))
 	}
 
 	if plan.Put != nil {
-		return build.buildPutStep(logger, plan)
+		return build.drainable(build.buildPutStep(logger, plan))
 	}
 
 	if plan.Retry != nil {
 		return build.buildRetryStep(logger, plan)
 	}
 
+	if plan.SaveCache != nil {
+		return build.drainable(build.buildSaveCacheStep(logger, plan))
+	}
+
+	if plan.RestoreCache != nil {
+		return build.drainable(build.buildRestoreCacheStep(logger, plan))
+	}
+
+	if plan.WaitFor != nil {
+		return build.drainable(build.buildWaitForStep(logger, plan))
+	}
+
+	if plan.Promote != nil {
+		return build.drainable(build.buildPromoteStep(logger, plan))
+	}
+
+	if plan.SetVar != nil {
+		return build.drainable(build.buildSetVarStep(logger, plan))
+	}
+
 	if plan.UserArtifact != nil {
-		return build.buildUserArtifactStep(logger, plan)
+		return build.drainable(build.buildUserArtifactStep(logger, plan))
 	}
 
 	if plan.ArtifactOutput != nil {
-		return build.buildArtifactOutputStep(logger, plan)
+		return build.drainable(build.buildArtifactOutputStep(logger, plan))
 	}
 
 	return exec.IdentityStep{}
 }
 
+// drainable stops the step from being started once the engine is draining.
+func (build *execBuild) drainable(step exec.Step) exec.Step {
+	return drainableStep{
+		step:    step,
+		buildID: build.dbBuild.ID(),
+		gate:    build.drainGate,
+	}
+}
+
+// eachPlan calls f with the plan and every plan nested within it.
+func eachPlan(plan atc.Plan, f func(atc.Plan)) {
+	f(plan)
+
+	var children []atc.Plan
+
+	switch {
+	case plan.Aggregate != nil:
+		children = *plan.Aggregate
+	case plan.Do != nil:
+		children = *plan.Do
+	case plan.Retry != nil:
+		children = *plan.Retry
+	case plan.Timeout != nil:
+		children = []atc.Plan{plan.Timeout.Step}
+	case plan.Try != nil:
+		children = []atc.Plan{plan.Try.Step}
+	case plan.OnAbort != nil:
+		children = []atc.Plan{plan.OnAbort.Step, plan.OnAbort.Next}
+	case plan.OnSuccess != nil:
+		children = []atc.Plan{plan.OnSuccess.Step, plan.OnSuccess.Next}
+	case plan.OnFailure != nil:
+		children = []atc.Plan{plan.OnFailure.Step, plan.OnFailure.Next}
+	case plan.Ensure != nil:
+		children = []atc.Plan{plan.Ensure.Step, plan.Ensure.Next}
+	}
+
+	for _, child := range children {
+		eachPlan(child, f)
+	}
+}
+
+// stepsPreceding returns the IDs of the plans visited by eachPlan before the
+// one with the given ID, and whether it was visited at all.
+func stepsPreceding(plan atc.Plan, id atc.PlanID) ([]atc.PlanID, bool) {
+	var preceding []atc.PlanID
+	var found bool
+
+	eachPlan(plan, func(plan atc.Plan) {
+		if plan.ID == id {
+			found = true
+		}
+
+		if !found {
+			preceding = append(preceding, plan.ID)
+		}
+	})
+
+	return preceding, found
+}
+
+// reusedSteps returns the steps of a rerun build whose results are reused
+// from the build being rerun. It is empty for other builds, including reruns
+// of builds which have since been deleted.
+func reusedSteps(build db.Build, plan atc.Plan) map[atc.PlanID]bool {
+	reused := map[atc.PlanID]bool{}
+	if build.RerunOf() == 0 {
+		return reused
+	}
+
+	preceding, _ := stepsPreceding(plan, build.RerunFrom())
+	for _, id := range preceding {
+		reused[id] = true
+	}
+
+	return reused
+}
+
 func (build *execBuild) containerMetadata(
 	containerType db.ContainerType,
 	stepName string,
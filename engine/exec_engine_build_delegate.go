@@ -16,10 +16,18 @@ type BuildDelegate interface {
 	GetDelegate(atc.PlanID) exec.GetDelegate
 	PutDelegate(atc.PlanID) exec.PutDelegate
 	TaskDelegate(atc.PlanID) exec.TaskDelegate
+	WaitDelegate(atc.PlanID) exec.WaitDelegate
+	PromoteDelegate(atc.PlanID) exec.PromoteDelegate
+	SetVarDelegate(atc.PlanID) exec.SetVarDelegate
 
 	BuildStepDelegate(atc.PlanID) exec.BuildStepDelegate
+	RetryDelegate(atc.PlanID) exec.RetryDelegate
 
 	Finish(lager.Logger, error, bool)
+
+	// Release saves any events the build has yet to save, for when the build
+	// is released or drained without finishing.
+	Release(lager.Logger)
 }
 
 //go:generate counterfeiter . BuildDelegateFactory
@@ -39,12 +47,12 @@ func (factory buildDelegateFactory) Delegate(build db.Build) BuildDelegate {
 }
 
 type delegate struct {
-	build db.Build
+	build *eventBatchingBuild
 }
 
 func newBuildDelegate(build db.Build) BuildDelegate {
 	return &delegate{
-		build: build,
+		build: newEventBatchingBuild(build, clock.NewClock()),
 	}
 }
 
@@ -60,11 +68,29 @@ func (delegate *delegate) TaskDelegate(planID atc.PlanID) exec.TaskDelegate {
 	return NewTaskDelegate(delegate.build, planID, clock.NewClock())
 }
 
+func (delegate *delegate) WaitDelegate(planID atc.PlanID) exec.WaitDelegate {
+	return NewWaitDelegate(delegate.build, planID, clock.NewClock())
+}
+
+func (delegate *delegate) PromoteDelegate(planID atc.PlanID) exec.PromoteDelegate {
+	return NewPromoteDelegate(delegate.build, planID, clock.NewClock())
+}
+
+func (delegate *delegate) SetVarDelegate(planID atc.PlanID) exec.SetVarDelegate {
+	return NewSetVarDelegate(delegate.build, planID, clock.NewClock())
+}
+
 func (delegate *delegate) BuildStepDelegate(planID atc.PlanID) exec.BuildStepDelegate {
 	return NewBuildStepDelegate(delegate.build, planID, clock.NewClock())
 }
 
+func (delegate *delegate) RetryDelegate(planID atc.PlanID) exec.RetryDelegate {
+	return NewBuildStepDelegate(delegate.build, planID, clock.NewClock())
+}
+
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded bool) {
+	delegate.flushEvents(logger)
+
 	if err == context.Canceled {
 		delegate.saveStatus(logger, atc.StatusAborted)
 		logger.Info("aborted")
@@ -80,6 +106,17 @@ func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded bool)
 	}
 }
 
+func (delegate *delegate) Release(logger lager.Logger) {
+	delegate.flushEvents(logger)
+}
+
+func (delegate *delegate) flushEvents(logger lager.Logger) {
+	err := delegate.build.Flush()
+	if err != nil {
+		logger.Error("failed-to-flush-events", err)
+	}
+}
+
 func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	err := delegate.build.Finish(db.BuildStatus(status))
 	if err != nil {
@@ -3,8 +3,10 @@ package engine_test
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/engine"
@@ -61,4 +63,122 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 	})
+
+	Describe("saving events", func() {
+		var planID atc.PlanID
+
+		BeforeEach(func() {
+			planID = atc.PlanID("some-plan-id")
+		})
+
+		It("saves logs in batches", func() {
+			stdout := delegate.BuildStepDelegate(planID).Stdout()
+
+			_, err := stdout.Write([]byte("hello"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = stdout.Write([]byte("world"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+
+			Eventually(fakeBuild.SaveEventsCallCount).Should(Equal(1))
+
+			events := fakeBuild.SaveEventsArgsForCall(0)
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].(event.Log).Payload).To(Equal("hello"))
+			Expect(events[1].(event.Log).Payload).To(Equal("world"))
+		})
+
+		It("saves the batch right away once it is large enough", func() {
+			_, err := delegate.BuildStepDelegate(planID).Stdout().Write([]byte(strings.Repeat("x", 64*1024)))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeBuild.SaveEventsCallCount()).To(Equal(1))
+		})
+
+		It("saves the buffered logs before any other event", func() {
+			stepDelegate := delegate.BuildStepDelegate(planID)
+
+			_, err := stepDelegate.Stdout().Write([]byte("hello"))
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeBuild.SaveEventsStub = func([]atc.Event) error {
+				Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+				return nil
+			}
+
+			stepDelegate.Errored(logger, "nope", atc.ErrorKindUnknown)
+
+			Expect(fakeBuild.SaveEventsCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+		})
+
+		It("saves the buffered logs before finishing the build", func() {
+			_, err := delegate.BuildStepDelegate(planID).Stdout().Write([]byte("hello"))
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeBuild.FinishStub = func(db.BuildStatus) error {
+				Expect(fakeBuild.SaveEventsCallCount()).To(Equal(1))
+				return nil
+			}
+
+			delegate.Finish(logger, nil, true)
+
+			Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+		})
+
+		It("saves the buffered logs when released", func() {
+			_, err := delegate.BuildStepDelegate(planID).Stdout().Write([]byte("hello"))
+			Expect(err).NotTo(HaveOccurred())
+
+			delegate.Release(logger)
+
+			Expect(fakeBuild.SaveEventsCallCount()).To(Equal(1))
+		})
+
+		Context("when saving the batch fails", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveEventsReturnsOnCall(0, errors.New("nope"))
+			})
+
+			It("keeps the logs to save with the next batch", func() {
+				_, err := delegate.BuildStepDelegate(planID).Stdout().Write([]byte("hello"))
+				Expect(err).NotTo(HaveOccurred())
+
+				delegate.Release(logger)
+				delegate.Release(logger)
+
+				Expect(fakeBuild.SaveEventsCallCount()).To(Equal(2))
+				Expect(fakeBuild.SaveEventsArgsForCall(1)).To(HaveLen(1))
+			})
+		})
+
+		Context("when saving the batch keeps failing", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveEventsReturns(errors.New("nope"))
+			})
+
+			It("drops logs once too many are buffered", func() {
+				stdout := delegate.BuildStepDelegate(planID).Stdout()
+
+				for i := 0; i < 16; i++ {
+					_, err := stdout.Write([]byte(strings.Repeat("x", 64*1024)))
+					Expect(err).To(MatchError("nope"))
+				}
+
+				_, err := stdout.Write([]byte("dropped"))
+				Expect(err).To(MatchError("nope"))
+
+				fakeBuild.SaveEventsReturns(nil)
+				delegate.Release(logger)
+
+				events := fakeBuild.SaveEventsArgsForCall(fakeBuild.SaveEventsCallCount() - 1)
+				Expect(events).To(HaveLen(16))
+				for _, ev := range events {
+					Expect(ev.(event.Log).Payload).ToNot(Equal("dropped"))
+				}
+			})
+		})
+	})
 })
@@ -1,12 +1,19 @@
 package engine_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
 
 	. "github.com/onsi/ginkgo"
@@ -36,6 +43,8 @@ var _ = Describe("ExecEngine", func() {
 			fakeFactory,
 			fakeDelegateFactory,
 			"http://example.com",
+			0,
+			0,
 		)
 	})
 
@@ -80,6 +89,7 @@ var _ = Describe("ExecEngine", func() {
 			}
 
 			fakeDelegate = new(enginefakes.FakeBuildDelegate)
+			fakeDelegate.RetryDelegateReturns(new(execfakes.FakeRetryDelegate))
 			fakeDelegateFactory.DelegateReturns(fakeDelegate)
 
 			inputStep = new(execfakes.FakeStep)
@@ -141,7 +151,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.PutArgsForCall(0)
+					logger, plan, build, _, stepMetadata, containerMetadata, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(putPlan))
@@ -157,7 +167,7 @@ var _ = Describe("ExecEngine", func() {
 						BuildName:    "42",
 					}))
 
-					logger, plan, build, stepMetadata, containerMetadata, _ = fakeFactory.PutArgsForCall(1)
+					logger, plan, build, _, stepMetadata, containerMetadata, _ = fakeFactory.PutArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(otherPutPlan))
@@ -175,6 +185,76 @@ var _ = Describe("ExecEngine", func() {
 			})
 		})
 
+		Context("with a put", func() {
+			var putPlan atc.Plan
+
+			BeforeEach(func() {
+				putPlan = planFactory.NewPlan(atc.PutPlan{
+					Name:     "some-put",
+					Resource: "some-output-resource",
+					Type:     "put",
+					Source:   atc.Source{"some": "source"},
+				})
+
+				outputStep.RunStub = func(ctx context.Context, state exec.RunState) error {
+					state.StoreResult(putPlan.ID, exec.VersionInfo{Version: atc.Version{"some": "version"}})
+					return nil
+				}
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				build, err = execEngine.CreateBuild(logger, dbBuild, putPlan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+			})
+
+			It("records the put's outcome", func() {
+				Expect(outputStep.RunCallCount()).To(Equal(1))
+
+				Expect(dbBuild.SaveStepStateCallCount()).To(Equal(1))
+				planID, state := dbBuild.SaveStepStateArgsForCall(0)
+				Expect(planID).To(Equal(putPlan.ID))
+				Expect(state).To(MatchJSON(`{"succeeded":true,"result":{"Version":{"some":"version"},"Metadata":null}}`))
+			})
+
+			Context("when the put completed before the build was resumed", func() {
+				BeforeEach(func() {
+					dbBuild.StepStatesReturns(map[atc.PlanID]json.RawMessage{
+						putPlan.ID: json.RawMessage(`{"succeeded":true}`),
+					}, nil)
+				})
+
+				It("does not run it again", func() {
+					Expect(outputStep.RunCallCount()).To(BeZero())
+					Expect(dbBuild.SaveStepStateCallCount()).To(BeZero())
+				})
+
+				It("finishes the build with the recorded outcome", func() {
+					Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+					_, err, succeeded := fakeDelegate.FinishArgsForCall(0)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(succeeded).To(BeTrue())
+				})
+			})
+
+			Context("when the step states cannot be loaded", func() {
+				BeforeEach(func() {
+					dbBuild.StepStatesReturns(nil, errors.New("nope"))
+				})
+
+				It("finishes the build as errored without running it", func() {
+					Expect(outputStep.RunCallCount()).To(BeZero())
+
+					Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+					_, err, succeeded := fakeDelegate.FinishArgsForCall(0)
+					Expect(err).To(MatchError("nope"))
+					Expect(succeeded).To(BeFalse())
+				})
+			})
+		})
+
 		Context("with a retry plan", func() {
 			var (
 				getPlan       atc.Plan
@@ -234,7 +314,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the first get correctly", func() {
-				logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+				logger, plan, build, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan := getPlan
@@ -255,7 +335,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the second get correctly", func() {
-				logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(1)
+				logger, plan, build, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan := getPlan
@@ -280,7 +360,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				logger, plan, build, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+				logger, plan, build, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan := taskPlan
@@ -298,7 +378,7 @@ var _ = Describe("ExecEngine", func() {
 					Attempt:      "2.1",
 				}))
 
-				logger, plan, build, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
+				logger, plan, build, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan = taskPlan
@@ -367,15 +447,15 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				_, _, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
+				_, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(2)
+				_, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(2)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(3)
+				_, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(3)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(4)
+				_, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(4)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
 			})
 		})
@@ -404,7 +484,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					logger, plan, dBuild, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+					logger, plan, dBuild, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(dBuild).To(Equal(dbBuild))
 					Expect(plan).To(Equal(expectedPlan))
@@ -453,7 +533,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-					logger, plan, build, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+					logger, plan, build, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(expectedPlan))
@@ -510,7 +590,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(1))
 
-					logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.PutArgsForCall(0)
+					logger, plan, build, _, stepMetadata, containerMetadata, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(putPlan))
@@ -535,7 +615,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+					logger, plan, build, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(dependentGetPlan))
@@ -602,7 +682,7 @@ var _ = Describe("ExecEngine", func() {
 
 				foundBuild.Resume(logger)
 				Expect(fakeFactory.GetCallCount()).To(Equal(1))
-				logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+				logger, plan, build, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				Expect(plan.ID).To(Equal(atc.PlanID("47")))
@@ -646,4 +726,562 @@ var _ = Describe("ExecEngine", func() {
 			})
 		})
 	})
+
+	Describe("Replay", func() {
+		var (
+			dbBuild     *dbfakes.FakeBuild
+			planFactory atc.PlanFactory
+
+			getPlan        atc.Plan
+			dependentPlan  atc.Plan
+			taskPlan       atc.Plan
+			replayedPlanID atc.PlanID
+
+			getStep    *execfakes.FakeStep
+			replayStep *execfakes.FakeStep
+
+			replayErr error
+		)
+
+		BeforeEach(func() {
+			planFactory = atc.NewPlanFactory(123)
+
+			dbBuild = new(dbfakes.FakeBuild)
+			dbBuild.IDReturns(expectedBuildID)
+
+			getPlan = planFactory.NewPlan(atc.GetPlan{
+				Name:     "some-input",
+				Resource: "some-input-resource",
+				Type:     "some-type",
+				Version:  &atc.Version{"some": "version"},
+			})
+
+			dependentPlan = planFactory.NewPlan(atc.GetPlan{
+				Name:        "some-output",
+				Resource:    "some-output-resource",
+				Type:        "some-type",
+				VersionFrom: &getPlan.ID,
+			})
+
+			taskPlan = planFactory.NewPlan(atc.TaskPlan{
+				Name:       "some-task",
+				ConfigPath: "some-input/build.yml",
+			})
+
+			replayedPlanID = taskPlan.ID
+
+			fakeDelegateFactory.DelegateReturns(new(enginefakes.FakeBuildDelegate))
+
+			getStep = new(execfakes.FakeStep)
+			fakeFactory.GetReturns(getStep)
+
+			replayStep = new(execfakes.FakeStep)
+			fakeFactory.ReplayTaskReturns(replayStep)
+		})
+
+		JustBeforeEach(func() {
+			build, err := execEngine.CreateBuild(logger, dbBuild, planFactory.NewPlan(atc.DoPlan{
+				getPlan,
+				planFactory.NewPlan(atc.TryPlan{Step: dependentPlan}),
+				taskPlan,
+			}))
+			Expect(err).NotTo(HaveOccurred())
+
+			replayErr = build.Replay(logger, replayedPlanID)
+		})
+
+		It("returns without waiting for the replay", func() {
+			Expect(replayErr).NotTo(HaveOccurred())
+		})
+
+		It("fetches the build's inputs again, without fetching versions it created", func() {
+			Eventually(getStep.RunCallCount).Should(Equal(1))
+
+			Expect(fakeFactory.GetCallCount()).To(Equal(1))
+			_, plan, _, _, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+			Expect(plan).To(Equal(getPlan))
+			Expect(containerMetadata.Type).To(Equal(db.ContainerTypeGet))
+		})
+
+		It("replays the task with the fetched inputs, within the deadline", func() {
+			Eventually(replayStep.RunCallCount).Should(Equal(1))
+
+			Expect(fakeFactory.ReplayTaskCallCount()).To(Equal(1))
+			_, plan, _, containerMetadata, _ := fakeFactory.ReplayTaskArgsForCall(0)
+			Expect(plan).To(Equal(taskPlan))
+			Expect(containerMetadata.Type).To(Equal(db.ContainerTypeTask))
+			Expect(containerMetadata.StepName).To(Equal("some-task"))
+
+			_, getState := getStep.RunArgsForCall(0)
+			replayCtx, replayState := replayStep.RunArgsForCall(0)
+			Expect(replayState).To(BeIdenticalTo(getState))
+
+			deadline, ok := replayCtx.Deadline()
+			Expect(ok).To(BeTrue())
+			Expect(deadline).To(BeTemporally("~", time.Now().Add(engine.ReplayTimeout), time.Minute))
+		})
+
+		Context("when fetching an input fails", func() {
+			BeforeEach(func() {
+				getStep.RunReturns(errors.New("nope"))
+			})
+
+			It("replays the task anyway", func() {
+				Eventually(replayStep.RunCallCount).Should(Equal(1))
+			})
+		})
+
+		Context("when the step is not a task", func() {
+			BeforeEach(func() {
+				replayedPlanID = getPlan.ID
+			})
+
+			It("returns ErrStepNotReplayable", func() {
+				Expect(replayErr).To(Equal(engine.ErrStepNotReplayable))
+				Consistently(fakeFactory.ReplayTaskCallCount).Should(BeZero())
+			})
+		})
+
+		Context("when the step is not in the plan", func() {
+			BeforeEach(func() {
+				replayedPlanID = "bogus"
+			})
+
+			It("returns ErrStepNotFound", func() {
+				Expect(replayErr).To(Equal(engine.ErrStepNotFound))
+			})
+		})
+	})
+	Describe("rerunning a build", func() {
+		var (
+			dbBuild     *dbfakes.FakeBuild
+			planFactory atc.PlanFactory
+
+			getPlan        atc.Plan
+			firstTaskPlan  atc.Plan
+			secondTaskPlan atc.Plan
+			plan           atc.Plan
+
+			taskStep      *execfakes.FakeStep
+			rerunTaskStep *execfakes.FakeStep
+		)
+
+		BeforeEach(func() {
+			planFactory = atc.NewPlanFactory(123)
+
+			dbBuild = new(dbfakes.FakeBuild)
+			dbBuild.IDReturns(expectedBuildID)
+
+			getPlan = planFactory.NewPlan(atc.GetPlan{
+				Name:     "some-input",
+				Resource: "some-input-resource",
+				Type:     "some-type",
+				Version:  &atc.Version{"some": "version"},
+			})
+
+			firstTaskPlan = planFactory.NewPlan(atc.TaskPlan{
+				Name:       "some-task",
+				ConfigPath: "some-input/build.yml",
+			})
+
+			secondTaskPlan = planFactory.NewPlan(atc.TaskPlan{
+				Name:       "some-flaky-task",
+				ConfigPath: "some-input/build.yml",
+			})
+
+			plan = planFactory.NewPlan(atc.DoPlan{
+				getPlan,
+				firstTaskPlan,
+				secondTaskPlan,
+			})
+
+			fakeDelegate := new(enginefakes.FakeBuildDelegate)
+			fakeDelegateFactory.DelegateReturns(fakeDelegate)
+
+			getStep := new(execfakes.FakeStep)
+			getStep.SucceededReturns(true)
+			fakeFactory.GetReturns(getStep)
+
+			taskStep = new(execfakes.FakeStep)
+			taskStep.SucceededReturns(true)
+			fakeFactory.TaskReturns(taskStep)
+
+			rerunTaskStep = new(execfakes.FakeStep)
+			rerunTaskStep.SucceededReturns(true)
+			fakeFactory.RerunTaskReturns(rerunTaskStep)
+		})
+
+		Describe("PlanRerun", func() {
+			var (
+				rerun    engine.Rerun
+				rerunErr error
+			)
+
+			JustBeforeEach(func() {
+				build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+				Expect(err).NotTo(HaveOccurred())
+
+				rerun, rerunErr = build.PlanRerun(logger, secondTaskPlan.ID)
+			})
+
+			It("returns the build's plan and the steps preceding the one to rerun from", func() {
+				Expect(rerunErr).NotTo(HaveOccurred())
+				Expect(rerun.Plan).To(Equal(plan))
+				Expect(rerun.Reused).To(Equal([]atc.PlanID{plan.ID, getPlan.ID, firstTaskPlan.ID}))
+			})
+
+			Context("when the step is not in the plan", func() {
+				BeforeEach(func() {
+					plan = planFactory.NewPlan(atc.DoPlan{getPlan, firstTaskPlan})
+				})
+
+				It("returns ErrStepNotFound", func() {
+					Expect(rerunErr).To(Equal(engine.ErrStepNotFound))
+				})
+			})
+		})
+
+		Context("when the build reruns another from a step", func() {
+			BeforeEach(func() {
+				dbBuild.RerunOfReturns(1000)
+				dbBuild.RerunFromReturns(secondTaskPlan.ID)
+			})
+
+			JustBeforeEach(func() {
+				build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+			})
+
+			It("fetches the inputs again", func() {
+				Expect(fakeFactory.GetCallCount()).To(Equal(1))
+			})
+
+			It("reuses the preceding tasks", func() {
+				Expect(fakeFactory.RerunTaskCallCount()).To(Equal(1))
+				_, rerunPlan, build, _, _, _ := fakeFactory.RerunTaskArgsForCall(0)
+				Expect(rerunPlan).To(Equal(firstTaskPlan))
+				Expect(build).To(Equal(dbBuild))
+
+				Expect(rerunTaskStep.RunCallCount()).To(Equal(1))
+			})
+
+			It("runs the step it is rerun from, and the ones after it", func() {
+				Expect(fakeFactory.TaskCallCount()).To(Equal(1))
+				_, taskPlan, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				Expect(taskPlan).To(Equal(secondTaskPlan))
+
+				Expect(taskStep.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the build is not a rerun", func() {
+			JustBeforeEach(func() {
+				build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+			})
+
+			It("runs every task", func() {
+				Expect(fakeFactory.TaskCallCount()).To(Equal(2))
+				Expect(fakeFactory.RerunTaskCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("Drain", func() {
+		var (
+			dbBuild      *dbfakes.FakeBuild
+			fakeDelegate *enginefakes.FakeBuildDelegate
+
+			taskStep *execfakes.FakeStep
+			putStep  *execfakes.FakeStep
+
+			taskStarted chan struct{}
+			finishTask  chan struct{}
+			resumed     chan struct{}
+		)
+
+		BeforeEach(func() {
+			planFactory := atc.NewPlanFactory(123)
+
+			dbBuild = new(dbfakes.FakeBuild)
+			dbBuild.IDReturns(expectedBuildID)
+
+			fakeDelegate = new(enginefakes.FakeBuildDelegate)
+			fakeDelegateFactory.DelegateReturns(fakeDelegate)
+
+			taskStarted = make(chan struct{})
+			finishTask = make(chan struct{})
+
+			taskStep = new(execfakes.FakeStep)
+			taskStep.SucceededReturns(true)
+			taskStep.RunStub = func(context.Context, exec.RunState) error {
+				close(taskStarted)
+				<-finishTask
+				return nil
+			}
+			fakeFactory.TaskReturns(taskStep)
+
+			putStep = new(execfakes.FakeStep)
+			putStep.SucceededReturns(true)
+			fakeFactory.PutReturns(putStep)
+
+			plan := planFactory.NewPlan(atc.DoPlan{
+				planFactory.NewPlan(atc.TaskPlan{
+					Name:   "some-task",
+					Config: &atc.TaskConfig{},
+				}),
+				planFactory.NewPlan(atc.PutPlan{
+					Name:     "some-put",
+					Resource: "some-output-resource",
+					Type:     "put",
+				}),
+			})
+
+			build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+			Expect(err).NotTo(HaveOccurred())
+
+			resumed = make(chan struct{})
+			go func() {
+				defer close(resumed)
+				build.Resume(logger)
+			}()
+
+			Eventually(taskStarted).Should(BeClosed())
+		})
+
+		AfterEach(func() {
+			select {
+			case <-finishTask:
+			default:
+				close(finishTask)
+			}
+
+			Eventually(resumed).Should(BeClosed())
+		})
+
+		It("waits for running steps to finish without starting any more", func() {
+			reports := make(chan engine.DrainReport, 1)
+			go func() {
+				reports <- execEngine.Drain(context.Background())
+			}()
+
+			Consistently(reports).ShouldNot(Receive())
+
+			close(finishTask)
+
+			var report engine.DrainReport
+			Eventually(reports).Should(Receive(&report))
+			Expect(report.Drained).To(Equal([]int{expectedBuildID}))
+			Expect(report.Interrupted).To(BeEmpty())
+
+			Eventually(resumed).Should(BeClosed())
+			Expect(putStep.RunCallCount()).To(BeZero())
+		})
+
+		It("leaves the build unfinished, to be resumed elsewhere", func() {
+			go execEngine.Drain(context.Background())
+
+			Consistently(putStep.RunCallCount).Should(BeZero())
+			close(finishTask)
+
+			Eventually(resumed).Should(BeClosed())
+			Expect(fakeDelegate.FinishCallCount()).To(BeZero())
+			Expect(dbBuild.FinishCallCount()).To(BeZero())
+			Expect(fakeDelegate.ReleaseCallCount()).To(Equal(1))
+		})
+
+		Context("when the deadline passes before the running steps finish", func() {
+			It("reports the build as interrupted", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				report := execEngine.Drain(ctx)
+				Expect(report.Drained).To(BeEmpty())
+				Expect(report.Interrupted).To(Equal([]int{expectedBuildID}))
+			})
+		})
+	})
+
+	Context("with a retry budget", func() {
+		var (
+			fakeDelegate      *enginefakes.FakeBuildDelegate
+			fakeRetryDelegate *execfakes.FakeRetryDelegate
+
+			taskStep *execfakes.FakeStep
+		)
+
+		BeforeEach(func() {
+			execEngine = engine.NewExecEngine(
+				fakeFactory,
+				fakeDelegateFactory,
+				"http://example.com",
+				0,
+				2,
+			)
+
+			fakeRetryDelegate = new(execfakes.FakeRetryDelegate)
+
+			fakeDelegate = new(enginefakes.FakeBuildDelegate)
+			fakeDelegate.RetryDelegateReturns(fakeRetryDelegate)
+			fakeDelegateFactory.DelegateReturns(fakeDelegate)
+
+			taskStep = new(execfakes.FakeStep)
+			taskStep.SucceededReturns(false)
+			fakeFactory.TaskReturns(taskStep)
+		})
+
+		It("shares it between every retried step in the build", func() {
+			planFactory := atc.NewPlanFactory(123)
+
+			taskPlan := planFactory.NewPlan(atc.TaskPlan{
+				Name:   "some-task",
+				Config: &atc.TaskConfig{},
+			})
+
+			retryPlan := planFactory.NewPlan(atc.RetryPlan{taskPlan, taskPlan})
+
+			plan := planFactory.NewPlan(atc.RetryPlan{retryPlan, retryPlan, retryPlan})
+
+			dbBuild := new(dbfakes.FakeBuild)
+			dbBuild.IDReturns(expectedBuildID)
+
+			build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+			Expect(err).NotTo(HaveOccurred())
+
+			build.Resume(logger)
+
+			Expect(taskStep.RunCallCount()).To(Equal(3))
+			Expect(fakeRetryDelegate.RetryingCallCount()).To(Equal(2))
+			Expect(fakeRetryDelegate.RetryBudgetExhaustedCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("with a set_var step", func() {
+		var (
+			fakeDelegate       *enginefakes.FakeBuildDelegate
+			fakeSetVarDelegate *execfakes.FakeSetVarDelegate
+
+			setVarStep *execfakes.FakeStep
+			taskStep   *execfakes.FakeStep
+
+			setVarPlan atc.Plan
+		)
+
+		BeforeEach(func() {
+			fakeSetVarDelegate = new(execfakes.FakeSetVarDelegate)
+
+			fakeDelegate = new(enginefakes.FakeBuildDelegate)
+			fakeDelegate.SetVarDelegateReturns(fakeSetVarDelegate)
+			fakeDelegateFactory.DelegateReturns(fakeDelegate)
+
+			setVarStep = new(execfakes.FakeStep)
+			setVarStep.SucceededReturns(true)
+			fakeFactory.SetVarReturns(setVarStep)
+
+			taskStep = new(execfakes.FakeStep)
+			taskStep.SucceededReturns(true)
+			fakeFactory.TaskReturns(taskStep)
+
+			planFactory := atc.NewPlanFactory(123)
+
+			setVarPlan = planFactory.NewPlan(atc.SetVarPlan{
+				Name:  "some-var",
+				Value: "some-value",
+			})
+
+			plan := planFactory.NewPlan(atc.DoPlan{
+				setVarPlan,
+				planFactory.NewPlan(atc.TaskPlan{
+					Name:   "some-task",
+					Config: &atc.TaskConfig{},
+				}),
+			})
+
+			dbBuild := new(dbfakes.FakeBuild)
+			dbBuild.IDReturns(expectedBuildID)
+
+			build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+			Expect(err).NotTo(HaveOccurred())
+
+			build.Resume(logger)
+		})
+
+		It("constructs the step with its delegate", func() {
+			Expect(fakeFactory.SetVarCallCount()).To(Equal(1))
+
+			_, plan, _, delegate := fakeFactory.SetVarArgsForCall(0)
+			Expect(plan).To(Equal(setVarPlan))
+			Expect(delegate).To(Equal(fakeSetVarDelegate))
+
+			Expect(fakeDelegate.SetVarDelegateArgsForCall(0)).To(Equal(setVarPlan.ID))
+		})
+
+		It("gives later steps the variables of the build's run state", func() {
+			_, state := setVarStep.RunArgsForCall(0)
+
+			_, _, _, variables, _, _ := fakeFactory.TaskArgsForCall(0)
+			Expect(variables).To(BeIdenticalTo(state.Variables()))
+		})
+	})
+
+	Context("with a step annotated for display", func() {
+		var (
+			dbBuild  *dbfakes.FakeBuild
+			taskStep *execfakes.FakeStep
+
+			taskPlan atc.Plan
+		)
+
+		BeforeEach(func() {
+			fakeDelegateFactory.DelegateReturns(new(enginefakes.FakeBuildDelegate))
+
+			taskStep = new(execfakes.FakeStep)
+			taskStep.SucceededReturns(true)
+			fakeFactory.TaskReturns(taskStep)
+
+			planFactory := atc.NewPlanFactory(123)
+
+			taskPlan = planFactory.NewPlan(atc.TaskPlan{
+				Name:   "some-task",
+				Config: &atc.TaskConfig{},
+			})
+			taskPlan.Display = &atc.StepDisplay{
+				Group:     "some-group",
+				Collapsed: true,
+			}
+
+			dbBuild = new(dbfakes.FakeBuild)
+			dbBuild.IDReturns(expectedBuildID)
+			dbBuild.SaveEventStub = func(atc.Event) error {
+				Expect(taskStep.RunCallCount()).To(BeZero())
+				return nil
+			}
+
+			build, err := execEngine.CreateBuild(logger, dbBuild, taskPlan)
+			Expect(err).NotTo(HaveOccurred())
+
+			build.Resume(logger)
+		})
+
+		It("saves the display annotation as the step starts", func() {
+			Expect(dbBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(dbBuild.SaveEventArgsForCall(0)).To(Equal(event.StepDisplay{
+				Origin: event.Origin{
+					ID: event.OriginID(taskPlan.ID),
+				},
+				Display: atc.StepDisplay{
+					Group:     "some-group",
+					Collapsed: true,
+				},
+			}))
+		})
+
+		It("runs the step", func() {
+			Expect(taskStep.RunCallCount()).To(Equal(1))
+		})
+	})
 })
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"errors"
 	"io"
 
@@ -26,13 +27,20 @@ func (execV1DummyEngine) CreateBuild(logger lager.Logger, build db.Build, plan a
 }
 
 func (execV1DummyEngine) LookupBuild(logger lager.Logger, build db.Build) (Build, error) {
-	return execV1DummyBuild{}, nil
+	return execV1DummyBuild{
+		buildEvents: buildEvents{build},
+	}, nil
 }
 
 func (execV1DummyEngine) ReleaseAll(lager.Logger) {
 }
 
+func (execV1DummyEngine) Drain(context.Context) DrainReport {
+	return DrainReport{}
+}
+
 type execV1DummyBuild struct {
+	buildEvents
 }
 
 func (execV1DummyBuild) Metadata() string {
@@ -46,7 +54,7 @@ func (execV1DummyBuild) PublicPlan(lager.Logger) (atc.PublicBuildPlan, error) {
 	}, nil
 }
 
-func (execV1DummyBuild) Abort(lager.Logger) error {
+func (execV1DummyBuild) Abort(lager.Logger, db.BuildAbortCause) error {
 	return nil
 }
 
@@ -56,5 +64,18 @@ func (execV1DummyBuild) Resume(logger lager.Logger) {
 func (execV1DummyBuild) ReceiveInput(logger lager.Logger, id atc.PlanID, input io.ReadCloser) {
 }
 
-func (execV1DummyBuild) SendOutput(logger lager.Logger, id atc.PlanID, output io.Writer) {
+func (execV1DummyBuild) SendOutput(logger lager.Logger, id atc.PlanID, output io.Writer) error {
+	return nil
+}
+
+func (execV1DummyBuild) Replay(logger lager.Logger, id atc.PlanID) error {
+	return errors.New("dummy engine does not support replaying steps")
+}
+
+func (execV1DummyBuild) PlanRerun(logger lager.Logger, id atc.PlanID) (Rerun, error) {
+	return Rerun{}, errors.New("dummy engine does not support rerunning builds")
+}
+
+func (execV1DummyBuild) PlanGraph(logger lager.Logger) (atc.PlanGraph, error) {
+	return atc.PlanGraph{}, errors.New("dummy engine does not support plan graphs")
 }
@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// NoopEngineName is the name of the engine constructed by NewNoopEngine.
+const NoopEngineName = "noop"
+
+type noopEngine struct{}
+
+// NewNoopEngine constructs an engine which succeeds builds without running
+// any of their steps, recording the plan it was given as the build's
+// metadata. It allows the scheduler and database to be load tested, and
+// generated plans to be inspected, without any workers.
+func NewNoopEngine() Engine {
+	return noopEngine{}
+}
+
+func (noopEngine) Name() string {
+	return NoopEngineName
+}
+
+func (noopEngine) CreateBuild(logger lager.Logger, build db.Build, plan atc.Plan) (Build, error) {
+	return &noopBuild{
+		buildEvents: buildEvents{build},
+
+		dbBuild: build,
+		metadata: execMetadata{
+			Plan: plan,
+		},
+	}, nil
+}
+
+func (noopEngine) LookupBuild(logger lager.Logger, build db.Build) (Build, error) {
+	var metadata execMetadata
+	err := json.Unmarshal([]byte(build.EngineMetadata()), &metadata)
+	if err != nil {
+		logger.Error("invalid-metadata", err)
+		return nil, err
+	}
+
+	return &noopBuild{
+		buildEvents: buildEvents{build},
+
+		dbBuild:  build,
+		metadata: metadata,
+	}, nil
+}
+
+func (noopEngine) ReleaseAll(lager.Logger) {
+}
+
+func (noopEngine) Drain(context.Context) DrainReport {
+	return DrainReport{}
+}
+
+type noopBuild struct {
+	buildEvents
+
+	dbBuild  db.Build
+	metadata execMetadata
+}
+
+func (build *noopBuild) Metadata() string {
+	payload, err := json.Marshal(build.metadata)
+	if err != nil {
+		panic("failed to marshal build metadata: " + err.Error())
+	}
+
+	return string(payload)
+}
+
+func (build *noopBuild) Abort(lager.Logger, db.BuildAbortCause) error {
+	return nil
+}
+
+func (build *noopBuild) Resume(logger lager.Logger) {
+	logger.Info("succeeding-without-running-plan")
+
+	err := build.dbBuild.Finish(db.BuildStatusSucceeded)
+	if err != nil {
+		logger.Error("failed-to-finish-build", err)
+	}
+}
+
+func (build *noopBuild) ReceiveInput(logger lager.Logger, id atc.PlanID, input io.ReadCloser) {
+	input.Close()
+}
+
+func (build *noopBuild) SendOutput(logger lager.Logger, id atc.PlanID, output io.Writer) error {
+	return nil
+}
+
+func (build *noopBuild) Replay(logger lager.Logger, id atc.PlanID) error {
+	return errors.New("noop engine does not run steps to replay")
+}
+
+func (build *noopBuild) PlanRerun(logger lager.Logger, id atc.PlanID) (Rerun, error) {
+	return Rerun{}, errors.New("noop engine does not run steps to rerun")
+}
+
+func (build *noopBuild) PlanGraph(logger lager.Logger) (atc.PlanGraph, error) {
+	return NewPlanGraph(build.metadata.Plan), nil
+}
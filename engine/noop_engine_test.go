@@ -0,0 +1,116 @@
+package engine_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/engine"
+)
+
+var _ = Describe("NoopEngine", func() {
+	var (
+		logger lager.Logger
+
+		dbBuild *dbfakes.FakeBuild
+		plan    atc.Plan
+
+		noopEngine engine.Engine
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		dbBuild = new(dbfakes.FakeBuild)
+		dbBuild.IDReturns(128)
+
+		plan = atc.NewPlanFactory(123).NewPlan(atc.TaskPlan{
+			Name: "some-task",
+		})
+
+		noopEngine = engine.NewNoopEngine()
+	})
+
+	It("is named noop", func() {
+		Expect(noopEngine.Name()).To(Equal("noop"))
+	})
+
+	Describe("CreateBuild", func() {
+		var build engine.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = noopEngine.CreateBuild(logger, dbBuild, plan)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("records the plan in the build's metadata", func() {
+			var metadata struct {
+				Plan atc.Plan
+			}
+
+			Expect(json.Unmarshal([]byte(build.Metadata()), &metadata)).To(Succeed())
+			Expect(metadata.Plan).To(Equal(plan))
+		})
+
+		Describe("Resume", func() {
+			It("succeeds the build without running anything", func() {
+				build.Resume(logger)
+
+				Expect(dbBuild.FinishCallCount()).To(Equal(1))
+				Expect(dbBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
+			})
+
+			Context("when finishing the build fails", func() {
+				BeforeEach(func() {
+					dbBuild.FinishReturns(errors.New("nope"))
+				})
+
+				It("does not panic", func() {
+					Expect(func() { build.Resume(logger) }).ToNot(Panic())
+				})
+			})
+		})
+
+		Describe("Abort", func() {
+			It("succeeds", func() {
+				Expect(build.Abort(logger, db.BuildAbortCause{})).To(Succeed())
+			})
+		})
+	})
+
+	Describe("LookupBuild", func() {
+		Context("when the build has valid metadata", func() {
+			BeforeEach(func() {
+				created, err := noopEngine.CreateBuild(logger, dbBuild, plan)
+				Expect(err).ToNot(HaveOccurred())
+
+				dbBuild.EngineMetadataReturns(created.Metadata())
+			})
+
+			It("returns a build with the same metadata", func() {
+				build, err := noopEngine.LookupBuild(logger, dbBuild)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(build.Metadata()).To(Equal(dbBuild.EngineMetadata()))
+			})
+		})
+
+		Context("when the build has invalid metadata", func() {
+			BeforeEach(func() {
+				dbBuild.EngineMetadataReturns("bogus")
+			})
+
+			It("returns an error", func() {
+				_, err := noopEngine.LookupBuild(logger, dbBuild)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type OrphanReaper interface {
+	Run() error
+}
+
+type orphanReaper struct {
+	logger lager.Logger
+	mark   func() ([]int, error)
+}
+
+// NewOrphanReaper constructs a task which marks started builds as orphaned
+// once no heartbeat has been recorded for them within the timeout, i.e. the
+// ATC tracking them has died. Orphaned builds are adopted by whichever ATC's
+// build tracker next resumes them.
+func NewOrphanReaper(
+	logger lager.Logger,
+	buildFactory db.BuildFactory,
+	timeout time.Duration,
+) OrphanReaper {
+	return &orphanReaper{
+		logger: logger,
+		mark: func() ([]int, error) {
+			return buildFactory.MarkOrphanedBuilds(timeout)
+		},
+	}
+}
+
+// NewLockOrphanReaper constructs a task which marks started builds as
+// orphaned once no ATC holds their tracking lock, rather than once their
+// heartbeats lapse, so that ATCs need not record heartbeats at all. Builds
+// started within the grace period are left to their ATC to lock.
+func NewLockOrphanReaper(
+	logger lager.Logger,
+	buildFactory db.BuildFactory,
+	grace time.Duration,
+) OrphanReaper {
+	return &orphanReaper{
+		logger: logger,
+		mark: func() ([]int, error) {
+			return buildFactory.MarkUntrackedBuilds(grace)
+		},
+	}
+}
+
+func (reaper *orphanReaper) Run() error {
+	orphaned, err := reaper.mark()
+	if err != nil {
+		reaper.logger.Error("failed-to-mark-orphaned-builds", err)
+		return err
+	}
+
+	for _, buildID := range orphaned {
+		reaper.logger.Info("marked-build-as-orphaned", lager.Data{"build": buildID})
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package engine_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/engine"
+)
+
+var _ = Describe("OrphanReaper", func() {
+	var (
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		logger           *lagertest.TestLogger
+
+		reaper OrphanReaper
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		logger = lagertest.NewTestLogger("test")
+
+		reaper = NewOrphanReaper(logger, fakeBuildFactory, time.Minute)
+	})
+
+	JustBeforeEach(func() {
+		runErr = reaper.Run()
+	})
+
+	Context("when builds' heartbeats have lapsed", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.MarkOrphanedBuildsReturns([]int{1, 2}, nil)
+		})
+
+		It("marks them as orphaned after the timeout", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			Expect(fakeBuildFactory.MarkOrphanedBuildsCallCount()).To(Equal(1))
+			Expect(fakeBuildFactory.MarkOrphanedBuildsArgsForCall(0)).To(Equal(time.Minute))
+		})
+
+		It("logs each orphaned build", func() {
+			Expect(logger.LogMessages()).To(Equal([]string{
+				"test.marked-build-as-orphaned",
+				"test.marked-build-as-orphaned",
+			}))
+		})
+	})
+
+	Context("when marking builds as orphaned fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeBuildFactory.MarkOrphanedBuildsReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
+
+var _ = Describe("LockOrphanReaper", func() {
+	var (
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		logger           *lagertest.TestLogger
+
+		reaper OrphanReaper
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		logger = lagertest.NewTestLogger("test")
+
+		reaper = NewLockOrphanReaper(logger, fakeBuildFactory, time.Minute)
+	})
+
+	JustBeforeEach(func() {
+		runErr = reaper.Run()
+	})
+
+	Context("when builds' tracking locks are not held", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.MarkUntrackedBuildsReturns([]int{1, 2}, nil)
+		})
+
+		It("marks them as orphaned after the grace period", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			Expect(fakeBuildFactory.MarkUntrackedBuildsCallCount()).To(Equal(1))
+			Expect(fakeBuildFactory.MarkUntrackedBuildsArgsForCall(0)).To(Equal(time.Minute))
+			Expect(fakeBuildFactory.MarkOrphanedBuildsCallCount()).To(BeZero())
+		})
+
+		It("logs each orphaned build", func() {
+			Expect(logger.LogMessages()).To(Equal([]string{
+				"test.marked-build-as-orphaned",
+				"test.marked-build-as-orphaned",
+			}))
+		})
+	})
+
+	Context("when marking builds as orphaned fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeBuildFactory.MarkUntrackedBuildsReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
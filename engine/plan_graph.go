@@ -0,0 +1,195 @@
+package engine
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/concourse/atc"
+)
+
+// NewPlanGraph describes the plan as a graph of its steps, with the plans
+// composing them, their hooks, retries and the dependencies between them.
+func NewPlanGraph(plan atc.Plan) atc.PlanGraph {
+	graph := atc.PlanGraph{
+		Nodes: []atc.PlanGraphNode{},
+		Edges: []atc.PlanGraphEdge{},
+	}
+
+	addPlanToGraph(&graph, plan)
+
+	return graph
+}
+
+func addPlanToGraph(graph *atc.PlanGraph, plan atc.Plan) {
+	node := atc.PlanGraphNode{
+		ID:      plan.ID,
+		Display: plan.Display,
+	}
+
+	edge := func(from atc.PlanID, to atc.PlanID, typ atc.PlanGraphEdgeType) {
+		graph.Edges = append(graph.Edges, atc.PlanGraphEdge{
+			From: from,
+			To:   to,
+			Type: typ,
+		})
+	}
+
+	var children []atc.Plan
+
+	hook := func(step atc.Plan, next atc.Plan, typ atc.PlanGraphEdgeType) {
+		children = append(children, step, next)
+		edge(plan.ID, step.ID, atc.PlanGraphEdgeStep)
+		edge(plan.ID, next.ID, atc.PlanGraphEdgeStep)
+		edge(step.ID, next.ID, typ)
+	}
+
+	switch {
+	case plan.Aggregate != nil:
+		node.Type = "aggregate"
+
+		for _, step := range *plan.Aggregate {
+			children = append(children, step)
+			edge(plan.ID, step.ID, atc.PlanGraphEdgeStep)
+		}
+
+	case plan.Do != nil:
+		node.Type = "do"
+
+		for i, step := range *plan.Do {
+			children = append(children, step)
+			edge(plan.ID, step.ID, atc.PlanGraphEdgeStep)
+
+			if i > 0 {
+				edge((*plan.Do)[i-1].ID, step.ID, atc.PlanGraphEdgeNext)
+			}
+		}
+
+	case plan.Retry != nil:
+		node.Type = "retry"
+
+		for _, attempt := range *plan.Retry {
+			children = append(children, attempt)
+			edge(plan.ID, attempt.ID, atc.PlanGraphEdgeAttempt)
+		}
+
+	case plan.OnSuccess != nil:
+		node.Type = "on_success"
+		hook(plan.OnSuccess.Step, plan.OnSuccess.Next, atc.PlanGraphEdgeOnSuccess)
+
+	case plan.OnFailure != nil:
+		node.Type = "on_failure"
+		hook(plan.OnFailure.Step, plan.OnFailure.Next, atc.PlanGraphEdgeOnFailure)
+
+	case plan.OnAbort != nil:
+		node.Type = "on_abort"
+		hook(plan.OnAbort.Step, plan.OnAbort.Next, atc.PlanGraphEdgeOnAbort)
+
+	case plan.Ensure != nil:
+		node.Type = "ensure"
+		hook(plan.Ensure.Step, plan.Ensure.Next, atc.PlanGraphEdgeEnsure)
+
+	case plan.Try != nil:
+		node.Type = "try"
+		children = append(children, plan.Try.Step)
+		edge(plan.ID, plan.Try.Step.ID, atc.PlanGraphEdgeStep)
+
+	case plan.Timeout != nil:
+		node.Type = "timeout"
+		node.Name = plan.Timeout.Duration
+		children = append(children, plan.Timeout.Step)
+		edge(plan.ID, plan.Timeout.Step.ID, atc.PlanGraphEdgeStep)
+
+	case plan.Get != nil:
+		node.Type = "get"
+		node.Name = plan.Get.Name
+
+		if plan.Get.VersionFrom != nil {
+			edge(*plan.Get.VersionFrom, plan.ID, atc.PlanGraphEdgeVersionFrom)
+		}
+
+	case plan.Put != nil:
+		node.Type = "put"
+		node.Name = plan.Put.Name
+
+	case plan.Task != nil:
+		node.Type = "task"
+		node.Name = plan.Task.Name
+
+	case plan.WaitFor != nil:
+		node.Type = "wait_for"
+		node.Name = plan.WaitFor.Name
+
+	case plan.Promote != nil:
+		node.Type = "promote"
+		node.Name = plan.Promote.Name
+
+	case plan.SetVar != nil:
+		node.Type = "set_var"
+		node.Name = plan.SetVar.Name
+
+	case plan.SaveCache != nil:
+		node.Type = "save_cache"
+		node.Name = plan.SaveCache.Name
+
+	case plan.RestoreCache != nil:
+		node.Type = "restore_cache"
+		node.Name = plan.RestoreCache.Name
+
+	case plan.UserArtifact != nil:
+		node.Type = "user_artifact"
+		node.Name = plan.UserArtifact.Name
+
+	case plan.ArtifactOutput != nil:
+		node.Type = "artifact_output"
+		node.Name = plan.ArtifactOutput.Name
+
+	case plan.DependentGet != nil:
+		node.Type = "dependent_get"
+		node.Name = plan.DependentGet.Name
+	}
+
+	graph.Nodes = append(graph.Nodes, node)
+
+	for _, child := range children {
+		addPlanToGraph(graph, child)
+	}
+}
+
+// PlanGraphDOT renders the graph in the DOT language of Graphviz. Steps are
+// drawn as boxes and the plans composing them as ellipses, with hooks and
+// dependencies drawn dashed.
+func PlanGraphDOT(graph atc.PlanGraph) string {
+	buf := new(bytes.Buffer)
+
+	fmt.Fprintln(buf, "digraph plan {")
+
+	for _, node := range graph.Nodes {
+		label := node.Type
+		if node.Name != "" {
+			label += ": " + node.Name
+		}
+
+		shape := "box"
+		switch node.Type {
+		case "aggregate", "do", "retry", "on_success", "on_failure", "on_abort", "ensure", "try", "timeout":
+			shape = "ellipse"
+		}
+
+		fmt.Fprintf(buf, "  %q [label=%q shape=%s];\n", node.ID, label, shape)
+	}
+
+	for _, edge := range graph.Edges {
+		style := "solid"
+		switch edge.Type {
+		case atc.PlanGraphEdgeStep, atc.PlanGraphEdgeNext, atc.PlanGraphEdgeAttempt:
+		default:
+			style = "dashed"
+		}
+
+		fmt.Fprintf(buf, "  %q -> %q [label=%q style=%s];\n", edge.From, edge.To, edge.Type, style)
+	}
+
+	fmt.Fprintln(buf, "}")
+
+	return buf.String()
+}
@@ -0,0 +1,120 @@
+package engine_test
+
+import (
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/engine"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewPlanGraph", func() {
+	var planFactory atc.PlanFactory
+
+	BeforeEach(func() {
+		planFactory = atc.NewPlanFactory(123)
+	})
+
+	It("describes steps run in sequence", func() {
+		get := planFactory.NewPlan(atc.GetPlan{Name: "some-input"})
+		task := planFactory.NewPlan(atc.TaskPlan{Name: "some-task"})
+		do := planFactory.NewPlan(atc.DoPlan{get, task})
+
+		graph := NewPlanGraph(do)
+
+		Expect(graph.Nodes).To(Equal([]atc.PlanGraphNode{
+			{ID: do.ID, Type: "do"},
+			{ID: get.ID, Type: "get", Name: "some-input"},
+			{ID: task.ID, Type: "task", Name: "some-task"},
+		}))
+
+		Expect(graph.Edges).To(Equal([]atc.PlanGraphEdge{
+			{From: do.ID, To: get.ID, Type: atc.PlanGraphEdgeStep},
+			{From: do.ID, To: task.ID, Type: atc.PlanGraphEdgeStep},
+			{From: get.ID, To: task.ID, Type: atc.PlanGraphEdgeNext},
+		}))
+	})
+
+	It("describes hooks and retries", func() {
+		attempt1 := planFactory.NewPlan(atc.TaskPlan{Name: "some-task"})
+		attempt2 := planFactory.NewPlan(atc.TaskPlan{Name: "some-task"})
+		retry := planFactory.NewPlan(atc.RetryPlan{attempt1, attempt2})
+		cleanup := planFactory.NewPlan(atc.TaskPlan{Name: "some-cleanup"})
+		ensure := planFactory.NewPlan(atc.EnsurePlan{
+			Step: retry,
+			Next: cleanup,
+		})
+
+		graph := NewPlanGraph(ensure)
+
+		Expect(graph.Nodes).To(Equal([]atc.PlanGraphNode{
+			{ID: ensure.ID, Type: "ensure"},
+			{ID: retry.ID, Type: "retry"},
+			{ID: attempt1.ID, Type: "task", Name: "some-task"},
+			{ID: attempt2.ID, Type: "task", Name: "some-task"},
+			{ID: cleanup.ID, Type: "task", Name: "some-cleanup"},
+		}))
+
+		Expect(graph.Edges).To(Equal([]atc.PlanGraphEdge{
+			{From: ensure.ID, To: retry.ID, Type: atc.PlanGraphEdgeStep},
+			{From: ensure.ID, To: cleanup.ID, Type: atc.PlanGraphEdgeStep},
+			{From: retry.ID, To: cleanup.ID, Type: atc.PlanGraphEdgeEnsure},
+			{From: retry.ID, To: attempt1.ID, Type: atc.PlanGraphEdgeAttempt},
+			{From: retry.ID, To: attempt2.ID, Type: atc.PlanGraphEdgeAttempt},
+		}))
+	})
+
+	It("describes gets of the versions created by puts", func() {
+		put := planFactory.NewPlan(atc.PutPlan{Name: "some-output"})
+		get := planFactory.NewPlan(atc.GetPlan{Name: "some-output", VersionFrom: &put.ID})
+		do := planFactory.NewPlan(atc.DoPlan{put, get})
+
+		graph := NewPlanGraph(do)
+
+		Expect(graph.Edges).To(ContainElement(atc.PlanGraphEdge{
+			From: put.ID,
+			To:   get.ID,
+			Type: atc.PlanGraphEdgeVersionFrom,
+		}))
+	})
+
+	It("includes how steps are displayed", func() {
+		display := &atc.StepDisplay{Group: "integration"}
+
+		task := planFactory.NewPlan(atc.TaskPlan{Name: "some-task"})
+		task.Display = display
+
+		graph := NewPlanGraph(task)
+
+		Expect(graph.Nodes).To(Equal([]atc.PlanGraphNode{
+			{ID: task.ID, Type: "task", Name: "some-task", Display: display},
+		}))
+	})
+})
+
+var _ = Describe("PlanGraphDOT", func() {
+	It("renders steps as boxes, composing plans as ellipses, and hooks dashed", func() {
+		dot := PlanGraphDOT(atc.PlanGraph{
+			Nodes: []atc.PlanGraphNode{
+				{ID: "1", Type: "on_failure"},
+				{ID: "2", Type: "task", Name: "some-task"},
+				{ID: "3", Type: "put", Name: "some-alert"},
+			},
+			Edges: []atc.PlanGraphEdge{
+				{From: "1", To: "2", Type: atc.PlanGraphEdgeStep},
+				{From: "1", To: "3", Type: atc.PlanGraphEdgeStep},
+				{From: "2", To: "3", Type: atc.PlanGraphEdgeOnFailure},
+			},
+		})
+
+		Expect(dot).To(Equal(`digraph plan {
+  "1" [label="on_failure" shape=ellipse];
+  "2" [label="task: some-task" shape=box];
+  "3" [label="put: some-alert" shape=box];
+  "1" -> "2" [label="step" style=solid];
+  "1" -> "3" [label="step" style=solid];
+  "2" -> "3" [label="on_failure" style=dashed];
+}
+`))
+	})
+})
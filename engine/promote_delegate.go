@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/exec"
+)
+
+type promoteDelegate struct {
+	exec.BuildStepDelegate
+
+	build       db.Build
+	eventOrigin event.Origin
+}
+
+func NewPromoteDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.PromoteDelegate {
+	return &promoteDelegate{
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+
+		build: build,
+		eventOrigin: event.Origin{
+			ID: event.OriginID(planID),
+		},
+	}
+}
+
+func (d *promoteDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus, promotion exec.Promotion) {
+	err := d.build.SaveEvent(event.FinishPromote{
+		Origin:          d.eventOrigin,
+		ExitStatus:      int(exitStatus),
+		From:            promotion.From,
+		FromVersion:     promotion.FromVersion,
+		CreatedVersion:  promotion.Version,
+		CreatedMetadata: promotion.Metadata,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-promote-event", err)
+		return
+	}
+
+	logger.Info("finished", lager.Data{
+		"exit-status": exitStatus,
+		"promotion":   promotion,
+	})
+}
@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// PlanSupporter may be implemented by engines which can only run some plans,
+// e.g. an experimental engine lacking support for some steps. Builds whose
+// plans such an engine does not support are run by the default engine.
+type PlanSupporter interface {
+	SupportsPlan(atc.Plan) bool
+}
+
+// EngineRegistry chooses which engine runs each new build. Builds run by the
+// first engine unless their pipeline or team has been configured to use
+// another. A build's schema is the name of the engine which started it, and
+// the build is always resumed by that engine, so new engines can be added and
+// selected without disrupting builds that are already running.
+type EngineRegistry struct {
+	engines Engines
+
+	teamEngines     map[string]Engine
+	pipelineEngines map[string]Engine
+}
+
+func NewEngineRegistry(engines Engines) *EngineRegistry {
+	return &EngineRegistry{
+		engines: engines,
+
+		teamEngines:     map[string]Engine{},
+		pipelineEngines: map[string]Engine{},
+	}
+}
+
+// SetTeamEngine runs new builds of the team's pipelines and one-off builds
+// using the named engine, unless their pipeline is configured otherwise.
+func (registry *EngineRegistry) SetTeamEngine(teamName string, engineName string) error {
+	buildEngine, found := registry.engines.Lookup(engineName)
+	if !found {
+		return UnknownEngineError{engineName}
+	}
+
+	registry.teamEngines[teamName] = buildEngine
+
+	return nil
+}
+
+// SetPipelineEngine runs new builds of the pipeline using the named engine.
+func (registry *EngineRegistry) SetPipelineEngine(teamName string, pipelineName string, engineName string) error {
+	buildEngine, found := registry.engines.Lookup(engineName)
+	if !found {
+		return UnknownEngineError{engineName}
+	}
+
+	registry.pipelineEngines[pipelineKey(teamName, pipelineName)] = buildEngine
+
+	return nil
+}
+
+// Lookup returns the engine for a build's schema.
+func (registry *EngineRegistry) Lookup(schema string) (Engine, bool) {
+	return registry.engines.Lookup(schema)
+}
+
+// Engines returns every registered engine.
+func (registry *EngineRegistry) Engines() Engines {
+	return registry.engines
+}
+
+// EngineFor returns the engine to create the build with.
+func (registry *EngineRegistry) EngineFor(logger lager.Logger, build db.Build, plan atc.Plan) Engine {
+	defaultEngine := registry.engines[0]
+
+	buildEngine, configured := registry.pipelineEngines[pipelineKey(build.TeamName(), build.PipelineName())]
+	if !configured || build.PipelineName() == "" {
+		buildEngine, configured = registry.teamEngines[build.TeamName()]
+	}
+
+	if !configured {
+		return defaultEngine
+	}
+
+	if supporter, ok := buildEngine.(PlanSupporter); ok && !supporter.SupportsPlan(plan) {
+		logger.Info("engine-does-not-support-plan", lager.Data{
+			"engine":  buildEngine.Name(),
+			"default": defaultEngine.Name(),
+		})
+
+		return defaultEngine
+	}
+
+	return buildEngine
+}
+
+func pipelineKey(teamName string, pipelineName string) string {
+	return fmt.Sprintf("%s/%s", teamName, pipelineName)
+}
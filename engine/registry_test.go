@@ -0,0 +1,133 @@
+package engine_test
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/engine"
+	"github.com/concourse/atc/engine/enginefakes"
+)
+
+type fakePlanSupportingEngine struct {
+	*enginefakes.FakeEngine
+
+	supported bool
+}
+
+func (engine fakePlanSupportingEngine) SupportsPlan(atc.Plan) bool {
+	return engine.supported
+}
+
+var _ = Describe("EngineRegistry", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		defaultEngine      *enginefakes.FakeEngine
+		experimentalEngine fakePlanSupportingEngine
+		noopEngine         *enginefakes.FakeEngine
+
+		registry *EngineRegistry
+
+		dbBuild *dbfakes.FakeBuild
+		plan    atc.Plan
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		defaultEngine = new(enginefakes.FakeEngine)
+		defaultEngine.NameReturns("default")
+
+		experimentalEngine = fakePlanSupportingEngine{
+			FakeEngine: new(enginefakes.FakeEngine),
+			supported:  true,
+		}
+		experimentalEngine.NameReturns("experimental")
+
+		noopEngine = new(enginefakes.FakeEngine)
+		noopEngine.NameReturns("noop")
+
+		registry = NewEngineRegistry(Engines{defaultEngine, experimentalEngine, noopEngine})
+
+		dbBuild = new(dbfakes.FakeBuild)
+		dbBuild.TeamNameReturns("some-team")
+		dbBuild.PipelineNameReturns("some-pipeline")
+
+		plan = atc.NewPlanFactory(123).NewPlan(atc.TaskPlan{Name: "some-task"})
+	})
+
+	Describe("EngineFor", func() {
+		It("returns the first engine by default", func() {
+			Expect(registry.EngineFor(logger, dbBuild, plan)).To(Equal(defaultEngine))
+		})
+
+		Context("when the build's team is configured to use another engine", func() {
+			BeforeEach(func() {
+				Expect(registry.SetTeamEngine("some-team", "noop")).To(Succeed())
+			})
+
+			It("returns the team's engine", func() {
+				Expect(registry.EngineFor(logger, dbBuild, plan)).To(Equal(noopEngine))
+			})
+
+			Context("when the build's pipeline is configured to use another engine", func() {
+				BeforeEach(func() {
+					Expect(registry.SetPipelineEngine("some-team", "some-pipeline", "experimental")).To(Succeed())
+				})
+
+				It("returns the pipeline's engine", func() {
+					Expect(registry.EngineFor(logger, dbBuild, plan)).To(Equal(experimentalEngine))
+				})
+			})
+
+			Context("when another team's pipeline of the same name is configured", func() {
+				BeforeEach(func() {
+					Expect(registry.SetPipelineEngine("other-team", "some-pipeline", "experimental")).To(Succeed())
+				})
+
+				It("returns the team's engine", func() {
+					Expect(registry.EngineFor(logger, dbBuild, plan)).To(Equal(noopEngine))
+				})
+			})
+		})
+
+		Context("when the build's pipeline is configured to use an engine which does not support the plan", func() {
+			BeforeEach(func() {
+				experimentalEngine.supported = false
+				registry = NewEngineRegistry(Engines{defaultEngine, experimentalEngine, noopEngine})
+
+				Expect(registry.SetPipelineEngine("some-team", "some-pipeline", "experimental")).To(Succeed())
+			})
+
+			It("falls back to the default engine", func() {
+				Expect(registry.EngineFor(logger, dbBuild, plan)).To(Equal(defaultEngine))
+			})
+		})
+	})
+
+	Describe("SetTeamEngine", func() {
+		It("rejects unknown engines", func() {
+			Expect(registry.SetTeamEngine("some-team", "bogus")).To(Equal(UnknownEngineError{"bogus"}))
+		})
+	})
+
+	Describe("SetPipelineEngine", func() {
+		It("rejects unknown engines", func() {
+			Expect(registry.SetPipelineEngine("some-team", "some-pipeline", "bogus")).To(Equal(UnknownEngineError{"bogus"}))
+		})
+	})
+
+	Describe("Lookup", func() {
+		It("finds engines by the schema of the builds they started", func() {
+			found, ok := registry.Lookup("experimental")
+			Expect(ok).To(BeTrue())
+			Expect(found).To(Equal(experimentalEngine))
+
+			_, ok = registry.Lookup("bogus")
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"io"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/exec"
+)
+
+// replayDelegate is used by the steps run to replay a task. It discards their
+// output and saves no events, as the build being replayed has already
+// finished.
+type replayDelegate struct{}
+
+func (replayDelegate) ImageVersionDetermined(*db.UsedResourceCache) error { return nil }
+
+func (replayDelegate) Stdout() io.Writer { return ioutil.Discard }
+func (replayDelegate) Stderr() io.Writer { return ioutil.Discard }
+
+func (replayDelegate) Errored(lager.Logger, string, atc.ErrorKind) {}
+func (replayDelegate) TerminatedDuringCleanup(lager.Logger)        {}
+
+type replayGetDelegate struct {
+	replayDelegate
+}
+
+func (replayGetDelegate) Finished(lager.Logger, exec.ExitStatus, exec.VersionInfo) {}
+
+type replayTaskDelegate struct {
+	replayDelegate
+}
+
+func (replayTaskDelegate) Initializing(lager.Logger, atc.TaskConfig)        {}
+func (replayTaskDelegate) Starting(lager.Logger, atc.TaskConfig)            {}
+func (replayTaskDelegate) Finished(lager.Logger, exec.ExitStatus)           {}
+func (replayTaskDelegate) NoisyNeighbors(lager.Logger, exec.NoisyNeighbors) {}
+func (replayTaskDelegate) WaitingForWorker(lager.Logger, int)               {}
@@ -0,0 +1,46 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/exec"
+)
+
+type setVarDelegate struct {
+	exec.BuildStepDelegate
+
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func NewSetVarDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.SetVarDelegate {
+	return &setVarDelegate{
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+
+		build: build,
+		eventOrigin: event.Origin{
+			ID: event.OriginID(planID),
+		},
+		clock: clock,
+	}
+}
+
+func (d *setVarDelegate) VariableSet(logger lager.Logger, name string, value interface{}) {
+	err := d.build.SaveEvent(event.SetVar{
+		Time:   d.clock.Now().Unix(),
+		Origin: d.eventOrigin,
+		Name:   name,
+		Value:  value,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-set-var-event", err)
+		return
+	}
+
+	logger.Info("set", lager.Data{"name": name})
+}
@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/exec"
+)
+
+// displayedStep saves a StepDisplay event as the step starts, so that viewers
+// following the build's events can present the step as configured without
+// looking it up in the plan.
+type displayedStep struct {
+	step    exec.Step
+	build   db.Build
+	planID  atc.PlanID
+	display atc.StepDisplay
+}
+
+func (step displayedStep) Run(ctx context.Context, state exec.RunState) error {
+	err := step.build.SaveEvent(event.StepDisplay{
+		Origin: event.Origin{
+			ID: event.OriginID(step.planID),
+		},
+		Display: step.display,
+	})
+	if err != nil {
+		lagerctx.FromContext(ctx).Error("failed-to-save-step-display-event", err)
+	}
+
+	return step.step.Run(ctx, state)
+}
+
+func (step displayedStep) Succeeded() bool {
+	return step.step.Succeeded()
+}
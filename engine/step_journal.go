@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"encoding/json"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/exec"
+)
+
+// stepJournal keeps the outcome of a build's steps in the database, so that
+// they survive the ATC tracking the build.
+type stepJournal struct {
+	build db.Build
+
+	records  map[atc.PlanID]exec.StepRecord
+	recordsL sync.RWMutex
+}
+
+func newStepJournal(build db.Build) (exec.StepJournal, error) {
+	states, err := build.StepStates()
+	if err != nil {
+		return nil, err
+	}
+
+	records := map[atc.PlanID]exec.StepRecord{}
+	for planID, state := range states {
+		var record exec.StepRecord
+		err := json.Unmarshal(state, &record)
+		if err != nil {
+			return nil, err
+		}
+
+		records[planID] = record
+	}
+
+	return &stepJournal{
+		build:   build,
+		records: records,
+	}, nil
+}
+
+func (journal *stepJournal) Completed(planID atc.PlanID) (exec.StepRecord, bool) {
+	journal.recordsL.RLock()
+	defer journal.recordsL.RUnlock()
+
+	record, found := journal.records[planID]
+	return record, found
+}
+
+func (journal *stepJournal) Record(logger lager.Logger, planID atc.PlanID, record exec.StepRecord) error {
+	state, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	err = journal.build.SaveStepState(planID, state)
+	if err != nil {
+		return err
+	}
+
+	journal.recordsL.Lock()
+	journal.records[planID] = record
+	journal.recordsL.Unlock()
+
+	return nil
+}
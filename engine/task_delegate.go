@@ -69,3 +69,33 @@ func (d *taskDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus)
 
 	logger.Info("finished", lager.Data{"exit-status": exitStatus})
 }
+
+func (d *taskDelegate) NoisyNeighbors(logger lager.Logger, interference exec.NoisyNeighbors) {
+	err := d.build.SaveEvent(event.NoisyNeighbors{
+		Time:              time.Now().Unix(),
+		Origin:            d.eventOrigin,
+		Worker:            interference.WorkerName,
+		Duration:          int64(interference.Duration / time.Second),
+		BaselineDuration:  int64(interference.BaselineDuration / time.Second),
+		Neighbors:         interference.Neighbors,
+		BaselineNeighbors: interference.BaselineNeighbors,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-noisy-neighbors-event", err)
+		return
+	}
+}
+
+func (d *taskDelegate) WaitingForWorker(logger lager.Logger, polls int) {
+	err := d.build.SaveEvent(event.WaitingForWorker{
+		Time:   time.Now().Unix(),
+		Origin: d.eventOrigin,
+		Polls:  polls,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-waiting-for-worker-event", err)
+		return
+	}
+
+	logger.Debug("waiting-for-worker", lager.Data{"polls": polls})
+}
@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/exec"
+)
+
+type waitDelegate struct {
+	exec.BuildStepDelegate
+
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func NewWaitDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.WaitDelegate {
+	return &waitDelegate{
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+
+		build: build,
+		eventOrigin: event.Origin{
+			ID: event.OriginID(planID),
+		},
+		clock: clock,
+	}
+}
+
+func (d *waitDelegate) Waiting(logger lager.Logger, polls int, status string) {
+	err := d.build.SaveEvent(event.WaitForHeartbeat{
+		Time:   d.clock.Now().Unix(),
+		Origin: d.eventOrigin,
+		Polls:  polls,
+		Status: status,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-wait-for-heartbeat-event", err)
+		return
+	}
+
+	logger.Debug("waiting", lager.Data{
+		"polls":  polls,
+		"status": status,
+	})
+}
+
+func (d *waitDelegate) Finished(logger lager.Logger, polls int) {
+	err := d.build.SaveEvent(event.FinishWaitFor{
+		Time:   d.clock.Now().Unix(),
+		Origin: d.eventOrigin,
+		Polls:  polls,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-wait-for-event", err)
+		return
+	}
+
+	logger.Info("finished", lager.Data{
+		"polls": polls,
+	})
+}
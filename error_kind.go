@@ -0,0 +1,45 @@
+package atc
+
+// ErrorKind classifies why a build or one of its steps errored, so that retry
+// policies and dashboards can act on it without matching error messages.
+type ErrorKind string
+
+const (
+	// ErrorKindUnknown is for any error not otherwise classified.
+	ErrorKindUnknown ErrorKind = "unknown"
+
+	// ErrorKindAborted is for steps interrupted by the build being aborted.
+	ErrorKindAborted ErrorKind = "aborted"
+
+	// ErrorKindTimeout is for steps which exceeded their timeout.
+	ErrorKindTimeout ErrorKind = "timeout"
+
+	// ErrorKindWorkerUnavailable is for steps which could not be placed on a
+	// worker, or whose worker went away.
+	ErrorKindWorkerUnavailable ErrorKind = "worker-unavailable"
+
+	// ErrorKindImageFetchFailed is for steps whose container image could not
+	// be fetched.
+	ErrorKindImageFetchFailed ErrorKind = "image-fetch-failed"
+
+	// ErrorKindConfigInvalid is for steps whose config could not be loaded or
+	// is invalid.
+	ErrorKindConfigInvalid ErrorKind = "config-invalid"
+)
+
+// Retryable returns false for kinds of errors which are bound to happen
+// again if the step is retried.
+func (kind ErrorKind) Retryable() bool {
+	switch kind {
+	case ErrorKindAborted, ErrorKindConfigInvalid:
+		return false
+	default:
+		return true
+	}
+}
+
+// KindedError is implemented by errors which know their kind.
+type KindedError interface {
+	error
+	ErrorKind() ErrorKind
+}
@@ -3,12 +3,92 @@ package event
 import "github.com/concourse/atc"
 
 type Error struct {
-	Message string `json:"message"`
-	Origin  Origin `json:"origin,omitempty"`
+	Message string        `json:"message"`
+	Kind    atc.ErrorKind `json:"kind,omitempty"`
+	Origin  Origin        `json:"origin,omitempty"`
 }
 
 func (Error) EventType() atc.EventType  { return EventTypeError }
-func (Error) Version() atc.EventVersion { return "4.0" }
+func (Error) Version() atc.EventVersion { return "4.1" }
+
+type TerminatedDuringCleanup struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+}
+
+func (TerminatedDuringCleanup) EventType() atc.EventType  { return EventTypeTerminatedDuringCleanup }
+func (TerminatedDuringCleanup) Version() atc.EventVersion { return "1.0" }
+
+type NoisyNeighbors struct {
+	Time              int64  `json:"time"`
+	Origin            Origin `json:"origin"`
+	Worker            string `json:"worker"`
+	Duration          int64  `json:"duration"`
+	BaselineDuration  int64  `json:"baseline_duration"`
+	Neighbors         int    `json:"neighbors"`
+	BaselineNeighbors int    `json:"baseline_neighbors"`
+}
+
+func (NoisyNeighbors) EventType() atc.EventType  { return EventTypeNoisyNeighbors }
+func (NoisyNeighbors) Version() atc.EventVersion { return "1.0" }
+
+type WaitingForWorker struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+	Polls  int    `json:"polls"`
+}
+
+func (WaitingForWorker) EventType() atc.EventType  { return EventTypeWaitingForWorker }
+func (WaitingForWorker) Version() atc.EventVersion { return "1.0" }
+
+type SettingClamped struct {
+	Time      int64  `json:"time"`
+	Setting   string `json:"setting"`
+	Requested string `json:"requested"`
+	Applied   string `json:"applied"`
+}
+
+func (SettingClamped) EventType() atc.EventType  { return EventTypeSettingClamped }
+func (SettingClamped) Version() atc.EventVersion { return "1.0" }
+
+type RetryAttempt struct {
+	Time            int64  `json:"time"`
+	Origin          Origin `json:"origin"`
+	Attempt         int    `json:"attempt"`
+	MaxAttempts     int    `json:"max_attempts"`
+	BudgetRemaining *int   `json:"budget_remaining,omitempty"`
+}
+
+func (RetryAttempt) EventType() atc.EventType  { return EventTypeRetryAttempt }
+func (RetryAttempt) Version() atc.EventVersion { return "1.0" }
+
+type RetryBudgetExhausted struct {
+	Time        int64  `json:"time"`
+	Origin      Origin `json:"origin"`
+	Attempt     int    `json:"attempt"`
+	MaxAttempts int    `json:"max_attempts"`
+}
+
+func (RetryBudgetExhausted) EventType() atc.EventType  { return EventTypeRetryBudgetExhausted }
+func (RetryBudgetExhausted) Version() atc.EventVersion { return "1.0" }
+
+type StepDisplay struct {
+	Origin  Origin          `json:"origin"`
+	Display atc.StepDisplay `json:"display"`
+}
+
+func (StepDisplay) EventType() atc.EventType  { return EventTypeStepDisplay }
+func (StepDisplay) Version() atc.EventVersion { return "1.0" }
+
+type SetVar struct {
+	Time   int64       `json:"time"`
+	Origin Origin      `json:"origin"`
+	Name   string      `json:"name"`
+	Value  interface{} `json:"value"`
+}
+
+func (SetVar) EventType() atc.EventType  { return EventTypeSetVar }
+func (SetVar) Version() atc.EventVersion { return "1.0" }
 
 type FinishTask struct {
 	Time       int64  `json:"time"`
@@ -78,6 +158,15 @@ type StartTask struct {
 func (StartTask) EventType() atc.EventType  { return EventTypeStartTask }
 func (StartTask) Version() atc.EventVersion { return "5.0" }
 
+type AbortRequested struct {
+	Time      int64  `json:"time"`
+	Requester string `json:"requester"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+func (AbortRequested) EventType() atc.EventType  { return EventTypeAbortRequested }
+func (AbortRequested) Version() atc.EventVersion { return "1.0" }
+
 type Status struct {
 	Status atc.BuildStatus `json:"status"`
 	Time   int64           `json:"time"`
@@ -128,3 +217,34 @@ type FinishPut struct {
 
 func (FinishPut) EventType() atc.EventType  { return EventTypeFinishPut }
 func (FinishPut) Version() atc.EventVersion { return "5.0" }
+
+type WaitForHeartbeat struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+	Polls  int    `json:"polls"`
+	Status string `json:"status"`
+}
+
+func (WaitForHeartbeat) EventType() atc.EventType  { return EventTypeWaitForHeartbeat }
+func (WaitForHeartbeat) Version() atc.EventVersion { return "1.0" }
+
+type FinishWaitFor struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+	Polls  int    `json:"polls"`
+}
+
+func (FinishWaitFor) EventType() atc.EventType  { return EventTypeFinishWaitFor }
+func (FinishWaitFor) Version() atc.EventVersion { return "1.0" }
+
+type FinishPromote struct {
+	Origin          Origin              `json:"origin"`
+	ExitStatus      int                 `json:"exit_status"`
+	From            string              `json:"from,omitempty"`
+	FromVersion     atc.Version         `json:"from_version,omitempty"`
+	CreatedVersion  atc.Version         `json:"version"`
+	CreatedMetadata []atc.MetadataField `json:"metadata,omitempty"`
+}
+
+func (FinishPromote) EventType() atc.EventType  { return EventTypeFinishPromote }
+func (FinishPromote) Version() atc.EventVersion { return "1.0" }
@@ -38,9 +38,21 @@ func init() {
 	registerEvent(FinishTask{})
 	registerEvent(FinishGet{})
 	registerEvent(FinishPut{})
+	registerEvent(WaitForHeartbeat{})
+	registerEvent(FinishWaitFor{})
+	registerEvent(FinishPromote{})
+	registerEvent(SetVar{})
 	registerEvent(Status{})
+	registerEvent(AbortRequested{})
 	registerEvent(Log{})
 	registerEvent(Error{})
+	registerEvent(TerminatedDuringCleanup{})
+	registerEvent(NoisyNeighbors{})
+	registerEvent(WaitingForWorker{})
+	registerEvent(SettingClamped{})
+	registerEvent(RetryAttempt{})
+	registerEvent(RetryBudgetExhausted{})
+	registerEvent(StepDisplay{})
 
 	// deprecated:
 	registerEvent(InitializeV10{})
@@ -53,6 +65,7 @@ func init() {
 	registerEvent(ErrorV10{})
 	registerEvent(ErrorV20{})
 	registerEvent(ErrorV30{})
+	registerEvent(ErrorV40{})
 	registerEvent(FinishTaskV10{})
 	registerEvent(FinishTaskV20{})
 	registerEvent(FinishTaskV30{})
@@ -9,6 +9,9 @@ const (
 	// build status change (e.g. 'started', 'succeeded')
 	EventTypeStatus atc.EventType = "status"
 
+	// build aborted by a team or the system, to be finished as aborted
+	EventTypeAbortRequested atc.EventType = "abort-requested"
+
 	// step initializing
 	EventTypeInitialize atc.EventType = "initialize"
 
@@ -27,6 +30,39 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// still waiting for an external condition
+	EventTypeWaitForHeartbeat atc.EventType = "wait-for-heartbeat"
+
+	// task waiting for a worker with a free task slot
+	EventTypeWaitingForWorker atc.EventType = "waiting-for-worker"
+
+	// external condition met
+	EventTypeFinishWaitFor atc.EventType = "finish-wait-for"
+
+	// finished promoting a version from one resource to another
+	EventTypeFinishPromote atc.EventType = "finish-promote"
+
+	// build variable set for later steps to interpolate
+	EventTypeSetVar atc.EventType = "set-var"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// step forcibly terminated after exceeding the cleanup budget
+	EventTypeTerminatedDuringCleanup atc.EventType = "terminated-during-cleanup"
+
+	// step ran slower than usual while its worker was busier than usual
+	EventTypeNoisyNeighbors atc.EventType = "noisy-neighbors"
+
+	// retried step starting another attempt
+	EventTypeRetryAttempt atc.EventType = "retry-attempt"
+
+	// retried step given up on as the build has no retries left
+	EventTypeRetryBudgetExhausted atc.EventType = "retry-budget-exhausted"
+
+	// pipeline setting lowered to stay within the operator's limits
+	EventTypeSettingClamped atc.EventType = "setting-clamped"
+
+	// step annotated with how build viewers should present it starting
+	EventTypeStepDisplay atc.EventType = "step-display"
 )
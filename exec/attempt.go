@@ -0,0 +1,59 @@
+package exec
+
+import (
+	"context"
+	"strconv"
+)
+
+// StepAttempt identifies which attempt of a retried step is running, so that
+// the step and its hooks can behave differently on the final attempt.
+type StepAttempt struct {
+	Number int
+	Max    int
+}
+
+// Env returns the environment variables exposing the attempt to tasks and
+// resources.
+func (attempt StepAttempt) Env() []string {
+	return []string{
+		"BUILD_ATTEMPT=" + strconv.Itoa(attempt.Number),
+		"BUILD_MAX_ATTEMPTS=" + strconv.Itoa(attempt.Max),
+	}
+}
+
+type attemptKey struct{}
+
+// WithAttempt makes the attempt available to steps run with the returned
+// context.
+func WithAttempt(ctx context.Context, attempt StepAttempt) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// AttemptFromContext returns the attempt of the innermost retried step that
+// is running, if any.
+func AttemptFromContext(ctx context.Context) (StepAttempt, bool) {
+	attempt, ok := ctx.Value(attemptKey{}).(StepAttempt)
+	return attempt, ok
+}
+
+// attemptEnv returns the environment variables for the attempt in the
+// context, or none if the step is not being retried.
+func attemptEnv(ctx context.Context) []string {
+	attempt, ok := AttemptFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return attempt.Env()
+}
+
+// hookContext returns the context to run a hook of the given step with. If
+// the step was retried, the hook is told which attempt it ran last.
+func hookContext(ctx context.Context, step Step) context.Context {
+	retry, ok := step.(*RetryStep)
+	if !ok || retry.LastAttempt == nil {
+		return ctx
+	}
+
+	return WithAttempt(ctx, retry.lastAttempt())
+}
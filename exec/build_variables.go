@@ -0,0 +1,137 @@
+package exec
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+)
+
+// RedactedValue is shown in place of a build variable whose value was
+// derived from credentials.
+const RedactedValue = "((redacted))"
+
+// BuildVariables holds the variables set by the steps of a build, which later
+// steps may interpolate with ((name)) like any credential. They last as long
+// as the build's RunState, so they are lost if the build is resumed by
+// another ATC.
+type BuildVariables struct {
+	vars  map[string]buildVariable
+	varsL *sync.RWMutex
+}
+
+type buildVariable struct {
+	value  interface{}
+	redact bool
+}
+
+func NewBuildVariables() *BuildVariables {
+	return &BuildVariables{
+		vars:  map[string]buildVariable{},
+		varsL: new(sync.RWMutex),
+	}
+}
+
+// Set stores a variable for later steps to interpolate, replacing any
+// previous value. Values derived from credentials must be marked to be
+// redacted.
+func (variables *BuildVariables) Set(name string, value interface{}, redact bool) {
+	variables.varsL.Lock()
+	variables.vars[name] = buildVariable{
+		value:  value,
+		redact: redact,
+	}
+	variables.varsL.Unlock()
+}
+
+// Get implements creds.Variables.
+func (variables *BuildVariables) Get(def template.VariableDefinition) (interface{}, bool, error) {
+	variable, found := variables.lookup(def.Name)
+	return variable.value, found, nil
+}
+
+// List implements creds.Variables.
+func (variables *BuildVariables) List() ([]template.VariableDefinition, error) {
+	variables.varsL.RLock()
+	defer variables.varsL.RUnlock()
+
+	defs := []template.VariableDefinition{}
+	for name := range variables.vars {
+		defs = append(defs, template.VariableDefinition{Name: name})
+	}
+
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Name < defs[j].Name
+	})
+
+	return defs, nil
+}
+
+// Redacted returns the value of the variable as it may be shown to users of
+// the build, i.e. RedactedValue if it was derived from credentials.
+func (variables *BuildVariables) Redacted(name string) (interface{}, bool) {
+	variable, found := variables.lookup(name)
+	if found && variable.redact {
+		return RedactedValue, true
+	}
+
+	return variable.value, found
+}
+
+// Scope returns variables resolving the build's own variables, falling back
+// to the given credentials.
+func (variables *BuildVariables) Scope(credentials creds.Variables) creds.Variables {
+	return variables.scope(credentials)
+}
+
+func (variables *BuildVariables) scope(credentials creds.Variables) *scopedVariables {
+	return &scopedVariables{
+		build:       variables,
+		credentials: credentials,
+	}
+}
+
+func (variables *BuildVariables) lookup(name string) (buildVariable, bool) {
+	variables.varsL.RLock()
+	variable, found := variables.vars[name]
+	variables.varsL.RUnlock()
+
+	return variable, found
+}
+
+type scopedVariables struct {
+	build       *BuildVariables
+	credentials creds.Variables
+}
+
+func (variables *scopedVariables) Get(def template.VariableDefinition) (interface{}, bool, error) {
+	value, found, _, err := variables.resolve(def)
+	return value, found, err
+}
+
+// resolve returns the value of the variable, and whether it is sensitive,
+// i.e. resolved from credentials or from a redacted build variable.
+func (variables *scopedVariables) resolve(def template.VariableDefinition) (interface{}, bool, bool, error) {
+	if variable, found := variables.build.lookup(def.Name); found {
+		return variable.value, true, variable.redact, nil
+	}
+
+	value, found, err := variables.credentials.Get(def)
+
+	return value, found, found, err
+}
+
+func (variables *scopedVariables) List() ([]template.VariableDefinition, error) {
+	buildDefs, err := variables.build.List()
+	if err != nil {
+		return nil, err
+	}
+
+	credDefs, err := variables.credentials.List()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(buildDefs, credDefs...), nil
+}
@@ -0,0 +1,99 @@
+package exec_test
+
+import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/exec"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildVariables", func() {
+	var variables *exec.BuildVariables
+
+	BeforeEach(func() {
+		variables = exec.NewBuildVariables()
+	})
+
+	Describe("Get", func() {
+		It("returns the value of a variable that has been set", func() {
+			variables.Set("some-var", "some-value", false)
+
+			value, found, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+		})
+
+		It("does not find a variable that has not been set", func() {
+			_, found, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("List", func() {
+		It("lists the variables by name", func() {
+			variables.Set("b", "some-value", false)
+			variables.Set("a", "some-value", true)
+
+			Expect(variables.List()).To(Equal([]template.VariableDefinition{
+				{Name: "a"},
+				{Name: "b"},
+			}))
+		})
+	})
+
+	Describe("Redacted", func() {
+		It("returns the value of a variable not derived from credentials", func() {
+			variables.Set("some-var", "some-value", false)
+
+			value, found := variables.Redacted("some-var")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+		})
+
+		It("hides the value of a variable derived from credentials", func() {
+			variables.Set("some-var", "some-secret", true)
+
+			value, found := variables.Redacted("some-var")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(exec.RedactedValue))
+		})
+	})
+
+	Describe("Scope", func() {
+		var credentials template.StaticVariables
+
+		BeforeEach(func() {
+			credentials = template.StaticVariables{
+				"some-var":  "some-credential",
+				"other-var": "other-credential",
+			}
+		})
+
+		It("prefers the build's own variables to credentials", func() {
+			variables.Set("some-var", "some-value", false)
+
+			value, found, err := variables.Scope(credentials).Get(template.VariableDefinition{Name: "some-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+		})
+
+		It("falls back to credentials", func() {
+			value, found, err := variables.Scope(credentials).Get(template.VariableDefinition{Name: "other-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("other-credential"))
+		})
+
+		It("sees variables set after it was created", func() {
+			scope := variables.Scope(credentials)
+
+			variables.Set("some-var", "some-value", false)
+
+			value, _, _ := scope.Get(template.VariableDefinition{Name: "some-var"})
+			Expect(value).To(Equal("some-value"))
+		})
+	})
+})
@@ -0,0 +1,66 @@
+package exec
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/concourse/atc/worker"
+)
+
+// resolveCacheKey evaluates the cache key template configured on a
+// save_cache or restore_cache step. The template may call `checksum` with
+// the path to a file within an artifact, e.g. `{{checksum "repo/go.sum"}}`,
+// in order to key the cache on the file's contents.
+func resolveCacheKey(repository *worker.ArtifactRepository, key string) (string, error) {
+	tmpl, err := template.New("key").Funcs(template.FuncMap{
+		"checksum": func(path string) (string, error) {
+			return checksumArtifactFile(repository, path)
+		},
+	}).Parse(key)
+	if err != nil {
+		return "", fmt.Errorf("invalid cache key '%s': %s", key, err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = tmpl.Execute(buf, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to evaluate cache key '%s': %s", key, err)
+	}
+
+	return buf.String(), nil
+}
+
+func checksumArtifactFile(repository *worker.ArtifactRepository, path string) (string, error) {
+	segs := strings.SplitN(path, "/", 2)
+	if len(segs) != 2 {
+		return "", fmt.Errorf("checksum path '%s' does not specify which artifact contains the file", path)
+	}
+
+	sourceName := worker.ArtifactName(segs[0])
+
+	source, found := repository.SourceFor(sourceName)
+	if !found {
+		return "", UnknownArtifactSourceError{sourceName}
+	}
+
+	stream, err := source.StreamFile(segs[1])
+	if err != nil {
+		return "", err
+	}
+
+	defer stream.Close()
+
+	hash := sha256.New()
+
+	_, err = io.Copy(hash, stream)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
@@ -0,0 +1,67 @@
+package exec
+
+import (
+	"context"
+	"time"
+)
+
+type cleanupBudgetKey struct{}
+type cleanupKey struct{}
+
+// WithCleanupBudget configures how long steps are given to clean up once the
+// build has been aborted, e.g. for on_abort and ensure hooks to run and for
+// containers to be gracefully stopped. Once the budget elapses the remaining
+// work is forcibly terminated. A budget of zero means no limit.
+func WithCleanupBudget(ctx context.Context, budget time.Duration) context.Context {
+	return context.WithValue(ctx, cleanupBudgetKey{}, budget)
+}
+
+// CleanupBudget returns the cleanup budget configured on the context, or zero
+// if none has been configured.
+func CleanupBudget(ctx context.Context) time.Duration {
+	budget, _ := ctx.Value(cleanupBudgetKey{}).(time.Duration)
+	return budget
+}
+
+// inCleanup is true if the context was created by cleanupContext, i.e. the
+// step is running after the build was aborted.
+func inCleanup(ctx context.Context) bool {
+	_, ok := ctx.Value(cleanupKey{}).(context.Context)
+	return ok
+}
+
+// cleanupBudgetExceeded is true if the context was created by cleanupContext
+// and the cleanup budget has since run out.
+func cleanupBudgetExceeded(ctx context.Context) bool {
+	cleanupCtx, ok := ctx.Value(cleanupKey{}).(context.Context)
+	return ok && cleanupCtx.Err() == context.DeadlineExceeded
+}
+
+// cleanupContext returns a context for running work after the given context
+// has been canceled. It is detached from the original context's cancellation
+// but keeps its values, and is bounded by the cleanup budget if one is
+// configured.
+func cleanupContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	var cleanupCtx context.Context
+	var cancel context.CancelFunc
+
+	budget := CleanupBudget(ctx)
+	if budget > 0 {
+		cleanupCtx, cancel = context.WithTimeout(detachedContext{ctx}, budget)
+	} else {
+		cleanupCtx, cancel = context.WithCancel(detachedContext{ctx})
+	}
+
+	return context.WithValue(cleanupCtx, cleanupKey{}, cleanupCtx), cancel
+}
+
+// detachedContext carries the values of its parent but none of its deadlines
+// or cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool)           { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}                 { return nil }
+func (detachedContext) Err() error                            { return nil }
+func (ctx detachedContext) Value(key interface{}) interface{} { return ctx.parent.Value(key) }
@@ -0,0 +1,73 @@
+package exec
+
+import (
+	"context"
+
+	"github.com/concourse/atc"
+)
+
+// DoResult is stored as the result of a DoStep in which any step failed,
+// recording the indexes of the steps that failed.
+type DoResult struct {
+	FailedSteps []int
+}
+
+// DoStep is a step of steps to run in sequence.
+type DoStep struct {
+	id                atc.PlanID
+	steps             []Step
+	continueOnFailure bool
+
+	succeeded bool
+}
+
+// Do constructs a DoStep. If continueOnFailure is true, the remaining steps
+// are run even after one of them fails, though the DoStep still fails.
+func Do(id atc.PlanID, continueOnFailure bool, steps ...Step) Step {
+	return &DoStep{
+		id:                id,
+		steps:             steps,
+		continueOnFailure: continueOnFailure,
+	}
+}
+
+// Run runs each step in order, stopping at the first step that fails unless
+// continuing on failure. If a step errors, the remaining steps are not run and
+// the error is returned.
+//
+// If any step failed, a DoResult is stored for the DoStep's plan ID.
+func (step *DoStep) Run(ctx context.Context, state RunState) error {
+	var failed []int
+
+	defer func() {
+		if len(failed) > 0 {
+			state.StoreResult(step.id, DoResult{FailedSteps: failed})
+		}
+	}()
+
+	for i, s := range step.steps {
+		err := s.Run(ctx, state)
+		if err != nil {
+			return err
+		}
+
+		if s.Succeeded() {
+			continue
+		}
+
+		failed = append(failed, i)
+
+		if !step.continueOnFailure {
+			return nil
+		}
+	}
+
+	step.succeeded = len(failed) == 0
+
+	return nil
+}
+
+// Succeeded is true if every step ran and succeeded.
+func (step *DoStep) Succeeded() bool {
+	return step.succeeded
+}
@@ -0,0 +1,150 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+)
+
+var _ = Describe("Do Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		step1 *execfakes.FakeStep
+		step2 *execfakes.FakeStep
+		step3 *execfakes.FakeStep
+
+		state exec.RunState
+
+		continueOnFailure bool
+		doStep            exec.Step
+
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		step1 = &execfakes.FakeStep{}
+		step2 = &execfakes.FakeStep{}
+		step3 = &execfakes.FakeStep{}
+
+		step1.SucceededReturns(true)
+		step2.SucceededReturns(true)
+		step3.SucceededReturns(true)
+
+		state = exec.NewRunState()
+
+		continueOnFailure = false
+	})
+
+	JustBeforeEach(func() {
+		doStep = exec.Do("some-plan-id", continueOnFailure, step1, step2, step3)
+		stepErr = doStep.Run(ctx, state)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	Context("when every step succeeds", func() {
+		It("runs every step with the context and run state", func() {
+			for _, step := range []*execfakes.FakeStep{step1, step2, step3} {
+				Expect(step.RunCallCount()).To(Equal(1))
+
+				runCtx, runState := step.RunArgsForCall(0)
+				Expect(runCtx).To(Equal(ctx))
+				Expect(runState).To(Equal(state))
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(doStep.Succeeded()).To(BeTrue())
+		})
+
+		It("does not store a result", func() {
+			var result exec.DoResult
+			Expect(state.Result("some-plan-id", &result)).To(BeFalse())
+		})
+	})
+
+	Context("when a step fails", func() {
+		BeforeEach(func() {
+			step2.SucceededReturns(false)
+		})
+
+		It("does not run the remaining steps", func() {
+			Expect(step1.RunCallCount()).To(Equal(1))
+			Expect(step2.RunCallCount()).To(Equal(1))
+			Expect(step3.RunCallCount()).To(BeZero())
+		})
+
+		It("fails without erroring", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(doStep.Succeeded()).To(BeFalse())
+		})
+
+		It("records which step failed", func() {
+			var result exec.DoResult
+			Expect(state.Result("some-plan-id", &result)).To(BeTrue())
+			Expect(result.FailedSteps).To(Equal([]int{1}))
+		})
+
+		Context("when continuing on failure", func() {
+			BeforeEach(func() {
+				continueOnFailure = true
+				step3.SucceededReturns(false)
+			})
+
+			It("runs the remaining steps", func() {
+				Expect(step3.RunCallCount()).To(Equal(1))
+			})
+
+			It("still fails", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(doStep.Succeeded()).To(BeFalse())
+			})
+
+			It("records every step that failed", func() {
+				var result exec.DoResult
+				Expect(state.Result("some-plan-id", &result)).To(BeTrue())
+				Expect(result.FailedSteps).To(Equal([]int{1, 2}))
+			})
+		})
+	})
+
+	Context("when a step errors", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			continueOnFailure = true
+			step1.SucceededReturns(false)
+			step2.RunReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+
+		It("does not run the remaining steps", func() {
+			Expect(step3.RunCallCount()).To(BeZero())
+		})
+
+		It("fails", func() {
+			Expect(doStep.Succeeded()).To(BeFalse())
+		})
+
+		It("records the steps that failed before the error", func() {
+			var result exec.DoResult
+			Expect(state.Result("some-plan-id", &result)).To(BeTrue())
+			Expect(result.FailedSteps).To(Equal([]int{0}))
+		})
+	})
+})
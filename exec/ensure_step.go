@@ -23,7 +23,9 @@ func Ensure(step Step, hook Step) EnsureStep {
 
 // Run will call Run on the first step, wait for it to complete, and then call
 // Run on the second step, regardless of whether the first step failed or
-// errored.
+// errored. If the first step was interrupted, the second step is given the
+// cleanup budget to run. If the first step was retried, the second step is
+// told which attempt ran last.
 //
 // If the first step or the second step errors, an aggregate of their errors is
 // returned.
@@ -38,10 +40,12 @@ func (o EnsureStep) Run(ctx context.Context, state RunState) error {
 	hookCtx := ctx
 	if ctx.Err() != nil {
 		// prevent hook from being immediately canceled
-		hookCtx = context.Background()
+		var cancel context.CancelFunc
+		hookCtx, cancel = cleanupContext(ctx)
+		defer cancel()
 	}
 
-	hookErr := o.hook.Run(hookCtx, state)
+	hookErr := o.hook.Run(hookContext(hookCtx, o.step), state)
 	if hookErr != nil {
 		errors = multierror.Append(errors, hookErr)
 	}
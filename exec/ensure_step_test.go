@@ -86,6 +86,29 @@ var _ = Describe("Ensure Step", func() {
 		})
 	})
 
+	Context("when the step is retried", func() {
+		var attempt1, attempt2 *execfakes.FakeStep
+
+		BeforeEach(func() {
+			attempt1 = new(execfakes.FakeStep)
+			attempt1.SucceededReturns(true)
+
+			attempt2 = new(execfakes.FakeStep)
+
+			ensure = exec.Ensure(exec.Retry(new(execfakes.FakeRetryDelegate), attempt1, attempt2), hook)
+		})
+
+		It("tells the hook which attempt ran last", func() {
+			Expect(attempt2.RunCallCount()).To(BeZero())
+			Expect(hook.RunCallCount()).To(Equal(1))
+
+			runCtx, _ := hook.RunArgsForCall(0)
+			attempt, ok := exec.AttemptFromContext(runCtx)
+			Expect(ok).To(BeTrue())
+			Expect(attempt).To(Equal(exec.StepAttempt{Number: 1, Max: 2}))
+		})
+	})
+
 	Context("when the step errors", func() {
 		disaster := errors.New("disaster")
 
@@ -1,6 +1,13 @@
 package exec
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/image"
+)
 
 // FileNotFoundError is the error to return from StreamFile when the given path
 // does not exist.
@@ -13,3 +20,63 @@ type FileNotFoundError struct {
 func (err FileNotFoundError) Error() string {
 	return fmt.Sprintf("file not found: %s", err.Path)
 }
+
+// StepError is returned by steps for errors of a known kind which would
+// otherwise not be told apart from others.
+type StepError struct {
+	Kind atc.ErrorKind
+	Err  error
+}
+
+// Error returns the message of the underlying error.
+func (err StepError) Error() string {
+	return err.Err.Error()
+}
+
+// ErrorKind returns the kind of the error.
+func (err StepError) ErrorKind() atc.ErrorKind {
+	return err.Kind
+}
+
+// ClassifyError determines the kind of an error returned by a step.
+func ClassifyError(err error) atc.ErrorKind {
+	switch err {
+	case context.Canceled:
+		return atc.ErrorKindAborted
+
+	case context.DeadlineExceeded:
+		return atc.ErrorKindTimeout
+
+	case worker.ErrNoWorkers,
+		worker.ErrMissingWorker,
+		worker.ErrDesiredWorkerNotRunning,
+		worker.ErrActiveTasksLimitReached:
+		return atc.ErrorKindWorkerUnavailable
+
+	case image.ErrImageUnavailable,
+		image.ErrImageGetDidNotProduceVolume:
+		return atc.ErrorKindImageFetchFailed
+	}
+
+	switch err := err.(type) {
+	case atc.KindedError:
+		return err.ErrorKind()
+
+	case worker.NoCompatibleWorkersError,
+		worker.NoWorkersMatchingPlatformError:
+		return atc.ErrorKindWorkerUnavailable
+
+	case image.MalformedMetadataError:
+		return atc.ErrorKindImageFetchFailed
+
+	case FileNotFoundError,
+		UnknownArtifactSourceError,
+		UnspecifiedArtifactSourceError,
+		MissingInputsError,
+		MissingTaskImageSourceError,
+		TaskImageSourceParametersError:
+		return atc.ErrorKindConfigInvalid
+	}
+
+	return atc.ErrorKindUnknown
+}
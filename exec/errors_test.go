@@ -0,0 +1,43 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/image"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClassifyError", func() {
+	DescribeTable("classifies errors returned by steps",
+		func(err error, kind atc.ErrorKind) {
+			Expect(ClassifyError(err)).To(Equal(kind))
+		},
+		Entry("aborted", context.Canceled, atc.ErrorKindAborted),
+		Entry("timed out", context.DeadlineExceeded, atc.ErrorKindTimeout),
+		Entry("no workers", worker.ErrNoWorkers, atc.ErrorKindWorkerUnavailable),
+		Entry("no compatible workers", worker.NoCompatibleWorkersError{}, atc.ErrorKindWorkerUnavailable),
+		Entry("no workers matching platform", worker.NoWorkersMatchingPlatformError{Platform: "windows"}, atc.ErrorKindWorkerUnavailable),
+		Entry("missing worker", worker.ErrMissingWorker, atc.ErrorKindWorkerUnavailable),
+		Entry("active tasks limit reached", worker.ErrActiveTasksLimitReached, atc.ErrorKindWorkerUnavailable),
+		Entry("image unavailable", image.ErrImageUnavailable, atc.ErrorKindImageFetchFailed),
+		Entry("malformed image metadata", image.MalformedMetadataError{}, atc.ErrorKindImageFetchFailed),
+		Entry("missing inputs", MissingInputsError{Inputs: []string{"some-input"}}, atc.ErrorKindConfigInvalid),
+		Entry("unknown artifact source", UnknownArtifactSourceError{SourceName: "some-source"}, atc.ErrorKindConfigInvalid),
+		Entry("step error", StepError{Kind: atc.ErrorKindTimeout, Err: errors.New("nope")}, atc.ErrorKindTimeout),
+		Entry("anything else", errors.New("nope"), atc.ErrorKindUnknown),
+	)
+
+	Describe("StepError", func() {
+		It("has the message of the underlying error", func() {
+			err := StepError{Kind: atc.ErrorKindConfigInvalid, Err: errors.New("bad config")}
+			Expect(err.Error()).To(Equal("bad config"))
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 )
@@ -40,11 +41,17 @@ type FakeBuildStepDelegate struct {
 	stderrReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
-	ErroredStub        func(lager.Logger, string)
+	ErroredStub        func(lager.Logger, string, atc.ErrorKind)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 atc.ErrorKind
+	}
+	TerminatedDuringCleanupStub        func(lager.Logger)
+	terminatedDuringCleanupMutex       sync.RWMutex
+	terminatedDuringCleanupArgsForCall []struct {
+		arg1 lager.Logger
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
@@ -178,16 +185,17 @@ func (fake *FakeBuildStepDelegate) StderrReturnsOnCall(i int, result1 io.Writer)
 	}{result1}
 }
 
-func (fake *FakeBuildStepDelegate) Errored(arg1 lager.Logger, arg2 string) {
+func (fake *FakeBuildStepDelegate) Errored(arg1 lager.Logger, arg2 string, arg3 atc.ErrorKind) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("Errored", []interface{}{arg1, arg2})
+		arg3 atc.ErrorKind
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Errored", []interface{}{arg1, arg2, arg3})
 	fake.erroredMutex.Unlock()
 	if fake.ErroredStub != nil {
-		fake.ErroredStub(arg1, arg2)
+		fake.ErroredStub(arg1, arg2, arg3)
 	}
 }
 
@@ -197,10 +205,34 @@ func (fake *FakeBuildStepDelegate) ErroredCallCount() int {
 	return len(fake.erroredArgsForCall)
 }
 
-func (fake *FakeBuildStepDelegate) ErroredArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeBuildStepDelegate) ErroredArgsForCall(i int) (lager.Logger, string, atc.ErrorKind) {
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
-	return fake.erroredArgsForCall[i].arg1, fake.erroredArgsForCall[i].arg2
+	return fake.erroredArgsForCall[i].arg1, fake.erroredArgsForCall[i].arg2, fake.erroredArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildStepDelegate) TerminatedDuringCleanup(arg1 lager.Logger) {
+	fake.terminatedDuringCleanupMutex.Lock()
+	fake.terminatedDuringCleanupArgsForCall = append(fake.terminatedDuringCleanupArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("TerminatedDuringCleanup", []interface{}{arg1})
+	fake.terminatedDuringCleanupMutex.Unlock()
+	if fake.TerminatedDuringCleanupStub != nil {
+		fake.TerminatedDuringCleanupStub(arg1)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) TerminatedDuringCleanupCallCount() int {
+	fake.terminatedDuringCleanupMutex.RLock()
+	defer fake.terminatedDuringCleanupMutex.RUnlock()
+	return len(fake.terminatedDuringCleanupArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) TerminatedDuringCleanupArgsForCall(i int) lager.Logger {
+	fake.terminatedDuringCleanupMutex.RLock()
+	defer fake.terminatedDuringCleanupMutex.RUnlock()
+	return fake.terminatedDuringCleanupArgsForCall[i].arg1
 }
 
 func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
@@ -214,6 +246,8 @@ func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stderrMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
+	fake.terminatedDuringCleanupMutex.RLock()
+	defer fake.terminatedDuringCleanupMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
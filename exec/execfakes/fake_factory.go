@@ -11,15 +11,16 @@ import (
 )
 
 type FakeFactory struct {
-	GetStub        func(lager.Logger, atc.Plan, db.Build, exec.StepMetadata, db.ContainerMetadata, exec.GetDelegate) exec.Step
+	GetStub        func(lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, exec.StepMetadata, db.ContainerMetadata, exec.GetDelegate) exec.Step
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 atc.Plan
 		arg3 db.Build
-		arg4 exec.StepMetadata
-		arg5 db.ContainerMetadata
-		arg6 exec.GetDelegate
+		arg4 *exec.BuildVariables
+		arg5 exec.StepMetadata
+		arg6 db.ContainerMetadata
+		arg7 exec.GetDelegate
 	}
 	getReturns struct {
 		result1 exec.Step
@@ -27,15 +28,16 @@ type FakeFactory struct {
 	getReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
-	PutStub        func(lager.Logger, atc.Plan, db.Build, exec.StepMetadata, db.ContainerMetadata, exec.PutDelegate) exec.Step
+	PutStub        func(lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, exec.StepMetadata, db.ContainerMetadata, exec.PutDelegate) exec.Step
 	putMutex       sync.RWMutex
 	putArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 atc.Plan
 		arg3 db.Build
-		arg4 exec.StepMetadata
-		arg5 db.ContainerMetadata
-		arg6 exec.PutDelegate
+		arg4 *exec.BuildVariables
+		arg5 exec.StepMetadata
+		arg6 db.ContainerMetadata
+		arg7 exec.PutDelegate
 	}
 	putReturns struct {
 		result1 exec.Step
@@ -43,14 +45,15 @@ type FakeFactory struct {
 	putReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
-	TaskStub        func(lager.Logger, atc.Plan, db.Build, db.ContainerMetadata, exec.TaskDelegate) exec.Step
+	TaskStub        func(lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, db.ContainerMetadata, exec.TaskDelegate) exec.Step
 	taskMutex       sync.RWMutex
 	taskArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 atc.Plan
 		arg3 db.Build
-		arg4 db.ContainerMetadata
-		arg5 exec.TaskDelegate
+		arg4 *exec.BuildVariables
+		arg5 db.ContainerMetadata
+		arg6 exec.TaskDelegate
 	}
 	taskReturns struct {
 		result1 exec.Step
@@ -58,25 +61,133 @@ type FakeFactory struct {
 	taskReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
+	ReplayTaskStub        func(lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, db.ContainerMetadata, exec.TaskDelegate) exec.Step
+	replayTaskMutex       sync.RWMutex
+	replayTaskArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 *exec.BuildVariables
+		arg5 db.ContainerMetadata
+		arg6 exec.TaskDelegate
+	}
+	replayTaskReturns struct {
+		result1 exec.Step
+	}
+	replayTaskReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
+	SaveCacheStub        func(lager.Logger, atc.Plan, db.Build, exec.BuildStepDelegate) exec.Step
+	saveCacheMutex       sync.RWMutex
+	saveCacheArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.BuildStepDelegate
+	}
+	saveCacheReturns struct {
+		result1 exec.Step
+	}
+	saveCacheReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
+	RestoreCacheStub        func(lager.Logger, atc.Plan, db.Build, exec.BuildStepDelegate) exec.Step
+	restoreCacheMutex       sync.RWMutex
+	restoreCacheArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.BuildStepDelegate
+	}
+	restoreCacheReturns struct {
+		result1 exec.Step
+	}
+	restoreCacheReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
+	PromoteStub        func(lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, exec.StepMetadata, db.ContainerMetadata, exec.PromoteDelegate) exec.Step
+	promoteMutex       sync.RWMutex
+	promoteArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 *exec.BuildVariables
+		arg5 exec.StepMetadata
+		arg6 db.ContainerMetadata
+		arg7 exec.PromoteDelegate
+	}
+	promoteReturns struct {
+		result1 exec.Step
+	}
+	promoteReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
+	SetVarStub        func(lager.Logger, atc.Plan, db.Build, exec.SetVarDelegate) exec.Step
+	setVarMutex       sync.RWMutex
+	setVarArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.SetVarDelegate
+	}
+	setVarReturns struct {
+		result1 exec.Step
+	}
+	setVarReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
+	WaitForStub        func(lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, db.ContainerMetadata, exec.WaitDelegate) exec.Step
+	waitForMutex       sync.RWMutex
+	waitForArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 *exec.BuildVariables
+		arg5 db.ContainerMetadata
+		arg6 exec.WaitDelegate
+	}
+	waitForReturns struct {
+		result1 exec.Step
+	}
+	waitForReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
+	RerunTaskStub        func(lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, db.ContainerMetadata, exec.TaskDelegate) exec.Step
+	rerunTaskMutex       sync.RWMutex
+	rerunTaskArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 *exec.BuildVariables
+		arg5 db.ContainerMetadata
+		arg6 exec.TaskDelegate
+	}
+	rerunTaskReturns struct {
+		result1 exec.Step
+	}
+	rerunTaskReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeFactory) Get(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 exec.StepMetadata, arg5 db.ContainerMetadata, arg6 exec.GetDelegate) exec.Step {
+func (fake *FakeFactory) Get(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 *exec.BuildVariables, arg5 exec.StepMetadata, arg6 db.ContainerMetadata, arg7 exec.GetDelegate) exec.Step {
 	fake.getMutex.Lock()
 	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
 	fake.getArgsForCall = append(fake.getArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 atc.Plan
 		arg3 db.Build
-		arg4 exec.StepMetadata
-		arg5 db.ContainerMetadata
-		arg6 exec.GetDelegate
-	}{arg1, arg2, arg3, arg4, arg5, arg6})
-	fake.recordInvocation("Get", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+		arg4 *exec.BuildVariables
+		arg5 exec.StepMetadata
+		arg6 db.ContainerMetadata
+		arg7 exec.GetDelegate
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.recordInvocation("Get", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
 	fake.getMutex.Unlock()
 	if fake.GetStub != nil {
-		return fake.GetStub(arg1, arg2, arg3, arg4, arg5, arg6)
+		return fake.GetStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	}
 	if specificReturn {
 		return ret.result1
@@ -90,10 +201,10 @@ func (fake *FakeFactory) GetCallCount() int {
 	return len(fake.getArgsForCall)
 }
 
-func (fake *FakeFactory) GetArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, exec.StepMetadata, db.ContainerMetadata, exec.GetDelegate) {
+func (fake *FakeFactory) GetArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, exec.StepMetadata, db.ContainerMetadata, exec.GetDelegate) {
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
-	return fake.getArgsForCall[i].arg1, fake.getArgsForCall[i].arg2, fake.getArgsForCall[i].arg3, fake.getArgsForCall[i].arg4, fake.getArgsForCall[i].arg5, fake.getArgsForCall[i].arg6
+	return fake.getArgsForCall[i].arg1, fake.getArgsForCall[i].arg2, fake.getArgsForCall[i].arg3, fake.getArgsForCall[i].arg4, fake.getArgsForCall[i].arg5, fake.getArgsForCall[i].arg6, fake.getArgsForCall[i].arg7
 }
 
 func (fake *FakeFactory) GetReturns(result1 exec.Step) {
@@ -115,21 +226,22 @@ func (fake *FakeFactory) GetReturnsOnCall(i int, result1 exec.Step) {
 	}{result1}
 }
 
-func (fake *FakeFactory) Put(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 exec.StepMetadata, arg5 db.ContainerMetadata, arg6 exec.PutDelegate) exec.Step {
+func (fake *FakeFactory) Put(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 *exec.BuildVariables, arg5 exec.StepMetadata, arg6 db.ContainerMetadata, arg7 exec.PutDelegate) exec.Step {
 	fake.putMutex.Lock()
 	ret, specificReturn := fake.putReturnsOnCall[len(fake.putArgsForCall)]
 	fake.putArgsForCall = append(fake.putArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 atc.Plan
 		arg3 db.Build
-		arg4 exec.StepMetadata
-		arg5 db.ContainerMetadata
-		arg6 exec.PutDelegate
-	}{arg1, arg2, arg3, arg4, arg5, arg6})
-	fake.recordInvocation("Put", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+		arg4 *exec.BuildVariables
+		arg5 exec.StepMetadata
+		arg6 db.ContainerMetadata
+		arg7 exec.PutDelegate
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.recordInvocation("Put", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
 	fake.putMutex.Unlock()
 	if fake.PutStub != nil {
-		return fake.PutStub(arg1, arg2, arg3, arg4, arg5, arg6)
+		return fake.PutStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	}
 	if specificReturn {
 		return ret.result1
@@ -143,10 +255,10 @@ func (fake *FakeFactory) PutCallCount() int {
 	return len(fake.putArgsForCall)
 }
 
-func (fake *FakeFactory) PutArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, exec.StepMetadata, db.ContainerMetadata, exec.PutDelegate) {
+func (fake *FakeFactory) PutArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, exec.StepMetadata, db.ContainerMetadata, exec.PutDelegate) {
 	fake.putMutex.RLock()
 	defer fake.putMutex.RUnlock()
-	return fake.putArgsForCall[i].arg1, fake.putArgsForCall[i].arg2, fake.putArgsForCall[i].arg3, fake.putArgsForCall[i].arg4, fake.putArgsForCall[i].arg5, fake.putArgsForCall[i].arg6
+	return fake.putArgsForCall[i].arg1, fake.putArgsForCall[i].arg2, fake.putArgsForCall[i].arg3, fake.putArgsForCall[i].arg4, fake.putArgsForCall[i].arg5, fake.putArgsForCall[i].arg6, fake.putArgsForCall[i].arg7
 }
 
 func (fake *FakeFactory) PutReturns(result1 exec.Step) {
@@ -168,20 +280,21 @@ func (fake *FakeFactory) PutReturnsOnCall(i int, result1 exec.Step) {
 	}{result1}
 }
 
-func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 db.ContainerMetadata, arg5 exec.TaskDelegate) exec.Step {
+func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 *exec.BuildVariables, arg5 db.ContainerMetadata, arg6 exec.TaskDelegate) exec.Step {
 	fake.taskMutex.Lock()
 	ret, specificReturn := fake.taskReturnsOnCall[len(fake.taskArgsForCall)]
 	fake.taskArgsForCall = append(fake.taskArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 atc.Plan
 		arg3 db.Build
-		arg4 db.ContainerMetadata
-		arg5 exec.TaskDelegate
-	}{arg1, arg2, arg3, arg4, arg5})
-	fake.recordInvocation("Task", []interface{}{arg1, arg2, arg3, arg4, arg5})
+		arg4 *exec.BuildVariables
+		arg5 db.ContainerMetadata
+		arg6 exec.TaskDelegate
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("Task", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.taskMutex.Unlock()
 	if fake.TaskStub != nil {
-		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5)
+		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -195,10 +308,10 @@ func (fake *FakeFactory) TaskCallCount() int {
 	return len(fake.taskArgsForCall)
 }
 
-func (fake *FakeFactory) TaskArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, db.ContainerMetadata, exec.TaskDelegate) {
+func (fake *FakeFactory) TaskArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, db.ContainerMetadata, exec.TaskDelegate) {
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
-	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5
+	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6
 }
 
 func (fake *FakeFactory) TaskReturns(result1 exec.Step) {
@@ -220,6 +333,372 @@ func (fake *FakeFactory) TaskReturnsOnCall(i int, result1 exec.Step) {
 	}{result1}
 }
 
+func (fake *FakeFactory) ReplayTask(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 *exec.BuildVariables, arg5 db.ContainerMetadata, arg6 exec.TaskDelegate) exec.Step {
+	fake.replayTaskMutex.Lock()
+	ret, specificReturn := fake.replayTaskReturnsOnCall[len(fake.replayTaskArgsForCall)]
+	fake.replayTaskArgsForCall = append(fake.replayTaskArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 *exec.BuildVariables
+		arg5 db.ContainerMetadata
+		arg6 exec.TaskDelegate
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("ReplayTask", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.replayTaskMutex.Unlock()
+	if fake.ReplayTaskStub != nil {
+		return fake.ReplayTaskStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.replayTaskReturns.result1
+}
+
+func (fake *FakeFactory) ReplayTaskCallCount() int {
+	fake.replayTaskMutex.RLock()
+	defer fake.replayTaskMutex.RUnlock()
+	return len(fake.replayTaskArgsForCall)
+}
+
+func (fake *FakeFactory) ReplayTaskArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, db.ContainerMetadata, exec.TaskDelegate) {
+	fake.replayTaskMutex.RLock()
+	defer fake.replayTaskMutex.RUnlock()
+	return fake.replayTaskArgsForCall[i].arg1, fake.replayTaskArgsForCall[i].arg2, fake.replayTaskArgsForCall[i].arg3, fake.replayTaskArgsForCall[i].arg4, fake.replayTaskArgsForCall[i].arg5, fake.replayTaskArgsForCall[i].arg6
+}
+
+func (fake *FakeFactory) ReplayTaskReturns(result1 exec.Step) {
+	fake.ReplayTaskStub = nil
+	fake.replayTaskReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) ReplayTaskReturnsOnCall(i int, result1 exec.Step) {
+	fake.ReplayTaskStub = nil
+	if fake.replayTaskReturnsOnCall == nil {
+		fake.replayTaskReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.replayTaskReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) SaveCache(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 exec.BuildStepDelegate) exec.Step {
+	fake.saveCacheMutex.Lock()
+	ret, specificReturn := fake.saveCacheReturnsOnCall[len(fake.saveCacheArgsForCall)]
+	fake.saveCacheArgsForCall = append(fake.saveCacheArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.BuildStepDelegate
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SaveCache", []interface{}{arg1, arg2, arg3, arg4})
+	fake.saveCacheMutex.Unlock()
+	if fake.SaveCacheStub != nil {
+		return fake.SaveCacheStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveCacheReturns.result1
+}
+
+func (fake *FakeFactory) SaveCacheCallCount() int {
+	fake.saveCacheMutex.RLock()
+	defer fake.saveCacheMutex.RUnlock()
+	return len(fake.saveCacheArgsForCall)
+}
+
+func (fake *FakeFactory) SaveCacheArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, exec.BuildStepDelegate) {
+	fake.saveCacheMutex.RLock()
+	defer fake.saveCacheMutex.RUnlock()
+	return fake.saveCacheArgsForCall[i].arg1, fake.saveCacheArgsForCall[i].arg2, fake.saveCacheArgsForCall[i].arg3, fake.saveCacheArgsForCall[i].arg4
+}
+
+func (fake *FakeFactory) SaveCacheReturns(result1 exec.Step) {
+	fake.SaveCacheStub = nil
+	fake.saveCacheReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) SaveCacheReturnsOnCall(i int, result1 exec.Step) {
+	fake.SaveCacheStub = nil
+	if fake.saveCacheReturnsOnCall == nil {
+		fake.saveCacheReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.saveCacheReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) RestoreCache(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 exec.BuildStepDelegate) exec.Step {
+	fake.restoreCacheMutex.Lock()
+	ret, specificReturn := fake.restoreCacheReturnsOnCall[len(fake.restoreCacheArgsForCall)]
+	fake.restoreCacheArgsForCall = append(fake.restoreCacheArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.BuildStepDelegate
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RestoreCache", []interface{}{arg1, arg2, arg3, arg4})
+	fake.restoreCacheMutex.Unlock()
+	if fake.RestoreCacheStub != nil {
+		return fake.RestoreCacheStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.restoreCacheReturns.result1
+}
+
+func (fake *FakeFactory) RestoreCacheCallCount() int {
+	fake.restoreCacheMutex.RLock()
+	defer fake.restoreCacheMutex.RUnlock()
+	return len(fake.restoreCacheArgsForCall)
+}
+
+func (fake *FakeFactory) RestoreCacheArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, exec.BuildStepDelegate) {
+	fake.restoreCacheMutex.RLock()
+	defer fake.restoreCacheMutex.RUnlock()
+	return fake.restoreCacheArgsForCall[i].arg1, fake.restoreCacheArgsForCall[i].arg2, fake.restoreCacheArgsForCall[i].arg3, fake.restoreCacheArgsForCall[i].arg4
+}
+
+func (fake *FakeFactory) RestoreCacheReturns(result1 exec.Step) {
+	fake.RestoreCacheStub = nil
+	fake.restoreCacheReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) RestoreCacheReturnsOnCall(i int, result1 exec.Step) {
+	fake.RestoreCacheStub = nil
+	if fake.restoreCacheReturnsOnCall == nil {
+		fake.restoreCacheReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.restoreCacheReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) Promote(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 *exec.BuildVariables, arg5 exec.StepMetadata, arg6 db.ContainerMetadata, arg7 exec.PromoteDelegate) exec.Step {
+	fake.promoteMutex.Lock()
+	ret, specificReturn := fake.promoteReturnsOnCall[len(fake.promoteArgsForCall)]
+	fake.promoteArgsForCall = append(fake.promoteArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 *exec.BuildVariables
+		arg5 exec.StepMetadata
+		arg6 db.ContainerMetadata
+		arg7 exec.PromoteDelegate
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.recordInvocation("Promote", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.promoteMutex.Unlock()
+	if fake.PromoteStub != nil {
+		return fake.PromoteStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.promoteReturns.result1
+}
+
+func (fake *FakeFactory) PromoteCallCount() int {
+	fake.promoteMutex.RLock()
+	defer fake.promoteMutex.RUnlock()
+	return len(fake.promoteArgsForCall)
+}
+
+func (fake *FakeFactory) PromoteArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, exec.StepMetadata, db.ContainerMetadata, exec.PromoteDelegate) {
+	fake.promoteMutex.RLock()
+	defer fake.promoteMutex.RUnlock()
+	return fake.promoteArgsForCall[i].arg1, fake.promoteArgsForCall[i].arg2, fake.promoteArgsForCall[i].arg3, fake.promoteArgsForCall[i].arg4, fake.promoteArgsForCall[i].arg5, fake.promoteArgsForCall[i].arg6, fake.promoteArgsForCall[i].arg7
+}
+
+func (fake *FakeFactory) PromoteReturns(result1 exec.Step) {
+	fake.PromoteStub = nil
+	fake.promoteReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) PromoteReturnsOnCall(i int, result1 exec.Step) {
+	fake.PromoteStub = nil
+	if fake.promoteReturnsOnCall == nil {
+		fake.promoteReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.promoteReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) SetVar(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 exec.SetVarDelegate) exec.Step {
+	fake.setVarMutex.Lock()
+	ret, specificReturn := fake.setVarReturnsOnCall[len(fake.setVarArgsForCall)]
+	fake.setVarArgsForCall = append(fake.setVarArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.SetVarDelegate
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SetVar", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setVarMutex.Unlock()
+	if fake.SetVarStub != nil {
+		return fake.SetVarStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setVarReturns.result1
+}
+
+func (fake *FakeFactory) SetVarCallCount() int {
+	fake.setVarMutex.RLock()
+	defer fake.setVarMutex.RUnlock()
+	return len(fake.setVarArgsForCall)
+}
+
+func (fake *FakeFactory) SetVarArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, exec.SetVarDelegate) {
+	fake.setVarMutex.RLock()
+	defer fake.setVarMutex.RUnlock()
+	return fake.setVarArgsForCall[i].arg1, fake.setVarArgsForCall[i].arg2, fake.setVarArgsForCall[i].arg3, fake.setVarArgsForCall[i].arg4
+}
+
+func (fake *FakeFactory) SetVarReturns(result1 exec.Step) {
+	fake.SetVarStub = nil
+	fake.setVarReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) SetVarReturnsOnCall(i int, result1 exec.Step) {
+	fake.SetVarStub = nil
+	if fake.setVarReturnsOnCall == nil {
+		fake.setVarReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.setVarReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) WaitFor(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 *exec.BuildVariables, arg5 db.ContainerMetadata, arg6 exec.WaitDelegate) exec.Step {
+	fake.waitForMutex.Lock()
+	ret, specificReturn := fake.waitForReturnsOnCall[len(fake.waitForArgsForCall)]
+	fake.waitForArgsForCall = append(fake.waitForArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 *exec.BuildVariables
+		arg5 db.ContainerMetadata
+		arg6 exec.WaitDelegate
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("WaitFor", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.waitForMutex.Unlock()
+	if fake.WaitForStub != nil {
+		return fake.WaitForStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.waitForReturns.result1
+}
+
+func (fake *FakeFactory) WaitForCallCount() int {
+	fake.waitForMutex.RLock()
+	defer fake.waitForMutex.RUnlock()
+	return len(fake.waitForArgsForCall)
+}
+
+func (fake *FakeFactory) WaitForArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, db.ContainerMetadata, exec.WaitDelegate) {
+	fake.waitForMutex.RLock()
+	defer fake.waitForMutex.RUnlock()
+	return fake.waitForArgsForCall[i].arg1, fake.waitForArgsForCall[i].arg2, fake.waitForArgsForCall[i].arg3, fake.waitForArgsForCall[i].arg4, fake.waitForArgsForCall[i].arg5, fake.waitForArgsForCall[i].arg6
+}
+
+func (fake *FakeFactory) WaitForReturns(result1 exec.Step) {
+	fake.WaitForStub = nil
+	fake.waitForReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) WaitForReturnsOnCall(i int, result1 exec.Step) {
+	fake.WaitForStub = nil
+	if fake.waitForReturnsOnCall == nil {
+		fake.waitForReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.waitForReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) RerunTask(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 *exec.BuildVariables, arg5 db.ContainerMetadata, arg6 exec.TaskDelegate) exec.Step {
+	fake.rerunTaskMutex.Lock()
+	ret, specificReturn := fake.rerunTaskReturnsOnCall[len(fake.rerunTaskArgsForCall)]
+	fake.rerunTaskArgsForCall = append(fake.rerunTaskArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 *exec.BuildVariables
+		arg5 db.ContainerMetadata
+		arg6 exec.TaskDelegate
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("RerunTask", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.rerunTaskMutex.Unlock()
+	if fake.RerunTaskStub != nil {
+		return fake.RerunTaskStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.rerunTaskReturns.result1
+}
+
+func (fake *FakeFactory) RerunTaskCallCount() int {
+	fake.rerunTaskMutex.RLock()
+	defer fake.rerunTaskMutex.RUnlock()
+	return len(fake.rerunTaskArgsForCall)
+}
+
+func (fake *FakeFactory) RerunTaskArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, *exec.BuildVariables, db.ContainerMetadata, exec.TaskDelegate) {
+	fake.rerunTaskMutex.RLock()
+	defer fake.rerunTaskMutex.RUnlock()
+	return fake.rerunTaskArgsForCall[i].arg1, fake.rerunTaskArgsForCall[i].arg2, fake.rerunTaskArgsForCall[i].arg3, fake.rerunTaskArgsForCall[i].arg4, fake.rerunTaskArgsForCall[i].arg5, fake.rerunTaskArgsForCall[i].arg6
+}
+
+func (fake *FakeFactory) RerunTaskReturns(result1 exec.Step) {
+	fake.RerunTaskStub = nil
+	fake.rerunTaskReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeFactory) RerunTaskReturnsOnCall(i int, result1 exec.Step) {
+	fake.RerunTaskStub = nil
+	if fake.rerunTaskReturnsOnCall == nil {
+		fake.rerunTaskReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.rerunTaskReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -229,6 +708,20 @@ func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	defer fake.putMutex.RUnlock()
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
+	fake.replayTaskMutex.RLock()
+	defer fake.replayTaskMutex.RUnlock()
+	fake.saveCacheMutex.RLock()
+	defer fake.saveCacheMutex.RUnlock()
+	fake.restoreCacheMutex.RLock()
+	defer fake.restoreCacheMutex.RUnlock()
+	fake.promoteMutex.RLock()
+	defer fake.promoteMutex.RUnlock()
+	fake.setVarMutex.RLock()
+	defer fake.setVarMutex.RUnlock()
+	fake.waitForMutex.RLock()
+	defer fake.waitForMutex.RUnlock()
+	fake.rerunTaskMutex.RLock()
+	defer fake.rerunTaskMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
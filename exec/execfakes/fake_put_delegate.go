@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 )
@@ -40,11 +41,17 @@ type FakePutDelegate struct {
 	stderrReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
-	ErroredStub        func(lager.Logger, string)
+	ErroredStub        func(lager.Logger, string, atc.ErrorKind)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 atc.ErrorKind
+	}
+	TerminatedDuringCleanupStub        func(lager.Logger)
+	terminatedDuringCleanupMutex       sync.RWMutex
+	terminatedDuringCleanupArgsForCall []struct {
+		arg1 lager.Logger
 	}
 	FinishedStub        func(lager.Logger, exec.ExitStatus, exec.VersionInfo)
 	finishedMutex       sync.RWMutex
@@ -185,16 +192,17 @@ func (fake *FakePutDelegate) StderrReturnsOnCall(i int, result1 io.Writer) {
 	}{result1}
 }
 
-func (fake *FakePutDelegate) Errored(arg1 lager.Logger, arg2 string) {
+func (fake *FakePutDelegate) Errored(arg1 lager.Logger, arg2 string, arg3 atc.ErrorKind) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("Errored", []interface{}{arg1, arg2})
+		arg3 atc.ErrorKind
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Errored", []interface{}{arg1, arg2, arg3})
 	fake.erroredMutex.Unlock()
 	if fake.ErroredStub != nil {
-		fake.ErroredStub(arg1, arg2)
+		fake.ErroredStub(arg1, arg2, arg3)
 	}
 }
 
@@ -204,10 +212,34 @@ func (fake *FakePutDelegate) ErroredCallCount() int {
 	return len(fake.erroredArgsForCall)
 }
 
-func (fake *FakePutDelegate) ErroredArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakePutDelegate) ErroredArgsForCall(i int) (lager.Logger, string, atc.ErrorKind) {
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
-	return fake.erroredArgsForCall[i].arg1, fake.erroredArgsForCall[i].arg2
+	return fake.erroredArgsForCall[i].arg1, fake.erroredArgsForCall[i].arg2, fake.erroredArgsForCall[i].arg3
+}
+
+func (fake *FakePutDelegate) TerminatedDuringCleanup(arg1 lager.Logger) {
+	fake.terminatedDuringCleanupMutex.Lock()
+	fake.terminatedDuringCleanupArgsForCall = append(fake.terminatedDuringCleanupArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("TerminatedDuringCleanup", []interface{}{arg1})
+	fake.terminatedDuringCleanupMutex.Unlock()
+	if fake.TerminatedDuringCleanupStub != nil {
+		fake.TerminatedDuringCleanupStub(arg1)
+	}
+}
+
+func (fake *FakePutDelegate) TerminatedDuringCleanupCallCount() int {
+	fake.terminatedDuringCleanupMutex.RLock()
+	defer fake.terminatedDuringCleanupMutex.RUnlock()
+	return len(fake.terminatedDuringCleanupArgsForCall)
+}
+
+func (fake *FakePutDelegate) TerminatedDuringCleanupArgsForCall(i int) lager.Logger {
+	fake.terminatedDuringCleanupMutex.RLock()
+	defer fake.terminatedDuringCleanupMutex.RUnlock()
+	return fake.terminatedDuringCleanupArgsForCall[i].arg1
 }
 
 func (fake *FakePutDelegate) Finished(arg1 lager.Logger, arg2 exec.ExitStatus, arg3 exec.VersionInfo) {
@@ -247,6 +279,8 @@ func (fake *FakePutDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stderrMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
+	fake.terminatedDuringCleanupMutex.RLock()
+	defer fake.terminatedDuringCleanupMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
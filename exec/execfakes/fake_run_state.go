@@ -56,12 +56,18 @@ type FakeRunState struct {
 	readUserInputReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ReadPlanOutputStub        func(atc.PlanID, io.Writer)
+	ReadPlanOutputStub        func(atc.PlanID, io.Writer) error
 	readPlanOutputMutex       sync.RWMutex
 	readPlanOutputArgsForCall []struct {
 		arg1 atc.PlanID
 		arg2 io.Writer
 	}
+	readPlanOutputReturns struct {
+		result1 error
+	}
+	readPlanOutputReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SendPlanOutputStub        func(atc.PlanID, exec.OutputHandler) error
 	sendPlanOutputMutex       sync.RWMutex
 	sendPlanOutputArgsForCall []struct {
@@ -74,6 +80,33 @@ type FakeRunState struct {
 	sendPlanOutputReturnsOnCall map[int]struct {
 		result1 error
 	}
+	VariablesStub        func() *exec.BuildVariables
+	variablesMutex       sync.RWMutex
+	variablesArgsForCall []struct{}
+	variablesReturns     struct {
+		result1 *exec.BuildVariables
+	}
+	variablesReturnsOnCall map[int]struct {
+		result1 *exec.BuildVariables
+	}
+	TakeReusableContainerStub        func(string) (exec.ReusableContainer, bool)
+	takeReusableContainerMutex       sync.RWMutex
+	takeReusableContainerArgsForCall []struct {
+		arg1 string
+	}
+	takeReusableContainerReturns struct {
+		result1 exec.ReusableContainer
+		result2 bool
+	}
+	takeReusableContainerReturnsOnCall map[int]struct {
+		result1 exec.ReusableContainer
+		result2 bool
+	}
+	LeaveReusableContainerStub        func(exec.ReusableContainer)
+	leaveReusableContainerMutex       sync.RWMutex
+	leaveReusableContainerArgsForCall []struct {
+		arg1 exec.ReusableContainer
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -266,8 +299,9 @@ func (fake *FakeRunState) ReadUserInputReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeRunState) ReadPlanOutput(arg1 atc.PlanID, arg2 io.Writer) {
+func (fake *FakeRunState) ReadPlanOutput(arg1 atc.PlanID, arg2 io.Writer) error {
 	fake.readPlanOutputMutex.Lock()
+	ret, specificReturn := fake.readPlanOutputReturnsOnCall[len(fake.readPlanOutputArgsForCall)]
 	fake.readPlanOutputArgsForCall = append(fake.readPlanOutputArgsForCall, struct {
 		arg1 atc.PlanID
 		arg2 io.Writer
@@ -275,8 +309,12 @@ func (fake *FakeRunState) ReadPlanOutput(arg1 atc.PlanID, arg2 io.Writer) {
 	fake.recordInvocation("ReadPlanOutput", []interface{}{arg1, arg2})
 	fake.readPlanOutputMutex.Unlock()
 	if fake.ReadPlanOutputStub != nil {
-		fake.ReadPlanOutputStub(arg1, arg2)
+		return fake.ReadPlanOutputStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	return fake.readPlanOutputReturns.result1
 }
 
 func (fake *FakeRunState) ReadPlanOutputCallCount() int {
@@ -291,6 +329,25 @@ func (fake *FakeRunState) ReadPlanOutputArgsForCall(i int) (atc.PlanID, io.Write
 	return fake.readPlanOutputArgsForCall[i].arg1, fake.readPlanOutputArgsForCall[i].arg2
 }
 
+func (fake *FakeRunState) ReadPlanOutputReturns(result1 error) {
+	fake.ReadPlanOutputStub = nil
+	fake.readPlanOutputReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRunState) ReadPlanOutputReturnsOnCall(i int, result1 error) {
+	fake.ReadPlanOutputStub = nil
+	if fake.readPlanOutputReturnsOnCall == nil {
+		fake.readPlanOutputReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.readPlanOutputReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRunState) SendPlanOutput(arg1 atc.PlanID, arg2 exec.OutputHandler) error {
 	fake.sendPlanOutputMutex.Lock()
 	ret, specificReturn := fake.sendPlanOutputReturnsOnCall[len(fake.sendPlanOutputArgsForCall)]
@@ -340,6 +397,121 @@ func (fake *FakeRunState) SendPlanOutputReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeRunState) Variables() *exec.BuildVariables {
+	fake.variablesMutex.Lock()
+	ret, specificReturn := fake.variablesReturnsOnCall[len(fake.variablesArgsForCall)]
+	fake.variablesArgsForCall = append(fake.variablesArgsForCall, struct{}{})
+	fake.recordInvocation("Variables", []interface{}{})
+	fake.variablesMutex.Unlock()
+	if fake.VariablesStub != nil {
+		return fake.VariablesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.variablesReturns.result1
+}
+
+func (fake *FakeRunState) VariablesCallCount() int {
+	fake.variablesMutex.RLock()
+	defer fake.variablesMutex.RUnlock()
+	return len(fake.variablesArgsForCall)
+}
+
+func (fake *FakeRunState) VariablesReturns(result1 *exec.BuildVariables) {
+	fake.VariablesStub = nil
+	fake.variablesReturns = struct {
+		result1 *exec.BuildVariables
+	}{result1}
+}
+
+func (fake *FakeRunState) VariablesReturnsOnCall(i int, result1 *exec.BuildVariables) {
+	fake.VariablesStub = nil
+	if fake.variablesReturnsOnCall == nil {
+		fake.variablesReturnsOnCall = make(map[int]struct {
+			result1 *exec.BuildVariables
+		})
+	}
+	fake.variablesReturnsOnCall[i] = struct {
+		result1 *exec.BuildVariables
+	}{result1}
+}
+
+func (fake *FakeRunState) TakeReusableContainer(arg1 string) (exec.ReusableContainer, bool) {
+	fake.takeReusableContainerMutex.Lock()
+	ret, specificReturn := fake.takeReusableContainerReturnsOnCall[len(fake.takeReusableContainerArgsForCall)]
+	fake.takeReusableContainerArgsForCall = append(fake.takeReusableContainerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("TakeReusableContainer", []interface{}{arg1})
+	fake.takeReusableContainerMutex.Unlock()
+	if fake.TakeReusableContainerStub != nil {
+		return fake.TakeReusableContainerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.takeReusableContainerReturns.result1, fake.takeReusableContainerReturns.result2
+}
+
+func (fake *FakeRunState) TakeReusableContainerCallCount() int {
+	fake.takeReusableContainerMutex.RLock()
+	defer fake.takeReusableContainerMutex.RUnlock()
+	return len(fake.takeReusableContainerArgsForCall)
+}
+
+func (fake *FakeRunState) TakeReusableContainerArgsForCall(i int) string {
+	fake.takeReusableContainerMutex.RLock()
+	defer fake.takeReusableContainerMutex.RUnlock()
+	return fake.takeReusableContainerArgsForCall[i].arg1
+}
+
+func (fake *FakeRunState) TakeReusableContainerReturns(result1 exec.ReusableContainer, result2 bool) {
+	fake.TakeReusableContainerStub = nil
+	fake.takeReusableContainerReturns = struct {
+		result1 exec.ReusableContainer
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeRunState) TakeReusableContainerReturnsOnCall(i int, result1 exec.ReusableContainer, result2 bool) {
+	fake.TakeReusableContainerStub = nil
+	if fake.takeReusableContainerReturnsOnCall == nil {
+		fake.takeReusableContainerReturnsOnCall = make(map[int]struct {
+			result1 exec.ReusableContainer
+			result2 bool
+		})
+	}
+	fake.takeReusableContainerReturnsOnCall[i] = struct {
+		result1 exec.ReusableContainer
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeRunState) LeaveReusableContainer(arg1 exec.ReusableContainer) {
+	fake.leaveReusableContainerMutex.Lock()
+	fake.leaveReusableContainerArgsForCall = append(fake.leaveReusableContainerArgsForCall, struct {
+		arg1 exec.ReusableContainer
+	}{arg1})
+	fake.recordInvocation("LeaveReusableContainer", []interface{}{arg1})
+	fake.leaveReusableContainerMutex.Unlock()
+	if fake.LeaveReusableContainerStub != nil {
+		fake.LeaveReusableContainerStub(arg1)
+	}
+}
+
+func (fake *FakeRunState) LeaveReusableContainerCallCount() int {
+	fake.leaveReusableContainerMutex.RLock()
+	defer fake.leaveReusableContainerMutex.RUnlock()
+	return len(fake.leaveReusableContainerArgsForCall)
+}
+
+func (fake *FakeRunState) LeaveReusableContainerArgsForCall(i int) exec.ReusableContainer {
+	fake.leaveReusableContainerMutex.RLock()
+	defer fake.leaveReusableContainerMutex.RUnlock()
+	return fake.leaveReusableContainerArgsForCall[i].arg1
+}
+
 func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -357,6 +529,12 @@ func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	defer fake.readPlanOutputMutex.RUnlock()
 	fake.sendPlanOutputMutex.RLock()
 	defer fake.sendPlanOutputMutex.RUnlock()
+	fake.variablesMutex.RLock()
+	defer fake.variablesMutex.RUnlock()
+	fake.takeReusableContainerMutex.RLock()
+	defer fake.takeReusableContainerMutex.RUnlock()
+	fake.leaveReusableContainerMutex.RLock()
+	defer fake.leaveReusableContainerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -41,11 +41,17 @@ type FakeTaskDelegate struct {
 	stderrReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
-	ErroredStub        func(lager.Logger, string)
+	ErroredStub        func(lager.Logger, string, atc.ErrorKind)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 atc.ErrorKind
+	}
+	TerminatedDuringCleanupStub        func(lager.Logger)
+	terminatedDuringCleanupMutex       sync.RWMutex
+	terminatedDuringCleanupArgsForCall []struct {
+		arg1 lager.Logger
 	}
 	InitializingStub        func(lager.Logger, atc.TaskConfig)
 	initializingMutex       sync.RWMutex
@@ -65,6 +71,18 @@ type FakeTaskDelegate struct {
 		arg1 lager.Logger
 		arg2 exec.ExitStatus
 	}
+	NoisyNeighborsStub        func(lager.Logger, exec.NoisyNeighbors)
+	noisyNeighborsMutex       sync.RWMutex
+	noisyNeighborsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 exec.NoisyNeighbors
+	}
+	WaitingForWorkerStub        func(lager.Logger, int)
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -197,16 +215,17 @@ func (fake *FakeTaskDelegate) StderrReturnsOnCall(i int, result1 io.Writer) {
 	}{result1}
 }
 
-func (fake *FakeTaskDelegate) Errored(arg1 lager.Logger, arg2 string) {
+func (fake *FakeTaskDelegate) Errored(arg1 lager.Logger, arg2 string, arg3 atc.ErrorKind) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("Errored", []interface{}{arg1, arg2})
+		arg3 atc.ErrorKind
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Errored", []interface{}{arg1, arg2, arg3})
 	fake.erroredMutex.Unlock()
 	if fake.ErroredStub != nil {
-		fake.ErroredStub(arg1, arg2)
+		fake.ErroredStub(arg1, arg2, arg3)
 	}
 }
 
@@ -216,10 +235,34 @@ func (fake *FakeTaskDelegate) ErroredCallCount() int {
 	return len(fake.erroredArgsForCall)
 }
 
-func (fake *FakeTaskDelegate) ErroredArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeTaskDelegate) ErroredArgsForCall(i int) (lager.Logger, string, atc.ErrorKind) {
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
-	return fake.erroredArgsForCall[i].arg1, fake.erroredArgsForCall[i].arg2
+	return fake.erroredArgsForCall[i].arg1, fake.erroredArgsForCall[i].arg2, fake.erroredArgsForCall[i].arg3
+}
+
+func (fake *FakeTaskDelegate) TerminatedDuringCleanup(arg1 lager.Logger) {
+	fake.terminatedDuringCleanupMutex.Lock()
+	fake.terminatedDuringCleanupArgsForCall = append(fake.terminatedDuringCleanupArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("TerminatedDuringCleanup", []interface{}{arg1})
+	fake.terminatedDuringCleanupMutex.Unlock()
+	if fake.TerminatedDuringCleanupStub != nil {
+		fake.TerminatedDuringCleanupStub(arg1)
+	}
+}
+
+func (fake *FakeTaskDelegate) TerminatedDuringCleanupCallCount() int {
+	fake.terminatedDuringCleanupMutex.RLock()
+	defer fake.terminatedDuringCleanupMutex.RUnlock()
+	return len(fake.terminatedDuringCleanupArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) TerminatedDuringCleanupArgsForCall(i int) lager.Logger {
+	fake.terminatedDuringCleanupMutex.RLock()
+	defer fake.terminatedDuringCleanupMutex.RUnlock()
+	return fake.terminatedDuringCleanupArgsForCall[i].arg1
 }
 
 func (fake *FakeTaskDelegate) Initializing(arg1 lager.Logger, arg2 atc.TaskConfig) {
@@ -297,6 +340,56 @@ func (fake *FakeTaskDelegate) FinishedArgsForCall(i int) (lager.Logger, exec.Exi
 	return fake.finishedArgsForCall[i].arg1, fake.finishedArgsForCall[i].arg2
 }
 
+func (fake *FakeTaskDelegate) NoisyNeighbors(arg1 lager.Logger, arg2 exec.NoisyNeighbors) {
+	fake.noisyNeighborsMutex.Lock()
+	fake.noisyNeighborsArgsForCall = append(fake.noisyNeighborsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 exec.NoisyNeighbors
+	}{arg1, arg2})
+	fake.recordInvocation("NoisyNeighbors", []interface{}{arg1, arg2})
+	fake.noisyNeighborsMutex.Unlock()
+	if fake.NoisyNeighborsStub != nil {
+		fake.NoisyNeighborsStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) NoisyNeighborsCallCount() int {
+	fake.noisyNeighborsMutex.RLock()
+	defer fake.noisyNeighborsMutex.RUnlock()
+	return len(fake.noisyNeighborsArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) NoisyNeighborsArgsForCall(i int) (lager.Logger, exec.NoisyNeighbors) {
+	fake.noisyNeighborsMutex.RLock()
+	defer fake.noisyNeighborsMutex.RUnlock()
+	return fake.noisyNeighborsArgsForCall[i].arg1, fake.noisyNeighborsArgsForCall[i].arg2
+}
+
+func (fake *FakeTaskDelegate) WaitingForWorker(arg1 lager.Logger, arg2 int) {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("WaitingForWorker", []interface{}{arg1, arg2})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) WaitingForWorkerArgsForCall(i int) (lager.Logger, int) {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return fake.waitingForWorkerArgsForCall[i].arg1, fake.waitingForWorkerArgsForCall[i].arg2
+}
+
 func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -308,12 +401,18 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stderrMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
+	fake.terminatedDuringCleanupMutex.RLock()
+	defer fake.terminatedDuringCleanupMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
 	fake.startingMutex.RLock()
 	defer fake.startingMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
+	fake.noisyNeighborsMutex.RLock()
+	defer fake.noisyNeighborsMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -17,6 +17,7 @@ type Factory interface {
 		lager.Logger,
 		atc.Plan,
 		db.Build,
+		*BuildVariables,
 		StepMetadata,
 		db.ContainerMetadata,
 		GetDelegate,
@@ -27,6 +28,7 @@ type Factory interface {
 		lager.Logger,
 		atc.Plan,
 		db.Build,
+		*BuildVariables,
 		StepMetadata,
 		db.ContainerMetadata,
 		PutDelegate,
@@ -37,9 +39,77 @@ type Factory interface {
 		lager.Logger,
 		atc.Plan,
 		db.Build,
+		*BuildVariables,
 		db.ContainerMetadata,
 		TaskDelegate,
 	) Step
+
+	// ReplayTask constructs a step that recreates a Task step's container
+	// without running it.
+	ReplayTask(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		*BuildVariables,
+		db.ContainerMetadata,
+		TaskDelegate,
+	) Step
+
+	// RerunTask constructs a Task step which reuses the outputs of the task's
+	// container in the build being rerun, if it is still around.
+	RerunTask(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		*BuildVariables,
+		db.ContainerMetadata,
+		TaskDelegate,
+	) Step
+
+	// SaveCache constructs a SaveCache step.
+	SaveCache(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		BuildStepDelegate,
+	) Step
+
+	// RestoreCache constructs a RestoreCache step.
+	RestoreCache(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		BuildStepDelegate,
+	) Step
+
+	// Promote constructs a Promote step.
+	Promote(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		*BuildVariables,
+		StepMetadata,
+		db.ContainerMetadata,
+		PromoteDelegate,
+	) Step
+
+	// SetVar constructs a SetVar step.
+	SetVar(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		SetVarDelegate,
+	) Step
+
+	// WaitFor constructs a Wait step.
+	WaitFor(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		*BuildVariables,
+		db.ContainerMetadata,
+		WaitDelegate,
+	) Step
 }
 
 // StepMetadata is used to inject metadata to make available to the step when
@@ -56,7 +126,8 @@ type BuildStepDelegate interface {
 	Stdout() io.Writer
 	Stderr() io.Writer
 
-	Errored(lager.Logger, string)
+	Errored(lager.Logger, string, atc.ErrorKind)
+	TerminatedDuringCleanup(lager.Logger)
 }
 
 // Privileged is used to indicate whether the given step should run with
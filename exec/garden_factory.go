@@ -4,10 +4,13 @@ import (
 	"crypto/sha1"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/artifactcache"
+	"github.com/concourse/atc/artifactscan"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
@@ -20,14 +23,40 @@ type gardenFactory struct {
 	resourceFactory        resource.ResourceFactory
 	dbResourceCacheFactory db.ResourceCacheFactory
 	variablesFactory       creds.VariablesFactory
+	artifactCache          artifactcache.Store
+	checkpoints            artifactcache.Store
+	noisyNeighbors         NoisyNeighborDetector
+	artifactScanner        artifactscan.Scanner
+	artifactScanPolicy     artifactscan.Policy
+	defaultTimeouts        DefaultTimeouts
+	middlewares            []StepMiddleware
 }
 
+// DefaultTimeouts are the timeouts of steps not run within a timeout
+// configured by the pipeline, so that a hung resource cannot hold on to its
+// containers indefinitely. Zero means no timeout.
+type DefaultTimeouts struct {
+	Get time.Duration
+	Put time.Duration
+}
+
+// NewGardenFactory constructs a Factory running steps on workers. Each step
+// it constructs logs its error and is traced, and is wrapped by the given
+// middlewares within that.
 func NewGardenFactory(
 	workerClient worker.Client,
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
 	variablesFactory creds.VariablesFactory,
+	artifactCache artifactcache.Store,
+	checkpoints artifactcache.Store,
+	tracer Tracer,
+	noisyNeighbors NoisyNeighborDetector,
+	artifactScanner artifactscan.Scanner,
+	artifactScanPolicy artifactscan.Policy,
+	defaultTimeouts DefaultTimeouts,
+	middlewares ...StepMiddleware,
 ) Factory {
 	return &gardenFactory{
 		workerClient:           workerClient,
@@ -35,6 +64,18 @@ func NewGardenFactory(
 		resourceFactory:        resourceFactory,
 		dbResourceCacheFactory: dbResourceCacheFactory,
 		variablesFactory:       variablesFactory,
+		artifactCache:          artifactCache,
+		checkpoints:            checkpoints,
+		noisyNeighbors:         noisyNeighbors,
+		artifactScanner:        artifactScanner,
+		artifactScanPolicy:     artifactScanPolicy,
+		defaultTimeouts:        defaultTimeouts,
+
+		// every step logs its error, and is traced within it
+		middlewares: append([]StepMiddleware{
+			LogErrorMiddleware,
+			TracingMiddleware(tracer),
+		}, middlewares...),
 	}
 }
 
@@ -42,13 +83,14 @@ func (factory *gardenFactory) Get(
 	logger lager.Logger,
 	plan atc.Plan,
 	build db.Build,
+	buildVariables *BuildVariables,
 	stepMetadata StepMetadata,
 	workerMetadata db.ContainerMetadata,
 	delegate GetDelegate,
 ) Step {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("get")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := factory.variables(build, buildVariables)
 
 	getStep := NewGetStep(
 		build,
@@ -60,6 +102,7 @@ func (factory *gardenFactory) Get(
 		creds.NewParams(variables, plan.Get.Params),
 		NewVersionSourceFromPlan(plan.Get),
 		plan.Get.Tags,
+		plan.Get.Path,
 
 		delegate,
 		factory.resourceFetcher,
@@ -73,20 +116,24 @@ func (factory *gardenFactory) Get(
 		creds.NewVersionedResourceTypes(variables, plan.Get.VersionedResourceTypes),
 	)
 
-	return LogError(getStep, delegate)
+	return factory.withDefaultTimeout(
+		factory.wrap(getStep, "get", plan.Get.Name, plan, build, delegate),
+		factory.defaultTimeouts.Get,
+	)
 }
 
 func (factory *gardenFactory) Put(
 	logger lager.Logger,
 	plan atc.Plan,
 	build db.Build,
+	buildVariables *BuildVariables,
 	stepMetadata StepMetadata,
 	workerMetadata db.ContainerMetadata,
 	delegate PutDelegate,
 ) Step {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("put")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := factory.variables(build, buildVariables)
 
 	putStep := NewPutStep(
 		build,
@@ -107,16 +154,60 @@ func (factory *gardenFactory) Put(
 		creds.NewVersionedResourceTypes(variables, plan.Put.VersionedResourceTypes),
 	)
 
-	return LogError(putStep, delegate)
+	return factory.withDefaultTimeout(
+		factory.wrap(putStep, "put", plan.Put.Name, plan, build, delegate),
+		factory.defaultTimeouts.Put,
+	)
 }
 
 func (factory *gardenFactory) Task(
 	logger lager.Logger,
 	plan atc.Plan,
 	build db.Build,
+	buildVariables *BuildVariables,
+	containerMetadata db.ContainerMetadata,
+	delegate TaskDelegate,
+) Step {
+	taskStep := factory.taskStep(plan, build, buildVariables, containerMetadata, delegate)
+
+	return factory.wrap(taskStep, "task", plan.Task.Name, plan, build, delegate)
+}
+
+func (factory *gardenFactory) ReplayTask(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	buildVariables *BuildVariables,
 	containerMetadata db.ContainerMetadata,
 	delegate TaskDelegate,
 ) Step {
+	taskStep := factory.taskStep(plan, build, buildVariables, containerMetadata, delegate)
+
+	return factory.wrap(NewReplayTaskStep(taskStep, time.Now().Add(ReplayContainerTTL)), "task", plan.Task.Name, plan, build, delegate)
+}
+
+func (factory *gardenFactory) RerunTask(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	buildVariables *BuildVariables,
+	containerMetadata db.ContainerMetadata,
+	delegate TaskDelegate,
+) Step {
+	taskStep := factory.taskStep(plan, build, buildVariables, containerMetadata, delegate)
+
+	rerunStep := NewRerunTaskStep(taskStep, build.RerunOf())
+
+	return factory.wrap(rerunStep, "task", plan.Task.Name, plan, build, delegate)
+}
+
+func (factory *gardenFactory) taskStep(
+	plan atc.Plan,
+	build db.Build,
+	buildVariables *BuildVariables,
+	containerMetadata db.ContainerMetadata,
+	delegate TaskDelegate,
+) *TaskStep {
 	workingDirectory := factory.taskWorkingDirectory(worker.ArtifactName(plan.Task.Name))
 	containerMetadata.WorkingDirectory = workingDirectory
 
@@ -139,9 +230,9 @@ func (factory *gardenFactory) Task(
 		Stderr:   delegate.Stderr(),
 	}
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := factory.variables(build, buildVariables)
 
-	taskStep := NewTaskStep(
+	return NewTaskStep(
 		Privileged(plan.Task.Privileged),
 		taskConfigSource,
 		plan.Task.Tags,
@@ -150,6 +241,7 @@ func (factory *gardenFactory) Task(
 
 		workingDirectory,
 		plan.Task.ImageArtifactName,
+		plan.Task.ReuseContainer,
 
 		delegate,
 
@@ -163,12 +255,174 @@ func (factory *gardenFactory) Task(
 
 		creds.NewVersionedResourceTypes(variables, plan.Task.VersionedResourceTypes),
 		variables,
+		factory.noisyNeighbors,
+		factory.artifactScanner,
+		factory.artifactScanPolicy,
+		factory.checkpoints,
+	)
+}
+
+func (factory *gardenFactory) SaveCache(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	delegate BuildStepDelegate,
+) Step {
+	saveCacheStep := NewSaveCacheStep(
+		plan.ID,
+		worker.ArtifactName(plan.SaveCache.Name),
+		plan.SaveCache.Key,
+		build.TeamID(),
+		factory.artifactCache,
+		delegate,
+	)
+
+	return factory.wrap(saveCacheStep, "save_cache", plan.SaveCache.Name, plan, build, delegate)
+}
+
+func (factory *gardenFactory) RestoreCache(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	delegate BuildStepDelegate,
+) Step {
+	restoreCacheStep := NewRestoreCacheStep(
+		plan.ID,
+		worker.ArtifactName(plan.RestoreCache.Name),
+		plan.RestoreCache.Key,
+		build.TeamID(),
+		factory.artifactCache,
+		delegate,
+	)
+
+	return factory.wrap(restoreCacheStep, "restore_cache", plan.RestoreCache.Name, plan, build, delegate)
+}
+
+func (factory *gardenFactory) WaitFor(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	buildVariables *BuildVariables,
+	containerMetadata db.ContainerMetadata,
+	delegate WaitDelegate,
+) Step {
+	variables := factory.variables(build, buildVariables)
+
+	var condition WaitCondition
+	if plan.WaitFor.HTTP != nil {
+		condition = HTTPWaitCondition{
+			URL:    creds.NewString(variables, plan.WaitFor.HTTP.URL),
+			Status: plan.WaitFor.HTTP.Status,
+		}
+	} else {
+		condition = NewResourceWaitCondition(
+			build,
+			plan.ID,
+			plan.WaitFor.Type,
+			creds.NewSource(variables, plan.WaitFor.Source),
+			plan.WaitFor.Tags,
+			containerMetadata,
+			creds.NewVersionedResourceTypes(variables, plan.WaitFor.VersionedResourceTypes),
+			factory.resourceFactory,
+			delegate,
+		)
+	}
+
+	waitStep := NewWaitStep(plan.ID, condition, plan.WaitFor.Interval, delegate)
+
+	return factory.wrap(waitStep, "wait_for", plan.WaitFor.Name, plan, build, delegate)
+}
+
+func (factory *gardenFactory) Promote(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	buildVariables *BuildVariables,
+	stepMetadata StepMetadata,
+	workerMetadata db.ContainerMetadata,
+	delegate PromoteDelegate,
+) Step {
+	workerMetadata.WorkingDirectory = resource.ResourcesDir("put")
+
+	variables := factory.variables(build, buildVariables)
+
+	promoteStep := NewPromoteStep(
+		build,
+
+		plan.Promote.Name,
+		plan.Promote.Type,
+		plan.Promote.Resource,
+		creds.NewSource(variables, plan.Promote.Source),
+		creds.NewParams(variables, plan.Promote.Params),
+		plan.Promote.Tags,
+
+		plan.Promote.FromType,
+		plan.Promote.FromName,
+		creds.NewSource(variables, plan.Promote.FromSource),
+		creds.NewParams(variables, plan.Promote.FromParams),
+		plan.Promote.FromVersion,
+
+		delegate,
+		factory.resourceFetcher,
+		factory.resourceFactory,
+		factory.dbResourceCacheFactory,
+		plan.ID,
+		workerMetadata,
+		stepMetadata,
+
+		creds.NewVersionedResourceTypes(variables, plan.Promote.VersionedResourceTypes),
 	)
 
-	return LogError(taskStep, delegate)
+	return factory.wrap(promoteStep, "promote", plan.Promote.Name, plan, build, delegate)
+}
+
+func (factory *gardenFactory) SetVar(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	delegate SetVarDelegate,
+) Step {
+	setVarStep := NewSetVarStep(
+		plan.ID,
+		plan.SetVar.Name,
+		plan.SetVar.Value,
+		factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()),
+		delegate,
+	)
+
+	return factory.wrap(setVarStep, "set_var", plan.SetVar.Name, plan, build, delegate)
+}
+
+// variables returns the variables a step of the build interpolates: those
+// set by earlier steps of the build, and the credentials of its pipeline.
+func (factory *gardenFactory) variables(build db.Build, buildVariables *BuildVariables) creds.Variables {
+	credentials := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	return buildVariables.Scope(credentials)
 }
 
 func (factory *gardenFactory) taskWorkingDirectory(sourceName worker.ArtifactName) string {
 	sum := sha1.Sum([]byte(sourceName))
 	return filepath.Join("/tmp", "build", fmt.Sprintf("%x", sum[:4]))
 }
+
+// withDefaultTimeout applies the default timeout of the step's type, unless
+// it is zero.
+func (factory *gardenFactory) withDefaultTimeout(step Step, timeout time.Duration) Step {
+	if timeout == 0 {
+		return step
+	}
+
+	return DefaultTimeout(step, timeout)
+}
+
+// wrap applies the factory's middlewares to the step.
+func (factory *gardenFactory) wrap(step Step, stepType string, stepName string, plan atc.Plan, build db.Build, delegate BuildStepDelegate) Step {
+	return ChainStepMiddleware(StepInfo{
+		Type: stepType,
+		Name: stepName,
+
+		Plan:     plan,
+		Build:    build,
+		Delegate: delegate,
+	}, step, factory.middlewares...)
+}
@@ -35,6 +35,7 @@ type GetStep struct {
 	params        creds.Params
 	versionSource VersionSource
 	tags          atc.Tags
+	path          string
 
 	delegate GetDelegate
 
@@ -61,6 +62,7 @@ func NewGetStep(
 	params creds.Params,
 	versionSource VersionSource,
 	tags atc.Tags,
+	path string,
 
 	delegate GetDelegate,
 
@@ -84,6 +86,7 @@ func NewGetStep(
 		params:        params,
 		versionSource: versionSource,
 		tags:          tags,
+		path:          path,
 
 		delegate: delegate,
 
@@ -121,7 +124,8 @@ func NewGetStep(
 // fetched ArtifactSource is initialized, thus warming the worker's cache.
 //
 // At the end, the resulting ArtifactSource (either from using the cache or
-// fetching the resource) is registered under the step's SourceName.
+// fetching the resource) is registered under the step's SourceName, and at
+// the step's path if one is configured.
 func (step *GetStep) Run(ctx context.Context, state RunState) error {
 	logger := lagerctx.FromContext(ctx)
 
@@ -188,11 +192,18 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
-	state.Artifacts().RegisterSource(worker.ArtifactName(step.name), &getArtifactSource{
+	artifactSource := &getArtifactSource{
 		logger:           logger,
 		resourceInstance: resourceInstance,
 		versionedSource:  versionedSource,
-	})
+		teamID:           step.teamID,
+	}
+
+	if step.path != "" {
+		state.Artifacts().RegisterSourceAt(worker.ArtifactName(step.name), step.path, artifactSource)
+	} else {
+		state.Artifacts().RegisterSource(worker.ArtifactName(step.name), artifactSource)
+	}
 
 	if step.resource != "" {
 		err := step.build.SaveInput(db.BuildInput{
@@ -229,12 +240,13 @@ type getArtifactSource struct {
 	logger           lager.Logger
 	resourceInstance resource.ResourceInstance
 	versionedSource  resource.VersionedSource
+	teamID           int
 }
 
 // VolumeOn locates the cache for the GetStep's resource and version on the
 // given worker.
 func (s *getArtifactSource) VolumeOn(worker worker.Worker) (worker.Volume, bool, error) {
-	return s.resourceInstance.FindOn(s.logger.Session("volume-on"), worker)
+	return s.resourceInstance.FindOn(s.logger.Session("volume-on"), worker, s.teamID)
 }
 
 // StreamTo streams the resource's data to the destination.
@@ -249,6 +261,16 @@ func (s *getArtifactSource) StreamTo(destination worker.ArtifactDestination) err
 	return destination.StreamIn(".", out)
 }
 
+// SourceVolume returns the volume the resource was fetched into.
+func (s *getArtifactSource) SourceVolume() (worker.Volume, string, bool) {
+	volume := s.versionedSource.Volume()
+	if volume == nil {
+		return nil, "", false
+	}
+
+	return volume, ".", true
+}
+
 // StreamFile streams a single file out of the resource.
 func (s *getArtifactSource) StreamFile(path string) (io.ReadCloser, error) {
 	out, err := s.versionedSource.StreamOut(path)
@@ -38,6 +38,7 @@ var _ = Describe("GetStep", func() {
 		fakeDBResourceCacheFactory *dbfakes.FakeResourceCacheFactory
 		fakeVariablesFactory       *credsfakes.FakeVariablesFactory
 		variables                  creds.Variables
+		buildVariables             *exec.BuildVariables
 		fakeBuild                  *dbfakes.FakeBuild
 		fakeDelegate               *execfakes.FakeGetDelegate
 		getPlan                    *atc.GetPlan
@@ -78,6 +79,8 @@ var _ = Describe("GetStep", func() {
 		}
 		fakeVariablesFactory.NewVariablesReturns(variables)
 
+		buildVariables = exec.NewBuildVariables()
+
 		artifactRepository = worker.NewArtifactRepository()
 		state = new(execfakes.FakeRunState)
 		state.ArtifactsReturns(artifactRepository)
@@ -112,7 +115,7 @@ var _ = Describe("GetStep", func() {
 			VersionedResourceTypes: resourceTypes,
 		}
 
-		factory = exec.NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, fakeVariablesFactory)
+		factory = exec.NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, fakeVariablesFactory, nil, nil, exec.NoopTracer{}, nil, nil, "", exec.DefaultTimeouts{})
 
 		fakeDelegate = new(execfakes.FakeGetDelegate)
 	})
@@ -125,6 +128,7 @@ var _ = Describe("GetStep", func() {
 				Get: getPlan,
 			},
 			fakeBuild,
+			buildVariables,
 			stepMetadata,
 			containerMetadata,
 			fakeDelegate,
@@ -155,11 +159,11 @@ var _ = Describe("GetStep", func() {
 			atc.Version{"some-version": "some-value"},
 			atc.Source{"some": "super-secret-source"},
 			atc.Params{"some-param": "some-value"},
-			creds.NewVersionedResourceTypes(variables, resourceTypes),
+			creds.NewVersionedResourceTypes(buildVariables.Scope(variables), resourceTypes),
 			nil,
 			db.NewBuildStepContainerOwner(buildID, atc.PlanID(planID)),
 		)))
-		Expect(actualResourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, resourceTypes)))
+		Expect(actualResourceTypes).To(Equal(creds.NewVersionedResourceTypes(buildVariables.Scope(variables), resourceTypes)))
 		Expect(delegate).To(Equal(fakeDelegate))
 		expectedLockName := fmt.Sprintf("%x",
 			sha256.Sum256([]byte(
@@ -170,6 +174,17 @@ var _ = Describe("GetStep", func() {
 		Expect(resourceInstance.LockName("fake-worker")).To(Equal(expectedLockName))
 	})
 
+	Context("when a variable has been set by an earlier step of the build", func() {
+		BeforeEach(func() {
+			buildVariables.Set("source-param", "some-build-value", false)
+		})
+
+		It("interpolates it in place of the credential", func() {
+			_, _, _, _, _, _, resourceInstance, _, _ := fakeResourceFetcher.FetchArgsForCall(0)
+			Expect(resourceInstance.Source()).To(Equal(atc.Source{"some": "some-build-value"}))
+		})
+	})
+
 	Context("when fetching resource succeeds", func() {
 		BeforeEach(func() {
 			fakeVersionedSource.VersionReturns(atc.Version{"some": "version"})
@@ -227,6 +242,26 @@ var _ = Describe("GetStep", func() {
 			})
 		})
 
+		It("does not register the source at a path", func() {
+			_, found := artifactRepository.PathFor("some-name")
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the plan has a path", func() {
+			BeforeEach(func() {
+				getPlan.Path = "src/some-name"
+			})
+
+			It("registers the source at the path", func() {
+				_, found := artifactRepository.SourceFor("some-name")
+				Expect(found).To(BeTrue())
+
+				path, found := artifactRepository.PathFor("some-name")
+				Expect(found).To(BeTrue())
+				Expect(path).To(Equal("src/some-name"))
+			})
+		})
+
 		Describe("the source registered with the repository", func() {
 			var artifactSource worker.ArtifactSource
 
@@ -29,6 +29,14 @@ func (step LogErrorStep) Run(ctx context.Context, state RunState) error {
 
 	runErr := step.Step.Run(ctx, state)
 
+	if runErr == context.DeadlineExceeded && cleanupBudgetExceeded(ctx) {
+		logger.Info("terminated-during-cleanup")
+
+		step.delegate.TerminatedDuringCleanup(logger)
+
+		return runErr
+	}
+
 	var message string
 	switch runErr {
 	case nil:
@@ -41,9 +49,11 @@ func (step LogErrorStep) Run(ctx context.Context, state RunState) error {
 		message = runErr.Error()
 	}
 
-	logger.Info("errored", lager.Data{"error": runErr.Error()})
+	kind := ClassifyError(runErr)
+
+	logger.Info("errored", lager.Data{"error": runErr.Error(), "kind": kind})
 
-	step.delegate.Errored(logger, message)
+	step.delegate.Errored(logger, message, kind)
 
 	return runErr
 }
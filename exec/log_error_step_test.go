@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/concourse/atc"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
 
@@ -71,8 +72,9 @@ var _ = Describe("LogErrorStep", func() {
 
 			It("logs 'interrupted'", func() {
 				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
-				_, message := fakeDelegate.ErroredArgsForCall(0)
+				_, message, kind := fakeDelegate.ErroredArgsForCall(0)
 				Expect(message).To(Equal("interrupted"))
+				Expect(kind).To(Equal(atc.ErrorKindAborted))
 			})
 		})
 
@@ -87,8 +89,9 @@ var _ = Describe("LogErrorStep", func() {
 
 			It("logs 'timeout exceeded'", func() {
 				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
-				_, message := fakeDelegate.ErroredArgsForCall(0)
+				_, message, kind := fakeDelegate.ErroredArgsForCall(0)
 				Expect(message).To(Equal("timeout exceeded"))
+				Expect(kind).To(Equal(atc.ErrorKindTimeout))
 			})
 		})
 
@@ -105,8 +108,22 @@ var _ = Describe("LogErrorStep", func() {
 
 			It("logs the error", func() {
 				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
-				_, message := fakeDelegate.ErroredArgsForCall(0)
+				_, message, kind := fakeDelegate.ErroredArgsForCall(0)
 				Expect(message).To(Equal("disaster"))
+				Expect(kind).To(Equal(atc.ErrorKindUnknown))
+			})
+		})
+
+		Context("when the inner step returns an error of a known kind", func() {
+			BeforeEach(func() {
+				fakeStep.RunReturns(worker.ErrNoWorkers)
+			})
+
+			It("logs the error with its kind", func() {
+				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
+				_, message, kind := fakeDelegate.ErroredArgsForCall(0)
+				Expect(message).To(Equal("no workers"))
+				Expect(kind).To(Equal(atc.ErrorKindWorkerUnavailable))
 			})
 		})
 	})
@@ -0,0 +1,114 @@
+package exec
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// minNoisyNeighborSamples is the number of previous runs of a step needed
+// before its duration is considered to have a baseline.
+const minNoisyNeighborSamples = 3
+
+// StepRun is a single observation of how long a step took to run on a worker,
+// along with the number of other containers that were running on the worker
+// at the time.
+type StepRun struct {
+	Key        string
+	WorkerName string
+	Duration   time.Duration
+	Neighbors  int
+}
+
+// NoisyNeighbors describes a step run that was slower than usual while its
+// worker was busier than usual.
+type NoisyNeighbors struct {
+	WorkerName        string
+	Duration          time.Duration
+	BaselineDuration  time.Duration
+	Neighbors         int
+	BaselineNeighbors int
+}
+
+//go:generate counterfeiter . NoisyNeighborDetector
+
+// NoisyNeighborDetector correlates regressions in a step's duration with the
+// number of containers co-located with it, so that interference from other
+// workloads can be told apart from the step itself getting slower.
+type NoisyNeighborDetector interface {
+	Observe(StepRun) (NoisyNeighbors, bool)
+}
+
+type noisyNeighborDetector struct {
+	window    int
+	threshold float64
+
+	history  map[string][]StepRun
+	historyL sync.Mutex
+}
+
+// NewNoisyNeighborDetector returns a detector which compares each run against
+// the last window runs of the same step. A run is flagged when both its
+// duration and its number of neighbors exceed their usual values by the given
+// threshold, e.g. 1.5 for 50% more.
+func NewNoisyNeighborDetector(window int, threshold float64) NoisyNeighborDetector {
+	return &noisyNeighborDetector{
+		window:    window,
+		threshold: threshold,
+
+		history: map[string][]StepRun{},
+	}
+}
+
+func (detector *noisyNeighborDetector) Observe(run StepRun) (NoisyNeighbors, bool) {
+	detector.historyL.Lock()
+	defer detector.historyL.Unlock()
+
+	previous := detector.history[run.Key]
+
+	runs := append(previous, run)
+	if len(runs) > detector.window {
+		runs = runs[len(runs)-detector.window:]
+	}
+
+	detector.history[run.Key] = runs
+
+	if len(previous) < minNoisyNeighborSamples {
+		return NoisyNeighbors{}, false
+	}
+
+	baselineDuration, baselineNeighbors := baseline(previous)
+
+	slower := float64(run.Duration) > float64(baselineDuration)*detector.threshold
+	busier := run.Neighbors > baselineNeighbors &&
+		float64(run.Neighbors) > float64(baselineNeighbors)*detector.threshold
+
+	if !slower || !busier {
+		return NoisyNeighbors{}, false
+	}
+
+	return NoisyNeighbors{
+		WorkerName:        run.WorkerName,
+		Duration:          run.Duration,
+		BaselineDuration:  baselineDuration,
+		Neighbors:         run.Neighbors,
+		BaselineNeighbors: baselineNeighbors,
+	}, true
+}
+
+// baseline returns the median duration and number of neighbors of the runs,
+// so that a single outlier does not skew what is considered usual.
+func baseline(runs []StepRun) (time.Duration, int) {
+	durations := make([]time.Duration, len(runs))
+	neighbors := make([]int, len(runs))
+
+	for i, run := range runs {
+		durations[i] = run.Duration
+		neighbors[i] = run.Neighbors
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	sort.Ints(neighbors)
+
+	return durations[len(durations)/2], neighbors[len(neighbors)/2]
+}
@@ -0,0 +1,96 @@
+package exec_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/exec"
+)
+
+var _ = Describe("NoisyNeighborDetector", func() {
+	var detector exec.NoisyNeighborDetector
+
+	run := func(duration time.Duration, neighbors int) exec.StepRun {
+		return exec.StepRun{
+			Key:        "some-job/some-step",
+			WorkerName: "some-worker",
+			Duration:   duration,
+			Neighbors:  neighbors,
+		}
+	}
+
+	BeforeEach(func() {
+		detector = exec.NewNoisyNeighborDetector(5, 1.5)
+	})
+
+	Context("when the step has not run enough times to have a baseline", func() {
+		BeforeEach(func() {
+			detector.Observe(run(time.Minute, 2))
+			detector.Observe(run(time.Minute, 2))
+		})
+
+		It("does not flag the run", func() {
+			_, detected := detector.Observe(run(10*time.Minute, 20))
+			Expect(detected).To(BeFalse())
+		})
+	})
+
+	Context("when the step has a baseline", func() {
+		BeforeEach(func() {
+			detector.Observe(run(time.Minute, 2))
+			detector.Observe(run(70*time.Second, 3))
+			detector.Observe(run(50*time.Second, 2))
+		})
+
+		It("flags runs that are slower while the worker is busier", func() {
+			interference, detected := detector.Observe(run(3*time.Minute, 8))
+			Expect(detected).To(BeTrue())
+			Expect(interference).To(Equal(exec.NoisyNeighbors{
+				WorkerName:        "some-worker",
+				Duration:          3 * time.Minute,
+				BaselineDuration:  time.Minute,
+				Neighbors:         8,
+				BaselineNeighbors: 2,
+			}))
+		})
+
+		It("does not flag runs that are slower while the worker is as busy as usual", func() {
+			_, detected := detector.Observe(run(3*time.Minute, 2))
+			Expect(detected).To(BeFalse())
+		})
+
+		It("does not flag runs that are as fast as usual while the worker is busier", func() {
+			_, detected := detector.Observe(run(time.Minute, 8))
+			Expect(detected).To(BeFalse())
+		})
+
+		It("does not compare against runs of other steps", func() {
+			other := run(3*time.Minute, 8)
+			other.Key = "some-job/some-other-step"
+
+			_, detected := detector.Observe(other)
+			Expect(detected).To(BeFalse())
+		})
+	})
+
+	Context("when the step has run more times than the window", func() {
+		BeforeEach(func() {
+			for i := 0; i < 5; i++ {
+				detector.Observe(run(3*time.Minute, 8))
+			}
+
+			for i := 0; i < 3; i++ {
+				detector.Observe(run(time.Minute, 2))
+			}
+		})
+
+		It("only compares against the most recent runs", func() {
+			interference, detected := detector.Observe(run(3*time.Minute, 8))
+			Expect(detected).To(BeTrue())
+			Expect(interference.BaselineDuration).To(Equal(time.Minute))
+			Expect(interference.BaselineNeighbors).To(Equal(2))
+		})
+	})
+})
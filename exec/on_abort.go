@@ -24,7 +24,8 @@ func OnAbort(step Step, hook Step) OnAbortStep {
 // the first step is ready.
 //
 // If the first step aborts (that is, it gets interrupted), the second
-// step is executed. If the second step errors, its error is returned.
+// step is executed, bounded by the cleanup budget if one is configured. If the
+// second step errors, its error is returned.
 func (o OnAbortStep) Run(ctx context.Context, state RunState) error {
 	stepRunErr := o.step.Run(ctx, state)
 	if stepRunErr == nil {
@@ -33,7 +34,10 @@ func (o OnAbortStep) Run(ctx context.Context, state RunState) error {
 
 	if stepRunErr == context.Canceled {
 		// run only on abort, not timeout
-		o.hook.Run(context.Background(), state)
+		hookCtx, cancel := cleanupContext(ctx)
+		defer cancel()
+
+		o.hook.Run(hookCtx, state)
 	}
 
 	return stepRunErr
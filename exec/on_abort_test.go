@@ -3,6 +3,7 @@ package exec_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -56,6 +57,52 @@ var _ = Describe("On Abort Step", func() {
 			Expect(stepErr).To(Equal(context.Canceled))
 			Expect(hook.RunCallCount()).To(Equal(1))
 		})
+
+		It("runs the abort hook with a context that is not canceled", func() {
+			hookCtx, _ := hook.RunArgsForCall(0)
+			Expect(hookCtx.Err()).ToNot(HaveOccurred())
+
+			_, hasDeadline := hookCtx.Deadline()
+			Expect(hasDeadline).To(BeFalse())
+		})
+
+		Context("when a cleanup budget is configured", func() {
+			BeforeEach(func() {
+				ctx = exec.WithCleanupBudget(ctx, time.Minute)
+			})
+
+			It("runs the abort hook with a deadline of the cleanup budget", func() {
+				hookCtx, _ := hook.RunArgsForCall(0)
+				Expect(hookCtx.Err()).ToNot(HaveOccurred())
+
+				deadline, hasDeadline := hookCtx.Deadline()
+				Expect(hasDeadline).To(BeTrue())
+				Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), time.Second))
+			})
+
+			Context("when the abort hook exceeds the cleanup budget", func() {
+				var fakeDelegate *execfakes.FakeBuildStepDelegate
+
+				BeforeEach(func() {
+					ctx = exec.WithCleanupBudget(ctx, 10*time.Millisecond)
+
+					hook.RunStub = func(ctx context.Context, state exec.RunState) error {
+						<-ctx.Done()
+						return ctx.Err()
+					}
+
+					fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+
+					onAbortStep = exec.OnAbort(step, exec.LogError(hook, fakeDelegate))
+				})
+
+				It("reports that the hook was terminated during cleanup", func() {
+					Expect(stepErr).To(Equal(context.Canceled))
+					Expect(fakeDelegate.TerminatedDuringCleanupCallCount()).To(Equal(1))
+					Expect(fakeDelegate.ErroredCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Context("when the step succeeds", func() {
@@ -24,7 +24,8 @@ func OnFailure(firstStep Step, secondStep Step) OnFailureStep {
 // the first step is ready.
 //
 // If the first step fails (that is, its Success result is false), the second
-// step is executed. If the second step errors, its error is returned.
+// step is executed. If the second step errors, its error is returned. If the
+// first step was retried, the second step is told which attempt failed last.
 func (o OnFailureStep) Run(ctx context.Context, state RunState) error {
 	err := o.step.Run(ctx, state)
 	if err != nil {
@@ -32,7 +33,7 @@ func (o OnFailureStep) Run(ctx context.Context, state RunState) error {
 	}
 
 	if !o.step.Succeeded() {
-		return o.hook.Run(ctx, state)
+		return o.hook.Run(hookContext(ctx, o.step), state)
 	}
 
 	return nil
@@ -72,6 +72,26 @@ var _ = Describe("On Failure Step", func() {
 		})
 	})
 
+	Context("when the step is retried", func() {
+		var attempt1, attempt2 *execfakes.FakeStep
+
+		BeforeEach(func() {
+			attempt1 = new(execfakes.FakeStep)
+			attempt2 = new(execfakes.FakeStep)
+
+			onFailureStep = exec.OnFailure(exec.Retry(new(execfakes.FakeRetryDelegate), attempt1, attempt2), hook)
+		})
+
+		It("tells the hook which attempt failed last", func() {
+			Expect(hook.RunCallCount()).To(Equal(1))
+
+			runCtx, _ := hook.RunArgsForCall(0)
+			attempt, ok := exec.AttemptFromContext(runCtx)
+			Expect(ok).To(BeTrue())
+			Expect(attempt).To(Equal(exec.StepAttempt{Number: 2, Max: 2}))
+		})
+	})
+
 	Context("when the step errors", func() {
 		disaster := errors.New("disaster")
 
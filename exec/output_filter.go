@@ -0,0 +1,161 @@
+package exec
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/concourse/atc/worker"
+)
+
+// filteredArtifactSource restricts an ArtifactSource to the subset of its
+// files matching any of the configured path patterns. It is used for task
+// outputs configured with `paths`, so that scratch data produced alongside
+// the real output is never streamed to subsequent steps.
+type filteredArtifactSource struct {
+	source   worker.ArtifactSource
+	patterns []string
+}
+
+func newFilteredArtifactSource(source worker.ArtifactSource, patterns []string) worker.ArtifactSource {
+	return &filteredArtifactSource{
+		source:   source,
+		patterns: patterns,
+	}
+}
+
+func (src *filteredArtifactSource) StreamTo(destination worker.ArtifactDestination) error {
+	return src.source.StreamTo(filteringDestination{
+		destination: destination,
+		patterns:    src.patterns,
+	})
+}
+
+func (src *filteredArtifactSource) StreamFile(filename string) (io.ReadCloser, error) {
+	if !matchesAnyOutputPath(src.patterns, filename) {
+		return nil, FileNotFoundError{Path: filename}
+	}
+
+	return src.source.StreamFile(filename)
+}
+
+// VolumeOn never finds a volume, as the underlying volume contains files
+// that have been filtered out. Consumers will stream the filtered subset in
+// instead.
+func (src *filteredArtifactSource) VolumeOn(w worker.Worker) (worker.Volume, bool, error) {
+	return nil, false, nil
+}
+
+type filteringDestination struct {
+	destination worker.ArtifactDestination
+	patterns    []string
+}
+
+func (dest filteringDestination) StreamIn(dst string, tgzStream io.Reader) error {
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(filterTarStream(tgzStream, pw, dest.patterns))
+	}()
+
+	err := dest.destination.StreamIn(dst, pr)
+
+	// unblock the filtering goroutine if the destination stopped reading early
+	pr.Close()
+
+	return err
+}
+
+func filterTarStream(tgzStream io.Reader, out io.Writer, patterns []string) error {
+	gzReader, err := gzip.NewReader(tgzStream)
+	if err != nil {
+		return err
+	}
+
+	defer gzReader.Close()
+
+	tarReader := tar.NewReader(gzReader)
+
+	gzWriter := gzip.NewWriter(out)
+	tarWriter := tar.NewWriter(gzWriter)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !matchesAnyOutputPath(patterns, header.Name) {
+			continue
+		}
+
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(tarWriter, tarReader)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	return gzWriter.Close()
+}
+
+func matchesAnyOutputPath(patterns []string, name string) bool {
+	name = strings.Trim(strings.TrimPrefix(name, "./"), "/")
+
+	for _, pattern := range patterns {
+		if matchOutputPath(strings.Trim(pattern, "/"), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchOutputPath matches a slash-separated path against a pattern using the
+// syntax of path.Match for each segment, with the addition of `**` matching
+// zero or more segments.
+func matchOutputPath(pattern string, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern []string, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(name) == 0 {
+		return false
+	}
+
+	matched, err := path.Match(pattern[0], name[0])
+	if err != nil || !matched {
+		return false
+	}
+
+	return matchSegments(pattern[1:], name[1:])
+}
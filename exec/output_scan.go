@@ -0,0 +1,58 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/concourse/atc/artifactscan"
+	"github.com/concourse/atc/worker"
+)
+
+// OutputScanFailedError is returned when the artifact scanner finds anything
+// in a task output and the scan policy is to fail.
+type OutputScanFailedError struct {
+	Output   string
+	Findings []string
+}
+
+// Error prints a human-friendly message listing the findings.
+func (err OutputScanFailedError) Error() string {
+	return fmt.Sprintf("output '%s' failed scan: %s", err.Output, strings.Join(err.Findings, ", "))
+}
+
+// scanOutput streams the artifact source through the scanner.
+func scanOutput(ctx context.Context, scanner artifactscan.Scanner, name string, source worker.ArtifactSource) (artifactscan.Result, error) {
+	dest := &scanningDestination{
+		ctx:     ctx,
+		scanner: scanner,
+		name:    name,
+	}
+
+	err := source.StreamTo(dest)
+	if err != nil {
+		return artifactscan.Result{}, err
+	}
+
+	return dest.result, nil
+}
+
+type scanningDestination struct {
+	ctx     context.Context
+	scanner artifactscan.Scanner
+	name    string
+
+	result artifactscan.Result
+}
+
+func (dest *scanningDestination) StreamIn(dst string, tgzStream io.Reader) error {
+	result, err := dest.scanner.Scan(dest.ctx, dest.name, tgzStream)
+	if err != nil {
+		return err
+	}
+
+	dest.result = result
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package exec
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/worker"
+)
+
+// OutputSizeExceededError is returned when the files in a task output add up
+// to more than the output's configured max size. Size is how large the output
+// was measured to be, which is only a lower bound if the measuring was cut
+// short once it went over.
+type OutputSizeExceededError struct {
+	Output  string
+	MaxSize uint64
+	Size    uint64
+}
+
+// Error prints a human-friendly message including the configured limit.
+func (err OutputSizeExceededError) Error() string {
+	return fmt.Sprintf("output '%s' exceeds %s", err.Output, formatSize(err.MaxSize))
+}
+
+var errSizeLimitReached = errors.New("size limit reached")
+
+// measureOutputSize runs du in the task's container to total up the disk
+// space used by the output at the given path, so that it is measured on the
+// worker rather than streamed through the ATC. It returns false if the size
+// could not be measured this way, e.g. because the image has no du.
+func measureOutputSize(logger lager.Logger, container worker.Container, path string) (uint64, bool) {
+	stdout := new(bytes.Buffer)
+
+	process, err := container.Run(garden.ProcessSpec{
+		Path: "du",
+		Args: []string{"-sk", path},
+	}, garden.ProcessIO{
+		Stdout: stdout,
+	})
+	if err != nil {
+		logger.Debug("failed-to-run-du", lager.Data{"error": err.Error()})
+		return 0, false
+	}
+
+	status, err := process.Wait()
+	if err != nil || status != 0 {
+		logger.Debug("du-failed", lager.Data{"status": status})
+		return 0, false
+	}
+
+	fields := strings.Fields(stdout.String())
+	if len(fields) == 0 {
+		return 0, false
+	}
+
+	kilobytes, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return kilobytes * 1024, true
+}
+
+// checkOutputSize streams the artifact source, totalling the size of its
+// files, and returns OutputSizeExceededError as soon as the total exceeds
+// maxSize, without streaming the rest. It is for outputs which can't be
+// measured with measureOutputSize.
+func checkOutputSize(name string, source worker.ArtifactSource, maxSize uint64) error {
+	dest := &sizeLimitedDestination{maxSize: maxSize}
+
+	err := source.StreamTo(dest)
+	if err == errSizeLimitReached {
+		return OutputSizeExceededError{
+			Output:  name,
+			MaxSize: maxSize,
+			Size:    dest.total,
+		}
+	}
+
+	return err
+}
+
+type sizeLimitedDestination struct {
+	maxSize uint64
+	total   uint64
+}
+
+func (dest *sizeLimitedDestination) StreamIn(dst string, tgzStream io.Reader) error {
+	gzReader, err := gzip.NewReader(tgzStream)
+	if err != nil {
+		return err
+	}
+
+	defer gzReader.Close()
+
+	tarReader := tar.NewReader(gzReader)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+
+		dest.total += uint64(header.Size)
+		if dest.total > dest.maxSize {
+			return errSizeLimitReached
+		}
+	}
+}
+
+var sizeUnits = []string{"B", "KB", "MB", "GB", "TB"}
+
+func formatSize(size uint64) string {
+	value := float64(size)
+
+	unit := 0
+	for value >= 1024 && unit < len(sizeUnits)-1 {
+		value /= 1024
+		unit++
+	}
+
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0") + " " + sizeUnits[unit]
+}
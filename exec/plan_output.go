@@ -0,0 +1,171 @@
+package exec
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// planOutputBufferChunks is the number of writes a reader of a plan's output
+// may fall behind before the step waits for it to catch up, so that readers
+// of differing speeds do not hold each other up on every write.
+const planOutputBufferChunks = 256
+
+// ErrNoPlanOutputReaders is returned when writing a plan's output after every
+// one of its readers has gone away.
+var ErrNoPlanOutputReaders = errors.New("no readers left for plan output")
+
+// ErrPlanOutputInProgress is returned to a reader arriving once the output
+// has started being sent, as it would only receive the rest of it.
+var ErrPlanOutputInProgress = errors.New("plan output is already being sent")
+
+// errPlanOutputClosed is returned to a reader arriving once the output has
+// been sent, which then waits for the next send.
+var errPlanOutputClosed = errors.New("plan output has been sent")
+
+// planOutput fans a plan's output out to any number of readers, each with its
+// own buffer. Writes wait for any reader whose buffer is full, so every reader
+// receives the whole output or is told that it did not.
+type planOutput struct {
+	joined   chan struct{}
+	joinOnce sync.Once
+
+	readers  map[*planOutputReader]struct{}
+	sending  bool
+	closed   bool
+	readersL sync.Mutex
+
+	draining sync.WaitGroup
+}
+
+type planOutputReader struct {
+	chunks chan []byte
+
+	// closed when the reader gives up, so that writes stop waiting for it
+	gone chan struct{}
+
+	// set before chunks is closed
+	err error
+}
+
+func newPlanOutput() *planOutput {
+	return &planOutput{
+		joined:  make(chan struct{}),
+		readers: map[*planOutputReader]struct{}{},
+	}
+}
+
+// ReadTo streams the output to w until the output is closed or w fails. If
+// sending the output failed part way, the error is returned so that the
+// output is not mistaken for being complete.
+func (output *planOutput) ReadTo(w io.Writer) error {
+	reader, err := output.join()
+	if err != nil {
+		return err
+	}
+
+	defer output.draining.Done()
+
+	for chunk := range reader.chunks {
+		_, err := w.Write(chunk)
+		if err != nil {
+			output.leave(reader)
+			return err
+		}
+	}
+
+	return reader.err
+}
+
+// Write sends p to every reader, waiting for any whose buffer is full. It
+// only fails once there is nobody left to read the output.
+func (output *planOutput) Write(p []byte) (int, error) {
+	chunk := make([]byte, len(p))
+	copy(chunk, p)
+
+	output.readersL.Lock()
+	output.sending = true
+
+	readers := make([]*planOutputReader, 0, len(output.readers))
+	for reader := range output.readers {
+		readers = append(readers, reader)
+	}
+
+	output.readersL.Unlock()
+
+	sent := 0
+	for _, reader := range readers {
+		select {
+		case reader.chunks <- chunk:
+			sent++
+		case <-reader.gone:
+		}
+	}
+
+	if sent == 0 {
+		return 0, ErrNoPlanOutputReaders
+	}
+
+	return len(p), nil
+}
+
+// Joined is closed once the first reader has joined.
+func (output *planOutput) Joined() <-chan struct{} {
+	return output.joined
+}
+
+// Close stops sending output and waits for the readers to catch up with what
+// has been sent so far. If sending failed, the readers are given err once
+// they have caught up.
+//
+// It must not be called while a write is in progress.
+func (output *planOutput) Close(err error) {
+	output.readersL.Lock()
+
+	output.closed = true
+
+	for reader := range output.readers {
+		delete(output.readers, reader)
+		reader.err = err
+		close(reader.chunks)
+	}
+
+	output.readersL.Unlock()
+
+	output.draining.Wait()
+}
+
+func (output *planOutput) join() (*planOutputReader, error) {
+	output.readersL.Lock()
+	defer output.readersL.Unlock()
+
+	if output.closed {
+		return nil, errPlanOutputClosed
+	}
+
+	if output.sending {
+		return nil, ErrPlanOutputInProgress
+	}
+
+	reader := &planOutputReader{
+		chunks: make(chan []byte, planOutputBufferChunks),
+		gone:   make(chan struct{}),
+	}
+
+	output.readers[reader] = struct{}{}
+	output.draining.Add(1)
+
+	output.joinOnce.Do(func() {
+		close(output.joined)
+	})
+
+	return reader, nil
+}
+
+func (output *planOutput) leave(reader *planOutputReader) {
+	output.readersL.Lock()
+	delete(output.readers, reader)
+	output.readersL.Unlock()
+
+	close(reader.gone)
+}
@@ -0,0 +1,353 @@
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/worker"
+)
+
+//go:generate counterfeiter . PromoteDelegate
+
+type PromoteDelegate interface {
+	BuildStepDelegate
+
+	Finished(lager.Logger, ExitStatus, Promotion)
+}
+
+// Promotion describes a version created by promoting another resource's
+// version.
+type Promotion struct {
+	From        string
+	FromVersion atc.Version
+
+	VersionInfo
+}
+
+// PromoteVersionMissingError is returned when there is no version of the
+// resource being promoted from, i.e. it was not an input of the build.
+type PromoteVersionMissingError struct {
+	From string
+}
+
+func (err PromoteVersionMissingError) Error() string {
+	return fmt.Sprintf("no version of '%s' to promote; it must be fetched by a get step in the same job", err.From)
+}
+
+// PromoteStep copies a version of one resource to another, e.g. an image from
+// a staging registry to a production registry. The version is fetched using
+// the first resource's source, and put using the second's.
+type PromoteStep struct {
+	build db.Build
+
+	name         string
+	resourceType string
+	resource     string
+	source       creds.Source
+	params       creds.Params
+	tags         atc.Tags
+
+	fromType    string
+	fromName    string
+	fromSource  creds.Source
+	fromParams  creds.Params
+	fromVersion *atc.Version
+
+	delegate               PromoteDelegate
+	resourceFetcher        resource.Fetcher
+	resourceFactory        resource.ResourceFactory
+	dbResourceCacheFactory db.ResourceCacheFactory
+	planID                 atc.PlanID
+	containerMetadata      db.ContainerMetadata
+	stepMetadata           StepMetadata
+
+	resourceTypes creds.VersionedResourceTypes
+
+	promotion Promotion
+	succeeded bool
+}
+
+func NewPromoteStep(
+	build db.Build,
+	name string,
+	resourceType string,
+	resourceName string,
+	source creds.Source,
+	params creds.Params,
+	tags atc.Tags,
+	fromType string,
+	fromName string,
+	fromSource creds.Source,
+	fromParams creds.Params,
+	fromVersion *atc.Version,
+	delegate PromoteDelegate,
+	resourceFetcher resource.Fetcher,
+	resourceFactory resource.ResourceFactory,
+	dbResourceCacheFactory db.ResourceCacheFactory,
+	planID atc.PlanID,
+	containerMetadata db.ContainerMetadata,
+	stepMetadata StepMetadata,
+	resourceTypes creds.VersionedResourceTypes,
+) *PromoteStep {
+	return &PromoteStep{
+		build: build,
+
+		name:         name,
+		resourceType: resourceType,
+		resource:     resourceName,
+		source:       source,
+		params:       params,
+		tags:         tags,
+
+		fromType:    fromType,
+		fromName:    fromName,
+		fromSource:  fromSource,
+		fromParams:  fromParams,
+		fromVersion: fromVersion,
+
+		delegate:               delegate,
+		resourceFetcher:        resourceFetcher,
+		resourceFactory:        resourceFactory,
+		dbResourceCacheFactory: dbResourceCacheFactory,
+		planID:                 planID,
+		containerMetadata:      containerMetadata,
+		stepMetadata:           stepMetadata,
+		resourceTypes:          resourceTypes,
+	}
+}
+
+// Run fetches the build's version of the resource being promoted from, and
+// runs the destination resource's put script with it available under the
+// name of that resource, along with the rest of the build's artifacts.
+//
+// The created version is only recorded as an output of the build once both
+// scripts have succeeded, along with metadata identifying the version it was
+// promoted from, so a failure in either half leaves nothing behind. As with a
+// put, a failing script does not make Run return an error.
+func (step *PromoteStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+
+	if step.fromVersion == nil {
+		return PromoteVersionMissingError{From: step.fromName}
+	}
+
+	fetched, err := step.fetch(ctx, logger)
+	if err != nil {
+		logger.Error("failed-to-fetch-promoted-resource", err)
+
+		if err, ok := err.(resource.ErrResourceScriptFailed); ok {
+			step.delegate.Finished(logger, ExitStatus(err.ExitStatus), Promotion{})
+			return nil
+		}
+
+		return err
+	}
+
+	versionedSource, err := step.put(ctx, logger, state, fetched)
+	if err != nil {
+		logger.Error("failed-to-put-promoted-resource", err)
+
+		if err, ok := err.(resource.ErrResourceScriptFailed); ok {
+			step.delegate.Finished(logger, ExitStatus(err.ExitStatus), Promotion{})
+			return nil
+		}
+
+		return err
+	}
+
+	fromVersion := fetched.versionedSource.Version()
+
+	provenance, err := json.Marshal(fromVersion)
+	if err != nil {
+		return err
+	}
+
+	step.promotion = Promotion{
+		From:        step.fromName,
+		FromVersion: fromVersion,
+		VersionInfo: VersionInfo{
+			Version: versionedSource.Version(),
+			Metadata: append(
+				versionedSource.Metadata(),
+				atc.MetadataField{Name: "promoted_from", Value: step.fromName},
+				atc.MetadataField{Name: "promoted_from_version", Value: string(provenance)},
+			),
+		},
+	}
+
+	err = step.build.SaveOutput(
+		db.VersionedResource{
+			Resource: step.resource,
+			Type:     step.resourceType,
+			Version:  db.ResourceVersion(step.promotion.Version),
+			Metadata: db.NewResourceMetadataFields(step.promotion.Metadata),
+		},
+	)
+	if err != nil {
+		logger.Error("failed-to-save-output", err)
+		return err
+	}
+
+	state.StoreResult(step.planID, step.promotion.VersionInfo)
+
+	step.succeeded = true
+
+	step.delegate.Finished(logger, 0, step.promotion)
+
+	return nil
+}
+
+// fetch runs the get script of the resource being promoted from in a
+// container of its own.
+func (step *PromoteStep) fetch(ctx context.Context, logger lager.Logger) (*getArtifactSource, error) {
+	source, err := step.fromSource.Evaluate()
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := step.fromParams.Evaluate()
+	if err != nil {
+		return nil, err
+	}
+
+	version := *step.fromVersion
+
+	resourceCache, err := step.dbResourceCacheFactory.FindOrCreateResourceCache(
+		logger,
+		db.ForBuild(step.build.ID()),
+		step.fromType,
+		version,
+		source,
+		params,
+		step.resourceTypes,
+	)
+	if err != nil {
+		logger.Error("failed-to-create-resource-cache", err)
+		return nil, err
+	}
+
+	resourceInstance := resource.NewResourceInstance(
+		resource.ResourceType(step.fromType),
+		version,
+		source,
+		params,
+		step.resourceTypes,
+		resourceCache,
+		db.NewBuildStepContainerOwner(step.build.ID(), step.fetchPlanID()),
+	)
+
+	metadata := step.containerMetadata
+	metadata.Type = db.ContainerTypeGet
+	metadata.WorkingDirectory = resource.ResourcesDir("get")
+
+	versionedSource, err := step.resourceFetcher.Fetch(
+		ctx,
+		logger,
+		resource.Session{
+			Metadata: metadata,
+		},
+		step.tags,
+		step.build.TeamID(),
+		step.resourceTypes,
+		resourceInstance,
+		step.stepMetadata,
+		step.delegate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &getArtifactSource{
+		logger:           logger,
+		resourceInstance: resourceInstance,
+		versionedSource:  versionedSource,
+	}, nil
+}
+
+// put runs the destination resource's put script with the fetched version
+// as one of its inputs.
+func (step *PromoteStep) put(ctx context.Context, logger lager.Logger, state RunState, fetched worker.ArtifactSource) (resource.VersionedSource, error) {
+	containerSpec := worker.ContainerSpec{
+		ImageSpec: worker.ImageSpec{
+			ResourceType: step.resourceType,
+		},
+		Tags:   step.tags,
+		TeamID: step.build.TeamID(),
+
+		Dir: resource.ResourcesDir("put"),
+
+		Env: append(step.stepMetadata.Env(), attemptEnv(ctx)...),
+	}
+
+	for name, source := range state.Artifacts().AsMap() {
+		if name == worker.ArtifactName(step.fromName) {
+			continue
+		}
+
+		containerSpec.Inputs = append(containerSpec.Inputs, &putInputSource{
+			name:   name,
+			source: PutResourceSource{source},
+		})
+	}
+
+	containerSpec.Inputs = append(containerSpec.Inputs, &putInputSource{
+		name:   worker.ArtifactName(step.fromName),
+		source: PutResourceSource{fetched},
+	})
+
+	putResource, err := step.resourceFactory.NewResource(
+		ctx,
+		logger,
+		db.NewBuildStepContainerOwner(step.build.ID(), step.planID),
+		step.containerMetadata,
+		containerSpec,
+		step.resourceTypes,
+		step.delegate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := step.source.Evaluate()
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := step.params.Evaluate()
+	if err != nil {
+		return nil, err
+	}
+
+	return putResource.Put(
+		ctx,
+		resource.IOConfig{
+			Stdout: step.delegate.Stdout(),
+			Stderr: step.delegate.Stderr(),
+		},
+		source,
+		params,
+	)
+}
+
+// fetchPlanID distinguishes the container fetching the promoted version from
+// the one running the put, as both belong to the same step.
+func (step *PromoteStep) fetchPlanID() atc.PlanID {
+	return atc.PlanID(fmt.Sprintf("%s/from", step.planID))
+}
+
+// Promotion returns the promoted version and where it came from.
+func (step *PromoteStep) Promotion() Promotion {
+	return step.promotion
+}
+
+// Succeeded returns true if both the get and put scripts exited successfully.
+func (step *PromoteStep) Succeeded() bool {
+	return step.succeeded
+}
@@ -0,0 +1,259 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/resource/resourcefakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("PromoteStep", func() {
+	var (
+		ctx context.Context
+
+		fakeBuild *dbfakes.FakeBuild
+
+		fakeResourceFetcher        *resourcefakes.FakeFetcher
+		fakeResourceFactory        *resourcefakes.FakeResourceFactory
+		fakeDBResourceCacheFactory *dbfakes.FakeResourceCacheFactory
+		variables                  creds.Variables
+
+		stepMetadata testMetadata = []string{"a=1", "b=2"}
+
+		containerMetadata = db.ContainerMetadata{
+			Type:     db.ContainerTypePut,
+			StepName: "some-step",
+		}
+		planID       atc.PlanID
+		fromVersion  *atc.Version
+		fakeDelegate *execfakes.FakePromoteDelegate
+
+		resourceTypes creds.VersionedResourceTypes
+
+		repo  *worker.ArtifactRepository
+		state *execfakes.FakeRunState
+
+		fakeFetchedSource   *resourcefakes.FakeVersionedSource
+		fakeResource        *resourcefakes.FakeResource
+		fakeVersionedSource *resourcefakes.FakeVersionedSource
+
+		promoteStep *exec.PromoteStep
+		stepErr     error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.TeamIDReturns(123)
+
+		planID = atc.PlanID("some-plan-id")
+		fromVersion = &atc.Version{"digest": "sha256:abc"}
+
+		fakeResourceFetcher = new(resourcefakes.FakeFetcher)
+		fakeResourceFactory = new(resourcefakes.FakeResourceFactory)
+		fakeDBResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
+		variables = template.StaticVariables{
+			"staging-password": "super-secret-staging",
+			"prod-password":    "super-secret-prod",
+		}
+
+		fakeDelegate = new(execfakes.FakePromoteDelegate)
+		fakeDelegate.StdoutReturns(gbytes.NewBuffer())
+		fakeDelegate.StderrReturns(gbytes.NewBuffer())
+
+		repo = worker.NewArtifactRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+
+		resourceTypes = creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{})
+
+		fakeFetchedSource = new(resourcefakes.FakeVersionedSource)
+		fakeFetchedSource.VersionReturns(atc.Version{"digest": "sha256:abc"})
+		fakeResourceFetcher.FetchReturns(fakeFetchedSource, nil)
+
+		fakeVersionedSource = new(resourcefakes.FakeVersionedSource)
+		fakeVersionedSource.VersionReturns(atc.Version{"tag": "1.2.3"})
+		fakeVersionedSource.MetadataReturns([]atc.MetadataField{{"some", "metadata"}})
+
+		fakeResource = new(resourcefakes.FakeResource)
+		fakeResource.PutReturns(fakeVersionedSource, nil)
+		fakeResourceFactory.NewResourceReturns(fakeResource, nil)
+	})
+
+	JustBeforeEach(func() {
+		promoteStep = exec.NewPromoteStep(
+			fakeBuild,
+			"some-name",
+			"some-resource-type",
+			"prod-image",
+			creds.NewSource(variables, atc.Source{"password": "((prod-password))"}),
+			creds.NewParams(variables, atc.Params{"tag": "latest"}),
+			[]string{"some", "tags"},
+			"some-from-type",
+			"staging-image",
+			creds.NewSource(variables, atc.Source{"password": "((staging-password))"}),
+			creds.NewParams(variables, atc.Params{"format": "oci"}),
+			fromVersion,
+			fakeDelegate,
+			fakeResourceFetcher,
+			fakeResourceFactory,
+			fakeDBResourceCacheFactory,
+			planID,
+			containerMetadata,
+			stepMetadata,
+			resourceTypes,
+		)
+
+		stepErr = promoteStep.Run(ctx, state)
+	})
+
+	It("fetches the version being promoted using its resource's source", func() {
+		Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
+
+		_, _, session, tags, teamID, _, resourceInstance, _, delegate := fakeResourceFetcher.FetchArgsForCall(0)
+		Expect(session.Metadata.Type).To(Equal(db.ContainerTypeGet))
+		Expect(session.Metadata.WorkingDirectory).To(Equal("/tmp/build/get"))
+		Expect(tags).To(ConsistOf("some", "tags"))
+		Expect(teamID).To(Equal(123))
+		Expect(resourceInstance.ResourceType()).To(Equal(resource.ResourceType("some-from-type")))
+		Expect(resourceInstance.Version()).To(Equal(atc.Version{"digest": "sha256:abc"}))
+		Expect(resourceInstance.Source()).To(Equal(atc.Source{"password": "super-secret-staging"}))
+		Expect(resourceInstance.Params()).To(Equal(atc.Params{"format": "oci"}))
+		Expect(resourceInstance.ContainerOwner()).To(Equal(db.NewBuildStepContainerOwner(42, "some-plan-id/from")))
+		Expect(delegate).To(Equal(fakeDelegate))
+	})
+
+	It("puts the fetched version using the destination resource's source", func() {
+		Expect(fakeResourceFactory.NewResourceCallCount()).To(Equal(1))
+
+		_, _, owner, metadata, containerSpec, _, _ := fakeResourceFactory.NewResourceArgsForCall(0)
+		Expect(owner).To(Equal(db.NewBuildStepContainerOwner(42, "some-plan-id")))
+		Expect(metadata).To(Equal(containerMetadata))
+		Expect(containerSpec.ImageSpec.ResourceType).To(Equal("some-resource-type"))
+		Expect(containerSpec.Inputs).To(HaveLen(1))
+		Expect(containerSpec.Inputs[0].DestinationPath()).To(Equal("/tmp/build/put/staging-image"))
+
+		Expect(fakeResource.PutCallCount()).To(Equal(1))
+		_, _, source, params := fakeResource.PutArgsForCall(0)
+		Expect(source).To(Equal(atc.Source{"password": "super-secret-prod"}))
+		Expect(params).To(Equal(atc.Params{"tag": "latest"}))
+	})
+
+	Context("when the build has other artifacts", func() {
+		BeforeEach(func() {
+			repo.RegisterSource("some-source", new(workerfakes.FakeArtifactSource))
+		})
+
+		It("makes them available to the put as well", func() {
+			_, _, _, _, containerSpec, _, _ := fakeResourceFactory.NewResourceArgsForCall(0)
+			Expect(containerSpec.Inputs).To(HaveLen(2))
+		})
+	})
+
+	It("saves the created version as an output, recording where it came from", func() {
+		Expect(fakeBuild.SaveOutputCallCount()).To(Equal(1))
+		Expect(fakeBuild.SaveOutputArgsForCall(0)).To(Equal(db.VersionedResource{
+			Resource: "prod-image",
+			Type:     "some-resource-type",
+			Version:  db.ResourceVersion{"tag": "1.2.3"},
+			Metadata: db.ResourceMetadataFields{
+				{Name: "some", Value: "metadata"},
+				{Name: "promoted_from", Value: "staging-image"},
+				{Name: "promoted_from_version", Value: `{"digest":"sha256:abc"}`},
+			},
+		}))
+	})
+
+	It("finishes with the promotion", func() {
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+		_, status, promotion := fakeDelegate.FinishedArgsForCall(0)
+		Expect(status).To(Equal(exec.ExitStatus(0)))
+		Expect(promotion.From).To(Equal("staging-image"))
+		Expect(promotion.FromVersion).To(Equal(atc.Version{"digest": "sha256:abc"}))
+		Expect(promotion.Version).To(Equal(atc.Version{"tag": "1.2.3"}))
+	})
+
+	It("stores the created version as the step's result", func() {
+		Expect(state.StoreResultCallCount()).To(Equal(1))
+		sID, result := state.StoreResultArgsForCall(0)
+		Expect(sID).To(Equal(planID))
+		Expect(result).To(Equal(promoteStep.Promotion().VersionInfo))
+	})
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(promoteStep.Succeeded()).To(BeTrue())
+	})
+
+	Context("when there is no version to promote", func() {
+		BeforeEach(func() {
+			fromVersion = nil
+		})
+
+		It("returns an error without fetching anything", func() {
+			Expect(stepErr).To(Equal(exec.PromoteVersionMissingError{From: "staging-image"}))
+			Expect(fakeResourceFetcher.FetchCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the get script fails", func() {
+		BeforeEach(func() {
+			fakeResourceFetcher.FetchReturns(nil, resource.ErrResourceScriptFailed{ExitStatus: 1})
+		})
+
+		It("finishes with the exit status without putting or saving anything", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(promoteStep.Succeeded()).To(BeFalse())
+
+			_, status, _ := fakeDelegate.FinishedArgsForCall(0)
+			Expect(status).To(Equal(exec.ExitStatus(1)))
+
+			Expect(fakeResource.PutCallCount()).To(BeZero())
+			Expect(fakeBuild.SaveOutputCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the put script fails", func() {
+		BeforeEach(func() {
+			fakeResource.PutReturns(nil, resource.ErrResourceScriptFailed{ExitStatus: 2})
+		})
+
+		It("finishes with the exit status without saving anything", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(promoteStep.Succeeded()).To(BeFalse())
+
+			_, status, _ := fakeDelegate.FinishedArgsForCall(0)
+			Expect(status).To(Equal(exec.ExitStatus(2)))
+
+			Expect(fakeBuild.SaveOutputCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when saving the output fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeBuild.SaveOutputReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(promoteStep.Succeeded()).To(BeFalse())
+		})
+	})
+})
@@ -98,7 +98,7 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 
 		Dir: resource.ResourcesDir("put"),
 
-		Env: step.stepMetadata.Env(),
+		Env: append(step.stepMetadata.Env(), attemptEnv(ctx)...),
 	}
 
 	for name, source := range state.Artifacts().AsMap() {
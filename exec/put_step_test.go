@@ -179,6 +179,17 @@ var _ = Describe("PutStep", func() {
 				Expect(delegate).To(Equal(fakeDelegate))
 			})
 
+			Context("when the step is being retried", func() {
+				BeforeEach(func() {
+					ctx = exec.WithAttempt(ctx, exec.StepAttempt{Number: 1, Max: 2})
+				})
+
+				It("exposes the attempt to the resource", func() {
+					_, _, _, _, containerSpec, _, _ := fakeResourceFactory.NewResourceArgsForCall(0)
+					Expect(containerSpec.Env).To(Equal([]string{"a=1", "b=2", "BUILD_ATTEMPT=1", "BUILD_MAX_ATTEMPTS=2"}))
+				})
+			})
+
 			It("puts the resource with the given context", func() {
 				Expect(fakeResource.PutCallCount()).To(Equal(1))
 				putCtx, _, _, _ := fakeResource.PutArgsForCall(0)
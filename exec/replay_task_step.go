@@ -0,0 +1,112 @@
+package exec
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+)
+
+// ReplayContainerTTL is how long the container created by a ReplayTaskStep is
+// kept around for, so that it can be hijacked after the build has finished.
+const ReplayContainerTTL = time.Hour
+
+// ReplayResult is stored as the result of a ReplayTaskStep.
+type ReplayResult struct {
+	ContainerHandle string
+	MissingInputs   []string
+}
+
+// ReplayTaskStep recreates the container of a task step for debugging, with
+// the same image and whichever of its inputs are still available, but does
+// not run the task's script.
+type ReplayTaskStep struct {
+	task      *TaskStep
+	expiresAt time.Time
+
+	succeeded bool
+}
+
+func NewReplayTaskStep(task *TaskStep, expiresAt time.Time) *ReplayTaskStep {
+	return &ReplayTaskStep{
+		task:      task,
+		expiresAt: expiresAt,
+	}
+}
+
+// Run creates a container for the task as TaskStep would, owned by a plan ID
+// of its own so that it does not collide with the original container. The
+// container is kept until the expiry, regardless of the build's state.
+//
+// Unlike TaskStep, required inputs missing from the worker.ArtifactRepository
+// do not cause an error; they are left out of the container and listed in
+// the ReplayResult stored for the step.
+func (step *ReplayTaskStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+	task := step.task
+
+	repository := state.Artifacts()
+
+	config, err := task.configSource.FetchConfig(repository)
+	if err != nil {
+		return err
+	}
+
+	var missingInputs []string
+	inputs := make([]atc.TaskInputConfig, len(config.Inputs))
+	for i, input := range config.Inputs {
+		inputName := input.Name
+		if sourceName, ok := task.inputMapping[inputName]; ok {
+			inputName = sourceName
+		}
+
+		if _, found := repository.SourceFor(worker.ArtifactName(inputName)); !found && !input.Optional {
+			missingInputs = append(missingInputs, inputName)
+			input.Optional = true
+		}
+
+		inputs[i] = input
+	}
+
+	config.Inputs = inputs
+
+	if len(missingInputs) > 0 {
+		logger.Info("replaying-without-inputs", lager.Data{"inputs": missingInputs})
+	}
+
+	containerSpec, err := task.containerSpec(logger, repository, config)
+	if err != nil {
+		return err
+	}
+
+	container, err := task.workerPool.FindOrCreateContainer(
+		ctx,
+		logger,
+		task.delegate,
+		db.NewReplayContainerOwner(task.buildID, task.planID, step.expiresAt),
+		task.containerMetadata,
+		containerSpec,
+		task.resourceTypes,
+	)
+	if err != nil {
+		return err
+	}
+
+	state.StoreResult(task.planID, ReplayResult{
+		ContainerHandle: container.Handle(),
+		MissingInputs:   missingInputs,
+	})
+
+	step.succeeded = true
+
+	return nil
+}
+
+// Succeeded returns true if the container was created.
+func (step *ReplayTaskStep) Succeeded() bool {
+	return step.succeeded
+}
@@ -0,0 +1,164 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/artifactscan"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReplayTaskStep", func() {
+	var (
+		ctx context.Context
+
+		fakeWorkerClient *workerfakes.FakeClient
+		fakeContainer    *workerfakes.FakeContainer
+		fakeDelegate     *execfakes.FakeTaskDelegate
+		configSource     *execfakes.FakeTaskConfigSource
+		inputMapping     map[string]string
+
+		containerMetadata = db.ContainerMetadata{
+			Type:     db.ContainerTypeTask,
+			StepName: "some-step",
+		}
+
+		repo  *worker.ArtifactRepository
+		state *execfakes.FakeRunState
+
+		expiresAt time.Time
+
+		replayStep exec.Step
+		stepErr    error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		expiresAt = time.Unix(123, 0)
+
+		fakeContainer = new(workerfakes.FakeContainer)
+		fakeContainer.HandleReturns("some-handle")
+
+		fakeWorkerClient = new(workerfakes.FakeClient)
+		fakeWorkerClient.FindOrCreateContainerReturns(fakeContainer, nil)
+
+		fakeDelegate = new(execfakes.FakeTaskDelegate)
+
+		configSource = new(execfakes.FakeTaskConfigSource)
+		configSource.FetchConfigReturns(atc.TaskConfig{
+			Platform:  "some-platform",
+			RootfsURI: "some-image",
+			Inputs: []atc.TaskInputConfig{
+				{Name: "some-input"},
+				{Name: "some-other-input"},
+				{Name: "some-optional-input", Optional: true},
+			},
+			Run: atc.TaskRunConfig{
+				Path: "ls",
+			},
+		}, nil)
+
+		inputMapping = nil
+
+		repo = worker.NewArtifactRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+
+		repo.RegisterSource("some-input", new(workerfakes.FakeArtifactSource))
+	})
+
+	JustBeforeEach(func() {
+		replayStep = exec.NewReplayTaskStep(exec.NewTaskStep(
+			false,
+			configSource,
+			atc.Tags{"some", "tags"},
+			inputMapping,
+			nil,
+			"some-artifact-root",
+			"",
+			false,
+			fakeDelegate,
+			fakeWorkerClient,
+			123,
+			1234,
+			12345,
+			"some-task",
+			"some-plan-id",
+			containerMetadata,
+			creds.VersionedResourceTypes{},
+			template.StaticVariables{},
+			nil,
+			nil,
+			artifactscan.PolicyFail,
+			nil,
+		), expiresAt)
+
+		stepErr = replayStep.Run(ctx, state)
+	})
+
+	It("creates a container with the task's image and available inputs", func() {
+		Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+
+		_, _, delegate, owner, metadata, containerSpec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+		Expect(delegate).To(Equal(fakeDelegate))
+		Expect(owner).To(Equal(db.NewReplayContainerOwner(1234, "some-plan-id", expiresAt)))
+		Expect(metadata).To(Equal(containerMetadata))
+		Expect(containerSpec.Platform).To(Equal("some-platform"))
+		Expect(containerSpec.ImageSpec.ImageURL).To(Equal("some-image"))
+		Expect(containerSpec.Inputs).To(HaveLen(1))
+		Expect(containerSpec.Inputs[0].DestinationPath()).To(Equal("some-artifact-root/some-input"))
+	})
+
+	It("does not run the task's script", func() {
+		Expect(fakeContainer.RunCallCount()).To(BeZero())
+	})
+
+	It("stores the container and the missing inputs as the step's result", func() {
+		Expect(state.StoreResultCallCount()).To(Equal(1))
+		sID, result := state.StoreResultArgsForCall(0)
+		Expect(sID).To(Equal(atc.PlanID("some-plan-id")))
+		Expect(result).To(Equal(exec.ReplayResult{
+			ContainerHandle: "some-handle",
+			MissingInputs:   []string{"some-other-input"},
+		}))
+	})
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(replayStep.Succeeded()).To(BeTrue())
+	})
+
+	Context("when an input is mapped from another artifact", func() {
+		BeforeEach(func() {
+			inputMapping = map[string]string{"some-other-input": "some-artifact"}
+		})
+
+		It("reports the missing artifact by its name in the build", func() {
+			_, result := state.StoreResultArgsForCall(0)
+			Expect(result.(exec.ReplayResult).MissingInputs).To(ConsistOf("some-artifact"))
+		})
+	})
+
+	Context("when creating the container fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeWorkerClient.FindOrCreateContainerReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(replayStep.Succeeded()).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,93 @@
+package exec
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/db"
+)
+
+// RerunTaskStep stands in for a task step preceding the step a build is
+// rerun from. Instead of running the task again, it reuses the outputs of the
+// task's container in the original build, provided the task succeeded there
+// and its container is still around.
+//
+// The original build's containers are kept for as long as it is the latest
+// finished build of its job, i.e. at least until the rerun finishes.
+type RerunTaskStep struct {
+	task            *TaskStep
+	originalBuildID int
+
+	succeeded bool
+}
+
+func NewRerunTaskStep(task *TaskStep, originalBuildID int) *RerunTaskStep {
+	return &RerunTaskStep{
+		task:            task,
+		originalBuildID: originalBuildID,
+	}
+}
+
+// Run registers the outputs of the original build's container for the task
+// with the worker.ArtifactRepository, as TaskStep does when it re-attaches to
+// a container which has exited. If there is no such container, or the task
+// did not succeed in it, the task is run as usual.
+func (step *RerunTaskStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+	task := step.task
+
+	container, found, err := task.workerPool.FindContainerByOwner(
+		logger,
+		task.teamID,
+		db.NewBuildStepContainerOwner(step.originalBuildID, task.planID),
+	)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		logger.Info("original-container-not-found")
+		return step.runTask(ctx, state)
+	}
+
+	exitStatus, err := container.Property(taskExitStatusPropertyName)
+	if err != nil || exitStatus != "0" {
+		logger.Info("original-task-did-not-succeed", lager.Data{"status": exitStatus})
+		return step.runTask(ctx, state)
+	}
+
+	repository := state.Artifacts()
+
+	config, err := task.configSource.FetchConfig(repository)
+	if err != nil {
+		return err
+	}
+
+	task.delegate.Initializing(logger, config)
+
+	err = task.registerOutputs(logger, repository, config, container)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("reused-original-outputs", lager.Data{"container": container.Handle()})
+
+	task.delegate.Finished(logger, ExitStatus(0))
+
+	step.succeeded = true
+
+	return nil
+}
+
+func (step *RerunTaskStep) runTask(ctx context.Context, state RunState) error {
+	err := step.task.Run(ctx, state)
+	step.succeeded = step.task.Succeeded()
+	return err
+}
+
+// Succeeded returns true if the original outputs were reused, or the task
+// succeeded when run again.
+func (step *RerunTaskStep) Succeeded() bool {
+	return step.succeeded
+}
@@ -0,0 +1,186 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/artifactscan"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RerunTaskStep", func() {
+	var (
+		ctx context.Context
+
+		fakeWorkerClient      *workerfakes.FakeClient
+		fakeOriginalContainer *workerfakes.FakeContainer
+		fakeNewContainer      *workerfakes.FakeContainer
+		fakeOriginalVolume    *workerfakes.FakeVolume
+		fakeNewVolume         *workerfakes.FakeVolume
+		fakeDelegate          *execfakes.FakeTaskDelegate
+		configSource          *execfakes.FakeTaskConfigSource
+
+		repo  *worker.ArtifactRepository
+		state *execfakes.FakeRunState
+
+		rerunStep exec.Step
+		stepErr   error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		fakeOriginalVolume = new(workerfakes.FakeVolume)
+		fakeOriginalVolume.HandleReturns("some-original-volume")
+		fakeOriginalContainer = new(workerfakes.FakeContainer)
+		fakeOriginalContainer.HandleReturns("some-original-handle")
+		fakeOriginalContainer.PropertyReturns("0", nil)
+		fakeOriginalContainer.VolumeMountsReturns([]worker.VolumeMount{
+			{Volume: fakeOriginalVolume, MountPath: "some-artifact-root/some-output"},
+		})
+
+		fakeNewVolume = new(workerfakes.FakeVolume)
+		fakeNewVolume.HandleReturns("some-new-volume")
+		fakeNewContainer = new(workerfakes.FakeContainer)
+		fakeNewContainer.PropertyReturns("0", nil)
+		fakeNewContainer.VolumeMountsReturns([]worker.VolumeMount{
+			{Volume: fakeNewVolume, MountPath: "some-artifact-root/some-output"},
+		})
+
+		fakeWorkerClient = new(workerfakes.FakeClient)
+		fakeWorkerClient.FindContainerByOwnerReturns(fakeOriginalContainer, true, nil)
+		fakeWorkerClient.FindOrCreateContainerReturns(fakeNewContainer, nil)
+
+		fakeDelegate = new(execfakes.FakeTaskDelegate)
+
+		configSource = new(execfakes.FakeTaskConfigSource)
+		configSource.FetchConfigReturns(atc.TaskConfig{
+			Platform:  "some-platform",
+			RootfsURI: "some-image",
+			Outputs: []atc.TaskOutputConfig{
+				{Name: "some-output"},
+			},
+			Run: atc.TaskRunConfig{
+				Path: "ls",
+			},
+		}, nil)
+
+		repo = worker.NewArtifactRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+	})
+
+	JustBeforeEach(func() {
+		rerunStep = exec.NewRerunTaskStep(exec.NewTaskStep(
+			false,
+			configSource,
+			atc.Tags{"some", "tags"},
+			nil,
+			nil,
+			"some-artifact-root",
+			"",
+			false,
+			fakeDelegate,
+			fakeWorkerClient,
+			123,
+			1234,
+			0,
+			"some-task",
+			"some-plan-id",
+			db.ContainerMetadata{Type: db.ContainerTypeTask},
+			creds.VersionedResourceTypes{},
+			template.StaticVariables{},
+			nil,
+			nil,
+			artifactscan.PolicyFail,
+			nil,
+		), 1000)
+
+		stepErr = rerunStep.Run(ctx, state)
+	})
+
+	outputVolumeHandle := func() string {
+		source, found := repo.SourceFor("some-output")
+		Expect(found).To(BeTrue())
+
+		fakeWorker := new(workerfakes.FakeWorker)
+		_, _, err := source.VolumeOn(fakeWorker)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, handle := fakeWorker.LookupVolumeArgsForCall(0)
+		return handle
+	}
+
+	It("looks for the task's container in the original build", func() {
+		Expect(fakeWorkerClient.FindContainerByOwnerCallCount()).To(Equal(1))
+
+		_, teamID, owner := fakeWorkerClient.FindContainerByOwnerArgsForCall(0)
+		Expect(teamID).To(Equal(123))
+		Expect(owner).To(Equal(db.NewBuildStepContainerOwner(1000, "some-plan-id")))
+	})
+
+	It("registers the original container's outputs without running the task", func() {
+		Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(BeZero())
+
+		Expect(outputVolumeHandle()).To(Equal("some-original-volume"))
+	})
+
+	It("finishes successfully", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(rerunStep.Succeeded()).To(BeTrue())
+
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+		_, status := fakeDelegate.FinishedArgsForCall(0)
+		Expect(status).To(Equal(exec.ExitStatus(0)))
+	})
+
+	Context("when the original container is gone", func() {
+		BeforeEach(func() {
+			fakeWorkerClient.FindContainerByOwnerReturns(nil, false, nil)
+		})
+
+		It("runs the task in a container of its own", func() {
+			Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+
+			_, _, _, owner, _, _, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+			Expect(owner).To(Equal(db.NewBuildStepContainerOwner(1234, "some-plan-id")))
+
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(rerunStep.Succeeded()).To(BeTrue())
+		})
+	})
+
+	Context("when the task did not succeed in the original container", func() {
+		BeforeEach(func() {
+			fakeOriginalContainer.PropertyReturns("1", nil)
+		})
+
+		It("runs the task again", func() {
+			Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+
+			Expect(outputVolumeHandle()).To(Equal("some-new-volume"))
+		})
+	})
+
+	Context("when looking for the original container fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeWorkerClient.FindContainerByOwnerReturns(nil, false, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(rerunStep.Succeeded()).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,190 @@
+package exec
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/artifactcache"
+	"github.com/concourse/atc/worker"
+)
+
+// RestoreCacheStep registers an artifact previously saved to the artifact
+// cache by a SaveCacheStep.
+type RestoreCacheStep struct {
+	planID   atc.PlanID
+	name     worker.ArtifactName
+	key      string
+	teamID   int
+	store    artifactcache.Store
+	delegate BuildStepDelegate
+
+	succeeded bool
+}
+
+func NewRestoreCacheStep(
+	planID atc.PlanID,
+	name worker.ArtifactName,
+	key string,
+	teamID int,
+	store artifactcache.Store,
+	delegate BuildStepDelegate,
+) Step {
+	return &RestoreCacheStep{
+		planID:   planID,
+		name:     name,
+		key:      key,
+		teamID:   teamID,
+		store:    store,
+		delegate: delegate,
+	}
+}
+
+// Run resolves the cache key and registers the cached artifact with the
+// worker.ArtifactRepository. A cache miss is not a failure: an empty artifact
+// is registered instead, so that subsequent steps can populate it.
+func (step *RestoreCacheStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx).WithData(lager.Data{
+		"plan-id": step.planID,
+		"name":    step.name,
+	})
+
+	if step.store == nil {
+		fmt.Fprintln(step.delegate.Stderr(), "artifact caching is not configured; skipping restore_cache")
+		state.Artifacts().RegisterSource(step.name, emptyArtifactSource{})
+		step.succeeded = true
+		return nil
+	}
+
+	key, err := resolveCacheKey(state.Artifacts(), step.key)
+	if err != nil {
+		return err
+	}
+
+	stream, found, err := step.store.Restore(logger, step.teamID, key)
+	if err != nil {
+		logger.Error("failed-to-restore-cache", err)
+		return err
+	}
+
+	if found {
+		stream.Close()
+
+		fmt.Fprintf(step.delegate.Stdout(), "restored %s from cache with key %s\n", step.name, key)
+
+		state.Artifacts().RegisterSource(step.name, &cachedArtifactSource{
+			logger: logger,
+			store:  step.store,
+			teamID: step.teamID,
+			key:    key,
+		})
+	} else {
+		fmt.Fprintf(step.delegate.Stdout(), "no cache found for %s with key %s\n", step.name, key)
+
+		state.Artifacts().RegisterSource(step.name, emptyArtifactSource{})
+	}
+
+	step.succeeded = true
+
+	return nil
+}
+
+func (step *RestoreCacheStep) Succeeded() bool {
+	return step.succeeded
+}
+
+type cachedArtifactSource struct {
+	logger lager.Logger
+	store  artifactcache.Store
+	teamID int
+	key    string
+}
+
+func (src *cachedArtifactSource) StreamTo(destination worker.ArtifactDestination) error {
+	stream, err := src.open()
+	if err != nil {
+		return err
+	}
+
+	defer stream.Close()
+
+	return destination.StreamIn(".", stream)
+}
+
+func (src *cachedArtifactSource) StreamFile(filename string) (io.ReadCloser, error) {
+	stream, err := src.open()
+	if err != nil {
+		return nil, err
+	}
+
+	gzReader, err := gzip.NewReader(stream)
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+
+	tarReader := tar.NewReader(gzReader)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			stream.Close()
+			return nil, err
+		}
+
+		if path.Clean(header.Name) == path.Clean(filename) {
+			return fileReadCloser{
+				Reader: tarReader,
+				Closer: stream,
+			}, nil
+		}
+	}
+
+	stream.Close()
+
+	return nil, FileNotFoundError{Path: filename}
+}
+
+// VolumeOn never finds a volume, as cached artifacts are not kept on workers.
+func (src *cachedArtifactSource) VolumeOn(worker.Worker) (worker.Volume, bool, error) {
+	return nil, false, nil
+}
+
+func (src *cachedArtifactSource) open() (io.ReadCloser, error) {
+	stream, found, err := src.store.Restore(src.logger, src.teamID, src.key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("cache entry for key %s disappeared", src.key)
+	}
+
+	return stream, nil
+}
+
+// emptyArtifactSource is registered on a cache miss, so that steps consuming
+// the artifact are given an empty directory.
+type emptyArtifactSource struct{}
+
+func (emptyArtifactSource) StreamTo(worker.ArtifactDestination) error {
+	return nil
+}
+
+func (emptyArtifactSource) StreamFile(filename string) (io.ReadCloser, error) {
+	return nil, FileNotFoundError{Path: filename}
+}
+
+func (emptyArtifactSource) VolumeOn(worker.Worker) (worker.Volume, bool, error) {
+	return nil, false, nil
+}
@@ -0,0 +1,190 @@
+package exec_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/artifactcache/artifactcachefakes"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("RestoreCacheStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStore    *artifactcachefakes.FakeStore
+		fakeDelegate *execfakes.FakeBuildStepDelegate
+
+		stdout *gbytes.Buffer
+		stderr *gbytes.Buffer
+
+		repo  *worker.ArtifactRepository
+		state *execfakes.FakeRunState
+
+		step    exec.Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeStore = new(artifactcachefakes.FakeStore)
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StderrReturns(stderr)
+
+		repo = worker.NewArtifactRepository()
+
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+
+		step = exec.NewRestoreCacheStep("some-plan-id", "some-artifact", "some-key", 42, fakeStore, fakeDelegate)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		stepErr = step.Run(ctx, state)
+	})
+
+	Context("when the key is found in the store", func() {
+		BeforeEach(func() {
+			fakeStore.RestoreStub = func(_ lager.Logger, _ int, _ string) (io.ReadCloser, bool, error) {
+				tgzBuffer := new(bytes.Buffer)
+
+				gzWriter := gzip.NewWriter(tgzBuffer)
+				tarWriter := tar.NewWriter(gzWriter)
+
+				Expect(tarWriter.WriteHeader(&tar.Header{Name: "./some-file", Mode: 0644, Size: 13})).To(Succeed())
+				_, err := tarWriter.Write([]byte("some-contents"))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(tarWriter.Close()).To(Succeed())
+				Expect(gzWriter.Close()).To(Succeed())
+
+				return ioutil.NopCloser(tgzBuffer), true, nil
+			}
+		})
+
+		It("looks up the key for the team", func() {
+			Expect(fakeStore.RestoreCallCount()).To(Equal(1))
+			_, teamID, key := fakeStore.RestoreArgsForCall(0)
+			Expect(teamID).To(Equal(42))
+			Expect(key).To(Equal("some-key"))
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+
+		It("reports the restored key", func() {
+			Expect(stdout).To(gbytes.Say("restored some-artifact from cache with key some-key"))
+		})
+
+		It("registers a source which streams the cached artifact", func() {
+			source, found := repo.SourceFor("some-artifact")
+			Expect(found).To(BeTrue())
+
+			fakeDestination := new(workerfakes.FakeArtifactDestination)
+			Expect(source.StreamTo(fakeDestination)).To(Succeed())
+
+			Expect(fakeDestination.StreamInCallCount()).To(Equal(1))
+			path, _ := fakeDestination.StreamInArgsForCall(0)
+			Expect(path).To(Equal("."))
+		})
+
+		It("registers a source which streams files from the cached artifact", func() {
+			source, found := repo.SourceFor("some-artifact")
+			Expect(found).To(BeTrue())
+
+			stream, err := source.StreamFile("some-file")
+			Expect(err).ToNot(HaveOccurred())
+
+			defer stream.Close()
+
+			Expect(ioutil.ReadAll(stream)).To(Equal([]byte("some-contents")))
+
+			_, err = source.StreamFile("some-missing-file")
+			Expect(err).To(Equal(exec.FileNotFoundError{Path: "some-missing-file"}))
+		})
+	})
+
+	Context("when the key is not found in the store", func() {
+		BeforeEach(func() {
+			fakeStore.RestoreReturns(nil, false, nil)
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+
+		It("reports the cache miss", func() {
+			Expect(stdout).To(gbytes.Say("no cache found for some-artifact with key some-key"))
+		})
+
+		It("registers an empty artifact", func() {
+			source, found := repo.SourceFor("some-artifact")
+			Expect(found).To(BeTrue())
+
+			fakeDestination := new(workerfakes.FakeArtifactDestination)
+			Expect(source.StreamTo(fakeDestination)).To(Succeed())
+			Expect(fakeDestination.StreamInCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when restoring fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeStore.RestoreReturns(nil, false, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+
+		It("is not successful", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		It("does not register the artifact", func() {
+			_, found := repo.SourceFor("some-artifact")
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when no store is configured", func() {
+		BeforeEach(func() {
+			step = exec.NewRestoreCacheStep("some-plan-id", "some-artifact", "some-key", 42, nil, fakeDelegate)
+		})
+
+		It("registers an empty artifact", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stderr).To(gbytes.Say("artifact caching is not configured"))
+
+			_, found := repo.SourceFor("some-artifact")
+			Expect(found).To(BeTrue())
+		})
+	})
+})
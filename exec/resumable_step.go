@@ -0,0 +1,100 @@
+package exec
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+)
+
+//go:generate counterfeiter . StepJournal
+
+// StepJournal records the outcome of a build's steps as they finish, so that
+// a build resumed after its ATC went away does not run them again.
+type StepJournal interface {
+	Completed(atc.PlanID) (StepRecord, bool)
+	Record(lager.Logger, atc.PlanID, StepRecord) error
+}
+
+// StepRecord is the outcome of a finished step.
+type StepRecord struct {
+	Succeeded bool         `json:"succeeded"`
+	Result    *VersionInfo `json:"result,omitempty"`
+}
+
+// ResumableStep records the outcome of a step in a StepJournal, and restores
+// it in place of running the step if the journal already has one.
+//
+// It is meant for steps with side effects that must not be repeated, e.g. a
+// put. Steps which fetch or produce artifacts are instead run again when a
+// build is resumed, as that is how their artifacts are registered; gets find
+// their versions in the resource cache, and tasks re-attach to their
+// containers.
+//
+// Only these outcomes are journalled. Where the build had got to is not
+// recorded beyond them, and nor are the attempts made by a retry step, which
+// starts over from its first attempt when resumed; any put it had completed
+// is still not run again.
+type ResumableStep struct {
+	step    Step
+	planID  atc.PlanID
+	journal StepJournal
+
+	succeeded bool
+}
+
+func Resumable(step Step, planID atc.PlanID, journal StepJournal) Step {
+	return &ResumableStep{
+		step:    step,
+		planID:  planID,
+		journal: journal,
+	}
+}
+
+// Run restores the step's outcome from the journal if it has finished before,
+// storing its result for later steps. Otherwise it runs the step, and records
+// its outcome if it finished without error. Failing to record the outcome
+// does not fail the step.
+func (step *ResumableStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+
+	record, found := step.journal.Completed(step.planID)
+	if found {
+		logger.Info("already-completed", lager.Data{"succeeded": record.Succeeded})
+
+		if record.Result != nil {
+			state.StoreResult(step.planID, *record.Result)
+		}
+
+		step.succeeded = record.Succeeded
+
+		return nil
+	}
+
+	err := step.step.Run(ctx, state)
+	if err != nil {
+		return err
+	}
+
+	step.succeeded = step.step.Succeeded()
+
+	record = StepRecord{Succeeded: step.succeeded}
+
+	var info VersionInfo
+	if state.Result(step.planID, &info) {
+		record.Result = &info
+	}
+
+	err = step.journal.Record(logger, step.planID, record)
+	if err != nil {
+		logger.Error("failed-to-record-step", err)
+	}
+
+	return nil
+}
+
+// Succeeded returns the outcome of the step, whether it was run or restored.
+func (step *ResumableStep) Succeeded() bool {
+	return step.succeeded
+}
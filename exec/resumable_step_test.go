@@ -0,0 +1,129 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResumableStep", func() {
+	var (
+		ctx context.Context
+
+		fakeStep    *execfakes.FakeStep
+		fakeJournal *execfakes.FakeStepJournal
+
+		state exec.RunState
+
+		step    exec.Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeJournal = new(execfakes.FakeStepJournal)
+
+		state = exec.NewRunState()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.Resumable(fakeStep, "some-plan-id", fakeJournal)
+		stepErr = step.Run(ctx, state)
+	})
+
+	Context("when the step has not completed before", func() {
+		BeforeEach(func() {
+			fakeStep.RunStub = func(ctx context.Context, state exec.RunState) error {
+				state.StoreResult("some-plan-id", exec.VersionInfo{
+					Version: atc.Version{"some": "version"},
+				})
+				return nil
+			}
+			fakeStep.SucceededReturns(true)
+		})
+
+		It("runs the step", func() {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+
+		It("records its outcome and result", func() {
+			Expect(fakeJournal.RecordCallCount()).To(Equal(1))
+			_, planID, record := fakeJournal.RecordArgsForCall(0)
+			Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+			Expect(record).To(Equal(exec.StepRecord{
+				Succeeded: true,
+				Result:    &exec.VersionInfo{Version: atc.Version{"some": "version"}},
+			}))
+		})
+
+		Context("when the step fails", func() {
+			BeforeEach(func() {
+				fakeStep.RunStub = nil
+				fakeStep.SucceededReturns(false)
+			})
+
+			It("records the failure", func() {
+				_, _, record := fakeJournal.RecordArgsForCall(0)
+				Expect(record).To(Equal(exec.StepRecord{Succeeded: false}))
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+		})
+
+		Context("when the step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeStep.RunStub = nil
+				fakeStep.RunReturns(disaster)
+			})
+
+			It("returns the error without recording anything", func() {
+				Expect(stepErr).To(Equal(disaster))
+				Expect(fakeJournal.RecordCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when recording fails", func() {
+			BeforeEach(func() {
+				fakeJournal.RecordReturns(errors.New("nope"))
+			})
+
+			It("does not fail the step", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(step.Succeeded()).To(BeTrue())
+			})
+		})
+	})
+
+	Context("when the step has completed before", func() {
+		BeforeEach(func() {
+			fakeJournal.CompletedReturns(exec.StepRecord{
+				Succeeded: true,
+				Result:    &exec.VersionInfo{Version: atc.Version{"some": "version"}},
+			}, true)
+		})
+
+		It("does not run the step again", func() {
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+			Expect(fakeJournal.RecordCallCount()).To(BeZero())
+		})
+
+		It("restores its outcome and result", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+
+			var info exec.VersionInfo
+			Expect(state.Result("some-plan-id", &info)).To(BeTrue())
+			Expect(info).To(Equal(exec.VersionInfo{Version: atc.Version{"some": "version"}}))
+		})
+	})
+})
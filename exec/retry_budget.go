@@ -0,0 +1,61 @@
+package exec
+
+import (
+	"context"
+	"sync"
+)
+
+// RetryBudget limits the number of retries made across every retried step of
+// a build, so that nested retries cannot multiply into hours of repeated
+// work.
+type RetryBudget struct {
+	remaining  int
+	remainingL *sync.Mutex
+}
+
+// NewRetryBudget returns a budget allowing the given number of retries. The
+// first attempt of each retried step is not counted.
+func NewRetryBudget(retries int) *RetryBudget {
+	return &RetryBudget{
+		remaining:  retries,
+		remainingL: new(sync.Mutex),
+	}
+}
+
+// Spend draws a single retry from the budget, returning false if none are
+// left. The number of retries remaining afterwards is returned either way.
+func (budget *RetryBudget) Spend() (int, bool) {
+	budget.remainingL.Lock()
+	defer budget.remainingL.Unlock()
+
+	if budget.remaining == 0 {
+		return 0, false
+	}
+
+	budget.remaining--
+
+	return budget.remaining, true
+}
+
+// Remaining returns the number of retries left in the budget.
+func (budget *RetryBudget) Remaining() int {
+	budget.remainingL.Lock()
+	defer budget.remainingL.Unlock()
+
+	return budget.remaining
+}
+
+type retryBudgetKey struct{}
+
+// WithRetryBudget makes every retried step run with the returned context draw
+// from the same budget.
+func WithRetryBudget(ctx context.Context, budget *RetryBudget) context.Context {
+	return context.WithValue(ctx, retryBudgetKey{}, budget)
+}
+
+// RetryBudgetFromContext returns the retry budget configured on the context,
+// if any. Without one, retried steps make every attempt.
+func RetryBudgetFromContext(ctx context.Context) (*RetryBudget, bool) {
+	budget, ok := ctx.Value(retryBudgetKey{}).(*RetryBudget)
+	return budget, ok
+}
@@ -2,35 +2,78 @@ package exec
 
 import (
 	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
 )
 
+//go:generate counterfeiter . RetryDelegate
+
+type RetryDelegate interface {
+	// Retrying is called before each attempt after the first. The remaining
+	// retry budget of the build is nil if it has none.
+	Retrying(logger lager.Logger, attempt StepAttempt, budgetRemaining *int)
+
+	// RetryBudgetExhausted is called instead of Retrying when the build has
+	// no retries left, in which case no further attempts are made.
+	RetryBudgetExhausted(logger lager.Logger, attempt StepAttempt)
+}
+
 // RetryStep is a step that will run the steps in order until one of them
 // succeeds.
 type RetryStep struct {
 	Attempts    []Step
 	LastAttempt Step
+
+	delegate RetryDelegate
+
+	lastAttemptNumber int
 }
 
-func Retry(attempts ...Step) Step {
+func Retry(delegate RetryDelegate, attempts ...Step) Step {
 	return &RetryStep{
 		Attempts: attempts,
+		delegate: delegate,
 	}
 }
 
 // Run iterates through each step, stopping once a step succeeds. If all steps
 // fail, the RetryStep will fail.
+//
+// Each step is told which attempt it is through its context, see
+// AttemptFromContext.
+//
+// Errors of a kind which would happen again, e.g. an invalid config, are
+// returned without further attempts being made, see atc.ErrorKind.
+//
+// If the context carries a RetryBudget, each attempt after the first draws
+// from it, and once it is spent the result of the last attempt made is
+// returned, see WithRetryBudget.
 func (step *RetryStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+
 	var attemptErr error
 
-	for _, attempt := range step.Attempts {
+	for i, attempt := range step.Attempts {
+		if i > 0 && !step.retry(ctx, logger, i+1) {
+			break
+		}
+
 		step.LastAttempt = attempt
+		step.lastAttemptNumber = i + 1
 
-		attemptErr = attempt.Run(ctx, state)
+		attemptErr = attempt.Run(WithAttempt(ctx, step.lastAttempt()), state)
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
 
 		if attemptErr != nil {
+			kind := ClassifyError(attemptErr)
+			if !kind.Retryable() {
+				logger.Info("not-retrying", lager.Data{"attempt": i + 1, "kind": kind})
+				break
+			}
+
 			continue
 		}
 
@@ -42,6 +85,39 @@ func (step *RetryStep) Run(ctx context.Context, state RunState) error {
 	return attemptErr
 }
 
+// retry draws the given attempt from the build's retry budget, if it has
+// one, returning false if the budget is spent.
+func (step *RetryStep) retry(ctx context.Context, logger lager.Logger, number int) bool {
+	attempt := StepAttempt{
+		Number: number,
+		Max:    len(step.Attempts),
+	}
+
+	budget, ok := RetryBudgetFromContext(ctx)
+	if !ok {
+		step.delegate.Retrying(logger, attempt, nil)
+		return true
+	}
+
+	remaining, ok := budget.Spend()
+	if !ok {
+		logger.Info("retry-budget-exhausted", lager.Data{"attempt": number})
+		step.delegate.RetryBudgetExhausted(logger, attempt)
+		return false
+	}
+
+	step.delegate.Retrying(logger, attempt, &remaining)
+
+	return true
+}
+
+func (step *RetryStep) lastAttempt() StepAttempt {
+	return StepAttempt{
+		Number: step.lastAttemptNumber,
+		Max:    len(step.Attempts),
+	}
+}
+
 // Succeeded delegates to the last step that it ran.
 func (step *RetryStep) Succeeded() bool {
 	return step.LastAttempt.Succeeded()
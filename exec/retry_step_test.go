@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/concourse/atc"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
 
@@ -21,6 +22,8 @@ var _ = Describe("Retry Step", func() {
 		attempt2 *execfakes.FakeStep
 		attempt3 *execfakes.FakeStep
 
+		fakeDelegate *execfakes.FakeRetryDelegate
+
 		repo  *worker.ArtifactRepository
 		state *execfakes.FakeRunState
 
@@ -38,7 +41,9 @@ var _ = Describe("Retry Step", func() {
 		state = new(execfakes.FakeRunState)
 		state.ArtifactsReturns(repo)
 
-		step = Retry(attempt1, attempt2, attempt3)
+		fakeDelegate = new(execfakes.FakeRetryDelegate)
+
+		step = Retry(fakeDelegate, attempt1, attempt2, attempt3)
 	})
 
 	Context("when attempt 1 succeeds", func() {
@@ -97,6 +102,26 @@ var _ = Describe("Retry Step", func() {
 				Expect(attempt3.RunCallCount()).To(Equal(0))
 			})
 
+			It("tells the delegate before retrying", func() {
+				Expect(fakeDelegate.RetryingCallCount()).To(Equal(1))
+
+				_, attempt, budgetRemaining := fakeDelegate.RetryingArgsForCall(0)
+				Expect(attempt).To(Equal(StepAttempt{Number: 2, Max: 3}))
+				Expect(budgetRemaining).To(BeNil())
+			})
+
+			It("tells each attempt which attempt it is", func() {
+				attempt1Ctx, _ := attempt1.RunArgsForCall(0)
+				attempt, ok := AttemptFromContext(attempt1Ctx)
+				Expect(ok).To(BeTrue())
+				Expect(attempt).To(Equal(StepAttempt{Number: 1, Max: 3}))
+
+				attempt2Ctx, _ := attempt2.RunArgsForCall(0)
+				attempt, ok = AttemptFromContext(attempt2Ctx)
+				Expect(ok).To(BeTrue())
+				Expect(attempt).To(Equal(StepAttempt{Number: 2, Max: 3}))
+			})
+
 			Describe("Succeeded", func() {
 				It("delegates to attempt 2", func() {
 					// internal check for success within retry loop
@@ -340,4 +365,109 @@ var _ = Describe("Retry Step", func() {
 			})
 		})
 	})
+
+	Context("when attempt 1 errors with an error which would happen again", func() {
+		invalid := StepError{
+			Kind: atc.ErrorKindConfigInvalid,
+			Err:  errors.New("invalid task configuration"),
+		}
+
+		BeforeEach(func() {
+			attempt1.RunReturns(invalid)
+		})
+
+		Describe("Run", func() {
+			var stepErr error
+
+			JustBeforeEach(func() {
+				stepErr = step.Run(ctx, state)
+			})
+
+			It("returns the error without making further attempts", func() {
+				Expect(stepErr).To(Equal(invalid))
+
+				Expect(attempt1.RunCallCount()).To(Equal(1))
+				Expect(attempt2.RunCallCount()).To(Equal(0))
+				Expect(attempt3.RunCallCount()).To(Equal(0))
+
+				Expect(fakeDelegate.RetryingCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the build has a retry budget", func() {
+		var budget *RetryBudget
+
+		BeforeEach(func() {
+			attempt1.SucceededReturns(false)
+			attempt2.SucceededReturns(false)
+			attempt3.SucceededReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			ctx = WithRetryBudget(ctx, budget)
+		})
+
+		Context("with enough retries left", func() {
+			BeforeEach(func() {
+				budget = NewRetryBudget(5)
+			})
+
+			It("draws each retry from the budget", func() {
+				Expect(step.Run(ctx, state)).To(Succeed())
+				Expect(attempt3.RunCallCount()).To(Equal(1))
+
+				Expect(budget.Remaining()).To(Equal(3))
+			})
+
+			It("tells the delegate how much of the budget remains", func() {
+				Expect(step.Run(ctx, state)).To(Succeed())
+
+				Expect(fakeDelegate.RetryingCallCount()).To(Equal(2))
+
+				_, attempt, budgetRemaining := fakeDelegate.RetryingArgsForCall(0)
+				Expect(attempt).To(Equal(StepAttempt{Number: 2, Max: 3}))
+				Expect(*budgetRemaining).To(Equal(4))
+
+				_, attempt, budgetRemaining = fakeDelegate.RetryingArgsForCall(1)
+				Expect(attempt).To(Equal(StepAttempt{Number: 3, Max: 3}))
+				Expect(*budgetRemaining).To(Equal(3))
+			})
+		})
+
+		Context("when the budget runs out", func() {
+			BeforeEach(func() {
+				budget = NewRetryBudget(1)
+			})
+
+			It("stops retrying, failing with the last attempt made", func() {
+				Expect(step.Run(ctx, state)).To(Succeed())
+
+				Expect(attempt1.RunCallCount()).To(Equal(1))
+				Expect(attempt2.RunCallCount()).To(Equal(1))
+				Expect(attempt3.RunCallCount()).To(BeZero())
+
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+
+			It("tells the delegate that the budget is exhausted", func() {
+				Expect(step.Run(ctx, state)).To(Succeed())
+
+				Expect(fakeDelegate.RetryBudgetExhaustedCallCount()).To(Equal(1))
+				_, attempt := fakeDelegate.RetryBudgetExhaustedArgsForCall(0)
+				Expect(attempt).To(Equal(StepAttempt{Number: 3, Max: 3}))
+			})
+
+			It("is shared with other retried steps", func() {
+				other1 := new(execfakes.FakeStep)
+				other2 := new(execfakes.FakeStep)
+
+				Expect(step.Run(ctx, state)).To(Succeed())
+				Expect(Retry(fakeDelegate, other1, other2).Run(ctx, state)).To(Succeed())
+
+				Expect(other1.RunCallCount()).To(Equal(1))
+				Expect(other2.RunCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -14,6 +14,10 @@ type runState struct {
 	results   *sync.Map
 	inputs    *sync.Map
 	outputs   *sync.Map
+	variables *BuildVariables
+
+	reusableContainerLock sync.Mutex
+	reusableContainer     *ReusableContainer
 }
 
 func NewRunState() RunState {
@@ -22,6 +26,7 @@ func NewRunState() RunState {
 		results:   &sync.Map{},
 		inputs:    &sync.Map{},
 		outputs:   &sync.Map{},
+		variables: NewBuildVariables(),
 	}
 }
 
@@ -29,6 +34,10 @@ func (state *runState) Artifacts() *worker.ArtifactRepository {
 	return state.artifacts
 }
 
+func (state *runState) Variables() *BuildVariables {
+	return state.variables
+}
+
 func (state *runState) Result(id atc.PlanID, to interface{}) bool {
 	val, ok := state.results.Load(id)
 	if !ok {
@@ -82,37 +91,55 @@ func (state *runState) ReadUserInput(id atc.PlanID, handler InputHandler) error
 	return handler(stream)
 }
 
-func (state *runState) ReadPlanOutput(id atc.PlanID, output io.Writer) {
-	i, loaded := state.outputs.LoadOrStore(id, make(chan io.Writer))
-	if loaded {
-		// clear out the channel
-		state.outputs.Delete(id)
+func (state *runState) ReadPlanOutput(id atc.PlanID, output io.Writer) error {
+	for {
+		err := state.planOutput(id).ReadTo(output)
+		// arrived just as the last send finished; wait for the next one
+		if err != errPlanOutputClosed {
+			return err
+		}
 	}
+}
 
-	outputs := i.(chan io.Writer)
+func (state *runState) SendPlanOutput(id atc.PlanID, handler OutputHandler) (err error) {
+	output := state.planOutput(id)
 
-	// send input (blocking) to a reader
-	outputs <- output
+	// wait for someone to read the output
+	<-output.Joined()
 
-	// wait for reader to close channel signifying that they're done
-	<-outputs
+	defer func() {
+		// readers arriving from now on will wait for the next send
+		state.outputs.Delete(id)
+		output.Close(err)
+	}()
+
+	// synchronously stream out to every reader, waiting for slow ones
+	return handler(output)
 }
 
-func (state *runState) SendPlanOutput(id atc.PlanID, handler OutputHandler) error {
-	i, loaded := state.outputs.LoadOrStore(id, make(chan io.Writer))
-	if loaded {
-		// clear out the channel
-		state.outputs.Delete(id)
+func (state *runState) TakeReusableContainer(key string) (ReusableContainer, bool) {
+	state.reusableContainerLock.Lock()
+	defer state.reusableContainerLock.Unlock()
+
+	if state.reusableContainer == nil || state.reusableContainer.Key != key {
+		return ReusableContainer{}, false
 	}
 
-	outputs := i.(chan io.Writer)
+	// taken, so that steps running in parallel do not share it
+	container := *state.reusableContainer
+	state.reusableContainer = nil
 
-	// signal to sender that we're done
-	defer close(outputs)
+	return container, true
+}
 
-	// wait for stream from request to arrive
-	stream := <-outputs
+func (state *runState) LeaveReusableContainer(container ReusableContainer) {
+	state.reusableContainerLock.Lock()
+	defer state.reusableContainerLock.Unlock()
 
-	// synchronously stream in
-	return handler(stream)
+	state.reusableContainer = &container
+}
+
+func (state *runState) planOutput(id atc.PlanID) *planOutput {
+	o, _ := state.outputs.LoadOrStore(id, newPlanOutput())
+	return o.(*planOutput)
 }
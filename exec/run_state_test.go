@@ -6,6 +6,8 @@ import (
 	"io"
 	"io/ioutil"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/exec"
@@ -154,31 +156,39 @@ var _ = Describe("RunState", func() {
 			for i := 0; i < 1000; i++ {
 				go state.ReadPlanOutput("some-plan-id", out)
 
-				state.SendPlanOutput("some-plan-id", func(w io.Writer) error {
-					Expect(w).To(Equal(out))
-					return nil
+				err := state.SendPlanOutput("some-plan-id", func(w io.Writer) error {
+					_, err := w.Write([]byte("x"))
+					return err
 				})
+				Expect(err).ToNot(HaveOccurred())
 			}
+
+			Expect(out.Len()).To(Equal(1000))
 		})
 
 		It("blocks the sender until the reader is finished", func() {
 			buf := new(bytes.Buffer)
 
 			var done bool
+			finished := make(chan struct{})
 			go func() {
 				defer GinkgoRecover()
+				defer close(finished)
 				state.ReadPlanOutput("some-plan-id", buf)
 				Expect(done).To(BeTrue())
+				Expect(buf.String()).To(Equal("hello"))
 			}()
 
 			runtime.Gosched()
 
 			err := state.SendPlanOutput("some-plan-id", func(w io.Writer) error {
-				Expect(w).To(Equal(buf))
+				_, err := w.Write([]byte("hello"))
 				done = true
-				return nil
+				return err
 			})
 			Expect(err).ToNot(HaveOccurred())
+
+			<-finished
 		})
 
 		It("bubbles up the handler error", func() {
@@ -192,5 +202,126 @@ var _ = Describe("RunState", func() {
 			})
 			Expect(err).To(Equal(disaster))
 		})
+
+		It("sends the whole output to every reader, waiting for slow ones", func() {
+			fast := new(bytes.Buffer)
+			slow := &blockingWriter{unblock: make(chan struct{})}
+
+			fastErr := make(chan error, 1)
+			slowErr := make(chan error, 1)
+
+			go func() {
+				fastErr <- state.ReadPlanOutput("some-plan-id", fast)
+			}()
+
+			go func() {
+				slowErr <- state.ReadPlanOutput("some-plan-id", slow)
+			}()
+
+			// give both readers a chance to join before anything is sent
+			time.Sleep(100 * time.Millisecond)
+
+			sent := make(chan error, 1)
+			go func() {
+				sent <- state.SendPlanOutput("some-plan-id", func(w io.Writer) error {
+					for i := 0; i < 1000; i++ {
+						_, err := w.Write([]byte("y"))
+						if err != nil {
+							return err
+						}
+					}
+
+					return nil
+				})
+			}()
+
+			Consistently(sent).ShouldNot(Receive())
+
+			close(slow.unblock)
+
+			Eventually(sent).Should(Receive(BeNil()))
+
+			Expect(<-fastErr).ToNot(HaveOccurred())
+			Expect(strings.Count(fast.String(), "y")).To(Equal(1000))
+
+			Expect(<-slowErr).ToNot(HaveOccurred())
+			Expect(strings.Count(slow.String(), "y")).To(Equal(1000))
+		})
+
+		It("fails the readers if sending fails part way", func() {
+			buf := new(bytes.Buffer)
+
+			readErr := make(chan error, 1)
+			go func() {
+				readErr <- state.ReadPlanOutput("some-plan-id", buf)
+			}()
+
+			disaster := errors.New("nope")
+			err := state.SendPlanOutput("some-plan-id", func(w io.Writer) error {
+				_, err := w.Write([]byte("hel"))
+				if err != nil {
+					return err
+				}
+
+				return disaster
+			})
+			Expect(err).To(Equal(disaster))
+
+			Expect(<-readErr).To(Equal(disaster))
+			Expect(buf.String()).To(Equal("hel"))
+		})
+
+		It("refuses readers arriving once the output is being sent", func() {
+			go state.ReadPlanOutput("some-plan-id", new(bytes.Buffer))
+
+			var lateErr error
+			err := state.SendPlanOutput("some-plan-id", func(w io.Writer) error {
+				_, err := w.Write([]byte("hel"))
+				if err != nil {
+					return err
+				}
+
+				lateErr = state.ReadPlanOutput("some-plan-id", new(bytes.Buffer))
+
+				_, err = w.Write([]byte("lo"))
+				return err
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(lateErr).To(Equal(exec.ErrPlanOutputInProgress))
+		})
+
+		It("fails to write once every reader has gone away", func() {
+			go state.ReadPlanOutput("some-plan-id", failingWriter{})
+
+			err := state.SendPlanOutput("some-plan-id", func(w io.Writer) error {
+				for {
+					_, err := w.Write([]byte("x"))
+					if err != nil {
+						return err
+					}
+
+					runtime.Gosched()
+				}
+			})
+			Expect(err).To(Equal(exec.ErrNoPlanOutputReaders))
+		})
 	})
 })
+
+// blockingWriter blocks writes until it is unblocked.
+type blockingWriter struct {
+	bytes.Buffer
+	unblock chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.unblock
+	return w.Buffer.Write(p)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("nope")
+}
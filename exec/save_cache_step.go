@@ -0,0 +1,104 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/artifactcache"
+	"github.com/concourse/atc/worker"
+)
+
+// SaveCacheStep persists an artifact to the artifact cache under a key, so
+// that a RestoreCacheStep in a later build can make it available again.
+type SaveCacheStep struct {
+	planID   atc.PlanID
+	name     worker.ArtifactName
+	key      string
+	teamID   int
+	store    artifactcache.Store
+	delegate BuildStepDelegate
+
+	succeeded bool
+}
+
+func NewSaveCacheStep(
+	planID atc.PlanID,
+	name worker.ArtifactName,
+	key string,
+	teamID int,
+	store artifactcache.Store,
+	delegate BuildStepDelegate,
+) Step {
+	return &SaveCacheStep{
+		planID:   planID,
+		name:     name,
+		key:      key,
+		teamID:   teamID,
+		store:    store,
+		delegate: delegate,
+	}
+}
+
+// Run resolves the cache key and streams the artifact into the cache. If no
+// artifact cache is configured, the step is skipped.
+//
+// If the artifact is not present in the worker.ArtifactRepository,
+// UnknownArtifactSourceError is returned.
+func (step *SaveCacheStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx).WithData(lager.Data{
+		"plan-id": step.planID,
+		"name":    step.name,
+	})
+
+	if step.store == nil {
+		fmt.Fprintln(step.delegate.Stderr(), "artifact caching is not configured; skipping save_cache")
+		step.succeeded = true
+		return nil
+	}
+
+	source, found := state.Artifacts().SourceFor(step.name)
+	if !found {
+		return UnknownArtifactSourceError{step.name}
+	}
+
+	key, err := resolveCacheKey(state.Artifacts(), step.key)
+	if err != nil {
+		return err
+	}
+
+	err = source.StreamTo(cacheDestination{
+		logger: logger,
+		store:  step.store,
+		teamID: step.teamID,
+		key:    key,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-cache", err)
+		return err
+	}
+
+	fmt.Fprintf(step.delegate.Stdout(), "saved %s to cache with key %s\n", step.name, key)
+
+	step.succeeded = true
+
+	return nil
+}
+
+func (step *SaveCacheStep) Succeeded() bool {
+	return step.succeeded
+}
+
+type cacheDestination struct {
+	logger lager.Logger
+	store  artifactcache.Store
+	teamID int
+	key    string
+}
+
+func (dest cacheDestination) StreamIn(path string, tgzStream io.Reader) error {
+	return dest.store.Save(dest.logger, dest.teamID, dest.key, tgzStream)
+}
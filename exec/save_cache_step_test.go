@@ -0,0 +1,159 @@
+package exec_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/concourse/atc/artifactcache/artifactcachefakes"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("SaveCacheStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStore    *artifactcachefakes.FakeStore
+		fakeDelegate *execfakes.FakeBuildStepDelegate
+		fakeSource   *workerfakes.FakeArtifactSource
+
+		stdout *gbytes.Buffer
+		stderr *gbytes.Buffer
+
+		repo  *worker.ArtifactRepository
+		state *execfakes.FakeRunState
+
+		key string
+
+		step    exec.Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeStore = new(artifactcachefakes.FakeStore)
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StderrReturns(stderr)
+
+		fakeSource = new(workerfakes.FakeArtifactSource)
+		fakeSource.StreamToStub = func(dest worker.ArtifactDestination) error {
+			return dest.StreamIn(".", bytes.NewBufferString("some-tgz"))
+		}
+
+		repo = worker.NewArtifactRepository()
+		repo.RegisterSource("some-artifact", fakeSource)
+
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+
+		key = "some-key"
+
+		step = exec.NewSaveCacheStep("some-plan-id", "some-artifact", key, 42, fakeStore, fakeDelegate)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		stepErr = step.Run(ctx, state)
+	})
+
+	It("saves the artifact to the store under the key", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+
+		Expect(fakeStore.SaveCallCount()).To(Equal(1))
+		_, teamID, savedKey, stream := fakeStore.SaveArgsForCall(0)
+		Expect(teamID).To(Equal(42))
+		Expect(savedKey).To(Equal("some-key"))
+		Expect(ioutil.ReadAll(stream)).To(Equal([]byte("some-tgz")))
+	})
+
+	It("succeeds", func() {
+		Expect(step.Succeeded()).To(BeTrue())
+	})
+
+	It("reports the saved key", func() {
+		Expect(stdout).To(gbytes.Say("saved some-artifact to cache with key some-key"))
+	})
+
+	Context("when the key checksums a file", func() {
+		BeforeEach(func() {
+			fakeSource.StreamFileStub = func(path string) (io.ReadCloser, error) {
+				Expect(path).To(Equal("go.sum"))
+				return ioutil.NopCloser(bytes.NewBufferString("some-contents")), nil
+			}
+
+			step = exec.NewSaveCacheStep("some-plan-id", "some-artifact", `deps-{{checksum "some-artifact/go.sum"}}`, 42, fakeStore, fakeDelegate)
+		})
+
+		It("saves the artifact under the key with the file's checksum", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+
+			Expect(fakeStore.SaveCallCount()).To(Equal(1))
+			_, _, savedKey, _ := fakeStore.SaveArgsForCall(0)
+			Expect(savedKey).To(Equal("deps-6e32ea34db1b3755d7dec972eb72c705338f0dd8e0be881d966963438fb2e800"))
+		})
+	})
+
+	Context("when the artifact is not present", func() {
+		BeforeEach(func() {
+			step = exec.NewSaveCacheStep("some-plan-id", "some-other-artifact", key, 42, fakeStore, fakeDelegate)
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(exec.UnknownArtifactSourceError{SourceName: "some-other-artifact"}))
+		})
+
+		It("is not successful", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when saving fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeStore.SaveReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+
+		It("is not successful", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when no store is configured", func() {
+		BeforeEach(func() {
+			step = exec.NewSaveCacheStep("some-plan-id", "some-artifact", key, 42, nil, fakeDelegate)
+		})
+
+		It("skips saving the artifact", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(fakeSource.StreamToCallCount()).To(BeZero())
+			Expect(stderr).To(gbytes.Say("artifact caching is not configured"))
+		})
+
+		It("succeeds", func() {
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+	})
+})
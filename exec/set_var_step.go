@@ -0,0 +1,104 @@
+package exec
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+)
+
+//go:generate counterfeiter . SetVarDelegate
+
+type SetVarDelegate interface {
+	BuildStepDelegate
+
+	// VariableSet is given the value as it may be shown to users of the
+	// build, i.e. RedactedValue if it was derived from credentials.
+	VariableSet(logger lager.Logger, name string, value interface{})
+}
+
+// SetVarStep sets a variable of the build for later steps to interpolate.
+type SetVarStep struct {
+	planID      atc.PlanID
+	name        string
+	value       string
+	credentials creds.Variables
+	delegate    SetVarDelegate
+
+	succeeded bool
+}
+
+func NewSetVarStep(
+	planID atc.PlanID,
+	name string,
+	value string,
+	credentials creds.Variables,
+	delegate SetVarDelegate,
+) *SetVarStep {
+	return &SetVarStep{
+		planID:      planID,
+		name:        name,
+		value:       value,
+		credentials: credentials,
+		delegate:    delegate,
+	}
+}
+
+// Run interpolates the value with the build's variables and credentials, and
+// stores it in the BuildVariables of the RunState.
+//
+// If interpolating the value resolves any credential, or any build variable
+// that is itself redacted, the variable is redacted too.
+func (step *SetVarStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+
+	buildVariables := state.Variables()
+
+	variables := &sensitivityTracker{
+		scope: buildVariables.scope(step.credentials),
+	}
+
+	value, err := creds.NewString(variables, step.value).Evaluate()
+	if err != nil {
+		return err
+	}
+
+	buildVariables.Set(step.name, value, variables.sensitive)
+
+	shown, _ := buildVariables.Redacted(step.name)
+
+	logger.Info("set", lager.Data{"name": step.name, "redacted": variables.sensitive})
+
+	step.delegate.VariableSet(logger, step.name, shown)
+
+	step.succeeded = true
+
+	return nil
+}
+
+// Succeeded returns true if the variable was set.
+func (step *SetVarStep) Succeeded() bool {
+	return step.succeeded
+}
+
+// sensitivityTracker records whether any variable it resolves is sensitive.
+type sensitivityTracker struct {
+	scope     *scopedVariables
+	sensitive bool
+}
+
+func (tracker *sensitivityTracker) Get(def template.VariableDefinition) (interface{}, bool, error) {
+	value, found, sensitive, err := tracker.scope.resolve(def)
+	if sensitive {
+		tracker.sensitive = true
+	}
+
+	return value, found, err
+}
+
+func (tracker *sensitivityTracker) List() ([]template.VariableDefinition, error) {
+	return tracker.scope.List()
+}
@@ -0,0 +1,128 @@
+package exec_test
+
+import (
+	"context"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SetVarStep", func() {
+	var (
+		ctx context.Context
+
+		credentials  template.StaticVariables
+		fakeDelegate *execfakes.FakeSetVarDelegate
+
+		buildVariables *exec.BuildVariables
+		state          *execfakes.FakeRunState
+
+		value string
+
+		step    *exec.SetVarStep
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		credentials = template.StaticVariables{
+			"some-secret": "super-secret",
+		}
+
+		fakeDelegate = new(execfakes.FakeSetVarDelegate)
+
+		buildVariables = exec.NewBuildVariables()
+		state = new(execfakes.FakeRunState)
+		state.VariablesReturns(buildVariables)
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewSetVarStep("some-plan-id", "some-var", value, credentials, fakeDelegate)
+		stepErr = step.Run(ctx, state)
+	})
+
+	Context("when the value is plain", func() {
+		BeforeEach(func() {
+			value = "some-value"
+		})
+
+		It("sets the variable for later steps", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+
+			value, found, err := buildVariables.Get(template.VariableDefinition{Name: "some-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+		})
+
+		It("shows the value", func() {
+			Expect(fakeDelegate.VariableSetCallCount()).To(Equal(1))
+			_, name, shown := fakeDelegate.VariableSetArgsForCall(0)
+			Expect(name).To(Equal("some-var"))
+			Expect(shown).To(Equal("some-value"))
+		})
+	})
+
+	Context("when the value interpolates a credential", func() {
+		BeforeEach(func() {
+			value = "prefix-((some-secret))"
+		})
+
+		It("sets the interpolated value", func() {
+			value, _, _ := buildVariables.Get(template.VariableDefinition{Name: "some-var"})
+			Expect(value).To(Equal("prefix-super-secret"))
+		})
+
+		It("redacts it", func() {
+			_, _, shown := fakeDelegate.VariableSetArgsForCall(0)
+			Expect(shown).To(Equal(exec.RedactedValue))
+		})
+	})
+
+	Context("when the value interpolates another build variable", func() {
+		BeforeEach(func() {
+			value = "((other-var))-suffix"
+		})
+
+		Context("that is not redacted", func() {
+			BeforeEach(func() {
+				buildVariables.Set("other-var", "other-value", false)
+			})
+
+			It("shows the value", func() {
+				_, _, shown := fakeDelegate.VariableSetArgsForCall(0)
+				Expect(shown).To(Equal("other-value-suffix"))
+			})
+		})
+
+		Context("that is redacted", func() {
+			BeforeEach(func() {
+				buildVariables.Set("other-var", "other-secret", true)
+			})
+
+			It("redacts it too", func() {
+				_, _, shown := fakeDelegate.VariableSetArgsForCall(0)
+				Expect(shown).To(Equal(exec.RedactedValue))
+			})
+		})
+	})
+
+	Context("when the value refers to a variable that does not exist", func() {
+		BeforeEach(func() {
+			value = "((bogus))"
+		})
+
+		It("returns an error without setting the variable", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(step.Succeeded()).To(BeFalse())
+
+			_, found, _ := buildVariables.Get(template.VariableDefinition{Name: "some-var"})
+			Expect(found).To(BeFalse())
+		})
+	})
+})
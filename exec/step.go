@@ -43,8 +43,26 @@ type RunState interface {
 	SendUserInput(atc.PlanID, io.ReadCloser)
 	ReadUserInput(atc.PlanID, InputHandler) error
 
-	ReadPlanOutput(atc.PlanID, io.Writer)
+	ReadPlanOutput(atc.PlanID, io.Writer) error
 	SendPlanOutput(atc.PlanID, OutputHandler) error
+
+	Variables() *BuildVariables
+
+	// TakeReusableContainer takes the container left by the last task step
+	// which opted into reusing containers, if it was left for the given key.
+	TakeReusableContainer(key string) (ReusableContainer, bool)
+	LeaveReusableContainer(ReusableContainer)
+}
+
+// ReusableContainer is a task container left behind by a task step for the
+// next task step with the same image, platform and inputs to run in.
+type ReusableContainer struct {
+	Key           string
+	Container     worker.Container
+	ArtifactsRoot string
+
+	// the handle of the volume mounted for each input by its path
+	Inputs map[string]string
 }
 
 // ExitStatus is the resulting exit code from the process that the step ran.
@@ -0,0 +1,127 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
+)
+
+// StepInfo describes the step being constructed by the factory.
+type StepInfo struct {
+	Type string
+	Name string
+
+	Plan     atc.Plan
+	Build    db.Build
+	Delegate BuildStepDelegate
+}
+
+// StepMiddleware wraps each step constructed by the factory, so that behavior
+// common to every type of step, e.g. logging its error or tracing it, does
+// not have to be implemented by each of them.
+type StepMiddleware func(StepInfo, Step) Step
+
+// ChainStepMiddleware wraps the step with the middlewares, the first being
+// the outermost.
+func ChainStepMiddleware(info StepInfo, step Step, middlewares ...StepMiddleware) Step {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		step = middlewares[i](info, step)
+	}
+
+	return step
+}
+
+// LogErrorMiddleware logs the error the step fails with to the build.
+func LogErrorMiddleware(info StepInfo, step Step) Step {
+	return LogError(step, info.Delegate)
+}
+
+// TracingMiddleware runs the step in a span started by the tracer.
+func TracingMiddleware(tracer Tracer) StepMiddleware {
+	return func(info StepInfo, step Step) Step {
+		return Traced(step, tracer, info.Type+" "+info.Name, map[string]string{
+			"build-id":  fmt.Sprintf("%d", info.Build.ID()),
+			"build":     info.Build.Name(),
+			"job":       info.Build.JobName(),
+			"pipeline":  info.Build.PipelineName(),
+			"team":      info.Build.TeamName(),
+			"plan-id":   string(info.Plan.ID),
+			"step-name": info.Name,
+		})
+	}
+}
+
+// MetricsMiddleware emits how long the step took once it has finished.
+func MetricsMiddleware(info StepInfo, step Step) Step {
+	return metricsStep{
+		Step: step,
+		info: info,
+	}
+}
+
+type metricsStep struct {
+	Step
+
+	info StepInfo
+}
+
+func (step metricsStep) Run(ctx context.Context, state RunState) error {
+	start := time.Now()
+
+	err := step.Step.Run(ctx, state)
+
+	metric.StepFinished{
+		PipelineName: step.info.Build.PipelineName(),
+		JobName:      step.info.Build.JobName(),
+		BuildID:      step.info.Build.ID(),
+		StepType:     step.info.Type,
+		StepName:     step.info.Name,
+		Succeeded:    err == nil && step.Step.Succeeded(),
+		Duration:     time.Since(start),
+	}.Emit(lagerctx.FromContext(ctx))
+
+	return err
+}
+
+//go:generate counterfeiter . StepPolicy
+
+// StepPolicy decides whether a step may run at all, e.g. to restrict the
+// resource types a team's builds may use.
+type StepPolicy interface {
+	Check(StepInfo) error
+}
+
+// PolicyMiddleware fails the step without running it if the policy rejects
+// it.
+func PolicyMiddleware(policy StepPolicy) StepMiddleware {
+	return func(info StepInfo, step Step) Step {
+		return policyStep{
+			Step: step,
+
+			info:   info,
+			policy: policy,
+		}
+	}
+}
+
+type policyStep struct {
+	Step
+
+	info   StepInfo
+	policy StepPolicy
+}
+
+func (step policyStep) Run(ctx context.Context, state RunState) error {
+	err := step.policy.Check(step.info)
+	if err != nil {
+		return StepError{Kind: atc.ErrorKindConfigInvalid, Err: err}
+	}
+
+	return step.Step.Run(ctx, state)
+}
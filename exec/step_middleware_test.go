@@ -0,0 +1,194 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/worker"
+)
+
+var _ = Describe("Step Middleware", func() {
+	var (
+		ctx context.Context
+
+		fakeStep     *execfakes.FakeStep
+		fakeBuild    *dbfakes.FakeBuild
+		fakeDelegate *execfakes.FakeBuildStepDelegate
+
+		state *execfakes.FakeRunState
+
+		info exec.StepInfo
+
+		disaster error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("7")
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.TeamNameReturns("some-team")
+
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(worker.NewArtifactRepository())
+
+		info = exec.StepInfo{
+			Type: "task",
+			Name: "some-task",
+
+			Plan:     atc.Plan{ID: "some-plan-id"},
+			Build:    fakeBuild,
+			Delegate: fakeDelegate,
+		}
+
+		disaster = errors.New("nope")
+	})
+
+	Describe("ChainStepMiddleware", func() {
+		var ran []string
+
+		recording := func(name string) exec.StepMiddleware {
+			return func(_ exec.StepInfo, step exec.Step) exec.Step {
+				stub := new(execfakes.FakeStep)
+				stub.RunStub = func(ctx context.Context, state exec.RunState) error {
+					ran = append(ran, name)
+					return step.Run(ctx, state)
+				}
+
+				return stub
+			}
+		}
+
+		BeforeEach(func() {
+			ran = nil
+
+			fakeStep.RunStub = func(context.Context, exec.RunState) error {
+				ran = append(ran, "step")
+				return nil
+			}
+		})
+
+		It("wraps the step with the first middleware outermost", func() {
+			step := exec.ChainStepMiddleware(info, fakeStep, recording("first"), recording("second"))
+
+			Expect(step.Run(ctx, state)).To(Succeed())
+			Expect(ran).To(Equal([]string{"first", "second", "step"}))
+		})
+
+		It("returns the step itself without any middleware", func() {
+			Expect(exec.ChainStepMiddleware(info, fakeStep)).To(Equal(fakeStep))
+		})
+	})
+
+	Describe("LogErrorMiddleware", func() {
+		BeforeEach(func() {
+			fakeStep.RunReturns(disaster)
+		})
+
+		It("logs the step's error to its delegate", func() {
+			err := exec.LogErrorMiddleware(info, fakeStep).Run(ctx, state)
+			Expect(err).To(Equal(disaster))
+
+			Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
+			_, message, kind := fakeDelegate.ErroredArgsForCall(0)
+			Expect(message).To(Equal("nope"))
+			Expect(kind).To(Equal(atc.ErrorKindUnknown))
+		})
+	})
+
+	Describe("TracingMiddleware", func() {
+		var fakeTracer *execfakes.FakeTracer
+
+		BeforeEach(func() {
+			fakeTracer = new(execfakes.FakeTracer)
+			fakeTracer.StartStub = func(ctx context.Context, _ string, _ map[string]string) (context.Context, exec.Span) {
+				return ctx, new(execfakes.FakeSpan)
+			}
+		})
+
+		It("starts a span describing the step and its build", func() {
+			err := exec.TracingMiddleware(fakeTracer)(info, fakeStep).Run(ctx, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTracer.StartCallCount()).To(Equal(1))
+			_, name, attributes := fakeTracer.StartArgsForCall(0)
+			Expect(name).To(Equal("task some-task"))
+			Expect(attributes).To(Equal(map[string]string{
+				"build-id":  "42",
+				"build":     "7",
+				"job":       "some-job",
+				"pipeline":  "some-pipeline",
+				"team":      "some-team",
+				"plan-id":   "some-plan-id",
+				"step-name": "some-task",
+			}))
+
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("MetricsMiddleware", func() {
+		BeforeEach(func() {
+			fakeStep.RunReturns(disaster)
+		})
+
+		It("runs the step and returns its error", func() {
+			step := exec.MetricsMiddleware(info, fakeStep)
+
+			Expect(step.Run(ctx, state)).To(Equal(disaster))
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("PolicyMiddleware", func() {
+		var (
+			fakePolicy *execfakes.FakeStepPolicy
+
+			runErr error
+		)
+
+		BeforeEach(func() {
+			fakePolicy = new(execfakes.FakeStepPolicy)
+		})
+
+		JustBeforeEach(func() {
+			runErr = exec.PolicyMiddleware(fakePolicy)(info, fakeStep).Run(ctx, state)
+		})
+
+		It("checks the step against the policy", func() {
+			Expect(fakePolicy.CheckCallCount()).To(Equal(1))
+			Expect(fakePolicy.CheckArgsForCall(0)).To(Equal(info))
+		})
+
+		Context("when the policy allows the step", func() {
+			It("runs the step", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+				Expect(fakeStep.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the policy rejects the step", func() {
+			BeforeEach(func() {
+				fakePolicy.CheckReturns(disaster)
+			})
+
+			It("fails with an invalid config error without running the step", func() {
+				Expect(runErr).To(Equal(exec.StepError{Kind: atc.ErrorKindConfigInvalid, Err: disaster}))
+				Expect(fakeStep.RunCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
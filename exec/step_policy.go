@@ -0,0 +1,88 @@
+package exec
+
+import (
+	"fmt"
+
+	"github.com/concourse/atc"
+)
+
+// ResourceTypeNotAllowedError is returned by the resource type policy when a
+// step uses a resource type the operator has not allowed.
+type ResourceTypeNotAllowedError struct {
+	Type string
+}
+
+func (err ResourceTypeNotAllowedError) Error() string {
+	return fmt.Sprintf("resource type not allowed: %s", err.Type)
+}
+
+type resourceTypePolicy struct {
+	allowed map[string]bool
+}
+
+// NewResourceTypePolicy constructs a StepPolicy which only allows steps to
+// use the given resource types. A custom resource type is allowed if the type
+// it is ultimately provided by is.
+//
+// Only what is known as the step is constructed is checked, so the image of a
+// task whose config is loaded from a file is not.
+func NewResourceTypePolicy(allowed []string) StepPolicy {
+	policy := resourceTypePolicy{
+		allowed: map[string]bool{},
+	}
+
+	for _, resourceType := range allowed {
+		policy.allowed[resourceType] = true
+	}
+
+	return policy
+}
+
+func (policy resourceTypePolicy) Check(info StepInfo) error {
+	plan := info.Plan
+
+	switch {
+	case plan.Get != nil:
+		return policy.checkType(plan.Get.Type, plan.Get.VersionedResourceTypes)
+
+	case plan.Put != nil:
+		return policy.checkType(plan.Put.Type, plan.Put.VersionedResourceTypes)
+
+	case plan.Promote != nil:
+		err := policy.checkType(plan.Promote.FromType, plan.Promote.VersionedResourceTypes)
+		if err != nil {
+			return err
+		}
+
+		return policy.checkType(plan.Promote.Type, plan.Promote.VersionedResourceTypes)
+
+	case plan.WaitFor != nil && plan.WaitFor.HTTP == nil:
+		return policy.checkType(plan.WaitFor.Type, plan.WaitFor.VersionedResourceTypes)
+
+	case plan.Task != nil && plan.Task.Config != nil && plan.Task.Config.ImageResource != nil:
+		return policy.checkType(plan.Task.Config.ImageResource.Type, plan.Task.VersionedResourceTypes)
+	}
+
+	return nil
+}
+
+// checkType follows the custom resource types providing the type down to
+// the base resource type, which must be allowed.
+func (policy resourceTypePolicy) checkType(name string, resourceTypes atc.VersionedResourceTypes) error {
+	for {
+		customType, found := resourceTypes.Lookup(name)
+		if !found {
+			break
+		}
+
+		// a custom type may shadow the type providing it, e.g. a newer git
+		resourceTypes = resourceTypes.Without(name)
+		name = customType.Type
+	}
+
+	if !policy.allowed[name] {
+		return ResourceTypeNotAllowedError{Type: name}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package exec_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/exec"
+)
+
+var _ = Describe("ResourceTypePolicy", func() {
+	var (
+		policy exec.StepPolicy
+		plan   atc.Plan
+
+		checkErr error
+	)
+
+	BeforeEach(func() {
+		policy = exec.NewResourceTypePolicy([]string{"git", "registry-image"})
+	})
+
+	JustBeforeEach(func() {
+		checkErr = policy.Check(exec.StepInfo{Plan: plan})
+	})
+
+	Context("when a get uses an allowed type", func() {
+		BeforeEach(func() {
+			plan = atc.Plan{Get: &atc.GetPlan{Type: "git"}}
+		})
+
+		It("allows it", func() {
+			Expect(checkErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when a put uses a type that is not allowed", func() {
+		BeforeEach(func() {
+			plan = atc.Plan{Put: &atc.PutPlan{Type: "s3"}}
+		})
+
+		It("rejects it", func() {
+			Expect(checkErr).To(Equal(exec.ResourceTypeNotAllowedError{Type: "s3"}))
+		})
+	})
+
+	Context("when a get uses a custom type", func() {
+		var getPlan *atc.GetPlan
+
+		BeforeEach(func() {
+			getPlan = &atc.GetPlan{Type: "some-custom-type"}
+			plan = atc.Plan{Get: getPlan}
+		})
+
+		Context("provided by an allowed type", func() {
+			BeforeEach(func() {
+				getPlan.VersionedResourceTypes = atc.VersionedResourceTypes{
+					{ResourceType: atc.ResourceType{Name: "some-custom-type", Type: "registry-image"}},
+				}
+			})
+
+			It("allows it", func() {
+				Expect(checkErr).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("provided by a type that is not allowed", func() {
+			BeforeEach(func() {
+				getPlan.VersionedResourceTypes = atc.VersionedResourceTypes{
+					{ResourceType: atc.ResourceType{Name: "some-custom-type", Type: "some-other-custom-type"}},
+					{ResourceType: atc.ResourceType{Name: "some-other-custom-type", Type: "docker-image"}},
+				}
+			})
+
+			It("rejects it", func() {
+				Expect(checkErr).To(Equal(exec.ResourceTypeNotAllowedError{Type: "docker-image"}))
+			})
+		})
+
+		Context("shadowing the type providing it", func() {
+			BeforeEach(func() {
+				getPlan.VersionedResourceTypes = atc.VersionedResourceTypes{
+					{ResourceType: atc.ResourceType{Name: "some-custom-type", Type: "some-custom-type"}},
+				}
+			})
+
+			It("rejects it as the base type", func() {
+				Expect(checkErr).To(Equal(exec.ResourceTypeNotAllowedError{Type: "some-custom-type"}))
+			})
+		})
+	})
+
+	Context("when a task's image resource uses a type that is not allowed", func() {
+		BeforeEach(func() {
+			plan = atc.Plan{
+				Task: &atc.TaskPlan{
+					Config: &atc.TaskConfig{
+						ImageResource: &atc.ImageResource{Type: "docker-image"},
+					},
+				},
+			}
+		})
+
+		It("rejects it", func() {
+			Expect(checkErr).To(Equal(exec.ResourceTypeNotAllowedError{Type: "docker-image"}))
+		})
+	})
+
+	Context("when a step uses no resource type", func() {
+		BeforeEach(func() {
+			plan = atc.Plan{SetVar: &atc.SetVarPlan{Name: "some-var", Value: "some-value"}}
+		})
+
+		It("allows it", func() {
+			Expect(checkErr).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -133,7 +133,10 @@ func (configSource FileConfigSource) FetchConfig(repo *worker.ArtifactRepository
 	stream, err := source.StreamFile(filePath)
 	if err != nil {
 		if err == baggageclaim.ErrFileNotFound {
-			return atc.TaskConfig{}, fmt.Errorf("task config '%s/%s' not found", sourceName, filePath)
+			return atc.TaskConfig{}, StepError{
+				Kind: atc.ErrorKindConfigInvalid,
+				Err:  fmt.Errorf("task config '%s/%s' not found", sourceName, filePath),
+			}
 		}
 		return atc.TaskConfig{}, err
 	}
@@ -147,7 +150,10 @@ func (configSource FileConfigSource) FetchConfig(repo *worker.ArtifactRepository
 
 	config, err := atc.NewTaskConfig(streamedFile)
 	if err != nil {
-		return atc.TaskConfig{}, fmt.Errorf("failed to load %s: %s", configSource.Path, err)
+		return atc.TaskConfig{}, StepError{
+			Kind: atc.ErrorKindConfigInvalid,
+			Err:  fmt.Errorf("failed to load %s: %s", configSource.Path, err),
+		}
 	}
 
 	return config, nil
@@ -203,7 +209,10 @@ func (configSource ValidatingConfigSource) FetchConfig(source *worker.ArtifactRe
 	}
 
 	if err := config.Validate(); err != nil {
-		return atc.TaskConfig{}, err
+		return atc.TaskConfig{}, StepError{
+			Kind: atc.ErrorKindConfigInvalid,
+			Err:  err,
+		}
 	}
 
 	return config, nil
@@ -525,6 +525,10 @@ run: {path: a/file}
 			It("returns the validation error", func() {
 				Expect(fetchErr).To(HaveOccurred())
 			})
+
+			It("classifies the error as an invalid config", func() {
+				Expect(ClassifyError(fetchErr)).To(Equal(atc.ErrorKindConfigInvalid))
+			})
 		})
 
 		Context("when fetching the config fails", func() {
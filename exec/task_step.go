@@ -4,20 +4,27 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/artifactcache"
+	"github.com/concourse/atc/artifactscan"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/worker"
 )
 
@@ -25,6 +32,27 @@ const taskProcessID = "task"
 const taskProcessPropertyName = "concourse:task-process"
 const taskExitStatusPropertyName = "concourse:exit-status"
 
+// DefaultTaskCheckpointInterval is how often a task is checked for a new
+// checkpoint when no interval is configured.
+const DefaultTaskCheckpointInterval = time.Minute
+
+// TaskCheckpointMarker is the file in a task's checkpoint directory which the
+// task writes to, e.g. with a sequence number, once the directory holds a new
+// checkpoint. The directory is only saved when the marker changes.
+const TaskCheckpointMarker = ".checkpoint"
+
+// maxCheckpointMarkerSize bounds how much of the marker file is compared.
+const maxCheckpointMarkerSize = 1024
+
+// maxTaskRecoveries is how many times a checkpointed task is re-run after
+// losing its process before the step gives up.
+const maxTaskRecoveries = 3
+
+// WorkerSlotPollInterval is how long a TaskStep waits between attempts to
+// place its container while every worker is running the maximum number of
+// active tasks.
+const WorkerSlotPollInterval = 10 * time.Second
+
 // MissingInputsError is returned when any of the task's required inputs are
 // missing.
 type MissingInputsError struct {
@@ -62,6 +90,8 @@ type TaskDelegate interface {
 	Initializing(lager.Logger, atc.TaskConfig)
 	Starting(lager.Logger, atc.TaskConfig)
 	Finished(lager.Logger, ExitStatus)
+	NoisyNeighbors(lager.Logger, NoisyNeighbors)
+	WaitingForWorker(logger lager.Logger, polls int)
 }
 
 // TaskStep executes a TaskConfig, whose inputs will be fetched from the
@@ -75,6 +105,7 @@ type TaskStep struct {
 
 	artifactsRoot     string
 	imageArtifactName string
+	reuseContainer    bool
 
 	delegate TaskDelegate
 
@@ -90,6 +121,14 @@ type TaskStep struct {
 
 	variables creds.Variables
 
+	noisyNeighbors NoisyNeighborDetector
+
+	scanner    artifactscan.Scanner
+	scanPolicy artifactscan.Policy
+
+	checkpoints artifactcache.Store
+	recoveries  int
+
 	succeeded bool
 }
 
@@ -101,6 +140,7 @@ func NewTaskStep(
 	outputMapping map[string]string,
 	artifactsRoot string,
 	imageArtifactName string,
+	reuseContainer bool,
 	delegate TaskDelegate,
 	workerPool worker.Client,
 	teamID int,
@@ -111,7 +151,11 @@ func NewTaskStep(
 	containerMetadata db.ContainerMetadata,
 	resourceTypes creds.VersionedResourceTypes,
 	variables creds.Variables,
-) Step {
+	noisyNeighbors NoisyNeighborDetector,
+	scanner artifactscan.Scanner,
+	scanPolicy artifactscan.Policy,
+	checkpoints artifactcache.Store,
+) *TaskStep {
 	return &TaskStep{
 		privileged:        privileged,
 		configSource:      configSource,
@@ -120,6 +164,7 @@ func NewTaskStep(
 		outputMapping:     outputMapping,
 		artifactsRoot:     artifactsRoot,
 		imageArtifactName: imageArtifactName,
+		reuseContainer:    reuseContainer,
 		delegate:          delegate,
 		workerPool:        workerPool,
 		teamID:            teamID,
@@ -130,6 +175,10 @@ func NewTaskStep(
 		containerMetadata: containerMetadata,
 		resourceTypes:     resourceTypes,
 		variables:         variables,
+		noisyNeighbors:    noisyNeighbors,
+		scanner:           scanner,
+		scanPolicy:        scanPolicy,
+		checkpoints:       checkpoints,
 	}
 }
 
@@ -142,15 +191,78 @@ func NewTaskStep(
 // is returned.
 //
 // Once all the inputs are satisfied, the task's script will be executed. If
-// the task is canceled via the context, the script will be interrupted.
+// the task is canceled via the context, the script will be interrupted, and
+// forcibly terminated if it outlives the cleanup budget.
 //
 // If the script exits successfully, the outputs specified in the TaskConfig
 // are registered with the worker.ArtifactRepository. If no outputs are specified, the
 // task's entire working directory is registered as an ArtifactSource under the
-// name of the task.
+// name of the task. Outputs configured with paths only expose the files
+// matching those paths, and outputs configured with a max size fail the task
+// with OutputSizeExceededError if their files add up to more than it. If an
+// artifact scanner is configured, each output is then streamed through it.
+//
+// Any sidecars configured for the task are started along with its container
+// and terminated once the step returns.
+//
+// If noisy neighbor detection is enabled, the script's duration is compared
+// with previous runs of the step, and any slowdown that coincides with more
+// containers than usual running on the worker is reported to the delegate.
+//
+// If the TaskConfig declares a checkpoint, its directory is saved to the
+// checkpoint store whenever the script marks a new checkpoint, checked at the
+// configured interval, and restored into the container when it is created.
+// Should the script's process be lost, e.g. along with its worker, the step
+// finds or creates the container again and resumes from the last checkpoint,
+// up to maxTaskRecoveries times. The checkpoint is deleted once the step no
+// longer needs it.
+//
+// While every worker is running the maximum number of active tasks, the step
+// waits for a free slot, reporting each attempt to place its container to the
+// delegate.
+//
+// If the step opts into reusing containers, it runs in the container left by
+// the last such task step of the build, provided they have the same image and
+// platform and the container has volumes for each of the task's outputs. The
+// task then runs in the artifacts directory of that step, with its inputs
+// streamed into the container unless they are already mounted there. Tasks
+// with caches, checkpoints, or sidecars always get a container of their own.
 func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 	logger := lagerctx.FromContext(ctx)
 
+	for {
+		err := action.run(ctx, state)
+
+		lost, ok := err.(lostTaskProcessError)
+		if !ok {
+			return err
+		}
+
+		if action.recoveries >= maxTaskRecoveries {
+			action.deleteCheckpoint(logger)
+			return lost.err
+		}
+
+		action.recoveries++
+
+		logger.Error("lost-task-process", lost.err, lager.Data{"recoveries": action.recoveries})
+		fmt.Fprintf(action.delegate.Stderr(), "lost task process (%s); resuming from last checkpoint\n", lost.err)
+	}
+}
+
+// lostTaskProcessError is returned by run when the process of a checkpointed
+// task is lost, in which case it is run again.
+type lostTaskProcessError struct {
+	err error
+}
+
+func (err lostTaskProcessError) Error() string {
+	return err.err.Error()
+}
+
+func (action *TaskStep) run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+
 	repository := state.Artifacts()
 
 	config, err := action.configSource.FetchConfig(repository)
@@ -160,25 +272,56 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 
 	action.delegate.Initializing(logger, config)
 
-	containerSpec, err := action.containerSpec(logger, repository, config)
+	reuseKey, err := action.reuseKey(config)
 	if err != nil {
 		return err
 	}
 
-	container, err := action.workerPool.FindOrCreateContainer(
-		ctx,
-		logger,
-		action.delegate,
-		db.NewBuildStepContainerOwner(action.buildID, action.planID),
-		action.containerMetadata,
-		containerSpec,
-		action.resourceTypes,
-	)
+	var container worker.Container
+	var reused bool
+
+	var reusable ReusableContainer
+	if reuseKey != "" {
+		var found bool
+		reusable, found = state.TakeReusableContainer(reuseKey)
+		if found {
+			action.artifactsRoot = reusable.ArtifactsRoot
+		}
+	}
+
+	containerSpec, err := action.containerSpec(logger, repository, config)
 	if err != nil {
 		return err
 	}
 
-	exitStatusProp, err := container.Property(taskExitStatusPropertyName)
+	inputVolumes, reusableInputs := taskInputVolumes(containerSpec)
+
+	if reusable.Container != nil && reusableInputs && sameInputVolumes(reusable.Inputs, inputVolumes) {
+		container = reusable.Container
+		reused = true
+	}
+
+	processID := taskProcessID
+	exitStatusPropertyName := taskExitStatusPropertyName
+
+	if reused {
+		logger.Info("reusing-container", lager.Data{"handle": container.Handle()})
+
+		// the container's own task has already run and exited in it
+		processID = taskProcessID + "-" + string(action.planID)
+		exitStatusPropertyName = taskExitStatusPropertyName + "-" + string(action.planID)
+	} else {
+		container, err = action.findOrCreateContainer(ctx, logger, containerSpec)
+		if err != nil {
+			return err
+		}
+
+		defer action.releaseTaskSlot(ctx, logger)
+	}
+
+	defer action.stopSidecars(logger, container, config)
+
+	exitStatusProp, err := container.Property(exitStatusPropertyName)
 	if err == nil {
 		logger.Info("already-exited", lager.Data{"status": exitStatusProp})
 
@@ -194,15 +337,21 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 			return err
 		}
 
-		return nil
+		err = action.checkOutputSizes(logger, repository, config, container)
+		if err != nil {
+			return err
+		}
+
+		return action.scanOutputs(ctx, logger, repository, config)
 	}
 
-	// for backwards compatibility with containers
-	// that had their task process name set as property
-	var processID string
-	processID, err = container.Property(taskProcessPropertyName)
-	if err != nil {
-		processID = taskProcessID
+	if !reused {
+		// for backwards compatibility with containers
+		// that had their task process name set as property
+		legacyProcessID, err := container.Property(taskProcessPropertyName)
+		if err == nil {
+			processID = legacyProcessID
+		}
 	}
 
 	processIO := garden.ProcessIO{
@@ -210,6 +359,11 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 		Stderr: action.delegate.Stderr(),
 	}
 
+	// the duration of a process that was already running is unknown, so only
+	// processes spawned by this step are checked for noisy neighbors
+	var started time.Time
+	var neighbors int
+
 	process, err := container.Attach(processID, processIO)
 	if err == nil {
 		logger.Info("already-running")
@@ -218,13 +372,21 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 
 		action.delegate.Starting(logger, config)
 
+		started = time.Now()
+		neighbors = action.neighbors(logger, container)
+
 		process, err = container.Run(garden.ProcessSpec{
-			ID: taskProcessID,
+			ID: processID,
 
 			Path: config.Run.Path,
 			Args: config.Run.Args,
 
-			Dir: path.Join(action.artifactsRoot, config.Run.Dir),
+			Dir: taskWorkingDir(config.Platform, action.artifactsRoot, config.Run.Dir),
+
+			// propagate the step's trace context so that the task can report
+			// spans of its own as part of the build's trace, and let it know
+			// which attempt it is if it's being retried
+			Env: append(traceEnv(SpanFromContext(ctx)), attemptEnv(ctx)...),
 
 			// Guardian sets the default TTY window size to width: 80, height: 24,
 			// which creates ANSI control sequences that do not work with other window sizes
@@ -237,6 +399,8 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 
 	logger.Info("attached")
 
+	stopCheckpointing := action.startCheckpointing(logger, container, config)
+
 	exited := make(chan struct{})
 	var processStatus int
 	var processErr error
@@ -248,43 +412,473 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 
 	select {
 	case <-ctx.Done():
+		stopCheckpointing()
+
 		err = action.registerOutputs(logger, repository, config, container)
 		if err != nil {
 			return err
 		}
 
-		err = container.Stop(false)
-		if err != nil {
-			logger.Error("stopping-container", err)
-		}
-
-		<-exited
+		action.stop(ctx, logger, container, exited)
 
 		return ctx.Err()
 
 	case <-exited:
+		stopCheckpointing()
+
 		if processErr != nil {
+			if config.Checkpoint != nil {
+				return lostTaskProcessError{processErr}
+			}
+
 			return processErr
 		}
 
+		if config.Checkpoint != nil {
+			action.deleteCheckpoint(logger)
+		}
+
 		err = action.registerOutputs(logger, repository, config, container)
 		if err != nil {
 			return err
 		}
 
+		err = action.checkOutputSizes(logger, repository, config, container)
+		if err != nil {
+			return err
+		}
+
+		err = action.scanOutputs(ctx, logger, repository, config)
+		if err != nil {
+			return err
+		}
+
 		action.delegate.Finished(logger, ExitStatus(processStatus))
 
-		err = container.SetProperty(taskExitStatusPropertyName, fmt.Sprintf("%d", processStatus))
+		if !started.IsZero() {
+			action.detectNoisyNeighbors(logger, container, time.Since(started), neighbors)
+		}
+
+		err = container.SetProperty(exitStatusPropertyName, fmt.Sprintf("%d", processStatus))
 		if err != nil {
 			return err
 		}
 
 		action.succeeded = processStatus == 0
 
+		if reuseKey != "" && reusableInputs {
+			state.LeaveReusableContainer(ReusableContainer{
+				Key:           reuseKey,
+				Container:     container,
+				ArtifactsRoot: action.artifactsRoot,
+				Inputs:        inputVolumes,
+			})
+		}
+
 		return nil
 	}
 }
 
+// startCheckpointing periodically checks whether the task has marked a new
+// checkpoint, by writing to the marker file in its checkpoint directory, and
+// if so saves the directory to the checkpoint store. This goes on until the
+// returned function is called. Failing to save a checkpoint is only logged,
+// as the task can still run to completion.
+func (action *TaskStep) startCheckpointing(logger lager.Logger, container worker.Container, config atc.TaskConfig) func() {
+	if config.Checkpoint == nil {
+		return func() {}
+	}
+
+	if action.checkpoints == nil {
+		fmt.Fprintln(action.delegate.Stderr(), "checkpoints are not configured; the task will start over if its container is lost")
+		return func() {}
+	}
+
+	interval := DefaultTaskCheckpointInterval
+	if config.Checkpoint.Interval != "" {
+		var err error
+		interval, err = time.ParseDuration(config.Checkpoint.Interval)
+		if err != nil {
+			logger.Error("failed-to-parse-checkpoint-interval", err)
+			interval = DefaultTaskCheckpointInterval
+		}
+	}
+
+	checkpointPath := filepath.Join(action.artifactsRoot, config.Checkpoint.Path)
+
+	var volume worker.Volume
+	for _, mount := range container.VolumeMounts() {
+		if mount.MountPath == checkpointPath {
+			volume = mount.Volume
+		}
+	}
+
+	if volume == nil {
+		logger.Info("checkpoint-volume-not-found", lager.Data{"path": checkpointPath})
+		return func() {}
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// a restored checkpoint is already saved
+		saved := checkpointMarker(logger, volume)
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				marker := checkpointMarker(logger, volume)
+				if marker == "" || marker == saved {
+					continue
+				}
+
+				if action.saveCheckpoint(logger, volume) {
+					saved = marker
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// checkpointMarker reads the marker file in the checkpoint directory, which
+// the task writes to once the directory holds a new checkpoint. It is empty
+// if the task has not marked a checkpoint yet.
+func checkpointMarker(logger lager.Logger, volume worker.Volume) string {
+	file, err := newTaskArtifactSource(logger, volume).StreamFile(TaskCheckpointMarker)
+	if err != nil {
+		return ""
+	}
+
+	defer file.Close()
+
+	marker, err := ioutil.ReadAll(io.LimitReader(file, maxCheckpointMarkerSize))
+	if err != nil {
+		logger.Error("failed-to-read-checkpoint-marker", err)
+		return ""
+	}
+
+	return string(marker)
+}
+
+func (action *TaskStep) saveCheckpoint(logger lager.Logger, volume worker.Volume) bool {
+	out, err := volume.StreamOut(".")
+	if err != nil {
+		logger.Error("failed-to-stream-out-checkpoint", err)
+		return false
+	}
+
+	defer out.Close()
+
+	err = action.checkpoints.Save(logger, action.teamID, action.checkpointKey(), out)
+	if err != nil {
+		logger.Error("failed-to-save-checkpoint", err)
+		return false
+	}
+
+	logger.Debug("saved-checkpoint")
+
+	return true
+}
+
+// deleteCheckpoint removes the step's checkpoint from the checkpoint store,
+// once the step has either finished or given up on resuming from it.
+func (action *TaskStep) deleteCheckpoint(logger lager.Logger) {
+	if action.checkpoints == nil {
+		return
+	}
+
+	err := action.checkpoints.Delete(logger, action.teamID, action.checkpointKey())
+	if err != nil {
+		logger.Error("failed-to-delete-checkpoint", err)
+	}
+}
+
+// checkpointKey identifies the step's checkpoint within the team's entries
+// in the checkpoint store.
+func (action *TaskStep) checkpointKey() string {
+	return fmt.Sprintf("checkpoint/%d/%s", action.buildID, action.planID)
+}
+
+// stop interrupts the task's process and waits for it to exit. If a cleanup
+// budget is configured and the process has not exited by the time it elapses,
+// the container is forcibly stopped.
+func (action *TaskStep) stop(ctx context.Context, logger lager.Logger, container worker.Container, exited <-chan struct{}) {
+	if cleanupBudgetExceeded(ctx) {
+		// the task is itself part of a cleanup that has run out of time
+		err := container.Stop(true)
+		if err != nil {
+			logger.Error("killing-container", err)
+		}
+
+		<-exited
+
+		return
+	}
+
+	budget := CleanupBudget(ctx)
+	if budget <= 0 {
+		err := container.Stop(false)
+		if err != nil {
+			logger.Error("stopping-container", err)
+		}
+
+		<-exited
+
+		return
+	}
+
+	go func() {
+		err := container.Stop(false)
+		if err != nil {
+			logger.Error("stopping-container", err)
+		}
+	}()
+
+	timer := time.NewTimer(budget)
+	defer timer.Stop()
+
+	select {
+	case <-exited:
+	case <-timer.C:
+		logger.Info("cleanup-budget-exceeded", lager.Data{"budget": budget.String()})
+
+		err := container.Stop(true)
+		if err != nil {
+			logger.Error("killing-container", err)
+		}
+
+		action.delegate.TerminatedDuringCleanup(logger)
+
+		<-exited
+	}
+}
+
+// stopSidecars terminates the task's sidecars once the step is done with
+// them. They are destroyed along with the container regardless, so failing to
+// stop them is only logged.
+func (action *TaskStep) stopSidecars(logger lager.Logger, container worker.Container, config atc.TaskConfig) {
+	for _, sidecar := range config.Sidecars {
+		process, err := container.Attach(worker.SidecarProcessID(sidecar.Name), garden.ProcessIO{})
+		if err != nil {
+			logger.Info("sidecar-not-found", lager.Data{"sidecar": sidecar.Name, "error": err.Error()})
+			continue
+		}
+
+		err = process.Signal(garden.SignalTerminate)
+		if err != nil {
+			logger.Error("failed-to-stop-sidecar", err, lager.Data{"sidecar": sidecar.Name})
+		}
+	}
+}
+
+// findOrCreateContainer places the task's container, polling until a worker
+// has a free task slot if every worker is running the maximum number of
+// active tasks. The wait is reported on the first poll and then ever less
+// often, so that a long wait doesn't flood the build's events.
+func (action *TaskStep) findOrCreateContainer(ctx context.Context, logger lager.Logger, containerSpec worker.ContainerSpec) (worker.Container, error) {
+	for polls := 1; ; polls++ {
+		container, err := action.workerPool.FindOrCreateContainer(
+			ctx,
+			logger,
+			action.delegate,
+			db.NewBuildStepContainerOwner(action.buildID, action.planID),
+			action.containerMetadata,
+			containerSpec,
+			action.resourceTypes,
+		)
+		if err != worker.ErrActiveTasksLimitReached {
+			return container, err
+		}
+
+		if polls&(polls-1) == 0 {
+			action.delegate.WaitingForWorker(logger, polls)
+		}
+
+		timer := time.NewTimer(WorkerSlotPollInterval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// releaseTaskSlot frees the task slot reserved for the step's container once
+// the step is done with it. If the step was interrupted, e.g. as the ATC is
+// shutting down, the task may still be running, so it keeps its slot; the slot
+// is freed for good once the build finishes.
+func (action *TaskStep) releaseTaskSlot(ctx context.Context, logger lager.Logger) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	err := action.workerPool.ReleaseTaskSlot(
+		logger,
+		action.teamID,
+		db.NewBuildStepContainerOwner(action.buildID, action.planID),
+	)
+	if err != nil {
+		logger.Error("failed-to-release-task-slot", err)
+	}
+}
+
+// detectNoisyNeighbors reports the run to the delegate and as a metric if it
+// was slowed down by other containers on its worker. Runs of one-off builds
+// are not compared, as they have no history to compare against.
+func (action *TaskStep) detectNoisyNeighbors(logger lager.Logger, container worker.Container, duration time.Duration, neighborsAtStart int) {
+	if action.noisyNeighbors == nil || action.jobID == 0 {
+		return
+	}
+
+	neighbors := action.neighbors(logger, container)
+	if neighborsAtStart > neighbors {
+		neighbors = neighborsAtStart
+	}
+
+	interference, detected := action.noisyNeighbors.Observe(StepRun{
+		Key:        fmt.Sprintf("%d/%s", action.jobID, action.stepName),
+		WorkerName: container.WorkerName(),
+		Duration:   duration,
+		Neighbors:  neighbors,
+	})
+	if !detected {
+		return
+	}
+
+	logger.Info("noisy-neighbors-detected", lager.Data{
+		"worker":             interference.WorkerName,
+		"duration":           interference.Duration.String(),
+		"baseline-duration":  interference.BaselineDuration.String(),
+		"neighbors":          interference.Neighbors,
+		"baseline-neighbors": interference.BaselineNeighbors,
+	})
+
+	action.delegate.NoisyNeighbors(logger, interference)
+
+	metric.NoisyNeighborsDetected{
+		PipelineName:      action.containerMetadata.PipelineName,
+		JobName:           action.containerMetadata.JobName,
+		StepName:          action.stepName,
+		WorkerName:        interference.WorkerName,
+		Duration:          interference.Duration,
+		BaselineDuration:  interference.BaselineDuration,
+		Neighbors:         interference.Neighbors,
+		BaselineNeighbors: interference.BaselineNeighbors,
+	}.Emit(logger)
+}
+
+// neighbors returns the number of other containers running on the container's
+// worker.
+func (action *TaskStep) neighbors(logger lager.Logger, container worker.Container) int {
+	if action.noisyNeighbors == nil {
+		return 0
+	}
+
+	workers, err := action.workerPool.RunningWorkers(logger)
+	if err != nil {
+		logger.Error("failed-to-list-workers", err)
+		return 0
+	}
+
+	for _, w := range workers {
+		if w.Name() == container.WorkerName() && w.ActiveContainers() > 0 {
+			// don't count the task's own container
+			return w.ActiveContainers() - 1
+		}
+	}
+
+	return 0
+}
+
+// reuseKey identifies the containers the task could run in if it opts into
+// reusing them, i.e. those with the same image and platform. It is empty if
+// the task cannot reuse a container, e.g. as it has outputs, which would
+// otherwise be written into the volumes of the previous task's outputs.
+func (action *TaskStep) reuseKey(config atc.TaskConfig) (string, error) {
+	if !action.reuseContainer {
+		return "", nil
+	}
+
+	if len(config.Outputs) > 0 || len(config.Caches) > 0 || config.Checkpoint != nil || len(config.Sidecars) > 0 {
+		return "", nil
+	}
+
+	key, err := json.Marshal(struct {
+		Platform          string             `json:"platform"`
+		Tags              atc.Tags           `json:"tags"`
+		Privileged        bool               `json:"privileged"`
+		RootfsURI         string             `json:"rootfs_uri"`
+		ImageResource     *atc.ImageResource `json:"image_resource"`
+		ImageArtifactName string             `json:"image"`
+	}{
+		Platform:          config.Platform,
+		Tags:              action.tags,
+		Privileged:        bool(action.privileged),
+		RootfsURI:         config.RootfsURI,
+		ImageResource:     config.ImageResource,
+		ImageArtifactName: action.imageArtifactName,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(key), nil
+}
+
+// taskInputVolumes returns the handle of the volume mounted for each of the
+// task's inputs by its destination path, or false if any input is not a whole
+// volume, in which case its container cannot be compared with another's.
+func taskInputVolumes(containerSpec worker.ContainerSpec) (map[string]string, bool) {
+	volumes := map[string]string{}
+	for _, input := range containerSpec.Inputs {
+		volumeSource, ok := input.Source().(worker.VolumeArtifactSource)
+		if !ok {
+			return nil, false
+		}
+
+		volume, subPath, found := volumeSource.SourceVolume()
+		if !found || subPath != "." {
+			return nil, false
+		}
+
+		volumes[filepath.Clean(input.DestinationPath())] = volume.Handle()
+	}
+
+	return volumes, true
+}
+
+// sameInputVolumes is whether a container was created with the same input
+// volumes at the same paths as a task would be, as volumes cannot be mounted
+// into a container once it has been created.
+func sameInputVolumes(mounted map[string]string, inputs map[string]string) bool {
+	if len(mounted) != len(inputs) {
+		return false
+	}
+
+	for path, handle := range inputs {
+		if mounted[path] != handle {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (action *TaskStep) Succeeded() bool {
 	return action.succeeded
 }
@@ -340,6 +934,11 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *worker.Ar
 		}
 
 		source, found := repository.SourceFor(worker.ArtifactName(inputName))
+
+		if input.Optional {
+			containerSpec.Env = append(containerSpec.Env, optionalInputEnv(input.Name, found))
+		}
+
 		if !found {
 			if !input.Optional {
 				missingRequiredInputs = append(missingRequiredInputs, inputName)
@@ -347,10 +946,19 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *worker.Ar
 			continue
 		}
 
+		// inputs without a path of their own are placed wherever the
+		// artifact's producer asked for, e.g. `get: repo, path: src/repo`
+		if input.Path == "" {
+			if artifactPath, found := repository.PathFor(worker.ArtifactName(inputName)); found {
+				input.Path = artifactPath
+			}
+		}
+
 		containerSpec.Inputs = append(containerSpec.Inputs, &taskInputSource{
 			config:        input,
 			source:        source,
 			artifactsRoot: action.artifactsRoot,
+			streamed:      repository.StreamedArtifacts(),
 		})
 	}
 
@@ -367,14 +975,73 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *worker.Ar
 		})
 	}
 
+	if config.Checkpoint != nil {
+		checkpointPath := filepath.Join(action.artifactsRoot, config.Checkpoint.Path)
+
+		containerSpec.Inputs = append(containerSpec.Inputs, &taskCheckpointInputSource{
+			source: &taskCheckpointSource{
+				logger: logger,
+				store:  action.checkpoints,
+				teamID: action.teamID,
+				key:    action.checkpointKey(),
+				stdout: action.delegate.Stdout(),
+			},
+			destinationPath: checkpointPath,
+		})
+
+		containerSpec.Env = append(containerSpec.Env, "CHECKPOINT_DIR="+checkpointPath)
+	}
+
 	for _, output := range config.Outputs {
 		path := artifactsPath(output, action.artifactsRoot)
 		containerSpec.Outputs[output.Name] = path
 	}
 
+	for _, sidecar := range config.Sidecars {
+		sidecarSpec, err := action.sidecarSpec(sidecar)
+		if err != nil {
+			return worker.ContainerSpec{}, err
+		}
+
+		containerSpec.Sidecars = append(containerSpec.Sidecars, sidecarSpec)
+	}
+
+	containerSpec.Env = taskEnv(config.Platform, containerSpec.Env)
+
 	return containerSpec, nil
 }
 
+func (action *TaskStep) sidecarSpec(sidecar atc.TaskSidecarConfig) (worker.SidecarSpec, error) {
+	imageSpec := worker.ImageSpec{
+		Privileged: bool(action.privileged),
+	}
+
+	if sidecar.ImageResource != nil {
+		imageSpec.ImageResource = &worker.ImageResource{
+			Type:    sidecar.ImageResource.Type,
+			Source:  creds.NewSource(action.variables, sidecar.ImageResource.Source),
+			Params:  sidecar.ImageResource.Params,
+			Version: sidecar.ImageResource.Version,
+		}
+	} else {
+		imageSpec.ImageURL = sidecar.RootfsURI
+	}
+
+	params, err := creds.NewTaskParams(action.variables, sidecar.Params).Evaluate()
+	if err != nil {
+		return worker.SidecarSpec{}, err
+	}
+
+	return worker.SidecarSpec{
+		Name:      sidecar.Name,
+		ImageSpec: imageSpec,
+		Env:       action.envForParams(params),
+		Path:      sidecar.Run.Path,
+		Args:      sidecar.Run.Args,
+		User:      sidecar.Run.User,
+	}, nil
+}
+
 func (action *TaskStep) registerOutputs(logger lager.Logger, repository *worker.ArtifactRepository, config atc.TaskConfig, container worker.Container) error {
 	volumeMounts := container.VolumeMounts()
 
@@ -390,7 +1057,11 @@ func (action *TaskStep) registerOutputs(logger lager.Logger, repository *worker.
 
 		for _, mount := range volumeMounts {
 			if mount.MountPath == outputPath {
-				source := newTaskArtifactSource(logger, mount.Volume)
+				var source worker.ArtifactSource = newTaskArtifactSource(logger, mount.Volume)
+				if len(output.Paths) > 0 {
+					source = newFilteredArtifactSource(source, output.Paths)
+				}
+
 				repository.RegisterSource(worker.ArtifactName(outputName), source)
 			}
 		}
@@ -419,6 +1090,119 @@ func (action *TaskStep) registerOutputs(logger lager.Logger, repository *worker.
 	return nil
 }
 
+// checkOutputSizes verifies that none of the registered outputs exceed their
+// configured max size, so that runaway outputs fail the task instead of being
+// streamed to other workers. Outputs are measured in the task's container
+// where possible, and only streamed through the ATC to be measured if they
+// are filtered by paths or the container can't measure them.
+func (action *TaskStep) checkOutputSizes(logger lager.Logger, repository *worker.ArtifactRepository, config atc.TaskConfig, container worker.Container) error {
+	for _, output := range config.Outputs {
+		if output.MaxSize == 0 {
+			continue
+		}
+
+		outputName := output.Name
+		if destinationName, ok := action.outputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		source, found := repository.SourceFor(worker.ArtifactName(outputName))
+		if !found {
+			continue
+		}
+
+		var size uint64
+		var measured bool
+		if len(output.Paths) == 0 {
+			size, measured = measureOutputSize(logger, container, artifactsPath(output, action.artifactsRoot))
+		}
+
+		var err error
+		if !measured {
+			err = checkOutputSize(output.Name, source, output.MaxSize)
+		} else if size > output.MaxSize {
+			err = OutputSizeExceededError{
+				Output:  output.Name,
+				MaxSize: output.MaxSize,
+				Size:    size,
+			}
+		}
+
+		if err != nil {
+			if exceeded, ok := err.(OutputSizeExceededError); ok {
+				logger.Info("output-size-exceeded", lager.Data{"output": output.Name, "max-size": output.MaxSize, "size": exceeded.Size})
+
+				metric.TaskOutputSizeExceeded{
+					PipelineName: action.containerMetadata.PipelineName,
+					JobName:      action.containerMetadata.JobName,
+					BuildName:    action.containerMetadata.BuildName,
+					BuildID:      action.buildID,
+					OutputName:   output.Name,
+					MaxSize:      output.MaxSize,
+					Size:         exceeded.Size,
+				}.Emit(logger)
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scanOutputs streams each output through the artifact scanner, if one is
+// configured. Depending on the scan policy, outputs that are not clean or that
+// could not be scanned either fail the step or are reported on its stderr.
+func (action *TaskStep) scanOutputs(ctx context.Context, logger lager.Logger, repository *worker.ArtifactRepository, config atc.TaskConfig) error {
+	if action.scanner == nil {
+		return nil
+	}
+
+	for _, output := range config.Outputs {
+		outputName := output.Name
+		if destinationName, ok := action.outputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		source, found := repository.SourceFor(worker.ArtifactName(outputName))
+		if !found {
+			continue
+		}
+
+		result, err := scanOutput(ctx, action.scanner, output.Name, source)
+		if err != nil {
+			logger.Error("failed-to-scan-output", err, lager.Data{"output": output.Name})
+
+			if action.scanPolicy == artifactscan.PolicyFail {
+				return err
+			}
+
+			fmt.Fprintf(action.delegate.Stderr(), "failed to scan output '%s': %s\n", output.Name, err)
+			continue
+		}
+
+		if result.Clean() {
+			continue
+		}
+
+		logger.Info("output-scan-findings", lager.Data{"output": output.Name, "findings": result.Findings})
+
+		if action.scanPolicy == artifactscan.PolicyFail {
+			return OutputScanFailedError{
+				Output:   output.Name,
+				Findings: result.Findings,
+			}
+		}
+
+		fmt.Fprintf(action.delegate.Stderr(), "output '%s' was flagged by the artifact scanner:\n", output.Name)
+		for _, finding := range result.Findings {
+			fmt.Fprintf(action.delegate.Stderr(), "  - %s\n", finding)
+		}
+	}
+
+	return nil
+}
+
 func (TaskStep) envForParams(params map[string]string) []string {
 	env := make([]string, 0, len(params))
 
@@ -429,6 +1213,54 @@ func (TaskStep) envForParams(params map[string]string) []string {
 	return env
 }
 
+// optionalInputEnv returns the environment variable telling the task whether
+// an optional input was provided, e.g. INPUT_SOME_INPUT_PRESENT=true.
+func optionalInputEnv(name string, present bool) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+
+		return '_'
+	}, name)
+
+	return "INPUT_" + sanitized + "_PRESENT=" + strconv.FormatBool(present)
+}
+
+// taskWorkingDir returns the directory in which to run the task, relative to
+// the artifacts root. Windows tasks may configure it with backslashes, which
+// are normalized so that the directory is always within the root.
+func taskWorkingDir(platform string, artifactsRoot string, dir string) string {
+	if platform == "windows" {
+		dir = strings.Replace(dir, `\`, "/", -1)
+	}
+
+	return path.Join(artifactsRoot, dir)
+}
+
+// taskEnv returns the environment to run the task with on the platform.
+// Variable names are case-insensitive on Windows, so only the last of any
+// variables differing only by case is kept, as only one of them can be set.
+func taskEnv(platform string, env []string) []string {
+	if platform != "windows" {
+		return env
+	}
+
+	last := map[string]int{}
+	for i, v := range env {
+		last[strings.ToUpper(strings.SplitN(v, "=", 2)[0])] = i
+	}
+
+	deduped := []string{}
+	for i, v := range env {
+		if last[strings.ToUpper(strings.SplitN(v, "=", 2)[0])] == i {
+			deduped = append(deduped, v)
+		}
+	}
+
+	return deduped
+}
+
 type taskArtifactSource struct {
 	logger lager.Logger
 	volume worker.Volume
@@ -455,6 +1287,10 @@ func (src *taskArtifactSource) StreamTo(destination worker.ArtifactDestination)
 	return destination.StreamIn(".", out)
 }
 
+func (src *taskArtifactSource) SourceVolume() (worker.Volume, string, bool) {
+	return src.volume, ".", true
+}
+
 func (src *taskArtifactSource) StreamFile(filename string) (io.ReadCloser, error) {
 	out, err := src.volume.StreamOut(filename)
 	if err != nil {
@@ -487,10 +1323,13 @@ type taskInputSource struct {
 	config        atc.TaskInputConfig
 	source        worker.ArtifactSource
 	artifactsRoot string
+	streamed      *worker.StreamedArtifacts
 }
 
 func (s *taskInputSource) Source() worker.ArtifactSource { return s.source }
 
+func (s *taskInputSource) StreamedArtifacts() *worker.StreamedArtifacts { return s.streamed }
+
 func (s *taskInputSource) DestinationPath() string {
 	subdir := s.config.Path
 	if s.config.Path == "" {
@@ -557,3 +1396,52 @@ func (src *taskCacheSource) StreamFile(filename string) (io.ReadCloser, error) {
 func (src *taskCacheSource) VolumeOn(w worker.Worker) (worker.Volume, bool, error) {
 	return w.FindVolumeForTaskCache(src.logger, src.teamID, src.jobID, src.stepName, src.path)
 }
+
+type taskCheckpointInputSource struct {
+	source          worker.ArtifactSource
+	destinationPath string
+}
+
+func (s *taskCheckpointInputSource) Source() worker.ArtifactSource { return s.source }
+
+func (s *taskCheckpointInputSource) DestinationPath() string { return s.destinationPath }
+
+// taskCheckpointSource restores the task's last checkpoint, if there is one,
+// into a fresh volume.
+type taskCheckpointSource struct {
+	logger lager.Logger
+	store  artifactcache.Store
+	teamID int
+	key    string
+	stdout io.Writer
+}
+
+func (src *taskCheckpointSource) StreamTo(destination worker.ArtifactDestination) error {
+	if src.store == nil {
+		return nil
+	}
+
+	stream, found, err := src.store.Restore(src.logger, src.teamID, src.key)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return nil
+	}
+
+	defer stream.Close()
+
+	fmt.Fprintln(src.stdout, "restoring checkpoint")
+
+	return destination.StreamIn(".", stream)
+}
+
+func (src *taskCheckpointSource) StreamFile(filename string) (io.ReadCloser, error) {
+	return nil, errors.New("taskCheckpointSource.StreamFile not implemented")
+}
+
+// VolumeOn never finds a volume, as checkpoints are not kept on workers.
+func (src *taskCheckpointSource) VolumeOn(worker.Worker) (worker.Volume, bool, error) {
+	return nil, false, nil
+}
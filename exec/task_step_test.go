@@ -5,15 +5,21 @@ import (
 	"compress/gzip"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
 	"code.cloudfoundry.org/lager"
 	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/artifactcache"
+	"github.com/concourse/atc/artifactcache/artifactcachefakes"
+	"github.com/concourse/atc/artifactscan"
+	"github.com/concourse/atc/artifactscan/artifactscanfakes"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
@@ -38,6 +44,7 @@ var _ = Describe("TaskStep", func() {
 		stderrBuf *gbytes.Buffer
 
 		imageArtifactName string
+		reuseContainer    bool
 		containerMetadata db.ContainerMetadata
 
 		fakeDelegate *execfakes.FakeTaskDelegate
@@ -54,6 +61,16 @@ var _ = Describe("TaskStep", func() {
 		outputMapping map[string]string
 		variables     creds.Variables
 
+		fakeNoisyNeighbors *execfakes.FakeNoisyNeighborDetector
+		noisyNeighbors     exec.NoisyNeighborDetector
+
+		fakeScanner *artifactscanfakes.FakeScanner
+		scanner     artifactscan.Scanner
+		scanPolicy  artifactscan.Policy
+
+		fakeCheckpoints *artifactcachefakes.FakeStore
+		checkpoints     artifactcache.Store
+
 		repo  *worker.ArtifactRepository
 		state *execfakes.FakeRunState
 
@@ -102,6 +119,7 @@ var _ = Describe("TaskStep", func() {
 		inputMapping = nil
 		outputMapping = nil
 		imageArtifactName = ""
+		reuseContainer = false
 
 		variables = template.StaticVariables{
 			"source-param": "super-secret-source",
@@ -113,6 +131,16 @@ var _ = Describe("TaskStep", func() {
 			StepName: "some-step",
 		}
 
+		fakeNoisyNeighbors = nil
+		noisyNeighbors = nil
+
+		fakeScanner = nil
+		scanner = nil
+		scanPolicy = artifactscan.PolicyFail
+
+		fakeCheckpoints = nil
+		checkpoints = nil
+
 		stepErr = nil
 	})
 
@@ -125,6 +153,7 @@ var _ = Describe("TaskStep", func() {
 			outputMapping,
 			"some-artifact-root",
 			imageArtifactName,
+			reuseContainer,
 			fakeDelegate,
 			fakeWorkerClient,
 			teamID,
@@ -135,6 +164,10 @@ var _ = Describe("TaskStep", func() {
 			containerMetadata,
 			resourceTypes,
 			variables,
+			noisyNeighbors,
+			scanner,
+			scanPolicy,
+			checkpoints,
 		)
 
 		stepErr = taskStep.Run(ctx, state)
@@ -466,6 +499,38 @@ var _ = Describe("TaskStep", func() {
 					Expect(spec.Dir).To(Equal("some-artifact-root"))
 					Expect(spec.User).To(BeEmpty())
 					Expect(spec.TTY).To(Equal(&garden.TTYSpec{WindowSize: &garden.WindowSize{Columns: 500, Rows: 500}}))
+					Expect(spec.Env).To(BeEmpty())
+				})
+
+				Context("when the step is being retried", func() {
+					BeforeEach(func() {
+						ctx = exec.WithAttempt(ctx, exec.StepAttempt{Number: 2, Max: 3})
+					})
+
+					It("exposes the attempt to the process", func() {
+						Expect(fakeContainer.RunCallCount()).To(Equal(1))
+
+						spec, _ := fakeContainer.RunArgsForCall(0)
+						Expect(spec.Env).To(Equal([]string{"BUILD_ATTEMPT=2", "BUILD_MAX_ATTEMPTS=3"}))
+					})
+				})
+
+				Context("when the step is being traced", func() {
+					BeforeEach(func() {
+						fakeSpan := new(execfakes.FakeSpan)
+						fakeSpan.CarrierReturns(map[string]string{
+							"traceparent": "00-some-trace-id-some-span-id-01",
+						})
+
+						ctx = exec.WithSpan(ctx, fakeSpan)
+					})
+
+					It("propagates the trace context to the process", func() {
+						Expect(fakeContainer.RunCallCount()).To(Equal(1))
+
+						spec, _ := fakeContainer.RunArgsForCall(0)
+						Expect(spec.Env).To(Equal([]string{"TRACEPARENT=00-some-trace-id-some-span-id-01"}))
+					})
 				})
 
 				It("directs the process's stdout/stderr to the io config", func() {
@@ -544,6 +609,37 @@ var _ = Describe("TaskStep", func() {
 								}
 							}
 						})
+
+						It("shares the inputs with other steps of the build on the same worker", func() {
+							_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+							for _, input := range spec.Inputs {
+								streamedInput, ok := input.(worker.StreamedInputSource)
+								Expect(ok).To(BeTrue())
+								Expect(streamedInput.StreamedArtifacts()).To(BeIdenticalTo(repo.StreamedArtifacts()))
+							}
+						})
+					})
+
+					Context("when the inputs were registered at paths", func() {
+						BeforeEach(func() {
+							repo.RegisterSourceAt("some-input", "src/some-input", inputSource)
+							repo.RegisterSourceAt("some-other-input", "src/some-other-input", otherInputSource)
+						})
+
+						It("places inputs without a configured path at the registered path", func() {
+							_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+							Expect(spec.Inputs).To(HaveLen(2))
+							for _, input := range spec.Inputs {
+								switch input.DestinationPath() {
+								case "some-artifact-root/some-input-configured-path":
+									Expect(input.Source()).To(Equal(inputSource))
+								case "some-artifact-root/src/some-other-input":
+									Expect(input.Source()).To(Equal(otherInputSource))
+								default:
+									panic("unknown input: " + input.DestinationPath())
+								}
+							}
+						})
 					})
 
 					Context("when any of the inputs are missing", func() {
@@ -633,6 +729,38 @@ var _ = Describe("TaskStep", func() {
 							Expect(spec.Inputs[1].Source()).To(Equal(requiredInputSource))
 							Expect(spec.Inputs[1].DestinationPath()).To(Equal("some-artifact-root/required-input"))
 						})
+
+						It("tells the task which optional inputs are present", func() {
+							_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+							Expect(spec.Env).To(ContainElement("INPUT_OPTIONAL_INPUT_PRESENT=false"))
+							Expect(spec.Env).To(ContainElement("INPUT_OPTIONAL_INPUT_2_PRESENT=true"))
+						})
+
+						It("does not expose the presence of required inputs", func() {
+							_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+							Expect(spec.Env).ToNot(ContainElement(HavePrefix("INPUT_REQUIRED_INPUT_PRESENT=")))
+						})
+					})
+
+					Context("when a windows task has a param differing from the presence of an input only by case", func() {
+						BeforeEach(func() {
+							configSource.FetchConfigReturns(atc.TaskConfig{
+								Platform: "windows",
+								Params:   map[string]string{"input_optional_input_present": "maybe"},
+								Run: atc.TaskRunConfig{
+									Path: "ls",
+								},
+								Inputs: []atc.TaskInputConfig{
+									{Name: "optional-input", Optional: true},
+								},
+							}, nil)
+						})
+
+						It("only sets the presence of the input, as the names are the same on windows", func() {
+							_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+							Expect(spec.Env).To(ContainElement("INPUT_OPTIONAL_INPUT_PRESENT=false"))
+							Expect(spec.Env).ToNot(ContainElement("input_optional_input_present=maybe"))
+						})
 					})
 
 					Context("when a required input is missing", func() {
@@ -728,6 +856,318 @@ var _ = Describe("TaskStep", func() {
 					})
 				})
 
+				Context("when the configuration specifies sidecars", func() {
+					var fakeSidecarProcess *gardenfakes.FakeProcess
+
+					BeforeEach(func() {
+						configSource.FetchConfigReturns(atc.TaskConfig{
+							Platform:  "some-platform",
+							RootfsURI: "some-image",
+							Run: atc.TaskRunConfig{
+								Path: "ls",
+							},
+							Sidecars: []atc.TaskSidecarConfig{
+								{
+									Name:      "db",
+									RootfsURI: "docker:///postgres",
+									Params:    map[string]string{"SECRET": "((task-param))"},
+									Run: atc.TaskRunConfig{
+										Path: "postgres",
+										Args: []string{"-p", "5432"},
+										User: "postgres",
+									},
+								},
+							},
+						}, nil)
+
+						fakeSidecarProcess = new(gardenfakes.FakeProcess)
+						fakeContainer.AttachStub = func(processID string, _ garden.ProcessIO) (garden.Process, error) {
+							if processID == "sidecar-db" {
+								return fakeSidecarProcess, nil
+							}
+
+							return nil, errors.New("no garden error type for this :(")
+						}
+
+						fakeProcess.WaitReturns(0, nil)
+					})
+
+					It("configures them in the container spec", func() {
+						_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+						Expect(spec.Sidecars).To(Equal([]worker.SidecarSpec{
+							{
+								Name:      "db",
+								ImageSpec: worker.ImageSpec{ImageURL: "docker:///postgres"},
+								Env:       []string{"SECRET=super-secret-param"},
+								Path:      "postgres",
+								Args:      []string{"-p", "5432"},
+								User:      "postgres",
+							},
+						}))
+					})
+
+					It("terminates the sidecars once the task finishes", func() {
+						Expect(fakeSidecarProcess.SignalCallCount()).To(Equal(1))
+						Expect(fakeSidecarProcess.SignalArgsForCall(0)).To(Equal(garden.SignalTerminate))
+					})
+				})
+
+				Context("when the configuration specifies a checkpoint", func() {
+					var (
+						fakeCheckpointVolume *workerfakes.FakeVolume
+						marker               func() (string, bool)
+					)
+
+					markerStream := func(content string) io.ReadCloser {
+						tgzBuffer := gbytes.NewBuffer()
+
+						gzWriter := gzip.NewWriter(tgzBuffer)
+						tarWriter := tar.NewWriter(gzWriter)
+
+						err := tarWriter.WriteHeader(&tar.Header{
+							Name: exec.TaskCheckpointMarker,
+							Mode: 0644,
+							Size: int64(len(content)),
+						})
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = tarWriter.Write([]byte(content))
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(tarWriter.Close()).To(Succeed())
+						Expect(gzWriter.Close()).To(Succeed())
+
+						return tgzBuffer
+					}
+
+					BeforeEach(func() {
+						configSource.FetchConfigReturns(atc.TaskConfig{
+							Platform:  "some-platform",
+							RootfsURI: "some-image",
+							Run: atc.TaskRunConfig{
+								Path: "ls",
+							},
+							Checkpoint: &atc.TaskCheckpointConfig{
+								Path:     "progress",
+								Interval: "1ms",
+							},
+						}, nil)
+
+						fakeCheckpoints = new(artifactcachefakes.FakeStore)
+						checkpoints = fakeCheckpoints
+
+						progress := make(chan int, 1)
+						progress <- 0
+						marker = func() (string, bool) {
+							step := <-progress
+							progress <- step + 1
+							return fmt.Sprintf("step-%d", step), true
+						}
+
+						fakeCheckpointVolume = new(workerfakes.FakeVolume)
+						fakeCheckpointVolume.StreamOutStub = func(path string) (io.ReadCloser, error) {
+							if path == exec.TaskCheckpointMarker {
+								content, found := marker()
+								if !found {
+									return nil, errors.New("file not found")
+								}
+
+								return markerStream(content), nil
+							}
+
+							return ioutil.NopCloser(strings.NewReader("some-checkpoint")), nil
+						}
+
+						fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+							{
+								Volume:    fakeCheckpointVolume,
+								MountPath: "some-artifact-root/progress",
+							},
+						})
+
+						fakeProcess.WaitStub = func() (int, error) {
+							defer GinkgoRecover()
+							Eventually(fakeCheckpoints.SaveCallCount).ShouldNot(BeZero())
+							return 0, nil
+						}
+					})
+
+					It("tells the task where to write its checkpoint", func() {
+						_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+						Expect(spec.Env).To(ContainElement("CHECKPOINT_DIR=some-artifact-root/progress"))
+					})
+
+					Describe("the checkpoint input", func() {
+						var (
+							checkpointInput worker.InputSource
+							fakeDestination *workerfakes.FakeArtifactDestination
+							streamErr       error
+						)
+
+						JustBeforeEach(func() {
+							_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+							Expect(spec.Inputs).To(HaveLen(1))
+							checkpointInput = spec.Inputs[0]
+
+							fakeDestination = new(workerfakes.FakeArtifactDestination)
+							streamErr = checkpointInput.Source().StreamTo(fakeDestination)
+						})
+
+						It("is mounted at the checkpoint path", func() {
+							Expect(checkpointInput.DestinationPath()).To(Equal("some-artifact-root/progress"))
+						})
+
+						It("is never found on a worker", func() {
+							_, found, err := checkpointInput.Source().VolumeOn(new(workerfakes.FakeWorker))
+							Expect(err).ToNot(HaveOccurred())
+							Expect(found).To(BeFalse())
+						})
+
+						Context("when a checkpoint has been saved", func() {
+							BeforeEach(func() {
+								fakeCheckpoints.RestoreReturns(ioutil.NopCloser(strings.NewReader("some-checkpoint")), true, nil)
+							})
+
+							It("restores it", func() {
+								Expect(streamErr).ToNot(HaveOccurred())
+
+								_, restoredTeamID, key := fakeCheckpoints.RestoreArgsForCall(0)
+								Expect(restoredTeamID).To(Equal(teamID))
+								Expect(key).To(Equal("checkpoint/1234/42"))
+
+								Expect(fakeDestination.StreamInCallCount()).To(Equal(1))
+								dest, stream := fakeDestination.StreamInArgsForCall(0)
+								Expect(dest).To(Equal("."))
+								Expect(ioutil.ReadAll(stream)).To(Equal([]byte("some-checkpoint")))
+
+								Expect(stdoutBuf).To(gbytes.Say("restoring checkpoint"))
+							})
+						})
+
+						Context("when no checkpoint has been saved", func() {
+							BeforeEach(func() {
+								fakeCheckpoints.RestoreReturns(nil, false, nil)
+							})
+
+							It("leaves the volume empty", func() {
+								Expect(streamErr).ToNot(HaveOccurred())
+								Expect(fakeDestination.StreamInCallCount()).To(BeZero())
+							})
+						})
+					})
+
+					It("saves the checkpoint while the process runs", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+
+						var paths []string
+						for i := 0; i < fakeCheckpointVolume.StreamOutCallCount(); i++ {
+							paths = append(paths, fakeCheckpointVolume.StreamOutArgsForCall(i))
+						}
+						Expect(paths).To(ContainElement("."))
+
+						_, savedTeamID, key, stream := fakeCheckpoints.SaveArgsForCall(0)
+						Expect(savedTeamID).To(Equal(teamID))
+						Expect(key).To(Equal("checkpoint/1234/42"))
+						Expect(ioutil.ReadAll(stream)).To(Equal([]byte("some-checkpoint")))
+					})
+
+					It("deletes the checkpoint once the task finishes", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+
+						Expect(fakeCheckpoints.DeleteCallCount()).To(Equal(1))
+						_, deletedTeamID, key := fakeCheckpoints.DeleteArgsForCall(0)
+						Expect(deletedTeamID).To(Equal(teamID))
+						Expect(key).To(Equal("checkpoint/1234/42"))
+					})
+
+					Context("when the task does not change its checkpoint marker", func() {
+						BeforeEach(func() {
+							marker = func() (string, bool) {
+								return "step-0", true
+							}
+
+							fakeProcess.WaitStub = func() (int, error) {
+								defer GinkgoRecover()
+								Eventually(fakeCheckpointVolume.StreamOutCallCount).Should(BeNumerically(">", 2))
+								return 0, nil
+							}
+						})
+
+						It("does not save the checkpoint", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(fakeCheckpoints.SaveCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the task has not written a checkpoint marker", func() {
+						BeforeEach(func() {
+							marker = func() (string, bool) {
+								return "", false
+							}
+
+							fakeProcess.WaitStub = func() (int, error) {
+								defer GinkgoRecover()
+								Eventually(fakeCheckpointVolume.StreamOutCallCount).Should(BeNumerically(">", 2))
+								return 0, nil
+							}
+						})
+
+						It("does not save the checkpoint", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(fakeCheckpoints.SaveCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the process is lost", func() {
+						disaster := errors.New("lost connection")
+
+						BeforeEach(func() {
+							fakeProcess.WaitStub = nil
+							fakeProcess.WaitReturnsOnCall(0, 0, disaster)
+							fakeProcess.WaitReturnsOnCall(1, 0, nil)
+						})
+
+						It("resumes the task from the last checkpoint", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(taskStep.Succeeded()).To(BeTrue())
+
+							Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(2))
+							Expect(stderrBuf).To(gbytes.Say("resuming from last checkpoint"))
+						})
+
+						Context("when it keeps being lost", func() {
+							BeforeEach(func() {
+								fakeProcess.WaitReturnsOnCall(1, 0, disaster)
+								fakeProcess.WaitReturns(0, disaster)
+							})
+
+							It("gives up after a few attempts", func() {
+								Expect(stepErr).To(Equal(disaster))
+								Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(4))
+							})
+
+							It("deletes the checkpoint", func() {
+								Expect(fakeCheckpoints.DeleteCallCount()).To(Equal(1))
+								_, _, key := fakeCheckpoints.DeleteArgsForCall(0)
+								Expect(key).To(Equal("checkpoint/1234/42"))
+							})
+						})
+					})
+
+					Context("when no checkpoint store is configured", func() {
+						BeforeEach(func() {
+							checkpoints = nil
+							fakeProcess.WaitStub = nil
+							fakeProcess.WaitReturns(0, nil)
+						})
+
+						It("warns that the task will start over if its container is lost", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(stderrBuf).To(gbytes.Say("the task will start over if its container is lost"))
+						})
+					})
+				})
+
 				Context("when the configuration specifies paths for outputs", func() {
 					BeforeEach(func() {
 						configSource.FetchConfigReturns(atc.TaskConfig{
@@ -1015,6 +1455,47 @@ var _ = Describe("TaskStep", func() {
 							Expect(taskStep.Succeeded()).To(BeFalse())
 						})
 
+						Context("when a cleanup budget is configured", func() {
+							BeforeEach(func() {
+								ctx = exec.WithCleanupBudget(ctx, 10*time.Millisecond)
+							})
+
+							Context("when the process exits within the budget", func() {
+								It("stops the container gracefully", func() {
+									Expect(fakeContainer.StopCallCount()).To(Equal(1))
+									Expect(fakeContainer.StopArgsForCall(0)).To(BeFalse())
+									Expect(stepErr).To(Equal(context.Canceled))
+								})
+
+								It("does not report that the step was terminated", func() {
+									Expect(fakeDelegate.TerminatedDuringCleanupCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when the process outlives the budget", func() {
+								BeforeEach(func() {
+									fakeContainer.StopStub = func(kill bool) error {
+										if kill {
+											close(stopped)
+										}
+
+										return nil
+									}
+								})
+
+								It("forcibly stops the container", func() {
+									Expect(fakeContainer.StopCallCount()).To(Equal(2))
+									Expect(fakeContainer.StopArgsForCall(0)).To(BeFalse())
+									Expect(fakeContainer.StopArgsForCall(1)).To(BeTrue())
+									Expect(stepErr).To(Equal(context.Canceled))
+								})
+
+								It("reports that the step was terminated during cleanup", func() {
+									Expect(fakeDelegate.TerminatedDuringCleanupCallCount()).To(Equal(1))
+								})
+							})
+						})
+
 						Context("when container.stop returns an error", func() {
 							var disaster error
 
@@ -1144,22 +1625,360 @@ var _ = Describe("TaskStep", func() {
 					})
 				})
 
-				Context("when an image artifact name is specified", func() {
+				Context("when an output is filtered by paths", func() {
+					var fakeVolume *workerfakes.FakeVolume
+
 					BeforeEach(func() {
-						imageArtifactName = "some-image-artifact"
+						configSource.FetchConfigReturns(atc.TaskConfig{
+							Run: atc.TaskRunConfig{
+								Path: "ls",
+							},
+							Outputs: []atc.TaskOutputConfig{
+								{Name: "some-output", Paths: []string{"dist/**"}},
+							},
+						}, nil)
 
 						fakeProcess.WaitReturns(0, nil)
-					})
 
-					Context("when the image artifact is registered in the source repo", func() {
-						var imageArtifactSource *workerfakes.FakeArtifactSource
+						fakeVolume = new(workerfakes.FakeVolume)
+						fakeVolume.HandleReturns("some-handle")
 
-						BeforeEach(func() {
-							imageArtifactSource = new(workerfakes.FakeArtifactSource)
-							repo.RegisterSource("some-image-artifact", imageArtifactSource)
-						})
+						tgzBuffer := gbytes.NewBuffer()
 
-						It("creates the container with the image artifact source", func() {
+						gzWriter := gzip.NewWriter(tgzBuffer)
+						tarWriter := tar.NewWriter(gzWriter)
+
+						for _, name := range []string{"./dist/", "./dist/app", "./scratch/", "./scratch/big-file"} {
+							header := &tar.Header{Name: name, Mode: 0644}
+							if strings.HasSuffix(name, "/") {
+								header.Typeflag = tar.TypeDir
+							}
+
+							Expect(tarWriter.WriteHeader(header)).To(Succeed())
+						}
+
+						Expect(tarWriter.Close()).To(Succeed())
+						Expect(gzWriter.Close()).To(Succeed())
+
+						fakeVolume.StreamOutReturns(tgzBuffer, nil)
+
+						fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+							worker.VolumeMount{
+								Volume:    fakeVolume,
+								MountPath: "some-artifact-root/some-output/",
+							},
+						})
+					})
+
+					JustBeforeEach(func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+					})
+
+					It("only streams the matching files to the destination", func() {
+						artifactSource, found := repo.SourceFor("some-output")
+						Expect(found).To(BeTrue())
+
+						var streamedNames []string
+
+						fakeDestination := new(workerfakes.FakeArtifactDestination)
+						fakeDestination.StreamInStub = func(dest string, src io.Reader) error {
+							gzReader, err := gzip.NewReader(src)
+							if err != nil {
+								return err
+							}
+
+							tarReader := tar.NewReader(gzReader)
+
+							for {
+								header, err := tarReader.Next()
+								if err == io.EOF {
+									return nil
+								}
+
+								if err != nil {
+									return err
+								}
+
+								streamedNames = append(streamedNames, header.Name)
+							}
+						}
+
+						Expect(artifactSource.StreamTo(fakeDestination)).To(Succeed())
+						Expect(streamedNames).To(Equal([]string{"./dist/", "./dist/app"}))
+					})
+
+					It("does not expose files outside of the paths", func() {
+						artifactSource, found := repo.SourceFor("some-output")
+						Expect(found).To(BeTrue())
+
+						_, err := artifactSource.StreamFile("scratch/big-file")
+						Expect(err).To(MatchError(exec.FileNotFoundError{Path: "scratch/big-file"}))
+					})
+
+					It("does not report a volume on any worker", func() {
+						artifactSource, found := repo.SourceFor("some-output")
+						Expect(found).To(BeTrue())
+
+						_, found, err := artifactSource.VolumeOn(new(workerfakes.FakeWorker))
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeFalse())
+					})
+				})
+
+				Context("when an output has a max size", func() {
+					var maxSize uint64
+
+					BeforeEach(func() {
+						maxSize = 1024
+
+						fakeProcess.WaitReturns(0, nil)
+
+						fakeVolume := new(workerfakes.FakeVolume)
+						fakeVolume.HandleReturns("some-handle")
+						fakeVolume.StreamOutStub = func(string) (io.ReadCloser, error) {
+							tgzBuffer := gbytes.NewBuffer()
+
+							gzWriter := gzip.NewWriter(tgzBuffer)
+							tarWriter := tar.NewWriter(gzWriter)
+
+							for _, name := range []string{"./some-file", "./some-other-file"} {
+								Expect(tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: 600})).To(Succeed())
+
+								_, err := tarWriter.Write(make([]byte, 600))
+								Expect(err).ToNot(HaveOccurred())
+							}
+
+							Expect(tarWriter.Close()).To(Succeed())
+							Expect(gzWriter.Close()).To(Succeed())
+
+							return tgzBuffer, nil
+						}
+
+						fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+							worker.VolumeMount{
+								Volume:    fakeVolume,
+								MountPath: "some-artifact-root/some-output/",
+							},
+						})
+					})
+
+					BeforeEach(func() {
+						configSource.FetchConfigStub = func(*worker.ArtifactRepository) (atc.TaskConfig, error) {
+							return atc.TaskConfig{
+								Run: atc.TaskRunConfig{
+									Path: "ls",
+								},
+								Outputs: []atc.TaskOutputConfig{
+									{Name: "some-output", MaxSize: maxSize},
+								},
+							}, nil
+						}
+					})
+
+					Context("when the output can't be measured in the container", func() {
+						Context("when the output exceeds the max size", func() {
+							It("returns an error naming the output and its limit, with the size streamed so far", func() {
+								Expect(stepErr).To(Equal(exec.OutputSizeExceededError{
+									Output:  "some-output",
+									MaxSize: 1024,
+									Size:    1200,
+								}))
+								Expect(stepErr).To(MatchError("output 'some-output' exceeds 1 KB"))
+							})
+
+							It("does not finish the task", func() {
+								Expect(fakeDelegate.FinishedCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when the output is within the max size", func() {
+							BeforeEach(func() {
+								maxSize = 2048
+							})
+
+							It("succeeds", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+								Expect(taskStep.Succeeded()).To(BeTrue())
+							})
+						})
+					})
+
+					Context("when the output is measured in the container", func() {
+						var duProcess *gardenfakes.FakeProcess
+
+						BeforeEach(func() {
+							duProcess = new(gardenfakes.FakeProcess)
+							duProcess.WaitReturns(0, nil)
+
+							fakeContainer.RunStub = func(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+								if spec.Path != "du" {
+									return fakeProcess, nil
+								}
+
+								_, err := fmt.Fprintf(io.Stdout, "3\t%s\n", spec.Args[1])
+								Expect(err).ToNot(HaveOccurred())
+
+								return duProcess, nil
+							}
+						})
+
+						It("measures the output's path rather than streaming it", func() {
+							Expect(fakeContainer.RunCallCount()).To(Equal(2))
+							spec, _ := fakeContainer.RunArgsForCall(1)
+							Expect(spec.Path).To(Equal("du"))
+							Expect(spec.Args).To(Equal([]string{"-sk", "some-artifact-root/some-output/"}))
+						})
+
+						Context("when the output exceeds the max size", func() {
+							It("returns an error with the measured size", func() {
+								Expect(stepErr).To(Equal(exec.OutputSizeExceededError{
+									Output:  "some-output",
+									MaxSize: 1024,
+									Size:    3072,
+								}))
+							})
+						})
+
+						Context("when the output is within the max size", func() {
+							BeforeEach(func() {
+								maxSize = 4096
+							})
+
+							It("succeeds", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+								Expect(taskStep.Succeeded()).To(BeTrue())
+							})
+						})
+					})
+				})
+
+				Context("when an artifact scanner is configured", func() {
+					BeforeEach(func() {
+						fakeProcess.WaitReturns(0, nil)
+
+						fakeVolume := new(workerfakes.FakeVolume)
+						fakeVolume.HandleReturns("some-handle")
+						fakeVolume.StreamOutReturns(gbytes.BufferWithBytes([]byte("some-tgz")), nil)
+
+						fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+							worker.VolumeMount{
+								Volume:    fakeVolume,
+								MountPath: "some-artifact-root/some-output/",
+							},
+						})
+
+						configSource.FetchConfigReturns(atc.TaskConfig{
+							Run: atc.TaskRunConfig{
+								Path: "ls",
+							},
+							Outputs: []atc.TaskOutputConfig{
+								{Name: "some-output"},
+							},
+						}, nil)
+
+						fakeScanner = new(artifactscanfakes.FakeScanner)
+						scanner = fakeScanner
+					})
+
+					It("streams each output through the scanner", func() {
+						Expect(fakeScanner.ScanCallCount()).To(Equal(1))
+
+						_, name, tgzStream := fakeScanner.ScanArgsForCall(0)
+						Expect(name).To(Equal("some-output"))
+						Expect(ioutil.ReadAll(tgzStream)).To(Equal([]byte("some-tgz")))
+					})
+
+					Context("when the output is clean", func() {
+						It("succeeds", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(taskStep.Succeeded()).To(BeTrue())
+						})
+					})
+
+					Context("when the scanner finds something in the output", func() {
+						BeforeEach(func() {
+							fakeScanner.ScanReturns(artifactscan.Result{
+								Findings: []string{"some-virus", "some-secret"},
+							}, nil)
+						})
+
+						Context("when the policy is to fail", func() {
+							It("returns an error listing the findings", func() {
+								Expect(stepErr).To(Equal(exec.OutputScanFailedError{
+									Output:   "some-output",
+									Findings: []string{"some-virus", "some-secret"},
+								}))
+								Expect(stepErr).To(MatchError("output 'some-output' failed scan: some-virus, some-secret"))
+							})
+
+							It("does not finish the task", func() {
+								Expect(fakeDelegate.FinishedCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when the policy is to annotate", func() {
+							BeforeEach(func() {
+								scanPolicy = artifactscan.PolicyAnnotate
+							})
+
+							It("prints the findings to stderr", func() {
+								Expect(stderrBuf).To(gbytes.Say("output 'some-output' was flagged by the artifact scanner:\n"))
+								Expect(stderrBuf).To(gbytes.Say("  - some-virus\n"))
+								Expect(stderrBuf).To(gbytes.Say("  - some-secret\n"))
+							})
+
+							It("succeeds", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+								Expect(taskStep.Succeeded()).To(BeTrue())
+							})
+						})
+					})
+
+					Context("when scanning the output fails", func() {
+						disaster := errors.New("scanner unavailable")
+
+						BeforeEach(func() {
+							fakeScanner.ScanReturns(artifactscan.Result{}, disaster)
+						})
+
+						Context("when the policy is to fail", func() {
+							It("returns the error", func() {
+								Expect(stepErr).To(Equal(disaster))
+							})
+						})
+
+						Context("when the policy is to annotate", func() {
+							BeforeEach(func() {
+								scanPolicy = artifactscan.PolicyAnnotate
+							})
+
+							It("prints the error to stderr", func() {
+								Expect(stderrBuf).To(gbytes.Say("failed to scan output 'some-output': scanner unavailable"))
+							})
+
+							It("succeeds", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+							})
+						})
+					})
+				})
+
+				Context("when an image artifact name is specified", func() {
+					BeforeEach(func() {
+						imageArtifactName = "some-image-artifact"
+
+						fakeProcess.WaitReturns(0, nil)
+					})
+
+					Context("when the image artifact is registered in the source repo", func() {
+						var imageArtifactSource *workerfakes.FakeArtifactSource
+
+						BeforeEach(func() {
+							imageArtifactSource = new(workerfakes.FakeArtifactSource)
+							repo.RegisterSource("some-image-artifact", imageArtifactSource)
+						})
+
+						It("creates the container with the image artifact source", func() {
 							_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
 							Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
 								ImageArtifactSource: imageArtifactSource,
@@ -1288,6 +2107,19 @@ var _ = Describe("TaskStep", func() {
 					})
 				})
 
+				Context("when a windows task specifies a run dir with backslashes", func() {
+					BeforeEach(func() {
+						fetchedConfig.Platform = "windows"
+						fetchedConfig.Run.Dir = `some\dir`
+						configSource.FetchConfigReturns(fetchedConfig, nil)
+					})
+
+					It("runs a process in the directory within the artifacts root", func() {
+						spec, _ := fakeContainer.RunArgsForCall(0)
+						Expect(spec.Dir).To(Equal("some-artifact-root/some/dir"))
+					})
+				})
+
 				Context("when a run user is specified", func() {
 					BeforeEach(func() {
 						fetchedConfig.Run.User = "some-user"
@@ -1323,6 +2155,14 @@ var _ = Describe("TaskStep", func() {
 						Expect(taskStep.Succeeded()).To(BeTrue())
 					})
 
+					It("releases the task's slot on its worker", func() {
+						Expect(fakeWorkerClient.ReleaseTaskSlotCallCount()).To(Equal(1))
+
+						_, actualTeamID, owner := fakeWorkerClient.ReleaseTaskSlotArgsForCall(0)
+						Expect(actualTeamID).To(Equal(teamID))
+						Expect(owner).To(Equal(db.NewBuildStepContainerOwner(buildID, planID)))
+					})
+
 					It("doesn't register a source", func() {
 						Expect(stepErr).ToNot(HaveOccurred())
 
@@ -1340,6 +2180,107 @@ var _ = Describe("TaskStep", func() {
 						})
 					})
 
+					It("does not check for noisy neighbors", func() {
+						Expect(fakeDelegate.NoisyNeighborsCallCount()).To(BeZero())
+						Expect(fakeWorkerClient.RunningWorkersCallCount()).To(BeZero())
+					})
+
+					Context("when noisy neighbor detection is enabled", func() {
+						var fakeWorker *workerfakes.FakeWorker
+
+						BeforeEach(func() {
+							fakeNoisyNeighbors = new(execfakes.FakeNoisyNeighborDetector)
+							noisyNeighbors = fakeNoisyNeighbors
+
+							fakeContainer.WorkerNameReturns("some-worker")
+
+							otherWorker := new(workerfakes.FakeWorker)
+							otherWorker.NameReturns("some-other-worker")
+							otherWorker.ActiveContainersReturns(20)
+
+							fakeWorker = new(workerfakes.FakeWorker)
+							fakeWorker.NameReturns("some-worker")
+							fakeWorker.ActiveContainersStub = func() int {
+								if fakeWorkerClient.RunningWorkersCallCount() == 1 {
+									return 4
+								}
+
+								return 7
+							}
+
+							fakeWorkerClient.RunningWorkersReturns([]worker.Worker{otherWorker, fakeWorker}, nil)
+						})
+
+						It("observes the run with the most containers seen alongside it on its worker", func() {
+							Expect(fakeNoisyNeighbors.ObserveCallCount()).To(Equal(1))
+
+							run := fakeNoisyNeighbors.ObserveArgsForCall(0)
+							Expect(run.Key).To(Equal("12345/some-task"))
+							Expect(run.WorkerName).To(Equal("some-worker"))
+							Expect(run.Neighbors).To(Equal(6))
+						})
+
+						Context("when noisy neighbors are detected", func() {
+							BeforeEach(func() {
+								fakeNoisyNeighbors.ObserveReturns(exec.NoisyNeighbors{
+									WorkerName:        "some-worker",
+									Duration:          time.Minute,
+									BaselineDuration:  30 * time.Second,
+									Neighbors:         6,
+									BaselineNeighbors: 2,
+								}, true)
+							})
+
+							It("reports them to the delegate", func() {
+								Expect(fakeDelegate.NoisyNeighborsCallCount()).To(Equal(1))
+
+								_, interference := fakeDelegate.NoisyNeighborsArgsForCall(0)
+								Expect(interference.WorkerName).To(Equal("some-worker"))
+								Expect(interference.Neighbors).To(Equal(6))
+							})
+
+							It("is still successful", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+								Expect(taskStep.Succeeded()).To(BeTrue())
+							})
+						})
+
+						Context("when noisy neighbors are not detected", func() {
+							BeforeEach(func() {
+								fakeNoisyNeighbors.ObserveReturns(exec.NoisyNeighbors{}, false)
+							})
+
+							It("does not report anything to the delegate", func() {
+								Expect(fakeDelegate.NoisyNeighborsCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when the task does not belong to a job (one-off build)", func() {
+							BeforeEach(func() {
+								jobID = 0
+							})
+
+							It("does not observe the run", func() {
+								Expect(fakeNoisyNeighbors.ObserveCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when listing the workers fails", func() {
+							BeforeEach(func() {
+								fakeWorkerClient.RunningWorkersReturns(nil, errors.New("nope"))
+							})
+
+							It("observes the run without any neighbors", func() {
+								Expect(fakeNoisyNeighbors.ObserveCallCount()).To(Equal(1))
+								Expect(fakeNoisyNeighbors.ObserveArgsForCall(0).Neighbors).To(BeZero())
+							})
+
+							It("is still successful", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+							})
+						})
+					})
+
 					Context("when saving the exit status fails", func() {
 						disaster := errors.New("nope")
 
@@ -1534,6 +2475,200 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when every worker is running the maximum number of active tasks", func() {
+			BeforeEach(func() {
+				fakeWorkerClient.FindOrCreateContainerReturns(nil, worker.ErrActiveTasksLimitReached)
+
+				fakeDelegate.WaitingForWorkerStub = func(lager.Logger, int) {
+					cancel()
+				}
+			})
+
+			It("waits for a worker, reporting the first attempt to the delegate", func() {
+				Expect(fakeDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+				_, polls := fakeDelegate.WaitingForWorkerArgsForCall(0)
+				Expect(polls).To(Equal(1))
+			})
+
+			It("returns the context's error once canceled", func() {
+				Expect(stepErr).To(Equal(context.Canceled))
+				Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+			})
+
+			It("is not successful", func() {
+				Expect(taskStep.Succeeded()).To(BeFalse())
+			})
+		})
+
+		Context("when the task reuses containers", func() {
+			var (
+				fakeContainer *workerfakes.FakeContainer
+				fakeProcess   *gardenfakes.FakeProcess
+
+				inputSource *workerfakes.FakeVolumeArtifactSource
+			)
+
+			BeforeEach(func() {
+				reuseContainer = true
+
+				inputVolume := new(workerfakes.FakeVolume)
+				inputVolume.HandleReturns("some-input-volume")
+
+				inputSource = new(workerfakes.FakeVolumeArtifactSource)
+				inputSource.SourceVolumeReturns(inputVolume, ".", true)
+				repo.RegisterSource("some-input", inputSource)
+
+				fetchedConfig.Inputs = []atc.TaskInputConfig{{Name: "some-input"}}
+				configSource.FetchConfigReturns(fetchedConfig, nil)
+
+				fakeProcess = new(gardenfakes.FakeProcess)
+				fakeProcess.WaitReturns(0, nil)
+
+				fakeContainer = new(workerfakes.FakeContainer)
+				fakeContainer.HandleReturns("some-handle")
+				fakeContainer.PropertyReturns("", errors.New("no exit status property"))
+				fakeContainer.RunReturns(fakeProcess, nil)
+			})
+
+			Context("when the build has a container left with the same image and inputs", func() {
+				BeforeEach(func() {
+					state.TakeReusableContainerReturns(exec.ReusableContainer{
+						Container:     fakeContainer,
+						ArtifactsRoot: "other-artifact-root",
+						Inputs: map[string]string{
+							"other-artifact-root/some-input": "some-input-volume",
+						},
+					}, true)
+				})
+
+				It("runs in that container rather than creating one", func() {
+					Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(BeZero())
+					Expect(fakeContainer.RunCallCount()).To(Equal(1))
+				})
+
+				It("runs a process of its own in the other step's artifacts directory", func() {
+					spec, _ := fakeContainer.RunArgsForCall(0)
+					Expect(spec.ID).To(Equal("task-42"))
+					Expect(spec.Dir).To(Equal("other-artifact-root"))
+				})
+
+				It("saves its own exit status property", func() {
+					name, value := fakeContainer.SetPropertyArgsForCall(0)
+					Expect(name).To(Equal("concourse:exit-status-42"))
+					Expect(value).To(Equal("0"))
+				})
+
+				It("leaves the container for the next task", func() {
+					Expect(state.LeaveReusableContainerCallCount()).To(Equal(1))
+
+					reusable := state.LeaveReusableContainerArgsForCall(0)
+					Expect(reusable.Container).To(Equal(fakeContainer))
+					Expect(reusable.ArtifactsRoot).To(Equal("other-artifact-root"))
+					Expect(reusable.Inputs).To(Equal(map[string]string{
+						"other-artifact-root/some-input": "some-input-volume",
+					}))
+				})
+
+				It("does not stream its inputs into the container", func() {
+					Expect(inputSource.StreamToCallCount()).To(BeZero())
+					Expect(fakeContainer.StreamInCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the build has a container left with different inputs", func() {
+				var createdContainer *workerfakes.FakeContainer
+
+				BeforeEach(func() {
+					state.TakeReusableContainerReturns(exec.ReusableContainer{
+						Container:     fakeContainer,
+						ArtifactsRoot: "some-artifact-root",
+						Inputs: map[string]string{
+							"some-artifact-root/some-input": "other-input-volume",
+						},
+					}, true)
+
+					createdContainer = new(workerfakes.FakeContainer)
+					createdContainer.PropertyReturns("", errors.New("no exit status property"))
+					createdContainer.RunReturns(fakeProcess, nil)
+					fakeWorkerClient.FindOrCreateContainerReturns(createdContainer, nil)
+				})
+
+				It("creates a container rather than reusing that one", func() {
+					Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+					Expect(createdContainer.RunCallCount()).To(Equal(1))
+					Expect(fakeContainer.RunCallCount()).To(BeZero())
+				})
+
+				It("leaves its own container for the next task", func() {
+					reusable := state.LeaveReusableContainerArgsForCall(0)
+					Expect(reusable.Container).To(Equal(createdContainer))
+					Expect(reusable.Inputs).To(Equal(map[string]string{
+						"some-artifact-root/some-input": "some-input-volume",
+					}))
+				})
+			})
+
+			Context("when the build has no container left with the same image", func() {
+				BeforeEach(func() {
+					fakeWorkerClient.FindOrCreateContainerReturns(fakeContainer, nil)
+				})
+
+				It("creates a container and leaves it for the next task", func() {
+					Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+
+					spec, _ := fakeContainer.RunArgsForCall(0)
+					Expect(spec.ID).To(Equal("task"))
+
+					reusable := state.LeaveReusableContainerArgsForCall(0)
+					Expect(reusable.Container).To(Equal(fakeContainer))
+					Expect(reusable.ArtifactsRoot).To(Equal("some-artifact-root"))
+
+					key := state.TakeReusableContainerArgsForCall(0)
+					Expect(reusable.Key).To(Equal(key))
+				})
+			})
+
+			Context("when the task has caches", func() {
+				BeforeEach(func() {
+					fetchedConfig.Caches = []atc.CacheConfig{{Path: "some-cache"}}
+					configSource.FetchConfigReturns(fetchedConfig, nil)
+
+					fakeWorkerClient.FindOrCreateContainerReturns(fakeContainer, nil)
+				})
+
+				It("does not share its container", func() {
+					Expect(state.TakeReusableContainerCallCount()).To(BeZero())
+					Expect(state.LeaveReusableContainerCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the task has outputs", func() {
+				BeforeEach(func() {
+					fetchedConfig.Outputs = []atc.TaskOutputConfig{{Name: "some-output"}}
+					configSource.FetchConfigReturns(fetchedConfig, nil)
+
+					fakeWorkerClient.FindOrCreateContainerReturns(fakeContainer, nil)
+				})
+
+				It("does not share its container", func() {
+					Expect(state.TakeReusableContainerCallCount()).To(BeZero())
+					Expect(state.LeaveReusableContainerCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when an input has no volume", func() {
+				BeforeEach(func() {
+					inputSource.SourceVolumeReturns(nil, "", false)
+
+					fakeWorkerClient.FindOrCreateContainerReturns(fakeContainer, nil)
+				})
+
+				It("does not leave its container for the next task", func() {
+					Expect(state.LeaveReusableContainerCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when getting the config fails", func() {
 			disaster := errors.New("nope")
 
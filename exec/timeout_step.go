@@ -10,6 +10,8 @@ type TimeoutStep struct {
 	step     Step
 	duration string
 	timedOut bool
+
+	isDefault bool
 }
 
 // Timeout constructs a TimeoutStep factory.
@@ -21,6 +23,19 @@ func Timeout(step Step, duration string) *TimeoutStep {
 	}
 }
 
+// DefaultTimeout constructs a TimeoutStep which only applies its timeout if
+// the step is not already running within one configured by the pipeline,
+// whether on the step itself or on a step enclosing it.
+func DefaultTimeout(step Step, duration time.Duration) *TimeoutStep {
+	return &TimeoutStep{
+		step:      step,
+		duration:  duration.String(),
+		isDefault: true,
+	}
+}
+
+type configuredTimeoutKey struct{}
+
 // Run parses the timeout duration and invokes the nested step.
 //
 // If the nested step takes longer than the duration, it is sent the Interrupt
@@ -29,6 +44,10 @@ func Timeout(step Step, duration string) *TimeoutStep {
 //
 // The result of the nested step's Run is returned.
 func (ts *TimeoutStep) Run(ctx context.Context, state RunState) error {
+	if ts.isDefault && ctx.Value(configuredTimeoutKey{}) != nil {
+		return ts.step.Run(ctx, state)
+	}
+
 	parsedDuration, err := time.ParseDuration(ts.duration)
 	if err != nil {
 		return err
@@ -37,6 +56,10 @@ func (ts *TimeoutStep) Run(ctx context.Context, state RunState) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, parsedDuration)
 	defer cancel()
 
+	if !ts.isDefault {
+		timeoutCtx = context.WithValue(timeoutCtx, configuredTimeoutKey{}, true)
+	}
+
 	err = ts.step.Run(timeoutCtx, state)
 	if err == context.DeadlineExceeded {
 		ts.timedOut = true
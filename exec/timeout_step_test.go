@@ -131,4 +131,42 @@ var _ = Describe("Timeout Step", func() {
 			Expect(fakeStep.RunCallCount()).To(BeZero())
 		})
 	})
+
+})
+
+var _ = Describe("Default Timeout Step", func() {
+	var (
+		fakeStep *execfakes.FakeStep
+		state    *execfakes.FakeRunState
+
+		runCtx context.Context
+	)
+
+	BeforeEach(func() {
+		fakeStep = new(execfakes.FakeStep)
+		fakeStep.RunStub = func(ctx context.Context, state RunState) error {
+			runCtx = ctx
+			return nil
+		}
+
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(worker.NewArtifactRepository())
+	})
+
+	It("applies its timeout when no timeout is configured", func() {
+		Expect(DefaultTimeout(fakeStep, time.Minute).Run(context.Background(), state)).To(Succeed())
+
+		deadline, ok := runCtx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), 10*time.Second))
+	})
+
+	It("does not apply its timeout within a configured timeout", func() {
+		step := Timeout(DefaultTimeout(fakeStep, time.Minute), "1h")
+		Expect(step.Run(context.Background(), state)).To(Succeed())
+
+		deadline, ok := runCtx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Hour), 10*time.Second))
+	})
 })
@@ -0,0 +1,97 @@
+package exec
+
+import (
+	"context"
+	"strings"
+)
+
+//go:generate counterfeiter . Tracer
+
+// Tracer starts a span for each step of a build, so that builds can be
+// reported as distributed traces.
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes map[string]string) (context.Context, Span)
+}
+
+//go:generate counterfeiter . Span
+
+type Span interface {
+	// Carrier returns the fields needed to propagate the span's trace context,
+	// e.g. the W3C "traceparent" and "tracestate" headers.
+	Carrier() map[string]string
+
+	// End finishes the span, recording the error the step failed with, if any.
+	End(err error)
+}
+
+// NoopTracer is used when tracing is not configured.
+type NoopTracer struct{}
+
+func (NoopTracer) Start(ctx context.Context, _ string, _ map[string]string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) Carrier() map[string]string { return nil }
+func (noopSpan) End(error)                  {}
+
+type spanKey struct{}
+
+// WithSpan makes the span available to steps run with the returned context.
+func WithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span of the step currently running, or a span
+// that does nothing if the step is not being traced.
+func SpanFromContext(ctx context.Context) Span {
+	span, ok := ctx.Value(spanKey{}).(Span)
+	if !ok {
+		return noopSpan{}
+	}
+
+	return span
+}
+
+// TracedStep runs its step in a span started by the tracer, made available to
+// the step through its context.
+type TracedStep struct {
+	Step
+
+	tracer     Tracer
+	name       string
+	attributes map[string]string
+}
+
+func Traced(step Step, tracer Tracer, name string, attributes map[string]string) Step {
+	return TracedStep{
+		Step: step,
+
+		tracer:     tracer,
+		name:       name,
+		attributes: attributes,
+	}
+}
+
+func (step TracedStep) Run(ctx context.Context, state RunState) error {
+	ctx, span := step.tracer.Start(ctx, step.name, step.attributes)
+
+	err := step.Step.Run(WithSpan(ctx, span), state)
+
+	span.End(err)
+
+	return err
+}
+
+// traceEnv converts the trace context carried by the span into environment
+// variables, e.g. "traceparent" becomes TRACEPARENT.
+func traceEnv(span Span) []string {
+	var env []string
+	for k, v := range span.Carrier() {
+		name := strings.ToUpper(strings.Replace(k, "-", "_", -1))
+		env = append(env, name+"="+v)
+	}
+
+	return env
+}
@@ -0,0 +1,99 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/worker"
+)
+
+var _ = Describe("Traced Step", func() {
+	var (
+		ctx context.Context
+
+		step       *execfakes.FakeStep
+		fakeTracer *execfakes.FakeTracer
+		fakeSpan   *execfakes.FakeSpan
+
+		state *execfakes.FakeRunState
+
+		tracedStep exec.Step
+
+		stepErr error
+	)
+
+	type someKey struct{}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		step = new(execfakes.FakeStep)
+
+		fakeSpan = new(execfakes.FakeSpan)
+		fakeTracer = new(execfakes.FakeTracer)
+		fakeTracer.StartStub = func(ctx context.Context, name string, attributes map[string]string) (context.Context, exec.Span) {
+			return context.WithValue(ctx, someKey{}, "some-value"), fakeSpan
+		}
+
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(worker.NewArtifactRepository())
+
+		tracedStep = exec.Traced(step, fakeTracer, "task some-task", map[string]string{"team": "some-team"})
+	})
+
+	JustBeforeEach(func() {
+		stepErr = tracedStep.Run(ctx, state)
+	})
+
+	It("starts a span for the step", func() {
+		Expect(fakeTracer.StartCallCount()).To(Equal(1))
+		_, name, attributes := fakeTracer.StartArgsForCall(0)
+		Expect(name).To(Equal("task some-task"))
+		Expect(attributes).To(Equal(map[string]string{"team": "some-team"}))
+	})
+
+	It("runs the step with the span's context", func() {
+		Expect(step.RunCallCount()).To(Equal(1))
+		runCtx, runState := step.RunArgsForCall(0)
+		Expect(runCtx.Value(someKey{})).To(Equal("some-value"))
+		Expect(exec.SpanFromContext(runCtx)).To(Equal(fakeSpan))
+		Expect(runState).To(Equal(state))
+	})
+
+	It("ends the span", func() {
+		Expect(fakeSpan.EndCallCount()).To(Equal(1))
+		Expect(fakeSpan.EndArgsForCall(0)).To(BeNil())
+	})
+
+	Context("when the step fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			step.RunReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+
+		It("records the error on the span", func() {
+			Expect(fakeSpan.EndCallCount()).To(Equal(1))
+			Expect(fakeSpan.EndArgsForCall(0)).To(Equal(disaster))
+		})
+	})
+
+	Describe("Succeeded", func() {
+		It("is the step's result", func() {
+			step.SucceededReturns(true)
+			Expect(tracedStep.Succeeded()).To(BeTrue())
+
+			step.SucceededReturns(false)
+			Expect(tracedStep.Succeeded()).To(BeFalse())
+		})
+	})
+})
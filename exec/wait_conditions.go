@@ -0,0 +1,264 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/worker"
+)
+
+// waitForHTTPTimeout bounds each poll of an HTTP wait condition, so that an
+// endpoint that never responds is polled again rather than hanging the step.
+const waitForHTTPTimeout = 30 * time.Second
+
+// ErrInternalAddress is returned when an HTTP wait condition's URL resolves
+// to an address internal to the ATC's network.
+var ErrInternalAddress = errors.New("refusing to connect to an internal address")
+
+// internalNetworks are the networks HTTP wait conditions may not connect to,
+// as the URL is configured by pipelines rather than by the operator: e.g. the
+// ATC's own API, its database, or a cloud metadata service.
+var internalNetworks = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"224.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+// defaultWaitForHTTPClient refuses to connect to internal networks. The
+// address is checked as it is dialed, so that neither redirects nor DNS
+// answers can lead it to one.
+var defaultWaitForHTTPClient = &http.Client{
+	Timeout: waitForHTTPTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: waitForHTTPTimeout,
+			Control: rejectInternalAddresses,
+		}).DialContext,
+	},
+}
+
+func rejectInternalAddresses(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ErrInternalAddress
+	}
+
+	for _, internal := range internalNetworks {
+		if internal.Contains(ip) {
+			return ErrInternalAddress
+		}
+	}
+
+	return nil
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks[i] = network
+	}
+
+	return networks
+}
+
+// HTTPWaitCondition is met once a GET of the URL responds with the expected
+// status, or with any 2xx status if no status is expected. Failed requests
+// count as the condition not being met.
+//
+// Unless a Client is given, requests to internal networks are refused.
+type HTTPWaitCondition struct {
+	URL    creds.String
+	Status int
+	Client *http.Client
+}
+
+func (condition HTTPWaitCondition) Poll(ctx context.Context) (bool, string, error) {
+	endpoint, err := condition.URL.Evaluate()
+	if err != nil {
+		return false, "", err
+	}
+
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return false, "", err
+	}
+
+	client := condition.Client
+	if client == nil {
+		client = defaultWaitForHTTPClient
+	}
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, "", ctx.Err()
+		}
+
+		// the url may carry credentials, so leave it out of the status
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+
+		return false, fmt.Sprintf("request failed: %s", err), nil
+	}
+
+	defer resp.Body.Close()
+
+	var met bool
+	if condition.Status == 0 {
+		met = resp.StatusCode >= 200 && resp.StatusCode < 300
+	} else {
+		met = resp.StatusCode == condition.Status
+	}
+
+	return met, fmt.Sprintf("responded with %s", resp.Status), nil
+}
+
+// resourceWaitCondition is met once the resource's check reports a version
+// newer than the latest one reported by the first poll. If the first poll
+// reports no versions at all, any version meets the condition.
+type resourceWaitCondition struct {
+	build             db.Build
+	planID            atc.PlanID
+	resourceType      string
+	source            creds.Source
+	tags              atc.Tags
+	containerMetadata db.ContainerMetadata
+	resourceTypes     creds.VersionedResourceTypes
+	resourceFactory   resource.ResourceFactory
+	delegate          BuildStepDelegate
+
+	checkResource resource.Resource
+	latest        atc.Version
+	polled        bool
+}
+
+func NewResourceWaitCondition(
+	build db.Build,
+	planID atc.PlanID,
+	resourceType string,
+	source creds.Source,
+	tags atc.Tags,
+	containerMetadata db.ContainerMetadata,
+	resourceTypes creds.VersionedResourceTypes,
+	resourceFactory resource.ResourceFactory,
+	delegate BuildStepDelegate,
+) WaitCondition {
+	return &resourceWaitCondition{
+		build:             build,
+		planID:            planID,
+		resourceType:      resourceType,
+		source:            source,
+		tags:              tags,
+		containerMetadata: containerMetadata,
+		resourceTypes:     resourceTypes,
+		resourceFactory:   resourceFactory,
+		delegate:          delegate,
+	}
+}
+
+func (condition *resourceWaitCondition) Poll(ctx context.Context) (bool, string, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	if condition.checkResource == nil {
+		checkResource, err := condition.resourceFactory.NewResource(
+			ctx,
+			logger,
+			db.NewBuildStepContainerOwner(condition.build.ID(), condition.planID),
+			condition.containerMetadata,
+			worker.ContainerSpec{
+				ImageSpec: worker.ImageSpec{
+					ResourceType: condition.resourceType,
+				},
+				Tags:   condition.tags,
+				TeamID: condition.build.TeamID(),
+			},
+			condition.resourceTypes,
+			condition.delegate,
+		)
+		if err != nil {
+			return false, "", err
+		}
+
+		condition.checkResource = checkResource
+	}
+
+	source, err := condition.source.Evaluate()
+	if err != nil {
+		return false, "", err
+	}
+
+	versions, err := condition.checkResource.Check(ctx, source, condition.latest)
+	if err != nil {
+		if err, ok := err.(resource.ErrResourceScriptFailed); ok {
+			return false, fmt.Sprintf("check failed with exit status %d", err.ExitStatus), nil
+		}
+
+		return false, "", err
+	}
+
+	var newVersions []atc.Version
+	for _, version := range versions {
+		if condition.latest == nil || !sameVersion(version, condition.latest) {
+			newVersions = append(newVersions, version)
+		}
+	}
+
+	if len(newVersions) == 0 {
+		condition.polled = true
+		return false, "no new versions", nil
+	}
+
+	if !condition.polled {
+		condition.polled = true
+		condition.latest = newVersions[len(newVersions)-1]
+		return false, "no new versions", nil
+	}
+
+	return true, fmt.Sprintf("found %d new version(s)", len(newVersions)), nil
+}
+
+func sameVersion(a, b atc.Version) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,112 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+)
+
+// DefaultWaitInterval is how long a WaitStep waits between polls when no
+// interval is configured.
+const DefaultWaitInterval = 10 * time.Second
+
+//go:generate counterfeiter . WaitDelegate
+
+type WaitDelegate interface {
+	BuildStepDelegate
+
+	Waiting(logger lager.Logger, polls int, status string)
+	Finished(logger lager.Logger, polls int)
+}
+
+//go:generate counterfeiter . WaitCondition
+
+// WaitCondition is polled by a WaitStep until it is met.
+type WaitCondition interface {
+	// Poll returns whether the condition is met, along with a short description
+	// of what was observed. An error aborts the wait.
+	Poll(context.Context) (bool, string, error)
+}
+
+// WaitStep polls a condition until it is met, so that a build can wait on an
+// external system without keeping a container busy.
+type WaitStep struct {
+	planID    atc.PlanID
+	condition WaitCondition
+	interval  string
+	delegate  WaitDelegate
+
+	succeeded bool
+}
+
+func NewWaitStep(
+	planID atc.PlanID,
+	condition WaitCondition,
+	interval string,
+	delegate WaitDelegate,
+) Step {
+	return &WaitStep{
+		planID:    planID,
+		condition: condition,
+		interval:  interval,
+		delegate:  delegate,
+	}
+}
+
+// Run polls the condition at the configured interval until it is met,
+// reporting a heartbeat to the delegate after each poll that finds it unmet.
+//
+// The step waits indefinitely; it is expected to be bounded by a timeout. If
+// the context is canceled or times out, its error is returned.
+func (step *WaitStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx).WithData(lager.Data{
+		"plan-id": step.planID,
+	})
+
+	interval := DefaultWaitInterval
+	if step.interval != "" {
+		var err error
+		interval, err = time.ParseDuration(step.interval)
+		if err != nil {
+			return err
+		}
+
+		if interval <= 0 {
+			return fmt.Errorf("wait interval must be positive: %s", step.interval)
+		}
+	}
+
+	for polls := 1; ; polls++ {
+		met, status, err := step.condition.Poll(ctx)
+		if err != nil {
+			logger.Error("failed-to-poll-condition", err)
+			return err
+		}
+
+		if met {
+			step.succeeded = true
+			step.delegate.Finished(logger, polls)
+			return nil
+		}
+
+		step.delegate.Waiting(logger, polls, status)
+
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Succeeded is true if the condition was met.
+func (step *WaitStep) Succeeded() bool {
+	return step.succeeded
+}
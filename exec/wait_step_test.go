@@ -0,0 +1,161 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+)
+
+var _ = Describe("WaitStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeCondition *execfakes.FakeWaitCondition
+		fakeDelegate  *execfakes.FakeWaitDelegate
+
+		interval string
+
+		state exec.RunState
+
+		step    exec.Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeCondition = new(execfakes.FakeWaitCondition)
+		fakeDelegate = new(execfakes.FakeWaitDelegate)
+
+		interval = "1ms"
+
+		state = exec.NewRunState()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewWaitStep("some-plan-id", fakeCondition, interval, fakeDelegate)
+		stepErr = step.Run(ctx, state)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	Context("when the condition is met straight away", func() {
+		BeforeEach(func() {
+			fakeCondition.PollReturns(true, "responded with 200 OK", nil)
+		})
+
+		It("polls once", func() {
+			Expect(fakeCondition.PollCallCount()).To(Equal(1))
+		})
+
+		It("does not report a heartbeat", func() {
+			Expect(fakeDelegate.WaitingCallCount()).To(BeZero())
+		})
+
+		It("finishes after one poll", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, polls := fakeDelegate.FinishedArgsForCall(0)
+			Expect(polls).To(Equal(1))
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+	})
+
+	Context("when the condition is met after a few polls", func() {
+		BeforeEach(func() {
+			fakeCondition.PollReturns(false, "responded with 503 Service Unavailable", nil)
+			fakeCondition.PollReturnsOnCall(2, true, "responded with 200 OK", nil)
+		})
+
+		It("reports a heartbeat for each poll that found the condition unmet", func() {
+			Expect(fakeDelegate.WaitingCallCount()).To(Equal(2))
+
+			_, polls, status := fakeDelegate.WaitingArgsForCall(0)
+			Expect(polls).To(Equal(1))
+			Expect(status).To(Equal("responded with 503 Service Unavailable"))
+
+			_, polls, _ = fakeDelegate.WaitingArgsForCall(1)
+			Expect(polls).To(Equal(2))
+		})
+
+		It("finishes after the poll that met the condition", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, polls := fakeDelegate.FinishedArgsForCall(0)
+			Expect(polls).To(Equal(3))
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+	})
+
+	Context("when polling errors", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeCondition.PollReturns(false, "", disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+
+		It("does not succeed", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when the context is canceled while waiting", func() {
+		BeforeEach(func() {
+			interval = "1h"
+
+			fakeCondition.PollStub = func(context.Context) (bool, string, error) {
+				cancel()
+				return false, "no new versions", nil
+			}
+		})
+
+		It("stops waiting and returns the context's error", func() {
+			Expect(stepErr).To(Equal(context.Canceled))
+			Expect(fakeCondition.PollCallCount()).To(Equal(1))
+		})
+
+		It("does not succeed", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when the interval is invalid", func() {
+		BeforeEach(func() {
+			interval = "nope"
+		})
+
+		It("returns an error without polling", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(fakeCondition.PollCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the interval is not positive", func() {
+		BeforeEach(func() {
+			interval = "-1s"
+		})
+
+		It("returns an error without polling", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(fakeCondition.PollCallCount()).To(BeZero())
+		})
+	})
+})
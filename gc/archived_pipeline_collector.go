@@ -0,0 +1,75 @@
+package gc
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type archivedPipelineCollector struct {
+	logger          lager.Logger
+	pipelineFactory db.PipelineFactory
+	retention       time.Duration
+	clock           clock.Clock
+}
+
+// NewArchivedPipelineCollector constructs a Collector which destroys
+// pipelines once they have been archived for longer than the retention.
+// Archived pipelines are kept forever if the retention is zero.
+func NewArchivedPipelineCollector(
+	logger lager.Logger,
+	pipelineFactory db.PipelineFactory,
+	retention time.Duration,
+	clock clock.Clock,
+) Collector {
+	return &archivedPipelineCollector{
+		logger:          logger,
+		pipelineFactory: pipelineFactory,
+		retention:       retention,
+		clock:           clock,
+	}
+}
+
+func (apc *archivedPipelineCollector) Run() error {
+	if apc.retention == 0 {
+		return nil
+	}
+
+	logger := apc.logger.Session("run")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	archivedBefore := apc.clock.Now().Add(-apc.retention)
+
+	pipelines, err := apc.pipelineFactory.ArchivedPipelines(archivedBefore)
+	if err != nil {
+		logger.Error("failed-to-get-archived-pipelines", err)
+		return err
+	}
+
+	for _, pipeline := range pipelines {
+		// the pipeline may have been restored since it was listed
+		destroyed, err := pipeline.DestroyIfArchivedBefore(archivedBefore)
+		if err != nil {
+			logger.Error("failed-to-destroy-pipeline", err, lager.Data{
+				"pipeline-id": pipeline.ID(),
+			})
+			continue
+		}
+
+		if !destroyed {
+			continue
+		}
+
+		logger.Info("destroyed-pipeline", lager.Data{
+			"pipeline-id":   pipeline.ID(),
+			"pipeline-name": pipeline.Name(),
+			"team-name":     pipeline.TeamName(),
+		})
+	}
+
+	return nil
+}
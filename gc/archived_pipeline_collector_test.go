@@ -0,0 +1,92 @@
+package gc_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ArchivedPipelineCollector", func() {
+	var (
+		fakePipelineFactory *dbfakes.FakePipelineFactory
+		fakeClock           *fakeclock.FakeClock
+		retention           time.Duration
+
+		fakePipeline      *dbfakes.FakePipeline
+		otherFakePipeline *dbfakes.FakePipeline
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
+		retention = 24 * time.Hour
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		otherFakePipeline = new(dbfakes.FakePipeline)
+		fakePipelineFactory.ArchivedPipelinesReturns([]db.Pipeline{fakePipeline, otherFakePipeline}, nil)
+	})
+
+	JustBeforeEach(func() {
+		runErr = gc.NewArchivedPipelineCollector(
+			lagertest.NewTestLogger("test"),
+			fakePipelineFactory,
+			retention,
+			fakeClock,
+		).Run()
+	})
+
+	It("destroys the pipelines archived before the retention", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+
+		Expect(fakePipelineFactory.ArchivedPipelinesCallCount()).To(Equal(1))
+		Expect(fakePipelineFactory.ArchivedPipelinesArgsForCall(0)).To(Equal(fakeClock.Now().Add(-24 * time.Hour)))
+
+		Expect(fakePipeline.DestroyIfArchivedBeforeCallCount()).To(Equal(1))
+		Expect(fakePipeline.DestroyIfArchivedBeforeArgsForCall(0)).To(Equal(fakeClock.Now().Add(-24 * time.Hour)))
+		Expect(otherFakePipeline.DestroyIfArchivedBeforeCallCount()).To(Equal(1))
+
+		Expect(fakePipeline.DestroyCallCount()).To(BeZero())
+	})
+
+	Context("when destroying a pipeline fails", func() {
+		BeforeEach(func() {
+			fakePipeline.DestroyIfArchivedBeforeReturns(false, errors.New("nope"))
+		})
+
+		It("destroys the rest", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(otherFakePipeline.DestroyIfArchivedBeforeCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the archived pipelines cannot be found", func() {
+		BeforeEach(func() {
+			fakePipelineFactory.ArchivedPipelinesReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(HaveOccurred())
+		})
+	})
+
+	Context("when there is no retention", func() {
+		BeforeEach(func() {
+			retention = 0
+		})
+
+		It("keeps every archived pipeline", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakePipelineFactory.ArchivedPipelinesCallCount()).To(BeZero())
+		})
+	})
+})
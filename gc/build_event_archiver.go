@@ -0,0 +1,63 @@
+package gc
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type buildEventArchiver struct {
+	logger       lager.Logger
+	buildFactory db.BuildFactory
+	age          time.Duration
+	batchSize    int
+}
+
+// NewBuildEventArchiver constructs a Collector which offloads the events of
+// builds which completed longer ago than the age to the event archive, up to
+// batchSize builds each run.
+func NewBuildEventArchiver(
+	logger lager.Logger,
+	buildFactory db.BuildFactory,
+	age time.Duration,
+	batchSize int,
+) Collector {
+	return &buildEventArchiver{
+		logger:       logger,
+		buildFactory: buildFactory,
+		age:          age,
+		batchSize:    batchSize,
+	}
+}
+
+func (archiver *buildEventArchiver) Run() error {
+	logger := archiver.logger.Session("run")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	builds, err := archiver.buildFactory.BuildsToArchive(archiver.age, archiver.batchSize)
+	if err != nil {
+		logger.Error("failed-to-find-builds-to-archive", err)
+		return err
+	}
+
+	archived := 0
+	for _, build := range builds {
+		err := build.ArchiveEvents()
+		if err != nil {
+			// carry on with the others; the build is retried next run
+			logger.Error("failed-to-archive-build-events", err, lager.Data{"build": build.ID()})
+			continue
+		}
+
+		archived++
+	}
+
+	if archived > 0 {
+		logger.Info("archived-build-events", lager.Data{"count": archived})
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package gc_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildEventArchiver", func() {
+	var (
+		archiver         gc.Collector
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		fakeBuild1       *dbfakes.FakeBuild
+		fakeBuild2       *dbfakes.FakeBuild
+	)
+
+	BeforeEach(func() {
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+
+		fakeBuild1 = new(dbfakes.FakeBuild)
+		fakeBuild1.IDReturns(1)
+		fakeBuild2 = new(dbfakes.FakeBuild)
+		fakeBuild2.IDReturns(2)
+
+		fakeBuildFactory.BuildsToArchiveReturns([]db.Build{fakeBuild1, fakeBuild2}, nil)
+
+		archiver = gc.NewBuildEventArchiver(
+			lagertest.NewTestLogger("build-event-archiver"),
+			fakeBuildFactory,
+			time.Hour,
+			50,
+		)
+	})
+
+	It("archives the events of builds which completed longer ago than the age", func() {
+		err := archiver.Run()
+		Expect(err).NotTo(HaveOccurred())
+
+		age, limit := fakeBuildFactory.BuildsToArchiveArgsForCall(0)
+		Expect(age).To(Equal(time.Hour))
+		Expect(limit).To(Equal(50))
+
+		Expect(fakeBuild1.ArchiveEventsCallCount()).To(Equal(1))
+		Expect(fakeBuild2.ArchiveEventsCallCount()).To(Equal(1))
+	})
+
+	It("carries on when archiving a build's events fails", func() {
+		fakeBuild1.ArchiveEventsReturns(errors.New("nope"))
+
+		err := archiver.Run()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeBuild2.ArchiveEventsCallCount()).To(Equal(1))
+	})
+
+	It("returns an error if finding the builds fails", func() {
+		disaster := errors.New("nope")
+		fakeBuildFactory.BuildsToArchiveReturns(nil, disaster)
+
+		err := archiver.Run()
+		Expect(err).To(Equal(disaster))
+	})
+})
@@ -2,6 +2,7 @@ package gc
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -13,17 +14,20 @@ type buildReaper struct {
 	logger          lager.Logger
 	pipelineFactory db.PipelineFactory
 	batchSize       int
+	limits          atc.JobLimits
 }
 
 func NewBuildReaper(
 	logger lager.Logger,
 	pipelineFactory db.PipelineFactory,
 	batchSize int,
+	limits atc.JobLimits,
 ) BuildReaper {
 	return &buildReaper{
 		logger:          logger,
 		pipelineFactory: pipelineFactory,
 		batchSize:       batchSize,
+		limits:          limits,
 	}
 }
 
@@ -46,10 +50,17 @@ func (br *buildReaper) Run() error {
 		}
 
 		for _, job := range jobs {
-			if job.Config().BuildLogsToRetain == 0 {
+			retention := br.retention(pipeline.BuildLogRetention(), job.Config())
+
+			if retention.Builds == 0 && retention.Days == 0 {
 				continue
 			}
 
+			retained, err := br.reapSucceededBuilds(pipeline, job, retention)
+			if err != nil {
+				return err
+			}
+
 			buildsToConsiderDeleting := []db.Build{}
 			until := job.FirstLoggedBuildID() - 1
 			limit := br.batchSize
@@ -88,25 +99,16 @@ func (br *buildReaper) Run() error {
 				buildIDsToConsiderDeleting = append(buildIDsToConsiderDeleting, build.ID())
 			}
 
-			buildsToRetain, _, err := job.Builds(
-				db.Page{Limit: job.Config().BuildLogsToRetain},
-			)
+			firstBuildToRetain, found, err := br.firstBuildToRetain(job, retention)
 			if err != nil {
 				br.logger.Error("could-not-get-job-builds-to-retain", err)
 				return err
 			}
 
-			buildIDsToRetain := []int{}
-			for _, build := range buildsToRetain {
-				buildIDsToRetain = append(buildIDsToRetain, build.ID())
-			}
-
-			if len(buildsToRetain) == 0 {
+			if !found {
 				continue
 			}
 
-			firstBuildToRetain := buildsToRetain[len(buildsToRetain)-1].ID()
-
 			buildIDsToDelete := []int{}
 			for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
 				build := buildsToConsiderDeleting[i]
@@ -115,6 +117,10 @@ func (br *buildReaper) Run() error {
 					break
 				}
 
+				if retained[build.ID()] {
+					continue
+				}
+
 				buildIDsToDelete = append(buildIDsToDelete, build.ID())
 			}
 
@@ -138,3 +144,83 @@ func (br *buildReaper) Run() error {
 
 	return nil
 }
+
+// retention is the policy a job's build logs are reaped under: its pipeline's
+// policy, with the job's own build_logs_to_retain in place of the number of
+// builds if configured, and that number lowered to the operator's limit.
+func (br *buildReaper) retention(policy atc.BuildLogRetention, jobConfig atc.JobConfig) atc.BuildLogRetention {
+	if jobConfig.BuildLogsToRetain == 0 {
+		jobConfig.BuildLogsToRetain = policy.Builds
+	}
+
+	jobConfig, _ = br.limits.Clamp(jobConfig)
+
+	policy.Builds = jobConfig.BuildLogsToRetain
+
+	return policy
+}
+
+// reapSucceededBuilds returns the IDs of the job's most recent succeeded
+// builds whose logs are kept regardless of the retention policy's cutoff.
+// Succeeded builds kept this way on previous runs, which the cutoff has since
+// passed, are reaped once newer builds have succeeded in their place.
+func (br *buildReaper) reapSucceededBuilds(pipeline db.Pipeline, job db.Job, retention atc.BuildLogRetention) (map[int]bool, error) {
+	retained := map[int]bool{}
+	if retention.MinimumSucceeded == 0 {
+		return retained, nil
+	}
+
+	succeededIDs, err := job.LatestSucceededBuildIDs(retention.MinimumSucceeded)
+	if err != nil {
+		br.logger.Error("could-not-get-job-succeeded-builds-to-retain", err)
+		return nil, err
+	}
+
+	for _, id := range succeededIDs {
+		retained[id] = true
+	}
+
+	unreapedIDs, err := job.UnreapedBuildIDsBefore(job.FirstLoggedBuildID())
+	if err != nil {
+		br.logger.Error("could-not-get-job-unreaped-builds", err)
+		return nil, err
+	}
+
+	buildIDsToDelete := []int{}
+	for _, id := range unreapedIDs {
+		if !retained[id] {
+			buildIDsToDelete = append(buildIDsToDelete, id)
+		}
+	}
+
+	if len(buildIDsToDelete) == 0 {
+		return retained, nil
+	}
+
+	err = pipeline.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
+	if err != nil {
+		br.logger.Error("could-not-delete-build-events", err)
+		return nil, err
+	}
+
+	return retained, nil
+}
+
+func (br *buildReaper) firstBuildToRetain(job db.Job, retention atc.BuildLogRetention) (int, bool, error) {
+	if retention.Days != 0 {
+		return job.FirstBuildIDToRetain(retention)
+	}
+
+	buildsToRetain, _, err := job.Builds(
+		db.Page{Limit: retention.Builds},
+	)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(buildsToRetain) == 0 {
+		return 0, false, nil
+	}
+
+	return buildsToRetain[len(buildsToRetain)-1].ID(), true, nil
+}
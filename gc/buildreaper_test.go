@@ -19,11 +19,13 @@ var _ = Describe("BuildReaper", func() {
 		buildReaper         BuildReaper
 		fakePipelineFactory *dbfakes.FakePipelineFactory
 		batchSize           int
+		limits              atc.JobLimits
 	)
 
 	BeforeEach(func() {
 		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
 		batchSize = 5
+		limits = atc.JobLimits{}
 	})
 
 	JustBeforeEach(func() {
@@ -32,6 +34,7 @@ var _ = Describe("BuildReaper", func() {
 			buildReaperLogger,
 			fakePipelineFactory,
 			batchSize,
+			limits,
 		)
 	})
 
@@ -280,6 +283,35 @@ var _ = Describe("BuildReaper", func() {
 				})
 			})
 
+			Context("when the job limits retain fewer build logs than the job", func() {
+				BeforeEach(func() {
+					limits = atc.JobLimits{MaxBuildLogsToRetain: 3}
+
+					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+						if page == (db.Page{Limit: 3}) {
+							return []db.Build{sb(10), sb(9), sb(8)}, db.Pagination{}, nil
+						} else if page == (db.Page{Until: 5, Limit: 5}) {
+							return []db.Build{sb(10), sb(9), sb(8), sb(7), sb(6)}, db.Pagination{}, nil
+						} else {
+							Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+						}
+						return nil, db.Pagination{}, nil
+					}
+
+					fakePipeline.DeleteBuildEventsByBuildIDsReturns(nil)
+					fakeJob.UpdateFirstLoggedBuildIDReturns(nil)
+				})
+
+				It("reaps all but as many builds as the limit retains", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					actualBuildIDs := fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(6, 7))
+				})
+			})
+
 			Context("when getting the job builds fails", func() {
 				var disaster error
 
@@ -498,6 +530,158 @@ var _ = Describe("BuildReaper", func() {
 				Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(BeZero())
 			})
 		})
+
+		Context("when the pipeline has a build log retention policy", func() {
+			var fakeJob *dbfakes.FakeJob
+
+			BeforeEach(func() {
+				fakePipeline.BuildLogRetentionReturns(atc.BuildLogRetention{
+					Builds:           10,
+					Days:             30,
+					MinimumSucceeded: 5,
+				})
+
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.NameReturns("job-1")
+				fakeJob.FirstLoggedBuildIDReturns(6)
+				fakeJob.FirstBuildIDToRetainReturns(9, true, nil)
+				fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+					if page == (db.Page{Until: 5, Limit: 5}) {
+						return []db.Build{sb(10), sb(9), sb(8), sb(7), sb(6)}, db.Pagination{}, nil
+					} else {
+						Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+					}
+					return nil, db.Pagination{}, nil
+				}
+
+				fakePipeline.JobsReturns([]db.Job{fakeJob}, nil)
+			})
+
+			It("reaps the builds before the first build the policy retains", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeJob.FirstBuildIDToRetainCallCount()).To(Equal(1))
+				Expect(fakeJob.FirstBuildIDToRetainArgsForCall(0)).To(Equal(atc.BuildLogRetention{
+					Builds:           10,
+					Days:             30,
+					MinimumSucceeded: 5,
+				}))
+
+				Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+				Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(6, 7, 8))
+
+				Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+				Expect(fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)).To(Equal(9))
+			})
+
+			Context("when some of the builds before the first to retain succeeded most recently", func() {
+				BeforeEach(func() {
+					fakeJob.LatestSucceededBuildIDsReturns([]int{10, 7}, nil)
+				})
+
+				It("keeps them and reaps the rest", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeJob.LatestSucceededBuildIDsArgsForCall(0)).To(Equal(5))
+
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(6, 8))
+
+					Expect(fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)).To(Equal(9))
+				})
+			})
+
+			Context("when builds kept on a previous run are no longer among the most recent succeeded", func() {
+				BeforeEach(func() {
+					fakeJob.UnreapedBuildIDsBeforeReturns([]int{2, 4}, nil)
+					fakeJob.LatestSucceededBuildIDsReturns([]int{10, 7, 4}, nil)
+				})
+
+				It("reaps them", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeJob.UnreapedBuildIDsBeforeArgsForCall(0)).To(Equal(6))
+
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(2))
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(2))
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(1)).To(ConsistOf(6, 8))
+				})
+			})
+
+			Context("when getting the most recent succeeded builds fails", func() {
+				var disaster error
+
+				BeforeEach(func() {
+					disaster = errors.New("major malfunction")
+					fakeJob.LatestSucceededBuildIDsReturns(nil, disaster)
+				})
+
+				It("returns the error without reaping", func() {
+					err := buildReaper.Run()
+					Expect(err).To(Equal(disaster))
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the job configures build_logs_to_retain", func() {
+				BeforeEach(func() {
+					fakeJob.ConfigReturns(atc.JobConfig{
+						BuildLogsToRetain: 3,
+					})
+				})
+
+				It("retains that many builds in place of the policy's", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeJob.FirstBuildIDToRetainArgsForCall(0).Builds).To(Equal(3))
+				})
+			})
+
+			Context("when the job limits retain fewer build logs than the policy", func() {
+				BeforeEach(func() {
+					limits.MaxBuildLogsToRetain = 4
+				})
+
+				It("retains no more builds than the limit", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeJob.FirstBuildIDToRetainArgsForCall(0).Builds).To(Equal(4))
+				})
+			})
+
+			Context("when no build may be reaped", func() {
+				BeforeEach(func() {
+					fakeJob.FirstBuildIDToRetainReturns(0, false, nil)
+				})
+
+				It("doesn't reap any builds", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+					Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when finding the first build to retain fails", func() {
+				var disaster error
+
+				BeforeEach(func() {
+					disaster = errors.New("major malfunction")
+					fakeJob.FirstBuildIDToRetainReturns(0, false, disaster)
+				})
+
+				It("returns the error", func() {
+					err := buildReaper.Run()
+					Expect(err).To(Equal(disaster))
+				})
+			})
+		})
 	})
 
 	Context("when there is a paused pipeline", func() {
@@ -0,0 +1,54 @@
+package gc
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
+)
+
+type cacheUsageCollector struct {
+	logger lager.Logger
+	ledger db.CacheUsageLedger
+}
+
+// NewCacheUsageCollector constructs a Collector which totals up the disk
+// space used by each team's caches, so that it can be reported and held
+// against the team's cache quota.
+func NewCacheUsageCollector(
+	logger lager.Logger,
+	ledger db.CacheUsageLedger,
+) Collector {
+	return &cacheUsageCollector{
+		logger: logger,
+		ledger: ledger,
+	}
+}
+
+func (cuc *cacheUsageCollector) Run() error {
+	logger := cuc.logger.Session("run")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	err := cuc.ledger.Aggregate()
+	if err != nil {
+		logger.Error("failed-to-aggregate-cache-usage", err)
+		return err
+	}
+
+	usages, err := cuc.ledger.TeamUsages()
+	if err != nil {
+		logger.Error("failed-to-get-team-cache-usages", err)
+		return err
+	}
+
+	for _, usage := range usages {
+		metric.TeamCacheUsage{
+			TeamName:           usage.TeamName,
+			ResourceCacheBytes: usage.ResourceCacheBytes,
+			TaskCacheBytes:     usage.TaskCacheBytes,
+		}.Emit(logger)
+	}
+
+	return nil
+}
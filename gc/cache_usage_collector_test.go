@@ -0,0 +1,52 @@
+package gc_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CacheUsageCollector", func() {
+	var (
+		fakeLedger *dbfakes.FakeCacheUsageLedger
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeLedger = new(dbfakes.FakeCacheUsageLedger)
+		fakeLedger.TeamUsagesReturns([]db.TeamCacheUsage{
+			{TeamName: "some-team", ResourceCacheBytes: 1024, TaskCacheBytes: 2048},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		runErr = gc.NewCacheUsageCollector(
+			lagertest.NewTestLogger("test"),
+			fakeLedger,
+		).Run()
+	})
+
+	It("aggregates the cache usage of each team", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+		Expect(fakeLedger.AggregateCallCount()).To(Equal(1))
+		Expect(fakeLedger.TeamUsagesCallCount()).To(Equal(1))
+	})
+
+	Context("when aggregating fails", func() {
+		BeforeEach(func() {
+			fakeLedger.AggregateReturns(errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(HaveOccurred())
+			Expect(fakeLedger.TeamUsagesCallCount()).To(BeZero())
+		})
+	})
+})
@@ -18,6 +18,9 @@ type aggregateCollector struct {
 	volumeCollector                     Collector
 	containerCollector                  Collector
 	resourceConfigCheckSessionCollector Collector
+	volumePolicyCollector               Collector
+	archivedPipelineCollector           Collector
+	cacheUsageCollector                 Collector
 }
 
 func NewCollector(
@@ -30,6 +33,9 @@ func NewCollector(
 	volumes Collector,
 	containers Collector,
 	resourceConfigCheckSessionCollector Collector,
+	volumePolicies Collector,
+	archivedPipelines Collector,
+	cacheUsages Collector,
 ) Collector {
 	return &aggregateCollector{
 		logger:                              logger,
@@ -41,12 +47,22 @@ func NewCollector(
 		volumeCollector:                     volumes,
 		containerCollector:                  containers,
 		resourceConfigCheckSessionCollector: resourceConfigCheckSessionCollector,
+		volumePolicyCollector:               volumePolicies,
+		archivedPipelineCollector:           archivedPipelines,
+		cacheUsageCollector:                 cacheUsages,
 	}
 }
 
 func (c *aggregateCollector) Run() error {
 	var err error
 
+	// destroying archived pipelines orphans the containers of their builds,
+	// which are then destroyed in the same run
+	err = c.archivedPipelineCollector.Run()
+	if err != nil {
+		c.logger.Error("failed-to-run-archived-pipeline-collector", err)
+	}
+
 	err = c.buildCollector.Run()
 	if err != nil {
 		c.logger.Error("failed-to-run-build-collector", err)
@@ -82,6 +98,20 @@ func (c *aggregateCollector) Run() error {
 		c.logger.Error("container-collector", err)
 	}
 
+	// the volume policy holds teams to their cache quotas as of the usage
+	// aggregated here
+	err = c.cacheUsageCollector.Run()
+	if err != nil {
+		c.logger.Error("cache-usage-collector", err)
+	}
+
+	// reclaimed volumes are orphaned, and so are destroyed by the volume
+	// collector in the same run
+	err = c.volumePolicyCollector.Run()
+	if err != nil {
+		c.logger.Error("volume-policy-collector", err)
+	}
+
 	err = c.volumeCollector.Run()
 	if err != nil {
 		c.logger.Error("volume-collector", err)
@@ -24,6 +24,9 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeVolumeCollector                     *gcfakes.FakeCollector
 		fakeContainerCollector                  *gcfakes.FakeCollector
 		fakeResourceConfigCheckSessionCollector *gcfakes.FakeCollector
+		fakeVolumePolicyCollector               *gcfakes.FakeCollector
+		fakeArchivedPipelineCollector           *gcfakes.FakeCollector
+		fakeCacheUsageCollector                 *gcfakes.FakeCollector
 
 		err      error
 		disaster error
@@ -39,6 +42,9 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeVolumeCollector = new(gcfakes.FakeCollector)
 		fakeContainerCollector = new(gcfakes.FakeCollector)
 		fakeResourceConfigCheckSessionCollector = new(gcfakes.FakeCollector)
+		fakeVolumePolicyCollector = new(gcfakes.FakeCollector)
+		fakeArchivedPipelineCollector = new(gcfakes.FakeCollector)
+		fakeCacheUsageCollector = new(gcfakes.FakeCollector)
 
 		subject = NewCollector(
 			logger,
@@ -50,6 +56,9 @@ var _ = Describe("Aggregate Collector", func() {
 			fakeVolumeCollector,
 			fakeContainerCollector,
 			fakeResourceConfigCheckSessionCollector,
+			fakeVolumePolicyCollector,
+			fakeArchivedPipelineCollector,
+			fakeCacheUsageCollector,
 		)
 
 		disaster = errors.New("disaster")
@@ -64,6 +73,46 @@ var _ = Describe("Aggregate Collector", func() {
 			Expect(fakeBuildCollector.RunCallCount()).To(Equal(1))
 		})
 
+		It("runs the archived pipeline collector", func() {
+			Expect(fakeArchivedPipelineCollector.RunCallCount()).To(Equal(1))
+		})
+
+		It("runs the volume policy collector", func() {
+			Expect(fakeVolumePolicyCollector.RunCallCount()).To(Equal(1))
+		})
+
+		It("runs the cache usage collector", func() {
+			Expect(fakeCacheUsageCollector.RunCallCount()).To(Equal(1))
+		})
+
+		Context("when the cache usage collector errors", func() {
+			BeforeEach(func() {
+				fakeCacheUsageCollector.RunReturns(disaster)
+			})
+
+			It("does not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("still runs the volume policy collector", func() {
+				Expect(fakeVolumePolicyCollector.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the volume policy collector errors", func() {
+			BeforeEach(func() {
+				fakeVolumePolicyCollector.RunReturns(disaster)
+			})
+
+			It("does not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("still collects volumes", func() {
+				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when the build collector errors", func() {
 			BeforeEach(func() {
 				fakeBuildCollector.RunReturns(disaster)
@@ -72,7 +72,7 @@ var _ = BeforeEach(func() {
 	defaultTeam, err = teamFactory.CreateTeam(atc.Team{Name: "default-team"})
 	Expect(err).NotTo(HaveOccurred())
 
-	defaultBuild, err = defaultTeam.CreateOneOffBuild()
+	defaultBuild, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 	Expect(err).NotTo(HaveOccurred())
 
 	atcConfig := atc.Config{
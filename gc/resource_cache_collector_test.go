@@ -34,7 +34,7 @@ var _ = Describe("ResourceCacheCollector", func() {
 			BeforeEach(func() {
 				resourceCacheUseCollector = gc.NewResourceCacheUseCollector(logger, resourceCacheLifecycle)
 
-				oneOffBuild, err = defaultTeam.CreateOneOffBuild()
+				oneOffBuild, err = defaultTeam.CreateOneOffBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 
 				oneOffCache, err = resourceCacheFactory.FindOrCreateResourceCache(
@@ -50,7 +50,7 @@ var _ = Describe("ResourceCacheCollector", func() {
 				)
 				Expect(err).NotTo(HaveOccurred())
 
-				jobBuild, err = defaultJob.CreateBuild()
+				jobBuild, err = defaultJob.CreateBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 
 				jobCache, err = resourceCacheFactory.FindOrCreateResourceCache(
@@ -125,7 +125,7 @@ var _ = Describe("ResourceCacheCollector", func() {
 
 					Context("when pipeline is paused", func() {
 						BeforeEach(func() {
-							err := defaultPipeline.Pause()
+							_, err := defaultPipeline.Pause(db.AnyConfigVersion)
 							Expect(err).NotTo(HaveOccurred())
 						})
 
@@ -156,7 +156,7 @@ var _ = Describe("ResourceCacheCollector", func() {
 						var secondJobCache *db.UsedResourceCache
 
 						BeforeEach(func() {
-							secondJobBuild, err = defaultJob.CreateBuild()
+							secondJobBuild, err = defaultJob.CreateBuild(db.BuildTriggerCause{})
 							Expect(err).ToNot(HaveOccurred())
 
 							secondJobCache, err = resourceCacheFactory.FindOrCreateResourceCache(
@@ -207,7 +207,7 @@ var _ = Describe("ResourceCacheCollector", func() {
 							Expect(err).NotTo(HaveOccurred())
 							Expect(found).To(BeTrue())
 
-							secondJobBuild, err = secondJob.CreateBuild()
+							secondJobBuild, err = secondJob.CreateBuild(db.BuildTriggerCause{})
 							Expect(err).ToNot(HaveOccurred())
 
 							secondJobCache, err = resourceCacheFactory.FindOrCreateResourceCache(
@@ -8,15 +8,22 @@ import (
 type resourceConfigCheckSessionCollector struct {
 	logger                      lager.Logger
 	configCheckSessionLifecycle db.ResourceConfigCheckSessionLifecycle
+	migrateFromPressuredWorkers bool
 }
 
+// NewResourceConfigCheckSessionCollector constructs a Collector cleaning up
+// expired and inactive check sessions. If migrateFromPressuredWorkers is set,
+// it also releases the check containers of workers under pressure, so that
+// they move to other workers.
 func NewResourceConfigCheckSessionCollector(
 	logger lager.Logger,
 	configCheckSessionLifecycle db.ResourceConfigCheckSessionLifecycle,
+	migrateFromPressuredWorkers bool,
 ) Collector {
 	return &resourceConfigCheckSessionCollector{
 		logger: logger.Session("resource-config-check-session-collector"),
 		configCheckSessionLifecycle: configCheckSessionLifecycle,
+		migrateFromPressuredWorkers: migrateFromPressuredWorkers,
 	}
 }
 
@@ -33,5 +40,13 @@ func (rccsc *resourceConfigCheckSessionCollector) Run() error {
 		return err
 	}
 
+	if rccsc.migrateFromPressuredWorkers {
+		err = rccsc.configCheckSessionLifecycle.CleanResourceConfigCheckSessionsOnPressuredWorkers()
+		if err != nil {
+			rccsc.logger.Error("unable-to-clean-up-resource-config-check-sessions-on-pressured-workers", err)
+			return err
+		}
+	}
+
 	return nil
 }
@@ -28,7 +28,7 @@ var _ = Describe("ResourceConfigCheckSessionCollector", func() {
 		logger := lagertest.NewTestLogger("resource-config-check-session-collector")
 		resourceConfigCheckSessionLifecycle = db.NewResourceConfigCheckSessionLifecycle(dbConn)
 		resourceConfigCheckSessionFactory = db.NewResourceConfigCheckSessionFactory(dbConn, lockFactory)
-		collector = gc.NewResourceConfigCheckSessionCollector(logger, resourceConfigCheckSessionLifecycle)
+		collector = gc.NewResourceConfigCheckSessionCollector(logger, resourceConfigCheckSessionLifecycle, false)
 	})
 
 	Describe("Run", func() {
@@ -125,7 +125,7 @@ var _ = Describe("ResourceConfigCheckSessionCollector", func() {
 		Context("when the resource is paused", func() {
 			BeforeEach(func() {
 				var err error
-				err = resource.Pause()
+				_, err = resource.Pause(db.AnyConfigVersion)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
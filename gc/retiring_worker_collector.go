@@ -0,0 +1,65 @@
+package gc
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+)
+
+type retiringWorkerCollector struct {
+	logger          lager.Logger
+	workerLifecycle db.WorkerLifecycle
+	replicator      worker.TaskCacheReplicator
+}
+
+// NewRetiringWorkerCollector constructs a Collector which deletes retiring
+// workers once they have finished their builds. Before a retiring worker is
+// deleted, its task caches are copied to another worker by the replicator.
+//
+// Copying the caches can take a while, so this is run on its own rather than
+// as part of the aggregate Collector, to keep it from holding up the rest of
+// garbage collection.
+func NewRetiringWorkerCollector(
+	logger lager.Logger,
+	workerLifecycle db.WorkerLifecycle,
+	replicator worker.TaskCacheReplicator,
+) Collector {
+	return &retiringWorkerCollector{
+		logger:          logger,
+		workerLifecycle: workerLifecycle,
+		replicator:      replicator,
+	}
+}
+
+func (rc *retiringWorkerCollector) Run() error {
+	logger := rc.logger.Session("run")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	finished, err := rc.workerLifecycle.FindFinishedRetiringWorkers()
+	if err != nil {
+		logger.Error("failed-to-find-finished-retiring-workers", err)
+		return err
+	}
+
+	for _, workerName := range finished {
+		err := rc.replicator.ReplicateTaskCaches(logger, workerName)
+		if err != nil {
+			// retire the worker regardless, rather than holding it up forever
+			logger.Error("failed-to-replicate-task-caches", err, lager.Data{"worker": workerName})
+		}
+	}
+
+	affected, err := rc.workerLifecycle.DeleteFinishedRetiringWorkers()
+	if err != nil {
+		logger.Error("failed-to-delete-finished-retiring-workers", err)
+		return err
+	}
+
+	if len(affected) > 0 {
+		logger.Debug("retired", lager.Data{"count": len(affected), "workers": affected})
+	}
+
+	return nil
+}
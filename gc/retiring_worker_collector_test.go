@@ -0,0 +1,96 @@
+package gc_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RetiringWorkerCollector", func() {
+	var (
+		retiringWorkerCollector gc.Collector
+		fakeWorkerLifecycle     *dbfakes.FakeWorkerLifecycle
+		fakeReplicator          *workerfakes.FakeTaskCacheReplicator
+	)
+
+	BeforeEach(func() {
+		logger := lagertest.NewTestLogger("retiring-worker-collector")
+		fakeWorkerLifecycle = new(dbfakes.FakeWorkerLifecycle)
+		fakeReplicator = new(workerfakes.FakeTaskCacheReplicator)
+
+		retiringWorkerCollector = gc.NewRetiringWorkerCollector(
+			logger,
+			fakeWorkerLifecycle,
+			fakeReplicator,
+		)
+
+		fakeWorkerLifecycle.FindFinishedRetiringWorkersReturns(nil, nil)
+		fakeWorkerLifecycle.DeleteFinishedRetiringWorkersReturns(nil, nil)
+	})
+
+	Describe("Run", func() {
+		It("tells the worker factory to delete finished retiring workers", func() {
+			err := retiringWorkerCollector.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeWorkerLifecycle.DeleteFinishedRetiringWorkersCallCount()).To(Equal(1))
+		})
+
+		Context("when there are finished retiring workers", func() {
+			BeforeEach(func() {
+				fakeWorkerLifecycle.FindFinishedRetiringWorkersReturns([]string{"worker-a", "worker-b"}, nil)
+			})
+
+			It("replicates their task caches before deleting them", func() {
+				fakeReplicator.ReplicateTaskCachesStub = func(lager.Logger, string) error {
+					Expect(fakeWorkerLifecycle.DeleteFinishedRetiringWorkersCallCount()).To(BeZero())
+					return nil
+				}
+
+				err := retiringWorkerCollector.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeReplicator.ReplicateTaskCachesCallCount()).To(Equal(2))
+				_, workerName := fakeReplicator.ReplicateTaskCachesArgsForCall(0)
+				Expect(workerName).To(Equal("worker-a"))
+				_, workerName = fakeReplicator.ReplicateTaskCachesArgsForCall(1)
+				Expect(workerName).To(Equal("worker-b"))
+
+				Expect(fakeWorkerLifecycle.DeleteFinishedRetiringWorkersCallCount()).To(Equal(1))
+			})
+
+			It("deletes them even if replicating fails", func() {
+				fakeReplicator.ReplicateTaskCachesReturns(errors.New("some-error"))
+
+				err := retiringWorkerCollector.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeWorkerLifecycle.DeleteFinishedRetiringWorkersCallCount()).To(Equal(1))
+			})
+		})
+
+		It("returns an error if finding finished retiring workers fails", func() {
+			returnedErr := errors.New("some-error")
+			fakeWorkerLifecycle.FindFinishedRetiringWorkersReturns(nil, returnedErr)
+
+			err := retiringWorkerCollector.Run()
+			Expect(err).To(MatchError(returnedErr))
+			Expect(fakeWorkerLifecycle.DeleteFinishedRetiringWorkersCallCount()).To(BeZero())
+		})
+
+		It("returns an error if deleting finished retiring workers fails", func() {
+			returnedErr := errors.New("some-error")
+			fakeWorkerLifecycle.DeleteFinishedRetiringWorkersReturns(nil, returnedErr)
+
+			err := retiringWorkerCollector.Run()
+			Expect(err).To(MatchError(returnedErr))
+		})
+	})
+})
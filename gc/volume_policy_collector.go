@@ -0,0 +1,210 @@
+package gc
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
+)
+
+// VolumePolicy configures when the cache volumes not in use by any container
+// are reclaimed, on top of the volumes the other collectors find to no longer
+// be needed at all. Each policy is disabled by its zero value.
+type VolumePolicy struct {
+	// MaxAge reclaims cache volumes once they have gone unused for longer
+	// than it.
+	MaxAge time.Duration
+
+	// MaxDiskUsage reclaims the oldest cache volumes on workers reporting a
+	// higher percentage of their disk in use, in proportion to how far over
+	// the limit they are.
+	MaxDiskUsage int
+
+	// TeamQuotas limits the number of cache volumes kept for each named team,
+	// reclaiming the oldest of any beyond it.
+	TeamQuotas map[string]int
+
+	// TeamCacheQuotas limits the disk space in bytes used by the caches of
+	// each named team, reclaiming its oldest cache volumes until it is back
+	// under the quota. Usage is as last aggregated by the cache usage
+	// collector, from the volume sizes workers report with their heartbeats.
+	// Volumes whose size has not been reported are not reclaimed for it.
+	TeamCacheQuotas map[string]int64
+}
+
+func (policy VolumePolicy) enabled() bool {
+	return policy.MaxAge > 0 || policy.MaxDiskUsage > 0 || len(policy.TeamQuotas) > 0 || len(policy.TeamCacheQuotas) > 0
+}
+
+type volumePolicyCollector struct {
+	logger        lager.Logger
+	volumeFactory db.VolumeFactory
+	policy        VolumePolicy
+	clock         clock.Clock
+}
+
+// NewVolumePolicyCollector constructs a Collector which reclaims the cache
+// volumes selected by the policy. Reclaiming a volume only releases its
+// cache; the volume is then destroyed as an orphan by the volume collector.
+func NewVolumePolicyCollector(
+	logger lager.Logger,
+	volumeFactory db.VolumeFactory,
+	policy VolumePolicy,
+	clock clock.Clock,
+) Collector {
+	return &volumePolicyCollector{
+		logger:        logger,
+		volumeFactory: volumeFactory,
+		policy:        policy,
+		clock:         clock,
+	}
+}
+
+func (vpc *volumePolicyCollector) Run() error {
+	if !vpc.policy.enabled() {
+		return nil
+	}
+
+	logger := vpc.logger.Session("run")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	volumes, err := vpc.volumeFactory.GetReclaimableVolumes()
+	if err != nil {
+		logger.Error("failed-to-get-reclaimable-volumes", err)
+		return err
+	}
+
+	selected := vpc.selectVolumes(volumes)
+
+	reclaimed := map[string]int{}
+	for _, volume := range volumes {
+		policy, found := selected[volume.Handle()]
+		if !found {
+			continue
+		}
+
+		vLog := logger.Session("reclaim", lager.Data{
+			"volume": volume.Handle(),
+			"worker": volume.WorkerName(),
+			"policy": policy,
+		})
+
+		ok, err := volume.Reclaim()
+		if err != nil {
+			vLog.Error("failed-to-reclaim", err)
+			continue
+		}
+
+		if !ok {
+			vLog.Debug("volume-in-use")
+			continue
+		}
+
+		reclaimed[policy]++
+	}
+
+	for policy, count := range reclaimed {
+		metric.VolumesReclaimed{
+			Policy:  policy,
+			Volumes: count,
+		}.Emit(logger)
+	}
+
+	return nil
+}
+
+// selectVolumes returns the handles of the volumes to reclaim, along with the
+// first policy which selected each of them. The volumes are least recently
+// used first, so those are the first to go when only some of them need to.
+func (vpc *volumePolicyCollector) selectVolumes(volumes []db.ReclaimableVolume) map[string]string {
+	selected := map[string]string{}
+
+	selectVolume := func(volume db.ReclaimableVolume, policy string) {
+		if _, found := selected[volume.Handle()]; !found {
+			selected[volume.Handle()] = policy
+		}
+	}
+
+	if vpc.policy.MaxAge > 0 {
+		for _, volume := range volumes {
+			if vpc.clock.Since(volume.LastUsedAt()) > vpc.policy.MaxAge {
+				selectVolume(volume, "max-age")
+			}
+		}
+	}
+
+	if vpc.policy.MaxDiskUsage > 0 {
+		workerVolumes := map[string][]db.ReclaimableVolume{}
+		for _, volume := range volumes {
+			workerVolumes[volume.WorkerName()] = append(workerVolumes[volume.WorkerName()], volume)
+		}
+
+		for _, volumes := range workerVolumes {
+			usage := volumes[0].WorkerDiskUsage()
+			if usage <= vpc.policy.MaxDiskUsage {
+				continue
+			}
+
+			// without knowing the size of each volume, presume they are all
+			// about the same size, rounding up so that at least one goes
+			excess := (len(volumes)*(usage-vpc.policy.MaxDiskUsage) + usage - 1) / usage
+			for _, volume := range volumes[:excess] {
+				selectVolume(volume, "max-disk-usage")
+			}
+		}
+	}
+
+	if len(vpc.policy.TeamQuotas) > 0 {
+		teamVolumes := map[string][]db.ReclaimableVolume{}
+		for _, volume := range volumes {
+			teamVolumes[volume.TeamName()] = append(teamVolumes[volume.TeamName()], volume)
+		}
+
+		for teamName, quota := range vpc.policy.TeamQuotas {
+			volumes := teamVolumes[teamName]
+			if len(volumes) <= quota {
+				continue
+			}
+
+			for _, volume := range volumes[:len(volumes)-quota] {
+				selectVolume(volume, "team-quota")
+			}
+		}
+	}
+
+	if len(vpc.policy.TeamCacheQuotas) > 0 {
+		teamVolumes := map[string][]db.ReclaimableVolume{}
+		for _, volume := range volumes {
+			teamVolumes[volume.TeamName()] = append(teamVolumes[volume.TeamName()], volume)
+		}
+
+		for teamName, quota := range vpc.policy.TeamCacheQuotas {
+			volumes := teamVolumes[teamName]
+			if len(volumes) == 0 {
+				continue
+			}
+
+			excess := volumes[0].TeamCacheUsage() - quota
+			for _, volume := range volumes {
+				if excess <= 0 {
+					break
+				}
+
+				// reclaiming a volume of unknown size would not count
+				// towards the excess, so every volume would go
+				if volume.Size() == 0 {
+					continue
+				}
+
+				selectVolume(volume, "team-cache-quota")
+				excess -= volume.Size()
+			}
+		}
+	}
+
+	return selected
+}
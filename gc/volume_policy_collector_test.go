@@ -0,0 +1,181 @@
+package gc_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VolumePolicyCollector", func() {
+	var (
+		fakeVolumeFactory *dbfakes.FakeVolumeFactory
+		fakeClock         *fakeclock.FakeClock
+		policy            gc.VolumePolicy
+
+		volumes []*dbfakes.FakeReclaimableVolume
+
+		runErr error
+	)
+
+	newVolume := func(handle string, workerName string, teamName string, age time.Duration, diskUsage int) *dbfakes.FakeReclaimableVolume {
+		volume := new(dbfakes.FakeReclaimableVolume)
+		volume.HandleReturns(handle)
+		volume.WorkerNameReturns(workerName)
+		volume.TeamNameReturns(teamName)
+		volume.LastUsedAtReturns(fakeClock.Now().Add(-age))
+		volume.WorkerDiskUsageReturns(diskUsage)
+		volume.ReclaimReturns(true, nil)
+		return volume
+	}
+
+	reclaimed := func() []string {
+		handles := []string{}
+		for _, volume := range volumes {
+			if volume.ReclaimCallCount() > 0 {
+				handles = append(handles, volume.Handle())
+			}
+		}
+
+		return handles
+	}
+
+	BeforeEach(func() {
+		fakeVolumeFactory = new(dbfakes.FakeVolumeFactory)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
+		policy = gc.VolumePolicy{}
+
+		volumes = []*dbfakes.FakeReclaimableVolume{
+			newVolume("oldest", "worker-a", "team-a", 3*time.Hour, 50),
+			newVolume("older", "worker-b", "team-a", 2*time.Hour, 90),
+			newVolume("old", "worker-b", "team-b", time.Hour, 90),
+			newVolume("new", "worker-a", "team-a", time.Minute, 50),
+		}
+
+		fakeVolumeFactory.GetReclaimableVolumesStub = func() ([]db.ReclaimableVolume, error) {
+			reclaimableVolumes := []db.ReclaimableVolume{}
+			for _, volume := range volumes {
+				reclaimableVolumes = append(reclaimableVolumes, volume)
+			}
+
+			return reclaimableVolumes, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		collector := gc.NewVolumePolicyCollector(
+			lagertest.NewTestLogger("test"),
+			fakeVolumeFactory,
+			policy,
+			fakeClock,
+		)
+
+		runErr = collector.Run()
+	})
+
+	Context("when no policies are configured", func() {
+		It("does not look for volumes to reclaim", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeVolumeFactory.GetReclaimableVolumesCallCount()).To(BeZero())
+		})
+	})
+
+	Context("with a maximum volume age", func() {
+		BeforeEach(func() {
+			policy.MaxAge = 90 * time.Minute
+		})
+
+		It("reclaims the volumes older than it", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(reclaimed()).To(Equal([]string{"oldest", "older"}))
+		})
+	})
+
+	Context("with a maximum disk usage", func() {
+		BeforeEach(func() {
+			policy.MaxDiskUsage = 80
+		})
+
+		It("reclaims the oldest volumes on the workers over it", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(reclaimed()).To(Equal([]string{"older"}))
+		})
+	})
+
+	Context("with team quotas", func() {
+		BeforeEach(func() {
+			policy.TeamQuotas = map[string]int{"team-a": 1, "team-b": 1}
+		})
+
+		It("reclaims the oldest volumes of the teams over their quota", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(reclaimed()).To(Equal([]string{"oldest", "older"}))
+		})
+	})
+
+	Context("when teams have cache quotas", func() {
+		BeforeEach(func() {
+			policy.TeamCacheQuotas = map[string]int64{"team-a": 1000, "team-b": 1000}
+
+			for _, volume := range volumes {
+				volume.SizeReturns(300)
+			}
+
+			// including caches in use, which cannot be reclaimed
+			volumes[0].TeamCacheUsageReturns(1500)
+			volumes[1].TeamCacheUsageReturns(1500)
+			volumes[2].TeamCacheUsageReturns(800)
+			volumes[3].TeamCacheUsageReturns(1500)
+		})
+
+		It("reclaims the oldest volumes of the teams over their quota until they are under it", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(reclaimed()).To(Equal([]string{"oldest", "older"}))
+		})
+
+		Context("when the size of a volume is unknown", func() {
+			BeforeEach(func() {
+				volumes[0].SizeReturns(0)
+			})
+
+			It("does not reclaim it, as it would not count towards the quota", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+				Expect(reclaimed()).To(Equal([]string{"older", "new"}))
+			})
+		})
+	})
+
+	Context("when a volume fails to be reclaimed", func() {
+		BeforeEach(func() {
+			policy.MaxAge = 90 * time.Minute
+			volumes[0].ReclaimReturns(false, errors.New("disaster"))
+		})
+
+		It("reclaims the rest", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(volumes[1].ReclaimCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the volumes fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			policy.MaxAge = time.Hour
+
+			fakeVolumeFactory.GetReclaimableVolumesStub = nil
+			fakeVolumeFactory.GetReclaimableVolumesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
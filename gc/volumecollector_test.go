@@ -72,7 +72,7 @@ var _ = Describe("VolumeCollector", func() {
 				team, err = teamFactory.CreateTeam(atc.Team{Name: "some-team"})
 				Expect(err).ToNot(HaveOccurred())
 
-				build, err := team.CreateOneOffBuild()
+				build, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 
 				worker, err = workerFactory.SaveWorker(atc.Worker{
@@ -115,7 +115,7 @@ var _ = Describe("VolumeCollector", func() {
 				team, err = teamFactory.CreateTeam(atc.Team{Name: "some-team"})
 				Expect(err).ToNot(HaveOccurred())
 
-				build, err := team.CreateOneOffBuild()
+				build, err := team.CreateOneOffBuild(db.BuildTriggerCause{})
 				Expect(err).ToNot(HaveOccurred())
 
 				worker, err = workerFactory.SaveWorker(atc.Worker{
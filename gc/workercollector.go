@@ -1,22 +1,31 @@
 package gc
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db"
 )
 
 type workerCollector struct {
-	logger          lager.Logger
-	workerLifecycle db.WorkerLifecycle
+	logger                     lager.Logger
+	workerLifecycle            db.WorkerLifecycle
+	ephemeralWorkerGracePeriod time.Duration
 }
 
+// NewWorkerCollector constructs a Collector which moves workers along their
+// lifecycle. Ephemeral workers are deleted once they have been stalled for
+// longer than the grace period. Retiring workers are left to the
+// RetiringWorkerCollector.
 func NewWorkerCollector(
 	logger lager.Logger,
 	workerLifecycle db.WorkerLifecycle,
+	ephemeralWorkerGracePeriod time.Duration,
 ) Collector {
 	return &workerCollector{
-		logger:          logger,
-		workerLifecycle: workerLifecycle,
+		logger:                     logger,
+		workerLifecycle:            workerLifecycle,
+		ephemeralWorkerGracePeriod: ephemeralWorkerGracePeriod,
 	}
 }
 
@@ -26,24 +35,24 @@ func (wc *workerCollector) Run() error {
 	logger.Debug("start")
 	defer logger.Debug("done")
 
-	affected, err := wc.workerLifecycle.StallUnresponsiveWorkers()
+	affected, err := wc.workerLifecycle.DeleteUnresponsiveEphemeralWorkers(wc.ephemeralWorkerGracePeriod)
 	if err != nil {
-		logger.Error("failed-to-mark-workers-as-stalled", err)
+		logger.Error("failed-to-delete-unresponsive-ephemeral-workers", err)
 		return err
 	}
 
 	if len(affected) > 0 {
-		logger.Debug("stalled", lager.Data{"count": len(affected), "workers": affected})
+		logger.Info("deleted-ephemeral-workers", lager.Data{"count": len(affected), "workers": affected})
 	}
 
-	affected, err = wc.workerLifecycle.DeleteFinishedRetiringWorkers()
+	affected, err = wc.workerLifecycle.StallUnresponsiveWorkers()
 	if err != nil {
-		logger.Error("failed-to-delete-finished-retiring-workers", err)
+		logger.Error("failed-to-mark-workers-as-stalled", err)
 		return err
 	}
 
 	if len(affected) > 0 {
-		logger.Debug("retired", lager.Data{"count": len(affected), "workers": affected})
+		logger.Debug("stalled", lager.Data{"count": len(affected), "workers": affected})
 	}
 
 	affected, err = wc.workerLifecycle.LandFinishedLandingWorkers()
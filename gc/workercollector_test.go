@@ -5,6 +5,7 @@ import (
 	"github.com/concourse/atc/gc"
 
 	"errors"
+	"time"
 
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/onsi/ginkgo"
@@ -24,26 +25,36 @@ var _ = Describe("WorkerCollector", func() {
 		workerCollector = gc.NewWorkerCollector(
 			logger,
 			fakeWorkerLifecycle,
+			2*time.Minute,
 		)
 
+		fakeWorkerLifecycle.DeleteUnresponsiveEphemeralWorkersReturns(nil, nil)
 		fakeWorkerLifecycle.StallUnresponsiveWorkersReturns(nil, nil)
-		fakeWorkerLifecycle.DeleteFinishedRetiringWorkersReturns(nil, nil)
 		fakeWorkerLifecycle.LandFinishedLandingWorkersReturns(nil, nil)
 	})
 
 	Describe("Run", func() {
-		It("tells the worker factory to expired stalled workers", func() {
+		It("tells the worker factory to delete unresponsive ephemeral workers", func() {
 			err := workerCollector.Run()
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeWorkerLifecycle.StallUnresponsiveWorkersCallCount()).To(Equal(1))
+			Expect(fakeWorkerLifecycle.DeleteUnresponsiveEphemeralWorkersCallCount()).To(Equal(1))
+			Expect(fakeWorkerLifecycle.DeleteUnresponsiveEphemeralWorkersArgsForCall(0)).To(Equal(2 * time.Minute))
+		})
+
+		It("returns an error if deleting unresponsive ephemeral workers fails", func() {
+			returnedErr := errors.New("some-error")
+			fakeWorkerLifecycle.DeleteUnresponsiveEphemeralWorkersReturns(nil, returnedErr)
+
+			err := workerCollector.Run()
+			Expect(err).To(MatchError(returnedErr))
 		})
 
-		It("tells the worker factory to delete finished retiring workers", func() {
+		It("tells the worker factory to expired stalled workers", func() {
 			err := workerCollector.Run()
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeWorkerLifecycle.DeleteFinishedRetiringWorkersCallCount()).To(Equal(1))
+			Expect(fakeWorkerLifecycle.StallUnresponsiveWorkersCallCount()).To(Equal(1))
 		})
 
 		It("tells the worker factory to land finished landing workers", func() {
@@ -61,14 +72,6 @@ var _ = Describe("WorkerCollector", func() {
 			Expect(err).To(MatchError(returnedErr))
 		})
 
-		It("returns an error if deleting finished retiring workers fails", func() {
-			returnedErr := errors.New("some-error")
-			fakeWorkerLifecycle.DeleteFinishedRetiringWorkersReturns(nil, returnedErr)
-
-			err := workerCollector.Run()
-			Expect(err).To(MatchError(returnedErr))
-		})
-
 		It("returns an error if landing finished landing workers fails", func() {
 			returnedErr := errors.New("some-error")
 			fakeWorkerLifecycle.LandFinishedLandingWorkersReturns(nil, returnedErr)
@@ -0,0 +1,13 @@
+package imageresolver_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestImageResolver(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Image Resolver Suite")
+}
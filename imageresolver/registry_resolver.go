@@ -0,0 +1,260 @@
+package imageresolver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	dockerHubRegistry  = "registry-1.docker.io"
+	dockerHubNamespace = "library"
+
+	defaultTag = "latest"
+
+	// tokenResponseLimit bounds how much of a token response is read.
+	tokenResponseLimit = 1024 * 1024
+)
+
+// realmHosts are the hosts, other than the registry's own, trusted to issue
+// tokens for each registry.
+var realmHosts = map[string][]string{
+	dockerHubRegistry:     {"auth.docker.io"},
+	"registry.gitlab.com": {"gitlab.com"},
+}
+
+// manifestMediaTypes are accepted when resolving a tag, so that the digest
+// is that of the multi-platform index, if any, rather than of one platform's
+// manifest.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// ErrNoDigest is returned when the registry does not report the digest of
+// the manifest a tag points to.
+var ErrNoDigest = errors.New("registry did not report a digest")
+
+// ErrNoToken is returned when the registry's realm does not issue a token.
+var ErrNoToken = errors.New("registry did not issue a token")
+
+// UnsupportedChallengeError is returned when the registry requires a means of
+// authentication other than anonymous bearer tokens.
+type UnsupportedChallengeError struct {
+	Challenge string
+}
+
+func (err UnsupportedChallengeError) Error() string {
+	return fmt.Sprintf("unsupported authentication challenge: %s", err.Challenge)
+}
+
+// UntrustedRealmError is returned when the registry's challenge names a realm
+// that is not served over HTTPS by the registry or a host trusted to issue
+// its tokens, so that the registry cannot make the ATC request arbitrary
+// URLs.
+type UntrustedRealmError struct {
+	Realm string
+}
+
+func (err UntrustedRealmError) Error() string {
+	return fmt.Sprintf("untrusted token realm: %s", err.Realm)
+}
+
+// UnexpectedResponseError is returned when the registry responds with
+// anything other than 200 OK.
+type UnexpectedResponseError struct {
+	StatusCode int
+}
+
+func (err UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("registry responded with unexpected status: %d", err.StatusCode)
+}
+
+type registryResolver struct {
+	client *http.Client
+}
+
+// NewRegistryResolver constructs a Resolver which asks the image's registry
+// for the digest of the manifest its tag points to, through the OCI
+// distribution API over HTTPS. Registries are accessed anonymously, fetching
+// a bearer token first for those which require one, such as Docker Hub.
+func NewRegistryResolver(client *http.Client) Resolver {
+	return &registryResolver{
+		client: client,
+	}
+}
+
+func (resolver *registryResolver) Resolve(ctx context.Context, repository string, tag string) (string, error) {
+	if tag == "" {
+		tag = defaultTag
+	}
+
+	registry, name := parseRepository(repository)
+
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, name, tag)
+
+	resp, err := resolver.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := resolver.fetchToken(ctx, registry, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", err
+		}
+
+		resp, err = resolver.headManifest(ctx, manifestURL, token)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", UnexpectedResponseError{StatusCode: resp.StatusCode}
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", ErrNoDigest
+	}
+
+	return digest, nil
+}
+
+func (resolver *registryResolver) headManifest(ctx context.Context, manifestURL string, token string) (*http.Response, error) {
+	req, err := http.NewRequest("HEAD", manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := resolver.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body.Close()
+
+	return resp, nil
+}
+
+var challengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// fetchToken fetches an anonymous bearer token from the realm given by the
+// registry's challenge.
+func (resolver *registryResolver) fetchToken(ctx context.Context, registry string, challenge string) (string, error) {
+	if len(challenge) < len("Bearer ") || !strings.EqualFold(challenge[:len("Bearer ")], "Bearer ") {
+		return "", UnsupportedChallengeError{Challenge: challenge}
+	}
+
+	params := map[string]string{}
+	for _, match := range challengeParamRegexp.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", UnsupportedChallengeError{Challenge: challenge}
+	}
+
+	if !trustedRealm(registry, realm) {
+		return "", UntrustedRealmError{Realm: params["realm"]}
+	}
+
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequest("GET", realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := resolver.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", UnexpectedResponseError{StatusCode: resp.StatusCode}
+	}
+
+	// decoded by exact key, as decoding into a struct would also take e.g.
+	// "Token" for "token"
+	var body map[string]interface{}
+	err = json.NewDecoder(io.LimitReader(resp.Body, tokenResponseLimit)).Decode(&body)
+	if err != nil {
+		return "", err
+	}
+
+	for _, key := range []string{"token", "access_token"} {
+		if token, ok := body[key].(string); ok && token != "" {
+			return token, nil
+		}
+	}
+
+	return "", ErrNoToken
+}
+
+// trustedRealm is whether the realm is served over HTTPS by the registry
+// itself or by a host trusted to issue its tokens.
+func trustedRealm(registry string, realm *url.URL) bool {
+	if realm.Scheme != "https" || realm.User != nil {
+		return false
+	}
+
+	if realm.Host == registry {
+		return true
+	}
+
+	for _, host := range realmHosts[registry] {
+		if realm.Host == host {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseRepository splits the repository into the host of its registry and
+// the name of the image within it, defaulting to Docker Hub as docker does.
+func parseRepository(repository string) (string, string) {
+	registry := dockerHubRegistry
+	name := repository
+
+	parts := strings.SplitN(repository, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		registry, name = parts[0], parts[1]
+	}
+
+	if registry == "docker.io" || registry == "index.docker.io" {
+		registry = dockerHubRegistry
+	}
+
+	if registry == dockerHubRegistry && !strings.Contains(name, "/") {
+		name = dockerHubNamespace + "/" + name
+	}
+
+	return registry, name
+}
@@ -0,0 +1,180 @@
+package imageresolver_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/atc/imageresolver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("RegistryResolver", func() {
+	var (
+		server   *ghttp.Server
+		resolver imageresolver.Resolver
+		registry string
+
+		digest     string
+		resolveErr error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewTLSServer()
+		resolver = imageresolver.NewRegistryResolver(server.HTTPTestServer.Client())
+		registry = strings.TrimPrefix(server.URL(), "https://")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		digest, resolveErr = resolver.Resolve(context.Background(), registry+"/some/image", "some-tag")
+	})
+
+	Context("when the registry allows anonymous access", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("HEAD", "/v2/some/image/manifests/some-tag"),
+					func(w http.ResponseWriter, r *http.Request) {
+						Expect(r.Header.Get("Accept")).To(ContainSubstring("application/vnd.oci.image.index.v1+json"))
+					},
+					ghttp.RespondWith(http.StatusOK, nil, http.Header{
+						"Docker-Content-Digest": {"sha256:some-digest"},
+					}),
+				),
+			)
+		})
+
+		It("returns the digest of the manifest the tag points to", func() {
+			Expect(resolveErr).ToNot(HaveOccurred())
+			Expect(digest).To(Equal("sha256:some-digest"))
+		})
+	})
+
+	Context("when the registry requires a bearer token", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("HEAD", "/v2/some/image/manifests/some-tag"),
+					ghttp.RespondWith(http.StatusUnauthorized, nil, http.Header{
+						"Www-Authenticate": {`Bearer realm="` + server.URL() + `/token",service="some-registry",scope="repository:some/image:pull"`},
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/token", "scope=repository%3Asome%2Fimage%3Apull&service=some-registry"),
+					ghttp.RespondWith(http.StatusOK, `{"token":"some-token"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("HEAD", "/v2/some/image/manifests/some-tag"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+					ghttp.RespondWith(http.StatusOK, nil, http.Header{
+						"Docker-Content-Digest": {"sha256:some-digest"},
+					}),
+				),
+			)
+		})
+
+		It("fetches a token and tries again with it", func() {
+			Expect(resolveErr).ToNot(HaveOccurred())
+			Expect(digest).To(Equal("sha256:some-digest"))
+		})
+	})
+
+	Context("when the token is only given under a differently cased key", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusUnauthorized, nil, http.Header{
+					"Www-Authenticate": {`Bearer realm="` + server.URL() + `/token"`},
+				}),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/token"),
+					ghttp.RespondWith(http.StatusOK, `{"Token":"some-token"}`),
+				),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(resolveErr).To(Equal(imageresolver.ErrNoToken))
+		})
+	})
+
+	Context("when the realm is on another host", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusUnauthorized, nil, http.Header{
+					"Www-Authenticate": {`Bearer realm="https://169.254.169.254/latest/meta-data"`},
+				}),
+			)
+		})
+
+		It("returns an error without requesting it", func() {
+			Expect(resolveErr).To(Equal(imageresolver.UntrustedRealmError{
+				Realm: "https://169.254.169.254/latest/meta-data",
+			}))
+		})
+	})
+
+	Context("when the realm is not served over HTTPS", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusUnauthorized, nil, http.Header{
+					"Www-Authenticate": {`Bearer realm="http://` + registry + `/token"`},
+				}),
+			)
+		})
+
+		It("returns an error without requesting it", func() {
+			Expect(resolveErr).To(Equal(imageresolver.UntrustedRealmError{
+				Realm: "http://" + registry + "/token",
+			}))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("when the registry requires basic auth", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusUnauthorized, nil, http.Header{
+					"Www-Authenticate": {`Basic realm="some-registry"`},
+				}),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(resolveErr).To(Equal(imageresolver.UnsupportedChallengeError{
+				Challenge: `Basic realm="some-registry"`,
+			}))
+		})
+	})
+
+	Context("when the tag does not exist", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, nil),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(resolveErr).To(Equal(imageresolver.UnexpectedResponseError{
+				StatusCode: http.StatusNotFound,
+			}))
+		})
+	})
+
+	Context("when the registry does not report a digest", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, nil),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(resolveErr).To(Equal(imageresolver.ErrNoDigest))
+		})
+	})
+})
@@ -0,0 +1,90 @@
+package imageresolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+)
+
+//go:generate counterfeiter . Resolver
+
+// Resolver resolves the tag of an image to the digest it currently points
+// to, so that resource types can be pinned to the image they were set with
+// rather than to whatever the tag points to when they are next checked.
+type Resolver interface {
+	Resolve(ctx context.Context, repository string, tag string) (string, error)
+}
+
+// imageResourceTypes are the types of resource types whose source names an
+// image in a registry by its repository and tag.
+var imageResourceTypes = map[string]bool{
+	"docker-image":   true,
+	"registry-image": true,
+}
+
+// PinTimeout bounds how long pinning the resource types of a config may take
+// altogether, so that slow registries cannot hold up setting the pipeline.
+const PinTimeout = 30 * time.Second
+
+// PinResourceTypes returns the resource types with the digests of their
+// images set, for those which name an image in a registry and are not yet
+// pinned. A resource type whose image cannot be resolved, e.g. because its
+// source refers to credentials, is left unpinned with a warning, as are any
+// left once PinTimeout has passed.
+func PinResourceTypes(ctx context.Context, resolver Resolver, resourceTypes atc.ResourceTypes) (atc.ResourceTypes, []atc.Warning) {
+	logger := lagerctx.FromContext(ctx).Session("pin-resource-types")
+
+	ctx, cancel := context.WithTimeout(ctx, PinTimeout)
+	defer cancel()
+
+	warnings := []atc.Warning{}
+
+	pinned := make(atc.ResourceTypes, len(resourceTypes))
+	for i, resourceType := range resourceTypes {
+		pinned[i] = resourceType
+
+		if resourceType.Digest != "" || !imageResourceTypes[resourceType.Type] {
+			continue
+		}
+
+		repository, _ := resourceType.Source["repository"].(string)
+		tag, _ := resourceType.Source["tag"].(string)
+
+		if repository == "" {
+			continue
+		}
+
+		if strings.Contains(repository, "((") || strings.Contains(tag, "((") {
+			warnings = append(warnings, atc.Warning{
+				Type:    "resource_type",
+				Message: fmt.Sprintf("resource type '%s' is not pinned by digest, as its image refers to credentials", resourceType.Name),
+			})
+
+			continue
+		}
+
+		digest, err := resolver.Resolve(ctx, repository, tag)
+		if err != nil {
+			logger.Info("failed-to-resolve-digest", lager.Data{
+				"resource-type": resourceType.Name,
+				"error":         err.Error(),
+			})
+
+			warnings = append(warnings, atc.Warning{
+				Type:    "resource_type",
+				Message: fmt.Sprintf("resource type '%s' is not pinned by digest, as its image could not be resolved: %s", resourceType.Name, err),
+			})
+
+			continue
+		}
+
+		pinned[i].Digest = digest
+	}
+
+	return pinned, warnings
+}
@@ -0,0 +1,113 @@
+package imageresolver_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/imageresolver"
+	"github.com/concourse/atc/imageresolver/imageresolverfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PinResourceTypes", func() {
+	var (
+		fakeResolver  *imageresolverfakes.FakeResolver
+		resourceTypes atc.ResourceTypes
+
+		pinned   atc.ResourceTypes
+		warnings []atc.Warning
+	)
+
+	BeforeEach(func() {
+		fakeResolver = new(imageresolverfakes.FakeResolver)
+		fakeResolver.ResolveReturns("sha256:some-digest", nil)
+
+		resourceTypes = atc.ResourceTypes{
+			{
+				Name:   "some-type",
+				Type:   "docker-image",
+				Source: atc.Source{"repository": "some/image", "tag": "some-tag"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		pinned, warnings = imageresolver.PinResourceTypes(context.Background(), fakeResolver, resourceTypes)
+	})
+
+	It("pins the resource types to the digests of their images", func() {
+		Expect(fakeResolver.ResolveCallCount()).To(Equal(1))
+		_, repository, tag := fakeResolver.ResolveArgsForCall(0)
+		Expect(repository).To(Equal("some/image"))
+		Expect(tag).To(Equal("some-tag"))
+
+		Expect(pinned[0].Digest).To(Equal("sha256:some-digest"))
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("does not modify the given resource types", func() {
+		Expect(resourceTypes[0].Digest).To(BeEmpty())
+	})
+
+	Context("when the resource type is already pinned", func() {
+		BeforeEach(func() {
+			resourceTypes[0].Digest = "sha256:pinned-digest"
+		})
+
+		It("keeps its digest", func() {
+			Expect(fakeResolver.ResolveCallCount()).To(BeZero())
+			Expect(pinned[0].Digest).To(Equal("sha256:pinned-digest"))
+		})
+	})
+
+	Context("when the resource type does not name an image", func() {
+		BeforeEach(func() {
+			resourceTypes[0].Type = "some-custom-type"
+		})
+
+		It("leaves it unpinned", func() {
+			Expect(fakeResolver.ResolveCallCount()).To(BeZero())
+			Expect(pinned[0].Digest).To(BeEmpty())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("when the image refers to credentials", func() {
+		BeforeEach(func() {
+			resourceTypes[0].Source["tag"] = "((some-tag))"
+		})
+
+		It("leaves it unpinned, with a warning", func() {
+			Expect(fakeResolver.ResolveCallCount()).To(BeZero())
+			Expect(pinned[0].Digest).To(BeEmpty())
+			Expect(warnings).To(HaveLen(1))
+		})
+	})
+
+	Context("when the image cannot be resolved", func() {
+		BeforeEach(func() {
+			fakeResolver.ResolveReturns("", errors.New("nope"))
+		})
+
+		It("leaves it unpinned, with a warning", func() {
+			Expect(pinned[0].Digest).To(BeEmpty())
+			Expect(warnings).To(Equal([]atc.Warning{
+				{
+					Type:    "resource_type",
+					Message: "resource type 'some-type' is not pinned by digest, as its image could not be resolved: nope",
+				},
+			}))
+		})
+	})
+
+	It("resolves the images within a deadline", func() {
+		ctx, _, _ := fakeResolver.ResolveArgsForCall(0)
+
+		deadline, ok := ctx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", time.Now().Add(imageresolver.PinTimeout), time.Second))
+	})
+})
@@ -17,16 +17,18 @@ type Job struct {
 	Outputs []JobOutput `json:"outputs"`
 
 	Groups []string `json:"groups"`
+	Labels Labels   `json:"labels,omitempty"`
 }
 
 type JobInput struct {
-	Name     string         `json:"name"`
-	Resource string         `json:"resource"`
-	Passed   []string       `json:"passed,omitempty"`
-	Trigger  bool           `json:"trigger"`
-	Version  *VersionConfig `json:"version,omitempty"`
-	Params   Params         `json:"params,omitempty"`
-	Tags     Tags           `json:"tags,omitempty"`
+	Name      string            `json:"name"`
+	Resource  string            `json:"resource"`
+	Passed    []string          `json:"passed,omitempty"`
+	Annotated map[string]string `json:"annotated,omitempty"`
+	Trigger   bool              `json:"trigger"`
+	Version   *VersionConfig    `json:"version,omitempty"`
+	Params    Params            `json:"params,omitempty"`
+	Tags      Tags              `json:"tags,omitempty"`
 }
 
 type JobOutput struct {
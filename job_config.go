@@ -10,6 +10,7 @@ type JobConfig struct {
 	SerialGroups         []string `yaml:"serial_groups,omitempty" json:"serial_groups,omitempty" mapstructure:"serial_groups"`
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
+	Labels               Labels   `yaml:"labels,omitempty" json:"labels,omitempty" mapstructure:"labels"`
 
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
@@ -113,7 +114,7 @@ func (config JobConfig) OutputPlans() []PlanConfig {
 	var outputs []PlanConfig
 
 	for _, plan := range config.Plans() {
-		if plan.Put != "" {
+		if plan.Put != "" || plan.Promote != "" {
 			outputs = append(outputs, plan)
 		}
 	}
@@ -134,13 +135,14 @@ func (config JobConfig) Inputs() []JobInput {
 			}
 
 			inputs = append(inputs, JobInput{
-				Name:     get,
-				Resource: resource,
-				Passed:   plan.Passed,
-				Version:  plan.Version,
-				Trigger:  plan.Trigger,
-				Params:   plan.Params,
-				Tags:     plan.Tags,
+				Name:      get,
+				Resource:  resource,
+				Passed:    plan.Passed,
+				Annotated: plan.Annotated,
+				Version:   plan.Version,
+				Trigger:   plan.Trigger,
+				Params:    plan.Params,
+				Tags:      plan.Tags,
 			})
 		}
 	}
@@ -165,6 +167,13 @@ func (config JobConfig) Outputs() []JobOutput {
 				Resource: resource,
 			})
 		}
+
+		if plan.Promote != "" {
+			outputs = append(outputs, JobOutput{
+				Name:     plan.Promote,
+				Resource: plan.ResourceName(),
+			})
+		}
 	}
 
 	return outputs
@@ -129,9 +129,10 @@ var _ = Describe("JobConfig", func() {
 				BeforeEach(func() {
 					jobConfig.Plan = atc.PlanSequence{
 						{
-							Get:     "some-get-plan",
-							Passed:  []string{"a", "b"},
-							Trigger: true,
+							Get:       "some-get-plan",
+							Passed:    []string{"a", "b"},
+							Annotated: map[string]string{"qa": "passed"},
+							Trigger:   true,
 						},
 						{
 							Get: "some-other-get-plan",
@@ -142,10 +143,11 @@ var _ = Describe("JobConfig", func() {
 				It("uses both for inputs", func() {
 					Expect(inputs).To(Equal([]atc.JobInput{
 						{
-							Name:     "some-get-plan",
-							Resource: "some-get-plan",
-							Passed:   []string{"a", "b"},
-							Trigger:  true,
+							Name:      "some-get-plan",
+							Resource:  "some-get-plan",
+							Passed:    []string{"a", "b"},
+							Annotated: map[string]string{"qa": "passed"},
+							Trigger:   true,
 						},
 						{
 							Name:     "some-other-get-plan",
@@ -584,6 +586,29 @@ var _ = Describe("JobConfig", func() {
 				})
 			})
 
+			Context("when a job promotes a resource", func() {
+				BeforeEach(func() {
+					jobConfig.Plan = atc.PlanSequence{
+						{Get: "staging"},
+						{Promote: "a", From: "staging"},
+						{Promote: "b", From: "staging", Resource: "some-resource"},
+					}
+				})
+
+				It("returns an output for each promote plan", func() {
+					Expect(outputs).To(Equal([]atc.JobOutput{
+						{
+							Name:     "a",
+							Resource: "a",
+						},
+						{
+							Name:     "b",
+							Resource: "some-resource",
+						},
+					}))
+				})
+			})
+
 			Context("when a job has an ensure hook", func() {
 				BeforeEach(func() {
 					jobConfig.Plan = atc.PlanSequence{
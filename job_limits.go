@@ -0,0 +1,113 @@
+package atc
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// JobLimits are hard caps imposed by the operator on settings configured by
+// pipelines. A zero value means no cap.
+type JobLimits struct {
+	MaxStepTimeout       time.Duration
+	MaxBuildLogsToRetain int
+}
+
+// ClampedSetting records a pipeline setting which was lowered to stay within
+// the operator's limits.
+type ClampedSetting struct {
+	Setting   string
+	Requested string
+	Applied   string
+}
+
+// Clamp returns the job config with its settings lowered to the limits,
+// along with the settings that were lowered. The given config is not
+// modified.
+//
+// Step timeouts are only lowered where configured; steps without a timeout
+// are left alone. Jobs retaining every build's logs are made to retain no
+// more than the limit.
+func (limits JobLimits) Clamp(config JobConfig) (JobConfig, []ClampedSetting) {
+	var clamped []ClampedSetting
+
+	if limits.MaxBuildLogsToRetain > 0 &&
+		(config.BuildLogsToRetain == 0 || config.BuildLogsToRetain > limits.MaxBuildLogsToRetain) {
+		requested := strconv.Itoa(config.BuildLogsToRetain)
+		if config.BuildLogsToRetain == 0 {
+			requested = "unlimited"
+		}
+
+		config.BuildLogsToRetain = limits.MaxBuildLogsToRetain
+
+		clamped = append(clamped, ClampedSetting{
+			Setting:   "build_logs_to_retain",
+			Requested: requested,
+			Applied:   strconv.Itoa(limits.MaxBuildLogsToRetain),
+		})
+	}
+
+	if limits.MaxStepTimeout > 0 {
+		plan := make(PlanSequence, len(config.Plan))
+		for i, step := range config.Plan {
+			plan[i] = limits.clampStep(step, fmt.Sprintf("plan[%d]", i), &clamped)
+		}
+
+		config.Plan = plan
+
+		config.Abort = limits.clampHook(config.Abort, "on_abort", &clamped)
+		config.Failure = limits.clampHook(config.Failure, "on_failure", &clamped)
+		config.Ensure = limits.clampHook(config.Ensure, "ensure", &clamped)
+		config.Success = limits.clampHook(config.Success, "on_success", &clamped)
+	}
+
+	return config, clamped
+}
+
+func (limits JobLimits) clampStep(step PlanConfig, location string, clamped *[]ClampedSetting) PlanConfig {
+	if step.Timeout != "" {
+		timeout, err := time.ParseDuration(step.Timeout)
+		if err == nil && timeout > limits.MaxStepTimeout {
+			*clamped = append(*clamped, ClampedSetting{
+				Setting:   location + ".timeout",
+				Requested: step.Timeout,
+				Applied:   limits.MaxStepTimeout.String(),
+			})
+
+			step.Timeout = limits.MaxStepTimeout.String()
+		}
+	}
+
+	step.Do = limits.clampSequence(step.Do, location+".do", clamped)
+	step.Aggregate = limits.clampSequence(step.Aggregate, location+".aggregate", clamped)
+
+	step.Try = limits.clampHook(step.Try, location+".try", clamped)
+	step.Abort = limits.clampHook(step.Abort, location+".on_abort", clamped)
+	step.Failure = limits.clampHook(step.Failure, location+".on_failure", clamped)
+	step.Ensure = limits.clampHook(step.Ensure, location+".ensure", clamped)
+	step.Success = limits.clampHook(step.Success, location+".on_success", clamped)
+
+	return step
+}
+
+func (limits JobLimits) clampSequence(sequence *PlanSequence, location string, clamped *[]ClampedSetting) *PlanSequence {
+	if sequence == nil {
+		return nil
+	}
+
+	steps := make(PlanSequence, len(*sequence))
+	for i, step := range *sequence {
+		steps[i] = limits.clampStep(step, fmt.Sprintf("%s[%d]", location, i), clamped)
+	}
+
+	return &steps
+}
+
+func (limits JobLimits) clampHook(hook *PlanConfig, location string, clamped *[]ClampedSetting) *PlanConfig {
+	if hook == nil {
+		return nil
+	}
+
+	step := limits.clampStep(*hook, location, clamped)
+	return &step
+}
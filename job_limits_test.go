@@ -0,0 +1,128 @@
+package atc_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JobLimits", func() {
+	var (
+		limits atc.JobLimits
+		config atc.JobConfig
+
+		clampedConfig   atc.JobConfig
+		clampedSettings []atc.ClampedSetting
+	)
+
+	BeforeEach(func() {
+		limits = atc.JobLimits{}
+
+		config = atc.JobConfig{
+			Name:              "some-job",
+			BuildLogsToRetain: 20,
+			Plan: atc.PlanSequence{
+				{Get: "some-input"},
+				{
+					Task:    "some-task",
+					Timeout: "2h",
+				},
+				{
+					Do: &atc.PlanSequence{
+						{Put: "some-output", Timeout: "30m"},
+						{Task: "some-other-task", Timeout: "90m"},
+					},
+				},
+			},
+			Failure: &atc.PlanConfig{
+				Task:    "some-alert",
+				Timeout: "3h",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		clampedConfig, clampedSettings = limits.Clamp(config)
+	})
+
+	Context("when there are no limits", func() {
+		It("leaves the config alone", func() {
+			Expect(clampedConfig).To(Equal(config))
+			Expect(clampedSettings).To(BeEmpty())
+		})
+	})
+
+	Context("when the build logs to retain are limited", func() {
+		BeforeEach(func() {
+			limits.MaxBuildLogsToRetain = 10
+		})
+
+		It("lowers the build logs to retain", func() {
+			Expect(clampedConfig.BuildLogsToRetain).To(Equal(10))
+			Expect(clampedSettings).To(Equal([]atc.ClampedSetting{
+				{Setting: "build_logs_to_retain", Requested: "20", Applied: "10"},
+			}))
+		})
+
+		Context("when the job retains every build's logs", func() {
+			BeforeEach(func() {
+				config.BuildLogsToRetain = 0
+			})
+
+			It("retains no more than the limit", func() {
+				Expect(clampedConfig.BuildLogsToRetain).To(Equal(10))
+				Expect(clampedSettings).To(Equal([]atc.ClampedSetting{
+					{Setting: "build_logs_to_retain", Requested: "unlimited", Applied: "10"},
+				}))
+			})
+		})
+
+		Context("when the job retains fewer build logs than the limit", func() {
+			BeforeEach(func() {
+				config.BuildLogsToRetain = 5
+			})
+
+			It("leaves it alone", func() {
+				Expect(clampedConfig.BuildLogsToRetain).To(Equal(5))
+				Expect(clampedSettings).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("when step timeouts are limited", func() {
+		BeforeEach(func() {
+			limits.MaxStepTimeout = time.Hour
+		})
+
+		It("lowers every timeout exceeding the limit", func() {
+			Expect(clampedConfig.Plan[1].Timeout).To(Equal("1h0m0s"))
+			Expect((*clampedConfig.Plan[2].Do)[1].Timeout).To(Equal("1h0m0s"))
+			Expect(clampedConfig.Failure.Timeout).To(Equal("1h0m0s"))
+		})
+
+		It("leaves timeouts within the limit alone", func() {
+			Expect((*clampedConfig.Plan[2].Do)[0].Timeout).To(Equal("30m"))
+		})
+
+		It("does not add timeouts to steps without one", func() {
+			Expect(clampedConfig.Plan[0].Timeout).To(BeEmpty())
+		})
+
+		It("reports where each timeout was lowered", func() {
+			Expect(clampedSettings).To(Equal([]atc.ClampedSetting{
+				{Setting: "plan[1].timeout", Requested: "2h", Applied: "1h0m0s"},
+				{Setting: "plan[2].do[1].timeout", Requested: "90m", Applied: "1h0m0s"},
+				{Setting: "on_failure.timeout", Requested: "3h", Applied: "1h0m0s"},
+			}))
+		})
+
+		It("does not modify the given config", func() {
+			Expect(config.Plan[1].Timeout).To(Equal("2h"))
+			Expect((*config.Plan[2].Do)[1].Timeout).To(Equal("90m"))
+			Expect(config.Failure.Timeout).To(Equal("3h"))
+		})
+	})
+})
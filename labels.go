@@ -0,0 +1,57 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Labels are arbitrary key/value pairs set on pipelines and jobs in their
+// config, e.g. env: staging, by which they can be selected across teams and
+// pipelines.
+type Labels map[string]string
+
+// Matches returns true if the labels include every label of the selector. An
+// empty selector matches any labels.
+func (labels Labels) Matches(selector Labels) bool {
+	for key, value := range selector {
+		actual, found := labels[key]
+		if !found || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Merge returns the labels with those given set over them, e.g. a pipeline's
+// labels with those of one of its jobs.
+func (labels Labels) Merge(overrides Labels) Labels {
+	merged := Labels{}
+
+	for key, value := range labels {
+		merged[key] = value
+	}
+
+	for key, value := range overrides {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// ParseLabelSelector parses the label query parameters given to the API, each
+// of the form key=value.
+func ParseLabelSelector(selectors []string) (Labels, error) {
+	selector := Labels{}
+
+	for _, s := range selectors {
+		segs := strings.SplitN(s, "=", 2)
+		if len(segs) != 2 || segs[0] == "" {
+			return nil, fmt.Errorf("invalid label selector '%s': expected key=value", s)
+		}
+
+		selector[segs[0]] = segs[1]
+	}
+
+	return selector, nil
+}
@@ -0,0 +1,67 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Labels", func() {
+	Describe("Matches", func() {
+		labels := Labels{"env": "staging", "tier": "web"}
+
+		It("matches a selector of a subset of the labels", func() {
+			Expect(labels.Matches(Labels{"env": "staging"})).To(BeTrue())
+			Expect(labels.Matches(Labels{"env": "staging", "tier": "web"})).To(BeTrue())
+		})
+
+		It("matches an empty selector", func() {
+			Expect(labels.Matches(Labels{})).To(BeTrue())
+			Expect(Labels(nil).Matches(nil)).To(BeTrue())
+		})
+
+		It("does not match a selector with a different value", func() {
+			Expect(labels.Matches(Labels{"env": "production"})).To(BeFalse())
+		})
+
+		It("does not match a selector with a missing label", func() {
+			Expect(labels.Matches(Labels{"region": "us"})).To(BeFalse())
+		})
+	})
+
+	Describe("Merge", func() {
+		It("sets the given labels over the labels", func() {
+			labels := Labels{"env": "staging", "tier": "web"}
+
+			Expect(labels.Merge(Labels{"env": "production"})).To(Equal(Labels{
+				"env":  "production",
+				"tier": "web",
+			}))
+
+			Expect(labels).To(Equal(Labels{"env": "staging", "tier": "web"}))
+		})
+	})
+
+	Describe("ParseLabelSelector", func() {
+		It("parses key=value pairs", func() {
+			selector, err := ParseLabelSelector([]string{"env=staging", "url=a=b"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selector).To(Equal(Labels{"env": "staging", "url": "a=b"}))
+		})
+
+		It("returns an empty selector for no pairs", func() {
+			selector, err := ParseLabelSelector(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selector).To(BeEmpty())
+		})
+
+		It("errors on pairs without a key or value", func() {
+			_, err := ParseLabelSelector([]string{"env"})
+			Expect(err).To(HaveOccurred())
+
+			_, err = ParseLabelSelector([]string{"=staging"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,135 @@
+package lifecycle
+
+import (
+	"fmt"
+	"time"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"code.cloudfoundry.org/lager"
+)
+
+type EventType string
+
+const (
+	PipelineSet       EventType = "pipeline-set"
+	PipelineDestroyed EventType = "pipeline-destroyed"
+	PipelinePaused    EventType = "pipeline-paused"
+	PipelineUnpaused  EventType = "pipeline-unpaused"
+	JobPaused         EventType = "job-paused"
+	JobUnpaused       EventType = "job-unpaused"
+	ResourcePaused    EventType = "resource-paused"
+	ResourceUnpaused  EventType = "resource-unpaused"
+	BuildStarted      EventType = "build-started"
+	BuildFinished     EventType = "build-finished"
+)
+
+// Event describes a change to the configuration or state of a pipeline, or
+// to one of its builds. Only the fields relevant to the event's type are set.
+type Event struct {
+	Type EventType `json:"type"`
+	Time int64     `json:"time"`
+
+	TeamName     string `json:"team"`
+	PipelineName string `json:"pipeline,omitempty"`
+	JobName      string `json:"job,omitempty"`
+	ResourceName string `json:"resource,omitempty"`
+
+	BuildID     int    `json:"build_id,omitempty"`
+	BuildName   string `json:"build_name,omitempty"`
+	BuildStatus string `json:"build_status,omitempty"`
+}
+
+//go:generate counterfeiter . Sink
+
+type Sink interface {
+	Send(lager.Logger, Event) error
+}
+
+//go:generate counterfeiter . SinkFactory
+
+type SinkFactory interface {
+	Description() string
+	IsConfigured() bool
+	NewSink() (Sink, error)
+}
+
+var sinkFactories []SinkFactory
+
+func RegisterSink(factory SinkFactory) {
+	sinkFactories = append(sinkFactories, factory)
+}
+
+func WireSinks(group *flags.Group) {
+	for _, factory := range sinkFactories {
+		_, err := group.AddGroup(fmt.Sprintf("Lifecycle Event Sink (%s)", factory.Description()), "", factory)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+var sinks []Sink
+
+type eventPublication struct {
+	event  Event
+	logger lager.Logger
+}
+
+var publications = make(chan eventPublication, 1000)
+
+// Initialize constructs every configured sink. Unlike metric emitters, any
+// number of sinks may be configured; each event is sent to all of them.
+func Initialize(logger lager.Logger) error {
+	for _, factory := range sinkFactories {
+		if !factory.IsConfigured() {
+			continue
+		}
+
+		sink, err := factory.NewSink()
+		if err != nil {
+			return err
+		}
+
+		logger.Info("configured-sink", lager.Data{"sink": factory.Description()})
+
+		sinks = append(sinks, sink)
+	}
+
+	if len(sinks) == 0 {
+		return nil
+	}
+
+	go publishLoop()
+
+	return nil
+}
+
+// Publish queues the event to be sent to the configured sinks. It never
+// blocks; if the queue is full the event is dropped.
+func (event Event) Publish(logger lager.Logger) {
+	if len(sinks) == 0 {
+		return
+	}
+
+	event.Time = time.Now().Unix()
+
+	select {
+	case publications <- eventPublication{logger: logger, event: event}:
+	default:
+		logger.Error("queue-full", nil, lager.Data{"type": event.Type})
+	}
+}
+
+func publishLoop() {
+	for publication := range publications {
+		logger := publication.logger.Session("publish", lager.Data{"type": publication.event.Type})
+
+		for _, sink := range sinks {
+			err := sink.Send(logger, publication.event)
+			if err != nil {
+				logger.Error("failed-to-send", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package lifecycle_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/lifecycle"
+	"github.com/concourse/atc/lifecycle/lifecyclefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bus", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		configuredSinkA     *lifecyclefakes.FakeSink
+		configuredSinkB     *lifecyclefakes.FakeSink
+		unconfiguredFactory *lifecyclefakes.FakeSinkFactory
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		configuredSinkA = new(lifecyclefakes.FakeSink)
+		configuredSinkA.SendReturns(errors.New("sink a is down"))
+		configuredSinkB = new(lifecyclefakes.FakeSink)
+
+		for _, sink := range []lifecycle.Sink{configuredSinkA, configuredSinkB} {
+			factory := new(lifecyclefakes.FakeSinkFactory)
+			factory.IsConfiguredReturns(true)
+			factory.NewSinkReturns(sink, nil)
+			lifecycle.RegisterSink(factory)
+		}
+
+		unconfiguredFactory = new(lifecyclefakes.FakeSinkFactory)
+		lifecycle.RegisterSink(unconfiguredFactory)
+
+		err := lifecycle.Initialize(logger)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("sends published events to every configured sink, even if one fails", func() {
+		Expect(unconfiguredFactory.NewSinkCallCount()).To(BeZero())
+
+		lifecycle.Event{
+			Type:         lifecycle.JobPaused,
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+		}.Publish(logger)
+
+		Eventually(configuredSinkA.SendCallCount).Should(Equal(1))
+		Eventually(configuredSinkB.SendCallCount).Should(Equal(1))
+
+		_, event := configuredSinkB.SendArgsForCall(0)
+		Expect(event.Type).To(Equal(lifecycle.JobPaused))
+		Expect(event.TeamName).To(Equal("some-team"))
+		Expect(event.PipelineName).To(Equal("some-pipeline"))
+		Expect(event.JobName).To(Equal("some-job"))
+		Expect(event.Time).ToNot(BeZero())
+	})
+})
@@ -0,0 +1,48 @@
+package sink
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/lifecycle"
+	"github.com/concourse/atc/nats"
+)
+
+type NATSSink struct {
+	conn    *nats.Conn
+	subject string
+}
+
+type NATSConfig struct {
+	URL string `long:"lifecycle-nats-url" description:"NATS server to publish lifecycle events to."`
+
+	Subject string `long:"lifecycle-nats-subject" default:"concourse.lifecycle" description:"Subject prefix to publish lifecycle events under. Each event is published to <prefix>.<type>."`
+}
+
+func init() {
+	lifecycle.RegisterSink(&NATSConfig{})
+}
+
+func (config *NATSConfig) Description() string { return "NATS" }
+func (config *NATSConfig) IsConfigured() bool  { return config.URL != "" }
+
+func (config *NATSConfig) NewSink() (lifecycle.Sink, error) {
+	conn, err := nats.Connect(config.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NATSSink{
+		conn:    conn,
+		subject: config.Subject,
+	}, nil
+}
+
+func (sink *NATSSink) Send(logger lager.Logger, event lifecycle.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return sink.conn.Publish(sink.subject+"."+string(event.Type), payload)
+}
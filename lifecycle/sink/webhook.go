@@ -0,0 +1,61 @@
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/lifecycle"
+)
+
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+type WebhookConfig struct {
+	URL string `long:"lifecycle-webhook-url" description:"URL to POST lifecycle events to as JSON."`
+
+	Timeout time.Duration `long:"lifecycle-webhook-timeout" default:"10s" description:"Timeout for each request to the lifecycle webhook."`
+}
+
+func init() {
+	lifecycle.RegisterSink(&WebhookConfig{})
+}
+
+func (config *WebhookConfig) Description() string { return "Webhook" }
+func (config *WebhookConfig) IsConfigured() bool  { return config.URL != "" }
+
+func (config *WebhookConfig) NewSink() (lifecycle.Sink, error) {
+	return NewWebhookSink(config.URL, &http.Client{Timeout: config.Timeout}), nil
+}
+
+func NewWebhookSink(url string, client *http.Client) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: client,
+	}
+}
+
+func (sink *WebhookSink) Send(logger lager.Logger, event lifecycle.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sink.client.Post(sink.url, "application/json", bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package sink_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/lifecycle"
+	"github.com/concourse/atc/lifecycle/sink"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("WebhookSink", func() {
+	var (
+		server *ghttp.Server
+
+		webhookSink *sink.WebhookSink
+		event       lifecycle.Event
+		sendErr     error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		webhookSink = sink.NewWebhookSink(server.URL()+"/events", http.DefaultClient)
+
+		event = lifecycle.Event{
+			Type:         lifecycle.BuildFinished,
+			Time:         1234,
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			BuildID:      42,
+			BuildName:    "7",
+			BuildStatus:  "succeeded",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		sendErr = webhookSink.Send(lagertest.NewTestLogger("test"), event)
+	})
+
+	Context("when the webhook accepts the event", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/events"),
+					ghttp.VerifyContentType("application/json"),
+					ghttp.VerifyJSON(`{
+						"type": "build-finished",
+						"time": 1234,
+						"team": "some-team",
+						"pipeline": "some-pipeline",
+						"job": "some-job",
+						"build_id": 42,
+						"build_name": "7",
+						"build_status": "succeeded"
+					}`),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("posts the event as JSON", func() {
+			Expect(sendErr).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("when the webhook responds with an error", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusInternalServerError, nil),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(sendErr).To(MatchError("webhook responded with 500 Internal Server Error"))
+		})
+	})
+})
@@ -250,8 +250,9 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 }
 
 type dbPromMetricsCollector struct {
-	dbConns   *prometheus.Desc
-	dbQueries *prometheus.Desc
+	dbConns    *prometheus.Desc
+	dbMaxConns *prometheus.Desc
+	dbQueries  *prometheus.Desc
 }
 
 func newDBPromCollector() prometheus.Collector {
@@ -262,6 +263,12 @@ func newDBPromCollector() prometheus.Collector {
 			[]string{"dbname"},
 			nil,
 		),
+		dbMaxConns: prometheus.NewDesc(
+			"concourse_db_connections_max",
+			"Maximum number of concourse database connections each pool may open",
+			[]string{"dbname"},
+			nil,
+		),
 		// this needs to be a recent number, because it is reset every 10 seconds
 		// by the periodic metrics emitter
 		dbQueries: prometheus.NewDesc(
@@ -275,6 +282,7 @@ func newDBPromCollector() prometheus.Collector {
 
 func (c *dbPromMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.dbConns
+	ch <- c.dbMaxConns
 	ch <- c.dbQueries
 }
 
@@ -286,6 +294,16 @@ func (c *dbPromMetricsCollector) Collect(ch chan<- prometheus.Metric) {
 			float64(database.Stats().OpenConnections),
 			database.Name(),
 		)
+
+		maxConns, found := metric.DatabaseMaxConns[database.Name()]
+		if found {
+			ch <- prometheus.MustNewConstMetric(
+				c.dbMaxConns,
+				prometheus.GaugeValue,
+				float64(maxConns),
+				database.Name(),
+			)
+		}
 	}
 
 	ch <- prometheus.MustNewConstMetric(
@@ -11,25 +11,86 @@ type MetricsHandler struct {
 	Logger lager.Logger
 
 	Route   string
+	Budget  time.Duration
 	Handler http.Handler
 }
 
-func WrapHandler(logger lager.Logger, route string, handler http.Handler) http.Handler {
+// WrapHandler emits the response time of every request to the handler. A
+// request taking longer than the budget is logged as slow; a zero budget
+// disables slow request logging.
+func WrapHandler(logger lager.Logger, route string, budget time.Duration, handler http.Handler) http.Handler {
 	return MetricsHandler{
 		Logger:  logger,
 		Route:   route,
+		Budget:  budget,
 		Handler: handler,
 	}
 }
 
 func (handler MetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recorder := &statusRecorder{
+		ResponseWriter: w,
+		status:         http.StatusOK,
+	}
+
 	start := time.Now()
-	handler.Handler.ServeHTTP(w, r)
-
-	HTTPResponseTime{
-		Route:    handler.Route,
-		Path:     r.URL.Path,
-		Method:   r.Method,
-		Duration: time.Since(start),
-	}.Emit(handler.Logger)
+	handler.Handler.ServeHTTP(recorder, r)
+	duration := time.Since(start)
+
+	// rata passes route parameters in the query, so this does not read the
+	// request body
+	params := r.URL.Query()
+
+	event := HTTPResponseTime{
+		Route:      handler.Route,
+		Path:       r.URL.Path,
+		Method:     r.Method,
+		StatusCode: recorder.status,
+		Team:       params.Get(":team_name"),
+		Pipeline:   params.Get(":pipeline_name"),
+		Duration:   duration,
+		Budget:     handler.Budget,
+	}
+
+	event.Emit(handler.Logger)
+
+	if handler.Budget != 0 && duration > handler.Budget {
+		handler.Logger.Info("slow-request", lager.Data{
+			"route":    event.Route,
+			"path":     event.Path,
+			"method":   event.Method,
+			"status":   event.StatusCode,
+			"team":     event.Team,
+			"pipeline": event.Pipeline,
+			"duration": duration.String(),
+			"budget":   handler.Budget.String(),
+		})
+	}
+}
+
+// statusRecorder records the status code written to the response, while
+// still letting streaming handlers flush and notice closed connections.
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	recorder.status = status
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *statusRecorder) Flush() {
+	if flusher, ok := recorder.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (recorder *statusRecorder) CloseNotify() <-chan bool {
+	if notifier, ok := recorder.ResponseWriter.(http.CloseNotifier); ok {
+		return notifier.CloseNotify()
+	}
+
+	return make(chan bool)
 }
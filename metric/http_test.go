@@ -0,0 +1,82 @@
+package metric_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/metric"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetricsHandler", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		delay  time.Duration
+		budget time.Duration
+
+		recorder *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		delay = 0
+		budget = time.Second
+
+		recorder = httptest.NewRecorder()
+	})
+
+	JustBeforeEach(func() {
+		handler := metric.WrapHandler(logger, "some-route", budget, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(delay)
+			w.WriteHeader(http.StatusTeapot)
+		}))
+
+		request, err := http.NewRequest("GET", "/api/v1/teams/some-team/pipelines/some-pipeline?:team_name=some-team&:pipeline_name=some-pipeline", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(recorder, request)
+	})
+
+	It("passes the response through", func() {
+		Expect(recorder.Code).To(Equal(http.StatusTeapot))
+	})
+
+	It("does not log requests within the budget", func() {
+		Expect(logger.LogMessages()).To(BeEmpty())
+	})
+
+	Context("when the request exceeds the budget", func() {
+		BeforeEach(func() {
+			delay = 20 * time.Millisecond
+			budget = time.Millisecond
+		})
+
+		It("logs the request as slow, with its team and pipeline", func() {
+			Expect(logger.LogMessages()).To(Equal([]string{"test.slow-request"}))
+
+			log := logger.Logs()[0]
+			Expect(log.LogLevel).To(Equal(lager.INFO))
+			Expect(log.Data["route"]).To(Equal("some-route"))
+			Expect(log.Data["status"]).To(BeNumerically("==", http.StatusTeapot))
+			Expect(log.Data["team"]).To(Equal("some-team"))
+			Expect(log.Data["pipeline"]).To(Equal("some-pipeline"))
+		})
+
+		Context("when the budget is zero", func() {
+			BeforeEach(func() {
+				budget = 0
+			})
+
+			It("does not log the request", func() {
+				Expect(logger.LogMessages()).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package metric
 
 import (
+	"net/http"
 	"strconv"
 	"time"
 
@@ -9,6 +10,11 @@ import (
 )
 
 var Databases []db.Conn
+
+// DatabaseMaxConns is the most connections each of the Databases may open,
+// by connection name.
+var DatabaseMaxConns = map[string]int{}
+
 var DatabaseQueries = Meter(0)
 
 var ContainersCreated = Meter(0)
@@ -259,6 +265,57 @@ func (event FailedVolumesToBeGarbageCollected) Emit(logger lager.Logger) {
 	)
 }
 
+type VolumesReclaimed struct {
+	Policy  string
+	Volumes int
+}
+
+func (event VolumesReclaimed) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("gc-reclaimed-volumes"),
+		Event{
+			Name:  "volumes reclaimed",
+			Value: event.Volumes,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"policy": event.Policy,
+			},
+		},
+	)
+}
+
+type TeamCacheUsage struct {
+	TeamName           string
+	ResourceCacheBytes int64
+	TaskCacheBytes     int64
+}
+
+func (event TeamCacheUsage) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("gc-team-cache-usage"),
+		Event{
+			Name:  "team resource cache bytes",
+			Value: event.ResourceCacheBytes,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"team": event.TeamName,
+			},
+		},
+	)
+
+	emit(
+		logger.Session("gc-team-cache-usage"),
+		Event{
+			Name:  "team task cache bytes",
+			Value: event.TaskCacheBytes,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"team": event.TeamName,
+			},
+		},
+	)
+}
+
 type GarbageCollectionContainerCollectorJobDropped struct {
 	WorkerName string
 }
@@ -350,39 +407,183 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 	)
 }
 
+type StepFinished struct {
+	PipelineName string
+	JobName      string
+	BuildID      int
+	StepType     string
+	StepName     string
+	Succeeded    bool
+	Duration     time.Duration
+}
+
+func (event StepFinished) Emit(logger lager.Logger) {
+	state := EventStateOK
+	if !event.Succeeded {
+		state = EventStateWarning
+	}
+
+	emit(
+		logger.Session("step-finished"),
+		Event{
+			Name:  "step finished",
+			Value: ms(event.Duration),
+			State: state,
+			Attributes: map[string]string{
+				"pipeline":  event.PipelineName,
+				"job":       event.JobName,
+				"build_id":  strconv.Itoa(event.BuildID),
+				"step_type": event.StepType,
+				"step":      event.StepName,
+				"succeeded": strconv.FormatBool(event.Succeeded),
+			},
+		},
+	)
+}
+
+type TaskOutputSizeExceeded struct {
+	PipelineName string
+	JobName      string
+	BuildName    string
+	BuildID      int
+	OutputName   string
+	MaxSize      uint64
+	Size         uint64
+}
+
+func (event TaskOutputSizeExceeded) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("task-output-size-exceeded"),
+		Event{
+			Name:  "task output size exceeded",
+			Value: event.Size,
+			State: EventStateWarning,
+			Attributes: map[string]string{
+				"pipeline":   event.PipelineName,
+				"job":        event.JobName,
+				"build_name": event.BuildName,
+				"build_id":   strconv.Itoa(event.BuildID),
+				"output":     event.OutputName,
+				"max_size":   strconv.FormatUint(event.MaxSize, 10),
+			},
+		},
+	)
+}
+
+type NoisyNeighborsDetected struct {
+	PipelineName      string
+	JobName           string
+	StepName          string
+	WorkerName        string
+	Duration          time.Duration
+	BaselineDuration  time.Duration
+	Neighbors         int
+	BaselineNeighbors int
+}
+
+func (event NoisyNeighborsDetected) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("noisy-neighbors-detected"),
+		Event{
+			Name:  "noisy neighbors detected",
+			Value: ms(event.Duration - event.BaselineDuration),
+			State: EventStateWarning,
+			Attributes: map[string]string{
+				"pipeline":           event.PipelineName,
+				"job":                event.JobName,
+				"step":               event.StepName,
+				"worker":             event.WorkerName,
+				"neighbors":          strconv.Itoa(event.Neighbors),
+				"baseline_neighbors": strconv.Itoa(event.BaselineNeighbors),
+			},
+		},
+	)
+}
+
+type VolumeStreamed struct {
+	WorkerName string
+	Direction  string
+	Encoding   string
+	Bytes      int64
+	WireBytes  int64
+	Duration   time.Duration
+}
+
+func (event VolumeStreamed) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("volume-streamed"),
+		Event{
+			Name:  "volume streamed",
+			Value: event.WireBytes,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"worker":      event.WorkerName,
+				"direction":   event.Direction,
+				"encoding":    event.Encoding,
+				"bytes":       strconv.FormatInt(event.Bytes, 10),
+				"duration_ms": strconv.FormatFloat(ms(event.Duration), 'f', -1, 64),
+			},
+		},
+	)
+}
+
 func ms(duration time.Duration) float64 {
 	return float64(duration) / 1000000
 }
 
 type HTTPResponseTime struct {
-	Route    string
-	Path     string
-	Method   string
-	Duration time.Duration
+	Route      string
+	Path       string
+	Method     string
+	StatusCode int
+	Team       string
+	Pipeline   string
+	Duration   time.Duration
+	Budget     time.Duration
 }
 
+// defaultHTTPResponseTimeBudget is used to judge the state of responses from
+// routes without a budget of their own.
+const defaultHTTPResponseTimeBudget = 100 * time.Millisecond
+
 func (event HTTPResponseTime) Emit(logger lager.Logger) {
+	budget := event.Budget
+	if budget == 0 {
+		budget = defaultHTTPResponseTimeBudget
+	}
+
 	state := EventStateOK
 
-	if event.Duration > 100*time.Millisecond {
+	if event.Duration > budget {
 		state = EventStateWarning
 	}
 
-	if event.Duration > 1*time.Second {
+	if event.Duration > 10*budget || event.StatusCode >= http.StatusInternalServerError {
 		state = EventStateCritical
 	}
 
+	attributes := map[string]string{
+		"route":  event.Route,
+		"path":   event.Path,
+		"method": event.Method,
+		"status": strconv.Itoa(event.StatusCode),
+	}
+
+	if event.Team != "" {
+		attributes["team"] = event.Team
+	}
+
+	if event.Pipeline != "" {
+		attributes["pipeline"] = event.Pipeline
+	}
+
 	emit(
 		logger.Session("http-response-time"),
 		Event{
-			Name:  "http response time",
-			Value: ms(event.Duration),
-			State: state,
-			Attributes: map[string]string{
-				"route":  event.Route,
-				"path":   event.Path,
-				"method": event.Method,
-			},
+			Name:       "http response time",
+			Value:      ms(event.Duration),
+			State:      state,
+			Attributes: attributes,
 		},
 	)
 }
@@ -36,6 +36,28 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 						},
 					},
 				)
+
+				maxConns, found := DatabaseMaxConns[database.Name()]
+				if !found {
+					continue
+				}
+
+				state := EventStateOK
+				if database.Stats().OpenConnections >= maxConns {
+					state = EventStateWarning
+				}
+
+				emit(
+					tLog.Session("database-connections-max"),
+					Event{
+						Name:  "database connections max",
+						Value: maxConns,
+						State: state,
+						Attributes: map[string]string{
+							"ConnectionName": database.Name(),
+						},
+					},
+				)
 			}
 		}
 
@@ -31,6 +31,7 @@ var _ = Describe("Periodic emission of metrics", func() {
 		b := &dbfakes.FakeConn{}
 		b.NameReturns("B")
 		metric.Databases = []db.Conn{a, b}
+		metric.DatabaseMaxConns = map[string]int{"A": 32}
 		metric.Initialize(nil, "test", map[string]string{})
 
 		go metric.PeriodicallyEmit(lager.NewLogger("dont care"), 250*time.Millisecond)
@@ -69,5 +70,28 @@ var _ = Describe("Periodic emission of metrics", func() {
 				),
 			),
 		)
+
+		By("emits the most connections each pool may open, if limited")
+		Expect(emitter.Invocations()["Emit"]).To(
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":       Equal("database connections max"),
+						"Value":      Equal(32),
+						"Attributes": Equal(map[string]string{"ConnectionName": "A"}),
+					}),
+				),
+			),
+		)
+		Expect(emitter.Invocations()["Emit"]).ToNot(
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":       Equal("database connections max"),
+						"Attributes": Equal(map[string]string{"ConnectionName": "B"}),
+					}),
+				),
+			),
+		)
 	})
 })
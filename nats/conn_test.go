@@ -0,0 +1,225 @@
+package nats_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/concourse/atc/nats"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conn", func() {
+	var (
+		listener net.Listener
+		accepted chan *fakeClient
+
+		conn        *nats.Conn
+		reconnected chan struct{}
+		connectErr  error
+	)
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+
+		accepted = make(chan *fakeClient, 10)
+		go func() {
+			for {
+				netConn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+
+				accepted <- &fakeClient{conn: netConn, reader: bufio.NewReader(netConn)}
+			}
+		}()
+
+		reconnected = make(chan struct{}, 10)
+	})
+
+	AfterEach(func() {
+		if conn != nil {
+			conn.Close()
+		}
+
+		listener.Close()
+	})
+
+	It("introduces itself with the user and password", func() {
+		connected := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(connected)
+
+			conn, connectErr = nats.Connect("nats://some-user:some-password@"+listener.Addr().String(), nil)
+		}()
+
+		client := <-accepted
+		client.send("INFO {}")
+
+		connectLine := client.readLine()
+		Expect(connectLine).To(HavePrefix("CONNECT "))
+		Expect(strings.TrimPrefix(connectLine, "CONNECT ")).To(MatchJSON(`{
+			"verbose": false,
+			"pedantic": false,
+			"name": "atc",
+			"user": "some-user",
+			"pass": "some-password"
+		}`))
+		Expect(client.readLine()).To(Equal("PING"))
+
+		client.send("PONG")
+
+		Eventually(connected).Should(BeClosed())
+		Expect(connectErr).ToNot(HaveOccurred())
+	})
+
+	It("fails to connect if the server refuses the connection", func() {
+		connected := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(connected)
+
+			conn, connectErr = nats.Connect("nats://"+listener.Addr().String(), nil)
+		}()
+
+		client := <-accepted
+		client.send("INFO {}")
+		client.readLine()
+		client.readLine()
+		client.send("-ERR 'Authorization Violation'")
+
+		Eventually(connected).Should(BeClosed())
+		Expect(connectErr).To(MatchError("nats server refused connection: -ERR 'Authorization Violation'"))
+	})
+
+	It("rejects urls which aren't for nats", func() {
+		_, err := nats.Connect("http://127.0.0.1:4222", nil)
+		Expect(err).To(MatchError("unsupported nats url scheme: http"))
+	})
+
+	Context("once connected", func() {
+		var client *fakeClient
+
+		BeforeEach(func() {
+			connected := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(connected)
+
+				conn, connectErr = nats.Connect("nats://"+listener.Addr().String(), func() {
+					reconnected <- struct{}{}
+				})
+			}()
+
+			client = <-accepted
+			client.handshake()
+
+			Eventually(connected).Should(BeClosed())
+			Expect(connectErr).ToNot(HaveOccurred())
+		})
+
+		It("publishes messages", func() {
+			Expect(conn.Publish("some-subject", []byte("hello"))).To(Succeed())
+
+			Expect(client.readLine()).To(Equal("PUB some-subject 5"))
+			Expect(client.readLine()).To(Equal("hello"))
+		})
+
+		It("passes messages published to a subscribed subject to the handler", func() {
+			messages := make(chan string, 1)
+			_, err := conn.Subscribe("some-subject", func(data []byte) {
+				messages <- string(data)
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(client.readLine()).To(Equal("SUB some-subject 1"))
+
+			client.send("MSG some-subject 1 5")
+			client.send("hello")
+
+			Eventually(messages).Should(Receive(Equal("hello")))
+		})
+
+		It("stops passing messages on once unsubscribed", func() {
+			sub, err := conn.Subscribe("some-subject", func([]byte) {})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(client.readLine()).To(Equal("SUB some-subject 1"))
+
+			Expect(sub.Unsubscribe()).To(Succeed())
+			Expect(client.readLine()).To(Equal("UNSUB 1"))
+		})
+
+		It("answers pings", func() {
+			client.send("PING")
+			Expect(client.readLine()).To(Equal("PONG"))
+		})
+
+		Context("when the connection drops", func() {
+			BeforeEach(func() {
+				_, err := conn.Subscribe("some-subject", func([]byte) {})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.readLine()).To(Equal("SUB some-subject 1"))
+
+				client.conn.Close()
+			})
+
+			It("reconnects and resubscribes", func() {
+				var newClient *fakeClient
+				Eventually(accepted, "5s").Should(Receive(&newClient))
+				newClient.handshake()
+
+				Expect(newClient.readLine()).To(Equal("SUB some-subject 1"))
+				Eventually(reconnected).Should(Receive())
+			})
+		})
+
+		Context("once closed", func() {
+			BeforeEach(func() {
+				Expect(conn.Close()).To(Succeed())
+			})
+
+			It("fails to publish", func() {
+				Expect(conn.Publish("some-subject", nil)).To(Equal(nats.ErrClosed))
+			})
+
+			It("does not reconnect", func() {
+				Consistently(accepted, "2s").ShouldNot(Receive())
+			})
+		})
+	})
+})
+
+type fakeClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func (client *fakeClient) handshake() {
+	client.send("INFO {}")
+
+	Expect(client.readLine()).To(HavePrefix("CONNECT "))
+	Expect(client.readLine()).To(Equal("PING"))
+
+	client.send("PONG")
+}
+
+func (client *fakeClient) send(line string) {
+	_, err := fmt.Fprintf(client.conn, "%s\r\n", line)
+	Expect(err).ToNot(HaveOccurred())
+}
+
+func (client *fakeClient) readLine() string {
+	line, err := client.reader.ReadString('\n')
+	if err != io.EOF {
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return strings.TrimRight(line, "\r\n")
+}
@@ -6,6 +6,7 @@ type Pipeline struct {
 	Paused   bool         `json:"paused"`
 	Public   bool         `json:"public"`
 	Groups   GroupConfigs `json:"groups,omitempty"`
+	Labels   Labels       `json:"labels,omitempty"`
 	TeamName string       `json:"team_name"`
 }
 
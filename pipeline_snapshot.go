@@ -0,0 +1,55 @@
+package atc
+
+// PipelineSnapshot is the state of a pipeline, exported from one ATC so that
+// it can be imported into another. Only finished builds are included, and
+// without their logs.
+type PipelineSnapshot struct {
+	Config Config `json:"config"`
+	Paused bool   `json:"paused"`
+	Public bool   `json:"public"`
+
+	Resources []ResourceSnapshot `json:"resources"`
+	Jobs      []JobSnapshot      `json:"jobs"`
+}
+
+// ResourceSnapshot is a resource of a snapshotted pipeline along with its
+// versions, oldest first.
+type ResourceSnapshot struct {
+	Name     string            `json:"name"`
+	Paused   bool              `json:"paused"`
+	Versions []VersionSnapshot `json:"versions"`
+}
+
+type VersionSnapshot struct {
+	Version     Version             `json:"version"`
+	Metadata    []MetadataField     `json:"metadata,omitempty"`
+	Enabled     bool                `json:"enabled"`
+	Annotations []VersionAnnotation `json:"annotations,omitempty"`
+}
+
+// JobSnapshot is a job of a snapshotted pipeline along with its finished
+// builds, oldest first.
+type JobSnapshot struct {
+	Name   string          `json:"name"`
+	Paused bool            `json:"paused"`
+	Builds []BuildSnapshot `json:"builds"`
+}
+
+type BuildSnapshot struct {
+	Name              string      `json:"name"`
+	Status            BuildStatus `json:"status"`
+	ManuallyTriggered bool        `json:"manually_triggered,omitempty"`
+	StartTime         int64       `json:"start_time,omitempty"`
+	EndTime           int64       `json:"end_time,omitempty"`
+
+	Inputs  []BuildVersionSnapshot `json:"inputs,omitempty"`
+	Outputs []BuildVersionSnapshot `json:"outputs,omitempty"`
+}
+
+// BuildVersionSnapshot is a version of one of the pipeline's resources that a
+// build used as an input or produced as an output. Outputs have no name.
+type BuildVersionSnapshot struct {
+	Name     string  `json:"name,omitempty"`
+	Resource string  `json:"resource"`
+	Version  Version `json:"version"`
+}
@@ -28,25 +28,43 @@ type radarSchedulerFactory struct {
 	resourceFactory                   resource.ResourceFactory
 	resourceConfigCheckSessionFactory db.ResourceConfigCheckSessionFactory
 	interval                          time.Duration
+	checkTimeout                      time.Duration
+	containerExpiries                 db.ContainerOwnerExpiries
+	globalResources                   bool
 	engine                            engine.Engine
+	limits                            atc.JobLimits
+	checkQueue                        radar.CheckQueue
+	checkJitter                       float64
 }
 
 func NewRadarSchedulerFactory(
 	resourceFactory resource.ResourceFactory,
 	resourceConfigCheckSessionFactory db.ResourceConfigCheckSessionFactory,
 	interval time.Duration,
+	checkTimeout time.Duration,
+	containerExpiries db.ContainerOwnerExpiries,
+	globalResources bool,
 	engine engine.Engine,
+	limits atc.JobLimits,
+	checkQueue radar.CheckQueue,
+	checkJitter float64,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
 		resourceFactory:                   resourceFactory,
 		resourceConfigCheckSessionFactory: resourceConfigCheckSessionFactory,
-		interval: interval,
-		engine:   engine,
+		interval:                          interval,
+		checkTimeout:                      checkTimeout,
+		containerExpiries:                 containerExpiries,
+		globalResources:                   globalResources,
+		engine:                            engine,
+		limits:                            limits,
+		checkQueue:                        checkQueue,
+		checkJitter:                       checkJitter,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(dbPipeline db.Pipeline, externalURL string, variables creds.Variables) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.resourceFactory, rsf.resourceConfigCheckSessionFactory, rsf.interval, dbPipeline, clock.NewClock(), externalURL, variables)
+	return radar.NewScanRunnerFactory(rsf.resourceFactory, rsf.resourceConfigCheckSessionFactory, rsf.interval, rsf.checkTimeout, rsf.containerExpiries, rsf.globalResources, dbPipeline, clock.NewClock(), externalURL, variables, rsf.checkQueue, rsf.checkJitter)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, externalURL string, variables creds.Variables) scheduler.BuildScheduler {
@@ -56,6 +74,8 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, externalU
 		rsf.resourceFactory,
 		rsf.resourceConfigCheckSessionFactory,
 		rsf.interval,
+		rsf.checkTimeout,
+		rsf.containerExpiries,
 		pipeline,
 		externalURL,
 		variables,
@@ -66,6 +86,9 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, externalU
 		rsf.resourceFactory,
 		rsf.resourceConfigCheckSessionFactory,
 		rsf.interval,
+		rsf.checkTimeout,
+		rsf.containerExpiries,
+		rsf.globalResources,
 		pipeline,
 		externalURL,
 		variables,
@@ -89,6 +112,7 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, externalU
 			scanner,
 			inputMapper,
 			rsf.engine,
+			rsf.limits,
 		),
 		Scanner: scanner,
 	}
@@ -4,6 +4,12 @@ type Plan struct {
 	ID       PlanID `json:"id"`
 	Attempts []int  `json:"attempts,omitempty"`
 
+	// only applies to Do plans; run the remaining steps after one fails
+	ContinueOnFailure bool `json:"continue_on_failure,omitempty"`
+
+	// how build viewers present the step configured with it
+	Display *StepDisplay `json:"display,omitempty"`
+
 	Aggregate *AggregatePlan `json:"aggregate,omitempty"`
 	Do        *DoPlan        `json:"do,omitempty"`
 	Get       *GetPlan       `json:"get,omitempty"`
@@ -16,6 +22,12 @@ type Plan struct {
 	Try       *TryPlan       `json:"try,omitempty"`
 	Timeout   *TimeoutPlan   `json:"timeout,omitempty"`
 	Retry     *RetryPlan     `json:"retry,omitempty"`
+	WaitFor   *WaitForPlan   `json:"wait_for,omitempty"`
+	Promote   *PromotePlan   `json:"promote,omitempty"`
+	SetVar    *SetVarPlan    `json:"set_var,omitempty"`
+
+	SaveCache    *SaveCachePlan    `json:"save_cache,omitempty"`
+	RestoreCache *RestoreCachePlan `json:"restore_cache,omitempty"`
 
 	// used for 'fly execute'
 	UserArtifact   *UserArtifactPlan   `json:"user_artifact,omitempty"`
@@ -77,6 +89,7 @@ type GetPlan struct {
 	Version     *Version `json:"version,omitempty"`
 	VersionFrom *PlanID  `json:"version_from,omitempty"`
 	Tags        Tags     `json:"tags,omitempty"`
+	Path        string   `json:"path,omitempty"`
 
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
@@ -105,12 +118,62 @@ type TaskPlan struct {
 	InputMapping      map[string]string `json:"input_mapping,omitempty"`
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
+	ReuseContainer    bool              `json:"reuse_container,omitempty"`
 
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
 type RetryPlan []Plan
 
+type SaveCachePlan struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+type RestoreCachePlan struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+type WaitForPlan struct {
+	Name     string `json:"name"`
+	Interval string `json:"interval,omitempty"`
+
+	// set when polling a resource's check
+	Type     string `json:"type,omitempty"`
+	Resource string `json:"resource,omitempty"`
+	Source   Source `json:"source,omitempty"`
+	Tags     Tags   `json:"tags,omitempty"`
+
+	// set when polling an HTTP endpoint
+	HTTP *WaitForHTTPConfig `json:"http,omitempty"`
+
+	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
+}
+
+type PromotePlan struct {
+	Name     string `json:"name,omitempty"`
+	Type     string `json:"type"`
+	Resource string `json:"resource"`
+	Source   Source `json:"source"`
+	Params   Params `json:"params,omitempty"`
+	Tags     Tags   `json:"tags,omitempty"`
+
+	// the resource and version being promoted
+	FromType    string   `json:"from_type"`
+	FromName    string   `json:"from"`
+	FromSource  Source   `json:"from_source"`
+	FromParams  Params   `json:"from_params,omitempty"`
+	FromVersion *Version `json:"from_version,omitempty"`
+
+	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
+}
+
+type SetVarPlan struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
 type DependentGetPlan struct {
 	Type     string `json:"type"`
 	Name     string `json:"name,omitempty"`
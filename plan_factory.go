@@ -49,6 +49,16 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Timeout = &t
 	case RetryPlan:
 		plan.Retry = &t
+	case WaitForPlan:
+		plan.WaitFor = &t
+	case PromotePlan:
+		plan.Promote = &t
+	case SetVarPlan:
+		plan.SetVar = &t
+	case SaveCachePlan:
+		plan.SaveCache = &t
+	case RestoreCachePlan:
+		plan.RestoreCache = &t
 	case UserArtifactPlan:
 		plan.UserArtifact = &t
 	case ArtifactOutputPlan:
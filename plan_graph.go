@@ -0,0 +1,48 @@
+package atc
+
+// PlanGraph is a build plan as a directed graph of its steps, for rendering.
+type PlanGraph struct {
+	Nodes []PlanGraphNode `json:"nodes"`
+	Edges []PlanGraphEdge `json:"edges"`
+}
+
+// PlanGraphNode is one plan of a PlanGraph, either a step such as a get or a
+// task, or a plan composing others such as a do or a hook.
+type PlanGraphNode struct {
+	ID   PlanID `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+
+	Display *StepDisplay `json:"display,omitempty"`
+}
+
+type PlanGraphEdgeType string
+
+const (
+	// PlanGraphEdgeStep leads from a plan to each plan it is composed of.
+	PlanGraphEdgeStep PlanGraphEdgeType = "step"
+
+	// PlanGraphEdgeNext leads from a step of a do plan to the one run after
+	// it.
+	PlanGraphEdgeNext PlanGraphEdgeType = "next"
+
+	// PlanGraphEdgeAttempt leads from a retry plan to each of its attempts, in
+	// order.
+	PlanGraphEdgeAttempt PlanGraphEdgeType = "attempt"
+
+	// the hook edges lead from a step to the hook run after it
+	PlanGraphEdgeOnSuccess PlanGraphEdgeType = "on_success"
+	PlanGraphEdgeOnFailure PlanGraphEdgeType = "on_failure"
+	PlanGraphEdgeOnAbort   PlanGraphEdgeType = "on_abort"
+	PlanGraphEdgeEnsure    PlanGraphEdgeType = "ensure"
+
+	// PlanGraphEdgeVersionFrom leads from a put to the get fetching the
+	// version it created.
+	PlanGraphEdgeVersionFrom PlanGraphEdgeType = "version_from"
+)
+
+type PlanGraphEdge struct {
+	From PlanID            `json:"from"`
+	To   PlanID            `json:"to"`
+	Type PlanGraphEdgeType `json:"type"`
+}
@@ -0,0 +1,193 @@
+package atc
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// StructuralPlanIDs returns a copy of the plan with the ID of each of its
+// plans derived from where it is in the plan, rather than from the order in
+// which the plans were constructed. Every build of the same config therefore
+// has the same plan IDs, so that its steps can be correlated between builds.
+//
+// A plan's ID is derived from the ID of the plan composing it together with
+// the step's name, e.g. "get:repo" or "task:unit", or its role, e.g. the hook
+// of an on_success or the attempt of a retry. Steps of a do or an aggregate
+// are told apart by name rather than by position, so adding or removing a
+// step leaves the IDs of the others as they were; only steps with the same
+// name, or unnamed steps of the same kind, are numbered in order.
+//
+// The root plan's ID is derived from root. Gets fetching the version created
+// by a put refer to its new ID.
+func StructuralPlanIDs(plan Plan, root string) Plan {
+	assigner := &planIDAssigner{
+		ids: map[PlanID]PlanID{},
+	}
+
+	plan = assigner.assign(plan, root)
+
+	for _, get := range assigner.dependentGets {
+		id, found := assigner.ids[*get.VersionFrom]
+		if found {
+			get.VersionFrom = &id
+		}
+	}
+
+	return plan
+}
+
+type planIDAssigner struct {
+	// the new ID of each plan by its old one
+	ids map[PlanID]PlanID
+
+	// gets whose VersionFrom is to be updated once every plan has its new ID
+	dependentGets []*GetPlan
+}
+
+func (assigner *planIDAssigner) assign(plan Plan, path string) Plan {
+	id := structuralPlanID(path)
+	assigner.ids[plan.ID] = id
+	plan.ID = id
+
+	switch {
+	case plan.Aggregate != nil:
+		steps := AggregatePlan(assigner.assignSteps(*plan.Aggregate, path))
+		plan.Aggregate = &steps
+
+	case plan.Do != nil:
+		steps := DoPlan(assigner.assignSteps(*plan.Do, path))
+		plan.Do = &steps
+
+	case plan.Retry != nil:
+		attempts := make(RetryPlan, len(*plan.Retry))
+		for i, attempt := range *plan.Retry {
+			attempts[i] = assigner.assign(attempt, fmt.Sprintf("%s/attempt:%d", path, i+1))
+		}
+
+		plan.Retry = &attempts
+
+	case plan.OnSuccess != nil:
+		hook := *plan.OnSuccess
+		hook.Step = assigner.assign(hook.Step, path+"/step")
+		hook.Next = assigner.assign(hook.Next, path+"/on_success")
+		plan.OnSuccess = &hook
+
+	case plan.OnFailure != nil:
+		hook := *plan.OnFailure
+		hook.Step = assigner.assign(hook.Step, path+"/step")
+		hook.Next = assigner.assign(hook.Next, path+"/on_failure")
+		plan.OnFailure = &hook
+
+	case plan.OnAbort != nil:
+		hook := *plan.OnAbort
+		hook.Step = assigner.assign(hook.Step, path+"/step")
+		hook.Next = assigner.assign(hook.Next, path+"/on_abort")
+		plan.OnAbort = &hook
+
+	case plan.Ensure != nil:
+		hook := *plan.Ensure
+		hook.Step = assigner.assign(hook.Step, path+"/step")
+		hook.Next = assigner.assign(hook.Next, path+"/ensure")
+		plan.Ensure = &hook
+
+	case plan.Try != nil:
+		try := *plan.Try
+		try.Step = assigner.assign(try.Step, path+"/step")
+		plan.Try = &try
+
+	case plan.Timeout != nil:
+		timeout := *plan.Timeout
+		timeout.Step = assigner.assign(timeout.Step, path+"/step")
+		plan.Timeout = &timeout
+
+	case plan.Get != nil:
+		get := *plan.Get
+		plan.Get = &get
+
+		if get.VersionFrom != nil {
+			assigner.dependentGets = append(assigner.dependentGets, plan.Get)
+		}
+	}
+
+	return plan
+}
+
+func (assigner *planIDAssigner) assignSteps(steps []Plan, path string) []Plan {
+	assigned := make([]Plan, len(steps))
+
+	seen := map[string]int{}
+	for i, step := range steps {
+		key := structuralPlanKey(step)
+
+		seen[key]++
+		if seen[key] > 1 {
+			key = fmt.Sprintf("%s#%d", key, seen[key])
+		}
+
+		assigned[i] = assigner.assign(step, path+"/"+key)
+	}
+
+	return assigned
+}
+
+// structuralPlanKey identifies a step among the others of a do or an
+// aggregate. Hooks, tries, timeouts and retries are identified by the step
+// they wrap.
+func structuralPlanKey(plan Plan) string {
+	switch {
+	case plan.Aggregate != nil:
+		return "aggregate"
+	case plan.Do != nil:
+		return "do"
+	case plan.Retry != nil:
+		if len(*plan.Retry) == 0 {
+			return "retry"
+		}
+
+		return "retry(" + structuralPlanKey((*plan.Retry)[0]) + ")"
+	case plan.OnSuccess != nil:
+		return "on_success(" + structuralPlanKey(plan.OnSuccess.Step) + ")"
+	case plan.OnFailure != nil:
+		return "on_failure(" + structuralPlanKey(plan.OnFailure.Step) + ")"
+	case plan.OnAbort != nil:
+		return "on_abort(" + structuralPlanKey(plan.OnAbort.Step) + ")"
+	case plan.Ensure != nil:
+		return "ensure(" + structuralPlanKey(plan.Ensure.Step) + ")"
+	case plan.Try != nil:
+		return "try(" + structuralPlanKey(plan.Try.Step) + ")"
+	case plan.Timeout != nil:
+		return "timeout(" + structuralPlanKey(plan.Timeout.Step) + ")"
+	case plan.Get != nil:
+		return "get:" + plan.Get.Name
+	case plan.Put != nil:
+		return "put:" + plan.Put.Name
+	case plan.Task != nil:
+		return "task:" + plan.Task.Name
+	case plan.WaitFor != nil:
+		return "wait_for:" + plan.WaitFor.Name
+	case plan.Promote != nil:
+		return "promote:" + plan.Promote.Name
+	case plan.SetVar != nil:
+		return "set_var:" + plan.SetVar.Name
+	case plan.SaveCache != nil:
+		return "save_cache:" + plan.SaveCache.Name
+	case plan.RestoreCache != nil:
+		return "restore_cache:" + plan.RestoreCache.Name
+	case plan.UserArtifact != nil:
+		return "user_artifact:" + plan.UserArtifact.Name
+	case plan.ArtifactOutput != nil:
+		return "artifact_output:" + plan.ArtifactOutput.Name
+	case plan.DependentGet != nil:
+		return "dependent_get:" + plan.DependentGet.Name
+	}
+
+	return ""
+}
+
+// structuralPlanID hashes the path to a plan, keeping IDs short and safe to
+// use in URLs whatever the steps are named.
+func structuralPlanID(path string) PlanID {
+	hash := fnv.New64a()
+	hash.Write([]byte(path))
+	return PlanID(fmt.Sprintf("%x", hash.Sum64()))
+}
@@ -4,7 +4,8 @@ import "encoding/json"
 
 func (plan Plan) Public() *json.RawMessage {
 	var public struct {
-		ID PlanID `json:"id"`
+		ID      PlanID       `json:"id"`
+		Display *StepDisplay `json:"display,omitempty"`
 
 		Aggregate      *json.RawMessage `json:"aggregate,omitempty"`
 		Do             *json.RawMessage `json:"do,omitempty"`
@@ -19,11 +20,17 @@ func (plan Plan) Public() *json.RawMessage {
 		DependentGet   *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout        *json.RawMessage `json:"timeout,omitempty"`
 		Retry          *json.RawMessage `json:"retry,omitempty"`
+		WaitFor        *json.RawMessage `json:"wait_for,omitempty"`
+		Promote        *json.RawMessage `json:"promote,omitempty"`
+		SetVar         *json.RawMessage `json:"set_var,omitempty"`
+		SaveCache      *json.RawMessage `json:"save_cache,omitempty"`
+		RestoreCache   *json.RawMessage `json:"restore_cache,omitempty"`
 		UserArtifact   *json.RawMessage `json:"user_artifact,omitempty"`
 		ArtifactOutput *json.RawMessage `json:"artifact_output,omitempty"`
 	}
 
 	public.ID = plan.ID
+	public.Display = plan.Display
 
 	if plan.Aggregate != nil {
 		public.Aggregate = plan.Aggregate.Public()
@@ -73,6 +80,26 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Retry = plan.Retry.Public()
 	}
 
+	if plan.WaitFor != nil {
+		public.WaitFor = plan.WaitFor.Public()
+	}
+
+	if plan.Promote != nil {
+		public.Promote = plan.Promote.Public()
+	}
+
+	if plan.SetVar != nil {
+		public.SetVar = plan.SetVar.Public()
+	}
+
+	if plan.SaveCache != nil {
+		public.SaveCache = plan.SaveCache.Public()
+	}
+
+	if plan.RestoreCache != nil {
+		public.RestoreCache = plan.RestoreCache.Public()
+	}
+
 	if plan.UserArtifact != nil {
 		public.UserArtifact = plan.UserArtifact.Public()
 	}
@@ -224,6 +251,52 @@ func (plan RetryPlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan WaitForPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name     string `json:"name"`
+		Type     string `json:"type,omitempty"`
+		Resource string `json:"resource,omitempty"`
+		HTTP     bool   `json:"http,omitempty"`
+	}{
+		Name:     plan.Name,
+		Type:     plan.Type,
+		Resource: plan.Resource,
+		HTTP:     plan.HTTP != nil,
+	})
+}
+
+func (plan SetVarPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
+func (plan PromotePlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name     string `json:"name,omitempty"`
+		Type     string `json:"type"`
+		Resource string `json:"resource"`
+		FromType string `json:"from_type"`
+		FromName string `json:"from"`
+	}{
+		Name:     plan.Name,
+		Type:     plan.Type,
+		Resource: plan.Resource,
+		FromType: plan.FromType,
+		FromName: plan.FromName,
+	})
+}
+
+func (plan SaveCachePlan) Public() *json.RawMessage {
+	return enc(plan)
+}
+
+func (plan RestoreCachePlan) Public() *json.RawMessage {
+	return enc(plan)
+}
+
 func (plan UserArtifactPlan) Public() *json.RawMessage {
 	return enc(plan)
 }
@@ -0,0 +1,205 @@
+package radar
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+)
+
+// CheckPriority orders the checks waiting in a CheckQueue.
+type CheckPriority int
+
+const (
+	// CheckPriorityRoutine is for checks run on the resource's interval.
+	CheckPriorityRoutine CheckPriority = iota
+
+	// CheckPriorityBlocking is for checks of resources which a pending build
+	// is waiting on for a version of one of its inputs.
+	CheckPriorityBlocking
+)
+
+//go:generate counterfeiter . CheckPrioritizer
+
+// CheckPrioritizer decides the priority of a resource's check as it is
+// queued.
+type CheckPrioritizer interface {
+	CheckPriority(resourceName string) CheckPriority
+}
+
+type routineChecks struct{}
+
+func (routineChecks) CheckPriority(string) CheckPriority {
+	return CheckPriorityRoutine
+}
+
+//go:generate counterfeiter . CheckQueue
+
+// CheckQueue limits how many interval checks are run at once across all
+// pipelines. While checks are backed up, those of a higher priority are
+// started first, and checks of the same priority in the order they were
+// queued.
+type CheckQueue interface {
+	// Acquire waits for the check to be started, and returns a function to
+	// call once it has finished. It returns false if the context is done
+	// first.
+	Acquire(ctx context.Context, priority CheckPriority) (func(), bool)
+}
+
+// NewCheckQueue constructs a CheckQueue running at most maxInFlight checks at
+// once. Zero means no limit, in which case checks never wait.
+func NewCheckQueue(maxInFlight int) CheckQueue {
+	return &checkQueue{
+		maxInFlight: maxInFlight,
+		waiting:     map[CheckPriority][]chan struct{}{},
+	}
+}
+
+type checkQueue struct {
+	maxInFlight int
+
+	inFlight int
+	waiting  map[CheckPriority][]chan struct{}
+	lock     sync.Mutex
+}
+
+func (queue *checkQueue) Acquire(ctx context.Context, priority CheckPriority) (func(), bool) {
+	if queue.maxInFlight == 0 {
+		return func() {}, true
+	}
+
+	queue.lock.Lock()
+
+	if queue.inFlight < queue.maxInFlight && queue.waiters() == 0 {
+		queue.inFlight++
+		queue.lock.Unlock()
+		return queue.release, true
+	}
+
+	started := make(chan struct{})
+	queue.waiting[priority] = append(queue.waiting[priority], started)
+
+	queue.lock.Unlock()
+
+	select {
+	case <-started:
+		return queue.release, true
+
+	case <-ctx.Done():
+		queue.lock.Lock()
+		defer queue.lock.Unlock()
+
+		if !queue.dequeue(priority, started) {
+			// the check was started as the context was done; pass its slot on
+			queue.releaseLocked()
+		}
+
+		return nil, false
+	}
+}
+
+func (queue *checkQueue) release() {
+	queue.lock.Lock()
+	queue.releaseLocked()
+	queue.lock.Unlock()
+}
+
+// releaseLocked hands the finished check's slot to the next check waiting,
+// if any.
+func (queue *checkQueue) releaseLocked() {
+	for _, priority := range []CheckPriority{CheckPriorityBlocking, CheckPriorityRoutine} {
+		waiting := queue.waiting[priority]
+		if len(waiting) == 0 {
+			continue
+		}
+
+		close(waiting[0])
+		queue.waiting[priority] = waiting[1:]
+
+		return
+	}
+
+	queue.inFlight--
+}
+
+func (queue *checkQueue) dequeue(priority CheckPriority, started chan struct{}) bool {
+	waiting := queue.waiting[priority]
+	for i, ch := range waiting {
+		if ch == started {
+			queue.waiting[priority] = append(waiting[:i:i], waiting[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (queue *checkQueue) waiters() int {
+	count := 0
+	for _, waiting := range queue.waiting {
+		count += len(waiting)
+	}
+
+	return count
+}
+
+// RateLimitChecks constructs a CheckQueue which starts checks no more than
+// checksPerSecond at a time, spacing them out evenly. Each check waits for its
+// turn in the rate before waiting in the given queue, so that checks held
+// back by the rate don't take up its slots. Zero means no limit, in which
+// case the queue is returned as-is.
+func RateLimitChecks(queue CheckQueue, clock clock.Clock, checksPerSecond float64) CheckQueue {
+	if checksPerSecond <= 0 {
+		return queue
+	}
+
+	return &rateLimitedCheckQueue{
+		CheckQueue: queue,
+		clock:      clock,
+		spacing:    time.Duration(float64(time.Second) / checksPerSecond),
+	}
+}
+
+type rateLimitedCheckQueue struct {
+	CheckQueue
+
+	clock   clock.Clock
+	spacing time.Duration
+
+	next time.Time
+	lock sync.Mutex
+}
+
+func (queue *rateLimitedCheckQueue) Acquire(ctx context.Context, priority CheckPriority) (func(), bool) {
+	delay := queue.reserve()
+	if delay > 0 {
+		timer := queue.clock.NewTimer(delay)
+
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, false
+		}
+	}
+
+	return queue.CheckQueue.Acquire(ctx, priority)
+}
+
+// reserve takes the next free start time, returning how long to wait until
+// it.
+func (queue *rateLimitedCheckQueue) reserve() time.Duration {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	now := queue.clock.Now()
+	if queue.next.Before(now) {
+		queue.next = now
+	}
+
+	delay := queue.next.Sub(now)
+	queue.next = queue.next.Add(queue.spacing)
+
+	return delay
+}
@@ -0,0 +1,203 @@
+package radar_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+
+	. "github.com/concourse/atc/radar"
+	"github.com/concourse/atc/radar/radarfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckQueue", func() {
+	var (
+		queue CheckQueue
+		ctx   context.Context
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	acquire := func(ctx context.Context, priority CheckPriority, started chan<- CheckPriority) <-chan func() {
+		releases := make(chan func(), 1)
+
+		go func() {
+			defer GinkgoRecover()
+
+			release, ok := queue.Acquire(ctx, priority)
+			if ok {
+				started <- priority
+				releases <- release
+			}
+
+			close(releases)
+		}()
+
+		return releases
+	}
+
+	Context("with no limit", func() {
+		BeforeEach(func() {
+			queue = NewCheckQueue(0)
+		})
+
+		It("never makes checks wait", func() {
+			for i := 0; i < 10; i++ {
+				release, started := queue.Acquire(ctx, CheckPriorityRoutine)
+				Expect(started).To(BeTrue())
+				defer release()
+			}
+		})
+	})
+
+	Context("with a limit", func() {
+		var (
+			release func()
+			started chan CheckPriority
+		)
+
+		BeforeEach(func() {
+			queue = NewCheckQueue(1)
+			started = make(chan CheckPriority, 10)
+
+			var ok bool
+			release, ok = queue.Acquire(ctx, CheckPriorityRoutine)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("makes checks wait until one finishes", func() {
+			acquire(ctx, CheckPriorityRoutine, started)
+			Consistently(started).ShouldNot(Receive())
+
+			release()
+			Eventually(started).Should(Receive())
+		})
+
+		It("starts blocking checks before routine ones", func() {
+			routine := acquire(ctx, CheckPriorityRoutine, started)
+			Consistently(started).ShouldNot(Receive())
+
+			blocking := acquire(ctx, CheckPriorityBlocking, started)
+			Consistently(started).ShouldNot(Receive())
+
+			release()
+			Eventually(started).Should(Receive(Equal(CheckPriorityBlocking)))
+			Consistently(started).ShouldNot(Receive())
+
+			(<-blocking)()
+			Eventually(started).Should(Receive(Equal(CheckPriorityRoutine)))
+
+			(<-routine)()
+		})
+
+		Context("when the context of a waiting check is done", func() {
+			It("gives up waiting and does not take a slot", func() {
+				waitCtx, cancel := context.WithCancel(ctx)
+
+				abandoned := acquire(waitCtx, CheckPriorityBlocking, started)
+				Consistently(started).ShouldNot(Receive())
+
+				cancel()
+				Eventually(abandoned).Should(BeClosed())
+
+				acquire(ctx, CheckPriorityRoutine, started)
+
+				release()
+				Eventually(started).Should(Receive(Equal(CheckPriorityRoutine)))
+			})
+		})
+	})
+
+	Context("when rate limited", func() {
+		var (
+			fakeClock      *fakeclock.FakeClock
+			fakeCheckQueue *radarfakes.FakeCheckQueue
+			released       chan struct{}
+			started        chan CheckPriority
+		)
+
+		BeforeEach(func() {
+			fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+			released = make(chan struct{}, 10)
+			fakeCheckQueue = new(radarfakes.FakeCheckQueue)
+			fakeCheckQueue.AcquireReturns(func() { released <- struct{}{} }, true)
+
+			started = make(chan CheckPriority, 10)
+
+			queue = RateLimitChecks(fakeCheckQueue, fakeClock, 2)
+		})
+
+		It("starts the first check right away", func() {
+			_, ok := queue.Acquire(ctx, CheckPriorityRoutine)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("spaces out the checks started after it", func() {
+			_, ok := queue.Acquire(ctx, CheckPriorityRoutine)
+			Expect(ok).To(BeTrue())
+
+			acquire(ctx, CheckPriorityRoutine, started)
+			acquire(ctx, CheckPriorityRoutine, started)
+
+			fakeClock.WaitForNWatchersAndIncrement(500*time.Millisecond, 2)
+			Eventually(started).Should(Receive())
+			Consistently(started).ShouldNot(Receive())
+
+			fakeClock.Increment(500 * time.Millisecond)
+			Eventually(started).Should(Receive())
+		})
+
+		It("waits its turn in the given queue after its turn in the rate", func() {
+			fakeCheckQueue.AcquireReturns(nil, false)
+
+			_, ok := queue.Acquire(ctx, CheckPriorityBlocking)
+			Expect(ok).To(BeFalse())
+
+			Expect(fakeCheckQueue.AcquireCallCount()).To(Equal(1))
+			_, priority := fakeCheckQueue.AcquireArgsForCall(0)
+			Expect(priority).To(Equal(CheckPriorityBlocking))
+		})
+
+		It("does not take a slot in the given queue while spaced out", func() {
+			_, ok := queue.Acquire(ctx, CheckPriorityRoutine)
+			Expect(ok).To(BeTrue())
+			Expect(fakeCheckQueue.AcquireCallCount()).To(Equal(1))
+
+			acquire(ctx, CheckPriorityRoutine, started)
+			fakeClock.WaitForWatcherAndIncrement(0)
+			Consistently(fakeCheckQueue.AcquireCallCount).Should(Equal(1))
+
+			fakeClock.Increment(500 * time.Millisecond)
+			Eventually(started).Should(Receive())
+			Expect(fakeCheckQueue.AcquireCallCount()).To(Equal(2))
+		})
+
+		Context("when the context of a spaced out check is done", func() {
+			It("gives up waiting without taking a slot in the given queue", func() {
+				_, ok := queue.Acquire(ctx, CheckPriorityRoutine)
+				Expect(ok).To(BeTrue())
+
+				waitCtx, cancel := context.WithCancel(ctx)
+
+				abandoned := acquire(waitCtx, CheckPriorityRoutine, started)
+				fakeClock.WaitForWatcherAndIncrement(0)
+
+				cancel()
+				Eventually(abandoned).Should(BeClosed())
+				Expect(started).ToNot(Receive())
+				Expect(fakeCheckQueue.AcquireCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("with no limit", func() {
+			It("returns the given queue", func() {
+				Expect(RateLimitChecks(fakeCheckQueue, fakeClock, 0)).To(BeIdenticalTo(fakeCheckQueue))
+			})
+		})
+	})
+})
@@ -2,6 +2,7 @@ package radar
 
 import (
 	"context"
+	"math/rand"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -14,29 +15,51 @@ type IntervalRunner interface {
 }
 
 type intervalRunner struct {
-	logger  lager.Logger
-	clock   clock.Clock
-	name    string
-	scanner Scanner
+	logger      lager.Logger
+	clock       clock.Clock
+	name        string
+	scanner     Scanner
+	queue       CheckQueue
+	prioritizer CheckPrioritizer
+
+	defaultInterval time.Duration
+	jitterFraction  float64
 }
 
+// NewIntervalRunner constructs a runner which scans on the interval returned
+// by each scan, waiting its turn in the queue with the priority decided by
+// the prioritizer each time. Each interval is lengthened by up to the jitter
+// fraction of it at random, so that resources with the same interval drift
+// apart rather than all checking at once. The first scan is likewise delayed
+// by up to the jitter fraction of the default interval, so that the runners
+// started together on startup or when a pipeline is configured don't all
+// check at once either.
 func NewIntervalRunner(
 	logger lager.Logger,
 	clock clock.Clock,
 	name string,
 	scanner Scanner,
+	queue CheckQueue,
+	prioritizer CheckPrioritizer,
+	defaultInterval time.Duration,
+	jitter float64,
 ) IntervalRunner {
 	return &intervalRunner{
-		logger:  logger,
-		clock:   clock,
-		name:    name,
-		scanner: scanner,
+		logger:      logger,
+		clock:       clock,
+		name:        name,
+		scanner:     scanner,
+		queue:       queue,
+		prioritizer: prioritizer,
+
+		defaultInterval: defaultInterval,
+		jitterFraction:  jitter,
 	}
 }
 
 func (r *intervalRunner) Run(ctx context.Context) error {
-	// do an immediate initial check
-	var interval time.Duration = 0
+	// do an initial check right away, give or take the jitter
+	interval := r.jitter(r.defaultInterval)
 
 	for {
 		timer := r.clock.NewTimer(interval)
@@ -46,8 +69,16 @@ func (r *intervalRunner) Run(ctx context.Context) error {
 			timer.Stop()
 			return nil
 		case <-timer.C():
+			release, started := r.queue.Acquire(ctx, r.prioritizer.CheckPriority(r.name))
+			if !started {
+				return nil
+			}
+
 			var err error
 			interval, err = r.scanner.Run(r.logger, r.name)
+			release()
+
+			interval += r.jitter(interval)
 			if err != nil {
 				if err == ErrFailedToAcquireLock {
 					break
@@ -57,3 +88,13 @@ func (r *intervalRunner) Run(ctx context.Context) error {
 		}
 	}
 }
+
+// jitter returns a random delay of up to the jitter fraction of the interval.
+func (r *intervalRunner) jitter(interval time.Duration) time.Duration {
+	max := int64(float64(interval) * r.jitterFraction)
+	if max <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(max + 1))
+}
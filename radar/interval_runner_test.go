@@ -21,10 +21,16 @@ var _ = Describe("IntervalRunner", func() {
 
 		fakeClock *fakeclock.FakeClock
 		interval  time.Duration
-		times     chan time.Time
+		jitter    float64
 
-		intervalRunner IntervalRunner
-		fakeScanner    *radarfakes.FakeScanner
+		defaultInterval time.Duration
+		times           chan time.Time
+
+		intervalRunner  IntervalRunner
+		fakeScanner     *radarfakes.FakeScanner
+		fakeCheckQueue  *radarfakes.FakeCheckQueue
+		fakePrioritizer *radarfakes.FakeCheckPrioritizer
+		releasedChecks  chan struct{}
 
 		ctx    context.Context
 		cancel context.CancelFunc
@@ -43,8 +49,20 @@ var _ = Describe("IntervalRunner", func() {
 		}
 		ctx, cancel = context.WithCancel(context.Background())
 
+		releasedChecks = make(chan struct{}, 100)
+		fakeCheckQueue = new(radarfakes.FakeCheckQueue)
+		fakeCheckQueue.AcquireReturns(func() { releasedChecks <- struct{}{} }, true)
+
+		fakePrioritizer = new(radarfakes.FakeCheckPrioritizer)
+		fakePrioritizer.CheckPriorityReturns(CheckPriorityBlocking)
+
+		defaultInterval = 1 * time.Minute
+		jitter = 0
+	})
+
+	JustBeforeEach(func() {
 		logger := lagertest.NewTestLogger("test")
-		intervalRunner = NewIntervalRunner(logger, fakeClock, "some-resource", fakeScanner)
+		intervalRunner = NewIntervalRunner(logger, fakeClock, "some-resource", fakeScanner, fakeCheckQueue, fakePrioritizer, defaultInterval, jitter)
 	})
 
 	Describe("RunFunc", func() {
@@ -95,6 +113,68 @@ var _ = Describe("IntervalRunner", func() {
 			})
 		})
 
+		Context("with jitter", func() {
+			BeforeEach(func() {
+				jitter = 0.5
+			})
+
+			It("delays the first scan by no more than the jitter of the default interval", func() {
+				fakeClock.WaitForWatcherAndIncrement(defaultInterval / 2)
+
+				var scanned time.Time
+				Eventually(times).Should(Receive(&scanned))
+				Expect(scanned).To(BeTemporally("<=", epoch.Add(defaultInterval/2)))
+			})
+
+			Context("when the first scan is not delayed", func() {
+				BeforeEach(func() {
+					defaultInterval = 0
+				})
+
+				It("runs a scan after the interval, delayed by no more than the jitter", func() {
+					Expect(<-times).To(Equal(epoch))
+
+					fakeClock.WaitForWatcherAndIncrement(interval - time.Nanosecond)
+					Consistently(times).ShouldNot(Receive())
+
+					fakeClock.Increment(interval/2 + time.Nanosecond)
+
+					var scanned time.Time
+					Eventually(times).Should(Receive(&scanned))
+					Expect(scanned).To(Equal(epoch.Add(interval + interval/2)))
+				})
+			})
+		})
+
+		Context("when the check is queued", func() {
+			It("waits its turn with the resource's priority", func() {
+				<-times
+
+				Expect(fakePrioritizer.CheckPriorityCallCount()).To(Equal(1))
+				Expect(fakePrioritizer.CheckPriorityArgsForCall(0)).To(Equal("some-resource"))
+
+				Expect(fakeCheckQueue.AcquireCallCount()).To(Equal(1))
+				_, priority := fakeCheckQueue.AcquireArgsForCall(0)
+				Expect(priority).To(Equal(CheckPriorityBlocking))
+			})
+
+			It("releases its turn once the scan has finished", func() {
+				<-times
+				Eventually(releasedChecks).Should(Receive())
+			})
+
+			Context("when the context is done before it is started", func() {
+				BeforeEach(func() {
+					fakeCheckQueue.AcquireReturns(nil, false)
+				})
+
+				It("stops without scanning", func() {
+					Expect(<-runErrs).To(BeNil())
+					Expect(fakeScanner.RunCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when scanner.Run() returns an error", func() {
 			var disaster = errors.New("failed")
 			BeforeEach(func() {
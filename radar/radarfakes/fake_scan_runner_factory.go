@@ -9,11 +9,12 @@ import (
 )
 
 type FakeScanRunnerFactory struct {
-	ScanResourceRunnerStub        func(lager.Logger, string) radar.IntervalRunner
+	ScanResourceRunnerStub        func(lager.Logger, string, radar.CheckPrioritizer) radar.IntervalRunner
 	scanResourceRunnerMutex       sync.RWMutex
 	scanResourceRunnerArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 radar.CheckPrioritizer
 	}
 	scanResourceRunnerReturns struct {
 		result1 radar.IntervalRunner
@@ -37,17 +38,18 @@ type FakeScanRunnerFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeScanRunnerFactory) ScanResourceRunner(arg1 lager.Logger, arg2 string) radar.IntervalRunner {
+func (fake *FakeScanRunnerFactory) ScanResourceRunner(arg1 lager.Logger, arg2 string, arg3 radar.CheckPrioritizer) radar.IntervalRunner {
 	fake.scanResourceRunnerMutex.Lock()
 	ret, specificReturn := fake.scanResourceRunnerReturnsOnCall[len(fake.scanResourceRunnerArgsForCall)]
 	fake.scanResourceRunnerArgsForCall = append(fake.scanResourceRunnerArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("ScanResourceRunner", []interface{}{arg1, arg2})
+		arg3 radar.CheckPrioritizer
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ScanResourceRunner", []interface{}{arg1, arg2, arg3})
 	fake.scanResourceRunnerMutex.Unlock()
 	if fake.ScanResourceRunnerStub != nil {
-		return fake.ScanResourceRunnerStub(arg1, arg2)
+		return fake.ScanResourceRunnerStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -61,10 +63,10 @@ func (fake *FakeScanRunnerFactory) ScanResourceRunnerCallCount() int {
 	return len(fake.scanResourceRunnerArgsForCall)
 }
 
-func (fake *FakeScanRunnerFactory) ScanResourceRunnerArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeScanRunnerFactory) ScanResourceRunnerArgsForCall(i int) (lager.Logger, string, radar.CheckPrioritizer) {
 	fake.scanResourceRunnerMutex.RLock()
 	defer fake.scanResourceRunnerMutex.RUnlock()
-	return fake.scanResourceRunnerArgsForCall[i].arg1, fake.scanResourceRunnerArgsForCall[i].arg2
+	return fake.scanResourceRunnerArgsForCall[i].arg1, fake.scanResourceRunnerArgsForCall[i].arg2, fake.scanResourceRunnerArgsForCall[i].arg3
 }
 
 func (fake *FakeScanRunnerFactory) ScanResourceRunnerReturns(result1 radar.IntervalRunner) {
@@ -50,6 +50,20 @@ type FakeScanner struct {
 	scanFromVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ScanFromVersionToDepthStub        func(lager.Logger, string, atc.Version, int) error
+	scanFromVersionToDepthMutex       sync.RWMutex
+	scanFromVersionToDepthArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+		arg4 int
+	}
+	scanFromVersionToDepthReturns struct {
+		result1 error
+	}
+	scanFromVersionToDepthReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -205,6 +219,57 @@ func (fake *FakeScanner) ScanFromVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeScanner) ScanFromVersionToDepth(arg1 lager.Logger, arg2 string, arg3 atc.Version, arg4 int) error {
+	fake.scanFromVersionToDepthMutex.Lock()
+	ret, specificReturn := fake.scanFromVersionToDepthReturnsOnCall[len(fake.scanFromVersionToDepthArgsForCall)]
+	fake.scanFromVersionToDepthArgsForCall = append(fake.scanFromVersionToDepthArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ScanFromVersionToDepth", []interface{}{arg1, arg2, arg3, arg4})
+	fake.scanFromVersionToDepthMutex.Unlock()
+	if fake.ScanFromVersionToDepthStub != nil {
+		return fake.ScanFromVersionToDepthStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.scanFromVersionToDepthReturns.result1
+}
+
+func (fake *FakeScanner) ScanFromVersionToDepthCallCount() int {
+	fake.scanFromVersionToDepthMutex.RLock()
+	defer fake.scanFromVersionToDepthMutex.RUnlock()
+	return len(fake.scanFromVersionToDepthArgsForCall)
+}
+
+func (fake *FakeScanner) ScanFromVersionToDepthArgsForCall(i int) (lager.Logger, string, atc.Version, int) {
+	fake.scanFromVersionToDepthMutex.RLock()
+	defer fake.scanFromVersionToDepthMutex.RUnlock()
+	return fake.scanFromVersionToDepthArgsForCall[i].arg1, fake.scanFromVersionToDepthArgsForCall[i].arg2, fake.scanFromVersionToDepthArgsForCall[i].arg3, fake.scanFromVersionToDepthArgsForCall[i].arg4
+}
+
+func (fake *FakeScanner) ScanFromVersionToDepthReturns(result1 error) {
+	fake.ScanFromVersionToDepthStub = nil
+	fake.scanFromVersionToDepthReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScanner) ScanFromVersionToDepthReturnsOnCall(i int, result1 error) {
+	fake.ScanFromVersionToDepthStub = nil
+	if fake.scanFromVersionToDepthReturnsOnCall == nil {
+		fake.scanFromVersionToDepthReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.scanFromVersionToDepthReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeScanner) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -214,6 +279,8 @@ func (fake *FakeScanner) Invocations() map[string][][]interface{} {
 	defer fake.scanMutex.RUnlock()
 	fake.scanFromVersionMutex.RLock()
 	defer fake.scanFromVersionMutex.RUnlock()
+	fake.scanFromVersionToDepthMutex.RLock()
+	defer fake.scanFromVersionToDepthMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
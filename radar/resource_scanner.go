@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
@@ -20,6 +21,9 @@ type resourceScanner struct {
 	resourceFactory                   resource.ResourceFactory
 	resourceConfigCheckSessionFactory db.ResourceConfigCheckSessionFactory
 	defaultInterval                   time.Duration
+	checkTimeout                      time.Duration
+	containerExpiries                 db.ContainerOwnerExpiries
+	globalResources                   bool
 	dbPipeline                        db.Pipeline
 	externalURL                       string
 	variables                         creds.Variables
@@ -31,6 +35,9 @@ func NewResourceScanner(
 	resourceFactory resource.ResourceFactory,
 	resourceConfigCheckSessionFactory db.ResourceConfigCheckSessionFactory,
 	defaultInterval time.Duration,
+	checkTimeout time.Duration,
+	containerExpiries db.ContainerOwnerExpiries,
+	globalResources bool,
 	dbPipeline db.Pipeline,
 	externalURL string,
 	variables creds.Variables,
@@ -41,6 +48,9 @@ func NewResourceScanner(
 		resourceFactory:                   resourceFactory,
 		resourceConfigCheckSessionFactory: resourceConfigCheckSessionFactory,
 		defaultInterval:                   defaultInterval,
+		checkTimeout:                      checkTimeout,
+		containerExpiries:                 containerExpiries,
+		globalResources:                   globalResources,
 		dbPipeline:                        dbPipeline,
 		externalURL:                       externalURL,
 		variables:                         variables,
@@ -51,7 +61,7 @@ func NewResourceScanner(
 var ErrFailedToAcquireLock = errors.New("failed-to-acquire-lock")
 
 func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (time.Duration, error) {
-	interval, err := scanner.scan(logger.Session("tick"), resourceName, nil, false)
+	interval, err := scanner.scan(logger.Session("tick"), resourceName, nil, true, 1, false)
 
 	err = swallowErrResourceScriptFailed(err)
 
@@ -59,20 +69,35 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 }
 
 func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceName string, fromVersion atc.Version) error {
-	_, err := scanner.scan(logger, resourceName, fromVersion, true)
+	_, err := scanner.scan(logger, resourceName, fromVersion, fromVersion == nil, 1, true)
+
+	return err
+}
+
+func (scanner *resourceScanner) ScanFromVersionToDepth(logger lager.Logger, resourceName string, fromVersion atc.Version, depth int) error {
+	_, err := scanner.scan(logger, resourceName, fromVersion, false, depth, true)
 
 	return err
 }
 
 func (scanner *resourceScanner) Scan(logger lager.Logger, resourceName string) error {
-	_, err := scanner.scan(logger, resourceName, nil, true)
+	_, err := scanner.scan(logger, resourceName, nil, true, 1, true)
 
 	err = swallowErrResourceScriptFailed(err)
 
 	return err
 }
 
-func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, fromVersion atc.Version, mustComplete bool) (time.Duration, error) {
+// scan checks the resource from the version, or from the latest version
+// found if fromLatest is set, running up to depth checks one after another.
+func (scanner *resourceScanner) scan(
+	logger lager.Logger,
+	resourceName string,
+	fromVersion atc.Version,
+	fromLatest bool,
+	depth int,
+	mustComplete bool,
+) (time.Duration, error) {
 	lockLogger := logger.Session("lock", lager.Data{
 		"resource": resourceName,
 	})
@@ -87,10 +112,12 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 		return 0, db.ResourceNotFoundError{Name: resourceName}
 	}
 
+	scanStart := scanner.clock.Now()
+
 	interval, err := scanner.checkInterval(savedResource.CheckEvery())
 	if err != nil {
 		scanner.setResourceCheckError(logger, savedResource, err)
-
+		scanner.saveCheckResult(logger, savedResource, scanStart, nil, err)
 		return 0, err
 	}
 
@@ -111,6 +138,8 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 		err = scanner.typeScanner.Scan(logger.Session("resource-type-scanner"), parentType.Name())
 		if err != nil {
 			logger.Error("failed-to-scan-parent-resource-type-version", err)
+			scanner.setResourceCheckError(logger, savedResource, err)
+			scanner.saveCheckResult(logger, savedResource, scanStart, nil, err)
 			return 0, err
 		}
 	}
@@ -130,6 +159,7 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 	if err != nil {
 		logger.Error("failed-to-evaluate-resource-source", err)
 		scanner.setResourceCheckError(logger, savedResource, err)
+		scanner.saveCheckResult(logger, savedResource, scanStart, nil, err)
 		return 0, err
 	}
 
@@ -138,11 +168,12 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 		savedResource.Type(),
 		source,
 		versionedResourceTypes,
-		ContainerExpiries,
+		scanner.containerExpiries,
 	)
 	if err != nil {
 		logger.Error("failed-to-find-or-create-resource-config-check-session", err)
 		scanner.setResourceCheckError(logger, savedResource, err)
+		scanner.saveCheckResult(logger, savedResource, scanStart, nil, err)
 		return 0, err
 	}
 
@@ -150,13 +181,27 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 	if err != nil {
 		logger.Error("failed-to-set-resource-config-id-on-resource", err)
 		scanner.setResourceCheckError(logger, savedResource, err)
+		scanner.saveCheckResult(logger, savedResource, scanStart, nil, err)
 		return 0, err
 	}
 
+	if scanner.globalResources {
+		// checking on behalf of every resource with the same config counts
+		// towards its interval, so a paused resource must not take its turn
+		skip, err := scanner.skipCheck(logger, savedResource)
+		if err != nil {
+			return interval, err
+		}
+
+		if skip {
+			return interval, nil
+		}
+	}
+
 	for breaker := true; breaker == true; breaker = mustComplete {
-		lock, acquired, err := scanner.dbPipeline.AcquireResourceCheckingLockWithIntervalCheck(
+		lock, acquired, err := scanner.acquireCheckingLock(
 			logger,
-			savedResource.Name(),
+			savedResource,
 			resourceConfigCheckSession.ResourceConfig(),
 			interval,
 			mustComplete,
@@ -183,23 +228,35 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 		break
 	}
 
-	if fromVersion == nil {
-		vr, _, err := scanner.dbPipeline.GetLatestVersionedResource(resourceName)
+	if fromLatest {
+		fromVersion, err = scanner.latestVersion(savedResource, resourceConfigCheckSession.ResourceConfig())
 		if err != nil {
 			logger.Error("failed-to-get-current-version", err)
 			return interval, err
 		}
-		fromVersion = atc.Version(vr.Version)
 	}
 
-	return interval, scanner.check(
-		logger,
-		savedResource,
-		resourceConfigCheckSession,
-		fromVersion,
-		versionedResourceTypes,
-		source,
-	)
+	for checked := 0; checked < depth; checked++ {
+		newVersions, err := scanner.check(
+			logger,
+			savedResource,
+			resourceConfigCheckSession,
+			fromVersion,
+			versionedResourceTypes,
+			source,
+		)
+		if err != nil {
+			return interval, err
+		}
+
+		if len(newVersions) == 0 {
+			break
+		}
+
+		fromVersion = newVersions[len(newVersions)-1]
+	}
+
+	return interval, nil
 }
 
 func (scanner *resourceScanner) check(
@@ -209,33 +266,28 @@ func (scanner *resourceScanner) check(
 	fromVersion atc.Version,
 	resourceTypes creds.VersionedResourceTypes,
 	source atc.Source,
-) error {
-	pipelinePaused, err := scanner.dbPipeline.CheckPaused()
+) ([]atc.Version, error) {
+	skip, err := scanner.skipCheck(logger, savedResource)
 	if err != nil {
-		logger.Error("failed-to-check-if-pipeline-paused", err)
-		return err
-	}
-
-	if pipelinePaused {
-		logger.Debug("pipeline-paused")
-		return nil
+		return nil, err
 	}
 
-	if savedResource.Paused() {
-		logger.Debug("resource-paused")
-		return nil
+	if skip {
+		return nil, nil
 	}
 
 	found, err := scanner.dbPipeline.Reload()
 	if err != nil {
 		logger.Error("failed-to-reload-scannerdb", err)
-		return err
+		return nil, err
 	}
 	if !found {
 		logger.Info("pipeline-removed")
-		return errPipelineRemoved
+		return nil, errPipelineRemoved
 	}
 
+	checkStart := scanner.clock.Now()
+
 	metadata := resource.TrackerMetadata{
 		ResourceName: savedResource.Name(),
 		PipelineName: savedResource.PipelineName(),
@@ -265,30 +317,36 @@ func (scanner *resourceScanner) check(
 	if err != nil {
 		logger.Error("failed-to-initialize-new-container", err)
 		scanner.setResourceCheckError(logger, savedResource, err)
-		return err
+		scanner.saveCheckResult(logger, savedResource, checkStart, nil, err)
+		return nil, err
 	}
 
 	logger.Debug("checking", lager.Data{
 		"from": fromVersion,
 	})
 
-	newVersions, err := res.Check(source, fromVersion)
-
-	scanner.setResourceCheckError(logger, savedResource, err)
+	checkCtx, cancel := checkContext(scanner.checkTimeout)
+	defer cancel()
 
+	newVersions, err := res.Check(checkCtx, source, fromVersion)
 	if err != nil {
+		scanner.setResourceCheckError(logger, savedResource, err)
+		scanner.saveCheckResult(logger, savedResource, checkStart, nil, err)
+
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
-			return rErr
+			return nil, rErr
 		}
 
 		logger.Error("failed-to-check", err)
-		return err
+		return nil, err
 	}
 
 	if len(newVersions) == 0 || reflect.DeepEqual(newVersions, []atc.Version{fromVersion}) {
 		logger.Debug("no-new-versions")
-		return nil
+		scanner.setResourceCheckError(logger, savedResource, nil)
+		scanner.saveCheckResult(logger, savedResource, checkStart, newVersions, nil)
+		return nil, nil
 	}
 
 	logger.Info("versions-found", lager.Data{
@@ -296,17 +354,98 @@ func (scanner *resourceScanner) check(
 		"total":    len(newVersions),
 	})
 
-	err = scanner.dbPipeline.SaveResourceVersions(atc.ResourceConfig{
-		Name: savedResource.Name(),
-		Type: savedResource.Type(),
-	}, newVersions)
+	if scanner.globalResources {
+		err = scanner.dbPipeline.SaveResourceConfigVersions(resourceConfigCheckSession.ResourceConfig(), newVersions)
+	} else {
+		err = scanner.dbPipeline.SaveResourceVersions(atc.ResourceConfig{
+			Name: savedResource.Name(),
+			Type: savedResource.Type(),
+		}, newVersions)
+	}
 	if err != nil {
 		logger.Error("failed-to-save-versions", err, lager.Data{
 			"versions": newVersions,
 		})
+
+		scanner.setResourceCheckError(logger, savedResource, err)
+		scanner.saveCheckResult(logger, savedResource, checkStart, nil, err)
+		return nil, nil
 	}
 
-	return nil
+	scanner.setResourceCheckError(logger, savedResource, nil)
+	scanner.saveCheckResult(logger, savedResource, checkStart, newVersions, nil)
+
+	return newVersions, nil
+}
+
+// skipCheck returns whether the resource is not to be checked, because it or
+// its pipeline is paused.
+func (scanner *resourceScanner) skipCheck(logger lager.Logger, savedResource db.Resource) (bool, error) {
+	pipelinePaused, err := scanner.dbPipeline.CheckPaused()
+	if err != nil {
+		logger.Error("failed-to-check-if-pipeline-paused", err)
+		return false, err
+	}
+
+	if pipelinePaused {
+		logger.Debug("pipeline-paused")
+		return true, nil
+	}
+
+	if savedResource.Paused() {
+		logger.Debug("resource-paused")
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// acquireCheckingLock acquires the lock for checking the resource config once
+// the interval has elapsed since the resource was last checked, or since any
+// resource with the same config was when checking globally.
+func (scanner *resourceScanner) acquireCheckingLock(
+	logger lager.Logger,
+	savedResource db.Resource,
+	resourceConfig *db.UsedResourceConfig,
+	interval time.Duration,
+	immediate bool,
+) (lock.Lock, bool, error) {
+	if scanner.globalResources {
+		return scanner.dbPipeline.AcquireResourceConfigCheckingLockWithIntervalCheck(
+			logger,
+			resourceConfig,
+			interval,
+			immediate,
+		)
+	}
+
+	return scanner.dbPipeline.AcquireResourceCheckingLockWithIntervalCheck(
+		logger,
+		savedResource.Name(),
+		resourceConfig,
+		interval,
+		immediate,
+	)
+}
+
+// latestVersion returns the version to check from, which when checking
+// globally is the latest of the history shared by every resource with the
+// same config. Until the config has a history, e.g. as global checking has
+// just been turned on, the resource's own latest version is used instead.
+func (scanner *resourceScanner) latestVersion(savedResource db.Resource, resourceConfig *db.UsedResourceConfig) (atc.Version, error) {
+	if scanner.globalResources {
+		version, found, err := scanner.dbPipeline.LatestResourceConfigVersion(resourceConfig)
+		if err != nil || found {
+			return version, err
+		}
+	}
+
+	vr, _, err := scanner.dbPipeline.GetLatestVersionedResource(savedResource.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Version(vr.Version), nil
 }
 
 func swallowErrResourceScriptFailed(err error) error {
@@ -337,4 +476,40 @@ func (scanner *resourceScanner) setResourceCheckError(logger lager.Logger, saved
 	}
 }
 
+// saveCheckResult records the outcome of the check of the resource started
+// at the given time, so that it can be seen why a resource is not finding new
+// versions.
+func (scanner *resourceScanner) saveCheckResult(
+	logger lager.Logger,
+	savedResource db.Resource,
+	start time.Time,
+	versions []atc.Version,
+	err error,
+) {
+	result := db.ResourceCheckResult{
+		StartTime:    start,
+		EndTime:      scanner.clock.Now(),
+		VersionCount: len(versions),
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	saveErr := savedResource.SaveCheckResult(result)
+	if saveErr != nil {
+		logger.Error("failed-to-save-check-result", saveErr)
+	}
+}
+
 var errPipelineRemoved = errors.New("pipeline removed")
+
+// checkContext returns the context to run a check in, which times out after
+// the timeout unless it is zero.
+func checkContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.WithCancel(context.Background())
+	}
+
+	return context.WithTimeout(context.Background(), timeout)
+}
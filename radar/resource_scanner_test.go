@@ -1,6 +1,7 @@
 package radar_test
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -34,6 +35,7 @@ var _ = Describe("ResourceScanner", func() {
 		fakeDBPipeline                        *dbfakes.FakePipeline
 		fakeClock                             *fakeclock.FakeClock
 		interval                              time.Duration
+		containerExpiries                     db.ContainerOwnerExpiries
 		variables                             creds.Variables
 
 		fakeResourceType      *dbfakes.FakeResourceType
@@ -53,6 +55,7 @@ var _ = Describe("ResourceScanner", func() {
 		epoch = time.Unix(123, 456).UTC()
 		fakeLock = &lockfakes.FakeLock{}
 		interval = 1 * time.Minute
+		containerExpiries = db.ContainerOwnerExpiries{GraceTime: time.Minute, Min: 10 * time.Minute, Max: 10 * time.Minute}
 		variables = template.StaticVariables{
 			"source-params": "some-secret-sauce",
 		}
@@ -116,6 +119,9 @@ var _ = Describe("ResourceScanner", func() {
 			fakeResourceFactory,
 			fakeResourceConfigCheckSessionFactory,
 			interval,
+			time.Hour,
+			containerExpiries,
+			false,
 			fakeDBPipeline,
 			"https://www.example.com",
 			variables,
@@ -154,6 +160,84 @@ var _ = Describe("ResourceScanner", func() {
 			})
 		})
 
+		Context("when checking globally", func() {
+			BeforeEach(func() {
+				scanner = NewResourceScanner(
+					fakeClock,
+					fakeResourceFactory,
+					fakeResourceConfigCheckSessionFactory,
+					interval,
+					time.Hour,
+					containerExpiries,
+					true,
+					fakeDBPipeline,
+					"https://www.example.com",
+					variables,
+					fakeResourceTypeScanner,
+				)
+
+				fakeDBPipeline.AcquireResourceConfigCheckingLockWithIntervalCheckReturns(fakeLock, true, nil)
+				fakeDBPipeline.LatestResourceConfigVersionReturns(atc.Version{"version": "1"}, true, nil)
+				fakeResource.CheckReturns([]atc.Version{{"version": "2"}}, nil)
+			})
+
+			It("checks once the interval has elapsed for the resource config", func() {
+				Expect(fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckCallCount()).To(BeZero())
+				Expect(fakeDBPipeline.AcquireResourceConfigCheckingLockWithIntervalCheckCallCount()).To(Equal(1))
+
+				_, resourceConfig, leaseInterval, immediate := fakeDBPipeline.AcquireResourceConfigCheckingLockWithIntervalCheckArgsForCall(0)
+				Expect(resourceConfig).To(Equal(fakeResourceConfigCheckSession.ResourceConfig()))
+				Expect(leaseInterval).To(Equal(interval))
+				Expect(immediate).To(BeFalse())
+			})
+
+			It("checks from the latest version shared by the resource config", func() {
+				Expect(fakeDBPipeline.LatestResourceConfigVersionArgsForCall(0)).To(Equal(fakeResourceConfigCheckSession.ResourceConfig()))
+
+				_, _, version := fakeResource.CheckArgsForCall(0)
+				Expect(version).To(Equal(atc.Version{"version": "1"}))
+			})
+
+			Context("when the resource config has no shared history yet", func() {
+				BeforeEach(func() {
+					fakeDBPipeline.LatestResourceConfigVersionReturns(nil, false, nil)
+					fakeDBPipeline.GetLatestVersionedResourceReturns(db.SavedVersionedResource{
+						VersionedResource: db.VersionedResource{
+							Version: db.ResourceVersion{"version": "0"},
+						},
+					}, true, nil)
+				})
+
+				It("checks from the resource's own latest version", func() {
+					Expect(fakeDBPipeline.GetLatestVersionedResourceArgsForCall(0)).To(Equal("some-resource"))
+
+					_, _, version := fakeResource.CheckArgsForCall(0)
+					Expect(version).To(Equal(atc.Version{"version": "0"}))
+				})
+			})
+
+			It("saves the versions for every resource with the resource config", func() {
+				Expect(fakeDBPipeline.SaveResourceVersionsCallCount()).To(BeZero())
+				Expect(fakeDBPipeline.SaveResourceConfigVersionsCallCount()).To(Equal(1))
+
+				resourceConfig, versions := fakeDBPipeline.SaveResourceConfigVersionsArgsForCall(0)
+				Expect(resourceConfig).To(Equal(fakeResourceConfigCheckSession.ResourceConfig()))
+				Expect(versions).To(Equal([]atc.Version{{"version": "2"}}))
+			})
+
+			Context("when the resource is paused", func() {
+				BeforeEach(func() {
+					fakeDBResource.PausedReturns(true)
+				})
+
+				It("does not take the resource config's turn to check", func() {
+					Expect(fakeDBPipeline.AcquireResourceConfigCheckingLockWithIntervalCheckCallCount()).To(BeZero())
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+					Expect(runErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
 		Context("when the lock can be acquired", func() {
 			BeforeEach(func() {
 				fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckReturns(fakeLock, true, nil)
@@ -163,6 +247,395 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(fakeResource.CheckCallCount()).To(Equal(1))
 			})
 
+			It("constructs the resource of the correct type", func() {
+				Expect(fakeResourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSessionCallCount()).To(Equal(1))
+				_, resourceType, resourceSource, resourceTypes, expiries := fakeResourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSessionArgsForCall(0)
+				Expect(resourceType).To(Equal("git"))
+				Expect(resourceSource).To(Equal(atc.Source{"uri": "some-secret-sauce"}))
+				Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
+					versionedResourceType,
+				})))
+				Expect(expiries).To(Equal(containerExpiries))
+
+				Expect(fakeDBResource.SetResourceConfigCallCount()).To(Equal(1))
+				resourceConfigID := fakeDBResource.SetResourceConfigArgsForCall(0)
+				Expect(resourceConfigID).To(Equal(123))
+
+				_, _, owner, metadata, resourceSpec, resourceTypes, _ := fakeResourceFactory.NewResourceArgsForCall(0)
+				Expect(owner).To(Equal(db.NewResourceConfigCheckSessionContainerOwner(fakeResourceConfigCheckSession, teamID)))
+				Expect(metadata).To(Equal(db.ContainerMetadata{
+					Type: db.ContainerTypeCheck,
+				}))
+				Expect(resourceSpec).To(Equal(worker.ContainerSpec{
+					ImageSpec: worker.ImageSpec{
+						ResourceType: "git",
+					},
+					Tags:   atc.Tags{"some-tag"},
+					TeamID: 123,
+					Env: []string{
+						"ATC_EXTERNAL_URL=https://www.example.com",
+						"RESOURCE_PIPELINE_NAME=some-pipeline",
+						"RESOURCE_NAME=some-resource",
+					},
+				}))
+				Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
+					versionedResourceType,
+				})))
+			})
+
+			Context("when the resource config has a specified check interval", func() {
+				BeforeEach(func() {
+					fakeDBResource.CheckEveryReturns("10ms")
+					fakeDBPipeline.ResourceReturns(fakeDBResource, true, nil)
+				})
+
+				It("leases for the configured interval", func() {
+					Expect(fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckCallCount()).To(Equal(1))
+
+					_, resourceName, resourceConfig, leaseInterval, immediate := fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckArgsForCall(0)
+					Expect(resourceName).To(Equal("some-resource"))
+					Expect(leaseInterval).To(Equal(10 * time.Millisecond))
+					Expect(immediate).To(BeFalse())
+					Expect(resourceConfig).To(Equal(fakeResourceConfigCheckSession.ResourceConfig()))
+
+					Eventually(fakeLock.ReleaseCallCount).Should(Equal(1))
+				})
+
+				It("returns configured interval", func() {
+					Expect(actualInterval).To(Equal(10 * time.Millisecond))
+				})
+
+				Context("when the interval cannot be parsed", func() {
+					BeforeEach(func() {
+						fakeDBResource.CheckEveryReturns("bad-value")
+						fakeDBPipeline.ResourceReturns(fakeDBResource, true, nil)
+					})
+
+					It("sets the check error", func() {
+						Expect(fakeDBPipeline.SetResourceCheckErrorCallCount()).To(Equal(1))
+
+						savedResource, resourceErr := fakeDBPipeline.SetResourceCheckErrorArgsForCall(0)
+						Expect(savedResource.Name()).To(Equal("some-resource"))
+						Expect(resourceErr).To(MatchError("time: invalid duration bad-value"))
+
+						Expect(fakeDBResource.SaveCheckResultCallCount()).To(Equal(1))
+						Expect(fakeDBResource.SaveCheckResultArgsForCall(0).Error).To(Equal("time: invalid duration bad-value"))
+					})
+
+					It("returns an error", func() {
+						Expect(runErr).To(HaveOccurred())
+					})
+				})
+			})
+
+			It("grabs a periodic resource checking lock before checking, breaks lock after done", func() {
+				Expect(fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckCallCount()).To(Equal(1))
+
+				_, resourceName, resourceConfig, leaseInterval, immediate := fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckArgsForCall(0)
+				Expect(resourceName).To(Equal("some-resource"))
+				Expect(leaseInterval).To(Equal(interval))
+				Expect(immediate).To(BeFalse())
+				Expect(resourceConfig).To(Equal(fakeResourceConfigCheckSession.ResourceConfig()))
+
+				Eventually(fakeLock.ReleaseCallCount).Should(Equal(1))
+			})
+
+			Context("when the resource uses a custom type", func() {
+				BeforeEach(func() {
+					fakeDBResource.TypeReturns("some-custom-resource")
+				})
+				Context("and the custom type has a version", func() {
+					It("doesn't scan for new versions of the custom type", func() {
+						Expect(fakeResourceTypeScanner.ScanCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("and the custom type does not have a version", func() {
+					BeforeEach(func() {
+						fakeResourceType.VersionReturns(nil)
+					})
+
+					It("scans for new versions of the custom type", func() {
+						Expect(fakeResourceTypeScanner.ScanCallCount()).To(Equal(1))
+						_, scannedResourceType := fakeResourceTypeScanner.ScanArgsForCall(0)
+						Expect(scannedResourceType).To(Equal("some-custom-resource"))
+					})
+
+					Context("when scanning for the custom type fails", func() {
+						var typeScanErr = errors.New("type scan failed")
+						BeforeEach(func() {
+							fakeResourceTypeScanner.ScanReturns(typeScanErr)
+						})
+						It("returns the error from scanning for the type", func() {
+							Expect(runErr).To(Equal(typeScanErr))
+						})
+					})
+
+					Context("when scanning for the custom type succeeds", func() {
+						BeforeEach(func() {
+							fakeResourceTypeScanner.ScanReturns(nil)
+						})
+						It("reloads the resource types", func() {
+							Expect(fakeDBPipeline.ResourceTypesCallCount()).To(Equal(2))
+						})
+					})
+				})
+			})
+
+			It("times out the check after the check timeout", func() {
+				ctx, _, _ := fakeResource.CheckArgsForCall(0)
+				deadline, ok := ctx.Deadline()
+				Expect(ok).To(BeTrue())
+				Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			})
+
+			Context("when there is no current version", func() {
+				It("checks from nil", func() {
+					_, _, version := fakeResource.CheckArgsForCall(0)
+					Expect(version).To(BeNil())
+				})
+			})
+
+			Context("when there is a current version", func() {
+				BeforeEach(func() {
+					fakeDBPipeline.GetLatestVersionedResourceReturns(
+						db.SavedVersionedResource{
+							ID: 1,
+							VersionedResource: db.VersionedResource{
+								Version: db.ResourceVersion{
+									"version": "1",
+								},
+							},
+						}, true, nil)
+				})
+
+				It("checks from it", func() {
+					_, _, version := fakeResource.CheckArgsForCall(0)
+					Expect(version).To(Equal(atc.Version{"version": "1"}))
+				})
+			})
+
+			Context("when the check returns versions", func() {
+				var checkedFrom chan atc.Version
+
+				var nextVersions []atc.Version
+
+				BeforeEach(func() {
+					checkedFrom = make(chan atc.Version, 100)
+
+					nextVersions = []atc.Version{
+						{"version": "1"},
+						{"version": "2"},
+						{"version": "3"},
+					}
+
+					checkResults := map[int][]atc.Version{
+						0: nextVersions,
+					}
+
+					check := 0
+					fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+						defer GinkgoRecover()
+
+						Expect(source).To(Equal(resourceConfig.Source))
+
+						checkedFrom <- from
+						result := checkResults[check]
+						check++
+
+						return result, nil
+					}
+				})
+
+				It("saves them all, in order", func() {
+					Eventually(fakeDBPipeline.SaveResourceVersionsCallCount).Should(Equal(1))
+
+					resourceConfig, versions := fakeDBPipeline.SaveResourceVersionsArgsForCall(0)
+					Expect(resourceConfig).To(Equal(atc.ResourceConfig{
+						Name: "some-resource",
+						Type: "git",
+					}))
+
+					Expect(versions).To(Equal([]atc.Version{
+						{"version": "1"},
+						{"version": "2"},
+						{"version": "3"},
+					}))
+				})
+
+				It("records the result of the check", func() {
+					Expect(fakeDBResource.SaveCheckResultCallCount()).To(Equal(1))
+
+					result := fakeDBResource.SaveCheckResultArgsForCall(0)
+					Expect(result.StartTime).To(Equal(epoch))
+					Expect(result.EndTime).To(Equal(epoch))
+					Expect(result.Error).To(BeEmpty())
+					Expect(result.VersionCount).To(Equal(3))
+				})
+
+				Context("when saving versions fails", func() {
+					BeforeEach(func() {
+						fakeDBPipeline.SaveResourceVersionsReturns(errors.New("failed"))
+					})
+
+					It("does not return an error", func() {
+						Expect(runErr).NotTo(HaveOccurred())
+					})
+
+					It("records the failure as the result of the check", func() {
+						Expect(fakeDBResource.SaveCheckResultCallCount()).To(Equal(1))
+
+						result := fakeDBResource.SaveCheckResultArgsForCall(0)
+						Expect(result.Error).To(Equal("failed"))
+						Expect(result.VersionCount).To(BeZero())
+					})
+
+					It("sets the check error on the resource", func() {
+						Expect(fakeDBPipeline.SetResourceCheckErrorCallCount()).To(Equal(1))
+
+						_, resourceErr := fakeDBPipeline.SetResourceCheckErrorArgsForCall(0)
+						Expect(resourceErr).To(Equal(errors.New("failed")))
+					})
+				})
+			})
+
+			Context("when checking fails internally", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeResource.CheckReturns(nil, disaster)
+				})
+
+				It("exits with the failure", func() {
+					Expect(runErr).To(HaveOccurred())
+					Expect(runErr).To(Equal(disaster))
+				})
+
+				It("records the failed check", func() {
+					Expect(fakeDBResource.SaveCheckResultCallCount()).To(Equal(1))
+
+					result := fakeDBResource.SaveCheckResultArgsForCall(0)
+					Expect(result.Error).To(Equal("nope"))
+					Expect(result.VersionCount).To(BeZero())
+				})
+			})
+
+			Context("when checking fails with ErrResourceScriptFailed", func() {
+				scriptFail := resource.ErrResourceScriptFailed{}
+
+				BeforeEach(func() {
+					fakeResource.CheckReturns(nil, scriptFail)
+				})
+
+				It("returns no error", func() {
+					Expect(runErr).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("when the pipeline is paused", func() {
+				BeforeEach(func() {
+					fakeDBPipeline.CheckPausedReturns(true, nil)
+				})
+
+				It("does not check", func() {
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+				})
+
+				It("returns the default interval", func() {
+					Expect(actualInterval).To(Equal(interval))
+				})
+
+				It("does not return an error", func() {
+					Expect(runErr).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("when the resource is paused", func() {
+				var anotherFakeResource *dbfakes.FakeResource
+				BeforeEach(func() {
+					anotherFakeResource = new(dbfakes.FakeResource)
+					anotherFakeResource.NameReturns("some-resource")
+					anotherFakeResource.PausedReturns(true)
+					fakeDBPipeline.ResourceReturns(anotherFakeResource, true, nil)
+				})
+
+				It("does not check", func() {
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+				})
+
+				It("returns the default interval", func() {
+					Expect(actualInterval).To(Equal(interval))
+				})
+
+				It("does not return an error", func() {
+					Expect(runErr).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("when checking if the resource is paused fails", func() {
+				disaster := errors.New("disaster")
+
+				BeforeEach(func() {
+					fakeDBPipeline.CheckPausedReturns(false, disaster)
+				})
+
+				It("returns an error", func() {
+					Expect(runErr).To(HaveOccurred())
+					Expect(runErr).To(Equal(disaster))
+				})
+			})
+
+			Context("when checking if the resource is paused fails", func() {
+				disaster := errors.New("disaster")
+
+				BeforeEach(func() {
+					fakeDBPipeline.ResourceReturns(nil, false, disaster)
+				})
+
+				It("returns an error", func() {
+					Expect(runErr).To(HaveOccurred())
+					Expect(runErr).To(Equal(disaster))
+				})
+			})
+
+			Context("when the resource is not in the database", func() {
+				BeforeEach(func() {
+					fakeDBPipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns an error", func() {
+					Expect(runErr).To(HaveOccurred())
+					Expect(runErr.Error()).To(ContainSubstring("resource 'some-resource' not found"))
+				})
+			})
+		})
+	})
+
+	Describe("Scan", func() {
+		var (
+			fakeResource *rfakes.FakeResource
+
+			scanErr error
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(rfakes.FakeResource)
+			fakeResourceFactory.NewResourceReturns(fakeResource, nil)
+		})
+
+		JustBeforeEach(func() {
+			scanErr = scanner.Scan(lagertest.NewTestLogger("test"), "some-resource")
+		})
+
+		Context("if the lock can be acquired", func() {
+			BeforeEach(func() {
+				fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckReturns(fakeLock, true, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(scanErr).NotTo(HaveOccurred())
+			})
+
 			It("constructs the resource of the correct type", func() {
 				Expect(fakeResourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSessionCallCount()).To(Equal(1))
 				_, resourceType, resourceSource, resourceTypes, _ := fakeResourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSessionArgsForCall(0)
@@ -198,6 +671,18 @@ var _ = Describe("ResourceScanner", func() {
 				})))
 			})
 
+			It("grabs an immediate resource checking lock before checking, breaks lock after done", func() {
+				Expect(fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckCallCount()).To(Equal(1))
+
+				_, resourceName, resourceConfig, leaseInterval, immediate := fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckArgsForCall(0)
+				Expect(resourceName).To(Equal("some-resource"))
+				Expect(leaseInterval).To(Equal(interval))
+				Expect(immediate).To(BeTrue())
+				Expect(resourceConfig).To(Equal(fakeResourceConfigCheckSession.ResourceConfig()))
+
+				Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+			})
+
 			Context("when the resource config has a specified check interval", func() {
 				BeforeEach(func() {
 					fakeDBResource.CheckEveryReturns("10ms")
@@ -294,9 +779,16 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			It("times out the check after the check timeout", func() {
+				ctx, _, _ := fakeResource.CheckArgsForCall(0)
+				deadline, ok := ctx.Deadline()
+				Expect(ok).To(BeTrue())
+				Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			})
+
 			Context("when there is no current version", func() {
 				It("checks from nil", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -315,7 +807,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 			})
@@ -339,7 +831,7 @@ var _ = Describe("ResourceScanner", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(resourceConfig.Source))
@@ -368,6 +860,16 @@ var _ = Describe("ResourceScanner", func() {
 					}))
 				})
 
+				It("records the result of the check", func() {
+					Expect(fakeDBResource.SaveCheckResultCallCount()).To(Equal(1))
+
+					result := fakeDBResource.SaveCheckResultArgsForCall(0)
+					Expect(result.StartTime).To(Equal(epoch))
+					Expect(result.EndTime).To(Equal(epoch))
+					Expect(result.Error).To(BeEmpty())
+					Expect(result.VersionCount).To(Equal(3))
+				})
+
 				Context("when saving versions fails", func() {
 					BeforeEach(func() {
 						fakeDBPipeline.SaveResourceVersionsReturns(errors.New("failed"))
@@ -376,6 +878,21 @@ var _ = Describe("ResourceScanner", func() {
 					It("does not return an error", func() {
 						Expect(runErr).NotTo(HaveOccurred())
 					})
+
+					It("records the failure as the result of the check", func() {
+						Expect(fakeDBResource.SaveCheckResultCallCount()).To(Equal(1))
+
+						result := fakeDBResource.SaveCheckResultArgsForCall(0)
+						Expect(result.Error).To(Equal("failed"))
+						Expect(result.VersionCount).To(BeZero())
+					})
+
+					It("sets the check error on the resource", func() {
+						Expect(fakeDBPipeline.SetResourceCheckErrorCallCount()).To(Equal(1))
+
+						_, resourceErr := fakeDBPipeline.SetResourceCheckErrorArgsForCall(0)
+						Expect(resourceErr).To(Equal(errors.New("failed")))
+					})
 				})
 			})
 
@@ -390,6 +907,14 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(runErr).To(HaveOccurred())
 					Expect(runErr).To(Equal(disaster))
 				})
+
+				It("records the failed check", func() {
+					Expect(fakeDBResource.SaveCheckResultCallCount()).To(Equal(1))
+
+					result := fakeDBResource.SaveCheckResultArgsForCall(0)
+					Expect(result.Error).To(Equal("nope"))
+					Expect(result.VersionCount).To(BeZero())
+				})
 			})
 
 			Context("when checking fails with ErrResourceScriptFailed", func() {
@@ -694,7 +1219,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from nil", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -729,7 +1254,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 
@@ -763,7 +1288,7 @@ var _ = Describe("ResourceScanner", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(resourceConfig.Source))
@@ -861,7 +1386,7 @@ var _ = Describe("ResourceScanner", func() {
 
 			Context("when fromVersion is nil", func() {
 				It("checks from nil", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -874,7 +1399,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 			})
@@ -903,4 +1428,96 @@ var _ = Describe("ResourceScanner", func() {
 			})
 		})
 	})
+
+	Describe("ScanFromVersionToDepth", func() {
+		var (
+			fakeResource *rfakes.FakeResource
+			fromVersion  atc.Version
+			depth        int
+
+			scanErr error
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(rfakes.FakeResource)
+			fakeResourceFactory.NewResourceReturns(fakeResource, nil)
+			fromVersion = atc.Version{"version": "1"}
+			depth = 3
+
+			fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckReturns(fakeLock, true, nil)
+			fakeDBPipeline.GetLatestVersionedResourceReturns(
+				db.SavedVersionedResource{
+					VersionedResource: db.VersionedResource{
+						Version: db.ResourceVersion{"version": "latest"},
+					},
+				}, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			scanErr = scanner.ScanFromVersionToDepth(lagertest.NewTestLogger("test"), "some-resource", fromVersion, depth)
+		})
+
+		Context("when each check finds new versions", func() {
+			BeforeEach(func() {
+				fakeResource.CheckReturnsOnCall(0, []atc.Version{{"version": "2"}, {"version": "3"}}, nil)
+				fakeResource.CheckReturnsOnCall(1, []atc.Version{{"version": "4"}}, nil)
+				fakeResource.CheckReturnsOnCall(2, []atc.Version{{"version": "5"}}, nil)
+			})
+
+			It("checks from the newest version found by the check before, up to the depth", func() {
+				Expect(scanErr).ToNot(HaveOccurred())
+				Expect(fakeResource.CheckCallCount()).To(Equal(3))
+
+				_, _, version := fakeResource.CheckArgsForCall(0)
+				Expect(version).To(Equal(atc.Version{"version": "1"}))
+
+				_, _, version = fakeResource.CheckArgsForCall(1)
+				Expect(version).To(Equal(atc.Version{"version": "3"}))
+
+				_, _, version = fakeResource.CheckArgsForCall(2)
+				Expect(version).To(Equal(atc.Version{"version": "4"}))
+
+				Expect(fakeDBPipeline.SaveResourceVersionsCallCount()).To(Equal(3))
+			})
+		})
+
+		Context("when a check finds no new versions", func() {
+			BeforeEach(func() {
+				fakeResource.CheckReturnsOnCall(0, []atc.Version{{"version": "2"}}, nil)
+				fakeResource.CheckReturnsOnCall(1, []atc.Version{{"version": "2"}}, nil)
+			})
+
+			It("stops checking", func() {
+				Expect(scanErr).ToNot(HaveOccurred())
+				Expect(fakeResource.CheckCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when fromVersion is nil", func() {
+			BeforeEach(func() {
+				fromVersion = nil
+				depth = 1
+			})
+
+			It("checks from scratch rather than from the latest version", func() {
+				Expect(fakeResource.CheckCallCount()).To(Equal(1))
+
+				_, _, version := fakeResource.CheckArgsForCall(0)
+				Expect(version).To(BeNil())
+			})
+		})
+
+		Context("when a check fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeResource.CheckReturns(nil, disaster)
+			})
+
+			It("stops checking and returns the error", func() {
+				Expect(scanErr).To(Equal(disaster))
+				Expect(fakeResource.CheckCallCount()).To(Equal(1))
+			})
+		})
+	})
 })
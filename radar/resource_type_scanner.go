@@ -2,6 +2,7 @@ package radar
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"time"
 
@@ -19,6 +20,8 @@ type resourceTypeScanner struct {
 	resourceFactory                   resource.ResourceFactory
 	resourceConfigCheckSessionFactory db.ResourceConfigCheckSessionFactory
 	defaultInterval                   time.Duration
+	checkTimeout                      time.Duration
+	containerExpiries                 db.ContainerOwnerExpiries
 	dbPipeline                        db.Pipeline
 	externalURL                       string
 	variables                         creds.Variables
@@ -29,6 +32,8 @@ func NewResourceTypeScanner(
 	resourceFactory resource.ResourceFactory,
 	resourceConfigCheckSessionFactory db.ResourceConfigCheckSessionFactory,
 	defaultInterval time.Duration,
+	checkTimeout time.Duration,
+	containerExpiries db.ContainerOwnerExpiries,
 	dbPipeline db.Pipeline,
 	externalURL string,
 	variables creds.Variables,
@@ -38,6 +43,8 @@ func NewResourceTypeScanner(
 		resourceFactory:                   resourceFactory,
 		resourceConfigCheckSessionFactory: resourceConfigCheckSessionFactory,
 		defaultInterval:                   defaultInterval,
+		checkTimeout:                      checkTimeout,
+		containerExpiries:                 containerExpiries,
 		dbPipeline:                        dbPipeline,
 		externalURL:                       externalURL,
 		variables:                         variables,
@@ -52,6 +59,22 @@ func (scanner *resourceTypeScanner) ScanFromVersion(logger lager.Logger, resourc
 	return nil
 }
 
+// ErrResourceTypeCheckDepthUnsupported is returned when a resource type is
+// asked to be checked more than once in a row. Only the latest version of a
+// resource type is kept, so there is nothing for the checks after the first
+// to build on.
+var ErrResourceTypeCheckDepthUnsupported = errors.New("resource types can only be checked to a depth of 1")
+
+func (scanner *resourceTypeScanner) ScanFromVersionToDepth(logger lager.Logger, resourceTypeName string, fromVersion atc.Version, depth int) error {
+	if depth > 1 {
+		return ErrResourceTypeCheckDepthUnsupported
+	}
+
+	_, err := scanner.scan(logger, resourceTypeName, fromVersion, true)
+
+	return err
+}
+
 func (scanner *resourceTypeScanner) Scan(logger lager.Logger, resourceTypeName string) error {
 	_, err := scanner.scan(logger, resourceTypeName, nil, true)
 
@@ -76,6 +99,11 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 	// TODO: maybe consider scanner.checkInterval
 	interval := scanner.defaultInterval
 
+	if savedResourceType.Digest() != "" {
+		logger.Debug("pinned-by-digest")
+		return interval, nil
+	}
+
 	resourceTypes, err := scanner.dbPipeline.ResourceTypes()
 	if err != nil {
 		logger.Error("failed-to-get-resource-types", err)
@@ -121,7 +149,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 		savedResourceType.Type(),
 		source,
 		versionedResourceTypes.Without(savedResourceType.Name()),
-		ContainerExpiries,
+		scanner.containerExpiries,
 	)
 	if err != nil {
 		logger.Error("failed-to-find-or-create-resource-config", err)
@@ -221,7 +249,10 @@ func (scanner *resourceTypeScanner) check(
 		return err
 	}
 
-	newVersions, err := res.Check(source, fromVersion)
+	checkCtx, cancel := checkContext(scanner.checkTimeout)
+	defer cancel()
+
+	newVersions, err := res.Check(checkCtx, source, fromVersion)
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
@@ -1,6 +1,7 @@
 package radar_test
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -32,6 +33,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 		fakeDBPipeline                        *dbfakes.FakePipeline
 		fakeClock                             *fakeclock.FakeClock
 		interval                              time.Duration
+		containerExpiries                     db.ContainerOwnerExpiries
 		variables                             creds.Variables
 
 		fakeResourceType      *dbfakes.FakeResourceType
@@ -46,6 +48,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 	BeforeEach(func() {
 		fakeLock = &lockfakes.FakeLock{}
 		interval = 1 * time.Minute
+		containerExpiries = db.ContainerOwnerExpiries{GraceTime: time.Minute, Min: 10 * time.Minute, Max: 10 * time.Minute}
 		variables = template.StaticVariables{
 			"source-params": "some-secret-sauce",
 		}
@@ -91,6 +94,8 @@ var _ = Describe("ResourceTypeScanner", func() {
 			fakeResourceFactory,
 			fakeResourceConfigCheckSessionFactory,
 			interval,
+			time.Hour,
+			containerExpiries,
 			fakeDBPipeline,
 			"https://www.example.com",
 			variables,
@@ -113,6 +118,23 @@ var _ = Describe("ResourceTypeScanner", func() {
 			actualInterval, runErr = scanner.Run(lagertest.NewTestLogger("test"), fakeResourceType.Name())
 		})
 
+		Context("when the resource type is pinned by digest", func() {
+			BeforeEach(func() {
+				fakeResourceType.DigestReturns("sha256:some-digest")
+				fakeDBPipeline.AcquireResourceTypeCheckingLockWithIntervalCheckReturns(fakeLock, true, nil)
+			})
+
+			It("does not check", func() {
+				Expect(fakeDBPipeline.AcquireResourceTypeCheckingLockWithIntervalCheckCallCount()).To(BeZero())
+				Expect(fakeResource.CheckCallCount()).To(BeZero())
+			})
+
+			It("returns the configured interval", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(actualInterval).To(Equal(interval))
+			})
+		})
+
 		Context("when the lock cannot be acquired", func() {
 			BeforeEach(func() {
 				fakeDBPipeline.AcquireResourceTypeCheckingLockWithIntervalCheckReturns(nil, false, nil)
@@ -139,10 +161,11 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 			It("constructs the resource of the correct type", func() {
 				Expect(fakeResourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSessionCallCount()).To(Equal(1))
-				_, resourceType, resourceSource, resourceTypes, _ := fakeResourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSessionArgsForCall(0)
+				_, resourceType, resourceSource, resourceTypes, expiries := fakeResourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSessionArgsForCall(0)
 				Expect(resourceType).To(Equal("docker-image"))
 				Expect(resourceSource).To(Equal(atc.Source{"custom": "some-secret-sauce"}))
 				Expect(resourceTypes).To(Equal(creds.VersionedResourceTypes{}))
+				Expect(expiries).To(Equal(containerExpiries))
 
 				Expect(fakeResourceType.SetResourceConfigCallCount()).To(Equal(1))
 				resourceConfigID := fakeResourceType.SetResourceConfigArgsForCall(0)
@@ -232,7 +255,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 
 				It("checks from nil", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -244,7 +267,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 				It("checks with it", func() {
 					Expect(fakeResource.CheckCallCount()).To(Equal(1))
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "42"}))
 				})
 			})
@@ -268,7 +291,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(atc.Source{"custom": "some-secret-sauce"}))
@@ -506,7 +529,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 
 				It("checks from nil", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -518,7 +541,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 				It("checks with it", func() {
 					Expect(fakeResource.CheckCallCount()).To(Equal(1))
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "42"}))
 				})
 			})
@@ -542,7 +565,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(atc.Source{"custom": "some-secret-sauce"}))
@@ -636,4 +659,41 @@ var _ = Describe("ResourceTypeScanner", func() {
 			})
 		})
 	})
+
+	Describe("ScanFromVersionToDepth", func() {
+		var (
+			fakeResource *rfakes.FakeResource
+			depth        int
+			scanErr      error
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(rfakes.FakeResource)
+			fakeResourceFactory.NewResourceReturns(fakeResource, nil)
+			fakeDBPipeline.AcquireResourceTypeCheckingLockWithIntervalCheckReturns(fakeLock, true, nil)
+			depth = 1
+		})
+
+		JustBeforeEach(func() {
+			scanErr = scanner.ScanFromVersionToDepth(lagertest.NewTestLogger("test"), fakeResourceType.Name(), atc.Version{"custom": "from"}, depth)
+		})
+
+		It("checks once from the version", func() {
+			Expect(scanErr).NotTo(HaveOccurred())
+			Expect(fakeResource.CheckCallCount()).To(Equal(1))
+			_, _, version := fakeResource.CheckArgsForCall(0)
+			Expect(version).To(Equal(atc.Version{"custom": "from"}))
+		})
+
+		Context("when checking more than once in a row", func() {
+			BeforeEach(func() {
+				depth = 2
+			})
+
+			It("fails without checking", func() {
+				Expect(scanErr).To(Equal(ErrResourceTypeCheckDepthUnsupported))
+				Expect(fakeResource.CheckCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -24,6 +24,8 @@ type Runner struct {
 
 	scanning   *sync.Map
 	scanningWg *sync.WaitGroup
+
+	blocking *blockingResources
 }
 
 func NewRunner(
@@ -41,6 +43,7 @@ func NewRunner(
 		syncInterval:      syncInterval,
 		scanning:          &sync.Map{},
 		scanningWg:        &sync.WaitGroup{},
+		blocking:          &blockingResources{},
 	}
 }
 
@@ -92,12 +95,64 @@ func (r *Runner) tick(ctx context.Context) error {
 		return err
 	}
 
+	r.updateBlockingResources()
+
 	r.scanResourceTypes(ctx, resourceTypes.Configs())
 	r.scanResources(ctx, resources.Configs())
 
 	return nil
 }
 
+// updateBlockingResources finds the resources which the pending builds of
+// each job are waiting on for a version of one of their inputs, so that their
+// checks are prioritized. Inputs with passed constraints are left out, as
+// checking their resources would not help. If it fails, the resources found
+// by the previous tick are kept.
+//
+// It runs on every tick of every pipeline, so it makes the same two queries
+// however many jobs are waiting.
+func (r *Runner) updateBlockingResources() {
+	awaitingJobs, err := r.pipeline.PendingJobsAwaitingInputs()
+	if err != nil {
+		r.logger.Error("failed-to-get-jobs-awaiting-inputs", err)
+		return
+	}
+
+	blocking := map[string]bool{}
+	if len(awaitingJobs) == 0 {
+		r.blocking.set(blocking)
+		return
+	}
+
+	jobs, err := r.pipeline.Jobs()
+	if err != nil {
+		r.logger.Error("failed-to-get-jobs", err)
+		return
+	}
+
+	for _, job := range jobs {
+		availableInputs, found := awaitingJobs[job.Name()]
+		if !found {
+			continue
+		}
+
+		available := map[string]bool{}
+		for _, name := range availableInputs {
+			available[name] = true
+		}
+
+		for _, input := range job.Config().Inputs() {
+			if available[input.Name] || len(input.Passed) > 0 {
+				continue
+			}
+
+			blocking[input.Resource] = true
+		}
+	}
+
+	r.blocking.set(blocking)
+}
+
 func (r *Runner) scanResources(ctx context.Context, resources atc.ResourceConfigs) {
 	for _, resource := range resources {
 		scopedName := r.pipeline.ScopedName("resource:" + resource.Name)
@@ -114,7 +169,7 @@ func (r *Runner) scanResources(ctx context.Context, resources atc.ResourceConfig
 			defer r.scanningWg.Done()
 
 			r.scanning.Store(scopedName, true)
-			runner := r.scanRunnerFactory.ScanResourceRunner(logger, name)
+			runner := r.scanRunnerFactory.ScanResourceRunner(logger, name, r.blocking)
 			err := runner.Run(ctx)
 			if err != nil {
 				r.logger.Info("scanresources-runner-error", lager.Data{
@@ -153,3 +208,27 @@ func (r *Runner) scanResourceTypes(ctx context.Context, resourceTypes atc.Resour
 		}(resourceType.Name, scopedName)
 	}
 }
+
+// blockingResources is the CheckPrioritizer for a pipeline's resources,
+// prioritizing those which pending builds are waiting on.
+type blockingResources struct {
+	resources map[string]bool
+	lock      sync.RWMutex
+}
+
+func (blocking *blockingResources) set(resources map[string]bool) {
+	blocking.lock.Lock()
+	blocking.resources = resources
+	blocking.lock.Unlock()
+}
+
+func (blocking *blockingResources) CheckPriority(resourceName string) CheckPriority {
+	blocking.lock.RLock()
+	defer blocking.lock.RUnlock()
+
+	if blocking.resources[resourceName] {
+		return CheckPriorityBlocking
+	}
+
+	return CheckPriorityRoutine
+}
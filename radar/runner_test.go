@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/radar"
@@ -88,13 +89,96 @@ var _ = Describe("Runner", func() {
 	It("scans for every configured resource", func() {
 		Eventually(scanRunnerFactory.ScanResourceRunnerCallCount).Should(Equal(2))
 
-		_, call1Resource := scanRunnerFactory.ScanResourceRunnerArgsForCall(0)
-		_, call2Resource := scanRunnerFactory.ScanResourceRunnerArgsForCall(1)
+		_, call1Resource, _ := scanRunnerFactory.ScanResourceRunnerArgsForCall(0)
+		_, call2Resource, _ := scanRunnerFactory.ScanResourceRunnerArgsForCall(1)
 
 		resources := []string{call1Resource, call2Resource}
 		Expect(resources).To(ConsistOf([]string{"some-resource", "some-other-resource"}))
 	})
 
+	It("checks resources at routine priority", func() {
+		Eventually(scanRunnerFactory.ScanResourceRunnerCallCount).Should(Equal(2))
+
+		_, _, prioritizer := scanRunnerFactory.ScanResourceRunnerArgsForCall(0)
+		Expect(prioritizer.CheckPriority("some-resource")).To(Equal(CheckPriorityRoutine))
+	})
+
+	It("does not look up the jobs when none are awaiting inputs", func() {
+		Eventually(scanRunnerFactory.ScanResourceRunnerCallCount).Should(Equal(2))
+		Expect(fakePipeline.PendingJobsAwaitingInputsCallCount()).ToNot(BeZero())
+		Expect(fakePipeline.JobsCallCount()).To(BeZero())
+	})
+
+	Context("when a pending build is blocked on an input", func() {
+		var awaitingJobs map[string][]string
+
+		BeforeEach(func() {
+			awaitingJobs = map[string][]string{
+				"some-job": {"another-input"},
+			}
+			fakePipeline.PendingJobsAwaitingInputsReturns(awaitingJobs, nil)
+
+			fakeJob := new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+			fakeJob.ConfigReturns(atc.JobConfig{
+				Name: "some-job",
+				Plan: atc.PlanSequence{
+					{Get: "some-input", Resource: "some-resource"},
+					{Get: "some-other-input", Resource: "some-other-resource", Passed: []string{"some-upstream-job"}},
+					{Get: "another-input", Resource: "another-resource"},
+				},
+			})
+
+			otherJob := new(dbfakes.FakeJob)
+			otherJob.NameReturns("some-unblocked-job")
+			otherJob.ConfigReturns(atc.JobConfig{
+				Name: "some-unblocked-job",
+				Plan: atc.PlanSequence{
+					{Get: "unblocked-input", Resource: "unblocked-resource"},
+				},
+			})
+
+			fakePipeline.JobsReturns(db.Jobs{fakeJob, otherJob}, nil)
+		})
+
+		It("checks the input's resource at blocking priority", func() {
+			Eventually(scanRunnerFactory.ScanResourceRunnerCallCount).Should(Equal(2))
+
+			_, _, prioritizer := scanRunnerFactory.ScanResourceRunnerArgsForCall(0)
+			Expect(prioritizer.CheckPriority("some-resource")).To(Equal(CheckPriorityBlocking))
+			Expect(prioritizer.CheckPriority("another-resource")).To(Equal(CheckPriorityRoutine))
+			Expect(prioritizer.CheckPriority("unblocked-resource")).To(Equal(CheckPriorityRoutine))
+		})
+
+		It("does not prioritize inputs waiting on passed constraints", func() {
+			Eventually(scanRunnerFactory.ScanResourceRunnerCallCount).Should(Equal(2))
+
+			_, _, prioritizer := scanRunnerFactory.ScanResourceRunnerArgsForCall(0)
+			Expect(prioritizer.CheckPriority("some-other-resource")).To(Equal(CheckPriorityRoutine))
+		})
+
+		Context("when the build is no longer blocked", func() {
+			BeforeEach(func() {
+				fakePipeline.PendingJobsAwaitingInputsStub = func() (map[string][]string, error) {
+					if fakePipeline.PendingJobsAwaitingInputsCallCount() > 1 {
+						return map[string][]string{}, nil
+					}
+
+					return awaitingJobs, nil
+				}
+			})
+
+			It("goes back to checking it at routine priority", func() {
+				Eventually(scanRunnerFactory.ScanResourceRunnerCallCount).Should(Equal(2))
+
+				_, _, prioritizer := scanRunnerFactory.ScanResourceRunnerArgsForCall(0)
+				Eventually(func() CheckPriority {
+					return prioritizer.CheckPriority("some-resource")
+				}, 10*syncInterval).Should(Equal(CheckPriorityRoutine))
+			})
+		})
+	})
+
 	Context("when new resources are configured", func() {
 		BeforeEach(func() {
 			fakeResource3 := new(dbfakes.FakeResource)
@@ -106,14 +190,14 @@ var _ = Describe("Runner", func() {
 		It("scans for them eventually", func() {
 			Eventually(scanRunnerFactory.ScanResourceRunnerCallCount).Should(Equal(2))
 
-			_, call1Resource := scanRunnerFactory.ScanResourceRunnerArgsForCall(0)
-			_, call2Resource := scanRunnerFactory.ScanResourceRunnerArgsForCall(1)
+			_, call1Resource, _ := scanRunnerFactory.ScanResourceRunnerArgsForCall(0)
+			_, call2Resource, _ := scanRunnerFactory.ScanResourceRunnerArgsForCall(1)
 			resources := []string{call1Resource, call2Resource}
 			Expect(resources).To(ConsistOf([]string{"some-resource", "some-other-resource"}))
 
 			Eventually(scanRunnerFactory.ScanResourceRunnerCallCount, time.Second).Should(Equal(3))
 
-			_, call3Resource := scanRunnerFactory.ScanResourceRunnerArgsForCall(2)
+			_, call3Resource, _ := scanRunnerFactory.ScanResourceRunnerArgsForCall(2)
 			resources = append(resources, call3Resource)
 			Expect(resources).To(ConsistOf([]string{"some-resource", "some-other-resource", "another-resource"}))
 
@@ -135,8 +219,8 @@ var _ = Describe("Runner", func() {
 		It("starts scanning again eventually", func() {
 			Eventually(scanRunnerFactory.ScanResourceRunnerCallCount).Should(Equal(2))
 
-			_, call1Resource := scanRunnerFactory.ScanResourceRunnerArgsForCall(0)
-			_, call2Resource := scanRunnerFactory.ScanResourceRunnerArgsForCall(1)
+			_, call1Resource, _ := scanRunnerFactory.ScanResourceRunnerArgsForCall(0)
+			_, call2Resource, _ := scanRunnerFactory.ScanResourceRunnerArgsForCall(1)
 			resources := []string{call1Resource, call2Resource}
 
 			Expect(resources).To(ConsistOf([]string{"some-resource", "some-other-resource"}))
@@ -145,8 +229,8 @@ var _ = Describe("Runner", func() {
 
 			Eventually(scanRunnerFactory.ScanResourceRunnerCallCount, 10*syncInterval).Should(Equal(4))
 
-			_, call3Resource := scanRunnerFactory.ScanResourceRunnerArgsForCall(2)
-			_, call4Resource := scanRunnerFactory.ScanResourceRunnerArgsForCall(3)
+			_, call3Resource, _ := scanRunnerFactory.ScanResourceRunnerArgsForCall(2)
+			_, call4Resource, _ := scanRunnerFactory.ScanResourceRunnerArgsForCall(3)
 			resources = append(resources, call3Resource, call4Resource)
 			Expect(resources).To(ConsistOf([]string{"some-resource", "some-other-resource", "some-resource", "some-other-resource"}))
 
@@ -18,12 +18,18 @@ type Scanner interface {
 	Run(lager.Logger, string) (time.Duration, error)
 	Scan(lager.Logger, string) error
 	ScanFromVersion(lager.Logger, string, atc.Version) error
+
+	// ScanFromVersionToDepth runs up to depth checks one after another, the
+	// first from the version, or from no version at all if nil, and each
+	// after it from the newest version found by the one before. It stops once
+	// a check finds no new versions.
+	ScanFromVersionToDepth(lager.Logger, string, atc.Version, int) error
 }
 
 //go:generate counterfeiter . ScanRunnerFactory
 
 type ScanRunnerFactory interface {
-	ScanResourceRunner(lager.Logger, string) IntervalRunner
+	ScanResourceRunner(lager.Logger, string, CheckPrioritizer) IntervalRunner
 	ScanResourceTypeRunner(lager.Logger, string) IntervalRunner
 }
 
@@ -31,22 +37,32 @@ type scanRunnerFactory struct {
 	clock               clock.Clock
 	resourceScanner     Scanner
 	resourceTypeScanner Scanner
+	checkQueue          CheckQueue
+	defaultInterval     time.Duration
+	checkJitter         float64
 }
 
 func NewScanRunnerFactory(
 	resourceFactory resource.ResourceFactory,
 	resourceConfigCheckSessionFactory db.ResourceConfigCheckSessionFactory,
 	defaultInterval time.Duration,
+	checkTimeout time.Duration,
+	containerExpiries db.ContainerOwnerExpiries,
+	globalResources bool,
 	dbPipeline db.Pipeline,
 	clock clock.Clock,
 	externalURL string,
 	variables creds.Variables,
+	checkQueue CheckQueue,
+	checkJitter float64,
 ) ScanRunnerFactory {
 	resourceTypeScanner := NewResourceTypeScanner(
 		clock,
 		resourceFactory,
 		resourceConfigCheckSessionFactory,
 		defaultInterval,
+		checkTimeout,
+		containerExpiries,
 		dbPipeline,
 		externalURL,
 		variables,
@@ -57,6 +73,9 @@ func NewScanRunnerFactory(
 		resourceFactory,
 		resourceConfigCheckSessionFactory,
 		defaultInterval,
+		checkTimeout,
+		containerExpiries,
+		globalResources,
 		dbPipeline,
 		externalURL,
 		variables,
@@ -66,13 +85,16 @@ func NewScanRunnerFactory(
 		clock:               clock,
 		resourceScanner:     resourceScanner,
 		resourceTypeScanner: resourceTypeScanner,
+		checkQueue:          checkQueue,
+		defaultInterval:     defaultInterval,
+		checkJitter:         checkJitter,
 	}
 }
 
-func (sf *scanRunnerFactory) ScanResourceRunner(logger lager.Logger, name string) IntervalRunner {
-	return NewIntervalRunner(logger.Session("interval-runner"), sf.clock, name, sf.resourceScanner)
+func (sf *scanRunnerFactory) ScanResourceRunner(logger lager.Logger, name string, prioritizer CheckPrioritizer) IntervalRunner {
+	return NewIntervalRunner(logger.Session("interval-runner"), sf.clock, name, sf.resourceScanner, sf.checkQueue, prioritizer, sf.defaultInterval, sf.checkJitter)
 }
 
 func (sf *scanRunnerFactory) ScanResourceTypeRunner(logger lager.Logger, name string) IntervalRunner {
-	return NewIntervalRunner(logger.Session("interval-runner"), sf.clock, name, sf.resourceTypeScanner)
+	return NewIntervalRunner(logger.Session("interval-runner"), sf.clock, name, sf.resourceTypeScanner, sf.checkQueue, routineChecks{}, sf.defaultInterval, sf.checkJitter)
 }
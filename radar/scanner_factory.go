@@ -17,6 +17,9 @@ type scannerFactory struct {
 	resourceFactory                   resource.ResourceFactory
 	resourceConfigCheckSessionFactory db.ResourceConfigCheckSessionFactory
 	defaultInterval                   time.Duration
+	checkTimeout                      time.Duration
+	containerExpiries                 db.ContainerOwnerExpiries
+	globalResources                   bool
 	externalURL                       string
 	variablesFactory                  creds.VariablesFactory
 }
@@ -27,10 +30,36 @@ var ContainerExpiries = db.ContainerOwnerExpiries{
 	Max:       1 * time.Hour,
 }
 
+// CheckContainerExpiries returns the expiries of check containers which are
+// kept for the TTL, to be reused by every check of the same resource config
+// run within it. Zero means the default ContainerExpiries, which keep
+// containers for longer the longer the workers have been up.
+func CheckContainerExpiries(ttl time.Duration) db.ContainerOwnerExpiries {
+	if ttl == 0 {
+		return ContainerExpiries
+	}
+
+	// a container about to expire is not reused, so leave most of the TTL
+	// for reusing it even when the TTL is short
+	graceTime := ContainerExpiries.GraceTime
+	if graceTime > ttl/2 {
+		graceTime = ttl / 2
+	}
+
+	return db.ContainerOwnerExpiries{
+		GraceTime: graceTime,
+		Min:       ttl,
+		Max:       ttl,
+	}
+}
+
 func NewScannerFactory(
 	resourceFactory resource.ResourceFactory,
 	resourceConfigCheckSessionFactory db.ResourceConfigCheckSessionFactory,
 	defaultInterval time.Duration,
+	checkTimeout time.Duration,
+	containerExpiries db.ContainerOwnerExpiries,
+	globalResources bool,
 	externalURL string,
 	variablesFactory creds.VariablesFactory,
 ) ScannerFactory {
@@ -38,6 +67,9 @@ func NewScannerFactory(
 		resourceFactory:                   resourceFactory,
 		resourceConfigCheckSessionFactory: resourceConfigCheckSessionFactory,
 		defaultInterval:                   defaultInterval,
+		checkTimeout:                      checkTimeout,
+		containerExpiries:                 containerExpiries,
+		globalResources:                   globalResources,
 		externalURL:                       externalURL,
 		variablesFactory:                  variablesFactory,
 	}
@@ -49,6 +81,8 @@ func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) Scanner {
 		f.resourceFactory,
 		f.resourceConfigCheckSessionFactory,
 		f.defaultInterval,
+		f.checkTimeout,
+		f.containerExpiries,
 		dbPipeline,
 		f.externalURL,
 		f.variablesFactory.NewVariables(dbPipeline.TeamName(), dbPipeline.Name()),
@@ -58,6 +92,9 @@ func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) Scanner {
 		f.resourceFactory,
 		f.resourceConfigCheckSessionFactory,
 		f.defaultInterval,
+		f.checkTimeout,
+		f.containerExpiries,
+		f.globalResources,
 		dbPipeline,
 		f.externalURL,
 		f.variablesFactory.NewVariables(dbPipeline.TeamName(), dbPipeline.Name()),
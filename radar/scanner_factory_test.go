@@ -0,0 +1,30 @@
+package radar_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/db"
+	. "github.com/concourse/atc/radar"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckContainerExpiries", func() {
+	It("keeps containers for the TTL", func() {
+		Expect(CheckContainerExpiries(30 * time.Minute)).To(Equal(db.ContainerOwnerExpiries{
+			GraceTime: 2 * time.Minute,
+			Min:       30 * time.Minute,
+			Max:       30 * time.Minute,
+		}))
+	})
+
+	It("leaves half of a short TTL for reusing containers", func() {
+		Expect(CheckContainerExpiries(time.Minute).GraceTime).To(Equal(30 * time.Second))
+	})
+
+	Context("with no TTL", func() {
+		It("uses the default expiries", func() {
+			Expect(CheckContainerExpiries(0)).To(Equal(ContainerExpiries))
+		})
+	})
+})
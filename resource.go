@@ -13,3 +13,22 @@ type Resource struct {
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
 }
+
+// ResourceWebhookToken is a token with which external systems can trigger a
+// check of a resource. The token itself is only returned when it is created.
+type ResourceWebhookToken struct {
+	ID         int    `json:"id"`
+	Token      string `json:"token,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+	LastUsedAt int64  `json:"last_used_at,omitempty"`
+}
+
+// ResourceCheckResult is the outcome of a check of a resource.
+type ResourceCheckResult struct {
+	ID           int    `json:"id"`
+	StartTime    int64  `json:"start_time"`
+	EndTime      int64  `json:"end_time"`
+	Succeeded    bool   `json:"succeeded"`
+	Error        string `json:"error,omitempty"`
+	VersionCount int    `json:"version_count"`
+}
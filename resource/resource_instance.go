@@ -26,7 +26,7 @@ type ResourceInstance interface {
 
 	LockName(string) (string, error)
 
-	FindOn(lager.Logger, worker.Worker) (worker.Volume, bool, error)
+	FindOn(lager.Logger, worker.Worker, int) (worker.Volume, bool, error)
 }
 
 type resourceInstance struct {
@@ -103,9 +103,10 @@ func (instance resourceInstance) LockName(workerName string) (string, error) {
 	return fmt.Sprintf("%x", sha256.Sum256(taskNameJSON)), nil
 }
 
-func (instance resourceInstance) FindOn(logger lager.Logger, workerClient worker.Worker) (worker.Volume, bool, error) {
+func (instance resourceInstance) FindOn(logger lager.Logger, workerClient worker.Worker, teamID int) (worker.Volume, bool, error) {
 	return workerClient.FindVolumeForResourceCache(
 		logger,
+		teamID,
 		instance.resourceCache,
 	)
 }
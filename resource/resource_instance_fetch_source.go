@@ -56,7 +56,7 @@ func (s *resourceInstanceFetchSource) LockName() (string, error) {
 func (s *resourceInstanceFetchSource) Find() (VersionedSource, bool, error) {
 	sLog := s.logger.Session("find")
 
-	volume, found, err := s.resourceInstance.FindOn(s.logger, s.worker)
+	volume, found, err := s.resourceInstance.FindOn(s.logger, s.worker, s.teamID)
 	if err != nil {
 		sLog.Error("failed-to-find-initialized-on", err)
 		return nil, false, err
@@ -66,6 +66,8 @@ func (s *resourceInstanceFetchSource) Find() (VersionedSource, bool, error) {
 		return nil, false, nil
 	}
 
+	s.recordFetch(sLog, volume)
+
 	metadata, err := s.dbResourceCacheFactory.ResourceCacheMetadata(s.resourceInstance.ResourceCache())
 	if err != nil {
 		sLog.Error("failed-to-get-resource-cache-metadata", err)
@@ -161,6 +163,8 @@ func (s *resourceInstanceFetchSource) Create(ctx context.Context) (VersionedSour
 		return nil, err
 	}
 
+	s.recordFetch(sLog, volume)
+
 	err = s.dbResourceCacheFactory.UpdateResourceCacheMetadata(s.resourceInstance.ResourceCache(), versionedSource.Metadata())
 	if err != nil {
 		s.logger.Error("failed-to-update-resource-cache-metadata", err, lager.Data{"resource-cache": s.resourceInstance.ResourceCache()})
@@ -169,3 +173,12 @@ func (s *resourceInstanceFetchSource) Create(ctx context.Context) (VersionedSour
 
 	return versionedSource, nil
 }
+
+// recordFetch counts the fetch towards the cache being copied onto other
+// workers. Failing to count it is not worth failing the fetch over.
+func (s *resourceInstanceFetchSource) recordFetch(logger lager.Logger, volume worker.Volume) {
+	err := volume.RecordFetch()
+	if err != nil {
+		logger.Error("failed-to-record-fetch", err)
+	}
+}
@@ -128,6 +128,24 @@ var _ = Describe("ResourceInstanceFetchSource", func() {
 				Expect(found).To(BeTrue())
 				Expect(versionedSource).To(Equal(expectedInitializedVersionedSource))
 			})
+
+			It("records the fetch of the cache", func() {
+				_, _, err := fetchSource.Find()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeVolume.RecordFetchCallCount()).To(Equal(1))
+			})
+
+			Context("when recording the fetch fails", func() {
+				BeforeEach(func() {
+					fakeVolume.RecordFetchReturns(errors.New("disaster"))
+				})
+
+				It("still finds the volume", func() {
+					_, found, err := fetchSource.Find()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+			})
 		})
 
 		Context("when there is no volume", func() {
@@ -217,6 +235,11 @@ var _ = Describe("ResourceInstanceFetchSource", func() {
 				Expect(rc).To(Equal(resourceCache))
 			})
 
+			It("records the fetch of the cache", func() {
+				Expect(initErr).NotTo(HaveOccurred())
+				Expect(fakeVolume.RecordFetchCallCount()).To(Equal(1))
+			})
+
 			It("updates resource cache metadata", func() {
 				Expect(fakeResourceCacheFactory.UpdateResourceCacheMetadataCallCount()).To(Equal(1))
 				passedResourceCache, _ := fakeResourceCacheFactory.UpdateResourceCacheMetadataArgsForCall(0)
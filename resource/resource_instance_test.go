@@ -48,7 +48,7 @@ var _ = Describe("ResourceInstance", func() {
 		)
 
 		JustBeforeEach(func() {
-			foundVolume, found, findErr = resourceInstance.FindOn(logger, fakeWorker)
+			foundVolume, found, findErr = resourceInstance.FindOn(logger, fakeWorker, 42)
 		})
 
 		Context("when initialized volume for resource cache exists on worker", func() {
@@ -64,6 +64,11 @@ var _ = Describe("ResourceInstance", func() {
 				Expect(found).To(BeTrue())
 				Expect(foundVolume).To(Equal(fakeVolume))
 			})
+
+			It("looks for the team's cache", func() {
+				_, teamID, _ := fakeWorker.FindVolumeForResourceCacheArgsForCall(0)
+				Expect(teamID).To(Equal(42))
+			})
 		})
 
 		Context("when initialized volume for resource cache does not exist on worker", func() {
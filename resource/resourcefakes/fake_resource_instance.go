@@ -79,11 +79,12 @@ type FakeResourceInstance struct {
 		result1 string
 		result2 error
 	}
-	FindOnStub        func(lager.Logger, worker.Worker) (worker.Volume, bool, error)
+	FindOnStub        func(lager.Logger, worker.Worker, int) (worker.Volume, bool, error)
 	findOnMutex       sync.RWMutex
 	findOnArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 worker.Worker
+		arg3 int
 	}
 	findOnReturns struct {
 		result1 worker.Volume
@@ -390,17 +391,18 @@ func (fake *FakeResourceInstance) LockNameReturnsOnCall(i int, result1 string, r
 	}{result1, result2}
 }
 
-func (fake *FakeResourceInstance) FindOn(arg1 lager.Logger, arg2 worker.Worker) (worker.Volume, bool, error) {
+func (fake *FakeResourceInstance) FindOn(arg1 lager.Logger, arg2 worker.Worker, arg3 int) (worker.Volume, bool, error) {
 	fake.findOnMutex.Lock()
 	ret, specificReturn := fake.findOnReturnsOnCall[len(fake.findOnArgsForCall)]
 	fake.findOnArgsForCall = append(fake.findOnArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 worker.Worker
-	}{arg1, arg2})
-	fake.recordInvocation("FindOn", []interface{}{arg1, arg2})
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("FindOn", []interface{}{arg1, arg2, arg3})
 	fake.findOnMutex.Unlock()
 	if fake.FindOnStub != nil {
-		return fake.FindOnStub(arg1, arg2)
+		return fake.FindOnStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -414,10 +416,10 @@ func (fake *FakeResourceInstance) FindOnCallCount() int {
 	return len(fake.findOnArgsForCall)
 }
 
-func (fake *FakeResourceInstance) FindOnArgsForCall(i int) (lager.Logger, worker.Worker) {
+func (fake *FakeResourceInstance) FindOnArgsForCall(i int) (lager.Logger, worker.Worker, int) {
 	fake.findOnMutex.RLock()
 	defer fake.findOnMutex.RUnlock()
-	return fake.findOnArgsForCall[i].arg1, fake.findOnArgsForCall[i].arg2
+	return fake.findOnArgsForCall[i].arg1, fake.findOnArgsForCall[i].arg2, fake.findOnArgsForCall[i].arg3
 }
 
 func (fake *FakeResourceInstance) FindOnReturns(result1 worker.Volume, result2 bool, result3 error) {
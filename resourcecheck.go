@@ -2,6 +2,14 @@ package atc
 
 type CheckRequestBody struct {
 	From Version `json:"from"`
+
+	// FromScratch checks from no version at all, rather than from the latest
+	// version, as a resource's first check does.
+	FromScratch bool `json:"from_scratch,omitempty"`
+
+	// Depth is the number of checks to run one after another, each from the
+	// newest version found by the one before. Zero means one.
+	Depth int `json:"depth,omitempty"`
 }
 
 type CheckResponseBody struct {
@@ -3,17 +3,22 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig             = "SaveConfig"
+	GetConfig              = "GetConfig"
+	ListConfigRevisions    = "ListConfigRevisions"
+	RollbackConfigRevision = "RollbackConfigRevision"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
+	GetBuildPlanGraph   = "GetBuildPlanGraph"
 	CreateBuild         = "CreateBuild"
 	ListBuilds          = "ListBuilds"
 	BuildEvents         = "BuildEvents"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
 	GetBuildPreparation = "GetBuildPreparation"
+	GetBuildEnvironment = "GetBuildEnvironment"
+	GetBuildUsage       = "GetBuildUsage"
 
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
@@ -24,6 +29,8 @@ const (
 	GetJobBuild    = "GetJobBuild"
 	PauseJob       = "PauseJob"
 	UnpauseJob     = "UnpauseJob"
+	PauseJobs      = "PauseJobs"
+	UnpauseJobs    = "UnpauseJobs"
 	GetVersionsDB  = "GetVersionsDB"
 	JobBadge       = "JobBadge"
 	MainJobBadge   = "MainJobBadge"
@@ -35,13 +42,21 @@ const (
 	CheckResource        = "CheckResource"
 	CheckResourceWebHook = "CheckResourceWebHook"
 
-	ListResourceVersions          = "ListResourceVersions"
-	GetResourceVersion            = "GetResourceVersion"
-	EnableResourceVersion         = "EnableResourceVersion"
-	DisableResourceVersion        = "DisableResourceVersion"
-	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
-	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
-	GetResourceCausality          = "GetResourceCausality"
+	ListResourceWebhookTokens  = "ListResourceWebhookTokens"
+	CreateResourceWebhookToken = "CreateResourceWebhookToken"
+	DeleteResourceWebhookToken = "DeleteResourceWebhookToken"
+	ListResourceCheckResults   = "ListResourceCheckResults"
+
+	ListResourceVersions            = "ListResourceVersions"
+	GetResourceVersion              = "GetResourceVersion"
+	EnableResourceVersion           = "EnableResourceVersion"
+	DisableResourceVersion          = "DisableResourceVersion"
+	AnnotateResourceVersion         = "AnnotateResourceVersion"
+	RemoveResourceVersionAnnotation = "RemoveResourceVersionAnnotation"
+	ListBuildsWithVersionAsInput    = "ListBuildsWithVersionAsInput"
+	ListBuildsWithVersionAsOutput   = "ListBuildsWithVersionAsOutput"
+	GetResourceCausality            = "GetResourceCausality"
+	SearchResourceVersions          = "SearchResourceVersions"
 
 	ListAllPipelines    = "ListAllPipelines"
 	ListPipelines       = "ListPipelines"
@@ -56,14 +71,21 @@ const (
 	ListPipelineBuilds  = "ListPipelineBuilds"
 	CreatePipelineBuild = "CreatePipelineBuild"
 	PipelineBadge       = "PipelineBadge"
-
-	RegisterWorker  = "RegisterWorker"
-	LandWorker      = "LandWorker"
-	RetireWorker    = "RetireWorker"
-	PruneWorker     = "PruneWorker"
-	HeartbeatWorker = "HeartbeatWorker"
-	ListWorkers     = "ListWorkers"
-	DeleteWorker    = "DeleteWorker"
+	GetBuildCalendar    = "GetBuildCalendar"
+
+	RegisterWorker       = "RegisterWorker"
+	LandWorker           = "LandWorker"
+	RetireWorker         = "RetireWorker"
+	PruneWorker          = "PruneWorker"
+	HeartbeatWorker      = "HeartbeatWorker"
+	ListWorkers          = "ListWorkers"
+	DeleteWorker         = "DeleteWorker"
+	ListWorkerContainers = "ListWorkerContainers"
+	ListWorkerVolumes    = "ListWorkerVolumes"
+
+	ListWorkerMaintenanceWindows = "ListWorkerMaintenanceWindows"
+	ScheduleWorkerMaintenance    = "ScheduleWorkerMaintenance"
+	CancelWorkerMaintenance      = "CancelWorkerMaintenance"
 
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
@@ -81,31 +103,45 @@ const (
 	LegacyGetAuthToken    = "LegacyGetAuthToken"
 	LegacyGetUser         = "LegacyGetUser"
 
-	ListTeams      = "ListTeams"
-	SetTeam        = "SetTeam"
-	RenameTeam     = "RenameTeam"
-	DestroyTeam    = "DestroyTeam"
-	ListTeamBuilds = "ListTeamBuilds"
+	ListTeams           = "ListTeams"
+	SetTeam             = "SetTeam"
+	RenameTeam          = "RenameTeam"
+	DestroyTeam         = "DestroyTeam"
+	ListTeamBuilds      = "ListTeamBuilds"
+	ListTeamAuditEvents = "ListTeamAuditEvents"
+	GetTeamCacheUsage   = "GetTeamCacheUsage"
+	ListTeamCacheUsages = "ListTeamCacheUsages"
 
 	SendInputToBuildPlan    = "SendInputToBuildPlan"
 	ReadOutputFromBuildPlan = "ReadOutputFromBuildPlan"
+	ReplayBuildPlan         = "ReplayBuildPlan"
+	GetBuildPlanReplay      = "GetBuildPlanReplay"
+	RerunBuildPlan          = "RerunBuildPlan"
 )
 
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions", Method: "GET", Name: ListConfigRevisions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions/:revision_id/rollback", Method: "PUT", Name: RollbackConfigRevision},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
 	{Path: "/api/v1/builds", Method: "GET", Name: ListBuilds},
 	{Path: "/api/v1/builds/:build_id", Method: "GET", Name: GetBuild},
 	{Path: "/api/v1/builds/:build_id/plan", Method: "GET", Name: GetBuildPlan},
+	{Path: "/api/v1/builds/:build_id/plan/graph", Method: "GET", Name: GetBuildPlanGraph},
 	{Path: "/api/v1/builds/:build_id/plan/:plan_id/input", Method: "PUT", Name: SendInputToBuildPlan},
 	{Path: "/api/v1/builds/:build_id/plan/:plan_id/output", Method: "GET", Name: ReadOutputFromBuildPlan},
+	{Path: "/api/v1/builds/:build_id/plan/:plan_id/replay", Method: "POST", Name: ReplayBuildPlan},
+	{Path: "/api/v1/builds/:build_id/plan/:plan_id/replay", Method: "GET", Name: GetBuildPlanReplay},
+	{Path: "/api/v1/builds/:build_id/plan/:plan_id/rerun", Method: "POST", Name: RerunBuildPlan},
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
+	{Path: "/api/v1/builds/:build_id/environment", Method: "GET", Name: GetBuildEnvironment},
+	{Path: "/api/v1/builds/:build_id/usage", Method: "GET", Name: GetBuildUsage},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
@@ -118,6 +154,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: MainJobBadge},
+	{Path: "/api/v1/teams/:team_name/jobs/pause", Method: "PUT", Name: PauseJobs},
+	{Path: "/api/v1/teams/:team_name/jobs/unpause", Method: "PUT", Name: UnpauseJobs},
 
 	{Path: "/api/v1/pipelines", Method: "GET", Name: ListAllPipelines},
 	{Path: "/api/v1/teams/:team_name/pipelines", Method: "GET", Name: ListPipelines},
@@ -132,6 +170,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/rename", Method: "PUT", Name: RenamePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "GET", Name: ListPipelineBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "POST", Name: CreatePipelineBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds/calendar", Method: "GET", Name: GetBuildCalendar},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/badge", Method: "GET", Name: PipelineBadge},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
@@ -140,14 +179,21 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/webhook_tokens", Method: "GET", Name: ListResourceWebhookTokens},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/webhook_tokens", Method: "POST", Name: CreateResourceWebhookToken},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/webhook_tokens/:token_id", Method: "DELETE", Name: DeleteResourceWebhookToken},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check_results", Method: "GET", Name: ListResourceCheckResults},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id", Method: "GET", Name: GetResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/annotations/:annotation_name", Method: "PUT", Name: AnnotateResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/annotations/:annotation_name", Method: "DELETE", Name: RemoveResourceVersionAnnotation},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/causality", Method: "GET", Name: GetResourceCausality},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource_versions", Method: "GET", Name: SearchResourceVersions},
 
 	{Path: "/api/v1/workers", Method: "GET", Name: ListWorkers},
 	{Path: "/api/v1/workers", Method: "POST", Name: RegisterWorker},
@@ -156,6 +202,11 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
 	{Path: "/api/v1/workers/:worker_name/heartbeat", Method: "PUT", Name: HeartbeatWorker},
 	{Path: "/api/v1/workers/:worker_name", Method: "DELETE", Name: DeleteWorker},
+	{Path: "/api/v1/workers/:worker_name/containers", Method: "GET", Name: ListWorkerContainers},
+	{Path: "/api/v1/workers/:worker_name/volumes", Method: "GET", Name: ListWorkerVolumes},
+	{Path: "/api/v1/workers/:worker_name/maintenance_windows", Method: "GET", Name: ListWorkerMaintenanceWindows},
+	{Path: "/api/v1/workers/:worker_name/maintenance_windows", Method: "POST", Name: ScheduleWorkerMaintenance},
+	{Path: "/api/v1/workers/:worker_name/maintenance_windows/:window_id", Method: "DELETE", Name: CancelWorkerMaintenance},
 
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
 	{Path: "/api/v1/log-level", Method: "PUT", Name: SetLogLevel},
@@ -178,4 +229,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/teams/:team_name/audit_events", Method: "GET", Name: ListTeamAuditEvents},
+	{Path: "/api/v1/teams/:team_name/cache_usage", Method: "GET", Name: GetTeamCacheUsage},
+	{Path: "/api/v1/cache_usage", Method: "GET", Name: ListTeamCacheUsages},
 })
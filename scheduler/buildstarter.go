@@ -1,10 +1,13 @@
 package scheduler
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/maxinflight"
 )
@@ -34,6 +37,7 @@ func NewBuildStarter(
 	scanner Scanner,
 	inputMapper inputmapper.InputMapper,
 	execEngine engine.Engine,
+	limits atc.JobLimits,
 ) BuildStarter {
 	return &buildStarter{
 		pipeline:           pipeline,
@@ -42,6 +46,7 @@ func NewBuildStarter(
 		scanner:            scanner,
 		inputMapper:        inputMapper,
 		execEngine:         execEngine,
+		limits:             limits,
 	}
 }
 
@@ -52,6 +57,7 @@ type buildStarter struct {
 	execEngine         engine.Engine
 	scanner            Scanner
 	inputMapper        inputmapper.InputMapper
+	limits             atc.JobLimits
 }
 
 func (s *buildStarter) TryStartPendingBuildsForJob(
@@ -95,45 +101,78 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
+	if nextPendingBuild.RerunOf() != 0 {
+		return s.tryStartRerun(logger, nextPendingBuild, job)
+	}
+
+	checkpoint, err := nextPendingBuild.SchedulingCheckpoint()
+	if err != nil {
+		logger.Error("failed-to-get-scheduling-checkpoint", err)
+		return false, err
+	}
+
+	if checkpoint != db.BuildSchedulingCheckpointNone {
+		logger.Info("resuming-from-checkpoint", lager.Data{"checkpoint": checkpoint})
+	}
+
 	if nextPendingBuild.IsManuallyTriggered() {
-		jobBuildInputs := job.Config().Inputs()
-		for _, input := range jobBuildInputs {
-			scanLog := logger.Session("scan", lager.Data{
-				"input":    input.Name,
-				"resource": input.Resource,
-			})
+		if !checkpoint.Reached(db.BuildSchedulingCheckpointInputsScanned) {
+			jobBuildInputs := job.Config().Inputs()
+			for _, input := range jobBuildInputs {
+				scanLog := logger.Session("scan", lager.Data{
+					"input":    input.Name,
+					"resource": input.Resource,
+				})
+
+				err := s.scanner.Scan(scanLog, input.Resource)
+				if err != nil {
+					return false, err
+				}
+			}
+
+			versions, err := s.pipeline.LoadVersionsDB()
+			if err != nil {
+				logger.Error("failed-to-load-versions-db", err)
+				return false, err
+			}
+
+			_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job)
+			if err != nil {
+				return false, err
+			}
 
-			err := s.scanner.Scan(scanLog, input.Resource)
+			checkpoint, err = s.saveCheckpoint(logger, nextPendingBuild, db.BuildSchedulingCheckpointInputsScanned)
 			if err != nil {
 				return false, err
 			}
 		}
 
-		versions, err := s.pipeline.LoadVersionsDB()
+		dbResourceTypes, err := s.pipeline.ResourceTypes()
 		if err != nil {
-			logger.Error("failed-to-load-versions-db", err)
 			return false, err
 		}
+		resourceTypes = dbResourceTypes.Deserialize()
+	}
 
-		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job)
+	var buildInputs []db.BuildInput
+	if checkpoint.Reached(db.BuildSchedulingCheckpointInputsResolved) {
+		// keep the inputs chosen before the failure; the next inputs for the job
+		// may have moved on since
+		buildInputs, _, err = nextPendingBuild.Resources()
 		if err != nil {
+			logger.Error("failed-to-get-checkpointed-build-inputs", err)
 			return false, err
 		}
-
-		dbResourceTypes, err := s.pipeline.ResourceTypes()
+	} else {
+		var found bool
+		buildInputs, found, err = job.GetNextBuildInputs()
 		if err != nil {
+			logger.Error("failed-to-get-next-build-inputs", err)
 			return false, err
 		}
-		resourceTypes = dbResourceTypes.Deserialize()
-	}
-
-	buildInputs, found, err := job.GetNextBuildInputs()
-	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
-		return false, err
-	}
-	if !found {
-		return false, nil
+		if !found {
+			return false, nil
+		}
 	}
 
 	pipelinePaused, err := s.pipeline.CheckPaused()
@@ -160,9 +199,16 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	err = nextPendingBuild.UseInputs(buildInputs)
-	if err != nil {
-		return false, err
+	if !checkpoint.Reached(db.BuildSchedulingCheckpointInputsResolved) {
+		err = nextPendingBuild.UseInputs(buildInputs)
+		if err != nil {
+			return false, err
+		}
+
+		checkpoint, err = s.saveCheckpoint(logger, nextPendingBuild, db.BuildSchedulingCheckpointInputsResolved)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	resourceConfigs := atc.ResourceConfigs{}
@@ -175,7 +221,11 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		})
 	}
 
-	plan, err := s.factory.Create(job.Config(), resourceConfigs, resourceTypes, buildInputs)
+	// the plan is not persisted, as it may contain credentials; it is
+	// recreated from the checkpointed inputs instead
+	jobConfig, clampedSettings := s.limits.Clamp(job.Config())
+
+	plan, err := s.factory.Create(jobConfig, resourceConfigs, resourceTypes, buildInputs)
 	if err != nil {
 		// Don't use ErrorBuild because it logs a build event, and this build hasn't started
 		err := nextPendingBuild.Finish(db.BuildStatusErrored)
@@ -185,6 +235,26 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
+	if !checkpoint.Reached(db.BuildSchedulingCheckpointPlanCreated) {
+		for _, clamped := range clampedSettings {
+			err = nextPendingBuild.SaveEvent(event.SettingClamped{
+				Time:      time.Now().Unix(),
+				Setting:   clamped.Setting,
+				Requested: clamped.Requested,
+				Applied:   clamped.Applied,
+			})
+			if err != nil {
+				logger.Error("failed-to-save-setting-clamped-event", err)
+				return false, err
+			}
+		}
+
+		_, err = s.saveCheckpoint(logger, nextPendingBuild, db.BuildSchedulingCheckpointPlanCreated)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	createdBuild, err := s.execEngine.CreateBuild(logger, nextPendingBuild, plan)
 	if err != nil {
 		logger.Error("failed-to-create-build", err)
@@ -197,3 +267,92 @@ func (s *buildStarter) tryStartNextPendingBuild(
 
 	return true, nil
 }
+
+// tryStartRerun starts a build rerunning another from one of its steps once
+// the job can run it. Its inputs were copied from the original build and its
+// plan is the original's, so neither is chosen afresh.
+func (s *buildStarter) tryStartRerun(
+	logger lager.Logger,
+	rerun db.Build,
+	job db.Job,
+) (bool, error) {
+	pipelinePaused, err := s.pipeline.CheckPaused()
+	if err != nil {
+		logger.Error("failed-to-check-if-pipeline-is-paused", err)
+		return false, err
+	}
+	if pipelinePaused {
+		return false, nil
+	}
+
+	if job.Paused() {
+		return false, nil
+	}
+
+	original, found, err := rerun.RerunOfBuild()
+	if err != nil {
+		logger.Error("failed-to-find-rerun-build", err)
+		return false, err
+	}
+
+	if !found {
+		logger.Info("rerun-build-not-found", lager.Data{"rerun-of": rerun.RerunOf()})
+		s.errorRerun(logger, rerun)
+		return false, nil
+	}
+
+	originalBuild, err := s.execEngine.LookupBuild(logger, original)
+	if err != nil {
+		logger.Error("failed-to-lookup-rerun-build", err)
+		s.errorRerun(logger, rerun)
+		return false, nil
+	}
+
+	planned, err := originalBuild.PlanRerun(logger, rerun.RerunFrom())
+	if err != nil {
+		logger.Error("failed-to-plan-rerun", err, lager.Data{"plan": rerun.RerunFrom()})
+		s.errorRerun(logger, rerun)
+		return false, nil
+	}
+
+	updated, err := rerun.Schedule()
+	if err != nil {
+		logger.Error("failed-to-update-build-to-scheduled", err)
+		return false, err
+	}
+
+	if !updated {
+		logger.Debug("build-already-scheduled")
+		return false, nil
+	}
+
+	createdBuild, err := s.execEngine.CreateBuild(logger, rerun, planned.Plan)
+	if err != nil {
+		logger.Error("failed-to-create-build", err)
+		return false, nil
+	}
+
+	logger.Info("starting-rerun")
+
+	go createdBuild.Resume(logger)
+
+	return true, nil
+}
+
+func (s *buildStarter) errorRerun(logger lager.Logger, rerun db.Build) {
+	// Don't use ErrorBuild because it logs a build event, and this build hasn't started
+	err := rerun.Finish(db.BuildStatusErrored)
+	if err != nil {
+		logger.Error("failed-to-mark-build-as-errored", err)
+	}
+}
+
+func (s *buildStarter) saveCheckpoint(logger lager.Logger, build db.Build, checkpoint db.BuildSchedulingCheckpoint) (db.BuildSchedulingCheckpoint, error) {
+	err := build.SaveSchedulingCheckpoint(checkpoint)
+	if err != nil {
+		logger.Error("failed-to-save-scheduling-checkpoint", err, lager.Data{"checkpoint": checkpoint})
+		return db.BuildSchedulingCheckpointNone, err
+	}
+
+	return checkpoint, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/scheduler/inputmapper/inputmapperfakes"
 	"github.com/concourse/atc/scheduler/maxinflight/maxinflightfakes"
@@ -30,6 +31,7 @@ var _ = Describe("I'm a BuildStarter", func() {
 		fakeScanner      *schedulerfakes.FakeScanner
 		fakeInputMapper  *inputmapperfakes.FakeInputMapper
 		fakeBuildStarter *schedulerfakes.FakeBuildStarter
+		limits           atc.JobLimits
 
 		buildStarter scheduler.BuildStarter
 
@@ -44,12 +46,15 @@ var _ = Describe("I'm a BuildStarter", func() {
 		fakeScanner = new(schedulerfakes.FakeScanner)
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
-
-		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine)
+		limits = atc.JobLimits{}
 
 		disaster = errors.New("bad thing")
 	})
 
+	JustBeforeEach(func() {
+		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine, limits)
+	})
+
 	Describe("TryStartPendingBuildsForJob", func() {
 		var tryStartErr error
 		var createdBuild *dbfakes.FakeBuild
@@ -118,6 +123,24 @@ var _ = Describe("I'm a BuildStarter", func() {
 					Expect(fakeScanner.ScanCallCount()).To(Equal(2))
 				})
 
+				Context("when the build was checkpointed with its inputs scanned", func() {
+					BeforeEach(func() {
+						createdBuild.SchedulingCheckpointReturns(db.BuildSchedulingCheckpointInputsScanned, nil)
+					})
+
+					It("does not run resource check", func() {
+						Expect(fakeScanner.ScanCallCount()).To(Equal(0))
+					})
+
+					It("does not save the next input mapping", func() {
+						Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(Equal(0))
+					})
+
+					It("reloads the resource types list", func() {
+						Expect(fakePipeline.ResourceTypesCallCount()).To(Equal(1))
+					})
+				})
+
 				Context("when resource checking fails", func() {
 					BeforeEach(func() {
 						fakeScanner.ScanReturns(disaster)
@@ -377,6 +400,48 @@ var _ = Describe("I'm a BuildStarter", func() {
 						pendingBuilds = []db.Build{pendingBuild1, pendingBuild2, pendingBuild3}
 					})
 
+					Context("when the build was checkpointed with its inputs resolved", func() {
+						BeforeEach(func() {
+							pendingBuild1.SchedulingCheckpointReturns(db.BuildSchedulingCheckpointInputsResolved, nil)
+							pendingBuild1.ResourcesReturns([]db.BuildInput{{Name: "some-checkpointed-input"}}, nil, nil)
+							fakeFactory.CreateReturns(atc.Plan{}, nil)
+							fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+						})
+
+						It("doesn't use new inputs for the build", func() {
+							Expect(pendingBuild1.UseInputsCallCount()).To(BeZero())
+						})
+
+						It("creates the build plan from the checkpointed inputs", func() {
+							_, _, _, actualBuildInputs := fakeFactory.CreateArgsForCall(0)
+							Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-checkpointed-input"}}))
+						})
+
+						It("only checkpoints the plan being created", func() {
+							Expect(pendingBuild1.SaveSchedulingCheckpointCallCount()).To(Equal(1))
+							Expect(pendingBuild1.SaveSchedulingCheckpointArgsForCall(0)).To(Equal(db.BuildSchedulingCheckpointPlanCreated))
+						})
+
+						It("resumes starting the build", func() {
+							_, actualBuild, _ := fakeEngine.CreateBuildArgsForCall(0)
+							Expect(actualBuild).To(Equal(pendingBuild1))
+						})
+					})
+
+					Context("when getting the scheduling checkpoint fails", func() {
+						BeforeEach(func() {
+							pendingBuild1.SchedulingCheckpointReturns(db.BuildSchedulingCheckpointNone, disaster)
+						})
+
+						It("returns the error", func() {
+							Expect(tryStartErr).To(Equal(disaster))
+						})
+
+						It("doesn't mark the build as scheduled", func() {
+							Expect(pendingBuild1.ScheduleCallCount()).To(BeZero())
+						})
+					})
+
 					Context("when marking the build as scheduled fails", func() {
 						BeforeEach(func() {
 							pendingBuild1.ScheduleReturns(false, disaster)
@@ -478,6 +543,66 @@ var _ = Describe("I'm a BuildStarter", func() {
 									fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
 								})
 
+								It("checkpoints each build once its inputs are resolved and its plan is created", func() {
+									Expect(pendingBuild1.SaveSchedulingCheckpointCallCount()).To(Equal(2))
+									Expect(pendingBuild1.SaveSchedulingCheckpointArgsForCall(0)).To(Equal(db.BuildSchedulingCheckpointInputsResolved))
+									Expect(pendingBuild1.SaveSchedulingCheckpointArgsForCall(1)).To(Equal(db.BuildSchedulingCheckpointPlanCreated))
+								})
+
+								It("does not record any clamped settings", func() {
+									Expect(pendingBuild1.SaveEventCallCount()).To(BeZero())
+								})
+
+								Context("when the job's settings exceed the job limits", func() {
+									BeforeEach(func() {
+										limits = atc.JobLimits{MaxBuildLogsToRetain: 10}
+										job.ConfigReturns(atc.JobConfig{Name: "some-job", BuildLogsToRetain: 20})
+									})
+
+									It("creates the build plan from the clamped job config", func() {
+										actualJobConfig, _, _, _ := fakeFactory.CreateArgsForCall(0)
+										Expect(actualJobConfig).To(Equal(atc.JobConfig{Name: "some-job", BuildLogsToRetain: 10}))
+									})
+
+									It("records the clamped settings on the build", func() {
+										Expect(pendingBuild1.SaveEventCallCount()).To(Equal(1))
+
+										clamped, ok := pendingBuild1.SaveEventArgsForCall(0).(event.SettingClamped)
+										Expect(ok).To(BeTrue())
+										Expect(clamped.Setting).To(Equal("build_logs_to_retain"))
+										Expect(clamped.Requested).To(Equal("20"))
+										Expect(clamped.Applied).To(Equal("10"))
+									})
+
+									Context("when recording the clamped settings fails", func() {
+										BeforeEach(func() {
+											pendingBuild1.SaveEventReturns(disaster)
+										})
+
+										It("returns the error", func() {
+											Expect(tryStartErr).To(Equal(disaster))
+										})
+
+										It("doesn't checkpoint the plan being created", func() {
+											Expect(pendingBuild1.SaveSchedulingCheckpointCallCount()).To(Equal(1))
+										})
+									})
+								})
+
+								Context("when saving the checkpoint fails", func() {
+									BeforeEach(func() {
+										pendingBuild1.SaveSchedulingCheckpointReturns(disaster)
+									})
+
+									It("returns the error", func() {
+										Expect(tryStartErr).To(Equal(disaster))
+									})
+
+									It("doesn't create the engine build", func() {
+										Expect(fakeEngine.CreateBuildCallCount()).To(BeZero())
+									})
+								})
+
 								It("creates build plans for all builds", func() {
 									Expect(fakeFactory.CreateCallCount()).To(Equal(3))
 									actualJobConfig, actualResourceConfigs, actualResourceTypes, actualBuildInputs := fakeFactory.CreateArgsForCall(0)
@@ -629,5 +754,130 @@ var _ = Describe("I'm a BuildStarter", func() {
 				})
 			})
 		})
+
+		Context("when rerunning a build", func() {
+			var originalBuild *dbfakes.FakeBuild
+			var engineOriginal *enginefakes.FakeBuild
+			var rerunPlan atc.Plan
+
+			BeforeEach(func() {
+				job = new(dbfakes.FakeJob)
+				job.NameReturns("some-job")
+				job.ConfigReturns(atc.JobConfig{Plan: atc.PlanSequence{{Get: "input-1"}}})
+
+				createdBuild.RerunOfReturns(65)
+				createdBuild.RerunFromReturns("some-plan")
+
+				originalBuild = new(dbfakes.FakeBuild)
+				originalBuild.IDReturns(65)
+				createdBuild.RerunOfBuildReturns(originalBuild, true, nil)
+
+				rerunPlan = atc.Plan{ID: "some-do", Do: &atc.DoPlan{}}
+
+				engineOriginal = new(enginefakes.FakeBuild)
+				engineOriginal.PlanRerunReturns(engine.Rerun{Plan: rerunPlan}, nil)
+				fakeEngine.LookupBuildReturns(engineOriginal, nil)
+
+				createdBuild.ScheduleReturns(true, nil)
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					job,
+					db.Resources{resource},
+					versionedResourceTypes,
+					pendingBuilds,
+				)
+			})
+
+			It("starts the build with the original build's plan from the step", func() {
+				Expect(tryStartErr).NotTo(HaveOccurred())
+
+				Expect(fakeEngine.LookupBuildCallCount()).To(Equal(1))
+				_, lookedUp := fakeEngine.LookupBuildArgsForCall(0)
+				Expect(lookedUp).To(Equal(originalBuild))
+
+				Expect(engineOriginal.PlanRerunCallCount()).To(Equal(1))
+				_, from := engineOriginal.PlanRerunArgsForCall(0)
+				Expect(from).To(Equal(atc.PlanID("some-plan")))
+
+				Expect(createdBuild.ScheduleCallCount()).To(Equal(1))
+
+				Expect(fakeEngine.CreateBuildCallCount()).To(Equal(1))
+				_, build, plan := fakeEngine.CreateBuildArgsForCall(0)
+				Expect(build).To(Equal(createdBuild))
+				Expect(plan).To(Equal(rerunPlan))
+			})
+
+			It("keeps the inputs copied from the original build", func() {
+				Expect(fakeScanner.ScanCallCount()).To(BeZero())
+				Expect(job.GetNextBuildInputsCallCount()).To(BeZero())
+				Expect(createdBuild.UseInputsCallCount()).To(BeZero())
+				Expect(fakeFactory.CreateCallCount()).To(BeZero())
+			})
+
+			Context("when max in flight is reached", func() {
+				BeforeEach(func() {
+					fakeUpdater.UpdateMaxInFlightReachedReturns(true, nil)
+				})
+
+				It("leaves the build pending", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(createdBuild.ScheduleCallCount()).To(BeZero())
+					Expect(fakeEngine.CreateBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the pipeline is paused", func() {
+				BeforeEach(func() {
+					fakePipeline.CheckPausedReturns(true, nil)
+				})
+
+				It("leaves the build pending", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(createdBuild.ScheduleCallCount()).To(BeZero())
+					Expect(fakeEngine.CreateBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the job is paused", func() {
+				BeforeEach(func() {
+					job.PausedReturns(true)
+				})
+
+				It("leaves the build pending", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(createdBuild.ScheduleCallCount()).To(BeZero())
+					Expect(fakeEngine.CreateBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the original build is gone", func() {
+				BeforeEach(func() {
+					createdBuild.RerunOfBuildReturns(nil, false, nil)
+				})
+
+				It("marks the build as errored without starting it", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(createdBuild.FinishCallCount()).To(Equal(1))
+					Expect(createdBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
+					Expect(fakeEngine.CreateBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when planning the rerun fails", func() {
+				BeforeEach(func() {
+					engineOriginal.PlanRerunReturns(engine.Rerun{}, disaster)
+				})
+
+				It("marks the build as errored without starting it", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(createdBuild.FinishCallCount()).To(Equal(1))
+					Expect(createdBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
+					Expect(createdBuild.ScheduleCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 })
@@ -20,6 +20,9 @@ type buildFactory struct {
 	planFactory atc.PlanFactory
 }
 
+// NewBuildFactory constructs a BuildFactory whose plans have structural IDs,
+// so that the plan IDs are determined by the job's config alone and are the
+// same for every build of it.
 func NewBuildFactory(pipelineID int, planFactory atc.PlanFactory) BuildFactory {
 	return &buildFactory{
 		PipelineID:  pipelineID,
@@ -38,13 +41,18 @@ func (factory *buildFactory) Create(
 		return atc.Plan{}, err
 	}
 
-	return factory.applyHooks(constructionParams{
+	plan, err = factory.applyHooks(constructionParams{
 		plan:          plan,
 		hooks:         job.Hooks(),
 		resources:     resources,
 		resourceTypes: resourceTypes,
 		inputs:        inputs,
 	})
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	return atc.StructuralPlanIDs(plan, job.Name), nil
 }
 
 func (factory *buildFactory) constructPlanFromJob(
@@ -122,13 +130,22 @@ func (factory *buildFactory) constructPlanFromConfig(
 		plan = factory.planFactory.NewPlan(retryStep)
 	}
 
-	return factory.applyHooks(constructionParams{
+	plan, err = factory.applyHooks(constructionParams{
 		plan:          plan,
 		hooks:         planConfig.Hooks(),
 		resources:     resources,
 		resourceTypes: resourceTypes,
 		inputs:        inputs,
 	})
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	// annotate the outermost plan, so that viewers present the step along with
+	// its attempts and hooks
+	plan.Display = planConfig.Display
+
+	return plan, nil
 }
 
 func (factory *buildFactory) constructUnhookedPlan(
@@ -152,6 +169,8 @@ func (factory *buildFactory) constructUnhookedPlan(
 			return atc.Plan{}, err
 		}
 
+		plan.ContinueOnFailure = planConfig.ContinueOnFailure
+
 	case planConfig.Put != "":
 		logicalName := planConfig.Put
 
@@ -222,6 +241,7 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Params:   planConfig.Params,
 			Version:  &version,
 			Tags:     planConfig.Tags,
+			Path:     planConfig.Path,
 
 			VersionedResourceTypes: resourceTypes,
 		})
@@ -237,9 +257,92 @@ func (factory *buildFactory) constructUnhookedPlan(
 			InputMapping:      planConfig.InputMapping,
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
+			ReuseContainer:    planConfig.ReuseContainer,
 
 			VersionedResourceTypes: resourceTypes,
 		})
+
+	case planConfig.SaveCache != "":
+		plan = factory.planFactory.NewPlan(atc.SaveCachePlan{
+			Name: planConfig.SaveCache,
+			Key:  planConfig.CacheKey,
+		})
+
+	case planConfig.RestoreCache != "":
+		plan = factory.planFactory.NewPlan(atc.RestoreCachePlan{
+			Name: planConfig.RestoreCache,
+			Key:  planConfig.CacheKey,
+		})
+
+	case planConfig.WaitFor != "":
+		waitForPlan := atc.WaitForPlan{
+			Name:     planConfig.WaitFor,
+			Interval: planConfig.Interval,
+			HTTP:     planConfig.HTTP,
+		}
+
+		if planConfig.HTTP == nil {
+			resourceName := planConfig.ResourceName()
+
+			resource, found := resources.Lookup(resourceName)
+			if !found {
+				return atc.Plan{}, ErrResourceNotFound
+			}
+
+			waitForPlan.Type = resource.Type
+			waitForPlan.Resource = resourceName
+			waitForPlan.Source = resource.Source
+			waitForPlan.Tags = planConfig.Tags
+			waitForPlan.VersionedResourceTypes = resourceTypes
+		}
+
+		plan = factory.planFactory.NewPlan(waitForPlan)
+
+	case planConfig.Promote != "":
+		resourceName := planConfig.ResourceName()
+
+		resource, found := resources.Lookup(resourceName)
+		if !found {
+			return atc.Plan{}, ErrResourceNotFound
+		}
+
+		fromResource, found := resources.Lookup(planConfig.From)
+		if !found {
+			return atc.Plan{}, ErrResourceNotFound
+		}
+
+		var fromVersion *atc.Version
+		for _, input := range inputs {
+			if input.Resource == planConfig.From {
+				version := atc.Version(input.Version)
+				fromVersion = &version
+				break
+			}
+		}
+
+		plan = factory.planFactory.NewPlan(atc.PromotePlan{
+			Name:     planConfig.Promote,
+			Type:     resource.Type,
+			Resource: resourceName,
+			Source:   resource.Source,
+			Params:   planConfig.Params,
+			Tags:     planConfig.Tags,
+
+			FromType:    fromResource.Type,
+			FromName:    planConfig.From,
+			FromSource:  fromResource.Source,
+			FromParams:  planConfig.GetParams,
+			FromVersion: fromVersion,
+
+			VersionedResourceTypes: resourceTypes,
+		})
+
+	case planConfig.SetVar != "":
+		plan = factory.planFactory.NewPlan(atc.SetVarPlan{
+			Name:  planConfig.SetVar,
+			Value: planConfig.Value,
+		})
+
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
@@ -0,0 +1,71 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Cache", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.VersionedResourceTypes
+		input               atc.JobConfig
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{}
+		resourceTypes = atc.VersionedResourceTypes{}
+	})
+
+	Context("when restoring and saving a cache around a task", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						RestoreCache: "some-deps",
+						CacheKey:     `deps-{{checksum "some-repo/go.sum"}}`,
+					},
+					{
+						Task: "some-task",
+					},
+					{
+						SaveCache: "some-deps",
+						CacheKey:  `deps-{{checksum "some-repo/go.sum"}}`,
+					},
+				},
+			}
+		})
+
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+				expectedPlanFactory.NewPlan(atc.RestoreCachePlan{
+					Name: "some-deps",
+					Key:  `deps-{{checksum "some-repo/go.sum"}}`,
+				}),
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:                   "some-task",
+					VersionedResourceTypes: resourceTypes,
+				}),
+				expectedPlanFactory.NewPlan(atc.SaveCachePlan{
+					Name: "some-deps",
+					Key:  `deps-{{checksum "some-repo/go.sum"}}`,
+				}),
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+})
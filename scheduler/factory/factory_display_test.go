@@ -0,0 +1,96 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Display", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+
+		display *atc.StepDisplay
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		display = &atc.StepDisplay{
+			Group:       "integration",
+			Collapsed:   true,
+			Description: "runs the integration suite",
+		}
+	})
+
+	It("annotates the step's plan", func() {
+		actual, err := buildFactory.Create(atc.JobConfig{
+			Plan: atc.PlanSequence{
+				{
+					Task:    "some-task",
+					Display: display,
+				},
+				{
+					Task: "some-other-task",
+				},
+			},
+		}, nil, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		taskPlan := expectedPlanFactory.NewPlan(atc.TaskPlan{
+			Name: "some-task",
+		})
+		taskPlan.Display = display
+
+		expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+			taskPlan,
+			expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name: "some-other-task",
+			}),
+		})
+
+		Expect(actual).To(testhelpers.MatchPlan(expected))
+	})
+
+	Context("when the step has hooks and attempts", func() {
+		It("annotates the outermost plan", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:     "some-task",
+						Attempts: 2,
+						Display:  display,
+						Ensure: &atc.PlanConfig{
+							Task: "some-cleanup-task",
+						},
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.EnsurePlan{
+				Step: expectedPlanFactory.NewPlan(atc.RetryPlan{
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name: "some-task",
+					}),
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name: "some-task",
+					}),
+				}),
+				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name: "some-cleanup-task",
+				}),
+			})
+			expected.Display = display
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+})
@@ -90,6 +90,41 @@ var _ = Describe("Factory Do", func() {
 		})
 	})
 
+	Context("when a do continues on failure", func() {
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Do: &atc.PlanSequence{
+							{
+								Task: "some thing",
+							},
+							{
+								Task: "some thing-2",
+							},
+						},
+						ContinueOnFailure: true,
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name: "some thing",
+					VersionedResourceTypes: resourceTypes,
+				}),
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name: "some thing-2",
+					VersionedResourceTypes: resourceTypes,
+				}),
+			})
+			expected.ContinueOnFailure = true
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
 	Context("when I have an aggregate inside a do", func() {
 		It("returns the correct plan", func() {
 			actual, err := buildFactory.Create(atc.JobConfig{
@@ -235,4 +270,76 @@ var _ = Describe("Factory Do", func() {
 			Expect(actual).To(testhelpers.MatchPlan(expected))
 		})
 	})
+
+	Context("when the same job is planned more than once", func() {
+		It("generates the same plan IDs each time", func() {
+			job := atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Do: &atc.PlanSequence{
+							{
+								Task: "some thing",
+							},
+							{
+								Task: "some thing-2",
+							},
+						},
+					},
+				},
+			}
+
+			first, err := buildFactory.Create(job, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			second, err := buildFactory.Create(job, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(second).To(Equal(first))
+		})
+
+		It("keeps the plan IDs of the other steps when a step is inserted", func() {
+			job := atc.JobConfig{
+				Name: "some-job",
+				Plan: atc.PlanSequence{
+					{Get: "some-resource"},
+					{Put: "some-resource"},
+					{Task: "some thing"},
+				},
+			}
+
+			before, err := buildFactory.Create(job, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			job.Plan = atc.PlanSequence{
+				job.Plan[0],
+				{Task: "some new thing"},
+				job.Plan[1],
+				job.Plan[2],
+			}
+
+			after, err := buildFactory.Create(job, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(after.ID).To(Equal(before.ID))
+
+			beforeSteps := *before.Do
+			afterSteps := *after.Do
+			Expect(afterSteps).To(HaveLen(4))
+
+			Expect(afterSteps[0]).To(Equal(beforeSteps[0]))
+			Expect(afterSteps[2]).To(Equal(beforeSteps[1]))
+			Expect(afterSteps[3]).To(Equal(beforeSteps[2]))
+
+			Expect([]atc.PlanID{
+				before.ID,
+				beforeSteps[0].ID,
+				beforeSteps[1].ID,
+				beforeSteps[2].ID,
+			}).NotTo(ContainElement(afterSteps[1].ID))
+
+			putGet := afterSteps[2].OnSuccess
+			Expect(putGet).NotTo(BeNil())
+			Expect(putGet.Next.Get.VersionFrom).To(Equal(&putGet.Step.ID))
+		})
+	})
 })
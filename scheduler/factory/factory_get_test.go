@@ -75,6 +75,38 @@ var _ = Describe("Factory Get", func() {
 		})
 	})
 
+	Context("with a get with a path", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get:      "some-get",
+						Resource: "some-resource",
+						Path:     "src/some-get",
+					},
+				},
+			}
+		})
+
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.GetPlan{
+				Type:     "git",
+				Name:     "some-get",
+				Resource: "some-resource",
+				Source: atc.Source{
+					"uri": "git://some-resource",
+				},
+				Path:                   "src/some-get",
+				Version:                &version,
+				VersionedResourceTypes: resourceTypes,
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
 	Context("with a get for a non-existent resource", func() {
 		BeforeEach(func() {
 			input = atc.JobConfig{
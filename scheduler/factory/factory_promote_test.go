@@ -0,0 +1,121 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Promote", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.VersionedResourceTypes
+		input               atc.JobConfig
+		inputs              []db.BuildInput
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "staging-image",
+				Type:   "some-type",
+				Source: atc.Source{"repository": "staging/image"},
+			},
+			{
+				Name:   "prod-image",
+				Type:   "some-other-type",
+				Source: atc.Source{"repository": "prod/image"},
+			},
+		}
+
+		resourceTypes = atc.VersionedResourceTypes{
+			{
+				ResourceType: atc.ResourceType{
+					Name:   "some-custom-resource",
+					Type:   "docker-image",
+					Source: atc.Source{"some": "custom-source"},
+				},
+				Version: atc.Version{"some": "version"},
+			},
+		}
+
+		input = atc.JobConfig{
+			Plan: atc.PlanSequence{
+				{
+					Promote:   "image",
+					Resource:  "prod-image",
+					From:      "staging-image",
+					Params:    atc.Params{"tag": "latest"},
+					GetParams: atc.Params{"format": "oci"},
+					Tags:      atc.Tags{"some-tag"},
+				},
+			},
+		}
+
+		inputs = []db.BuildInput{
+			{
+				Name: "staging",
+				VersionedResource: db.VersionedResource{
+					Resource: "staging-image",
+					Version:  db.ResourceVersion{"digest": "sha256:abc"},
+				},
+			},
+		}
+	})
+
+	It("returns the correct plan", func() {
+		actual, err := buildFactory.Create(input, resources, resourceTypes, inputs)
+		Expect(err).NotTo(HaveOccurred())
+
+		expected := expectedPlanFactory.NewPlan(atc.PromotePlan{
+			Name:     "image",
+			Type:     "some-other-type",
+			Resource: "prod-image",
+			Source:   atc.Source{"repository": "prod/image"},
+			Params:   atc.Params{"tag": "latest"},
+			Tags:     atc.Tags{"some-tag"},
+
+			FromType:    "some-type",
+			FromName:    "staging-image",
+			FromSource:  atc.Source{"repository": "staging/image"},
+			FromParams:  atc.Params{"format": "oci"},
+			FromVersion: &atc.Version{"digest": "sha256:abc"},
+
+			VersionedResourceTypes: resourceTypes,
+		})
+		Expect(actual).To(testhelpers.MatchPlan(expected))
+	})
+
+	Context("when the resource being promoted from does not exist", func() {
+		BeforeEach(func() {
+			input.Plan[0].From = "bogus-resource"
+		})
+
+		It("returns an error", func() {
+			_, err := buildFactory.Create(input, resources, resourceTypes, inputs)
+			Expect(err).To(Equal(factory.ErrResourceNotFound))
+		})
+	})
+
+	Context("when the resource being promoted to does not exist", func() {
+		BeforeEach(func() {
+			input.Plan[0].Resource = "bogus-resource"
+		})
+
+		It("returns an error", func() {
+			_, err := buildFactory.Create(input, resources, resourceTypes, inputs)
+			Expect(err).To(Equal(factory.ErrResourceNotFound))
+		})
+	})
+})
@@ -0,0 +1,44 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory SetVar", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+	})
+
+	It("returns the correct plan", func() {
+		input := atc.JobConfig{
+			Plan: atc.PlanSequence{
+				{
+					SetVar: "some-var",
+					Value:  "((some-secret))",
+				},
+			},
+		}
+
+		actual, err := buildFactory.Create(input, nil, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		expected := expectedPlanFactory.NewPlan(atc.SetVarPlan{
+			Name:  "some-var",
+			Value: "((some-secret))",
+		})
+		Expect(actual).To(testhelpers.MatchPlan(expected))
+	})
+})
@@ -0,0 +1,119 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory WaitFor", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.VersionedResourceTypes
+		input               atc.JobConfig
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "some-type",
+				Source: atc.Source{"uri": "git://some-resource"},
+			},
+		}
+
+		resourceTypes = atc.VersionedResourceTypes{
+			{
+				ResourceType: atc.ResourceType{
+					Name:   "some-custom-resource",
+					Type:   "docker-image",
+					Source: atc.Source{"some": "custom-source"},
+				},
+				Version: atc.Version{"some": "version"},
+			},
+		}
+	})
+
+	Context("when waiting for a resource", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						WaitFor:  "some-condition",
+						Resource: "some-resource",
+						Interval: "30s",
+						Tags:     atc.Tags{"some-tag"},
+					},
+				},
+			}
+		})
+
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.WaitForPlan{
+				Name:     "some-condition",
+				Interval: "30s",
+				Type:     "some-type",
+				Resource: "some-resource",
+				Source:   atc.Source{"uri": "git://some-resource"},
+				Tags:     atc.Tags{"some-tag"},
+
+				VersionedResourceTypes: resourceTypes,
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				input.Plan[0].Resource = "bogus-resource"
+			})
+
+			It("returns an error", func() {
+				_, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).To(Equal(factory.ErrResourceNotFound))
+			})
+		})
+	})
+
+	Context("when waiting for an HTTP endpoint", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						WaitFor: "some-condition",
+						HTTP: &atc.WaitForHTTPConfig{
+							URL:    "https://example.com/health",
+							Status: 204,
+						},
+					},
+				},
+			}
+		})
+
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.WaitForPlan{
+				Name: "some-condition",
+				HTTP: &atc.WaitForHTTPConfig{
+					URL:    "https://example.com/health",
+					Status: 204,
+				},
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+})
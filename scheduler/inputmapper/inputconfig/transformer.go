@@ -53,6 +53,7 @@ func (i *transformer) TransformInputConfigs(db *algorithm.VersionsDB, jobName st
 			PinnedVersionID: pinnedVersionID,
 			ResourceID:      db.ResourceIDs[input.Resource],
 			Passed:          jobs,
+			Annotated:       input.Annotated,
 			JobID:           db.JobIDs[jobName],
 		})
 	}
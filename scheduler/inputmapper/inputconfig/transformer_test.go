@@ -86,6 +86,29 @@ var _ = Describe("Transformer", func() {
 				})
 			})
 
+			Context("when an input requires annotations", func() {
+				BeforeEach(func() {
+					jobInputs = []atc.JobInput{{
+						Name:      "job-input-1",
+						Resource:  "r1",
+						Version:   &atc.VersionConfig{Latest: true},
+						Annotated: map[string]string{"qa": "passed"},
+					}}
+				})
+
+				It("passes them through", func() {
+					Expect(algorithmInputs).To(ConsistOf(algorithm.InputConfig{
+						Name:            "job-input-1",
+						UseEveryVersion: false,
+						PinnedVersionID: 0,
+						ResourceID:      11,
+						Passed:          algorithm.JobSet{},
+						Annotated:       map[string]string{"qa": "passed"},
+						JobID:           1,
+					}))
+				})
+			})
+
 			Context("when an input has version: every", func() {
 				BeforeEach(func() {
 					jobInputs = []atc.JobInput{{
@@ -1,7 +1,13 @@
 package inputmapper
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler/inputmapper/inputconfig"
@@ -17,13 +23,31 @@ type InputMapper interface {
 	) (algorithm.InputMapping, error)
 }
 
+// NewInputMapper constructs an InputMapper which remembers the mapping last
+// saved for each job, along with the generation of the versions and the
+// config of the job it was resolved from. While neither changes, the mapping
+// saved is returned again without resolving or saving it.
 func NewInputMapper(pipeline db.Pipeline, transformer inputconfig.Transformer) InputMapper {
-	return &inputMapper{pipeline: pipeline, transformer: transformer}
+	return &inputMapper{
+		pipeline:    pipeline,
+		transformer: transformer,
+
+		cache: map[int]cachedInputMapping{},
+	}
 }
 
 type inputMapper struct {
 	pipeline    db.Pipeline
 	transformer inputconfig.Transformer
+
+	cacheL sync.Mutex
+	cache  map[int]cachedInputMapping
+}
+
+type cachedInputMapping struct {
+	generation int64
+	configHash string
+	mapping    algorithm.InputMapping
 }
 
 func (i *inputMapper) SaveNextInputMapping(
@@ -33,6 +57,68 @@ func (i *inputMapper) SaveNextInputMapping(
 ) (algorithm.InputMapping, error) {
 	logger = logger.Session("save-next-input-mapping")
 
+	configHash, err := hashJobConfig(job.Config())
+	if err != nil {
+		logger.Error("failed-to-hash-job-config", err)
+		return nil, err
+	}
+
+	key := cachedInputMapping{
+		generation: versions.Generation,
+		configHash: configHash,
+	}
+
+	if cached, found := i.cached(job.ID(), key); found {
+		logger.Debug("unchanged-since-last-saved")
+		return cached.mapping, nil
+	}
+
+	mapping, err := i.saveNextInputMapping(logger, versions, job)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.generation != 0 {
+		key.mapping = mapping
+
+		i.cacheL.Lock()
+		i.cache[job.ID()] = key
+		i.cacheL.Unlock()
+	}
+
+	return mapping, nil
+}
+
+func (i *inputMapper) cached(jobID int, key cachedInputMapping) (cachedInputMapping, bool) {
+	if key.generation == 0 {
+		return cachedInputMapping{}, false
+	}
+
+	i.cacheL.Lock()
+	defer i.cacheL.Unlock()
+
+	cached, found := i.cache[jobID]
+	if !found || cached.generation != key.generation || cached.configHash != key.configHash {
+		return cachedInputMapping{}, false
+	}
+
+	return cached, true
+}
+
+func hashJobConfig(config atc.JobConfig) (string, error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(payload)), nil
+}
+
+func (i *inputMapper) saveNextInputMapping(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+) (algorithm.InputMapping, error) {
 	inputConfigs := job.Config().Inputs()
 
 	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name(), inputConfigs)
@@ -377,5 +377,113 @@ var _ = Describe("Inputmapper", func() {
 				Expect(inputMapping).To(BeEmpty())
 			})
 		})
+
+		Context("when the mapping has already been saved", func() {
+			var expectedMapping algorithm.InputMapping
+
+			resolveAgain := func() (algorithm.InputMapping, error) {
+				return inputMapper.SaveNextInputMapping(
+					lagertest.NewTestLogger("test"),
+					versionsDB,
+					fakeJob,
+				)
+			}
+
+			BeforeEach(func() {
+				versionsDB.Generation = 1
+
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.IDReturns(1)
+				fakeJob.NameReturns("some-job")
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{Get: "a", Version: &atc.VersionConfig{Latest: true}},
+					},
+				})
+
+				fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+					{
+						Name:       "a",
+						ResourceID: 11,
+						Passed:     algorithm.JobSet{},
+						JobID:      1,
+					},
+				}, nil)
+
+				expectedMapping = algorithm.InputMapping{
+					"a": algorithm.InputVersion{VersionID: 1, FirstOccurrence: true},
+				}
+			})
+
+			It("returns the saved mapping without resolving it again", func() {
+				Expect(mappingErr).NotTo(HaveOccurred())
+
+				mapping, err := resolveAgain()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mapping).To(Equal(expectedMapping))
+
+				Expect(fakeTransformer.TransformInputConfigsCallCount()).To(Equal(1))
+				Expect(fakeJob.SaveIndependentInputMappingCallCount()).To(Equal(1))
+				Expect(fakeJob.SaveNextInputMappingCallCount()).To(Equal(1))
+			})
+
+			Context("when the versions have changed", func() {
+				It("resolves the mapping again", func() {
+					versionsDB.Generation = 2
+
+					_, err := resolveAgain()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTransformer.TransformInputConfigsCallCount()).To(Equal(2))
+					Expect(fakeJob.SaveNextInputMappingCallCount()).To(Equal(2))
+				})
+			})
+
+			Context("when the job's config has changed", func() {
+				It("resolves the mapping again", func() {
+					fakeJob.ConfigReturns(atc.JobConfig{
+						Plan: atc.PlanSequence{
+							{Get: "a", Version: &atc.VersionConfig{Every: true}},
+						},
+					})
+
+					_, err := resolveAgain()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTransformer.TransformInputConfigsCallCount()).To(Equal(2))
+					Expect(fakeJob.SaveNextInputMappingCallCount()).To(Equal(2))
+				})
+			})
+
+			Context("when the generation of the versions is unknown", func() {
+				BeforeEach(func() {
+					versionsDB.Generation = 0
+				})
+
+				It("resolves the mapping again", func() {
+					_, err := resolveAgain()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTransformer.TransformInputConfigsCallCount()).To(Equal(2))
+					Expect(fakeJob.SaveNextInputMappingCallCount()).To(Equal(2))
+				})
+			})
+
+			Context("when saving the mapping failed", func() {
+				BeforeEach(func() {
+					fakeJob.SaveNextInputMappingReturnsOnCall(0, disaster)
+				})
+
+				It("resolves the mapping again", func() {
+					Expect(mappingErr).To(Equal(disaster))
+
+					mapping, err := resolveAgain()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(mapping).To(Equal(expectedMapping))
+
+					Expect(fakeTransformer.TransformInputConfigsCallCount()).To(Equal(2))
+				})
+			})
+		})
 	})
 })
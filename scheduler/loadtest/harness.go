@@ -0,0 +1,202 @@
+package loadtest
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/scheduler/inputmapper"
+	"github.com/concourse/atc/scheduler/inputmapper/inputconfig"
+	"github.com/concourse/atc/scheduler/maxinflight"
+)
+
+const schedulingLockInterval = 10 * time.Second
+
+// Config describes the synthetic workload replayed by the harness.
+type Config struct {
+	TeamName string
+
+	Pipelines            int
+	JobsPerPipeline      int
+	ResourcesPerPipeline int
+	ResourceType         string
+
+	// Versions is the length of the version history replayed for every
+	// resource. One version of each resource is saved before each tick.
+	Versions int
+}
+
+// Report summarizes a run of the harness.
+//
+// Duration only covers time spent ticking the scheduler, not saving versions.
+// Queries counts every query issued while ticking, including those made by
+// builds started during the run.
+type Report struct {
+	Ticks    int
+	Builds   int
+	Queries  int
+	Duration time.Duration
+}
+
+func (report Report) TicksPerSecond() float64 {
+	return report.perSecond(report.Ticks)
+}
+
+func (report Report) BuildsPerSecond() float64 {
+	return report.perSecond(report.Builds)
+}
+
+func (report Report) QueriesPerTick() float64 {
+	if report.Ticks == 0 {
+		return 0
+	}
+
+	return float64(report.Queries) / float64(report.Ticks)
+}
+
+func (report Report) perSecond(count int) float64 {
+	if report.Duration == 0 {
+		return 0
+	}
+
+	return float64(count) / report.Duration.Seconds()
+}
+
+// Harness replays synthetic pipelines and version histories through the real
+// scheduler. Builds are created through the given engine, which would
+// typically be the noop engine so that only scheduling is measured.
+//
+// Query counts are taken from metric.DatabaseQueries, so the team factory's
+// connection must be wrapped with metric.CountQueries.
+type Harness struct {
+	Logger      lager.Logger
+	TeamFactory db.TeamFactory
+	Engine      engine.Engine
+	Config      Config
+}
+
+func (harness *Harness) Run() (Report, error) {
+	logger := harness.Logger.Session("load-test")
+
+	team, err := harness.TeamFactory.CreateTeam(atc.Team{Name: harness.Config.TeamName})
+	if err != nil {
+		logger.Error("failed-to-create-team", err)
+		return Report{}, err
+	}
+
+	pipelineConfig := SyntheticPipelineConfig(harness.Config)
+
+	buildEngine := &countingEngine{Engine: harness.Engine}
+
+	runners := []*scheduler.Runner{}
+	for i := 0; i < harness.Config.Pipelines; i++ {
+		pipeline, _, err := team.SavePipeline(pipelineName(i), pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+		if err != nil {
+			logger.Error("failed-to-save-pipeline", err)
+			return Report{}, err
+		}
+
+		runners = append(runners, &scheduler.Runner{
+			Logger:    logger.Session("scheduler", lager.Data{"pipeline": pipeline.Name()}),
+			Pipeline:  pipeline,
+			Scheduler: buildScheduler(pipeline, buildEngine),
+			Interval:  schedulingLockInterval,
+		})
+	}
+
+	report := Report{}
+
+	for version := 0; version < harness.Config.Versions; version++ {
+		for _, runner := range runners {
+			for _, resource := range pipelineConfig.Resources {
+				err := runner.Pipeline.SaveResourceVersions(resource, []atc.Version{
+					{"version": strconv.Itoa(version)},
+				})
+				if err != nil {
+					logger.Error("failed-to-save-resource-version", err)
+					return Report{}, err
+				}
+			}
+
+			// discard the queries made by saving versions
+			metric.DatabaseQueries.Delta()
+
+			start := time.Now()
+			err := runner.Tick(runner.Logger.Session("tick"))
+			report.Duration += time.Since(start)
+			report.Queries += metric.DatabaseQueries.Delta()
+			report.Ticks++
+
+			if err != nil {
+				logger.Error("failed-to-tick", err)
+				return Report{}, err
+			}
+		}
+	}
+
+	report.Builds = buildEngine.Builds()
+
+	return report, nil
+}
+
+func buildScheduler(pipeline db.Pipeline, buildEngine engine.Engine) *scheduler.Scheduler {
+	scanner := noopScanner{}
+
+	inputMapper := inputmapper.NewInputMapper(
+		pipeline,
+		inputconfig.NewTransformer(pipeline),
+	)
+
+	return &scheduler.Scheduler{
+		Pipeline:    pipeline,
+		InputMapper: inputMapper,
+		BuildStarter: scheduler.NewBuildStarter(
+			pipeline,
+			maxinflight.NewUpdater(pipeline),
+			factory.NewBuildFactory(
+				pipeline.ID(),
+				atc.NewPlanFactory(time.Now().Unix()),
+			),
+			scanner,
+			inputMapper,
+			buildEngine,
+			atc.JobLimits{},
+		),
+		Scanner: scanner,
+	}
+}
+
+// versions are saved by the harness itself, so there is nothing to check
+type noopScanner struct{}
+
+func (noopScanner) Scan(lager.Logger, string) error {
+	return nil
+}
+
+type countingEngine struct {
+	engine.Engine
+
+	builds int64
+}
+
+func (counter *countingEngine) CreateBuild(logger lager.Logger, build db.Build, plan atc.Plan) (engine.Build, error) {
+	createdBuild, err := counter.Engine.CreateBuild(logger, build, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	atomic.AddInt64(&counter.builds, 1)
+
+	return createdBuild, nil
+}
+
+func (counter *countingEngine) Builds() int {
+	return int(atomic.LoadInt64(&counter.builds))
+}
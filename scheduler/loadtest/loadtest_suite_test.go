@@ -0,0 +1,13 @@
+package loadtest_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestLoadTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Load Test Suite")
+}
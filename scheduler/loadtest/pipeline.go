@@ -0,0 +1,58 @@
+package loadtest
+
+import (
+	"fmt"
+
+	"github.com/concourse/atc"
+)
+
+// SyntheticPipelineConfig generates the pipeline config used for each
+// synthetic pipeline.
+//
+// Each job triggers on one resource. Jobs wrap around the resources, so once
+// every resource has a job, each further job takes its resource with a passed
+// constraint on the previous job for that resource, forming one chain of jobs
+// per resource.
+func SyntheticPipelineConfig(config Config) atc.Config {
+	pipelineConfig := atc.Config{}
+
+	for i := 0; i < config.ResourcesPerPipeline; i++ {
+		pipelineConfig.Resources = append(pipelineConfig.Resources, atc.ResourceConfig{
+			Name: resourceName(i),
+			Type: config.ResourceType,
+			Source: atc.Source{
+				"index": i,
+			},
+		})
+	}
+
+	for i := 0; i < config.JobsPerPipeline; i++ {
+		get := atc.PlanConfig{
+			Get:     resourceName(i % config.ResourcesPerPipeline),
+			Trigger: true,
+		}
+
+		if i >= config.ResourcesPerPipeline {
+			get.Passed = []string{jobName(i - config.ResourcesPerPipeline)}
+		}
+
+		pipelineConfig.Jobs = append(pipelineConfig.Jobs, atc.JobConfig{
+			Name: jobName(i),
+			Plan: atc.PlanSequence{get},
+		})
+	}
+
+	return pipelineConfig
+}
+
+func pipelineName(i int) string {
+	return fmt.Sprintf("pipeline-%d", i)
+}
+
+func resourceName(i int) string {
+	return fmt.Sprintf("resource-%d", i)
+}
+
+func jobName(i int) string {
+	return fmt.Sprintf("job-%d", i)
+}
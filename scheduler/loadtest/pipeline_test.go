@@ -0,0 +1,53 @@
+package loadtest_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/loadtest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SyntheticPipelineConfig", func() {
+	var config atc.Config
+
+	BeforeEach(func() {
+		config = loadtest.SyntheticPipelineConfig(loadtest.Config{
+			JobsPerPipeline:      5,
+			ResourcesPerPipeline: 2,
+			ResourceType:         "some-type",
+		})
+	})
+
+	It("generates a valid config", func() {
+		_, errorMessages := config.Validate()
+		Expect(errorMessages).To(BeEmpty())
+	})
+
+	It("generates the configured resources", func() {
+		Expect(config.Resources).To(HaveLen(2))
+		Expect(config.Resources[0].Name).To(Equal("resource-0"))
+		Expect(config.Resources[0].Type).To(Equal("some-type"))
+		Expect(config.Resources[1].Name).To(Equal("resource-1"))
+	})
+
+	It("chains the jobs for each resource with passed constraints", func() {
+		Expect(config.Jobs).To(HaveLen(5))
+
+		Expect(config.Jobs[0].Plan).To(Equal(atc.PlanSequence{
+			{Get: "resource-0", Trigger: true},
+		}))
+
+		Expect(config.Jobs[1].Plan).To(Equal(atc.PlanSequence{
+			{Get: "resource-1", Trigger: true},
+		}))
+
+		Expect(config.Jobs[2].Plan).To(Equal(atc.PlanSequence{
+			{Get: "resource-0", Trigger: true, Passed: []string{"job-0"}},
+		}))
+
+		Expect(config.Jobs[4].Plan).To(Equal(atc.PlanSequence{
+			{Get: "resource-0", Trigger: true, Passed: []string{"job-2"}},
+		}))
+	})
+})
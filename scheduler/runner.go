@@ -26,6 +26,7 @@ type BuildScheduler interface {
 	TriggerImmediately(
 		logger lager.Logger,
 		job db.Job,
+		cause db.BuildTriggerCause,
 		resources db.Resources,
 		resourceTypes atc.VersionedResourceTypes,
 	) (db.Build, Waiter, error)
@@ -73,6 +74,11 @@ dance:
 	return nil
 }
 
+// Tick runs a single scheduling pass for the pipeline.
+func (runner *Runner) Tick(logger lager.Logger) error {
+	return runner.tick(logger)
+}
+
 func (runner *Runner) tick(logger lager.Logger) error {
 	if runner.Noop {
 		return nil
@@ -82,7 +82,22 @@ func (s *Scheduler) ensurePendingBuildExists(
 
 		//trigger: true, and the version has not been used
 		if ok && inputVersion.FirstOccurrence && inputConfig.Trigger {
-			err := job.EnsurePendingBuildExists()
+			cause := db.BuildTriggerCause{
+				Type:     db.BuildTriggerInput,
+				Resource: inputConfig.Resource,
+			}
+
+			versionedResource, found, err := s.Pipeline.VersionedResource(inputVersion.VersionID)
+			if err != nil {
+				logger.Error("failed-to-get-triggering-version", err)
+				return err
+			}
+
+			if found {
+				cause.Version = versionedResource.Version
+			}
+
+			err = job.EnsurePendingBuildExists(cause)
 			if err != nil {
 				logger.Error("failed-to-ensure-pending-build-exists", err)
 				return err
@@ -102,12 +117,13 @@ type Waiter interface {
 func (s *Scheduler) TriggerImmediately(
 	logger lager.Logger,
 	job db.Job,
+	cause db.BuildTriggerCause,
 	resources db.Resources,
 	resourceTypes atc.VersionedResourceTypes,
 ) (db.Build, Waiter, error) {
 	logger = logger.Session("trigger-immediately", lager.Data{"job_name": job.Name()})
 
-	build, err := job.CreateBuild()
+	build, err := job.CreateBuild(cause)
 	if err != nil {
 		logger.Error("failed-to-create-job-build", err)
 		return nil, nil, err
@@ -223,6 +223,49 @@ var _ = Describe("Scheduler", func() {
 						"a": algorithm.InputVersion{VersionID: 1, FirstOccurrence: true},
 						"b": algorithm.InputVersion{VersionID: 2, FirstOccurrence: false},
 					}, nil)
+
+					fakePipeline.VersionedResourceReturns(db.SavedVersionedResource{
+						VersionedResource: db.VersionedResource{
+							Resource: "a",
+							Version:  db.ResourceVersion{"ref": "abc"},
+						},
+					}, true, nil)
+				})
+
+				It("records the triggering version as the cause of the build", func() {
+					Expect(fakePipeline.VersionedResourceCallCount()).To(Equal(1))
+					Expect(fakePipeline.VersionedResourceArgsForCall(0)).To(Equal(1))
+
+					Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+					Expect(fakeJob.EnsurePendingBuildExistsArgsForCall(0)).To(Equal(db.BuildTriggerCause{
+						Type:     db.BuildTriggerInput,
+						Resource: "a",
+						Version:  db.ResourceVersion{"ref": "abc"},
+					}))
+				})
+
+				Context("when the triggering version cannot be found", func() {
+					BeforeEach(func() {
+						fakePipeline.VersionedResourceReturns(db.SavedVersionedResource{}, false, nil)
+					})
+
+					It("records the resource without a version", func() {
+						Expect(fakeJob.EnsurePendingBuildExistsArgsForCall(0)).To(Equal(db.BuildTriggerCause{
+							Type:     db.BuildTriggerInput,
+							Resource: "a",
+						}))
+					})
+				})
+
+				Context("when looking up the triggering version fails", func() {
+					BeforeEach(func() {
+						fakePipeline.VersionedResourceReturns(db.SavedVersionedResource{}, false, disaster)
+					})
+
+					It("returns the error without creating a pending build", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+						Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+					})
 				})
 
 				Context("when creating a pending build fails", func() {
@@ -276,6 +319,7 @@ var _ = Describe("Scheduler", func() {
 			triggeredBuild, waiter, triggerErr = scheduler.TriggerImmediately(
 				lagertest.NewTestLogger("test"),
 				fakeJob,
+				db.BuildTriggerCause{Type: db.BuildTriggerManual, Requester: "some-team"},
 				db.Resources{fakeResource},
 				atc.VersionedResourceTypes{
 					{
@@ -312,6 +356,13 @@ var _ = Describe("Scheduler", func() {
 				Expect(fakeJob.CreateBuildCallCount()).To(Equal(1))
 			})
 
+			It("records the cause of the build", func() {
+				Expect(fakeJob.CreateBuildArgsForCall(0)).To(Equal(db.BuildTriggerCause{
+					Type:      db.BuildTriggerManual,
+					Requester: "some-team",
+				}))
+			})
+
 			Context("when get pending builds for job fails", func() {
 				BeforeEach(func() {
 					fakeJob.GetPendingBuildsReturns(nil, disaster)
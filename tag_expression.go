@@ -0,0 +1,350 @@
+package atc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TagExpression is a boolean expression over the tags of a worker, e.g.
+// "linux && (gpu || highmem)". Tags are combined with && and ||, negated with
+// !, and grouped with parentheses; && binds tighter than ||. A bare tag is an
+// expression matching the workers which have it.
+type TagExpression interface {
+	Matches(workerTags []string) bool
+	String() string
+}
+
+// ParseTagExpression parses the expression, returning an error describing
+// the first problem found if it is malformed.
+func ParseTagExpression(expression string) (TagExpression, error) {
+	parser := &tagParser{tokens: tokenizeTags(expression)}
+	if len(parser.tokens) == 0 {
+		return nil, errors.New("empty expression")
+	}
+
+	expr, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !parser.done() {
+		return nil, fmt.Errorf("unexpected '%s'", parser.peek())
+	}
+
+	return expr, nil
+}
+
+// tagExpressionPrefix marks Tags holding an expression rather than plain
+// tags. Only tags configured as a single string, e.g.
+// `tags: "linux && (gpu || highmem)"`, are an expression; tags configured as
+// a list are plain tags, which may contain any characters and are matched
+// exactly.
+const tagExpressionPrefix = "expr:"
+
+// TagsExpression returns Tags matching the workers which satisfy the
+// expression. An empty expression is no tags at all.
+func TagsExpression(expression string) Tags {
+	if expression == "" {
+		return nil
+	}
+
+	return Tags{tagExpressionPrefix + expression}
+}
+
+// Expression returns the expression the tags hold, if they were configured
+// as one.
+func (tags Tags) Expression() (string, bool) {
+	if len(tags) == 1 && strings.HasPrefix(tags[0], tagExpressionPrefix) {
+		return strings.TrimPrefix(tags[0], tagExpressionPrefix), true
+	}
+
+	return "", false
+}
+
+// Matches returns whether the worker's tags satisfy the expression, if the
+// tags are one, or otherwise whether the worker has every one of the tags. A
+// malformed expression matches no worker.
+func (tags Tags) Matches(workerTags []string) bool {
+	if expression, ok := tags.Expression(); ok {
+		expr, err := ParseTagExpression(expression)
+		if err != nil {
+			return false
+		}
+
+		return expr.Matches(workerTags)
+	}
+
+	for _, tag := range tags {
+		if !tagLiteral(tag).Matches(workerTags) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateExpressions returns an error if the tags are a malformed
+// expression. Plain tags are always valid.
+func (tags Tags) ValidateExpressions() []error {
+	expression, ok := tags.Expression()
+	if !ok {
+		return []error{}
+	}
+
+	_, err := ParseTagExpression(expression)
+	if err != nil {
+		return []error{fmt.Errorf("invalid tag expression ('%s'): %s", expression, err)}
+	}
+
+	return []error{}
+}
+
+func (tags Tags) MarshalJSON() ([]byte, error) {
+	if expression, ok := tags.Expression(); ok {
+		return json.Marshal(expression)
+	}
+
+	return json.Marshal([]string(tags))
+}
+
+func (tags *Tags) UnmarshalJSON(data []byte) error {
+	var expression string
+	if string(data) != "null" && json.Unmarshal(data, &expression) == nil {
+		*tags = TagsExpression(expression)
+		return nil
+	}
+
+	var plain []string
+	err := json.Unmarshal(data, &plain)
+	if err != nil {
+		return err
+	}
+
+	*tags = plain
+
+	return nil
+}
+
+func (tags Tags) MarshalYAML() (interface{}, error) {
+	if expression, ok := tags.Expression(); ok {
+		return expression, nil
+	}
+
+	return []string(tags), nil
+}
+
+func (tags *Tags) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var expression string
+	if unmarshal(&expression) == nil {
+		*tags = TagsExpression(expression)
+		return nil
+	}
+
+	var plain []string
+	err := unmarshal(&plain)
+	if err != nil {
+		return err
+	}
+
+	*tags = plain
+
+	return nil
+}
+
+// TagsDecodeHook decodes tags configured as a single string as an
+// expression, as mapstructure would otherwise take it for a single plain tag.
+var TagsDecodeHook = func(
+	srcType reflect.Type,
+	dstType reflect.Type,
+	data interface{},
+) (interface{}, error) {
+	if dstType != reflect.TypeOf(Tags{}) || srcType.Kind() != reflect.String {
+		return data, nil
+	}
+
+	return TagsExpression(data.(string)), nil
+}
+
+type tagLiteral string
+
+func (tag tagLiteral) Matches(workerTags []string) bool {
+	for _, workerTag := range workerTags {
+		if workerTag == string(tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (tag tagLiteral) String() string { return string(tag) }
+
+type tagNot struct {
+	expr TagExpression
+}
+
+func (not tagNot) Matches(workerTags []string) bool { return !not.expr.Matches(workerTags) }
+func (not tagNot) String() string                   { return "!" + not.expr.String() }
+
+type tagAnd struct {
+	left, right TagExpression
+}
+
+func (and tagAnd) Matches(workerTags []string) bool {
+	return and.left.Matches(workerTags) && and.right.Matches(workerTags)
+}
+
+func (and tagAnd) String() string {
+	return "(" + and.left.String() + " && " + and.right.String() + ")"
+}
+
+type tagOr struct {
+	left, right TagExpression
+}
+
+func (or tagOr) Matches(workerTags []string) bool {
+	return or.left.Matches(workerTags) || or.right.Matches(workerTags)
+}
+
+func (or tagOr) String() string {
+	return "(" + or.left.String() + " || " + or.right.String() + ")"
+}
+
+func tokenizeTags(expression string) []string {
+	tokens := []string{}
+
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			tokens = append(tokens, expression[start:end])
+			start = -1
+		}
+	}
+
+	for i := 0; i < len(expression); i++ {
+		c := expression[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			flush(i)
+		case c == '(' || c == ')' || c == '!':
+			flush(i)
+			tokens = append(tokens, string(c))
+		case (c == '&' || c == '|') && i+1 < len(expression) && expression[i+1] == c:
+			flush(i)
+			tokens = append(tokens, expression[i:i+2])
+			i++
+		case c == '&' || c == '|':
+			flush(i)
+			tokens = append(tokens, string(c))
+		default:
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+
+	flush(len(expression))
+
+	return tokens
+}
+
+type tagParser struct {
+	tokens []string
+	pos    int
+}
+
+func (parser *tagParser) done() bool {
+	return parser.pos >= len(parser.tokens)
+}
+
+func (parser *tagParser) peek() string {
+	if parser.done() {
+		return ""
+	}
+
+	return parser.tokens[parser.pos]
+}
+
+func (parser *tagParser) next() string {
+	token := parser.peek()
+	parser.pos++
+	return token
+}
+
+func (parser *tagParser) parseOr() (TagExpression, error) {
+	left, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.peek() == "||" {
+		parser.next()
+
+		right, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = tagOr{left, right}
+	}
+
+	return left, nil
+}
+
+func (parser *tagParser) parseAnd() (TagExpression, error) {
+	left, err := parser.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.peek() == "&&" {
+		parser.next()
+
+		right, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = tagAnd{left, right}
+	}
+
+	return left, nil
+}
+
+func (parser *tagParser) parseUnary() (TagExpression, error) {
+	if parser.done() {
+		return nil, errors.New("unexpected end of expression")
+	}
+
+	switch token := parser.next(); token {
+	case "!":
+		expr, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return tagNot{expr}, nil
+
+	case "(":
+		expr, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if parser.next() != ")" {
+			return nil, errors.New("missing ')'")
+		}
+
+		return expr, nil
+
+	case ")", "&&", "||", "&", "|":
+		return nil, fmt.Errorf("unexpected '%s'", token)
+
+	default:
+		return tagLiteral(token), nil
+	}
+}
@@ -0,0 +1,100 @@
+package atc_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/concourse/atc"
+)
+
+var _ = Describe("TagExpression", func() {
+	workerTags := []string{"linux", "gpu"}
+
+	DescribeTable("matching a worker's tags",
+		func(expression string, matches bool) {
+			expr, err := atc.ParseTagExpression(expression)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expr.Matches(workerTags)).To(Equal(matches))
+		},
+		Entry("a tag the worker has", "linux", true),
+		Entry("a tag the worker lacks", "highmem", false),
+		Entry("a conjunction", "linux && gpu", true),
+		Entry("a conjunction with a missing tag", "linux && highmem", false),
+		Entry("a disjunction", "highmem || gpu", true),
+		Entry("a negation", "!highmem", true),
+		Entry("a negated tag the worker has", "!gpu", false),
+		Entry("a grouped expression", "linux && (gpu || highmem)", true),
+		Entry("&& binding tighter than ||", "highmem && linux || gpu", true),
+		Entry("a negated group", "!(linux && gpu)", false),
+		Entry("unspaced operators", "linux&&!(highmem||windows)", true),
+	)
+
+	DescribeTable("parsing malformed expressions",
+		func(expression string) {
+			_, err := atc.ParseTagExpression(expression)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("an empty expression", ""),
+		Entry("a dangling operator", "linux &&"),
+		Entry("a leading operator", "|| linux"),
+		Entry("an unclosed group", "(linux || gpu"),
+		Entry("an unopened group", "linux || gpu)"),
+		Entry("a single ampersand", "linux & gpu"),
+		Entry("adjacent tags", "linux gpu"),
+	)
+
+	Describe("Tags.Matches", func() {
+		It("requires the worker to have every plain tag", func() {
+			Expect(atc.Tags{"linux", "gpu"}.Matches(workerTags)).To(BeTrue())
+			Expect(atc.Tags{"linux", "highmem"}.Matches(workerTags)).To(BeFalse())
+		})
+
+		It("matches plain tags literally, whatever they contain", func() {
+			Expect(atc.Tags{"gpu || highmem"}.Matches(workerTags)).To(BeFalse())
+			Expect(atc.Tags{"gpu (a100)", "!special"}.Matches([]string{"gpu (a100)", "!special"})).To(BeTrue())
+		})
+
+		It("evaluates an expression", func() {
+			Expect(atc.TagsExpression("linux && (gpu || highmem)").Matches(workerTags)).To(BeTrue())
+			Expect(atc.TagsExpression("linux && highmem").Matches(workerTags)).To(BeFalse())
+		})
+
+		It("matches no worker with a malformed expression", func() {
+			Expect(atc.TagsExpression("linux ||").Matches(workerTags)).To(BeFalse())
+		})
+	})
+
+	Describe("unmarshaling tags", func() {
+		It("takes a list as plain tags", func() {
+			var tags atc.Tags
+			Expect(json.Unmarshal([]byte(`["linux", "gpu || highmem"]`), &tags)).To(Succeed())
+			Expect(tags).To(Equal(atc.Tags{"linux", "gpu || highmem"}))
+
+			Expect(yaml.Unmarshal([]byte(`[linux, gpu]`), &tags)).To(Succeed())
+			Expect(tags).To(Equal(atc.Tags{"linux", "gpu"}))
+		})
+
+		It("takes a string as an expression", func() {
+			var tags atc.Tags
+			Expect(json.Unmarshal([]byte(`"linux && gpu"`), &tags)).To(Succeed())
+			Expect(tags).To(Equal(atc.TagsExpression("linux && gpu")))
+
+			Expect(yaml.Unmarshal([]byte(`linux && gpu`), &tags)).To(Succeed())
+			Expect(tags).To(Equal(atc.TagsExpression("linux && gpu")))
+		})
+
+		It("round-trips an expression", func() {
+			payload, err := json.Marshal(atc.TagsExpression("linux && gpu"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload).To(MatchJSON(`"linux && gpu"`))
+
+			payload, err = json.Marshal(atc.Tags{"linux"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload).To(MatchJSON(`["linux"]`))
+		})
+	})
+})
@@ -2,8 +2,10 @@ package atc
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
 
@@ -34,6 +36,24 @@ type TaskConfig struct {
 
 	// Path to cached directory that will be shared between builds for the same task.
 	Caches []CacheConfig `json:"caches,omitempty" yaml:"caches,omitempty" mapstructure:"caches"`
+
+	// Helper containers (e.g. a database) run alongside the task, sharing its
+	// network namespace, for the duration of the step.
+	Sidecars []TaskSidecarConfig `json:"sidecars,omitempty" yaml:"sidecars,omitempty" mapstructure:"sidecars"`
+
+	// Directory the task saves its progress to, so that it can pick up where it
+	// left off if it has to be re-run in a new container. The task marks each
+	// new checkpoint by writing to the .checkpoint file in the directory.
+	Checkpoint *TaskCheckpointConfig `json:"checkpoint,omitempty" yaml:"checkpoint,omitempty" mapstructure:"checkpoint"`
+}
+
+type TaskCheckpointConfig struct {
+	// Path to the directory, relative to the task's working directory.
+	Path string `json:"path" yaml:"path" mapstructure:"path"`
+
+	// How often the task is checked for a new checkpoint while it runs, which
+	// is then saved (e.g. 5m).
+	Interval string `json:"interval,omitempty" yaml:"interval,omitempty" mapstructure:"interval"`
 }
 
 type ImageResource struct {
@@ -131,6 +151,8 @@ func (config TaskConfig) Validate() error {
 	}
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
+	messages = append(messages, config.validateSidecars()...)
+	messages = append(messages, config.validateCheckpoint()...)
 
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
@@ -146,6 +168,84 @@ func (config TaskConfig) validateInputsAndOutputs() []string {
 	messages = append(messages, config.validateOutputContainsNames()...)
 	messages = append(messages, config.validateDotPath()...)
 	messages = append(messages, config.validateOverlappingPaths()...)
+	messages = append(messages, config.validateOutputPaths()...)
+
+	return messages
+}
+
+func (config TaskConfig) validateOutputPaths() []string {
+	messages := []string{}
+
+	for _, output := range config.Outputs {
+		for _, pattern := range output.Paths {
+			if pattern == "" {
+				messages = append(messages, fmt.Sprintf("  output '%s' has an empty path pattern", output.Name))
+				continue
+			}
+
+			if path.IsAbs(pattern) {
+				messages = append(messages, fmt.Sprintf("  output '%s' path pattern '%s' must be relative", output.Name, pattern))
+				continue
+			}
+
+			if _, err := path.Match(pattern, ""); err != nil {
+				messages = append(messages, fmt.Sprintf("  output '%s' has an invalid path pattern '%s'", output.Name, pattern))
+			}
+		}
+	}
+
+	return messages
+}
+
+func (config TaskConfig) validateSidecars() []string {
+	messages := []string{}
+
+	names := map[string]bool{}
+
+	for i, sidecar := range config.Sidecars {
+		if sidecar.Name == "" {
+			messages = append(messages, fmt.Sprintf("  sidecar in position %d is missing a name", i))
+			continue
+		}
+
+		if names[sidecar.Name] {
+			messages = append(messages, fmt.Sprintf("  cannot have more than one sidecar named '%s'", sidecar.Name))
+		}
+
+		names[sidecar.Name] = true
+
+		if sidecar.ImageResource == nil && sidecar.RootfsURI == "" {
+			messages = append(messages, fmt.Sprintf("  sidecar '%s' is missing an image_resource or rootfs_uri", sidecar.Name))
+		}
+
+		if sidecar.Run.Path == "" {
+			messages = append(messages, fmt.Sprintf("  sidecar '%s' is missing path to executable to run", sidecar.Name))
+		}
+	}
+
+	return messages
+}
+
+func (config TaskConfig) validateCheckpoint() []string {
+	messages := []string{}
+
+	if config.Checkpoint == nil {
+		return messages
+	}
+
+	checkpointPath := config.Checkpoint.Path
+
+	if checkpointPath == "" {
+		messages = append(messages, "  checkpoint is missing a path")
+	} else if path.IsAbs(checkpointPath) || strings.HasPrefix(path.Clean(checkpointPath), "..") || path.Clean(checkpointPath) == "." {
+		messages = append(messages, fmt.Sprintf("  checkpoint path '%s' must be a directory within the task's working directory", checkpointPath))
+	}
+
+	if config.Checkpoint.Interval != "" {
+		if _, err := time.ParseDuration(config.Checkpoint.Interval); err != nil {
+			messages = append(messages, fmt.Sprintf("  checkpoint interval '%s' could not be parsed", config.Checkpoint.Interval))
+		}
+	}
 
 	return messages
 }
@@ -342,10 +442,26 @@ type TaskRunConfig struct {
 	User string `json:"user,omitempty" yaml:"user,omitempty" mapstructure:"user"`
 }
 
+type TaskSidecarConfig struct {
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// The image to run the sidecar in, configured the same way as the task's.
+	RootfsURI     string         `json:"rootfs_uri,omitempty" yaml:"rootfs_uri,omitempty" mapstructure:"rootfs_uri"`
+	ImageResource *ImageResource `json:"image_resource,omitempty" yaml:"image_resource,omitempty" mapstructure:"image_resource"`
+
+	// Parameters to pass to the sidecar via environment variables.
+	Params map[string]string `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params"`
+
+	Run TaskRunConfig `json:"run" yaml:"run" mapstructure:"run"`
+}
+
 type TaskInputConfig struct {
-	Name     string `json:"name" yaml:"name"`
-	Path     string `json:"path,omitempty" yaml:"path,omitempty"`
-	Optional bool   `json:"optional,omitempty" yaml:"optional,omitempty"`
+	Name string `json:"name" yaml:"name"`
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+
+	// Optional inputs may be left unprovided rather than failing the task.
+	// Whether they were provided is exposed as INPUT_<NAME>_PRESENT.
+	Optional bool `json:"optional,omitempty" yaml:"optional,omitempty"`
 }
 
 func (input TaskInputConfig) resolvePath() string {
@@ -358,6 +474,15 @@ func (input TaskInputConfig) resolvePath() string {
 type TaskOutputConfig struct {
 	Name string `json:"name" yaml:"name"`
 	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+
+	// Optional set of glob patterns, relative to the output directory, limiting
+	// which files are made available to subsequent steps. `**` matches any
+	// number of directories.
+	Paths []string `json:"paths,omitempty" yaml:"paths,omitempty"`
+
+	// Optional limit, in bytes, on the total size of the files in the output.
+	// The task fails if its output exceeds the limit.
+	MaxSize uint64 `json:"max_size,omitempty" yaml:"max_size,omitempty"`
 }
 
 func (output TaskOutputConfig) resolvePath() string {
@@ -232,6 +232,36 @@ run: {path: a/file}
 					Expect(err).To(MatchError(ContainSubstring("  output in position 2 is missing a name")))
 				})
 			})
+
+			Context("when output.paths are valid patterns", func() {
+				BeforeEach(func() {
+					validConfig.Outputs = []TaskOutputConfig{{Name: "concourse", Paths: []string{"dist/**", "*.tgz"}}}
+				})
+
+				It("is valid", func() {
+					Expect(validConfig.Validate()).ToNot(HaveOccurred())
+				})
+			})
+
+			Context("when output.paths contains an absolute path", func() {
+				BeforeEach(func() {
+					invalidConfig.Outputs = append(invalidConfig.Outputs, TaskOutputConfig{Name: "concourse", Paths: []string{"/dist/**"}})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  output 'concourse' path pattern '/dist/**' must be relative")))
+				})
+			})
+
+			Context("when output.paths contains a malformed pattern", func() {
+				BeforeEach(func() {
+					invalidConfig.Outputs = append(invalidConfig.Outputs, TaskOutputConfig{Name: "concourse", Paths: []string{"dist/[a-"}})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  output 'concourse' has an invalid path pattern 'dist/[a-'")))
+				})
+			})
 		})
 
 		Context("when run is missing", func() {
@@ -244,6 +274,117 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has sidecars", func() {
+			BeforeEach(func() {
+				validConfig.Sidecars = []TaskSidecarConfig{
+					{Name: "db", RootfsURI: "docker:///postgres", Run: TaskRunConfig{Path: "postgres"}},
+				}
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when sidecar.name is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Sidecars = []TaskSidecarConfig{
+						{RootfsURI: "docker:///postgres", Run: TaskRunConfig{Path: "postgres"}},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  sidecar in position 0 is missing a name")))
+				})
+			})
+
+			Context("when two sidecars have the same name", func() {
+				BeforeEach(func() {
+					invalidConfig.Sidecars = []TaskSidecarConfig{
+						{Name: "db", RootfsURI: "docker:///postgres", Run: TaskRunConfig{Path: "postgres"}},
+						{Name: "db", RootfsURI: "docker:///mysql", Run: TaskRunConfig{Path: "mysqld"}},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cannot have more than one sidecar named 'db'")))
+				})
+			})
+
+			Context("when a sidecar has no image", func() {
+				BeforeEach(func() {
+					invalidConfig.Sidecars = []TaskSidecarConfig{
+						{Name: "db", Run: TaskRunConfig{Path: "postgres"}},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  sidecar 'db' is missing an image_resource or rootfs_uri")))
+				})
+			})
+
+			Context("when a sidecar has no run path", func() {
+				BeforeEach(func() {
+					invalidConfig.Sidecars = []TaskSidecarConfig{
+						{Name: "db", RootfsURI: "docker:///postgres"},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  sidecar 'db' is missing path to executable to run")))
+				})
+			})
+		})
+
+		Context("when the task has a checkpoint", func() {
+			BeforeEach(func() {
+				validConfig.Checkpoint = &TaskCheckpointConfig{Path: "progress", Interval: "5m"}
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when checkpoint.path is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Checkpoint = &TaskCheckpointConfig{}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  checkpoint is missing a path")))
+				})
+			})
+
+			Context("when checkpoint.path is absolute", func() {
+				BeforeEach(func() {
+					invalidConfig.Checkpoint = &TaskCheckpointConfig{Path: "/tmp/progress"}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  checkpoint path '/tmp/progress' must be a directory within the task's working directory")))
+				})
+			})
+
+			Context("when checkpoint.path escapes the working directory", func() {
+				BeforeEach(func() {
+					invalidConfig.Checkpoint = &TaskCheckpointConfig{Path: "../progress"}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  checkpoint path '../progress' must be a directory within the task's working directory")))
+				})
+			})
+
+			Context("when checkpoint.interval cannot be parsed", func() {
+				BeforeEach(func() {
+					invalidConfig.Checkpoint = &TaskCheckpointConfig{Path: "progress", Interval: "often"}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  checkpoint interval 'often' could not be parsed")))
+				})
+			})
+		})
+
 		Describe("input overlapping checks", func() {
 			Context("when two inputs have the same name", func() {
 				BeforeEach(func() {
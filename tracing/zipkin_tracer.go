@@ -0,0 +1,230 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/exec"
+)
+
+// UnexpectedResponseError is returned when the collector responds with
+// anything other than 202 Accepted or 200 OK.
+type UnexpectedResponseError struct {
+	StatusCode int
+}
+
+func (err UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("collector responded with unexpected status: %d", err.StatusCode)
+}
+
+// ZipkinTracer is an exec.Tracer which reports spans to a Zipkin collector,
+// or anything accepting Zipkin's v2 JSON span format (e.g. Jaeger or an
+// OpenTelemetry collector). Finished spans are buffered and reported in
+// batches while the tracer is running.
+type ZipkinTracer struct {
+	logger      lager.Logger
+	url         string
+	serviceName string
+	client      *http.Client
+	interval    time.Duration
+
+	spans chan zipkinSpan
+}
+
+// NewZipkinTracer constructs a tracer which POSTs spans to the given URL,
+// e.g. http://zipkin:9411/api/v2/spans, every interval. Spans finished while
+// the buffer is full are dropped rather than holding up the build.
+func NewZipkinTracer(
+	logger lager.Logger,
+	collectorURL string,
+	serviceName string,
+	client *http.Client,
+	interval time.Duration,
+	bufferSize int,
+) *ZipkinTracer {
+	return &ZipkinTracer{
+		logger:      logger,
+		url:         collectorURL,
+		serviceName: serviceName,
+		client:      client,
+		interval:    interval,
+
+		spans: make(chan zipkinSpan, bufferSize),
+	}
+}
+
+type spanKey struct{}
+
+func (tracer *ZipkinTracer) Start(ctx context.Context, name string, attributes map[string]string) (context.Context, exec.Span) {
+	tags := map[string]string{}
+	for k, v := range attributes {
+		tags[k] = v
+	}
+
+	s := &span{
+		tracer: tracer,
+
+		id:    randomID(8),
+		name:  name,
+		tags:  tags,
+		start: time.Now(),
+	}
+
+	if parent, ok := ctx.Value(spanKey{}).(*span); ok {
+		s.traceID = parent.traceID
+		s.parentID = parent.id
+	} else {
+		s.traceID = randomID(16)
+	}
+
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// Run reports the buffered spans every interval until signalled, at which
+// point the remaining spans are reported before exiting.
+func (tracer *ZipkinTracer) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	ticker := time.NewTicker(tracer.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			tracer.flush()
+		case <-signals:
+			tracer.flush()
+			return nil
+		}
+	}
+}
+
+func (tracer *ZipkinTracer) record(s zipkinSpan) {
+	select {
+	case tracer.spans <- s:
+	default:
+		tracer.logger.Info("dropped-span", lager.Data{"trace-id": s.TraceID, "span-id": s.ID})
+	}
+}
+
+func (tracer *ZipkinTracer) flush() {
+	var batch []zipkinSpan
+
+drain:
+	for {
+		select {
+		case s := <-tracer.spans:
+			batch = append(batch, s)
+		default:
+			break drain
+		}
+	}
+
+	if len(batch) == 0 {
+		return
+	}
+
+	err := tracer.report(batch)
+	if err != nil {
+		tracer.logger.Error("failed-to-report-spans", err, lager.Data{"spans": len(batch)})
+	}
+}
+
+func (tracer *ZipkinTracer) report(batch []zipkinSpan) error {
+	payload, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", tracer.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := tracer.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return UnexpectedResponseError{StatusCode: resp.StatusCode}
+	}
+
+	return nil
+}
+
+type span struct {
+	tracer *ZipkinTracer
+
+	traceID  string
+	id       string
+	parentID string
+	name     string
+	tags     map[string]string
+	start    time.Time
+}
+
+// Carrier returns the W3C trace context of the span, so that anything the
+// step runs can continue the trace.
+func (s *span) Carrier() map[string]string {
+	return map[string]string{
+		"traceparent": "00-" + s.traceID + "-" + s.id + "-01",
+	}
+}
+
+func (s *span) End(err error) {
+	if err != nil {
+		s.tags["error"] = err.Error()
+	}
+
+	s.tracer.record(zipkinSpan{
+		TraceID:   s.traceID,
+		ID:        s.id,
+		ParentID:  s.parentID,
+		Name:      s.name,
+		Timestamp: s.start.UnixNano() / int64(time.Microsecond),
+		Duration:  int64(time.Since(s.start) / time.Microsecond),
+		LocalEndpoint: zipkinEndpoint{
+			ServiceName: s.tracer.serviceName,
+		},
+		Tags: s.tags,
+	})
+}
+
+type zipkinSpan struct {
+	TraceID       string            `json:"traceId"`
+	ID            string            `json:"id"`
+	ParentID      string            `json:"parentId,omitempty"`
+	Name          string            `json:"name"`
+	Timestamp     int64             `json:"timestamp"`
+	Duration      int64             `json:"duration"`
+	LocalEndpoint zipkinEndpoint    `json:"localEndpoint"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+func randomID(size int) string {
+	id := make([]byte, size)
+
+	_, err := rand.Read(id)
+	if err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(id)
+}
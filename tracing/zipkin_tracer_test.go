@@ -0,0 +1,142 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/tracing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("ZipkinTracer", func() {
+	var (
+		server  *ghttp.Server
+		tracer  *tracing.ZipkinTracer
+		process ifrit.Process
+
+		reported chan []map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		reported = make(chan []map[string]interface{}, 10)
+
+		server = ghttp.NewServer()
+		server.RouteToHandler("POST", "/api/v2/spans", func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			payload, err := ioutil.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			var spans []map[string]interface{}
+			Expect(json.Unmarshal(payload, &spans)).To(Succeed())
+
+			reported <- spans
+
+			w.WriteHeader(http.StatusAccepted)
+		})
+
+		tracer = tracing.NewZipkinTracer(
+			lagertest.NewTestLogger("test"),
+			server.URL()+"/api/v2/spans",
+			"concourse",
+			http.DefaultClient,
+			time.Hour,
+			10,
+		)
+
+		process = ifrit.Invoke(tracer)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	stop := func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	}
+
+	It("reports the spans that ended when signalled", func() {
+		ctx, parent := tracer.Start(context.Background(), "build", map[string]string{"team": "main"})
+		_, child := tracer.Start(ctx, "get", map[string]string{"name": "some-input"})
+
+		child.End(errors.New("nope"))
+		parent.End(nil)
+
+		stop()
+
+		var spans []map[string]interface{}
+		Expect(reported).To(Receive(&spans))
+		Expect(spans).To(HaveLen(2))
+
+		childSpan, parentSpan := spans[0], spans[1]
+
+		Expect(parentSpan["name"]).To(Equal("build"))
+		Expect(parentSpan).NotTo(HaveKey("parentId"))
+		Expect(parentSpan["traceId"]).To(HaveLen(32))
+		Expect(parentSpan["id"]).To(HaveLen(16))
+		Expect(parentSpan["localEndpoint"]).To(Equal(map[string]interface{}{"serviceName": "concourse"}))
+		Expect(parentSpan["tags"]).To(Equal(map[string]interface{}{"team": "main"}))
+
+		Expect(childSpan["name"]).To(Equal("get"))
+		Expect(childSpan["traceId"]).To(Equal(parentSpan["traceId"]))
+		Expect(childSpan["parentId"]).To(Equal(parentSpan["id"]))
+		Expect(childSpan["tags"]).To(Equal(map[string]interface{}{
+			"name":  "some-input",
+			"error": "nope",
+		}))
+	})
+
+	It("propagates the span's trace context as a W3C traceparent", func() {
+		ctx, parent := tracer.Start(context.Background(), "build", nil)
+		_, child := tracer.Start(ctx, "task", nil)
+
+		parentFields := strings.Split(parent.Carrier()["traceparent"], "-")
+		Expect(parentFields).To(HaveLen(4))
+		Expect(parentFields[0]).To(Equal("00"))
+		Expect(parentFields[1]).To(HaveLen(32))
+		Expect(parentFields[2]).To(HaveLen(16))
+		Expect(parentFields[3]).To(Equal("01"))
+
+		childFields := strings.Split(child.Carrier()["traceparent"], "-")
+		Expect(childFields[1]).To(Equal(parentFields[1]))
+		Expect(childFields[2]).NotTo(Equal(parentFields[2]))
+
+		stop()
+	})
+
+	It("does not report anything when no spans have ended", func() {
+		tracer.Start(context.Background(), "build", nil)
+
+		stop()
+
+		Expect(reported).NotTo(Receive())
+	})
+
+	Context("when more spans end than fit in the buffer", func() {
+		It("drops the rest rather than blocking", func() {
+			for i := 0; i < 15; i++ {
+				_, span := tracer.Start(context.Background(), "build", nil)
+				span.End(nil)
+			}
+
+			stop()
+
+			var spans []map[string]interface{}
+			Expect(reported).To(Receive(&spans))
+			Expect(spans).To(HaveLen(10))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package atc
 import (
 	"errors"
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 	"time"
@@ -49,6 +50,11 @@ func (c Config) Validate() ([]Warning, []string) {
 	}
 	warnings = append(warnings, jobWarnings...)
 
+	retentionErr := validateBuildLogRetention(c)
+	if retentionErr != nil {
+		errorMessages = append(errorMessages, formatErr("build log retention", retentionErr))
+	}
+
 	return warnings, errorMessages
 }
 
@@ -120,6 +126,8 @@ func validateResources(c Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		errorMessages = append(errorMessages, validateTags(identifier, resource.Tags)...)
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -156,6 +164,30 @@ func validateResourceTypes(c Config) error {
 		if resourceType.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		errorMessages = append(errorMessages, validateTags(identifier, resourceType.Tags)...)
+	}
+
+	return compositeErr(errorMessages)
+}
+
+func validateBuildLogRetention(c Config) error {
+	if c.BuildLogRetention == nil {
+		return nil
+	}
+
+	errorMessages := []string{}
+
+	if c.BuildLogRetention.Builds < 0 {
+		errorMessages = append(errorMessages, fmt.Sprintf("build_log_retention has negative builds: %d", c.BuildLogRetention.Builds))
+	}
+
+	if c.BuildLogRetention.Days < 0 {
+		errorMessages = append(errorMessages, fmt.Sprintf("build_log_retention has negative days: %d", c.BuildLogRetention.Days))
+	}
+
+	if c.BuildLogRetention.MinimumSucceeded < 0 {
+		errorMessages = append(errorMessages, fmt.Sprintf("build_log_retention has negative minimum_succeeded: %d", c.BuildLogRetention.MinimumSucceeded))
 	}
 
 	return compositeErr(errorMessages)
@@ -185,6 +217,14 @@ func usedResources(c Config) map[string]bool {
 		for _, output := range job.Outputs() {
 			usedResources[output.Resource] = true
 		}
+		for _, plan := range job.Plans() {
+			if plan.WaitFor != "" && plan.HTTP == nil {
+				usedResources[plan.ResourceName()] = true
+			}
+			if plan.Promote != "" && plan.From != "" {
+				usedResources[plan.From] = true
+			}
+		}
 	}
 
 	return usedResources
@@ -256,6 +296,27 @@ func validateJobs(c Config) ([]Warning, error) {
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
 
+		for _, plan := range job.Plans() {
+			if plan.Promote == "" || plan.From == "" {
+				continue
+			}
+
+			fetched := false
+			for _, input := range job.Inputs() {
+				if input.Resource == plan.From {
+					fetched = true
+					break
+				}
+			}
+
+			if !fetched {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s promotes a resource that none of its get steps fetch ('%s')", identifier, plan.From),
+				)
+			}
+		}
+
 		encountered := map[string]int{}
 		for _, input := range job.Inputs() {
 			encountered[input.Name]++
@@ -331,6 +392,26 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		foundTypes.Find("try")
 	}
 
+	if plan.SaveCache != "" {
+		foundTypes.Find("save_cache")
+	}
+
+	if plan.RestoreCache != "" {
+		foundTypes.Find("restore_cache")
+	}
+
+	if plan.WaitFor != "" {
+		foundTypes.Find("wait_for")
+	}
+
+	if plan.Promote != "" {
+		foundTypes.Find("promote")
+	}
+
+	if plan.SetVar != "" {
+		foundTypes.Find("set_var")
+	}
+
 	if valid, message := foundTypes.IsValid(); !valid {
 		return []Warning{}, []string{message}
 	}
@@ -359,10 +440,21 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"continue_on_failure", "privileged", "config", "file", "http", "interval", "from"},
 			plan, identifier)...,
 		)
 
+		if plan.Path != "" && !isNestedPath(plan.Path) {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf(
+					"%s.path must be a relative path within the task's working directory ('%s')",
+					identifier,
+					plan.Path,
+				),
+			)
+		}
+
 		if plan.Resource != "" {
 			_, found := c.Resources.Lookup(plan.Resource)
 			if !found {
@@ -434,7 +526,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "annotated", "trigger", "continue_on_failure", "privileged", "config", "file", "path", "http", "interval", "from"},
 			plan, identifier)...,
 		)
 
@@ -491,7 +583,117 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		}
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "annotated", "trigger", "continue_on_failure", "path", "http", "interval", "from"},
+			plan, identifier)...,
+		)
+
+	case plan.SaveCache != "":
+		identifier = fmt.Sprintf("%s.save_cache.%s", identifier, plan.SaveCache)
+
+		if plan.CacheKey == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a cache key")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "annotated", "trigger", "continue_on_failure", "privileged", "config", "file", "path", "http", "interval", "from"},
+			plan, identifier)...,
+		)
+
+	case plan.RestoreCache != "":
+		identifier = fmt.Sprintf("%s.restore_cache.%s", identifier, plan.RestoreCache)
+
+		if plan.CacheKey == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a cache key")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "annotated", "trigger", "continue_on_failure", "privileged", "config", "file", "path", "http", "interval", "from"},
+			plan, identifier)...,
+		)
+
+	case plan.WaitFor != "":
+		identifier = fmt.Sprintf("%s.wait_for.%s", identifier, plan.WaitFor)
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"passed", "annotated", "trigger", "continue_on_failure", "privileged", "config", "file", "path", "from"},
+			plan, identifier)...,
+		)
+
+		if plan.Interval != "" {
+			subIdentifier := fmt.Sprintf("%s.interval", identifier)
+
+			interval, err := time.ParseDuration(plan.Interval)
+			if err != nil {
+				errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" refers to a duration that could not be parsed ('%s')", plan.Interval))
+			} else if interval <= 0 {
+				errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" must be a positive duration ('%s')", plan.Interval))
+			}
+		}
+
+		if plan.HTTP != nil {
+			if plan.Resource != "" {
+				errorMessages = append(errorMessages, identifier+" specifies both `http` and `resource`")
+			}
+
+			if plan.HTTP.URL == "" {
+				errorMessages = append(errorMessages, identifier+" does not specify an http url")
+			}
+		} else {
+			_, found := c.Resources.Lookup(plan.ResourceName())
+			if !found {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf(
+						"%s refers to a resource that does not exist ('%s')",
+						identifier,
+						plan.ResourceName(),
+					),
+				)
+			}
+		}
+
+	case plan.Promote != "":
+		identifier = fmt.Sprintf("%s.promote.%s", identifier, plan.Promote)
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"passed", "annotated", "trigger", "continue_on_failure", "privileged", "config", "file", "path", "http", "interval"},
+			plan, identifier)...,
+		)
+
+		_, found := c.Resources.Lookup(plan.ResourceName())
+		if !found {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf(
+					"%s refers to a resource that does not exist ('%s')",
+					identifier,
+					plan.ResourceName(),
+				),
+			)
+		}
+
+		if plan.From == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a resource to promote from")
+		} else if _, found := c.Resources.Lookup(plan.From); !found {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf(
+					"%s.from refers to a resource that does not exist ('%s')",
+					identifier,
+					plan.From,
+				),
+			)
+		}
+
+	case plan.SetVar != "":
+		identifier = fmt.Sprintf("%s.set_var.%s", identifier, plan.SetVar)
+
+		if plan.Value == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a value")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "annotated", "trigger", "continue_on_failure", "privileged", "config", "file", "path", "http", "interval", "from"},
 			plan, identifier)...,
 		)
 
@@ -543,9 +745,20 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
 	}
 
+	errorMessages = append(errorMessages, validateTags(identifier, plan.Tags)...)
+
 	return warnings, errorMessages
 }
 
+func validateTags(identifier string, tags Tags) []string {
+	errorMessages := []string{}
+	for _, err := range tags.ValidateExpressions() {
+		errorMessages = append(errorMessages, fmt.Sprintf("%s.tags has an %s", identifier, err))
+	}
+
+	return errorMessages
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
@@ -560,6 +773,14 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if len(plan.Passed) != 0 {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "annotated":
+			if len(plan.Annotated) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "continue_on_failure":
+			if plan.ContinueOnFailure {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		case "trigger":
 			if plan.Trigger {
 				foundInapplicableFields = append(foundInapplicableFields, field)
@@ -576,6 +797,22 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "path":
+			if plan.Path != "" {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "http":
+			if plan.HTTP != nil {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "interval":
+			if plan.Interval != "" {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "from":
+			if plan.From != "" {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
@@ -593,6 +830,13 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 	return errorMessages
 }
 
+// isNestedPath is true if the path is relative and does not escape the
+// directory it is relative to.
+func isNestedPath(p string) bool {
+	clean := path.Clean(p)
+	return !path.IsAbs(clean) && clean != "." && clean != ".." && !strings.HasPrefix(clean, "../")
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
@@ -144,6 +144,26 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("invalid build log retention", func() {
+		Context("when the retention has negative limits", func() {
+			BeforeEach(func() {
+				config.BuildLogRetention = &BuildLogRetention{
+					Builds:           -1,
+					Days:             -2,
+					MinimumSucceeded: -3,
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid build log retention:"))
+				Expect(errorMessages[0]).To(ContainSubstring("build_log_retention has negative builds: -1"))
+				Expect(errorMessages[0]).To(ContainSubstring("build_log_retention has negative days: -2"))
+				Expect(errorMessages[0]).To(ContainSubstring("build_log_retention has negative minimum_succeeded: -3"))
+			})
+		})
+	})
+
 	Describe("invalid resources", func() {
 		Context("when a resource has no name", func() {
 			BeforeEach(func() {
@@ -159,6 +179,35 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource has a malformed tag expression", func() {
+			BeforeEach(func() {
+				config.Resources = append(config.Resources, ResourceConfig{
+					Name: "some-tagged-resource",
+					Type: "some-type",
+					Tags: TagsExpression("linux ||"),
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-tagged-resource.tags has an invalid tag expression ('linux ||'): unexpected end of expression"))
+			})
+		})
+
+		Context("when a resource has plain tags containing operators", func() {
+			BeforeEach(func() {
+				config.Resources = append(config.Resources, ResourceConfig{
+					Name: "some-tagged-resource",
+					Type: "some-type",
+					Tags: Tags{"linux ||", "gpu (a100)"},
+				})
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
 		Context("when a resource has no type", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, ResourceConfig{
@@ -257,6 +306,10 @@ var _ = Describe("ValidateConfig", func() {
 						Name: "success",
 						Type: "some-type",
 					},
+					{
+						Name: "wait",
+						Type: "some-type",
+					},
 					{
 						Name: "try",
 						Type: "some-type",
@@ -344,6 +397,9 @@ var _ = Describe("ValidateConfig", func() {
 							{
 								Get: "another-job",
 							},
+							{
+								WaitFor: "wait",
+							},
 							{
 								Task:           "some-task",
 								TaskConfigPath: "some/config/path.yml",
@@ -616,6 +672,108 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a get plan has a path that leaves the working directory", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:      "lol",
+						Resource: "some-resource",
+						Path:     "src/../../lol",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.lol.path must be a relative path within the task's working directory ('src/../../lol')"))
+				})
+			})
+
+			Context("when a get plan has an absolute path", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:      "lol",
+						Resource: "some-resource",
+						Path:     "/lol",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.lol.path must be a relative path within the task's working directory ('/lol')"))
+				})
+			})
+
+			Context("when a get plan has a nested path", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:      "lol",
+						Resource: "some-resource",
+						Path:     "src/github.com/lol",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a put plan has a path", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:      "lol",
+						Resource: "some-resource",
+						Path:     "src/lol",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.lol has invalid fields specified (path)"))
+				})
+			})
+
+			Context("when a put plan continues on failure", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:               "lol",
+						Resource:          "some-resource",
+						ContinueOnFailure: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.lol has invalid fields specified (continue_on_failure)"))
+				})
+			})
+
+			Context("when a put plan requires annotations", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:       "lol",
+						Resource:  "some-resource",
+						Annotated: map[string]string{"qa": "passed"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.lol has invalid fields specified (annotated)"))
+				})
+			})
+
 			Context("when a task plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -674,6 +832,277 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a save_cache plan does not specify a key", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SaveCache: "some-artifact",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].save_cache.some-artifact does not specify a cache key"))
+				})
+			})
+
+			Context("when a restore_cache plan does not specify a key", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						RestoreCache: "some-artifact",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].restore_cache.some-artifact does not specify a cache key"))
+				})
+			})
+
+			Context("when a wait_for plan refers to a resource that does not exist", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						WaitFor: "some-condition",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].wait_for.some-condition refers to a resource that does not exist ('some-condition')"))
+				})
+			})
+
+			Context("when a wait_for plan polls an HTTP endpoint", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						WaitFor:  "some-condition",
+						HTTP:     &WaitForHTTPConfig{URL: "https://example.com/health"},
+						Interval: "30s",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a wait_for plan specifies both http and resource", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						WaitFor:  "some-condition",
+						Resource: "some-resource",
+						HTTP:     &WaitForHTTPConfig{URL: "https://example.com/health"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].wait_for.some-condition specifies both `http` and `resource`"))
+				})
+			})
+
+			Context("when a wait_for plan does not specify an http url", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						WaitFor: "some-condition",
+						HTTP:    &WaitForHTTPConfig{},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].wait_for.some-condition does not specify an http url"))
+				})
+			})
+
+			Context("when a wait_for plan has an invalid interval", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						WaitFor:  "some-resource",
+						Interval: "nope",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].wait_for.some-resource.interval refers to a duration that could not be parsed ('nope')"))
+				})
+			})
+
+			Context("when a wait_for plan has a non-positive interval", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						WaitFor:  "some-resource",
+						Interval: "0s",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].wait_for.some-resource.interval must be a positive duration ('0s')"))
+				})
+			})
+
+			Context("when a promote plan promotes a resource fetched by the job", func() {
+				BeforeEach(func() {
+					config.Resources = append(config.Resources, ResourceConfig{
+						Name: "some-prod-resource",
+						Type: "some-type",
+					})
+
+					job.Plan = append(job.Plan, PlanConfig{
+						Get: "some-resource",
+					}, PlanConfig{
+						Promote: "some-prod-resource",
+						From:    "some-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a promote plan does not specify a resource to promote from", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Promote: "some-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].promote.some-resource does not specify a resource to promote from"))
+				})
+			})
+
+			Context("when a promote plan promotes from a resource that does not exist", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Promote: "some-resource",
+						From:    "some-nonexistent-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].promote.some-resource.from refers to a resource that does not exist ('some-nonexistent-resource')"))
+				})
+			})
+
+			Context("when a promote plan promotes a resource the job does not fetch", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Promote: "some-resource",
+						From:    "some-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job promotes a resource that none of its get steps fetch ('some-resource')"))
+				})
+			})
+
+			Context("when a set_var plan does not specify a value", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetVar: "some-var",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_var.some-var does not specify a value"))
+				})
+			})
+
+			Context("when a set_var plan specifies a resource", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetVar:   "some-var",
+						Value:    "some-value",
+						Resource: "some-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_var.some-var has invalid fields specified (resource)"))
+				})
+			})
+
+			Context("when a put plan specifies from", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:  "some-resource",
+						From: "some-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource has invalid fields specified (from)"))
+				})
+			})
+
+			Context("when a get plan specifies an interval", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:      "some-resource",
+						Interval: "30s",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource has invalid fields specified (interval)"))
+				})
+			})
+
 			Context("when a task plan is invalid", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1116,6 +1545,22 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has a malformed tag expression", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:  "some-resource",
+						Tags: TagsExpression("linux && (gpu"),
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.tags has an invalid tag expression ('linux && (gpu'): missing ')'"))
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -24,3 +24,19 @@ type Volume struct {
 	JobName          string                  `json:"job_name"`
 	StepName         string                  `json:"step_name"`
 }
+
+// WorkerVolume is a volume on a worker which is not mounted in any container,
+// e.g. a cache, along with the team it belongs to and its size, for finding
+// out what is using up the worker.
+type WorkerVolume struct {
+	ID           string `json:"id"`
+	WorkerName   string `json:"worker_name"`
+	Type         string `json:"type"`
+	State        string `json:"state"`
+	TeamName     string `json:"team_name,omitempty"`
+	Size         int64  `json:"size,omitempty"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+	JobName      string `json:"job_name,omitempty"`
+	StepName     string `json:"step_name,omitempty"`
+	Path         string `json:"path,omitempty"`
+}
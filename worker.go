@@ -29,6 +29,52 @@ type Worker struct {
 	Version   string   `json:"version"`
 	StartTime int64    `json:"start_time"`
 	State     string   `json:"state"`
+
+	// Ephemeral workers, e.g. autoscaled or spot instances, are removed once
+	// they have been stalled for a grace period, rather than being kept in
+	// the hope that they come back.
+	Ephemeral bool `json:"ephemeral,omitempty"`
+
+	// MemoryPressure and DiskPressure are reported by the worker with each
+	// heartbeat when it is running low on memory or disk. New containers are
+	// not placed on workers under pressure.
+	MemoryPressure bool `json:"memory_pressure,omitempty"`
+	DiskPressure   bool `json:"disk_pressure,omitempty"`
+
+	// DiskUsage is the percentage of the worker's volume disk in use, as
+	// reported with each heartbeat.
+	DiskUsage int `json:"disk_usage,omitempty"`
+
+	// ContainerUsages are the resource usages of the worker's containers, as
+	// of the heartbeat. Those of build containers are kept with the build.
+	ContainerUsages []ContainerUsage `json:"container_usages,omitempty"`
+
+	// VolumeUsages are the sizes of the worker's volumes, as of the
+	// heartbeat. They are totalled up for each team's caches. Workers which
+	// do not report them leave the sizes of their volumes unknown.
+	VolumeUsages []VolumeUsage `json:"volume_usages,omitempty"`
+
+	// InMaintenance is whether one of the worker's maintenance windows is in
+	// progress. No new containers are placed on the worker until it is over.
+	InMaintenance bool `json:"in_maintenance,omitempty"`
+}
+
+// WorkerMaintenanceWindow is a period, as unix timestamps, during which a
+// worker is drained of new containers.
+type WorkerMaintenanceWindow struct {
+	ID       int   `json:"id"`
+	StartsAt int64 `json:"starts_at"`
+	EndsAt   int64 `json:"ends_at"`
+}
+
+var ErrInvalidMaintenanceWindow = errors.New("maintenance window must end after it starts")
+
+func (w WorkerMaintenanceWindow) Validate() error {
+	if w.EndsAt <= w.StartsAt {
+		return ErrInvalidMaintenanceWindow
+	}
+
+	return nil
 }
 
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
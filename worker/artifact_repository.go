@@ -24,25 +24,57 @@ type ArtifactName string
 // subdirectories corresponding to their ArtifactName.
 type ArtifactRepository struct {
 	repo  map[ArtifactName]ArtifactSource
+	paths map[ArtifactName]string
 	repoL sync.RWMutex
+
+	streamed *StreamedArtifacts
 }
 
 // NewArtifactRepository constructs a new repository.
 func NewArtifactRepository() *ArtifactRepository {
 	return &ArtifactRepository{
-		repo: make(map[ArtifactName]ArtifactSource),
+		repo:  make(map[ArtifactName]ArtifactSource),
+		paths: make(map[ArtifactName]string),
+
+		streamed: NewStreamedArtifacts(),
 	}
 }
 
+// StreamedArtifacts returns the record of where the repository's artifacts
+// have been streamed to, for consumers to share through StreamedInputSource.
+func (repo *ArtifactRepository) StreamedArtifacts() *StreamedArtifacts {
+	return repo.streamed
+}
+
 // RegisterSource inserts an ArtifactSource into the map under the given
 // ArtifactName. Producers of artifacts, e.g. the Get step and the Task step,
 // will call this after they've successfully produced their artifact(s).
 func (repo *ArtifactRepository) RegisterSource(name ArtifactName, source ArtifactSource) {
 	repo.repoL.Lock()
 	repo.repo[name] = source
+	delete(repo.paths, name)
+	repo.repoL.Unlock()
+}
+
+// RegisterSourceAt is like RegisterSource, but additionally records a nested
+// path at which consumers should place the artifact by default, e.g.
+// "src/github.com/concourse/atc" rather than the artifact's name.
+func (repo *ArtifactRepository) RegisterSourceAt(name ArtifactName, path string, source ArtifactSource) {
+	repo.repoL.Lock()
+	repo.repo[name] = source
+	repo.paths[name] = path
 	repo.repoL.Unlock()
 }
 
+// PathFor looks up the path the source for the given ArtifactName was
+// registered at with RegisterSourceAt.
+func (repo *ArtifactRepository) PathFor(name ArtifactName) (string, bool) {
+	repo.repoL.RLock()
+	path, found := repo.paths[name]
+	repo.repoL.RUnlock()
+	return path, found
+}
+
 // SourceFor looks up a Source for the given ArtifactName. Consumers of
 // artifacts, e.g. the Task step, will call this to locate their dependencies.
 func (repo *ArtifactRepository) SourceFor(name ArtifactName) (ArtifactSource, bool) {
@@ -113,6 +145,7 @@ func (repo *ArtifactRepository) VolumeOn(worker Worker) (Volume, bool, error) {
 // not have a volume available on its destination.
 func (repo *ArtifactRepository) ScopedTo(names ...ArtifactName) (*ArtifactRepository, error) {
 	newRepo := NewArtifactRepository()
+	newRepo.streamed = repo.streamed
 
 	for _, name := range names {
 		source, found := repo.SourceFor(name)
@@ -120,7 +153,11 @@ func (repo *ArtifactRepository) ScopedTo(names ...ArtifactName) (*ArtifactReposi
 			return nil, fmt.Errorf("source does not exist in repository: %s", name)
 		}
 
-		newRepo.RegisterSource(name, source)
+		if path, found := repo.PathFor(name); found {
+			newRepo.RegisterSourceAt(name, path, source)
+		} else {
+			newRepo.RegisterSource(name, source)
+		}
 	}
 
 	return newRepo, nil
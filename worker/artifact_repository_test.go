@@ -50,6 +50,51 @@ var _ = Describe("ArtifactRepository", func() {
 			})
 		})
 
+		Describe("PathFor", func() {
+			It("yields nothing", func() {
+				_, found := repo.PathFor("first-source")
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the source is re-registered at a path", func() {
+			BeforeEach(func() {
+				repo.RegisterSourceAt("first-source", "src/first-source", firstSource)
+			})
+
+			It("yields the source by the given name", func() {
+				source, found := repo.SourceFor("first-source")
+				Expect(source).To(Equal(firstSource))
+				Expect(found).To(BeTrue())
+			})
+
+			It("yields the path by the given name", func() {
+				path, found := repo.PathFor("first-source")
+				Expect(path).To(Equal("src/first-source"))
+				Expect(found).To(BeTrue())
+			})
+
+			It("keeps the path when scoped", func() {
+				scoped, err := repo.ScopedTo("first-source")
+				Expect(err).ToNot(HaveOccurred())
+
+				path, found := scoped.PathFor("first-source")
+				Expect(path).To(Equal("src/first-source"))
+				Expect(found).To(BeTrue())
+			})
+
+			Context("when the source is then registered without a path", func() {
+				BeforeEach(func() {
+					repo.RegisterSource("first-source", firstSource)
+				})
+
+				It("forgets the path", func() {
+					_, found := repo.PathFor("first-source")
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when a second source is registered", func() {
 			var secondSource *workerfakes.FakeArtifactSource
 
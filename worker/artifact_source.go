@@ -25,3 +25,14 @@ type ArtifactSource interface {
 	// `StreamTo` will be used to copy the data to the destination instead.
 	VolumeOn(Worker) (Volume, bool, error)
 }
+
+// VolumeArtifactSource is implemented by sources whose data is a volume on a
+// worker, so that it can be streamed directly to another worker rather than
+// through the ATC.
+type VolumeArtifactSource interface {
+	ArtifactSource
+
+	// SourceVolume returns the volume and the path within it of the data, or
+	// false if the source has no volume.
+	SourceVolume() (Volume, string, bool)
+}
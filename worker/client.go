@@ -26,6 +26,11 @@ type Client interface {
 	) (Container, error)
 
 	FindContainerByHandle(lager.Logger, int, string) (Container, bool, error)
+	FindContainerByOwner(lager.Logger, int, db.ContainerOwner) (Container, bool, error)
+
+	// ReleaseTaskSlot frees the worker's task slot held by the task owning the
+	// container once its process has exited.
+	ReleaseTaskSlot(lager.Logger, int, db.ContainerOwner) error
 
 	LookupVolume(lager.Logger, string) (Volume, bool, error)
 
@@ -28,6 +28,7 @@ func NewContainerProvider(
 	dbVolumeFactory db.VolumeFactory,
 	dbTeamFactory db.TeamFactory,
 	lockFactory lock.LockFactory,
+	p2pStreamer P2PStreamer,
 ) ContainerProvider {
 
 	return &containerProvider{
@@ -43,6 +44,7 @@ func NewContainerProvider(
 		noProxy:            dbWorker.NoProxy(),
 		clock:              clock,
 		worker:             dbWorker,
+		p2pStreamer:        p2pStreamer,
 	}
 }
 
@@ -55,6 +57,12 @@ type ContainerProvider interface {
 		teamID int,
 	) (Container, bool, error)
 
+	FindCreatedContainerByOwner(
+		logger lager.Logger,
+		owner db.ContainerOwner,
+		teamID int,
+	) (Container, bool, error)
+
 	FindOrCreateContainer(
 		ctx context.Context,
 		logger lager.Logger,
@@ -82,6 +90,10 @@ type containerProvider struct {
 	noProxy       string
 
 	clock clock.Clock
+
+	// p2pStreamer streams inputs directly from the workers they are on, if
+	// set. Otherwise they are streamed through the ATC.
+	p2pStreamer P2PStreamer
 }
 
 func (p *containerProvider) FindOrCreateContainer(
@@ -163,6 +175,22 @@ func (p *containerProvider) FindOrCreateContainer(
 				return nil, err
 			}
 
+			sidecarImages := make([]Image, len(spec.Sidecars))
+			for i, sidecar := range spec.Sidecars {
+				sidecarImages[i], err = p.imageFactory.GetImage(
+					logger,
+					worker,
+					p.volumeClient,
+					sidecar.ImageSpec,
+					spec.TeamID,
+					delegate,
+					resourceTypes,
+				)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			if creatingContainer == nil {
 				logger.Debug("creating-container-in-db")
 
@@ -203,6 +231,16 @@ func (p *containerProvider) FindOrCreateContainer(
 				return nil, err
 			}
 
+			fetchedSidecarImages := make([]FetchedImage, len(sidecarImages))
+			for i, sidecarImage := range sidecarImages {
+				fetchedSidecarImages[i], err = sidecarImage.FetchForContainer(ctx, logger, creatingContainer)
+				if err != nil {
+					creatingContainer.Failed()
+					logger.Error("failed-to-fetch-sidecar-image-for-container", err, lager.Data{"sidecar": spec.Sidecars[i].Name})
+					return nil, err
+				}
+			}
+
 			logger.Debug("creating-container-in-garden")
 
 			gardenContainer, err = p.createGardenContainer(
@@ -225,6 +263,17 @@ func (p *containerProvider) FindOrCreateContainer(
 			metric.ContainersCreated.Inc()
 
 			logger.Debug("created-container-in-garden")
+
+			err = p.startSidecars(logger, gardenContainer, spec.Sidecars, fetchedSidecarImages)
+			if err != nil {
+				_, failedErr := creatingContainer.Failed()
+				if failedErr != nil {
+					logger.Error("failed-to-mark-container-as-failed", err)
+				}
+
+				logger.Error("failed-to-start-sidecars", err)
+				return nil, err
+			}
 		}
 
 		createdContainer, err = creatingContainer.Created()
@@ -295,6 +344,29 @@ func (p *containerProvider) FindCreatedContainerByHandle(
 	return container, true, nil
 }
 
+// FindCreatedContainerByOwner finds the container created on the worker for
+// the owner, without creating one if there is none.
+func (p *containerProvider) FindCreatedContainerByOwner(
+	logger lager.Logger,
+	owner db.ContainerOwner,
+	teamID int,
+) (Container, bool, error) {
+	_, createdContainer, err := p.dbTeamFactory.GetByID(teamID).FindContainerOnWorker(
+		p.worker.Name(),
+		owner,
+	)
+	if err != nil {
+		logger.Error("failed-to-find-container-in-db", err)
+		return nil, false, err
+	}
+
+	if createdContainer == nil {
+		return nil, false, nil
+	}
+
+	return p.FindCreatedContainerByHandle(logger, createdContainer.Handle(), teamID)
+}
+
 func (p *containerProvider) constructGardenWorkerContainer(
 	logger lager.Logger,
 	createdContainer db.CreatedContainer,
@@ -397,22 +469,49 @@ func (p *containerProvider) createGardenContainer(
 			if err != nil {
 				return nil, err
 			}
-		} else {
-			inputVolume, err = p.volumeClient.FindOrCreateVolumeForContainer(
+		} else if streamedSource, ok := inputSource.(StreamedInputSource); ok {
+			streamedVolume, err := streamedSource.StreamedArtifacts().VolumeOn(
+				logger,
+				worker,
+				inputSource.Source(),
+				func() (Volume, error) {
+					return p.streamInput(
+						logger,
+						creatingContainer,
+						spec.TeamID,
+						fetchedImage.Privileged,
+						inputSource.Source(),
+						streamedInputPath(inputSource.DestinationPath()),
+					)
+				},
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			inputVolume, err = p.volumeClient.FindOrCreateCOWVolumeForContainer(
 				logger,
 				VolumeSpec{
-					Strategy:   baggageclaim.EmptyStrategy{},
+					Strategy:   streamedVolume.COWStrategy(),
 					Privileged: fetchedImage.Privileged,
 				},
 				creatingContainer,
+				streamedVolume,
 				spec.TeamID,
 				inputSource.DestinationPath(),
 			)
 			if err != nil {
 				return nil, err
 			}
-
-			err = inputSource.Source().StreamTo(inputVolume)
+		} else {
+			inputVolume, err = p.streamInput(
+				logger,
+				creatingContainer,
+				spec.TeamID,
+				fetchedImage.Privileged,
+				inputSource.Source(),
+				inputSource.DestinationPath(),
+			)
 			if err != nil {
 				return nil, err
 			}
@@ -499,6 +598,40 @@ func (p *containerProvider) createGardenContainer(
 	})
 }
 
+func (p *containerProvider) startSidecars(
+	logger lager.Logger,
+	gardenContainer garden.Container,
+	sidecars []SidecarSpec,
+	fetchedImages []FetchedImage,
+) error {
+	for i, sidecar := range sidecars {
+		fetchedImage := fetchedImages[i]
+
+		user := sidecar.User
+		if user == "" {
+			user = fetchedImage.Metadata.User
+		}
+
+		logger.Debug("starting-sidecar", lager.Data{"sidecar": sidecar.Name})
+
+		// processes run with their own image share the container's network
+		// namespace, so the task can reach its sidecars on localhost
+		_, err := gardenContainer.Run(garden.ProcessSpec{
+			ID:    SidecarProcessID(sidecar.Name),
+			Path:  sidecar.Path,
+			Args:  sidecar.Args,
+			Env:   append(fetchedImage.Metadata.Env, sidecar.Env...),
+			User:  user,
+			Image: garden.ImageRef{URI: fetchedImage.URL},
+		}, garden.ProcessIO{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (p *containerProvider) anyMountTo(path string, inputs []InputSource) bool {
 	for _, input := range inputs {
 		if input.DestinationPath() == path {
@@ -508,3 +641,82 @@ func (p *containerProvider) anyMountTo(path string, inputs []InputSource) bool {
 
 	return false
 }
+
+func (p *containerProvider) streamInput(
+	logger lager.Logger,
+	creatingContainer db.CreatingContainer,
+	teamID int,
+	privileged bool,
+	source ArtifactSource,
+	path string,
+) (Volume, error) {
+	volume, err := p.volumeClient.FindOrCreateVolumeForContainer(
+		logger,
+		VolumeSpec{
+			Strategy:   baggageclaim.EmptyStrategy{},
+			Privileged: privileged,
+		},
+		creatingContainer,
+		teamID,
+		path,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	streamed, err := p.streamInputP2P(logger, source, volume)
+	if err != nil {
+		return nil, err
+	}
+
+	if !streamed {
+		err = source.StreamTo(volume)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return volume, nil
+}
+
+// streamInputP2P streams the source directly from the worker it is on to
+// the volume, returning false if it could not and must be streamed through
+// the ATC instead.
+func (p *containerProvider) streamInputP2P(
+	logger lager.Logger,
+	source ArtifactSource,
+	volume Volume,
+) (bool, error) {
+	if p.p2pStreamer == nil {
+		return false, nil
+	}
+
+	volumeSource, ok := source.(VolumeArtifactSource)
+	if !ok {
+		return false, nil
+	}
+
+	sourceVolume, sourcePath, found := volumeSource.SourceVolume()
+	if !found {
+		return false, nil
+	}
+
+	err := p.p2pStreamer.StreamP2P(logger, sourceVolume, sourcePath, volume, ".")
+	if err == ErrP2PStreamingUnsupported {
+		logger.Info("falling-back-to-streaming-through-atc")
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// streamedInputPath is recorded as the path of the volume a shared input is
+// streamed into. The volume is never mounted, so that it stays untouched
+// for the containers cloning it.
+func streamedInputPath(destinationPath string) string {
+	return destinationPath + ".streamed"
+}
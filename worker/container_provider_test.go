@@ -46,6 +46,7 @@ var _ = Describe("ContainerProvider", func() {
 		fakeDBResourceCacheFactory  *dbfakes.FakeResourceCacheFactory
 		fakeDBResourceConfigFactory *dbfakes.FakeResourceConfigFactory
 		fakeLockFactory             *lockfakes.FakeLockFactory
+		fakeP2PStreamer             *workerfakes.FakeP2PStreamer
 
 		containerProvider ContainerProvider
 
@@ -108,6 +109,8 @@ var _ = Describe("ContainerProvider", func() {
 		fakeGardenContainer = new(gardenfakes.FakeContainer)
 		fakeGardenClient.CreateReturns(fakeGardenContainer, nil)
 
+		fakeP2PStreamer = new(workerfakes.FakeP2PStreamer)
+
 		fakeDBWorker := new(dbfakes.FakeWorker)
 		fakeDBWorker.HTTPProxyURLReturns("http://proxy.com")
 		fakeDBWorker.HTTPSProxyURLReturns("https://proxy.com")
@@ -123,6 +126,7 @@ var _ = Describe("ContainerProvider", func() {
 			fakeDBVolumeFactory,
 			fakeDBTeamFactory,
 			fakeLockFactory,
+			fakeP2PStreamer,
 		)
 
 		fakeLocalInput = new(workerfakes.FakeInputSource)
@@ -481,12 +485,126 @@ var _ = Describe("ContainerProvider", func() {
 			dst, from := fakeRemoteInputContainerVolume.StreamInArgsForCall(0)
 			Expect(dst).To(Equal("."))
 			Expect(ioutil.ReadAll(from)).To(Equal([]byte("some-stream")))
+
+			Expect(fakeP2PStreamer.StreamP2PCallCount()).To(BeZero())
+		})
+
+		Context("when a remote input is a volume on another worker", func() {
+			var (
+				fakeRemoteInputVAS    *workerfakes.FakeVolumeArtifactSource
+				fakeRemoteInputVolume *workerfakes.FakeVolume
+			)
+
+			BeforeEach(func() {
+				fakeRemoteInputVolume = new(workerfakes.FakeVolume)
+
+				fakeRemoteInputVAS = new(workerfakes.FakeVolumeArtifactSource)
+				fakeRemoteInputVAS.VolumeOnReturns(nil, false, nil)
+				fakeRemoteInputVAS.SourceVolumeReturns(fakeRemoteInputVolume, ".", true)
+				fakeRemoteInput.SourceReturns(fakeRemoteInputVAS)
+			})
+
+			It("streams it directly from the other worker", func() {
+				Expect(fakeP2PStreamer.StreamP2PCallCount()).To(Equal(1))
+				_, source, sourcePath, destination, destinationPath := fakeP2PStreamer.StreamP2PArgsForCall(0)
+				Expect(source).To(Equal(fakeRemoteInputVolume))
+				Expect(sourcePath).To(Equal("."))
+				Expect(destination).To(Equal(fakeRemoteInputContainerVolume))
+				Expect(destinationPath).To(Equal("."))
+
+				Expect(fakeRemoteInputVAS.StreamToCallCount()).To(BeZero())
+			})
+
+			Context("when the other worker does not support it", func() {
+				BeforeEach(func() {
+					fakeP2PStreamer.StreamP2PReturns(ErrP2PStreamingUnsupported)
+				})
+
+				It("streams it through the ATC", func() {
+					Expect(fakeRemoteInputVAS.StreamToCallCount()).To(Equal(1))
+				})
+			})
 		})
 
 		It("marks container as created", func() {
 			Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(1))
 		})
 
+		Context("when the spec has sidecars", func() {
+			var fakeSidecarImage *workerfakes.FakeImage
+
+			BeforeEach(func() {
+				containerSpec.Sidecars = []SidecarSpec{
+					{
+						Name:      "db",
+						ImageSpec: ImageSpec{ImageURL: "docker:///postgres"},
+						Env:       []string{"SIDECAR=ENV"},
+						Path:      "postgres",
+						Args:      []string{"-p", "5432"},
+					},
+				}
+
+				fakeSidecarImage = new(workerfakes.FakeImage)
+				fakeSidecarImage.FetchForContainerReturns(FetchedImage{
+					Metadata: ImageMetadata{
+						Env:  []string{"SIDECAR_IMAGE=ENV"},
+						User: "postgres",
+					},
+					URL: "some-sidecar-image-url",
+				}, nil)
+
+				fakeImageFactory.GetImageStub = func(_ lager.Logger, _ Worker, _ VolumeClient, imageSpec ImageSpec, _ int, _ ImageFetchingDelegate, _ creds.VersionedResourceTypes) (Image, error) {
+					if imageSpec.ImageURL == "docker:///postgres" {
+						return fakeSidecarImage, nil
+					}
+
+					return fakeImage, nil
+				}
+			})
+
+			It("fetches the sidecar image for the container", func() {
+				Expect(fakeImageFactory.GetImageCallCount()).To(Equal(2))
+				_, _, _, actualImageSpec, _, _, _ := fakeImageFactory.GetImageArgsForCall(1)
+				Expect(actualImageSpec).To(Equal(ImageSpec{ImageURL: "docker:///postgres"}))
+
+				Expect(fakeSidecarImage.FetchForContainerCallCount()).To(Equal(1))
+				_, _, actualContainer := fakeSidecarImage.FetchForContainerArgsForCall(0)
+				Expect(actualContainer).To(Equal(fakeCreatingContainer))
+			})
+
+			It("starts the sidecar in its own image", func() {
+				Expect(fakeGardenContainer.RunCallCount()).To(Equal(1))
+
+				spec, _ := fakeGardenContainer.RunArgsForCall(0)
+				Expect(spec).To(Equal(garden.ProcessSpec{
+					ID:    "sidecar-db",
+					Path:  "postgres",
+					Args:  []string{"-p", "5432"},
+					Env:   []string{"SIDECAR_IMAGE=ENV", "SIDECAR=ENV"},
+					User:  "postgres",
+					Image: garden.ImageRef{URI: "some-sidecar-image-url"},
+				}))
+			})
+
+			Context("when starting the sidecar fails", func() {
+				BeforeEach(func() {
+					fakeGardenContainer.RunReturns(nil, disasterErr)
+				})
+
+				It("returns an error", func() {
+					Expect(findOrCreateErr).To(Equal(disasterErr))
+				})
+
+				It("marks the container as failed", func() {
+					Expect(fakeCreatingContainer.FailedCallCount()).To(Equal(1))
+				})
+
+				It("does not mark container as created", func() {
+					Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when the fetched image was privileged", func() {
 			BeforeEach(func() {
 				fakeImage.FetchForContainerReturns(FetchedImage{
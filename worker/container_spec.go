@@ -38,6 +38,30 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Helper processes started in their own images once the container is
+	// created. They share the container's network namespace and are destroyed
+	// along with it.
+	Sidecars []SidecarSpec
+}
+
+// SidecarSpec describes a process run alongside a container's main process.
+type SidecarSpec struct {
+	Name      string
+	ImageSpec ImageSpec
+	Env       []string
+
+	Path string
+	Args []string
+
+	// Optional user to run the process as. Overwrites the one specified in the image.
+	User string
+}
+
+// SidecarProcessID is the ID of the garden process running the named sidecar,
+// for attaching to it once the container has been created.
+func SidecarProcessID(name string) string {
+	return "sidecar-" + name
 }
 
 // OutputPaths is a mapping from output name to its path in the container.
@@ -32,6 +32,10 @@ type dbWorkerProvider struct {
 	dbWorkerFactory                   db.WorkerFactory
 	workerVersion                     *version.Version
 	baggageclaimResponseHeaderTimeout time.Duration
+	streamConfig                      transport.StreamConfig
+	acceptedEncodings                 *transport.AcceptedEncodings
+	p2pStreamer                       P2PStreamer
+	globalResourceCaches              bool
 }
 
 func NewDBWorkerProvider(
@@ -47,6 +51,9 @@ func NewDBWorkerProvider(
 	workerFactory db.WorkerFactory,
 	workerVersion *version.Version,
 	baggageclaimResponseHeaderTimeout time.Duration,
+	streamConfig transport.StreamConfig,
+	p2pStreamer P2PStreamer,
+	globalResourceCaches bool,
 ) WorkerProvider {
 	return &dbWorkerProvider{
 		lockFactory:                       lockFactory,
@@ -61,6 +68,10 @@ func NewDBWorkerProvider(
 		dbWorkerFactory:                   workerFactory,
 		workerVersion:                     workerVersion,
 		baggageclaimResponseHeaderTimeout: baggageclaimResponseHeaderTimeout,
+		streamConfig:                      streamConfig,
+		acceptedEncodings:                 transport.NewAcceptedEncodings(),
+		p2pStreamer:                       p2pStreamer,
+		globalResourceCaches:              globalResourceCaches,
 	}
 }
 
@@ -79,6 +90,12 @@ func (provider *dbWorkerProvider) RunningWorkers(logger lager.Logger) ([]Worker,
 			continue
 		}
 
+		// ephemeral workers are removed soon after their TTL lapses, so
+		// nothing more should be placed on them
+		if savedWorker.Ephemeral() && savedWorker.Expired() {
+			continue
+		}
+
 		workerLog := logger.Session("running-worker")
 		worker := provider.NewGardenWorker(workerLog, tikTok, savedWorker)
 		if !worker.IsVersionCompatible(workerLog, provider.workerVersion) {
@@ -154,10 +171,16 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		savedWorker.Name(),
 		savedWorker.BaggageclaimURL(),
 		provider.dbWorkerFactory,
-		&http.Transport{
-			DisableKeepAlives:     true,
-			ResponseHeaderTimeout: provider.baggageclaimResponseHeaderTimeout,
-		},
+		transport.NewStreamRoundTripper(
+			logger.Session("baggageclaim-stream"),
+			savedWorker.Name(),
+			provider.streamConfig,
+			provider.acceptedEncodings,
+			&http.Transport{
+				DisableKeepAlives:     true,
+				ResponseHeaderTimeout: provider.baggageclaimResponseHeaderTimeout,
+			},
+		),
 	))
 
 	volumeClient := NewVolumeClient(
@@ -168,6 +191,7 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		provider.dbVolumeFactory,
 		provider.dbWorkerBaseResourceTypeFactory,
 		provider.dbWorkerTaskCacheFactory,
+		provider.globalResourceCaches,
 	)
 
 	containerProvider := NewContainerProvider(
@@ -180,6 +204,7 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		provider.dbVolumeFactory,
 		provider.dbTeamFactory,
 		provider.lockFactory,
+		provider.p2pStreamer,
 	)
 
 	return NewGardenWorker(
@@ -17,6 +17,7 @@ import (
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/db/lock/lockfakes"
 	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/transport"
 	"github.com/concourse/atc/worker/workerfakes"
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/retryhttp/retryhttpfakes"
@@ -171,6 +172,9 @@ var _ = Describe("DBProvider", func() {
 			fakeDBWorkerFactory,
 			&wantWorkerVersion,
 			baggageclaimResponseHeaderTimeout,
+			transport.StreamConfig{},
+			nil,
+			false,
 		)
 		baggageclaimURL = baggageclaimServer.URL()
 	})
@@ -242,6 +246,31 @@ var _ = Describe("DBProvider", func() {
 				})
 			})
 
+			Context("when an ephemeral worker's TTL has lapsed", func() {
+				BeforeEach(func() {
+					fakeWorker2.EphemeralReturns(true)
+					fakeWorker2.ExpiredReturns(true)
+				})
+
+				It("does not return it", func() {
+					Expect(workersErr).NotTo(HaveOccurred())
+					Expect(workers).To(HaveLen(1))
+					Expect(workers[0].Name()).To(Equal(fakeWorker1.Name()))
+				})
+			})
+
+			Context("when a worker is in maintenance", func() {
+				BeforeEach(func() {
+					fakeWorker2.InMaintenanceReturns(true)
+				})
+
+				It("still returns it, as only new containers are kept off it", func() {
+					Expect(workersErr).NotTo(HaveOccurred())
+					Expect(workers).To(HaveLen(2))
+					Expect(workers[1].InMaintenance()).To(BeTrue())
+				})
+			})
+
 			Context("when a worker's major version is higher or lower than the atc worker version", func() {
 				BeforeEach(func() {
 					worker1 := new(dbfakes.FakeWorker)
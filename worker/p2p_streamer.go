@@ -0,0 +1,245 @@
+package worker
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// ErrP2PStreamingUnsupported is returned when the source worker's
+// baggageclaim cannot stream volumes directly to other workers, in which
+// case the volume should be streamed through the ATC instead.
+var ErrP2PStreamingUnsupported = errors.New("worker does not support streaming volumes directly to other workers")
+
+//go:generate counterfeiter . P2PStreamer
+
+// P2PStreamer streams a volume directly from the baggageclaim of its worker
+// to that of another worker, so that the data does not pass through the ATC.
+type P2PStreamer interface {
+	StreamP2P(
+		logger lager.Logger,
+		source Volume,
+		sourcePath string,
+		destination Volume,
+		destinationPath string,
+	) error
+}
+
+// P2PStreamError is returned when the source worker fails to stream the
+// volume to the destination worker.
+type P2PStreamError struct {
+	SourceWorker      string
+	DestinationWorker string
+	StatusCode        int
+	Message           string
+}
+
+func (err P2PStreamError) Error() string {
+	return fmt.Sprintf(
+		"failed to stream volume from worker '%s' to worker '%s' (status %d): %s",
+		err.SourceWorker,
+		err.DestinationWorker,
+		err.StatusCode,
+		err.Message,
+	)
+}
+
+// streamVolume copies the whole of the source volume into the destination
+// volume, directly between the workers if the streamer is given and the
+// source worker supports it, and through the ATC otherwise.
+func streamVolume(logger lager.Logger, streamer P2PStreamer, source Volume, destination Volume) error {
+	if streamer != nil {
+		err := streamer.StreamP2P(logger, source, ".", destination, ".")
+		if err != ErrP2PStreamingUnsupported {
+			return err
+		}
+
+		logger.Info("falling-back-to-streaming-through-atc")
+	}
+
+	out, err := source.StreamOut(".")
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	return destination.StreamIn(".", out)
+}
+
+type p2pStreamer struct {
+	dbWorkerFactory db.WorkerFactory
+	httpClient      *http.Client
+	signingKey      *rsa.PrivateKey
+	tokenTTL        time.Duration
+	retries         int
+	retryInterval   time.Duration
+	clock           clock.Clock
+}
+
+// NewP2PStreamer constructs a P2PStreamer which brokers each stream: it
+// asks the source worker's baggageclaim to stream the volume to the stream-in
+// URL of the destination worker's baggageclaim, authorized by a short-lived
+// token signed with the key. The token only permits streaming into the one
+// destination volume, and is verified by the destination worker.
+//
+// The ATC does not see how far a stream between the workers got, so a stream
+// which breaks is restarted from the beginning into the same volume, up to
+// the number of retries, waiting the retry interval before each attempt.
+func NewP2PStreamer(
+	dbWorkerFactory db.WorkerFactory,
+	httpClient *http.Client,
+	signingKey *rsa.PrivateKey,
+	tokenTTL time.Duration,
+	retries int,
+	retryInterval time.Duration,
+	clock clock.Clock,
+) P2PStreamer {
+	return &p2pStreamer{
+		dbWorkerFactory: dbWorkerFactory,
+		httpClient:      httpClient,
+		signingKey:      signingKey,
+		tokenTTL:        tokenTTL,
+		retries:         retries,
+		retryInterval:   retryInterval,
+		clock:           clock,
+	}
+}
+
+func (streamer *p2pStreamer) StreamP2P(
+	logger lager.Logger,
+	source Volume,
+	sourcePath string,
+	destination Volume,
+	destinationPath string,
+) error {
+	logger = logger.Session("stream-p2p", lager.Data{
+		"source-worker":      source.WorkerName(),
+		"source-volume":      source.Handle(),
+		"destination-worker": destination.WorkerName(),
+		"destination-volume": destination.Handle(),
+	})
+
+	sourceURL, err := streamer.baggageclaimURL(source.WorkerName())
+	if err != nil {
+		logger.Error("failed-to-find-source-worker", err)
+		return err
+	}
+
+	destinationURL, err := streamer.baggageclaimURL(destination.WorkerName())
+	if err != nil {
+		logger.Error("failed-to-find-destination-worker", err)
+		return err
+	}
+
+	streamInURL := *destinationURL
+	streamInURL.Path = "/volumes/" + destination.Handle() + "/stream-in"
+	streamInURL.RawQuery = url.Values{"path": {destinationPath}}.Encode()
+
+	token, err := streamer.streamInToken(destination)
+	if err != nil {
+		logger.Error("failed-to-sign-token", err)
+		return err
+	}
+
+	streamOutURL := *sourceURL
+	streamOutURL.Path = "/volumes/" + source.Handle() + "/stream-p2p-out"
+	streamOutURL.RawQuery = url.Values{
+		"path":          {sourcePath},
+		"streamInURL":   {streamInURL.String()},
+		"streamInToken": {token},
+	}.Encode()
+
+	for attempt := 0; ; attempt++ {
+		err = streamer.stream(streamOutURL.String(), source, destination)
+		if err == nil || err == ErrP2PStreamingUnsupported {
+			return err
+		}
+
+		logger.Error("failed-to-stream", err, lager.Data{"attempt": attempt + 1})
+
+		if attempt >= streamer.retries || !brokenP2PStream(err) {
+			return err
+		}
+
+		streamer.clock.Sleep(streamer.retryInterval)
+	}
+}
+
+func (streamer *p2pStreamer) stream(streamOutURL string, source Volume, destination Volume) error {
+	request, err := http.NewRequest("PUT", streamOutURL, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := streamer.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return ErrP2PStreamingUnsupported
+
+	default:
+		message, _ := ioutil.ReadAll(response.Body)
+
+		return P2PStreamError{
+			SourceWorker:      source.WorkerName(),
+			DestinationWorker: destination.WorkerName(),
+			StatusCode:        response.StatusCode,
+			Message:           string(message),
+		}
+	}
+}
+
+// brokenP2PStream returns whether the stream may have broken part-way, as
+// opposed to having been refused, in which case it is worth restarting.
+func brokenP2PStream(err error) bool {
+	streamErr, ok := err.(P2PStreamError)
+	if !ok {
+		return true
+	}
+
+	return streamErr.StatusCode >= http.StatusInternalServerError
+}
+
+func (streamer *p2pStreamer) baggageclaimURL(workerName string) (*url.URL, error) {
+	savedWorker, found, err := streamer.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found || savedWorker.BaggageclaimURL() == nil {
+		return nil, ErrDesiredWorkerNotRunning
+	}
+
+	return url.Parse(*savedWorker.BaggageclaimURL())
+}
+
+func (streamer *p2pStreamer) streamInToken(destination Volume) (string, error) {
+	now := streamer.clock.Now()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"worker": destination.WorkerName(),
+		"volume": destination.Handle(),
+		"iat":    now.Unix(),
+		"exp":    now.Add(streamer.tokenTTL).Unix(),
+	})
+
+	return token.SignedString(streamer.signingKey)
+}
@@ -0,0 +1,203 @@
+package worker_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	jwt "github.com/dgrijalva/jwt-go"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("P2PStreamer", func() {
+	var (
+		sourceServer *ghttp.Server
+
+		fakeDBWorkerFactory *dbfakes.FakeWorkerFactory
+		fakeClock           *fakeclock.FakeClock
+		signingKey          *rsa.PrivateKey
+
+		fakeSourceVolume      *workerfakes.FakeVolume
+		fakeDestinationVolume *workerfakes.FakeVolume
+
+		streamer  P2PStreamer
+		streamErr error
+	)
+
+	BeforeEach(func() {
+		sourceServer = ghttp.NewServer()
+
+		var err error
+		signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+
+		sourceURL := sourceServer.URL()
+		destinationURL := "http://1.2.3.4:7788"
+
+		fakeSourceWorker := new(dbfakes.FakeWorker)
+		fakeSourceWorker.BaggageclaimURLReturns(&sourceURL)
+
+		fakeDestinationWorker := new(dbfakes.FakeWorker)
+		fakeDestinationWorker.BaggageclaimURLReturns(&destinationURL)
+
+		fakeDBWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeDBWorkerFactory.GetWorkerStub = func(name string) (db.Worker, bool, error) {
+			switch name {
+			case "source-worker":
+				return fakeSourceWorker, true, nil
+			case "destination-worker":
+				return fakeDestinationWorker, true, nil
+			default:
+				return nil, false, nil
+			}
+		}
+
+		fakeSourceVolume = new(workerfakes.FakeVolume)
+		fakeSourceVolume.HandleReturns("source-handle")
+		fakeSourceVolume.WorkerNameReturns("source-worker")
+
+		fakeDestinationVolume = new(workerfakes.FakeVolume)
+		fakeDestinationVolume.HandleReturns("destination-handle")
+		fakeDestinationVolume.WorkerNameReturns("destination-worker")
+
+		streamer = NewP2PStreamer(
+			fakeDBWorkerFactory,
+			http.DefaultClient,
+			signingKey,
+			time.Hour,
+			1,
+			0,
+			fakeClock,
+		)
+	})
+
+	AfterEach(func() {
+		sourceServer.Close()
+	})
+
+	JustBeforeEach(func() {
+		streamErr = streamer.StreamP2P(
+			lagertest.NewTestLogger("test"),
+			fakeSourceVolume,
+			"some/path",
+			fakeDestinationVolume,
+			".",
+		)
+	})
+
+	Context("when the source worker streams the volume", func() {
+		var query url.Values
+
+		BeforeEach(func() {
+			sourceServer.AppendHandlers(
+				ghttp.CombineHandler(
+					ghttp.VerifyRequest("PUT", "/volumes/source-handle/stream-p2p-out"),
+					func(w http.ResponseWriter, r *http.Request) {
+						query = r.URL.Query()
+					},
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("succeeds", func() {
+			Expect(streamErr).NotTo(HaveOccurred())
+		})
+
+		It("asks it to stream the path to the destination volume", func() {
+			Expect(query.Get("path")).To(Equal("some/path"))
+			Expect(query.Get("streamInURL")).To(Equal("http://1.2.3.4:7788/volumes/destination-handle/stream-in?path=."))
+		})
+
+		It("authorizes the stream with a token for the destination volume", func() {
+			token, err := jwt.Parse(query.Get("streamInToken"), func(*jwt.Token) (interface{}, error) {
+				return &signingKey.PublicKey, nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			claims := token.Claims.(jwt.MapClaims)
+			Expect(claims["worker"]).To(Equal("destination-worker"))
+			Expect(claims["volume"]).To(Equal("destination-handle"))
+			Expect(claims["exp"]).To(BeNumerically("==", time.Unix(123, 0).Add(time.Hour).Unix()))
+		})
+	})
+
+	Context("when the source worker does not support streaming directly", func() {
+		BeforeEach(func() {
+			sourceServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, nil),
+			)
+		})
+
+		It("returns ErrP2PStreamingUnsupported", func() {
+			Expect(streamErr).To(Equal(ErrP2PStreamingUnsupported))
+		})
+	})
+
+	Context("when the stream breaks", func() {
+		BeforeEach(func() {
+			sourceServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusInternalServerError, "connection reset"),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			)
+		})
+
+		It("restarts it", func() {
+			Expect(streamErr).NotTo(HaveOccurred())
+			Expect(sourceServer.ReceivedRequests()).To(HaveLen(2))
+		})
+	})
+
+	Context("when the stream is refused", func() {
+		BeforeEach(func() {
+			sourceServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusForbidden, "invalid token"),
+			)
+		})
+
+		It("returns the error without restarting it", func() {
+			Expect(streamErr).To(HaveOccurred())
+			Expect(sourceServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("when the source worker fails to stream the volume", func() {
+		BeforeEach(func() {
+			sourceServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusInternalServerError, "destination unreachable"),
+				ghttp.RespondWith(http.StatusInternalServerError, "destination unreachable"),
+			)
+		})
+
+		It("returns the error once out of retries", func() {
+			Expect(streamErr).To(Equal(P2PStreamError{
+				SourceWorker:      "source-worker",
+				DestinationWorker: "destination-worker",
+				StatusCode:        http.StatusInternalServerError,
+				Message:           "destination unreachable",
+			}))
+		})
+	})
+
+	Context("when the destination worker is not running", func() {
+		BeforeEach(func() {
+			fakeDestinationVolume.WorkerNameReturns("missing-worker")
+		})
+
+		It("returns ErrDesiredWorkerNotRunning", func() {
+			Expect(streamErr).To(Equal(ErrDesiredWorkerNotRunning))
+		})
+	})
+})
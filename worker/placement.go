@@ -1,12 +1,39 @@
 package worker
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
 	"time"
+
+	"github.com/concourse/atc/db"
 )
 
+// ErrActiveTasksLimitReached is returned when placing a task while every
+// worker is already running the maximum number of active tasks.
+var ErrActiveTasksLimitReached = errors.New("all workers are running the maximum number of active tasks")
+
+// ContainerPlacementStrategy chooses which of the workers satisfying a
+// container's spec it is created on.
 type ContainerPlacementStrategy interface {
-	Choose([]Worker, ContainerSpec) (Worker, error)
+	Choose([]Worker, ContainerSpec, db.ContainerMetadata) (Worker, error)
+}
+
+// NewContainerPlacementStrategy constructs the strategy with the given name,
+// as selected by the --container-placement-strategy flag.
+func NewContainerPlacementStrategy(name string) (ContainerPlacementStrategy, error) {
+	switch name {
+	case "volume-locality":
+		return NewVolumeLocalityPlacementStrategy(), nil
+	case "random":
+		return NewRandomPlacementStrategy(), nil
+	case "fewest-build-containers":
+		return NewFewestBuildContainersPlacementStrategy(), nil
+	case "limit-active-tasks":
+		return NewLimitActiveTasksPlacementStrategy(), nil
+	default:
+		return nil, fmt.Errorf("unknown container placement strategy: %s", name)
+	}
 }
 
 type VolumeLocalityPlacementStrategy struct {
@@ -19,7 +46,7 @@ func NewVolumeLocalityPlacementStrategy() ContainerPlacementStrategy {
 	}
 }
 
-func (strategy *VolumeLocalityPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+func (strategy *VolumeLocalityPlacementStrategy) Choose(workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) (Worker, error) {
 	workersByCount := map[int][]Worker{}
 	var highestCount int
 	for _, w := range workers {
@@ -58,6 +85,62 @@ func NewRandomPlacementStrategy() ContainerPlacementStrategy {
 	}
 }
 
-func (strategy *RandomPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+func (strategy *RandomPlacementStrategy) Choose(workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) (Worker, error) {
 	return workers[strategy.rand.Intn(len(workers))], nil
 }
+
+// FewestBuildContainersPlacementStrategy places containers on the worker
+// running the fewest containers for builds, choosing randomly between those
+// tied.
+type FewestBuildContainersPlacementStrategy struct {
+	rand *rand.Rand
+}
+
+func NewFewestBuildContainersPlacementStrategy() ContainerPlacementStrategy {
+	return &FewestBuildContainersPlacementStrategy{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *FewestBuildContainersPlacementStrategy) Choose(workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) (Worker, error) {
+	fewestWorkers := fewest(workers, Worker.BuildContainers)
+
+	return fewestWorkers[strategy.rand.Intn(len(fewestWorkers))], nil
+}
+
+// LimitActiveTasksPlacementStrategy places tasks on the worker running the
+// fewest active tasks, spreading them out before any worker reaches the
+// limit the pool enforces. Other containers are placed randomly.
+type LimitActiveTasksPlacementStrategy struct {
+	rand *rand.Rand
+}
+
+func NewLimitActiveTasksPlacementStrategy() ContainerPlacementStrategy {
+	return &LimitActiveTasksPlacementStrategy{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *LimitActiveTasksPlacementStrategy) Choose(workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) (Worker, error) {
+	if metadata.Type != db.ContainerTypeTask {
+		return workers[strategy.rand.Intn(len(workers))], nil
+	}
+
+	fewestWorkers := fewest(workers, Worker.ActiveTasks)
+
+	return fewestWorkers[strategy.rand.Intn(len(fewestWorkers))], nil
+}
+
+// fewest returns the workers with the lowest count.
+func fewest(workers []Worker, count func(Worker) int) []Worker {
+	var fewestWorkers []Worker
+	for _, w := range workers {
+		if len(fewestWorkers) == 0 || count(w) < count(fewestWorkers[0]) {
+			fewestWorkers = []Worker{w}
+		} else if count(w) == count(fewestWorkers[0]) {
+			fewestWorkers = append(fewestWorkers, w)
+		}
+	}
+
+	return fewestWorkers
+}
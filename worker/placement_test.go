@@ -1,6 +1,7 @@
 package worker_test
 
 import (
+	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/workerfakes"
 
@@ -13,8 +14,9 @@ import (
 var (
 	strategy ContainerPlacementStrategy
 
-	spec    ContainerSpec
-	workers []Worker
+	spec     ContainerSpec
+	metadata db.ContainerMetadata
+	workers  []Worker
 
 	chosenWorker Worker
 	chooseErr    error
@@ -32,6 +34,7 @@ var _ = Describe("VolumeLocalityPlacementStrategy", func() {
 			chosenWorker, chooseErr = strategy.Choose(
 				workers,
 				spec,
+				metadata,
 			)
 		})
 
@@ -125,6 +128,7 @@ var _ = Describe("VolumeLocalityPlacementStrategy", func() {
 					worker, err := strategy.Choose(
 						workers,
 						spec,
+						metadata,
 					)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(chosenWorker).To(SatisfyAny(Equal(compatibleWorkerOneCache1), Equal(compatibleWorkerOneCache2)))
@@ -156,6 +160,7 @@ var _ = Describe("VolumeLocalityPlacementStrategy", func() {
 					worker, err := strategy.Choose(
 						workers,
 						spec,
+						metadata,
 					)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(chosenWorker).To(SatisfyAny(Equal(compatibleWorkerNoCaches1), Equal(compatibleWorkerNoCaches2)))
@@ -175,6 +180,7 @@ var _ = Describe("RandomPlacementStrategy", func() {
 			chosenWorker, chooseErr = strategy.Choose(
 				workers,
 				spec,
+				metadata,
 			)
 		})
 
@@ -197,6 +203,7 @@ var _ = Describe("RandomPlacementStrategy", func() {
 				worker, err := strategy.Choose(
 					workers,
 					spec,
+					metadata,
 				)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(chosenWorker).To(SatisfyAny(Equal(compatibleWorkerNoCaches1), Equal(compatibleWorkerNoCaches2)))
@@ -208,3 +215,105 @@ var _ = Describe("RandomPlacementStrategy", func() {
 		})
 	})
 })
+
+var _ = Describe("FewestBuildContainersPlacementStrategy", func() {
+	Describe("Choose", func() {
+		var (
+			busyWorker  *workerfakes.FakeWorker
+			idleWorker1 *workerfakes.FakeWorker
+			idleWorker2 *workerfakes.FakeWorker
+		)
+
+		BeforeEach(func() {
+			strategy = NewFewestBuildContainersPlacementStrategy()
+
+			busyWorker = new(workerfakes.FakeWorker)
+			busyWorker.BuildContainersReturns(20)
+
+			idleWorker1 = new(workerfakes.FakeWorker)
+			idleWorker1.BuildContainersReturns(2)
+
+			idleWorker2 = new(workerfakes.FakeWorker)
+			idleWorker2.BuildContainersReturns(2)
+
+			workers = []Worker{busyWorker, idleWorker1, idleWorker2}
+		})
+
+		It("creates it on a random one of those with the fewest build containers", func() {
+			workerChoiceCounts := map[Worker]int{}
+
+			for i := 0; i < 100; i++ {
+				worker, err := strategy.Choose(workers, spec, metadata)
+				Expect(err).ToNot(HaveOccurred())
+				workerChoiceCounts[worker]++
+			}
+
+			Expect(workerChoiceCounts[idleWorker1]).ToNot(BeZero())
+			Expect(workerChoiceCounts[idleWorker2]).ToNot(BeZero())
+			Expect(workerChoiceCounts[busyWorker]).To(BeZero())
+		})
+	})
+})
+
+var _ = Describe("LimitActiveTasksPlacementStrategy", func() {
+	Describe("Choose", func() {
+		var (
+			busyWorker *workerfakes.FakeWorker
+			idleWorker *workerfakes.FakeWorker
+		)
+
+		BeforeEach(func() {
+			strategy = NewLimitActiveTasksPlacementStrategy()
+
+			busyWorker = new(workerfakes.FakeWorker)
+			busyWorker.ActiveTasksReturns(2)
+
+			idleWorker = new(workerfakes.FakeWorker)
+			idleWorker.ActiveTasksReturns(1)
+
+			workers = []Worker{busyWorker, idleWorker}
+		})
+
+		JustBeforeEach(func() {
+			chosenWorker, chooseErr = strategy.Choose(workers, spec, metadata)
+		})
+
+		Context("when placing a task", func() {
+			BeforeEach(func() {
+				metadata = db.ContainerMetadata{Type: db.ContainerTypeTask}
+			})
+
+			It("creates it on the worker with the fewest active tasks", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(idleWorker))
+			})
+		})
+
+		Context("when placing any other container", func() {
+			BeforeEach(func() {
+				metadata = db.ContainerMetadata{Type: db.ContainerTypeGet}
+
+				idleWorker.ActiveTasksReturns(2)
+			})
+
+			It("creates it on any of the workers", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(SatisfyAny(Equal(busyWorker), Equal(idleWorker)))
+			})
+		})
+	})
+})
+
+var _ = Describe("NewContainerPlacementStrategy", func() {
+	It("constructs the strategy with the given name", func() {
+		Expect(NewContainerPlacementStrategy("volume-locality")).To(BeAssignableToTypeOf(&VolumeLocalityPlacementStrategy{}))
+		Expect(NewContainerPlacementStrategy("random")).To(BeAssignableToTypeOf(&RandomPlacementStrategy{}))
+		Expect(NewContainerPlacementStrategy("fewest-build-containers")).To(BeAssignableToTypeOf(&FewestBuildContainersPlacementStrategy{}))
+		Expect(NewContainerPlacementStrategy("limit-active-tasks")).To(BeAssignableToTypeOf(&LimitActiveTasksPlacementStrategy{}))
+	})
+
+	It("errors for an unknown strategy", func() {
+		_, err := NewContainerPlacementStrategy("bogus")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -4,9 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
 	"path/filepath"
-	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/garden"
@@ -64,18 +62,39 @@ func (err NoCompatibleWorkersError) Error() string {
 	)
 }
 
+// NoWorkersMatchingPlatformError is returned when none of the workers run on
+// the platform a container requires with the tags it requires, e.g. when a
+// windows task is run without any windows workers registered.
+type NoWorkersMatchingPlatformError struct {
+	Platform string
+	Tags     []string
+}
+
+func (err NoWorkersMatchingPlatformError) Error() string {
+	if len(err.Tags) == 0 {
+		return fmt.Sprintf("no workers matching platform %s", err.Platform)
+	}
+
+	return fmt.Sprintf("no workers matching platform %s, tags %v", err.Platform, err.Tags)
+}
+
 type pool struct {
 	provider WorkerProvider
-
-	rand     *rand.Rand
 	strategy ContainerPlacementStrategy
+
+	maxActiveTasksPerWorker int
 }
 
-func NewPool(provider WorkerProvider, strategy ContainerPlacementStrategy) Client {
+// NewPool constructs a Client placing containers on the provider's workers
+// with the strategy. Task containers are only placed on workers running fewer
+// than maxActiveTasksPerWorker tasks, whichever the strategy; zero means no
+// limit.
+func NewPool(provider WorkerProvider, strategy ContainerPlacementStrategy, maxActiveTasksPerWorker int) Client {
 	return &pool{
 		provider: provider,
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
 		strategy: strategy,
+
+		maxActiveTasksPerWorker: maxActiveTasksPerWorker,
 	}
 }
 
@@ -95,9 +114,16 @@ func (pool *pool) AllSatisfying(logger lager.Logger, spec WorkerSpec, resourceTy
 
 	compatibleTeamWorkers := []Worker{}
 	compatibleGeneralWorkers := []Worker{}
+	anyMatchingPlatform := false
 	for _, worker := range workers {
 		satisfyingWorker, err := worker.Satisfying(logger, spec, resourceTypes)
-		if err == nil {
+		if err != ErrIncompatiblePlatform && err != ErrMismatchedTags {
+			anyMatchingPlatform = true
+		}
+
+		// workers in maintenance are drained of new containers; their
+		// existing ones are still found through FindWorkerForContainer
+		if err == nil && !worker.InMaintenance() {
 			if worker.IsOwnedByTeam() {
 				compatibleTeamWorkers = append(compatibleTeamWorkers, satisfyingWorker)
 			} else {
@@ -114,6 +140,13 @@ func (pool *pool) AllSatisfying(logger lager.Logger, spec WorkerSpec, resourceTy
 		return compatibleGeneralWorkers, nil
 	}
 
+	if spec.Platform != "" && !anyMatchingPlatform {
+		return nil, NoWorkersMatchingPlatformError{
+			Platform: spec.Platform,
+			Tags:     spec.Tags,
+		}
+	}
+
 	return nil, NoCompatibleWorkersError{
 		Spec:    spec,
 		Workers: workers,
@@ -125,8 +158,14 @@ func (pool *pool) Satisfying(logger lager.Logger, spec WorkerSpec, resourceTypes
 	if err != nil {
 		return nil, err
 	}
-	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
-	return randomWorker, nil
+
+	// the worker is chosen for a container to be created on it, so it is
+	// placed the same way as any other
+	return pool.strategy.Choose(withoutPressure(compatibleWorkers), ContainerSpec{
+		Platform: spec.Platform,
+		Tags:     spec.Tags,
+		TeamID:   spec.TeamID,
+	}, db.ContainerMetadata{})
 }
 
 func (pool *pool) FindOrCreateContainer(
@@ -153,7 +192,13 @@ func (pool *pool) FindOrCreateContainer(
 			return nil, err
 		}
 
-		worker, err = pool.strategy.Choose(compatibleWorkers, spec)
+		compatibleWorkers = withoutPressure(compatibleWorkers)
+
+		if metadata.Type == db.ContainerTypeTask && pool.maxActiveTasksPerWorker > 0 {
+			worker, err = pool.chooseTaskWorker(logger, compatibleWorkers, owner, spec, metadata)
+		} else {
+			worker, err = pool.strategy.Choose(compatibleWorkers, spec, metadata)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -170,6 +215,71 @@ func (pool *pool) FindOrCreateContainer(
 	)
 }
 
+// chooseTaskWorker places a task on one of the workers with a free task slot,
+// reserving the slot for it. The workers' counts of active tasks may be out of
+// date by the time the slot is reserved, so each worker chosen whose slots
+// have all been taken in the meantime is passed over for the next. If none of
+// them has a free slot, ErrActiveTasksLimitReached is returned.
+func (pool *pool) chooseTaskWorker(
+	logger lager.Logger,
+	workers []Worker,
+	owner db.ContainerOwner,
+	spec ContainerSpec,
+	metadata db.ContainerMetadata,
+) (Worker, error) {
+	candidates := []Worker{}
+	for _, w := range workers {
+		if w.ActiveTasks() < pool.maxActiveTasksPerWorker {
+			candidates = append(candidates, w)
+		}
+	}
+
+	for len(candidates) > 0 {
+		chosen, err := pool.strategy.Choose(candidates, spec, metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		reserved, err := chosen.ReserveTaskSlot(logger, owner, pool.maxActiveTasksPerWorker)
+		if err != nil {
+			return nil, err
+		}
+
+		if reserved {
+			return chosen, nil
+		}
+
+		remaining := []Worker{}
+		for _, w := range candidates {
+			if w != chosen {
+				remaining = append(remaining, w)
+			}
+		}
+
+		candidates = remaining
+	}
+
+	return nil, ErrActiveTasksLimitReached
+}
+
+// withoutPressure returns the workers which are not under memory or disk
+// pressure. If every worker is under pressure they are all returned, as
+// placing the container on one of them is better than not running it at all.
+func withoutPressure(workers []Worker) []Worker {
+	available := []Worker{}
+	for _, w := range workers {
+		if !w.UnderPressure() {
+			available = append(available, w)
+		}
+	}
+
+	if len(available) == 0 {
+		return workers
+	}
+
+	return available
+}
+
 func (pool *pool) FindContainerByHandle(logger lager.Logger, teamID int, handle string) (Container, bool, error) {
 	worker, found, err := pool.provider.FindWorkerForContainer(
 		logger.Session("find-worker"),
@@ -187,6 +297,41 @@ func (pool *pool) FindContainerByHandle(logger lager.Logger, teamID int, handle
 	return worker.FindContainerByHandle(logger, teamID, handle)
 }
 
+func (pool *pool) FindContainerByOwner(logger lager.Logger, teamID int, owner db.ContainerOwner) (Container, bool, error) {
+	worker, found, err := pool.provider.FindWorkerForContainerByOwner(
+		logger.Session("find-worker"),
+		teamID,
+		owner,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	return worker.FindContainerByOwner(logger, teamID, owner)
+}
+
+func (pool *pool) ReleaseTaskSlot(logger lager.Logger, teamID int, owner db.ContainerOwner) error {
+	worker, found, err := pool.provider.FindWorkerForContainerByOwner(
+		logger.Session("find-worker"),
+		teamID,
+		owner,
+	)
+	if err != nil {
+		return err
+	}
+
+	// the slots of a worker which is gone went with it
+	if !found {
+		return nil
+	}
+
+	return worker.ReleaseTaskSlot(logger, teamID, owner)
+}
+
 func (*pool) FindResourceTypeByPath(string) (atc.WorkerResourceType, bool) {
 	return atc.WorkerResourceType{}, false
 }
@@ -30,7 +30,7 @@ var _ = Describe("Pool", func() {
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 
-		pool = NewPool(fakeProvider, fakeStrategy)
+		pool = NewPool(fakeProvider, fakeStrategy, 0)
 	})
 
 	Describe("Satisfying", func() {
@@ -108,15 +108,37 @@ var _ = Describe("Pool", func() {
 				Expect(actualResourceTypes).To(Equal(resourceTypes))
 			})
 
-			It("returns a random worker satisfying the spec", func() {
-				chosenCount := map[Worker]int{workerA: 0, workerB: 0, workerC: 0}
-				for i := 0; i < 100; i++ {
-					satisfyingWorker, satisfyingErr = pool.Satisfying(logger, spec, resourceTypes)
-					Expect(satisfyingErr).NotTo(HaveOccurred())
-					chosenCount[satisfyingWorker]++
-				}
-				Expect(chosenCount[workerA]).To(BeNumerically("~", chosenCount[workerB], 50))
-				Expect(chosenCount[workerC]).To(BeZero())
+			It("chooses among the workers satisfying the spec with the placement strategy", func() {
+				Expect(fakeStrategy.ChooseCallCount()).To(Equal(1))
+				workers, containerSpec, metadata := fakeStrategy.ChooseArgsForCall(0)
+				Expect(workers).To(Equal([]Worker{workerA, workerB}))
+				Expect(containerSpec).To(Equal(ContainerSpec{
+					Platform: "some-platform",
+					Tags:     []string{"step", "tags"},
+				}))
+				Expect(metadata).To(Equal(db.ContainerMetadata{}))
+			})
+
+			Context("when the strategy chooses a worker", func() {
+				BeforeEach(func() {
+					fakeStrategy.ChooseReturns(workerB, nil)
+				})
+
+				It("returns it", func() {
+					Expect(satisfyingWorker).To(Equal(workerB))
+				})
+			})
+
+			Context("when the strategy fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeStrategy.ChooseReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(satisfyingErr).To(Equal(disaster))
+				})
 			})
 
 			Context("when no workers satisfy the spec", func() {
@@ -133,6 +155,22 @@ var _ = Describe("Pool", func() {
 					}))
 				})
 			})
+
+			Context("when no workers run on the platform with the tags", func() {
+				BeforeEach(func() {
+					workerA.SatisfyingReturns(nil, ErrIncompatiblePlatform)
+					workerB.SatisfyingReturns(nil, ErrMismatchedTags)
+					workerC.SatisfyingReturns(nil, ErrIncompatiblePlatform)
+				})
+
+				It("returns a NoWorkersMatchingPlatformError", func() {
+					Expect(satisfyingErr).To(Equal(NoWorkersMatchingPlatformError{
+						Platform: "some-platform",
+						Tags:     []string{"step", "tags"},
+					}))
+					Expect(satisfyingErr.Error()).To(Equal("no workers matching platform some-platform, tags [step tags]"))
+				})
+			})
 		})
 
 		Context("with no workers", func() {
@@ -239,6 +277,17 @@ var _ = Describe("Pool", func() {
 				Expect(satisfyingWorkers).To(ConsistOf(workerA, workerB))
 			})
 
+			Context("when a worker is in maintenance", func() {
+				BeforeEach(func() {
+					workerB.InMaintenanceReturns(true)
+				})
+
+				It("leaves it out, so that no containers are placed on it", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorkers).To(ConsistOf(workerA))
+				})
+			})
+
 			Context("when no workers satisfy the spec", func() {
 				BeforeEach(func() {
 					workerA.SatisfyingReturns(nil, errors.New("nope"))
@@ -385,6 +434,67 @@ var _ = Describe("Pool", func() {
 		})
 	})
 
+	Describe("FindContainerByOwner", func() {
+		var (
+			owner db.ContainerOwner
+
+			foundContainer Container
+			found          bool
+			findErr        error
+		)
+
+		BeforeEach(func() {
+			owner = db.NewBuildStepContainerOwner(1234, "some-plan-id")
+		})
+
+		JustBeforeEach(func() {
+			foundContainer, found, findErr = pool.FindContainerByOwner(
+				logger,
+				4567,
+				owner,
+			)
+		})
+
+		Context("when a worker is found with the container", func() {
+			var fakeWorker *workerfakes.FakeWorker
+			var fakeContainer *workerfakes.FakeContainer
+
+			BeforeEach(func() {
+				fakeWorker = new(workerfakes.FakeWorker)
+				fakeProvider.FindWorkerForContainerByOwnerReturns(fakeWorker, true, nil)
+
+				fakeContainer = new(workerfakes.FakeContainer)
+				fakeWorker.FindContainerByOwnerReturns(fakeContainer, true, nil)
+			})
+
+			It("returns the container found on that worker", func() {
+				Expect(findErr).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundContainer).To(Equal(fakeContainer))
+
+				_, actualTeamID, actualOwner := fakeProvider.FindWorkerForContainerByOwnerArgsForCall(0)
+				Expect(actualTeamID).To(Equal(4567))
+				Expect(actualOwner).To(Equal(owner))
+
+				_, actualTeamID, actualOwner = fakeWorker.FindContainerByOwnerArgsForCall(0)
+				Expect(actualTeamID).To(Equal(4567))
+				Expect(actualOwner).To(Equal(owner))
+			})
+		})
+
+		Context("when no worker is found with the container", func() {
+			BeforeEach(func() {
+				fakeProvider.FindWorkerForContainerByOwnerReturns(nil, false, nil)
+			})
+
+			It("returns no container, false, and no error", func() {
+				Expect(findErr).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+				Expect(foundContainer).To(BeNil())
+			})
+		})
+	})
+
 	Describe("FindOrCreateContainer", func() {
 		var (
 			ctx                       context.Context
@@ -408,6 +518,8 @@ var _ = Describe("Pool", func() {
 
 			fakeImageFetchingDelegate = new(workerfakes.FakeImageFetchingDelegate)
 
+			metadata = db.ContainerMetadata{}
+
 			fakeOwner = new(dbfakes.FakeContainerOwner)
 
 			fakeInput1 := new(workerfakes.FakeInputSource)
@@ -551,6 +663,10 @@ var _ = Describe("Pool", func() {
 					It("chooses a worker", func() {
 						Expect(createErr).ToNot(HaveOccurred())
 						Expect(fakeStrategy.ChooseCallCount()).To(Equal(1))
+						workers, actualSpec, actualMetadata := fakeStrategy.ChooseArgsForCall(0)
+						Expect(workers).To(Equal([]Worker{compatibleWorker}))
+						Expect(actualSpec).To(Equal(spec))
+						Expect(actualMetadata).To(Equal(metadata))
 						Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(Equal(1))
 						Expect(createdContainer).To(Equal(fakeContainer))
 					})
@@ -570,6 +686,143 @@ var _ = Describe("Pool", func() {
 						Expect(createErr).To(Equal(strategyError))
 					})
 				})
+
+				Context("with a maximum number of active tasks per worker", func() {
+					var busyWorker *workerfakes.FakeWorker
+
+					BeforeEach(func() {
+						pool = NewPool(fakeProvider, fakeStrategy, 2)
+
+						busyWorker = new(workerfakes.FakeWorker)
+						busyWorker.SatisfyingReturns(busyWorker, nil)
+						busyWorker.ActiveTasksReturns(2)
+
+						compatibleWorker.ActiveTasksReturns(1)
+						compatibleWorker.ReserveTaskSlotReturns(true, nil)
+
+						fakeProvider.RunningWorkersReturns([]Worker{
+							busyWorker,
+							compatibleWorker,
+						}, nil)
+
+						fakeStrategy.ChooseReturns(compatibleWorker, nil)
+					})
+
+					Context("when creating a task container", func() {
+						BeforeEach(func() {
+							metadata = db.ContainerMetadata{Type: db.ContainerTypeTask}
+						})
+
+						It("chooses between the workers below the limit", func() {
+							Expect(createErr).ToNot(HaveOccurred())
+							workers, _, _ := fakeStrategy.ChooseArgsForCall(0)
+							Expect(workers).To(Equal([]Worker{compatibleWorker}))
+						})
+
+						It("reserves a slot on the chosen worker for the task", func() {
+							Expect(compatibleWorker.ReserveTaskSlotCallCount()).To(Equal(1))
+							_, owner, max := compatibleWorker.ReserveTaskSlotArgsForCall(0)
+							Expect(owner).To(Equal(fakeOwner))
+							Expect(max).To(Equal(2))
+						})
+
+						Context("when the chosen worker's slots have been taken in the meantime", func() {
+							var otherWorker *workerfakes.FakeWorker
+
+							BeforeEach(func() {
+								compatibleWorker.ReserveTaskSlotReturns(false, nil)
+
+								otherWorker = new(workerfakes.FakeWorker)
+								otherWorker.SatisfyingReturns(otherWorker, nil)
+								otherWorker.ReserveTaskSlotReturns(true, nil)
+
+								fakeProvider.RunningWorkersReturns([]Worker{
+									busyWorker,
+									compatibleWorker,
+									otherWorker,
+								}, nil)
+
+								fakeStrategy.ChooseStub = func(workers []Worker, _ ContainerSpec, _ db.ContainerMetadata) (Worker, error) {
+									return workers[0], nil
+								}
+							})
+
+							It("places the task on the next worker with a free slot", func() {
+								Expect(createErr).ToNot(HaveOccurred())
+								Expect(fakeStrategy.ChooseCallCount()).To(Equal(2))
+
+								workers, _, _ := fakeStrategy.ChooseArgsForCall(1)
+								Expect(workers).To(Equal([]Worker{otherWorker}))
+
+								Expect(otherWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+							})
+
+							Context("when no worker has a free slot", func() {
+								BeforeEach(func() {
+									otherWorker.ReserveTaskSlotReturns(false, nil)
+								})
+
+								It("returns ErrActiveTasksLimitReached", func() {
+									Expect(createErr).To(Equal(ErrActiveTasksLimitReached))
+								})
+							})
+						})
+
+						Context("when every worker is at the limit", func() {
+							BeforeEach(func() {
+								compatibleWorker.ActiveTasksReturns(2)
+							})
+
+							It("returns ErrActiveTasksLimitReached without choosing a worker", func() {
+								Expect(createErr).To(Equal(ErrActiveTasksLimitReached))
+								Expect(fakeStrategy.ChooseCallCount()).To(BeZero())
+							})
+						})
+					})
+
+					Context("when creating any other container", func() {
+						It("chooses between every compatible worker", func() {
+							Expect(createErr).ToNot(HaveOccurred())
+							workers, _, _ := fakeStrategy.ChooseArgsForCall(0)
+							Expect(workers).To(Equal([]Worker{busyWorker, compatibleWorker}))
+						})
+					})
+				})
+
+				Context("when a worker is under pressure", func() {
+					var pressuredWorker *workerfakes.FakeWorker
+
+					BeforeEach(func() {
+						pressuredWorker = new(workerfakes.FakeWorker)
+						pressuredWorker.SatisfyingReturns(pressuredWorker, nil)
+						pressuredWorker.UnderPressureReturns(true)
+
+						fakeProvider.RunningWorkersReturns([]Worker{
+							pressuredWorker,
+							compatibleWorker,
+						}, nil)
+
+						fakeStrategy.ChooseReturns(compatibleWorker, nil)
+					})
+
+					It("chooses between the workers not under pressure", func() {
+						Expect(createErr).ToNot(HaveOccurred())
+						workers, _, _ := fakeStrategy.ChooseArgsForCall(0)
+						Expect(workers).To(Equal([]Worker{compatibleWorker}))
+					})
+
+					Context("when every worker is under pressure", func() {
+						BeforeEach(func() {
+							compatibleWorker.UnderPressureReturns(true)
+						})
+
+						It("chooses between all of them", func() {
+							Expect(createErr).ToNot(HaveOccurred())
+							workers, _, _ := fakeStrategy.ChooseArgsForCall(0)
+							Expect(workers).To(Equal([]Worker{pressuredWorker, compatibleWorker}))
+						})
+					})
+				})
 			})
 		})
 	})
@@ -0,0 +1,217 @@
+package worker
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/baggageclaim"
+)
+
+// ResourceCacheMirror copies the most frequently fetched resource caches onto
+// idle workers, so that the steps using them are not all placed on the few
+// workers which happen to hold them.
+type ResourceCacheMirror interface {
+	Run() error
+}
+
+type resourceCacheMirror struct {
+	logger          lager.Logger
+	provider        WorkerProvider
+	dbVolumeFactory db.VolumeFactory
+	p2pStreamer     P2PStreamer
+	hotCaches       int
+	replicas        int
+}
+
+// NewResourceCacheMirror constructs a ResourceCacheMirror which considers the
+// given number of most fetched caches on each worker, copying each of them
+// until it is held by the given number of workers. A worker is only copied
+// onto while it is running no builds and is not under pressure, and receives
+// at most one cache each run. Caches are streamed directly between the
+// workers if the p2pStreamer is given and supported, and through the ATC
+// otherwise.
+func NewResourceCacheMirror(
+	logger lager.Logger,
+	provider WorkerProvider,
+	dbVolumeFactory db.VolumeFactory,
+	p2pStreamer P2PStreamer,
+	hotCaches int,
+	replicas int,
+) ResourceCacheMirror {
+	return &resourceCacheMirror{
+		logger:          logger,
+		provider:        provider,
+		dbVolumeFactory: dbVolumeFactory,
+		p2pStreamer:     p2pStreamer,
+		hotCaches:       hotCaches,
+		replicas:        replicas,
+	}
+}
+
+func (mirror *resourceCacheMirror) Run() error {
+	logger := mirror.logger.Session("run")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	hotVolumes, err := mirror.dbVolumeFactory.GetHotResourceCacheVolumes(mirror.hotCaches)
+	if err != nil {
+		logger.Error("failed-to-get-hot-resource-cache-volumes", err)
+		return err
+	}
+
+	if len(hotVolumes) == 0 {
+		return nil
+	}
+
+	workers, err := mirror.provider.RunningWorkers(logger)
+	if err != nil {
+		logger.Error("failed-to-get-running-workers", err)
+		return err
+	}
+
+	runningWorkers := map[string]Worker{}
+	idleWorkers := []Worker{}
+	for _, w := range workers {
+		runningWorkers[w.Name()] = w
+
+		if w.BuildContainers() == 0 && !w.UnderPressure() {
+			idleWorkers = append(idleWorkers, w)
+		}
+	}
+
+	for _, hotVolume := range hotVolumes {
+		if len(idleWorkers) == 0 {
+			logger.Debug("no-idle-workers-left")
+			break
+		}
+
+		source, found := runningWorkers[hotVolume.WorkerName]
+		if !found {
+			continue
+		}
+
+		hLog := logger.Session("mirror", lager.Data{
+			"resource-cache": hotVolume.ResourceCacheID,
+			"volume":         hotVolume.VolumeHandle,
+			"worker":         hotVolume.WorkerName,
+			"fetch-count":    hotVolume.FetchCount,
+		})
+
+		destination, found, err := mirror.destinationWorker(hLog, idleWorkers, hotVolume)
+		if err != nil {
+			hLog.Error("failed-to-find-destination-worker", err)
+			continue
+		}
+
+		if !found {
+			continue
+		}
+
+		idleWorkers = without(idleWorkers, destination)
+
+		hLog = hLog.WithData(lager.Data{"destination": destination.Name()})
+
+		err = mirror.replicate(hLog, source, destination, hotVolume)
+		if err != nil {
+			// the cache is still fetched from its own worker
+			hLog.Error("failed-to-replicate", err)
+		}
+	}
+
+	return nil
+}
+
+func (mirror *resourceCacheMirror) destinationWorker(logger lager.Logger, idleWorkers []Worker, hotVolume db.HotResourceCacheVolume) (Worker, bool, error) {
+	holders, err := mirror.dbVolumeFactory.FindResourceCacheReplicaWorkers(hotVolume)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(holders) >= mirror.replicas {
+		return nil, false, nil
+	}
+
+	spec := WorkerSpec{
+		ResourceType: hotVolume.BaseResourceTypeName,
+		TeamID:       hotVolume.TeamID,
+	}
+
+	compatibleWorkers := []Worker{}
+	for _, w := range idleWorkers {
+		if containsString(holders, w.Name()) {
+			continue
+		}
+
+		_, err := w.Satisfying(logger, spec, nil)
+		if err == nil {
+			compatibleWorkers = append(compatibleWorkers, w)
+		}
+	}
+
+	if len(compatibleWorkers) == 0 {
+		return nil, false, nil
+	}
+
+	return fewest(compatibleWorkers, Worker.ActiveContainers)[0], true, nil
+}
+
+func (mirror *resourceCacheMirror) replicate(
+	logger lager.Logger,
+	source Worker,
+	destination Worker,
+	hotVolume db.HotResourceCacheVolume,
+) error {
+	sourceVolume, found, err := source.LookupVolume(logger, hotVolume.VolumeHandle)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		logger.Debug("volume-not-found")
+		return nil
+	}
+
+	volume, err := destination.CreateVolumeForResourceCacheReplica(
+		logger,
+		VolumeSpec{
+			Strategy: baggageclaim.EmptyStrategy{},
+		},
+		hotVolume,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = streamVolume(logger, mirror.p2pStreamer, sourceVolume, volume)
+	if err != nil {
+		destroyErr := volume.Destroy()
+		if destroyErr != nil {
+			logger.Error("failed-to-destroy-volume", destroyErr)
+		}
+
+		return err
+	}
+
+	return volume.InitializeResourceCacheReplica()
+}
+
+func without(workers []Worker, worker Worker) []Worker {
+	remaining := []Worker{}
+	for _, w := range workers {
+		if w.Name() != worker.Name() {
+			remaining = append(remaining, w)
+		}
+	}
+
+	return remaining
+}
+
+func containsString(strings []string, s string) bool {
+	for _, str := range strings {
+		if str == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,229 @@
+package worker_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	"github.com/concourse/baggageclaim"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceCacheMirror", func() {
+	var (
+		fakeProvider      *workerfakes.FakeWorkerProvider
+		fakeVolumeFactory *dbfakes.FakeVolumeFactory
+		fakeP2PStreamer   *workerfakes.FakeP2PStreamer
+
+		fakeSourceWorker      *workerfakes.FakeWorker
+		fakeDestinationWorker *workerfakes.FakeWorker
+		fakeBusyWorker        *workerfakes.FakeWorker
+
+		fakeSourceVolume      *workerfakes.FakeVolume
+		fakeDestinationVolume *workerfakes.FakeVolume
+
+		hotVolume db.HotResourceCacheVolume
+
+		runErr error
+	)
+
+	newWorker := func(name string, buildContainers int) *workerfakes.FakeWorker {
+		w := new(workerfakes.FakeWorker)
+		w.NameReturns(name)
+		w.BuildContainersReturns(buildContainers)
+		w.SatisfyingReturns(w, nil)
+		return w
+	}
+
+	BeforeEach(func() {
+		fakeProvider = new(workerfakes.FakeWorkerProvider)
+		fakeVolumeFactory = new(dbfakes.FakeVolumeFactory)
+		fakeP2PStreamer = new(workerfakes.FakeP2PStreamer)
+
+		hotVolume = db.HotResourceCacheVolume{
+			WorkerResourceCacheID: 1,
+			ResourceCacheID:       2,
+			BaseResourceTypeName:  "git",
+			WorkerName:            "source-worker",
+			TeamID:                42,
+			VolumeHandle:          "source-handle",
+			FetchCount:            10,
+		}
+
+		fakeVolumeFactory.GetHotResourceCacheVolumesReturns([]db.HotResourceCacheVolume{hotVolume}, nil)
+		fakeVolumeFactory.FindResourceCacheReplicaWorkersReturns([]string{"source-worker"}, nil)
+
+		fakeSourceVolume = new(workerfakes.FakeVolume)
+		fakeSourceVolume.StreamOutReturns(ioutil.NopCloser(bytes.NewBufferString("some-cache")), nil)
+
+		fakeSourceWorker = newWorker("source-worker", 3)
+		fakeSourceWorker.LookupVolumeReturns(fakeSourceVolume, true, nil)
+
+		fakeDestinationVolume = new(workerfakes.FakeVolume)
+
+		fakeDestinationWorker = newWorker("destination-worker", 0)
+		fakeDestinationWorker.CreateVolumeForResourceCacheReplicaReturns(fakeDestinationVolume, nil)
+
+		fakeBusyWorker = newWorker("busy-worker", 1)
+
+		fakeProvider.RunningWorkersReturns([]Worker{
+			fakeSourceWorker,
+			fakeBusyWorker,
+			fakeDestinationWorker,
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		runErr = NewResourceCacheMirror(
+			lagertest.NewTestLogger("test"),
+			fakeProvider,
+			fakeVolumeFactory,
+			fakeP2PStreamer,
+			5,
+			2,
+		).Run()
+	})
+
+	It("looks for the most fetched caches on each worker", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+		Expect(fakeVolumeFactory.GetHotResourceCacheVolumesArgsForCall(0)).To(Equal(5))
+	})
+
+	It("looks for an idle worker which could fetch the same cache", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+		Expect(fakeBusyWorker.SatisfyingCallCount()).To(BeZero())
+
+		_, spec, _ := fakeDestinationWorker.SatisfyingArgsForCall(0)
+		Expect(spec).To(Equal(WorkerSpec{
+			ResourceType: "git",
+			TeamID:       42,
+		}))
+	})
+
+	It("creates a copy of the cache on the idle worker", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+		Expect(fakeBusyWorker.CreateVolumeForResourceCacheReplicaCallCount()).To(BeZero())
+		Expect(fakeDestinationWorker.CreateVolumeForResourceCacheReplicaCallCount()).To(Equal(1))
+
+		_, spec, source := fakeDestinationWorker.CreateVolumeForResourceCacheReplicaArgsForCall(0)
+		Expect(spec.Strategy).To(Equal(baggageclaim.EmptyStrategy{}))
+		Expect(source).To(Equal(hotVolume))
+	})
+
+	It("streams the cache directly between the workers and initializes it", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+
+		_, handle := fakeSourceWorker.LookupVolumeArgsForCall(0)
+		Expect(handle).To(Equal("source-handle"))
+
+		_, source, _, destination, _ := fakeP2PStreamer.StreamP2PArgsForCall(0)
+		Expect(source).To(Equal(fakeSourceVolume))
+		Expect(destination).To(Equal(fakeDestinationVolume))
+
+		Expect(fakeDestinationVolume.InitializeResourceCacheReplicaCallCount()).To(Equal(1))
+	})
+
+	Context("when the workers cannot stream directly", func() {
+		BeforeEach(func() {
+			fakeP2PStreamer.StreamP2PReturns(ErrP2PStreamingUnsupported)
+		})
+
+		It("streams the cache through the ATC", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			path, stream := fakeDestinationVolume.StreamInArgsForCall(0)
+			Expect(path).To(Equal("."))
+			Expect(ioutil.ReadAll(stream)).To(Equal([]byte("some-cache")))
+		})
+	})
+
+	Context("when streaming fails", func() {
+		BeforeEach(func() {
+			fakeP2PStreamer.StreamP2PReturns(errors.New("disaster"))
+		})
+
+		It("destroys the partial copy rather than initializing it", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			Expect(fakeDestinationVolume.DestroyCallCount()).To(Equal(1))
+			Expect(fakeDestinationVolume.InitializeResourceCacheReplicaCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the cache is already held by enough workers", func() {
+		BeforeEach(func() {
+			fakeVolumeFactory.FindResourceCacheReplicaWorkersReturns([]string{"source-worker", "other-worker"}, nil)
+		})
+
+		It("leaves it alone", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeDestinationWorker.CreateVolumeForResourceCacheReplicaCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the idle worker already holds the cache", func() {
+		BeforeEach(func() {
+			fakeVolumeFactory.FindResourceCacheReplicaWorkersReturns([]string{"destination-worker"}, nil)
+		})
+
+		It("does not copy it there again", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeDestinationWorker.CreateVolumeForResourceCacheReplicaCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when several caches are hot", func() {
+		BeforeEach(func() {
+			otherHotVolume := hotVolume
+			otherHotVolume.WorkerResourceCacheID = 3
+			otherHotVolume.ResourceCacheID = 4
+
+			fakeVolumeFactory.GetHotResourceCacheVolumesReturns([]db.HotResourceCacheVolume{hotVolume, otherHotVolume}, nil)
+		})
+
+		It("copies at most one onto each idle worker", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeDestinationWorker.CreateVolumeForResourceCacheReplicaCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the idle worker cannot fetch the cache", func() {
+		BeforeEach(func() {
+			fakeDestinationWorker.SatisfyingReturns(nil, ErrUnsupportedResourceType)
+		})
+
+		It("gives up on the cache", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeDestinationWorker.CreateVolumeForResourceCacheReplicaCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when no caches have been fetched", func() {
+		BeforeEach(func() {
+			fakeVolumeFactory.GetHotResourceCacheVolumesReturns(nil, nil)
+		})
+
+		It("does not look for workers", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeProvider.RunningWorkersCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the hot caches fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakeVolumeFactory.GetHotResourceCacheVolumesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
@@ -0,0 +1,167 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+)
+
+// ResourceTypeWarmer fetches the images of the configured resource types onto
+// every worker able to fetch them, ahead of any container needing them.
+type ResourceTypeWarmer interface {
+	Run() error
+}
+
+type resourceTypeWarmer struct {
+	logger                          lager.Logger
+	provider                        WorkerProvider
+	dbTeamFactory                   db.TeamFactory
+	dbWorkerWarmResourceTypeFactory db.WorkerWarmResourceTypeFactory
+	resourceTypes                   atc.ResourceTypes
+}
+
+// NewResourceTypeWarmer constructs a ResourceTypeWarmer for the resource
+// types, declared as in a pipeline. The images are fetched on behalf of the
+// main team and pinned on each worker, so that they survive garbage
+// collection. A pinned image is used by any team fetching the same version of
+// the same image onto the worker, e.g. for a resource type declared in its
+// pipeline, and never in place of a base resource type. Running it again
+// checks for newer versions of the images, fetching and pinning them in turn.
+func NewResourceTypeWarmer(
+	logger lager.Logger,
+	provider WorkerProvider,
+	dbTeamFactory db.TeamFactory,
+	dbWorkerWarmResourceTypeFactory db.WorkerWarmResourceTypeFactory,
+	resourceTypes atc.ResourceTypes,
+) ResourceTypeWarmer {
+	return &resourceTypeWarmer{
+		logger:                          logger,
+		provider:                        provider,
+		dbTeamFactory:                   dbTeamFactory,
+		dbWorkerWarmResourceTypeFactory: dbWorkerWarmResourceTypeFactory,
+		resourceTypes:                   resourceTypes,
+	}
+}
+
+func (warmer *resourceTypeWarmer) Run() error {
+	logger := warmer.logger.Session("run")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	names := []string{}
+	for _, resourceType := range warmer.resourceTypes {
+		names = append(names, resourceType.Name)
+	}
+
+	err := warmer.dbWorkerWarmResourceTypeFactory.Prune(names)
+	if err != nil {
+		logger.Error("failed-to-prune-warm-resource-types", err)
+		return err
+	}
+
+	if len(warmer.resourceTypes) == 0 {
+		return nil
+	}
+
+	team, found, err := warmer.dbTeamFactory.FindTeam(atc.DefaultTeamName)
+	if err != nil {
+		logger.Error("failed-to-find-main-team", err)
+		return err
+	}
+
+	if !found {
+		logger.Info("main-team-not-found")
+		return nil
+	}
+
+	workers, err := warmer.provider.RunningWorkers(logger)
+	if err != nil {
+		logger.Error("failed-to-get-running-workers", err)
+		return err
+	}
+
+	for _, worker := range workers {
+		for _, resourceType := range warmer.resourceTypes {
+			if !providesResourceType(worker, resourceType.Type) {
+				continue
+			}
+
+			wLog := logger.Session("warm", lager.Data{
+				"worker":        worker.Name(),
+				"resource-type": resourceType.Name,
+			})
+
+			err := warmer.warm(wLog, worker, team.ID(), resourceType)
+			if err != nil {
+				// the worker keeps using the image it shipped with
+				wLog.Error("failed-to-warm", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// warm creates a container using the image so that it is fetched onto the
+// worker, pinning the image if a version of it had to be fetched. The
+// container is garbage collected once it has been created.
+func (warmer *resourceTypeWarmer) warm(logger lager.Logger, worker Worker, teamID int, resourceType atc.ResourceType) error {
+	delegate := &warmingDelegate{}
+
+	_, err := worker.FindOrCreateContainer(
+		context.Background(),
+		logger,
+		delegate,
+		db.NewWarmResourceTypeContainerOwner(worker.Name(), resourceType.Name),
+		db.ContainerMetadata{},
+		ContainerSpec{
+			ImageSpec: ImageSpec{
+				ResourceType: resourceType.Name,
+			},
+			TeamID: teamID,
+		},
+		creds.NewVersionedResourceTypes(template.StaticVariables{}, atc.VersionedResourceTypes{
+			{ResourceType: resourceType},
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	if delegate.resourceCache == nil {
+		return nil
+	}
+
+	logger.Debug("pinning", lager.Data{"resource-cache": delegate.resourceCache.ID})
+
+	return warmer.dbWorkerWarmResourceTypeFactory.Pin(worker.Name(), resourceType.Name, delegate.resourceCache)
+}
+
+func providesResourceType(worker Worker, name string) bool {
+	for _, t := range worker.ResourceTypes() {
+		if t.Type == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+type warmingDelegate struct {
+	resourceCache *db.UsedResourceCache
+}
+
+func (delegate *warmingDelegate) Stdout() io.Writer { return ioutil.Discard }
+func (delegate *warmingDelegate) Stderr() io.Writer { return ioutil.Discard }
+
+func (delegate *warmingDelegate) ImageVersionDetermined(resourceCache *db.UsedResourceCache) error {
+	delegate.resourceCache = resourceCache
+	return nil
+}
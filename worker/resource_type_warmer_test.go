@@ -0,0 +1,169 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceTypeWarmer", func() {
+	var (
+		fakeProvider                        *workerfakes.FakeWorkerProvider
+		fakeDBTeamFactory                   *dbfakes.FakeTeamFactory
+		fakeDBWorkerWarmResourceTypeFactory *dbfakes.FakeWorkerWarmResourceTypeFactory
+
+		fakeTeam         *dbfakes.FakeTeam
+		fakeWorker       *workerfakes.FakeWorker
+		fakeOtherWorker  *workerfakes.FakeWorker
+		warmResourceType atc.ResourceType
+		resourceTypes    atc.ResourceTypes
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeProvider = new(workerfakes.FakeWorkerProvider)
+		fakeDBTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeDBWorkerWarmResourceTypeFactory = new(dbfakes.FakeWorkerWarmResourceTypeFactory)
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.IDReturns(1)
+		fakeDBTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		fakeWorker = new(workerfakes.FakeWorker)
+		fakeWorker.NameReturns("some-worker")
+		fakeWorker.ResourceTypesReturns([]atc.WorkerResourceType{
+			{Type: "git"},
+			{Type: "docker-image"},
+		})
+
+		fakeOtherWorker = new(workerfakes.FakeWorker)
+		fakeOtherWorker.NameReturns("other-worker")
+		fakeOtherWorker.ResourceTypesReturns([]atc.WorkerResourceType{
+			{Type: "git"},
+		})
+
+		fakeProvider.RunningWorkersReturns([]Worker{fakeWorker, fakeOtherWorker}, nil)
+
+		warmResourceType = atc.ResourceType{
+			Name:   "slack-notification",
+			Type:   "docker-image",
+			Source: atc.Source{"repository": "cfcommunity/slack-notification-resource"},
+		}
+
+		resourceTypes = atc.ResourceTypes{warmResourceType}
+	})
+
+	JustBeforeEach(func() {
+		runErr = NewResourceTypeWarmer(
+			lagertest.NewTestLogger("test"),
+			fakeProvider,
+			fakeDBTeamFactory,
+			fakeDBWorkerWarmResourceTypeFactory,
+			resourceTypes,
+		).Run()
+	})
+
+	It("stops keeping warm the types which are no longer configured", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+		Expect(fakeDBWorkerWarmResourceTypeFactory.PruneArgsForCall(0)).To(Equal([]string{"slack-notification"}))
+	})
+
+	It("creates a container using the image on the workers providing the type it is of", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+		Expect(fakeOtherWorker.FindOrCreateContainerCallCount()).To(BeZero())
+		Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+
+		_, _, _, owner, _, spec, resourceTypes := fakeWorker.FindOrCreateContainerArgsForCall(0)
+		Expect(owner).To(Equal(db.NewWarmResourceTypeContainerOwner("some-worker", "slack-notification")))
+		Expect(spec.ImageSpec).To(Equal(ImageSpec{ResourceType: "slack-notification"}))
+		Expect(spec.TeamID).To(Equal(1))
+
+		resourceType, found := resourceTypes.Lookup("slack-notification")
+		Expect(found).To(BeTrue())
+		Expect(resourceType.ResourceType).To(Equal(warmResourceType))
+	})
+
+	Context("when a version of the image is fetched", func() {
+		var resourceCache *db.UsedResourceCache
+
+		BeforeEach(func() {
+			resourceCache = &db.UsedResourceCache{ID: 42}
+
+			fakeWorker.FindOrCreateContainerStub = func(
+				ctx context.Context,
+				logger lager.Logger,
+				delegate ImageFetchingDelegate,
+				owner db.ContainerOwner,
+				metadata db.ContainerMetadata,
+				spec ContainerSpec,
+				resourceTypes creds.VersionedResourceTypes,
+			) (Container, error) {
+				return new(workerfakes.FakeContainer), delegate.ImageVersionDetermined(resourceCache)
+			}
+		})
+
+		It("pins it on the worker", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeDBWorkerWarmResourceTypeFactory.PinCallCount()).To(Equal(1))
+
+			workerName, name, pinnedCache := fakeDBWorkerWarmResourceTypeFactory.PinArgsForCall(0)
+			Expect(workerName).To(Equal("some-worker"))
+			Expect(name).To(Equal("slack-notification"))
+			Expect(pinnedCache).To(Equal(resourceCache))
+		})
+	})
+
+	Context("when the container already exists", func() {
+		It("leaves the pinned image alone", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeDBWorkerWarmResourceTypeFactory.PinCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when warming fails on a worker", func() {
+		BeforeEach(func() {
+			fakeOtherWorker.ResourceTypesReturns([]atc.WorkerResourceType{{Type: "docker-image"}})
+			fakeWorker.FindOrCreateContainerReturns(nil, errors.New("disaster"))
+		})
+
+		It("warms the other workers", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeOtherWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when no resource types are configured", func() {
+		BeforeEach(func() {
+			resourceTypes = atc.ResourceTypes{}
+		})
+
+		It("releases every pinned image without looking for workers", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeDBWorkerWarmResourceTypeFactory.PruneArgsForCall(0)).To(BeEmpty())
+			Expect(fakeProvider.RunningWorkersCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the running workers fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakeProvider.RunningWorkersReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
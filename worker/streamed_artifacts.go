@@ -0,0 +1,100 @@
+package worker
+
+import (
+	"reflect"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// StreamedInputSource is implemented by InputSources whose artifact may be
+// used by several containers on the same worker at once. Rather than being
+// streamed to each of them, the artifact is streamed to the worker once and
+// each container is given a copy-on-write clone of it.
+type StreamedInputSource interface {
+	InputSource
+
+	StreamedArtifacts() *StreamedArtifacts
+}
+
+// StreamedArtifacts records the volumes that artifacts have been streamed
+// into on each worker. There is one StreamedArtifacts for each
+// ArtifactRepository, so artifacts are only shared within a build.
+type StreamedArtifacts struct {
+	artifacts  map[streamedArtifactKey]*streamedArtifact
+	artifactsL sync.Mutex
+}
+
+type streamedArtifactKey struct {
+	worker string
+	source ArtifactSource
+}
+
+type streamedArtifact struct {
+	handle string
+
+	// held while the artifact is being streamed, so that others wait for it
+	// rather than streaming their own copy
+	streamingL sync.Mutex
+}
+
+func NewStreamedArtifacts() *StreamedArtifacts {
+	return &StreamedArtifacts{
+		artifacts: map[streamedArtifactKey]*streamedArtifact{},
+	}
+}
+
+// VolumeOn returns the volume the source was streamed into on the worker,
+// calling stream to create it if there is none yet or if it has since been
+// destroyed. Concurrent calls for the same source and worker wait for the
+// first to finish streaming.
+//
+// Sources which cannot be told apart from one another, i.e. which are not
+// comparable, are streamed every time.
+func (streamed *StreamedArtifacts) VolumeOn(
+	logger lager.Logger,
+	worker Worker,
+	source ArtifactSource,
+	stream func() (Volume, error),
+) (Volume, error) {
+	if !reflect.TypeOf(source).Comparable() {
+		return stream()
+	}
+
+	key := streamedArtifactKey{
+		worker: worker.Name(),
+		source: source,
+	}
+
+	streamed.artifactsL.Lock()
+	artifact, found := streamed.artifacts[key]
+	if !found {
+		artifact = &streamedArtifact{}
+		streamed.artifacts[key] = artifact
+	}
+	streamed.artifactsL.Unlock()
+
+	artifact.streamingL.Lock()
+	defer artifact.streamingL.Unlock()
+
+	if artifact.handle != "" {
+		volume, found, err := worker.LookupVolume(logger, artifact.handle)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			logger.Debug("cloning-streamed-artifact", lager.Data{"volume": artifact.handle})
+			return volume, nil
+		}
+	}
+
+	volume, err := stream()
+	if err != nil {
+		return nil, err
+	}
+
+	artifact.handle = volume.Handle()
+
+	return volume, nil
+}
@@ -0,0 +1,173 @@
+package worker_test
+
+import (
+	"errors"
+	"sync"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StreamedArtifacts", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		streamed *StreamedArtifacts
+
+		fakeWorker *workerfakes.FakeWorker
+		fakeSource *workerfakes.FakeArtifactSource
+		fakeVolume *workerfakes.FakeVolume
+
+		streams int
+		streamL sync.Mutex
+		stream  func() (Volume, error)
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		streamed = NewStreamedArtifacts()
+
+		fakeWorker = new(workerfakes.FakeWorker)
+		fakeWorker.NameReturns("some-worker")
+
+		fakeSource = new(workerfakes.FakeArtifactSource)
+
+		fakeVolume = new(workerfakes.FakeVolume)
+		fakeVolume.HandleReturns("some-handle")
+
+		streams = 0
+		stream = func() (Volume, error) {
+			streamL.Lock()
+			streams++
+			streamL.Unlock()
+
+			return fakeVolume, nil
+		}
+	})
+
+	It("streams the source the first time", func() {
+		volume, err := streamed.VolumeOn(logger, fakeWorker, fakeSource, stream)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(volume).To(Equal(fakeVolume))
+		Expect(streams).To(Equal(1))
+	})
+
+	Context("when the source has been streamed to the worker", func() {
+		BeforeEach(func() {
+			_, err := streamed.VolumeOn(logger, fakeWorker, fakeSource, stream)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the volume still exists", func() {
+			var existingVolume *workerfakes.FakeVolume
+
+			BeforeEach(func() {
+				existingVolume = new(workerfakes.FakeVolume)
+				fakeWorker.LookupVolumeReturns(existingVolume, true, nil)
+			})
+
+			It("returns it without streaming again", func() {
+				volume, err := streamed.VolumeOn(logger, fakeWorker, fakeSource, stream)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volume).To(Equal(existingVolume))
+				Expect(streams).To(Equal(1))
+
+				_, handle := fakeWorker.LookupVolumeArgsForCall(0)
+				Expect(handle).To(Equal("some-handle"))
+			})
+		})
+
+		Context("when the volume has been destroyed", func() {
+			BeforeEach(func() {
+				fakeWorker.LookupVolumeReturns(nil, false, nil)
+			})
+
+			It("streams the source again", func() {
+				volume, err := streamed.VolumeOn(logger, fakeWorker, fakeSource, stream)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volume).To(Equal(fakeVolume))
+				Expect(streams).To(Equal(2))
+			})
+		})
+
+		Context("when looking up the volume fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeWorker.LookupVolumeReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				_, err := streamed.VolumeOn(logger, fakeWorker, fakeSource, stream)
+				Expect(err).To(Equal(disaster))
+			})
+		})
+
+		It("streams the source to other workers", func() {
+			otherWorker := new(workerfakes.FakeWorker)
+			otherWorker.NameReturns("some-other-worker")
+
+			_, err := streamed.VolumeOn(logger, otherWorker, fakeSource, stream)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(streams).To(Equal(2))
+		})
+
+		It("streams other sources to the worker", func() {
+			_, err := streamed.VolumeOn(logger, fakeWorker, new(workerfakes.FakeArtifactSource), stream)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(streams).To(Equal(2))
+		})
+	})
+
+	Context("when the source is requested many times at once", func() {
+		BeforeEach(func() {
+			fakeWorker.LookupVolumeReturns(fakeVolume, true, nil)
+		})
+
+		It("streams it only once", func() {
+			wg := new(sync.WaitGroup)
+
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					_, err := streamed.VolumeOn(logger, fakeWorker, fakeSource, stream)
+					Expect(err).NotTo(HaveOccurred())
+				}()
+			}
+
+			wg.Wait()
+
+			Expect(streams).To(Equal(1))
+		})
+	})
+
+	Context("when streaming fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			stream = func() (Volume, error) {
+				streams++
+				return nil, disaster
+			}
+		})
+
+		It("returns the error, and streams again next time", func() {
+			_, err := streamed.VolumeOn(logger, fakeWorker, fakeSource, stream)
+			Expect(err).To(Equal(disaster))
+
+			_, err = streamed.VolumeOn(logger, fakeWorker, fakeSource, stream)
+			Expect(err).To(Equal(disaster))
+
+			Expect(streams).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,186 @@
+package worker
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/baggageclaim"
+)
+
+//go:generate counterfeiter . TaskCacheReplicator
+
+// TaskCacheReplicator copies the task caches of a worker which is going away
+// onto another worker, so that the jobs using them do not start over with
+// empty caches.
+type TaskCacheReplicator interface {
+	ReplicateTaskCaches(logger lager.Logger, workerName string) error
+}
+
+type taskCacheReplicator struct {
+	provider                 WorkerProvider
+	dbWorkerFactory          db.WorkerFactory
+	dbWorkerTaskCacheFactory db.WorkerTaskCacheFactory
+	p2pStreamer              P2PStreamer
+	clock                    clock.Clock
+}
+
+// NewTaskCacheReplicator constructs a TaskCacheReplicator which copies the
+// caches to the running worker with the fewest active containers that could
+// have run the same steps, i.e. one with the same platform, tags, and team.
+// Caches are streamed directly between the workers if the p2pStreamer is
+// given and supported, and through the ATC otherwise.
+func NewTaskCacheReplicator(
+	provider WorkerProvider,
+	dbWorkerFactory db.WorkerFactory,
+	dbWorkerTaskCacheFactory db.WorkerTaskCacheFactory,
+	p2pStreamer P2PStreamer,
+	clock clock.Clock,
+) TaskCacheReplicator {
+	return &taskCacheReplicator{
+		provider:                 provider,
+		dbWorkerFactory:          dbWorkerFactory,
+		dbWorkerTaskCacheFactory: dbWorkerTaskCacheFactory,
+		p2pStreamer:              p2pStreamer,
+		clock:                    clock,
+	}
+}
+
+func (replicator *taskCacheReplicator) ReplicateTaskCaches(logger lager.Logger, workerName string) error {
+	logger = logger.Session("replicate-task-caches", lager.Data{"worker": workerName})
+
+	savedWorker, found, err := replicator.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-to-get-worker", err)
+		return err
+	}
+
+	if !found {
+		return nil
+	}
+
+	caches, err := replicator.dbWorkerTaskCacheFactory.FindVolumesOnWorker(workerName)
+	if err != nil {
+		logger.Error("failed-to-find-task-caches", err)
+		return err
+	}
+
+	if len(caches) == 0 {
+		return nil
+	}
+
+	destination, found, err := replicator.destinationWorker(logger, savedWorker)
+	if err != nil {
+		logger.Error("failed-to-find-destination-worker", err)
+		return err
+	}
+
+	if !found {
+		logger.Info("no-worker-to-replicate-to")
+		return nil
+	}
+
+	source := replicator.provider.NewGardenWorker(logger, replicator.clock, savedWorker)
+
+	for _, cache := range caches {
+		cLog := logger.Session("replicate", lager.Data{
+			"job-id":      cache.JobID,
+			"step-name":   cache.StepName,
+			"path":        cache.Path,
+			"volume":      cache.VolumeHandle,
+			"destination": destination.Name(),
+		})
+
+		err := replicator.replicate(cLog, source, destination, cache)
+		if err != nil {
+			// a cache which cannot be copied is only a slower next build
+			cLog.Error("failed-to-replicate", err)
+		}
+	}
+
+	return nil
+}
+
+func (replicator *taskCacheReplicator) destinationWorker(logger lager.Logger, savedWorker db.Worker) (Worker, bool, error) {
+	workers, err := replicator.provider.RunningWorkers(logger)
+	if err != nil {
+		return nil, false, err
+	}
+
+	spec := WorkerSpec{
+		Platform: savedWorker.Platform(),
+		Tags:     savedWorker.Tags(),
+		TeamID:   savedWorker.TeamID(),
+	}
+
+	compatibleWorkers := []Worker{}
+	for _, w := range workers {
+		if w.Name() == savedWorker.Name() {
+			continue
+		}
+
+		_, err := w.Satisfying(logger, spec, nil)
+		if err == nil {
+			compatibleWorkers = append(compatibleWorkers, w)
+		}
+	}
+
+	if len(compatibleWorkers) == 0 {
+		return nil, false, nil
+	}
+
+	return fewest(withoutPressure(compatibleWorkers), Worker.ActiveContainers)[0], true, nil
+}
+
+func (replicator *taskCacheReplicator) replicate(
+	logger lager.Logger,
+	source Worker,
+	destination Worker,
+	cache db.WorkerTaskCacheVolume,
+) error {
+	_, found, err := destination.FindVolumeForTaskCache(logger, cache.TeamID, cache.JobID, cache.StepName, cache.Path)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		logger.Debug("already-on-destination")
+		return nil
+	}
+
+	sourceVolume, found, err := source.LookupVolume(logger, cache.VolumeHandle)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		logger.Debug("volume-not-found")
+		return nil
+	}
+
+	volume, err := destination.CreateVolumeForTaskCache(
+		logger,
+		VolumeSpec{
+			Strategy: baggageclaim.EmptyStrategy{},
+		},
+		cache.TeamID,
+		cache.JobID,
+		cache.StepName,
+		cache.Path,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = streamVolume(logger, replicator.p2pStreamer, sourceVolume, volume)
+	if err != nil {
+		// leave the cache to be built from scratch rather than half-copied
+		destroyErr := volume.Destroy()
+		if destroyErr != nil {
+			logger.Error("failed-to-destroy-volume", destroyErr)
+		}
+
+		return err
+	}
+
+	return volume.InitializeTaskCache(logger, cache.JobID, cache.StepName, cache.Path, false)
+}
@@ -0,0 +1,224 @@
+package worker_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	"github.com/concourse/baggageclaim"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskCacheReplicator", func() {
+	var (
+		fakeProvider                 *workerfakes.FakeWorkerProvider
+		fakeDBWorkerFactory          *dbfakes.FakeWorkerFactory
+		fakeDBWorkerTaskCacheFactory *dbfakes.FakeWorkerTaskCacheFactory
+		fakeP2PStreamer              *workerfakes.FakeP2PStreamer
+
+		fakeSavedWorker       *dbfakes.FakeWorker
+		fakeSourceWorker      *workerfakes.FakeWorker
+		fakeDestinationWorker *workerfakes.FakeWorker
+		fakeBusyWorker        *workerfakes.FakeWorker
+
+		fakeSourceVolume      *workerfakes.FakeVolume
+		fakeDestinationVolume *workerfakes.FakeVolume
+
+		replicator   TaskCacheReplicator
+		replicateErr error
+	)
+
+	newWorker := func(name string, activeContainers int) *workerfakes.FakeWorker {
+		w := new(workerfakes.FakeWorker)
+		w.NameReturns(name)
+		w.ActiveContainersReturns(activeContainers)
+		w.SatisfyingReturns(w, nil)
+		return w
+	}
+
+	BeforeEach(func() {
+		fakeProvider = new(workerfakes.FakeWorkerProvider)
+		fakeDBWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeDBWorkerTaskCacheFactory = new(dbfakes.FakeWorkerTaskCacheFactory)
+		fakeP2PStreamer = new(workerfakes.FakeP2PStreamer)
+
+		fakeSavedWorker = new(dbfakes.FakeWorker)
+		fakeSavedWorker.NameReturns("retiring-worker")
+		fakeSavedWorker.PlatformReturns("some-platform")
+		fakeSavedWorker.TagsReturns([]string{"some-tag"})
+		fakeSavedWorker.TeamIDReturns(42)
+		fakeDBWorkerFactory.GetWorkerReturns(fakeSavedWorker, true, nil)
+
+		fakeDBWorkerTaskCacheFactory.FindVolumesOnWorkerReturns([]db.WorkerTaskCacheVolume{
+			{
+				WorkerTaskCache: db.WorkerTaskCache{
+					JobID:      1,
+					StepName:   "some-step",
+					WorkerName: "retiring-worker",
+					Path:       "some-path",
+				},
+				TeamID:       42,
+				VolumeHandle: "source-handle",
+			},
+		}, nil)
+
+		fakeSourceVolume = new(workerfakes.FakeVolume)
+		fakeSourceVolume.StreamOutReturns(ioutil.NopCloser(bytes.NewBufferString("some-cache")), nil)
+
+		fakeSourceWorker = newWorker("retiring-worker", 0)
+		fakeSourceWorker.LookupVolumeReturns(fakeSourceVolume, true, nil)
+		fakeProvider.NewGardenWorkerReturns(fakeSourceWorker)
+
+		fakeDestinationVolume = new(workerfakes.FakeVolume)
+
+		fakeDestinationWorker = newWorker("destination-worker", 1)
+		fakeDestinationWorker.CreateVolumeForTaskCacheReturns(fakeDestinationVolume, nil)
+
+		fakeBusyWorker = newWorker("busy-worker", 5)
+
+		fakeProvider.RunningWorkersReturns([]Worker{
+			fakeBusyWorker,
+			fakeDestinationWorker,
+		}, nil)
+
+		fakeP2PStreamer.StreamP2PReturns(nil)
+	})
+
+	JustBeforeEach(func() {
+		replicator = NewTaskCacheReplicator(
+			fakeProvider,
+			fakeDBWorkerFactory,
+			fakeDBWorkerTaskCacheFactory,
+			fakeP2PStreamer,
+			fakeclock.NewFakeClock(time.Unix(123, 0)),
+		)
+
+		replicateErr = replicator.ReplicateTaskCaches(lagertest.NewTestLogger("test"), "retiring-worker")
+	})
+
+	It("looks for a worker which could have run the same steps", func() {
+		Expect(replicateErr).NotTo(HaveOccurred())
+
+		_, spec, _ := fakeDestinationWorker.SatisfyingArgsForCall(0)
+		Expect(spec).To(Equal(WorkerSpec{
+			Platform: "some-platform",
+			Tags:     []string{"some-tag"},
+			TeamID:   42,
+		}))
+	})
+
+	It("creates the cache on the compatible worker with the fewest containers", func() {
+		Expect(replicateErr).NotTo(HaveOccurred())
+
+		Expect(fakeBusyWorker.CreateVolumeForTaskCacheCallCount()).To(BeZero())
+		Expect(fakeDestinationWorker.CreateVolumeForTaskCacheCallCount()).To(Equal(1))
+
+		_, spec, teamID, jobID, stepName, path := fakeDestinationWorker.CreateVolumeForTaskCacheArgsForCall(0)
+		Expect(spec.Strategy).To(Equal(baggageclaim.EmptyStrategy{}))
+		Expect(teamID).To(Equal(42))
+		Expect(jobID).To(Equal(1))
+		Expect(stepName).To(Equal("some-step"))
+		Expect(path).To(Equal("some-path"))
+	})
+
+	It("streams the cache directly between the workers and initializes it", func() {
+		Expect(replicateErr).NotTo(HaveOccurred())
+
+		_, source, sourcePath, destination, destinationPath := fakeP2PStreamer.StreamP2PArgsForCall(0)
+		Expect(source).To(Equal(fakeSourceVolume))
+		Expect(sourcePath).To(Equal("."))
+		Expect(destination).To(Equal(fakeDestinationVolume))
+		Expect(destinationPath).To(Equal("."))
+
+		Expect(fakeDestinationVolume.InitializeTaskCacheCallCount()).To(Equal(1))
+		_, jobID, stepName, path, _ := fakeDestinationVolume.InitializeTaskCacheArgsForCall(0)
+		Expect(jobID).To(Equal(1))
+		Expect(stepName).To(Equal("some-step"))
+		Expect(path).To(Equal("some-path"))
+	})
+
+	Context("when the workers cannot stream directly", func() {
+		BeforeEach(func() {
+			fakeP2PStreamer.StreamP2PReturns(ErrP2PStreamingUnsupported)
+		})
+
+		It("streams the cache through the ATC", func() {
+			Expect(replicateErr).NotTo(HaveOccurred())
+
+			Expect(fakeSourceVolume.StreamOutCallCount()).To(Equal(1))
+			Expect(fakeDestinationVolume.StreamInCallCount()).To(Equal(1))
+
+			path, stream := fakeDestinationVolume.StreamInArgsForCall(0)
+			Expect(path).To(Equal("."))
+			Expect(ioutil.ReadAll(stream)).To(Equal([]byte("some-cache")))
+		})
+	})
+
+	Context("when streaming fails", func() {
+		BeforeEach(func() {
+			fakeP2PStreamer.StreamP2PReturns(errors.New("disaster"))
+		})
+
+		It("destroys the partial copy rather than initializing it", func() {
+			Expect(replicateErr).NotTo(HaveOccurred())
+
+			Expect(fakeDestinationVolume.DestroyCallCount()).To(Equal(1))
+			Expect(fakeDestinationVolume.InitializeTaskCacheCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the destination already has the cache", func() {
+		BeforeEach(func() {
+			fakeDestinationWorker.FindVolumeForTaskCacheReturns(new(workerfakes.FakeVolume), true, nil)
+		})
+
+		It("leaves it alone", func() {
+			Expect(replicateErr).NotTo(HaveOccurred())
+			Expect(fakeDestinationWorker.CreateVolumeForTaskCacheCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when no other worker is compatible", func() {
+		BeforeEach(func() {
+			fakeDestinationWorker.SatisfyingReturns(nil, ErrIncompatiblePlatform)
+			fakeBusyWorker.SatisfyingReturns(nil, ErrMismatchedTags)
+		})
+
+		It("gives up on the caches", func() {
+			Expect(replicateErr).NotTo(HaveOccurred())
+			Expect(fakeDestinationWorker.CreateVolumeForTaskCacheCallCount()).To(BeZero())
+			Expect(fakeBusyWorker.CreateVolumeForTaskCacheCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the worker has no task caches", func() {
+		BeforeEach(func() {
+			fakeDBWorkerTaskCacheFactory.FindVolumesOnWorkerReturns(nil, nil)
+		})
+
+		It("does not look for another worker", func() {
+			Expect(replicateErr).NotTo(HaveOccurred())
+			Expect(fakeProvider.RunningWorkersCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when finding the task caches fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakeDBWorkerTaskCacheFactory.FindVolumesOnWorkerReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(replicateErr).To(Equal(disaster))
+		})
+	})
+})
@@ -0,0 +1,241 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// ErrStreamNotResumable is returned when a stream out of a worker breaks and
+// the worker cannot serve the rest of the very same stream, either because it
+// did not identify the stream with an ETag or because it answered the resume
+// with anything but the matching range. The stream is failed rather than
+// spliced together with a regenerated one, which may differ.
+var ErrStreamNotResumable = errors.New("stream out of worker cannot be resumed")
+
+// resumableStream reads a stream out of a worker as it was sent over the
+// wire, before it is decoded. If the configured chunk size is set, the stream
+// is requested a chunk at a time. If the stream breaks, the rest of it is
+// requested from the last byte read, up to the configured number of retries.
+//
+// Each request after the first asks for a byte range, only if the stream
+// still has the ETag it was first sent with (If-Range), and the response is
+// only used if it is exactly that range of the same stream.
+type resumableStream struct {
+	logger lager.Logger
+	config StreamConfig
+
+	request func(offset int64, etag string) (*http.Response, error)
+
+	etag     string
+	encoding string
+
+	body   io.ReadCloser
+	offset int64
+	end    int64
+	total  int64
+	tries  int
+}
+
+// newResumableStream continues from the worker's first response to the
+// stream, which must have been successful.
+func newResumableStream(
+	logger lager.Logger,
+	config StreamConfig,
+	response *http.Response,
+	request func(offset int64, etag string) (*http.Response, error),
+) (*resumableStream, error) {
+	stream := &resumableStream{
+		logger:   logger,
+		config:   config,
+		request:  request,
+		etag:     response.Header.Get("ETag"),
+		encoding: response.Header.Get("Content-Encoding"),
+		end:      -1,
+		total:    -1,
+	}
+
+	err := stream.continueWith(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+func (stream *resumableStream) Read(p []byte) (int, error) {
+	for {
+		if stream.body == nil {
+			return 0, io.EOF
+		}
+
+		n, err := stream.body.Read(p)
+		stream.offset += int64(n)
+
+		if err == nil {
+			return n, nil
+		}
+
+		if err == io.EOF {
+			if stream.end < 0 || stream.offset == stream.end {
+				nextErr := stream.nextChunk()
+				if nextErr != nil {
+					return n, nextErr
+				}
+
+				if n > 0 {
+					return n, nil
+				}
+
+				continue
+			}
+
+			err = io.ErrUnexpectedEOF
+		}
+
+		resumeErr := stream.resume(err)
+		if resumeErr != nil {
+			return n, resumeErr
+		}
+
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+func (stream *resumableStream) Close() error {
+	if stream.body == nil {
+		return nil
+	}
+
+	return stream.body.Close()
+}
+
+// nextChunk requests the chunk following the one just read in full, if any.
+func (stream *resumableStream) nextChunk() error {
+	stream.body.Close()
+	stream.body = nil
+
+	if stream.end < 0 || stream.offset == stream.total {
+		return io.EOF
+	}
+
+	response, err := stream.request(stream.offset, stream.etag)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode == http.StatusRequestedRangeNotSatisfiable && stream.total < 0 {
+		response.Body.Close()
+		return io.EOF
+	}
+
+	return stream.continueWith(response)
+}
+
+// resume requests the rest of a broken stream, waiting the configured retry
+// interval first, and gives up once out of retries or if the worker cannot
+// serve the rest of the same stream.
+func (stream *resumableStream) resume(cause error) error {
+	stream.body.Close()
+	stream.body = nil
+
+	if stream.etag == "" || stream.tries >= stream.config.Retries {
+		return cause
+	}
+
+	stream.tries++
+
+	stream.logger.Info("resuming", lager.Data{
+		"offset":  stream.offset,
+		"attempt": stream.tries,
+		"error":   cause.Error(),
+	})
+
+	time.Sleep(stream.config.RetryInterval)
+
+	response, err := stream.request(stream.offset, stream.etag)
+	if err != nil {
+		stream.logger.Error("failed-to-resume", err)
+		return cause
+	}
+
+	err = stream.continueWith(response)
+	if err != nil {
+		stream.logger.Error("failed-to-resume", err)
+		return err
+	}
+
+	return nil
+}
+
+// continueWith reads on from the response, as long as it is the range of the
+// stream starting at the current offset.
+func (stream *resumableStream) continueWith(response *http.Response) error {
+	switch {
+	case response.StatusCode == http.StatusOK && stream.offset == 0:
+		stream.end = -1
+
+	case response.StatusCode == http.StatusPartialContent:
+		start, end, total, err := parseContentRange(response.Header.Get("Content-Range"))
+		if err != nil ||
+			start != stream.offset ||
+			stream.etag == "" ||
+			response.Header.Get("ETag") != stream.etag ||
+			response.Header.Get("Content-Encoding") != stream.encoding {
+			response.Body.Close()
+			return ErrStreamNotResumable
+		}
+
+		stream.end = end + 1
+		stream.total = total
+
+	default:
+		response.Body.Close()
+
+		if stream.offset == 0 {
+			return unexpectedStatusError{response.StatusCode}
+		}
+
+		return ErrStreamNotResumable
+	}
+
+	stream.body = response.Body
+
+	return nil
+}
+
+// parseContentRange parses a Content-Range header of the form
+// "bytes start-end/total", where the total may be "*" if it is not known, in
+// which case it is returned as -1.
+func parseContentRange(header string) (int64, int64, int64, error) {
+	var start, end int64
+	var total string
+
+	_, err := fmt.Sscanf(header, "bytes %d-%d/%s", &start, &end, &total)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	if end < start {
+		return 0, 0, 0, fmt.Errorf("invalid content range: %s", header)
+	}
+
+	if strings.TrimSpace(total) == "*" {
+		return start, end, -1, nil
+	}
+
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return start, end, size, nil
+}
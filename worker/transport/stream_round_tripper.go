@@ -0,0 +1,461 @@
+package transport
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+)
+
+const (
+	EncodingGzip = "gzip"
+
+	encodingIdentity = "identity"
+)
+
+// StreamConfig configures how volumes are streamed to and from a worker's
+// baggageclaim.
+type StreamConfig struct {
+	// Encodings are the content encodings to negotiate for each stream, most
+	// preferred first. Streams are sent as-is if none are configured, or if
+	// the worker does not support any of them.
+	Encodings []string
+
+	// BandwidthLimit caps each stream to the number of bytes per second sent
+	// over the wire. Zero means unlimited.
+	BandwidthLimit int64
+
+	// ChunkSize is the most bytes of a stream out of a worker requested at
+	// a time, as a byte range. Zero means the whole stream is requested at
+	// once.
+	ChunkSize int64
+
+	// Retries is the number of times a broken stream out of a worker is
+	// resumed from the last byte read before giving up, waiting
+	// RetryInterval before each attempt. A stream is only resumed if the
+	// worker identifies it with an ETag and serves byte ranges of it.
+	// Streams directly between workers are restarted instead, as the ATC
+	// does not see how far they got.
+	Retries       int
+	RetryInterval time.Duration
+}
+
+// AcceptedEncodings remembers the content encodings each worker is known to
+// decode, so that streams sent to the worker are only compressed with them.
+// A worker is known to decode an encoding once it has sent a stream out
+// encoded with it, or advertised it in the Accept-Encoding header of a
+// response (RFC 7694). An encoding is forgotten again if the worker rejects a
+// stream sent with it as an unsupported media type.
+type AcceptedEncodings struct {
+	encodingsL sync.Mutex
+	encodings  map[string][]string
+}
+
+func NewAcceptedEncodings() *AcceptedEncodings {
+	return &AcceptedEncodings{
+		encodings: map[string][]string{},
+	}
+}
+
+func (accepted *AcceptedEncodings) record(workerName string, header string) {
+	if header == "" {
+		return
+	}
+
+	encodings := []string{}
+	for _, encoding := range strings.Split(header, ",") {
+		encodings = append(encodings, strings.TrimSpace(strings.SplitN(encoding, ";", 2)[0]))
+	}
+
+	accepted.encodingsL.Lock()
+	accepted.encodings[workerName] = encodings
+	accepted.encodingsL.Unlock()
+}
+
+func (accepted *AcceptedEncodings) accept(workerName string, encoding string) {
+	accepted.encodingsL.Lock()
+	defer accepted.encodingsL.Unlock()
+
+	for _, supported := range accepted.encodings[workerName] {
+		if supported == encoding {
+			return
+		}
+	}
+
+	accepted.encodings[workerName] = append(accepted.encodings[workerName], encoding)
+}
+
+func (accepted *AcceptedEncodings) reject(workerName string, encoding string) {
+	accepted.encodingsL.Lock()
+	defer accepted.encodingsL.Unlock()
+
+	encodings := []string{}
+	for _, supported := range accepted.encodings[workerName] {
+		if supported != encoding {
+			encodings = append(encodings, supported)
+		}
+	}
+
+	accepted.encodings[workerName] = encodings
+}
+
+func (accepted *AcceptedEncodings) preferred(workerName string, encodings []string) string {
+	accepted.encodingsL.Lock()
+	defer accepted.encodingsL.Unlock()
+
+	for _, encoding := range encodings {
+		for _, supported := range accepted.encodings[workerName] {
+			if encoding == supported {
+				return encoding
+			}
+		}
+	}
+
+	return encodingIdentity
+}
+
+type streamRoundTripper struct {
+	logger            lager.Logger
+	workerName        string
+	config            StreamConfig
+	acceptedEncodings *AcceptedEncodings
+	innerRoundTripper http.RoundTripper
+}
+
+// NewStreamRoundTripper negotiates the encoding of, caps the bandwidth of,
+// and emits metrics for each volume streamed in to or out of the worker.
+// Any other request is passed through untouched.
+func NewStreamRoundTripper(
+	logger lager.Logger,
+	workerName string,
+	config StreamConfig,
+	acceptedEncodings *AcceptedEncodings,
+	innerRoundTripper http.RoundTripper,
+) http.RoundTripper {
+	return &streamRoundTripper{
+		logger:            logger,
+		workerName:        workerName,
+		config:            config,
+		acceptedEncodings: acceptedEncodings,
+		innerRoundTripper: innerRoundTripper,
+	}
+}
+
+func (c *streamRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	switch {
+	case strings.HasSuffix(request.URL.Path, "/stream-in") && request.Body != nil:
+		return c.streamIn(request)
+	case strings.HasSuffix(request.URL.Path, "/stream-out"):
+		return c.streamOut(request)
+	default:
+		return c.roundTrip(request)
+	}
+}
+
+func (c *streamRoundTripper) roundTrip(request *http.Request) (*http.Response, error) {
+	response, err := c.innerRoundTripper.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	c.acceptedEncodings.record(c.workerName, response.Header.Get("Accept-Encoding"))
+
+	return response, nil
+}
+
+func (c *streamRoundTripper) streamIn(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	encoding := c.acceptedEncodings.preferred(c.workerName, c.config.Encodings)
+
+	body := &countingReader{Reader: request.Body}
+
+	wire := &countingReader{Reader: body}
+	closer := multiCloser{request.Body}
+	if encoding != encodingIdentity {
+		encoded := encodeStream(encoding, body)
+		wire.Reader = encoded
+		closer = append(closer, encoded)
+	}
+
+	updatedRequest := *request
+	updatedRequest.Header = cloneHeader(request.Header)
+	updatedRequest.Body = struct {
+		io.Reader
+		io.Closer
+	}{throttle(wire, c.config.BandwidthLimit), closer}
+
+	if encoding != encodingIdentity {
+		updatedRequest.Header.Set("Content-Encoding", encoding)
+		updatedRequest.ContentLength = -1
+	}
+
+	response, err := c.roundTrip(&updatedRequest)
+	if err != nil {
+		// the inner round tripper may give up without closing the body, which
+		// would leave the encoder blocked on the pipe forever
+		closer.Close()
+	} else if encoding != encodingIdentity && response.StatusCode == http.StatusUnsupportedMediaType {
+		c.acceptedEncodings.reject(c.workerName, encoding)
+	}
+
+	metric.VolumeStreamed{
+		WorkerName: c.workerName,
+		Direction:  "in",
+		Encoding:   encoding,
+		Bytes:      body.count(),
+		WireBytes:  wire.count(),
+		Duration:   time.Since(start),
+	}.Emit(c.logger)
+
+	return response, err
+}
+
+func (c *streamRoundTripper) streamOut(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	response, err := c.requestStreamOut(request, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusPartialContent {
+		return response, nil
+	}
+
+	encoding := response.Header.Get("Content-Encoding")
+	if encoding == "" {
+		encoding = encodingIdentity
+	}
+
+	stream, err := newResumableStream(
+		c.logger.Session("resumable-stream", lager.Data{"worker": c.workerName, "path": request.URL.Path}),
+		c.config,
+		response,
+		func(offset int64, etag string) (*http.Response, error) {
+			return c.requestStreamOut(request, offset, etag)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	wire := &countingReader{Reader: throttle(stream, c.config.BandwidthLimit)}
+
+	var decoded io.Reader = wire
+	closer := multiCloser{stream}
+	if encoding != encodingIdentity {
+		decoder, err := decodeStream(encoding, wire)
+		if err != nil {
+			stream.Close()
+			return nil, err
+		}
+
+		decoded = decoder
+		closer = multiCloser{decoder, stream}
+
+		// a worker which encodes streams out can decode streams in
+		c.acceptedEncodings.accept(c.workerName, encoding)
+	}
+
+	body := &countingReader{Reader: decoded}
+
+	response.StatusCode = http.StatusOK
+	response.Status = http.StatusText(http.StatusOK)
+	response.Header.Del("Content-Encoding")
+	response.Header.Del("Content-Range")
+	response.ContentLength = -1
+	response.Body = &meteredBody{
+		Reader: body,
+		closer: closer,
+		emit: func() {
+			metric.VolumeStreamed{
+				WorkerName: c.workerName,
+				Direction:  "out",
+				Encoding:   encoding,
+				Bytes:      body.count(),
+				WireBytes:  wire.count(),
+				Duration:   time.Since(start),
+			}.Emit(c.logger)
+		},
+	}
+
+	return response, nil
+}
+
+// requestStreamOut asks the worker for the stream from the offset onwards,
+// or for just the next chunk of it if the chunk size is configured. Ranges
+// are of the stream as sent over the wire, so that an encoded stream is
+// resumed without being encoded again. Once the worker has identified the
+// stream with an ETag, a range is only served from that same stream.
+func (c *streamRoundTripper) requestStreamOut(request *http.Request, offset int64, etag string) (*http.Response, error) {
+	updatedRequest := *request
+	updatedRequest.Header = cloneHeader(request.Header)
+
+	if len(c.config.Encodings) > 0 {
+		updatedRequest.Header.Set("Accept-Encoding", strings.Join(c.config.Encodings, ", "))
+	}
+
+	if c.config.ChunkSize > 0 {
+		updatedRequest.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+c.config.ChunkSize-1))
+	} else if offset > 0 {
+		updatedRequest.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	if etag != "" {
+		updatedRequest.Header.Set("If-Range", etag)
+	}
+
+	return c.roundTrip(&updatedRequest)
+}
+
+type unexpectedStatusError struct {
+	status int
+}
+
+func (err unexpectedStatusError) Error() string {
+	return fmt.Sprintf("unexpected response status: %d", err.status)
+}
+
+type unsupportedEncodingError struct {
+	encoding string
+}
+
+func (err unsupportedEncodingError) Error() string {
+	return "unsupported stream encoding: " + err.encoding
+}
+
+func encodeStream(encoding string, src io.Reader) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		var encoder io.WriteCloser
+		switch encoding {
+		case EncodingGzip:
+			encoder = gzip.NewWriter(pw)
+		default:
+			pw.CloseWithError(unsupportedEncodingError{encoding})
+			return
+		}
+
+		_, err := io.Copy(encoder, src)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		pw.CloseWithError(encoder.Close())
+	}()
+
+	return pr
+}
+
+func decodeStream(encoding string, src io.Reader) (io.ReadCloser, error) {
+	switch encoding {
+	case EncodingGzip:
+		return gzip.NewReader(src)
+	default:
+		return nil, unsupportedEncodingError{encoding}
+	}
+}
+
+func cloneHeader(header http.Header) http.Header {
+	clone := http.Header{}
+	for key, values := range header {
+		clone[key] = append([]string(nil), values...)
+	}
+
+	return clone
+}
+
+type countingReader struct {
+	io.Reader
+
+	n int64
+}
+
+func (reader *countingReader) Read(p []byte) (int, error) {
+	n, err := reader.Reader.Read(p)
+	atomic.AddInt64(&reader.n, int64(n))
+	return n, err
+}
+
+func (reader *countingReader) count() int64 {
+	return atomic.LoadInt64(&reader.n)
+}
+
+// multiCloser closes each of the closers in turn, returning the first error.
+type multiCloser []io.Closer
+
+func (closers multiCloser) Close() error {
+	var firstErr error
+	for _, closer := range closers {
+		err := closer.Close()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+type meteredBody struct {
+	io.Reader
+
+	closer io.Closer
+	emit   func()
+	once   sync.Once
+}
+
+func (body *meteredBody) Close() error {
+	body.once.Do(body.emit)
+	return body.closer.Close()
+}
+
+// throttle caps the rate at which bytes are read from the reader by sleeping
+// whenever it gets ahead of the limit.
+func throttle(reader io.Reader, bytesPerSecond int64) io.Reader {
+	if bytesPerSecond <= 0 {
+		return reader
+	}
+
+	return &throttledReader{
+		Reader: reader,
+		limit:  bytesPerSecond,
+	}
+}
+
+type throttledReader struct {
+	io.Reader
+
+	limit int64
+	start time.Time
+	read  int64
+}
+
+func (reader *throttledReader) Read(p []byte) (int, error) {
+	if reader.start.IsZero() {
+		reader.start = time.Now()
+	}
+
+	if int64(len(p)) > reader.limit {
+		p = p[:reader.limit]
+	}
+
+	n, err := reader.Reader.Read(p)
+	reader.read += int64(n)
+
+	allowed := time.Duration(float64(reader.read) / float64(reader.limit) * float64(time.Second))
+	if ahead := allowed - time.Since(reader.start); ahead > 0 {
+		time.Sleep(ahead)
+	}
+
+	return n, err
+}
@@ -0,0 +1,511 @@
+package transport_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/worker/transport"
+	"github.com/concourse/retryhttp/retryhttpfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StreamRoundTripper #RoundTrip", func() {
+	var (
+		fakeRoundTripper  *retryhttpfakes.FakeRoundTripper
+		acceptedEncodings *transport.AcceptedEncodings
+		config            transport.StreamConfig
+
+		roundTripper http.RoundTripper
+	)
+
+	newRequest := func(path string, body string) *http.Request {
+		requestURL, err := url.Parse(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		request := &http.Request{
+			Method: "GET",
+			URL:    requestURL,
+			Header: http.Header{},
+		}
+
+		if body != "" {
+			request.Method = "PUT"
+			request.Body = ioutil.NopCloser(strings.NewReader(body))
+			request.ContentLength = int64(len(body))
+		}
+
+		return request
+	}
+
+	gzipped := func(content string) []byte {
+		buf := new(bytes.Buffer)
+		writer := gzip.NewWriter(buf)
+		_, err := writer.Write([]byte(content))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(writer.Close()).To(Succeed())
+		return buf.Bytes()
+	}
+
+	BeforeEach(func() {
+		fakeRoundTripper = new(retryhttpfakes.FakeRoundTripper)
+		acceptedEncodings = transport.NewAcceptedEncodings()
+		config = transport.StreamConfig{
+			Encodings: []string{transport.EncodingGzip},
+		}
+
+		fakeRoundTripper.RoundTripReturns(&http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		roundTripper = transport.NewStreamRoundTripper(
+			lagertest.NewTestLogger("test"),
+			"some-worker",
+			config,
+			acceptedEncodings,
+			fakeRoundTripper,
+		)
+	})
+
+	Context("when the request does not stream a volume", func() {
+		It("passes it through untouched", func() {
+			request := newRequest("/volumes", "")
+
+			_, err := roundTripper.RoundTrip(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeRoundTripper.RoundTripCallCount()).To(Equal(1))
+			Expect(fakeRoundTripper.RoundTripArgsForCall(0)).To(Equal(request))
+		})
+	})
+
+	Describe("streaming out", func() {
+		It("asks for the configured encodings", func() {
+			_, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+			Expect(err).NotTo(HaveOccurred())
+
+			actualRequest := fakeRoundTripper.RoundTripArgsForCall(0)
+			Expect(actualRequest.Header.Get("Accept-Encoding")).To(Equal("gzip"))
+		})
+
+		Context("when the worker responds with an encoded stream", func() {
+			BeforeEach(func() {
+				fakeRoundTripper.RoundTripReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Encoding": {"gzip"}},
+					Body:       ioutil.NopCloser(bytes.NewReader(gzipped("some-stream"))),
+				}, nil)
+			})
+
+			It("decodes it", func() {
+				response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+				Expect(err).NotTo(HaveOccurred())
+
+				defer response.Body.Close()
+
+				Expect(response.Header.Get("Content-Encoding")).To(BeEmpty())
+				Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("some-stream")))
+			})
+
+			It("sends streams in to the worker encoded the same way", func() {
+				response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Body.Close()).To(Succeed())
+
+				fakeRoundTripper.RoundTripStub = func(request *http.Request) (*http.Response, error) {
+					_, err := ioutil.ReadAll(request.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					return &http.Response{
+						StatusCode: http.StatusNoContent,
+						Header:     http.Header{},
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}, nil
+				}
+
+				_, err = roundTripper.RoundTrip(newRequest("/volumes/other-handle/stream-in", "some-stream"))
+				Expect(err).NotTo(HaveOccurred())
+
+				actualRequest := fakeRoundTripper.RoundTripArgsForCall(1)
+				Expect(actualRequest.Header.Get("Content-Encoding")).To(Equal("gzip"))
+			})
+		})
+
+		Context("when the worker responds with the stream as-is", func() {
+			BeforeEach(func() {
+				fakeRoundTripper.RoundTripReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       ioutil.NopCloser(strings.NewReader("some-stream")),
+				}, nil)
+			})
+
+			It("returns it as-is", func() {
+				response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+				Expect(err).NotTo(HaveOccurred())
+
+				defer response.Body.Close()
+
+				Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("some-stream")))
+			})
+		})
+
+		Context("when the bandwidth is limited", func() {
+			BeforeEach(func() {
+				config.BandwidthLimit = 20
+
+				fakeRoundTripper.RoundTripReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       ioutil.NopCloser(strings.NewReader("some-stream")),
+				}, nil)
+			})
+
+			It("streams no faster than the limit", func() {
+				start := time.Now()
+
+				response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+				Expect(err).NotTo(HaveOccurred())
+
+				defer response.Body.Close()
+
+				Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("some-stream")))
+				Expect(time.Since(start)).To(BeNumerically(">=", 500*time.Millisecond))
+			})
+		})
+
+		Context("when the stream breaks", func() {
+			var resumedResponse *http.Response
+
+			BeforeEach(func() {
+				config = transport.StreamConfig{
+					Retries: 1,
+				}
+
+				resumedResponse = &http.Response{
+					StatusCode: http.StatusPartialContent,
+					Header: http.Header{
+						"Etag":          {`"some-etag"`},
+						"Content-Range": {"bytes 7-10/11"},
+					},
+					Body: ioutil.NopCloser(strings.NewReader("ream")),
+				}
+
+				fakeRoundTripper.RoundTripStub = func(request *http.Request) (*http.Response, error) {
+					if fakeRoundTripper.RoundTripCallCount() == 1 {
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Header:     http.Header{"Etag": {`"some-etag"`}},
+							Body:       ioutil.NopCloser(io.MultiReader(strings.NewReader("some-st"), brokenReader{})),
+						}, nil
+					}
+
+					return resumedResponse, nil
+				}
+			})
+
+			It("resumes it after the bytes already read", func() {
+				response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+				Expect(err).NotTo(HaveOccurred())
+
+				defer response.Body.Close()
+
+				Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("some-stream")))
+				Expect(fakeRoundTripper.RoundTripCallCount()).To(Equal(2))
+			})
+
+			It("asks the worker for the rest of the same stream", func() {
+				response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+				Expect(err).NotTo(HaveOccurred())
+
+				defer response.Body.Close()
+
+				_, err = ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				resumeRequest := fakeRoundTripper.RoundTripArgsForCall(1)
+				Expect(resumeRequest.Header.Get("Range")).To(Equal("bytes=7-"))
+				Expect(resumeRequest.Header.Get("If-Range")).To(Equal(`"some-etag"`))
+			})
+
+			Context("when the worker sends the whole stream again", func() {
+				BeforeEach(func() {
+					resumedResponse = &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Etag": {`"other-etag"`}},
+						Body:       ioutil.NopCloser(strings.NewReader("some-other-stream")),
+					}
+				})
+
+				It("fails rather than splicing it in", func() {
+					response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+					Expect(err).NotTo(HaveOccurred())
+
+					defer response.Body.Close()
+
+					_, err = ioutil.ReadAll(response.Body)
+					Expect(err).To(Equal(transport.ErrStreamNotResumable))
+				})
+			})
+
+			Context("when the worker sends a range of a different stream", func() {
+				BeforeEach(func() {
+					resumedResponse.Header.Set("Etag", `"other-etag"`)
+				})
+
+				It("fails rather than splicing it in", func() {
+					response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+					Expect(err).NotTo(HaveOccurred())
+
+					defer response.Body.Close()
+
+					_, err = ioutil.ReadAll(response.Body)
+					Expect(err).To(Equal(transport.ErrStreamNotResumable))
+				})
+			})
+
+			Context("when the worker did not identify the stream", func() {
+				BeforeEach(func() {
+					fakeRoundTripper.RoundTripStub = nil
+					fakeRoundTripper.RoundTripReturns(&http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{},
+						Body:       ioutil.NopCloser(io.MultiReader(strings.NewReader("some-st"), brokenReader{})),
+					}, nil)
+				})
+
+				It("returns the error without resuming", func() {
+					response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+					Expect(err).NotTo(HaveOccurred())
+
+					defer response.Body.Close()
+
+					_, err = ioutil.ReadAll(response.Body)
+					Expect(err).To(Equal(errBrokenStream))
+					Expect(fakeRoundTripper.RoundTripCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when it is out of retries", func() {
+				BeforeEach(func() {
+					config.Retries = 0
+				})
+
+				It("returns the error", func() {
+					response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+					Expect(err).NotTo(HaveOccurred())
+
+					defer response.Body.Close()
+
+					_, err = ioutil.ReadAll(response.Body)
+					Expect(err).To(Equal(errBrokenStream))
+				})
+			})
+		})
+
+		Context("when the chunk size is configured", func() {
+			BeforeEach(func() {
+				config = transport.StreamConfig{
+					ChunkSize: 7,
+				}
+
+				fakeRoundTripper.RoundTripStub = func(request *http.Request) (*http.Response, error) {
+					switch request.Header.Get("Range") {
+					case "bytes=0-6":
+						return &http.Response{
+							StatusCode: http.StatusPartialContent,
+							Header: http.Header{
+								"Etag":          {`"some-etag"`},
+								"Content-Range": {"bytes 0-6/*"},
+							},
+							Body: ioutil.NopCloser(strings.NewReader("some-st")),
+						}, nil
+					case "bytes=7-13":
+						return &http.Response{
+							StatusCode: http.StatusPartialContent,
+							Header: http.Header{
+								"Etag":          {`"some-etag"`},
+								"Content-Range": {"bytes 7-10/*"},
+							},
+							Body: ioutil.NopCloser(strings.NewReader("ream")),
+						}, nil
+					default:
+						return &http.Response{
+							StatusCode: http.StatusRequestedRangeNotSatisfiable,
+							Header:     http.Header{},
+							Body:       ioutil.NopCloser(strings.NewReader("")),
+						}, nil
+					}
+				}
+			})
+
+			It("requests the stream a chunk at a time", func() {
+				response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+				Expect(err).NotTo(HaveOccurred())
+
+				defer response.Body.Close()
+
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("some-stream")))
+				Expect(fakeRoundTripper.RoundTripCallCount()).To(Equal(3))
+				Expect(fakeRoundTripper.RoundTripArgsForCall(1).Header.Get("If-Range")).To(Equal(`"some-etag"`))
+			})
+		})
+
+		Context("when the worker does not serve ranges", func() {
+			BeforeEach(func() {
+				config = transport.StreamConfig{
+					ChunkSize: 4,
+				}
+
+				fakeRoundTripper.RoundTripReturns(&http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{},
+					Body:       ioutil.NopCloser(strings.NewReader("some-stream")),
+				}, nil)
+			})
+
+			It("reads the whole stream at once", func() {
+				response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-out", ""))
+				Expect(err).NotTo(HaveOccurred())
+
+				defer response.Body.Close()
+
+				Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("some-stream")))
+				Expect(fakeRoundTripper.RoundTripCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("streaming in", func() {
+		var sentBody []byte
+
+		BeforeEach(func() {
+			sentBody = nil
+
+			fakeRoundTripper.RoundTripStub = func(request *http.Request) (*http.Response, error) {
+				var err error
+				sentBody, err = ioutil.ReadAll(request.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				return &http.Response{
+					StatusCode: http.StatusNoContent,
+					Header:     http.Header{"Accept-Encoding": {"zstd, gzip"}},
+					Body:       ioutil.NopCloser(strings.NewReader("")),
+				}, nil
+			}
+		})
+
+		Context("when the worker has not advertised any encodings", func() {
+			It("sends the stream as-is", func() {
+				_, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-in", "some-stream"))
+				Expect(err).NotTo(HaveOccurred())
+
+				actualRequest := fakeRoundTripper.RoundTripArgsForCall(0)
+				Expect(actualRequest.Header.Get("Content-Encoding")).To(BeEmpty())
+				Expect(sentBody).To(Equal([]byte("some-stream")))
+			})
+		})
+
+		Context("when the worker has advertised a configured encoding", func() {
+			JustBeforeEach(func() {
+				_, err := roundTripper.RoundTrip(newRequest("/volumes", ""))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("sends the stream encoded with it", func() {
+				_, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-in", "some-stream"))
+				Expect(err).NotTo(HaveOccurred())
+
+				actualRequest := fakeRoundTripper.RoundTripArgsForCall(1)
+				Expect(actualRequest.Header.Get("Content-Encoding")).To(Equal("gzip"))
+				Expect(actualRequest.ContentLength).To(Equal(int64(-1)))
+
+				reader, err := gzip.NewReader(bytes.NewReader(sentBody))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.ReadAll(reader)).To(Equal([]byte("some-stream")))
+			})
+
+			Context("when the worker rejects the encoded stream", func() {
+				It("sends the next stream as-is", func() {
+					fakeRoundTripper.RoundTripStub = func(request *http.Request) (*http.Response, error) {
+						var err error
+						sentBody, err = ioutil.ReadAll(request.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						status := http.StatusNoContent
+						if request.Header.Get("Content-Encoding") != "" {
+							status = http.StatusUnsupportedMediaType
+						}
+
+						return &http.Response{
+							StatusCode: status,
+							Header:     http.Header{},
+							Body:       ioutil.NopCloser(strings.NewReader("")),
+						}, nil
+					}
+
+					response, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-in", "some-stream"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(response.StatusCode).To(Equal(http.StatusUnsupportedMediaType))
+
+					response, err = roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-in", "some-stream"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					actualRequest := fakeRoundTripper.RoundTripArgsForCall(2)
+					Expect(actualRequest.Header.Get("Content-Encoding")).To(BeEmpty())
+					Expect(sentBody).To(Equal([]byte("some-stream")))
+				})
+			})
+
+			Context("when the request fails without reading the stream", func() {
+				var sentRequest *http.Request
+
+				BeforeEach(func() {
+					fakeRoundTripper.RoundTripStub = func(request *http.Request) (*http.Response, error) {
+						if request.URL.Path == "/volumes" {
+							return &http.Response{
+								StatusCode: http.StatusOK,
+								Header:     http.Header{"Accept-Encoding": {"gzip"}},
+								Body:       ioutil.NopCloser(strings.NewReader("")),
+							}, nil
+						}
+
+						sentRequest = request
+						return nil, errors.New("nope")
+					}
+				})
+
+				It("closes the encoded stream", func() {
+					_, err := roundTripper.RoundTrip(newRequest("/volumes/some-handle/stream-in", "some-stream"))
+					Expect(err).To(MatchError("nope"))
+
+					_, err = sentRequest.Body.Read(make([]byte, 1))
+					Expect(err).To(Equal(io.ErrClosedPipe))
+				})
+			})
+		})
+	})
+})
+
+var errBrokenStream = errors.New("broken stream")
+
+type brokenReader struct{}
+
+func (brokenReader) Read([]byte) (int, error) {
+	return 0, errBrokenStream
+}
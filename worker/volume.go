@@ -14,6 +14,7 @@ import (
 type Volume interface {
 	Handle() string
 	Path() string
+	WorkerName() string
 
 	SetProperty(key string, value string) error
 	Properties() (baggageclaim.VolumeProperties, error)
@@ -26,6 +27,8 @@ type Volume interface {
 	COWStrategy() baggageclaim.COWStrategy
 
 	InitializeResourceCache(*db.UsedResourceCache) error
+	InitializeResourceCacheReplica() error
+	RecordFetch() error
 	InitializeTaskCache(lager.Logger, int, string, string, bool) error
 
 	CreateChildForContainer(db.CreatingContainer, string) (db.CreatingVolume, error)
@@ -42,6 +45,8 @@ type volume struct {
 	bcVolume     baggageclaim.Volume
 	dbVolume     db.CreatedVolume
 	volumeClient VolumeClient
+
+	globalResourceCaches bool
 }
 
 func NewVolume(
@@ -60,6 +65,8 @@ func (v *volume) Handle() string { return v.bcVolume.Handle() }
 
 func (v *volume) Path() string { return v.bcVolume.Path() }
 
+func (v *volume) WorkerName() string { return v.dbVolume.WorkerName() }
+
 func (v *volume) SetProperty(key string, value string) error {
 	return v.bcVolume.SetProperty(key, value)
 }
@@ -90,8 +97,24 @@ func (v *volume) COWStrategy() baggageclaim.COWStrategy {
 	}
 }
 
+// InitializeResourceCache makes the volume the resource cache for the team
+// the volume was created for, or for every team if resource caches are
+// global.
 func (v *volume) InitializeResourceCache(urc *db.UsedResourceCache) error {
-	return v.dbVolume.InitializeResourceCache(urc)
+	teamID := v.dbVolume.TeamID()
+	if v.globalResourceCaches {
+		teamID = 0
+	}
+
+	return v.dbVolume.InitializeResourceCache(urc, teamID)
+}
+
+func (v *volume) InitializeResourceCacheReplica() error {
+	return v.dbVolume.InitializeResourceCacheReplica()
+}
+
+func (v *volume) RecordFetch() error {
+	return v.dbVolume.RecordFetch()
 }
 
 func (v *volume) InitializeTaskCache(
@@ -40,8 +40,9 @@ type VolumeClient interface {
 		string,
 	) (Volume, error)
 	FindVolumeForResourceCache(
-		lager.Logger,
-		*db.UsedResourceCache,
+		logger lager.Logger,
+		teamID int,
+		resourceCache *db.UsedResourceCache,
 	) (Volume, bool, error)
 	FindVolumeForTaskCache(
 		logger lager.Logger,
@@ -58,6 +59,11 @@ type VolumeClient interface {
 		stepName string,
 		path string,
 	) (Volume, error)
+	CreateVolumeForResourceCacheReplica(
+		logger lager.Logger,
+		volumeSpec VolumeSpec,
+		source db.HotResourceCacheVolume,
+	) (Volume, error)
 	FindOrCreateVolumeForResourceCerts(
 		logger lager.Logger,
 	) (volume Volume, found bool, err error)
@@ -85,6 +91,7 @@ type volumeClient struct {
 	dbWorkerTaskCacheFactory        db.WorkerTaskCacheFactory
 	clock                           clock.Clock
 	dbWorker                        db.Worker
+	globalResourceCaches            bool
 }
 
 func NewVolumeClient(
@@ -96,6 +103,7 @@ func NewVolumeClient(
 	dbVolumeFactory db.VolumeFactory,
 	dbWorkerBaseResourceTypeFactory db.WorkerBaseResourceTypeFactory,
 	dbWorkerTaskCacheFactory db.WorkerTaskCacheFactory,
+	globalResourceCaches bool,
 ) VolumeClient {
 	return &volumeClient{
 		baggageclaimClient:              baggageclaimClient,
@@ -103,8 +111,20 @@ func NewVolumeClient(
 		dbVolumeFactory:                 dbVolumeFactory,
 		dbWorkerBaseResourceTypeFactory: dbWorkerBaseResourceTypeFactory,
 		dbWorkerTaskCacheFactory:        dbWorkerTaskCacheFactory,
-		clock:    clock,
-		dbWorker: dbWorker,
+		clock:                           clock,
+		dbWorker:                        dbWorker,
+		globalResourceCaches:            globalResourceCaches,
+	}
+}
+
+// newVolume constructs a Volume which initializes resource caches with the
+// same scope the client finds them with.
+func (c *volumeClient) newVolume(bcVolume baggageclaim.Volume, dbVolume db.CreatedVolume) Volume {
+	return &volume{
+		bcVolume:             bcVolume,
+		dbVolume:             dbVolume,
+		volumeClient:         c,
+		globalResourceCaches: c.globalResourceCaches,
 	}
 }
 
@@ -174,16 +194,33 @@ func (c *volumeClient) FindOrCreateVolumeForBaseResourceType(
 	)
 }
 
+// FindVolumeForResourceCache finds the team's volume for the resource cache,
+// or the one shared across teams if resource caches are global. If the team
+// has no volume for it, the one shared across teams is used, which is where
+// caches initialized before they were scoped to teams are.
 func (c *volumeClient) FindVolumeForResourceCache(
 	logger lager.Logger,
+	teamID int,
 	usedResourceCache *db.UsedResourceCache,
 ) (Volume, bool, error) {
-	dbVolume, found, err := c.dbVolumeFactory.FindResourceCacheVolume(c.dbWorker.Name(), usedResourceCache)
+	if c.globalResourceCaches {
+		teamID = 0
+	}
+
+	dbVolume, found, err := c.dbVolumeFactory.FindResourceCacheVolume(c.dbWorker.Name(), teamID, usedResourceCache)
 	if err != nil {
 		logger.Error("failed-to-lookup-resource-cache-volume-in-db", err)
 		return nil, false, err
 	}
 
+	if !found && teamID != 0 {
+		dbVolume, found, err = c.dbVolumeFactory.FindResourceCacheVolume(c.dbWorker.Name(), 0, usedResourceCache)
+		if err != nil {
+			logger.Error("failed-to-lookup-shared-resource-cache-volume-in-db", err)
+			return nil, false, err
+		}
+	}
+
 	if !found {
 		return nil, false, nil
 	}
@@ -198,7 +235,7 @@ func (c *volumeClient) FindVolumeForResourceCache(
 		return nil, false, nil
 	}
 
-	return NewVolume(bcVolume, dbVolume, c), true, nil
+	return c.newVolume(bcVolume, dbVolume), true, nil
 }
 
 func (c *volumeClient) CreateVolumeForTaskCache(
@@ -227,6 +264,23 @@ func (c *volumeClient) CreateVolumeForTaskCache(
 	)
 }
 
+func (c *volumeClient) CreateVolumeForResourceCacheReplica(
+	logger lager.Logger,
+	volumeSpec VolumeSpec,
+	source db.HotResourceCacheVolume,
+) (Volume, error) {
+	return c.findOrCreateVolume(
+		logger.Session("create-volume-for-resource-cache-replica"),
+		volumeSpec,
+		func() (db.CreatingVolume, db.CreatedVolume, error) {
+			return nil, nil, nil
+		},
+		func() (db.CreatingVolume, error) {
+			return c.dbVolumeFactory.CreateResourceCacheReplicaVolume(c.dbWorker.Name(), source)
+		},
+	)
+}
+
 func (c *volumeClient) FindOrCreateVolumeForResourceCerts(logger lager.Logger) (Volume, bool, error) {
 
 	logger.Debug("finding-worker-resource-certs")
@@ -303,7 +357,7 @@ func (c *volumeClient) FindVolumeForTaskCache(
 		return nil, false, nil
 	}
 
-	return NewVolume(bcVolume, dbVolume, c), true, nil
+	return c.newVolume(bcVolume, dbVolume), true, nil
 }
 
 func (c *volumeClient) LookupVolume(logger lager.Logger, handle string) (Volume, bool, error) {
@@ -327,7 +381,7 @@ func (c *volumeClient) LookupVolume(logger lager.Logger, handle string) (Volume,
 		return nil, false, nil
 	}
 
-	return NewVolume(bcVolume, dbVolume, c), true, nil
+	return c.newVolume(bcVolume, dbVolume), true, nil
 }
 
 func (c *volumeClient) findOrCreateVolume(
@@ -361,7 +415,7 @@ func (c *volumeClient) findOrCreateVolume(
 
 		logger.Debug("found-created-volume")
 
-		return NewVolume(bcVolume, createdVolume, c), nil
+		return c.newVolume(bcVolume, createdVolume), nil
 	}
 
 	if creatingVolume != nil {
@@ -435,5 +489,5 @@ func (c *volumeClient) findOrCreateVolume(
 
 	logger.Debug("created")
 
-	return NewVolume(bcVolume, createdVolume, c), nil
+	return c.newVolume(bcVolume, createdVolume), nil
 }
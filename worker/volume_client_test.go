@@ -60,6 +60,7 @@ var _ = Describe("VolumeClient", func() {
 			fakeDBVolumeFactory,
 			fakeWorkerBaseResourceTypeFactory,
 			fakeWorkerTaskCacheFactory,
+			false,
 		)
 	})
 
@@ -634,6 +635,125 @@ var _ = Describe("VolumeClient", func() {
 		})
 	})
 
+	Describe("FindVolumeForResourceCache", func() {
+		var (
+			resourceCache *db.UsedResourceCache
+			dbVolume      *dbfakes.FakeCreatedVolume
+			bcVolume      *baggageclaimfakes.FakeVolume
+		)
+
+		BeforeEach(func() {
+			resourceCache = &db.UsedResourceCache{ID: 42}
+
+			dbVolume = new(dbfakes.FakeCreatedVolume)
+			dbVolume.HandleReturns("some-handle")
+			fakeDBVolumeFactory.FindResourceCacheVolumeReturns(dbVolume, true, nil)
+
+			bcVolume = new(baggageclaimfakes.FakeVolume)
+			fakeBaggageclaimClient.LookupVolumeReturns(bcVolume, true, nil)
+		})
+
+		It("finds the team's volume for the cache on the worker", func() {
+			volume, found, err := volumeClient.FindVolumeForResourceCache(testLogger, 123, resourceCache)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(volume).To(Equal(worker.NewVolume(bcVolume, dbVolume, volumeClient)))
+
+			workerName, teamID, cache := fakeDBVolumeFactory.FindResourceCacheVolumeArgsForCall(0)
+			Expect(workerName).To(Equal("some-worker"))
+			Expect(teamID).To(Equal(123))
+			Expect(cache).To(Equal(resourceCache))
+		})
+
+		It("initializes caches for the volume's team", func() {
+			dbVolume.TeamIDReturns(123)
+
+			volume, _, err := volumeClient.FindVolumeForResourceCache(testLogger, 123, resourceCache)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = volume.InitializeResourceCache(resourceCache)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, teamID := dbVolume.InitializeResourceCacheArgsForCall(0)
+			Expect(teamID).To(Equal(123))
+		})
+
+		Context("when resource caches are global", func() {
+			BeforeEach(func() {
+				volumeClient = worker.NewVolumeClient(
+					fakeBaggageclaimClient,
+					dbWorker,
+					fakeClock,
+
+					fakeLockFactory,
+					fakeDBVolumeFactory,
+					fakeWorkerBaseResourceTypeFactory,
+					fakeWorkerTaskCacheFactory,
+					true,
+				)
+			})
+
+			It("finds the volume shared across teams", func() {
+				_, found, err := volumeClient.FindVolumeForResourceCache(testLogger, 123, resourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, teamID, _ := fakeDBVolumeFactory.FindResourceCacheVolumeArgsForCall(0)
+				Expect(teamID).To(BeZero())
+			})
+
+			It("initializes caches shared across teams", func() {
+				dbVolume.TeamIDReturns(123)
+
+				volume, _, err := volumeClient.FindVolumeForResourceCache(testLogger, 123, resourceCache)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = volume.InitializeResourceCache(resourceCache)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, teamID := dbVolume.InitializeResourceCacheArgsForCall(0)
+				Expect(teamID).To(BeZero())
+			})
+		})
+
+		Context("when the team has no volume for the cache", func() {
+			BeforeEach(func() {
+				fakeDBVolumeFactory.FindResourceCacheVolumeStub = func(_ string, teamID int, _ *db.UsedResourceCache) (db.CreatedVolume, bool, error) {
+					if teamID == 0 {
+						return dbVolume, true, nil
+					}
+
+					return nil, false, nil
+				}
+			})
+
+			It("finds the volume shared across teams", func() {
+				volume, found, err := volumeClient.FindVolumeForResourceCache(testLogger, 123, resourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(volume).To(Equal(worker.NewVolume(bcVolume, dbVolume, volumeClient)))
+
+				Expect(fakeDBVolumeFactory.FindResourceCacheVolumeCallCount()).To(Equal(2))
+				_, teamID, _ := fakeDBVolumeFactory.FindResourceCacheVolumeArgsForCall(0)
+				Expect(teamID).To(Equal(123))
+				_, teamID, _ = fakeDBVolumeFactory.FindResourceCacheVolumeArgsForCall(1)
+				Expect(teamID).To(BeZero())
+			})
+		})
+
+		Context("when the volume is not in the db", func() {
+			BeforeEach(func() {
+				fakeDBVolumeFactory.FindResourceCacheVolumeReturns(nil, false, nil)
+			})
+
+			It("returns false", func() {
+				_, found, err := volumeClient.FindVolumeForResourceCache(testLogger, 123, resourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("LookupVolume", func() {
 		var handle string
 
@@ -657,6 +777,7 @@ var _ = Describe("VolumeClient", func() {
 				fakeDBVolumeFactory,
 				fakeWorkerBaseResourceTypeFactory,
 				fakeWorkerTaskCacheFactory,
+				false,
 			).LookupVolume(testLogger, handle)
 		})
 
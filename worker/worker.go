@@ -48,6 +48,20 @@ type Worker interface {
 	Client
 
 	ActiveContainers() int
+	BuildContainers() int
+	ActiveTasks() int
+
+	// ReserveTaskSlot reserves one of the worker's task slots for the task
+	// owning the container, returning false if max of them are already held.
+	ReserveTaskSlot(logger lager.Logger, owner db.ContainerOwner, max int) (bool, error)
+
+	// UnderPressure is whether the worker reported running low on memory or
+	// disk with its last heartbeat.
+	UnderPressure() bool
+
+	// InMaintenance is whether one of the worker's maintenance windows was in
+	// progress when it was loaded, during which no containers are placed on it.
+	InMaintenance() bool
 
 	Description() string
 	Name() string
@@ -57,8 +71,10 @@ type Worker interface {
 	IsOwnedByTeam() bool
 	IsVersionCompatible(lager.Logger, *version.Version) bool
 
-	FindVolumeForResourceCache(logger lager.Logger, resourceCache *db.UsedResourceCache) (Volume, bool, error)
+	FindVolumeForResourceCache(logger lager.Logger, teamID int, resourceCache *db.UsedResourceCache) (Volume, bool, error)
 	FindVolumeForTaskCache(lager.Logger, int, int, string, string) (Volume, bool, error)
+	CreateVolumeForTaskCache(lager.Logger, VolumeSpec, int, int, string, string) (Volume, error)
+	CreateVolumeForResourceCacheReplica(lager.Logger, VolumeSpec, db.HotResourceCacheVolume) (Volume, error)
 
 	CertsVolume(lager.Logger) (volume Volume, found bool, err error)
 
@@ -75,7 +91,13 @@ type gardenWorker struct {
 
 	clock clock.Clock
 
+	dbWorker db.Worker
+
 	activeContainers int
+	buildContainers  int
+	activeTasks      int
+	underPressure    bool
+	inMaintenance    bool
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             atc.Tags
@@ -101,7 +123,12 @@ func NewGardenWorker(
 		containerProvider:  containerProvider,
 
 		clock:            clock,
+		dbWorker:         dbWorker,
 		activeContainers: dbWorker.ActiveContainers(),
+		buildContainers:  dbWorker.BuildContainers(),
+		activeTasks:      dbWorker.ActiveTasks(),
+		underPressure:    dbWorker.MemoryPressure() || dbWorker.DiskPressure(),
+		inMaintenance:    dbWorker.InMaintenance(),
 		resourceTypes:    dbWorker.ResourceTypes(),
 		platform:         dbWorker.Platform(),
 		tags:             dbWorker.Tags(),
@@ -165,14 +192,22 @@ func (worker *gardenWorker) FindResourceTypeByPath(path string) (atc.WorkerResou
 	return atc.WorkerResourceType{}, false
 }
 
-func (worker *gardenWorker) FindVolumeForResourceCache(logger lager.Logger, resourceCache *db.UsedResourceCache) (Volume, bool, error) {
-	return worker.volumeClient.FindVolumeForResourceCache(logger, resourceCache)
+func (worker *gardenWorker) FindVolumeForResourceCache(logger lager.Logger, teamID int, resourceCache *db.UsedResourceCache) (Volume, bool, error) {
+	return worker.volumeClient.FindVolumeForResourceCache(logger, teamID, resourceCache)
 }
 
 func (worker *gardenWorker) FindVolumeForTaskCache(logger lager.Logger, teamID int, jobID int, stepName string, path string) (Volume, bool, error) {
 	return worker.volumeClient.FindVolumeForTaskCache(logger, teamID, jobID, stepName, path)
 }
 
+func (worker *gardenWorker) CreateVolumeForTaskCache(logger lager.Logger, volumeSpec VolumeSpec, teamID int, jobID int, stepName string, path string) (Volume, error) {
+	return worker.volumeClient.CreateVolumeForTaskCache(logger, volumeSpec, teamID, jobID, stepName, path)
+}
+
+func (worker *gardenWorker) CreateVolumeForResourceCacheReplica(logger lager.Logger, volumeSpec VolumeSpec, source db.HotResourceCacheVolume) (Volume, error) {
+	return worker.volumeClient.CreateVolumeForResourceCacheReplica(logger, volumeSpec, source)
+}
+
 func (worker *gardenWorker) CertsVolume(logger lager.Logger) (Volume, bool, error) {
 	return worker.volumeClient.FindOrCreateVolumeForResourceCerts(logger.Session("find-or-create"))
 }
@@ -206,10 +241,38 @@ func (worker *gardenWorker) FindContainerByHandle(logger lager.Logger, teamID in
 	return worker.containerProvider.FindCreatedContainerByHandle(logger, handle, teamID)
 }
 
+func (worker *gardenWorker) FindContainerByOwner(logger lager.Logger, teamID int, owner db.ContainerOwner) (Container, bool, error) {
+	return worker.containerProvider.FindCreatedContainerByOwner(logger, owner, teamID)
+}
+
 func (worker *gardenWorker) ActiveContainers() int {
 	return worker.activeContainers
 }
 
+func (worker *gardenWorker) BuildContainers() int {
+	return worker.buildContainers
+}
+
+func (worker *gardenWorker) ActiveTasks() int {
+	return worker.activeTasks
+}
+
+func (worker *gardenWorker) ReserveTaskSlot(logger lager.Logger, owner db.ContainerOwner, max int) (bool, error) {
+	return worker.dbWorker.ReserveTaskSlot(owner, max)
+}
+
+func (worker *gardenWorker) ReleaseTaskSlot(logger lager.Logger, teamID int, owner db.ContainerOwner) error {
+	return worker.dbWorker.ReleaseTaskSlot(owner)
+}
+
+func (worker *gardenWorker) UnderPressure() bool {
+	return worker.underPressure
+}
+
+func (worker *gardenWorker) InMaintenance() bool {
+	return worker.inMaintenance
+}
+
 func (worker *gardenWorker) Satisfying(logger lager.Logger, spec WorkerSpec, resourceTypes creds.VersionedResourceTypes) (Worker, error) {
 	if spec.TeamID != worker.teamID && worker.teamID != 0 {
 		return nil, ErrTeamMismatch
@@ -303,23 +366,15 @@ func (worker *gardenWorker) Uptime() time.Duration {
 	return worker.clock.Since(time.Unix(worker.startTime, 0))
 }
 
+// tagsMatch returns whether the worker satisfies every one of the tags, each
+// of which may be an expression over the worker's tags. Tagged workers only
+// run containers which ask for tags.
 func (worker *gardenWorker) tagsMatch(tags []string) bool {
 	if len(worker.tags) > 0 && len(tags) == 0 {
 		return false
 	}
 
-insert_coin:
-	for _, stag := range tags {
-		for _, wtag := range worker.tags {
-			if stag == wtag {
-				continue insert_coin
-			}
-		}
-
-		return false
-	}
-
-	return true
+	return atc.Tags(tags).Matches(worker.tags)
 }
 
 type artifactDestination struct {
@@ -334,6 +334,27 @@ var _ = Describe("Worker", func() {
 					Expect(satisfyingErr).To(Equal(ErrMismatchedTags))
 				})
 			})
+
+			Context("when a tag expression is satisfied by the worker's tags", func() {
+				BeforeEach(func() {
+					spec.Tags = atc.TagsExpression("some && (bogus || tags) && !other")
+				})
+
+				It("returns the worker", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorker).To(Equal(gardenWorker))
+				})
+			})
+
+			Context("when a tag expression is not satisfied by the worker's tags", func() {
+				BeforeEach(func() {
+					spec.Tags = atc.TagsExpression("some && !tags")
+				})
+
+				It("returns ErrMismatchedTags", func() {
+					Expect(satisfyingErr).To(Equal(ErrMismatchedTags))
+				})
+			})
 		})
 
 		Context("when the platform is incompatible", func() {
@@ -121,6 +121,36 @@ type FakeClient struct {
 		result1 []worker.Worker
 		result2 error
 	}
+	FindContainerByOwnerStub        func(lager.Logger, int, db.ContainerOwner) (worker.Container, bool, error)
+	findContainerByOwnerMutex       sync.RWMutex
+	findContainerByOwnerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 db.ContainerOwner
+	}
+	findContainerByOwnerReturns struct {
+		result1 worker.Container
+		result2 bool
+		result3 error
+	}
+	findContainerByOwnerReturnsOnCall map[int]struct {
+		result1 worker.Container
+		result2 bool
+		result3 error
+	}
+	ReleaseTaskSlotStub        func(lager.Logger, int, db.ContainerOwner) error
+	releaseTaskSlotMutex       sync.RWMutex
+	releaseTaskSlotArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 db.ContainerOwner
+	}
+	releaseTaskSlotReturns struct {
+		result1 error
+	}
+	releaseTaskSlotReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -501,6 +531,112 @@ func (fake *FakeClient) RunningWorkersReturnsOnCall(i int, result1 []worker.Work
 	}{result1, result2}
 }
 
+func (fake *FakeClient) FindContainerByOwner(arg1 lager.Logger, arg2 int, arg3 db.ContainerOwner) (worker.Container, bool, error) {
+	fake.findContainerByOwnerMutex.Lock()
+	ret, specificReturn := fake.findContainerByOwnerReturnsOnCall[len(fake.findContainerByOwnerArgsForCall)]
+	fake.findContainerByOwnerArgsForCall = append(fake.findContainerByOwnerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 db.ContainerOwner
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("FindContainerByOwner", []interface{}{arg1, arg2, arg3})
+	fake.findContainerByOwnerMutex.Unlock()
+	if fake.FindContainerByOwnerStub != nil {
+		return fake.FindContainerByOwnerStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.findContainerByOwnerReturns.result1, fake.findContainerByOwnerReturns.result2, fake.findContainerByOwnerReturns.result3
+}
+
+func (fake *FakeClient) FindContainerByOwnerCallCount() int {
+	fake.findContainerByOwnerMutex.RLock()
+	defer fake.findContainerByOwnerMutex.RUnlock()
+	return len(fake.findContainerByOwnerArgsForCall)
+}
+
+func (fake *FakeClient) FindContainerByOwnerArgsForCall(i int) (lager.Logger, int, db.ContainerOwner) {
+	fake.findContainerByOwnerMutex.RLock()
+	defer fake.findContainerByOwnerMutex.RUnlock()
+	return fake.findContainerByOwnerArgsForCall[i].arg1, fake.findContainerByOwnerArgsForCall[i].arg2, fake.findContainerByOwnerArgsForCall[i].arg3
+}
+
+func (fake *FakeClient) FindContainerByOwnerReturns(result1 worker.Container, result2 bool, result3 error) {
+	fake.FindContainerByOwnerStub = nil
+	fake.findContainerByOwnerReturns = struct {
+		result1 worker.Container
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) FindContainerByOwnerReturnsOnCall(i int, result1 worker.Container, result2 bool, result3 error) {
+	fake.FindContainerByOwnerStub = nil
+	if fake.findContainerByOwnerReturnsOnCall == nil {
+		fake.findContainerByOwnerReturnsOnCall = make(map[int]struct {
+			result1 worker.Container
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findContainerByOwnerReturnsOnCall[i] = struct {
+		result1 worker.Container
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) ReleaseTaskSlot(arg1 lager.Logger, arg2 int, arg3 db.ContainerOwner) error {
+	fake.releaseTaskSlotMutex.Lock()
+	ret, specificReturn := fake.releaseTaskSlotReturnsOnCall[len(fake.releaseTaskSlotArgsForCall)]
+	fake.releaseTaskSlotArgsForCall = append(fake.releaseTaskSlotArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 db.ContainerOwner
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ReleaseTaskSlot", []interface{}{arg1, arg2, arg3})
+	fake.releaseTaskSlotMutex.Unlock()
+	if fake.ReleaseTaskSlotStub != nil {
+		return fake.ReleaseTaskSlotStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.releaseTaskSlotReturns.result1
+}
+
+func (fake *FakeClient) ReleaseTaskSlotCallCount() int {
+	fake.releaseTaskSlotMutex.RLock()
+	defer fake.releaseTaskSlotMutex.RUnlock()
+	return len(fake.releaseTaskSlotArgsForCall)
+}
+
+func (fake *FakeClient) ReleaseTaskSlotArgsForCall(i int) (lager.Logger, int, db.ContainerOwner) {
+	fake.releaseTaskSlotMutex.RLock()
+	defer fake.releaseTaskSlotMutex.RUnlock()
+	return fake.releaseTaskSlotArgsForCall[i].arg1, fake.releaseTaskSlotArgsForCall[i].arg2, fake.releaseTaskSlotArgsForCall[i].arg3
+}
+
+func (fake *FakeClient) ReleaseTaskSlotReturns(result1 error) {
+	fake.ReleaseTaskSlotStub = nil
+	fake.releaseTaskSlotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ReleaseTaskSlotReturnsOnCall(i int, result1 error) {
+	fake.ReleaseTaskSlotStub = nil
+	if fake.releaseTaskSlotReturnsOnCall == nil {
+		fake.releaseTaskSlotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseTaskSlotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -518,6 +654,10 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.allSatisfyingMutex.RUnlock()
 	fake.runningWorkersMutex.RLock()
 	defer fake.runningWorkersMutex.RUnlock()
+	fake.findContainerByOwnerMutex.RLock()
+	defer fake.findContainerByOwnerMutex.RUnlock()
+	fake.releaseTaskSlotMutex.RLock()
+	defer fake.releaseTaskSlotMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -4,15 +4,17 @@ package workerfakes
 import (
 	"sync"
 
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 )
 
 type FakeContainerPlacementStrategy struct {
-	ChooseStub        func([]worker.Worker, worker.ContainerSpec) (worker.Worker, error)
+	ChooseStub        func([]worker.Worker, worker.ContainerSpec, db.ContainerMetadata) (worker.Worker, error)
 	chooseMutex       sync.RWMutex
 	chooseArgsForCall []struct {
 		arg1 []worker.Worker
 		arg2 worker.ContainerSpec
+		arg3 db.ContainerMetadata
 	}
 	chooseReturns struct {
 		result1 worker.Worker
@@ -26,7 +28,7 @@ type FakeContainerPlacementStrategy struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeContainerPlacementStrategy) Choose(arg1 []worker.Worker, arg2 worker.ContainerSpec) (worker.Worker, error) {
+func (fake *FakeContainerPlacementStrategy) Choose(arg1 []worker.Worker, arg2 worker.ContainerSpec, arg3 db.ContainerMetadata) (worker.Worker, error) {
 	var arg1Copy []worker.Worker
 	if arg1 != nil {
 		arg1Copy = make([]worker.Worker, len(arg1))
@@ -37,11 +39,12 @@ func (fake *FakeContainerPlacementStrategy) Choose(arg1 []worker.Worker, arg2 wo
 	fake.chooseArgsForCall = append(fake.chooseArgsForCall, struct {
 		arg1 []worker.Worker
 		arg2 worker.ContainerSpec
-	}{arg1Copy, arg2})
-	fake.recordInvocation("Choose", []interface{}{arg1Copy, arg2})
+		arg3 db.ContainerMetadata
+	}{arg1Copy, arg2, arg3})
+	fake.recordInvocation("Choose", []interface{}{arg1Copy, arg2, arg3})
 	fake.chooseMutex.Unlock()
 	if fake.ChooseStub != nil {
-		return fake.ChooseStub(arg1, arg2)
+		return fake.ChooseStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -55,10 +58,10 @@ func (fake *FakeContainerPlacementStrategy) ChooseCallCount() int {
 	return len(fake.chooseArgsForCall)
 }
 
-func (fake *FakeContainerPlacementStrategy) ChooseArgsForCall(i int) ([]worker.Worker, worker.ContainerSpec) {
+func (fake *FakeContainerPlacementStrategy) ChooseArgsForCall(i int) ([]worker.Worker, worker.ContainerSpec, db.ContainerMetadata) {
 	fake.chooseMutex.RLock()
 	defer fake.chooseMutex.RUnlock()
-	return fake.chooseArgsForCall[i].arg1, fake.chooseArgsForCall[i].arg2
+	return fake.chooseArgsForCall[i].arg1, fake.chooseArgsForCall[i].arg2, fake.chooseArgsForCall[i].arg3
 }
 
 func (fake *FakeContainerPlacementStrategy) ChooseReturns(result1 worker.Worker, result2 error) {
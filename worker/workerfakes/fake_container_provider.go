@@ -48,6 +48,23 @@ type FakeContainerProvider struct {
 		result1 worker.Container
 		result2 error
 	}
+	FindCreatedContainerByOwnerStub        func(lager.Logger, db.ContainerOwner, int) (worker.Container, bool, error)
+	findCreatedContainerByOwnerMutex       sync.RWMutex
+	findCreatedContainerByOwnerArgsForCall []struct {
+		logger lager.Logger
+		owner  db.ContainerOwner
+		teamID int
+	}
+	findCreatedContainerByOwnerReturns struct {
+		result1 worker.Container
+		result2 bool
+		result3 error
+	}
+	findCreatedContainerByOwnerReturnsOnCall map[int]struct {
+		result1 worker.Container
+		result2 bool
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -165,6 +182,62 @@ func (fake *FakeContainerProvider) FindOrCreateContainerReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *FakeContainerProvider) FindCreatedContainerByOwner(logger lager.Logger, owner db.ContainerOwner, teamID int) (worker.Container, bool, error) {
+	fake.findCreatedContainerByOwnerMutex.Lock()
+	ret, specificReturn := fake.findCreatedContainerByOwnerReturnsOnCall[len(fake.findCreatedContainerByOwnerArgsForCall)]
+	fake.findCreatedContainerByOwnerArgsForCall = append(fake.findCreatedContainerByOwnerArgsForCall, struct {
+		logger lager.Logger
+		owner  db.ContainerOwner
+		teamID int
+	}{logger, owner, teamID})
+	fake.recordInvocation("FindCreatedContainerByOwner", []interface{}{logger, owner, teamID})
+	fake.findCreatedContainerByOwnerMutex.Unlock()
+	if fake.FindCreatedContainerByOwnerStub != nil {
+		return fake.FindCreatedContainerByOwnerStub(logger, owner, teamID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.findCreatedContainerByOwnerReturns.result1, fake.findCreatedContainerByOwnerReturns.result2, fake.findCreatedContainerByOwnerReturns.result3
+}
+
+func (fake *FakeContainerProvider) FindCreatedContainerByOwnerCallCount() int {
+	fake.findCreatedContainerByOwnerMutex.RLock()
+	defer fake.findCreatedContainerByOwnerMutex.RUnlock()
+	return len(fake.findCreatedContainerByOwnerArgsForCall)
+}
+
+func (fake *FakeContainerProvider) FindCreatedContainerByOwnerArgsForCall(i int) (lager.Logger, db.ContainerOwner, int) {
+	fake.findCreatedContainerByOwnerMutex.RLock()
+	defer fake.findCreatedContainerByOwnerMutex.RUnlock()
+	return fake.findCreatedContainerByOwnerArgsForCall[i].logger, fake.findCreatedContainerByOwnerArgsForCall[i].owner, fake.findCreatedContainerByOwnerArgsForCall[i].teamID
+}
+
+func (fake *FakeContainerProvider) FindCreatedContainerByOwnerReturns(result1 worker.Container, result2 bool, result3 error) {
+	fake.FindCreatedContainerByOwnerStub = nil
+	fake.findCreatedContainerByOwnerReturns = struct {
+		result1 worker.Container
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeContainerProvider) FindCreatedContainerByOwnerReturnsOnCall(i int, result1 worker.Container, result2 bool, result3 error) {
+	fake.FindCreatedContainerByOwnerStub = nil
+	if fake.findCreatedContainerByOwnerReturnsOnCall == nil {
+		fake.findCreatedContainerByOwnerReturnsOnCall = make(map[int]struct {
+			result1 worker.Container
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findCreatedContainerByOwnerReturnsOnCall[i] = struct {
+		result1 worker.Container
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeContainerProvider) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -172,6 +245,8 @@ func (fake *FakeContainerProvider) Invocations() map[string][][]interface{} {
 	defer fake.findCreatedContainerByHandleMutex.RUnlock()
 	fake.findOrCreateContainerMutex.RLock()
 	defer fake.findOrCreateContainerMutex.RUnlock()
+	fake.findCreatedContainerByOwnerMutex.RLock()
+	defer fake.findCreatedContainerByOwnerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -147,6 +147,33 @@ type FakeVolume struct {
 	destroyReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WorkerNameStub        func() string
+	workerNameMutex       sync.RWMutex
+	workerNameArgsForCall []struct{}
+	workerNameReturns     struct {
+		result1 string
+	}
+	workerNameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	InitializeResourceCacheReplicaStub        func() error
+	initializeResourceCacheReplicaMutex       sync.RWMutex
+	initializeResourceCacheReplicaArgsForCall []struct{}
+	initializeResourceCacheReplicaReturns     struct {
+		result1 error
+	}
+	initializeResourceCacheReplicaReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RecordFetchStub        func() error
+	recordFetchMutex       sync.RWMutex
+	recordFetchArgsForCall []struct{}
+	recordFetchReturns     struct {
+		result1 error
+	}
+	recordFetchReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -703,6 +730,126 @@ func (fake *FakeVolume) DestroyReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeVolume) WorkerName() string {
+	fake.workerNameMutex.Lock()
+	ret, specificReturn := fake.workerNameReturnsOnCall[len(fake.workerNameArgsForCall)]
+	fake.workerNameArgsForCall = append(fake.workerNameArgsForCall, struct{}{})
+	fake.recordInvocation("WorkerName", []interface{}{})
+	fake.workerNameMutex.Unlock()
+	if fake.WorkerNameStub != nil {
+		return fake.WorkerNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.workerNameReturns.result1
+}
+
+func (fake *FakeVolume) WorkerNameCallCount() int {
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
+	return len(fake.workerNameArgsForCall)
+}
+
+func (fake *FakeVolume) WorkerNameReturns(result1 string) {
+	fake.WorkerNameStub = nil
+	fake.workerNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeVolume) WorkerNameReturnsOnCall(i int, result1 string) {
+	fake.WorkerNameStub = nil
+	if fake.workerNameReturnsOnCall == nil {
+		fake.workerNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.workerNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeVolume) InitializeResourceCacheReplica() error {
+	fake.initializeResourceCacheReplicaMutex.Lock()
+	ret, specificReturn := fake.initializeResourceCacheReplicaReturnsOnCall[len(fake.initializeResourceCacheReplicaArgsForCall)]
+	fake.initializeResourceCacheReplicaArgsForCall = append(fake.initializeResourceCacheReplicaArgsForCall, struct{}{})
+	fake.recordInvocation("InitializeResourceCacheReplica", []interface{}{})
+	fake.initializeResourceCacheReplicaMutex.Unlock()
+	if fake.InitializeResourceCacheReplicaStub != nil {
+		return fake.InitializeResourceCacheReplicaStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.initializeResourceCacheReplicaReturns.result1
+}
+
+func (fake *FakeVolume) InitializeResourceCacheReplicaCallCount() int {
+	fake.initializeResourceCacheReplicaMutex.RLock()
+	defer fake.initializeResourceCacheReplicaMutex.RUnlock()
+	return len(fake.initializeResourceCacheReplicaArgsForCall)
+}
+
+func (fake *FakeVolume) InitializeResourceCacheReplicaReturns(result1 error) {
+	fake.InitializeResourceCacheReplicaStub = nil
+	fake.initializeResourceCacheReplicaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) InitializeResourceCacheReplicaReturnsOnCall(i int, result1 error) {
+	fake.InitializeResourceCacheReplicaStub = nil
+	if fake.initializeResourceCacheReplicaReturnsOnCall == nil {
+		fake.initializeResourceCacheReplicaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.initializeResourceCacheReplicaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) RecordFetch() error {
+	fake.recordFetchMutex.Lock()
+	ret, specificReturn := fake.recordFetchReturnsOnCall[len(fake.recordFetchArgsForCall)]
+	fake.recordFetchArgsForCall = append(fake.recordFetchArgsForCall, struct{}{})
+	fake.recordInvocation("RecordFetch", []interface{}{})
+	fake.recordFetchMutex.Unlock()
+	if fake.RecordFetchStub != nil {
+		return fake.RecordFetchStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.recordFetchReturns.result1
+}
+
+func (fake *FakeVolume) RecordFetchCallCount() int {
+	fake.recordFetchMutex.RLock()
+	defer fake.recordFetchMutex.RUnlock()
+	return len(fake.recordFetchArgsForCall)
+}
+
+func (fake *FakeVolume) RecordFetchReturns(result1 error) {
+	fake.RecordFetchStub = nil
+	fake.recordFetchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) RecordFetchReturnsOnCall(i int, result1 error) {
+	fake.RecordFetchStub = nil
+	if fake.recordFetchReturnsOnCall == nil {
+		fake.recordFetchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordFetchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -730,6 +877,12 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.createChildForContainerMutex.RUnlock()
 	fake.destroyMutex.RLock()
 	defer fake.destroyMutex.RUnlock()
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
+	fake.initializeResourceCacheReplicaMutex.RLock()
+	defer fake.initializeResourceCacheReplicaMutex.RUnlock()
+	fake.recordFetchMutex.RLock()
+	defer fake.recordFetchMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
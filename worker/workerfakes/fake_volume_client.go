@@ -61,11 +61,12 @@ type FakeVolumeClient struct {
 		result1 worker.Volume
 		result2 error
 	}
-	FindVolumeForResourceCacheStub        func(lager.Logger, *db.UsedResourceCache) (worker.Volume, bool, error)
+	FindVolumeForResourceCacheStub        func(lager.Logger, int, *db.UsedResourceCache) (worker.Volume, bool, error)
 	findVolumeForResourceCacheMutex       sync.RWMutex
 	findVolumeForResourceCacheArgsForCall []struct {
 		arg1 lager.Logger
-		arg2 *db.UsedResourceCache
+		arg2 int
+		arg3 *db.UsedResourceCache
 	}
 	findVolumeForResourceCacheReturns struct {
 		result1 worker.Volume
@@ -145,6 +146,21 @@ type FakeVolumeClient struct {
 		result2 bool
 		result3 error
 	}
+	CreateVolumeForResourceCacheReplicaStub        func(lager.Logger, worker.VolumeSpec, db.HotResourceCacheVolume) (worker.Volume, error)
+	createVolumeForResourceCacheReplicaMutex       sync.RWMutex
+	createVolumeForResourceCacheReplicaArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.VolumeSpec
+		arg3 db.HotResourceCacheVolume
+	}
+	createVolumeForResourceCacheReplicaReturns struct {
+		result1 worker.Volume
+		result2 error
+	}
+	createVolumeForResourceCacheReplicaReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -314,17 +330,18 @@ func (fake *FakeVolumeClient) FindOrCreateVolumeForBaseResourceTypeReturnsOnCall
 	}{result1, result2}
 }
 
-func (fake *FakeVolumeClient) FindVolumeForResourceCache(arg1 lager.Logger, arg2 *db.UsedResourceCache) (worker.Volume, bool, error) {
+func (fake *FakeVolumeClient) FindVolumeForResourceCache(arg1 lager.Logger, arg2 int, arg3 *db.UsedResourceCache) (worker.Volume, bool, error) {
 	fake.findVolumeForResourceCacheMutex.Lock()
 	ret, specificReturn := fake.findVolumeForResourceCacheReturnsOnCall[len(fake.findVolumeForResourceCacheArgsForCall)]
 	fake.findVolumeForResourceCacheArgsForCall = append(fake.findVolumeForResourceCacheArgsForCall, struct {
 		arg1 lager.Logger
-		arg2 *db.UsedResourceCache
-	}{arg1, arg2})
-	fake.recordInvocation("FindVolumeForResourceCache", []interface{}{arg1, arg2})
+		arg2 int
+		arg3 *db.UsedResourceCache
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("FindVolumeForResourceCache", []interface{}{arg1, arg2, arg3})
 	fake.findVolumeForResourceCacheMutex.Unlock()
 	if fake.FindVolumeForResourceCacheStub != nil {
-		return fake.FindVolumeForResourceCacheStub(arg1, arg2)
+		return fake.FindVolumeForResourceCacheStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -338,10 +355,10 @@ func (fake *FakeVolumeClient) FindVolumeForResourceCacheCallCount() int {
 	return len(fake.findVolumeForResourceCacheArgsForCall)
 }
 
-func (fake *FakeVolumeClient) FindVolumeForResourceCacheArgsForCall(i int) (lager.Logger, *db.UsedResourceCache) {
+func (fake *FakeVolumeClient) FindVolumeForResourceCacheArgsForCall(i int) (lager.Logger, int, *db.UsedResourceCache) {
 	fake.findVolumeForResourceCacheMutex.RLock()
 	defer fake.findVolumeForResourceCacheMutex.RUnlock()
-	return fake.findVolumeForResourceCacheArgsForCall[i].arg1, fake.findVolumeForResourceCacheArgsForCall[i].arg2
+	return fake.findVolumeForResourceCacheArgsForCall[i].arg1, fake.findVolumeForResourceCacheArgsForCall[i].arg2, fake.findVolumeForResourceCacheArgsForCall[i].arg3
 }
 
 func (fake *FakeVolumeClient) FindVolumeForResourceCacheReturns(result1 worker.Volume, result2 bool, result3 error) {
@@ -592,6 +609,59 @@ func (fake *FakeVolumeClient) LookupVolumeReturnsOnCall(i int, result1 worker.Vo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeClient) CreateVolumeForResourceCacheReplica(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 db.HotResourceCacheVolume) (worker.Volume, error) {
+	fake.createVolumeForResourceCacheReplicaMutex.Lock()
+	ret, specificReturn := fake.createVolumeForResourceCacheReplicaReturnsOnCall[len(fake.createVolumeForResourceCacheReplicaArgsForCall)]
+	fake.createVolumeForResourceCacheReplicaArgsForCall = append(fake.createVolumeForResourceCacheReplicaArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.VolumeSpec
+		arg3 db.HotResourceCacheVolume
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CreateVolumeForResourceCacheReplica", []interface{}{arg1, arg2, arg3})
+	fake.createVolumeForResourceCacheReplicaMutex.Unlock()
+	if fake.CreateVolumeForResourceCacheReplicaStub != nil {
+		return fake.CreateVolumeForResourceCacheReplicaStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createVolumeForResourceCacheReplicaReturns.result1, fake.createVolumeForResourceCacheReplicaReturns.result2
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForResourceCacheReplicaCallCount() int {
+	fake.createVolumeForResourceCacheReplicaMutex.RLock()
+	defer fake.createVolumeForResourceCacheReplicaMutex.RUnlock()
+	return len(fake.createVolumeForResourceCacheReplicaArgsForCall)
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForResourceCacheReplicaArgsForCall(i int) (lager.Logger, worker.VolumeSpec, db.HotResourceCacheVolume) {
+	fake.createVolumeForResourceCacheReplicaMutex.RLock()
+	defer fake.createVolumeForResourceCacheReplicaMutex.RUnlock()
+	return fake.createVolumeForResourceCacheReplicaArgsForCall[i].arg1, fake.createVolumeForResourceCacheReplicaArgsForCall[i].arg2, fake.createVolumeForResourceCacheReplicaArgsForCall[i].arg3
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForResourceCacheReplicaReturns(result1 worker.Volume, result2 error) {
+	fake.CreateVolumeForResourceCacheReplicaStub = nil
+	fake.createVolumeForResourceCacheReplicaReturns = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeClient) CreateVolumeForResourceCacheReplicaReturnsOnCall(i int, result1 worker.Volume, result2 error) {
+	fake.CreateVolumeForResourceCacheReplicaStub = nil
+	if fake.createVolumeForResourceCacheReplicaReturnsOnCall == nil {
+		fake.createVolumeForResourceCacheReplicaReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 error
+		})
+	}
+	fake.createVolumeForResourceCacheReplicaReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -611,6 +681,8 @@ func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	defer fake.findOrCreateVolumeForResourceCertsMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
+	fake.createVolumeForResourceCacheReplicaMutex.RLock()
+	defer fake.createVolumeForResourceCacheReplicaMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
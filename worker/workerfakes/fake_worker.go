@@ -200,11 +200,12 @@ type FakeWorker struct {
 	isVersionCompatibleReturnsOnCall map[int]struct {
 		result1 bool
 	}
-	FindVolumeForResourceCacheStub        func(logger lager.Logger, resourceCache *db.UsedResourceCache) (worker.Volume, bool, error)
+	FindVolumeForResourceCacheStub        func(lager.Logger, int, *db.UsedResourceCache) (worker.Volume, bool, error)
 	findVolumeForResourceCacheMutex       sync.RWMutex
 	findVolumeForResourceCacheArgsForCall []struct {
-		logger        lager.Logger
-		resourceCache *db.UsedResourceCache
+		arg1 lager.Logger
+		arg2 int
+		arg3 *db.UsedResourceCache
 	}
 	findVolumeForResourceCacheReturns struct {
 		result1 worker.Volume
@@ -268,6 +269,120 @@ type FakeWorker struct {
 	baggageclaimClientReturnsOnCall map[int]struct {
 		result1 baggageclaim.Client
 	}
+	FindContainerByOwnerStub        func(lager.Logger, int, db.ContainerOwner) (worker.Container, bool, error)
+	findContainerByOwnerMutex       sync.RWMutex
+	findContainerByOwnerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 db.ContainerOwner
+	}
+	findContainerByOwnerReturns struct {
+		result1 worker.Container
+		result2 bool
+		result3 error
+	}
+	findContainerByOwnerReturnsOnCall map[int]struct {
+		result1 worker.Container
+		result2 bool
+		result3 error
+	}
+	BuildContainersStub        func() int
+	buildContainersMutex       sync.RWMutex
+	buildContainersArgsForCall []struct{}
+	buildContainersReturns     struct {
+		result1 int
+	}
+	buildContainersReturnsOnCall map[int]struct {
+		result1 int
+	}
+	ActiveTasksStub        func() int
+	activeTasksMutex       sync.RWMutex
+	activeTasksArgsForCall []struct{}
+	activeTasksReturns     struct {
+		result1 int
+	}
+	activeTasksReturnsOnCall map[int]struct {
+		result1 int
+	}
+	UnderPressureStub        func() bool
+	underPressureMutex       sync.RWMutex
+	underPressureArgsForCall []struct{}
+	underPressureReturns     struct {
+		result1 bool
+	}
+	underPressureReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	CreateVolumeForTaskCacheStub        func(lager.Logger, worker.VolumeSpec, int, int, string, string) (worker.Volume, error)
+	createVolumeForTaskCacheMutex       sync.RWMutex
+	createVolumeForTaskCacheArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.VolumeSpec
+		arg3 int
+		arg4 int
+		arg5 string
+		arg6 string
+	}
+	createVolumeForTaskCacheReturns struct {
+		result1 worker.Volume
+		result2 error
+	}
+	createVolumeForTaskCacheReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 error
+	}
+	CreateVolumeForResourceCacheReplicaStub        func(lager.Logger, worker.VolumeSpec, db.HotResourceCacheVolume) (worker.Volume, error)
+	createVolumeForResourceCacheReplicaMutex       sync.RWMutex
+	createVolumeForResourceCacheReplicaArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.VolumeSpec
+		arg3 db.HotResourceCacheVolume
+	}
+	createVolumeForResourceCacheReplicaReturns struct {
+		result1 worker.Volume
+		result2 error
+	}
+	createVolumeForResourceCacheReplicaReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 error
+	}
+	ReserveTaskSlotStub        func(lager.Logger, db.ContainerOwner, int) (bool, error)
+	reserveTaskSlotMutex       sync.RWMutex
+	reserveTaskSlotArgsForCall []struct {
+		logger lager.Logger
+		owner  db.ContainerOwner
+		max    int
+	}
+	reserveTaskSlotReturns struct {
+		result1 bool
+		result2 error
+	}
+	reserveTaskSlotReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ReleaseTaskSlotStub        func(lager.Logger, int, db.ContainerOwner) error
+	releaseTaskSlotMutex       sync.RWMutex
+	releaseTaskSlotArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 db.ContainerOwner
+	}
+	releaseTaskSlotReturns struct {
+		result1 error
+	}
+	releaseTaskSlotReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InMaintenanceStub        func() bool
+	inMaintenanceMutex       sync.RWMutex
+	inMaintenanceArgsForCall []struct{}
+	inMaintenanceReturns     struct {
+		result1 bool
+	}
+	inMaintenanceReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -977,17 +1092,18 @@ func (fake *FakeWorker) IsVersionCompatibleReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
-func (fake *FakeWorker) FindVolumeForResourceCache(logger lager.Logger, resourceCache *db.UsedResourceCache) (worker.Volume, bool, error) {
+func (fake *FakeWorker) FindVolumeForResourceCache(arg1 lager.Logger, arg2 int, arg3 *db.UsedResourceCache) (worker.Volume, bool, error) {
 	fake.findVolumeForResourceCacheMutex.Lock()
 	ret, specificReturn := fake.findVolumeForResourceCacheReturnsOnCall[len(fake.findVolumeForResourceCacheArgsForCall)]
 	fake.findVolumeForResourceCacheArgsForCall = append(fake.findVolumeForResourceCacheArgsForCall, struct {
-		logger        lager.Logger
-		resourceCache *db.UsedResourceCache
-	}{logger, resourceCache})
-	fake.recordInvocation("FindVolumeForResourceCache", []interface{}{logger, resourceCache})
+		arg1 lager.Logger
+		arg2 int
+		arg3 *db.UsedResourceCache
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("FindVolumeForResourceCache", []interface{}{arg1, arg2, arg3})
 	fake.findVolumeForResourceCacheMutex.Unlock()
 	if fake.FindVolumeForResourceCacheStub != nil {
-		return fake.FindVolumeForResourceCacheStub(logger, resourceCache)
+		return fake.FindVolumeForResourceCacheStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -1001,10 +1117,10 @@ func (fake *FakeWorker) FindVolumeForResourceCacheCallCount() int {
 	return len(fake.findVolumeForResourceCacheArgsForCall)
 }
 
-func (fake *FakeWorker) FindVolumeForResourceCacheArgsForCall(i int) (lager.Logger, *db.UsedResourceCache) {
+func (fake *FakeWorker) FindVolumeForResourceCacheArgsForCall(i int) (lager.Logger, int, *db.UsedResourceCache) {
 	fake.findVolumeForResourceCacheMutex.RLock()
 	defer fake.findVolumeForResourceCacheMutex.RUnlock()
-	return fake.findVolumeForResourceCacheArgsForCall[i].logger, fake.findVolumeForResourceCacheArgsForCall[i].resourceCache
+	return fake.findVolumeForResourceCacheArgsForCall[i].arg1, fake.findVolumeForResourceCacheArgsForCall[i].arg2, fake.findVolumeForResourceCacheArgsForCall[i].arg3
 }
 
 func (fake *FakeWorker) FindVolumeForResourceCacheReturns(result1 worker.Volume, result2 bool, result3 error) {
@@ -1224,6 +1340,434 @@ func (fake *FakeWorker) BaggageclaimClientReturnsOnCall(i int, result1 baggagecl
 	}{result1}
 }
 
+func (fake *FakeWorker) FindContainerByOwner(arg1 lager.Logger, arg2 int, arg3 db.ContainerOwner) (worker.Container, bool, error) {
+	fake.findContainerByOwnerMutex.Lock()
+	ret, specificReturn := fake.findContainerByOwnerReturnsOnCall[len(fake.findContainerByOwnerArgsForCall)]
+	fake.findContainerByOwnerArgsForCall = append(fake.findContainerByOwnerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 db.ContainerOwner
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("FindContainerByOwner", []interface{}{arg1, arg2, arg3})
+	fake.findContainerByOwnerMutex.Unlock()
+	if fake.FindContainerByOwnerStub != nil {
+		return fake.FindContainerByOwnerStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.findContainerByOwnerReturns.result1, fake.findContainerByOwnerReturns.result2, fake.findContainerByOwnerReturns.result3
+}
+
+func (fake *FakeWorker) FindContainerByOwnerCallCount() int {
+	fake.findContainerByOwnerMutex.RLock()
+	defer fake.findContainerByOwnerMutex.RUnlock()
+	return len(fake.findContainerByOwnerArgsForCall)
+}
+
+func (fake *FakeWorker) FindContainerByOwnerArgsForCall(i int) (lager.Logger, int, db.ContainerOwner) {
+	fake.findContainerByOwnerMutex.RLock()
+	defer fake.findContainerByOwnerMutex.RUnlock()
+	return fake.findContainerByOwnerArgsForCall[i].arg1, fake.findContainerByOwnerArgsForCall[i].arg2, fake.findContainerByOwnerArgsForCall[i].arg3
+}
+
+func (fake *FakeWorker) FindContainerByOwnerReturns(result1 worker.Container, result2 bool, result3 error) {
+	fake.FindContainerByOwnerStub = nil
+	fake.findContainerByOwnerReturns = struct {
+		result1 worker.Container
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) FindContainerByOwnerReturnsOnCall(i int, result1 worker.Container, result2 bool, result3 error) {
+	fake.FindContainerByOwnerStub = nil
+	if fake.findContainerByOwnerReturnsOnCall == nil {
+		fake.findContainerByOwnerReturnsOnCall = make(map[int]struct {
+			result1 worker.Container
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findContainerByOwnerReturnsOnCall[i] = struct {
+		result1 worker.Container
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) BuildContainers() int {
+	fake.buildContainersMutex.Lock()
+	ret, specificReturn := fake.buildContainersReturnsOnCall[len(fake.buildContainersArgsForCall)]
+	fake.buildContainersArgsForCall = append(fake.buildContainersArgsForCall, struct{}{})
+	fake.recordInvocation("BuildContainers", []interface{}{})
+	fake.buildContainersMutex.Unlock()
+	if fake.BuildContainersStub != nil {
+		return fake.BuildContainersStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.buildContainersReturns.result1
+}
+
+func (fake *FakeWorker) BuildContainersCallCount() int {
+	fake.buildContainersMutex.RLock()
+	defer fake.buildContainersMutex.RUnlock()
+	return len(fake.buildContainersArgsForCall)
+}
+
+func (fake *FakeWorker) BuildContainersReturns(result1 int) {
+	fake.BuildContainersStub = nil
+	fake.buildContainersReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) BuildContainersReturnsOnCall(i int, result1 int) {
+	fake.BuildContainersStub = nil
+	if fake.buildContainersReturnsOnCall == nil {
+		fake.buildContainersReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.buildContainersReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ActiveTasks() int {
+	fake.activeTasksMutex.Lock()
+	ret, specificReturn := fake.activeTasksReturnsOnCall[len(fake.activeTasksArgsForCall)]
+	fake.activeTasksArgsForCall = append(fake.activeTasksArgsForCall, struct{}{})
+	fake.recordInvocation("ActiveTasks", []interface{}{})
+	fake.activeTasksMutex.Unlock()
+	if fake.ActiveTasksStub != nil {
+		return fake.ActiveTasksStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.activeTasksReturns.result1
+}
+
+func (fake *FakeWorker) ActiveTasksCallCount() int {
+	fake.activeTasksMutex.RLock()
+	defer fake.activeTasksMutex.RUnlock()
+	return len(fake.activeTasksArgsForCall)
+}
+
+func (fake *FakeWorker) ActiveTasksReturns(result1 int) {
+	fake.ActiveTasksStub = nil
+	fake.activeTasksReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ActiveTasksReturnsOnCall(i int, result1 int) {
+	fake.ActiveTasksStub = nil
+	if fake.activeTasksReturnsOnCall == nil {
+		fake.activeTasksReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.activeTasksReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) UnderPressure() bool {
+	fake.underPressureMutex.Lock()
+	ret, specificReturn := fake.underPressureReturnsOnCall[len(fake.underPressureArgsForCall)]
+	fake.underPressureArgsForCall = append(fake.underPressureArgsForCall, struct{}{})
+	fake.recordInvocation("UnderPressure", []interface{}{})
+	fake.underPressureMutex.Unlock()
+	if fake.UnderPressureStub != nil {
+		return fake.UnderPressureStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.underPressureReturns.result1
+}
+
+func (fake *FakeWorker) UnderPressureCallCount() int {
+	fake.underPressureMutex.RLock()
+	defer fake.underPressureMutex.RUnlock()
+	return len(fake.underPressureArgsForCall)
+}
+
+func (fake *FakeWorker) UnderPressureReturns(result1 bool) {
+	fake.UnderPressureStub = nil
+	fake.underPressureReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) UnderPressureReturnsOnCall(i int, result1 bool) {
+	fake.UnderPressureStub = nil
+	if fake.underPressureReturnsOnCall == nil {
+		fake.underPressureReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.underPressureReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) CreateVolumeForTaskCache(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 int, arg4 int, arg5 string, arg6 string) (worker.Volume, error) {
+	fake.createVolumeForTaskCacheMutex.Lock()
+	ret, specificReturn := fake.createVolumeForTaskCacheReturnsOnCall[len(fake.createVolumeForTaskCacheArgsForCall)]
+	fake.createVolumeForTaskCacheArgsForCall = append(fake.createVolumeForTaskCacheArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.VolumeSpec
+		arg3 int
+		arg4 int
+		arg5 string
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("CreateVolumeForTaskCache", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.createVolumeForTaskCacheMutex.Unlock()
+	if fake.CreateVolumeForTaskCacheStub != nil {
+		return fake.CreateVolumeForTaskCacheStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createVolumeForTaskCacheReturns.result1, fake.createVolumeForTaskCacheReturns.result2
+}
+
+func (fake *FakeWorker) CreateVolumeForTaskCacheCallCount() int {
+	fake.createVolumeForTaskCacheMutex.RLock()
+	defer fake.createVolumeForTaskCacheMutex.RUnlock()
+	return len(fake.createVolumeForTaskCacheArgsForCall)
+}
+
+func (fake *FakeWorker) CreateVolumeForTaskCacheArgsForCall(i int) (lager.Logger, worker.VolumeSpec, int, int, string, string) {
+	fake.createVolumeForTaskCacheMutex.RLock()
+	defer fake.createVolumeForTaskCacheMutex.RUnlock()
+	return fake.createVolumeForTaskCacheArgsForCall[i].arg1, fake.createVolumeForTaskCacheArgsForCall[i].arg2, fake.createVolumeForTaskCacheArgsForCall[i].arg3, fake.createVolumeForTaskCacheArgsForCall[i].arg4, fake.createVolumeForTaskCacheArgsForCall[i].arg5, fake.createVolumeForTaskCacheArgsForCall[i].arg6
+}
+
+func (fake *FakeWorker) CreateVolumeForTaskCacheReturns(result1 worker.Volume, result2 error) {
+	fake.CreateVolumeForTaskCacheStub = nil
+	fake.createVolumeForTaskCacheReturns = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) CreateVolumeForTaskCacheReturnsOnCall(i int, result1 worker.Volume, result2 error) {
+	fake.CreateVolumeForTaskCacheStub = nil
+	if fake.createVolumeForTaskCacheReturnsOnCall == nil {
+		fake.createVolumeForTaskCacheReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 error
+		})
+	}
+	fake.createVolumeForTaskCacheReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) CreateVolumeForResourceCacheReplica(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 db.HotResourceCacheVolume) (worker.Volume, error) {
+	fake.createVolumeForResourceCacheReplicaMutex.Lock()
+	ret, specificReturn := fake.createVolumeForResourceCacheReplicaReturnsOnCall[len(fake.createVolumeForResourceCacheReplicaArgsForCall)]
+	fake.createVolumeForResourceCacheReplicaArgsForCall = append(fake.createVolumeForResourceCacheReplicaArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.VolumeSpec
+		arg3 db.HotResourceCacheVolume
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CreateVolumeForResourceCacheReplica", []interface{}{arg1, arg2, arg3})
+	fake.createVolumeForResourceCacheReplicaMutex.Unlock()
+	if fake.CreateVolumeForResourceCacheReplicaStub != nil {
+		return fake.CreateVolumeForResourceCacheReplicaStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createVolumeForResourceCacheReplicaReturns.result1, fake.createVolumeForResourceCacheReplicaReturns.result2
+}
+
+func (fake *FakeWorker) CreateVolumeForResourceCacheReplicaCallCount() int {
+	fake.createVolumeForResourceCacheReplicaMutex.RLock()
+	defer fake.createVolumeForResourceCacheReplicaMutex.RUnlock()
+	return len(fake.createVolumeForResourceCacheReplicaArgsForCall)
+}
+
+func (fake *FakeWorker) CreateVolumeForResourceCacheReplicaArgsForCall(i int) (lager.Logger, worker.VolumeSpec, db.HotResourceCacheVolume) {
+	fake.createVolumeForResourceCacheReplicaMutex.RLock()
+	defer fake.createVolumeForResourceCacheReplicaMutex.RUnlock()
+	return fake.createVolumeForResourceCacheReplicaArgsForCall[i].arg1, fake.createVolumeForResourceCacheReplicaArgsForCall[i].arg2, fake.createVolumeForResourceCacheReplicaArgsForCall[i].arg3
+}
+
+func (fake *FakeWorker) CreateVolumeForResourceCacheReplicaReturns(result1 worker.Volume, result2 error) {
+	fake.CreateVolumeForResourceCacheReplicaStub = nil
+	fake.createVolumeForResourceCacheReplicaReturns = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) CreateVolumeForResourceCacheReplicaReturnsOnCall(i int, result1 worker.Volume, result2 error) {
+	fake.CreateVolumeForResourceCacheReplicaStub = nil
+	if fake.createVolumeForResourceCacheReplicaReturnsOnCall == nil {
+		fake.createVolumeForResourceCacheReplicaReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 error
+		})
+	}
+	fake.createVolumeForResourceCacheReplicaReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReserveTaskSlot(logger lager.Logger, owner db.ContainerOwner, max int) (bool, error) {
+	fake.reserveTaskSlotMutex.Lock()
+	ret, specificReturn := fake.reserveTaskSlotReturnsOnCall[len(fake.reserveTaskSlotArgsForCall)]
+	fake.reserveTaskSlotArgsForCall = append(fake.reserveTaskSlotArgsForCall, struct {
+		logger lager.Logger
+		owner  db.ContainerOwner
+		max    int
+	}{logger, owner, max})
+	fake.recordInvocation("ReserveTaskSlot", []interface{}{logger, owner, max})
+	fake.reserveTaskSlotMutex.Unlock()
+	if fake.ReserveTaskSlotStub != nil {
+		return fake.ReserveTaskSlotStub(logger, owner, max)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.reserveTaskSlotReturns.result1, fake.reserveTaskSlotReturns.result2
+}
+
+func (fake *FakeWorker) ReserveTaskSlotCallCount() int {
+	fake.reserveTaskSlotMutex.RLock()
+	defer fake.reserveTaskSlotMutex.RUnlock()
+	return len(fake.reserveTaskSlotArgsForCall)
+}
+
+func (fake *FakeWorker) ReserveTaskSlotArgsForCall(i int) (lager.Logger, db.ContainerOwner, int) {
+	fake.reserveTaskSlotMutex.RLock()
+	defer fake.reserveTaskSlotMutex.RUnlock()
+	return fake.reserveTaskSlotArgsForCall[i].logger, fake.reserveTaskSlotArgsForCall[i].owner, fake.reserveTaskSlotArgsForCall[i].max
+}
+
+func (fake *FakeWorker) ReserveTaskSlotReturns(result1 bool, result2 error) {
+	fake.ReserveTaskSlotStub = nil
+	fake.reserveTaskSlotReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReserveTaskSlotReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ReserveTaskSlotStub = nil
+	if fake.reserveTaskSlotReturnsOnCall == nil {
+		fake.reserveTaskSlotReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.reserveTaskSlotReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReleaseTaskSlot(arg1 lager.Logger, arg2 int, arg3 db.ContainerOwner) error {
+	fake.releaseTaskSlotMutex.Lock()
+	ret, specificReturn := fake.releaseTaskSlotReturnsOnCall[len(fake.releaseTaskSlotArgsForCall)]
+	fake.releaseTaskSlotArgsForCall = append(fake.releaseTaskSlotArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 db.ContainerOwner
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ReleaseTaskSlot", []interface{}{arg1, arg2, arg3})
+	fake.releaseTaskSlotMutex.Unlock()
+	if fake.ReleaseTaskSlotStub != nil {
+		return fake.ReleaseTaskSlotStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.releaseTaskSlotReturns.result1
+}
+
+func (fake *FakeWorker) ReleaseTaskSlotCallCount() int {
+	fake.releaseTaskSlotMutex.RLock()
+	defer fake.releaseTaskSlotMutex.RUnlock()
+	return len(fake.releaseTaskSlotArgsForCall)
+}
+
+func (fake *FakeWorker) ReleaseTaskSlotArgsForCall(i int) (lager.Logger, int, db.ContainerOwner) {
+	fake.releaseTaskSlotMutex.RLock()
+	defer fake.releaseTaskSlotMutex.RUnlock()
+	return fake.releaseTaskSlotArgsForCall[i].arg1, fake.releaseTaskSlotArgsForCall[i].arg2, fake.releaseTaskSlotArgsForCall[i].arg3
+}
+
+func (fake *FakeWorker) ReleaseTaskSlotReturns(result1 error) {
+	fake.ReleaseTaskSlotStub = nil
+	fake.releaseTaskSlotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) ReleaseTaskSlotReturnsOnCall(i int, result1 error) {
+	fake.ReleaseTaskSlotStub = nil
+	if fake.releaseTaskSlotReturnsOnCall == nil {
+		fake.releaseTaskSlotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseTaskSlotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) InMaintenance() bool {
+	fake.inMaintenanceMutex.Lock()
+	ret, specificReturn := fake.inMaintenanceReturnsOnCall[len(fake.inMaintenanceArgsForCall)]
+	fake.inMaintenanceArgsForCall = append(fake.inMaintenanceArgsForCall, struct{}{})
+	fake.recordInvocation("InMaintenance", []interface{}{})
+	fake.inMaintenanceMutex.Unlock()
+	if fake.InMaintenanceStub != nil {
+		return fake.InMaintenanceStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.inMaintenanceReturns.result1
+}
+
+func (fake *FakeWorker) InMaintenanceCallCount() int {
+	fake.inMaintenanceMutex.RLock()
+	defer fake.inMaintenanceMutex.RUnlock()
+	return len(fake.inMaintenanceArgsForCall)
+}
+
+func (fake *FakeWorker) InMaintenanceReturns(result1 bool) {
+	fake.InMaintenanceStub = nil
+	fake.inMaintenanceReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) InMaintenanceReturnsOnCall(i int, result1 bool) {
+	fake.InMaintenanceStub = nil
+	if fake.inMaintenanceReturnsOnCall == nil {
+		fake.inMaintenanceReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.inMaintenanceReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1267,6 +1811,24 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.gardenClientMutex.RUnlock()
 	fake.baggageclaimClientMutex.RLock()
 	defer fake.baggageclaimClientMutex.RUnlock()
+	fake.findContainerByOwnerMutex.RLock()
+	defer fake.findContainerByOwnerMutex.RUnlock()
+	fake.buildContainersMutex.RLock()
+	defer fake.buildContainersMutex.RUnlock()
+	fake.activeTasksMutex.RLock()
+	defer fake.activeTasksMutex.RUnlock()
+	fake.underPressureMutex.RLock()
+	defer fake.underPressureMutex.RUnlock()
+	fake.createVolumeForTaskCacheMutex.RLock()
+	defer fake.createVolumeForTaskCacheMutex.RUnlock()
+	fake.createVolumeForResourceCacheReplicaMutex.RLock()
+	defer fake.createVolumeForResourceCacheReplicaMutex.RUnlock()
+	fake.reserveTaskSlotMutex.RLock()
+	defer fake.reserveTaskSlotMutex.RUnlock()
+	fake.releaseTaskSlotMutex.RLock()
+	defer fake.releaseTaskSlotMutex.RUnlock()
+	fake.inMaintenanceMutex.RLock()
+	defer fake.inMaintenanceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
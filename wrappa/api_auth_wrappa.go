@@ -53,24 +53,35 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		// pipeline is public or authorized
 		case atc.GetBuild,
 			atc.BuildResources,
-			atc.GetBuildPlan:
+			atc.GetBuildPlan,
+			atc.GetBuildPlanGraph:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.AnyJobHandler(handler, rejector)
 
 		// pipeline and job are public or authorized
 		case atc.GetBuildPreparation,
+			atc.GetBuildUsage,
 			atc.BuildEvents:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 		// resource belongs to authorized team
 		case atc.AbortBuild,
+			atc.GetBuildEnvironment,
 			atc.SendInputToBuildPlan,
-			atc.ReadOutputFromBuildPlan:
+			atc.ReadOutputFromBuildPlan,
+			atc.ReplayBuildPlan,
+			atc.GetBuildPlanReplay,
+			atc.RerunBuildPlan:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
 		case atc.PruneWorker,
 			atc.LandWorker,
-			atc.RetireWorker:
+			atc.RetireWorker,
+			atc.ListWorkerContainers,
+			atc.ListWorkerVolumes,
+			atc.ListWorkerMaintenanceWindows,
+			atc.ScheduleWorkerMaintenance,
+			atc.CancelWorkerMaintenance:
 			newHandler = wrappa.checkWorkerTeamAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// pipeline is public or authorized
@@ -82,13 +93,15 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetJob,
 			atc.ListJobBuilds,
 			atc.ListPipelineBuilds,
+			atc.GetBuildCalendar,
 			atc.GetResource,
 			atc.ListBuildsWithVersionAsInput,
 			atc.ListBuildsWithVersionAsOutput,
 			atc.GetResourceCausality,
 			atc.GetResourceVersion,
 			atc.ListResources,
-			atc.ListResourceVersions:
+			atc.ListResourceVersions,
+			atc.SearchResourceVersions:
 			newHandler = wrappa.checkPipelineAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// authenticated
@@ -108,7 +121,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		case atc.GetLogLevel,
-			atc.SetLogLevel:
+			atc.SetLogLevel,
+			atc.ListTeamCacheUsages:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team)
@@ -118,20 +132,32 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
+			atc.AnnotateResourceVersion,
+			atc.RemoveResourceVersionAnnotation,
 			atc.GetConfig,
+			atc.ListConfigRevisions,
+			atc.RollbackConfigRevision,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
 			atc.OrderPipelines,
 			atc.PauseJob,
+			atc.PauseJobs,
 			atc.PausePipeline,
 			atc.PauseResource,
 			atc.RenamePipeline,
 			atc.UnpauseJob,
+			atc.UnpauseJobs,
 			atc.UnpausePipeline,
 			atc.UnpauseResource,
 			atc.ExposePipeline,
 			atc.HidePipeline,
-			atc.SaveConfig:
+			atc.SaveConfig,
+			atc.ListTeamAuditEvents,
+			atc.GetTeamCacheUsage,
+			atc.ListResourceWebhookTokens,
+			atc.CreateResourceWebhookToken,
+			atc.DeleteResourceWebhookToken,
+			atc.ListResourceCheckResults:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
 		// think about it!
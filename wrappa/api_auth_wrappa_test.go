@@ -155,23 +155,35 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.LegacyGetUser:         unauthenticated(inputHandlers[atc.LegacyGetUser]),
 
 				// authorized or public pipeline
-				atc.GetBuild:       doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuild]),
-				atc.BuildResources: doesNotCheckIfPrivateJob(inputHandlers[atc.BuildResources]),
-				atc.GetBuildPlan:   doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
+				atc.GetBuild:          doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuild]),
+				atc.BuildResources:    doesNotCheckIfPrivateJob(inputHandlers[atc.BuildResources]),
+				atc.GetBuildPlan:      doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
+				atc.GetBuildPlanGraph: doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuildPlanGraph]),
 
 				// authorized or public pipeline and public job
 				atc.BuildEvents:         checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
 				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.GetBuildUsage:       checksIfPrivateJob(inputHandlers[atc.GetBuildUsage]),
 
 				// resource belongs to authorized team
 				atc.AbortBuild:              checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.GetBuildEnvironment:     checkWritePermissionForBuild(inputHandlers[atc.GetBuildEnvironment]),
 				atc.SendInputToBuildPlan:    checkWritePermissionForBuild(inputHandlers[atc.SendInputToBuildPlan]),
 				atc.ReadOutputFromBuildPlan: checkWritePermissionForBuild(inputHandlers[atc.ReadOutputFromBuildPlan]),
+				atc.ReplayBuildPlan:         checkWritePermissionForBuild(inputHandlers[atc.ReplayBuildPlan]),
+				atc.GetBuildPlanReplay:      checkWritePermissionForBuild(inputHandlers[atc.GetBuildPlanReplay]),
+				atc.RerunBuildPlan:          checkWritePermissionForBuild(inputHandlers[atc.RerunBuildPlan]),
 
 				// resource belongs to authorized team
-				atc.PruneWorker:  checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
-				atc.LandWorker:   checkTeamAccessForWorker(inputHandlers[atc.LandWorker]),
-				atc.RetireWorker: checkTeamAccessForWorker(inputHandlers[atc.RetireWorker]),
+				atc.PruneWorker:          checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
+				atc.LandWorker:           checkTeamAccessForWorker(inputHandlers[atc.LandWorker]),
+				atc.RetireWorker:         checkTeamAccessForWorker(inputHandlers[atc.RetireWorker]),
+				atc.ListWorkerContainers: checkTeamAccessForWorker(inputHandlers[atc.ListWorkerContainers]),
+				atc.ListWorkerVolumes:    checkTeamAccessForWorker(inputHandlers[atc.ListWorkerVolumes]),
+
+				atc.ListWorkerMaintenanceWindows: checkTeamAccessForWorker(inputHandlers[atc.ListWorkerMaintenanceWindows]),
+				atc.ScheduleWorkerMaintenance:    checkTeamAccessForWorker(inputHandlers[atc.ScheduleWorkerMaintenance]),
+				atc.CancelWorkerMaintenance:      checkTeamAccessForWorker(inputHandlers[atc.CancelWorkerMaintenance]),
 
 				// belongs to public pipeline or authorized
 				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipeline]),
@@ -182,6 +194,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetJob:                        openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJob]),
 				atc.ListJobBuilds:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobBuilds]),
 				atc.ListPipelineBuilds:            openForPublicPipelineOrAuthorized(inputHandlers[atc.ListPipelineBuilds]),
+				atc.GetBuildCalendar:              openForPublicPipelineOrAuthorized(inputHandlers[atc.GetBuildCalendar]),
 				atc.GetResource:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResource]),
 				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsInput]),
 				atc.ListBuildsWithVersionAsOutput: openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsOutput]),
@@ -189,6 +202,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListResourceVersions:          openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceVersions]),
 				atc.GetResourceCausality:          openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResourceCausality]),
 				atc.GetResourceVersion:            openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResourceVersion]),
+				atc.SearchResourceVersions:        openForPublicPipelineOrAuthorized(inputHandlers[atc.SearchResourceVersions]),
 
 				// authenticated
 				atc.CreateBuild:     authenticated(inputHandlers[atc.CreateBuild]),
@@ -206,30 +220,43 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.DestroyTeam:     authenticated(inputHandlers[atc.DestroyTeam]),
 
 				// authenticated and is admin
-				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
-				atc.SetLogLevel: authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.GetLogLevel:         authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
+				atc.SetLogLevel:         authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.ListTeamCacheUsages: authenticatedAndAdmin(inputHandlers[atc.ListTeamCacheUsages]),
 
 				// authorized (requested team matches resource team)
-				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
-				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
-				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
-				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
-				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
-				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
-				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
-				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
-				atc.ExposePipeline:         authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:           authorized(inputHandlers[atc.HidePipeline]),
-				atc.CreatePipelineBuild:    authorized(inputHandlers[atc.CreatePipelineBuild]),
+				atc.CheckResource:                   authorized(inputHandlers[atc.CheckResource]),
+				atc.CreateJobBuild:                  authorized(inputHandlers[atc.CreateJobBuild]),
+				atc.DeletePipeline:                  authorized(inputHandlers[atc.DeletePipeline]),
+				atc.DisableResourceVersion:          authorized(inputHandlers[atc.DisableResourceVersion]),
+				atc.EnableResourceVersion:           authorized(inputHandlers[atc.EnableResourceVersion]),
+				atc.AnnotateResourceVersion:         authorized(inputHandlers[atc.AnnotateResourceVersion]),
+				atc.RemoveResourceVersionAnnotation: authorized(inputHandlers[atc.RemoveResourceVersionAnnotation]),
+				atc.GetConfig:                       authorized(inputHandlers[atc.GetConfig]),
+				atc.ListConfigRevisions:             authorized(inputHandlers[atc.ListConfigRevisions]),
+				atc.RollbackConfigRevision:          authorized(inputHandlers[atc.RollbackConfigRevision]),
+				atc.GetVersionsDB:                   authorized(inputHandlers[atc.GetVersionsDB]),
+				atc.ListJobInputs:                   authorized(inputHandlers[atc.ListJobInputs]),
+				atc.OrderPipelines:                  authorized(inputHandlers[atc.OrderPipelines]),
+				atc.PauseJob:                        authorized(inputHandlers[atc.PauseJob]),
+				atc.PauseJobs:                       authorized(inputHandlers[atc.PauseJobs]),
+				atc.PausePipeline:                   authorized(inputHandlers[atc.PausePipeline]),
+				atc.PauseResource:                   authorized(inputHandlers[atc.PauseResource]),
+				atc.RenamePipeline:                  authorized(inputHandlers[atc.RenamePipeline]),
+				atc.SaveConfig:                      authorized(inputHandlers[atc.SaveConfig]),
+				atc.ListTeamAuditEvents:             authorized(inputHandlers[atc.ListTeamAuditEvents]),
+				atc.GetTeamCacheUsage:               authorized(inputHandlers[atc.GetTeamCacheUsage]),
+				atc.ListResourceWebhookTokens:       authorized(inputHandlers[atc.ListResourceWebhookTokens]),
+				atc.CreateResourceWebhookToken:      authorized(inputHandlers[atc.CreateResourceWebhookToken]),
+				atc.DeleteResourceWebhookToken:      authorized(inputHandlers[atc.DeleteResourceWebhookToken]),
+				atc.ListResourceCheckResults:        authorized(inputHandlers[atc.ListResourceCheckResults]),
+				atc.UnpauseJob:                      authorized(inputHandlers[atc.UnpauseJob]),
+				atc.UnpauseJobs:                     authorized(inputHandlers[atc.UnpauseJobs]),
+				atc.UnpausePipeline:                 authorized(inputHandlers[atc.UnpausePipeline]),
+				atc.UnpauseResource:                 authorized(inputHandlers[atc.UnpauseResource]),
+				atc.ExposePipeline:                  authorized(inputHandlers[atc.ExposePipeline]),
+				atc.HidePipeline:                    authorized(inputHandlers[atc.HidePipeline]),
+				atc.CreatePipelineBuild:             authorized(inputHandlers[atc.CreatePipelineBuild]),
 			}
 		})
 
@@ -1,6 +1,8 @@
 package wrappa
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/metric"
@@ -9,11 +11,20 @@ import (
 
 type APIMetricsWrappa struct {
 	logger lager.Logger
+
+	budget       time.Duration
+	routeBudgets map[string]time.Duration
 }
 
-func NewAPIMetricsWrappa(logger lager.Logger) Wrappa {
+// NewAPIMetricsWrappa emits metrics for the requests to each route. Requests
+// exceeding the route's latency budget, or the given budget for routes
+// without one, are logged as slow.
+func NewAPIMetricsWrappa(logger lager.Logger, budget time.Duration, routeBudgets map[string]time.Duration) Wrappa {
 	return APIMetricsWrappa{
 		logger: logger,
+
+		budget:       budget,
+		routeBudgets: routeBudgets,
 	}
 }
 
@@ -25,7 +36,12 @@ func (wrappa APIMetricsWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.BuildEvents, atc.DownloadCLI, atc.HijackContainer:
 			wrapped[name] = handler
 		default:
-			wrapped[name] = metric.WrapHandler(wrappa.logger, name, handler)
+			budget, found := wrappa.routeBudgets[name]
+			if !found {
+				budget = wrappa.budget
+			}
+
+			wrapped[name] = metric.WrapHandler(wrappa.logger, name, budget, handler)
 		}
 	}
 
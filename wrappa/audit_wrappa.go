@@ -0,0 +1,141 @@
+package wrappa
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/api/auth"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+type AuditWrappa struct {
+	logger   lager.Logger
+	auditLog db.AuditLog
+}
+
+// NewAuditWrappa records who set and destroyed pipelines, paused jobs,
+// triggered and aborted builds, and changed teams, once the request succeeds.
+// It must wrap handlers before authentication, so that rejected requests are
+// not recorded and builds are loaded by the time it records their team.
+func NewAuditWrappa(logger lager.Logger, auditLog db.AuditLog) Wrappa {
+	return AuditWrappa{
+		logger:   logger,
+		auditLog: auditLog,
+	}
+}
+
+func (wrappa AuditWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	params := map[string][]string{}
+	for _, route := range atc.Routes {
+		for _, segment := range strings.Split(route.Path, "/") {
+			if strings.HasPrefix(segment, ":") && segment != ":team_name" {
+				params[route.Name] = append(params[route.Name], segment)
+			}
+		}
+	}
+
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		switch name {
+		case atc.SaveConfig,
+			atc.RollbackConfigRevision,
+			atc.DeletePipeline,
+			atc.PausePipeline,
+			atc.UnpausePipeline,
+			atc.RenamePipeline,
+			atc.ExposePipeline,
+			atc.HidePipeline,
+			atc.PauseJob,
+			atc.UnpauseJob,
+			atc.PauseJobs,
+			atc.UnpauseJobs,
+			atc.CreateJobBuild,
+			atc.CreatePipelineBuild,
+			atc.CreateBuild,
+			atc.AbortBuild,
+			atc.SetTeam,
+			atc.RenameTeam,
+			atc.DestroyTeam:
+			wrapped[name] = auditHandler{
+				logger:   wrappa.logger.Session("audit", lager.Data{"action": name}),
+				auditLog: wrappa.auditLog,
+				action:   name,
+				params:   params[name],
+				handler:  handler,
+			}
+		default:
+			wrapped[name] = handler
+		}
+	}
+
+	return wrapped
+}
+
+type auditHandler struct {
+	logger   lager.Logger
+	auditLog db.AuditLog
+
+	action string
+	params []string
+
+	handler http.Handler
+}
+
+func (handler auditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recorder := &auditStatusRecorder{ResponseWriter: w}
+
+	handler.handler.ServeHTTP(recorder, r)
+
+	if recorder.status >= http.StatusBadRequest {
+		return
+	}
+
+	var teamID int
+	teamName := r.FormValue(":team_name")
+	if build, ok := r.Context().Value(auth.BuildContextKey).(db.Build); ok {
+		teamID = build.TeamID()
+		teamName = build.TeamName()
+	}
+
+	subject := map[string]string{}
+	for _, param := range handler.params {
+		subject[strings.TrimPrefix(param, ":")] = r.FormValue(param)
+	}
+
+	remoteAddr, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remoteAddr = r.RemoteAddr
+	}
+
+	err = handler.auditLog.Record(db.AuditEvent{
+		TeamID:     teamID,
+		TeamName:   teamName,
+		Requester:  accessor.GetAccessor(r).Requester(),
+		Action:     handler.action,
+		Subject:    subject,
+		RemoteAddr: remoteAddr,
+	})
+	if err != nil {
+		handler.logger.Error("failed-to-record-audit-event", err)
+	}
+}
+
+type auditStatusRecorder struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (recorder *auditStatusRecorder) WriteHeader(status int) {
+	if recorder.status == 0 {
+		recorder.status = status
+	}
+
+	recorder.ResponseWriter.WriteHeader(status)
+}
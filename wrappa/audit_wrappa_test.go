@@ -0,0 +1,125 @@
+package wrappa_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/api/auth"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditWrappa", func() {
+	var (
+		fakeAuditLog *dbfakes.FakeAuditLog
+		fakeAccess   *accessorfakes.FakeAccess
+
+		inputHandlers rata.Handlers
+		router        http.Handler
+	)
+
+	BeforeEach(func() {
+		fakeAuditLog = new(dbfakes.FakeAuditLog)
+
+		fakeAccess = new(accessorfakes.FakeAccess)
+		fakeAccess.RequesterReturns("some-team")
+
+		inputHandlers = rata.Handlers{}
+		for _, route := range atc.Routes {
+			inputHandlers[route.Name] = &stupidHandler{}
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		router, err = rata.NewRouter(atc.Routes, wrappa.NewAuditWrappa(lagertest.NewTestLogger("test"), fakeAuditLog).Wrap(inputHandlers))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	request := func(name string, params rata.Params, ctx context.Context) {
+		req, err := rata.NewRequestGenerator("").CreateRequest(name, params, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		req.RemoteAddr = "1.2.3.4:5678"
+
+		ctx = context.WithValue(ctx, "accessor", fakeAccess)
+		router.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+	}
+
+	It("records audited actions with their subject", func() {
+		request(atc.PauseJob, rata.Params{
+			"team_name":     "some-team",
+			"pipeline_name": "some-pipeline",
+			"job_name":      "some-job",
+		}, context.Background())
+
+		Expect(fakeAuditLog.RecordCallCount()).To(Equal(1))
+		Expect(fakeAuditLog.RecordArgsForCall(0)).To(Equal(db.AuditEvent{
+			TeamName:  "some-team",
+			Requester: "some-team",
+			Action:    atc.PauseJob,
+			Subject: map[string]string{
+				"pipeline_name": "some-pipeline",
+				"job_name":      "some-job",
+			},
+			RemoteAddr: "1.2.3.4",
+		}))
+	})
+
+	It("records builds under the team they belong to", func() {
+		fakeBuild := new(dbfakes.FakeBuild)
+		fakeBuild.TeamIDReturns(7)
+		fakeBuild.TeamNameReturns("some-team")
+
+		request(atc.AbortBuild, rata.Params{
+			"build_id": "42",
+		}, context.WithValue(context.Background(), auth.BuildContextKey, fakeBuild))
+
+		Expect(fakeAuditLog.RecordCallCount()).To(Equal(1))
+
+		event := fakeAuditLog.RecordArgsForCall(0)
+		Expect(event.TeamID).To(Equal(7))
+		Expect(event.TeamName).To(Equal("some-team"))
+		Expect(event.Action).To(Equal(atc.AbortBuild))
+		Expect(event.Subject).To(Equal(map[string]string{"build_id": "42"}))
+	})
+
+	It("does not record other requests", func() {
+		request(atc.ListTeamBuilds, rata.Params{"team_name": "some-team"}, context.Background())
+		Expect(fakeAuditLog.RecordCallCount()).To(BeZero())
+	})
+
+	Context("when the request fails", func() {
+		BeforeEach(func() {
+			inputHandlers[atc.DestroyTeam] = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			})
+		})
+
+		It("does not record it", func() {
+			request(atc.DestroyTeam, rata.Params{"team_name": "some-team"}, context.Background())
+			Expect(fakeAuditLog.RecordCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when recording fails", func() {
+		BeforeEach(func() {
+			fakeAuditLog.RecordReturns(errors.New("nope"))
+		})
+
+		It("still serves the request", func() {
+			request(atc.DestroyTeam, rata.Params{"team_name": "some-team"}, context.Background())
+			Expect(fakeAuditLog.RecordCallCount()).To(Equal(1))
+		})
+	})
+})
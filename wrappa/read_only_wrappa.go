@@ -0,0 +1,44 @@
+package wrappa
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/tedsuo/rata"
+)
+
+type ReadOnlyWrappa struct{}
+
+// NewReadOnlyWrappa returns a Wrappa which rejects every request that could
+// modify state, for an ATC running against a database it must not write to.
+// Only GET routes are served, with the exception of hijacking, which runs
+// processes on workers.
+func NewReadOnlyWrappa() Wrappa {
+	return ReadOnlyWrappa{}
+}
+
+func (wrappa ReadOnlyWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	methods := map[string]string{}
+	for _, route := range atc.Routes {
+		methods[route.Name] = route.Method
+	}
+
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		if methods[name] == "GET" && name != atc.HijackContainer {
+			wrapped[name] = handler
+		} else {
+			wrapped[name] = readOnlyHandler{}
+		}
+	}
+
+	return wrapped
+}
+
+type readOnlyHandler struct{}
+
+func (readOnlyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = w.Write([]byte("this ATC is running in read-only mode"))
+}
@@ -0,0 +1,68 @@
+package wrappa_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReadOnlyWrappa", func() {
+	Describe("Wrap", func() {
+		var (
+			inputHandlers   rata.Handlers
+			wrappedHandlers rata.Handlers
+		)
+
+		BeforeEach(func() {
+			inputHandlers = rata.Handlers{}
+
+			for _, route := range atc.Routes {
+				inputHandlers[route.Name] = &stupidHandler{}
+			}
+		})
+
+		JustBeforeEach(func() {
+			wrappedHandlers = wrappa.NewReadOnlyWrappa().Wrap(inputHandlers)
+		})
+
+		It("serves reads as usual", func() {
+			for _, route := range atc.Routes {
+				if route.Method != "GET" || route.Name == atc.HijackContainer {
+					continue
+				}
+
+				Expect(descriptiveRoute{
+					route:   route.Name,
+					handler: wrappedHandlers[route.Name],
+				}).To(Equal(descriptiveRoute{
+					route:   route.Name,
+					handler: inputHandlers[route.Name],
+				}))
+			}
+		})
+
+		It("rejects everything else as unavailable", func() {
+			for _, name := range []string{
+				atc.SaveConfig,
+				atc.CreateBuild,
+				atc.AbortBuild,
+				atc.PauseJob,
+				atc.CheckResource,
+				atc.RegisterWorker,
+				atc.HijackContainer,
+			} {
+				Expect(wrappedHandlers[name]).NotTo(Equal(inputHandlers[name]), name)
+
+				recorder := httptest.NewRecorder()
+				wrappedHandlers[name].ServeHTTP(recorder, httptest.NewRequest("POST", "/", nil))
+				Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable), name)
+			}
+		})
+	})
+})